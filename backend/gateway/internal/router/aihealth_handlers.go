@@ -8,6 +8,7 @@ import (
 	"time"
 
 	v1 "github.com/manpasik/backend/shared/gen/go/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 // ============================================================================
@@ -802,6 +803,7 @@ func (r *Router) handleGenerateCoaching(w http.ResponseWriter, req *http.Request
 		UserID        string `json:"user_id"`
 		MeasurementID string `json:"measurement_id"`
 		CoachingType  int32  `json:"coaching_type"`
+		Language      string `json:"language"`
 	}
 	if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
 		writeError(w, http.StatusBadRequest, "잘못된 요청 형식")
@@ -823,6 +825,7 @@ func (r *Router) handleGenerateCoaching(w http.ResponseWriter, req *http.Request
 		UserId:        body.UserID,
 		MeasurementId: body.MeasurementID,
 		CoachingType:  v1.CoachingType(body.CoachingType),
+		Language:      body.Language,
 	})
 	if err != nil {
 		writeError(w, http.StatusBadRequest, err.Error())
@@ -880,6 +883,74 @@ func (r *Router) handleGenerateDailyReport(w http.ResponseWriter, req *http.Requ
 	writeJSON(w, http.StatusOK, result)
 }
 
+// PUT /api/v1/coaching/schedule/{userId}
+func (r *Router) handleSetCoachingSchedule(w http.ResponseWriter, req *http.Request) {
+	userID := req.PathValue("userId")
+	if userID == "" {
+		writeError(w, http.StatusBadRequest, "user_id가 필요합니다")
+		return
+	}
+	var body struct {
+		DailyTipTime    string `json:"daily_tip_time"`
+		DailyReportTime string `json:"daily_report_time"`
+	}
+	if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, "잘못된 요청 형식")
+		return
+	}
+
+	conn, err := dialGRPC(r.coachingAddr)
+	if err != nil {
+		writeError(w, http.StatusServiceUnavailable, "코칭 서비스 연결 실패")
+		return
+	}
+	defer conn.Close()
+
+	client := v1.NewCoachingServiceClient(conn)
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	resp, err := client.SetCoachingSchedule(ctx, &v1.SetCoachingScheduleRequest{
+		UserId:          userID,
+		DailyTipTime:    body.DailyTipTime,
+		DailyReportTime: body.DailyReportTime,
+	})
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, coachingScheduleToMap(resp))
+}
+
+// GET /api/v1/coaching/schedule/{userId}
+func (r *Router) handleGetCoachingSchedule(w http.ResponseWriter, req *http.Request) {
+	userID := req.PathValue("userId")
+	if userID == "" {
+		writeError(w, http.StatusBadRequest, "user_id가 필요합니다")
+		return
+	}
+
+	conn, err := dialGRPC(r.coachingAddr)
+	if err != nil {
+		writeError(w, http.StatusServiceUnavailable, "코칭 서비스 연결 실패")
+		return
+	}
+	defer conn.Close()
+
+	client := v1.NewCoachingServiceClient(conn)
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	resp, err := client.GetCoachingSchedule(ctx, &v1.GetCoachingScheduleRequest{UserId: userID})
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, coachingScheduleToMap(resp))
+}
+
 // GET /api/v1/coaching/recommendations/{userId}?type_filter=...&limit=...
 func (r *Router) handleGetRecommendations(w http.ResponseWriter, req *http.Request) {
 	userID := req.PathValue("userId")
@@ -939,6 +1010,89 @@ func (r *Router) handleGetRecommendations(w http.ResponseWriter, req *http.Reque
 	})
 }
 
+// POST /api/v1/coaching/reports/export/{userId}
+// period_start는 YYYY-MM-DD이며, 생략하면 이번 주(월)입니다.
+func (r *Router) handleExportHealthReport(w http.ResponseWriter, req *http.Request) {
+	userID := req.PathValue("userId")
+	if userID == "" {
+		writeError(w, http.StatusBadRequest, "user_id가 필요합니다")
+		return
+	}
+	var body struct {
+		Period      int32  `json:"period"`
+		PeriodStart string `json:"period_start"`
+		Language    string `json:"language"`
+	}
+	if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, "잘못된 요청 형식")
+		return
+	}
+	exportReq := &v1.ExportHealthReportRequest{
+		UserId:   userID,
+		Period:   v1.ReportPeriod(body.Period),
+		Language: body.Language,
+	}
+	if body.PeriodStart != "" {
+		start, err := time.Parse("2006-01-02", body.PeriodStart)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "period_start는 YYYY-MM-DD 형식이어야 합니다")
+			return
+		}
+		exportReq.PeriodStart = timestamppb.New(start)
+	}
+
+	conn, err := dialGRPC(r.coachingAddr)
+	if err != nil {
+		writeError(w, http.StatusServiceUnavailable, "코칭 서비스 연결 실패")
+		return
+	}
+	defer conn.Close()
+
+	client := v1.NewCoachingServiceClient(conn)
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	resp, err := client.ExportHealthReport(ctx, exportReq)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, healthReportExportToMap(resp))
+}
+
+// GET /api/v1/coaching/reports/{userId}/{exportId}
+func (r *Router) handleGetHealthReportExport(w http.ResponseWriter, req *http.Request) {
+	userID := req.PathValue("userId")
+	exportID := req.PathValue("exportId")
+	if userID == "" || exportID == "" {
+		writeError(w, http.StatusBadRequest, "user_id와 export_id가 필요합니다")
+		return
+	}
+
+	conn, err := dialGRPC(r.coachingAddr)
+	if err != nil {
+		writeError(w, http.StatusServiceUnavailable, "코칭 서비스 연결 실패")
+		return
+	}
+	defer conn.Close()
+
+	client := v1.NewCoachingServiceClient(conn)
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	resp, err := client.GetHealthReportExport(ctx, &v1.GetHealthReportExportRequest{
+		ExportId: exportID,
+		UserId:   userID,
+	})
+	if err != nil {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, healthReportExportToMap(resp))
+}
+
 // --- Coaching helper mappers ---
 
 func healthGoalToMap(g *v1.HealthGoal) map[string]interface{} {
@@ -966,6 +1120,22 @@ func healthGoalToMap(g *v1.HealthGoal) map[string]interface{} {
 	return m
 }
 
+func coachingScheduleToMap(c *v1.CoachingSchedule) map[string]interface{} {
+	m := map[string]interface{}{
+		"user_id":           c.GetUserId(),
+		"daily_tip_time":    c.GetDailyTipTime(),
+		"daily_report_time": c.GetDailyReportTime(),
+		"timezone":          c.GetTimezone(),
+	}
+	if c.GetNextDailyTipAt() != nil {
+		m["next_daily_tip_at"] = c.GetNextDailyTipAt().AsTime().Format(time.RFC3339)
+	}
+	if c.GetNextDailyReportAt() != nil {
+		m["next_daily_report_at"] = c.GetNextDailyReportAt().AsTime().Format(time.RFC3339)
+	}
+	return m
+}
+
 func coachingMessageToMap(c *v1.CoachingMessage) map[string]interface{} {
 	m := map[string]interface{}{
 		"message_id":     c.GetMessageId(),
@@ -977,9 +1147,35 @@ func coachingMessageToMap(c *v1.CoachingMessage) map[string]interface{} {
 		"action_items":   c.GetActionItems(),
 		"related_metric": c.GetRelatedMetric(),
 		"related_value":  c.GetRelatedValue(),
+		"strategy":       c.GetStrategy(),
+		"language":       c.GetLanguage(),
 	}
 	if c.GetCreatedAt() != nil {
 		m["created_at"] = c.GetCreatedAt().AsTime().Format(time.RFC3339)
 	}
 	return m
 }
+
+func healthReportExportToMap(e *v1.HealthReportExport) map[string]interface{} {
+	m := map[string]interface{}{
+		"export_id": e.GetExportId(),
+		"user_id":   e.GetUserId(),
+		"period":    e.GetPeriod().String(),
+		"language":  e.GetLanguage(),
+		"html_url":  e.GetHtmlUrl(),
+		"pdf_url":   e.GetPdfUrl(),
+	}
+	if e.GetPeriodStart() != nil {
+		m["period_start"] = e.GetPeriodStart().AsTime().Format("2006-01-02")
+	}
+	if e.GetPeriodEnd() != nil {
+		m["period_end"] = e.GetPeriodEnd().AsTime().Format("2006-01-02")
+	}
+	if e.GetUrlExpiresAt() != nil {
+		m["url_expires_at"] = e.GetUrlExpiresAt().AsTime().Format(time.RFC3339)
+	}
+	if e.GetCreatedAt() != nil {
+		m["created_at"] = e.GetCreatedAt().AsTime().Format(time.RFC3339)
+	}
+	return m
+}
@@ -333,6 +333,12 @@ func notificationToMap(n *v1.Notification) map[string]interface{} {
 	if n.GetReadAt() != nil {
 		m["read_at"] = n.GetReadAt().AsTime().Format(time.RFC3339)
 	}
+	if n.GetDeliveryStatus() != "" {
+		m["delivery_status"] = n.GetDeliveryStatus()
+	}
+	if n.GetDeliverAt() != nil {
+		m["deliver_at"] = n.GetDeliverAt().AsTime().Format(time.RFC3339)
+	}
 	return m
 }
 
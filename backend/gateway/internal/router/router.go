@@ -202,6 +202,10 @@ func (r *Router) setupRoutes() {
 	r.mux.HandleFunc("POST /api/v1/coaching/generate", r.handleGenerateCoaching)
 	r.mux.HandleFunc("GET /api/v1/coaching/daily-report/{userId}", r.handleGenerateDailyReport)
 	r.mux.HandleFunc("GET /api/v1/coaching/recommendations/{userId}", r.handleGetRecommendations)
+	r.mux.HandleFunc("PUT /api/v1/coaching/schedule/{userId}", r.handleSetCoachingSchedule)
+	r.mux.HandleFunc("GET /api/v1/coaching/schedule/{userId}", r.handleGetCoachingSchedule)
+	r.mux.HandleFunc("POST /api/v1/coaching/reports/export/{userId}", r.handleExportHealthReport)
+	r.mux.HandleFunc("GET /api/v1/coaching/reports/{userId}/{exportId}", r.handleGetHealthReportExport)
 
 	// File upload service (S3/MinIO)
 	r.setupUploadRoutes()
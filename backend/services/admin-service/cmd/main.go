@@ -10,6 +10,7 @@
 // - 시스템 통계 조회
 // - 감사 로그 조회
 // - 시스템 설정 관리
+// - 클리니컬 디바이스 플릿 통계 (DEVICE_SERVICE_ADDR 설정 시 device-service 조회)
 package main
 
 import (
@@ -36,6 +37,7 @@ import (
 	"github.com/manpasik/backend/shared/observability"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/health"
 	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
@@ -139,6 +141,30 @@ func main() {
 		log.Printf("[%s] Kafka 이벤트 버스 연결됨", serviceName)
 	}
 
+	// 플릿 통계: DEVICE_SERVICE_ADDR 설정 시 device-service에서 그룹별 통계 조회
+	if deviceAddr := os.Getenv("DEVICE_SERVICE_ADDR"); deviceAddr != "" {
+		deviceConn, dialErr := grpc.NewClient(deviceAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		if dialErr != nil {
+			log.Printf("[%s] device-service 연결 실패, 플릿 통계 비활성: %v", serviceName, dialErr)
+		} else {
+			defer deviceConn.Close()
+			adminSvc.SetFleetStatsProvider(&deviceFleetStats{client: v1.NewDeviceServiceClient(deviceConn)})
+			log.Printf("[%s] device-service 연결됨 (플릿 통계): %s", serviceName, deviceAddr)
+		}
+	}
+
+	// 알림 템플릿: NOTIFICATION_SERVICE_ADDR 설정 시 notification-service 템플릿 CRUD·버전 관리
+	if notiAddr := os.Getenv("NOTIFICATION_SERVICE_ADDR"); notiAddr != "" {
+		notiConn, dialErr := grpc.NewClient(notiAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		if dialErr != nil {
+			log.Printf("[%s] notification-service 연결 실패, 알림 템플릿 관리 비활성: %v", serviceName, dialErr)
+		} else {
+			defer notiConn.Close()
+			adminSvc.SetNotificationTemplateStore(&notificationTemplates{client: v1.NewNotificationServiceClient(notiConn)})
+			log.Printf("[%s] notification-service 연결됨 (알림 템플릿): %s", serviceName, notiAddr)
+		}
+	}
+
 	// ConfigManager 생성
 	cfgMgr := service.NewConfigManager(logger, configRepo, metaRepo, transRepo, auditRepo, encryptor, eventPublisher)
 
@@ -200,3 +226,113 @@ func main() {
 	<-ctx.Done()
 	log.Printf("[%s] Shutdown complete", serviceName)
 }
+
+// deviceFleetStats는 device-service 클라이언트를 service.FleetStatsProvider로 래핑합니다.
+type deviceFleetStats struct {
+	client v1.DeviceServiceClient
+}
+
+func (d *deviceFleetStats) ListFleetStats(ctx context.Context, days int32) ([]*service.FleetGroupStats, error) {
+	resp, err := d.client.ListFleetStats(ctx, &v1.ListFleetStatsRequest{Days: days})
+	if err != nil {
+		return nil, err
+	}
+	groups := make([]*service.FleetGroupStats, 0, len(resp.Groups))
+	for _, g := range resp.Groups {
+		groups = append(groups, &service.FleetGroupStats{
+			GroupID:               g.GroupId,
+			GroupName:             g.GroupName,
+			TotalDevices:          g.TotalDevices,
+			Online:                g.OnlineCount,
+			OnlinePercent:         g.OnlinePercent,
+			Calibrated:            g.CalibratedCount,
+			CalibrationCompliance: g.CalibrationCompliancePercent,
+			CartridgesUsed:        g.CartridgesUsed,
+			LowBattery:            g.LowBatteryCount,
+			PeriodDays:            g.PeriodDays,
+			CalculatedAt:          g.CalculatedAt.AsTime(),
+		})
+	}
+	return groups, nil
+}
+
+// notificationTemplates는 notification-service 클라이언트를 service.NotificationTemplateStore로 래핑합니다.
+type notificationTemplates struct {
+	client v1.NotificationServiceClient
+}
+
+func (n *notificationTemplates) ListTemplates(ctx context.Context, key, language string) ([]*service.NotificationTemplate, error) {
+	resp, err := n.client.ListNotificationTemplates(ctx, &v1.ListNotificationTemplatesRequest{TemplateKey: key, Language: language})
+	if err != nil {
+		return nil, err
+	}
+	return templatesFromProto(resp.Templates), nil
+}
+
+func (n *notificationTemplates) ListTemplateVersions(ctx context.Context, key, language string) ([]*service.NotificationTemplate, error) {
+	resp, err := n.client.ListNotificationTemplateVersions(ctx, &v1.ListNotificationTemplateVersionsRequest{TemplateKey: key, Language: language})
+	if err != nil {
+		return nil, err
+	}
+	return templatesFromProto(resp.Templates), nil
+}
+
+func (n *notificationTemplates) SaveTemplate(ctx context.Context, t *service.NotificationTemplate, baseVersion int32, updatedBy string) (*service.NotificationTemplate, error) {
+	pb, err := n.client.SaveNotificationTemplate(ctx, &v1.SaveNotificationTemplateRequest{
+		Template:    handler.NotificationTemplateToProto(t),
+		BaseVersion: baseVersion,
+		UpdatedBy:   updatedBy,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return handler.ProtoToNotificationTemplate(pb), nil
+}
+
+func (n *notificationTemplates) DeleteTemplate(ctx context.Context, key, language string, baseVersion int32, updatedBy string) (*service.NotificationTemplate, error) {
+	pb, err := n.client.DeleteNotificationTemplate(ctx, &v1.DeleteNotificationTemplateRequest{
+		TemplateKey: key, Language: language, BaseVersion: baseVersion, UpdatedBy: updatedBy,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return handler.ProtoToNotificationTemplate(pb), nil
+}
+
+func (n *notificationTemplates) RollbackTemplate(ctx context.Context, key, language string, version int32, updatedBy string) (*service.NotificationTemplate, error) {
+	pb, err := n.client.RollbackNotificationTemplate(ctx, &v1.RollbackNotificationTemplateRequest{
+		TemplateKey: key, Language: language, Version: version, UpdatedBy: updatedBy,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return handler.ProtoToNotificationTemplate(pb), nil
+}
+
+func (n *notificationTemplates) PreviewTemplate(ctx context.Context, key, language string, draft *service.NotificationTemplate, data map[string]string) (*service.NotificationTemplatePreview, error) {
+	req := &v1.PreviewNotificationTemplateRequest{TemplateKey: key, Language: language, Data: data}
+	if draft != nil {
+		req.Draft = handler.NotificationTemplateToProto(draft)
+	}
+	resp, err := n.client.PreviewNotificationTemplate(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return &service.NotificationTemplatePreview{
+		Valid:               resp.Valid,
+		Errors:              resp.Errors,
+		Language:            resp.Language,
+		Version:             resp.Version,
+		Title:               resp.Title,
+		Body:                resp.Body,
+		MissingPlaceholders: resp.MissingPlaceholders,
+	}, nil
+}
+
+func templatesFromProto(list []*v1.NotificationTemplate) []*service.NotificationTemplate {
+	result := make([]*service.NotificationTemplate, 0, len(list))
+	for _, pb := range list {
+		result = append(result, handler.ProtoToNotificationTemplate(pb))
+	}
+	return result
+}
@@ -3,6 +3,7 @@ package handler
 
 import (
 	"context"
+	"strings"
 
 	"github.com/manpasik/backend/services/admin-service/internal/service"
 	apperrors "github.com/manpasik/backend/shared/errors"
@@ -635,6 +636,188 @@ func (h *AdminHandler) GetInventoryStats(ctx context.Context, req *v1.GetInvento
 	}, nil
 }
 
+// ============================================================================
+// GetFleetStats — 클리니컬 디바이스 플릿 통계 조회
+// ============================================================================
+
+func (h *AdminHandler) GetFleetStats(ctx context.Context, req *v1.AdminGetFleetStatsRequest) (*v1.AdminGetFleetStatsResponse, error) {
+	if req == nil {
+		req = &v1.AdminGetFleetStatsRequest{}
+	}
+
+	overview, err := h.svc.GetFleetStats(ctx, req.Days)
+	if err != nil {
+		return nil, toGRPC(err)
+	}
+
+	protoGroups := make([]*v1.FleetStats, 0, len(overview.Groups))
+	for _, g := range overview.Groups {
+		protoGroups = append(protoGroups, &v1.FleetStats{
+			GroupId:                      g.GroupID,
+			GroupName:                    g.GroupName,
+			TotalDevices:                 g.TotalDevices,
+			OnlineCount:                  g.Online,
+			OnlinePercent:                g.OnlinePercent,
+			CalibratedCount:              g.Calibrated,
+			CalibrationCompliancePercent: g.CalibrationCompliance,
+			CartridgesUsed:               g.CartridgesUsed,
+			LowBatteryCount:              g.LowBattery,
+			PeriodDays:                   g.PeriodDays,
+			CalculatedAt:                 timestamppb.New(g.CalculatedAt),
+		})
+	}
+
+	return &v1.AdminGetFleetStatsResponse{
+		Groups:                       protoGroups,
+		TotalDevices:                 overview.TotalDevices,
+		OnlinePercent:                overview.OnlinePercent,
+		CalibrationCompliancePercent: overview.CalibrationCompliance,
+		CartridgesUsed:               overview.CartridgesUsed,
+	}, nil
+}
+
+// ============================================================================
+// 알림 템플릿 — notification-service 템플릿 CRUD·버전 관리
+// ============================================================================
+
+func (h *AdminHandler) ListNotificationTemplates(ctx context.Context, req *v1.ListNotificationTemplatesRequest) (*v1.ListNotificationTemplatesResponse, error) {
+	if req == nil {
+		req = &v1.ListNotificationTemplatesRequest{}
+	}
+	list, err := h.svc.ListNotificationTemplates(ctx, req.TemplateKey, req.Language)
+	if err != nil {
+		return nil, toGRPC(err)
+	}
+	return templatesToProto(list), nil
+}
+
+func (h *AdminHandler) ListNotificationTemplateVersions(ctx context.Context, req *v1.ListNotificationTemplateVersionsRequest) (*v1.ListNotificationTemplatesResponse, error) {
+	if req == nil || req.TemplateKey == "" || req.Language == "" {
+		return nil, status.Error(codes.InvalidArgument, "template_key와 language는 필수입니다")
+	}
+	list, err := h.svc.ListNotificationTemplateVersions(ctx, req.TemplateKey, req.Language)
+	if err != nil {
+		return nil, toGRPC(err)
+	}
+	return templatesToProto(list), nil
+}
+
+func (h *AdminHandler) SaveNotificationTemplate(ctx context.Context, req *v1.SaveNotificationTemplateRequest) (*v1.NotificationTemplate, error) {
+	if req == nil || req.Template == nil {
+		return nil, status.Error(codes.InvalidArgument, "template은 필수입니다")
+	}
+	t, err := h.svc.SaveNotificationTemplate(ctx, ProtoToNotificationTemplate(req.Template), req.BaseVersion, req.UpdatedBy)
+	if err != nil {
+		return nil, toGRPC(err)
+	}
+	return NotificationTemplateToProto(t), nil
+}
+
+func (h *AdminHandler) DeleteNotificationTemplate(ctx context.Context, req *v1.DeleteNotificationTemplateRequest) (*v1.NotificationTemplate, error) {
+	if req == nil || req.TemplateKey == "" || req.Language == "" {
+		return nil, status.Error(codes.InvalidArgument, "template_key와 language는 필수입니다")
+	}
+	t, err := h.svc.DeleteNotificationTemplate(ctx, req.TemplateKey, req.Language, req.BaseVersion, req.UpdatedBy)
+	if err != nil {
+		return nil, toGRPC(err)
+	}
+	return NotificationTemplateToProto(t), nil
+}
+
+func (h *AdminHandler) RollbackNotificationTemplate(ctx context.Context, req *v1.RollbackNotificationTemplateRequest) (*v1.NotificationTemplate, error) {
+	if req == nil || req.TemplateKey == "" || req.Language == "" || req.Version <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "template_key, language, version은 필수입니다")
+	}
+	t, err := h.svc.RollbackNotificationTemplate(ctx, req.TemplateKey, req.Language, req.Version, req.UpdatedBy)
+	if err != nil {
+		return nil, toGRPC(err)
+	}
+	return NotificationTemplateToProto(t), nil
+}
+
+func (h *AdminHandler) PreviewNotificationTemplate(ctx context.Context, req *v1.PreviewNotificationTemplateRequest) (*v1.PreviewNotificationTemplateResponse, error) {
+	if req == nil || (req.Draft == nil && req.TemplateKey == "") {
+		return nil, status.Error(codes.InvalidArgument, "template_key 또는 draft는 필수입니다")
+	}
+	var draft *service.NotificationTemplate
+	if req.Draft != nil {
+		draft = ProtoToNotificationTemplate(req.Draft)
+	}
+	p, err := h.svc.PreviewNotificationTemplate(ctx, req.TemplateKey, req.Language, draft, req.Data)
+	if err != nil {
+		return nil, toGRPC(err)
+	}
+	return &v1.PreviewNotificationTemplateResponse{
+		Valid:               p.Valid,
+		Errors:              p.Errors,
+		Language:            p.Language,
+		Version:             p.Version,
+		Title:               p.Title,
+		Body:                p.Body,
+		MissingPlaceholders: p.MissingPlaceholders,
+	}, nil
+}
+
+func templatesToProto(list []*service.NotificationTemplate) *v1.ListNotificationTemplatesResponse {
+	resp := &v1.ListNotificationTemplatesResponse{}
+	for _, t := range list {
+		resp.Templates = append(resp.Templates, NotificationTemplateToProto(t))
+	}
+	return resp
+}
+
+// NotificationTemplateToProto는 템플릿을 proto로 변환합니다. 유형·우선순위·채널은 소문자 이름 ↔ enum으로 변환합니다.
+func NotificationTemplateToProto(t *service.NotificationTemplate) *v1.NotificationTemplate {
+	pb := &v1.NotificationTemplate{
+		TemplateKey:  t.Key,
+		Language:     t.Language,
+		Version:      t.Version,
+		Title:        t.Title,
+		Body:         t.Body,
+		Type:         v1.NotificationType(enumValue(v1.NotificationType_value, "NOTIFICATION_TYPE_", t.Type)),
+		Priority:     v1.NotificationPriority(enumValue(v1.NotificationPriority_value, "NOTIFICATION_PRIORITY_", t.Priority)),
+		Channel:      v1.NotificationChannel(enumValue(v1.NotificationChannel_value, "NOTIFICATION_CHANNEL_", t.Channel)),
+		Placeholders: t.Placeholders,
+		Deleted:      t.Deleted,
+		UpdatedBy:    t.UpdatedBy,
+	}
+	if !t.CreatedAt.IsZero() {
+		pb.CreatedAt = timestamppb.New(t.CreatedAt)
+	}
+	return pb
+}
+
+// ProtoToNotificationTemplate은 proto 템플릿을 변환합니다.
+func ProtoToNotificationTemplate(pb *v1.NotificationTemplate) *service.NotificationTemplate {
+	t := &service.NotificationTemplate{
+		Key:          pb.TemplateKey,
+		Language:     pb.Language,
+		Version:      pb.Version,
+		Title:        pb.Title,
+		Body:         pb.Body,
+		Type:         enumName(pb.Type.String(), "NOTIFICATION_TYPE_"),
+		Priority:     enumName(pb.Priority.String(), "NOTIFICATION_PRIORITY_"),
+		Channel:      enumName(pb.Channel.String(), "NOTIFICATION_CHANNEL_"),
+		Placeholders: pb.Placeholders,
+		Deleted:      pb.Deleted,
+		UpdatedBy:    pb.UpdatedBy,
+	}
+	if pb.CreatedAt != nil {
+		t.CreatedAt = pb.CreatedAt.AsTime()
+	}
+	return t
+}
+
+// enumName은 "NOTIFICATION_TYPE_HEALTH_ALERT" → "health_alert"로 변환합니다.
+func enumName(name, prefix string) string {
+	return strings.ToLower(strings.TrimPrefix(name, prefix))
+}
+
+// enumValue는 enumName의 역변환입니다. 알 수 없는 이름은 0(UNKNOWN)입니다.
+func enumValue(values map[string]int32, prefix, name string) int32 {
+	return values[prefix+strings.ToUpper(name)]
+}
+
 // --- toGRPC 에러 변환 ---
 
 func toGRPC(err error) error {
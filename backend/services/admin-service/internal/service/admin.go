@@ -169,7 +169,9 @@ type AdminService struct {
 	auditRepo     AuditLogRepository
 	configRepo    SystemConfigRepository
 	userRepo      UserSummaryRepository
-	auditLogStore AuditLogStore // 확장 감사 로그 저장소 (선택)
+	auditLogStore AuditLogStore             // 확장 감사 로그 저장소 (선택)
+	fleetStats    FleetStatsProvider        // 디바이스 플릿 통계 제공자 (선택)
+	templates     NotificationTemplateStore // 알림 템플릿 저장소 (선택)
 }
 
 // NewAdminService는 새 AdminService를 생성합니다.
@@ -194,6 +196,11 @@ func (s *AdminService) SetAuditLogStore(store AuditLogStore) {
 	s.auditLogStore = store
 }
 
+// SetFleetStatsProvider는 디바이스 플릿 통계 제공자를 설정합니다.
+func (s *AdminService) SetFleetStatsProvider(p FleetStatsProvider) {
+	s.fleetStats = p
+}
+
 // recordAuditDetail은 확장 감사 로그를 기록합니다.
 // auditLogStore가 설정되지 않은 경우 무시합니다.
 func (s *AdminService) recordAuditDetail(ctx context.Context, adminID, action, resource, oldValue, newValue string) {
@@ -679,6 +686,62 @@ func (s *AdminService) GetInventoryStats(ctx context.Context, categoryFilter int
 	}, nil
 }
 
+// FleetGroupStats는 디바이스 그룹 단위 플릿 통계입니다 (device-service 집계).
+type FleetGroupStats struct {
+	GroupID               string
+	GroupName             string
+	TotalDevices          int32
+	Online                int32
+	OnlinePercent         float64
+	Calibrated            int32
+	CalibrationCompliance float64
+	CartridgesUsed        int32
+	LowBattery            int32
+	PeriodDays            int32
+	CalculatedAt          time.Time
+}
+
+// FleetOverview는 전체 그룹 플릿 통계 요약입니다.
+type FleetOverview struct {
+	Groups                []*FleetGroupStats
+	TotalDevices          int32
+	OnlinePercent         float64
+	CalibrationCompliance float64
+	CartridgesUsed        int32
+}
+
+// FleetStatsProvider는 그룹별 플릿 통계를 제공합니다.
+type FleetStatsProvider interface {
+	ListFleetStats(ctx context.Context, days int32) ([]*FleetGroupStats, error)
+}
+
+// GetFleetStats는 클리니컬 디바이스 그룹 전체의 플릿 통계를 반환합니다.
+// 비율은 그룹별 디바이스 수로 가중 평균합니다.
+func (s *AdminService) GetFleetStats(ctx context.Context, days int32) (*FleetOverview, error) {
+	if s.fleetStats == nil {
+		return nil, apperrors.New(apperrors.ErrServiceUnavailable, "플릿 통계 제공자가 설정되지 않았습니다")
+	}
+
+	groups, err := s.fleetStats.ListFleetStats(ctx, days)
+	if err != nil {
+		return nil, apperrors.New(apperrors.ErrServiceUnavailable, "플릿 통계 조회에 실패했습니다")
+	}
+
+	overview := &FleetOverview{Groups: groups}
+	var online, calibrated int32
+	for _, g := range groups {
+		overview.TotalDevices += g.TotalDevices
+		overview.CartridgesUsed += g.CartridgesUsed
+		online += g.Online
+		calibrated += g.Calibrated
+	}
+	if overview.TotalDevices > 0 {
+		overview.OnlinePercent = float64(online) * 100 / float64(overview.TotalDevices)
+		overview.CalibrationCompliance = float64(calibrated) * 100 / float64(overview.TotalDevices)
+	}
+	return overview, nil
+}
+
 // ============================================================================
 // 헬퍼 함수
 // ============================================================================
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/manpasik/backend/services/admin-service/internal/service"
+	apperrors "github.com/manpasik/backend/shared/errors"
 	"go.uber.org/zap"
 )
 
@@ -848,3 +849,123 @@ func TestEndToEnd_AdminFlow(t *testing.T) {
 		t.Errorf("E2E: 반환된 사용자 수: got %d, want >= 1", len(users))
 	}
 }
+
+// fakeFleetStats는 테스트용 플릿 통계 제공자입니다.
+type fakeFleetStats struct {
+	groups []*service.FleetGroupStats
+}
+
+func (f *fakeFleetStats) ListFleetStats(_ context.Context, _ int32) ([]*service.FleetGroupStats, error) {
+	return f.groups, nil
+}
+
+func TestGetFleetStats_디바이스수_가중평균(t *testing.T) {
+	svc, _, _, _, _ := newTestService()
+	ctx := context.Background()
+
+	if _, err := svc.GetFleetStats(ctx, 30); err == nil {
+		t.Fatal("제공자 미설정 시 에러가 반환되어야 합니다")
+	}
+
+	svc.SetFleetStatsProvider(&fakeFleetStats{groups: []*service.FleetGroupStats{
+		{GroupID: "g1", TotalDevices: 8, Online: 8, Calibrated: 4, CartridgesUsed: 20},
+		{GroupID: "g2", TotalDevices: 2, Online: 0, Calibrated: 2, CartridgesUsed: 5},
+	}})
+
+	overview, err := svc.GetFleetStats(ctx, 30)
+	if err != nil {
+		t.Fatalf("플릿 통계 조회 실패: %v", err)
+	}
+	if overview.TotalDevices != 10 || overview.CartridgesUsed != 25 {
+		t.Errorf("합계 불일치: devices=%d, cartridges=%d", overview.TotalDevices, overview.CartridgesUsed)
+	}
+	if overview.OnlinePercent != 80 || overview.CalibrationCompliance != 60 {
+		t.Errorf("가중 평균 불일치: online=%.1f, calibration=%.1f", overview.OnlinePercent, overview.CalibrationCompliance)
+	}
+}
+
+// fakeTemplateStore는 테스트용 알림 템플릿 저장소입니다.
+type fakeTemplateStore struct {
+	latest map[string]*service.NotificationTemplate
+}
+
+func (f *fakeTemplateStore) ListTemplates(_ context.Context, _, _ string) ([]*service.NotificationTemplate, error) {
+	var list []*service.NotificationTemplate
+	for _, t := range f.latest {
+		list = append(list, t)
+	}
+	return list, nil
+}
+
+func (f *fakeTemplateStore) ListTemplateVersions(_ context.Context, key, language string) ([]*service.NotificationTemplate, error) {
+	return []*service.NotificationTemplate{f.latest[key+"/"+language]}, nil
+}
+
+func (f *fakeTemplateStore) SaveTemplate(_ context.Context, t *service.NotificationTemplate, baseVersion int32, updatedBy string) (*service.NotificationTemplate, error) {
+	id := t.Key + "/" + t.Language
+	if cur := f.latest[id]; (cur == nil && baseVersion != 0) || (cur != nil && cur.Version != baseVersion) {
+		return nil, apperrors.New(apperrors.ErrConflict, "버전 충돌")
+	}
+	saved := *t
+	saved.Version, saved.UpdatedBy = baseVersion+1, updatedBy
+	f.latest[id] = &saved
+	return &saved, nil
+}
+
+func (f *fakeTemplateStore) DeleteTemplate(_ context.Context, key, language string, baseVersion int32, updatedBy string) (*service.NotificationTemplate, error) {
+	t := *f.latest[key+"/"+language]
+	t.Version, t.Deleted, t.UpdatedBy = baseVersion+1, true, updatedBy
+	f.latest[key+"/"+language] = &t
+	return &t, nil
+}
+
+func (f *fakeTemplateStore) RollbackTemplate(_ context.Context, key, language string, _ int32, updatedBy string) (*service.NotificationTemplate, error) {
+	t := *f.latest[key+"/"+language]
+	t.Version, t.Deleted, t.UpdatedBy = t.Version+1, false, updatedBy
+	f.latest[key+"/"+language] = &t
+	return &t, nil
+}
+
+func (f *fakeTemplateStore) PreviewTemplate(_ context.Context, _, language string, _ *service.NotificationTemplate, _ map[string]string) (*service.NotificationTemplatePreview, error) {
+	return &service.NotificationTemplatePreview{Valid: true, Language: language}, nil
+}
+
+func TestNotificationTemplates_감사로그(t *testing.T) {
+	svc, _, auditRepo, _, _ := newTestService()
+	ctx := context.Background()
+
+	if _, err := svc.ListNotificationTemplates(ctx, "", ""); err == nil {
+		t.Fatal("저장소 미설정 시 에러가 반환되어야 합니다")
+	}
+	svc.SetNotificationTemplateStore(&fakeTemplateStore{latest: make(map[string]*service.NotificationTemplate)})
+
+	draft := &service.NotificationTemplate{Key: "delivery_arrived", Language: "en", Title: "Delivered", Body: "Your medication has arrived",
+		Type: "prescription", Priority: "high", Channel: "push"}
+	if _, err := svc.SaveNotificationTemplate(ctx, draft, 0, ""); err == nil {
+		t.Fatal("수정자 ID가 없으면 에러가 반환되어야 합니다")
+	}
+	created, err := svc.SaveNotificationTemplate(ctx, draft, 0, "admin-1")
+	if err != nil || created.Version != 1 {
+		t.Fatalf("템플릿 생성 실패: %+v, %v", created, err)
+	}
+	if _, err := svc.SaveNotificationTemplate(ctx, draft, 0, "admin-2"); err == nil {
+		t.Fatal("오래된 기준 버전 저장은 충돌이어야 합니다")
+	}
+	if _, err := svc.DeleteNotificationTemplate(ctx, "delivery_arrived", "en", 1, "admin-1"); err != nil {
+		t.Fatalf("템플릿 삭제 실패: %v", err)
+	}
+	restored, err := svc.RollbackNotificationTemplate(ctx, "delivery_arrived", "en", 1, "admin-1")
+	if err != nil || restored.Version != 3 || restored.Deleted {
+		t.Fatalf("템플릿 롤백 실패: %+v, %v", restored, err)
+	}
+
+	wantActions := []service.AuditAction{service.ActionCreate, service.ActionDelete, service.ActionUpdate}
+	if len(auditRepo.entries) != len(wantActions) {
+		t.Fatalf("감사 로그 수: got %d, want %d", len(auditRepo.entries), len(wantActions))
+	}
+	for i, e := range auditRepo.entries {
+		if e.Action != wantActions[i] || e.ResourceType != "notification_template" || e.ResourceID != "delivery_arrived/en" {
+			t.Errorf("감사 로그[%d] = %+v", i, e)
+		}
+	}
+}
@@ -0,0 +1,184 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	apperrors "github.com/manpasik/backend/shared/errors"
+	"go.uber.org/zap"
+)
+
+// NotificationTemplate은 notification-service 알림 템플릿의 언어별 버전입니다.
+// Type/Priority/Channel은 "health_alert", "high", "push"처럼 소문자 이름입니다.
+type NotificationTemplate struct {
+	Key          string
+	Language     string
+	Version      int32
+	Title        string
+	Body         string
+	Type         string
+	Priority     string
+	Channel      string
+	Placeholders []string
+	Deleted      bool
+	UpdatedBy    string
+	CreatedAt    time.Time
+}
+
+// NotificationTemplatePreview는 템플릿 검증·렌더링 결과입니다.
+type NotificationTemplatePreview struct {
+	Valid               bool
+	Errors              []string
+	Language            string
+	Version             int32
+	Title               string
+	Body                string
+	MissingPlaceholders []string
+}
+
+// NotificationTemplateStore는 알림 템플릿 저장소(notification-service)입니다.
+// 버전 관리·검증·동시 수정 충돌 판정은 저장소가 담당하며, 오류는 그대로 전달합니다.
+type NotificationTemplateStore interface {
+	ListTemplates(ctx context.Context, key, language string) ([]*NotificationTemplate, error)
+	ListTemplateVersions(ctx context.Context, key, language string) ([]*NotificationTemplate, error)
+	SaveTemplate(ctx context.Context, t *NotificationTemplate, baseVersion int32, updatedBy string) (*NotificationTemplate, error)
+	DeleteTemplate(ctx context.Context, key, language string, baseVersion int32, updatedBy string) (*NotificationTemplate, error)
+	RollbackTemplate(ctx context.Context, key, language string, version int32, updatedBy string) (*NotificationTemplate, error)
+	PreviewTemplate(ctx context.Context, key, language string, draft *NotificationTemplate, data map[string]string) (*NotificationTemplatePreview, error)
+}
+
+// SetNotificationTemplateStore는 알림 템플릿 저장소를 설정합니다.
+func (s *AdminService) SetNotificationTemplateStore(store NotificationTemplateStore) {
+	s.templates = store
+}
+
+// ListNotificationTemplates는 알림 템플릿 최신 버전 목록을 조회합니다.
+func (s *AdminService) ListNotificationTemplates(ctx context.Context, key, language string) ([]*NotificationTemplate, error) {
+	if err := s.requireTemplateStore(); err != nil {
+		return nil, err
+	}
+	return s.templates.ListTemplates(ctx, key, language)
+}
+
+// ListNotificationTemplateVersions는 템플릿 언어별 버전 이력을 최신순으로 조회합니다.
+func (s *AdminService) ListNotificationTemplateVersions(ctx context.Context, key, language string) ([]*NotificationTemplate, error) {
+	if key == "" || language == "" {
+		return nil, apperrors.New(apperrors.ErrInvalidInput, "template_key와 language는 필수입니다")
+	}
+	if err := s.requireTemplateStore(); err != nil {
+		return nil, err
+	}
+	return s.templates.ListTemplateVersions(ctx, key, language)
+}
+
+// SaveNotificationTemplate은 템플릿을 새 버전으로 저장하고 감사 로그를 남깁니다.
+// baseVersion은 편집을 시작한 버전(새 템플릿은 0)입니다.
+func (s *AdminService) SaveNotificationTemplate(ctx context.Context, t *NotificationTemplate, baseVersion int32, updatedBy string) (*NotificationTemplate, error) {
+	if t == nil || t.Key == "" || t.Language == "" {
+		return nil, apperrors.New(apperrors.ErrInvalidInput, "template_key와 language는 필수입니다")
+	}
+	if updatedBy == "" {
+		return nil, apperrors.New(apperrors.ErrInvalidInput, "수정자 ID는 필수입니다")
+	}
+	if err := s.requireTemplateStore(); err != nil {
+		return nil, err
+	}
+
+	saved, err := s.templates.SaveTemplate(ctx, t, baseVersion, updatedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	if baseVersion == 0 {
+		s.recordTemplateAudit(ctx, updatedBy, ActionCreate, "template_create", saved, "알림 템플릿 생성", baseVersion)
+	} else {
+		s.recordTemplateAudit(ctx, updatedBy, ActionUpdate, "template_update", saved, "알림 템플릿 수정", baseVersion)
+	}
+	return saved, nil
+}
+
+// DeleteNotificationTemplate은 템플릿 언어 변형을 삭제 표시합니다. 이력은 남아 롤백할 수 있습니다.
+func (s *AdminService) DeleteNotificationTemplate(ctx context.Context, key, language string, baseVersion int32, deletedBy string) (*NotificationTemplate, error) {
+	if key == "" || language == "" {
+		return nil, apperrors.New(apperrors.ErrInvalidInput, "template_key와 language는 필수입니다")
+	}
+	if deletedBy == "" {
+		return nil, apperrors.New(apperrors.ErrInvalidInput, "삭제자 ID는 필수입니다")
+	}
+	if err := s.requireTemplateStore(); err != nil {
+		return nil, err
+	}
+
+	deleted, err := s.templates.DeleteTemplate(ctx, key, language, baseVersion, deletedBy)
+	if err != nil {
+		return nil, err
+	}
+	s.recordTemplateAudit(ctx, deletedBy, ActionDelete, "template_delete", deleted, "알림 템플릿 삭제", baseVersion)
+	return deleted, nil
+}
+
+// RollbackNotificationTemplate은 템플릿을 이전 버전 내용으로 되돌립니다 (새 버전으로 저장).
+func (s *AdminService) RollbackNotificationTemplate(ctx context.Context, key, language string, version int32, updatedBy string) (*NotificationTemplate, error) {
+	if key == "" || language == "" || version <= 0 {
+		return nil, apperrors.New(apperrors.ErrInvalidInput, "template_key, language, version은 필수입니다")
+	}
+	if updatedBy == "" {
+		return nil, apperrors.New(apperrors.ErrInvalidInput, "수정자 ID는 필수입니다")
+	}
+	if err := s.requireTemplateStore(); err != nil {
+		return nil, err
+	}
+
+	restored, err := s.templates.RollbackTemplate(ctx, key, language, version, updatedBy)
+	if err != nil {
+		return nil, err
+	}
+	s.recordTemplateAudit(ctx, updatedBy, ActionUpdate, "template_rollback", restored, fmt.Sprintf("알림 템플릿 v%d로 롤백", version), restored.Version-1)
+	return restored, nil
+}
+
+// PreviewNotificationTemplate은 저장된 템플릿 또는 초안을 검증하고 샘플 데이터로 렌더링합니다.
+func (s *AdminService) PreviewNotificationTemplate(ctx context.Context, key, language string, draft *NotificationTemplate, data map[string]string) (*NotificationTemplatePreview, error) {
+	if draft == nil && key == "" {
+		return nil, apperrors.New(apperrors.ErrInvalidInput, "template_key 또는 draft는 필수입니다")
+	}
+	if err := s.requireTemplateStore(); err != nil {
+		return nil, err
+	}
+	return s.templates.PreviewTemplate(ctx, key, language, draft, data)
+}
+
+func (s *AdminService) requireTemplateStore() error {
+	if s.templates == nil {
+		return apperrors.New(apperrors.ErrServiceUnavailable, "알림 템플릿 저장소가 설정되지 않았습니다")
+	}
+	return nil
+}
+
+// recordTemplateAudit은 템플릿 변경을 감사 로그에 기록합니다. 확장 감사 로그에는 버전 변화를 남깁니다.
+func (s *AdminService) recordTemplateAudit(ctx context.Context, adminID string, action AuditAction, detailAction string, t *NotificationTemplate, description string, fromVersion int32) {
+	resourceID := t.Key + "/" + t.Language
+	_ = s.auditRepo.Save(ctx, &AuditLogEntry{
+		EntryID:      uuid.New().String(),
+		AdminID:      adminID,
+		Action:       action,
+		ResourceType: "notification_template",
+		ResourceID:   resourceID,
+		Description:  fmt.Sprintf("%s: %s (v%d)", description, resourceID, t.Version),
+		Timestamp:    time.Now().UTC(),
+	})
+
+	oldValue := ""
+	if fromVersion > 0 {
+		oldValue = fmt.Sprintf("v%d", fromVersion)
+	}
+	s.recordAuditDetail(ctx, adminID, detailAction, "notification_template:"+resourceID, oldValue, fmt.Sprintf("v%d", t.Version))
+
+	s.logger.Info("알림 템플릿 변경",
+		zap.String("template", resourceID),
+		zap.Int32("version", t.Version),
+		zap.String("admin_id", adminID),
+	)
+}
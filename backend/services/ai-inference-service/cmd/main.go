@@ -14,15 +14,25 @@ import (
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/manpasik/backend/services/ai-inference-service/internal/handler"
 	"github.com/manpasik/backend/services/ai-inference-service/internal/llm"
+	kafkaPublisher "github.com/manpasik/backend/services/ai-inference-service/internal/repository/kafka"
 	"github.com/manpasik/backend/services/ai-inference-service/internal/repository/memory"
+	milvusRepo "github.com/manpasik/backend/services/ai-inference-service/internal/repository/milvus"
+	"github.com/manpasik/backend/services/ai-inference-service/internal/repository/objectstore"
 	"github.com/manpasik/backend/services/ai-inference-service/internal/repository/postgres"
+	redisRepo "github.com/manpasik/backend/services/ai-inference-service/internal/repository/redis"
 	"github.com/manpasik/backend/services/ai-inference-service/internal/service"
+	"github.com/manpasik/backend/shared/cache"
+	"github.com/manpasik/backend/shared/clients"
 	"github.com/manpasik/backend/shared/config"
+	"github.com/manpasik/backend/shared/events"
 	v1 "github.com/manpasik/backend/shared/gen/go/v1"
 	"github.com/manpasik/backend/shared/middleware"
 	"github.com/manpasik/backend/shared/observability"
+	"github.com/manpasik/backend/shared/storage"
+	"github.com/manpasik/backend/shared/vectordb"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/health"
 	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
@@ -47,6 +57,14 @@ func main() {
 	// Repository: PostgreSQL 또는 인메모리
 	var analysisRepo service.AnalysisRepository
 	var healthScoreRepo service.HealthScoreRepository
+	var baselineRepo service.BaselineRepository
+	var conversationRepo service.ConversationRepository
+	var snippetRepo service.SnippetRepository
+	var registryRepo service.ModelRegistryRepository // nil이면 레지스트리 메타데이터를 메모리에만 유지
+	var llmUsageStore llm.UsageStore                 // nil이면 토큰 사용량을 메모리에만 유지
+	var guardrailAuditStore llm.GuardrailAuditStore  // nil이면 가드레일 감사 기록을 메모리에만 유지
+	var llmUsageLedger llm.UsageLedger               // nil이면 LLM 사용량·비용을 메모리에만 기록
+	var dbPool *pgxpool.Pool                         // nil이면 LLM 설정을 환경변수에서만 조회
 
 	if _, dbHostSet := os.LookupEnv("DB_HOST"); dbHostSet && cfg.DB.Host != "" && cfg.DB.DBName != "" {
 		connCtx, connCancel := context.WithTimeout(context.Background(), 5*time.Second)
@@ -56,6 +74,9 @@ func main() {
 			log.Printf("[%s] DB 풀 생성 실패, 인메모리 사용: %v", serviceName, poolErr)
 			analysisRepo = memory.NewAnalysisRepository()
 			healthScoreRepo = memory.NewHealthScoreRepository()
+			baselineRepo = memory.NewBaselineRepository()
+			conversationRepo = memory.NewConversationRepository()
+			snippetRepo = memory.NewSnippetRepository()
 		} else {
 			pingCtx, pingCancel := context.WithTimeout(context.Background(), 3*time.Second)
 			if pingErr := pool.Ping(pingCtx); pingErr != nil {
@@ -64,32 +85,178 @@ func main() {
 				log.Printf("[%s] DB Ping 실패, 인메모리 사용: %v", serviceName, pingErr)
 				analysisRepo = memory.NewAnalysisRepository()
 				healthScoreRepo = memory.NewHealthScoreRepository()
+				baselineRepo = memory.NewBaselineRepository()
+				conversationRepo = memory.NewConversationRepository()
+				snippetRepo = memory.NewSnippetRepository()
 			} else {
 				pingCancel()
 				defer pool.Close()
 				analysisRepo = postgres.NewAnalysisRepository(pool)
 				healthScoreRepo = postgres.NewHealthScoreRepository(pool)
+				baselineRepo = postgres.NewBaselineRepository(pool)
+				conversationRepo = postgres.NewConversationRepository(pool)
+				snippetRepo = postgres.NewSnippetRepository(pool)
+				registryRepo = postgres.NewModelRegistryRepository(pool)
+				llmUsageStore = postgres.NewLLMUsageRepository(pool)
+				guardrailAuditStore = postgres.NewGuardrailAuditRepository(pool)
+				llmUsageLedger = postgres.NewLLMUsageLedgerRepository(pool)
+				dbPool = pool
 				log.Printf("[%s] DB 연결됨: %s", serviceName, cfg.DB.DBName)
 			}
 		}
 	} else {
 		analysisRepo = memory.NewAnalysisRepository()
 		healthScoreRepo = memory.NewHealthScoreRepository()
+		baselineRepo = memory.NewBaselineRepository()
+		conversationRepo = memory.NewConversationRepository()
+		snippetRepo = memory.NewSnippetRepository()
 		log.Printf("[%s] 인메모리 저장소 사용", serviceName)
 	}
 
-	// LLM 클라이언트 초기화 (환경변수 LLM_API_KEY가 설정된 경우에만)
+	if llmUsageLedger == nil {
+		llmUsageLedger = llm.NewMemoryUsageLedger()
+	}
+
+	// LLM 라우터: SystemConfig(llm.*) 우선, 없으면 환경변수로 폴백 체인 구성
 	var svcOpts []service.InferenceOption
-	if apiKey, ok := os.LookupEnv("LLM_API_KEY"); ok && apiKey != "" {
-		llmClient := llm.NewOpenAIClientFromEnv()
-		svcOpts = append(svcOpts, service.WithLLMClient(llmClient))
-		log.Printf("[%s] LLM 클라이언트 활성화 (모델: %s)", serviceName, llmClient.Model())
+	var llmRouter *llm.Router
+	llmSettings := llm.LoadSettings(func(dbKey, envVar string) string {
+		return config.LoadConfigWithFallback(dbPool, dbKey, envVar)
+	})
+	if providers, err := llmSettings.BuildProviders(); err != nil {
+		log.Printf("[%s] LLM 프로바이더 미설정 — LLM 기능 비활성화: %v", serviceName, err)
+	} else {
+		// 구독 등급: SUBSCRIPTION_SERVICE_ADDR 설정 시 등급별 토큰 한도, 아니면 모두 free 한도
+		var tiers llm.TierResolver
+		if subscriptionAddr := os.Getenv("SUBSCRIPTION_SERVICE_ADDR"); subscriptionAddr != "" {
+			subscriptionConn, dialErr := grpc.NewClient(subscriptionAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+			if dialErr != nil {
+				log.Printf("[%s] subscription-service 연결 실패, free 등급 한도 적용: %v", serviceName, dialErr)
+			} else {
+				defer subscriptionConn.Close()
+				subscriptionClient := clients.NewGRPCSubscriptionClient(v1.NewSubscriptionServiceClient(subscriptionConn))
+				tiers = llm.TierResolverFunc(func(ctx context.Context, userID string) (string, error) {
+					_, tier, err := subscriptionClient.CheckAccess(ctx, userID, "ai_coaching")
+					return tier, err
+				})
+				log.Printf("[%s] subscription-service 연결됨: %s", serviceName, subscriptionAddr)
+			}
+		}
+		budget := llm.NewBudget(llmSettings.DailyTokenBudgets, llmUsageStore, tiers,
+			llm.WithMonthlySpendCaps(llmSettings.MonthlySpendCaps, llmUsageLedger))
+		llmRouter = llm.NewRouter(providers, append(llmSettings.RouterOptions(),
+			llm.WithBudget(budget), llm.WithUsageLedger(llmUsageLedger, llmSettings.Pricing))...)
+		log.Printf("[%s] LLM 라우터 활성화 (폴백 순서: %s)", serviceName, llmRouter)
+	}
+
+	// 모델 레지스트리: S3_ENDPOINT 설정 시 모델 아티팩트를 오브젝트 스토리지에 보관
+	var artifactStore service.ArtifactStore
+	if _, s3Set := os.LookupEnv("S3_ENDPOINT"); s3Set && cfg.S3.Endpoint != "" {
+		s3Client, s3Err := storage.NewS3Client(cfg.S3.Endpoint, cfg.S3.AccessKey, cfg.S3.SecretKey, cfg.S3.Bucket, cfg.S3.Region, cfg.S3.UseSSL)
+		if s3Err != nil {
+			log.Printf("[%s] S3 연결 실패, 내장 모델만 사용: %v", serviceName, s3Err)
+		} else {
+			artifactStore = objectstore.NewArtifactStore(s3Client)
+			log.Printf("[%s] 모델 아티팩트 저장소 연결됨: %s (버킷: %s)", serviceName, cfg.S3.Endpoint, cfg.S3.Bucket)
+		}
+	}
+	registry := service.NewModelRegistry(registryRepo, artifactStore)
+	bootCtx, bootCancel := context.WithTimeout(context.Background(), 10*time.Second)
+	if err := registry.Bootstrap(bootCtx); err != nil {
+		logger.Fatal("모델 레지스트리 초기화 실패", zap.Error(err))
+	}
+	bootCancel()
+	svcOpts = append(svcOpts, service.WithModelRegistry(registry))
+
+	// 측정 데이터: MEASUREMENT_SERVICE_ADDR 설정 시 measurement-service에서 실제 측정값 조회
+	var measurementClient clients.MeasurementClient
+	if measurementAddr := os.Getenv("MEASUREMENT_SERVICE_ADDR"); measurementAddr != "" {
+		measurementConn, dialErr := grpc.NewClient(measurementAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		if dialErr != nil {
+			log.Printf("[%s] measurement-service 연결 실패, 측정 분석 비활성: %v", serviceName, dialErr)
+		} else {
+			defer measurementConn.Close()
+			grpcMeasurement := clients.NewGRPCMeasurementClient(v1.NewMeasurementServiceClient(measurementConn))
+			measurementClient = grpcMeasurement
+			// 물질 식별(SUBSTANCE_IDENTIFIER)도 measurement-service 참조 라이브러리를 사용
+			svcOpts = append(svcOpts, service.WithMeasurementClient(measurementClient), service.WithSubstanceIdentifier(grpcMeasurement))
+			log.Printf("[%s] measurement-service 연결됨: %s", serviceName, measurementAddr)
+		}
+	} else {
+		log.Printf("[%s] MEASUREMENT_SERVICE_ADDR 미설정 — 측정 분석 비활성화", serviceName)
+	}
+
+	// 건강 목표: COACHING_SERVICE_ADDR 설정 시 건강 점수에 coaching-service 목표 달성률 반영
+	var coachingClient clients.CoachingClient
+	if coachingAddr := os.Getenv("COACHING_SERVICE_ADDR"); coachingAddr != "" {
+		coachingConn, dialErr := grpc.NewClient(coachingAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		if dialErr != nil {
+			log.Printf("[%s] coaching-service 연결 실패, 목표 반영 비활성: %v", serviceName, dialErr)
+		} else {
+			defer coachingConn.Close()
+			coachingClient = clients.NewGRPCCoachingClient(v1.NewCoachingServiceClient(coachingConn))
+			svcOpts = append(svcOpts, service.WithCoachingClient(coachingClient))
+			log.Printf("[%s] coaching-service 연결됨: %s", serviceName, coachingAddr)
+		}
 	} else {
-		log.Printf("[%s] LLM_API_KEY 미설정 — LLM 기능 비활성화", serviceName)
+		log.Printf("[%s] COACHING_SERVICE_ADDR 미설정 — 건강 점수 목표 반영 비활성화", serviceName)
+	}
+	// 식사 기록: vision-service gRPC API가 아직 없어 MealLogClient 미연결 (영양 카테고리는 식사 커버리지 0으로 산출)
+
+	// 개인 기준선 이상 탐지: Kafka 설정 시 measurement.completed 소비, ai.anomaly_detected 발행
+	svcOpts = append(svcOpts, service.WithBaselineRepository(baselineRepo))
+	svcOpts = append(svcOpts, service.WithConversationRepository(conversationRepo))
+	var eventBus *events.KafkaEventBus
+	var eventPublisher *kafkaPublisher.EventPublisher
+	if _, kafkaBrokersSet := os.LookupEnv("KAFKA_BROKERS"); kafkaBrokersSet && len(cfg.Kafka.Brokers) > 0 {
+		bus, kafkaErr := events.NewKafkaEventBus(events.KafkaAdapterConfig{
+			Brokers:     cfg.Kafka.Brokers,
+			GroupID:     serviceName,
+			TopicPrefix: "manpasik.",
+		})
+		if kafkaErr != nil {
+			log.Printf("[%s] Kafka 연결 실패, 기준선 이상 탐지 비활성: %v", serviceName, kafkaErr)
+		} else {
+			defer bus.Close()
+			eventBus = bus
+			eventPublisher = kafkaPublisher.NewEventPublisher(bus)
+			svcOpts = append(svcOpts, service.WithAnomalyEventPublisher(eventPublisher))
+			log.Printf("[%s] Kafka 연결됨: %v", serviceName, cfg.Kafka.Brokers)
+		}
+	}
+
+	// LLM 가드레일: 프롬프트 개인정보 가명 처리, 응답 의료 안전 정책, 결정 감사 기록.
+	// Kafka 연결 시 응급 단서를 health_alert.triggered로 에스컬레이션.
+	// 가드레일 안쪽의 응답 캐시는 가명 처리된 분석 프롬프트를 키로 반복 호출을 줄임
+	svcOpts = append(svcOpts, service.WithLLMUsageLedger(llmUsageLedger))
+	if llmRouter != nil {
+		guardOpts := []llm.GuardrailOption{llm.WithGuardrailAudit(guardrailAuditStore)}
+		if eventPublisher != nil {
+			guardOpts = append(guardOpts, llm.WithSafetyEscalator(eventPublisher))
+		} else {
+			log.Printf("[%s] Kafka 미연결 — LLM 응급 단서는 응답 안내만 추가 (에스컬레이션 비활성)", serviceName)
+		}
+		redactor := llm.NewRedactor(llmSettings.PseudonymSecret)
+		embedder := llmSettings.BuildEmbedder(redactor)
+		cachedRouter := llm.NewCachingClient(llmRouter, newResponseCache(cfg),
+			llm.WithCacheTTL(llmSettings.CacheTTL),
+			llm.WithCacheLedger(llmUsageLedger),
+			llm.WithSemanticCache(embedder, llmSettings.SemanticCacheThreshold))
+		svcOpts = append(svcOpts, service.WithLLMClient(llm.NewGuardrail(cachedRouter, redactor, guardOpts...)))
+
+		// AI 채팅 검색 증강: HEALTH_RECORD_SERVICE_ADDR 설정 시 사용자가 AI 어시스턴트에게 공유 동의한
+		// 건강 기록·측정 요약·처방·코칭 메시지를 색인해 답변 근거와 출처로 사용
+		if retriever := newHealthRetriever(cfg, embedder, snippetRepo, measurementClient, coachingClient); retriever != nil {
+			svcOpts = append(svcOpts, service.WithHealthRetriever(retriever))
+		}
 	}
 
 	// Service
 	svc := service.NewInferenceService(analysisRepo, healthScoreRepo, svcOpts...)
+	if eventBus != nil {
+		eventBus.Subscribe(events.EventMeasurementCompleted, kafkaPublisher.NewMeasurementCompletedHandler(svc))
+		eventBus.StartConsuming(context.Background())
+	}
 
 	// gRPC Handler
 	h := handler.NewInferenceHandler(svc)
@@ -141,5 +308,69 @@ func main() {
 	sig := <-quit
 	fmt.Printf("\nReceived signal: %v. Shutting down...\n", sig)
 	grpcServer.GracefulStop()
+	svc.WaitShadowRuns()
 	logger.Info("ai-inference-service stopped")
 }
+
+// newResponseCache는 REDIS_HOST 설정 시 인스턴스 간 공유되는 Redis 응답 캐시를,
+// 미설정이거나 연결에 실패하면 인메모리 캐시를 반환합니다.
+func newResponseCache(cfg *config.ServiceConfig) llm.ResponseCache {
+	if _, redisHostSet := os.LookupEnv("REDIS_HOST"); redisHostSet && cfg.Redis.Host != "" {
+		redisClient, err := cache.NewRedisClient(cfg.Redis.Addr(), cfg.Redis.Password, cfg.Redis.DB)
+		if err != nil {
+			log.Printf("[%s] Redis 연결 실패, LLM 응답 캐시를 메모리로 폴백: %v", serviceName, err)
+		} else {
+			log.Printf("[%s] Redis 연결됨 (LLM 응답 캐시): %s", serviceName, cfg.Redis.Addr())
+			return redisRepo.NewResponseCache(redisClient)
+		}
+	}
+	return llm.NewMemoryResponseCache(llm.DefaultMemoryCacheSize)
+}
+
+// newHealthRetriever는 AI 채팅 검색기를 구성합니다. 동의 확인에 필요한 health-record-service가
+// 설정되지 않았으면 nil을 반환합니다. 벡터 색인은 MILVUS_HOST 설정 시 Milvus, 아니면 인메모리입니다.
+// 연결은 프로세스 종료 시까지 유지합니다.
+func newHealthRetriever(cfg *config.ServiceConfig, embedder llm.Embedder, snippets service.SnippetRepository,
+	measurements clients.MeasurementClient, coaching clients.CoachingClient) *service.HealthRetriever {
+	healthRecordAddr := os.Getenv("HEALTH_RECORD_SERVICE_ADDR")
+	if healthRecordAddr == "" {
+		log.Printf("[%s] HEALTH_RECORD_SERVICE_ADDR 미설정 — AI 채팅 검색 증강 비활성화", serviceName)
+		return nil
+	}
+	healthRecordConn, err := grpc.NewClient(healthRecordAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		log.Printf("[%s] health-record-service 연결 실패, AI 채팅 검색 증강 비활성: %v", serviceName, err)
+		return nil
+	}
+	log.Printf("[%s] health-record-service 연결됨: %s", serviceName, healthRecordAddr)
+
+	var index service.SnippetVectorIndex = memory.NewSnippetVectorIndex()
+	if _, milvusHostSet := os.LookupEnv("MILVUS_HOST"); milvusHostSet && cfg.Milvus.Host != "" {
+		collection := os.Getenv("MILVUS_RAG_COLLECTION")
+		if collection == "" {
+			collection = "manpasik_health_snippets"
+		}
+		milvusClient, milvusErr := vectordb.NewMilvusClient(cfg.Milvus.Addr(), collection, embedder.Dimension())
+		if milvusErr != nil {
+			log.Printf("[%s] Milvus 연결 실패, 인메모리 RAG 색인 사용: %v", serviceName, milvusErr)
+		} else {
+			index = milvusRepo.NewSnippetVectorIndex(milvusClient)
+			log.Printf("[%s] Milvus 연결됨: %s (컬렉션: %s)", serviceName, cfg.Milvus.Addr(), collection)
+		}
+	}
+
+	opts := []service.RetrieverOption{service.WithRetrieverCoaching(coaching), service.WithRetrieverMeasurements(measurements)}
+	if prescriptionAddr := os.Getenv("PRESCRIPTION_SERVICE_ADDR"); prescriptionAddr != "" {
+		prescriptionConn, dialErr := grpc.NewClient(prescriptionAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		if dialErr != nil {
+			log.Printf("[%s] prescription-service 연결 실패, 처방 검색 비활성: %v", serviceName, dialErr)
+		} else {
+			opts = append(opts, service.WithRetrieverPrescriptions(
+				clients.NewGRPCPrescriptionClient(v1.NewPrescriptionServiceClient(prescriptionConn))))
+			log.Printf("[%s] prescription-service 연결됨: %s", serviceName, prescriptionAddr)
+		}
+	}
+
+	records := clients.NewGRPCHealthRecordClient(v1.NewHealthRecordServiceClient(healthRecordConn))
+	return service.NewHealthRetriever(records, embedder, snippets, index, opts...)
+}
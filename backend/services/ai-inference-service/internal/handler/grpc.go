@@ -2,10 +2,13 @@ package handler
 
 import (
 	"context"
+	"strings"
+	"time"
 
 	"github.com/manpasik/backend/services/ai-inference-service/internal/service"
 	apperr "github.com/manpasik/backend/shared/errors"
 	v1 "github.com/manpasik/backend/shared/gen/go/v1"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
@@ -71,6 +74,129 @@ func (h *InferenceHandler) ListModels(ctx context.Context, _ *v1.ListModelsReque
 	return &v1.ListModelsResponse{Models: protoModels}, nil
 }
 
+// RegisterModelVersion implements v1.AiInferenceServiceServer.
+// 관리자 전용 — 후보 모델 아티팩트를 업로드하고 섀도 후보로 등록합니다.
+func (h *InferenceHandler) RegisterModelVersion(ctx context.Context, req *v1.RegisterModelVersionRequest) (*v1.ModelInfo, error) {
+	if req == nil || req.ModelType == v1.AiModelType_AI_MODEL_TYPE_UNSPECIFIED {
+		return nil, status.Error(codes.InvalidArgument, "model_type은 필수입니다")
+	}
+	v := &service.ModelVersion{
+		ModelType:   protoModelTypeToService(req.ModelType),
+		Name:        req.Name,
+		Version:     req.Version,
+		Description: req.Description,
+		Kind:        req.Kind,
+		Metrics:     req.Metrics,
+		Status:      service.ModelStatus(req.Status),
+	}
+	if req.TrainingDataStart != nil {
+		v.TrainingDataStart = req.TrainingDataStart.AsTime()
+	}
+	if req.TrainingDataEnd != nil {
+		v.TrainingDataEnd = req.TrainingDataEnd.AsTime()
+	}
+	if req.TrainedAt != nil {
+		v.TrainedAt = req.TrainedAt.AsTime()
+	}
+	info, err := h.svc.RegisterModelVersion(ctx, v, req.Artifact)
+	if err != nil {
+		return nil, toGRPC(err)
+	}
+	return modelInfoToProto(info), nil
+}
+
+// PromoteModelVersion implements v1.AiInferenceServiceServer.
+// 관리자 전용 — 등록된 버전을 운영으로 전환합니다.
+func (h *InferenceHandler) PromoteModelVersion(ctx context.Context, req *v1.PromoteModelVersionRequest) (*v1.ModelInfo, error) {
+	if req == nil || req.ModelType == v1.AiModelType_AI_MODEL_TYPE_UNSPECIFIED {
+		return nil, status.Error(codes.InvalidArgument, "model_type은 필수입니다")
+	}
+	info, err := h.svc.PromoteModelVersion(ctx, protoModelTypeToService(req.ModelType), req.Version)
+	if err != nil {
+		return nil, toGRPC(err)
+	}
+	return modelInfoToProto(info), nil
+}
+
+// StreamChat implements v1.AiInferenceServiceServer.
+// LLM이 생성하는 텍스트 조각을 chunk로 보내고, 마지막에 전체 응답을 is_final 메시지로 보냅니다.
+func (h *InferenceHandler) StreamChat(req *v1.StreamChatRequest, stream grpc.ServerStreamingServer[v1.StreamChatResponse]) error {
+	reply, err := h.svc.StreamChat(stream.Context(), req.UserId, req.SessionId, req.Message, req.ContextMeasurementIds,
+		func(sessionID, chunk string) error {
+			return stream.Send(&v1.StreamChatResponse{Chunk: chunk, SessionId: sessionID})
+		})
+	if err != nil {
+		return toGRPC(err)
+	}
+	return stream.Send(&v1.StreamChatResponse{
+		IsFinal:      true,
+		SessionId:    reply.SessionID,
+		FullResponse: reply.Content,
+		TokensUsed:   int32(reply.TokensUsed),
+		Sources:      chatSourcesToProto(reply.Sources),
+	})
+}
+
+// GetLLMUsageReport implements v1.AiInferenceServiceServer.
+// 관리자용 LLM 사용량·예상 비용 보고서를 반환합니다.
+func (h *InferenceHandler) GetLLMUsageReport(ctx context.Context, req *v1.GetLLMUsageReportRequest) (*v1.LLMUsageReport, error) {
+	var from, to time.Time
+	if req.StartTime != nil {
+		from = req.StartTime.AsTime()
+	}
+	if req.EndTime != nil {
+		to = req.EndTime.AsTime()
+	}
+	report, err := h.svc.LLMUsageReport(ctx, from, to, req.UserId)
+	if err != nil {
+		return nil, toGRPC(err)
+	}
+	rows := make([]*v1.LLMUsageRow, len(report.Rows))
+	for i, r := range report.Rows {
+		rows[i] = &v1.LLMUsageRow{
+			UserId:    r.UserID,
+			Feature:   r.Feature,
+			Provider:  r.Provider,
+			Model:     r.Model,
+			Calls:     int32(r.Calls),
+			CacheHits: int32(r.CacheHits),
+			Tokens:    int64(r.Tokens),
+			CostUsd:   r.CostUSD,
+		}
+	}
+	return &v1.LLMUsageReport{
+		StartTime:      timestamppb.New(report.From),
+		EndTime:        timestamppb.New(report.To),
+		Rows:           rows,
+		TotalCalls:     int32(report.TotalCalls),
+		TotalCacheHits: int32(report.TotalCacheHits),
+		TotalTokens:    int64(report.TotalTokens),
+		TotalCostUsd:   report.TotalCostUSD,
+	}, nil
+}
+
+// ComposeCoachingMessage implements v1.AiInferenceServiceServer.
+// coaching-service의 규칙 기반 초안을 사용자 컨텍스트로 개인화한 본문을 반환합니다.
+func (h *InferenceHandler) ComposeCoachingMessage(ctx context.Context, req *v1.ComposeCoachingMessageRequest) (*v1.ComposeCoachingMessageResponse, error) {
+	out, err := h.svc.ComposeCoachingMessage(ctx, &service.CoachingComposeRequest{
+		UserID:       req.UserId,
+		CoachingType: strings.ToLower(strings.TrimPrefix(req.CoachingType.String(), "COACHING_TYPE_")),
+		Language:     req.Language,
+		DraftTitle:   req.DraftTitle,
+		DraftBody:    req.DraftBody,
+		ContextLines: req.ContextLines,
+	})
+	if err != nil {
+		return nil, toGRPC(err)
+	}
+	return &v1.ComposeCoachingMessageResponse{
+		Body:     out.Body,
+		Provider: out.Provider,
+		Model:    out.Model,
+		Cached:   out.Cached,
+	}, nil
+}
+
 // GenerateHealthInsight는 LLM 기반 건강 인사이트를 생성합니다.
 // Proto에 별도 RPC가 정의되지 않았으므로, AnalyzeMeasurement의 Summary 필드에서
 // LLM 향상이 자동으로 적용됩니다. 이 메서드는 내부/테스트용으로 직접 호출할 수 있습니다.
@@ -91,6 +217,25 @@ func (h *InferenceHandler) LLMEnabled() bool {
 // Converters
 // ============================================================================
 
+func chatSourcesToProto(sources []service.ChatSource) []*v1.ChatSource {
+	if len(sources) == 0 {
+		return nil
+	}
+	result := make([]*v1.ChatSource, len(sources))
+	for i, src := range sources {
+		result[i] = &v1.ChatSource{
+			Index:      int32(src.Index),
+			SourceType: src.SourceType,
+			SourceId:   src.SourceID,
+			Title:      src.Title,
+		}
+		if !src.RecordedAt.IsZero() {
+			result[i].RecordedAt = src.RecordedAt.UTC().Format(time.RFC3339)
+		}
+	}
+	return result
+}
+
 func protoModelsToService(models []v1.AiModelType) []service.AiModelType {
 	if len(models) == 0 {
 		return nil
@@ -114,6 +259,8 @@ func protoModelTypeToService(mt v1.AiModelType) service.AiModelType {
 		return service.ModelHealthScorer
 	case v1.AiModelType_AI_MODEL_TYPE_FOOD_CALORIE_ESTIMATOR:
 		return service.ModelFoodCalorieEstimator
+	case v1.AiModelType_AI_MODEL_TYPE_SUBSTANCE_IDENTIFIER:
+		return service.ModelSubstanceIdentifier
 	default:
 		return service.ModelBiomarkerClassifier
 	}
@@ -131,6 +278,8 @@ func serviceModelTypeToProto(mt service.AiModelType) v1.AiModelType {
 		return v1.AiModelType_AI_MODEL_TYPE_HEALTH_SCORER
 	case service.ModelFoodCalorieEstimator:
 		return v1.AiModelType_AI_MODEL_TYPE_FOOD_CALORIE_ESTIMATOR
+	case service.ModelSubstanceIdentifier:
+		return v1.AiModelType_AI_MODEL_TYPE_SUBSTANCE_IDENTIFIER
 	default:
 		return v1.AiModelType_AI_MODEL_TYPE_UNSPECIFIED
 	}
@@ -176,25 +325,68 @@ func analysisResultToProto(r *service.AnalysisResult) *v1.AnalysisResult {
 		}
 	}
 	return &v1.AnalysisResult{
-		AnalysisId:         r.AnalysisID,
-		UserId:             r.UserID,
-		MeasurementId:      r.MeasurementID,
-		Biomarkers:         biomarkers,
-		Anomalies:          anomalies,
-		OverallHealthScore: r.OverallHealthScore,
-		Summary:            r.Summary,
-		AnalyzedAt:         timestamppb.New(r.AnalyzedAt),
+		AnalysisId:              r.AnalysisID,
+		UserId:                  r.UserID,
+		MeasurementId:           r.MeasurementID,
+		Biomarkers:              biomarkers,
+		Anomalies:               anomalies,
+		OverallHealthScore:      r.OverallHealthScore,
+		Summary:                 r.Summary,
+		AnalyzedAt:              timestamppb.New(r.AnalyzedAt),
+		SubstanceIdentification: substanceToProto(r),
 	}
 }
 
+func substanceToProto(r *service.AnalysisResult) *v1.SubstanceIdentification {
+	if r.Substance == nil {
+		return nil
+	}
+	out := &v1.SubstanceIdentification{
+		SessionId:       r.MeasurementID,
+		CartridgeType:   r.Substance.CartridgeType,
+		Unknown:         r.Substance.Unknown,
+		NearestDistance: r.Substance.NearestDistance,
+		Threshold:       r.Substance.Threshold,
+	}
+	for _, c := range r.Substance.Candidates {
+		out.Candidates = append(out.Candidates, &v1.SubstanceCandidate{
+			Substance:              c.Substance,
+			Distance:               c.Distance,
+			Votes:                  int32(c.Votes),
+			Confidence:             c.Confidence,
+			EstimatedConcentration: c.EstimatedConcentration,
+			ConcentrationUnit:      c.ConcentrationUnit,
+		})
+	}
+	return out
+}
+
 func healthScoreToProto(s *service.HealthScore) *v1.HealthScoreResponse {
+	contributions := make([]*v1.HealthScoreContribution, len(s.Contributions))
+	for i, c := range s.Contributions {
+		contributions[i] = &v1.HealthScoreContribution{
+			Category:     c.Category,
+			Feature:      c.Feature,
+			Source:       c.Source,
+			Value:        c.Value,
+			Score:        c.Score,
+			Weight:       c.Weight,
+			Contribution: c.Contribution,
+			SampleCount:  int32(c.SampleCount),
+		}
+	}
 	return &v1.HealthScoreResponse{
-		UserId:         s.UserID,
-		OverallScore:   s.OverallScore,
-		CategoryScores: s.CategoryScores,
-		Trend:          s.Trend,
-		Recommendation: s.Recommendation,
-		CalculatedAt:   timestamppb.New(s.CalculatedAt),
+		UserId:           s.UserID,
+		OverallScore:     s.OverallScore,
+		CategoryScores:   s.CategoryScores,
+		Trend:            s.Trend,
+		Recommendation:   s.Recommendation,
+		CalculatedAt:     timestamppb.New(s.CalculatedAt),
+		Contributions:    contributions,
+		CategoryCoverage: s.CategoryCoverage,
+		DataCoverage:     s.DataCoverage,
+		InsufficientData: s.InsufficientData,
+		ModelVersion:     s.ModelVersion,
 	}
 }
 
@@ -218,26 +410,51 @@ func trendPredictionToProto(p *service.TrendPrediction) *v1.TrendPrediction {
 		}
 	}
 	return &v1.TrendPrediction{
-		UserId:     p.UserID,
-		MetricName: p.MetricName,
-		Historical: historical,
-		Predicted:  predicted,
-		Confidence: p.Confidence,
-		Direction:  p.Direction,
-		Insight:    p.Insight,
+		UserId:           p.UserID,
+		MetricName:       p.MetricName,
+		Historical:       historical,
+		Predicted:        predicted,
+		Confidence:       p.Confidence,
+		Direction:        p.Direction,
+		Insight:          p.Insight,
+		InsufficientData: p.InsufficientData,
+		Method:           p.Method,
+		SampleCount:      int32(p.SampleCount),
 	}
 }
 
 func modelInfoToProto(m *service.ModelInfo) *v1.ModelInfo {
-	return &v1.ModelInfo{
-		ModelType:   serviceModelTypeToProto(m.ModelType),
-		Name:        m.Name,
-		Version:     m.Version,
-		Description: m.Description,
-		Accuracy:    m.Accuracy,
-		LastTrained: timestamppb.New(m.LastTrained),
-		Status:      string(m.Status),
+	info := &v1.ModelInfo{
+		ModelType:         serviceModelTypeToProto(m.ModelType),
+		Name:              m.Name,
+		Version:           m.Version,
+		Description:       m.Description,
+		Accuracy:          m.Accuracy,
+		LastTrained:       optionalTimestamp(m.LastTrained),
+		Status:            string(m.Status),
+		ArtifactUri:       m.ArtifactURI,
+		Metrics:           m.Metrics,
+		TrainingDataStart: optionalTimestamp(m.TrainingDataStart),
+		TrainingDataEnd:   optionalTimestamp(m.TrainingDataEnd),
+	}
+	if m.Shadow != nil {
+		info.Shadow = &v1.ShadowEvaluation{
+			Runs:              int32(m.Shadow.Runs),
+			Compared:          int32(m.Shadow.Compared),
+			Agreement:         m.Shadow.Agreement(),
+			MeanAbsScoreDelta: m.Shadow.MeanAbsScoreDelta(),
+			LastRecordedAt:    optionalTimestamp(m.Shadow.LastRecordedAt),
+		}
+	}
+	return info
+}
+
+// optionalTimestamp는 zero time을 nil로 변환합니다.
+func optionalTimestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
 	}
+	return timestamppb.New(t)
 }
 
 func toGRPC(err error) error {
@@ -0,0 +1,185 @@
+package llm
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	apperrors "github.com/manpasik/backend/shared/errors"
+)
+
+const (
+	// DefaultAnthropicModel은 기본 Anthropic 모델입니다.
+	DefaultAnthropicModel = "claude-3-5-sonnet-latest"
+
+	// DefaultAnthropicBaseURL은 Anthropic API 기본 엔드포인트입니다.
+	DefaultAnthropicBaseURL = "https://api.anthropic.com/v1"
+
+	// anthropicVersion은 Messages API 버전 헤더 값입니다.
+	anthropicVersion = "2023-06-01"
+
+	// DefaultMaxTokens는 max_tokens가 필수인 API(Anthropic)의 기본 응답 토큰 수입니다.
+	DefaultMaxTokens = 2048
+)
+
+// AnthropicClient는 Anthropic Messages API 클라이언트입니다.
+type AnthropicClient struct {
+	apiKey     string
+	model      string
+	baseURL    string
+	maxTokens  int
+	httpClient *http.Client
+}
+
+// AnthropicOption은 AnthropicClient 생성 시 옵션을 설정하는 함수 타입입니다.
+type AnthropicOption func(*AnthropicClient)
+
+// WithAnthropicBaseURL은 커스텀 API 기본 URL을 설정합니다.
+func WithAnthropicBaseURL(url string) AnthropicOption {
+	return func(c *AnthropicClient) {
+		if url != "" {
+			c.baseURL = url
+		}
+	}
+}
+
+// WithAnthropicMaxTokens는 응답 최대 토큰 수를 설정합니다.
+func WithAnthropicMaxTokens(n int) AnthropicOption {
+	return func(c *AnthropicClient) {
+		if n > 0 {
+			c.maxTokens = n
+		}
+	}
+}
+
+// NewAnthropicClient는 새 Anthropic 클라이언트를 생성합니다.
+// model이 비어있으면 DefaultAnthropicModel이 사용됩니다.
+func NewAnthropicClient(apiKey, model string, opts ...AnthropicOption) *AnthropicClient {
+	if model == "" {
+		model = DefaultAnthropicModel
+	}
+	c := &AnthropicClient{
+		apiKey:     apiKey,
+		model:      model,
+		baseURL:    DefaultAnthropicBaseURL,
+		maxTokens:  DefaultMaxTokens,
+		httpClient: &http.Client{Timeout: DefaultTimeout},
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Name은 프로바이더 이름을 반환합니다.
+func (c *AnthropicClient) Name() string {
+	return ProviderAnthropic
+}
+
+// Model은 현재 설정된 모델 이름을 반환합니다.
+func (c *AnthropicClient) Model() string {
+	return c.model
+}
+
+// Chat는 Anthropic Messages API를 호출합니다.
+func (c *AnthropicClient) Chat(ctx context.Context, systemPrompt string, messages []ChatMessage) (*ChatResponse, error) {
+	if c.apiKey == "" {
+		return nil, apperrors.New(apperrors.ErrInvalidInput, "LLM API 키가 설정되지 않았습니다")
+	}
+
+	var resp anthropicResponse
+	endpoint := fmt.Sprintf("%s/messages", c.baseURL)
+	if err := postJSON(ctx, c.httpClient, ProviderAnthropic, endpoint, c.headers(), c.buildRequest(systemPrompt, messages), &resp); err != nil {
+		return nil, err
+	}
+
+	var text strings.Builder
+	for _, block := range resp.Content {
+		if block.Type == "text" {
+			text.WriteString(block.Text)
+		}
+	}
+	if text.Len() == 0 {
+		return nil, apperrors.New(apperrors.ErrInternal, "LLM 응답에 텍스트가 없습니다")
+	}
+
+	return &ChatResponse{
+		Content:      text.String(),
+		FinishReason: anthropicFinishReason(resp.StopReason),
+		TokensUsed:   resp.Usage.InputTokens + resp.Usage.OutputTokens,
+	}, nil
+}
+
+// buildRequest는 Messages API 요청을 구성합니다.
+// Messages API는 system 역할 메시지를 받지 않으므로 system 메시지는 시스템 프롬프트에 이어 붙입니다.
+func (c *AnthropicClient) buildRequest(systemPrompt string, messages []ChatMessage) anthropicRequest {
+	system := []string{}
+	if systemPrompt != "" {
+		system = append(system, systemPrompt)
+	}
+	reqMessages := make([]anthropicMessage, 0, len(messages))
+	for _, m := range messages {
+		if m.Role == "system" {
+			system = append(system, m.Content)
+			continue
+		}
+		reqMessages = append(reqMessages, anthropicMessage{Role: m.Role, Content: m.Content})
+	}
+	return anthropicRequest{
+		Model:     c.model,
+		MaxTokens: c.maxTokens,
+		System:    strings.Join(system, "\n\n"),
+		Messages:  reqMessages,
+	}
+}
+
+func (c *AnthropicClient) headers() map[string]string {
+	return map[string]string{
+		"x-api-key":         c.apiKey,
+		"anthropic-version": anthropicVersion,
+	}
+}
+
+// anthropicFinishReason은 stop_reason을 OpenAI 형식 종료 사유로 맞춥니다.
+func anthropicFinishReason(stopReason string) string {
+	switch stopReason {
+	case "end_turn", "stop_sequence":
+		return "stop"
+	case "max_tokens":
+		return "length"
+	default:
+		return stopReason
+	}
+}
+
+// ============================================================================
+// Anthropic API 요청/응답 구조체 (내부용)
+// ============================================================================
+
+type anthropicMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type anthropicRequest struct {
+	Model     string             `json:"model"`
+	MaxTokens int                `json:"max_tokens"`
+	System    string             `json:"system,omitempty"`
+	Messages  []anthropicMessage `json:"messages"`
+	Stream    bool               `json:"stream,omitempty"`
+}
+
+type anthropicResponse struct {
+	Content []struct {
+		Type string `json:"type"`
+		Text string `json:"text"`
+	} `json:"content"`
+	StopReason string `json:"stop_reason"`
+	Usage      struct {
+		InputTokens  int `json:"input_tokens"`
+		OutputTokens int `json:"output_tokens"`
+	} `json:"usage"`
+}
+
+var _ Provider = (*AnthropicClient)(nil)
@@ -0,0 +1,73 @@
+package llm
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	apperrors "github.com/manpasik/backend/shared/errors"
+)
+
+// DefaultAzureAPIVersion은 Azure OpenAI Chat Completion API 기본 버전입니다.
+const DefaultAzureAPIVersion = "2024-06-01"
+
+// AzureOpenAIClient는 Azure OpenAI 배포(deployment) 단위 Chat Completion 클라이언트입니다.
+// 요청/응답 형식은 OpenAI와 같고, 엔드포인트·인증 헤더만 다릅니다.
+type AzureOpenAIClient struct {
+	endpoint   string // https://<resource>.openai.azure.com
+	deployment string
+	apiKey     string
+	apiVersion string
+	maxTokens  int
+	httpClient *http.Client
+}
+
+// NewAzureOpenAIClient는 새 Azure OpenAI 클라이언트를 생성합니다.
+// apiVersion이 비어있으면 DefaultAzureAPIVersion, maxTokens가 0이면 서버 기본값을 사용합니다.
+func NewAzureOpenAIClient(endpoint, deployment, apiKey, apiVersion string, maxTokens int) *AzureOpenAIClient {
+	if apiVersion == "" {
+		apiVersion = DefaultAzureAPIVersion
+	}
+	return &AzureOpenAIClient{
+		endpoint:   strings.TrimRight(endpoint, "/"),
+		deployment: deployment,
+		apiKey:     apiKey,
+		apiVersion: apiVersion,
+		maxTokens:  maxTokens,
+		httpClient: &http.Client{Timeout: DefaultTimeout},
+	}
+}
+
+// Name은 프로바이더 이름을 반환합니다.
+func (c *AzureOpenAIClient) Name() string {
+	return ProviderAzure
+}
+
+// Model은 배포 이름을 반환합니다.
+func (c *AzureOpenAIClient) Model() string {
+	return c.deployment
+}
+
+// Chat는 Azure OpenAI Chat Completion API를 호출합니다.
+func (c *AzureOpenAIClient) Chat(ctx context.Context, systemPrompt string, messages []ChatMessage) (*ChatResponse, error) {
+	if c.apiKey == "" || c.endpoint == "" || c.deployment == "" {
+		return nil, apperrors.New(apperrors.ErrInvalidInput, "Azure OpenAI 엔드포인트·배포·API 키가 설정되지 않았습니다")
+	}
+
+	reqBody := openaiChatRequest{
+		Messages:  openaiMessages(systemPrompt, messages),
+		MaxTokens: c.maxTokens,
+	}
+	endpoint := fmt.Sprintf("%s/openai/deployments/%s/chat/completions?api-version=%s",
+		c.endpoint, url.PathEscape(c.deployment), url.QueryEscape(c.apiVersion))
+
+	var chatResp openaiChatResponse
+	if err := postJSON(ctx, c.httpClient, ProviderAzure, endpoint, map[string]string{"api-key": c.apiKey}, reqBody, &chatResp); err != nil {
+		return nil, err
+	}
+	return chatResp.toChatResponse()
+}
+
+var _ Provider = (*AzureOpenAIClient)(nil)
@@ -0,0 +1,186 @@
+package llm
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	apperrors "github.com/manpasik/backend/shared/errors"
+)
+
+// 구독 등급 (subscription-service SubscriptionTier의 소문자 이름)
+const (
+	TierFree     = "free"
+	TierBasic    = "basic"
+	TierPro      = "pro"
+	TierClinical = "clinical"
+)
+
+// DefaultMonthlySpendCaps는 등급별 사용자 월간 AI 예상 비용 한도(USD) 기본값입니다. 0 이하는 무제한입니다.
+var DefaultMonthlySpendCaps = map[string]float64{
+	TierFree:     0.5,
+	TierBasic:    3,
+	TierPro:      15,
+	TierClinical: 0,
+}
+
+// DefaultDailyTokenBudgets는 등급별 사용자 일일 토큰 한도 기본값입니다. 0 이하는 무제한입니다.
+var DefaultDailyTokenBudgets = map[string]int{
+	TierFree:     20_000,
+	TierBasic:    100_000,
+	TierPro:      500_000,
+	TierClinical: 2_000_000,
+}
+
+type userContextKey struct{}
+
+// WithUser는 LLM 호출을 요청한 사용자를 컨텍스트에 기록합니다.
+// 사용자가 없는 호출(시스템 작업)은 토큰 예산을 적용하지 않습니다.
+func WithUser(ctx context.Context, userID string) context.Context {
+	return context.WithValue(ctx, userContextKey{}, userID)
+}
+
+// UserFromContext는 WithUser로 기록된 사용자 ID를 반환합니다.
+func UserFromContext(ctx context.Context) string {
+	userID, _ := ctx.Value(userContextKey{}).(string)
+	return userID
+}
+
+// TierResolver는 사용자의 구독 등급을 조회합니다.
+type TierResolver interface {
+	Tier(ctx context.Context, userID string) (string, error)
+}
+
+// TierResolverFunc는 함수를 TierResolver로 사용하게 합니다.
+type TierResolverFunc func(ctx context.Context, userID string) (string, error)
+
+// Tier는 f(ctx, userID)를 호출합니다.
+func (f TierResolverFunc) Tier(ctx context.Context, userID string) (string, error) {
+	return f(ctx, userID)
+}
+
+// UsageStore는 사용자별 일일 토큰 사용량 저장소입니다. day는 UTC 자정입니다.
+type UsageStore interface {
+	TokensUsed(ctx context.Context, userID string, day time.Time) (int, error)
+	AddTokens(ctx context.Context, userID string, day time.Time, tokens int) error
+}
+
+// Budget은 구독 등급별 사용자 일일 토큰 한도와 월간 비용 한도를 적용합니다.
+type Budget struct {
+	limits    map[string]int
+	store     UsageStore
+	tiers     TierResolver
+	spendCaps map[string]float64
+	ledger    UsageLedger
+	now       func() time.Time
+}
+
+// BudgetOption은 Budget 생성 시 옵션을 설정하는 함수 타입입니다.
+type BudgetOption func(*Budget)
+
+// WithMonthlySpendCaps는 등급별 월간 예상 비용 한도(USD)를 설정합니다.
+// 이번 달(UTC) 누적 비용은 ledger에서 조회하며, caps가 nil이면 DefaultMonthlySpendCaps를 사용합니다.
+func WithMonthlySpendCaps(caps map[string]float64, ledger UsageLedger) BudgetOption {
+	return func(b *Budget) {
+		if caps == nil {
+			caps = DefaultMonthlySpendCaps
+		}
+		b.spendCaps = caps
+		b.ledger = ledger
+	}
+}
+
+// NewBudget은 토큰 예산을 생성합니다.
+// limits가 nil이면 DefaultDailyTokenBudgets, store가 nil이면 인메모리 저장소를 사용하고,
+// tiers가 nil이거나 등급 조회에 실패하면 free 등급으로 간주합니다.
+func NewBudget(limits map[string]int, store UsageStore, tiers TierResolver, opts ...BudgetOption) *Budget {
+	if limits == nil {
+		limits = DefaultDailyTokenBudgets
+	}
+	if store == nil {
+		store = NewMemoryUsageStore()
+	}
+	b := &Budget{limits: limits, store: store, tiers: tiers, now: time.Now}
+	for _, opt := range opts {
+		opt(b)
+	}
+	return b
+}
+
+// Check는 사용자가 오늘 토큰 한도나 이번 달 비용 한도를 이미 소진했으면 ErrQuotaExceeded를 반환합니다.
+// 사용량 저장소 장애 시에는 LLM 기능 전체를 막지 않도록 허용합니다.
+func (b *Budget) Check(ctx context.Context, userID string) error {
+	tier := b.tier(ctx, userID)
+	if limit := b.limits[tier]; limit > 0 {
+		used, err := b.store.TokensUsed(ctx, userID, b.day())
+		if err == nil && used >= limit {
+			return apperrors.New(apperrors.ErrQuotaExceeded, "오늘의 AI 사용 한도를 초과했습니다").
+				WithDetails(fmt.Sprintf("tier=%s used=%d limit=%d", tier, used, limit))
+		}
+	}
+	if limit := b.spendCaps[tier]; limit > 0 && b.ledger != nil {
+		spent, err := b.ledger.SpendSince(ctx, userID, b.month())
+		if err == nil && spent >= limit {
+			return apperrors.New(apperrors.ErrQuotaExceeded, "이번 달 AI 사용 한도를 초과했습니다").
+				WithDetails(fmt.Sprintf("tier=%s spent_usd=%.4f cap_usd=%.2f", tier, spent, limit))
+		}
+	}
+	return nil
+}
+
+// Record는 응답에 사용된 토큰을 사용자의 오늘 사용량에 더합니다.
+func (b *Budget) Record(ctx context.Context, userID string, tokens int) error {
+	if tokens <= 0 {
+		return nil
+	}
+	return b.store.AddTokens(ctx, userID, b.day(), tokens)
+}
+
+func (b *Budget) tier(ctx context.Context, userID string) string {
+	if b.tiers == nil {
+		return TierFree
+	}
+	tier, err := b.tiers.Tier(ctx, userID)
+	if err != nil || tier == "" {
+		return TierFree
+	}
+	return tier
+}
+
+func (b *Budget) day() time.Time {
+	return b.now().UTC().Truncate(24 * time.Hour)
+}
+
+func (b *Budget) month() time.Time {
+	now := b.now().UTC()
+	return time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+// MemoryUsageStore는 단일 인스턴스용 인메모리 토큰 사용량 저장소입니다.
+type MemoryUsageStore struct {
+	mu    sync.Mutex
+	usage map[string]int
+}
+
+// NewMemoryUsageStore는 인메모리 사용량 저장소를 생성합니다.
+func NewMemoryUsageStore() *MemoryUsageStore {
+	return &MemoryUsageStore{usage: make(map[string]int)}
+}
+
+func (s *MemoryUsageStore) TokensUsed(_ context.Context, userID string, day time.Time) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.usage[usageKey(userID, day)], nil
+}
+
+func (s *MemoryUsageStore) AddTokens(_ context.Context, userID string, day time.Time, tokens int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.usage[usageKey(userID, day)] += tokens
+	return nil
+}
+
+func usageKey(userID string, day time.Time) string {
+	return userID + "|" + day.Format("2006-01-02")
+}
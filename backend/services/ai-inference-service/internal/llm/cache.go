@@ -0,0 +1,296 @@
+package llm
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"sync"
+	"time"
+)
+
+// 응답 캐시 기본값
+const (
+	DefaultCacheTTL        = 24 * time.Hour
+	DefaultMemoryCacheSize = 1000
+	semanticEntriesPerKey  = 64 // 의미 유사 색인의 범위(사용자·기능·프롬프트)별 최대 항목 수
+)
+
+// ResponseCache는 LLM 응답 캐시 저장소입니다. 없는 키는 (nil, false, nil)을 반환합니다.
+type ResponseCache interface {
+	GetResponse(ctx context.Context, key string) (*ChatResponse, bool, error)
+	SetResponse(ctx context.Context, key string, resp *ChatResponse, ttl time.Duration) error
+}
+
+// CacheKey는 모델 체인과 정규화한 프롬프트로 캐시 키를 만듭니다.
+// 공백 차이와 대소문자는 같은 프롬프트로 취급합니다.
+func CacheKey(namespace, systemPrompt string, messages []ChatMessage) string {
+	h := sha256.New()
+	h.Write([]byte(namespace))
+	h.Write([]byte{0})
+	h.Write([]byte(normalizePrompt(systemPrompt)))
+	for _, m := range messages {
+		h.Write([]byte{0})
+		h.Write([]byte(m.Role))
+		h.Write([]byte{0})
+		h.Write([]byte(normalizePrompt(m.Content)))
+	}
+	return "llm:cache:" + hex.EncodeToString(h.Sum(nil))
+}
+
+func normalizePrompt(s string) string {
+	return strings.ToLower(strings.Join(strings.Fields(s), " "))
+}
+
+// CacheOption은 CachingClient 생성 시 옵션을 설정하는 함수 타입입니다.
+type CacheOption func(*CachingClient)
+
+// WithCacheTTL은 캐시 항목 유효 시간을 설정합니다.
+func WithCacheTTL(ttl time.Duration) CacheOption {
+	return func(c *CachingClient) {
+		if ttl > 0 {
+			c.ttl = ttl
+		}
+	}
+}
+
+// WithCacheLedger는 캐시 적중을 사용량 원장에 기록하도록 설정합니다 (토큰·비용 0).
+func WithCacheLedger(ledger UsageLedger) CacheOption {
+	return func(c *CachingClient) {
+		c.ledger = ledger
+	}
+}
+
+// WithSemanticCache는 정확히 같은 프롬프트가 없을 때 같은 사용자·기능의 이전 프롬프트 중
+// 임베딩 코사인 유사도가 threshold 이상인 응답을 재사용하도록 설정합니다.
+// 수치만 다른 프롬프트도 유사하게 나올 수 있으므로 threshold는 충분히 높게(0.95 이상) 잡습니다.
+// threshold가 0 이하이면 사용하지 않습니다.
+func WithSemanticCache(embedder Embedder, threshold float64) CacheOption {
+	return func(c *CachingClient) {
+		if embedder != nil && threshold > 0 {
+			c.embedder = embedder
+			c.threshold = float32(threshold)
+		}
+	}
+}
+
+// CachingClient는 LLMClient를 감싸 같은 입력의 반복 호출을 캐시로 응답합니다.
+//
+//   - 캐시 대상은 기능(WithFeature)이 지정된 단발성 분석 호출뿐이며, 채팅(FeatureChat)과
+//     기능이 지정되지 않은 호출은 대화 맥락에 따라 답이 달라지므로 항상 그대로 전달합니다.
+//   - 키는 감싼 클라이언트의 모델 체인과 정규화한 프롬프트이며, 길이 초과로 잘린 응답은 저장하지 않습니다.
+//   - 캐시 저장소 장애는 미적중으로 취급합니다.
+//
+// Guardrail 안쪽에 두면 가명 처리된 프롬프트·응답만 캐시에 저장되고, 캐시 응답도 안전 검토를 거칩니다.
+type CachingClient struct {
+	next      LLMClient
+	cache     ResponseCache
+	namespace string
+	ttl       time.Duration
+	ledger    UsageLedger
+	embedder  Embedder
+	threshold float32
+	semantic  *semanticIndex
+	now       func() time.Time
+}
+
+// NewCachingClient는 next를 감싸는 응답 캐시를 생성합니다. cache가 nil이면 인메모리 캐시를 사용합니다.
+func NewCachingClient(next LLMClient, cache ResponseCache, opts ...CacheOption) *CachingClient {
+	if cache == nil {
+		cache = NewMemoryResponseCache(DefaultMemoryCacheSize)
+	}
+	c := &CachingClient{
+		next:      next,
+		cache:     cache,
+		namespace: clientName(next),
+		ttl:       DefaultCacheTTL,
+		semantic:  &semanticIndex{entries: map[string][]semanticEntry{}},
+		now:       time.Now,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// String은 로그용으로 감싼 클라이언트 표현을 반환합니다.
+func (c *CachingClient) String() string {
+	return c.namespace
+}
+
+// Chat는 캐시에 같은(또는 의미상 같은) 요청의 응답이 있으면 반환하고, 없으면 호출 후 저장합니다.
+func (c *CachingClient) Chat(ctx context.Context, systemPrompt string, messages []ChatMessage) (*ChatResponse, error) {
+	feature := FeatureFromContext(ctx)
+	if feature == "" || feature == FeatureChat {
+		return c.next.Chat(ctx, systemPrompt, messages)
+	}
+
+	key := CacheKey(c.namespace, systemPrompt, messages)
+	if resp := c.lookup(ctx, key); resp != nil {
+		return resp, nil
+	}
+
+	var scope string
+	var vector []float32
+	if c.embedder != nil && len(messages) == 1 {
+		scope = CacheKey(c.namespace+"|"+UserFromContext(ctx)+"|"+feature, systemPrompt, nil)
+		if vectors, err := c.embedder.Embed(ctx, []string{normalizePrompt(messages[0].Content)}); err == nil && len(vectors) == 1 {
+			vector = vectors[0]
+			if similar := c.semantic.nearest(scope, vector, c.threshold, c.now()); similar != "" {
+				if resp := c.lookup(ctx, similar); resp != nil {
+					return resp, nil
+				}
+			}
+		}
+	}
+
+	resp, err := c.next.Chat(ctx, systemPrompt, messages)
+	if err != nil {
+		return nil, err
+	}
+	if resp.FinishReason != "length" && strings.TrimSpace(resp.Content) != "" {
+		if c.cache.SetResponse(ctx, key, resp, c.ttl) == nil && vector != nil {
+			c.semantic.add(scope, semanticEntry{vector: vector, key: key, expiresAt: c.now().Add(c.ttl)})
+		}
+	}
+	return resp, nil
+}
+
+// ChatStream은 캐시하지 않고 감싼 클라이언트로 전달합니다 (스트리밍은 채팅 전용).
+func (c *CachingClient) ChatStream(ctx context.Context, systemPrompt string, messages []ChatMessage, onDelta func(string) error) (*ChatResponse, error) {
+	if sc, ok := c.next.(StreamingClient); ok {
+		return sc.ChatStream(ctx, systemPrompt, messages, onDelta)
+	}
+	resp, err := c.next.Chat(ctx, systemPrompt, messages)
+	if err != nil {
+		return nil, err
+	}
+	if err := onDelta(resp.Content); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// lookup은 캐시 적중 시 사본을 반환하고 적중을 사용량 원장에 기록합니다.
+func (c *CachingClient) lookup(ctx context.Context, key string) *ChatResponse {
+	cached, ok, err := c.cache.GetResponse(ctx, key)
+	if err != nil || !ok || cached == nil {
+		return nil
+	}
+	resp := *cached
+	resp.TokensUsed = 0
+	resp.Cached = true
+	if c.ledger != nil {
+		_ = c.ledger.RecordUsage(ctx, &UsageRecord{
+			UserID:    UserFromContext(ctx),
+			Feature:   FeatureFromContext(ctx),
+			Provider:  resp.Provider,
+			Model:     resp.Model,
+			CacheHit:  true,
+			CreatedAt: c.now(),
+		})
+	}
+	return &resp
+}
+
+func clientName(client LLMClient) string {
+	if s, ok := client.(interface{ String() string }); ok {
+		return s.String()
+	}
+	return "llm"
+}
+
+// semanticIndex는 범위별 최근 프롬프트 임베딩과 캐시 키의 인메모리 색인입니다.
+type semanticIndex struct {
+	mu      sync.Mutex
+	entries map[string][]semanticEntry
+}
+
+type semanticEntry struct {
+	vector    []float32
+	key       string
+	expiresAt time.Time
+}
+
+// nearest는 유사도가 threshold 이상인 가장 가까운 항목의 캐시 키를 반환합니다.
+func (s *semanticIndex) nearest(scope string, vector []float32, threshold float32, now time.Time) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	best, bestScore := "", threshold
+	for _, e := range s.entries[scope] {
+		if now.After(e.expiresAt) || len(e.vector) != len(vector) {
+			continue
+		}
+		var dot float32
+		for i := range vector {
+			dot += vector[i] * e.vector[i]
+		}
+		if dot >= bestScore {
+			best, bestScore = e.key, dot
+		}
+	}
+	return best
+}
+
+func (s *semanticIndex) add(scope string, e semanticEntry) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	list := append(s.entries[scope], e)
+	if len(list) > semanticEntriesPerKey {
+		list = list[len(list)-semanticEntriesPerKey:]
+	}
+	s.entries[scope] = list
+}
+
+// MemoryResponseCache는 단일 인스턴스용 인메모리 응답 캐시입니다.
+// 최대 항목 수를 넘으면 가장 먼저 저장한 항목부터 제거합니다.
+type MemoryResponseCache struct {
+	mu      sync.Mutex
+	maxSize int
+	items   map[string]memoryCacheItem
+	order   []string
+	now     func() time.Time
+}
+
+type memoryCacheItem struct {
+	resp      ChatResponse
+	expiresAt time.Time
+}
+
+// NewMemoryResponseCache는 인메모리 응답 캐시를 생성합니다.
+func NewMemoryResponseCache(maxSize int) *MemoryResponseCache {
+	if maxSize <= 0 {
+		maxSize = DefaultMemoryCacheSize
+	}
+	return &MemoryResponseCache{maxSize: maxSize, items: map[string]memoryCacheItem{}, now: time.Now}
+}
+
+func (m *MemoryResponseCache) GetResponse(_ context.Context, key string) (*ChatResponse, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	item, ok := m.items[key]
+	if !ok || m.now().After(item.expiresAt) {
+		return nil, false, nil
+	}
+	resp := item.resp
+	return &resp, true, nil
+}
+
+func (m *MemoryResponseCache) SetResponse(_ context.Context, key string, resp *ChatResponse, ttl time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, exists := m.items[key]; !exists {
+		m.order = append(m.order, key)
+	}
+	m.items[key] = memoryCacheItem{resp: *resp, expiresAt: m.now().Add(ttl)}
+	for len(m.items) > m.maxSize {
+		delete(m.items, m.order[0])
+		m.order = m.order[1:]
+	}
+	return nil
+}
+
+var (
+	_ LLMClient       = (*CachingClient)(nil)
+	_ StreamingClient = (*CachingClient)(nil)
+)
@@ -0,0 +1,117 @@
+package llm
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// countingClient는 호출 횟수를 세고 호출마다 다른 응답을 반환하는 LLMClient입니다.
+type countingClient struct {
+	calls        int
+	finishReason string
+}
+
+func (c *countingClient) String() string { return "openai/gpt-4o-mini" }
+
+func (c *countingClient) Chat(_ context.Context, _ string, _ []ChatMessage) (*ChatResponse, error) {
+	c.calls++
+	reason := c.finishReason
+	if reason == "" {
+		reason = "stop"
+	}
+	return &ChatResponse{Content: "응답 " + string(rune('0'+c.calls)), FinishReason: reason, TokensUsed: 50,
+		Provider: "openai", Model: "gpt-4o-mini"}, nil
+}
+
+func TestCachingClient_정규화된_프롬프트_정확_일치(t *testing.T) {
+	next := &countingClient{}
+	ledger := NewMemoryUsageLedger()
+	c := NewCachingClient(next, nil, WithCacheLedger(ledger))
+	ctx := WithFeature(WithUser(context.Background(), "user-1"), FeatureAnalysisSummary)
+
+	first, err := c.Chat(ctx, "시스템", []ChatMessage{{Role: "user", Content: "건강 점수: 82.0/100\n- 혈당: 95"}})
+	if err != nil || first.Cached {
+		t.Fatalf("첫 호출은 미적중이어야 함: %+v %v", first, err)
+	}
+	// 공백·대소문자만 다른 프롬프트는 같은 키
+	second, _ := c.Chat(ctx, "시스템", []ChatMessage{{Role: "user", Content: "  건강 점수:  82.0/100 - 혈당: 95 "}})
+	if next.calls != 1 || !second.Cached || second.Content != first.Content || second.TokensUsed != 0 {
+		t.Errorf("캐시 적중 기대: calls=%d resp=%+v", next.calls, second)
+	}
+	// 수치가 다르면 새 호출
+	c.Chat(ctx, "시스템", []ChatMessage{{Role: "user", Content: "건강 점수: 83.0/100\n- 혈당: 95"}})
+	if next.calls != 2 {
+		t.Errorf("다른 프롬프트는 호출해야 함: calls=%d", next.calls)
+	}
+
+	rows, _ := ledger.SummarizeUsage(context.Background(), UsageQuery{From: time.Now().Add(-time.Hour), To: time.Now().Add(time.Hour)})
+	if len(rows) != 1 || rows[0].CacheHits != 1 || rows[0].Provider != "openai" || rows[0].CostUSD != 0 {
+		t.Errorf("캐시 적중 기록 불일치: %+v", rows)
+	}
+}
+
+func TestCachingClient_채팅과_잘린_응답은_캐시하지_않음(t *testing.T) {
+	next := &countingClient{}
+	c := NewCachingClient(next, nil)
+	msgs := []ChatMessage{{Role: "user", Content: "오늘 컨디션이 어때?"}}
+
+	chat := WithFeature(WithUser(context.Background(), "user-1"), FeatureChat)
+	c.Chat(chat, "시스템", msgs)
+	c.Chat(chat, "시스템", msgs)
+	c.Chat(context.Background(), "시스템", msgs) // 기능 미지정
+	c.Chat(context.Background(), "시스템", msgs)
+	if next.calls != 4 {
+		t.Errorf("채팅·기능 미지정 호출은 캐시하지 않아야 함: calls=%d", next.calls)
+	}
+
+	next.finishReason = "length"
+	insight := WithFeature(context.Background(), FeatureHealthInsight)
+	c.Chat(insight, "시스템", msgs)
+	c.Chat(insight, "시스템", msgs)
+	if next.calls != 6 {
+		t.Errorf("길이 초과로 잘린 응답은 저장하지 않아야 함: calls=%d", next.calls)
+	}
+}
+
+func TestCachingClient_의미_유사_캐시는_같은_사용자만(t *testing.T) {
+	next := &countingClient{}
+	c := NewCachingClient(next, NewMemoryResponseCache(10), WithSemanticCache(NewHashEmbedder(256), 0.9))
+	ask := func(userID, prompt string) *ChatResponse {
+		resp, err := c.Chat(WithFeature(WithUser(context.Background(), userID), FeatureHealthInsight),
+			"시스템", []ChatMessage{{Role: "user", Content: prompt}})
+		if err != nil {
+			t.Fatalf("예상치 못한 오류: %v", err)
+		}
+		return resp
+	}
+
+	ask("user-1", "측정 데이터: 공복혈당 95 mg/dL, 수축기 혈압 118 mmHg. 건강 인사이트를 제공해 주세요.")
+	similar := ask("user-1", "측정 데이터: 공복혈당 95 mg/dL, 수축기 혈압 118 mmHg. 건강 인사이트를 제공해 주세요!")
+	if !similar.Cached || next.calls != 1 {
+		t.Errorf("같은 사용자의 거의 같은 프롬프트는 재사용해야 함: calls=%d", next.calls)
+	}
+	if other := ask("user-2", "측정 데이터: 공복혈당 95 mg/dL, 수축기 혈압 118 mmHg. 건강 인사이트를 제공해 주세요!"); other.Cached {
+		t.Error("다른 사용자에게 의미 유사 캐시를 적용하면 안 됩니다")
+	}
+	if unrelated := ask("user-1", "수면 7시간, 걸음 수 12000보 기록입니다."); unrelated.Cached {
+		t.Error("관련 없는 프롬프트가 캐시 적중했습니다")
+	}
+}
+
+func TestMemoryResponseCache_만료와_용량(t *testing.T) {
+	now := time.Now()
+	m := NewMemoryResponseCache(2)
+	m.now = func() time.Time { return now }
+	ctx := context.Background()
+	m.SetResponse(ctx, "a", &ChatResponse{Content: "A"}, time.Minute)
+	m.SetResponse(ctx, "b", &ChatResponse{Content: "B"}, time.Hour)
+	m.SetResponse(ctx, "c", &ChatResponse{Content: "C"}, time.Hour)
+	if _, ok, _ := m.GetResponse(ctx, "a"); ok {
+		t.Error("용량 초과 시 가장 오래된 항목을 제거해야 함")
+	}
+	now = now.Add(2 * time.Hour)
+	if _, ok, _ := m.GetResponse(ctx, "b"); ok {
+		t.Error("만료된 항목은 반환하지 않아야 함")
+	}
+}
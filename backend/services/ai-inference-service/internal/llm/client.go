@@ -1,16 +1,14 @@
 // Package llm은 LLM(대규모 언어 모델) 클라이언트를 제공합니다.
 //
-// OpenAI Chat Completion API를 기반으로 하며, 환경변수 또는 DB 설정으로
-// 프로바이더·모델·API 키를 구성할 수 있습니다.
+// OpenAI·Anthropic·Azure OpenAI·로컬 OpenAI 호환 서버 어댑터와, 이들을 폴백 체인으로
+// 묶는 Router(재시도·타임아웃·회로 차단·토큰 예산)를 제공합니다. 환경변수 또는
+// DB 설정(SystemConfig)으로 프로바이더·모델·API 키를 구성할 수 있습니다.
 // 보안 원칙: API 키를 코드에 하드코딩하지 않습니다.
 package llm
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
 	"os"
 	"time"
@@ -40,6 +38,9 @@ type ChatResponse struct {
 	Content      string // 모델이 생성한 텍스트
 	FinishReason string // "stop", "length" 등
 	TokensUsed   int    // 총 토큰 사용량
+	Provider     string // 응답한 프로바이더 (Router 경유 시 설정)
+	Model        string // 응답한 모델 (Router 경유 시 설정)
+	Cached       bool   // 응답 캐시에서 반환됨 (CachingClient 경유 시, 이때 TokensUsed는 0)
 }
 
 // ============================================================================
@@ -58,10 +59,13 @@ const (
 )
 
 // OpenAIClient는 OpenAI Chat Completion API 클라이언트입니다.
+// 로컬 OpenAI 호환 서버(vLLM, Ollama 등)도 같은 클라이언트를 사용합니다 (NewLocalClient).
 type OpenAIClient struct {
+	provider   string
 	apiKey     string
 	model      string
 	baseURL    string
+	maxTokens  int
 	httpClient *http.Client
 }
 
@@ -86,6 +90,15 @@ func WithHTTPClient(hc *http.Client) OpenAIOption {
 	}
 }
 
+// WithMaxTokens는 응답 최대 토큰 수를 설정합니다. 0이면 서버 기본값을 사용합니다.
+func WithMaxTokens(n int) OpenAIOption {
+	return func(c *OpenAIClient) {
+		if n > 0 {
+			c.maxTokens = n
+		}
+	}
+}
+
 // NewOpenAIClient는 새 OpenAI 클라이언트를 생성합니다.
 //
 // apiKey가 비어있으면 Chat 호출 시 에러를 반환합니다.
@@ -96,9 +109,10 @@ func NewOpenAIClient(apiKey, model string, opts ...OpenAIOption) *OpenAIClient {
 	}
 
 	c := &OpenAIClient{
-		apiKey:  apiKey,
-		model:   model,
-		baseURL: DefaultBaseURL,
+		provider: ProviderOpenAI,
+		apiKey:   apiKey,
+		model:    model,
+		baseURL:  DefaultBaseURL,
 		httpClient: &http.Client{
 			Timeout: DefaultTimeout,
 		},
@@ -125,6 +139,20 @@ func NewOpenAIClientFromEnv() *OpenAIClient {
 	return NewOpenAIClient(apiKey, model, WithBaseURL(baseURL))
 }
 
+// NewLocalClient는 로컬 OpenAI 호환 서버 클라이언트를 생성합니다.
+//
+// baseURL은 필수이며(예: http://ollama:11434/v1), apiKey는 서버가 요구할 때만 설정합니다.
+func NewLocalClient(baseURL, model, apiKey string, opts ...OpenAIOption) *OpenAIClient {
+	c := NewOpenAIClient(apiKey, model, append([]OpenAIOption{WithBaseURL(baseURL)}, opts...)...)
+	c.provider = ProviderLocal
+	return c
+}
+
+// Name은 프로바이더 이름을 반환합니다.
+func (c *OpenAIClient) Name() string {
+	return c.provider
+}
+
 // Model은 현재 설정된 모델 이름을 반환합니다.
 func (c *OpenAIClient) Model() string {
 	return c.model
@@ -139,12 +167,32 @@ func (c *OpenAIClient) BaseURL() string {
 //
 // ctx의 deadline/cancel을 존중하며, API 에러·네트워크 타임아웃·응답 파싱 에러를 처리합니다.
 func (c *OpenAIClient) Chat(ctx context.Context, systemPrompt string, messages []ChatMessage) (*ChatResponse, error) {
-	// API 키 검증
-	if c.apiKey == "" {
+	// API 키 검증 (로컬 OpenAI 호환 서버는 키 없이 허용)
+	if c.apiKey == "" && c.provider != ProviderLocal {
 		return nil, apperrors.New(apperrors.ErrInvalidInput, "LLM API 키가 설정되지 않았습니다")
 	}
 
-	// 요청 메시지 구성: system 프롬프트 + 사용자 메시지
+	reqBody := openaiChatRequest{
+		Model:     c.model,
+		Messages:  openaiMessages(systemPrompt, messages),
+		MaxTokens: c.maxTokens,
+	}
+
+	headers := map[string]string{}
+	if c.apiKey != "" {
+		headers["Authorization"] = fmt.Sprintf("Bearer %s", c.apiKey)
+	}
+
+	var chatResp openaiChatResponse
+	endpoint := fmt.Sprintf("%s/chat/completions", c.baseURL)
+	if err := postJSON(ctx, c.httpClient, c.provider, endpoint, headers, reqBody, &chatResp); err != nil {
+		return nil, err
+	}
+	return chatResp.toChatResponse()
+}
+
+// openaiMessages는 system 프롬프트 + 사용자 메시지로 요청 메시지를 구성합니다.
+func openaiMessages(systemPrompt string, messages []ChatMessage) []openaiMessage {
 	reqMessages := make([]openaiMessage, 0, len(messages)+1)
 	if systemPrompt != "" {
 		reqMessages = append(reqMessages, openaiMessage{
@@ -158,92 +206,7 @@ func (c *OpenAIClient) Chat(ctx context.Context, systemPrompt string, messages [
 			Content: m.Content,
 		})
 	}
-
-	reqBody := openaiChatRequest{
-		Model:    c.model,
-		Messages: reqMessages,
-	}
-
-	bodyBytes, err := json.Marshal(reqBody)
-	if err != nil {
-		return nil, apperrors.New(apperrors.ErrInternal, "LLM 요청 직렬화에 실패했습니다").
-			WithDetails(fmt.Sprintf("json marshal: %v", err))
-	}
-
-	// HTTP 요청 생성 (context 전파)
-	endpoint := fmt.Sprintf("%s/chat/completions", c.baseURL)
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(bodyBytes))
-	if err != nil {
-		return nil, apperrors.New(apperrors.ErrInternal, "LLM HTTP 요청 생성에 실패했습니다").
-			WithDetails(fmt.Sprintf("new request: %v", err))
-	}
-
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.apiKey))
-
-	// HTTP 호출
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		// context 취소/타임아웃 확인
-		if ctx.Err() != nil {
-			return nil, apperrors.New(apperrors.ErrServiceUnavailable, "LLM 요청이 취소되었거나 타임아웃되었습니다").
-				WithDetails(fmt.Sprintf("context: %v", ctx.Err()))
-		}
-		return nil, apperrors.New(apperrors.ErrServiceUnavailable, "LLM API 호출에 실패했습니다").
-			WithDetails(fmt.Sprintf("http do: %v", err))
-	}
-	defer resp.Body.Close()
-
-	// 응답 바디 읽기
-	respBody, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, apperrors.New(apperrors.ErrInternal, "LLM 응답 읽기에 실패했습니다").
-			WithDetails(fmt.Sprintf("read body: %v", err))
-	}
-
-	// HTTP 상태 코드 확인
-	if resp.StatusCode != http.StatusOK {
-		return nil, c.parseAPIError(resp.StatusCode, respBody)
-	}
-
-	// 응답 파싱
-	var chatResp openaiChatResponse
-	if err := json.Unmarshal(respBody, &chatResp); err != nil {
-		return nil, apperrors.New(apperrors.ErrInternal, "LLM 응답 파싱에 실패했습니다").
-			WithDetails(fmt.Sprintf("json unmarshal: %v", err))
-	}
-
-	if len(chatResp.Choices) == 0 {
-		return nil, apperrors.New(apperrors.ErrInternal, "LLM 응답에 선택지가 없습니다")
-	}
-
-	return &ChatResponse{
-		Content:      chatResp.Choices[0].Message.Content,
-		FinishReason: chatResp.Choices[0].FinishReason,
-		TokensUsed:   chatResp.Usage.TotalTokens,
-	}, nil
-}
-
-// parseAPIError는 OpenAI API 에러 응답을 파싱합니다.
-func (c *OpenAIClient) parseAPIError(statusCode int, body []byte) error {
-	var apiErr openaiErrorResponse
-	if err := json.Unmarshal(body, &apiErr); err == nil && apiErr.Error.Message != "" {
-		switch statusCode {
-		case http.StatusUnauthorized:
-			return apperrors.New(apperrors.ErrUnauthorized, "LLM API 인증에 실패했습니다").
-				WithDetails(apiErr.Error.Message)
-		case http.StatusTooManyRequests:
-			return apperrors.New(apperrors.ErrServiceUnavailable, "LLM API 요청 한도를 초과했습니다").
-				WithDetails(apiErr.Error.Message)
-		default:
-			return apperrors.New(apperrors.ErrInternal, "LLM API 오류가 발생했습니다").
-				WithDetails(fmt.Sprintf("status=%d: %s", statusCode, apiErr.Error.Message))
-		}
-	}
-
-	// 파싱 불가 시 일반 에러
-	return apperrors.New(apperrors.ErrInternal, "LLM API 오류가 발생했습니다").
-		WithDetails(fmt.Sprintf("status=%d, body=%s", statusCode, string(body)))
+	return reqMessages
 }
 
 // ============================================================================
@@ -258,8 +221,12 @@ type openaiMessage struct {
 
 // openaiChatRequest는 OpenAI Chat Completion 요청입니다.
 type openaiChatRequest struct {
-	Model    string           `json:"model"`
-	Messages []openaiMessage  `json:"messages"`
+	Model     string          `json:"model,omitempty"` // Azure는 배포 URL로 모델을 지정
+	Messages  []openaiMessage `json:"messages"`
+	MaxTokens int             `json:"max_tokens,omitempty"`
+
+	Stream        bool                 `json:"stream,omitempty"`
+	StreamOptions *openaiStreamOptions `json:"stream_options,omitempty"`
 }
 
 // openaiChatResponse는 OpenAI Chat Completion 응답입니다.
@@ -280,14 +247,17 @@ type openaiChatResponse struct {
 	} `json:"usage"`
 }
 
-// openaiErrorResponse는 OpenAI API 에러 응답입니다.
-type openaiErrorResponse struct {
-	Error struct {
-		Message string `json:"message"`
-		Type    string `json:"type"`
-		Code    string `json:"code"`
-	} `json:"error"`
+// toChatResponse는 첫 번째 선택지를 ChatResponse로 변환합니다.
+func (r *openaiChatResponse) toChatResponse() (*ChatResponse, error) {
+	if len(r.Choices) == 0 {
+		return nil, apperrors.New(apperrors.ErrInternal, "LLM 응답에 선택지가 없습니다")
+	}
+	return &ChatResponse{
+		Content:      r.Choices[0].Message.Content,
+		FinishReason: r.Choices[0].FinishReason,
+		TokensUsed:   r.Usage.TotalTokens,
+	}, nil
 }
 
 // 컴파일 타임 인터페이스 구현 확인
-var _ LLMClient = (*OpenAIClient)(nil)
+var _ Provider = (*OpenAIClient)(nil)
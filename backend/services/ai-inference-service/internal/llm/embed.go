@@ -0,0 +1,211 @@
+package llm
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"net/http"
+	"strings"
+	"unicode"
+
+	apperrors "github.com/manpasik/backend/shared/errors"
+)
+
+const (
+	// DefaultEmbeddingModel은 기본 OpenAI 임베딩 모델입니다.
+	DefaultEmbeddingModel = "text-embedding-3-small"
+
+	// DefaultEmbeddingDimension은 기본 임베딩 차원입니다. text-embedding-3 모델은 차원 축소를 지원합니다.
+	DefaultEmbeddingDimension = 256
+)
+
+// Embedder는 텍스트를 검색용 벡터로 변환합니다.
+type Embedder interface {
+	// Embed는 texts와 같은 순서로 L2 정규화된 벡터를 반환합니다.
+	Embed(ctx context.Context, texts []string) ([][]float32, error)
+	// Dimension은 벡터 차원입니다.
+	Dimension() int
+}
+
+// ============================================================================
+// OpenAI 임베딩
+// ============================================================================
+
+// OpenAIEmbedder는 OpenAI(또는 호환 서버) Embeddings API 클라이언트입니다.
+// Redactor가 설정되면 ctx의 사용자(WithUser) 기준으로 개인정보를 치환한 뒤 전송합니다.
+type OpenAIEmbedder struct {
+	apiKey     string
+	model      string
+	baseURL    string
+	dimension  int
+	redactor   *Redactor
+	httpClient *http.Client
+}
+
+// NewOpenAIEmbedder는 OpenAI 임베딩 클라이언트를 생성합니다.
+// model·baseURL이 비어있거나 dimension이 0 이하이면 기본값을 사용합니다.
+func NewOpenAIEmbedder(apiKey, model, baseURL string, dimension int, redactor *Redactor) *OpenAIEmbedder {
+	if model == "" {
+		model = DefaultEmbeddingModel
+	}
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+	if dimension <= 0 {
+		dimension = DefaultEmbeddingDimension
+	}
+	return &OpenAIEmbedder{
+		apiKey:     apiKey,
+		model:      model,
+		baseURL:    baseURL,
+		dimension:  dimension,
+		redactor:   redactor,
+		httpClient: &http.Client{Timeout: DefaultTimeout},
+	}
+}
+
+// Dimension은 벡터 차원을 반환합니다.
+func (e *OpenAIEmbedder) Dimension() int {
+	return e.dimension
+}
+
+type openaiEmbeddingRequest struct {
+	Model      string   `json:"model"`
+	Input      []string `json:"input"`
+	Dimensions int      `json:"dimensions,omitempty"`
+}
+
+type openaiEmbeddingResponse struct {
+	Data []struct {
+		Index     int       `json:"index"`
+		Embedding []float32 `json:"embedding"`
+	} `json:"data"`
+}
+
+// Embed는 Embeddings API를 한 번 호출해 texts 전체를 변환합니다.
+func (e *OpenAIEmbedder) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	if len(texts) == 0 {
+		return nil, nil
+	}
+	if e.apiKey == "" {
+		return nil, apperrors.New(apperrors.ErrInvalidInput, "LLM API 키가 설정되지 않았습니다")
+	}
+	input := texts
+	if e.redactor != nil {
+		userID := UserFromContext(ctx)
+		counts := map[string]int{}
+		input = make([]string, len(texts))
+		for i, t := range texts {
+			input[i] = e.redactor.Redact(t, userID, counts)
+		}
+	}
+
+	var resp openaiEmbeddingResponse
+	headers := map[string]string{"Authorization": fmt.Sprintf("Bearer %s", e.apiKey)}
+	req := openaiEmbeddingRequest{Model: e.model, Input: input, Dimensions: e.dimension}
+	if err := postJSON(ctx, e.httpClient, ProviderOpenAI, e.baseURL+"/embeddings", headers, req, &resp); err != nil {
+		return nil, err
+	}
+
+	out := make([][]float32, len(texts))
+	for _, d := range resp.Data {
+		if d.Index < 0 || d.Index >= len(out) || len(d.Embedding) != e.dimension {
+			return nil, apperrors.New(apperrors.ErrInternal, "임베딩 응답 형식이 올바르지 않습니다").
+				WithDetails(fmt.Sprintf("index=%d dim=%d", d.Index, len(d.Embedding)))
+		}
+		out[d.Index] = normalize(d.Embedding)
+	}
+	for i, v := range out {
+		if v == nil {
+			return nil, apperrors.New(apperrors.ErrInternal, "임베딩 응답 형식이 올바르지 않습니다").
+				WithDetails(fmt.Sprintf("missing index %d", i))
+		}
+	}
+	return out, nil
+}
+
+// ============================================================================
+// 해싱 임베딩 (외부 호출 없음)
+// ============================================================================
+
+// HashEmbedder는 단어와 한글 음절 bigram을 특징 해싱한 어휘 기반 임베딩입니다.
+// 외부로 데이터를 보내지 않으므로 임베딩 API가 없는 환경(로컬 LLM 전용, 테스트)에서 사용합니다.
+// 의미 유사도는 모델 임베딩보다 약하지만 같은 바이오마커·약품명·질환명이 포함된 기록은 잘 찾습니다.
+type HashEmbedder struct {
+	dimension int
+}
+
+// NewHashEmbedder는 해싱 임베딩을 생성합니다. dimension이 0 이하이면 기본값을 사용합니다.
+func NewHashEmbedder(dimension int) *HashEmbedder {
+	if dimension <= 0 {
+		dimension = DefaultEmbeddingDimension
+	}
+	return &HashEmbedder{dimension: dimension}
+}
+
+// Dimension은 벡터 차원을 반환합니다.
+func (e *HashEmbedder) Dimension() int {
+	return e.dimension
+}
+
+// Embed는 texts를 해싱 벡터로 변환합니다.
+func (e *HashEmbedder) Embed(_ context.Context, texts []string) ([][]float32, error) {
+	out := make([][]float32, len(texts))
+	for i, t := range texts {
+		v := make([]float32, e.dimension)
+		for _, f := range hashFeatures(t) {
+			h := fnv.New32a()
+			h.Write([]byte(f))
+			sum := h.Sum32()
+			// 최상위 비트로 부호를 정해 해시 충돌의 편향을 줄임
+			if sum&(1<<31) != 0 {
+				v[sum%uint32(e.dimension)] -= 1
+			} else {
+				v[sum%uint32(e.dimension)] += 1
+			}
+		}
+		out[i] = normalize(v)
+	}
+	return out, nil
+}
+
+// hashFeatures는 소문자 단어와, 한글 단어의 음절 bigram(조사가 붙어도 어간이 일치하도록)을 반환합니다.
+func hashFeatures(text string) []string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	features := make([]string, 0, len(words)*2)
+	for _, w := range words {
+		features = append(features, w)
+		runes := []rune(w)
+		if len(runes) < 2 || !unicode.Is(unicode.Hangul, runes[0]) {
+			continue
+		}
+		for i := 0; i+1 < len(runes); i++ {
+			features = append(features, string(runes[i:i+2]))
+		}
+	}
+	return features
+}
+
+// normalize는 v를 L2 정규화합니다. 영벡터는 그대로 반환합니다.
+func normalize(v []float32) []float32 {
+	var sum float64
+	for _, x := range v {
+		sum += float64(x) * float64(x)
+	}
+	if sum == 0 {
+		return v
+	}
+	norm := float32(math.Sqrt(sum))
+	for i := range v {
+		v[i] /= norm
+	}
+	return v
+}
+
+var (
+	_ Embedder = (*OpenAIEmbedder)(nil)
+	_ Embedder = (*HashEmbedder)(nil)
+)
@@ -0,0 +1,67 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestOpenAIEmbedder_가명처리_후_전송(t *testing.T) {
+	var got openaiEmbeddingRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/embeddings" {
+			t.Errorf("예상치 못한 경로: %s", r.URL.Path)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("요청 바디 파싱 실패: %v", err)
+		}
+		// 순서를 뒤집어 응답해도 index 기준으로 정렬되어야 함
+		w.Write([]byte(`{"data":[{"index":1,"embedding":[0,3,4]},{"index":0,"embedding":[2,0,0]}]}`))
+	}))
+	defer server.Close()
+
+	e := NewOpenAIEmbedder("sk-test", "", server.URL, 3, NewRedactor("test-secret"))
+	vecs, err := e.Embed(WithUser(context.Background(), "user-1234"), []string{"user-1234 혈당 기록", "연락처 010-1234-5678"})
+	if err != nil {
+		t.Fatalf("예상치 못한 오류: %v", err)
+	}
+	if got.Model != DefaultEmbeddingModel || got.Dimensions != 3 {
+		t.Errorf("요청 모델·차원이 올바르지 않습니다: %+v", got)
+	}
+	for _, in := range got.Input {
+		if strings.Contains(in, "user-1234") || strings.Contains(in, "010-1234") {
+			t.Errorf("개인정보가 임베딩 요청에 남아 있습니다: %q", in)
+		}
+	}
+	if vecs[0][0] != 1 || vecs[1][1] != 0.6 || vecs[1][2] != 0.8 {
+		t.Errorf("정규화·순서가 올바르지 않습니다: %v", vecs)
+	}
+}
+
+func TestHashEmbedder_같은_어간_유사도(t *testing.T) {
+	e := NewHashEmbedder(256)
+	vecs, _ := e.Embed(context.Background(), []string{
+		"공복혈당이 높게 나왔어요",
+		"혈액검사 결과: 공복혈당 126 mg/dL",
+		"무릎 관절 MRI 촬영",
+	})
+	related, unrelated := cosine(vecs[0], vecs[1]), cosine(vecs[0], vecs[2])
+	if related <= unrelated || related <= 0.1 {
+		t.Errorf("같은 단어가 있는 기록이 더 유사해야 합니다: related=%.3f unrelated=%.3f", related, unrelated)
+	}
+	again, _ := e.Embed(context.Background(), []string{"공복혈당이 높게 나왔어요"})
+	if cosine(vecs[0], again[0]) < 0.9999 {
+		t.Error("같은 텍스트는 같은 벡터여야 합니다")
+	}
+}
+
+func cosine(a, b []float32) float32 {
+	var dot float32
+	for i := range a {
+		dot += a[i] * b[i]
+	}
+	return dot
+}
@@ -0,0 +1,283 @@
+package llm
+
+import (
+	"context"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// GuardrailAudit은 가드레일 결정 1건의 감사 기록입니다.
+// 원문 프롬프트·응답은 저장하지 않고, 사용자도 가명으로만 기록합니다.
+type GuardrailAudit struct {
+	UserRef    string // 사용자 가명 (Redactor.Pseudonym), 시스템 호출이면 빈 값
+	Operation  string // "chat", "chat_stream"
+	Provider   string // 응답한 프로바이더/모델 (Router 경유 시)
+	Model      string
+	Redactions map[string]int // 항목별 가명 처리·마스킹 건수 (PIIUserID, PIIRRN 등)
+	Findings   []string       // 응답 안전성 분류 (SafetyDiagnosis 등)
+	Actions    []string       // 적용한 조치 (GuardActionRedact 등)
+	Escalated  bool           // 응급 확인 요청 신호 전달 성공 여부
+	Failed     bool           // LLM 호출이 실패해 응답 검토를 하지 못함
+	CreatedAt  time.Time
+}
+
+// GuardrailAuditStore는 가드레일 감사 기록 저장소입니다.
+type GuardrailAuditStore interface {
+	RecordGuardrailAudit(ctx context.Context, a *GuardrailAudit) error
+}
+
+// SafetyEscalation은 AI 채팅 사용자 입력에서 응급 단서가 발견되었다는 확인 요청 신호입니다.
+// 키워드 일치만으로는 응급인지 알 수 없으므로(예: "흉통이 뭐예요?") 사용자 확인 전에는
+// 긴급 에스컬레이션(보호자 호출·119 신고)을 시작하지 않는 비긴급 신호로만 전달해야 합니다.
+type SafetyEscalation struct {
+	UserID    string // 실제 사용자 ID (내부 응급 흐름용, 외부 LLM으로는 보내지 않음)
+	Source    string // 단서가 발견된 곳: 항상 "input"(사용자 입력). LLM 응답의 단서는 신호를 보내지 않음
+	CreatedAt time.Time
+}
+
+// SafetyEscalator는 응급 확인 요청 신호를 전달합니다 (비긴급 health_alert.triggered 등).
+type SafetyEscalator interface {
+	EscalateSafety(ctx context.Context, e *SafetyEscalation) error
+}
+
+// GuardrailOption은 Guardrail 생성 시 옵션을 설정하는 함수 타입입니다.
+type GuardrailOption func(*Guardrail)
+
+// WithGuardrailAudit은 감사 기록 저장소를 설정합니다. 설정하지 않으면 인메모리 저장소를 사용합니다.
+func WithGuardrailAudit(store GuardrailAuditStore) GuardrailOption {
+	return func(g *Guardrail) {
+		if store != nil {
+			g.audit = store
+		}
+	}
+}
+
+// WithSafetyEscalator는 응급 확인 요청 신호의 전달 대상을 설정합니다.
+// 설정하지 않으면 응답에 응급 안내만 덧붙이고 감사 기록에 미전달로 남깁니다.
+func WithSafetyEscalator(e SafetyEscalator) GuardrailOption {
+	return func(g *Guardrail) {
+		g.escalator = e
+	}
+}
+
+// Guardrail은 LLMClient를 감싸 의료 데이터 보호와 응답 안전 정책을 적용합니다.
+//
+//   - 요청: 사용자 ID·UUID를 가명으로, 주민등록번호·전화번호·이메일·이름을 자리표시자로 바꿉니다.
+//   - 응답: 진단 단정·용량 지시 문장은 안전 문구로 대체하고, 응급 단서(입력 또는 응답)가 있으면
+//     응급 안내를 덧붙이며, 면책·상담 권고가 없으면 면책 문구를 붙입니다.
+//   - AI 채팅(FeatureChat)의 사용자 입력에 응급 단서가 있을 때만 SafetyEscalator로 확인 요청 신호를 보냅니다.
+//     모델 응답이나 코칭·인사이트처럼 서비스가 만든 프롬프트의 단서로는 보내지 않습니다.
+//   - 모든 호출의 결정은 GuardrailAuditStore에 기록합니다.
+type Guardrail struct {
+	next      LLMClient
+	redactor  *Redactor
+	audit     GuardrailAuditStore
+	escalator SafetyEscalator
+	now       func() time.Time
+}
+
+// NewGuardrail은 next를 감싸는 가드레일을 생성합니다.
+func NewGuardrail(next LLMClient, redactor *Redactor, opts ...GuardrailOption) *Guardrail {
+	g := &Guardrail{
+		next:     next,
+		redactor: redactor,
+		audit:    NewMemoryGuardrailAuditStore(),
+		now:      time.Now,
+	}
+	for _, opt := range opts {
+		opt(g)
+	}
+	return g
+}
+
+// String은 로그용으로 감싼 클라이언트 표현을 반환합니다.
+func (g *Guardrail) String() string {
+	if s, ok := g.next.(interface{ String() string }); ok {
+		return s.String()
+	}
+	return "llm"
+}
+
+// Chat는 가명 처리한 프롬프트로 호출하고 응답에 안전 정책을 적용합니다.
+func (g *Guardrail) Chat(ctx context.Context, systemPrompt string, messages []ChatMessage) (*ChatResponse, error) {
+	userID := UserFromContext(ctx)
+	rec := g.newAudit(userID, "chat")
+	systemPrompt, messages = g.redactPrompt(userID, systemPrompt, messages, rec)
+
+	input := lastUserMessage(messages)
+	resp, err := g.next.Chat(ctx, systemPrompt, messages)
+	if err != nil {
+		// 응답이 없어도 사용자 입력의 응급 단서는 확인 요청 신호로 전달
+		reviewer := newSafetyReviewer(LanguageFromContext(ctx))
+		reviewer.noteInput(input)
+		rec.Failed = true
+		g.finish(ctx, rec, nil, reviewer.findings, reviewer.actions, reviewer.inputEmergency)
+		return nil, err
+	}
+
+	review := ReviewResponse(input, g.redactor.Restore(resp.Content, userID), LanguageFromContext(ctx))
+	out := *resp
+	out.Content = review.Content
+	g.finish(ctx, rec, resp, review.Findings, review.Actions, review.InputEmergency)
+	return &out, nil
+}
+
+// ChatStream은 응답 조각을 문장 단위로 모아 안전 정책을 적용한 뒤 전달합니다.
+// 응급 안내와 면책 문구는 마지막 조각으로 전달합니다. 감싼 클라이언트가 스트리밍을 지원하지 않으면
+// 전체 응답을 검토한 뒤 한 조각으로 전달합니다.
+func (g *Guardrail) ChatStream(ctx context.Context, systemPrompt string, messages []ChatMessage, onDelta func(string) error) (*ChatResponse, error) {
+	sc, ok := g.next.(StreamingClient)
+	if !ok {
+		resp, err := g.Chat(ctx, systemPrompt, messages)
+		if err != nil {
+			return nil, err
+		}
+		if err := onDelta(resp.Content); err != nil {
+			return nil, err
+		}
+		return resp, nil
+	}
+
+	userID := UserFromContext(ctx)
+	rec := g.newAudit(userID, "chat_stream")
+	systemPrompt, messages = g.redactPrompt(userID, systemPrompt, messages, rec)
+	input := lastUserMessage(messages)
+
+	reviewer := newSafetyReviewer(LanguageFromContext(ctx))
+	reviewer.noteInput(input)
+	var pending, content strings.Builder
+	emit := func(final bool) error {
+		for {
+			buf := pending.String()
+			end := sentenceEnd(buf, final)
+			if end <= 0 {
+				return nil
+			}
+			pending.Reset()
+			pending.WriteString(buf[end:])
+			if s := reviewer.sentence(g.redactor.Restore(buf[:end], userID)); s != "" {
+				content.WriteString(s)
+				if err := onDelta(s); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	resp, err := sc.ChatStream(ctx, systemPrompt, messages, func(delta string) error {
+		pending.WriteString(delta)
+		return emit(false)
+	})
+	if err == nil {
+		err = emit(true)
+	}
+	if err == nil {
+		if suffix := reviewer.suffix(); suffix != "" {
+			content.WriteString(suffix)
+			err = onDelta(suffix)
+		}
+	}
+	if err != nil {
+		rec.Failed = true
+		g.finish(ctx, rec, nil, reviewer.findings, reviewer.actions, reviewer.inputEmergency)
+		return nil, err
+	}
+
+	out := *resp
+	out.Content = strings.TrimSpace(content.String())
+	g.finish(ctx, rec, resp, reviewer.findings, reviewer.actions, reviewer.inputEmergency)
+	return &out, nil
+}
+
+func (g *Guardrail) newAudit(userID, operation string) *GuardrailAudit {
+	rec := &GuardrailAudit{Operation: operation, Redactions: map[string]int{}, CreatedAt: g.now()}
+	if userID != "" {
+		rec.UserRef = g.redactor.Pseudonym(userID)
+	}
+	return rec
+}
+
+// redactPrompt는 시스템 프롬프트와 메시지의 개인정보를 치환합니다. 원본 슬라이스는 바꾸지 않습니다.
+func (g *Guardrail) redactPrompt(userID, systemPrompt string, messages []ChatMessage, rec *GuardrailAudit) (string, []ChatMessage) {
+	systemPrompt = g.redactor.Redact(systemPrompt, userID, rec.Redactions)
+	redacted := make([]ChatMessage, len(messages))
+	for i, m := range messages {
+		redacted[i] = ChatMessage{Role: m.Role, Content: g.redactor.Redact(m.Content, userID, rec.Redactions)}
+	}
+	if len(rec.Redactions) > 0 {
+		rec.Actions = append(rec.Actions, GuardActionRedact)
+	}
+	return systemPrompt, redacted
+}
+
+// finish는 응답 검토 결과를 감사 기록에 반영하고, AI 채팅 입력에 응급 단서가 있으면 확인 요청 신호를 보냅니다.
+// resp는 LLM 호출이 실패했으면 nil입니다.
+func (g *Guardrail) finish(ctx context.Context, rec *GuardrailAudit, resp *ChatResponse, findings, actions []string, inputEmergency bool) {
+	if resp != nil {
+		rec.Provider = resp.Provider
+		rec.Model = resp.Model
+	}
+	rec.Findings = append(rec.Findings, findings...)
+	rec.Actions = append(rec.Actions, actions...)
+	userID := UserFromContext(ctx)
+	if inputEmergency && FeatureFromContext(ctx) == FeatureChat && g.escalator != nil && userID != "" {
+		rec.Actions = append(rec.Actions, GuardActionEscalate)
+		err := g.escalator.EscalateSafety(ctx, &SafetyEscalation{UserID: userID, Source: "input", CreatedAt: g.now()})
+		if err != nil {
+			log.Printf("[guardrail] 응급 확인 요청 신호 전달 실패 (user=%s): %v", rec.UserRef, err)
+		}
+		rec.Escalated = err == nil
+	}
+	g.record(ctx, rec)
+}
+
+// record는 감사 기록을 저장합니다. 저장 실패로 응답을 막지는 않습니다.
+func (g *Guardrail) record(ctx context.Context, rec *GuardrailAudit) {
+	if err := g.audit.RecordGuardrailAudit(ctx, rec); err != nil {
+		log.Printf("[guardrail] 감사 기록 저장 실패 (user=%s, op=%s): %v", rec.UserRef, rec.Operation, err)
+	}
+}
+
+func lastUserMessage(messages []ChatMessage) string {
+	for i := len(messages) - 1; i >= 0; i-- {
+		if messages[i].Role == "user" {
+			return messages[i].Content
+		}
+	}
+	return ""
+}
+
+// MemoryGuardrailAuditStore는 단일 인스턴스용 인메모리 감사 기록 저장소입니다.
+type MemoryGuardrailAuditStore struct {
+	mu      sync.Mutex
+	records []*GuardrailAudit
+}
+
+// NewMemoryGuardrailAuditStore는 인메모리 감사 기록 저장소를 생성합니다.
+func NewMemoryGuardrailAuditStore() *MemoryGuardrailAuditStore {
+	return &MemoryGuardrailAuditStore{}
+}
+
+func (s *MemoryGuardrailAuditStore) RecordGuardrailAudit(_ context.Context, a *GuardrailAudit) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.records = append(s.records, a)
+	return nil
+}
+
+// Records는 저장된 감사 기록을 시간순으로 반환합니다.
+func (s *MemoryGuardrailAuditStore) Records() []*GuardrailAudit {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := append([]*GuardrailAudit(nil), s.records...)
+	sort.SliceStable(out, func(i, j int) bool { return out[i].CreatedAt.Before(out[j].CreatedAt) })
+	return out
+}
+
+var (
+	_ LLMClient       = (*Guardrail)(nil)
+	_ StreamingClient = (*Guardrail)(nil)
+)
@@ -0,0 +1,273 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// recordingClient는 받은 프롬프트를 기록하고 정해진 응답(스트리밍 시 조각)을 돌려줍니다.
+type recordingClient struct {
+	chunks       []string
+	err          error
+	systemPrompt string
+	messages     []ChatMessage
+}
+
+func (c *recordingClient) Chat(_ context.Context, systemPrompt string, messages []ChatMessage) (*ChatResponse, error) {
+	c.systemPrompt, c.messages = systemPrompt, messages
+	if c.err != nil {
+		return nil, c.err
+	}
+	return &ChatResponse{Content: strings.Join(c.chunks, ""), TokensUsed: 30, Provider: "openai", Model: "gpt-4o"}, nil
+}
+
+func (c *recordingClient) ChatStream(ctx context.Context, systemPrompt string, messages []ChatMessage, onDelta func(string) error) (*ChatResponse, error) {
+	resp, err := c.Chat(ctx, systemPrompt, messages)
+	if err != nil {
+		return nil, err
+	}
+	for _, chunk := range c.chunks {
+		if err := onDelta(chunk); err != nil {
+			return nil, err
+		}
+	}
+	return resp, nil
+}
+
+type recordingEscalator struct {
+	escalations []*SafetyEscalation
+}
+
+func (e *recordingEscalator) EscalateSafety(_ context.Context, s *SafetyEscalation) error {
+	e.escalations = append(e.escalations, s)
+	return nil
+}
+
+func newTestGuardrail(next LLMClient) (*Guardrail, *MemoryGuardrailAuditStore, *recordingEscalator) {
+	audit := NewMemoryGuardrailAuditStore()
+	esc := &recordingEscalator{}
+	return NewGuardrail(next, NewRedactor("test-secret"), WithGuardrailAudit(audit), WithSafetyEscalator(esc)), audit, esc
+}
+
+func TestRedactor_개인정보_치환(t *testing.T) {
+	r := NewRedactor("test-secret")
+	counts := map[string]int{}
+	in := "사용자 ID: 3f2a9c1e-0b7d-4e5f-9a8b-1c2d3e4f5a6b\n이름: 홍길동, 주민번호 900101-1234567, 연락처 010-1234-5678, hong@example.com\n김철수님 혈당 92.5 mg/dL (2026-03-15)"
+	out := r.Redact(in, "3f2a9c1e-0b7d-4e5f-9a8b-1c2d3e4f5a6b", counts)
+
+	for _, leaked := range []string{"3f2a9c1e", "홍길동", "900101", "010-1234", "hong@example.com", "김철수"} {
+		if strings.Contains(out, leaked) {
+			t.Errorf("개인정보 %q가 남아 있습니다:\n%s", leaked, out)
+		}
+	}
+	for _, kept := range []string{r.Pseudonym("3f2a9c1e-0b7d-4e5f-9a8b-1c2d3e4f5a6b"), "[주민등록번호]", "[전화번호]", "[이메일]", "이름: [이름]", "[이름]님", "92.5 mg/dL", "2026-03-15"} {
+		if !strings.Contains(out, kept) {
+			t.Errorf("%q가 있어야 합니다:\n%s", kept, out)
+		}
+	}
+	if counts[PIIUserID] != 1 || counts[PIIRRN] != 1 || counts[PIIPhone] != 1 || counts[PIIEmail] != 1 || counts[PIIName] != 2 {
+		t.Errorf("치환 건수가 올바르지 않습니다: %v", counts)
+	}
+	if r.Pseudonym("a") != NewRedactor("test-secret").Pseudonym("a") || r.Pseudonym("a") == NewRedactor("other").Pseudonym("a") {
+		t.Error("가명은 같은 키에서 안정적이고 키마다 달라야 합니다")
+	}
+}
+
+func TestGuardrail_Chat_가명처리와_복원(t *testing.T) {
+	client := &recordingClient{}
+	g, audit, _ := newTestGuardrail(client)
+	pseudonym := g.redactor.Pseudonym("user-1234")
+	client.chunks = []string{pseudonym + "의 혈당은 정상 범위입니다. 전문의 상담을 권장합니다."}
+
+	resp, err := g.Chat(WithUser(context.Background(), "user-1234"), "시스템", []ChatMessage{
+		{Role: "user", Content: "사용자 ID: user-1234\n측정 데이터: glucose 92"},
+	})
+	if err != nil {
+		t.Fatalf("예상치 못한 오류: %v", err)
+	}
+	if strings.Contains(client.messages[0].Content, "user-1234") || !strings.Contains(client.messages[0].Content, pseudonym) {
+		t.Errorf("사용자 ID가 가명 처리되지 않았습니다: %q", client.messages[0].Content)
+	}
+	if strings.Contains(resp.Content, pseudonym) || !strings.HasPrefix(resp.Content, "사용자의 혈당") {
+		t.Errorf("응답의 가명이 복원되지 않았습니다: %q", resp.Content)
+	}
+	if strings.Contains(resp.Content, medicalDisclaim) {
+		t.Error("상담 권고가 이미 있으면 면책 문구를 추가하지 않아야 합니다")
+	}
+
+	records := audit.Records()
+	if len(records) != 1 || records[0].UserRef != pseudonym || records[0].Redactions[PIIUserID] != 1 ||
+		!containsString(records[0].Actions, GuardActionRedact) || records[0].Model != "gpt-4o" {
+		t.Errorf("감사 기록이 올바르지 않습니다: %+v", records)
+	}
+}
+
+func TestGuardrail_Chat_진단과_용량_문장_대체(t *testing.T) {
+	client := &recordingClient{chunks: []string{
+		"공복 혈당이 135 mg/dL로 높습니다. 당뇨병입니다. 메트포르민 500mg을 복용하세요. 규칙적인 운동이 도움이 됩니다.",
+	}}
+	g, audit, esc := newTestGuardrail(client)
+
+	resp, err := g.Chat(WithUser(context.Background(), "user-1"), "시스템", []ChatMessage{{Role: "user", Content: "혈당 어때?"}})
+	if err != nil {
+		t.Fatalf("예상치 못한 오류: %v", err)
+	}
+	for _, banned := range []string{"당뇨병입니다", "500mg을 복용"} {
+		if strings.Contains(resp.Content, banned) {
+			t.Errorf("위험 문장 %q가 남아 있습니다: %q", banned, resp.Content)
+		}
+	}
+	for _, want := range []string{"135 mg/dL로 높습니다.", diagnosisRewrite, dosageRewrite, "규칙적인 운동"} {
+		if !strings.Contains(resp.Content, want) {
+			t.Errorf("응답에 %q가 있어야 합니다: %q", want, resp.Content)
+		}
+	}
+	if len(esc.escalations) != 0 {
+		t.Error("응급 단서가 없으면 에스컬레이션하지 않아야 합니다")
+	}
+	rec := audit.Records()[0]
+	if !containsString(rec.Findings, SafetyDiagnosis) || !containsString(rec.Findings, SafetyDosage) || !containsString(rec.Actions, GuardActionRewrite) {
+		t.Errorf("감사 기록이 올바르지 않습니다: %+v", rec)
+	}
+}
+
+func TestGuardrail_Chat_면책문구_추가(t *testing.T) {
+	client := &recordingClient{chunks: []string{"최근 심박수는 안정적입니다."}}
+	g, audit, _ := newTestGuardrail(client)
+
+	resp, err := g.Chat(context.Background(), "시스템", []ChatMessage{{Role: "user", Content: "심박수 요약"}})
+	if err != nil {
+		t.Fatalf("예상치 못한 오류: %v", err)
+	}
+	if !strings.HasSuffix(resp.Content, medicalDisclaim) {
+		t.Errorf("면책 문구가 추가되어야 합니다: %q", resp.Content)
+	}
+	rec := audit.Records()[0]
+	if rec.UserRef != "" || !containsString(rec.Actions, GuardActionDisclaimer) {
+		t.Errorf("시스템 호출 감사 기록이 올바르지 않습니다: %+v", rec)
+	}
+}
+
+func TestGuardrail_Chat_영어_응답은_영어_안전문구(t *testing.T) {
+	client := &recordingClient{chunks: []string{
+		"Your fasting glucose is 135 mg/dL. You have diabetes. Take 500 mg of metformin. I have chest pain too.",
+	}}
+	g, _, _ := newTestGuardrail(client)
+
+	ctx := WithLanguage(WithUser(context.Background(), "user-1"), "en")
+	resp, err := g.Chat(ctx, "system", []ChatMessage{{Role: "user", Content: "How is my glucose?"}})
+	if err != nil {
+		t.Fatalf("예상치 못한 오류: %v", err)
+	}
+	en := safetyTexts["en"]
+	for _, want := range []string{"135 mg/dL.", en.diagnosis, en.dosage, en.emergency} {
+		if !strings.Contains(resp.Content, want) {
+			t.Errorf("응답에 %q가 있어야 합니다: %q", want, resp.Content)
+		}
+	}
+	if regexp.MustCompile(`[가-힣]`).MatchString(resp.Content) {
+		t.Errorf("영어 응답에 한국어 문구가 섞이면 안 됩니다: %q", resp.Content)
+	}
+
+	client.chunks = []string{"Your heart rate has been stable."}
+	resp, err = g.Chat(ctx, "system", []ChatMessage{{Role: "user", Content: "Summarize my heart rate"}})
+	if err != nil {
+		t.Fatalf("예상치 못한 오류: %v", err)
+	}
+	if !strings.HasSuffix(resp.Content, en.disclaimer) {
+		t.Errorf("영어 면책 문구가 추가되어야 합니다: %q", resp.Content)
+	}
+}
+
+func TestGuardrail_ChatStream_응급단서_에스컬레이션(t *testing.T) {
+	client := &recordingClient{chunks: []string{"말씀하신 증상은 ", "주의가 필요합니다. 혈압 수치는 12", "0/80입니다.", " 고혈압입니다."}}
+	g, audit, esc := newTestGuardrail(client)
+
+	var deltas []string
+	resp, err := g.ChatStream(WithFeature(WithUser(context.Background(), "user-1"), FeatureChat), "시스템",
+		[]ChatMessage{{Role: "user", Content: "가슴이 쥐어짜듯 아프고 숨을 못 쉬겠어요"}},
+		func(d string) error {
+			deltas = append(deltas, d)
+			return nil
+		})
+	if err != nil {
+		t.Fatalf("예상치 못한 오류: %v", err)
+	}
+	joined := strings.Join(deltas, "")
+	if strings.TrimSpace(joined) != resp.Content {
+		t.Errorf("전달한 조각과 최종 응답이 다릅니다:\n%q\n%q", joined, resp.Content)
+	}
+	// 문장 경계에서만 전달되므로 소수점·숫자가 조각 사이에서 잘리지 않음
+	if !strings.Contains(deltas[1], "120/80") {
+		t.Errorf("문장 단위로 전달되어야 합니다: %q", deltas)
+	}
+	if strings.Contains(joined, "고혈압입니다") || !strings.Contains(joined, diagnosisRewrite) {
+		t.Errorf("스트리밍 중 진단 문장이 대체되지 않았습니다: %q", joined)
+	}
+	if !strings.Contains(deltas[len(deltas)-1], emergencyNotice) {
+		t.Errorf("마지막 조각에 응급 안내가 있어야 합니다: %q", deltas[len(deltas)-1])
+	}
+	if len(esc.escalations) != 1 || esc.escalations[0].UserID != "user-1" || esc.escalations[0].Source != "input" {
+		t.Errorf("에스컬레이션이 올바르지 않습니다: %+v", esc.escalations)
+	}
+	rec := audit.Records()[0]
+	if rec.Operation != "chat_stream" || !rec.Escalated || !containsString(rec.Findings, SafetyEmergency) {
+		t.Errorf("감사 기록이 올바르지 않습니다: %+v", rec)
+	}
+}
+
+func TestGuardrail_Chat_호출_실패도_감사와_에스컬레이션(t *testing.T) {
+	client := &recordingClient{err: errors.New("upstream down")}
+	g, audit, esc := newTestGuardrail(client)
+
+	_, err := g.Chat(WithFeature(WithUser(context.Background(), "user-1"), FeatureChat), "시스템", []ChatMessage{{Role: "user", Content: "죽고 싶어요"}})
+	if err == nil {
+		t.Fatal("호출 오류가 반환되어야 합니다")
+	}
+	if len(esc.escalations) != 1 {
+		t.Error("LLM 실패 시에도 입력의 응급 단서는 에스컬레이션해야 합니다")
+	}
+	rec := audit.Records()[0]
+	if !rec.Failed || !rec.Escalated {
+		t.Errorf("감사 기록이 올바르지 않습니다: %+v", rec)
+	}
+}
+
+func TestGuardrail_Chat_응답의_응급_안내는_에스컬레이션하지_않음(t *testing.T) {
+	client := &recordingClient{chunks: []string{"흉통은 가슴 부위의 통증을 말합니다. 흉통이나 마비가 있으면 즉시 119에 연락하세요."}}
+	g, audit, esc := newTestGuardrail(client)
+
+	resp, err := g.Chat(WithFeature(WithUser(context.Background(), "user-1"), FeatureChat), "시스템",
+		[]ChatMessage{{Role: "user", Content: "오늘 혈당 관리 팁 알려줘"}})
+	if err != nil {
+		t.Fatalf("예상치 못한 오류: %v", err)
+	}
+	if !strings.Contains(resp.Content, emergencyNotice) {
+		t.Errorf("응답의 응급 단서에는 안내를 덧붙여야 합니다: %q", resp.Content)
+	}
+	if len(esc.escalations) != 0 {
+		t.Errorf("모델 응답의 안전 안내로 에스컬레이션하면 안 됩니다: %+v", esc.escalations)
+	}
+	rec := audit.Records()[0]
+	if rec.Escalated || containsString(rec.Actions, GuardActionEscalate) {
+		t.Errorf("감사 기록이 올바르지 않습니다: %+v", rec)
+	}
+}
+
+func TestGuardrail_Chat_채팅_외_기능은_에스컬레이션하지_않음(t *testing.T) {
+	client := &recordingClient{chunks: []string{"가벼운 산책을 권합니다."}}
+	g, _, esc := newTestGuardrail(client)
+
+	// 코칭 프롬프트는 서비스가 만든 문장이므로 응급 단어가 있어도 사용자 신호가 아님
+	_, err := g.Chat(WithFeature(WithUser(context.Background(), "user-1"), FeatureCoaching), "시스템",
+		[]ChatMessage{{Role: "user", Content: "흉통·호흡곤란 이력이 없는 사용자에게 오늘의 운동을 추천하세요"}})
+	if err != nil {
+		t.Fatalf("예상치 못한 오류: %v", err)
+	}
+	if len(esc.escalations) != 0 {
+		t.Errorf("코칭 요청으로 에스컬레이션하면 안 됩니다: %+v", esc.escalations)
+	}
+}
@@ -0,0 +1,160 @@
+package llm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	apperrors "github.com/manpasik/backend/shared/errors"
+)
+
+// 프로바이더 이름 (SystemConfig llm.provider / llm.providers 값)
+const (
+	ProviderOpenAI    = "openai"
+	ProviderAnthropic = "anthropic"
+	ProviderAzure     = "azure"
+	ProviderLocal     = "local"
+)
+
+// Provider는 라우터가 폴백 체인으로 묶는 이름 있는 LLM 클라이언트입니다.
+type Provider interface {
+	LLMClient
+	// Name은 프로바이더 이름입니다 (openai, anthropic, azure, local).
+	Name() string
+	// Model은 호출에 사용하는 모델(또는 Azure 배포) 이름입니다.
+	Model() string
+}
+
+// ProviderError는 프로바이더 HTTP 호출 실패입니다.
+// 라우터는 StatusCode로 재시도·폴백 여부를 판단하고, 호출자에게는 내장된 AppError로 노출됩니다.
+type ProviderError struct {
+	*apperrors.AppError
+	Provider   string
+	StatusCode int // 0이면 네트워크 오류 또는 타임아웃
+}
+
+// Unwrap은 errors.As로 AppError를 꺼낼 수 있게 합니다.
+func (e *ProviderError) Unwrap() error {
+	return e.AppError
+}
+
+// Retryable은 같은 요청을 다시 보내면 성공할 수 있는 오류(429, 5xx, 네트워크)인지 반환합니다.
+func (e *ProviderError) Retryable() bool {
+	return e.StatusCode == 0 || e.StatusCode == http.StatusTooManyRequests || e.StatusCode >= 500
+}
+
+// isRetryable은 err가 재시도 가능한 ProviderError인지 확인합니다.
+func isRetryable(err error) bool {
+	var pe *ProviderError
+	return errors.As(err, &pe) && pe.Retryable()
+}
+
+// postJSON은 payload를 JSON으로 POST하고 200 응답 바디를 out에 디코딩합니다.
+//
+// 네트워크 오류와 비정상 상태 코드는 ProviderError로, 직렬화·파싱 오류는 AppError로 반환합니다.
+func postJSON(ctx context.Context, hc *http.Client, provider, endpoint string, headers map[string]string, payload, out any) error {
+	resp, err := post(ctx, hc, provider, endpoint, headers, payload)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return &ProviderError{
+			AppError: apperrors.New(apperrors.ErrServiceUnavailable, "LLM 응답 읽기에 실패했습니다").
+				WithDetails(fmt.Sprintf("read body: %v", err)),
+			Provider: provider,
+		}
+	}
+
+	if err := json.Unmarshal(respBody, out); err != nil {
+		return apperrors.New(apperrors.ErrInternal, "LLM 응답 파싱에 실패했습니다").
+			WithDetails(fmt.Sprintf("json unmarshal: %v", err))
+	}
+	return nil
+}
+
+// post는 payload를 JSON으로 POST하고, 200 응답이면 바디를 닫지 않은 채 반환합니다.
+func post(ctx context.Context, hc *http.Client, provider, endpoint string, headers map[string]string, payload any) (*http.Response, error) {
+	bodyBytes, err := json.Marshal(payload)
+	if err != nil {
+		return nil, apperrors.New(apperrors.ErrInternal, "LLM 요청 직렬화에 실패했습니다").
+			WithDetails(fmt.Sprintf("json marshal: %v", err))
+	}
+
+	// HTTP 요청 생성 (context 전파)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(bodyBytes))
+	if err != nil {
+		return nil, apperrors.New(apperrors.ErrInternal, "LLM HTTP 요청 생성에 실패했습니다").
+			WithDetails(fmt.Sprintf("new request: %v", err))
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := hc.Do(req)
+	if err != nil {
+		// context 취소/타임아웃 확인
+		if ctx.Err() != nil {
+			return nil, &ProviderError{
+				AppError: apperrors.New(apperrors.ErrServiceUnavailable, "LLM 요청이 취소되었거나 타임아웃되었습니다").
+					WithDetails(fmt.Sprintf("context: %v", ctx.Err())),
+				Provider: provider,
+			}
+		}
+		return nil, &ProviderError{
+			AppError: apperrors.New(apperrors.ErrServiceUnavailable, "LLM API 호출에 실패했습니다").
+				WithDetails(fmt.Sprintf("http do: %v", err)),
+			Provider: provider,
+		}
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+		return nil, &ProviderError{
+			AppError:   parseAPIError(resp.StatusCode, respBody),
+			Provider:   provider,
+			StatusCode: resp.StatusCode,
+		}
+	}
+	return resp, nil
+}
+
+// parseAPIError는 프로바이더 에러 응답을 파싱합니다.
+// OpenAI·Azure·Anthropic 모두 {"error": {"message": ...}} 형식을 사용합니다.
+func parseAPIError(statusCode int, body []byte) *apperrors.AppError {
+	var apiErr openaiErrorResponse
+	if err := json.Unmarshal(body, &apiErr); err == nil && apiErr.Error.Message != "" {
+		switch statusCode {
+		case http.StatusUnauthorized:
+			return apperrors.New(apperrors.ErrUnauthorized, "LLM API 인증에 실패했습니다").
+				WithDetails(apiErr.Error.Message)
+		case http.StatusTooManyRequests:
+			return apperrors.New(apperrors.ErrServiceUnavailable, "LLM API 요청 한도를 초과했습니다").
+				WithDetails(apiErr.Error.Message)
+		default:
+			return apperrors.New(apperrors.ErrInternal, "LLM API 오류가 발생했습니다").
+				WithDetails(fmt.Sprintf("status=%d: %s", statusCode, apiErr.Error.Message))
+		}
+	}
+
+	// 파싱 불가 시 일반 에러
+	return apperrors.New(apperrors.ErrInternal, "LLM API 오류가 발생했습니다").
+		WithDetails(fmt.Sprintf("status=%d, body=%s", statusCode, string(body)))
+}
+
+// openaiErrorResponse는 OpenAI API 에러 응답입니다. Azure·Anthropic도 같은 형식을 사용합니다.
+type openaiErrorResponse struct {
+	Error struct {
+		Message string `json:"message"`
+		Type    string `json:"type"`
+		Code    string `json:"code"`
+	} `json:"error"`
+}
@@ -0,0 +1,102 @@
+package llm
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"regexp"
+	"strings"
+)
+
+// 가명 처리·마스킹 항목 (감사 기록의 Redactions 키)
+const (
+	PIIUserID = "user_id"
+	PIIID     = "identifier"
+	PIIRRN    = "rrn"
+	PIIPhone  = "phone"
+	PIIEmail  = "email"
+	PIIName   = "name"
+)
+
+var (
+	// 주민등록번호·외국인등록번호 (YYMMDD-GNNNNNN, 하이픈 생략 허용)
+	rrnPattern = regexp.MustCompile(`\b\d{2}(?:0[1-9]|1[0-2])(?:0[1-9]|[12]\d|3[01])\s?-?\s?[1-8]\d{6}\b`)
+	// 휴대전화(+82 포함)와 지역번호 유선전화
+	phonePattern = regexp.MustCompile(`(?:\+82[-\s]?|\b0)(?:1[016789]|2|[3-6][1-5])[-\s.]?\d{3,4}[-\s.]?\d{4}\b`)
+	emailPattern = regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`)
+	uuidPattern  = regexp.MustCompile(`(?i)\b[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}\b`)
+	// "이름: 홍길동", "환자명 = 김철수", "name: John Smith" 형태의 표시된 이름
+	labeledNamePattern = regexp.MustCompile(`(?i)((?:이름|성명|환자명|보호자명?|수신자|name)\s*[:：=]\s*)([가-힣]{2,4}|[A-Z][a-z]+(?:\s[A-Z][a-z]+)?)`)
+	// 흔한 성씨로 시작하는 세 글자 한글 이름 + 호칭 ("홍길동님", "김철수 씨")
+	honorificNamePattern = regexp.MustCompile(`([김이박최정강조윤장임한오서신권황안송류전홍고문양손배백허유남심노하곽성차주우구민진나지엄채원천방공현함변염][가-힣]{2})(\s?(?:님|씨))`)
+)
+
+// Redactor는 외부 LLM으로 보내는 텍스트에서 식별자를 가명 처리하고 개인정보를 마스킹합니다.
+//
+// 사용자 ID와 UUID 형식 식별자는 비밀 키 기반 HMAC 가명으로 바꿔 같은 대상이 같은 가명을 갖게 하고,
+// 주민등록번호·전화번호·이메일·이름은 종류별 자리표시자로 바꿉니다.
+type Redactor struct {
+	secret []byte
+}
+
+// NewRedactor는 가명 처리 키로 Redactor를 생성합니다.
+// secret이 비어있으면 프로세스마다 무작위 키를 사용하므로 재시작 후에는 가명이 달라집니다.
+func NewRedactor(secret string) *Redactor {
+	key := []byte(secret)
+	if len(key) == 0 {
+		key = make([]byte, 32)
+		_, _ = rand.Read(key)
+	}
+	return &Redactor{secret: key}
+}
+
+// Pseudonym은 식별자의 가명(user_ + HMAC-SHA256 앞 12자리)을 반환합니다.
+func (r *Redactor) Pseudonym(id string) string {
+	return "user_" + r.digest(id)
+}
+
+func (r *Redactor) digest(id string) string {
+	mac := hmac.New(sha256.New, r.secret)
+	mac.Write([]byte(id))
+	return hex.EncodeToString(mac.Sum(nil))[:12]
+}
+
+// Redact는 text의 개인정보를 치환하고 항목별 치환 건수를 counts에 더합니다.
+func (r *Redactor) Redact(text, userID string, counts map[string]int) string {
+	if userID != "" && strings.Contains(text, userID) {
+		counts[PIIUserID] += strings.Count(text, userID)
+		text = strings.ReplaceAll(text, userID, r.Pseudonym(userID))
+	}
+	text = uuidPattern.ReplaceAllStringFunc(text, func(id string) string {
+		counts[PIIID]++
+		return "id_" + r.digest(strings.ToLower(id))
+	})
+	text = replaceCounting(rrnPattern, text, "[주민등록번호]", PIIRRN, counts)
+	text = replaceCounting(phonePattern, text, "[전화번호]", PIIPhone, counts)
+	text = replaceCounting(emailPattern, text, "[이메일]", PIIEmail, counts)
+	text = labeledNamePattern.ReplaceAllStringFunc(text, func(m string) string {
+		counts[PIIName]++
+		return labeledNamePattern.ReplaceAllString(m, "${1}[이름]")
+	})
+	text = honorificNamePattern.ReplaceAllStringFunc(text, func(m string) string {
+		counts[PIIName]++
+		return honorificNamePattern.ReplaceAllString(m, "[이름]${2}")
+	})
+	return text
+}
+
+// Restore는 응답에 되돌아온 사용자 가명을 일반 호칭으로 바꿉니다.
+func (r *Redactor) Restore(text, userID string) string {
+	if userID == "" {
+		return text
+	}
+	return strings.ReplaceAll(text, r.Pseudonym(userID), "사용자")
+}
+
+func replaceCounting(re *regexp.Regexp, text, placeholder, kind string, counts map[string]int) string {
+	return re.ReplaceAllStringFunc(text, func(string) string {
+		counts[kind]++
+		return placeholder
+	})
+}
@@ -0,0 +1,337 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"strings"
+	"sync"
+	"time"
+
+	apperrors "github.com/manpasik/backend/shared/errors"
+)
+
+// Router 기본값
+const (
+	DefaultMaxRetries       = 2
+	DefaultBaseBackoff      = 200 * time.Millisecond
+	DefaultMaxBackoff       = 2 * time.Second
+	DefaultCallTimeout      = 20 * time.Second
+	DefaultBreakerThreshold = 5
+	DefaultBreakerCooldown  = 30 * time.Second
+)
+
+// Router는 여러 프로바이더를 순서대로 시도하는 LLMClient입니다.
+//
+//   - 프로바이더마다 호출 타임아웃을 적용하고, 429/5xx/네트워크 오류는 지터 백오프로 재시도합니다.
+//   - 재시도 후에도 실패하거나 재시도 불가 오류(401, 400 등)면 다음 프로바이더로 폴백합니다.
+//   - 연속 실패가 임계값에 도달한 프로바이더는 냉각 시간 동안 건너뜁니다 (회로 차단).
+//   - Budget이 설정되면 컨텍스트 사용자(WithUser)의 일일 토큰·월간 비용 한도를 적용합니다.
+//   - UsageLedger가 설정되면 성공한 호출의 토큰과 예상 비용을 사용자·기능(WithFeature)별로 기록합니다.
+type Router struct {
+	providers   []Provider
+	breakers    []*breaker
+	budget      *Budget
+	ledger      UsageLedger
+	pricing     Pricing
+	maxRetries  int
+	baseBackoff time.Duration
+	maxBackoff  time.Duration
+	callTimeout time.Duration
+	now         func() time.Time
+	sleep       func(ctx context.Context, d time.Duration) error
+}
+
+// RouterOption은 Router 생성 시 옵션을 설정하는 함수 타입입니다.
+type RouterOption func(*Router)
+
+// WithRetryPolicy는 프로바이더별 재시도 횟수와 백오프 범위를 설정합니다.
+func WithRetryPolicy(maxRetries int, baseBackoff, maxBackoff time.Duration) RouterOption {
+	return func(r *Router) {
+		if maxRetries >= 0 {
+			r.maxRetries = maxRetries
+		}
+		if baseBackoff > 0 {
+			r.baseBackoff = baseBackoff
+		}
+		if maxBackoff > 0 {
+			r.maxBackoff = maxBackoff
+		}
+	}
+}
+
+// WithCallTimeout은 프로바이더 호출 1회의 타임아웃을 설정합니다.
+func WithCallTimeout(d time.Duration) RouterOption {
+	return func(r *Router) {
+		if d > 0 {
+			r.callTimeout = d
+		}
+	}
+}
+
+// WithCircuitBreaker는 회로 차단 임계값(연속 실패 수)과 냉각 시간을 설정합니다.
+func WithCircuitBreaker(threshold int, cooldown time.Duration) RouterOption {
+	return func(r *Router) {
+		for _, b := range r.breakers {
+			if threshold > 0 {
+				b.threshold = threshold
+			}
+			if cooldown > 0 {
+				b.cooldown = cooldown
+			}
+		}
+	}
+}
+
+// WithBudget은 사용자·등급별 토큰 예산을 설정합니다.
+func WithBudget(b *Budget) RouterOption {
+	return func(r *Router) {
+		r.budget = b
+	}
+}
+
+// WithUsageLedger는 호출별 사용량·예상 비용 원장을 설정합니다. pricing이 nil이면 DefaultPricing을 사용합니다.
+func WithUsageLedger(ledger UsageLedger, pricing Pricing) RouterOption {
+	return func(r *Router) {
+		if pricing == nil {
+			pricing = DefaultPricing
+		}
+		r.ledger = ledger
+		r.pricing = pricing
+	}
+}
+
+// NewRouter는 주어진 순서의 폴백 체인으로 Router를 생성합니다.
+func NewRouter(providers []Provider, opts ...RouterOption) *Router {
+	r := &Router{
+		providers:   providers,
+		breakers:    make([]*breaker, len(providers)),
+		maxRetries:  DefaultMaxRetries,
+		baseBackoff: DefaultBaseBackoff,
+		maxBackoff:  DefaultMaxBackoff,
+		callTimeout: DefaultCallTimeout,
+		now:         time.Now,
+		sleep:       sleepContext,
+	}
+	for i := range r.breakers {
+		r.breakers[i] = &breaker{threshold: DefaultBreakerThreshold, cooldown: DefaultBreakerCooldown}
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// Providers는 폴백 순서대로 "프로바이더/모델" 목록을 반환합니다.
+func (r *Router) Providers() []string {
+	names := make([]string, 0, len(r.providers))
+	for _, p := range r.providers {
+		names = append(names, p.Name()+"/"+p.Model())
+	}
+	return names
+}
+
+// Chat는 폴백 체인을 따라 첫 번째 성공 응답을 반환합니다.
+func (r *Router) Chat(ctx context.Context, systemPrompt string, messages []ChatMessage) (*ChatResponse, error) {
+	return r.route(ctx, func(callCtx context.Context, p Provider) (*ChatResponse, error) {
+		return p.Chat(callCtx, systemPrompt, messages)
+	}, nil)
+}
+
+// ChatStream은 폴백 체인을 따라 스트리밍 응답을 전달합니다.
+//
+// 첫 조각을 전달하기 전의 실패만 재시도·폴백하며, 조각이 이미 전달된 뒤의 실패는 그대로 반환합니다.
+// 스트리밍을 지원하지 않는 프로바이더는 전체 응답을 한 조각으로 전달합니다.
+func (r *Router) ChatStream(ctx context.Context, systemPrompt string, messages []ChatMessage, onDelta func(string) error) (*ChatResponse, error) {
+	emitted := false
+	forward := func(delta string) error {
+		emitted = true
+		return onDelta(delta)
+	}
+	return r.route(ctx, func(callCtx context.Context, p Provider) (*ChatResponse, error) {
+		if sp, ok := p.(StreamingClient); ok {
+			return sp.ChatStream(callCtx, systemPrompt, messages, forward)
+		}
+		resp, err := p.Chat(callCtx, systemPrompt, messages)
+		if err != nil {
+			return nil, err
+		}
+		if err := forward(resp.Content); err != nil {
+			return nil, err
+		}
+		return resp, nil
+	}, func() bool { return !emitted })
+}
+
+// route는 예산 확인 후 프로바이더를 순서대로 호출합니다.
+// canRetry가 false를 반환하면 재시도·폴백 없이 마지막 오류를 반환합니다 (nil이면 항상 허용).
+func (r *Router) route(ctx context.Context, call func(context.Context, Provider) (*ChatResponse, error), canRetry func() bool) (*ChatResponse, error) {
+	if canRetry == nil {
+		canRetry = func() bool { return true }
+	}
+	userID := UserFromContext(ctx)
+	if r.budget != nil && userID != "" {
+		if err := r.budget.Check(ctx, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	var lastErr error
+	for i, p := range r.providers {
+		b := r.breakers[i]
+		if !b.allow(r.now()) {
+			lastErr = apperrors.New(apperrors.ErrServiceUnavailable, "LLM 프로바이더가 일시 차단되었습니다").
+				WithDetails(p.Name())
+			continue
+		}
+
+		resp, err := r.callWithRetry(ctx, p, call, canRetry)
+		if err == nil {
+			b.success()
+			resp.Provider = p.Name()
+			resp.Model = p.Model()
+			if r.budget != nil && userID != "" {
+				_ = r.budget.Record(ctx, userID, resp.TokensUsed)
+			}
+			r.recordUsage(ctx, userID, resp)
+			return resp, nil
+		}
+		if ctx.Err() != nil {
+			// 호출자 취소는 프로바이더 상태와 무관하므로 실패로 집계하지 않고 시험 호출만 반납
+			b.abort()
+			return nil, apperrors.New(apperrors.ErrServiceUnavailable, "LLM 요청이 취소되었거나 타임아웃되었습니다").
+				WithDetails(fmt.Sprintf("context: %v", ctx.Err()))
+		}
+		// 재시도 불가 오류는 요청·설정 문제이므로 프로바이더 상태로 집계하지 않음
+		if isRetryable(err) {
+			b.failure(r.now())
+		} else {
+			b.success()
+		}
+		lastErr = err
+		if !canRetry() {
+			break
+		}
+	}
+
+	if lastErr == nil {
+		return nil, apperrors.New(apperrors.ErrServiceUnavailable, "사용 가능한 LLM 프로바이더가 없습니다")
+	}
+	var appErr *apperrors.AppError
+	if errors.As(lastErr, &appErr) {
+		return nil, appErr
+	}
+	return nil, lastErr
+}
+
+// recordUsage는 성공한 호출을 사용량 원장에 기록합니다. 기록 실패로 응답을 막지는 않습니다.
+func (r *Router) recordUsage(ctx context.Context, userID string, resp *ChatResponse) {
+	if r.ledger == nil {
+		return
+	}
+	_ = r.ledger.RecordUsage(ctx, &UsageRecord{
+		UserID:    userID,
+		Feature:   FeatureFromContext(ctx),
+		Provider:  resp.Provider,
+		Model:     resp.Model,
+		Tokens:    resp.TokensUsed,
+		CostUSD:   r.pricing.Cost(resp.Model, resp.TokensUsed),
+		CreatedAt: r.now(),
+	})
+}
+
+// callWithRetry는 한 프로바이더를 호출 타임아웃과 재시도 정책으로 호출합니다.
+func (r *Router) callWithRetry(ctx context.Context, p Provider, call func(context.Context, Provider) (*ChatResponse, error), canRetry func() bool) (*ChatResponse, error) {
+	for attempt := 0; ; attempt++ {
+		callCtx, cancel := context.WithTimeout(ctx, r.callTimeout)
+		resp, err := call(callCtx, p)
+		cancel()
+		if err == nil {
+			return resp, nil
+		}
+		if attempt >= r.maxRetries || !isRetryable(err) || ctx.Err() != nil || !canRetry() {
+			return nil, err
+		}
+		if sleepErr := r.sleep(ctx, r.backoff(attempt)); sleepErr != nil {
+			return nil, err
+		}
+	}
+}
+
+// backoff는 base·2^attempt(최대 maxBackoff)의 절반~전체 구간에서 지터를 적용한 대기 시간입니다.
+func (r *Router) backoff(attempt int) time.Duration {
+	ceiling := r.baseBackoff << attempt
+	if ceiling <= 0 || ceiling > r.maxBackoff {
+		ceiling = r.maxBackoff
+	}
+	half := ceiling / 2
+	return half + time.Duration(rand.Int63n(int64(ceiling-half)+1))
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+// breaker는 프로바이더별 연속 실패 기반 회로 차단기입니다.
+// 냉각 시간이 지나면 시험 호출 1건만 통과시키고(half-open), 그 결과로 닫히거나 다시 열립니다.
+type breaker struct {
+	mu        sync.Mutex
+	threshold int
+	cooldown  time.Duration
+	failures  int
+	openedAt  time.Time
+	probing   bool
+}
+
+func (b *breaker) allow(now time.Time) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.failures < b.threshold {
+		return true
+	}
+	if !b.probing && now.Sub(b.openedAt) >= b.cooldown {
+		b.probing = true
+		return true
+	}
+	return false
+}
+
+func (b *breaker) success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures = 0
+	b.probing = false
+}
+
+// abort는 결과 없이 끝난 호출의 시험 호출 자격을 반납합니다. 실패로 집계하지 않으므로
+// 냉각 시간이 지난 차단기는 다음 요청에서 다시 시험 호출을 보냅니다.
+func (b *breaker) abort() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.probing = false
+}
+
+func (b *breaker) failure(now time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures++
+	b.probing = false
+	if b.failures >= b.threshold {
+		b.openedAt = now
+	}
+}
+
+// String은 로그용 폴백 체인 표현입니다 (예: openai/gpt-4o → anthropic/claude-...).
+func (r *Router) String() string {
+	return strings.Join(r.Providers(), " → ")
+}
+
+var _ LLMClient = (*Router)(nil)
@@ -0,0 +1,377 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	apperrors "github.com/manpasik/backend/shared/errors"
+)
+
+// ============================================================================
+// 테스트 헬퍼
+// ============================================================================
+
+// scriptedProvider는 정해진 HTTP 상태 순서대로 실패한 뒤 성공하는 프로바이더입니다.
+type scriptedProvider struct {
+	name     string
+	failures []int // 호출 순서별 실패 상태 코드 (소진 후 성공)
+	calls    int
+}
+
+func (p *scriptedProvider) Name() string  { return p.name }
+func (p *scriptedProvider) Model() string { return p.name + "-model" }
+
+func (p *scriptedProvider) Chat(_ context.Context, _ string, _ []ChatMessage) (*ChatResponse, error) {
+	p.calls++
+	if p.calls <= len(p.failures) {
+		status := p.failures[p.calls-1]
+		return nil, &ProviderError{
+			AppError:   apperrors.New(apperrors.ErrServiceUnavailable, "scripted failure"),
+			Provider:   p.name,
+			StatusCode: status,
+		}
+	}
+	return &ChatResponse{Content: "ok from " + p.name, FinishReason: "stop", TokensUsed: 100}, nil
+}
+
+// newTestRouter는 대기 없이 재시도하는 Router를 생성합니다.
+func newTestRouter(providers []Provider, opts ...RouterOption) *Router {
+	r := NewRouter(providers, opts...)
+	r.sleep = func(context.Context, time.Duration) error { return nil }
+	return r
+}
+
+func ask(t *testing.T, r *Router, ctx context.Context) (*ChatResponse, error) {
+	t.Helper()
+	return r.Chat(ctx, "시스템", []ChatMessage{{Role: "user", Content: "질문"}})
+}
+
+// ============================================================================
+// 프로바이더 어댑터
+// ============================================================================
+
+func TestAnthropicClient_Chat_성공(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/messages" {
+			t.Errorf("경로가 /messages여야 하지만 %s입니다", r.URL.Path)
+		}
+		if r.Header.Get("x-api-key") != "ant-key" || r.Header.Get("anthropic-version") == "" {
+			t.Error("Anthropic 인증 헤더가 올바르지 않습니다")
+		}
+		var req anthropicRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("요청 바디 파싱 실패: %v", err)
+		}
+		// system 역할 메시지는 system 필드로 합쳐지고 messages에는 user/assistant만 남음
+		if req.System != "시스템\n\n추가 지침" || len(req.Messages) != 1 || req.MaxTokens != DefaultMaxTokens {
+			t.Errorf("요청 변환이 올바르지 않습니다: %+v", req)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"content":[{"type":"text","text":"안녕하세요"}],"stop_reason":"end_turn","usage":{"input_tokens":12,"output_tokens":8}}`))
+	}))
+	defer server.Close()
+
+	client := NewAnthropicClient("ant-key", "", WithAnthropicBaseURL(server.URL))
+	resp, err := client.Chat(context.Background(), "시스템", []ChatMessage{
+		{Role: "system", Content: "추가 지침"},
+		{Role: "user", Content: "질문"},
+	})
+	if err != nil {
+		t.Fatalf("예상치 못한 에러: %v", err)
+	}
+	if resp.Content != "안녕하세요" || resp.FinishReason != "stop" || resp.TokensUsed != 20 {
+		t.Errorf("응답 변환이 올바르지 않습니다: %+v", resp)
+	}
+	if client.Model() != DefaultAnthropicModel {
+		t.Errorf("기본 모델이 %q이어야 하지만 %q입니다", DefaultAnthropicModel, client.Model())
+	}
+}
+
+func TestAzureOpenAIClient_Chat_배포URL과_429(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/openai/deployments/health-gpt/chat/completions" || r.URL.Query().Get("api-version") != DefaultAzureAPIVersion {
+			t.Errorf("배포 URL이 올바르지 않습니다: %s", r.URL.String())
+		}
+		if r.Header.Get("api-key") != "az-key" {
+			t.Error("api-key 헤더가 올바르지 않습니다")
+		}
+		w.WriteHeader(http.StatusTooManyRequests)
+		w.Write([]byte(`{"error":{"message":"Rate limit is exceeded"}}`))
+	}))
+	defer server.Close()
+
+	client := NewAzureOpenAIClient(server.URL+"/", "health-gpt", "az-key", "", 0)
+	_, err := client.Chat(context.Background(), "", []ChatMessage{{Role: "user", Content: "질문"}})
+	var pe *ProviderError
+	if !errors.As(err, &pe) || pe.StatusCode != http.StatusTooManyRequests || !pe.Retryable() {
+		t.Fatalf("재시도 가능한 429 ProviderError여야 합니다: %v", err)
+	}
+	var appErr *apperrors.AppError
+	if !errors.As(err, &appErr) || appErr.Code != apperrors.ErrServiceUnavailable {
+		t.Errorf("AppError로 꺼낼 수 있어야 합니다: %v", err)
+	}
+}
+
+// ============================================================================
+// Router
+// ============================================================================
+
+func TestRouter_재시도후_성공(t *testing.T) {
+	primary := &scriptedProvider{name: "openai", failures: []int{503, 429}}
+	r := newTestRouter([]Provider{primary})
+
+	resp, err := ask(t, r, context.Background())
+	if err != nil {
+		t.Fatalf("두 번 재시도 후 성공해야 합니다: %v", err)
+	}
+	if primary.calls != 3 || resp.Provider != "openai" || resp.Model != "openai-model" {
+		t.Errorf("호출 3회, 응답 프로바이더 기록 기대: calls=%d resp=%+v", primary.calls, resp)
+	}
+}
+
+func TestRouter_폴백_순서(t *testing.T) {
+	// 401은 재시도하지 않고 바로 다음 프로바이더로, 5xx는 재시도 소진 후 다음으로
+	unauthorized := &scriptedProvider{name: "openai", failures: []int{401}}
+	overloaded := &scriptedProvider{name: "anthropic", failures: []int{529, 529, 529}}
+	local := &scriptedProvider{name: "local"}
+	r := newTestRouter([]Provider{unauthorized, overloaded, local})
+
+	resp, err := ask(t, r, context.Background())
+	if err != nil {
+		t.Fatalf("로컬 프로바이더로 폴백되어야 합니다: %v", err)
+	}
+	if resp.Provider != "local" || unauthorized.calls != 1 || overloaded.calls != 3 {
+		t.Errorf("폴백 경로 불일치: resp=%s openai=%d anthropic=%d", resp.Provider, unauthorized.calls, overloaded.calls)
+	}
+
+	// 모든 프로바이더 실패 시 마지막 오류를 AppError로 반환
+	r = newTestRouter([]Provider{&scriptedProvider{name: "openai", failures: []int{400}}})
+	_, err = ask(t, r, context.Background())
+	if _, ok := err.(*apperrors.AppError); !ok {
+		t.Errorf("AppError 반환 기대: %T %v", err, err)
+	}
+}
+
+func TestRouter_회로_차단(t *testing.T) {
+	now := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	flaky := &scriptedProvider{name: "openai", failures: []int{500, 500, 500, 500}}
+	backup := &scriptedProvider{name: "azure"}
+	r := newTestRouter([]Provider{flaky, backup},
+		WithRetryPolicy(0, 0, 0), WithCircuitBreaker(2, time.Minute))
+	r.now = func() time.Time { return now }
+
+	for i := 0; i < 3; i++ {
+		if _, err := ask(t, r, context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// 연속 2회 실패 후 차단 → 세 번째 요청은 openai를 건너뜀
+	if flaky.calls != 2 || backup.calls != 3 {
+		t.Errorf("차단 후 호출하지 않아야 함: openai=%d azure=%d", flaky.calls, backup.calls)
+	}
+
+	// 냉각 후 시험 호출 1건 허용 → 실패하면 다시 차단
+	now = now.Add(time.Minute)
+	ask(t, r, context.Background())
+	ask(t, r, context.Background())
+	if flaky.calls != 3 {
+		t.Errorf("냉각 후 시험 호출은 1건이어야 함: %d", flaky.calls)
+	}
+
+	// 다음 냉각 후 시험 호출이 성공하면 회로가 닫힘
+	now = now.Add(time.Minute)
+	flaky.failures = flaky.failures[:3]
+	resp, _ := ask(t, r, context.Background())
+	resp2, _ := ask(t, r, context.Background())
+	if resp.Provider != "openai" || resp2.Provider != "openai" {
+		t.Errorf("회로가 닫혀 openai가 응답해야 함: %s, %s", resp.Provider, resp2.Provider)
+	}
+}
+
+// cancellingProvider는 호출 중 호출자가 연결을 끊은 상황을 흉내 냅니다.
+type cancellingProvider struct {
+	scriptedProvider
+	cancel context.CancelFunc
+}
+
+func (p *cancellingProvider) Chat(ctx context.Context, systemPrompt string, messages []ChatMessage) (*ChatResponse, error) {
+	if p.cancel != nil {
+		p.calls++
+		p.cancel()
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	return p.scriptedProvider.Chat(ctx, systemPrompt, messages)
+}
+
+func TestRouter_시험_호출_취소는_차단기를_잠그지_않음(t *testing.T) {
+	now := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	flaky := &cancellingProvider{scriptedProvider: scriptedProvider{name: "openai", failures: []int{500, 500}}}
+	backup := &scriptedProvider{name: "azure"}
+	r := newTestRouter([]Provider{flaky, backup},
+		WithRetryPolicy(0, 0, 0), WithCircuitBreaker(2, time.Minute))
+	r.now = func() time.Time { return now }
+
+	ask(t, r, context.Background())
+	ask(t, r, context.Background())
+
+	// 냉각 후 시험 호출 중 호출자가 취소
+	now = now.Add(time.Minute)
+	ctx, cancel := context.WithCancel(context.Background())
+	flaky.cancel = cancel
+	if _, err := ask(t, r, ctx); err == nil {
+		t.Fatal("취소된 요청은 오류를 반환해야 함")
+	}
+	if flaky.calls != 3 {
+		t.Fatalf("시험 호출이 openai로 가야 함: %d", flaky.calls)
+	}
+
+	// 시험 호출 자격이 반납되어 다음 요청이 다시 시험 호출을 보내고, 성공하면 회로가 닫힘
+	flaky.cancel = nil
+	resp, err := ask(t, r, context.Background())
+	if err != nil || resp.Provider != "openai" {
+		t.Fatalf("취소 후에도 시험 호출이 가능해야 함: %+v, %v", resp, err)
+	}
+}
+
+func TestRouter_등급별_토큰_예산(t *testing.T) {
+	tiers := TierResolverFunc(func(_ context.Context, userID string) (string, error) {
+		if userID == "pro-user" {
+			return TierPro, nil
+		}
+		return "", errors.New("subscription-service unavailable")
+	})
+	budget := NewBudget(map[string]int{TierFree: 150, TierPro: 1000}, nil, tiers)
+	provider := &scriptedProvider{name: "openai"}
+	r := newTestRouter([]Provider{provider}, WithBudget(budget))
+
+	// 등급 조회 실패 시 free 한도: 100 사용 후 한 번 더 허용(100 < 150), 이후 200 ≥ 150으로 거부
+	free := WithUser(context.Background(), "free-user")
+	for i := 0; i < 2; i++ {
+		if _, err := ask(t, r, free); err != nil {
+			t.Fatalf("한도 내 호출 실패: %v", err)
+		}
+	}
+	_, err := ask(t, r, free)
+	var appErr *apperrors.AppError
+	if !errors.As(err, &appErr) || appErr.Code != apperrors.ErrQuotaExceeded {
+		t.Fatalf("한도 초과 오류 기대: %v", err)
+	}
+	if provider.calls != 2 {
+		t.Errorf("한도 초과 시 프로바이더를 호출하지 않아야 함: %d", provider.calls)
+	}
+
+	// pro 등급은 더 큰 한도, 사용자가 없는 시스템 호출은 예산 미적용
+	if _, err := ask(t, r, WithUser(context.Background(), "pro-user")); err != nil {
+		t.Errorf("pro 등급은 허용되어야 함: %v", err)
+	}
+	if _, err := ask(t, r, context.Background()); err != nil {
+		t.Errorf("시스템 호출은 예산 미적용: %v", err)
+	}
+
+	// 다음 날(UTC)에는 사용량 초기화
+	budget.now = func() time.Time { return time.Now().Add(24 * time.Hour) }
+	if _, err := ask(t, r, free); err != nil {
+		t.Errorf("다음 날에는 허용되어야 함: %v", err)
+	}
+}
+
+func TestRouter_월간_비용_한도와_사용량_기록(t *testing.T) {
+	ledger := NewMemoryUsageLedger()
+	budget := NewBudget(map[string]int{}, nil, nil, WithMonthlySpendCaps(map[string]float64{TierFree: 0.25}, ledger))
+	provider := &scriptedProvider{name: "openai"}
+	// 100 토큰 × 100만 토큰당 $1000 = 호출당 $0.1
+	r := newTestRouter([]Provider{provider}, WithBudget(budget), WithUsageLedger(ledger, Pricing{"openai-model": 1000}))
+
+	ctx := WithFeature(WithUser(context.Background(), "user-1"), FeatureRecommendation)
+	for i := 0; i < 3; i++ {
+		if _, err := ask(t, r, ctx); err != nil {
+			t.Fatalf("한도 내 호출 실패 (%d번째): %v", i+1, err)
+		}
+	}
+	_, err := ask(t, r, ctx)
+	var appErr *apperrors.AppError
+	if !errors.As(err, &appErr) || appErr.Code != apperrors.ErrQuotaExceeded {
+		t.Fatalf("월간 비용 한도 초과 오류 기대: %v", err)
+	}
+
+	rows, _ := ledger.SummarizeUsage(context.Background(), UsageQuery{From: time.Now().Add(-time.Hour), To: time.Now().Add(time.Hour)})
+	if len(rows) != 1 {
+		t.Fatalf("사용자·기능·모델별 1행 기대: %+v", rows)
+	}
+	if got := rows[0]; got.UserID != "user-1" || got.Feature != FeatureRecommendation || got.Provider != "openai" ||
+		got.Calls != 3 || got.Tokens != 300 || got.CostUSD < 0.2999 || got.CostUSD > 0.3001 {
+		t.Errorf("사용량 집계 불일치: %+v", got)
+	}
+
+	// 다음 달(UTC)에는 비용 초기화
+	budget.now = func() time.Time { return time.Now().AddDate(0, 1, 0) }
+	if _, err := ask(t, r, ctx); err != nil {
+		t.Errorf("다음 달에는 허용되어야 함: %v", err)
+	}
+}
+
+func TestPricing_모델_접두사_단가(t *testing.T) {
+	p := ParsePricing("gpt-4o-mini=0.5, custom-local=abc, =3")
+	if got := p.Cost("gpt-4o-mini-2024-07-18", 2_000_000); got != 1.0 {
+		t.Errorf("설정 단가·접두사 매칭 불일치: %v", got)
+	}
+	if got := p.Cost("gpt-4o-2024-08-06", 1_000_000); got != DefaultPricing["gpt-4o"] {
+		t.Errorf("기본 단가 매칭 불일치: %v", got)
+	}
+	if got := p.Cost("llama-3-local", 1_000_000); got != 0 {
+		t.Errorf("단가 없는 모델은 0이어야 함: %v", got)
+	}
+}
+
+// ============================================================================
+// Settings
+// ============================================================================
+
+func TestLoadSettings_SystemConfig_폴백체인(t *testing.T) {
+	db := map[string]string{
+		"llm.providers":               "anthropic, azure ,openai",
+		"llm.anthropic.api_key":       "ant-key",
+		"llm.azure.endpoint":          "https://manpasik.openai.azure.com",
+		"llm.azure.deployment":        "health-gpt",
+		"llm.budget.pro_daily_tokens": "42",
+	}
+	env := map[string]string{
+		"AZURE_OPENAI_API_KEY": "az-key",
+		"LLM_TIMEOUT_SECONDS":  "5",
+	}
+	s := LoadSettings(func(dbKey, envVar string) string {
+		if v := db[dbKey]; v != "" {
+			return v
+		}
+		return env[envVar]
+	})
+
+	if s.CallTimeout != 5*time.Second || s.MaxRetries != DefaultMaxRetries || s.DailyTokenBudgets[TierPro] != 42 ||
+		s.DailyTokenBudgets[TierFree] != DefaultDailyTokenBudgets[TierFree] {
+		t.Errorf("설정 로드 불일치: %+v", s)
+	}
+
+	// openai는 API 키가 없어 건너뜀
+	providers, err := s.BuildProviders()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := NewRouter(providers).String(); got != "anthropic/"+DefaultAnthropicModel+" → azure/health-gpt" {
+		t.Errorf("폴백 체인 불일치: %s", got)
+	}
+
+	s.Providers = []string{"gemini"}
+	if _, err := s.BuildProviders(); err == nil {
+		t.Error("알 수 없는 프로바이더는 오류여야 합니다")
+	}
+	s.Providers = []string{ProviderOpenAI}
+	if _, err := s.BuildProviders(); err == nil {
+		t.Error("자격 증명이 없으면 오류여야 합니다")
+	}
+}
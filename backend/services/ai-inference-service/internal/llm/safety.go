@@ -0,0 +1,276 @@
+package llm
+
+import (
+	"context"
+	"regexp"
+	"strings"
+)
+
+// 응답 안전성 분류
+const (
+	SafetyDiagnosis = "diagnosis" // 질환을 단정하는 진단성 표현
+	SafetyDosage    = "dosage"    // 약물 용량·복용 지시
+	SafetyEmergency = "emergency" // 응급 상황 단서
+)
+
+// 가드레일 조치 (감사 기록의 Actions 값)
+const (
+	GuardActionRedact     = "redact"     // 프롬프트 개인정보 가명 처리·마스킹
+	GuardActionRewrite    = "rewrite"    // 위험 문장을 안전 문구로 대체
+	GuardActionDisclaimer = "disclaimer" // 의료 면책 문구 추가
+	GuardActionEscalate   = "escalate"   // 사용자 입력의 응급 단서를 확인 요청 신호로 전달
+)
+
+// 안전 문구 (한국어 기본값)
+const (
+	diagnosisRewrite = "제공된 정보만으로 질환을 판단할 수 없으며, 정확한 진단은 의료진 상담이 필요합니다."
+	dosageRewrite    = "약의 종류나 복용량 변경은 반드시 담당 의사 또는 약사와 상담하세요."
+	emergencyNotice  = "⚠️ 응급 상황이 의심됩니다. 즉시 119에 연락하거나 가까운 응급실을 방문하세요."
+	medicalDisclaim  = "※ 이 내용은 참고용 건강 정보이며 의학적 진단이나 처방을 대신하지 않습니다."
+)
+
+// safetyText는 응답 언어별 안전 문구입니다.
+type safetyText struct {
+	diagnosis  string
+	dosage     string
+	emergency  string
+	disclaimer string
+}
+
+// safetyTexts는 지원 언어별 안전 문구입니다. 목록에 없는 언어는 한국어 문구를 사용합니다.
+var safetyTexts = map[string]safetyText{
+	"ko": {diagnosisRewrite, dosageRewrite, emergencyNotice, medicalDisclaim},
+	"en": {
+		diagnosis:  "A condition cannot be determined from the information provided; please consult a healthcare professional for an accurate diagnosis.",
+		dosage:     "Always consult your doctor or pharmacist before changing a medication or its dosage.",
+		emergency:  "⚠️ This may be an emergency. Call your local emergency number (119 in Korea) or go to the nearest emergency room immediately.",
+		disclaimer: "※ This is general health information for reference only and is not a substitute for medical diagnosis or treatment.",
+	},
+	"ja": {
+		diagnosis:  "提供された情報だけでは病気を判断できません。正確な診断には医療機関への相談が必要です。",
+		dosage:     "薬の種類や服用量を変更する際は、必ず主治医または薬剤師に相談してください。",
+		emergency:  "⚠️ 緊急事態の可能性があります。すぐに119番に通報するか、最寄りの救急外来を受診してください。",
+		disclaimer: "※ この内容は参考用の健康情報であり、医学的な診断や処方に代わるものではありません。",
+	},
+	"zh": {
+		diagnosis:  "仅凭所提供的信息无法判断疾病，准确诊断需要咨询医生。",
+		dosage:     "更换药物或调整剂量前，请务必咨询主治医生或药剂师。",
+		emergency:  "⚠️ 可能是紧急情况。请立即拨打急救电话（韩国为119）或前往最近的急诊室。",
+		disclaimer: "※ 本内容仅为参考性健康信息，不能替代医学诊断或处方。",
+	},
+}
+
+type languageContextKey struct{}
+
+// WithLanguage는 응답 언어(ko, en, ja, zh)를 컨텍스트에 기록합니다. 가드레일이 덧붙이거나
+// 대체하는 안전 문구를 이 언어로 작성합니다.
+func WithLanguage(ctx context.Context, lang string) context.Context {
+	return context.WithValue(ctx, languageContextKey{}, lang)
+}
+
+// LanguageFromContext는 WithLanguage로 기록된 응답 언어를 반환합니다.
+func LanguageFromContext(ctx context.Context) string {
+	lang, _ := ctx.Value(languageContextKey{}).(string)
+	return lang
+}
+
+var (
+	// 질환을 단정하는 표현 ("당뇨병입니다", "고혈압으로 진단됩니다", "you have diabetes")
+	diagnosisPattern = regexp.MustCompile(`(?i)(?:(?:[가-힣]+(?:병|증|질환|암|염)|고혈압|저혈압|빈혈|비만)(?:입니다|이십니다|이 확실합니다|임이 확실합니다|에 해당합니다|을 앓고 계십니다|를 앓고 계십니다)|(?:으로|로)\s?진단(?:됩니다|되었습니다|합니다)|진단(?:합니다|을 내립니다)|\byou (?:have|are suffering from|are diagnosed with) [a-z]+)`)
+	// 약물 용량·복용 지시 ("500mg을 복용하세요", "하루 2정씩 드세요", "인슐린 용량을 늘리세요")
+	dosagePattern = regexp.MustCompile(`(?i)(?:\d+(?:\.\d+)?\s?(?:mg|㎎|mcg|밀리그램|g|ml|iu|단위|정|알|캡슐)\s?(?:을|를|씩)?\s?(?:복용|투여|드세요|드십시오|섭취하세요|주사)|(?:용량|복용량|투여량)[을를]?\s?(?:늘리|줄이|두 배|2배|조절하세요|변경하세요)|\btake \d+\s?(?:mg|mcg|tablets?|pills?)|\b(?:increase|decrease|double|stop) (?:your )?(?:dose|dosage|medication))`)
+	// 응급 상황 단서 (사용자 메시지·응답 공통). 응답에서 찾으면 안내만 붙이고, 신호는 사용자 입력에서만 보냄
+	emergencyPattern = regexp.MustCompile(`(?i)(?:흉통|가슴이?\s?(?:조이|쥐어짜|찢어지)|호흡\s?곤란|숨(?:을|이)?\s?(?:못\s?쉬|쉬기\s?힘들|쉬기\s?어렵)|의식(?:을|이)?\s?(?:잃|없|저하)|실신|마비|경련|발작|자살|자해|죽고\s?싶|심정지|\bchest pain\b|can'?t breathe|\bsuicid|\bunconscious\b)`)
+	// 이미 면책 또는 상담 권고가 있으면 면책 문구를 추가하지 않음
+	disclaimerPattern = regexp.MustCompile(`참고용|진단을 대신|의료진 상담|전문의 상담|전문가 상담|의사와 상담|(?i:not (?:a )?(?:substitute|medical advice)|consult (?:a|your) (?:doctor|physician|healthcare professional))|参考用|医療機関への相談|医師に相談|仅为参考|咨询医生`)
+)
+
+// SafetyReview는 응답 검토 결과입니다.
+type SafetyReview struct {
+	Content        string   // 정책 적용 후 응답
+	Findings       []string // 발견된 분류 (SafetyDiagnosis, SafetyDosage, SafetyEmergency), 중복 없음
+	Actions        []string // 적용한 조치
+	Emergency      bool     // 입력 또는 응답에 응급 단서가 있어 응급 안내를 붙임
+	InputEmergency bool     // 사용자 입력에 응급 단서가 있음 (확인 요청 신호 대상)
+}
+
+// safetyReviewer는 응답을 문장 단위로 검토합니다. 스트리밍에서도 같은 규칙을 적용하기 위해
+// 문장을 하나씩 받아 정책이 적용된 문장을 돌려주고, 끝에서 덧붙일 안내 문구를 만듭니다.
+// 응답의 응급 단서는 모델이 안전 안내로 언급한 것일 수 있으므로 안내만 붙이고 inputEmergency로 보지 않습니다.
+type safetyReviewer struct {
+	findings       []string
+	actions        []string
+	emergency      bool
+	inputEmergency bool
+	rewritten      map[string]bool // 분류별 안전 문구를 이미 넣었는지
+	disclaims      bool            // 응답에 이미 면책·상담 권고가 있는지
+	text           safetyText      // 응답 언어의 안전 문구
+}
+
+// newSafetyReviewer는 lang 응답용 검토기를 만듭니다. 지원하지 않는 언어는 한국어 문구를 사용합니다.
+func newSafetyReviewer(lang string) *safetyReviewer {
+	text, ok := safetyTexts[lang]
+	if !ok {
+		text = safetyTexts["ko"]
+	}
+	return &safetyReviewer{rewritten: make(map[string]bool), text: text}
+}
+
+// noteInput은 사용자 입력의 응급 단서를 기록합니다.
+func (r *safetyReviewer) noteInput(text string) {
+	if emergencyPattern.MatchString(text) {
+		r.flagEmergency()
+		r.inputEmergency = true
+	}
+}
+
+// sentence는 문장 하나에 정책을 적용합니다. 진단·용량 문장은 분류별 안전 문구로 한 번만 대체하고,
+// 같은 분류의 이후 문장은 제거합니다.
+func (r *safetyReviewer) sentence(s string) string {
+	if strings.TrimSpace(s) == "" {
+		return s
+	}
+	if emergencyPattern.MatchString(s) {
+		r.flagEmergency()
+	}
+	for _, rule := range []struct {
+		category    string
+		pattern     *regexp.Regexp
+		replacement string
+	}{
+		{SafetyDosage, dosagePattern, r.text.dosage},
+		{SafetyDiagnosis, diagnosisPattern, r.text.diagnosis},
+	} {
+		if !rule.pattern.MatchString(s) {
+			continue
+		}
+		r.addFinding(rule.category)
+		r.addAction(GuardActionRewrite)
+		if r.rewritten[rule.category] {
+			return ""
+		}
+		r.rewritten[rule.category] = true
+		r.disclaims = true
+		return rule.replacement + trailingSpace(s)
+	}
+	if disclaimerPattern.MatchString(s) {
+		r.disclaims = true
+	}
+	return s
+}
+
+// suffix는 응답 끝에 덧붙일 응급 안내·면책 문구입니다. 이 서비스의 LLM 응답은 모두 건강 정보이므로
+// 면책·상담 권고가 없으면 항상 면책 문구를 붙입니다.
+func (r *safetyReviewer) suffix() string {
+	var parts []string
+	if r.emergency {
+		parts = append(parts, r.text.emergency)
+	}
+	if !r.disclaims {
+		r.addAction(GuardActionDisclaimer)
+		parts = append(parts, r.text.disclaimer)
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return "\n\n" + strings.Join(parts, "\n")
+}
+
+func (r *safetyReviewer) flagEmergency() {
+	if r.emergency {
+		return
+	}
+	r.emergency = true
+	r.addFinding(SafetyEmergency)
+}
+
+func (r *safetyReviewer) addFinding(f string) {
+	if !containsString(r.findings, f) {
+		r.findings = append(r.findings, f)
+	}
+}
+
+func (r *safetyReviewer) addAction(a string) {
+	if !containsString(r.actions, a) {
+		r.actions = append(r.actions, a)
+	}
+}
+
+// ReviewResponse는 응답 전체에 안전 정책을 적용합니다. input은 응급 단서를 확인할 사용자 입력이고,
+// lang은 안전 문구를 작성할 응답 언어입니다.
+func ReviewResponse(input, content, lang string) SafetyReview {
+	r := newSafetyReviewer(lang)
+	r.noteInput(input)
+	var sb strings.Builder
+	for _, s := range splitSentences(content) {
+		sb.WriteString(r.sentence(s))
+	}
+	sb.WriteString(r.suffix())
+	return SafetyReview{
+		Content:        strings.TrimSpace(sb.String()),
+		Findings:       r.findings,
+		Actions:        r.actions,
+		Emergency:      r.emergency,
+		InputEmergency: r.inputEmergency,
+	}
+}
+
+// splitSentences는 text를 문장 단위로 나눕니다. 각 문장은 종결 부호와 뒤따르는 공백을 포함하므로
+// 이어 붙이면 원문과 같습니다. 소수점(92.5)처럼 공백이 뒤따르지 않는 부호는 경계로 보지 않습니다.
+func splitSentences(text string) []string {
+	var out []string
+	for text != "" {
+		end := sentenceEnd(text, true)
+		out = append(out, text[:end])
+		text = text[end:]
+	}
+	return out
+}
+
+// sentenceEnd는 첫 문장의 끝(뒤따르는 공백 포함) 위치를 반환합니다.
+// 경계가 없으면 final일 때 len(text), 아니면 -1(더 받아야 함)을 반환합니다.
+func sentenceEnd(text string, final bool) int {
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+		boundary := c == '\n'
+		if !boundary && (c == '.' || c == '!' || c == '?') {
+			if i+1 == len(text) {
+				if !final {
+					return -1 // 다음 조각이 숫자일 수 있음
+				}
+				boundary = true
+			} else {
+				next := text[i+1]
+				boundary = next == ' ' || next == '\n' || next == '\t'
+			}
+		}
+		if !boundary {
+			continue
+		}
+		j := i + 1
+		for j < len(text) && (text[j] == ' ' || text[j] == '\n' || text[j] == '\t') {
+			j++
+		}
+		if j == len(text) && !final {
+			return -1 // 공백이 더 이어질 수 있음
+		}
+		return j
+	}
+	if final {
+		return len(text)
+	}
+	return -1
+}
+
+func trailingSpace(s string) string {
+	return s[len(strings.TrimRight(s, " \n\t")):]
+}
+
+func containsString(list []string, v string) bool {
+	for _, s := range list {
+		if s == v {
+			return true
+		}
+	}
+	return false
+}
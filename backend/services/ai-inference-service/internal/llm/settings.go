@@ -0,0 +1,184 @@
+package llm
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	apperrors "github.com/manpasik/backend/shared/errors"
+)
+
+// ConfigLookup은 SystemConfig 키를 먼저, 없으면 환경변수를 조회합니다.
+// 운영에서는 config.LoadConfigWithFallback(pool, dbKey, envVar)를 감싸서 전달합니다.
+type ConfigLookup func(dbKey, envVar string) string
+
+// Settings는 Router 구성입니다. 관리자 SystemConfig(llm.*)와 환경변수에서 로드합니다.
+type Settings struct {
+	Providers []string // 폴백 순서
+
+	OpenAIAPIKey  string
+	OpenAIModel   string
+	OpenAIBaseURL string
+
+	AnthropicAPIKey string
+	AnthropicModel  string
+
+	AzureEndpoint   string
+	AzureAPIKey     string
+	AzureDeployment string
+	AzureAPIVersion string
+
+	LocalBaseURL string
+	LocalModel   string
+	LocalAPIKey  string
+
+	MaxTokens         int
+	MaxRetries        int
+	CallTimeout       time.Duration
+	DailyTokenBudgets map[string]int
+	MonthlySpendCaps  map[string]float64 // 등급별 월간 예상 비용 한도 (USD)
+	Pricing           Pricing
+
+	CacheTTL               time.Duration // 분석 응답 캐시 유효 시간
+	SemanticCacheThreshold float64       // 의미 유사 캐시 임계값 (0이면 정확 일치만)
+
+	PseudonymSecret string // 가드레일 사용자 가명 HMAC 키
+
+	EmbeddingModel     string // AI 채팅 검색 증강(RAG)용 임베딩 모델
+	EmbeddingDimension int
+}
+
+// LoadSettings는 SystemConfig 키(환경변수 폴백)에서 Router 구성을 읽습니다.
+//
+// 폴백 순서는 llm.providers(LLM_PROVIDERS, 쉼표 구분)이며, 없으면 llm.provider(LLM_PROVIDER) 하나,
+// 그것도 없으면 openai를 사용합니다.
+func LoadSettings(lookup ConfigLookup) Settings {
+	s := Settings{
+		OpenAIAPIKey:  lookup("llm.api_key", "LLM_API_KEY"),
+		OpenAIModel:   lookup("llm.model", "LLM_MODEL"),
+		OpenAIBaseURL: lookup("llm.base_url", "LLM_BASE_URL"),
+
+		AnthropicAPIKey: lookup("llm.anthropic.api_key", "ANTHROPIC_API_KEY"),
+		AnthropicModel:  lookup("llm.anthropic.model", "ANTHROPIC_MODEL"),
+
+		AzureEndpoint:   lookup("llm.azure.endpoint", "AZURE_OPENAI_ENDPOINT"),
+		AzureAPIKey:     lookup("llm.azure.api_key", "AZURE_OPENAI_API_KEY"),
+		AzureDeployment: lookup("llm.azure.deployment", "AZURE_OPENAI_DEPLOYMENT"),
+		AzureAPIVersion: lookup("llm.azure.api_version", "AZURE_OPENAI_API_VERSION"),
+
+		LocalBaseURL: lookup("llm.local.base_url", "LOCAL_LLM_BASE_URL"),
+		LocalModel:   lookup("llm.local.model", "LOCAL_LLM_MODEL"),
+		LocalAPIKey:  lookup("llm.local.api_key", "LOCAL_LLM_API_KEY"),
+
+		MaxTokens:   atoiOr(lookup("llm.max_tokens", "LLM_MAX_TOKENS"), 0),
+		MaxRetries:  atoiOr(lookup("llm.max_retries", "LLM_MAX_RETRIES"), DefaultMaxRetries),
+		CallTimeout: time.Duration(atoiOr(lookup("llm.timeout_seconds", "LLM_TIMEOUT_SECONDS"), int(DefaultCallTimeout/time.Second))) * time.Second,
+
+		PseudonymSecret: lookup("llm.guardrail.pseudonym_secret", "LLM_PSEUDONYM_SECRET"),
+
+		EmbeddingModel:     lookup("llm.embedding.model", "LLM_EMBEDDING_MODEL"),
+		EmbeddingDimension: atoiOr(lookup("llm.embedding.dimension", "LLM_EMBEDDING_DIMENSION"), DefaultEmbeddingDimension),
+
+		Pricing:                ParsePricing(lookup("llm.pricing", "LLM_PRICING")),
+		CacheTTL:               time.Duration(atoiOr(lookup("llm.cache.ttl_seconds", "LLM_CACHE_TTL_SECONDS"), int(DefaultCacheTTL/time.Second))) * time.Second,
+		SemanticCacheThreshold: atofOr(lookup("llm.cache.semantic_threshold", "LLM_CACHE_SEMANTIC_THRESHOLD"), 0),
+	}
+
+	chain := lookup("llm.providers", "LLM_PROVIDERS")
+	if chain == "" {
+		chain = lookup("llm.provider", "LLM_PROVIDER")
+	}
+	if chain == "" {
+		chain = ProviderOpenAI
+	}
+	for _, name := range strings.Split(chain, ",") {
+		if name = strings.ToLower(strings.TrimSpace(name)); name != "" {
+			s.Providers = append(s.Providers, name)
+		}
+	}
+
+	s.DailyTokenBudgets = make(map[string]int, len(DefaultDailyTokenBudgets))
+	for tier, def := range DefaultDailyTokenBudgets {
+		key := fmt.Sprintf("llm.budget.%s_daily_tokens", tier)
+		env := fmt.Sprintf("LLM_BUDGET_%s_DAILY_TOKENS", strings.ToUpper(tier))
+		s.DailyTokenBudgets[tier] = atoiOr(lookup(key, env), def)
+	}
+	s.MonthlySpendCaps = make(map[string]float64, len(DefaultMonthlySpendCaps))
+	for tier, def := range DefaultMonthlySpendCaps {
+		key := fmt.Sprintf("llm.budget.%s_monthly_usd", tier)
+		env := fmt.Sprintf("LLM_BUDGET_%s_MONTHLY_USD", strings.ToUpper(tier))
+		s.MonthlySpendCaps[tier] = atofOr(lookup(key, env), def)
+	}
+	return s
+}
+
+// BuildProviders는 폴백 순서대로 프로바이더를 생성합니다.
+// 자격 증명이 없는 프로바이더는 건너뛰며, 알 수 없는 이름이거나 남는 프로바이더가 없으면 오류입니다.
+func (s Settings) BuildProviders() ([]Provider, error) {
+	var providers []Provider
+	for _, name := range s.Providers {
+		switch name {
+		case ProviderOpenAI:
+			if s.OpenAIAPIKey != "" {
+				providers = append(providers, NewOpenAIClient(s.OpenAIAPIKey, s.OpenAIModel,
+					WithBaseURL(s.OpenAIBaseURL), WithMaxTokens(s.MaxTokens)))
+			}
+		case ProviderAnthropic:
+			if s.AnthropicAPIKey != "" {
+				providers = append(providers, NewAnthropicClient(s.AnthropicAPIKey, s.AnthropicModel,
+					WithAnthropicMaxTokens(s.MaxTokens)))
+			}
+		case ProviderAzure:
+			if s.AzureEndpoint != "" && s.AzureAPIKey != "" && s.AzureDeployment != "" {
+				providers = append(providers, NewAzureOpenAIClient(s.AzureEndpoint, s.AzureDeployment,
+					s.AzureAPIKey, s.AzureAPIVersion, s.MaxTokens))
+			}
+		case ProviderLocal:
+			if s.LocalBaseURL != "" {
+				providers = append(providers, NewLocalClient(s.LocalBaseURL, s.LocalModel, s.LocalAPIKey,
+					WithMaxTokens(s.MaxTokens)))
+			}
+		default:
+			return nil, apperrors.New(apperrors.ErrInvalidInput, "알 수 없는 LLM 프로바이더입니다").WithDetails(name)
+		}
+	}
+	if len(providers) == 0 {
+		return nil, apperrors.New(apperrors.ErrInvalidInput, "자격 증명이 설정된 LLM 프로바이더가 없습니다").
+			WithDetails(strings.Join(s.Providers, ","))
+	}
+	return providers, nil
+}
+
+// BuildEmbedder는 OpenAI API 키가 있으면 OpenAI 임베딩을, 없으면 외부 호출이 없는 해싱 임베딩을 생성합니다.
+// OpenAI 임베딩에 보내는 텍스트는 redactor로 개인정보를 치환합니다.
+func (s Settings) BuildEmbedder(redactor *Redactor) Embedder {
+	if s.OpenAIAPIKey != "" {
+		return NewOpenAIEmbedder(s.OpenAIAPIKey, s.EmbeddingModel, s.OpenAIBaseURL, s.EmbeddingDimension, redactor)
+	}
+	return NewHashEmbedder(s.EmbeddingDimension)
+}
+
+// RouterOptions는 재시도·타임아웃 설정을 RouterOption으로 변환합니다.
+func (s Settings) RouterOptions() []RouterOption {
+	return []RouterOption{
+		WithRetryPolicy(s.MaxRetries, 0, 0),
+		WithCallTimeout(s.CallTimeout),
+	}
+}
+
+func atoiOr(v string, def int) int {
+	n, err := strconv.Atoi(strings.TrimSpace(v))
+	if err != nil {
+		return def
+	}
+	return n
+}
+
+func atofOr(v string, def float64) float64 {
+	f, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+	if err != nil {
+		return def
+	}
+	return f
+}
@@ -0,0 +1,223 @@
+package llm
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	apperrors "github.com/manpasik/backend/shared/errors"
+)
+
+// StreamingClient는 생성 중인 텍스트를 조각 단위로 전달하는 LLM 클라이언트입니다.
+type StreamingClient interface {
+	LLMClient
+	// ChatStream은 Chat과 같지만 생성되는 텍스트 조각을 도착 순서대로 onDelta에 전달하고,
+	// 완료되면 전체 응답을 반환합니다. onDelta가 오류를 반환하면 스트림을 중단하고 그 오류를 반환합니다.
+	ChatStream(ctx context.Context, systemPrompt string, messages []ChatMessage, onDelta func(string) error) (*ChatResponse, error)
+}
+
+// ChatStream은 OpenAI Chat Completion 스트리밍(SSE)을 호출합니다.
+func (c *OpenAIClient) ChatStream(ctx context.Context, systemPrompt string, messages []ChatMessage, onDelta func(string) error) (*ChatResponse, error) {
+	if c.apiKey == "" && c.provider != ProviderLocal {
+		return nil, apperrors.New(apperrors.ErrInvalidInput, "LLM API 키가 설정되지 않았습니다")
+	}
+	headers := map[string]string{}
+	if c.apiKey != "" {
+		headers["Authorization"] = fmt.Sprintf("Bearer %s", c.apiKey)
+	}
+	reqBody := openaiChatRequest{
+		Model:     c.model,
+		Messages:  openaiMessages(systemPrompt, messages),
+		MaxTokens: c.maxTokens,
+	}
+	return streamOpenAI(ctx, c.httpClient, c.provider, fmt.Sprintf("%s/chat/completions", c.baseURL), headers, reqBody, onDelta)
+}
+
+// ChatStream은 Azure OpenAI Chat Completion 스트리밍(SSE)을 호출합니다.
+func (c *AzureOpenAIClient) ChatStream(ctx context.Context, systemPrompt string, messages []ChatMessage, onDelta func(string) error) (*ChatResponse, error) {
+	if c.apiKey == "" || c.endpoint == "" || c.deployment == "" {
+		return nil, apperrors.New(apperrors.ErrInvalidInput, "Azure OpenAI 엔드포인트·배포·API 키가 설정되지 않았습니다")
+	}
+	reqBody := openaiChatRequest{
+		Messages:  openaiMessages(systemPrompt, messages),
+		MaxTokens: c.maxTokens,
+	}
+	endpoint := fmt.Sprintf("%s/openai/deployments/%s/chat/completions?api-version=%s",
+		c.endpoint, url.PathEscape(c.deployment), url.QueryEscape(c.apiVersion))
+	return streamOpenAI(ctx, c.httpClient, ProviderAzure, endpoint, map[string]string{"api-key": c.apiKey}, reqBody, onDelta)
+}
+
+// streamOpenAI는 OpenAI 형식 스트림의 delta.content를 전달하고, 마지막 usage 청크로 토큰 수를 채웁니다.
+func streamOpenAI(ctx context.Context, hc *http.Client, provider, endpoint string, headers map[string]string, reqBody openaiChatRequest, onDelta func(string) error) (*ChatResponse, error) {
+	reqBody.Stream = true
+	reqBody.StreamOptions = &openaiStreamOptions{IncludeUsage: true}
+	resp, err := post(ctx, hc, provider, endpoint, headers, reqBody)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	out := &ChatResponse{}
+	var content strings.Builder
+	err = readSSE(provider, resp.Body, func(data []byte) error {
+		var chunk openaiStreamChunk
+		if err := json.Unmarshal(data, &chunk); err != nil {
+			return apperrors.New(apperrors.ErrInternal, "LLM 스트림 파싱에 실패했습니다").
+				WithDetails(fmt.Sprintf("json unmarshal: %v", err))
+		}
+		if chunk.Usage != nil {
+			out.TokensUsed = chunk.Usage.TotalTokens
+		}
+		for _, choice := range chunk.Choices {
+			if choice.FinishReason != "" {
+				out.FinishReason = choice.FinishReason
+			}
+			if choice.Delta.Content == "" {
+				continue
+			}
+			content.WriteString(choice.Delta.Content)
+			if err := onDelta(choice.Delta.Content); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	out.Content = content.String()
+	return out, nil
+}
+
+// ChatStream은 Anthropic Messages 스트리밍(SSE)을 호출합니다.
+func (c *AnthropicClient) ChatStream(ctx context.Context, systemPrompt string, messages []ChatMessage, onDelta func(string) error) (*ChatResponse, error) {
+	if c.apiKey == "" {
+		return nil, apperrors.New(apperrors.ErrInvalidInput, "LLM API 키가 설정되지 않았습니다")
+	}
+	reqBody := c.buildRequest(systemPrompt, messages)
+	reqBody.Stream = true
+	resp, err := post(ctx, c.httpClient, ProviderAnthropic, fmt.Sprintf("%s/messages", c.baseURL), c.headers(), reqBody)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	out := &ChatResponse{}
+	var content strings.Builder
+	err = readSSE(ProviderAnthropic, resp.Body, func(data []byte) error {
+		var ev anthropicStreamEvent
+		if err := json.Unmarshal(data, &ev); err != nil {
+			return apperrors.New(apperrors.ErrInternal, "LLM 스트림 파싱에 실패했습니다").
+				WithDetails(fmt.Sprintf("json unmarshal: %v", err))
+		}
+		switch ev.Type {
+		case "message_start":
+			out.TokensUsed += ev.Message.Usage.InputTokens
+		case "content_block_delta":
+			if ev.Delta.Type != "text_delta" || ev.Delta.Text == "" {
+				return nil
+			}
+			content.WriteString(ev.Delta.Text)
+			return onDelta(ev.Delta.Text)
+		case "message_delta":
+			out.TokensUsed += ev.Usage.OutputTokens
+			out.FinishReason = anthropicFinishReason(ev.Delta.StopReason)
+		case "error":
+			// 스트림 중간 오류 (overloaded_error 등)는 재시도 가능한 프로바이더 오류로 취급
+			return &ProviderError{
+				AppError: apperrors.New(apperrors.ErrServiceUnavailable, "LLM 스트림 중 오류가 발생했습니다").
+					WithDetails(ev.Error.Message),
+				Provider: ProviderAnthropic,
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	out.Content = content.String()
+	return out, nil
+}
+
+// readSSE는 Server-Sent Events 스트림의 data 필드를 onData에 전달합니다.
+// OpenAI 형식의 종료 표시 "[DONE]"에서 멈추며, 수신 중단은 재시도 가능한 ProviderError로 반환합니다.
+func readSSE(provider string, body io.Reader, onData func([]byte) error) error {
+	sc := bufio.NewScanner(body)
+	sc.Buffer(make([]byte, 64*1024), 1<<20)
+	for sc.Scan() {
+		line := sc.Text()
+		if !strings.HasPrefix(line, "data:") {
+			continue
+		}
+		data := strings.TrimSpace(strings.TrimPrefix(line, "data:"))
+		if data == "[DONE]" {
+			return nil
+		}
+		if data == "" {
+			continue
+		}
+		if err := onData([]byte(data)); err != nil {
+			return err
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return &ProviderError{
+			AppError: apperrors.New(apperrors.ErrServiceUnavailable, "LLM 스트림 수신이 중단되었습니다").
+				WithDetails(fmt.Sprintf("read stream: %v", err)),
+			Provider: provider,
+		}
+	}
+	return nil
+}
+
+// ============================================================================
+// 스트리밍 요청/응답 구조체 (내부용)
+// ============================================================================
+
+type openaiStreamOptions struct {
+	IncludeUsage bool `json:"include_usage"`
+}
+
+type openaiStreamChunk struct {
+	Choices []struct {
+		Delta struct {
+			Content string `json:"content"`
+		} `json:"delta"`
+		FinishReason string `json:"finish_reason"`
+	} `json:"choices"`
+	Usage *struct {
+		TotalTokens int `json:"total_tokens"`
+	} `json:"usage"`
+}
+
+type anthropicStreamEvent struct {
+	Type    string `json:"type"`
+	Message struct {
+		Usage struct {
+			InputTokens int `json:"input_tokens"`
+		} `json:"usage"`
+	} `json:"message"`
+	Delta struct {
+		Type       string `json:"type"`
+		Text       string `json:"text"`
+		StopReason string `json:"stop_reason"`
+	} `json:"delta"`
+	Usage struct {
+		OutputTokens int `json:"output_tokens"`
+	} `json:"usage"`
+	Error struct {
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+var (
+	_ StreamingClient = (*OpenAIClient)(nil)
+	_ StreamingClient = (*AzureOpenAIClient)(nil)
+	_ StreamingClient = (*AnthropicClient)(nil)
+	_ StreamingClient = (*Router)(nil)
+)
@@ -0,0 +1,137 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	apperrors "github.com/manpasik/backend/shared/errors"
+)
+
+// streamingProvider는 정해진 조각을 전달한 뒤 선택적으로 실패하는 스트리밍 프로바이더입니다.
+type streamingProvider struct {
+	scriptedProvider
+	chunks []string
+	failAt int // 0보다 크면 failAt개 조각 전달 후 재시도 가능 오류 반환
+}
+
+func (p *streamingProvider) ChatStream(_ context.Context, _ string, _ []ChatMessage, onDelta func(string) error) (*ChatResponse, error) {
+	p.calls++
+	for i, c := range p.chunks {
+		if p.failAt > 0 && i == p.failAt {
+			return nil, &ProviderError{
+				AppError: apperrors.New(apperrors.ErrServiceUnavailable, "stream cut"),
+				Provider: p.name,
+			}
+		}
+		if err := onDelta(c); err != nil {
+			return nil, err
+		}
+	}
+	return &ChatResponse{Content: strings.Join(p.chunks, ""), TokensUsed: 10}, nil
+}
+
+func TestOpenAIClient_ChatStream_조각과_사용량(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req openaiChatRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("요청 바디 파싱 실패: %v", err)
+		}
+		if !req.Stream || req.StreamOptions == nil || !req.StreamOptions.IncludeUsage {
+			t.Errorf("스트리밍 요청 옵션이 설정되지 않았습니다: %+v", req)
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Write([]byte("data: {\"choices\":[{\"delta\":{\"content\":\"안녕\"}}]}\n\n" +
+			"data: {\"choices\":[{\"delta\":{\"content\":\"하세요\"},\"finish_reason\":\"stop\"}]}\n\n" +
+			"data: {\"choices\":[],\"usage\":{\"total_tokens\":17}}\n\n" +
+			"data: [DONE]\n\n"))
+	}))
+	defer server.Close()
+
+	client := NewOpenAIClient("sk-test", "gpt-4o", WithBaseURL(server.URL))
+	var deltas []string
+	resp, err := client.ChatStream(context.Background(), "시스템", []ChatMessage{{Role: "user", Content: "질문"}}, func(d string) error {
+		deltas = append(deltas, d)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("예상치 못한 오류: %v", err)
+	}
+	if len(deltas) != 2 || resp.Content != "안녕하세요" || resp.TokensUsed != 17 || resp.FinishReason != "stop" {
+		t.Errorf("스트림 결과가 올바르지 않습니다: deltas=%v resp=%+v", deltas, resp)
+	}
+}
+
+func TestAnthropicClient_ChatStream_이벤트_처리(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Write([]byte("event: message_start\ndata: {\"type\":\"message_start\",\"message\":{\"usage\":{\"input_tokens\":12}}}\n\n" +
+			"event: content_block_delta\ndata: {\"type\":\"content_block_delta\",\"delta\":{\"type\":\"text_delta\",\"text\":\"반갑\"}}\n\n" +
+			"event: content_block_delta\ndata: {\"type\":\"content_block_delta\",\"delta\":{\"type\":\"text_delta\",\"text\":\"습니다\"}}\n\n" +
+			"event: message_delta\ndata: {\"type\":\"message_delta\",\"delta\":{\"stop_reason\":\"end_turn\"},\"usage\":{\"output_tokens\":5}}\n\n" +
+			"event: message_stop\ndata: {\"type\":\"message_stop\"}\n\n"))
+	}))
+	defer server.Close()
+
+	client := NewAnthropicClient("ant-key", "", WithAnthropicBaseURL(server.URL))
+	resp, err := client.ChatStream(context.Background(), "시스템", []ChatMessage{{Role: "user", Content: "질문"}}, func(string) error { return nil })
+	if err != nil {
+		t.Fatalf("예상치 못한 오류: %v", err)
+	}
+	if resp.Content != "반갑습니다" || resp.TokensUsed != 17 || resp.FinishReason != "stop" {
+		t.Errorf("스트림 결과가 올바르지 않습니다: %+v", resp)
+	}
+}
+
+func TestRouter_ChatStream_첫조각_전_실패는_폴백(t *testing.T) {
+	// 첫 번째는 비스트리밍 프로바이더로, 첫 호출에서 재시도 불가(401)로 실패
+	primary := &scriptedProvider{name: "openai", failures: []int{401}}
+	secondary := &streamingProvider{scriptedProvider: scriptedProvider{name: "anthropic"}, chunks: []string{"폴백 ", "응답"}}
+	r := newTestRouter([]Provider{primary, secondary})
+
+	var deltas []string
+	resp, err := r.ChatStream(context.Background(), "시스템", []ChatMessage{{Role: "user", Content: "질문"}}, func(d string) error {
+		deltas = append(deltas, d)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("예상치 못한 오류: %v", err)
+	}
+	if resp.Provider != "anthropic" || strings.Join(deltas, "") != "폴백 응답" {
+		t.Errorf("폴백 결과가 올바르지 않습니다: deltas=%v resp=%+v", deltas, resp)
+	}
+	// 비스트리밍 프로바이더 성공 시에는 전체 응답이 한 조각으로 전달됨
+	deltas = nil
+	if _, err := r.ChatStream(context.Background(), "시스템", nil, func(d string) error {
+		deltas = append(deltas, d)
+		return nil
+	}); err != nil || len(deltas) != 1 || deltas[0] != "ok from openai" {
+		t.Errorf("비스트리밍 프로바이더는 한 조각이어야 합니다: deltas=%v err=%v", deltas, err)
+	}
+}
+
+func TestRouter_ChatStream_조각_전달후_실패는_폴백하지_않음(t *testing.T) {
+	primary := &streamingProvider{scriptedProvider: scriptedProvider{name: "openai"}, chunks: []string{"부분", "나머지"}, failAt: 1}
+	secondary := &streamingProvider{scriptedProvider: scriptedProvider{name: "anthropic"}, chunks: []string{"중복"}}
+	r := newTestRouter([]Provider{primary, secondary})
+
+	var deltas []string
+	_, err := r.ChatStream(context.Background(), "시스템", nil, func(d string) error {
+		deltas = append(deltas, d)
+		return nil
+	})
+	var appErr *apperrors.AppError
+	if !errors.As(err, &appErr) || appErr.Code != apperrors.ErrServiceUnavailable {
+		t.Fatalf("스트림 중단 오류가 반환되어야 합니다: %v", err)
+	}
+	if primary.calls != 1 || secondary.calls != 0 {
+		t.Errorf("조각 전달 후에는 재시도·폴백하지 않아야 합니다: primary=%d secondary=%d", primary.calls, secondary.calls)
+	}
+	if strings.Join(deltas, "") != "부분" {
+		t.Errorf("이미 전달된 조각만 있어야 합니다: %v", deltas)
+	}
+}
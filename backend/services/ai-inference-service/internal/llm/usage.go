@@ -0,0 +1,192 @@
+package llm
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// LLM 호출 기능 (사용량·비용 집계 단위)
+const (
+	FeatureHealthInsight   = "health_insight"   // GenerateHealthInsight
+	FeatureAnalysisSummary = "analysis_summary" // 측정 분석 요약 보강
+	FeatureRecommendation  = "recommendation"   // 건강 점수 추천
+	FeatureChat            = "chat"             // AI 채팅
+	FeatureCoaching        = "coaching"         // 코칭 메시지 개인화 (ComposeCoachingMessage)
+)
+
+type featureContextKey struct{}
+
+// WithFeature는 LLM 호출을 요청한 기능을 컨텍스트에 기록합니다.
+// 사용량 집계와 응답 캐시 대상 판단에 사용합니다.
+func WithFeature(ctx context.Context, feature string) context.Context {
+	return context.WithValue(ctx, featureContextKey{}, feature)
+}
+
+// FeatureFromContext는 WithFeature로 기록된 기능 이름을 반환합니다.
+func FeatureFromContext(ctx context.Context) string {
+	feature, _ := ctx.Value(featureContextKey{}).(string)
+	return feature
+}
+
+// Pricing은 모델별 100만 토큰당 예상 비용(USD)입니다.
+// 프로바이더 응답의 토큰 수는 입력·출력 합계이므로 혼합 단가를 사용합니다.
+type Pricing map[string]float64
+
+// DefaultPricing은 주요 모델의 혼합 단가 기본값입니다. 목록에 없는 모델(로컬 등)은 0으로 집계합니다.
+var DefaultPricing = Pricing{
+	"gpt-4o":            5.0,
+	"gpt-4o-mini":       0.3,
+	"gpt-4.1":           4.0,
+	"gpt-4.1-mini":      0.8,
+	"claude-3-5-sonnet": 6.0,
+	"claude-3-5-haiku":  1.6,
+	"claude-3-haiku":    0.5,
+}
+
+// Cost는 tokens의 예상 비용(USD)입니다. 모델 이름이 정확히 없으면 가장 긴 접두사 항목을 사용합니다
+// (예: gpt-4o-mini-2024-07-18 → gpt-4o-mini).
+func (p Pricing) Cost(model string, tokens int) float64 {
+	if tokens <= 0 {
+		return 0
+	}
+	model = strings.ToLower(model)
+	price, ok := p[model]
+	if !ok {
+		matched := 0
+		for name, v := range p {
+			if len(name) > matched && strings.HasPrefix(model, name) {
+				price, matched = v, len(name)
+			}
+		}
+	}
+	return price * float64(tokens) / 1_000_000
+}
+
+// ParsePricing은 "모델=단가,모델=단가" 형식의 설정을 기본 단가에 덮어씁니다.
+// 형식이 잘못된 항목은 무시합니다.
+func ParsePricing(v string) Pricing {
+	p := make(Pricing, len(DefaultPricing))
+	for name, price := range DefaultPricing {
+		p[name] = price
+	}
+	for _, item := range strings.Split(v, ",") {
+		name, value, ok := strings.Cut(item, "=")
+		if !ok {
+			continue
+		}
+		price, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		name = strings.ToLower(strings.TrimSpace(name))
+		if err != nil || price < 0 || name == "" {
+			continue
+		}
+		p[name] = price
+	}
+	return p
+}
+
+// UsageRecord는 LLM 호출 1건의 사용량 기록입니다. 캐시 적중은 토큰·비용 0으로 기록합니다.
+type UsageRecord struct {
+	UserID    string // 시스템 호출이면 빈 값
+	Feature   string // FeatureHealthInsight 등, 지정하지 않은 호출은 빈 값
+	Provider  string
+	Model     string
+	Tokens    int
+	CostUSD   float64
+	CacheHit  bool
+	CreatedAt time.Time
+}
+
+// UsageQuery는 사용량 보고서 조회 조건입니다. [From, To) 구간이며 UserID가 비어 있으면 전체 사용자입니다.
+type UsageQuery struct {
+	From   time.Time
+	To     time.Time
+	UserID string
+}
+
+// UsageSummary는 사용자·기능·프로바이더·모델별 사용량 합계입니다.
+type UsageSummary struct {
+	UserID    string
+	Feature   string
+	Provider  string
+	Model     string
+	Calls     int // 캐시 적중 포함
+	CacheHits int
+	Tokens    int
+	CostUSD   float64
+}
+
+// UsageLedger는 LLM 사용량·비용 원장입니다.
+type UsageLedger interface {
+	RecordUsage(ctx context.Context, u *UsageRecord) error
+	// SpendSince는 since 이후 사용자의 누적 예상 비용(USD)입니다.
+	SpendSince(ctx context.Context, userID string, since time.Time) (float64, error)
+	// SummarizeUsage는 조회 구간의 사용량을 사용자·기능·프로바이더·모델별로 집계합니다.
+	// 결과는 비용 내림차순입니다.
+	SummarizeUsage(ctx context.Context, q UsageQuery) ([]UsageSummary, error)
+}
+
+// MemoryUsageLedger는 단일 인스턴스용 인메모리 사용량 원장입니다.
+type MemoryUsageLedger struct {
+	mu      sync.Mutex
+	records []UsageRecord
+}
+
+// NewMemoryUsageLedger는 인메모리 사용량 원장을 생성합니다.
+func NewMemoryUsageLedger() *MemoryUsageLedger {
+	return &MemoryUsageLedger{}
+}
+
+func (l *MemoryUsageLedger) RecordUsage(_ context.Context, u *UsageRecord) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.records = append(l.records, *u)
+	return nil
+}
+
+func (l *MemoryUsageLedger) SpendSince(_ context.Context, userID string, since time.Time) (float64, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	var total float64
+	for _, r := range l.records {
+		if r.UserID == userID && !r.CreatedAt.Before(since) {
+			total += r.CostUSD
+		}
+	}
+	return total, nil
+}
+
+func (l *MemoryUsageLedger) SummarizeUsage(_ context.Context, q UsageQuery) ([]UsageSummary, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	type groupKey struct{ user, feature, provider, model string }
+	groups := map[groupKey]*UsageSummary{}
+	var order []groupKey
+	for _, r := range l.records {
+		if r.CreatedAt.Before(q.From) || !r.CreatedAt.Before(q.To) || (q.UserID != "" && r.UserID != q.UserID) {
+			continue
+		}
+		k := groupKey{r.UserID, r.Feature, r.Provider, r.Model}
+		s, ok := groups[k]
+		if !ok {
+			s = &UsageSummary{UserID: r.UserID, Feature: r.Feature, Provider: r.Provider, Model: r.Model}
+			groups[k] = s
+			order = append(order, k)
+		}
+		s.Calls++
+		if r.CacheHit {
+			s.CacheHits++
+		}
+		s.Tokens += r.Tokens
+		s.CostUSD += r.CostUSD
+	}
+	out := make([]UsageSummary, 0, len(order))
+	for _, k := range order {
+		out = append(out, *groups[k])
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].CostUSD > out[j].CostUSD })
+	return out, nil
+}
@@ -0,0 +1,98 @@
+// Package kafka는 Kafka 기반 AI 이벤트 발행기와 측정 이벤트 소비 핸들러를 제공합니다.
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/manpasik/backend/services/ai-inference-service/internal/llm"
+	"github.com/manpasik/backend/services/ai-inference-service/internal/service"
+	"github.com/manpasik/backend/shared/events"
+)
+
+// EventPublisher는 Kafka를 사용하는 이벤트 발행기입니다.
+type EventPublisher struct {
+	eventBus *events.KafkaEventBus
+}
+
+// NewEventPublisher는 Kafka 기반 EventPublisher를 생성합니다.
+func NewEventPublisher(eventBus *events.KafkaEventBus) *EventPublisher {
+	return &EventPublisher{eventBus: eventBus}
+}
+
+// PublishAnomalyDetected는 개인 기준선 이상 이벤트를 Kafka에 발행합니다.
+// notification-service는 severity가 critical이면 에스컬레이션을 시작합니다.
+func (p *EventPublisher) PublishAnomalyDetected(ctx context.Context, a *service.BaselineAnomaly) error {
+	payload := map[string]interface{}{
+		"measurement_id":  a.MeasurementID,
+		"biomarker":       a.Biomarker,
+		"kind":            a.Kind,
+		"severity":        a.Severity,
+		"value":           a.Value,
+		"unit":            a.Unit,
+		"baseline_median": a.Median,
+		"baseline_mad":    a.MAD,
+		"ewma":            a.EWMA,
+		"z_score":         a.ZScore,
+		"description":     a.Description,
+		"measured_at":     a.MeasuredAt.Format(time.RFC3339),
+	}
+
+	payloadBytes, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("이벤트 페이로드 직렬화 실패: %w", err)
+	}
+
+	kafkaEvent := events.Event{
+		Type: events.EventAIAnomalyDetected,
+		Payload: map[string]interface{}{
+			"event_id":   uuid.New().String(),
+			"event_type": "manpasik." + events.EventAIAnomalyDetected,
+			"version":    "1.0",
+			"timestamp":  time.Now().UTC().Format(time.RFC3339),
+			"source":     "ai-inference-service",
+			"user_id":    a.UserID,
+			"payload":    json.RawMessage(payloadBytes),
+		},
+	}
+
+	return p.eventBus.Publish(ctx, kafkaEvent)
+}
+
+// EscalateSafety는 AI 채팅 입력에서 발견된 응급 단서를 사용자 확인 요청(severity warning)으로
+// health_alert.triggered 이벤트에 발행합니다 (llm.SafetyEscalator 구현). 키워드 일치만으로는 응급 여부를
+// 알 수 없으므로 critical로 보내지 않으며, 보호자 호출·119 신고 에스컬레이션은 시작되지 않습니다.
+// 대화 원문은 싣지 않습니다.
+func (p *EventPublisher) EscalateSafety(ctx context.Context, e *llm.SafetyEscalation) error {
+	payload := map[string]interface{}{
+		"alert_type":            "ai_chat_emergency_check",
+		"severity":              "warning",
+		"requires_confirmation": true,
+		"source":                e.Source,
+		"message":               "AI 상담 대화에서 응급 상황 단서가 감지되었습니다. 현재 상태를 확인해 주세요",
+		"detected_at":           e.CreatedAt.UTC().Format(time.RFC3339),
+	}
+
+	payloadBytes, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("이벤트 페이로드 직렬화 실패: %w", err)
+	}
+
+	kafkaEvent := events.Event{
+		Type: events.EventHealthAlertTriggered,
+		Payload: map[string]interface{}{
+			"event_id":   uuid.New().String(),
+			"event_type": "manpasik." + events.EventHealthAlertTriggered,
+			"version":    "1.0",
+			"timestamp":  time.Now().UTC().Format(time.RFC3339),
+			"source":     "ai-inference-service",
+			"user_id":    e.UserID,
+			"payload":    json.RawMessage(payloadBytes),
+		},
+	}
+
+	return p.eventBus.Publish(ctx, kafkaEvent)
+}
@@ -0,0 +1,28 @@
+package kafka
+
+import (
+	"context"
+
+	"github.com/manpasik/backend/services/ai-inference-service/internal/service"
+	"github.com/manpasik/backend/shared/events"
+)
+
+// MeasurementProcessor는 완료된 측정으로 개인 기준선을 갱신하는 대상입니다.
+type MeasurementProcessor interface {
+	ProcessMeasurementCompleted(ctx context.Context, userID, sessionID string) ([]*service.BaselineAnomaly, error)
+}
+
+// NewMeasurementCompletedHandler는 measurement.completed 이벤트를 받아
+// 해당 측정으로 개인 기준선 이상 탐지를 수행하는 핸들러를 반환합니다.
+func NewMeasurementCompletedHandler(processor MeasurementProcessor) events.Handler {
+	return func(ctx context.Context, event events.Event) error {
+		payload := events.PayloadOf(event)
+		sessionID, _ := payload["session_id"].(string)
+		if sessionID == "" {
+			return nil
+		}
+		userID, _ := payload["user_id"].(string)
+		_, err := processor.ProcessMeasurementCompleted(ctx, userID, sessionID)
+		return err
+	}
+}
@@ -2,7 +2,10 @@ package memory
 
 import (
 	"context"
+	"fmt"
+	"sort"
 	"sync"
+	"time"
 
 	"github.com/manpasik/backend/services/ai-inference-service/internal/service"
 )
@@ -57,25 +60,244 @@ func (r *AnalysisRepository) FindByUserID(_ context.Context, userID string, limi
 
 type HealthScoreRepository struct {
 	mu   sync.RWMutex
-	data map[string]*service.HealthScore // keyed by userID (latest only)
+	data map[string][]*service.HealthScore // keyed by userID (산출 시간순)
 }
 
 func NewHealthScoreRepository() *HealthScoreRepository {
-	return &HealthScoreRepository{data: make(map[string]*service.HealthScore)}
+	return &HealthScoreRepository{data: make(map[string][]*service.HealthScore)}
 }
 
 func (r *HealthScoreRepository) Save(_ context.Context, score *service.HealthScore) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
-	r.data[score.UserID] = score
+	r.data[score.UserID] = append(r.data[score.UserID], score)
 	return nil
 }
 
 func (r *HealthScoreRepository) FindLatestByUserID(_ context.Context, userID string) (*service.HealthScore, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
-	if v, ok := r.data[userID]; ok {
-		return v, nil
+	if v := r.data[userID]; len(v) > 0 {
+		return v[len(v)-1], nil
 	}
 	return nil, nil
 }
+
+// FindByUserID는 since 이후 산출된 점수를 시간순으로 반환합니다.
+func (r *HealthScoreRepository) FindByUserID(_ context.Context, userID string, since time.Time) ([]*service.HealthScore, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var result []*service.HealthScore
+	for _, v := range r.data[userID] {
+		if !v.CalculatedAt.Before(since) {
+			result = append(result, v)
+		}
+	}
+	return result, nil
+}
+
+// ============================================================================
+// In-Memory Baseline Repository
+// ============================================================================
+
+// BaselineRepository는 개인 기준선 인메모리 저장소입니다.
+type BaselineRepository struct {
+	mu   sync.RWMutex
+	data map[string]*service.Baseline // userID|biomarker → 기준선
+}
+
+// NewBaselineRepository는 새 인메모리 기준선 저장소를 생성합니다.
+func NewBaselineRepository() *BaselineRepository {
+	return &BaselineRepository{data: make(map[string]*service.Baseline)}
+}
+
+// Get은 기준선을 조회합니다. 없으면 nil을 반환합니다.
+func (r *BaselineRepository) Get(_ context.Context, userID, biomarker string) (*service.Baseline, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	b, ok := r.data[userID+"|"+biomarker]
+	if !ok {
+		return nil, nil
+	}
+	cp := *b
+	cp.Recent = append([]float64(nil), b.Recent...)
+	return &cp, nil
+}
+
+// Save는 기준선을 저장합니다.
+func (r *BaselineRepository) Save(_ context.Context, b *service.Baseline) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	cp := *b
+	cp.Recent = append([]float64(nil), b.Recent...)
+	r.data[b.UserID+"|"+b.Biomarker] = &cp
+	return nil
+}
+
+// ============================================================================
+// In-Memory Conversation Repository
+// ============================================================================
+
+// ConversationRepository는 AI 대화 세션·메시지 인메모리 저장소입니다.
+type ConversationRepository struct {
+	mu       sync.RWMutex
+	sessions map[string]*service.ChatSession
+	messages map[string][]*service.ChatTurn // sessionID → 시간순 메시지
+}
+
+// NewConversationRepository는 새 인메모리 대화 저장소를 생성합니다.
+func NewConversationRepository() *ConversationRepository {
+	return &ConversationRepository{
+		sessions: make(map[string]*service.ChatSession),
+		messages: make(map[string][]*service.ChatTurn),
+	}
+}
+
+// CreateSession은 세션을 저장합니다.
+func (r *ConversationRepository) CreateSession(_ context.Context, session *service.ChatSession) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	cp := *session
+	r.sessions[session.SessionID] = &cp
+	return nil
+}
+
+// GetSession은 세션을 조회합니다. 없으면 nil을 반환합니다.
+func (r *ConversationRepository) GetSession(_ context.Context, sessionID string) (*service.ChatSession, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	s, ok := r.sessions[sessionID]
+	if !ok {
+		return nil, nil
+	}
+	cp := *s
+	return &cp, nil
+}
+
+// AppendMessage는 메시지를 추가하고 세션의 누적 토큰과 갱신 시각을 반영합니다.
+func (r *ConversationRepository) AppendMessage(_ context.Context, turn *service.ChatTurn) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	s, ok := r.sessions[turn.SessionID]
+	if !ok {
+		return fmt.Errorf("chat session not found: %s", turn.SessionID)
+	}
+	cp := *turn
+	r.messages[turn.SessionID] = append(r.messages[turn.SessionID], &cp)
+	s.TokensUsed += turn.TokensUsed
+	s.UpdatedAt = turn.CreatedAt
+	return nil
+}
+
+// RecentMessages는 세션의 최근 limit개 메시지를 시간순으로 반환합니다.
+func (r *ConversationRepository) RecentMessages(_ context.Context, sessionID string, limit int) ([]*service.ChatTurn, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	all := r.messages[sessionID]
+	if limit > 0 && len(all) > limit {
+		all = all[len(all)-limit:]
+	}
+	result := make([]*service.ChatTurn, len(all))
+	for i, t := range all {
+		cp := *t
+		result[i] = &cp
+	}
+	return result, nil
+}
+
+// ============================================================================
+// In-Memory RAG Snippet Repository / Vector Index
+// ============================================================================
+
+// SnippetRepository는 검색 증강 자료의 인메모리 본문 저장소입니다.
+type SnippetRepository struct {
+	mu   sync.RWMutex
+	data map[string]map[string]*service.HealthSnippet // userID → snippetID → 자료
+}
+
+// NewSnippetRepository는 새 인메모리 자료 저장소를 생성합니다.
+func NewSnippetRepository() *SnippetRepository {
+	return &SnippetRepository{data: make(map[string]map[string]*service.HealthSnippet)}
+}
+
+// ListSnippets는 사용자의 모든 자료를 반환합니다.
+func (r *SnippetRepository) ListSnippets(_ context.Context, userID string) ([]*service.HealthSnippet, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	result := make([]*service.HealthSnippet, 0, len(r.data[userID]))
+	for _, sn := range r.data[userID] {
+		cp := *sn
+		result = append(result, &cp)
+	}
+	return result, nil
+}
+
+// ReplaceSnippets는 사용자의 sourceType 자료를 snippets로 교체합니다.
+func (r *SnippetRepository) ReplaceSnippets(_ context.Context, userID, sourceType string, snippets []*service.HealthSnippet) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	byID, ok := r.data[userID]
+	if !ok {
+		byID = make(map[string]*service.HealthSnippet)
+		r.data[userID] = byID
+	}
+	for id, sn := range byID {
+		if sn.SourceType == sourceType {
+			delete(byID, id)
+		}
+	}
+	for _, sn := range snippets {
+		cp := *sn
+		byID[sn.SnippetID] = &cp
+	}
+	return nil
+}
+
+// SnippetVectorIndex는 사용자별 전수 비교(코사인 유사도) 인메모리 벡터 색인입니다.
+type SnippetVectorIndex struct {
+	mu   sync.RWMutex
+	data map[string]map[string][]float32 // userID → snippetID → 정규화 벡터
+}
+
+// NewSnippetVectorIndex는 새 인메모리 벡터 색인을 생성합니다.
+func NewSnippetVectorIndex() *SnippetVectorIndex {
+	return &SnippetVectorIndex{data: make(map[string]map[string][]float32)}
+}
+
+// Upsert는 자료 벡터를 저장하거나 교체합니다.
+func (x *SnippetVectorIndex) Upsert(_ context.Context, userID, snippetID string, vector []float32) error {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	if x.data[userID] == nil {
+		x.data[userID] = make(map[string][]float32)
+	}
+	x.data[userID][snippetID] = append([]float32(nil), vector...)
+	return nil
+}
+
+// Search는 userID의 벡터 중 유사도 상위 topK개를 반환합니다. 벡터는 정규화되어 있다고 가정합니다.
+func (x *SnippetVectorIndex) Search(_ context.Context, userID string, vector []float32, topK int) ([]service.VectorHit, error) {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+	hits := make([]service.VectorHit, 0, len(x.data[userID]))
+	for id, v := range x.data[userID] {
+		if len(v) != len(vector) {
+			continue
+		}
+		var dot float32
+		for i := range v {
+			dot += v[i] * vector[i]
+		}
+		hits = append(hits, service.VectorHit{SnippetID: id, Score: dot})
+	}
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].Score != hits[j].Score {
+			return hits[i].Score > hits[j].Score
+		}
+		return hits[i].SnippetID < hits[j].SnippetID
+	})
+	if topK > 0 && len(hits) > topK {
+		hits = hits[:topK]
+	}
+	return hits, nil
+}
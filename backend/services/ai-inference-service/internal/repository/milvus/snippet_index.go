@@ -0,0 +1,47 @@
+// Package milvus는 Milvus 기반 SnippetVectorIndex 구현을 제공합니다.
+package milvus
+
+import (
+	"context"
+
+	"github.com/manpasik/backend/services/ai-inference-service/internal/service"
+	"github.com/manpasik/backend/shared/vectordb"
+)
+
+// SnippetVectorIndex는 Milvus를 사용하는 검색 증강 자료 벡터 색인입니다.
+// 컬렉션의 session_id 필드에 사용자 ID를 저장해 검색을 사용자 본인의 자료로 제한합니다.
+type SnippetVectorIndex struct {
+	client *vectordb.MilvusClient
+}
+
+// NewSnippetVectorIndex는 Milvus 기반 SnippetVectorIndex를 생성합니다.
+func NewSnippetVectorIndex(client *vectordb.MilvusClient) *SnippetVectorIndex {
+	return &SnippetVectorIndex{client: client}
+}
+
+// Upsert는 자료 벡터를 저장하거나 교체합니다.
+func (x *SnippetVectorIndex) Upsert(ctx context.Context, userID, snippetID string, vector []float32) error {
+	return x.client.Upsert(ctx, vectorID(userID, snippetID), userID, vector)
+}
+
+// Search는 userID의 자료 벡터 중 유사도 상위 topK개를 반환합니다.
+func (x *SnippetVectorIndex) Search(ctx context.Context, userID string, vector []float32, topK int) ([]service.VectorHit, error) {
+	results, err := x.client.SearchInSession(ctx, userID, vector, topK)
+	if err != nil {
+		return nil, err
+	}
+	hits := make([]service.VectorHit, 0, len(results))
+	prefix := userID + "/"
+	for _, res := range results {
+		if res.SessionID != userID || len(res.ID) <= len(prefix) {
+			continue
+		}
+		hits = append(hits, service.VectorHit{SnippetID: res.ID[len(prefix):], Score: res.Score})
+	}
+	return hits, nil
+}
+
+// vectorID는 사용자마다 같은 자료 ID(예: "measurement:glucose")가 겹치지 않도록 사용자 ID를 붙입니다.
+func vectorID(userID, snippetID string) string {
+	return userID + "/" + snippetID
+}
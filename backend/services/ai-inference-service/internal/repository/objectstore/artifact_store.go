@@ -0,0 +1,37 @@
+// Package objectstore는 S3 호환 오브젝트 스토리지 기반 모델 아티팩트 저장소입니다.
+package objectstore
+
+import (
+	"bytes"
+	"context"
+	"io"
+
+	"github.com/manpasik/backend/shared/storage"
+)
+
+const artifactContentType = "application/octet-stream"
+
+// ArtifactStore는 service.ArtifactStore의 S3 구현입니다.
+type ArtifactStore struct {
+	client *storage.S3Client
+}
+
+// NewArtifactStore는 S3 클라이언트 기반 아티팩트 저장소를 생성합니다.
+func NewArtifactStore(client *storage.S3Client) *ArtifactStore {
+	return &ArtifactStore{client: client}
+}
+
+// Put은 아티팩트를 업로드합니다.
+func (s *ArtifactStore) Put(ctx context.Context, key string, data []byte) error {
+	return s.client.Upload(ctx, key, bytes.NewReader(data), int64(len(data)), artifactContentType)
+}
+
+// Get은 아티팩트를 내려받습니다.
+func (s *ArtifactStore) Get(ctx context.Context, key string) ([]byte, error) {
+	rc, err := s.client.Download(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	return io.ReadAll(rc)
+}
@@ -4,9 +4,11 @@ package postgres
 import (
 	"context"
 	"encoding/json"
+	"time"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/manpasik/backend/services/ai-inference-service/internal/llm"
 	"github.com/manpasik/backend/services/ai-inference-service/internal/service"
 )
 
@@ -32,11 +34,17 @@ func (r *AnalysisRepository) Save(ctx context.Context, result *service.AnalysisR
 	}
 	defer tx.Rollback(ctx)
 
-	const qAnalysis = `INSERT INTO analysis_results (id, user_id, measurement_id, overall_health_score, summary, analyzed_at)
-		VALUES ($1, $2, $3, $4, $5, $6)`
+	var substance []byte
+	if result.Substance != nil {
+		if substance, err = json.Marshal(result.Substance); err != nil {
+			return err
+		}
+	}
+	const qAnalysis = `INSERT INTO analysis_results (id, user_id, measurement_id, overall_health_score, summary, analyzed_at, substance_identification)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`
 	if _, err := tx.Exec(ctx, qAnalysis,
 		result.AnalysisID, result.UserID, result.MeasurementID,
-		result.OverallHealthScore, result.Summary, result.AnalyzedAt,
+		result.OverallHealthScore, result.Summary, result.AnalyzedAt, substance,
 	); err != nil {
 		return err
 	}
@@ -68,13 +76,14 @@ func (r *AnalysisRepository) Save(ctx context.Context, result *service.AnalysisR
 
 // FindByID는 분석 결과를 ID로 조회합니다.
 func (r *AnalysisRepository) FindByID(ctx context.Context, id string) (*service.AnalysisResult, error) {
-	const q = `SELECT id, user_id, measurement_id, overall_health_score, COALESCE(summary, ''), analyzed_at
+	const q = `SELECT id, user_id, measurement_id, overall_health_score, COALESCE(summary, ''), analyzed_at, substance_identification
 		FROM analysis_results WHERE id = $1`
 
 	var ar service.AnalysisResult
+	var substance []byte
 	err := r.pool.QueryRow(ctx, q, id).Scan(
 		&ar.AnalysisID, &ar.UserID, &ar.MeasurementID,
-		&ar.OverallHealthScore, &ar.Summary, &ar.AnalyzedAt,
+		&ar.OverallHealthScore, &ar.Summary, &ar.AnalyzedAt, &substance,
 	)
 	if err != nil {
 		if err == pgx.ErrNoRows {
@@ -82,6 +91,11 @@ func (r *AnalysisRepository) FindByID(ctx context.Context, id string) (*service.
 		}
 		return nil, err
 	}
+	if len(substance) > 0 {
+		if err := json.Unmarshal(substance, &ar.Substance); err != nil {
+			return nil, err
+		}
+	}
 
 	biomarkers, err := r.loadBiomarkers(ctx, id)
 	if err != nil {
@@ -100,7 +114,7 @@ func (r *AnalysisRepository) FindByID(ctx context.Context, id string) (*service.
 
 // FindByUserID는 사용자의 분석 결과 목록을 조회합니다.
 func (r *AnalysisRepository) FindByUserID(ctx context.Context, userID string, limit int) ([]*service.AnalysisResult, error) {
-	const q = `SELECT id, user_id, measurement_id, overall_health_score, COALESCE(summary, ''), analyzed_at
+	const q = `SELECT id, user_id, measurement_id, overall_health_score, COALESCE(summary, ''), analyzed_at, substance_identification
 		FROM analysis_results WHERE user_id = $1 ORDER BY analyzed_at DESC LIMIT $2`
 
 	if limit <= 0 {
@@ -116,12 +130,18 @@ func (r *AnalysisRepository) FindByUserID(ctx context.Context, userID string, li
 	var results []*service.AnalysisResult
 	for rows.Next() {
 		var ar service.AnalysisResult
+		var substance []byte
 		if err := rows.Scan(
 			&ar.AnalysisID, &ar.UserID, &ar.MeasurementID,
-			&ar.OverallHealthScore, &ar.Summary, &ar.AnalyzedAt,
+			&ar.OverallHealthScore, &ar.Summary, &ar.AnalyzedAt, &substance,
 		); err != nil {
 			return nil, err
 		}
+		if len(substance) > 0 {
+			if err := json.Unmarshal(substance, &ar.Substance); err != nil {
+				return nil, err
+			}
+		}
 		results = append(results, &ar)
 	}
 	if err := rows.Err(); err != nil {
@@ -215,35 +235,85 @@ func (r *HealthScoreRepository) Save(ctx context.Context, score *service.HealthS
 	if err != nil {
 		catJSON = []byte("{}")
 	}
+	covJSON, err := json.Marshal(score.CategoryCoverage)
+	if err != nil {
+		covJSON = []byte("{}")
+	}
+	contribJSON, err := json.Marshal(score.Contributions)
+	if err != nil {
+		contribJSON = []byte("[]")
+	}
 
-	const q = `INSERT INTO health_scores (user_id, overall_score, category_scores, trend, recommendation, calculated_at)
-		VALUES ($1, $2, $3, $4, $5, $6)`
+	const q = `INSERT INTO health_scores (user_id, overall_score, category_scores, trend, recommendation, calculated_at,
+			feature_contributions, category_coverage, data_coverage, insufficient_data, model_version)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`
 	_, err = r.pool.Exec(ctx, q,
 		score.UserID, score.OverallScore, catJSON,
 		score.Trend, score.Recommendation, score.CalculatedAt,
+		contribJSON, covJSON, score.DataCoverage, score.InsufficientData, score.ModelVersion,
 	)
 	return err
 }
 
+const healthScoreColumns = `user_id, overall_score, category_scores, trend, COALESCE(recommendation, ''), calculated_at,
+	feature_contributions, category_coverage, COALESCE(data_coverage, 0), COALESCE(insufficient_data, FALSE), COALESCE(model_version, '')`
+
 // FindLatestByUserID는 사용자의 최신 건강 점수를 조회합니다.
 func (r *HealthScoreRepository) FindLatestByUserID(ctx context.Context, userID string) (*service.HealthScore, error) {
-	const q = `SELECT user_id, overall_score, category_scores, trend, COALESCE(recommendation, ''), calculated_at
+	q := `SELECT ` + healthScoreColumns + `
 		FROM health_scores WHERE user_id = $1 ORDER BY calculated_at DESC LIMIT 1`
 
-	var hs service.HealthScore
-	var catJSON []byte
-	err := r.pool.QueryRow(ctx, q, userID).Scan(
-		&hs.UserID, &hs.OverallScore, &catJSON,
-		&hs.Trend, &hs.Recommendation, &hs.CalculatedAt,
-	)
+	hs, err := scanHealthScore(r.pool.QueryRow(ctx, q, userID))
 	if err != nil {
 		if err == pgx.ErrNoRows {
 			return nil, nil
 		}
 		return nil, err
 	}
+	return hs, nil
+}
+
+// FindByUserID는 since 이후 산출된 점수를 시간순으로 조회합니다.
+func (r *HealthScoreRepository) FindByUserID(ctx context.Context, userID string, since time.Time) ([]*service.HealthScore, error) {
+	q := `SELECT ` + healthScoreColumns + `
+		FROM health_scores WHERE user_id = $1 AND calculated_at >= $2 ORDER BY calculated_at ASC`
+
+	rows, err := r.pool.Query(ctx, q, userID, since)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []*service.HealthScore
+	for rows.Next() {
+		hs, err := scanHealthScore(rows)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, hs)
+	}
+	return results, rows.Err()
+}
+
+func scanHealthScore(row pgx.Row) (*service.HealthScore, error) {
+	var hs service.HealthScore
+	var catJSON, contribJSON, covJSON []byte
+	if err := row.Scan(
+		&hs.UserID, &hs.OverallScore, &catJSON,
+		&hs.Trend, &hs.Recommendation, &hs.CalculatedAt,
+		&contribJSON, &covJSON, &hs.DataCoverage, &hs.InsufficientData, &hs.ModelVersion,
+	); err != nil {
+		return nil, err
+	}
 	hs.CategoryScores = make(map[string]float64)
 	_ = json.Unmarshal(catJSON, &hs.CategoryScores)
+	hs.CategoryCoverage = make(map[string]float64)
+	if len(covJSON) > 0 {
+		_ = json.Unmarshal(covJSON, &hs.CategoryCoverage)
+	}
+	if len(contribJSON) > 0 {
+		_ = json.Unmarshal(contribJSON, &hs.Contributions)
+	}
 	return &hs, nil
 }
 
@@ -280,3 +350,489 @@ func riskLevelFromString(s string) service.RiskLevel {
 		return service.RiskLow
 	}
 }
+
+// ============================================================================
+// BaselineRepository — PostgreSQL 기반
+// ============================================================================
+
+// BaselineRepository는 PostgreSQL 기반 개인 기준선 저장소입니다.
+type BaselineRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewBaselineRepository는 PostgreSQL BaselineRepository를 생성합니다.
+func NewBaselineRepository(pool *pgxpool.Pool) *BaselineRepository {
+	return &BaselineRepository{pool: pool}
+}
+
+// Get은 사용자·바이오마커의 기준선을 조회합니다. 없으면 nil을 반환합니다.
+func (r *BaselineRepository) Get(ctx context.Context, userID, biomarker string) (*service.Baseline, error) {
+	const q = `SELECT user_id, biomarker, sample_count, recent_values, ewma, suspect_run, shift_run, shift_active,
+		last_measured_at, updated_at
+		FROM user_biomarker_baselines WHERE user_id = $1 AND biomarker = $2`
+
+	var b service.Baseline
+	err := r.pool.QueryRow(ctx, q, userID, biomarker).Scan(
+		&b.UserID, &b.Biomarker, &b.Count, &b.Recent, &b.EWMA, &b.SuspectRun, &b.ShiftRun, &b.ShiftActive,
+		&b.LastMeasuredAt, &b.UpdatedAt,
+	)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &b, nil
+}
+
+// Save는 기준선을 저장(upsert)합니다.
+func (r *BaselineRepository) Save(ctx context.Context, b *service.Baseline) error {
+	const q = `INSERT INTO user_biomarker_baselines
+		(user_id, biomarker, sample_count, recent_values, ewma, suspect_run, shift_run, shift_active, last_measured_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		ON CONFLICT (user_id, biomarker) DO UPDATE SET
+			sample_count = EXCLUDED.sample_count,
+			recent_values = EXCLUDED.recent_values,
+			ewma = EXCLUDED.ewma,
+			suspect_run = EXCLUDED.suspect_run,
+			shift_run = EXCLUDED.shift_run,
+			shift_active = EXCLUDED.shift_active,
+			last_measured_at = EXCLUDED.last_measured_at,
+			updated_at = EXCLUDED.updated_at`
+	_, err := r.pool.Exec(ctx, q,
+		b.UserID, b.Biomarker, b.Count, b.Recent, b.EWMA, b.SuspectRun, b.ShiftRun, b.ShiftActive,
+		b.LastMeasuredAt, b.UpdatedAt,
+	)
+	return err
+}
+
+// ============================================================================
+// ModelRegistryRepository — PostgreSQL 기반
+// ============================================================================
+
+// ModelRegistryRepository는 PostgreSQL 기반 모델 레지스트리 저장소입니다.
+type ModelRegistryRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewModelRegistryRepository는 PostgreSQL ModelRegistryRepository를 생성합니다.
+func NewModelRegistryRepository(pool *pgxpool.Pool) *ModelRegistryRepository {
+	return &ModelRegistryRepository{pool: pool}
+}
+
+// SaveVersion은 모델 버전 메타데이터를 저장(upsert)합니다.
+func (r *ModelRegistryRepository) SaveVersion(ctx context.Context, v *service.ModelVersion) error {
+	metricsJSON, err := json.Marshal(v.Metrics)
+	if err != nil {
+		metricsJSON = []byte("{}")
+	}
+	const q = `INSERT INTO ai_model_versions
+		(model_type, name, version, description, kind, artifact_uri, artifact_sha256,
+		 training_data_start, training_data_end, trained_at, metrics, status, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+		ON CONFLICT (model_type, version) DO UPDATE SET
+			description = EXCLUDED.description,
+			metrics = EXCLUDED.metrics,
+			status = EXCLUDED.status,
+			updated_at = EXCLUDED.updated_at`
+	_, err = r.pool.Exec(ctx, q,
+		modelTypeToString(v.ModelType), v.Name, v.Version, v.Description, v.Kind, v.ArtifactURI, v.ArtifactSHA256,
+		nullTime(v.TrainingDataStart), nullTime(v.TrainingDataEnd), nullTime(v.TrainedAt),
+		metricsJSON, string(v.Status), v.CreatedAt, v.UpdatedAt,
+	)
+	return err
+}
+
+// ListVersions는 등록된 모든 모델 버전을 조회합니다.
+func (r *ModelRegistryRepository) ListVersions(ctx context.Context) ([]*service.ModelVersion, error) {
+	const q = `SELECT model_type::text, name, version, COALESCE(description, ''), kind, artifact_uri, COALESCE(artifact_sha256, ''),
+		training_data_start, training_data_end, trained_at, metrics, status, created_at, updated_at
+		FROM ai_model_versions ORDER BY model_type, created_at`
+
+	rows, err := r.pool.Query(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []*service.ModelVersion
+	for rows.Next() {
+		var v service.ModelVersion
+		var modelType, status string
+		var start, end, trained *time.Time
+		var metricsJSON []byte
+		if err := rows.Scan(
+			&modelType, &v.Name, &v.Version, &v.Description, &v.Kind, &v.ArtifactURI, &v.ArtifactSHA256,
+			&start, &end, &trained, &metricsJSON, &status, &v.CreatedAt, &v.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		v.ModelType = modelTypeFromString(modelType)
+		v.Status = service.ModelStatus(status)
+		v.TrainingDataStart, v.TrainingDataEnd, v.TrainedAt = derefTime(start), derefTime(end), derefTime(trained)
+		if len(metricsJSON) > 0 {
+			_ = json.Unmarshal(metricsJSON, &v.Metrics)
+		}
+		results = append(results, &v)
+	}
+	return results, rows.Err()
+}
+
+// RecordShadowResult는 섀도 비교 결과를 후보 버전별 누적 통계에 더합니다.
+func (r *ModelRegistryRepository) RecordShadowResult(ctx context.Context, res *service.ShadowResult) error {
+	const q = `INSERT INTO ai_model_shadow_stats
+		(model_type, candidate_version, runs, compared, agreed, sum_abs_score_delta, last_recorded_at)
+		VALUES ($1, $2, 1, $3, $4, ABS($5::double precision), $6)
+		ON CONFLICT (model_type, candidate_version) DO UPDATE SET
+			runs = ai_model_shadow_stats.runs + 1,
+			compared = ai_model_shadow_stats.compared + EXCLUDED.compared,
+			agreed = ai_model_shadow_stats.agreed + EXCLUDED.agreed,
+			sum_abs_score_delta = ai_model_shadow_stats.sum_abs_score_delta + EXCLUDED.sum_abs_score_delta,
+			last_recorded_at = EXCLUDED.last_recorded_at`
+	_, err := r.pool.Exec(ctx, q,
+		modelTypeToString(res.ModelType), res.CandidateVersion, res.Compared, res.Agreed, res.ScoreDelta, res.RecordedAt,
+	)
+	return err
+}
+
+// ListShadowStats는 후보 버전별 누적 섀도 통계를 조회합니다.
+func (r *ModelRegistryRepository) ListShadowStats(ctx context.Context) ([]*service.ShadowStats, error) {
+	const q = `SELECT model_type::text, candidate_version, runs, compared, agreed, sum_abs_score_delta, last_recorded_at
+		FROM ai_model_shadow_stats`
+
+	rows, err := r.pool.Query(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []*service.ShadowStats
+	for rows.Next() {
+		var st service.ShadowStats
+		var modelType string
+		if err := rows.Scan(&modelType, &st.CandidateVersion, &st.Runs, &st.Compared, &st.Agreed,
+			&st.SumAbsScoreDelta, &st.LastRecordedAt); err != nil {
+			return nil, err
+		}
+		st.ModelType = modelTypeFromString(modelType)
+		results = append(results, &st)
+	}
+	return results, rows.Err()
+}
+
+// ============================================================================
+// LLMUsageRepository — PostgreSQL 기반
+// ============================================================================
+
+// LLMUsageRepository는 사용자별 일일 LLM 토큰 사용량 저장소입니다 (llm.UsageStore 구현).
+// 인스턴스가 여러 개여도 토큰 예산을 공유합니다.
+type LLMUsageRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewLLMUsageRepository는 PostgreSQL LLMUsageRepository를 생성합니다.
+func NewLLMUsageRepository(pool *pgxpool.Pool) *LLMUsageRepository {
+	return &LLMUsageRepository{pool: pool}
+}
+
+// TokensUsed는 사용자의 해당 일자 토큰 사용량을 조회합니다.
+func (r *LLMUsageRepository) TokensUsed(ctx context.Context, userID string, day time.Time) (int, error) {
+	var tokens int
+	err := r.pool.QueryRow(ctx,
+		`SELECT tokens FROM llm_token_usage WHERE user_id = $1 AND usage_date = $2`,
+		userID, day).Scan(&tokens)
+	if err == pgx.ErrNoRows {
+		return 0, nil
+	}
+	return tokens, err
+}
+
+// AddTokens는 사용자의 해당 일자 토큰 사용량을 누적합니다.
+func (r *LLMUsageRepository) AddTokens(ctx context.Context, userID string, day time.Time, tokens int) error {
+	_, err := r.pool.Exec(ctx,
+		`INSERT INTO llm_token_usage (user_id, usage_date, tokens, updated_at)
+		 VALUES ($1, $2, $3, NOW())
+		 ON CONFLICT (user_id, usage_date)
+		 DO UPDATE SET tokens = llm_token_usage.tokens + EXCLUDED.tokens, updated_at = NOW()`,
+		userID, day, tokens)
+	return err
+}
+
+// ============================================================================
+// LLMUsageLedgerRepository — PostgreSQL 기반
+// ============================================================================
+
+// LLMUsageLedgerRepository는 호출별 LLM 사용량·예상 비용 원장입니다 (llm.UsageLedger 구현).
+type LLMUsageLedgerRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewLLMUsageLedgerRepository는 PostgreSQL LLMUsageLedgerRepository를 생성합니다.
+func NewLLMUsageLedgerRepository(pool *pgxpool.Pool) *LLMUsageLedgerRepository {
+	return &LLMUsageLedgerRepository{pool: pool}
+}
+
+// RecordUsage는 호출 1건의 사용량을 저장합니다.
+func (r *LLMUsageLedgerRepository) RecordUsage(ctx context.Context, u *llm.UsageRecord) error {
+	_, err := r.pool.Exec(ctx,
+		`INSERT INTO llm_usage_events (user_id, feature, provider, model, tokens, cost_usd, cache_hit, created_at)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
+		u.UserID, u.Feature, u.Provider, u.Model, u.Tokens, u.CostUSD, u.CacheHit, u.CreatedAt)
+	return err
+}
+
+// SpendSince는 since 이후 사용자의 누적 예상 비용을 조회합니다.
+func (r *LLMUsageLedgerRepository) SpendSince(ctx context.Context, userID string, since time.Time) (float64, error) {
+	var spent float64
+	err := r.pool.QueryRow(ctx,
+		`SELECT COALESCE(SUM(cost_usd), 0)::float8 FROM llm_usage_events WHERE user_id = $1 AND created_at >= $2`,
+		userID, since).Scan(&spent)
+	return spent, err
+}
+
+// SummarizeUsage는 조회 구간의 사용량을 사용자·기능·프로바이더·모델별로 집계합니다.
+func (r *LLMUsageLedgerRepository) SummarizeUsage(ctx context.Context, q llm.UsageQuery) ([]llm.UsageSummary, error) {
+	rows, err := r.pool.Query(ctx,
+		`SELECT user_id, feature, provider, model, COUNT(*), COUNT(*) FILTER (WHERE cache_hit),
+		        COALESCE(SUM(tokens), 0), COALESCE(SUM(cost_usd), 0)::float8
+		 FROM llm_usage_events
+		 WHERE created_at >= $1 AND created_at < $2 AND ($3 = '' OR user_id = $3)
+		 GROUP BY user_id, feature, provider, model
+		 ORDER BY 8 DESC, 5 DESC`,
+		q.From, q.To, q.UserID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []llm.UsageSummary
+	for rows.Next() {
+		var s llm.UsageSummary
+		if err := rows.Scan(&s.UserID, &s.Feature, &s.Provider, &s.Model, &s.Calls, &s.CacheHits, &s.Tokens, &s.CostUSD); err != nil {
+			return nil, err
+		}
+		results = append(results, s)
+	}
+	return results, rows.Err()
+}
+
+// ============================================================================
+// GuardrailAuditRepository — PostgreSQL 기반
+// ============================================================================
+
+// GuardrailAuditRepository는 LLM 가드레일 감사 기록 저장소입니다 (llm.GuardrailAuditStore 구현).
+type GuardrailAuditRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewGuardrailAuditRepository는 PostgreSQL GuardrailAuditRepository를 생성합니다.
+func NewGuardrailAuditRepository(pool *pgxpool.Pool) *GuardrailAuditRepository {
+	return &GuardrailAuditRepository{pool: pool}
+}
+
+// RecordGuardrailAudit은 감사 기록을 저장합니다.
+func (r *GuardrailAuditRepository) RecordGuardrailAudit(ctx context.Context, a *llm.GuardrailAudit) error {
+	redactions, err := json.Marshal(a.Redactions)
+	if err != nil {
+		return err
+	}
+	_, err = r.pool.Exec(ctx,
+		`INSERT INTO llm_guardrail_audit
+		 (user_ref, operation, provider, model, redactions, findings, actions, escalated, failed, created_at)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`,
+		a.UserRef, a.Operation, a.Provider, a.Model, redactions, a.Findings, a.Actions, a.Escalated, a.Failed, a.CreatedAt)
+	return err
+}
+
+// ============================================================================
+// ConversationRepository — PostgreSQL 기반
+// ============================================================================
+
+// ConversationRepository는 PostgreSQL 기반 AI 대화 세션·메시지 저장소입니다.
+type ConversationRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewConversationRepository는 PostgreSQL ConversationRepository를 생성합니다.
+func NewConversationRepository(pool *pgxpool.Pool) *ConversationRepository {
+	return &ConversationRepository{pool: pool}
+}
+
+// CreateSession은 세션을 저장합니다.
+func (r *ConversationRepository) CreateSession(ctx context.Context, s *service.ChatSession) error {
+	_, err := r.pool.Exec(ctx,
+		`INSERT INTO ai_chat_sessions (session_id, user_id, title, tokens_used, created_at, updated_at)
+		 VALUES ($1, $2, $3, $4, $5, $6)`,
+		s.SessionID, s.UserID, s.Title, s.TokensUsed, s.CreatedAt, s.UpdatedAt)
+	return err
+}
+
+// GetSession은 세션을 조회합니다. 없으면 nil을 반환합니다.
+func (r *ConversationRepository) GetSession(ctx context.Context, sessionID string) (*service.ChatSession, error) {
+	var s service.ChatSession
+	err := r.pool.QueryRow(ctx,
+		`SELECT session_id, user_id, title, tokens_used, created_at, updated_at
+		 FROM ai_chat_sessions WHERE session_id = $1`, sessionID).
+		Scan(&s.SessionID, &s.UserID, &s.Title, &s.TokensUsed, &s.CreatedAt, &s.UpdatedAt)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &s, nil
+}
+
+// AppendMessage는 메시지를 추가하고 세션의 누적 토큰과 갱신 시각을 반영합니다 (트랜잭션).
+func (r *ConversationRepository) AppendMessage(ctx context.Context, t *service.ChatTurn) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx,
+		`INSERT INTO ai_chat_messages (message_id, session_id, role, content, tokens_used, provider, model, created_at)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
+		t.MessageID, t.SessionID, t.Role, t.Content, t.TokensUsed, t.Provider, t.Model, t.CreatedAt); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx,
+		`UPDATE ai_chat_sessions SET tokens_used = tokens_used + $2, updated_at = $3 WHERE session_id = $1`,
+		t.SessionID, t.TokensUsed, t.CreatedAt); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// RecentMessages는 세션의 최근 limit개 메시지를 시간순으로 반환합니다.
+func (r *ConversationRepository) RecentMessages(ctx context.Context, sessionID string, limit int) ([]*service.ChatTurn, error) {
+	rows, err := r.pool.Query(ctx,
+		`SELECT message_id, session_id, role, content, tokens_used, provider, model, created_at
+		 FROM (SELECT * FROM ai_chat_messages WHERE session_id = $1 ORDER BY created_at DESC, message_id DESC LIMIT $2) recent
+		 ORDER BY created_at, message_id`, sessionID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []*service.ChatTurn
+	for rows.Next() {
+		var t service.ChatTurn
+		if err := rows.Scan(&t.MessageID, &t.SessionID, &t.Role, &t.Content, &t.TokensUsed,
+			&t.Provider, &t.Model, &t.CreatedAt); err != nil {
+			return nil, err
+		}
+		result = append(result, &t)
+	}
+	return result, rows.Err()
+}
+
+// ============================================================================
+// SnippetRepository — PostgreSQL 기반
+// ============================================================================
+
+// SnippetRepository는 PostgreSQL 기반 검색 증강 자료 본문 저장소입니다.
+type SnippetRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewSnippetRepository는 PostgreSQL SnippetRepository를 생성합니다.
+func NewSnippetRepository(pool *pgxpool.Pool) *SnippetRepository {
+	return &SnippetRepository{pool: pool}
+}
+
+// ListSnippets는 사용자의 모든 자료를 반환합니다.
+func (r *SnippetRepository) ListSnippets(ctx context.Context, userID string) ([]*service.HealthSnippet, error) {
+	rows, err := r.pool.Query(ctx,
+		`SELECT snippet_id, user_id, source_type, source_id, title, body, recorded_at, indexed_at
+		 FROM ai_rag_snippets WHERE user_id = $1`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []*service.HealthSnippet
+	for rows.Next() {
+		var sn service.HealthSnippet
+		var recordedAt *time.Time
+		if err := rows.Scan(&sn.SnippetID, &sn.UserID, &sn.SourceType, &sn.SourceID,
+			&sn.Title, &sn.Text, &recordedAt, &sn.IndexedAt); err != nil {
+			return nil, err
+		}
+		sn.RecordedAt = derefTime(recordedAt)
+		result = append(result, &sn)
+	}
+	return result, rows.Err()
+}
+
+// ReplaceSnippets는 사용자의 sourceType 자료를 snippets로 교체합니다 (트랜잭션).
+func (r *SnippetRepository) ReplaceSnippets(ctx context.Context, userID, sourceType string, snippets []*service.HealthSnippet) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx,
+		`DELETE FROM ai_rag_snippets WHERE user_id = $1 AND source_type = $2`, userID, sourceType); err != nil {
+		return err
+	}
+	for _, sn := range snippets {
+		if _, err := tx.Exec(ctx,
+			`INSERT INTO ai_rag_snippets (snippet_id, user_id, source_type, source_id, title, body, recorded_at, indexed_at)
+			 VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
+			sn.SnippetID, userID, sn.SourceType, sn.SourceID, sn.Title, sn.Text, nullTime(sn.RecordedAt), sn.IndexedAt); err != nil {
+			return err
+		}
+	}
+	return tx.Commit(ctx)
+}
+
+func modelTypeToString(t service.AiModelType) string {
+	switch t {
+	case service.ModelAnomalyDetector:
+		return "ANOMALY_DETECTOR"
+	case service.ModelTrendPredictor:
+		return "TREND_PREDICTOR"
+	case service.ModelHealthScorer:
+		return "HEALTH_SCORER"
+	case service.ModelFoodCalorieEstimator:
+		return "FOOD_CALORIE_ESTIMATOR"
+	case service.ModelSubstanceIdentifier:
+		return "SUBSTANCE_IDENTIFIER"
+	default:
+		return "BIOMARKER_CLASSIFIER"
+	}
+}
+
+func modelTypeFromString(s string) service.AiModelType {
+	switch s {
+	case "ANOMALY_DETECTOR":
+		return service.ModelAnomalyDetector
+	case "TREND_PREDICTOR":
+		return service.ModelTrendPredictor
+	case "HEALTH_SCORER":
+		return service.ModelHealthScorer
+	case "FOOD_CALORIE_ESTIMATOR":
+		return service.ModelFoodCalorieEstimator
+	case "SUBSTANCE_IDENTIFIER":
+		return service.ModelSubstanceIdentifier
+	default:
+		return service.ModelBiomarkerClassifier
+	}
+}
+
+func nullTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
+
+func derefTime(t *time.Time) time.Time {
+	if t == nil {
+		return time.Time{}
+	}
+	return *t
+}
@@ -0,0 +1,51 @@
+// Package redis는 Redis 기반 LLM 응답 캐시입니다.
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/manpasik/backend/services/ai-inference-service/internal/llm"
+	"github.com/manpasik/backend/shared/cache"
+	goredis "github.com/redis/go-redis/v9"
+)
+
+// ResponseCache는 Redis에 LLM 응답을 JSON으로 저장합니다 (llm.ResponseCache 구현).
+// 인스턴스가 여러 개여도 캐시를 공유하며, 만료는 Redis TTL에 맡깁니다.
+type ResponseCache struct {
+	client *cache.RedisClient
+}
+
+// NewResponseCache는 Redis ResponseCache를 생성합니다.
+func NewResponseCache(client *cache.RedisClient) *ResponseCache {
+	return &ResponseCache{client: client}
+}
+
+// GetResponse는 키에 저장된 응답을 조회합니다.
+func (c *ResponseCache) GetResponse(ctx context.Context, key string) (*llm.ChatResponse, bool, error) {
+	raw, err := c.client.Get(ctx, key)
+	if errors.Is(err, goredis.Nil) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	var resp llm.ChatResponse
+	if err := json.Unmarshal([]byte(raw), &resp); err != nil {
+		return nil, false, err
+	}
+	return &resp, true, nil
+}
+
+// SetResponse는 응답을 ttl 동안 저장합니다.
+func (c *ResponseCache) SetResponse(ctx context.Context, key string, resp *llm.ChatResponse, ttl time.Duration) error {
+	raw, err := json.Marshal(resp)
+	if err != nil {
+		return err
+	}
+	return c.client.Set(ctx, key, raw, ttl)
+}
+
+var _ llm.ResponseCache = (*ResponseCache)(nil)
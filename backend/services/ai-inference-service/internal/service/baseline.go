@@ -0,0 +1,268 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	apperrors "github.com/manpasik/backend/shared/errors"
+)
+
+// 개인 기준선 이상 유형
+const (
+	AnomalyKindSpike = "spike"           // 단일 측정의 급격한 이탈
+	AnomalyKindShift = "sustained_shift" // EWMA가 기준선에서 지속적으로 벗어남
+)
+
+// 이상 심각도
+const (
+	SeverityWarning  = "warning"
+	SeverityCritical = "critical"
+)
+
+const (
+	baselineWindow        = 30  // 중앙값·MAD 계산에 쓰는 최근 측정 수
+	minBaselineSamples    = 7   // 이보다 적으면 기준선 학습만 하고 판정하지 않음
+	ewmaLambda            = 0.3 // EWMA 평활 계수
+	spikeZ                = 3.5 // 급변 판정 robust z-score
+	criticalZ             = 6.0 // 급변 critical 판정 robust z-score
+	shiftSigma            = 2.0 // 지속 변화 판정: EWMA가 기준선에서 벗어난 robust σ 배수
+	shiftRecoverSigma     = 1.0 // 지속 변화 해제: 이 배수 안으로 돌아오면 해제
+	shiftMinRun           = 3   // 지속 변화 판정에 필요한 shiftSigma 초과 측정 수
+	minBaselineConfidence = 0.5 // QC 반영 신뢰도가 이보다 낮은 측정은 기준선에 반영하지 않음
+	madToSigma            = 1.4826
+)
+
+// Baseline은 사용자·바이오마커별 개인 기준선 상태입니다.
+type Baseline struct {
+	UserID         string
+	Biomarker      string
+	Count          int       // 누적 반영 측정 수
+	Recent         []float64 // 최근 baselineWindow개 값 (시간순)
+	EWMA           float64
+	SuspectRun     int  // EWMA가 연속으로 shiftRecoverSigma를 넘은 측정 수 (기준선 계산에서 제외)
+	ShiftRun       int  // 의심 구간 중 EWMA가 shiftSigma를 넘은 측정 수
+	ShiftActive    bool // 지속 변화 이벤트를 이미 발행했는지
+	LastMeasuredAt time.Time
+	UpdatedAt      time.Time
+}
+
+// BaselineAnomaly는 개인 기준선 대비 이상 판정 결과입니다.
+type BaselineAnomaly struct {
+	UserID        string
+	MeasurementID string
+	Biomarker     string
+	Kind          string
+	Severity      string
+	Value         float64
+	Unit          string
+	Median        float64
+	MAD           float64
+	EWMA          float64
+	ZScore        float64
+	MeasuredAt    time.Time
+	Description   string
+}
+
+// BaselineRepository는 개인 기준선 저장소입니다.
+type BaselineRepository interface {
+	Get(ctx context.Context, userID, biomarker string) (*Baseline, error)
+	Save(ctx context.Context, b *Baseline) error
+}
+
+// AnomalyEventPublisher는 ai.anomaly_detected 이벤트 발행기입니다.
+type AnomalyEventPublisher interface {
+	PublishAnomalyDetected(ctx context.Context, a *BaselineAnomaly) error
+}
+
+// WithBaselineRepository는 개인 기준선 저장소를 주입합니다.
+func WithBaselineRepository(r BaselineRepository) InferenceOption {
+	return func(s *InferenceService) {
+		s.baselines = r
+	}
+}
+
+// WithAnomalyEventPublisher는 이상 이벤트 발행기를 주입합니다.
+func WithAnomalyEventPublisher(p AnomalyEventPublisher) InferenceOption {
+	return func(s *InferenceService) {
+		s.anomalyEvents = p
+	}
+}
+
+// ProcessMeasurementCompleted는 measurement.completed 이벤트의 측정을 조회해
+// 바이오마커별 개인 기준선을 갱신하고, 급변·지속 변화를 판정해 이벤트를 발행합니다.
+// 같은 측정이 중복 전달되면 LastMeasuredAt 이전 값은 건너뜁니다.
+// 발행이 실패한 측정은 기준선에 반영하지 않으므로 재전달 시 다시 발행됩니다.
+func (s *InferenceService) ProcessMeasurementCompleted(ctx context.Context, userID, sessionID string) ([]*BaselineAnomaly, error) {
+	if sessionID == "" {
+		return nil, apperrors.New(apperrors.ErrInvalidInput, "session_id is required")
+	}
+	if s.measurements == nil || s.baselines == nil {
+		return nil, apperrors.New(apperrors.ErrServiceUnavailable, "기준선 이상 탐지가 설정되지 않았습니다")
+	}
+
+	detail, err := s.measurements.GetMeasurement(ctx, sessionID)
+	if err != nil {
+		return nil, apperrors.New(apperrors.ErrServiceUnavailable, "측정 데이터 조회에 실패했습니다")
+	}
+	if detail == nil || (userID != "" && detail.UserID != userID) {
+		return nil, apperrors.New(apperrors.ErrNotFound, "측정을 찾을 수 없습니다")
+	}
+
+	var anomalies []*BaselineAnomaly
+	for _, r := range detail.Readings {
+		if adjustedConfidence(r.Confidence, r.QCFlags) < minBaselineConfidence {
+			continue
+		}
+		at, err := time.Parse(time.RFC3339, r.MeasuredAt)
+		if err != nil {
+			at = s.now()
+		}
+
+		biomarker := canonicalBiomarker(r.BiomarkerID)
+		b, err := s.baselines.Get(ctx, detail.UserID, biomarker)
+		if err != nil {
+			return nil, apperrors.New(apperrors.ErrInternal, "기준선 조회에 실패했습니다")
+		}
+		if b == nil {
+			b = &Baseline{UserID: detail.UserID, Biomarker: biomarker}
+		}
+		if !b.LastMeasuredAt.IsZero() && !at.After(b.LastMeasuredAt) {
+			continue
+		}
+
+		// 이벤트를 먼저 발행하고 기준선을 저장합니다. 발행이 실패하면 기준선이 그대로라
+		// 재전달된 이벤트가 같은 측정을 다시 판정·발행합니다.
+		for _, a := range b.observe(r.Value, at) {
+			a.MeasurementID = sessionID
+			a.Unit = r.Unit
+			if s.anomalyEvents != nil {
+				if err := s.anomalyEvents.PublishAnomalyDetected(ctx, a); err != nil {
+					return anomalies, apperrors.New(apperrors.ErrInternal, "이상 이벤트 발행에 실패했습니다")
+				}
+			}
+			anomalies = append(anomalies, a)
+		}
+		b.UpdatedAt = s.now()
+		if err := s.baselines.Save(ctx, b); err != nil {
+			return nil, apperrors.New(apperrors.ErrInternal, "기준선 저장에 실패했습니다")
+		}
+	}
+	return anomalies, nil
+}
+
+// observe는 새 값을 기존 기준선과 비교해 판정한 뒤 기준선에 반영합니다.
+// 판정은 반영 전 기준선(최근 값의 중앙값·MAD)으로 하므로 급변 값이 자기 자신을 가리지 않으며,
+// 지속 변화가 의심되는 동안의 값은 확정 전까지 기준선 계산에서 제외해 변화가 기준선에 흡수되지 않게 합니다.
+func (b *Baseline) observe(value float64, at time.Time) []*BaselineAnomaly {
+	var anomalies []*BaselineAnomaly
+
+	if len(b.Recent) < minBaselineSamples {
+		b.EWMA = value
+	} else {
+		reference := b.Recent
+		if !b.ShiftActive {
+			excl := b.SuspectRun
+			if excl > len(reference)-minBaselineSamples {
+				excl = len(reference) - minBaselineSamples
+			}
+			reference = reference[:len(reference)-excl]
+		}
+		median, mad := medianMAD(reference)
+		sigma := robustSigma(median, mad)
+		z := (value - median) / sigma
+
+		// 학습 구간의 EWMA는 버리고 기준선 중앙값에서 시작하며,
+		// 급변 값 하나가 지속 변화로 이어지지 않도록 EWMA 입력을 ±spikeZ·σ로 제한
+		if len(b.Recent) == minBaselineSamples {
+			b.EWMA = median
+		}
+		clipped := math.Max(median-spikeZ*sigma, math.Min(median+spikeZ*sigma, value))
+		b.EWMA = ewmaLambda*clipped + (1-ewmaLambda)*b.EWMA
+
+		base := BaselineAnomaly{
+			UserID:     b.UserID,
+			Biomarker:  b.Biomarker,
+			Value:      value,
+			Median:     median,
+			MAD:        mad,
+			EWMA:       b.EWMA,
+			ZScore:     math.Round(z*100) / 100,
+			MeasuredAt: at,
+		}
+
+		if math.Abs(z) >= spikeZ {
+			a := base
+			a.Kind = AnomalyKindSpike
+			a.Severity = SeverityWarning
+			if math.Abs(z) >= criticalZ {
+				a.Severity = SeverityCritical
+			}
+			a.Description = fmt.Sprintf("%s 값 %.2f이(가) 개인 기준선(중앙값 %.2f)에서 크게 벗어났습니다 (z=%.1f).",
+				b.Biomarker, value, median, z)
+			anomalies = append(anomalies, &a)
+		}
+
+		ewmaDev := math.Abs(b.EWMA-median) / sigma
+		if ewmaDev > shiftRecoverSigma {
+			b.SuspectRun++
+			if ewmaDev >= shiftSigma {
+				b.ShiftRun++
+			}
+		} else {
+			b.SuspectRun = 0
+			b.ShiftRun = 0
+			b.ShiftActive = false
+		}
+		if b.ShiftRun >= shiftMinRun && !b.ShiftActive {
+			b.ShiftActive = true
+			a := base
+			a.Kind = AnomalyKindShift
+			a.Severity = SeverityWarning
+			a.ZScore = math.Round((b.EWMA-median)/sigma*100) / 100
+			direction := "상승"
+			if b.EWMA < median {
+				direction = "하락"
+			}
+			a.Description = fmt.Sprintf("%s 수치가 최근 %d회 측정 동안 개인 기준선(중앙값 %.2f)보다 %s한 상태입니다 (EWMA %.2f).",
+				b.Biomarker, b.SuspectRun, median, direction, b.EWMA)
+			anomalies = append(anomalies, &a)
+		}
+	}
+
+	b.Recent = append(b.Recent, value)
+	if len(b.Recent) > baselineWindow {
+		b.Recent = b.Recent[len(b.Recent)-baselineWindow:]
+	}
+	b.Count++
+	b.LastMeasuredAt = at
+	return anomalies
+}
+
+// medianMAD는 중앙값과 중앙값 절대 편차(MAD)를 반환합니다.
+func medianMAD(values []float64) (float64, float64) {
+	median := medianOf(values)
+	dev := make([]float64, len(values))
+	for i, v := range values {
+		dev[i] = math.Abs(v - median)
+	}
+	return median, medianOf(dev)
+}
+
+func medianOf(values []float64) float64 {
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	n := len(sorted)
+	if n%2 == 1 {
+		return sorted[n/2]
+	}
+	return (sorted[n/2-1] + sorted[n/2]) / 2
+}
+
+// robustSigma는 MAD를 정규분포 표준편차로 환산합니다.
+// 값이 거의 일정해 MAD가 0이면 중앙값의 1%(최소 1e-6)를 하한으로 사용합니다.
+func robustSigma(median, mad float64) float64 {
+	return math.Max(mad*madToSigma, math.Max(math.Abs(median)*0.01, 1e-6))
+}
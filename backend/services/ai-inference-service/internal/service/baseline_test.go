@@ -0,0 +1,198 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/manpasik/backend/shared/clients"
+)
+
+// fakeBaselineRepo는 기준선을 복사해 보관합니다.
+type fakeBaselineRepo struct {
+	data map[string]Baseline
+}
+
+func (r *fakeBaselineRepo) Get(_ context.Context, userID, biomarker string) (*Baseline, error) {
+	b, ok := r.data[userID+"|"+biomarker]
+	if !ok {
+		return nil, nil
+	}
+	b.Recent = append([]float64(nil), b.Recent...)
+	return &b, nil
+}
+
+func (r *fakeBaselineRepo) Save(_ context.Context, b *Baseline) error {
+	cp := *b
+	cp.Recent = append([]float64(nil), b.Recent...)
+	r.data[b.UserID+"|"+b.Biomarker] = cp
+	return nil
+}
+
+// fakeAnomalyPublisher는 발행된 이상 이벤트를 보관합니다. failures만큼 발행을 실패시킵니다.
+type fakeAnomalyPublisher struct {
+	events   []*BaselineAnomaly
+	failures int
+}
+
+func (p *fakeAnomalyPublisher) PublishAnomalyDetected(_ context.Context, a *BaselineAnomaly) error {
+	if p.failures > 0 {
+		p.failures--
+		return errors.New("broker unavailable")
+	}
+	p.events = append(p.events, a)
+	return nil
+}
+
+type baselineHarness struct {
+	svc    *InferenceService
+	client *fakeMeasurementClient
+	events *fakeAnomalyPublisher
+	seq    int
+}
+
+func newBaselineHarness() *baselineHarness {
+	client := newFakeMeasurementClient()
+	pub := &fakeAnomalyPublisher{}
+	svc := NewInferenceService(newFakeAnalysisRepo(), newFakeHealthScoreRepo(),
+		WithMeasurementClient(client),
+		WithBaselineRepository(&fakeBaselineRepo{data: make(map[string]Baseline)}),
+		WithAnomalyEventPublisher(pub),
+		WithClock(func() time.Time { return testNow }))
+	return &baselineHarness{svc: svc, client: client, events: pub}
+}
+
+// measure는 한 시간 간격으로 user-1의 혈당 측정을 완료 처리합니다.
+func (h *baselineHarness) measure(t *testing.T, value, confidence float64) (string, []*BaselineAnomaly) {
+	t.Helper()
+	h.seq++
+	id := fmt.Sprintf("sess-%d", h.seq)
+	h.client.details[id] = &clients.MeasurementDetail{
+		SessionID: id, UserID: "user-1",
+		Readings: []clients.MeasurementSummary{{
+			BiomarkerID: "glucose", Value: value, Unit: "mg/dL", Confidence: confidence,
+			MeasuredAt: testNow.Add(time.Duration(h.seq) * time.Hour).Format(time.RFC3339),
+		}},
+	}
+	anomalies, err := h.svc.ProcessMeasurementCompleted(context.Background(), "user-1", id)
+	if err != nil {
+		t.Fatalf("ProcessMeasurementCompleted 실패: %v", err)
+	}
+	return id, anomalies
+}
+
+// warmUp은 100 ± 1 mg/dL의 안정적인 기준선을 만듭니다 (중앙값 100, MAD 0.5).
+func (h *baselineHarness) warmUp(t *testing.T, n int) {
+	t.Helper()
+	offsets := []float64{0, 0.5, -0.5, 1, -1}
+	for i := 0; i < n; i++ {
+		if _, a := h.measure(t, 100+offsets[i%len(offsets)], 0.95); len(a) != 0 {
+			t.Fatalf("안정 구간에서 이상이 판정되면 안 됩니다: %+v", a[0])
+		}
+	}
+}
+
+func TestBaseline_급변_감지(t *testing.T) {
+	h := newBaselineHarness()
+	h.warmUp(t, 10)
+
+	id, anomalies := h.measure(t, 130, 0.95)
+	if len(anomalies) != 1 || anomalies[0].Kind != AnomalyKindSpike || anomalies[0].Severity != SeverityCritical {
+		t.Fatalf("critical 급변이 판정되어야 합니다: %+v", anomalies)
+	}
+	a := anomalies[0]
+	if a.MeasurementID != id || a.Biomarker != "blood_glucose" || a.Median != 100 || a.MAD != 0.5 || a.UserID != "user-1" {
+		t.Errorf("이상 정보 불일치: %+v", a)
+	}
+	if len(h.events.events) != 1 {
+		t.Errorf("ai.anomaly_detected 이벤트가 발행되어야 합니다: %d", len(h.events.events))
+	}
+}
+
+func TestBaseline_발행_실패_시_재전달에서_다시_발행(t *testing.T) {
+	h := newBaselineHarness()
+	h.warmUp(t, 10)
+	before, _ := h.svc.baselines.Get(context.Background(), "user-1", "blood_glucose")
+
+	h.events.failures = 1
+	h.seq++
+	id := fmt.Sprintf("sess-%d", h.seq)
+	h.client.details[id] = &clients.MeasurementDetail{
+		SessionID: id, UserID: "user-1",
+		Readings: []clients.MeasurementSummary{{
+			BiomarkerID: "glucose", Value: 130, Unit: "mg/dL", Confidence: 0.95,
+			MeasuredAt: testNow.Add(time.Duration(h.seq) * time.Hour).Format(time.RFC3339),
+		}},
+	}
+	if _, err := h.svc.ProcessMeasurementCompleted(context.Background(), "user-1", id); err == nil {
+		t.Fatal("발행 실패가 오류로 반환되어야 합니다")
+	}
+	after, _ := h.svc.baselines.Get(context.Background(), "user-1", "blood_glucose")
+	if after.Count != before.Count || !after.LastMeasuredAt.Equal(before.LastMeasuredAt) {
+		t.Fatalf("발행 실패 시 기준선이 저장되면 안 됩니다: before=%d after=%d", before.Count, after.Count)
+	}
+
+	// 재전달 시 같은 이상이 다시 판정·발행됨
+	anomalies, err := h.svc.ProcessMeasurementCompleted(context.Background(), "user-1", id)
+	if err != nil {
+		t.Fatalf("재전달 처리 실패: %v", err)
+	}
+	if len(anomalies) != 1 || len(h.events.events) != 1 || h.events.events[0].MeasurementID != id {
+		t.Fatalf("재전달에서 이상 이벤트가 발행되어야 합니다: anomalies=%d events=%d", len(anomalies), len(h.events.events))
+	}
+}
+
+func TestBaseline_지속_변화_감지(t *testing.T) {
+	h := newBaselineHarness()
+	h.warmUp(t, 20)
+
+	shiftAt := 0
+	for i := 1; i <= 8; i++ {
+		_, anomalies := h.measure(t, 103, 0.95) // z≈3, 급변 기준 미만
+		for _, a := range anomalies {
+			if a.Kind == AnomalyKindSpike {
+				t.Fatalf("완만한 이동은 급변이 아닙니다: %+v", a)
+			}
+			if shiftAt != 0 {
+				t.Fatalf("지속 변화는 한 번만 발행되어야 합니다 (%d번째): %+v", i, a)
+			}
+			shiftAt = i
+		}
+	}
+	if shiftAt < shiftMinRun {
+		t.Errorf("지속 변화가 %d번째 측정에서 감지됨, %d번째 이후여야 합니다", shiftAt, shiftMinRun)
+	}
+}
+
+func TestBaseline_학습_중복_저품질(t *testing.T) {
+	h := newBaselineHarness()
+
+	// 기준선 학습 중에는 큰 값도 판정하지 않음
+	h.warmUp(t, minBaselineSamples-1)
+	if _, a := h.measure(t, 160, 0.95); len(a) != 0 {
+		t.Fatalf("학습 구간에서는 판정하지 않아야 합니다: %+v", a)
+	}
+	h.warmUp(t, 5)
+
+	// QC 반영 신뢰도가 낮은 측정은 기준선에 반영하지 않음
+	before, _ := h.svc.baselines.Get(context.Background(), "user-1", "blood_glucose")
+	if _, a := h.measure(t, 300, 0.3); len(a) != 0 {
+		t.Errorf("저품질 측정은 판정하지 않아야 합니다: %+v", a)
+	}
+
+	// 같은 측정이 다시 전달되면 무시
+	id, _ := h.measure(t, 100, 0.95)
+	if _, err := h.svc.ProcessMeasurementCompleted(context.Background(), "user-1", id); err != nil {
+		t.Fatalf("재전달 처리 실패: %v", err)
+	}
+	after, _ := h.svc.baselines.Get(context.Background(), "user-1", "blood_glucose")
+	if after.Count != before.Count+1 {
+		t.Errorf("저품질·중복 측정은 반영되지 않아야 합니다: before=%d after=%d", before.Count, after.Count)
+	}
+
+	if _, err := h.svc.ProcessMeasurementCompleted(context.Background(), "user-2", id); err == nil {
+		t.Error("다른 사용자의 측정은 거부되어야 합니다")
+	}
+}
@@ -0,0 +1,365 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/manpasik/backend/services/ai-inference-service/internal/llm"
+	"github.com/manpasik/backend/shared/clients"
+	apperrors "github.com/manpasik/backend/shared/errors"
+)
+
+const (
+	chatHistoryLimit    = 20   // 프롬프트에 포함할 최근 대화 메시지 수
+	chatContextReadings = 10   // 컨텍스트에 포함할 최근 측정값 수
+	maxChatMessageRunes = 2000 // 사용자 메시지 최대 길이
+	chatTitleRunes      = 40   // 첫 메시지로 만드는 세션 제목 길이
+)
+
+// chatSystemPrompt는 건강 상담 대화용 시스템 프롬프트입니다. 사용자 건강 데이터가 뒤에 붙습니다.
+const chatSystemPrompt = `당신은 만파식(ManPaSik) 건강 상담 AI 어시스턴트입니다.
+아래 [사용자 건강 데이터]와 이전 대화를 참고해 사용자의 질문에 한국어로 답합니다.
+다음 규칙을 따르세요:
+1. 의학적 진단이나 처방은 하지 않습니다. 참고 정보임을 명시합니다.
+2. 데이터에 없는 수치를 지어내지 않습니다.
+3. 위험 수치나 응급 증상이 언급되면 전문의 상담 또는 응급실 방문을 권합니다.
+4. 간결하고 이해하기 쉬운 표현을 사용합니다.
+5. [참고 자료]의 내용을 근거로 답할 때는 해당 문장 끝에 [1]처럼 자료 번호를 표기합니다. 자료에 없는 기록을 인용하지 않습니다.`
+
+// citationPattern은 응답 본문의 자료 인용 번호 [n]입니다.
+var citationPattern = regexp.MustCompile(`\[(\d{1,2})\]`)
+
+// ChatSession은 사용자의 AI 대화 세션(스레드)입니다.
+type ChatSession struct {
+	SessionID  string
+	UserID     string
+	Title      string
+	TokensUsed int // 세션 누적 LLM 토큰 사용량
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// ChatTurn은 대화 세션의 메시지 하나입니다.
+type ChatTurn struct {
+	MessageID  string
+	SessionID  string
+	Role       string // "user", "assistant"
+	Content    string
+	TokensUsed int    // assistant 응답 생성에 사용한 토큰
+	Provider   string // assistant 응답을 생성한 프로바이더/모델
+	Model      string
+	CreatedAt  time.Time
+}
+
+// ChatSource는 응답이 인용한 사용자 건강 데이터 출처입니다.
+type ChatSource struct {
+	Index      int    // 응답 본문의 인용 번호 [n]
+	SourceType string // RAGSourceHealthRecord 등
+	SourceID   string
+	Title      string
+	RecordedAt time.Time
+}
+
+// ChatReply는 StreamChat 완료 결과입니다.
+type ChatReply struct {
+	SessionID  string
+	Content    string // 출처 목록을 포함한 최종 응답
+	TokensUsed int
+	Sources    []ChatSource
+}
+
+// ConversationRepository는 AI 대화 세션·메시지 저장소입니다.
+type ConversationRepository interface {
+	CreateSession(ctx context.Context, session *ChatSession) error
+	// GetSession은 세션을 조회합니다. 없으면 nil을 반환합니다.
+	GetSession(ctx context.Context, sessionID string) (*ChatSession, error)
+	// AppendMessage는 메시지를 추가하고 세션의 누적 토큰과 갱신 시각을 반영합니다.
+	AppendMessage(ctx context.Context, turn *ChatTurn) error
+	// RecentMessages는 세션의 최근 limit개 메시지를 시간순으로 반환합니다.
+	RecentMessages(ctx context.Context, sessionID string, limit int) ([]*ChatTurn, error)
+}
+
+// WithConversationRepository는 대화 저장소를 주입합니다.
+// 설정되지 않으면 StreamChat은 ErrServiceUnavailable을 반환합니다.
+func WithConversationRepository(r ConversationRepository) InferenceOption {
+	return func(s *InferenceService) {
+		s.conversations = r
+	}
+}
+
+// StreamChat은 최근 대화와 사용자 건강 데이터(최신 건강 점수, 최근 또는 지정한 측정)로 구성한
+// 컨텍스트로 LLM 응답을 생성해 조각 단위로 onDelta에 전달하고, 응답이 생성되면 사용자 메시지와
+// 응답을 대화 세션에 함께 기록합니다. 응답 생성에 실패하면 세션과 메시지를 남기지 않습니다.
+//
+// 검색기(WithHealthRetriever)가 설정되면 사용자가 동의한 건강 기록·측정 요약·처방·코칭 메시지 중
+// 질문과 관련된 자료를 [참고 자료]로 제공하고, 응답이 인용한 자료의 출처 목록을 마지막 조각으로 덧붙입니다.
+//
+// sessionID가 비어있으면 새 세션을 만들고, onDelta에는 새 세션 ID가 함께 전달됩니다.
+// 스트리밍을 지원하지 않는 LLM 클라이언트는 전체 응답을 한 조각으로 전달합니다.
+func (s *InferenceService) StreamChat(ctx context.Context, userID, sessionID, message string, measurementIDs []string, onDelta func(sessionID, delta string) error) (*ChatReply, error) {
+	message = strings.TrimSpace(message)
+	if userID == "" {
+		return nil, apperrors.New(apperrors.ErrInvalidInput, "user_id is required")
+	}
+	if message == "" {
+		return nil, apperrors.New(apperrors.ErrInvalidInput, "message is required")
+	}
+	if utf8.RuneCountInString(message) > maxChatMessageRunes {
+		return nil, apperrors.New(apperrors.ErrInvalidInput, fmt.Sprintf("message must be at most %d characters", maxChatMessageRunes))
+	}
+	if s.llmClient == nil {
+		return nil, apperrors.New(apperrors.ErrServiceUnavailable, "AI 채팅이 비활성화되어 있습니다")
+	}
+	if s.conversations == nil {
+		return nil, apperrors.New(apperrors.ErrServiceUnavailable, "대화 저장소가 설정되지 않았습니다")
+	}
+
+	session, isNew, err := s.openChatSession(ctx, userID, sessionID, message)
+	if err != nil {
+		return nil, err
+	}
+	history, err := s.conversations.RecentMessages(ctx, session.SessionID, chatHistoryLimit)
+	if err != nil {
+		return nil, apperrors.New(apperrors.ErrInternal, "대화 기록 조회에 실패했습니다")
+	}
+
+	messages := make([]llm.ChatMessage, 0, len(history)+1)
+	for _, t := range history {
+		messages = append(messages, llm.ChatMessage{Role: t.Role, Content: t.Content})
+	}
+	messages = append(messages, llm.ChatMessage{Role: "user", Content: message})
+	systemPrompt := chatSystemPrompt + "\n\n" + s.buildChatContext(ctx, userID, measurementIDs)
+	references := s.retrieveReferences(ctx, userID, message)
+	if len(references) > 0 {
+		systemPrompt += "\n" + formatReferences(references)
+	}
+
+	askedAt := s.now()
+	llmCtx := llm.WithFeature(llm.WithUser(ctx, userID), llm.FeatureChat)
+	emit := func(delta string) error { return onDelta(session.SessionID, delta) }
+	var resp *llm.ChatResponse
+	if sc, ok := s.llmClient.(llm.StreamingClient); ok {
+		resp, err = sc.ChatStream(llmCtx, systemPrompt, messages, emit)
+	} else if resp, err = s.llmClient.Chat(llmCtx, systemPrompt, messages); err == nil {
+		err = emit(resp.Content)
+	}
+	if err != nil {
+		var appErr *apperrors.AppError
+		if errors.As(err, &appErr) {
+			return nil, appErr
+		}
+		return nil, apperrors.New(apperrors.ErrServiceUnavailable, "AI 응답 생성에 실패했습니다").WithDetails(err.Error())
+	}
+
+	content := resp.Content
+	sources := citedSources(content, references)
+	if len(sources) > 0 {
+		footer := formatSourceFooter(sources)
+		if err := emit(footer); err != nil {
+			return nil, err
+		}
+		content += footer
+	}
+
+	// 새 세션과 사용자 메시지는 응답이 생성된 뒤 응답과 함께 저장합니다. 호출이 실패하면 아무것도
+	// 남기지 않아 재시도해도 기록에 사용자 메시지가 연달아 쌓이지 않습니다.
+	if isNew {
+		if err := s.conversations.CreateSession(ctx, session); err != nil {
+			return nil, apperrors.New(apperrors.ErrInternal, "대화 세션 생성에 실패했습니다")
+		}
+	}
+	if err := s.conversations.AppendMessage(ctx, &ChatTurn{
+		MessageID: uuid.New().String(),
+		SessionID: session.SessionID,
+		Role:      "user",
+		Content:   message,
+		CreatedAt: askedAt,
+	}); err != nil {
+		return nil, apperrors.New(apperrors.ErrInternal, "대화 저장에 실패했습니다")
+	}
+	if err := s.conversations.AppendMessage(ctx, &ChatTurn{
+		MessageID:  uuid.New().String(),
+		SessionID:  session.SessionID,
+		Role:       "assistant",
+		Content:    content,
+		TokensUsed: resp.TokensUsed,
+		Provider:   resp.Provider,
+		Model:      resp.Model,
+		CreatedAt:  s.now(),
+	}); err != nil {
+		return nil, apperrors.New(apperrors.ErrInternal, "대화 저장에 실패했습니다")
+	}
+
+	return &ChatReply{SessionID: session.SessionID, Content: content, TokensUsed: resp.TokensUsed, Sources: sources}, nil
+}
+
+// openChatSession은 기존 세션을 조회하거나(소유자 확인) 첫 메시지로 새 세션을 만듭니다.
+// 새 세션은 저장하지 않고 isNew로 알려, 응답이 생성된 뒤 저장하도록 합니다.
+func (s *InferenceService) openChatSession(ctx context.Context, userID, sessionID, firstMessage string) (session *ChatSession, isNew bool, err error) {
+	if sessionID != "" {
+		session, err := s.conversations.GetSession(ctx, sessionID)
+		if err != nil {
+			return nil, false, apperrors.New(apperrors.ErrInternal, "대화 세션 조회에 실패했습니다")
+		}
+		// 다른 사용자의 세션은 존재 여부를 드러내지 않음
+		if session == nil || session.UserID != userID {
+			return nil, false, apperrors.New(apperrors.ErrNotFound, "대화 세션을 찾을 수 없습니다")
+		}
+		return session, false, nil
+	}
+
+	title := firstMessage
+	if utf8.RuneCountInString(title) > chatTitleRunes {
+		title = string([]rune(title)[:chatTitleRunes]) + "…"
+	}
+	now := s.now()
+	return &ChatSession{
+		SessionID: uuid.New().String(),
+		UserID:    userID,
+		Title:     title,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}, true, nil
+}
+
+// buildChatContext는 최신 건강 점수와 측정값으로 [사용자 건강 데이터] 블록을 만듭니다.
+// 조회에 실패한 항목은 생략하며, 대화 자체를 막지 않습니다.
+func (s *InferenceService) buildChatContext(ctx context.Context, userID string, measurementIDs []string) string {
+	var sb strings.Builder
+	sb.WriteString("[사용자 건강 데이터]\n")
+	empty := true
+
+	if score, err := s.healthScoreRepo.FindLatestByUserID(ctx, userID); err == nil && score != nil {
+		empty = false
+		sb.WriteString(fmt.Sprintf("건강 점수: %.1f/100 (추세: %s, 산출: %s)\n",
+			score.OverallScore, score.Trend, score.CalculatedAt.Format("2006-01-02")))
+		for _, spec := range healthCategories {
+			if sc, ok := score.CategoryScores[spec.name]; ok {
+				sb.WriteString(fmt.Sprintf("- %s: %.1f\n", spec.name, sc))
+			}
+		}
+	}
+
+	if readings := s.chatReadings(ctx, userID, measurementIDs); len(readings) > 0 {
+		empty = false
+		sb.WriteString("측정값:\n")
+		for _, r := range readings {
+			b := ClassifyReading(r)
+			sb.WriteString(fmt.Sprintf("- %s: %.1f %s (%s", r.BiomarkerID, r.Value, r.Unit, b.Classification))
+			if b.ReferenceRange != "" {
+				sb.WriteString(", 참고범위 " + b.ReferenceRange)
+			}
+			if r.MeasuredAt != "" {
+				sb.WriteString(", " + r.MeasuredAt)
+			}
+			sb.WriteString(")\n")
+		}
+	}
+
+	if empty {
+		sb.WriteString("등록된 건강 점수나 측정 데이터가 없습니다.\n")
+	}
+	return sb.String()
+}
+
+// chatReadings는 지정한 측정(본인 것만) 또는 최근 측정값을 조회합니다.
+func (s *InferenceService) chatReadings(ctx context.Context, userID string, measurementIDs []string) []clients.MeasurementSummary {
+	if s.measurements == nil {
+		return nil
+	}
+	if len(measurementIDs) == 0 {
+		readings, err := s.measurements.GetLatestMeasurements(ctx, userID, chatContextReadings)
+		if err != nil {
+			return nil
+		}
+		return readings
+	}
+
+	var readings []clients.MeasurementSummary
+	for _, id := range measurementIDs {
+		detail, err := s.measurements.GetMeasurement(ctx, id)
+		if err != nil || detail == nil || detail.UserID != userID {
+			continue
+		}
+		readings = append(readings, detail.Readings...)
+	}
+	return readings
+}
+
+// retrieveReferences는 질문과 관련된 동의 자료를 찾습니다. 검색에 실패하면 자료 없이 답하며 대화를 막지 않습니다.
+func (s *InferenceService) retrieveReferences(ctx context.Context, userID, query string) []*RetrievedSnippet {
+	if s.retriever == nil {
+		return nil
+	}
+	refs, err := s.retriever.Retrieve(ctx, userID, query)
+	if err != nil {
+		return nil
+	}
+	return refs
+}
+
+// formatReferences는 자료를 번호가 붙은 [참고 자료] 블록으로 만듭니다. 번호는 1부터 시작합니다.
+func formatReferences(refs []*RetrievedSnippet) string {
+	var sb strings.Builder
+	sb.WriteString("[참고 자료]\n")
+	for i, ref := range refs {
+		sb.WriteString(fmt.Sprintf("[%d] %s\n%s\n", i+1, sourceHeading(ref.Snippet), ref.Snippet.Text))
+	}
+	return sb.String()
+}
+
+// citedSources는 응답이 [n]으로 인용한 자료의 출처를 번호순으로 반환합니다.
+// 자료를 제공했지만 인용 표기가 없으면 제공한 자료 전체를 출처로 반환합니다.
+func citedSources(content string, refs []*RetrievedSnippet) []ChatSource {
+	if len(refs) == 0 {
+		return nil
+	}
+	cited := make(map[int]bool)
+	for _, m := range citationPattern.FindAllStringSubmatch(content, -1) {
+		if n, err := strconv.Atoi(m[1]); err == nil && n >= 1 && n <= len(refs) {
+			cited[n] = true
+		}
+	}
+	var sources []ChatSource
+	for i, ref := range refs {
+		if len(cited) > 0 && !cited[i+1] {
+			continue
+		}
+		sources = append(sources, ChatSource{
+			Index:      i + 1,
+			SourceType: ref.Snippet.SourceType,
+			SourceID:   ref.Snippet.SourceID,
+			Title:      ref.Snippet.Title,
+			RecordedAt: ref.Snippet.RecordedAt,
+		})
+	}
+	return sources
+}
+
+// formatSourceFooter는 응답 끝에 붙이는 출처 목록입니다.
+func formatSourceFooter(sources []ChatSource) string {
+	var sb strings.Builder
+	sb.WriteString("\n\n출처:")
+	for _, src := range sources {
+		sb.WriteString(fmt.Sprintf("\n[%d] %s", src.Index, sourceHeading(&HealthSnippet{
+			SourceType: src.SourceType, Title: src.Title, RecordedAt: src.RecordedAt,
+		})))
+	}
+	return sb.String()
+}
+
+// sourceHeading은 "건강 기록 · 혈액검사 결과 (2026-03-01)" 형식의 출처 표시입니다.
+func sourceHeading(sn *HealthSnippet) string {
+	heading := sourceLabel(sn.SourceType) + " · " + sn.Title
+	if !sn.RecordedAt.IsZero() {
+		heading += " (" + sn.RecordedAt.Format("2006-01-02") + ")"
+	}
+	return heading
+}
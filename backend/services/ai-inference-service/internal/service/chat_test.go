@@ -0,0 +1,292 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/manpasik/backend/services/ai-inference-service/internal/llm"
+	apperrors "github.com/manpasik/backend/shared/errors"
+)
+
+// fakeConversationRepo는 인메모리 대화 저장소입니다.
+type fakeConversationRepo struct {
+	mu       sync.Mutex
+	sessions map[string]*ChatSession
+	messages map[string][]*ChatTurn
+}
+
+func newFakeConversationRepo() *fakeConversationRepo {
+	return &fakeConversationRepo{sessions: map[string]*ChatSession{}, messages: map[string][]*ChatTurn{}}
+}
+
+func (r *fakeConversationRepo) CreateSession(_ context.Context, session *ChatSession) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.sessions[session.SessionID] = session
+	return nil
+}
+
+func (r *fakeConversationRepo) GetSession(_ context.Context, sessionID string) (*ChatSession, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.sessions[sessionID], nil
+}
+
+func (r *fakeConversationRepo) AppendMessage(_ context.Context, turn *ChatTurn) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.messages[turn.SessionID] = append(r.messages[turn.SessionID], turn)
+	if s, ok := r.sessions[turn.SessionID]; ok {
+		s.TokensUsed += turn.TokensUsed
+		s.UpdatedAt = turn.CreatedAt
+	}
+	return nil
+}
+
+func (r *fakeConversationRepo) RecentMessages(_ context.Context, sessionID string, limit int) ([]*ChatTurn, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	msgs := r.messages[sessionID]
+	if len(msgs) > limit {
+		msgs = msgs[len(msgs)-limit:]
+	}
+	return append([]*ChatTurn(nil), msgs...), nil
+}
+
+// fakeStreamingLLM은 응답을 정해진 조각으로 나눠 전달하고 받은 프롬프트를 기록합니다.
+type fakeStreamingLLM struct {
+	chunks       []string
+	err          error
+	systemPrompt string
+	messages     []llm.ChatMessage
+}
+
+func (f *fakeStreamingLLM) Chat(ctx context.Context, systemPrompt string, messages []llm.ChatMessage) (*llm.ChatResponse, error) {
+	return f.ChatStream(ctx, systemPrompt, messages, func(string) error { return nil })
+}
+
+func (f *fakeStreamingLLM) ChatStream(_ context.Context, systemPrompt string, messages []llm.ChatMessage, onDelta func(string) error) (*llm.ChatResponse, error) {
+	f.systemPrompt = systemPrompt
+	f.messages = messages
+	if f.err != nil {
+		return nil, f.err
+	}
+	for _, c := range f.chunks {
+		if err := onDelta(c); err != nil {
+			return nil, err
+		}
+	}
+	return &llm.ChatResponse{Content: strings.Join(f.chunks, ""), TokensUsed: 42, Provider: "openai", Model: "gpt-4o"}, nil
+}
+
+func newChatTestService(client llm.LLMClient) (*InferenceService, *fakeConversationRepo, *fakeHealthScoreRepo) {
+	repo := newFakeConversationRepo()
+	scores := newFakeHealthScoreRepo()
+	svc := NewInferenceService(newFakeAnalysisRepo(), scores,
+		WithLLMClient(client), WithConversationRepository(repo),
+		WithMeasurementClient(newFakeMeasurementClient()), WithClock(func() time.Time { return testNow }))
+	return svc, repo, scores
+}
+
+func TestStreamChat_NewSessionStreamsAndPersists(t *testing.T) {
+	client := &fakeStreamingLLM{chunks: []string{"혈당은 ", "정상 범위입니다."}}
+	svc, repo, _ := newChatTestService(client)
+
+	var deltas []string
+	var streamedSession string
+	reply, err := svc.StreamChat(context.Background(), "user-1", "", "내 혈당 괜찮아?", nil, func(sessionID, delta string) error {
+		streamedSession = sessionID
+		deltas = append(deltas, delta)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if reply.SessionID == "" || streamedSession != reply.SessionID {
+		t.Errorf("session id: reply=%q streamed=%q", reply.SessionID, streamedSession)
+	}
+	if len(deltas) != 2 || reply.Content != "혈당은 정상 범위입니다." || reply.TokensUsed != 42 {
+		t.Errorf("unexpected reply %+v (deltas %v)", reply, deltas)
+	}
+
+	session := repo.sessions[reply.SessionID]
+	if session == nil || session.UserID != "user-1" || session.Title != "내 혈당 괜찮아?" {
+		t.Fatalf("unexpected session %+v", session)
+	}
+	if session.TokensUsed != 42 {
+		t.Errorf("session tokens = %d, want 42", session.TokensUsed)
+	}
+	turns := repo.messages[reply.SessionID]
+	if len(turns) != 2 || turns[0].Role != "user" || turns[1].Role != "assistant" || turns[1].Model != "gpt-4o" {
+		t.Errorf("unexpected stored turns: %+v", turns)
+	}
+}
+
+func TestStreamChat_ContinuesSessionWithHistory(t *testing.T) {
+	client := &fakeStreamingLLM{chunks: []string{"네."}}
+	svc, _, _ := newChatTestService(client)
+	ctx := context.Background()
+	noop := func(string, string) error { return nil }
+
+	first, err := svc.StreamChat(ctx, "user-1", "", "첫 질문", nil, noop)
+	if err != nil {
+		t.Fatalf("first turn: %v", err)
+	}
+	second, err := svc.StreamChat(ctx, "user-1", first.SessionID, "두 번째 질문", nil, noop)
+	if err != nil {
+		t.Fatalf("second turn: %v", err)
+	}
+	if second.SessionID != first.SessionID {
+		t.Errorf("session changed: %q → %q", first.SessionID, second.SessionID)
+	}
+
+	var roles []string
+	for _, m := range client.messages {
+		roles = append(roles, m.Role+":"+m.Content)
+	}
+	want := []string{"user:첫 질문", "assistant:네.", "user:두 번째 질문"}
+	if strings.Join(roles, "|") != strings.Join(want, "|") {
+		t.Errorf("prompt messages = %v, want %v", roles, want)
+	}
+}
+
+func TestStreamChat_ContextIncludesHealthData(t *testing.T) {
+	client := &fakeStreamingLLM{chunks: []string{"ok"}}
+	svc, _, scores := newChatTestService(client)
+	_ = scores.Save(context.Background(), &HealthScore{
+		UserID: "user-1", OverallScore: 81.5, Trend: "stable",
+		CategoryScores: map[string]float64{}, CalculatedAt: testNow,
+	})
+
+	_, err := svc.StreamChat(context.Background(), "user-1", "", "요약해줘", []string{"meas-1"}, func(string, string) error { return nil })
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, want := range []string{"81.5/100", "glucose: 92.0", "cholesterol_total: 215.0"} {
+		if !strings.Contains(client.systemPrompt, want) {
+			t.Errorf("system prompt missing %q:\n%s", want, client.systemPrompt)
+		}
+	}
+}
+
+func TestStreamChat_IgnoresOtherUsersMeasurements(t *testing.T) {
+	client := &fakeStreamingLLM{chunks: []string{"ok"}}
+	svc, _, _ := newChatTestService(client)
+
+	_, err := svc.StreamChat(context.Background(), "user-2", "", "요약해줘", []string{"meas-1"}, func(string, string) error { return nil })
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Contains(client.systemPrompt, "glucose") {
+		t.Errorf("system prompt leaked another user's measurement:\n%s", client.systemPrompt)
+	}
+}
+
+func TestStreamChat_OtherUsersSessionNotFound(t *testing.T) {
+	client := &fakeStreamingLLM{chunks: []string{"ok"}}
+	svc, _, _ := newChatTestService(client)
+	noop := func(string, string) error { return nil }
+
+	first, err := svc.StreamChat(context.Background(), "user-1", "", "질문", nil, noop)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_, err = svc.StreamChat(context.Background(), "user-2", first.SessionID, "훔쳐보기", nil, noop)
+	var appErr *apperrors.AppError
+	if !errors.As(err, &appErr) || appErr.Code != apperrors.ErrNotFound {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestStreamChat_NonStreamingClientSendsSingleChunk(t *testing.T) {
+	client := &mockLLMClient{response: &llm.ChatResponse{Content: "전체 응답", TokensUsed: 7}}
+	svc, _, _ := newChatTestService(client)
+
+	var deltas []string
+	reply, err := svc.StreamChat(context.Background(), "user-1", "", "질문", nil, func(_, d string) error {
+		deltas = append(deltas, d)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(deltas) != 1 || deltas[0] != "전체 응답" || reply.TokensUsed != 7 {
+		t.Errorf("unexpected deltas %v reply %+v", deltas, reply)
+	}
+}
+
+func TestStreamChat_LLMErrorNotPersisted(t *testing.T) {
+	client := &fakeStreamingLLM{err: errors.New("upstream down")}
+	svc, repo, _ := newChatTestService(client)
+
+	_, err := svc.StreamChat(context.Background(), "user-1", "", "질문", nil, func(string, string) error { return nil })
+	var appErr *apperrors.AppError
+	if !errors.As(err, &appErr) || appErr.Code != apperrors.ErrServiceUnavailable {
+		t.Fatalf("expected ErrServiceUnavailable, got %v", err)
+	}
+	if len(repo.sessions) != 0 || len(repo.messages) != 0 {
+		t.Errorf("nothing should be stored after failure: sessions=%d messages=%d", len(repo.sessions), len(repo.messages))
+	}
+}
+
+func TestStreamChat_RetryAfterFailureKeepsTurnsAlternating(t *testing.T) {
+	client := &fakeStreamingLLM{chunks: []string{"첫 답변"}}
+	svc, repo, _ := newChatTestService(client)
+	ctx := context.Background()
+	noop := func(string, string) error { return nil }
+
+	first, err := svc.StreamChat(ctx, "user-1", "", "첫 질문", nil, noop)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	client.err = errors.New("upstream down")
+	if _, err := svc.StreamChat(ctx, "user-1", first.SessionID, "두 번째 질문", nil, noop); err == nil {
+		t.Fatal("expected error from failing LLM")
+	}
+	client.err = nil
+	if _, err := svc.StreamChat(ctx, "user-1", first.SessionID, "두 번째 질문", nil, noop); err != nil {
+		t.Fatalf("retry failed: %v", err)
+	}
+
+	turns := repo.messages[first.SessionID]
+	if len(turns) != 4 {
+		t.Fatalf("expected 4 turns, got %d", len(turns))
+	}
+	for i, turn := range turns {
+		want := "user"
+		if i%2 == 1 {
+			want = "assistant"
+		}
+		if turn.Role != want {
+			t.Errorf("turn %d: expected %s, got %s", i, want, turn.Role)
+		}
+	}
+}
+
+func TestStreamChat_Validation(t *testing.T) {
+	svc, _, _ := newChatTestService(&fakeStreamingLLM{})
+	noop := func(string, string) error { return nil }
+	cases := map[string]struct{ userID, message string }{
+		"empty user":    {"", "hi"},
+		"empty message": {"user-1", "   "},
+		"too long":      {"user-1", strings.Repeat("가", maxChatMessageRunes+1)},
+	}
+	for name, tc := range cases {
+		if _, err := svc.StreamChat(context.Background(), tc.userID, "", tc.message, nil, noop); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func TestStreamChat_LLMDisabled(t *testing.T) {
+	svc := NewInferenceService(newFakeAnalysisRepo(), newFakeHealthScoreRepo(), WithConversationRepository(newFakeConversationRepo()))
+	_, err := svc.StreamChat(context.Background(), "user-1", "", "hi", nil, func(string, string) error { return nil })
+	var appErr *apperrors.AppError
+	if !errors.As(err, &appErr) || appErr.Code != apperrors.ErrServiceUnavailable {
+		t.Errorf("expected ErrServiceUnavailable, got %v", err)
+	}
+}
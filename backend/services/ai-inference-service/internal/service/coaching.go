@@ -0,0 +1,120 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/manpasik/backend/services/ai-inference-service/internal/llm"
+	apperrors "github.com/manpasik/backend/shared/errors"
+)
+
+// maxCoachingContextLines는 프롬프트에 포함할 컨텍스트 문장 수입니다.
+const maxCoachingContextLines = 20
+
+// coachingSystemPrompt는 코칭 메시지 개인화용 시스템 프롬프트입니다. %s에는 응답 언어 이름이 들어갑니다.
+const coachingSystemPrompt = `당신은 만파식(ManPaSik) 건강 코치입니다.
+[초안]의 수치·위험도·권장 사항을 유지하면서 [사용자 컨텍스트]를 반영해 짧은 코칭 메시지 본문을 %s로 작성합니다.
+다음 규칙을 따르세요:
+1. 의학적 진단이나 처방, 약 용량 지시는 하지 않습니다.
+2. 초안과 컨텍스트에 없는 수치를 지어내지 않습니다.
+3. 초안이 전문의 상담을 권하면 그 권고를 빼지 않습니다.
+4. 목표 진행과 연속 기록을 구체적으로 언급해 격려합니다.
+5. 제목·목록·인사말 없이 본문만 3문장 이내로 작성합니다.`
+
+// coachingLanguages는 코칭 메시지 응답 언어 코드별 이름입니다.
+var coachingLanguages = map[string]string{
+	"ko": "한국어",
+	"en": "영어(English)",
+	"ja": "일본어(日本語)",
+	"zh": "중국어(简体中文)",
+}
+
+// CoachingComposeRequest는 코칭 메시지 개인화 요청입니다.
+type CoachingComposeRequest struct {
+	UserID       string
+	CoachingType string // "daily_tip", "goal_progress" 등
+	Language     string // "ko", "en" 등 (비어 있으면 "ko")
+	DraftTitle   string
+	DraftBody    string   // 규칙 기반 초안
+	ContextLines []string // 목표·최근 측정·실천도 요약 문장
+}
+
+// CoachingComposition은 LLM이 작성한 코칭 메시지 본문입니다.
+type CoachingComposition struct {
+	Body     string
+	Provider string
+	Model    string
+	Cached   bool
+}
+
+// ComposeCoachingMessage는 규칙 기반 초안과 사용자 컨텍스트로 코칭 메시지 본문을 LLM으로 작성합니다.
+//
+// 호출은 main에서 감싼 가드레일(가명 처리·응답 안전 검토)과 사용량 집계를 그대로 거칩니다.
+// 다른 분석 기능과 달리 규칙 기반 대체 응답을 만들지 않으므로, 호출자(coaching-service)가
+// ErrServiceUnavailable을 받으면 자체 템플릿으로 대체해야 합니다.
+func (s *InferenceService) ComposeCoachingMessage(ctx context.Context, req *CoachingComposeRequest) (*CoachingComposition, error) {
+	if req == nil || req.UserID == "" {
+		return nil, apperrors.New(apperrors.ErrInvalidInput, "user_id is required")
+	}
+	if strings.TrimSpace(req.DraftBody) == "" {
+		return nil, apperrors.New(apperrors.ErrInvalidInput, "draft_body is required")
+	}
+	if s.llmClient == nil {
+		return nil, apperrors.New(apperrors.ErrServiceUnavailable, "LLM이 비활성화되어 있습니다")
+	}
+
+	language := coachingLanguage(req.Language)
+	llmCtx := llm.WithLanguage(llm.WithFeature(llm.WithUser(ctx, req.UserID), llm.FeatureCoaching), language)
+	resp, err := s.llmClient.Chat(llmCtx, fmt.Sprintf(coachingSystemPrompt, coachingLanguages[language]), []llm.ChatMessage{
+		{Role: "user", Content: buildCoachingPrompt(req)},
+	})
+	if err != nil {
+		var appErr *apperrors.AppError
+		if errors.As(err, &appErr) {
+			return nil, appErr
+		}
+		return nil, apperrors.New(apperrors.ErrServiceUnavailable, "코칭 메시지 생성에 실패했습니다").WithDetails(err.Error())
+	}
+
+	body := strings.TrimSpace(resp.Content)
+	if body == "" {
+		return nil, apperrors.New(apperrors.ErrServiceUnavailable, "LLM 응답이 비어 있습니다")
+	}
+	return &CoachingComposition{Body: body, Provider: resp.Provider, Model: resp.Model, Cached: resp.Cached}, nil
+}
+
+// coachingLanguage는 지원하는 응답 언어 코드로 정규화합니다. "en-US"는 "en"으로, 미지원 언어는 "ko"로 바꿉니다.
+func coachingLanguage(lang string) string {
+	lang = strings.ToLower(strings.TrimSpace(lang))
+	if i := strings.IndexAny(lang, "-_"); i > 0 {
+		lang = lang[:i]
+	}
+	if _, ok := coachingLanguages[lang]; ok {
+		return lang
+	}
+	return "ko"
+}
+
+// buildCoachingPrompt는 초안과 사용자 컨텍스트를 프롬프트로 변환합니다.
+func buildCoachingPrompt(req *CoachingComposeRequest) string {
+	var sb strings.Builder
+	sb.WriteString("[코칭 유형] " + req.CoachingType + "\n")
+	sb.WriteString("[초안]\n")
+	if req.DraftTitle != "" {
+		sb.WriteString("제목: " + req.DraftTitle + "\n")
+	}
+	sb.WriteString(req.DraftBody + "\n")
+	if len(req.ContextLines) > 0 {
+		sb.WriteString("[사용자 컨텍스트]\n")
+		lines := req.ContextLines
+		if len(lines) > maxCoachingContextLines {
+			lines = lines[:maxCoachingContextLines]
+		}
+		for _, line := range lines {
+			sb.WriteString("- " + line + "\n")
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,92 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/manpasik/backend/services/ai-inference-service/internal/llm"
+	apperrors "github.com/manpasik/backend/shared/errors"
+)
+
+// promptRecordingLLM은 받은 시스템 프롬프트·메시지·기능 이름을 기록하는 LLMClient입니다.
+type promptRecordingLLM struct {
+	reply    string
+	system   string
+	messages []llm.ChatMessage
+	feature  string
+}
+
+func (p *promptRecordingLLM) Chat(ctx context.Context, systemPrompt string, messages []llm.ChatMessage) (*llm.ChatResponse, error) {
+	p.system = systemPrompt
+	p.messages = messages
+	p.feature = llm.FeatureFromContext(ctx)
+	return &llm.ChatResponse{Content: p.reply, FinishReason: "stop", TokensUsed: 40, Provider: "openai", Model: "gpt-4o-mini"}, nil
+}
+
+func TestComposeCoachingMessage_UsesDraftAndContext(t *testing.T) {
+	client := &promptRecordingLLM{reply: "You kept a 5-day streak — great work! Keep walking after meals."}
+	svc := NewInferenceService(newFakeAnalysisRepo(), newFakeHealthScoreRepo(), WithLLMClient(client))
+
+	out, err := svc.ComposeCoachingMessage(context.Background(), &CoachingComposeRequest{
+		UserID:       "user-1",
+		CoachingType: "daily_tip",
+		Language:     "en-US",
+		DraftTitle:   "혈당 관리 팁",
+		DraftBody:    "식후 30분 이내에 가벼운 산책을 하세요.",
+		ContextLines: []string{"Exercise goal: 60% (streak 5 days)"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out.Body != client.reply || out.Provider != "openai" || out.Model != "gpt-4o-mini" {
+		t.Errorf("unexpected composition: %+v", out)
+	}
+	if client.feature != llm.FeatureCoaching {
+		t.Errorf("expected feature %q, got %q", llm.FeatureCoaching, client.feature)
+	}
+	if !strings.Contains(client.system, "영어(English)") {
+		t.Errorf("system prompt should request English, got %q", client.system)
+	}
+	prompt := client.messages[0].Content
+	for _, want := range []string{"[코칭 유형] daily_tip", "식후 30분 이내에", "- Exercise goal: 60% (streak 5 days)"} {
+		if !strings.Contains(prompt, want) {
+			t.Errorf("prompt missing %q:\n%s", want, prompt)
+		}
+	}
+}
+
+func TestComposeCoachingMessage_GuardrailRewritesDosage(t *testing.T) {
+	client := &promptRecordingLLM{reply: "혈당이 높으니 메트포르민 500mg을 복용하세요."}
+	guarded := llm.NewGuardrail(client, llm.NewRedactor("test-secret"))
+	svc := NewInferenceService(newFakeAnalysisRepo(), newFakeHealthScoreRepo(), WithLLMClient(guarded))
+
+	out, err := svc.ComposeCoachingMessage(context.Background(), &CoachingComposeRequest{
+		UserID:    "user-1",
+		DraftBody: "공복혈당이 기준보다 높습니다. 전문의 상담을 권장합니다.",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Contains(out.Body, "500mg") {
+		t.Errorf("dosage instruction should be rewritten, got %q", out.Body)
+	}
+	if strings.Contains(client.messages[0].Content, "user-1") || strings.Contains(client.system, "user-1") {
+		t.Error("user id must not reach the LLM")
+	}
+}
+
+func TestComposeCoachingMessage_Unavailable(t *testing.T) {
+	svc := newTestService()
+	_, err := svc.ComposeCoachingMessage(context.Background(), &CoachingComposeRequest{UserID: "user-1", DraftBody: "초안"})
+	var appErr *apperrors.AppError
+	if !errors.As(err, &appErr) || appErr.Code != apperrors.ErrServiceUnavailable {
+		t.Fatalf("expected ErrServiceUnavailable without LLM, got %v", err)
+	}
+
+	_, err = svc.ComposeCoachingMessage(context.Background(), &CoachingComposeRequest{UserID: "user-1"})
+	if !errors.As(err, &appErr) || appErr.Code != apperrors.ErrInvalidInput {
+		t.Fatalf("expected ErrInvalidInput without draft, got %v", err)
+	}
+}
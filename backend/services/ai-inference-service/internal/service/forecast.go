@@ -0,0 +1,446 @@
+package service
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// 트렌드 예측 방법
+const (
+	MethodLinear      = "linear"
+	MethodDampedHolt  = "damped_holt"
+	MethodHoltWinters = "holt_winters"
+)
+
+const (
+	minForecastDays     = 5    // 관측 일수가 이보다 적으면 예측하지 않음
+	minHoltDays         = 10   // 감쇠 추세(Holt) 모델 최소 계열 길이
+	seasonPeriod        = 7    // 주간 계절성
+	minSeasonalCycles   = 3    // 계절 모델에 필요한 최소 주기 수
+	minSeasonalCoverage = 0.6  // 계절 모델에 필요한 관측일 비율 (나머지는 보간)
+	dampingPhi          = 0.9  // 추세 감쇠 계수
+	intervalZ           = 1.96 // 95% 예측 구간
+)
+
+var (
+	smoothingAlphas = []float64{0.1, 0.3, 0.5, 0.7, 0.9}
+	smoothingBetas  = []float64{0.05, 0.1, 0.2, 0.3}
+	smoothingGammas = []float64{0.1, 0.3}
+)
+
+// timedValue는 시각이 있는 측정값입니다.
+type timedValue struct {
+	at    time.Time
+	value float64
+}
+
+// dailyObservation은 하루(UTC) 단위로 묶은 측정값입니다.
+type dailyObservation struct {
+	Day   time.Time
+	Mean  float64
+	Min   float64
+	Max   float64
+	Count int
+}
+
+// aggregateDaily는 불규칙한 측정 시각을 UTC 일 단위로 묶어 평균·최소·최대를 구합니다.
+func aggregateDaily(values []timedValue) []dailyObservation {
+	byDay := make(map[time.Time]*dailyObservation)
+	for _, v := range values {
+		day := truncateDay(v.at)
+		d, ok := byDay[day]
+		if !ok {
+			d = &dailyObservation{Day: day, Min: v.value, Max: v.value}
+			byDay[day] = d
+		}
+		d.Mean += v.value
+		d.Count++
+		d.Min = math.Min(d.Min, v.value)
+		d.Max = math.Max(d.Max, v.value)
+	}
+
+	result := make([]dailyObservation, 0, len(byDay))
+	for _, d := range byDay {
+		d.Mean /= float64(d.Count)
+		result = append(result, *d)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Day.Before(result[j].Day) })
+	return result
+}
+
+func truncateDay(t time.Time) time.Time {
+	return t.UTC().Truncate(24 * time.Hour)
+}
+
+// dailySeries는 첫 관측일부터 마지막 관측일까지의 일 단위 등간격 계열입니다.
+// 측정이 없는 날은 앞뒤 관측값으로 선형 보간하고 observed=false로 표시합니다.
+type dailySeries struct {
+	values   []float64
+	observed []bool
+}
+
+func newDailySeries(obs []dailyObservation) dailySeries {
+	first := obs[0].Day
+	n := int(obs[len(obs)-1].Day.Sub(first).Hours()/24) + 1
+	s := dailySeries{values: make([]float64, n), observed: make([]bool, n)}
+
+	prevIdx := -1
+	for _, o := range obs {
+		idx := int(o.Day.Sub(first).Hours() / 24)
+		s.values[idx] = o.Mean
+		s.observed[idx] = true
+		if prevIdx >= 0 {
+			for k := prevIdx + 1; k < idx; k++ {
+				frac := float64(k-prevIdx) / float64(idx-prevIdx)
+				s.values[k] = s.values[prevIdx] + frac*(o.Mean-s.values[prevIdx])
+			}
+		}
+		prevIdx = idx
+	}
+	return s
+}
+
+func (s dailySeries) head(n int) dailySeries {
+	return dailySeries{values: s.values[:n], observed: s.observed[:n]}
+}
+
+func (s dailySeries) observedCount() int {
+	c := 0
+	for _, o := range s.observed {
+		if o {
+			c++
+		}
+	}
+	return c
+}
+
+// forecaster는 일 단위 계열 예측 모델입니다.
+type forecaster interface {
+	method() string
+	// fit은 모델을 적합하고 1-스텝 적합 잔차(관측일만)의 표준편차를 반환합니다.
+	fit(s dailySeries) float64
+	// forecast는 마지막 시점에서 h(≥1)일 뒤의 예측값을 반환합니다.
+	forecast(h int) float64
+	// interval은 h일 뒤 예측 구간의 반폭을 반환합니다.
+	interval(h int, sigma float64) float64
+	// level은 마지막 시점의 적합 수준입니다.
+	level() float64
+}
+
+// candidateForecasters는 계열 길이와 관측 밀도로 적용 가능한 모델을 단순한 순서로 반환합니다.
+func candidateForecasters(s dailySeries) []forecaster {
+	candidates := []forecaster{&linearForecaster{}}
+	if len(s.values) >= minHoltDays {
+		candidates = append(candidates, &holtForecaster{})
+	}
+	if len(s.values) >= seasonPeriod*minSeasonalCycles &&
+		float64(s.observedCount())/float64(len(s.values)) >= minSeasonalCoverage {
+		candidates = append(candidates, &holtForecaster{period: seasonPeriod})
+	}
+	return candidates
+}
+
+// forecastOutcome은 선택된 모델의 예측 결과입니다.
+type forecastOutcome struct {
+	method     string
+	values     []float64 // 1..horizon일 뒤 예측값
+	halfWidths []float64 // 예측 구간 반폭
+	sigma      float64
+	level      float64
+	confidence float64
+}
+
+// forecastSeries는 후보 모델을 마지막 holdout일로 백테스트해 RMSE가 가장 낮은 모델을 고르고,
+// 전체 계열로 다시 적합해 horizon일을 예측합니다. 신뢰도는 백테스트 MAPE로 산출합니다.
+func forecastSeries(s dailySeries, horizon, holdout int) forecastOutcome {
+	n := len(s.values)
+	k := holdout
+	if k > n/4 {
+		k = n / 4
+	}
+	if k < 1 {
+		k = 1
+	}
+
+	bestIdx, bestRMSE, bestMAPE := 0, math.Inf(1), 1.0
+	train := s.head(n - k)
+	for i, f := range candidateForecasters(s) {
+		if !trainable(f, train) {
+			continue
+		}
+		f.fit(train)
+		rmse, mape, ok := backtestErrors(f, s, n-k)
+		if ok && rmse < bestRMSE {
+			bestIdx, bestRMSE, bestMAPE = i, rmse, mape
+		}
+	}
+
+	model := candidateForecasters(s)[bestIdx]
+	sigma := model.fit(s)
+	if !math.IsInf(bestRMSE, 1) {
+		sigma = math.Max(sigma, bestRMSE)
+	} else {
+		bestMAPE = 1
+	}
+
+	out := forecastOutcome{
+		method:     model.method(),
+		values:     make([]float64, horizon),
+		halfWidths: make([]float64, horizon),
+		sigma:      sigma,
+		level:      model.level(),
+		confidence: math.Round(math.Max(0, math.Min(1, 1-bestMAPE))*1000) / 1000,
+	}
+	for h := 1; h <= horizon; h++ {
+		out.values[h-1] = model.forecast(h)
+		out.halfWidths[h-1] = model.interval(h, sigma)
+	}
+	return out
+}
+
+// trainable은 백테스트 학습 구간이 모델 최소 길이를 만족하는지 확인합니다.
+func trainable(f forecaster, train dailySeries) bool {
+	switch m := f.(type) {
+	case *holtForecaster:
+		if m.period > 0 {
+			return len(train.values) >= m.period*minSeasonalCycles
+		}
+		return len(train.values) >= minHoltDays
+	default:
+		return train.observedCount() >= 3
+	}
+}
+
+// backtestErrors는 from 이후 관측일에 대한 RMSE와 MAPE를 계산합니다.
+// 실측값이 0에 가까워 MAPE를 구할 수 없으면 평균 절댓값 대비 RMSE를 사용합니다.
+func backtestErrors(f forecaster, s dailySeries, from int) (rmse, mape float64, ok bool) {
+	var sq, pct, absSum float64
+	count, pctCount := 0, 0
+	for idx := from; idx < len(s.values); idx++ {
+		if !s.observed[idx] {
+			continue
+		}
+		actual := s.values[idx]
+		e := f.forecast(idx-from+1) - actual
+		sq += e * e
+		absSum += math.Abs(actual)
+		count++
+		if math.Abs(actual) > 1e-9 {
+			pct += math.Abs(e) / math.Abs(actual)
+			pctCount++
+		}
+	}
+	if count == 0 {
+		return 0, 0, false
+	}
+	rmse = math.Sqrt(sq / float64(count))
+	if pctCount > 0 {
+		mape = pct / float64(pctCount)
+	} else if absSum > 0 {
+		mape = rmse / (absSum / float64(count))
+	} else {
+		mape = 1
+	}
+	return rmse, mape, true
+}
+
+// ============================================================================
+// 선형 추세 — 관측일만으로 최소제곱 적합 (불규칙 간격 그대로 사용)
+// ============================================================================
+
+type linearForecaster struct {
+	a, b      float64
+	n         float64
+	xMean     float64
+	sxx       float64
+	lastIndex int
+}
+
+func (f *linearForecaster) method() string { return MethodLinear }
+
+func (f *linearForecaster) fit(s dailySeries) float64 {
+	var xs, ys []float64
+	for i, v := range s.values {
+		if s.observed[i] {
+			xs = append(xs, float64(i))
+			ys = append(ys, v)
+		}
+	}
+	f.n = float64(len(xs))
+	f.lastIndex = len(s.values) - 1
+
+	var xSum, ySum float64
+	for i := range xs {
+		xSum += xs[i]
+		ySum += ys[i]
+	}
+	f.xMean = xSum / f.n
+	yMean := ySum / f.n
+
+	var sxy float64
+	f.sxx = 0
+	for i := range xs {
+		f.sxx += (xs[i] - f.xMean) * (xs[i] - f.xMean)
+		sxy += (xs[i] - f.xMean) * (ys[i] - yMean)
+	}
+	f.b = 0
+	if f.sxx > 0 {
+		f.b = sxy / f.sxx
+	}
+	f.a = yMean - f.b*f.xMean
+
+	var sse float64
+	for i := range xs {
+		e := ys[i] - (f.a + f.b*xs[i])
+		sse += e * e
+	}
+	if f.n <= 2 {
+		return 0
+	}
+	return math.Sqrt(sse / (f.n - 2))
+}
+
+func (f *linearForecaster) forecast(h int) float64 {
+	return f.a + f.b*float64(f.lastIndex+h)
+}
+
+// interval은 회귀 예측 구간 σ·√(1 + 1/n + (x-x̄)²/Sxx)를 사용합니다.
+func (f *linearForecaster) interval(h int, sigma float64) float64 {
+	x := float64(f.lastIndex + h)
+	lev := 1 + 1/f.n
+	if f.sxx > 0 {
+		lev += (x - f.xMean) * (x - f.xMean) / f.sxx
+	}
+	return intervalZ * sigma * math.Sqrt(lev)
+}
+
+func (f *linearForecaster) level() float64 {
+	return f.a + f.b*float64(f.lastIndex)
+}
+
+// ============================================================================
+// 감쇠 추세 지수평활 (period > 0이면 가법 Holt-Winters)
+// ============================================================================
+
+type holtForecaster struct {
+	period int
+
+	alpha, beta, gamma float64
+	l, b               float64
+	seasonals          []float64
+	n                  int
+}
+
+func (f *holtForecaster) method() string {
+	if f.period > 0 {
+		return MethodHoltWinters
+	}
+	return MethodDampedHolt
+}
+
+// fit은 평활 계수를 격자 탐색해 관측일 1-스텝 오차 제곱합이 가장 작은 조합을 고릅니다.
+func (f *holtForecaster) fit(s dailySeries) float64 {
+	gammas := []float64{0}
+	if f.period > 0 {
+		gammas = smoothingGammas
+	}
+
+	best := math.Inf(1)
+	bestAlpha, bestBeta, bestGamma := smoothingAlphas[0], smoothingBetas[0], gammas[0]
+	for _, a := range smoothingAlphas {
+		for _, b := range smoothingBetas {
+			for _, g := range gammas {
+				cand := holtForecaster{period: f.period, alpha: a, beta: b, gamma: g}
+				if sse, count := cand.run(s); count > 0 && sse < best {
+					best = sse
+					bestAlpha, bestBeta, bestGamma = a, b, g
+				}
+			}
+		}
+	}
+
+	f.alpha, f.beta, f.gamma = bestAlpha, bestBeta, bestGamma
+	sse, count := f.run(s)
+	if count == 0 {
+		return 0
+	}
+	return math.Sqrt(sse / float64(count))
+}
+
+// run은 계수를 고정해 계열을 평활하고 관측일 1-스텝 오차 제곱합을 반환합니다.
+func (f *holtForecaster) run(s dailySeries) (float64, int) {
+	y := s.values
+	f.n = len(y)
+	start := 1
+	if f.period > 0 {
+		m := f.period
+		var c1, c2 float64
+		for i := 0; i < m; i++ {
+			c1 += y[i]
+			c2 += y[m+i]
+		}
+		c1 /= float64(m)
+		c2 /= float64(m)
+		f.l = c1
+		f.b = (c2 - c1) / float64(m)
+		f.seasonals = make([]float64, len(y))
+		for i := 0; i < m; i++ {
+			f.seasonals[i] = y[i] - c1
+		}
+		// 첫 주기는 초기화에 사용했으므로 수준을 첫 주기 끝으로 옮김
+		f.l = c1 + f.b*float64(m-1)/2
+		start = m
+	} else {
+		f.l = y[0]
+		f.b = y[1] - y[0]
+	}
+
+	var sse float64
+	count := 0
+	for t := start; t < len(y); t++ {
+		season := 0.0
+		if f.period > 0 {
+			season = f.seasonals[t-f.period]
+		}
+		pred := f.l + dampingPhi*f.b + season
+		if s.observed[t] {
+			e := y[t] - pred
+			sse += e * e
+			count++
+		}
+		prevLevel := f.l
+		f.l = f.alpha*(y[t]-season) + (1-f.alpha)*(prevLevel+dampingPhi*f.b)
+		f.b = f.beta*(f.l-prevLevel) + (1-f.beta)*dampingPhi*f.b
+		if f.period > 0 {
+			f.seasonals[t] = f.gamma*(y[t]-f.l) + (1-f.gamma)*season
+		}
+	}
+	return sse, count
+}
+
+func (f *holtForecaster) forecast(h int) float64 {
+	trend := 0.0
+	phi := 1.0
+	for i := 1; i <= h; i++ {
+		phi *= dampingPhi
+		trend += phi
+	}
+	v := f.l + trend*f.b
+	if f.period > 0 {
+		v += f.seasonals[f.n-f.period+(h-1)%f.period]
+	}
+	return v
+}
+
+// interval은 예측 오차 분산이 지평에 비례해 커진다고 보고 σ·√h를 사용합니다.
+func (f *holtForecaster) interval(h int, sigma float64) float64 {
+	return intervalZ * sigma * math.Sqrt(float64(h))
+}
+
+func (f *holtForecaster) level() float64 {
+	if f.period > 0 {
+		return f.l + f.seasonals[f.n-1]
+	}
+	return f.l
+}
@@ -0,0 +1,382 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/manpasik/backend/shared/clients"
+	apperrors "github.com/manpasik/backend/shared/errors"
+)
+
+// 건강 점수 모델 (HealthScoreModelVersion)
+//
+// 카테고리 점수는 카테고리에 속한 특징 점수(0~100)의 가중 평균입니다.
+//   - 측정(measurement): 기간 내 바이오마커 측정값을 참조 범위로 분류해 위험도별 점수(riskPoints)를
+//     QC 반영 신뢰도로 가중 평균합니다. 바이오마커마다 가중치 1.0.
+//   - 목표(goal): 진행 중·달성 목표의 달성률(달성 시 100)을 목표 분류별로 평균합니다. 가중치 0.5.
+//   - 식사(meal): 기록된 날의 일평균 섭취 칼로리가 목표(영양 목표의 kcal 목표, 없으면 defaultDailyKcal)에서
+//     벗어난 정도(calorie_balance, 가중치 1.0)와 하루 세 끼 대비 기록 끼니 수(meal_regularity, 가중치 0.5)입니다.
+//
+// 커버리지는 카테고리가 기대하는 신호(바이오마커별 측정, 목표, 식사 기록) 중 데이터가 있는 비율입니다.
+// 종합 점수는 데이터가 있는 카테고리 점수를 커버리지로 가중 평균하며, 데이터가 전혀 없으면 0점과 InsufficientData를 반환합니다.
+// 추세는 기간 내 이전 점수 평균 대비 변화가 ±healthTrendThreshold 이상이면 improving/declining, 아니면 stable입니다.
+const HealthScoreModelVersion = "health-score-v1"
+
+// 건강 점수 카테고리
+const (
+	CategoryCardiovascular = "cardiovascular"
+	CategoryMetabolic      = "metabolic"
+	CategoryNutritional    = "nutritional"
+	CategoryFitness        = "fitness"
+)
+
+// 특징 출처
+const (
+	SourceMeasurement = "measurement"
+	SourceGoal        = "goal"
+	SourceMeal        = "meal"
+)
+
+// 건강 점수 추세
+const (
+	TrendImproving = "improving"
+	TrendStable    = "stable"
+	TrendDeclining = "declining"
+)
+
+const (
+	biomarkerFeatureWeight = 1.0
+	goalFeatureWeight      = 0.5
+	calorieFeatureWeight   = 1.0
+	regularityWeight       = 0.5
+	defaultDailyKcal       = 2000.0
+	calorieTolerance       = 0.1 // 목표 대비 ±10%까지는 감점 없음
+	calorieZeroAt          = 0.6 // 목표 대비 ±60%에서 0점
+	mealsPerDay            = 3.0
+	healthTrendThreshold   = 3.0 // 추세 판정 점수 차
+)
+
+// riskPoints는 바이오마커 분류 위험도별 특징 점수입니다.
+var riskPoints = map[RiskLevel]float64{
+	RiskLow:      100,
+	RiskModerate: 70,
+	RiskHigh:     40,
+	RiskCritical: 10,
+}
+
+// categorySpec은 카테고리가 사용하는 신호 정의입니다.
+type categorySpec struct {
+	name       string
+	biomarkers []string // 참조 범위 키
+	goals      []string // clients.HealthGoalSummary.Category
+	meals      bool
+}
+
+// healthCategories는 카테고리 정의입니다. 순서가 결과 특징 순서를 결정합니다.
+var healthCategories = []categorySpec{
+	{
+		name:       CategoryCardiovascular,
+		biomarkers: []string{"heart_rate", "cholesterol_total", "oxygen_saturation"},
+		goals:      []string{"blood_pressure", "cholesterol"},
+	},
+	{
+		name:       CategoryMetabolic,
+		biomarkers: []string{"blood_glucose", "hemoglobin_a1c", "uric_acid", "creatinine"},
+		goals:      []string{"blood_glucose"},
+	},
+	{
+		name:       CategoryNutritional,
+		biomarkers: []string{"hemoglobin"},
+		goals:      []string{"nutrition"},
+		meals:      true,
+	},
+	{
+		name:  CategoryFitness,
+		goals: []string{"weight", "exercise", "sleep", "stress"},
+	},
+}
+
+// FeatureContribution은 카테고리 점수를 구성한 특징 하나의 기여도입니다.
+type FeatureContribution struct {
+	Category     string
+	Feature      string
+	Source       string
+	Value        float64 // 원시 값 (평균 측정값, 평균 달성률, 일평균 kcal, 일평균 끼니 수)
+	Score        float64 // 특징 점수 (0~100)
+	Weight       float64
+	Contribution float64 // 카테고리 점수에 기여한 점수 (Score × Weight / ΣWeight)
+	SampleCount  int
+}
+
+// WithCoachingClient는 건강 목표 조회 클라이언트를 주입합니다.
+func WithCoachingClient(c clients.CoachingClient) InferenceOption {
+	return func(s *InferenceService) {
+		s.coaching = c
+	}
+}
+
+// WithMealLogClient는 식사 기록 조회 클라이언트를 주입합니다.
+func WithMealLogClient(c clients.MealLogClient) InferenceOption {
+	return func(s *InferenceService) {
+		s.meals = c
+	}
+}
+
+// healthInputs는 점수 산출에 쓰는 기간 내 원천 데이터입니다.
+type healthInputs struct {
+	readings []clients.MeasurementSummary
+	goals    []clients.HealthGoalSummary
+	meals    []clients.MealLog
+}
+
+// loadHealthInputs는 설정된 클라이언트에서 [start, end] 기간의 데이터를 조회합니다.
+// 설정되지 않은 출처는 비어 있는 것으로 보고 커버리지에 반영됩니다.
+func (s *InferenceService) loadHealthInputs(ctx context.Context, userID string, start, end time.Time) (*healthInputs, error) {
+	in := &healthInputs{}
+	var err error
+	if s.measurements != nil {
+		if in.readings, err = s.measurements.GetMeasurementsInRange(ctx, userID, start, end); err != nil {
+			return nil, apperrors.New(apperrors.ErrServiceUnavailable, "측정 이력 조회에 실패했습니다")
+		}
+	}
+	if s.coaching != nil {
+		if in.goals, err = s.coaching.GetHealthGoals(ctx, userID); err != nil {
+			return nil, apperrors.New(apperrors.ErrServiceUnavailable, "건강 목표 조회에 실패했습니다")
+		}
+	}
+	if s.meals != nil {
+		if in.meals, err = s.meals.GetMealLogs(ctx, userID, start, end); err != nil {
+			return nil, apperrors.New(apperrors.ErrServiceUnavailable, "식사 기록 조회에 실패했습니다")
+		}
+	}
+	return in, nil
+}
+
+// computeHealthScore는 원천 데이터로 점수·기여도·커버리지를 산출합니다. 같은 입력에는 항상 같은 결과를 반환합니다.
+func computeHealthScore(in *healthInputs) *HealthScore {
+	byBiomarker := make(map[string][]BiomarkerResult)
+	for _, r := range in.readings {
+		result := ClassifyReading(r)
+		if result.Classification == ClassUnknown {
+			continue
+		}
+		byBiomarker[result.BiomarkerName] = append(byBiomarker[result.BiomarkerName], result)
+	}
+	byGoal := make(map[string][]clients.HealthGoalSummary)
+	for _, g := range in.goals {
+		if g.Status != "active" && g.Status != "achieved" {
+			continue
+		}
+		byGoal[g.Category] = append(byGoal[g.Category], g)
+	}
+
+	score := &HealthScore{
+		CategoryScores:   make(map[string]float64),
+		CategoryCoverage: make(map[string]float64),
+		ModelVersion:     HealthScoreModelVersion,
+	}
+	var coverageSum, weightedSum float64
+	for _, spec := range healthCategories {
+		var features []FeatureContribution
+		expected, covered := 0, 0
+
+		for _, name := range spec.biomarkers {
+			expected++
+			if f, ok := biomarkerFeature(spec.name, name, byBiomarker[name]); ok {
+				features = append(features, f)
+				covered++
+			}
+		}
+
+		expected++
+		goalCovered := false
+		for _, name := range spec.goals {
+			if goals := byGoal[name]; len(goals) > 0 {
+				features = append(features, goalFeature(spec.name, name, goals))
+				goalCovered = true
+			}
+		}
+		if goalCovered {
+			covered++
+		}
+
+		if spec.meals {
+			expected++
+			if len(in.meals) > 0 {
+				features = append(features, mealFeatures(spec.name, in.meals, calorieTarget(in.goals))...)
+				covered++
+			}
+		}
+
+		coverage := round2(float64(covered) / float64(expected))
+		score.CategoryCoverage[spec.name] = coverage
+		score.DataCoverage += coverage / float64(len(healthCategories))
+		if len(features) == 0 {
+			continue
+		}
+
+		var totalWeight, catScore float64
+		for _, f := range features {
+			totalWeight += f.Weight
+		}
+		for i := range features {
+			features[i].Contribution = round2(features[i].Score * features[i].Weight / totalWeight)
+			catScore += features[i].Score * features[i].Weight / totalWeight
+		}
+		catScore = round1(catScore)
+		score.CategoryScores[spec.name] = catScore
+		score.Contributions = append(score.Contributions, features...)
+		coverageSum += coverage
+		weightedSum += coverage * catScore
+	}
+
+	score.DataCoverage = round2(score.DataCoverage)
+	if coverageSum == 0 {
+		score.InsufficientData = true
+		return score
+	}
+	score.OverallScore = round1(weightedSum / coverageSum)
+	return score
+}
+
+// biomarkerFeature는 바이오마커 측정값들의 신뢰도 가중 평균 점수를 계산합니다.
+func biomarkerFeature(category, biomarker string, results []BiomarkerResult) (FeatureContribution, bool) {
+	var weight, points, value float64
+	for _, r := range results {
+		weight += r.Confidence
+		points += riskPoints[r.RiskLevel] * r.Confidence
+		value += r.Value
+	}
+	if len(results) == 0 {
+		return FeatureContribution{}, false
+	}
+	if weight == 0 {
+		// 신뢰도 정보가 없는 이력은 단순 평균
+		weight = float64(len(results))
+		for _, r := range results {
+			points += riskPoints[r.RiskLevel]
+		}
+	}
+	return FeatureContribution{
+		Category:    category,
+		Feature:     biomarker,
+		Source:      SourceMeasurement,
+		Value:       round2(value / float64(len(results))),
+		Score:       round1(points / weight),
+		Weight:      biomarkerFeatureWeight,
+		SampleCount: len(results),
+	}, true
+}
+
+// goalFeature는 같은 분류 목표들의 평균 달성률을 점수로 사용합니다.
+func goalFeature(category, goalCategory string, goals []clients.HealthGoalSummary) FeatureContribution {
+	var sum float64
+	for _, g := range goals {
+		if g.Status == "achieved" {
+			sum += 100
+			continue
+		}
+		sum += math.Max(0, math.Min(100, g.ProgressPct))
+	}
+	avg := sum / float64(len(goals))
+	return FeatureContribution{
+		Category:    category,
+		Feature:     "goal:" + goalCategory,
+		Source:      SourceGoal,
+		Value:       round2(avg),
+		Score:       round1(avg),
+		Weight:      goalFeatureWeight,
+		SampleCount: len(goals),
+	}
+}
+
+// mealFeatures는 기록된 날(UTC) 기준 일평균 칼로리 균형과 끼니 규칙성을 계산합니다.
+func mealFeatures(category string, meals []clients.MealLog, targetKcal float64) []FeatureContribution {
+	days := make(map[time.Time]bool)
+	var kcal float64
+	for _, m := range meals {
+		days[truncateDay(m.LoggedAt)] = true
+		kcal += m.TotalCalorieKcal
+	}
+	n := float64(len(days))
+	avgKcal := kcal / n
+	avgMeals := float64(len(meals)) / n
+
+	deviation := math.Abs(avgKcal-targetKcal) / targetKcal
+	balance := 100 * math.Max(0, math.Min(1, 1-(deviation-calorieTolerance)/(calorieZeroAt-calorieTolerance)))
+	regularity := 100 * math.Min(1, avgMeals/mealsPerDay)
+
+	return []FeatureContribution{
+		{
+			Category:    category,
+			Feature:     "calorie_balance",
+			Source:      SourceMeal,
+			Value:       round2(avgKcal),
+			Score:       round1(balance),
+			Weight:      calorieFeatureWeight,
+			SampleCount: len(meals),
+		},
+		{
+			Category:    category,
+			Feature:     "meal_regularity",
+			Source:      SourceMeal,
+			Value:       round2(avgMeals),
+			Score:       round1(regularity),
+			Weight:      regularityWeight,
+			SampleCount: len(meals),
+		},
+	}
+}
+
+// calorieTarget은 진행 중인 영양 목표의 kcal 목표를, 없으면 defaultDailyKcal을 반환합니다.
+func calorieTarget(goals []clients.HealthGoalSummary) float64 {
+	for _, g := range goals {
+		if g.Category == "nutrition" && g.Status == "active" && g.TargetValue > 0 &&
+			(strings.EqualFold(g.Unit, "kcal") || strings.Contains(strings.ToLower(g.MetricName), "calorie")) {
+			return g.TargetValue
+		}
+	}
+	return defaultDailyKcal
+}
+
+// scoreTrend는 기간 내 이전 점수 평균과 비교해 추세를 판정합니다.
+// 데이터 부족으로 산출된 이전 점수는 비교에서 제외합니다.
+func scoreTrend(current float64, history []*HealthScore) string {
+	var sum float64
+	n := 0
+	for _, h := range history {
+		if h.InsufficientData {
+			continue
+		}
+		sum += h.OverallScore
+		n++
+	}
+	if n == 0 {
+		return TrendStable
+	}
+	switch delta := current - sum/float64(n); {
+	case delta >= healthTrendThreshold:
+		return TrendImproving
+	case delta <= -healthTrendThreshold:
+		return TrendDeclining
+	default:
+		return TrendStable
+	}
+}
+
+func round1(v float64) float64 {
+	return math.Round(v*10) / 10
+}
+
+// describeContributions는 LLM 프롬프트용 특징 기여 요약을 만듭니다.
+func describeContributions(contributions []FeatureContribution) string {
+	var sb strings.Builder
+	for _, c := range contributions {
+		sb.WriteString(fmt.Sprintf("- [%s] %s (%s): 값 %.2f, 점수 %.1f, 기여 %.2f, 표본 %d\n",
+			c.Category, c.Feature, c.Source, c.Value, c.Score, c.Contribution, c.SampleCount))
+	}
+	return sb.String()
+}
@@ -4,11 +4,13 @@ import (
 	"context"
 	"fmt"
 	"math"
-	"math/rand"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/manpasik/backend/services/ai-inference-service/internal/llm"
+	"github.com/manpasik/backend/shared/clients"
 	apperrors "github.com/manpasik/backend/shared/errors"
 )
 
@@ -24,6 +26,7 @@ const (
 	ModelTrendPredictor
 	ModelHealthScorer
 	ModelFoodCalorieEstimator
+	ModelSubstanceIdentifier // 핑거프린트 참조 라이브러리 kNN 물질 식별 (measurement-service)
 )
 
 type RiskLevel int
@@ -39,10 +42,11 @@ type BiomarkerResult struct {
 	BiomarkerName  string
 	Value          float64
 	Unit           string
-	Classification string  // "normal", "borderline", "abnormal"
-	Confidence     float64 // 0.0 ~ 1.0
+	Classification string  // "normal", "borderline", "abnormal", "unknown"
+	Confidence     float64 // 0.0 ~ 1.0, 측정 신뢰도 × QC 감점
 	RiskLevel      RiskLevel
 	ReferenceRange string
+	QCFlags        []string
 }
 
 type AnomalyFlag struct {
@@ -63,15 +67,21 @@ type AnalysisResult struct {
 	OverallHealthScore float64
 	Summary            string
 	AnalyzedAt         time.Time
+	Substance          *clients.SubstanceIdentification // ModelSubstanceIdentifier 요청 시에만 설정
 }
 
 type HealthScore struct {
-	UserID         string
-	OverallScore   float64
-	CategoryScores map[string]float64
-	Trend          string // "improving", "stable", "declining"
-	Recommendation string
-	CalculatedAt   time.Time
+	UserID           string
+	OverallScore     float64
+	CategoryScores   map[string]float64 // 데이터가 있는 카테고리만 포함
+	Trend            string             // TrendImproving, TrendStable, TrendDeclining
+	Recommendation   string
+	CalculatedAt     time.Time
+	Contributions    []FeatureContribution
+	CategoryCoverage map[string]float64 // 모든 카테고리의 데이터 커버리지 (0~1)
+	DataCoverage     float64
+	InsufficientData bool
+	ModelVersion     string
 }
 
 type TrendDataPoint struct {
@@ -82,13 +92,16 @@ type TrendDataPoint struct {
 }
 
 type TrendPrediction struct {
-	UserID     string
-	MetricName string
-	Historical []TrendDataPoint
-	Predicted  []TrendDataPoint
-	Confidence float64
-	Direction  string // "up", "down", "stable"
-	Insight    string
+	UserID           string
+	MetricName       string
+	Historical       []TrendDataPoint // 일별 평균 (구간은 당일 최소·최대)
+	Predicted        []TrendDataPoint // 95% 예측 구간 포함
+	Confidence       float64          // 백테스트 MAPE 기반
+	Direction        string           // "up", "down", "stable" (데이터 부족 시 빈 값)
+	Insight          string
+	InsufficientData bool
+	Method           string // MethodLinear, MethodDampedHolt, MethodHoltWinters
+	SampleCount      int    // 관측 일수
 }
 
 type ModelStatus string
@@ -100,13 +113,18 @@ const (
 )
 
 type ModelInfo struct {
-	ModelType   AiModelType
-	Name        string
-	Version     string
-	Description string
-	Accuracy    float64
-	LastTrained time.Time
-	Status      ModelStatus
+	ModelType         AiModelType
+	Name              string
+	Version           string
+	Description       string
+	Accuracy          float64 // Metrics["accuracy"], 평가 전이면 0
+	LastTrained       time.Time
+	Status            ModelStatus
+	ArtifactURI       string
+	Metrics           map[string]float64
+	TrainingDataStart time.Time
+	TrainingDataEnd   time.Time
+	Shadow            *ShadowStats // 섀도 후보의 누적 비교 지표 (없으면 nil)
 }
 
 // ============================================================================
@@ -122,6 +140,8 @@ type AnalysisRepository interface {
 type HealthScoreRepository interface {
 	Save(ctx context.Context, score *HealthScore) error
 	FindLatestByUserID(ctx context.Context, userID string) (*HealthScore, error)
+	// FindByUserID는 since 이후 산출된 점수를 시간순으로 반환합니다.
+	FindByUserID(ctx context.Context, userID string, since time.Time) ([]*HealthScore, error)
 }
 
 // ============================================================================
@@ -134,73 +154,39 @@ type InferenceService struct {
 	analysisRepo    AnalysisRepository
 	healthScoreRepo HealthScoreRepository
 	llmClient       llm.LLMClient // nil이면 LLM 미사용
-	models          map[AiModelType]*ModelInfo
-	rng             *rand.Rand
+	measurements    clients.MeasurementClient
+	substances      clients.SubstanceIdentifierClient // nil이면 물질 식별 미제공
+	coaching        clients.CoachingClient
+	meals           clients.MealLogClient
+	baselines       BaselineRepository
+	anomalyEvents   AnomalyEventPublisher
+	now             func() time.Time
+	registry        *ModelRegistry
+	conversations   ConversationRepository
+	retriever       *HealthRetriever // nil이면 AI 채팅 검색 증강 미사용
+	usageLedger     llm.UsageLedger  // nil이면 LLM 사용량 보고서 미제공
+	shadowRuns      sync.WaitGroup   // 진행 중인 섀도 비교
 }
 
 // NewInferenceService는 새 InferenceService를 생성합니다.
 // llmClient는 nil 가능 — nil이면 LLM 기능이 비활성화됩니다.
 func NewInferenceService(ar AnalysisRepository, hsr HealthScoreRepository, opts ...InferenceOption) *InferenceService {
-	now := time.Now().Add(-24 * time.Hour) // 모델은 어제 학습된 것으로 가정
 	svc := &InferenceService{
 		analysisRepo:    ar,
 		healthScoreRepo: hsr,
-		rng:             rand.New(rand.NewSource(time.Now().UnixNano())),
-		models: map[AiModelType]*ModelInfo{
-			ModelBiomarkerClassifier: {
-				ModelType:   ModelBiomarkerClassifier,
-				Name:        "BiomarkerClassifier",
-				Version:     "1.0.0",
-				Description: "바이오마커 분류 모델 — 혈액·소변 수치 분석",
-				Accuracy:    0.942,
-				LastTrained: now,
-				Status:      ModelStatusActive,
-			},
-			ModelAnomalyDetector: {
-				ModelType:   ModelAnomalyDetector,
-				Name:        "AnomalyDetector",
-				Version:     "1.0.0",
-				Description: "이상치 탐지 모델 — 시계열 측정값 이상 감지",
-				Accuracy:    0.918,
-				LastTrained: now,
-				Status:      ModelStatusActive,
-			},
-			ModelTrendPredictor: {
-				ModelType:   ModelTrendPredictor,
-				Name:        "TrendPredictor",
-				Version:     "1.0.0",
-				Description: "트렌드 예측 모델 — 건강 지표 시계열 예측",
-				Accuracy:    0.876,
-				LastTrained: now,
-				Status:      ModelStatusActive,
-			},
-			ModelHealthScorer: {
-				ModelType:   ModelHealthScorer,
-				Name:        "HealthScorer",
-				Version:     "1.0.0",
-				Description: "건강 점수 산출 모델 — 종합 건강 점수 계산",
-				Accuracy:    0.905,
-				LastTrained: now,
-				Status:      ModelStatusActive,
-			},
-			ModelFoodCalorieEstimator: {
-				ModelType:   ModelFoodCalorieEstimator,
-				Name:        "FoodCalorieEstimator",
-				Version:     "0.9.0-beta",
-				Description: "음식 칼로리 추정 모델 (Phase 2 후반)",
-				Accuracy:    0.823,
-				LastTrained: now,
-				Status:      ModelStatusTraining,
-			},
-		},
+		now:             time.Now,
 	}
 	for _, opt := range opts {
 		opt(svc)
 	}
+	if svc.registry == nil {
+		// 저장소 없는 레지스트리는 내장 모델만 등록하므로 실패하지 않음
+		svc.registry = NewModelRegistry(nil, nil)
+		_ = svc.registry.Bootstrap(context.Background())
+	}
 	return svc
 }
 
-// InferenceOption은 InferenceService 생성 시 옵션 함수 타입입니다.
 type InferenceOption func(*InferenceService)
 
 // WithLLMClient는 LLM 클라이언트를 주입합니다.
@@ -211,6 +197,21 @@ func WithLLMClient(c llm.LLMClient) InferenceOption {
 	}
 }
 
+// WithMeasurementClient는 측정 데이터 조회 클라이언트를 주입합니다.
+// 설정되지 않으면 AnalyzeMeasurement는 ErrServiceUnavailable을 반환합니다.
+func WithMeasurementClient(c clients.MeasurementClient) InferenceOption {
+	return func(s *InferenceService) {
+		s.measurements = c
+	}
+}
+
+// WithClock은 현재 시각 함수를 주입합니다 (테스트용).
+func WithClock(now func() time.Time) InferenceOption {
+	return func(s *InferenceService) {
+		s.now = now
+	}
+}
+
 // LLMEnabled는 LLM 클라이언트가 설정되어 있는지 반환합니다.
 func (s *InferenceService) LLMEnabled() bool {
 	return s.llmClient != nil
@@ -245,7 +246,8 @@ func (s *InferenceService) GenerateHealthInsight(ctx context.Context, userID str
 	// 측정 데이터를 텍스트로 변환
 	prompt := s.buildMeasurementPrompt(userID, measurements)
 
-	resp, err := s.llmClient.Chat(ctx, healthInsightSystemPrompt, []llm.ChatMessage{
+	llmCtx := llm.WithFeature(llm.WithUser(ctx, userID), llm.FeatureHealthInsight)
+	resp, err := s.llmClient.Chat(llmCtx, healthInsightSystemPrompt, []llm.ChatMessage{
 		{Role: "user", Content: prompt},
 	})
 	if err != nil {
@@ -294,13 +296,19 @@ func (s *InferenceService) enhanceSummaryWithLLM(ctx context.Context, biomarkers
 
 	var sb strings.Builder
 	sb.WriteString("아래 건강 분석 결과를 바탕으로 사용자 친화적인 요약을 작성해 주세요.\n\n")
-	sb.WriteString(fmt.Sprintf("건강 점수: %.1f/100\n", score))
+	if score > 0 {
+		sb.WriteString(fmt.Sprintf("건강 점수: %.1f/100\n", score))
+	}
 
 	if len(biomarkers) > 0 {
 		sb.WriteString("바이오마커 결과:\n")
 		for _, b := range biomarkers {
-			sb.WriteString(fmt.Sprintf("- %s: %.1f %s (%s, 위험도: %d)\n",
-				b.BiomarkerName, b.Value, b.Unit, b.Classification, b.RiskLevel))
+			sb.WriteString(fmt.Sprintf("- %s: %.1f %s (%s, 위험도: %d, 참조 범위: %s, 신뢰도: %.2f",
+				b.BiomarkerName, b.Value, b.Unit, b.Classification, b.RiskLevel, b.ReferenceRange, b.Confidence))
+			if len(b.QCFlags) > 0 {
+				sb.WriteString(", QC: " + strings.Join(b.QCFlags, ","))
+			}
+			sb.WriteString(")\n")
 		}
 	}
 
@@ -312,7 +320,7 @@ func (s *InferenceService) enhanceSummaryWithLLM(ctx context.Context, biomarkers
 		}
 	}
 
-	resp, err := s.llmClient.Chat(ctx, healthInsightSystemPrompt, []llm.ChatMessage{
+	resp, err := s.llmClient.Chat(llm.WithFeature(ctx, llm.FeatureAnalysisSummary), healthInsightSystemPrompt, []llm.ChatMessage{
 		{Role: "user", Content: sb.String()},
 	})
 	if err != nil {
@@ -324,6 +332,7 @@ func (s *InferenceService) enhanceSummaryWithLLM(ctx context.Context, biomarkers
 }
 
 // AnalyzeMeasurement runs AI models on a measurement.
+// measurement-service에서 실제 측정값을 조회해 참조 범위로 분류하며, 같은 측정에는 항상 같은 결과를 냅니다.
 func (s *InferenceService) AnalyzeMeasurement(ctx context.Context, userID, measurementID string, requestedModels []AiModelType) (*AnalysisResult, error) {
 	if userID == "" {
 		return nil, apperrors.New(apperrors.ErrInvalidInput, "user_id is required")
@@ -331,6 +340,9 @@ func (s *InferenceService) AnalyzeMeasurement(ctx context.Context, userID, measu
 	if measurementID == "" {
 		return nil, apperrors.New(apperrors.ErrInvalidInput, "measurement_id is required")
 	}
+	if s.measurements == nil {
+		return nil, apperrors.New(apperrors.ErrServiceUnavailable, "측정 서비스가 설정되지 않았습니다")
+	}
 
 	// 요청 모델이 없으면 기본 4종 적용 (FoodCalorie 제외)
 	if len(requestedModels) == 0 {
@@ -341,25 +353,72 @@ func (s *InferenceService) AnalyzeMeasurement(ctx context.Context, userID, measu
 			ModelHealthScorer,
 		}
 	}
+	wants := make(map[AiModelType]bool, len(requestedModels))
+	for _, m := range requestedModels {
+		wants[m] = true
+	}
+
+	detail, err := s.measurements.GetMeasurement(ctx, measurementID)
+	if err != nil {
+		return nil, apperrors.New(apperrors.ErrServiceUnavailable, "측정 데이터 조회에 실패했습니다")
+	}
+	// 다른 사용자의 측정은 존재 여부를 노출하지 않음
+	if detail == nil || detail.UserID != userID {
+		return nil, apperrors.New(apperrors.ErrNotFound, "측정을 찾을 수 없습니다")
+	}
+	if len(detail.Readings) == 0 {
+		return nil, apperrors.New(apperrors.ErrInvalidInput, "측정값이 없는 측정입니다")
+	}
+
+	version, model, err := s.registry.Production(ctx, ModelBiomarkerClassifier)
+	if err != nil {
+		return nil, err
+	}
+	input := ModelInput{Readings: detail.Readings}
+	output, err := model.Predict(ctx, input)
+	if err != nil {
+		return nil, apperrors.New(apperrors.ErrInternal, "모델 추론에 실패했습니다")
+	}
+	s.startShadowModels(ctx, version, output, input, measurementID)
+	classified := output.Biomarkers
+
+	var substance *clients.SubstanceIdentification
+	if wants[ModelSubstanceIdentifier] {
+		if substance, err = s.identifySubstance(ctx, measurementID); err != nil {
+			return nil, err
+		}
+	}
 
-	// 시뮬레이션된 AI 추론 결과 생성
-	biomarkers := s.simulateBiomarkerAnalysis()
-	anomalies := s.simulateAnomalyDetection()
-	healthScore := s.simulateHealthScore()
+	var biomarkers []BiomarkerResult
+	var anomalies []AnomalyFlag
+	var healthScore float64
+	if wants[ModelBiomarkerClassifier] {
+		biomarkers = classified
+	}
+	if wants[ModelAnomalyDetector] {
+		anomalies = output.Anomalies
+	}
+	if wants[ModelHealthScorer] {
+		healthScore = output.HealthScore
+	}
 
 	// 규칙 기반 요약 생성 후, LLM이 활성화되어 있으면 향상된 요약으로 교체
-	summary := s.generateSummary(biomarkers, anomalies, healthScore)
-	summary = s.enhanceSummaryWithLLM(ctx, biomarkers, anomalies, healthScore, summary)
+	summary := s.generateSummary(classified, anomalies, healthScore)
+	if substance != nil {
+		summary += " " + substanceSummary(substance)
+	}
+	summary = s.enhanceSummaryWithLLM(llm.WithUser(ctx, userID), biomarkers, anomalies, healthScore, summary)
 
 	result := &AnalysisResult{
-		AnalysisID:         fmt.Sprintf("ana_%d", time.Now().UnixNano()),
+		AnalysisID:         uuid.New().String(),
 		UserID:             userID,
 		MeasurementID:      measurementID,
 		Biomarkers:         biomarkers,
 		Anomalies:          anomalies,
 		OverallHealthScore: healthScore,
 		Summary:            summary,
-		AnalyzedAt:         time.Now(),
+		AnalyzedAt:         s.now(),
+		Substance:          substance,
 	}
 
 	if err := s.analysisRepo.Save(ctx, result); err != nil {
@@ -369,6 +428,8 @@ func (s *InferenceService) AnalyzeMeasurement(ctx context.Context, userID, measu
 }
 
 // GetHealthScore calculates a user's health score based on recent data.
+// 최근 days일의 측정·건강 목표·식사 기록으로 카테고리 점수를 산출하고(computeHealthScore),
+// 기간 내 이전 점수 이력과 비교해 추세를 판정한 뒤 이력에 저장합니다.
 func (s *InferenceService) GetHealthScore(ctx context.Context, userID string, days int) (*HealthScore, error) {
 	if userID == "" {
 		return nil, apperrors.New(apperrors.ErrInvalidInput, "user_id is required")
@@ -377,27 +438,28 @@ func (s *InferenceService) GetHealthScore(ctx context.Context, userID string, da
 		days = 30
 	}
 
-	overallScore := math.Round(60+s.rng.Float64()*40*10) / 10 // 60~100
-	categoryScores := map[string]float64{
-		"cardiovascular": math.Round((70+s.rng.Float64()*30)*10) / 10,
-		"metabolic":      math.Round((65+s.rng.Float64()*35)*10) / 10,
-		"nutritional":    math.Round((60+s.rng.Float64()*40)*10) / 10,
-		"fitness":        math.Round((55+s.rng.Float64()*45)*10) / 10,
+	now := s.now()
+	start := now.AddDate(0, 0, -days)
+	inputs, err := s.loadHealthInputs(ctx, userID, start, now)
+	if err != nil {
+		return nil, err
+	}
+	history, err := s.healthScoreRepo.FindByUserID(ctx, userID, start)
+	if err != nil {
+		return nil, apperrors.New(apperrors.ErrInternal, "건강 점수 이력 조회에 실패했습니다")
 	}
-	trend := s.pickTrend()
-
-	// LLM으로 맞춤형 추천 생성 (실패 시 기본 추천 사용)
-	recommendation := s.generateRecommendation(ctx, overallScore, categoryScores, trend)
 
-	score := &HealthScore{
-		UserID:         userID,
-		OverallScore:   overallScore,
-		CategoryScores: categoryScores,
-		Trend:          trend,
-		Recommendation: recommendation,
-		CalculatedAt:   time.Now(),
+	score := computeHealthScore(inputs)
+	score.UserID = userID
+	score.CalculatedAt = now
+	score.Trend = TrendStable
+	if !score.InsufficientData {
+		score.Trend = scoreTrend(score.OverallScore, history)
 	}
 
+	// LLM으로 맞춤형 추천 생성 (실패 시 기본 추천 사용)
+	score.Recommendation = s.generateRecommendation(llm.WithUser(ctx, userID), score)
+
 	if err := s.healthScoreRepo.Save(ctx, score); err != nil {
 		return nil, apperrors.New(apperrors.ErrInternal, "건강 점수 저장에 실패했습니다")
 	}
@@ -405,6 +467,8 @@ func (s *InferenceService) GetHealthScore(ctx context.Context, userID string, da
 }
 
 // PredictTrend predicts future values for a given metric.
+// 실제 측정 이력을 일 단위로 묶어 선형·감쇠 추세·주간 계절 모델 중 백테스트 오차가 가장 작은 모델로 예측합니다.
+// 관측 일수가 minForecastDays보다 적으면 예측값 없이 InsufficientData를 반환합니다.
 func (s *InferenceService) PredictTrend(ctx context.Context, userID, metricName string, historyDays, predictionDays int) (*TrendPrediction, error) {
 	if userID == "" {
 		return nil, apperrors.New(apperrors.ErrInvalidInput, "user_id is required")
@@ -415,174 +479,271 @@ func (s *InferenceService) PredictTrend(ctx context.Context, userID, metricName
 	if historyDays <= 0 {
 		historyDays = 30
 	}
+	if historyDays > maxHistoryDays {
+		historyDays = maxHistoryDays
+	}
 	if predictionDays <= 0 {
 		predictionDays = 7
 	}
+	if predictionDays > maxPredictionDays {
+		return nil, apperrors.New(apperrors.ErrInvalidInput, fmt.Sprintf("prediction_days는 %d일 이하여야 합니다", maxPredictionDays))
+	}
+	if s.measurements == nil {
+		return nil, apperrors.New(apperrors.ErrServiceUnavailable, "측정 서비스가 설정되지 않았습니다")
+	}
 
-	now := time.Now()
-	baseValue := 80 + s.rng.Float64()*40 // 80~120 기본값
+	now := s.now()
+	readings, err := s.measurements.GetMeasurementsInRange(ctx, userID, now.AddDate(0, 0, -historyDays), now)
+	if err != nil {
+		return nil, apperrors.New(apperrors.ErrServiceUnavailable, "측정 이력 조회에 실패했습니다")
+	}
 
-	// 시뮬레이션된 과거 데이터
-	historical := make([]TrendDataPoint, historyDays)
-	for i := 0; i < historyDays; i++ {
-		t := now.AddDate(0, 0, -(historyDays - i))
-		val := baseValue + s.rng.Float64()*10 - 5
+	metric := canonicalBiomarker(metricName)
+	var values []timedValue
+	for _, r := range readings {
+		if canonicalBiomarker(r.BiomarkerID) != metric {
+			continue
+		}
+		at, err := time.Parse(time.RFC3339, r.MeasuredAt)
+		if err != nil {
+			continue
+		}
+		values = append(values, timedValue{at: at, value: r.Value})
+	}
+
+	daily := aggregateDaily(values)
+	historical := make([]TrendDataPoint, len(daily))
+	for i, d := range daily {
 		historical[i] = TrendDataPoint{
-			Timestamp:  t,
-			Value:      math.Round(val*100) / 100,
-			LowerBound: math.Round((val-3)*100) / 100,
-			UpperBound: math.Round((val+3)*100) / 100,
+			Timestamp:  d.Day,
+			Value:      round2(d.Mean),
+			LowerBound: round2(d.Min),
+			UpperBound: round2(d.Max),
 		}
 	}
 
-	// 시뮬레이션된 예측 데이터
-	predicted := make([]TrendDataPoint, predictionDays)
-	lastVal := historical[len(historical)-1].Value
+	prediction := &TrendPrediction{
+		UserID:      userID,
+		MetricName:  metricName,
+		Historical:  historical,
+		SampleCount: len(daily),
+	}
+	if len(daily) < minForecastDays {
+		prediction.InsufficientData = true
+		prediction.Insight = fmt.Sprintf("최근 %d일간 %s 측정일이 %d일로, 예측에 필요한 최소 %d일보다 적습니다.",
+			historyDays, metricName, len(daily), minForecastDays)
+		return prediction, nil
+	}
+
+	// 마지막 측정일 이후 오늘까지의 공백도 예측 지평에 포함
+	lastDay := daily[len(daily)-1].Day
+	gap := int(truncateDay(now).Sub(lastDay).Hours() / 24)
+	if gap < 0 {
+		gap = 0
+	}
+	outcome := forecastSeries(newDailySeries(daily), gap+predictionDays, predictionDays)
+
+	prediction.Predicted = make([]TrendDataPoint, predictionDays)
 	for i := 0; i < predictionDays; i++ {
-		t := now.AddDate(0, 0, i+1)
-		delta := s.rng.Float64()*4 - 2
-		val := lastVal + delta
-		margin := float64(i+1) * 1.5
-		predicted[i] = TrendDataPoint{
-			Timestamp:  t,
-			Value:      math.Round(val*100) / 100,
-			LowerBound: math.Round((val-margin)*100) / 100,
-			UpperBound: math.Round((val+margin)*100) / 100,
+		step := gap + i + 1
+		v := outcome.values[step-1]
+		w := outcome.halfWidths[step-1]
+		prediction.Predicted[i] = TrendDataPoint{
+			Timestamp:  lastDay.AddDate(0, 0, step),
+			Value:      round2(v),
+			LowerBound: round2(v - w),
+			UpperBound: round2(v + w),
 		}
-		lastVal = val
 	}
 
 	direction := "stable"
-	diff := predicted[len(predicted)-1].Value - historical[len(historical)-1].Value
-	if diff > 3 {
+	change := outcome.values[len(outcome.values)-1] - outcome.level
+	if threshold := math.Max(outcome.sigma, 0.02*math.Abs(outcome.level)); change > threshold {
 		direction = "up"
-	} else if diff < -3 {
+	} else if change < -threshold {
 		direction = "down"
 	}
 
-	return &TrendPrediction{
-		UserID:     userID,
-		MetricName: metricName,
-		Historical: historical,
-		Predicted:  predicted,
-		Confidence: math.Round(s.rng.Float64()*20+75*100) / 10000, // 0.75~0.95
-		Direction:  direction,
-		Insight:    fmt.Sprintf("%s 지표는 향후 %d일간 %s 추세입니다.", metricName, predictionDays, direction),
-	}, nil
+	prediction.Method = outcome.method
+	prediction.Confidence = outcome.confidence
+	prediction.Direction = direction
+	prediction.Insight = fmt.Sprintf("%s 지표는 향후 %d일간 %s 추세입니다. (관측 %d일, %s 모델)",
+		metricName, predictionDays, directionLabels[direction], len(daily), outcome.method)
+	return prediction, nil
+}
+
+const (
+	maxHistoryDays    = 365
+	maxPredictionDays = 90
+)
+
+var directionLabels = map[string]string{
+	"up":     "상승",
+	"down":   "하락",
+	"stable": "안정",
+}
+
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
 }
 
-// GetModelInfo returns information about a specific AI model.
+// GetModelInfo returns the registry's current version of a model type.
 func (s *InferenceService) GetModelInfo(_ context.Context, modelType AiModelType) (*ModelInfo, error) {
-	info, ok := s.models[modelType]
+	v, ok := s.registry.Current(modelType)
 	if !ok {
 		return nil, apperrors.New(apperrors.ErrNotFound, "모델을 찾을 수 없습니다")
 	}
-	return info, nil
+	return s.modelInfo(v), nil
 }
 
-// ListModels returns all available AI models.
+// ListModels returns the current version of every model type followed by its shadow candidates.
 func (s *InferenceService) ListModels(_ context.Context) ([]*ModelInfo, error) {
-	result := make([]*ModelInfo, 0, len(s.models))
-	for _, m := range s.models {
-		result = append(result, m)
+	var result []*ModelInfo
+	seen := make(map[AiModelType]bool)
+	for _, v := range s.registry.Versions() {
+		if v.Status == ModelStatusShadow {
+			result = append(result, s.modelInfo(v))
+			continue
+		}
+		if seen[v.ModelType] || v.Status == ModelStatusDeprecated {
+			continue
+		}
+		seen[v.ModelType] = true
+		result = append(result, s.modelInfo(v))
 	}
 	return result, nil
 }
 
-// ============================================================================
-// Internal helpers — simulated AI inference
-// ============================================================================
-
-func (s *InferenceService) simulateBiomarkerAnalysis() []BiomarkerResult {
-	biomarkers := []struct {
-		name string
-		unit string
-		min  float64
-		max  float64
-		ref  string
-	}{
-		{"blood_glucose", "mg/dL", 70, 140, "70-100 mg/dL"},
-		{"cholesterol_total", "mg/dL", 120, 280, "< 200 mg/dL"},
-		{"hemoglobin", "g/dL", 10, 18, "12-16 g/dL (여), 14-18 g/dL (남)"},
-		{"creatinine", "mg/dL", 0.5, 2.0, "0.7-1.3 mg/dL"},
-		{"uric_acid", "mg/dL", 2, 10, "3.5-7.2 mg/dL"},
-	}
-
-	results := make([]BiomarkerResult, len(biomarkers))
-	for i, bm := range biomarkers {
-		val := bm.min + s.rng.Float64()*(bm.max-bm.min)
-		val = math.Round(val*10) / 10
-
-		classification := "normal"
-		risk := RiskLow
-		if val > bm.max*0.85 {
-			classification = "abnormal"
-			risk = RiskHigh
-		} else if val > bm.max*0.7 {
-			classification = "borderline"
-			risk = RiskModerate
-		}
-
-		results[i] = BiomarkerResult{
-			BiomarkerName:  bm.name,
-			Value:          val,
-			Unit:           bm.unit,
-			Classification: classification,
-			Confidence:     math.Round((0.85+s.rng.Float64()*0.15)*1000) / 1000,
-			RiskLevel:      risk,
-			ReferenceRange: bm.ref,
-		}
+// RegisterModelVersion은 후보 모델 버전과 아티팩트를 레지스트리에 등록합니다 (관리자 전용).
+// 상태를 지정하지 않으면 섀도 후보가 되어 운영 모델과 함께 실행·비교됩니다.
+func (s *InferenceService) RegisterModelVersion(ctx context.Context, v *ModelVersion, artifact []byte) (*ModelInfo, error) {
+	if v == nil || v.ModelType == 0 {
+		return nil, apperrors.New(apperrors.ErrInvalidInput, "모델 유형은 필수입니다")
 	}
-	return results
+	if err := s.registry.Register(ctx, v, artifact); err != nil {
+		return nil, err
+	}
+	return s.modelInfo(v), nil
 }
 
-func (s *InferenceService) simulateAnomalyDetection() []AnomalyFlag {
-	// 20% 확률로 이상치 발생
-	if s.rng.Float64() > 0.2 {
-		return nil
+// PromoteModelVersion은 등록된 버전을 운영으로 전환합니다 (관리자 전용).
+func (s *InferenceService) PromoteModelVersion(ctx context.Context, modelType AiModelType, version string) (*ModelInfo, error) {
+	if version == "" {
+		return nil, apperrors.New(apperrors.ErrInvalidInput, "버전은 필수입니다")
+	}
+	if err := s.registry.Promote(ctx, modelType, version); err != nil {
+		return nil, err
 	}
-	return []AnomalyFlag{
-		{
-			MetricName:   "heart_rate_variability",
-			Value:        30 + s.rng.Float64()*20,
-			ExpectedMin:  50,
-			ExpectedMax:  100,
-			AnomalyScore: 0.7 + s.rng.Float64()*0.3,
-			Description:  "심박변이도(HRV)가 정상 범위보다 낮습니다.",
-		},
+	v, ok := s.registry.Version(modelType, version)
+	if !ok {
+		return nil, apperrors.New(apperrors.ErrNotFound, "모델 버전을 찾을 수 없습니다")
 	}
+	return s.modelInfo(v), nil
 }
 
-func (s *InferenceService) simulateHealthScore() float64 {
-	return math.Round((60+s.rng.Float64()*40)*10) / 10
+func (s *InferenceService) modelInfo(v *ModelVersion) *ModelInfo {
+	info := &ModelInfo{
+		ModelType:         v.ModelType,
+		Name:              v.Name,
+		Version:           v.Version,
+		Description:       v.Description,
+		Accuracy:          v.Metrics["accuracy"],
+		LastTrained:       v.TrainedAt,
+		Status:            v.Status,
+		ArtifactURI:       v.ArtifactURI,
+		Metrics:           v.Metrics,
+		TrainingDataStart: v.TrainingDataStart,
+		TrainingDataEnd:   v.TrainingDataEnd,
+	}
+	if v.Status == ModelStatusShadow {
+		info.Shadow = s.registry.ShadowStats(v.ModelType, v.Version)
+	}
+	return info
+}
+
+// shadowTimeout은 요청 하나의 섀도 비교(후보 로드·추론·기록)에 허용하는 시간입니다.
+const shadowTimeout = 10 * time.Second
+
+// startShadowModels는 섀도 비교를 요청 경로 밖에서 실행합니다.
+// 요청이 끝나도 비교는 이어지도록 취소는 끊고 shadowTimeout으로만 제한합니다.
+func (s *InferenceService) startShadowModels(ctx context.Context, production *ModelVersion, output *ModelOutput, input ModelInput, measurementID string) {
+	s.shadowRuns.Add(1)
+	go func() {
+		defer s.shadowRuns.Done()
+		shadowCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), shadowTimeout)
+		defer cancel()
+		s.runShadowModels(shadowCtx, production, output, input, measurementID)
+	}()
+}
+
+// WaitShadowRuns는 진행 중인 섀도 비교가 끝날 때까지 기다립니다 (종료 시 사용).
+func (s *InferenceService) WaitShadowRuns() {
+	s.shadowRuns.Wait()
+}
+
+// runShadowModels는 섀도 후보 모델을 같은 입력으로 실행해 운영 결과와의 일치도를 기록합니다.
+// 후보 실패는 운영 응답에 영향을 주지 않습니다.
+func (s *InferenceService) runShadowModels(ctx context.Context, production *ModelVersion, output *ModelOutput, input ModelInput, measurementID string) {
+	versions, models := s.registry.Shadows(ctx, production.ModelType)
+	for i, m := range models {
+		candidate, err := m.Predict(ctx, input)
+		if err != nil {
+			continue
+		}
+		compared, agreed, delta := compareShadow(output, candidate)
+		_ = s.registry.RecordShadow(ctx, &ShadowResult{
+			ModelType:         production.ModelType,
+			ProductionVersion: production.Version,
+			CandidateVersion:  versions[i].Version,
+			MeasurementID:     measurementID,
+			Compared:          compared,
+			Agreed:            agreed,
+			ScoreDelta:        round2(delta),
+			RecordedAt:        s.now(),
+		})
+	}
 }
 
+// ============================================================================
+// Internal helpers
+// ============================================================================
+
 func (s *InferenceService) generateSummary(biomarkers []BiomarkerResult, anomalies []AnomalyFlag, score float64) string {
-	abnormalCount := 0
+	abnormalCount, unknownCount := 0, 0
 	for _, b := range biomarkers {
-		if b.Classification == "abnormal" {
+		switch b.Classification {
+		case ClassAbnormal:
 			abnormalCount++
+		case ClassUnknown:
+			unknownCount++
 		}
 	}
 
+	suffix := ""
+	if score > 0 {
+		suffix = fmt.Sprintf(" (건강 점수: %.1f/100)", score)
+	}
+	if unknownCount == len(biomarkers) {
+		return "참조 범위가 없는 측정 항목이라 분류할 수 없습니다."
+	}
 	if abnormalCount == 0 && len(anomalies) == 0 {
-		return fmt.Sprintf("전반적으로 양호한 건강 상태입니다. (건강 점수: %.1f/100)", score)
+		return "전반적으로 양호한 건강 상태입니다." + suffix
 	}
 	if abnormalCount > 0 && len(anomalies) > 0 {
-		return fmt.Sprintf("비정상 바이오마커 %d건, 이상치 %d건이 감지되었습니다. 전문가 상담을 권장합니다. (건강 점수: %.1f/100)",
-			abnormalCount, len(anomalies), score)
+		return fmt.Sprintf("비정상 바이오마커 %d건, 이상치 %d건이 감지되었습니다. 전문가 상담을 권장합니다.%s",
+			abnormalCount, len(anomalies), suffix)
 	}
 	if abnormalCount > 0 {
-		return fmt.Sprintf("비정상 바이오마커 %d건이 감지되었습니다. 추가 검사를 권장합니다. (건강 점수: %.1f/100)",
-			abnormalCount, score)
+		return fmt.Sprintf("비정상 바이오마커 %d건이 감지되었습니다. 추가 검사를 권장합니다.%s",
+			abnormalCount, suffix)
 	}
-	return fmt.Sprintf("이상치 %d건이 감지되었습니다. 모니터링을 계속하세요. (건강 점수: %.1f/100)",
-		len(anomalies), score)
+	return fmt.Sprintf("이상치 %d건이 감지되었습니다. 모니터링을 계속하세요.%s",
+		len(anomalies), suffix)
 }
 
 // generateRecommendation은 LLM을 사용하여 맞춤형 건강 추천을 생성합니다.
 // LLM이 비활성화되어 있거나 호출 실패 시 기본 추천을 반환합니다.
-func (s *InferenceService) generateRecommendation(ctx context.Context, overallScore float64, categoryScores map[string]float64, trend string) string {
+func (s *InferenceService) generateRecommendation(ctx context.Context, score *HealthScore) string {
 	defaultRec := "규칙적인 운동과 균형 잡힌 식단을 유지하세요."
 
 	if s.llmClient == nil {
@@ -590,14 +751,21 @@ func (s *InferenceService) generateRecommendation(ctx context.Context, overallSc
 	}
 
 	var sb strings.Builder
-	sb.WriteString(fmt.Sprintf("건강 점수: %.1f/100, 추세: %s\n", overallScore, trend))
+	sb.WriteString(fmt.Sprintf("건강 점수: %.1f/100, 추세: %s, 데이터 커버리지: %.0f%%\n",
+		score.OverallScore, score.Trend, score.DataCoverage*100))
 	sb.WriteString("카테고리별 점수:\n")
-	for cat, sc := range categoryScores {
-		sb.WriteString(fmt.Sprintf("- %s: %.1f\n", cat, sc))
+	for _, spec := range healthCategories {
+		if sc, ok := score.CategoryScores[spec.name]; ok {
+			sb.WriteString(fmt.Sprintf("- %s: %.1f (커버리지 %.0f%%)\n", spec.name, sc, score.CategoryCoverage[spec.name]*100))
+		} else {
+			sb.WriteString(fmt.Sprintf("- %s: 데이터 없음\n", spec.name))
+		}
 	}
+	sb.WriteString("점수 근거:\n")
+	sb.WriteString(describeContributions(score.Contributions))
 	sb.WriteString("\n이 데이터를 바탕으로 구체적인 건강 개선 추천을 1~2문장으로 작성해 주세요.")
 
-	resp, err := s.llmClient.Chat(ctx, healthInsightSystemPrompt, []llm.ChatMessage{
+	resp, err := s.llmClient.Chat(llm.WithFeature(ctx, llm.FeatureRecommendation), healthInsightSystemPrompt, []llm.ChatMessage{
 		{Role: "user", Content: sb.String()},
 	})
 	if err != nil {
@@ -606,14 +774,3 @@ func (s *InferenceService) generateRecommendation(ctx context.Context, overallSc
 
 	return resp.Content
 }
-
-func (s *InferenceService) pickTrend() string {
-	v := s.rng.Float64()
-	if v < 0.4 {
-		return "improving"
-	}
-	if v < 0.7 {
-		return "stable"
-	}
-	return "declining"
-}
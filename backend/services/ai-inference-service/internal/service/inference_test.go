@@ -3,11 +3,16 @@ package service
 import (
 	"context"
 	"errors"
+	"math"
+	"strings"
 	"sync"
 	"testing"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/manpasik/backend/services/ai-inference-service/internal/llm"
+	"github.com/manpasik/backend/shared/clients"
+	apperrors "github.com/manpasik/backend/shared/errors"
 )
 
 // ============================================================================
@@ -56,35 +61,142 @@ func (r *fakeAnalysisRepo) FindByUserID(_ context.Context, userID string, limit
 
 type fakeHealthScoreRepo struct {
 	mu   sync.Mutex
-	data map[string]*HealthScore
+	data map[string][]*HealthScore
 }
 
 func newFakeHealthScoreRepo() *fakeHealthScoreRepo {
-	return &fakeHealthScoreRepo{data: make(map[string]*HealthScore)}
+	return &fakeHealthScoreRepo{data: make(map[string][]*HealthScore)}
 }
 
 func (r *fakeHealthScoreRepo) Save(_ context.Context, score *HealthScore) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
-	r.data[score.UserID] = score
+	r.data[score.UserID] = append(r.data[score.UserID], score)
 	return nil
 }
 
 func (r *fakeHealthScoreRepo) FindLatestByUserID(_ context.Context, userID string) (*HealthScore, error) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
-	if v, ok := r.data[userID]; ok {
-		return v, nil
+	if v := r.data[userID]; len(v) > 0 {
+		return v[len(v)-1], nil
 	}
 	return nil, nil
 }
 
+func (r *fakeHealthScoreRepo) FindByUserID(_ context.Context, userID string, since time.Time) ([]*HealthScore, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var result []*HealthScore
+	for _, v := range r.data[userID] {
+		if !v.CalculatedAt.Before(since) {
+			result = append(result, v)
+		}
+	}
+	return result, nil
+}
+
+type fakeCoachingClient struct {
+	goals    []clients.HealthGoalSummary
+	messages []clients.CoachingMessageSummary
+}
+
+func (c *fakeCoachingClient) GetHealthGoals(_ context.Context, _ string) ([]clients.HealthGoalSummary, error) {
+	return c.goals, nil
+}
+
+func (c *fakeCoachingClient) ListCoachingMessages(_ context.Context, _ string, limit int) ([]clients.CoachingMessageSummary, error) {
+	if len(c.messages) > limit {
+		return c.messages[:limit], nil
+	}
+	return c.messages, nil
+}
+
+type fakeMealLogClient struct {
+	meals []clients.MealLog
+}
+
+func (c *fakeMealLogClient) GetMealLogs(_ context.Context, _ string, start, end time.Time) ([]clients.MealLog, error) {
+	var result []clients.MealLog
+	for _, m := range c.meals {
+		if !m.LoggedAt.Before(start) && !m.LoggedAt.After(end) {
+			result = append(result, m)
+		}
+	}
+	return result, nil
+}
+
+// fakeMeasurementClient는 세션 ID별 측정 데이터와 사용자 측정 이력을 반환합니다.
+type fakeMeasurementClient struct {
+	details map[string]*clients.MeasurementDetail
+	history map[string][]clients.MeasurementSummary // userID → 이력
+}
+
+func (c *fakeMeasurementClient) GetLatestMeasurements(_ context.Context, _ string, _ int) ([]clients.MeasurementSummary, error) {
+	return nil, nil
+}
+
+func (c *fakeMeasurementClient) GetMeasurement(_ context.Context, id string) (*clients.MeasurementDetail, error) {
+	return c.details[id], nil
+}
+
+func (c *fakeMeasurementClient) GetMeasurementsInRange(_ context.Context, userID string, start, end time.Time) ([]clients.MeasurementSummary, error) {
+	var result []clients.MeasurementSummary
+	for _, m := range c.history[userID] {
+		at, _ := time.Parse(time.RFC3339, m.MeasuredAt)
+		if !at.Before(start) && !at.After(end) {
+			result = append(result, m)
+		}
+	}
+	return result, nil
+}
+
+// testNow는 테스트 기준 시각입니다.
+var testNow = time.Date(2026, 3, 15, 12, 0, 0, 0, time.UTC)
+
+func reading(biomarker string, value float64, at time.Time) clients.MeasurementSummary {
+	return clients.MeasurementSummary{BiomarkerID: biomarker, Value: value, Unit: "mg/dL", MeasuredAt: at.Format(time.RFC3339)}
+}
+
+// glucoseHistory는 매일 아침 측정한 30일간의 완만한 상승 추세 혈당입니다.
+func glucoseHistory() []clients.MeasurementSummary {
+	var h []clients.MeasurementSummary
+	for i := 0; i < 30; i++ {
+		at := testNow.AddDate(0, 0, -(29 - i)).Add(-4 * time.Hour)
+		h = append(h, reading("glucose", 90+0.5*float64(i)+0.3*float64(i%3-1), at))
+	}
+	return h
+}
+
+// newFakeMeasurementClient는 user-1의 측정 meas-1(정상·경계·비정상 혼합)과 meas-2(정상)를 준비합니다.
+func newFakeMeasurementClient() *fakeMeasurementClient {
+	return &fakeMeasurementClient{details: map[string]*clients.MeasurementDetail{
+		"meas-1": {
+			SessionID: "meas-1", UserID: "user-1", Status: "completed",
+			Readings: []clients.MeasurementSummary{
+				{BiomarkerID: "glucose", Value: 92, Unit: "mg/dL", Confidence: 0.95},
+				{BiomarkerID: "cholesterol_total", Value: 215, Unit: "mg/dL", Confidence: 0.9},
+				{BiomarkerID: "uric_acid", Value: 9.1, Unit: "mg/dL", Confidence: 0.8, QCFlags: []string{"humidity_high"}},
+			},
+		},
+		"meas-2": {
+			SessionID: "meas-2", UserID: "user-1", Status: "completed",
+			Readings: []clients.MeasurementSummary{
+				{BiomarkerID: "heart_rate", Value: 72, Unit: "bpm", Confidence: 0.99},
+			},
+		},
+	}, history: map[string][]clients.MeasurementSummary{
+		"user-1": glucoseHistory(),
+	}}
+}
+
 // ============================================================================
 // Tests
 // ============================================================================
 
 func newTestService() *InferenceService {
-	return NewInferenceService(newFakeAnalysisRepo(), newFakeHealthScoreRepo())
+	return NewInferenceService(newFakeAnalysisRepo(), newFakeHealthScoreRepo(),
+		WithMeasurementClient(newFakeMeasurementClient()), WithClock(func() time.Time { return testNow }))
 }
 
 func TestAnalyzeMeasurement_Success(t *testing.T) {
@@ -108,8 +220,11 @@ func TestAnalyzeMeasurement_Success(t *testing.T) {
 	if result.Summary == "" {
 		t.Error("expected non-empty summary")
 	}
-	if result.AnalysisID == "" {
-		t.Error("expected non-empty analysis ID")
+	if _, err := uuid.Parse(result.AnalysisID); err != nil {
+		t.Errorf("expected UUID analysis ID, got %q", result.AnalysisID)
+	}
+	if !result.AnalyzedAt.Equal(testNow) {
+		t.Errorf("expected AnalyzedAt from injected clock, got %v", result.AnalyzedAt)
 	}
 }
 
@@ -138,6 +253,107 @@ func TestAnalyzeMeasurement_WithSpecificModels(t *testing.T) {
 	if result == nil {
 		t.Fatal("expected non-nil result")
 	}
+	if len(result.Biomarkers) != 1 || result.Anomalies != nil || result.OverallHealthScore != 0 {
+		t.Errorf("only biomarker classification expected, got %+v", result)
+	}
+}
+
+func TestAnalyzeMeasurement_ReferenceRangeClassification(t *testing.T) {
+	svc := newTestService()
+	result, err := svc.AnalyzeMeasurement(context.Background(), "user-1", "meas-1", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []struct {
+		name       string
+		class      string
+		risk       RiskLevel
+		confidence float64
+	}{
+		{"blood_glucose", ClassNormal, RiskLow, 0.95},
+		{"cholesterol_total", ClassBorderline, RiskModerate, 0.9},
+		{"uric_acid", ClassAbnormal, RiskHigh, 0.68}, // 0.8 × humidity_high 0.85
+	}
+	if len(result.Biomarkers) != len(expected) {
+		t.Fatalf("expected %d biomarkers, got %d", len(expected), len(result.Biomarkers))
+	}
+	for i, e := range expected {
+		b := result.Biomarkers[i]
+		if b.BiomarkerName != e.name || b.Classification != e.class || b.RiskLevel != e.risk || b.Confidence != e.confidence {
+			t.Errorf("biomarker %d mismatch: %+v", i, b)
+		}
+		if b.ReferenceRange == "" {
+			t.Errorf("%s: expected reference range", b.BiomarkerName)
+		}
+	}
+
+	if len(result.Anomalies) != 1 || result.Anomalies[0].MetricName != "uric_acid" || result.Anomalies[0].AnomalyScore != 0.514 {
+		t.Errorf("expected single uric_acid anomaly, got %+v", result.Anomalies)
+	}
+	// 100 - (10×0.9 + 25×0.68)
+	if result.OverallHealthScore != 74 {
+		t.Errorf("expected health score 74, got %.1f", result.OverallHealthScore)
+	}
+}
+
+func TestAnalyzeMeasurement_Deterministic(t *testing.T) {
+	svc := newTestService()
+	first, err := svc.AnalyzeMeasurement(context.Background(), "user-1", "meas-1", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for i := 0; i < 5; i++ {
+		again, _ := svc.AnalyzeMeasurement(context.Background(), "user-1", "meas-1", nil)
+		if again.OverallHealthScore != first.OverallHealthScore || again.Summary != first.Summary ||
+			len(again.Biomarkers) != len(first.Biomarkers) || len(again.Anomalies) != len(first.Anomalies) {
+			t.Fatalf("results differ between runs: %+v vs %+v", first, again)
+		}
+		for j := range first.Biomarkers {
+			if again.Biomarkers[j].Classification != first.Biomarkers[j].Classification ||
+				again.Biomarkers[j].Confidence != first.Biomarkers[j].Confidence {
+				t.Fatalf("biomarker %d differs between runs", j)
+			}
+		}
+	}
+}
+
+func TestClassifyReading_CriticalAndUnknown(t *testing.T) {
+	critical := ClassifyReading(clients.MeasurementSummary{BiomarkerID: "blood_glucose", Value: 35, Unit: "mg/dL", Confidence: 1})
+	if critical.Classification != ClassAbnormal || critical.RiskLevel != RiskCritical {
+		t.Errorf("expected critical abnormal, got %+v", critical)
+	}
+
+	unitMismatch := ClassifyReading(clients.MeasurementSummary{BiomarkerID: "blood_glucose", Value: 5.5, Unit: "mmol/L", Confidence: 1})
+	if unitMismatch.Classification != ClassUnknown {
+		t.Errorf("unit mismatch should not be classified, got %+v", unitMismatch)
+	}
+
+	lowQuality := ClassifyReading(clients.MeasurementSummary{BiomarkerID: "heart_rate", Value: 70, Unit: "bpm", Confidence: 0.9,
+		QCFlags: []string{"reference_invalid", "battery_low"}})
+	if lowQuality.Confidence != 0.513 { // 0.9 × 0.6 × 0.95
+		t.Errorf("expected QC-reduced confidence 0.513, got %.3f", lowQuality.Confidence)
+	}
+}
+
+func TestAnalyzeMeasurement_OtherUsersMeasurement(t *testing.T) {
+	svc := newTestService()
+	for _, id := range []string{"meas-1", "missing"} {
+		_, err := svc.AnalyzeMeasurement(context.Background(), "user-2", id, nil)
+		var appErr *apperrors.AppError
+		if !errors.As(err, &appErr) || appErr.Code != apperrors.ErrNotFound {
+			t.Errorf("%s: expected NOT_FOUND, got %v", id, err)
+		}
+	}
+}
+
+func TestAnalyzeMeasurement_NoMeasurementClient(t *testing.T) {
+	svc := NewInferenceService(newFakeAnalysisRepo(), newFakeHealthScoreRepo())
+	_, err := svc.AnalyzeMeasurement(context.Background(), "user-1", "meas-1", nil)
+	var appErr *apperrors.AppError
+	if !errors.As(err, &appErr) || appErr.Code != apperrors.ErrServiceUnavailable {
+		t.Fatalf("expected SERVICE_UNAVAILABLE, got %v", err)
+	}
 }
 
 func TestGetHealthScore_Success(t *testing.T) {
@@ -179,6 +395,134 @@ func TestGetHealthScore_DefaultDays(t *testing.T) {
 	}
 }
 
+// newHealthScoreFixture는 30일 혈당 이력, 운동·영양 목표, 이틀치 식사 기록을 가진 user-1 서비스를 준비합니다.
+func newHealthScoreFixture() (*InferenceService, *fakeHealthScoreRepo) {
+	repo := newFakeHealthScoreRepo()
+	day1 := testNow.AddDate(0, 0, -2)
+	day2 := testNow.AddDate(0, 0, -1)
+	svc := NewInferenceService(newFakeAnalysisRepo(), repo,
+		WithMeasurementClient(newFakeMeasurementClient()),
+		WithCoachingClient(&fakeCoachingClient{goals: []clients.HealthGoalSummary{
+			{GoalID: "g1", Category: "exercise", MetricName: "weekly_minutes", ProgressPct: 60, Status: "active"},
+			{GoalID: "g2", Category: "nutrition", MetricName: "daily_calorie", TargetValue: 2000, Unit: "kcal", ProgressPct: 80, Status: "active"},
+			{GoalID: "g3", Category: "blood_pressure", ProgressPct: 10, Status: "paused"},
+		}}),
+		WithMealLogClient(&fakeMealLogClient{meals: []clients.MealLog{
+			{AnalysisID: "m1", MealType: "breakfast", TotalCalorieKcal: 500, LoggedAt: day1.Add(-4 * time.Hour)},
+			{AnalysisID: "m2", MealType: "lunch", TotalCalorieKcal: 700, LoggedAt: day1},
+			{AnalysisID: "m3", MealType: "dinner", TotalCalorieKcal: 800, LoggedAt: day1.Add(6 * time.Hour)},
+			{AnalysisID: "m4", MealType: "lunch", TotalCalorieKcal: 900, LoggedAt: day2},
+			{AnalysisID: "m5", MealType: "dinner", TotalCalorieKcal: 1300, LoggedAt: day2.Add(6 * time.Hour)},
+		}}),
+		WithClock(func() time.Time { return testNow }))
+	return svc, repo
+}
+
+func TestGetHealthScore_ExplainableModel(t *testing.T) {
+	svc, _ := newHealthScoreFixture()
+	score, err := svc.GetHealthScore(context.Background(), "user-1", 30)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// 혈당 30일: 정상 19회(100점) + 경계 11회(70점) → 89.0
+	// 영양: 목표 80(w0.5), 칼로리 균형 2100kcal/일 → 100(w1), 끼니 2.5/3 → 83.3(w0.5) → 90.8
+	wantScores := map[string]float64{CategoryMetabolic: 89.0, CategoryNutritional: 90.8, CategoryFitness: 60.0}
+	if len(score.CategoryScores) != len(wantScores) {
+		t.Errorf("expected only categories with data, got %v", score.CategoryScores)
+	}
+	for cat, want := range wantScores {
+		if got := score.CategoryScores[cat]; got != want {
+			t.Errorf("%s score: expected %.1f, got %.1f", cat, want, got)
+		}
+	}
+	wantCoverage := map[string]float64{CategoryCardiovascular: 0, CategoryMetabolic: 0.2, CategoryNutritional: 0.67, CategoryFitness: 1}
+	for cat, want := range wantCoverage {
+		if got := score.CategoryCoverage[cat]; got != want {
+			t.Errorf("%s coverage: expected %.2f, got %.2f", cat, want, got)
+		}
+	}
+	// 커버리지 가중: (0.2×89 + 0.67×90.8 + 1×60) / 1.87
+	if score.OverallScore != 74.1 {
+		t.Errorf("expected overall 74.1, got %.1f", score.OverallScore)
+	}
+	if math.Abs(score.DataCoverage-0.47) > 0.011 || score.InsufficientData || score.ModelVersion != HealthScoreModelVersion {
+		t.Errorf("unexpected coverage metadata: %+v", score)
+	}
+
+	byFeature := make(map[string]FeatureContribution)
+	for _, c := range score.Contributions {
+		byFeature[c.Feature] = c
+	}
+	if c := byFeature["blood_glucose"]; c.Source != SourceMeasurement || c.SampleCount != 30 || c.Contribution != 89 {
+		t.Errorf("unexpected glucose contribution: %+v", c)
+	}
+	if c := byFeature["calorie_balance"]; c.Value != 2100 || c.Score != 100 || c.Contribution != 50 {
+		t.Errorf("unexpected calorie contribution: %+v", c)
+	}
+	if _, ok := byFeature["goal:blood_pressure"]; ok {
+		t.Error("paused goals should not contribute")
+	}
+}
+
+func TestGetHealthScore_Deterministic(t *testing.T) {
+	svc, repo := newHealthScoreFixture()
+	first, err := svc.GetHealthScore(context.Background(), "user-1", 30)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	second, err := svc.GetHealthScore(context.Background(), "user-1", 30)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if first.OverallScore != second.OverallScore || len(first.Contributions) != len(second.Contributions) {
+		t.Errorf("expected identical scores, got %.1f and %.1f", first.OverallScore, second.OverallScore)
+	}
+	if first.Trend != TrendStable || second.Trend != TrendStable {
+		t.Errorf("expected stable trend for unchanged data, got %s, %s", first.Trend, second.Trend)
+	}
+	if n := len(repo.data["user-1"]); n != 2 {
+		t.Errorf("expected score history of 2, got %d", n)
+	}
+}
+
+func TestGetHealthScore_TrendFromHistory(t *testing.T) {
+	svc, repo := newHealthScoreFixture()
+	ctx := context.Background()
+	_ = repo.Save(ctx, &HealthScore{UserID: "user-1", OverallScore: 82, CalculatedAt: testNow.AddDate(0, 0, -7)})
+	_ = repo.Save(ctx, &HealthScore{UserID: "user-1", InsufficientData: true, CalculatedAt: testNow.AddDate(0, 0, -3)})
+	_ = repo.Save(ctx, &HealthScore{UserID: "user-1", OverallScore: 20, CalculatedAt: testNow.AddDate(0, 0, -60)})
+
+	score, err := svc.GetHealthScore(ctx, "user-1", 30)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// 기간 내 유효한 이전 점수는 82뿐 → 74.1은 하락
+	if score.Trend != TrendDeclining {
+		t.Errorf("expected declining, got %s", score.Trend)
+	}
+
+	repo.data["user-1"] = []*HealthScore{{UserID: "user-1", OverallScore: 65, CalculatedAt: testNow.AddDate(0, 0, -7)}}
+	score, _ = svc.GetHealthScore(ctx, "user-1", 30)
+	if score.Trend != TrendImproving {
+		t.Errorf("expected improving, got %s", score.Trend)
+	}
+}
+
+func TestGetHealthScore_InsufficientData(t *testing.T) {
+	svc := newTestService()
+	score, err := svc.GetHealthScore(context.Background(), "user-without-data", 30)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !score.InsufficientData || score.OverallScore != 0 || score.DataCoverage != 0 || len(score.CategoryScores) != 0 {
+		t.Errorf("expected insufficient data, got %+v", score)
+	}
+	if len(score.CategoryCoverage) != 4 || score.Trend != TrendStable {
+		t.Errorf("expected coverage for all categories and stable trend, got %+v", score)
+	}
+}
+
 func TestPredictTrend_Success(t *testing.T) {
 	svc := newTestService()
 	pred, err := svc.PredictTrend(context.Background(), "user-1", "blood_glucose", 30, 7)
@@ -197,8 +541,75 @@ func TestPredictTrend_Success(t *testing.T) {
 	if len(pred.Predicted) != 7 {
 		t.Errorf("expected 7 predicted points, got %d", len(pred.Predicted))
 	}
-	if pred.Direction == "" {
-		t.Error("expected non-empty direction")
+	if pred.Direction != "up" || pred.InsufficientData || pred.SampleCount != 30 || pred.Method == "" {
+		t.Errorf("expected rising forecast from 30 days, got %+v", pred)
+	}
+	if pred.Confidence < 0.9 || pred.Confidence > 1 {
+		t.Errorf("expected high backtest confidence, got %.3f", pred.Confidence)
+	}
+	prevWidth := 0.0
+	for i, p := range pred.Predicted {
+		if p.LowerBound > p.Value || p.UpperBound < p.Value {
+			t.Errorf("point %d outside its interval: %+v", i, p)
+		}
+		if width := p.UpperBound - p.LowerBound; width < prevWidth-0.011 {
+			t.Errorf("interval should not narrow with horizon: %d %.2f < %.2f", i, width, prevWidth)
+		} else {
+			prevWidth = width
+		}
+	}
+	if want := testNow.Truncate(24*time.Hour).AddDate(0, 0, 1); !pred.Predicted[0].Timestamp.Equal(want) {
+		t.Errorf("expected first prediction for %v, got %v", want, pred.Predicted[0].Timestamp)
+	}
+}
+
+func TestPredictTrend_InsufficientData(t *testing.T) {
+	svc := newTestService()
+	pred, err := svc.PredictTrend(context.Background(), "user-1", "cholesterol_total", 30, 7)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !pred.InsufficientData || len(pred.Predicted) != 0 || pred.Confidence != 0 || pred.Direction != "" {
+		t.Errorf("expected explicit insufficient-data result, got %+v", pred)
+	}
+}
+
+func TestPredictTrend_IrregularSamplingWithGaps(t *testing.T) {
+	client := newFakeMeasurementClient()
+	for _, back := range []int{27, 21, 20, 13, 12, 9, 5, 4, 4, 1} {
+		at := testNow.AddDate(0, 0, -back).Add(-time.Duration(back%5) * time.Hour)
+		client.history["user-1"] = append(client.history["user-1"], reading("cholesterol_total", 180+float64(back%4), at))
+	}
+	svc := NewInferenceService(newFakeAnalysisRepo(), newFakeHealthScoreRepo(),
+		WithMeasurementClient(client), WithClock(func() time.Time { return testNow }))
+
+	pred, err := svc.PredictTrend(context.Background(), "user-1", "cholesterol", 30, 5)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if pred.InsufficientData || pred.SampleCount != 9 || len(pred.Historical) != 9 || len(pred.Predicted) != 5 {
+		t.Fatalf("expected forecast over 9 observed days, got %+v", pred)
+	}
+	// 마지막 측정(1일 전) 이후 오늘까지의 공백을 건너뛰고 내일부터 예측
+	if want := testNow.Truncate(24*time.Hour).AddDate(0, 0, 1); !pred.Predicted[0].Timestamp.Equal(want) {
+		t.Errorf("expected first prediction for %v, got %v", want, pred.Predicted[0].Timestamp)
+	}
+}
+
+func TestForecastSeries_WeeklySeasonality(t *testing.T) {
+	pattern := []float64{0, 4, 8, 6, 2, -6, -14}
+	var obs []dailyObservation
+	for i := 0; i < 56; i++ {
+		obs = append(obs, dailyObservation{Day: testNow.AddDate(0, 0, i-56).Truncate(24 * time.Hour), Mean: 100 + pattern[i%7], Count: 1})
+	}
+	out := forecastSeries(newDailySeries(obs), 7, 7)
+	if out.method != MethodHoltWinters {
+		t.Fatalf("expected holt_winters for weekly pattern, got %s", out.method)
+	}
+	for h := 1; h <= 7; h++ {
+		if want := 100 + pattern[(56+h-1)%7]; math.Abs(out.values[h-1]-want) > 1 {
+			t.Errorf("h=%d: expected ~%.0f, got %.2f", h, want, out.values[h-1])
+		}
 	}
 }
 
@@ -246,8 +657,8 @@ func TestListModels(t *testing.T) {
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
-	if len(models) != 5 {
-		t.Errorf("expected 5 models, got %d", len(models))
+	if len(models) != 6 {
+		t.Errorf("expected 6 models, got %d", len(models))
 	}
 }
 
@@ -259,10 +670,14 @@ type mockLLMClient struct {
 	response *llm.ChatResponse
 	err      error
 	called   int
+	prompts  []string
 }
 
-func (m *mockLLMClient) Chat(_ context.Context, _ string, _ []llm.ChatMessage) (*llm.ChatResponse, error) {
+func (m *mockLLMClient) Chat(_ context.Context, _ string, messages []llm.ChatMessage) (*llm.ChatResponse, error) {
 	m.called++
+	for _, msg := range messages {
+		m.prompts = append(m.prompts, msg.Content)
+	}
 	if m.err != nil {
 		return nil, m.err
 	}
@@ -270,7 +685,8 @@ func (m *mockLLMClient) Chat(_ context.Context, _ string, _ []llm.ChatMessage) (
 }
 
 func newTestServiceWithLLM(client llm.LLMClient) *InferenceService {
-	return NewInferenceService(newFakeAnalysisRepo(), newFakeHealthScoreRepo(), WithLLMClient(client))
+	return NewInferenceService(newFakeAnalysisRepo(), newFakeHealthScoreRepo(),
+		WithLLMClient(client), WithMeasurementClient(newFakeMeasurementClient()), WithClock(func() time.Time { return testNow }))
 }
 
 // ============================================================================
@@ -409,6 +825,27 @@ func TestAnalyzeMeasurement_WithLLM_EnhancedSummary(t *testing.T) {
 	}
 }
 
+func TestAnalyzeMeasurement_LLMPromptContainsOnlyRealFindings(t *testing.T) {
+	mock := &mockLLMClient{response: &llm.ChatResponse{Content: "요약"}}
+	svc := newTestServiceWithLLM(mock)
+
+	if _, err := svc.AnalyzeMeasurement(context.Background(), "user-1", "meas-2", nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(mock.prompts) != 1 {
+		t.Fatalf("expected one prompt, got %d", len(mock.prompts))
+	}
+	prompt := mock.prompts[0]
+	if !strings.Contains(prompt, "heart_rate: 72.0 bpm") || !strings.Contains(prompt, "60-100 bpm") {
+		t.Errorf("prompt should contain the measured heart rate and its reference range: %s", prompt)
+	}
+	for _, absent := range []string{"blood_glucose", "cholesterol_total", "hemoglobin", "heart_rate_variability", "이상치"} {
+		if strings.Contains(prompt, absent) {
+			t.Errorf("prompt should not mention %q: %s", absent, prompt)
+		}
+	}
+}
+
 func TestGetHealthScore_WithLLM_EnhancedRecommendation(t *testing.T) {
 	mock := &mockLLMClient{
 		response: &llm.ChatResponse{
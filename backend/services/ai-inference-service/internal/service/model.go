@@ -0,0 +1,119 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+
+	"github.com/manpasik/backend/shared/clients"
+)
+
+// ModelInput은 측정 분석 모델의 입력입니다.
+type ModelInput struct {
+	Readings []clients.MeasurementSummary
+}
+
+// ModelOutput은 측정 분석 모델의 출력입니다.
+type ModelOutput struct {
+	Biomarkers  []BiomarkerResult // 입력 Readings와 같은 순서
+	Anomalies   []AnomalyFlag
+	HealthScore float64
+}
+
+// Model은 레지스트리에 등록되는 측정 분석 모델입니다.
+// 같은 입력에는 같은 출력을 반환해야 섀도 비교가 의미를 가집니다.
+type Model interface {
+	Predict(ctx context.Context, in ModelInput) (*ModelOutput, error)
+}
+
+// ModelLoader는 아티팩트 바이트로 모델을 생성합니다. 빈 아티팩트는 내장 기본값을 뜻합니다.
+type ModelLoader func(artifact []byte) (Model, error)
+
+// KindReferenceRange는 참조 범위 표 기반 모델의 아티팩트 종류입니다.
+const KindReferenceRange = "reference_range"
+
+// referenceRangeModel은 참조 범위 표로 분류·이상치·점수를 산출합니다.
+type referenceRangeModel struct {
+	table referenceTable
+}
+
+func (m *referenceRangeModel) Predict(_ context.Context, in ModelInput) (*ModelOutput, error) {
+	biomarkers := make([]BiomarkerResult, 0, len(in.Readings))
+	for _, r := range in.Readings {
+		biomarkers = append(biomarkers, m.table.classify(r))
+	}
+	return &ModelOutput{
+		Biomarkers:  biomarkers,
+		Anomalies:   m.table.detectAnomalies(biomarkers),
+		HealthScore: scoreFromBiomarkers(biomarkers),
+	}, nil
+}
+
+// referenceRangeArtifact는 reference_range 아티팩트(JSON) 형식입니다.
+// 열린 경계는 min 또는 max를 생략(null)합니다.
+type referenceRangeArtifact struct {
+	Ranges []struct {
+		Biomarker  string         `json:"biomarker"`
+		Unit       string         `json:"unit"`
+		Display    string         `json:"display"`
+		Normal     artifactBounds `json:"normal"`
+		Borderline artifactBounds `json:"borderline"`
+		Critical   artifactBounds `json:"critical"`
+	} `json:"ranges"`
+}
+
+type artifactBounds struct {
+	Min *float64 `json:"min"`
+	Max *float64 `json:"max"`
+}
+
+func (b artifactBounds) bounds() Bounds {
+	out := Bounds{Min: math.Inf(-1), Max: math.Inf(1)}
+	if b.Min != nil {
+		out.Min = *b.Min
+	}
+	if b.Max != nil {
+		out.Max = *b.Max
+	}
+	return out
+}
+
+// loadReferenceRangeModel은 아티팩트의 참조 범위로 기본 표를 덮어쓴 모델을 생성합니다.
+func loadReferenceRangeModel(artifact []byte) (Model, error) {
+	table := make(referenceTable, len(defaultReferenceRanges))
+	for k, v := range defaultReferenceRanges {
+		table[k] = v
+	}
+	if len(artifact) == 0 {
+		return &referenceRangeModel{table: table}, nil
+	}
+
+	var a referenceRangeArtifact
+	if err := json.Unmarshal(artifact, &a); err != nil {
+		return nil, fmt.Errorf("reference_range artifact: %w", err)
+	}
+	for _, r := range a.Ranges {
+		key := canonicalBiomarker(r.Biomarker)
+		if key == "" || r.Unit == "" {
+			return nil, fmt.Errorf("reference_range artifact: biomarker and unit are required")
+		}
+		rr := ReferenceRange{
+			Biomarker:  key,
+			Unit:       r.Unit,
+			Normal:     r.Normal.bounds(),
+			Borderline: r.Borderline.bounds(),
+			Critical:   r.Critical.bounds(),
+			Display:    r.Display,
+		}
+		if !nested(rr.Normal, rr.Borderline) || !nested(rr.Borderline, rr.Critical) {
+			return nil, fmt.Errorf("reference_range artifact: %s ranges must be nested normal ⊂ borderline ⊂ critical", key)
+		}
+		table[key] = rr
+	}
+	return &referenceRangeModel{table: table}, nil
+}
+
+func nested(inner, outer Bounds) bool {
+	return inner.Min <= inner.Max && outer.Min <= inner.Min && inner.Max <= outer.Max
+}
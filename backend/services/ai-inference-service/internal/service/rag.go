@@ -0,0 +1,521 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/manpasik/backend/services/ai-inference-service/internal/llm"
+	"github.com/manpasik/backend/shared/clients"
+	apperrors "github.com/manpasik/backend/shared/errors"
+)
+
+// AssistantProviderID는 AI 건강 어시스턴트의 데이터 공유 동의 제공자 ID입니다.
+// 사용자가 이 제공자에게 health-record-service 데이터 공유 동의를 부여한 scope만 검색 증강에 사용합니다.
+const AssistantProviderID = "manpasik-ai-assistant"
+
+// 검색 증강(RAG) 출처 유형. 데이터 공유 동의의 scope 값으로도 사용합니다.
+const (
+	RAGSourceHealthRecord = "health_record"
+	RAGSourceMeasurement  = "measurement"
+	RAGSourcePrescription = "prescription"
+	RAGSourceCoaching     = "coaching"
+)
+
+// ragSources는 색인 순서와 출처 표시 이름입니다.
+var ragSources = []struct {
+	source string
+	label  string
+}{
+	{RAGSourceHealthRecord, "건강 기록"},
+	{RAGSourceMeasurement, "측정 요약"},
+	{RAGSourcePrescription, "처방"},
+	{RAGSourceCoaching, "코칭"},
+}
+
+const (
+	ragRecordLimit       = 50                  // 색인할 최근 건강 기록 수
+	ragPrescriptionLimit = 20                  // 색인할 최근 처방 수
+	ragCoachingLimit     = 20                  // 색인할 최근 코칭 메시지 수
+	ragMeasurementWindow = 30 * 24 * time.Hour // 측정 요약 기간
+	ragSearchCandidates  = 20                  // 동의·점수 필터 전 벡터 검색 후보 수
+	ragTopK              = 4                   // 프롬프트에 넣을 자료 수
+	ragSnippetRunes      = 600                 // 자료 하나의 최대 길이
+
+	// DefaultRAGRefreshInterval은 사용자 색인을 다시 만드는 기본 주기입니다.
+	DefaultRAGRefreshInterval = 30 * time.Minute
+	// DefaultRAGMinScore는 프롬프트에 넣을 자료의 최소 코사인 유사도입니다.
+	DefaultRAGMinScore = 0.2
+)
+
+// HealthSnippet은 검색 증강용으로 색인한 사용자 건강 데이터 조각입니다.
+type HealthSnippet struct {
+	SnippetID  string // "{SourceType}:{SourceID}"
+	UserID     string
+	SourceType string // RAGSourceHealthRecord 등
+	SourceID   string // 원본 ID (측정 요약은 바이오마커 ID)
+	Title      string
+	Text       string
+	RecordedAt time.Time // 원본 기록 시각 (알 수 없으면 0)
+	IndexedAt  time.Time
+}
+
+// RetrievedSnippet은 질문과 관련된 자료와 유사도입니다.
+type RetrievedSnippet struct {
+	Snippet *HealthSnippet
+	Score   float32
+}
+
+// VectorHit는 벡터 검색 결과 한 건입니다.
+type VectorHit struct {
+	SnippetID string
+	Score     float32 // 코사인 유사도
+}
+
+// SnippetRepository는 색인한 자료의 본문 저장소입니다.
+type SnippetRepository interface {
+	// ListSnippets는 사용자의 모든 자료를 반환합니다.
+	ListSnippets(ctx context.Context, userID string) ([]*HealthSnippet, error)
+	// ReplaceSnippets는 사용자의 sourceType 자료를 snippets로 교체합니다 (nil이면 모두 삭제).
+	ReplaceSnippets(ctx context.Context, userID, sourceType string, snippets []*HealthSnippet) error
+}
+
+// SnippetVectorIndex는 사용자별로 분리된 자료 벡터 색인입니다.
+// 삭제된 자료의 벡터는 남아 있을 수 있으며, 검색 결과는 SnippetRepository에 있는 자료로 걸러냅니다.
+type SnippetVectorIndex interface {
+	Upsert(ctx context.Context, userID, snippetID string, vector []float32) error
+	// Search는 userID의 벡터 중 vector와 유사한 상위 topK개를 유사도 내림차순으로 반환합니다.
+	Search(ctx context.Context, userID string, vector []float32, topK int) ([]VectorHit, error)
+}
+
+// RetrieverOption은 HealthRetriever 생성 시 옵션을 설정하는 함수 타입입니다.
+type RetrieverOption func(*HealthRetriever)
+
+// WithRetrieverMeasurements는 측정 요약 출처를 설정합니다.
+func WithRetrieverMeasurements(c clients.MeasurementClient) RetrieverOption {
+	return func(r *HealthRetriever) {
+		r.measurements = c
+	}
+}
+
+// WithRetrieverPrescriptions는 처방 출처를 설정합니다.
+func WithRetrieverPrescriptions(c clients.PrescriptionHistoryClient) RetrieverOption {
+	return func(r *HealthRetriever) {
+		r.prescriptions = c
+	}
+}
+
+// WithRetrieverCoaching은 코칭 메시지 출처를 설정합니다.
+func WithRetrieverCoaching(c clients.CoachingClient) RetrieverOption {
+	return func(r *HealthRetriever) {
+		r.coaching = c
+	}
+}
+
+// WithRetrieverRefreshInterval은 사용자 색인 갱신 주기를 설정합니다.
+func WithRetrieverRefreshInterval(d time.Duration) RetrieverOption {
+	return func(r *HealthRetriever) {
+		if d > 0 {
+			r.refreshEvery = d
+		}
+	}
+}
+
+// HealthRetriever는 사용자 본인의 건강 기록·측정 요약·처방·코칭 메시지를 사용자별로 색인하고
+// 질문과 관련된 자료를 찾습니다.
+//
+// 모든 접근은 데이터 공유와 같은 동의 검사를 거칩니다: 사용자가 AssistantProviderID에게 부여한
+// 활성·미만료 동의의 scope에 포함된 출처만 색인하고 검색 결과로 반환합니다. 동의가 철회되면
+// 검색에서 즉시 제외되고 다음 색인 갱신 때 저장된 자료도 삭제됩니다.
+type HealthRetriever struct {
+	records       clients.HealthRecordClient // 동의 조회와 건강 기록 출처
+	embedder      llm.Embedder
+	snippets      SnippetRepository
+	index         SnippetVectorIndex
+	measurements  clients.MeasurementClient
+	prescriptions clients.PrescriptionHistoryClient
+	coaching      clients.CoachingClient
+	refreshEvery  time.Duration
+	minScore      float32
+	now           func() time.Time
+
+	mu        sync.Mutex
+	indexedAt map[string]time.Time // 사용자별 마지막 색인 시각
+}
+
+// NewHealthRetriever는 검색기를 생성합니다. records는 동의 확인에 필요하므로 필수입니다.
+func NewHealthRetriever(records clients.HealthRecordClient, embedder llm.Embedder, snippets SnippetRepository, index SnippetVectorIndex, opts ...RetrieverOption) *HealthRetriever {
+	r := &HealthRetriever{
+		records:      records,
+		embedder:     embedder,
+		snippets:     snippets,
+		index:        index,
+		refreshEvery: DefaultRAGRefreshInterval,
+		minScore:     DefaultRAGMinScore,
+		now:          time.Now,
+		indexedAt:    make(map[string]time.Time),
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// WithHealthRetriever는 AI 채팅의 검색 증강을 활성화합니다.
+func WithHealthRetriever(r *HealthRetriever) InferenceOption {
+	return func(s *InferenceService) {
+		s.retriever = r
+	}
+}
+
+// ConsentedSources는 사용자가 AI 어시스턴트에게 공유를 동의한 출처 유형입니다.
+// health-record-service의 동의 접근 검사(CheckAccess)와 같이 제공자·활성 상태·만료·scope를 확인합니다.
+func (r *HealthRetriever) ConsentedSources(ctx context.Context, userID string) (map[string]bool, error) {
+	consents, err := r.records.ListConsents(ctx, userID)
+	if err != nil {
+		return nil, apperrors.New(apperrors.ErrServiceUnavailable, "데이터 공유 동의 조회에 실패했습니다").WithDetails(err.Error())
+	}
+	now := r.now()
+	allowed := make(map[string]bool)
+	for _, c := range consents {
+		if c.ProviderID != AssistantProviderID || c.Status != "active" || !now.Before(c.ExpiresAt) {
+			continue
+		}
+		for _, scope := range c.Scope {
+			if sourceLabel(scope) != "" {
+				allowed[scope] = true
+			}
+		}
+	}
+	return allowed, nil
+}
+
+// Index는 동의한 출처의 자료를 다시 색인하고 동의하지 않은 출처의 자료를 삭제합니다.
+// 본문이 바뀌지 않은 자료는 다시 임베딩하지 않습니다.
+func (r *HealthRetriever) Index(ctx context.Context, userID string) error {
+	allowed, err := r.ConsentedSources(ctx, userID)
+	if err != nil {
+		return err
+	}
+	return r.reindex(ctx, userID, allowed)
+}
+
+func (r *HealthRetriever) reindex(ctx context.Context, userID string, allowed map[string]bool) error {
+	existing, err := r.snippets.ListSnippets(ctx, userID)
+	if err != nil {
+		return apperrors.New(apperrors.ErrInternal, "색인 자료 조회에 실패했습니다").WithDetails(err.Error())
+	}
+	indexedText := make(map[string]string, len(existing))
+	for _, sn := range existing {
+		indexedText[sn.SnippetID] = sn.Title + "\n" + sn.Text
+	}
+
+	now := r.now()
+	for _, src := range ragSources {
+		var snippets []*HealthSnippet
+		if allowed[src.source] {
+			var ok bool
+			snippets, ok, err = r.collect(ctx, userID, src.source, now)
+			if err != nil {
+				return apperrors.New(apperrors.ErrServiceUnavailable, fmt.Sprintf("%s 조회에 실패했습니다", src.label)).WithDetails(err.Error())
+			}
+			if !ok {
+				continue // 출처 미연결: 기존 자료 유지
+			}
+		}
+
+		var changed []*HealthSnippet
+		for _, sn := range snippets {
+			sn.UserID, sn.IndexedAt = userID, now
+			sn.SnippetID = sn.SourceType + ":" + sn.SourceID
+			if text, ok := indexedText[sn.SnippetID]; !ok || text != sn.Title+"\n"+sn.Text {
+				changed = append(changed, sn)
+			}
+		}
+		if err := r.embedAndUpsert(ctx, userID, changed); err != nil {
+			return err
+		}
+		if err := r.snippets.ReplaceSnippets(ctx, userID, src.source, snippets); err != nil {
+			return apperrors.New(apperrors.ErrInternal, "색인 자료 저장에 실패했습니다").WithDetails(err.Error())
+		}
+	}
+
+	r.mu.Lock()
+	r.indexedAt[userID] = now
+	r.mu.Unlock()
+	return nil
+}
+
+func (r *HealthRetriever) embedAndUpsert(ctx context.Context, userID string, snippets []*HealthSnippet) error {
+	if len(snippets) == 0 {
+		return nil
+	}
+	texts := make([]string, len(snippets))
+	for i, sn := range snippets {
+		texts[i] = sn.Title + "\n" + sn.Text
+	}
+	vectors, err := r.embedder.Embed(llm.WithUser(ctx, userID), texts)
+	if err != nil {
+		return apperrors.New(apperrors.ErrServiceUnavailable, "자료 임베딩에 실패했습니다").WithDetails(err.Error())
+	}
+	for i, sn := range snippets {
+		if err := r.index.Upsert(ctx, userID, sn.SnippetID, vectors[i]); err != nil {
+			return apperrors.New(apperrors.ErrInternal, "벡터 색인 저장에 실패했습니다").WithDetails(err.Error())
+		}
+	}
+	return nil
+}
+
+// Retrieve는 질문과 관련된 자료를 최대 ragTopK개 반환합니다. 동의한 출처가 없으면 빈 결과입니다.
+// 색인이 오래됐으면 먼저 갱신하며, 갱신에 실패하면 기존 색인으로 검색합니다.
+func (r *HealthRetriever) Retrieve(ctx context.Context, userID, query string) ([]*RetrievedSnippet, error) {
+	allowed, err := r.ConsentedSources(ctx, userID)
+	if err != nil || len(allowed) == 0 {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	last, ok := r.indexedAt[userID]
+	r.mu.Unlock()
+	if !ok || r.now().Sub(last) >= r.refreshEvery {
+		_ = r.reindex(ctx, userID, allowed)
+	}
+
+	vectors, err := r.embedder.Embed(llm.WithUser(ctx, userID), []string{query})
+	if err != nil {
+		return nil, apperrors.New(apperrors.ErrServiceUnavailable, "질문 임베딩에 실패했습니다").WithDetails(err.Error())
+	}
+	hits, err := r.index.Search(ctx, userID, vectors[0], ragSearchCandidates)
+	if err != nil {
+		return nil, apperrors.New(apperrors.ErrInternal, "벡터 검색에 실패했습니다").WithDetails(err.Error())
+	}
+	stored, err := r.snippets.ListSnippets(ctx, userID)
+	if err != nil {
+		return nil, apperrors.New(apperrors.ErrInternal, "색인 자료 조회에 실패했습니다").WithDetails(err.Error())
+	}
+	byID := make(map[string]*HealthSnippet, len(stored))
+	for _, sn := range stored {
+		byID[sn.SnippetID] = sn
+	}
+
+	var out []*RetrievedSnippet
+	for _, h := range hits {
+		sn, ok := byID[h.SnippetID]
+		if !ok || !allowed[sn.SourceType] || h.Score < r.minScore {
+			continue
+		}
+		out = append(out, &RetrievedSnippet{Snippet: sn, Score: h.Score})
+		if len(out) == ragTopK {
+			break
+		}
+	}
+	return out, nil
+}
+
+// collect는 출처 하나의 자료를 만듭니다. 출처 클라이언트가 연결되지 않았으면 ok=false입니다.
+func (r *HealthRetriever) collect(ctx context.Context, userID, source string, now time.Time) ([]*HealthSnippet, bool, error) {
+	switch source {
+	case RAGSourceHealthRecord:
+		records, err := r.records.ListHealthRecords(ctx, userID, ragRecordLimit)
+		if err != nil {
+			return nil, true, err
+		}
+		return healthRecordSnippets(records), true, nil
+	case RAGSourceMeasurement:
+		if r.measurements == nil {
+			return nil, false, nil
+		}
+		readings, err := r.measurements.GetMeasurementsInRange(ctx, userID, now.Add(-ragMeasurementWindow), now)
+		if err != nil {
+			return nil, true, err
+		}
+		return measurementSnippets(readings), true, nil
+	case RAGSourcePrescription:
+		if r.prescriptions == nil {
+			return nil, false, nil
+		}
+		prescriptions, err := r.prescriptions.ListPrescriptions(ctx, userID, ragPrescriptionLimit)
+		if err != nil {
+			return nil, true, err
+		}
+		return prescriptionSnippets(prescriptions), true, nil
+	case RAGSourceCoaching:
+		if r.coaching == nil {
+			return nil, false, nil
+		}
+		messages, err := r.coaching.ListCoachingMessages(ctx, userID, ragCoachingLimit)
+		if err != nil {
+			return nil, true, err
+		}
+		return coachingSnippets(messages), true, nil
+	}
+	return nil, false, nil
+}
+
+func healthRecordSnippets(records []clients.HealthRecordSummary) []*HealthSnippet {
+	out := make([]*HealthSnippet, 0, len(records))
+	for _, rec := range records {
+		text := rec.Description
+		if rec.Provider != "" {
+			text += fmt.Sprintf(" (기관: %s)", rec.Provider)
+		}
+		out = append(out, &HealthSnippet{
+			SourceType: RAGSourceHealthRecord,
+			SourceID:   rec.RecordID,
+			Title:      strings.TrimSpace(rec.Title + " " + recordTypeLabel(rec.RecordType)),
+			Text:       truncateRunes(strings.TrimSpace(text), ragSnippetRunes),
+			RecordedAt: rec.CreatedAt,
+		})
+	}
+	return out
+}
+
+// measurementSnippets는 바이오마커별로 기간 내 측정을 요약합니다.
+func measurementSnippets(readings []clients.MeasurementSummary) []*HealthSnippet {
+	type agg struct {
+		unit                  string
+		n                     int
+		sum, min, max, latest float64
+		latestAt              time.Time
+	}
+	byMarker := make(map[string]*agg)
+	for _, m := range readings {
+		a, ok := byMarker[m.BiomarkerID]
+		if !ok {
+			a = &agg{unit: m.Unit, min: math.Inf(1), max: math.Inf(-1)}
+			byMarker[m.BiomarkerID] = a
+		}
+		a.n++
+		a.sum += m.Value
+		a.min = math.Min(a.min, m.Value)
+		a.max = math.Max(a.max, m.Value)
+		at, _ := time.Parse(time.RFC3339, m.MeasuredAt)
+		if a.n == 1 || at.After(a.latestAt) {
+			a.latest, a.latestAt = m.Value, at
+		}
+	}
+
+	markers := make([]string, 0, len(byMarker))
+	for id := range byMarker {
+		markers = append(markers, id)
+	}
+	sort.Strings(markers)
+	out := make([]*HealthSnippet, 0, len(markers))
+	for _, id := range markers {
+		a := byMarker[id]
+		text := fmt.Sprintf("최근 30일 %d회 측정: 평균 %.1f %s (최저 %.1f, 최고 %.1f), 마지막 측정 %.1f %s",
+			a.n, a.sum/float64(a.n), a.unit, a.min, a.max, a.latest, a.unit)
+		if !a.latestAt.IsZero() {
+			text += " (" + a.latestAt.Format("2006-01-02") + ")"
+		}
+		out = append(out, &HealthSnippet{
+			SourceType: RAGSourceMeasurement,
+			SourceID:   id,
+			Title:      id + " 측정 요약",
+			Text:       text,
+			RecordedAt: a.latestAt,
+		})
+	}
+	return out
+}
+
+func prescriptionSnippets(prescriptions []clients.PrescriptionSummary) []*HealthSnippet {
+	out := make([]*HealthSnippet, 0, len(prescriptions))
+	for _, p := range prescriptions {
+		var sb strings.Builder
+		if p.Diagnosis != "" {
+			sb.WriteString("진단명: " + p.Diagnosis + "\n")
+		}
+		for _, m := range p.Medications {
+			sb.WriteString("- " + strings.Join(nonEmpty(m.Name, m.Dosage, m.Frequency, m.Duration), " "))
+			sb.WriteString("\n")
+		}
+		if p.Notes != "" {
+			sb.WriteString("메모: " + p.Notes + "\n")
+		}
+		if p.Status != "" {
+			sb.WriteString("상태: " + p.Status)
+		}
+		title := "처방"
+		if p.DoctorName != "" {
+			title += " (" + p.DoctorName + ")"
+		}
+		out = append(out, &HealthSnippet{
+			SourceType: RAGSourcePrescription,
+			SourceID:   p.PrescriptionID,
+			Title:      title,
+			Text:       truncateRunes(strings.TrimSpace(sb.String()), ragSnippetRunes),
+			RecordedAt: p.PrescribedAt,
+		})
+	}
+	return out
+}
+
+func coachingSnippets(messages []clients.CoachingMessageSummary) []*HealthSnippet {
+	out := make([]*HealthSnippet, 0, len(messages))
+	for _, m := range messages {
+		text := m.Body
+		if m.RelatedMetric != "" {
+			text += " (관련 지표: " + m.RelatedMetric + ")"
+		}
+		out = append(out, &HealthSnippet{
+			SourceType: RAGSourceCoaching,
+			SourceID:   m.MessageID,
+			Title:      m.Title,
+			Text:       truncateRunes(strings.TrimSpace(text), ragSnippetRunes),
+			RecordedAt: m.CreatedAt,
+		})
+	}
+	return out
+}
+
+func sourceLabel(source string) string {
+	for _, s := range ragSources {
+		if s.source == source {
+			return s.label
+		}
+	}
+	return ""
+}
+
+// recordTypeLabel은 건강 기록 유형(lab_result 등)의 표시 이름입니다.
+func recordTypeLabel(recordType string) string {
+	switch recordType {
+	case "lab_result":
+		return "[검사 결과]"
+	case "imaging":
+		return "[영상 검사]"
+	case "vital_sign":
+		return "[활력 징후]"
+	case "allergy":
+		return "[알레르기]"
+	case "condition":
+		return "[질환]"
+	case "immunization":
+		return "[예방접종]"
+	case "procedure":
+		return "[시술]"
+	}
+	return ""
+}
+
+func truncateRunes(s string, n int) string {
+	if r := []rune(s); len(r) > n {
+		return string(r[:n]) + "…"
+	}
+	return s
+}
+
+func nonEmpty(values ...string) []string {
+	out := values[:0:0]
+	for _, v := range values {
+		if v != "" {
+			out = append(out, v)
+		}
+	}
+	return out
+}
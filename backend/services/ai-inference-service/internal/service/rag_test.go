@@ -0,0 +1,238 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/manpasik/backend/services/ai-inference-service/internal/llm"
+	"github.com/manpasik/backend/shared/clients"
+)
+
+type fakeHealthRecordClient struct {
+	records  []clients.HealthRecordSummary
+	consents []clients.DataSharingConsentSummary
+}
+
+func (c *fakeHealthRecordClient) ListHealthRecords(_ context.Context, _ string, _ int) ([]clients.HealthRecordSummary, error) {
+	return c.records, nil
+}
+
+func (c *fakeHealthRecordClient) ListConsents(_ context.Context, _ string) ([]clients.DataSharingConsentSummary, error) {
+	return c.consents, nil
+}
+
+type fakePrescriptionClient struct {
+	prescriptions []clients.PrescriptionSummary
+}
+
+func (c *fakePrescriptionClient) ListPrescriptions(_ context.Context, _ string, _ int) ([]clients.PrescriptionSummary, error) {
+	return c.prescriptions, nil
+}
+
+// fakeSnippetStore는 자료 본문 저장소와 코사인 유사도 벡터 색인을 함께 구현합니다.
+type fakeSnippetStore struct {
+	mu       sync.Mutex
+	snippets map[string]map[string]*HealthSnippet // userID → snippetID → 자료
+	vectors  map[string]map[string][]float32
+	upserts  int
+}
+
+func newFakeSnippetStore() *fakeSnippetStore {
+	return &fakeSnippetStore{snippets: map[string]map[string]*HealthSnippet{}, vectors: map[string]map[string][]float32{}}
+}
+
+func (f *fakeSnippetStore) ListSnippets(_ context.Context, userID string) ([]*HealthSnippet, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var out []*HealthSnippet
+	for _, sn := range f.snippets[userID] {
+		out = append(out, sn)
+	}
+	return out, nil
+}
+
+func (f *fakeSnippetStore) ReplaceSnippets(_ context.Context, userID, sourceType string, snippets []*HealthSnippet) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.snippets[userID] == nil {
+		f.snippets[userID] = map[string]*HealthSnippet{}
+	}
+	for id, sn := range f.snippets[userID] {
+		if sn.SourceType == sourceType {
+			delete(f.snippets[userID], id)
+		}
+	}
+	for _, sn := range snippets {
+		f.snippets[userID][sn.SnippetID] = sn
+	}
+	return nil
+}
+
+func (f *fakeSnippetStore) Upsert(_ context.Context, userID, snippetID string, vector []float32) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.vectors[userID] == nil {
+		f.vectors[userID] = map[string][]float32{}
+	}
+	f.vectors[userID][snippetID] = vector
+	f.upserts++
+	return nil
+}
+
+func (f *fakeSnippetStore) Search(_ context.Context, userID string, vector []float32, topK int) ([]VectorHit, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var hits []VectorHit
+	for id, v := range f.vectors[userID] {
+		var dot float32
+		for i := range v {
+			dot += v[i] * vector[i]
+		}
+		hits = append(hits, VectorHit{SnippetID: id, Score: dot})
+	}
+	sort.Slice(hits, func(i, j int) bool { return hits[i].Score > hits[j].Score })
+	if len(hits) > topK {
+		hits = hits[:topK]
+	}
+	return hits, nil
+}
+
+func assistantConsent(scope ...string) clients.DataSharingConsentSummary {
+	return clients.DataSharingConsentSummary{
+		ConsentID: "consent-ai", ProviderID: AssistantProviderID, Scope: scope,
+		Status: "active", ExpiresAt: testNow.AddDate(1, 0, 0),
+	}
+}
+
+func newTestRetriever(records *fakeHealthRecordClient, store *fakeSnippetStore) *HealthRetriever {
+	r := NewHealthRetriever(records, llm.NewHashEmbedder(256), store, store,
+		WithRetrieverPrescriptions(&fakePrescriptionClient{prescriptions: []clients.PrescriptionSummary{{
+			PrescriptionID: "rx-1", DoctorName: "이정민", Diagnosis: "제2형 당뇨병",
+			Medications:  []clients.MedicationItem{{Name: "메트포르민", Dosage: "500mg", Frequency: "1일 2회"}},
+			PrescribedAt: testNow.AddDate(0, -1, 0),
+		}}}),
+		WithRetrieverMeasurements(newFakeMeasurementClient()))
+	r.now = func() time.Time { return testNow }
+	return r
+}
+
+func testHealthRecords() []clients.HealthRecordSummary {
+	return []clients.HealthRecordSummary{
+		{RecordID: "rec-1", RecordType: "lab_result", Title: "혈액검사 결과", Description: "공복혈당 126 mg/dL, 당화혈색소 6.8%", Provider: "서울내과", CreatedAt: testNow.AddDate(0, 0, -10)},
+		{RecordID: "rec-2", RecordType: "immunization", Title: "독감 예방접종", Description: "인플루엔자 4가 백신 접종", CreatedAt: testNow.AddDate(0, -2, 0)},
+	}
+}
+
+func TestHealthRetriever_OnlyConsentedSources(t *testing.T) {
+	records := &fakeHealthRecordClient{
+		records: testHealthRecords(),
+		consents: []clients.DataSharingConsentSummary{
+			assistantConsent(RAGSourceHealthRecord),
+			// 다른 제공자 동의, 만료된 동의는 AI 어시스턴트 접근을 허용하지 않음
+			{ProviderID: "hospital-1", Scope: []string{RAGSourcePrescription}, Status: "active", ExpiresAt: testNow.AddDate(1, 0, 0)},
+			{ProviderID: AssistantProviderID, Scope: []string{RAGSourcePrescription}, Status: "active", ExpiresAt: testNow.Add(-time.Hour)},
+		},
+	}
+	store := newFakeSnippetStore()
+	r := newTestRetriever(records, store)
+
+	refs, err := r.Retrieve(context.Background(), "user-1", "공복혈당 검사 결과가 어땠지?")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(refs) == 0 || refs[0].Snippet.SourceID != "rec-1" {
+		t.Fatalf("expected the lab result first, got %+v", refs)
+	}
+	for _, ref := range refs {
+		if ref.Snippet.SourceType != RAGSourceHealthRecord {
+			t.Errorf("unconsented source retrieved: %+v", ref.Snippet)
+		}
+	}
+	stored, _ := store.ListSnippets(context.Background(), "user-1")
+	for _, sn := range stored {
+		if sn.SourceType != RAGSourceHealthRecord {
+			t.Errorf("unconsented source indexed: %+v", sn)
+		}
+	}
+}
+
+func TestHealthRetriever_NoConsentNoAccess(t *testing.T) {
+	records := &fakeHealthRecordClient{records: testHealthRecords()}
+	store := newFakeSnippetStore()
+	r := newTestRetriever(records, store)
+
+	refs, err := r.Retrieve(context.Background(), "user-1", "공복혈당")
+	if err != nil || len(refs) != 0 {
+		t.Fatalf("expected no references without consent, got %+v (err %v)", refs, err)
+	}
+	if store.upserts != 0 {
+		t.Errorf("nothing should be indexed without consent, got %d upserts", store.upserts)
+	}
+}
+
+func TestHealthRetriever_RevokedConsentPurgesIndex(t *testing.T) {
+	records := &fakeHealthRecordClient{
+		records:  testHealthRecords(),
+		consents: []clients.DataSharingConsentSummary{assistantConsent(RAGSourceHealthRecord, RAGSourcePrescription)},
+	}
+	store := newFakeSnippetStore()
+	r := newTestRetriever(records, store)
+	if err := r.Index(context.Background(), "user-1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if stored, _ := store.ListSnippets(context.Background(), "user-1"); len(stored) != 3 {
+		t.Fatalf("expected 2 records + 1 prescription indexed, got %d", len(stored))
+	}
+	// 변경 없는 재색인은 다시 임베딩하지 않음
+	if err := r.Index(context.Background(), "user-1"); err != nil || store.upserts != 3 {
+		t.Fatalf("unchanged snippets re-embedded: upserts=%d err=%v", store.upserts, err)
+	}
+
+	records.consents[0].Status = "revoked"
+	// 철회 직후 검색에서 제외
+	if refs, _ := r.Retrieve(context.Background(), "user-1", "공복혈당"); len(refs) != 0 {
+		t.Errorf("revoked consent must block retrieval, got %+v", refs)
+	}
+	if err := r.Index(context.Background(), "user-1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if stored, _ := store.ListSnippets(context.Background(), "user-1"); len(stored) != 0 {
+		t.Errorf("revoked sources must be purged, got %d snippets", len(stored))
+	}
+}
+
+func TestStreamChat_CitesRetrievedSources(t *testing.T) {
+	client := &fakeStreamingLLM{chunks: []string{"최근 공복혈당은 126 mg/dL였습니다 [1]."}}
+	svc, repo, _ := newChatTestService(client)
+	records := &fakeHealthRecordClient{
+		records:  testHealthRecords(),
+		consents: []clients.DataSharingConsentSummary{assistantConsent(RAGSourceHealthRecord)},
+	}
+	WithHealthRetriever(newTestRetriever(records, newFakeSnippetStore()))(svc)
+
+	var deltas []string
+	reply, err := svc.StreamChat(context.Background(), "user-1", "", "지난 혈액검사 공복혈당 수치 알려줘", nil, func(_, delta string) error {
+		deltas = append(deltas, delta)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(client.systemPrompt, "[참고 자료]") || !strings.Contains(client.systemPrompt, "[1] 건강 기록 · 혈액검사 결과 [검사 결과]") {
+		t.Errorf("system prompt lacks references:\n%s", client.systemPrompt)
+	}
+	if len(reply.Sources) != 1 || reply.Sources[0].SourceID != "rec-1" || reply.Sources[0].Index != 1 {
+		t.Fatalf("unexpected sources %+v", reply.Sources)
+	}
+	footer := deltas[len(deltas)-1]
+	if !strings.HasPrefix(footer, "\n\n출처:\n[1] 건강 기록 · 혈액검사 결과") || !strings.HasSuffix(reply.Content, footer) {
+		t.Errorf("source footer missing: deltas=%q content=%q", deltas, reply.Content)
+	}
+	if turns := repo.messages[reply.SessionID]; turns[1].Content != reply.Content {
+		t.Errorf("stored answer must include sources: %q", turns[1].Content)
+	}
+}
@@ -0,0 +1,265 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/manpasik/backend/shared/clients"
+)
+
+// 바이오마커 분류 결과
+const (
+	ClassNormal     = "normal"
+	ClassBorderline = "borderline"
+	ClassAbnormal   = "abnormal"
+	ClassUnknown    = "unknown" // 참조 범위 없음 또는 단위 불일치
+)
+
+// Bounds는 닫힌 구간 [Min, Max]입니다. 한쪽이 열려 있으면 ±Inf를 사용합니다.
+type Bounds struct {
+	Min float64
+	Max float64
+}
+
+func (b Bounds) contains(v float64) bool {
+	return v >= b.Min && v <= b.Max
+}
+
+// ReferenceRange는 바이오마커 하나의 구조화된 참조 범위입니다.
+// Normal ⊂ Borderline ⊂ Critical 순으로 포함 관계를 가지며,
+// Borderline 밖은 abnormal(RiskHigh), Critical 밖은 abnormal(RiskCritical)로 분류합니다.
+type ReferenceRange struct {
+	Biomarker  string
+	Unit       string
+	Normal     Bounds
+	Borderline Bounds
+	Critical   Bounds
+	Display    string
+}
+
+var negInf = math.Inf(-1)
+
+// defaultReferenceRanges는 성인 기준 참조 범위입니다 (공복 혈당, 일반 성인 기준).
+var defaultReferenceRanges = map[string]ReferenceRange{
+	"blood_glucose": {
+		Biomarker: "blood_glucose", Unit: "mg/dL",
+		Normal: Bounds{70, 99}, Borderline: Bounds{54, 125}, Critical: Bounds{40, 400},
+		Display: "70-99 mg/dL",
+	},
+	"cholesterol_total": {
+		Biomarker: "cholesterol_total", Unit: "mg/dL",
+		Normal: Bounds{negInf, 199}, Borderline: Bounds{negInf, 239}, Critical: Bounds{negInf, 400},
+		Display: "< 200 mg/dL",
+	},
+	"hemoglobin_a1c": {
+		Biomarker: "hemoglobin_a1c", Unit: "%",
+		Normal: Bounds{negInf, 5.6}, Borderline: Bounds{negInf, 6.4}, Critical: Bounds{negInf, 10},
+		Display: "< 5.7 %",
+	},
+	"hemoglobin": {
+		Biomarker: "hemoglobin", Unit: "g/dL",
+		Normal: Bounds{12, 17.5}, Borderline: Bounds{10, 18.5}, Critical: Bounds{7, 20},
+		Display: "12-17.5 g/dL",
+	},
+	"creatinine": {
+		Biomarker: "creatinine", Unit: "mg/dL",
+		Normal: Bounds{0.6, 1.3}, Borderline: Bounds{0.5, 1.5}, Critical: Bounds{negInf, 4},
+		Display: "0.6-1.3 mg/dL",
+	},
+	"uric_acid": {
+		Biomarker: "uric_acid", Unit: "mg/dL",
+		Normal: Bounds{3.5, 7.2}, Borderline: Bounds{2.5, 8}, Critical: Bounds{negInf, 12},
+		Display: "3.5-7.2 mg/dL",
+	},
+	"heart_rate": {
+		Biomarker: "heart_rate", Unit: "bpm",
+		Normal: Bounds{60, 100}, Borderline: Bounds{50, 110}, Critical: Bounds{40, 130},
+		Display: "60-100 bpm",
+	},
+	"body_temperature": {
+		Biomarker: "body_temperature", Unit: "°C",
+		Normal: Bounds{36.1, 37.2}, Borderline: Bounds{35.5, 38}, Critical: Bounds{35, 40},
+		Display: "36.1-37.2 °C",
+	},
+	"oxygen_saturation": {
+		Biomarker: "oxygen_saturation", Unit: "%",
+		Normal: Bounds{95, 100}, Borderline: Bounds{92, 100}, Critical: Bounds{88, 100},
+		Display: "95-100 %",
+	},
+}
+
+// biomarkerAliases는 카트리지 유형 이름을 참조 범위 키로 정규화합니다.
+var biomarkerAliases = map[string]string{
+	"glucose":     "blood_glucose",
+	"cholesterol": "cholesterol_total",
+	"hba1c":       "hemoglobin_a1c",
+	"spo2":        "oxygen_saturation",
+	"temperature": "body_temperature",
+}
+
+// canonicalBiomarker는 바이오마커 이름을 소문자 표준 이름으로 정규화합니다.
+func canonicalBiomarker(name string) string {
+	key := strings.ToLower(strings.TrimSpace(name))
+	if alias, ok := biomarkerAliases[key]; ok {
+		return alias
+	}
+	return key
+}
+
+// referenceTable은 참조 범위 키 → 참조 범위 표입니다.
+// 기본 표는 defaultReferenceRanges이며, 레지스트리의 reference_range 모델은 아티팩트에서 읽은 표를 사용합니다.
+type referenceTable map[string]ReferenceRange
+
+func (t referenceTable) lookup(biomarker string) (ReferenceRange, bool) {
+	rr, ok := t[canonicalBiomarker(biomarker)]
+	return rr, ok
+}
+
+// LookupReferenceRange는 바이오마커의 참조 범위를 반환합니다.
+func LookupReferenceRange(biomarker string) (ReferenceRange, bool) {
+	return referenceTable(defaultReferenceRanges).lookup(biomarker)
+}
+
+// qcConfidencePenalty는 QC 플래그별 신뢰도 배수입니다.
+// 목록에 없는 플래그는 unknownQCPenalty를 적용합니다.
+var qcConfidencePenalty = map[string]float64{
+	"temperature_out_of_range": 0.8,
+	"humidity_high":            0.85,
+	"battery_low":              0.95,
+	"low_confidence":           0.9,
+	"reference_invalid":        0.6,
+}
+
+const unknownQCPenalty = 0.9
+
+// adjustedConfidence는 측정 자체 신뢰도에 QC 감점을 곱한 값을 반환합니다.
+func adjustedConfidence(confidence float64, qcFlags []string) float64 {
+	c := math.Max(0, math.Min(1, confidence))
+	for _, f := range qcFlags {
+		if p, ok := qcConfidencePenalty[f]; ok {
+			c *= p
+		} else {
+			c *= unknownQCPenalty
+		}
+	}
+	return math.Round(c*1000) / 1000
+}
+
+// ClassifyReading은 측정값 하나를 기본 참조 범위로 분류합니다.
+// 같은 입력에는 항상 같은 결과를 반환합니다.
+func ClassifyReading(r clients.MeasurementSummary) BiomarkerResult {
+	return referenceTable(defaultReferenceRanges).classify(r)
+}
+
+func (t referenceTable) classify(r clients.MeasurementSummary) BiomarkerResult {
+	result := BiomarkerResult{
+		BiomarkerName:  r.BiomarkerID,
+		Value:          r.Value,
+		Unit:           r.Unit,
+		Classification: ClassUnknown,
+		Confidence:     adjustedConfidence(r.Confidence, r.QCFlags),
+		QCFlags:        append([]string(nil), r.QCFlags...),
+	}
+
+	rr, ok := t.lookup(r.BiomarkerID)
+	if !ok {
+		return result
+	}
+	result.BiomarkerName = rr.Biomarker
+	result.ReferenceRange = rr.Display
+	if r.Unit != "" && !strings.EqualFold(r.Unit, rr.Unit) {
+		return result
+	}
+	if result.Unit == "" {
+		result.Unit = rr.Unit
+	}
+
+	switch {
+	case rr.Normal.contains(r.Value):
+		result.Classification, result.RiskLevel = ClassNormal, RiskLow
+	case rr.Borderline.contains(r.Value):
+		result.Classification, result.RiskLevel = ClassBorderline, RiskModerate
+	case rr.Critical.contains(r.Value):
+		result.Classification, result.RiskLevel = ClassAbnormal, RiskHigh
+	default:
+		result.Classification, result.RiskLevel = ClassAbnormal, RiskCritical
+	}
+	return result
+}
+
+// detectAnomalies는 abnormal로 분류된 바이오마커를 이상치로 표시합니다.
+// 이상 점수는 정상 경계에서 벗어난 거리를 정상 범위 폭(한쪽이 열려 있으면 경계값)으로 나눈 값입니다.
+func (t referenceTable) detectAnomalies(biomarkers []BiomarkerResult) []AnomalyFlag {
+	var anomalies []AnomalyFlag
+	for _, b := range biomarkers {
+		if b.Classification != ClassAbnormal {
+			continue
+		}
+		rr, ok := t.lookup(b.BiomarkerName)
+		if !ok {
+			continue
+		}
+
+		var dist, bound float64
+		direction := "높습니다"
+		if b.Value > rr.Normal.Max {
+			dist, bound = b.Value-rr.Normal.Max, rr.Normal.Max
+		} else {
+			dist, bound = rr.Normal.Min-b.Value, rr.Normal.Min
+			direction = "낮습니다"
+		}
+		scale := math.Abs(bound)
+		if !math.IsInf(rr.Normal.Min, 0) && !math.IsInf(rr.Normal.Max, 0) {
+			scale = rr.Normal.Max - rr.Normal.Min
+		}
+		score := 1.0
+		if scale > 0 {
+			score = math.Min(1, dist/scale)
+		}
+
+		anomalies = append(anomalies, AnomalyFlag{
+			MetricName:   b.BiomarkerName,
+			Value:        b.Value,
+			ExpectedMin:  finiteOr(rr.Normal.Min, 0),
+			ExpectedMax:  finiteOr(rr.Normal.Max, 0),
+			AnomalyScore: math.Round(score*1000) / 1000,
+			Description:  fmt.Sprintf("%s 수치가 참조 범위(%s)보다 %s.", b.BiomarkerName, rr.Display, direction),
+		})
+	}
+	sort.SliceStable(anomalies, func(i, j int) bool { return anomalies[i].AnomalyScore > anomalies[j].AnomalyScore })
+	return anomalies
+}
+
+// riskPenalty는 종합 점수 산출 시 위험도별 감점입니다.
+var riskPenalty = map[RiskLevel]float64{
+	RiskModerate: 10,
+	RiskHigh:     25,
+	RiskCritical: 40,
+}
+
+// scoreFromBiomarkers는 분류된 바이오마커로 0~100 종합 점수를 산출합니다.
+// 감점은 결과 신뢰도로 가중하며, 분류할 수 있는 값이 없으면 0을 반환합니다.
+func scoreFromBiomarkers(biomarkers []BiomarkerResult) float64 {
+	classified := 0
+	penalty := 0.0
+	for _, b := range biomarkers {
+		if b.Classification == ClassUnknown {
+			continue
+		}
+		classified++
+		penalty += riskPenalty[b.RiskLevel] * b.Confidence
+	}
+	if classified == 0 {
+		return 0
+	}
+	return math.Round(math.Max(0, 100-penalty)*10) / 10
+}
+
+func finiteOr(v, fallback float64) float64 {
+	if math.IsInf(v, 0) {
+		return fallback
+	}
+	return v
+}
@@ -0,0 +1,519 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	apperrors "github.com/manpasik/backend/shared/errors"
+)
+
+// ModelStatusShadow는 운영 모델과 함께 실행되어 비교만 기록되는 후보 모델 상태입니다.
+const ModelStatusShadow ModelStatus = "shadow"
+
+// builtinArtifactPrefix는 코드에 내장된 모델의 아티팩트 URI 접두사입니다 (오브젝트 스토리지 미사용).
+const builtinArtifactPrefix = "builtin://"
+
+// loadRetryInterval은 로드에 실패한 버전을 다시 시도하기까지의 간격입니다.
+// 깨진 후보가 요청마다 아티팩트를 다시 내려받지 않도록 실패를 이 기간 동안 캐시합니다.
+const loadRetryInterval = 5 * time.Minute
+
+// ModelVersion은 레지스트리에 등록된 모델 버전 메타데이터입니다.
+type ModelVersion struct {
+	ModelType         AiModelType
+	Name              string
+	Version           string
+	Description       string
+	Kind              string // ModelLoader 키 (빈 값이면 코드 내장 알고리즘으로 로드 대상 아님)
+	ArtifactURI       string // 오브젝트 스토리지 키 또는 builtin://...
+	ArtifactSHA256    string
+	TrainingDataStart time.Time
+	TrainingDataEnd   time.Time
+	TrainedAt         time.Time
+	Metrics           map[string]float64 // 오프라인 평가 지표 (accuracy, macro_f1 등)
+	Status            ModelStatus
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+}
+
+// ShadowResult는 운영 모델과 후보 모델을 같은 입력으로 실행한 비교 결과 한 건입니다.
+type ShadowResult struct {
+	ModelType         AiModelType
+	ProductionVersion string
+	CandidateVersion  string
+	MeasurementID     string
+	Compared          int     // 비교한 바이오마커 수
+	Agreed            int     // 분류가 일치한 바이오마커 수
+	ScoreDelta        float64 // 후보 점수 - 운영 점수
+	RecordedAt        time.Time
+}
+
+// ShadowStats는 후보 모델 버전의 누적 섀도 비교 지표입니다.
+type ShadowStats struct {
+	ModelType        AiModelType
+	CandidateVersion string
+	Runs             int
+	Compared         int
+	Agreed           int
+	SumAbsScoreDelta float64
+	LastRecordedAt   time.Time
+}
+
+// Agreement는 바이오마커 분류 일치율입니다 (비교 건이 없으면 0).
+func (s *ShadowStats) Agreement() float64 {
+	if s == nil || s.Compared == 0 {
+		return 0
+	}
+	return float64(s.Agreed) / float64(s.Compared)
+}
+
+// MeanAbsScoreDelta는 실행당 평균 점수 차 절댓값입니다.
+func (s *ShadowStats) MeanAbsScoreDelta() float64 {
+	if s == nil || s.Runs == 0 {
+		return 0
+	}
+	return s.SumAbsScoreDelta / float64(s.Runs)
+}
+
+// ModelRegistryRepository는 모델 버전 메타데이터와 섀도 통계 저장소입니다.
+type ModelRegistryRepository interface {
+	SaveVersion(ctx context.Context, v *ModelVersion) error
+	ListVersions(ctx context.Context) ([]*ModelVersion, error)
+	RecordShadowResult(ctx context.Context, r *ShadowResult) error
+	ListShadowStats(ctx context.Context) ([]*ShadowStats, error)
+}
+
+// ArtifactStore는 모델 아티팩트를 보관하는 오브젝트 스토리지입니다.
+type ArtifactStore interface {
+	Put(ctx context.Context, key string, data []byte) error
+	Get(ctx context.Context, key string) ([]byte, error)
+}
+
+// ModelRegistry는 모델 버전 메타데이터·아티팩트·로드된 모델을 관리합니다.
+// 메타데이터와 섀도 통계는 메모리에 유지하며 repo가 있으면 함께 저장합니다 (repo가 nil이면 프로세스 메모리에만 유지).
+// store가 nil이면 builtin:// 아티팩트 모델만 사용할 수 있습니다.
+type ModelRegistry struct {
+	repo    ModelRegistryRepository
+	store   ArtifactStore
+	now     func() time.Time
+	mu      sync.RWMutex
+	loaders map[string]ModelLoader
+	byKey   map[string]*ModelVersion // modelKey → 버전
+	loaded  map[string]Model
+	failed  map[string]loadFailure
+	shadow  map[string]*ShadowStats
+}
+
+// loadFailure는 캐시된 모델 로드 실패입니다.
+type loadFailure struct {
+	err error
+	at  time.Time
+}
+
+// NewModelRegistry는 reference_range 로더가 등록된 레지스트리를 생성합니다.
+func NewModelRegistry(repo ModelRegistryRepository, store ArtifactStore) *ModelRegistry {
+	r := &ModelRegistry{
+		repo:    repo,
+		store:   store,
+		now:     time.Now,
+		loaders: make(map[string]ModelLoader),
+		byKey:   make(map[string]*ModelVersion),
+		loaded:  make(map[string]Model),
+		failed:  make(map[string]loadFailure),
+		shadow:  make(map[string]*ShadowStats),
+	}
+	r.RegisterLoader(KindReferenceRange, loadReferenceRangeModel)
+	return r
+}
+
+// WithModelRegistry는 모델 레지스트리를 주입합니다. 주입하지 않으면 내장 모델만 가진 레지스트리를 사용합니다.
+func WithModelRegistry(r *ModelRegistry) InferenceOption {
+	return func(s *InferenceService) {
+		s.registry = r
+	}
+}
+
+// RegisterLoader는 아티팩트 종류별 모델 로더를 등록합니다.
+func (r *ModelRegistry) RegisterLoader(kind string, loader ModelLoader) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.loaders[kind] = loader
+}
+
+func modelKey(t AiModelType, version string) string {
+	return fmt.Sprintf("%d|%s", t, version)
+}
+
+// Bootstrap은 저장소의 버전과 섀도 통계를 불러오고, 버전이 하나도 없는 모델 유형에는 내장 버전을 등록합니다.
+func (r *ModelRegistry) Bootstrap(ctx context.Context) error {
+	if r.repo != nil {
+		versions, err := r.repo.ListVersions(ctx)
+		if err != nil {
+			return fmt.Errorf("load model versions: %w", err)
+		}
+		stats, err := r.repo.ListShadowStats(ctx)
+		if err != nil {
+			return fmt.Errorf("load shadow stats: %w", err)
+		}
+		r.mu.Lock()
+		for _, v := range versions {
+			r.byKey[modelKey(v.ModelType, v.Version)] = v
+		}
+		for _, st := range stats {
+			r.shadow[modelKey(st.ModelType, st.CandidateVersion)] = st
+		}
+		r.mu.Unlock()
+	}
+
+	present := make(map[AiModelType]bool)
+	r.mu.RLock()
+	for _, v := range r.byKey {
+		present[v.ModelType] = true
+	}
+	r.mu.RUnlock()
+
+	for _, v := range builtinModelVersions() {
+		if present[v.ModelType] {
+			continue
+		}
+		v.CreatedAt = r.now()
+		v.UpdatedAt = v.CreatedAt
+		if err := r.save(ctx, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// builtinModelVersions는 코드에 내장된 알고리즘의 초기 버전입니다.
+// 오프라인 평가를 거치지 않았으므로 Metrics는 비어 있습니다.
+func builtinModelVersions() []*ModelVersion {
+	return []*ModelVersion{
+		{
+			ModelType: ModelBiomarkerClassifier, Name: "BiomarkerClassifier", Version: "1.0.0",
+			Description: "참조 범위 기반 바이오마커 분류 — 이상치·종합 점수 포함",
+			Kind:        KindReferenceRange, ArtifactURI: builtinArtifactPrefix + KindReferenceRange,
+			Status: ModelStatusActive,
+		},
+		{
+			ModelType: ModelAnomalyDetector, Name: "AnomalyDetector", Version: "1.0.0",
+			Description: "개인 기준선(중앙값·MAD) 급변 및 EWMA 지속 변화 탐지",
+			ArtifactURI: builtinArtifactPrefix + "baseline_mad_ewma", Status: ModelStatusActive,
+		},
+		{
+			ModelType: ModelTrendPredictor, Name: "TrendPredictor", Version: "1.0.0",
+			Description: "백테스트로 선택하는 선형·감쇠 추세·Holt-Winters 예측",
+			ArtifactURI: builtinArtifactPrefix + "forecast_backtest", Status: ModelStatusActive,
+		},
+		{
+			ModelType: ModelHealthScorer, Name: "HealthScorer", Version: "1.0.0",
+			Description: "측정·목표·식사 기록 기반 설명 가능한 건강 점수 (" + HealthScoreModelVersion + ")",
+			ArtifactURI: builtinArtifactPrefix + HealthScoreModelVersion, Status: ModelStatusActive,
+		},
+		{
+			ModelType: ModelFoodCalorieEstimator, Name: "FoodCalorieEstimator", Version: "0.9.0-beta",
+			Description: "음식 칼로리 추정 모델 (vision-service, 학습 중)",
+			ArtifactURI: builtinArtifactPrefix + "food_calorie", Status: ModelStatusTraining,
+		},
+		{
+			ModelType: ModelSubstanceIdentifier, Name: "SubstanceIdentifier", Version: "1.0.0",
+			Description: "승인된 핑거프린트 참조 라이브러리 kNN 물질 식별 — 거리 임계값 초과 시 미확인",
+			ArtifactURI: builtinArtifactPrefix + "fingerprint_knn", Status: ModelStatusActive,
+		},
+	}
+}
+
+// Register는 새 모델 버전의 아티팩트를 오브젝트 스토리지에 올리고 메타데이터를 등록합니다.
+// 상태를 지정하지 않으면 섀도 후보로 등록되며, 운영 전환은 Promote로 합니다.
+func (r *ModelRegistry) Register(ctx context.Context, v *ModelVersion, artifact []byte) error {
+	if v == nil || v.Name == "" || v.Version == "" {
+		return apperrors.New(apperrors.ErrInvalidInput, "모델 이름과 버전은 필수입니다")
+	}
+	switch v.Status {
+	case "":
+		v.Status = ModelStatusShadow
+	case ModelStatusShadow, ModelStatusTraining:
+	case ModelStatusActive:
+		return apperrors.New(apperrors.ErrInvalidInput, "운영 전환은 Promote로만 할 수 있습니다")
+	default:
+		return apperrors.New(apperrors.ErrInvalidInput, "등록할 수 없는 모델 상태입니다: "+string(v.Status))
+	}
+
+	r.mu.RLock()
+	_, exists := r.byKey[modelKey(v.ModelType, v.Version)]
+	loader, hasLoader := r.loaders[v.Kind]
+	r.mu.RUnlock()
+	if exists {
+		return apperrors.New(apperrors.ErrAlreadyExists, "이미 등록된 모델 버전입니다")
+	}
+	if v.Kind != "" && !hasLoader {
+		return apperrors.New(apperrors.ErrInvalidInput, "지원하지 않는 모델 종류입니다: "+v.Kind)
+	}
+	if len(artifact) > 0 {
+		if r.store == nil {
+			return apperrors.New(apperrors.ErrServiceUnavailable, "모델 아티팩트 저장소가 설정되지 않았습니다")
+		}
+		var model Model
+		if hasLoader {
+			m, err := loader(artifact)
+			if err != nil {
+				return apperrors.New(apperrors.ErrInvalidInput, "모델 아티팩트를 불러올 수 없습니다: "+err.Error())
+			}
+			model = m
+		}
+		sum := sha256.Sum256(artifact)
+		key := fmt.Sprintf("ai-models/%s/%s/artifact", strings.ToLower(v.Name), v.Version)
+		if err := r.store.Put(ctx, key, artifact); err != nil {
+			return apperrors.New(apperrors.ErrInternal, "모델 아티팩트 업로드에 실패했습니다")
+		}
+		v.ArtifactURI = key
+		v.ArtifactSHA256 = hex.EncodeToString(sum[:])
+		if model != nil {
+			r.mu.Lock()
+			r.loaded[modelKey(v.ModelType, v.Version)] = model
+			r.mu.Unlock()
+		}
+	}
+
+	if len(artifact) == 0 && v.ArtifactURI == "" && v.Kind != "" {
+		// 아티팩트 없이 등록하면 해당 종류의 내장 기본값으로 로드
+		v.ArtifactURI = builtinArtifactPrefix + v.Kind
+	}
+
+	v.CreatedAt = r.now()
+	v.UpdatedAt = v.CreatedAt
+	return r.save(ctx, v)
+}
+
+// Promote는 지정한 버전을 운영(active)으로 전환하고 기존 운영 버전을 deprecated로 내립니다.
+func (r *ModelRegistry) Promote(ctx context.Context, modelType AiModelType, version string) error {
+	r.mu.RLock()
+	target, ok := r.byKey[modelKey(modelType, version)]
+	var previous []*ModelVersion
+	for _, v := range r.byKey {
+		if v.ModelType == modelType && v.Status == ModelStatusActive && v.Version != version {
+			previous = append(previous, v)
+		}
+	}
+	r.mu.RUnlock()
+	if !ok {
+		return apperrors.New(apperrors.ErrNotFound, "모델 버전을 찾을 수 없습니다")
+	}
+	if target.Kind != "" {
+		if _, err := r.load(ctx, target); err != nil {
+			return err
+		}
+	}
+
+	now := r.now()
+	for _, p := range previous {
+		updated := *p
+		updated.Status = ModelStatusDeprecated
+		updated.UpdatedAt = now
+		if err := r.save(ctx, &updated); err != nil {
+			return err
+		}
+	}
+	updated := *target
+	updated.Status = ModelStatusActive
+	updated.UpdatedAt = now
+	return r.save(ctx, &updated)
+}
+
+func (r *ModelRegistry) save(ctx context.Context, v *ModelVersion) error {
+	if r.repo != nil {
+		if err := r.repo.SaveVersion(ctx, v); err != nil {
+			return apperrors.New(apperrors.ErrInternal, "모델 버전 저장에 실패했습니다")
+		}
+	}
+	r.mu.Lock()
+	r.byKey[modelKey(v.ModelType, v.Version)] = v
+	r.mu.Unlock()
+	return nil
+}
+
+// Versions는 모델 유형별(유형 순), 같은 유형 안에서는 active → shadow → training → deprecated 순으로 버전을 반환합니다.
+func (r *ModelRegistry) Versions() []*ModelVersion {
+	r.mu.RLock()
+	result := make([]*ModelVersion, 0, len(r.byKey))
+	for _, v := range r.byKey {
+		result = append(result, v)
+	}
+	r.mu.RUnlock()
+
+	rank := map[ModelStatus]int{ModelStatusActive: 0, ModelStatusShadow: 1, ModelStatusTraining: 2, ModelStatusDeprecated: 3}
+	sort.Slice(result, func(i, j int) bool {
+		a, b := result[i], result[j]
+		if a.ModelType != b.ModelType {
+			return a.ModelType < b.ModelType
+		}
+		if rank[a.Status] != rank[b.Status] {
+			return rank[a.Status] < rank[b.Status]
+		}
+		return a.Version > b.Version
+	})
+	return result
+}
+
+// Version은 모델 유형·버전의 메타데이터를 반환합니다.
+func (r *ModelRegistry) Version(modelType AiModelType, version string) (*ModelVersion, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	v, ok := r.byKey[modelKey(modelType, version)]
+	return v, ok
+}
+
+// Current는 모델 유형의 운영 버전을, 없으면 가장 앞선 비운영 버전을 반환합니다.
+func (r *ModelRegistry) Current(modelType AiModelType) (*ModelVersion, bool) {
+	for _, v := range r.Versions() {
+		if v.ModelType == modelType && v.Status != ModelStatusDeprecated {
+			return v, true
+		}
+	}
+	return nil, false
+}
+
+// Production은 모델 유형의 운영 버전과 로드된 모델을 반환합니다.
+func (r *ModelRegistry) Production(ctx context.Context, modelType AiModelType) (*ModelVersion, Model, error) {
+	v, ok := r.Current(modelType)
+	if !ok || v.Status != ModelStatusActive || v.Kind == "" {
+		return nil, nil, apperrors.New(apperrors.ErrServiceUnavailable, "운영 중인 모델이 없습니다")
+	}
+	m, err := r.load(ctx, v)
+	if err != nil {
+		return nil, nil, err
+	}
+	return v, m, nil
+}
+
+// Shadows는 모델 유형의 섀도 후보 버전 중 로드 가능한 모델을 반환합니다.
+// 로드에 실패한 후보는 운영 경로에 영향을 주지 않도록 건너뜁니다.
+func (r *ModelRegistry) Shadows(ctx context.Context, modelType AiModelType) ([]*ModelVersion, []Model) {
+	var versions []*ModelVersion
+	var models []Model
+	for _, v := range r.Versions() {
+		if v.ModelType != modelType || v.Status != ModelStatusShadow || v.Kind == "" {
+			continue
+		}
+		m, err := r.load(ctx, v)
+		if err != nil {
+			continue
+		}
+		versions = append(versions, v)
+		models = append(models, m)
+	}
+	return versions, models
+}
+
+// load는 아티팩트를 내려받아 체크섬을 확인한 뒤 모델을 생성하고 캐시합니다.
+// 실패도 loadRetryInterval 동안 캐시해 그사이에는 다시 내려받지 않습니다.
+func (r *ModelRegistry) load(ctx context.Context, v *ModelVersion) (Model, error) {
+	key := modelKey(v.ModelType, v.Version)
+	r.mu.RLock()
+	m, ok := r.loaded[key]
+	failure, failed := r.failed[key]
+	loader, hasLoader := r.loaders[v.Kind]
+	r.mu.RUnlock()
+	if ok {
+		return m, nil
+	}
+	if failed && r.now().Sub(failure.at) < loadRetryInterval {
+		return nil, failure.err
+	}
+	if !hasLoader {
+		return nil, apperrors.New(apperrors.ErrServiceUnavailable, "지원하지 않는 모델 종류입니다: "+v.Kind)
+	}
+
+	m, err := r.fetch(ctx, v, loader)
+	r.mu.Lock()
+	if err != nil {
+		r.failed[key] = loadFailure{err: err, at: r.now()}
+	} else {
+		r.loaded[key] = m
+		delete(r.failed, key)
+	}
+	r.mu.Unlock()
+	return m, err
+}
+
+// fetch는 아티팩트를 내려받아(builtin://이면 생략) 체크섬을 확인하고 모델을 생성합니다.
+func (r *ModelRegistry) fetch(ctx context.Context, v *ModelVersion, loader ModelLoader) (Model, error) {
+	var artifact []byte
+	if !strings.HasPrefix(v.ArtifactURI, builtinArtifactPrefix) {
+		if r.store == nil {
+			return nil, apperrors.New(apperrors.ErrServiceUnavailable, "모델 아티팩트 저장소가 설정되지 않았습니다")
+		}
+		data, err := r.store.Get(ctx, v.ArtifactURI)
+		if err != nil {
+			return nil, apperrors.New(apperrors.ErrServiceUnavailable, "모델 아티팩트를 내려받을 수 없습니다")
+		}
+		sum := sha256.Sum256(data)
+		if v.ArtifactSHA256 != "" && hex.EncodeToString(sum[:]) != v.ArtifactSHA256 {
+			return nil, apperrors.New(apperrors.ErrInternal, "모델 아티팩트 체크섬이 일치하지 않습니다")
+		}
+		artifact = data
+	}
+	m, err := loader(artifact)
+	if err != nil {
+		return nil, apperrors.New(apperrors.ErrInternal, "모델을 불러올 수 없습니다: "+err.Error())
+	}
+	return m, nil
+}
+
+// RecordShadow는 섀도 비교 결과를 누적하고 저장소에 기록합니다.
+func (r *ModelRegistry) RecordShadow(ctx context.Context, res *ShadowResult) error {
+	r.mu.Lock()
+	key := modelKey(res.ModelType, res.CandidateVersion)
+	st, ok := r.shadow[key]
+	if !ok {
+		st = &ShadowStats{ModelType: res.ModelType, CandidateVersion: res.CandidateVersion}
+		r.shadow[key] = st
+	}
+	st.Runs++
+	st.Compared += res.Compared
+	st.Agreed += res.Agreed
+	if res.ScoreDelta < 0 {
+		st.SumAbsScoreDelta -= res.ScoreDelta
+	} else {
+		st.SumAbsScoreDelta += res.ScoreDelta
+	}
+	st.LastRecordedAt = res.RecordedAt
+	r.mu.Unlock()
+
+	if r.repo != nil {
+		return r.repo.RecordShadowResult(ctx, res)
+	}
+	return nil
+}
+
+// ShadowStats는 후보 버전의 누적 섀도 지표를 반환합니다 (기록이 없으면 nil).
+func (r *ModelRegistry) ShadowStats(modelType AiModelType, version string) *ShadowStats {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	st, ok := r.shadow[modelKey(modelType, version)]
+	if !ok {
+		return nil
+	}
+	cp := *st
+	return &cp
+}
+
+// compareShadow는 같은 입력에 대한 운영·후보 출력을 비교합니다.
+func compareShadow(production, candidate *ModelOutput) (compared, agreed int, scoreDelta float64) {
+	n := len(production.Biomarkers)
+	if len(candidate.Biomarkers) < n {
+		n = len(candidate.Biomarkers)
+	}
+	for i := 0; i < n; i++ {
+		compared++
+		if production.Biomarkers[i].Classification == candidate.Biomarkers[i].Classification {
+			agreed++
+		}
+	}
+	return compared, agreed, candidate.HealthScore - production.HealthScore
+}
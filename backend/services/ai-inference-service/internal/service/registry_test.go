@@ -0,0 +1,310 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	apperrors "github.com/manpasik/backend/shared/errors"
+)
+
+// fakeArtifactStore는 아티팩트를 키별로 보관합니다. block이 있으면 닫힐 때까지 Get을 멈춥니다.
+type fakeArtifactStore struct {
+	objects map[string][]byte
+	gets    int
+	block   chan struct{}
+}
+
+func (s *fakeArtifactStore) Put(_ context.Context, key string, data []byte) error {
+	s.objects[key] = append([]byte(nil), data...)
+	return nil
+}
+
+func (s *fakeArtifactStore) Get(_ context.Context, key string) ([]byte, error) {
+	s.gets++
+	if s.block != nil {
+		<-s.block
+	}
+	data, ok := s.objects[key]
+	if !ok {
+		return nil, errors.New("no such key")
+	}
+	return data, nil
+}
+
+// fakeRegistryRepo는 버전 메타데이터와 섀도 결과를 보관합니다.
+type fakeRegistryRepo struct {
+	versions map[string]ModelVersion
+	results  []*ShadowResult
+}
+
+func (r *fakeRegistryRepo) SaveVersion(_ context.Context, v *ModelVersion) error {
+	r.versions[modelKey(v.ModelType, v.Version)] = *v
+	return nil
+}
+
+func (r *fakeRegistryRepo) ListVersions(_ context.Context) ([]*ModelVersion, error) {
+	var result []*ModelVersion
+	for _, v := range r.versions {
+		cp := v
+		result = append(result, &cp)
+	}
+	return result, nil
+}
+
+func (r *fakeRegistryRepo) RecordShadowResult(_ context.Context, res *ShadowResult) error {
+	r.results = append(r.results, res)
+	return nil
+}
+
+func (r *fakeRegistryRepo) ListShadowStats(_ context.Context) ([]*ShadowStats, error) {
+	return nil, nil
+}
+
+// tightGlucoseArtifact는 정상 혈당 상한을 90으로 낮춘 후보 참조 범위입니다.
+const tightGlucoseArtifact = `{"ranges":[{"biomarker":"glucose","unit":"mg/dL","display":"70-90 mg/dL",
+	"normal":{"min":70,"max":90},"borderline":{"min":54,"max":125},"critical":{"min":40,"max":400}}]}`
+
+type registryHarness struct {
+	svc      *InferenceService
+	registry *ModelRegistry
+	repo     *fakeRegistryRepo
+	store    *fakeArtifactStore
+}
+
+func newRegistryHarness(t *testing.T) *registryHarness {
+	t.Helper()
+	repo := &fakeRegistryRepo{versions: make(map[string]ModelVersion)}
+	store := &fakeArtifactStore{objects: make(map[string][]byte)}
+	registry := NewModelRegistry(repo, store)
+	registry.now = func() time.Time { return testNow }
+	if err := registry.Bootstrap(context.Background()); err != nil {
+		t.Fatalf("bootstrap: %v", err)
+	}
+	svc := NewInferenceService(newFakeAnalysisRepo(), newFakeHealthScoreRepo(),
+		WithMeasurementClient(newFakeMeasurementClient()),
+		WithModelRegistry(registry),
+		WithClock(func() time.Time { return testNow }))
+	return &registryHarness{svc: svc, registry: registry, repo: repo, store: store}
+}
+
+func (h *registryHarness) registerCandidate(t *testing.T) {
+	t.Helper()
+	err := h.registry.Register(context.Background(), &ModelVersion{
+		ModelType:         ModelBiomarkerClassifier,
+		Name:              "BiomarkerClassifier",
+		Version:           "1.1.0",
+		Kind:              KindReferenceRange,
+		TrainingDataStart: testNow.AddDate(0, -6, 0),
+		TrainingDataEnd:   testNow.AddDate(0, 0, -7),
+		TrainedAt:         testNow.AddDate(0, 0, -1),
+		Metrics:           map[string]float64{"accuracy": 0.95, "macro_f1": 0.91},
+	}, []byte(tightGlucoseArtifact))
+	if err != nil {
+		t.Fatalf("register: %v", err)
+	}
+}
+
+func TestRegistry_내장_모델_부트스트랩(t *testing.T) {
+	h := newRegistryHarness(t)
+	if len(h.repo.versions) != 6 {
+		t.Fatalf("내장 버전 6개가 저장되어야 함: %d", len(h.repo.versions))
+	}
+	info, err := h.svc.GetModelInfo(context.Background(), ModelBiomarkerClassifier)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Status != ModelStatusActive || info.Accuracy != 0 || info.ArtifactURI != "builtin://reference_range" {
+		t.Errorf("평가되지 않은 내장 모델은 정확도가 없어야 함: %+v", info)
+	}
+
+	// 같은 저장소로 다시 부트스트랩해도 중복 등록하지 않음
+	again := NewModelRegistry(h.repo, h.store)
+	if err := again.Bootstrap(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if len(again.Versions()) != 6 {
+		t.Errorf("재부트스트랩 후 버전 수: %d", len(again.Versions()))
+	}
+}
+
+func TestRegistry_섀도_후보_비교_기록(t *testing.T) {
+	h := newRegistryHarness(t)
+	h.registerCandidate(t)
+
+	v := h.repo.versions[modelKey(ModelBiomarkerClassifier, "1.1.0")]
+	if v.Status != ModelStatusShadow || v.ArtifactSHA256 == "" || len(h.store.objects[v.ArtifactURI]) == 0 {
+		t.Fatalf("후보는 아티팩트와 함께 섀도로 등록되어야 함: %+v", v)
+	}
+
+	result, err := h.svc.AnalyzeMeasurement(context.Background(), "user-1", "meas-1", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	h.svc.WaitShadowRuns()
+	// 운영 결과는 기존 참조 범위 그대로 (혈당 92 정상, 점수 74)
+	if result.Biomarkers[0].Classification != ClassNormal || result.OverallHealthScore != 74 {
+		t.Errorf("섀도 후보가 운영 결과를 바꾸면 안 됨: %+v", result)
+	}
+
+	if len(h.repo.results) != 1 {
+		t.Fatalf("섀도 비교 1건이 기록되어야 함: %d", len(h.repo.results))
+	}
+	res := h.repo.results[0]
+	// 후보에서는 혈당 92가 경계(-10×0.95) → 3개 중 2개 일치, 점수 차 -9.5
+	if res.ProductionVersion != "1.0.0" || res.Compared != 3 || res.Agreed != 2 || res.ScoreDelta != -9.5 {
+		t.Errorf("섀도 비교 결과 불일치: %+v", res)
+	}
+
+	models, _ := h.svc.ListModels(context.Background())
+	if len(models) != 7 {
+		t.Fatalf("운영 6개 + 섀도 1개: %d", len(models))
+	}
+	var shadow *ModelInfo
+	for _, m := range models {
+		if m.Status == ModelStatusShadow {
+			shadow = m
+		}
+	}
+	if shadow == nil || shadow.Shadow == nil || shadow.Shadow.Runs != 1 || shadow.Accuracy != 0.95 {
+		t.Fatalf("섀도 모델 정보 불일치: %+v", shadow)
+	}
+	if got := shadow.Shadow.Agreement(); got < 0.66 || got > 0.67 {
+		t.Errorf("일치율 2/3 기대: %.3f", got)
+	}
+	if !shadow.TrainingDataEnd.Equal(testNow.AddDate(0, 0, -7)) {
+		t.Errorf("학습 데이터 기간 누락: %+v", shadow)
+	}
+}
+
+func TestRegistry_운영_전환과_아티팩트_검증(t *testing.T) {
+	h := newRegistryHarness(t)
+	h.registerCandidate(t)
+	ctx := context.Background()
+
+	if err := h.registry.Promote(ctx, ModelBiomarkerClassifier, "1.1.0"); err != nil {
+		t.Fatal(err)
+	}
+	info, _ := h.svc.GetModelInfo(ctx, ModelBiomarkerClassifier)
+	if info.Version != "1.1.0" || info.Status != ModelStatusActive {
+		t.Errorf("1.1.0이 운영이어야 함: %+v", info)
+	}
+	if old := h.repo.versions[modelKey(ModelBiomarkerClassifier, "1.0.0")]; old.Status != ModelStatusDeprecated {
+		t.Errorf("이전 운영 버전은 deprecated: %s", old.Status)
+	}
+	result, err := h.svc.AnalyzeMeasurement(ctx, "user-1", "meas-1", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Biomarkers[0].Classification != ClassBorderline || result.OverallHealthScore != 64.5 {
+		t.Errorf("전환된 모델로 분류해야 함: %+v", result.Biomarkers[0])
+	}
+
+	// 저장소의 아티팩트가 변조되면 새 프로세스에서 로드하지 않음
+	key := h.repo.versions[modelKey(ModelBiomarkerClassifier, "1.1.0")].ArtifactURI
+	h.store.objects[key] = []byte(`{"ranges":[]}`)
+	restarted := NewModelRegistry(h.repo, h.store)
+	if err := restarted.Bootstrap(ctx); err != nil {
+		t.Fatal(err)
+	}
+	_, _, err = restarted.Production(ctx, ModelBiomarkerClassifier)
+	var appErr *apperrors.AppError
+	if !errors.As(err, &appErr) || appErr.Code != apperrors.ErrInternal {
+		t.Errorf("체크섬 불일치 오류 기대: %v", err)
+	}
+
+	// 포함 관계가 깨진 참조 범위는 등록 거부
+	err = h.registry.Register(ctx, &ModelVersion{
+		ModelType: ModelBiomarkerClassifier, Name: "BiomarkerClassifier", Version: "1.2.0", Kind: KindReferenceRange,
+	}, []byte(`{"ranges":[{"biomarker":"glucose","unit":"mg/dL","normal":{"min":70,"max":130},"borderline":{"min":54,"max":125},"critical":{}}]}`))
+	if !errors.As(err, &appErr) || appErr.Code != apperrors.ErrInvalidInput {
+		t.Errorf("잘못된 아티팩트 거부 기대: %v", err)
+	}
+}
+
+func TestRegistry_섀도_후보는_요청을_막지_않고_로드_실패를_캐시(t *testing.T) {
+	h := newRegistryHarness(t)
+	h.registerCandidate(t)
+	ctx := context.Background()
+
+	// 새 프로세스에서 손상된 후보 아티팩트를 내려받는 동안 멈춰 있음
+	key := h.repo.versions[modelKey(ModelBiomarkerClassifier, "1.1.0")].ArtifactURI
+	h.store.objects[key] = []byte(`{"ranges":[]}`)
+	h.store.block = make(chan struct{})
+	restarted := NewModelRegistry(h.repo, h.store)
+	restarted.now = func() time.Time { return testNow }
+	if err := restarted.Bootstrap(ctx); err != nil {
+		t.Fatal(err)
+	}
+	svc := NewInferenceService(newFakeAnalysisRepo(), newFakeHealthScoreRepo(),
+		WithMeasurementClient(newFakeMeasurementClient()),
+		WithModelRegistry(restarted),
+		WithClock(func() time.Time { return testNow }))
+
+	// 섀도 후보 다운로드가 끝나지 않아도 운영 응답은 반환됨
+	if _, err := svc.AnalyzeMeasurement(ctx, "user-1", "meas-1", nil); err != nil {
+		t.Fatal(err)
+	}
+	close(h.store.block)
+	svc.WaitShadowRuns()
+
+	// 로드 실패는 캐시되어 다음 요청에서 다시 내려받지 않음
+	if _, err := svc.AnalyzeMeasurement(ctx, "user-1", "meas-1", nil); err != nil {
+		t.Fatal(err)
+	}
+	svc.WaitShadowRuns()
+	if h.store.gets != 1 || len(h.repo.results) != 0 {
+		t.Fatalf("실패한 후보는 한 번만 내려받고 비교하지 않아야 함: gets=%d results=%d", h.store.gets, len(h.repo.results))
+	}
+
+	// 재시도 간격이 지나면 다시 시도
+	restarted.now = func() time.Time { return testNow.Add(loadRetryInterval) }
+	if _, err := svc.AnalyzeMeasurement(ctx, "user-1", "meas-1", nil); err != nil {
+		t.Fatal(err)
+	}
+	svc.WaitShadowRuns()
+	if h.store.gets != 2 {
+		t.Errorf("재시도 간격 후 다시 내려받아야 함: gets=%d", h.store.gets)
+	}
+}
+
+func TestInference_모델_버전_등록과_운영_전환(t *testing.T) {
+	h := newRegistryHarness(t)
+	ctx := context.Background()
+
+	info, err := h.svc.RegisterModelVersion(ctx, &ModelVersion{
+		ModelType: ModelBiomarkerClassifier, Name: "BiomarkerClassifier", Version: "1.1.0", Kind: KindReferenceRange,
+		Metrics: map[string]float64{"accuracy": 0.95},
+	}, []byte(tightGlucoseArtifact))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Status != ModelStatusShadow || info.Accuracy != 0.95 || len(h.store.objects[info.ArtifactURI]) == 0 {
+		t.Fatalf("섀도 후보로 등록되어야 함: %+v", info)
+	}
+
+	info, err = h.svc.PromoteModelVersion(ctx, ModelBiomarkerClassifier, "1.1.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Version != "1.1.0" || info.Status != ModelStatusActive {
+		t.Errorf("운영으로 전환되어야 함: %+v", info)
+	}
+
+	var appErr *apperrors.AppError
+	_, err = h.svc.RegisterModelVersion(ctx, &ModelVersion{Name: "X", Version: "1.0.0"}, nil)
+	if !errors.As(err, &appErr) || appErr.Code != apperrors.ErrInvalidInput {
+		t.Errorf("모델 유형 없는 등록 거부 기대: %v", err)
+	}
+	_, err = h.svc.RegisterModelVersion(ctx, &ModelVersion{
+		ModelType: ModelBiomarkerClassifier, Name: "BiomarkerClassifier", Version: "1.2.0", Status: ModelStatusDeprecated,
+	}, nil)
+	if !errors.As(err, &appErr) || appErr.Code != apperrors.ErrInvalidInput {
+		t.Errorf("등록할 수 없는 상태 거부 기대: %v", err)
+	}
+	_, err = h.svc.PromoteModelVersion(ctx, ModelBiomarkerClassifier, "9.9.9")
+	if !errors.As(err, &appErr) || appErr.Code != apperrors.ErrNotFound {
+		t.Errorf("없는 버전 전환은 NotFound: %v", err)
+	}
+}
@@ -0,0 +1,48 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/manpasik/backend/shared/clients"
+	apperrors "github.com/manpasik/backend/shared/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// WithSubstanceIdentifier는 핑거프린트 참조 라이브러리 물질 식별 클라이언트를 주입합니다.
+// 설정되지 않으면 ModelSubstanceIdentifier 요청은 ErrServiceUnavailable을 반환합니다.
+func WithSubstanceIdentifier(c clients.SubstanceIdentifierClient) InferenceOption {
+	return func(s *InferenceService) {
+		s.substances = c
+	}
+}
+
+// identifySubstance는 측정의 핑거프린트를 참조 라이브러리와 비교해 물질을 식별합니다.
+func (s *InferenceService) identifySubstance(ctx context.Context, measurementID string) (*clients.SubstanceIdentification, error) {
+	if s.substances == nil {
+		return nil, apperrors.New(apperrors.ErrServiceUnavailable, "물질 식별 서비스가 설정되지 않았습니다")
+	}
+	result, err := s.substances.IdentifySubstance(ctx, measurementID)
+	if err != nil {
+		// 핑거프린트가 없는 측정은 요청 오류로 돌려줌
+		if status.Code(err) == codes.InvalidArgument {
+			return nil, apperrors.New(apperrors.ErrInvalidInput, "핑거프린트가 없는 측정은 물질을 식별할 수 없습니다")
+		}
+		return nil, apperrors.New(apperrors.ErrServiceUnavailable, "물질 식별에 실패했습니다")
+	}
+	return result, nil
+}
+
+// substanceSummary는 물질 식별 결과를 요약 문장으로 만듭니다.
+func substanceSummary(r *clients.SubstanceIdentification) string {
+	if r.Unknown || len(r.Candidates) == 0 {
+		return "핑거프린트가 참조 라이브러리의 물질과 일치하지 않습니다 (미확인)."
+	}
+	top := r.Candidates[0]
+	if top.EstimatedConcentration > 0 {
+		return fmt.Sprintf("핑거프린트 식별 결과 %s (약 %.1f %s, 신뢰도 %.0f%%)로 추정됩니다.",
+			top.Substance, top.EstimatedConcentration, top.ConcentrationUnit, top.Confidence*100)
+	}
+	return fmt.Sprintf("핑거프린트 식별 결과 %s (신뢰도 %.0f%%)로 추정됩니다.", top.Substance, top.Confidence*100)
+}
@@ -0,0 +1,80 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/manpasik/backend/shared/clients"
+	apperrors "github.com/manpasik/backend/shared/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeSubstanceIdentifier는 측정 ID별 고정 식별 결과를 반환합니다.
+type fakeSubstanceIdentifier struct {
+	results map[string]*clients.SubstanceIdentification
+	err     error
+}
+
+func (f *fakeSubstanceIdentifier) IdentifySubstance(_ context.Context, measurementID string) (*clients.SubstanceIdentification, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return f.results[measurementID], nil
+}
+
+func TestAnalyzeMeasurement_SubstanceIdentifier(t *testing.T) {
+	identifier := &fakeSubstanceIdentifier{results: map[string]*clients.SubstanceIdentification{
+		"meas-1": {CartridgeType: "glucose", NearestDistance: 0.03, Threshold: 0.15, Candidates: []clients.SubstanceCandidate{
+			{Substance: "glucose", Distance: 0.03, Votes: 4, Confidence: 0.92, EstimatedConcentration: 104.5, ConcentrationUnit: "mg/dL"},
+			{Substance: "fructose", Distance: 0.2, Votes: 1, Confidence: 0.08},
+		}},
+		"meas-2": {CartridgeType: "voc", Unknown: true, NearestDistance: 0.4, Threshold: 0.15},
+	}}
+	svc := NewInferenceService(newFakeAnalysisRepo(), newFakeHealthScoreRepo(),
+		WithMeasurementClient(newFakeMeasurementClient()), WithSubstanceIdentifier(identifier),
+		WithClock(func() time.Time { return testNow }))
+
+	result, err := svc.AnalyzeMeasurement(context.Background(), "user-1", "meas-1", []AiModelType{ModelSubstanceIdentifier})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Substance == nil || result.Substance.Candidates[0].Substance != "glucose" {
+		t.Fatalf("expected glucose identification, got %+v", result.Substance)
+	}
+	if result.Biomarkers != nil || result.OverallHealthScore != 0 {
+		t.Errorf("only substance identification was requested, got %+v", result)
+	}
+	if !strings.Contains(result.Summary, "glucose (약 104.5 mg/dL, 신뢰도 92%)") {
+		t.Errorf("summary should mention the identified substance: %q", result.Summary)
+	}
+
+	unknown, _ := svc.AnalyzeMeasurement(context.Background(), "user-1", "meas-2", []AiModelType{ModelSubstanceIdentifier})
+	if !unknown.Substance.Unknown || !strings.Contains(unknown.Summary, "미확인") {
+		t.Errorf("expected unknown identification, got %+v", unknown)
+	}
+
+	// 요청하지 않으면 식별하지 않음
+	plain, _ := svc.AnalyzeMeasurement(context.Background(), "user-1", "meas-1", nil)
+	if plain.Substance != nil {
+		t.Errorf("substance identification should be opt-in, got %+v", plain.Substance)
+	}
+}
+
+func TestAnalyzeMeasurement_SubstanceIdentifierErrors(t *testing.T) {
+	var appErr *apperrors.AppError
+	svc := newTestService()
+	_, err := svc.AnalyzeMeasurement(context.Background(), "user-1", "meas-1", []AiModelType{ModelSubstanceIdentifier})
+	if !errors.As(err, &appErr) || appErr.Code != apperrors.ErrServiceUnavailable {
+		t.Errorf("expected SERVICE_UNAVAILABLE without identifier, got %v", err)
+	}
+
+	WithSubstanceIdentifier(&fakeSubstanceIdentifier{err: status.Error(codes.InvalidArgument, "no fingerprint")})(svc)
+	_, err = svc.AnalyzeMeasurement(context.Background(), "user-1", "meas-1", []AiModelType{ModelSubstanceIdentifier})
+	if !errors.As(err, &appErr) || appErr.Code != apperrors.ErrInvalidInput {
+		t.Errorf("expected INVALID_INPUT for a measurement without fingerprint, got %v", err)
+	}
+}
@@ -0,0 +1,65 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/manpasik/backend/services/ai-inference-service/internal/llm"
+	apperrors "github.com/manpasik/backend/shared/errors"
+)
+
+// maxUsageReportRange는 LLM 사용량 보고서 조회 구간의 최대 길이입니다.
+const maxUsageReportRange = 366 * 24 * time.Hour
+
+// LLMUsageReport는 관리자용 LLM 사용량·예상 비용 보고서입니다.
+type LLMUsageReport struct {
+	From           time.Time
+	To             time.Time
+	Rows           []llm.UsageSummary // 사용자·기능·프로바이더·모델별, 비용 내림차순
+	TotalCalls     int
+	TotalCacheHits int
+	TotalTokens    int
+	TotalCostUSD   float64
+}
+
+// WithLLMUsageLedger는 LLM 사용량 원장을 주입합니다.
+// 설정되지 않으면 LLMUsageReport는 ErrServiceUnavailable을 반환합니다.
+func WithLLMUsageLedger(l llm.UsageLedger) InferenceOption {
+	return func(s *InferenceService) {
+		s.usageLedger = l
+	}
+}
+
+// LLMUsageReport는 [from, to) 구간의 LLM 사용량과 예상 비용을 집계합니다.
+// from이 비어 있으면 이번 달(UTC) 1일, to가 비어 있으면 현재 시각이며, userID로 한 사용자만 조회할 수 있습니다.
+func (s *InferenceService) LLMUsageReport(ctx context.Context, from, to time.Time, userID string) (*LLMUsageReport, error) {
+	if s.usageLedger == nil {
+		return nil, apperrors.New(apperrors.ErrServiceUnavailable, "LLM 사용량 집계가 설정되지 않았습니다")
+	}
+	now := s.now().UTC()
+	if to.IsZero() {
+		to = now
+	}
+	if from.IsZero() {
+		from = time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	}
+	if !from.Before(to) {
+		return nil, apperrors.New(apperrors.ErrInvalidInput, "조회 시작 시각은 종료 시각보다 앞서야 합니다")
+	}
+	if to.Sub(from) > maxUsageReportRange {
+		return nil, apperrors.New(apperrors.ErrInvalidInput, "조회 구간은 최대 1년입니다")
+	}
+
+	rows, err := s.usageLedger.SummarizeUsage(ctx, llm.UsageQuery{From: from, To: to, UserID: userID})
+	if err != nil {
+		return nil, apperrors.New(apperrors.ErrInternal, "LLM 사용량 조회에 실패했습니다")
+	}
+	report := &LLMUsageReport{From: from, To: to, Rows: rows}
+	for _, r := range rows {
+		report.TotalCalls += r.Calls
+		report.TotalCacheHits += r.CacheHits
+		report.TotalTokens += r.Tokens
+		report.TotalCostUSD += r.CostUSD
+	}
+	return report, nil
+}
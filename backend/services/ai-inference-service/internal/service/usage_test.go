@@ -0,0 +1,94 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/manpasik/backend/services/ai-inference-service/internal/llm"
+	apperrors "github.com/manpasik/backend/shared/errors"
+)
+
+// meteredLLM은 호출 횟수를 세고 받은 기능 이름을 기록하는 LLMClient입니다.
+type meteredLLM struct {
+	calls    int
+	features []string
+}
+
+func (m *meteredLLM) Chat(ctx context.Context, _ string, _ []llm.ChatMessage) (*llm.ChatResponse, error) {
+	m.calls++
+	m.features = append(m.features, llm.FeatureFromContext(ctx))
+	return &llm.ChatResponse{Content: "꾸준한 측정을 유지하세요.", FinishReason: "stop", TokensUsed: 30, Provider: "openai", Model: "gpt-4o-mini"}, nil
+}
+
+func TestGenerateHealthInsight_RepeatedInputServedFromCache(t *testing.T) {
+	client := &meteredLLM{}
+	ledger := llm.NewMemoryUsageLedger()
+	svc := NewInferenceService(newFakeAnalysisRepo(), newFakeHealthScoreRepo(),
+		WithLLMClient(llm.NewCachingClient(client, nil, llm.WithCacheLedger(ledger))))
+
+	measurements := []MeasurementData{{MetricName: "glucose", Value: 98, Unit: "mg/dL", Timestamp: testNow}}
+	for i := 0; i < 3; i++ {
+		if _, err := svc.GenerateHealthInsight(context.Background(), "user-1", measurements); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if client.calls != 1 {
+		t.Errorf("identical insight requests should hit the cache, got %d LLM calls", client.calls)
+	}
+	if client.features[0] != llm.FeatureHealthInsight {
+		t.Errorf("expected feature %q, got %q", llm.FeatureHealthInsight, client.features[0])
+	}
+}
+
+func TestLLMUsageReport_DefaultsToCurrentMonth(t *testing.T) {
+	ledger := llm.NewMemoryUsageLedger()
+	ctx := context.Background()
+	records := []llm.UsageRecord{
+		{UserID: "user-1", Feature: llm.FeatureHealthInsight, Provider: "openai", Model: "gpt-4o", Tokens: 1000, CostUSD: 0.005, CreatedAt: testNow.Add(-time.Hour)},
+		{UserID: "user-1", Feature: llm.FeatureHealthInsight, Provider: "openai", Model: "gpt-4o", CacheHit: true, CreatedAt: testNow.Add(-time.Minute)},
+		{UserID: "user-2", Feature: llm.FeatureChat, Provider: "anthropic", Model: "claude-3-5-sonnet", Tokens: 2000, CostUSD: 0.012, CreatedAt: testNow.AddDate(0, 0, -3)},
+		// 지난달 기록은 기본 구간에서 제외
+		{UserID: "user-1", Feature: llm.FeatureRecommendation, Provider: "openai", Model: "gpt-4o", Tokens: 500, CostUSD: 0.0025, CreatedAt: testNow.AddDate(0, -1, 0)},
+	}
+	for i := range records {
+		ledger.RecordUsage(ctx, &records[i])
+	}
+	svc := NewInferenceService(newFakeAnalysisRepo(), newFakeHealthScoreRepo(),
+		WithLLMUsageLedger(ledger), WithClock(func() time.Time { return testNow }))
+
+	report, err := svc.LLMUsageReport(ctx, time.Time{}, time.Time{}, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !report.From.Equal(time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)) || !report.To.Equal(testNow) {
+		t.Errorf("unexpected range %v ~ %v", report.From, report.To)
+	}
+	if len(report.Rows) != 2 || report.Rows[0].UserID != "user-2" {
+		t.Fatalf("expected 2 rows ordered by cost, got %+v", report.Rows)
+	}
+	if report.TotalCalls != 3 || report.TotalCacheHits != 1 || report.TotalTokens != 3000 {
+		t.Errorf("unexpected totals %+v", report)
+	}
+
+	byUser, _ := svc.LLMUsageReport(ctx, time.Time{}, time.Time{}, "user-1")
+	if len(byUser.Rows) != 1 || byUser.Rows[0].Calls != 2 {
+		t.Errorf("expected only user-1 rows, got %+v", byUser.Rows)
+	}
+}
+
+func TestLLMUsageReport_Validation(t *testing.T) {
+	svc := NewInferenceService(newFakeAnalysisRepo(), newFakeHealthScoreRepo())
+	var appErr *apperrors.AppError
+	_, err := svc.LLMUsageReport(context.Background(), time.Time{}, time.Time{}, "")
+	if !errors.As(err, &appErr) || appErr.Code != apperrors.ErrServiceUnavailable {
+		t.Errorf("expected ErrServiceUnavailable without ledger, got %v", err)
+	}
+
+	WithLLMUsageLedger(llm.NewMemoryUsageLedger())(svc)
+	_, err = svc.LLMUsageReport(context.Background(), testNow, testNow.Add(-time.Hour), "")
+	if !errors.As(err, &appErr) || appErr.Code != apperrors.ErrInvalidInput {
+		t.Errorf("expected ErrInvalidInput for reversed range, got %v", err)
+	}
+}
@@ -2,13 +2,21 @@ package main
 
 import (
 	"log"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
 
+	"github.com/manpasik/backend/services/analytics-service/internal/handler"
 	"github.com/manpasik/backend/services/analytics-service/internal/repository/memory"
 	"github.com/manpasik/backend/services/analytics-service/internal/service"
+	v1 "github.com/manpasik/backend/shared/gen/go/v1"
+	"github.com/manpasik/backend/shared/middleware"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
 )
 
 const serviceName = "analytics-service"
@@ -18,6 +26,15 @@ func main() {
 	if httpPort == "" {
 		httpPort = ":8080"
 	}
+	grpcPort := os.Getenv("GRPC_PORT")
+	if grpcPort == "" {
+		grpcPort = ":50074"
+	}
+	// 기여자 키 솔트가 비면 userID 해시가 사전 공격에 노출되므로 기동을 중단합니다.
+	envSalt := os.Getenv("ENV_AGG_SALT")
+	if envSalt == "" {
+		log.Fatalf("[%s] ENV_AGG_SALT must be set", serviceName)
+	}
 
 	log.Printf("[%s] Starting...", serviceName)
 
@@ -25,13 +42,39 @@ func main() {
 	svc := service.NewAnalyticsService(repo)
 	_ = svc
 
+	// 지역 환경 모니터링: 옵트인 익명 집계 (ENV_AGG_SALT로 기여자 키 솔트 지정)
+	envSvc := service.NewEnvironmentalService(memory.NewEnvironmentalRepository(), service.EnvironmentalConfig{
+		Salt: envSalt,
+	})
+	envHandler := handler.NewEnvironmentalHandler(envSvc)
+
 	mux := http.NewServeMux()
+	envHandler.RegisterPublicRoutes(mux)
 	mux.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusOK)
 		_, _ = w.Write([]byte(`{"status":"serving","service":"analytics-service"}`))
 	})
 
+	// 동의 설정·측정값 제출은 게이트웨이에서 gRPC로 호출합니다.
+	grpcServer := grpc.NewServer(grpc.ChainUnaryInterceptor(middleware.RequestIDInterceptor()))
+	healthServer := health.NewServer()
+	healthpb.RegisterHealthServer(grpcServer, healthServer)
+	healthServer.SetServingStatus(serviceName, healthpb.HealthCheckResponse_SERVING)
+	v1.RegisterEnvironmentServiceServer(grpcServer, envHandler)
+	reflection.Register(grpcServer)
+
+	lis, err := net.Listen("tcp", grpcPort)
+	if err != nil {
+		log.Fatalf("[%s] Failed to listen on %s: %v", serviceName, grpcPort, err)
+	}
+	go func() {
+		log.Printf("[%s] gRPC server on %s", serviceName, grpcPort)
+		if err := grpcServer.Serve(lis); err != nil {
+			log.Fatalf("[%s] gRPC server error: %v", serviceName, err)
+		}
+	}()
+
 	go func() {
 		log.Printf("[%s] HTTP server on %s", serviceName, httpPort)
 		if err := http.ListenAndServe(httpPort, mux); err != nil {
@@ -43,4 +86,6 @@ func main() {
 	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
 	sig := <-sigCh
 	log.Printf("[%s] Received signal %v, shutting down...", serviceName, sig)
+	healthServer.SetServingStatus(serviceName, healthpb.HealthCheckResponse_NOT_SERVING)
+	grpcServer.GracefulStop()
 }
@@ -0,0 +1,183 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/manpasik/backend/services/analytics-service/internal/service"
+	v1 "github.com/manpasik/backend/shared/gen/go/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// EnvironmentalHandler는 지역 환경 모니터링 핸들러입니다.
+// 측정값 제출·동의 설정은 gRPC(EnvironmentService, 게이트웨이 경유)로, 집계 조회는 인증 없는 공개 HTTP API로 제공합니다.
+type EnvironmentalHandler struct {
+	v1.UnimplementedEnvironmentServiceServer
+	svc *service.EnvironmentalService
+}
+
+// NewEnvironmentalHandler는 새 EnvironmentalHandler를 생성합니다.
+func NewEnvironmentalHandler(svc *service.EnvironmentalService) *EnvironmentalHandler {
+	return &EnvironmentalHandler{svc: svc}
+}
+
+// SetEnvironmentSharingConsent는 환경 데이터 공유 동의를 설정합니다.
+func (h *EnvironmentalHandler) SetEnvironmentSharingConsent(ctx context.Context, req *v1.SetEnvironmentSharingConsentRequest) (*v1.SetEnvironmentSharingConsentResponse, error) {
+	deleted, err := h.svc.SetSharingConsent(ctx, req.GetUserId(), req.GetOptIn())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return &v1.SetEnvironmentSharingConsentResponse{OptIn: req.GetOptIn(), DeletedReadings: int32(deleted)}, nil
+}
+
+// SubmitEnvironmentReading은 환경 측정값을 제출합니다.
+func (h *EnvironmentalHandler) SubmitEnvironmentReading(ctx context.Context, req *v1.SubmitEnvironmentReadingRequest) (*v1.SubmitEnvironmentReadingResponse, error) {
+	sub := service.EnvironmentalSubmission{
+		UserID:       req.GetUserId(),
+		Metric:       req.GetMetric(),
+		Value:        req.GetValue(),
+		Unit:         req.GetUnit(),
+		CountryCode:  req.GetCountryCode(),
+		RegionCode:   req.GetRegionCode(),
+		DistrictCode: req.GetDistrictCode(),
+	}
+	if req.GetHasLocation() {
+		lat, lon := req.GetLatitude(), req.GetLongitude()
+		sub.Latitude, sub.Longitude = &lat, &lon
+	}
+	if req.GetMeasuredAt() != nil {
+		sub.MeasuredAt = req.GetMeasuredAt().AsTime()
+	}
+
+	reading, err := h.svc.SubmitReading(ctx, sub)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return &v1.SubmitEnvironmentReadingResponse{
+		ReadingId:  reading.ID,
+		Metric:     reading.Metric,
+		Unit:       reading.Unit,
+		CellId:     reading.CellID,
+		MeasuredAt: timestamppb.New(reading.MeasuredAt),
+	}, nil
+}
+
+// RegisterPublicRoutes는 읽기 전용 공개 API를 등록합니다.
+//
+//	GET /api/v1/environment/regions?metric=radon&country=KR[&region=seoul&district=gangnam&window=24h]
+//	GET /api/v1/environment/grid?metric=radon[&window=24h&bbox=minLon,minLat,maxLon,maxLat]  (GeoJSON)
+func (h *EnvironmentalHandler) RegisterPublicRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("/api/v1/environment/regions", h.handleRegions)
+	mux.HandleFunc("/api/v1/environment/grid", h.handleGrid)
+}
+
+type regionAggregateJSON struct {
+	CountryCode  string  `json:"country_code"`
+	RegionCode   string  `json:"region_code"`
+	DistrictCode string  `json:"district_code,omitempty"`
+	Metric       string  `json:"metric"`
+	Unit         string  `json:"unit,omitempty"`
+	Contributors int     `json:"contributors"`
+	Samples      int     `json:"samples"`
+	Average      float64 `json:"average"`
+	WindowStart  string  `json:"window_start"`
+	WindowEnd    string  `json:"window_end"`
+}
+
+func (h *EnvironmentalHandler) handleRegions(w http.ResponseWriter, r *http.Request) {
+	q, ok := parseEnvQuery(w, r)
+	if !ok {
+		return
+	}
+	aggregates, err := h.svc.GetRegionalAggregates(r.Context(), q)
+	if err != nil {
+		writeEnvError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	items := make([]regionAggregateJSON, 0, len(aggregates))
+	for _, a := range aggregates {
+		items = append(items, regionAggregateJSON{
+			CountryCode:  a.CountryCode,
+			RegionCode:   a.RegionCode,
+			DistrictCode: a.DistrictCode,
+			Metric:       a.Metric,
+			Unit:         a.Unit,
+			Contributors: a.Contributors,
+			Samples:      a.Samples,
+			Average:      a.Average,
+			WindowStart:  a.WindowStart.Format(time.RFC3339),
+			WindowEnd:    a.WindowEnd.Format(time.RFC3339),
+		})
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{"regions": items})
+}
+
+func (h *EnvironmentalHandler) handleGrid(w http.ResponseWriter, r *http.Request) {
+	q, ok := parseEnvQuery(w, r)
+	if !ok {
+		return
+	}
+	if raw := r.URL.Query().Get("bbox"); raw != "" {
+		parts := strings.Split(raw, ",")
+		for _, p := range parts {
+			v, err := strconv.ParseFloat(strings.TrimSpace(p), 64)
+			if err != nil {
+				writeEnvError(w, http.StatusBadRequest, "bbox 값이 올바르지 않습니다")
+				return
+			}
+			q.BBox = append(q.BBox, v)
+		}
+	}
+
+	aggregates, err := h.svc.GetGridAggregates(r.Context(), q)
+	if err != nil {
+		writeEnvError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	body, err := h.svc.GridGeoJSON(aggregates)
+	if err != nil {
+		writeEnvError(w, http.StatusInternalServerError, "GeoJSON 생성에 실패했습니다")
+		return
+	}
+	w.Header().Set("Content-Type", "application/geo+json")
+	_, _ = w.Write(body)
+}
+
+// parseEnvQuery는 공통 조회 파라미터(metric, country, region, district, window)를 해석합니다.
+func parseEnvQuery(w http.ResponseWriter, r *http.Request) (service.EnvironmentalQuery, bool) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		writeEnvError(w, http.StatusMethodNotAllowed, "GET만 지원합니다")
+		return service.EnvironmentalQuery{}, false
+	}
+	values := r.URL.Query()
+	q := service.EnvironmentalQuery{
+		Metric:       values.Get("metric"),
+		CountryCode:  values.Get("country"),
+		RegionCode:   values.Get("region"),
+		DistrictCode: values.Get("district"),
+	}
+	if raw := values.Get("window"); raw != "" {
+		d, err := time.ParseDuration(raw)
+		if err != nil || d <= 0 {
+			writeEnvError(w, http.StatusBadRequest, "window 값이 올바르지 않습니다 (예: 24h)")
+			return q, false
+		}
+		q.Window = d
+	}
+	return q, true
+}
+
+func writeEnvError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]string{"error": message})
+}
@@ -0,0 +1,85 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/manpasik/backend/services/analytics-service/internal/service"
+)
+
+// EnvironmentalRepository는 환경 측정값 인메모리 저장소입니다.
+type EnvironmentalRepository struct {
+	mu       sync.RWMutex
+	consents map[string]bool // userID -> 공유 동의
+	readings []*service.EnvironmentalReading
+}
+
+// NewEnvironmentalRepository는 새 인메모리 환경 측정값 저장소를 생성합니다.
+func NewEnvironmentalRepository() *EnvironmentalRepository {
+	return &EnvironmentalRepository{
+		consents: make(map[string]bool),
+	}
+}
+
+// SetSharingConsent는 사용자의 공유 동의를 저장합니다.
+func (r *EnvironmentalRepository) SetSharingConsent(_ context.Context, userID string, optIn bool) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if optIn {
+		r.consents[userID] = true
+	} else {
+		delete(r.consents, userID)
+	}
+	return nil
+}
+
+// HasSharingConsent는 사용자의 공유 동의 여부를 반환합니다.
+func (r *EnvironmentalRepository) HasSharingConsent(_ context.Context, userID string) (bool, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.consents[userID], nil
+}
+
+// AddReading은 측정값을 저장합니다.
+func (r *EnvironmentalRepository) AddReading(_ context.Context, reading *service.EnvironmentalReading) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	cp := *reading
+	r.readings = append(r.readings, &cp)
+	return nil
+}
+
+// DeleteReadingsByContributor는 기여자의 측정값을 모두 삭제합니다.
+func (r *EnvironmentalRepository) DeleteReadingsByContributor(_ context.Context, contributorKey string) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	kept := r.readings[:0]
+	deleted := 0
+	for _, reading := range r.readings {
+		if reading.ContributorKey == contributorKey {
+			deleted++
+			continue
+		}
+		kept = append(kept, reading)
+	}
+	r.readings = kept
+	return deleted, nil
+}
+
+// ListReadings는 지표·기간에 해당하는 측정값을 반환합니다.
+func (r *EnvironmentalRepository) ListReadings(_ context.Context, metric string, since, until time.Time) ([]*service.EnvironmentalReading, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var result []*service.EnvironmentalReading
+	for _, reading := range r.readings {
+		if reading.Metric != metric || reading.MeasuredAt.Before(since) || reading.MeasuredAt.After(until) {
+			continue
+		}
+		cp := *reading
+		result = append(result, &cp)
+	}
+	return result, nil
+}
@@ -0,0 +1,409 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ============================================================================
+// 지역 환경 모니터링 집계 (Environmental 카트리지: 수질·공기질·라돈·방사능)
+// ============================================================================
+
+// 환경 측정 지표 (Environmental 카테고리 카트리지 4종)
+const (
+	EnvMetricWaterQuality = "water_quality"
+	EnvMetricAirQuality   = "air_quality"
+	EnvMetricRadon        = "radon"
+	EnvMetricRadiation    = "radiation"
+)
+
+// 기본 집계 설정
+const (
+	// DefaultEnvMinContributors는 집계를 공개하기 위한 최소 기여자 수입니다 (k-익명성).
+	DefaultEnvMinContributors = 5
+	// DefaultEnvGridSizeDeg는 격자 한 변의 크기(도)입니다. 0.01° ≈ 1.1km.
+	DefaultEnvGridSizeDeg = 0.01
+	// DefaultEnvWindow는 기본 집계 기간입니다.
+	DefaultEnvWindow = 24 * time.Hour
+	// MaxEnvWindow는 공개 API에서 허용하는 최대 집계 기간입니다.
+	MaxEnvWindow = 90 * 24 * time.Hour
+)
+
+// EnvironmentalReading은 익명화되어 저장되는 환경 측정값입니다.
+// 사용자 ID와 원 좌표는 저장하지 않으며, 기여자 키와 격자 셀만 남깁니다.
+type EnvironmentalReading struct {
+	ID             string
+	ContributorKey string // SHA-256(salt + userID), 기여자 수 집계와 철회 삭제에만 사용
+	Metric         string
+	Value          float64
+	Unit           string
+	CountryCode    string
+	RegionCode     string
+	DistrictCode   string
+	CellID         string // 격자 셀 ID (빈 값이면 좌표 미제공)
+	MeasuredAt     time.Time
+}
+
+// EnvironmentalSubmission은 측정값 제출 요청입니다. 좌표는 격자 셀로만 변환됩니다.
+type EnvironmentalSubmission struct {
+	UserID       string
+	Metric       string
+	Value        float64
+	Unit         string
+	CountryCode  string
+	RegionCode   string
+	DistrictCode string
+	Latitude     *float64
+	Longitude    *float64
+	MeasuredAt   time.Time
+}
+
+// EnvironmentalQuery는 공개 집계 조회 조건입니다.
+type EnvironmentalQuery struct {
+	Metric       string
+	CountryCode  string
+	RegionCode   string // 비어 있으면 국가 내 전체 지역
+	DistrictCode string
+	Window       time.Duration // 현재 시각 기준 집계 기간 (기본 24시간)
+	// BBox는 격자 조회 범위입니다 [minLon, minLat, maxLon, maxLat]. nil이면 전체.
+	BBox []float64
+}
+
+// EnvironmentalAggregate는 지역 또는 격자 셀 단위 집계 결과입니다.
+type EnvironmentalAggregate struct {
+	Metric       string
+	CountryCode  string
+	RegionCode   string
+	DistrictCode string
+	CellID       string
+	Unit         string
+	Contributors int
+	Samples      int
+	Average      float64
+	WindowStart  time.Time
+	WindowEnd    time.Time
+}
+
+// EnvironmentalRepository는 환경 측정값과 공유 동의 저장소입니다.
+type EnvironmentalRepository interface {
+	SetSharingConsent(ctx context.Context, userID string, optIn bool) error
+	HasSharingConsent(ctx context.Context, userID string) (bool, error)
+	AddReading(ctx context.Context, reading *EnvironmentalReading) error
+	DeleteReadingsByContributor(ctx context.Context, contributorKey string) (int, error)
+	// ListReadings는 지표·기간에 해당하는 측정값을 조회합니다.
+	ListReadings(ctx context.Context, metric string, since, until time.Time) ([]*EnvironmentalReading, error)
+}
+
+// EnvironmentalConfig는 익명화·공개 기준 설정입니다.
+type EnvironmentalConfig struct {
+	MinContributors int
+	GridSizeDeg     float64
+	// Salt는 기여자 키 생성용 비밀 값입니다. 배포 환경마다 달라야 합니다.
+	Salt string
+}
+
+// EnvironmentalService는 옵트인 환경 측정값의 익명 지역 집계를 제공합니다.
+type EnvironmentalService struct {
+	repo   EnvironmentalRepository
+	config EnvironmentalConfig
+	clock  func() time.Time
+}
+
+// NewEnvironmentalService는 EnvironmentalService를 생성합니다. 0 이하 설정값은 기본값을 사용합니다.
+func NewEnvironmentalService(repo EnvironmentalRepository, config EnvironmentalConfig) *EnvironmentalService {
+	if config.MinContributors <= 0 {
+		config.MinContributors = DefaultEnvMinContributors
+	}
+	if config.GridSizeDeg <= 0 {
+		config.GridSizeDeg = DefaultEnvGridSizeDeg
+	}
+	return &EnvironmentalService{repo: repo, config: config, clock: time.Now}
+}
+
+// SetClock은 현재 시각 함수를 교체합니다 (테스트용).
+func (s *EnvironmentalService) SetClock(now func() time.Time) {
+	s.clock = now
+}
+
+// SetSharingConsent는 사용자의 환경 데이터 공유 동의를 설정합니다.
+// 동의를 철회하면 지금까지 기여한 측정값을 모두 삭제하고 삭제 건수를 반환합니다.
+func (s *EnvironmentalService) SetSharingConsent(ctx context.Context, userID string, optIn bool) (int, error) {
+	if userID == "" {
+		return 0, errors.New("user_id는 필수입니다")
+	}
+	if err := s.repo.SetSharingConsent(ctx, userID, optIn); err != nil {
+		return 0, err
+	}
+	if optIn {
+		return 0, nil
+	}
+	return s.repo.DeleteReadingsByContributor(ctx, s.contributorKey(userID))
+}
+
+// SubmitReading은 공유에 동의한 사용자의 환경 측정값을 익명화하여 저장합니다.
+func (s *EnvironmentalService) SubmitReading(ctx context.Context, sub EnvironmentalSubmission) (*EnvironmentalReading, error) {
+	if sub.UserID == "" {
+		return nil, errors.New("user_id는 필수입니다")
+	}
+	if !IsEnvironmentalMetric(sub.Metric) {
+		return nil, errors.New("지원하지 않는 환경 지표입니다: " + sub.Metric)
+	}
+	if sub.CountryCode == "" || sub.RegionCode == "" {
+		return nil, errors.New("country_code와 region_code는 필수입니다")
+	}
+	if math.IsNaN(sub.Value) || math.IsInf(sub.Value, 0) {
+		return nil, errors.New("측정값이 올바르지 않습니다")
+	}
+
+	consented, err := s.repo.HasSharingConsent(ctx, sub.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if !consented {
+		return nil, errors.New("환경 데이터 공유에 동의하지 않은 사용자입니다")
+	}
+
+	measuredAt := sub.MeasuredAt
+	if measuredAt.IsZero() {
+		measuredAt = s.clock()
+	}
+	reading := &EnvironmentalReading{
+		ID:             uuid.New().String(),
+		ContributorKey: s.contributorKey(sub.UserID),
+		Metric:         sub.Metric,
+		Value:          sub.Value,
+		Unit:           sub.Unit,
+		CountryCode:    strings.ToUpper(sub.CountryCode),
+		RegionCode:     strings.ToLower(sub.RegionCode),
+		DistrictCode:   strings.ToLower(sub.DistrictCode),
+		MeasuredAt:     measuredAt.UTC(),
+	}
+	if sub.Latitude != nil && sub.Longitude != nil {
+		lat, lon := *sub.Latitude, *sub.Longitude
+		if lat < -90 || lat > 90 || lon < -180 || lon > 180 {
+			return nil, errors.New("좌표 범위가 올바르지 않습니다")
+		}
+		reading.CellID = s.cellID(lat, lon)
+	}
+
+	if err := s.repo.AddReading(ctx, reading); err != nil {
+		return nil, err
+	}
+	return reading, nil
+}
+
+// GetRegionalAggregates는 지역(RegionCode/DistrictCode)별 집계를 반환합니다.
+// 기여자가 MinContributors 미만인 지역은 결과에서 제외됩니다.
+func (s *EnvironmentalService) GetRegionalAggregates(ctx context.Context, q EnvironmentalQuery) ([]*EnvironmentalAggregate, error) {
+	if q.CountryCode == "" {
+		return nil, errors.New("country_code는 필수입니다")
+	}
+	readings, start, end, err := s.readings(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	country := strings.ToUpper(q.CountryCode)
+	region := strings.ToLower(q.RegionCode)
+	district := strings.ToLower(q.DistrictCode)
+	groups := make(map[string][]*EnvironmentalReading)
+	for _, r := range readings {
+		if r.CountryCode != country || (region != "" && r.RegionCode != region) || (district != "" && r.DistrictCode != district) {
+			continue
+		}
+		key := r.RegionCode + "/" + r.DistrictCode + "/" + r.Metric + "/" + r.Unit
+		groups[key] = append(groups[key], r)
+	}
+
+	result := s.aggregateGroups(groups, start, end)
+	for _, agg := range result {
+		agg.CountryCode = country
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].RegionCode != result[j].RegionCode {
+			return result[i].RegionCode < result[j].RegionCode
+		}
+		if result[i].DistrictCode != result[j].DistrictCode {
+			return result[i].DistrictCode < result[j].DistrictCode
+		}
+		return result[i].Unit < result[j].Unit
+	})
+	return result, nil
+}
+
+// GetGridAggregates는 격자 셀별 집계를 반환합니다.
+// 기여자가 MinContributors 미만인 셀은 결과에서 제외됩니다.
+func (s *EnvironmentalService) GetGridAggregates(ctx context.Context, q EnvironmentalQuery) ([]*EnvironmentalAggregate, error) {
+	if q.BBox != nil && len(q.BBox) != 4 {
+		return nil, errors.New("bbox는 minLon,minLat,maxLon,maxLat 형식이어야 합니다")
+	}
+	readings, start, end, err := s.readings(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	groups := make(map[string][]*EnvironmentalReading)
+	for _, r := range readings {
+		if r.CellID == "" {
+			continue
+		}
+		if q.BBox != nil {
+			minLon, minLat, maxLon, maxLat := s.cellBounds(r.CellID)
+			if maxLon < q.BBox[0] || minLon > q.BBox[2] || maxLat < q.BBox[1] || minLat > q.BBox[3] {
+				continue
+			}
+		}
+		key := r.CellID + "/" + r.Metric + "/" + r.Unit
+		groups[key] = append(groups[key], r)
+	}
+
+	result := s.aggregateGroups(groups, start, end)
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].CellID != result[j].CellID {
+			return result[i].CellID < result[j].CellID
+		}
+		return result[i].Unit < result[j].Unit
+	})
+	return result, nil
+}
+
+// GridGeoJSON은 격자 집계를 GeoJSON FeatureCollection(셀 사각형 Polygon)으로 변환합니다.
+func (s *EnvironmentalService) GridGeoJSON(aggregates []*EnvironmentalAggregate) ([]byte, error) {
+	type geometry struct {
+		Type        string         `json:"type"`
+		Coordinates [][][2]float64 `json:"coordinates"`
+	}
+	type feature struct {
+		Type       string                 `json:"type"`
+		ID         string                 `json:"id"`
+		Geometry   geometry               `json:"geometry"`
+		Properties map[string]interface{} `json:"properties"`
+	}
+	collection := struct {
+		Type     string    `json:"type"`
+		Features []feature `json:"features"`
+	}{Type: "FeatureCollection", Features: []feature{}}
+
+	for _, agg := range aggregates {
+		minLon, minLat, maxLon, maxLat := s.cellBounds(agg.CellID)
+		collection.Features = append(collection.Features, feature{
+			Type: "Feature",
+			ID:   agg.CellID,
+			Geometry: geometry{
+				Type: "Polygon",
+				Coordinates: [][][2]float64{{
+					{minLon, minLat}, {maxLon, minLat}, {maxLon, maxLat}, {minLon, maxLat}, {minLon, minLat},
+				}},
+			},
+			Properties: map[string]interface{}{
+				"metric":       agg.Metric,
+				"unit":         agg.Unit,
+				"contributors": agg.Contributors,
+				"samples":      agg.Samples,
+				"average":      agg.Average,
+				"window_start": agg.WindowStart.Format(time.RFC3339),
+				"window_end":   agg.WindowEnd.Format(time.RFC3339),
+			},
+		})
+	}
+	return json.Marshal(collection)
+}
+
+// IsEnvironmentalMetric은 지원하는 환경 지표인지 확인합니다.
+func IsEnvironmentalMetric(metric string) bool {
+	switch metric {
+	case EnvMetricWaterQuality, EnvMetricAirQuality, EnvMetricRadon, EnvMetricRadiation:
+		return true
+	}
+	return false
+}
+
+// readings는 조회 조건을 검증하고 기간 내 측정값을 조회합니다.
+func (s *EnvironmentalService) readings(ctx context.Context, q EnvironmentalQuery) ([]*EnvironmentalReading, time.Time, time.Time, error) {
+	if !IsEnvironmentalMetric(q.Metric) {
+		return nil, time.Time{}, time.Time{}, errors.New("지원하지 않는 환경 지표입니다: " + q.Metric)
+	}
+	window := q.Window
+	if window <= 0 {
+		window = DefaultEnvWindow
+	}
+	if window > MaxEnvWindow {
+		return nil, time.Time{}, time.Time{}, errors.New("집계 기간은 90일을 넘을 수 없습니다")
+	}
+	end := s.clock().UTC()
+	start := end.Add(-window)
+	readings, err := s.repo.ListReadings(ctx, q.Metric, start, end)
+	return readings, start, end, err
+}
+
+// aggregateGroups는 그룹별 통계를 계산하고 기여자 기준 미달 그룹을 제외합니다.
+// 그룹은 지표·단위별로 나뉘어 있어야 하며, 개별 측정값이 드러나는 최솟값·최댓값은 공개하지 않습니다.
+func (s *EnvironmentalService) aggregateGroups(groups map[string][]*EnvironmentalReading, start, end time.Time) []*EnvironmentalAggregate {
+	var result []*EnvironmentalAggregate
+	for _, readings := range groups {
+		contributors := make(map[string]bool)
+		for _, r := range readings {
+			contributors[r.ContributorKey] = true
+		}
+		if len(contributors) < s.config.MinContributors {
+			continue
+		}
+
+		first := readings[0]
+		agg := &EnvironmentalAggregate{
+			Metric:       first.Metric,
+			CountryCode:  first.CountryCode,
+			RegionCode:   first.RegionCode,
+			DistrictCode: first.DistrictCode,
+			CellID:       first.CellID,
+			Unit:         first.Unit,
+			Contributors: len(contributors),
+			Samples:      len(readings),
+			WindowStart:  start,
+			WindowEnd:    end,
+		}
+		var sum float64
+		for _, r := range readings {
+			sum += r.Value
+		}
+		agg.Average = math.Round(sum/float64(len(readings))*100) / 100
+		result = append(result, agg)
+	}
+	return result
+}
+
+// contributorKey는 사용자 ID를 되돌릴 수 없는 기여자 키로 변환합니다.
+func (s *EnvironmentalService) contributorKey(userID string) string {
+	sum := sha256.Sum256([]byte(s.config.Salt + ":" + userID))
+	return hex.EncodeToString(sum[:])
+}
+
+// cellID는 좌표를 격자 셀 ID("위도인덱스:경도인덱스")로 변환합니다.
+func (s *EnvironmentalService) cellID(lat, lon float64) string {
+	size := s.config.GridSizeDeg
+	return fmt.Sprintf("%d:%d", int(math.Floor(lat/size)), int(math.Floor(lon/size)))
+}
+
+// cellBounds는 격자 셀의 경계 [minLon, minLat, maxLon, maxLat]를 반환합니다.
+func (s *EnvironmentalService) cellBounds(cellID string) (minLon, minLat, maxLon, maxLat float64) {
+	var latIdx, lonIdx int
+	_, _ = fmt.Sscanf(cellID, "%d:%d", &latIdx, &lonIdx)
+	size := s.config.GridSizeDeg
+	minLat = roundDeg(float64(latIdx) * size)
+	minLon = roundDeg(float64(lonIdx) * size)
+	return minLon, minLat, roundDeg(minLon + size), roundDeg(minLat + size)
+}
+
+func roundDeg(v float64) float64 {
+	return math.Round(v*1e6) / 1e6
+}
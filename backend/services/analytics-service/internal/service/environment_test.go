@@ -0,0 +1,197 @@
+package service_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/manpasik/backend/services/analytics-service/internal/repository/memory"
+	"github.com/manpasik/backend/services/analytics-service/internal/service"
+)
+
+var envNow = time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+func setupEnvService(t *testing.T, minContributors int) *service.EnvironmentalService {
+	t.Helper()
+	svc := service.NewEnvironmentalService(memory.NewEnvironmentalRepository(), service.EnvironmentalConfig{
+		MinContributors: minContributors,
+		Salt:            "test-salt",
+	})
+	svc.SetClock(func() time.Time { return envNow })
+	return svc
+}
+
+// submit은 사용자를 옵트인시킨 뒤 서울 강남구 좌표로 라돈 측정값을 제출합니다.
+func submit(t *testing.T, svc *service.EnvironmentalService, userID, district string, value float64, age time.Duration) {
+	t.Helper()
+	ctx := context.Background()
+	svc.SetSharingConsent(ctx, userID, true)
+	lat, lon := 37.4979, 127.0276
+	_, err := svc.SubmitReading(ctx, service.EnvironmentalSubmission{
+		UserID:       userID,
+		Metric:       service.EnvMetricRadon,
+		Value:        value,
+		Unit:         "Bq/m3",
+		CountryCode:  "KR",
+		RegionCode:   "seoul",
+		DistrictCode: district,
+		Latitude:     &lat,
+		Longitude:    &lon,
+		MeasuredAt:   envNow.Add(-age),
+	})
+	if err != nil {
+		t.Fatalf("측정값 제출 실패: %v", err)
+	}
+}
+
+func TestSubmitReading_옵트인_필요(t *testing.T) {
+	svc := setupEnvService(t, 1)
+	ctx := context.Background()
+
+	_, err := svc.SubmitReading(ctx, service.EnvironmentalSubmission{
+		UserID: "user-1", Metric: service.EnvMetricRadon, Value: 100, CountryCode: "KR", RegionCode: "seoul",
+	})
+	if err == nil {
+		t.Fatal("공유 동의 없이 제출이 허용되면 안 됨")
+	}
+
+	svc.SetSharingConsent(ctx, "user-1", true)
+	reading, err := svc.SubmitReading(ctx, service.EnvironmentalSubmission{
+		UserID: "user-1", Metric: service.EnvMetricRadon, Value: 100, CountryCode: "KR", RegionCode: "seoul",
+	})
+	if err != nil {
+		t.Fatalf("동의 후 제출 실패: %v", err)
+	}
+	if reading.ContributorKey == "" || reading.ContributorKey == "user-1" {
+		t.Errorf("사용자 ID는 익명 기여자 키로 대체되어야 함: %q", reading.ContributorKey)
+	}
+}
+
+func TestRegionalAggregates_최소_기여자_기준(t *testing.T) {
+	svc := setupEnvService(t, 3)
+	ctx := context.Background()
+
+	for i, v := range []float64{100, 150, 200} {
+		submit(t, svc, fmt.Sprintf("gangnam-%d", i), "gangnam", v, time.Hour)
+	}
+	// 송파구는 한 사용자가 여러 번 측정 → 기여자 1명
+	for i := 0; i < 5; i++ {
+		submit(t, svc, "songpa-1", "songpa", 300, time.Hour)
+	}
+	// 집계 기간(24시간) 밖 측정값은 제외
+	submit(t, svc, "gangnam-9", "gangnam", 999, 48*time.Hour)
+
+	aggs, err := svc.GetRegionalAggregates(ctx, service.EnvironmentalQuery{Metric: service.EnvMetricRadon, CountryCode: "kr"})
+	if err != nil {
+		t.Fatalf("지역 집계 실패: %v", err)
+	}
+	if len(aggs) != 1 {
+		t.Fatalf("기여자 3명 이상인 강남구만 공개되어야 함: %d건", len(aggs))
+	}
+	a := aggs[0]
+	if a.DistrictCode != "gangnam" || a.Contributors != 3 || a.Average != 150 {
+		t.Errorf("강남구 집계 불일치: %+v", a)
+	}
+}
+
+func TestSetSharingConsent_철회시_기여_삭제(t *testing.T) {
+	svc := setupEnvService(t, 2)
+	ctx := context.Background()
+
+	submit(t, svc, "user-1", "gangnam", 100, time.Hour)
+	submit(t, svc, "user-2", "gangnam", 200, time.Hour)
+
+	deleted, err := svc.SetSharingConsent(ctx, "user-2", false)
+	if err != nil || deleted != 1 {
+		t.Fatalf("동의 철회 시 기여 삭제 실패: deleted=%d, err=%v", deleted, err)
+	}
+	aggs, _ := svc.GetRegionalAggregates(ctx, service.EnvironmentalQuery{Metric: service.EnvMetricRadon, CountryCode: "KR"})
+	if len(aggs) != 0 {
+		t.Errorf("철회 후 기여자 기준 미달로 비공개되어야 함: %+v", aggs)
+	}
+}
+
+func TestGridAggregates_GeoJSON(t *testing.T) {
+	svc := setupEnvService(t, 2)
+	ctx := context.Background()
+
+	submit(t, svc, "user-1", "gangnam", 100, time.Hour)
+	submit(t, svc, "user-2", "gangnam", 120, 2*time.Hour)
+
+	q := service.EnvironmentalQuery{Metric: service.EnvMetricRadon, BBox: []float64{127.0, 37.4, 127.1, 37.6}}
+	cells, err := svc.GetGridAggregates(ctx, q)
+	if err != nil || len(cells) != 1 {
+		t.Fatalf("격자 집계 실패: cells=%d, err=%v", len(cells), err)
+	}
+
+	body, err := svc.GridGeoJSON(cells)
+	if err != nil {
+		t.Fatalf("GeoJSON 생성 실패: %v", err)
+	}
+	var fc struct {
+		Type     string `json:"type"`
+		Features []struct {
+			Geometry struct {
+				Type        string         `json:"type"`
+				Coordinates [][][2]float64 `json:"coordinates"`
+			} `json:"geometry"`
+			Properties map[string]interface{} `json:"properties"`
+		} `json:"features"`
+	}
+	if err := json.Unmarshal(body, &fc); err != nil {
+		t.Fatalf("GeoJSON 파싱 실패: %v", err)
+	}
+	if fc.Type != "FeatureCollection" || len(fc.Features) != 1 {
+		t.Fatalf("FeatureCollection 불일치: %s", body)
+	}
+	f := fc.Features[0]
+	ring := f.Geometry.Coordinates[0]
+	if f.Geometry.Type != "Polygon" || len(ring) != 5 || ring[0] != ring[4] {
+		t.Errorf("셀은 닫힌 사각형 Polygon이어야 함: %+v", f.Geometry)
+	}
+	if ring[0][0] > 127.0276 || ring[2][0] < 127.0276 || ring[0][1] > 37.4979 || ring[2][1] < 37.4979 {
+		t.Errorf("셀 경계가 측정 좌표를 포함해야 함: %v", ring)
+	}
+	if f.Properties["average"] != 110.0 || f.Properties["contributors"] != 2.0 {
+		t.Errorf("셀 속성 불일치: %v", f.Properties)
+	}
+	if _, ok := f.Properties["min"]; ok {
+		t.Errorf("개별 측정값이 드러나는 min/max는 공개하지 않아야 함: %v", f.Properties)
+	}
+
+	// 범위 밖 bbox는 빈 결과
+	q.BBox = []float64{126.0, 35.0, 126.5, 35.5}
+	if cells, _ := svc.GetGridAggregates(ctx, q); len(cells) != 0 {
+		t.Errorf("bbox 밖 셀은 제외되어야 함: %d", len(cells))
+	}
+}
+
+func TestRegionalAggregates_단위별_분리(t *testing.T) {
+	svc := setupEnvService(t, 2)
+	ctx := context.Background()
+
+	submit(t, svc, "user-1", "gangnam", 100, time.Hour)
+	submit(t, svc, "user-2", "gangnam", 200, time.Hour)
+	for _, userID := range []string{"user-3", "user-4"} {
+		svc.SetSharingConsent(ctx, userID, true)
+		if _, err := svc.SubmitReading(ctx, service.EnvironmentalSubmission{
+			UserID: userID, Metric: service.EnvMetricRadon, Value: 4, Unit: "pCi/L",
+			CountryCode: "KR", RegionCode: "seoul", DistrictCode: "gangnam", MeasuredAt: envNow.Add(-time.Hour),
+		}); err != nil {
+			t.Fatalf("측정값 제출 실패: %v", err)
+		}
+	}
+
+	aggs, err := svc.GetRegionalAggregates(ctx, service.EnvironmentalQuery{Metric: service.EnvMetricRadon, CountryCode: "KR"})
+	if err != nil {
+		t.Fatalf("지역 집계 실패: %v", err)
+	}
+	if len(aggs) != 2 {
+		t.Fatalf("단위가 다른 측정값은 별도로 집계되어야 함: %d건", len(aggs))
+	}
+	if aggs[0].Unit != "Bq/m3" || aggs[0].Average != 150 || aggs[1].Unit != "pCi/L" || aggs[1].Average != 4 {
+		t.Errorf("단위별 집계 불일치: %+v, %+v", aggs[0], aggs[1])
+	}
+}
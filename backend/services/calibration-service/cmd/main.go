@@ -28,12 +28,14 @@ import (
 	"github.com/manpasik/backend/services/calibration-service/internal/repository/memory"
 	"github.com/manpasik/backend/services/calibration-service/internal/repository/postgres"
 	"github.com/manpasik/backend/services/calibration-service/internal/service"
+	"github.com/manpasik/backend/shared/clients"
 	"github.com/manpasik/backend/shared/config"
 	v1 "github.com/manpasik/backend/shared/gen/go/v1"
 	"github.com/manpasik/backend/shared/middleware"
 	"github.com/manpasik/backend/shared/observability"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/health"
 	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
@@ -95,6 +97,20 @@ func main() {
 
 	calSvc := service.NewCalibrationService(logger, calRepo, modelRepo)
 
+	// 디바이스 소유 이력: DEVICE_SERVICE_ADDR 설정 시 현장 보정을 보정 시점의 소유자에게만 허용
+	if deviceAddr := os.Getenv("DEVICE_SERVICE_ADDR"); deviceAddr != "" {
+		deviceConn, err := grpc.NewClient(deviceAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		if err != nil {
+			log.Printf("[%s] device-service 연결 실패, 소유자 확인 비활성: %v", serviceName, err)
+		} else {
+			defer deviceConn.Close()
+			calSvc.SetDeviceOwnerClient(clients.NewGRPCDeviceOwnerClient(v1.NewDeviceServiceClient(deviceConn)))
+			log.Printf("[%s] device-service 연결됨: %s", serviceName, deviceAddr)
+		}
+	} else {
+		log.Printf("[%s] DEVICE_SERVICE_ADDR 미설정 — 디바이스 소유자 확인 비활성", serviceName)
+	}
+
 	grpcServer := grpc.NewServer(
 		grpc.ChainUnaryInterceptor(
 			middleware.RequestIDInterceptor(),
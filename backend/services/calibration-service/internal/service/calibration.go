@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/manpasik/backend/shared/clients"
 	apperrors "github.com/manpasik/backend/shared/errors"
 	"go.uber.org/zap"
 )
@@ -85,6 +86,8 @@ type CalibrationService struct {
 	logger   *zap.Logger
 	calRepo  CalibrationRepository
 	modelRepo CalibrationModelRepository
+
+	deviceOwners clients.DeviceOwnerClient // optional: nil이면 디바이스 소유자 확인 생략
 }
 
 // NewCalibrationService는 새 CalibrationService를 생성합니다.
@@ -100,6 +103,12 @@ func NewCalibrationService(
 	}
 }
 
+// SetDeviceOwnerClient는 디바이스 소유 이력 조회 클라이언트를 설정합니다 (optional).
+// 설정하면 현장 보정은 보정 시점의 디바이스 소유자만 수행할 수 있습니다.
+func (s *CalibrationService) SetDeviceOwnerClient(c clients.DeviceOwnerClient) {
+	s.deviceOwners = c
+}
+
 // DefaultFactoryValidityDays는 팩토리 보정 기본 유효 기간 (일)입니다.
 const DefaultFactoryValidityDays = 90
 
@@ -192,6 +201,18 @@ func (s *CalibrationService) PerformFieldCalibration(
 		return nil, apperrors.New(apperrors.ErrInvalidInput, "기준값과 측정값의 개수가 일치해야 합니다")
 	}
 
+	now := time.Now().UTC()
+	if s.deviceOwners != nil {
+		owner, err := s.deviceOwners.OwnerAt(ctx, deviceID, now)
+		if err != nil {
+			s.logger.Warn("디바이스 소유자 조회 실패", zap.String("device_id", deviceID), zap.Error(err))
+			return nil, apperrors.New(apperrors.ErrServiceUnavailable, "디바이스 소유자 확인에 실패했습니다")
+		}
+		if owner != userID {
+			return nil, apperrors.New(apperrors.ErrForbidden, "디바이스 소유자만 현장 보정을 수행할 수 있습니다")
+		}
+	}
+
 	// Alpha 계산: mean(measured / reference) using least squares approach
 	alpha := calculateAlphaFromCalibration(referenceValues, measuredValues)
 
@@ -209,7 +230,6 @@ func (s *CalibrationService) PerformFieldCalibration(
 		}
 	}
 
-	now := time.Now().UTC()
 	record := &CalibrationRecord{
 		ID:                  uuid.New().String(),
 		DeviceID:            deviceID,
@@ -2,10 +2,12 @@ package service
 
 import (
 	"context"
+	"errors"
 	"math"
 	"testing"
 	"time"
 
+	apperrors "github.com/manpasik/backend/shared/errors"
 	"go.uber.org/zap"
 )
 
@@ -262,6 +264,30 @@ func TestPerformFieldCalibration_MismatchedLengths(t *testing.T) {
 	}
 }
 
+// fakeDeviceOwners는 디바이스별 현재 소유자를 돌려주는 소유 이력 조회 대역입니다.
+type fakeDeviceOwners map[string]string
+
+func (f fakeDeviceOwners) OwnerAt(_ context.Context, deviceID string, _ time.Time) (string, error) {
+	return f[deviceID], nil
+}
+
+func TestPerformFieldCalibration_CurrentOwnerOnly(t *testing.T) {
+	svc, _, _ := newTestCalibrationService()
+	svc.SetDeviceOwnerClient(fakeDeviceOwners{"device-002": "user-002"})
+	ctx := context.Background()
+	ref, measured := []float64{100.0, 200.0}, []float64{95.0, 190.0}
+
+	_, err := svc.PerformFieldCalibration(ctx, "device-002", "user-001", 1, 1, ref, measured, 25.0, 50.0)
+	var appErr *apperrors.AppError
+	if !errors.As(err, &appErr) || appErr.Code != apperrors.ErrForbidden {
+		t.Fatalf("이전 소유자의 현장 보정은 거부되어야 합니다: %v", err)
+	}
+	record, err := svc.PerformFieldCalibration(ctx, "device-002", "user-002", 1, 1, ref, measured, 25.0, 50.0)
+	if err != nil || record.CalibratedBy != "user-002" {
+		t.Fatalf("현재 소유자의 현장 보정 실패: %v", err)
+	}
+}
+
 func TestGetCalibration(t *testing.T) {
 	svc, _, _ := newTestCalibrationService()
 	ctx := context.Background()
@@ -2,12 +2,19 @@
 //
 // 포트: gRPC :50061
 // 의존: PostgreSQL(선택) — 미설정 시 인메모리 저장소 사용
+// 의존: Kafka(선택) — measurement.completed·vision.meal_analyzed 소비, coaching.goal_milestone 발행
+// 의존: measurement-service(선택) — 완료된 측정값 조회
+// 의존: ai-inference-service(선택) — LLM 코칭 메시지 개인화, 미설정 시 템플릿 라이브러리
+// 의존: user-service(선택) — 사용자 선호 언어
+// 의존: S3 호환 오브젝트 스토리지(선택) — 주간·월간 리포트 HTML·PDF 보관, 미설정 시 내보내기 비활성화
 //
 // 기능:
 // - 건강 목표 설정 / 조회
-// - AI 코칭 메시지 생성 (측정 피드백, 일일 팁, 목표 진행, 경고, 동기부여, 추천)
+// - 측정·식사 기록 이벤트로 목표 진행률·연속 기록 갱신, 목표일 마감(달성/실패)
+// - AI 코칭 메시지 생성 (측정 피드백, 일일 팁, 목표 진행, 경고, 동기부여, 추천) — 목표·최근 측정·실천도로 개인화
 // - 일일 건강 리포트 생성
 // - 주간 건강 리포트 조회
+// - 주간·월간 건강 리포트 HTML·PDF 내보내기 (차트·목표 진행·실천도·AI 인사이트, 다운로드 링크)
 // - 개인화 추천 조회
 package main
 
@@ -24,15 +31,22 @@ import (
 
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/manpasik/backend/services/coaching-service/internal/handler"
+	kafkaPublisher "github.com/manpasik/backend/services/coaching-service/internal/repository/kafka"
 	"github.com/manpasik/backend/services/coaching-service/internal/repository/memory"
+	"github.com/manpasik/backend/services/coaching-service/internal/repository/objectstore"
 	"github.com/manpasik/backend/services/coaching-service/internal/repository/postgres"
 	"github.com/manpasik/backend/services/coaching-service/internal/service"
+	"github.com/manpasik/backend/shared/clients"
 	"github.com/manpasik/backend/shared/config"
+	"github.com/manpasik/backend/shared/events"
 	v1 "github.com/manpasik/backend/shared/gen/go/v1"
 	"github.com/manpasik/backend/shared/middleware"
 	"github.com/manpasik/backend/shared/observability"
+	"github.com/manpasik/backend/shared/scheduler"
+	"github.com/manpasik/backend/shared/storage"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/health"
 	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
@@ -40,6 +54,9 @@ import (
 
 const serviceName = "coaching-service"
 
+// goalExpiryInterval은 목표일이 지난 목표를 마감하는 주기입니다.
+const goalExpiryInterval = time.Hour
+
 func main() {
 	cfg := config.LoadFromEnv(serviceName)
 
@@ -59,6 +76,8 @@ func main() {
 	var goalRepo service.HealthGoalRepository
 	var msgRepo service.CoachingMessageRepository
 	var reportRepo service.DailyReportRepository
+	var exportRepo service.ReportExportRepository
+	var scheduleStore scheduler.Store = scheduler.NewMemoryStore()
 
 	if _, dbHostSet := os.LookupEnv("DB_HOST"); dbHostSet && cfg.DB.Host != "" && cfg.DB.DBName != "" {
 		connCtx, connCancel := context.WithTimeout(context.Background(), 5*time.Second)
@@ -69,6 +88,7 @@ func main() {
 			goalRepo = memory.NewHealthGoalRepository()
 			msgRepo = memory.NewCoachingMessageRepository()
 			reportRepo = memory.NewDailyReportRepository()
+			exportRepo = memory.NewReportExportRepository()
 		} else {
 			pingCtx, pingCancel := context.WithTimeout(context.Background(), 3*time.Second)
 			if pingErr := pool.Ping(pingCtx); pingErr != nil {
@@ -78,12 +98,15 @@ func main() {
 				goalRepo = memory.NewHealthGoalRepository()
 				msgRepo = memory.NewCoachingMessageRepository()
 				reportRepo = memory.NewDailyReportRepository()
+				exportRepo = memory.NewReportExportRepository()
 			} else {
 				pingCancel()
 				defer pool.Close()
 				goalRepo = postgres.NewHealthGoalRepository(pool)
 				msgRepo = postgres.NewCoachingMessageRepository(pool)
 				reportRepo = postgres.NewDailyReportRepository(pool)
+				exportRepo = postgres.NewReportExportRepository(pool)
+				scheduleStore = scheduler.NewPostgresStore(pool)
 				log.Printf("[%s] DB 연결됨: %s", serviceName, cfg.DB.DBName)
 			}
 		}
@@ -91,11 +114,111 @@ func main() {
 		goalRepo = memory.NewHealthGoalRepository()
 		msgRepo = memory.NewCoachingMessageRepository()
 		reportRepo = memory.NewDailyReportRepository()
+		exportRepo = memory.NewReportExportRepository()
 		log.Printf("[%s] 인메모리 저장소 사용", serviceName)
 	}
 
 	coachingSvc := service.NewCoachingService(logger, goalRepo, msgRepo, reportRepo)
 
+	// 리포트 내보내기: S3_ENDPOINT 설정 시 주간·월간 리포트 파일을 오브젝트 스토리지에 보관하고 presigned 링크 발급
+	if _, s3Set := os.LookupEnv("S3_ENDPOINT"); s3Set && cfg.S3.Endpoint != "" {
+		s3Client, s3Err := storage.NewS3Client(cfg.S3.Endpoint, cfg.S3.AccessKey, cfg.S3.SecretKey, cfg.S3.Bucket, cfg.S3.Region, cfg.S3.UseSSL)
+		if s3Err != nil {
+			log.Printf("[%s] S3 연결 실패, 리포트 내보내기 비활성: %v", serviceName, s3Err)
+		} else {
+			coachingSvc.SetReportExporter(objectstore.NewReportStore(s3Client), exportRepo)
+			log.Printf("[%s] 리포트 저장소 연결됨: %s (버킷: %s)", serviceName, cfg.S3.Endpoint, cfg.S3.Bucket)
+		}
+	} else {
+		log.Printf("[%s] S3_ENDPOINT 미설정 — 리포트 내보내기 비활성화", serviceName)
+	}
+
+	// 측정 데이터: MEASUREMENT_SERVICE_ADDR 설정 시 measurement.completed 측정값을 목표 진행에 반영
+	measurementConnected := false
+	if measurementAddr := os.Getenv("MEASUREMENT_SERVICE_ADDR"); measurementAddr != "" {
+		measurementConn, dialErr := grpc.NewClient(measurementAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		if dialErr != nil {
+			log.Printf("[%s] measurement-service 연결 실패, 측정 기반 목표 진행 비활성: %v", serviceName, dialErr)
+		} else {
+			defer measurementConn.Close()
+			coachingSvc.SetMeasurementClient(clients.NewGRPCMeasurementClient(v1.NewMeasurementServiceClient(measurementConn)))
+			measurementConnected = true
+			log.Printf("[%s] measurement-service 연결됨: %s", serviceName, measurementAddr)
+		}
+	} else {
+		log.Printf("[%s] MEASUREMENT_SERVICE_ADDR 미설정 — 측정 기반 목표 진행 비활성화", serviceName)
+	}
+
+	// 코칭 메시지 개인화: AI_INFERENCE_SERVICE_ADDR 설정 시 LLM(가드레일 적용)으로 본문 생성, 미설정·실패 시 템플릿
+	if aiAddr := os.Getenv("AI_INFERENCE_SERVICE_ADDR"); aiAddr != "" {
+		aiConn, dialErr := grpc.NewClient(aiAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		if dialErr != nil {
+			log.Printf("[%s] ai-inference-service 연결 실패, 템플릿 코칭 사용: %v", serviceName, dialErr)
+		} else {
+			defer aiConn.Close()
+			coachingSvc.SetCoachingComposer(clients.NewGRPCCoachingComposerClient(v1.NewAiInferenceServiceClient(aiConn)))
+			log.Printf("[%s] ai-inference-service 연결됨: %s", serviceName, aiAddr)
+		}
+	} else {
+		log.Printf("[%s] AI_INFERENCE_SERVICE_ADDR 미설정 — 템플릿 코칭 사용", serviceName)
+	}
+
+	// 사용자 선호 언어·시간대: USER_SERVICE_ADDR 설정 시 프로필 언어로 코칭 메시지 작성, 시간대로 일일 코칭 예약
+	var scheduleSettings scheduler.ClientSettings
+	if userAddr := os.Getenv("USER_SERVICE_ADDR"); userAddr != "" {
+		userConn, dialErr := grpc.NewClient(userAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		if dialErr != nil {
+			log.Printf("[%s] user-service 연결 실패, 기본 언어 사용: %v", serviceName, dialErr)
+		} else {
+			defer userConn.Close()
+			profiles := clients.NewGRPCUserProfileClient(v1.NewUserServiceClient(userConn))
+			coachingSvc.SetUserProfileClient(profiles)
+			scheduleSettings.Profiles = profiles
+			log.Printf("[%s] user-service 연결됨: %s", serviceName, userAddr)
+		}
+	}
+
+	// 예약 코칭 알림: NOTIFICATION_SERVICE_ADDR 설정 시 일일 팁·리포트를 푸시로 발송, 방해 금지 시간 반영
+	if notificationAddr := os.Getenv("NOTIFICATION_SERVICE_ADDR"); notificationAddr != "" {
+		notificationConn, dialErr := grpc.NewClient(notificationAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		if dialErr != nil {
+			log.Printf("[%s] notification-service 연결 실패, 예약 코칭 알림 비활성: %v", serviceName, dialErr)
+		} else {
+			defer notificationConn.Close()
+			notifications := clients.NewGRPCNotificationClient(v1.NewNotificationServiceClient(notificationConn))
+			coachingSvc.SetNotificationClient(notifications)
+			scheduleSettings.Preferences = notifications
+			log.Printf("[%s] notification-service 연결됨: %s", serviceName, notificationAddr)
+		}
+	} else {
+		log.Printf("[%s] NOTIFICATION_SERVICE_ADDR 미설정 — 예약 코칭은 생성만 하고 알림 미발송", serviceName)
+	}
+
+	// 일일 코칭 스케줄러: 사용자 현지 시각에 팁·리포트 발송, 레플리카 간 1회 실행 (DB 사용 시 schedules 테이블 공유)
+	coachingScheduler := scheduler.New(scheduleStore, scheduler.WithSettings(scheduleSettings))
+	coachingSvc.SetScheduler(coachingScheduler)
+
+	// 목표 진행 이벤트: Kafka 설정 시 측정·식사 이벤트 소비, 목표 마일스톤 발행
+	if _, kafkaBrokersSet := os.LookupEnv("KAFKA_BROKERS"); kafkaBrokersSet && len(cfg.Kafka.Brokers) > 0 {
+		eventBus, kafkaErr := events.NewKafkaEventBus(events.KafkaAdapterConfig{
+			Brokers:     cfg.Kafka.Brokers,
+			GroupID:     serviceName,
+			TopicPrefix: "manpasik.",
+		})
+		if kafkaErr != nil {
+			log.Printf("[%s] Kafka 연결 실패, 이벤트 기반 목표 진행 비활성: %v", serviceName, kafkaErr)
+		} else {
+			defer eventBus.Close()
+			coachingSvc.SetGoalEventPublisher(kafkaPublisher.NewEventPublisher(eventBus))
+			if measurementConnected {
+				eventBus.Subscribe(events.EventMeasurementCompleted, kafkaPublisher.NewMeasurementCompletedHandler(coachingSvc))
+			}
+			eventBus.Subscribe(events.EventVisionMealAnalyzed, kafkaPublisher.NewMealAnalyzedHandler(coachingSvc))
+			eventBus.StartConsuming(context.Background())
+			log.Printf("[%s] Kafka 연결됨: %v", serviceName, cfg.Kafka.Brokers)
+		}
+	}
+
 	grpcServer := grpc.NewServer(
 		grpc.ChainUnaryInterceptor(
 			middleware.RequestIDInterceptor(),
@@ -125,6 +248,25 @@ func main() {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
+	// 목표일 마감 스윕: 목표일이 지난 진행 중 목표를 달성/실패로 전환
+	// (모든 레플리카에서 실행되며, 조건부 갱신으로 목표마다 한 레플리카만 마감·발행)
+	go func() {
+		ticker := time.NewTicker(goalExpiryInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case now := <-ticker.C:
+				if _, err := coachingSvc.ExpireGoals(ctx, now); err != nil {
+					log.Printf("[%s] 목표 마감 스윕 실패: %v", serviceName, err)
+				}
+			}
+		}
+	}()
+
+	go coachingScheduler.Start(ctx)
+
 	go func() {
 		sigCh := make(chan os.Signal, 1)
 		signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
@@ -3,7 +3,9 @@ package handler
 
 import (
 	"context"
+	"time"
 
+	"github.com/manpasik/backend/services/coaching-service/internal/report"
 	"github.com/manpasik/backend/services/coaching-service/internal/service"
 	apperrors "github.com/manpasik/backend/shared/errors"
 	v1 "github.com/manpasik/backend/shared/gen/go/v1"
@@ -98,6 +100,7 @@ func (h *CoachingHandler) GenerateCoaching(ctx context.Context, req *v1.Generate
 		req.UserId,
 		req.MeasurementId,
 		protoCoachingTypeToService(req.CoachingType),
+		req.Language,
 	)
 	if err != nil {
 		return nil, toGRPC(err)
@@ -206,6 +209,71 @@ func (h *CoachingHandler) GetRecommendations(ctx context.Context, req *v1.GetRec
 	return &v1.GetRecommendationsResponse{Recommendations: protoRecs}, nil
 }
 
+// ============================================================================
+// SetCoachingSchedule / GetCoachingSchedule — 일일 코칭 발송 설정
+// ============================================================================
+
+// SetCoachingSchedule은 일일 팁·리포트 발송 시각 설정 RPC입니다.
+func (h *CoachingHandler) SetCoachingSchedule(ctx context.Context, req *v1.SetCoachingScheduleRequest) (*v1.CoachingSchedule, error) {
+	if req == nil || req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id는 필수입니다")
+	}
+
+	sched, err := h.svc.SetCoachingSchedule(ctx, req.UserId, req.DailyTipTime, req.DailyReportTime)
+	if err != nil {
+		return nil, toGRPC(err)
+	}
+	return coachingScheduleToProto(sched), nil
+}
+
+// GetCoachingSchedule은 일일 팁·리포트 발송 설정 조회 RPC입니다.
+func (h *CoachingHandler) GetCoachingSchedule(ctx context.Context, req *v1.GetCoachingScheduleRequest) (*v1.CoachingSchedule, error) {
+	if req == nil || req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id는 필수입니다")
+	}
+
+	sched, err := h.svc.GetCoachingSchedule(ctx, req.UserId)
+	if err != nil {
+		return nil, toGRPC(err)
+	}
+	return coachingScheduleToProto(sched), nil
+}
+
+// ============================================================================
+// ExportHealthReport / GetHealthReportExport — 건강 리포트 내보내기
+// ============================================================================
+
+// ExportHealthReport는 주간·월간 리포트 HTML·PDF 생성 RPC입니다.
+func (h *CoachingHandler) ExportHealthReport(ctx context.Context, req *v1.ExportHealthReportRequest) (*v1.HealthReportExport, error) {
+	if req == nil || req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id는 필수입니다")
+	}
+
+	var periodStart time.Time
+	if req.PeriodStart != nil {
+		periodStart = req.PeriodStart.AsTime()
+	}
+
+	export, err := h.svc.ExportHealthReport(ctx, req.UserId, protoReportPeriodToService(req.Period), periodStart, req.Language)
+	if err != nil {
+		return nil, toGRPC(err)
+	}
+	return reportExportToProto(export), nil
+}
+
+// GetHealthReportExport는 생성된 리포트의 다운로드 링크 재발급 RPC입니다.
+func (h *CoachingHandler) GetHealthReportExport(ctx context.Context, req *v1.GetHealthReportExportRequest) (*v1.HealthReportExport, error) {
+	if req == nil || req.ExportId == "" {
+		return nil, status.Error(codes.InvalidArgument, "export_id는 필수입니다")
+	}
+
+	export, err := h.svc.GetHealthReportExport(ctx, req.ExportId, req.UserId)
+	if err != nil {
+		return nil, toGRPC(err)
+	}
+	return reportExportToProto(export), nil
+}
+
 // ============================================================================
 // 헬퍼 함수 — Proto ↔ Service 변환
 // ============================================================================
@@ -224,10 +292,14 @@ func goalToProto(g *service.HealthGoal) *v1.HealthGoal {
 		Description:  g.Description,
 		CreatedAt:    timestamppb.New(g.CreatedAt),
 		TargetDate:   timestamppb.New(g.TargetDate),
+		Streak:       g.Streak,
 	}
 	if g.AchievedAt != nil {
 		pg.AchievedAt = timestamppb.New(*g.AchievedAt)
 	}
+	if g.LastProgressAt != nil {
+		pg.LastProgressAt = timestamppb.New(*g.LastProgressAt)
+	}
 	return pg
 }
 
@@ -243,6 +315,8 @@ func coachingMessageToProto(m *service.CoachingMessage) *v1.CoachingMessage {
 		RelatedMetric: m.RelatedMetric,
 		RelatedValue:  m.RelatedValue,
 		CreatedAt:     timestamppb.New(m.CreatedAt),
+		Strategy:      string(m.Strategy),
+		Language:      m.Language,
 	}
 }
 
@@ -284,6 +358,37 @@ func weeklyReportToProto(r *service.WeeklyHealthReport) *v1.WeeklyHealthReport {
 	}
 }
 
+func coachingScheduleToProto(c *service.CoachingSchedule) *v1.CoachingSchedule {
+	pc := &v1.CoachingSchedule{
+		UserId:          c.UserID,
+		DailyTipTime:    c.DailyTipTime,
+		DailyReportTime: c.DailyReportTime,
+		Timezone:        c.Timezone,
+	}
+	if !c.NextDailyTipAt.IsZero() {
+		pc.NextDailyTipAt = timestamppb.New(c.NextDailyTipAt)
+	}
+	if !c.NextDailyReportAt.IsZero() {
+		pc.NextDailyReportAt = timestamppb.New(c.NextDailyReportAt)
+	}
+	return pc
+}
+
+func reportExportToProto(e *service.ReportExport) *v1.HealthReportExport {
+	return &v1.HealthReportExport{
+		ExportId:     e.ExportID,
+		UserId:       e.UserID,
+		Period:       serviceReportPeriodToProto(e.Period),
+		PeriodStart:  timestamppb.New(e.PeriodStart),
+		PeriodEnd:    timestamppb.New(e.PeriodEnd),
+		Language:     e.Language,
+		HtmlUrl:      e.HTMLURL,
+		PdfUrl:       e.PDFURL,
+		UrlExpiresAt: timestamppb.New(e.URLExpiresAt),
+		CreatedAt:    timestamppb.New(e.CreatedAt),
+	}
+}
+
 func recommendationToProto(r *service.Recommendation) *v1.Recommendation {
 	return &v1.Recommendation{
 		RecommendationId: r.RecommendationID,
@@ -362,6 +467,8 @@ func protoGoalStatusToService(s v1.GoalStatus) service.GoalStatus {
 		return service.GoalStatusPaused
 	case v1.GoalStatus_GOAL_STATUS_CANCELLED:
 		return service.GoalStatusCancelled
+	case v1.GoalStatus_GOAL_STATUS_FAILED:
+		return service.GoalStatusFailed
 	default:
 		return service.GoalStatusUnknown
 	}
@@ -377,6 +484,8 @@ func serviceGoalStatusToProto(s service.GoalStatus) v1.GoalStatus {
 		return v1.GoalStatus_GOAL_STATUS_PAUSED
 	case service.GoalStatusCancelled:
 		return v1.GoalStatus_GOAL_STATUS_CANCELLED
+	case service.GoalStatusFailed:
+		return v1.GoalStatus_GOAL_STATUS_FAILED
 	default:
 		return v1.GoalStatus_GOAL_STATUS_UNKNOWN
 	}
@@ -477,6 +586,28 @@ func serviceRecommendationTypeToProto(t service.RecommendationType) v1.Recommend
 
 // --- 에러 변환 ---
 
+func protoReportPeriodToService(p v1.ReportPeriod) report.Period {
+	switch p {
+	case v1.ReportPeriod_REPORT_PERIOD_WEEKLY:
+		return report.PeriodWeekly
+	case v1.ReportPeriod_REPORT_PERIOD_MONTHLY:
+		return report.PeriodMonthly
+	default:
+		return ""
+	}
+}
+
+func serviceReportPeriodToProto(p report.Period) v1.ReportPeriod {
+	switch p {
+	case report.PeriodWeekly:
+		return v1.ReportPeriod_REPORT_PERIOD_WEEKLY
+	case report.PeriodMonthly:
+		return v1.ReportPeriod_REPORT_PERIOD_MONTHLY
+	default:
+		return v1.ReportPeriod_REPORT_PERIOD_UNKNOWN
+	}
+}
+
 // toGRPC는 AppError를 gRPC status로 변환합니다.
 func toGRPC(err error) error {
 	if err == nil {
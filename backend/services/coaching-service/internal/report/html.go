@@ -0,0 +1,213 @@
+package report
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"html/template"
+	"strings"
+	"time"
+)
+
+// SVG 차트 크기
+const (
+	svgChartW = 640
+	svgChartH = 180
+	svgPadL   = 48
+	svgPadR   = 12
+	svgPadT   = 12
+	svgPadB   = 28
+)
+
+var htmlTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html lang="{{.Lang}}">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Title}}</title>
+<style>
+body{font-family:-apple-system,"Apple SD Gothic Neo","Noto Sans KR","Malgun Gothic",sans-serif;color:#1f2933;max-width:760px;margin:24px auto;padding:0 16px;line-height:1.5}
+h1{font-size:24px;margin:0 0 4px}h2{font-size:18px;margin:28px 0 8px;border-bottom:2px solid #e4e7eb;padding-bottom:4px}
+.meta{color:#616e7c;font-size:13px}
+table{width:100%;border-collapse:collapse;font-size:14px}th,td{padding:6px 8px;border-bottom:1px solid #e4e7eb;text-align:right}th:first-child,td:first-child{text-align:left}
+.bar{background:#e4e7eb;border-radius:4px;height:10px;overflow:hidden}.bar span{display:block;height:100%;background:#2186eb}
+.goal{margin:10px 0}.goal .head{display:flex;justify-content:space-between;font-size:14px}
+.chart{margin:8px 0 16px}.chart h3{font-size:14px;margin:0 0 4px}
+.empty{color:#9aa5b1}.disclaimer{margin-top:32px;font-size:12px;color:#7b8794}
+@media print{body{margin:0}}
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<div class="meta">{{.PeriodLabel}}: {{.PeriodText}} · {{.GeneratedLabel}}: {{.GeneratedText}}</div>
+
+<h2>{{.L.Adherence}}</h2>
+<div class="bar"><span style="width:{{.AdherencePct}}%"></span></div>
+<p>{{.MeasurementDaysText}} ({{.AdherencePct}}%) · {{.GoalsText}}{{if .StreakText}} · {{.StreakText}}{{end}}</p>
+
+<h2>{{.L.Measurements}}</h2>
+{{if .Metrics}}
+<table>
+<tr><th>{{.L.Metric}}</th><th>{{.L.Count}}</th><th>{{.L.Average}}</th><th>{{.L.Min}}</th><th>{{.L.Max}}</th></tr>
+{{range .Metrics}}<tr><td>{{.Name}}{{if .Unit}} ({{.Unit}}){{end}}</td><td>{{.Count}}</td><td>{{.Avg}}</td><td>{{.Min}}</td><td>{{.Max}}</td></tr>
+{{end}}</table>
+{{range .Metrics}}<div class="chart"><h3>{{.Name}}</h3>{{.Chart}}</div>
+{{end}}
+{{else}}<p class="empty">{{.L.NoMeasurements}}</p>{{end}}
+
+<h2>{{.L.Goals}}</h2>
+{{if .Goals}}{{range .Goals}}
+<div class="goal"><div class="head"><span>{{.Name}} · {{.Status}}</span><span>{{.Current}} / {{.Target}} {{.Unit}} · {{.Pct}}%{{if .StreakText}} · {{.StreakText}}{{end}}</span></div>
+<div class="bar"><span style="width:{{.Pct}}%"></span></div></div>
+{{end}}{{else}}<p class="empty">{{.L.NoGoals}}</p>{{end}}
+
+<h2>{{.L.Insights}}</h2>
+{{if .Insights}}<ul>{{range .Insights}}<li>{{.}}</li>{{end}}</ul>{{else}}<p class="empty">{{.L.NoInsights}}</p>{{end}}
+
+<p class="disclaimer">{{.L.Disclaimer}}</p>
+</body>
+</html>
+`))
+
+type htmlMetric struct {
+	Name, Unit, Avg, Min, Max string
+	Count                     int
+	Chart                     template.HTML
+}
+
+type htmlGoal struct {
+	Name, Unit, Status, Current, Target, StreakText string
+	Pct                                             string
+}
+
+type htmlView struct {
+	Lang                                       string
+	L                                          labels
+	Title, PeriodLabel, PeriodText             string
+	GeneratedLabel, GeneratedText              string
+	AdherencePct                               string
+	MeasurementDaysText, GoalsText, StreakText string
+	Metrics                                    []htmlMetric
+	Goals                                      []htmlGoal
+	Insights                                   []string
+}
+
+// RenderHTML은 리포트를 외부 리소스 없는 단일 HTML 문서로 렌더링합니다.
+func RenderHTML(d *Data) ([]byte, error) {
+	l := labelsFor(d.Language)
+	v := htmlView{
+		Lang:                d.Language,
+		L:                   l,
+		Title:               l.Title[d.Period],
+		PeriodLabel:         l.Period,
+		PeriodText:          d.Start.Format(l.DateFormat) + " – " + d.End.Format(l.DateFormat),
+		GeneratedLabel:      l.Generated,
+		GeneratedText:       d.GeneratedAt.Format(l.DateFormat),
+		AdherencePct:        formatNum(d.Adherence.Pct()),
+		MeasurementDaysText: fmt.Sprintf(l.MeasurementDays, d.Adherence.MeasurementDays, d.Adherence.PeriodDays),
+		GoalsText:           fmt.Sprintf(l.GoalsSummary, d.Adherence.GoalsActive, d.Adherence.GoalsAchieved),
+		Insights:            d.Insights,
+	}
+	if d.Adherence.BestStreak > 0 {
+		v.StreakText = fmt.Sprintf(l.BestStreak, d.Adherence.BestStreak)
+	}
+	for _, m := range d.Metrics {
+		v.Metrics = append(v.Metrics, htmlMetric{
+			Name: m.Name, Unit: m.Unit, Count: m.Count,
+			Avg: formatNum(m.Avg), Min: formatNum(m.Min), Max: formatNum(m.Max),
+			Chart: svgLineChart(d, m, l),
+		})
+	}
+	for _, g := range d.Goals {
+		hg := htmlGoal{
+			Name: g.Name, Unit: g.Unit, Status: g.Status,
+			Current: formatNum(g.Current), Target: formatNum(g.Target), Pct: formatNum(clampPct(g.ProgressPct)),
+		}
+		if g.Streak > 0 {
+			hg.StreakText = fmt.Sprintf(l.Streak, g.Streak)
+		}
+		v.Goals = append(v.Goals, hg)
+	}
+
+	var buf bytes.Buffer
+	if err := htmlTemplate.Execute(&buf, v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// svgLineChart는 일별 평균 추이를 인라인 SVG로 그립니다. 텍스트는 모두 이스케이프합니다.
+func svgLineChart(d *Data, m Metric, l labels) template.HTML {
+	days := d.Days()
+	lo, hi := chartRange(m)
+	plotW := float64(svgChartW - svgPadL - svgPadR)
+	plotH := float64(svgChartH - svgPadT - svgPadB)
+	x := func(i int) float64 {
+		if len(days) <= 1 {
+			return svgPadL + plotW/2
+		}
+		return svgPadL + plotW*float64(i)/float64(len(days)-1)
+	}
+	y := func(v float64) float64 { return svgPadT + plotH*(1-(v-lo)/(hi-lo)) }
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, `<svg viewBox="0 0 %d %d" width="100%%" role="img" aria-label="%s">`, svgChartW, svgChartH, html.EscapeString(m.Name))
+	fmt.Fprintf(&sb, `<rect x="%d" y="%d" width="%.0f" height="%.0f" fill="#f5f7fa"/>`, svgPadL, svgPadT, plotW, plotH)
+	for _, v := range []float64{lo, (lo + hi) / 2, hi} {
+		fmt.Fprintf(&sb, `<line x1="%d" x2="%d" y1="%.1f" y2="%.1f" stroke="#d9e2ec"/>`, svgPadL, svgChartW-svgPadR, y(v), y(v))
+		fmt.Fprintf(&sb, `<text x="%d" y="%.1f" font-size="11" text-anchor="end" fill="#7b8794">%s</text>`, svgPadL-6, y(v)+4, formatNum(v))
+	}
+	fmt.Fprintf(&sb, `<text x="%d" y="%d" font-size="11" fill="#7b8794">%s</text>`, svgPadL, svgChartH-8, html.EscapeString(d.Start.Format(l.DateFormat)))
+	fmt.Fprintf(&sb, `<text x="%d" y="%d" font-size="11" text-anchor="end" fill="#7b8794">%s</text>`, svgChartW-svgPadR, svgChartH-8, html.EscapeString(d.End.Format(l.DateFormat)))
+
+	index := dayIndex(days)
+	var pts []string
+	for _, p := range m.Points {
+		i, ok := index[p.Date.Format("2006-01-02")]
+		if !ok {
+			continue
+		}
+		pts = append(pts, fmt.Sprintf("%.1f,%.1f", x(i), y(p.Value)))
+		fmt.Fprintf(&sb, `<circle cx="%.1f" cy="%.1f" r="3" fill="#2186eb"/>`, x(i), y(p.Value))
+	}
+	if len(pts) > 1 {
+		fmt.Fprintf(&sb, `<polyline points="%s" fill="none" stroke="#2186eb" stroke-width="2"/>`, strings.Join(pts, " "))
+	}
+	sb.WriteString(`</svg>`)
+	return template.HTML(sb.String())
+}
+
+// chartRange는 차트 세로축 범위입니다. 값이 하나뿐이면 위아래로 여유를 둡니다.
+func chartRange(m Metric) (float64, float64) {
+	lo, hi := m.Min, m.Max
+	if hi-lo < 1e-9 {
+		pad := 1.0
+		if lo != 0 {
+			pad = absf(lo) * 0.1
+		}
+		return lo - pad, hi + pad
+	}
+	pad := (hi - lo) * 0.1
+	return lo - pad, hi + pad
+}
+
+func dayIndex(days []time.Time) map[string]int {
+	index := make(map[string]int, len(days))
+	for i, d := range days {
+		index[d.Format("2006-01-02")] = i
+	}
+	return index
+}
+
+func absf(v float64) float64 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+// formatNum은 소수점 한 자리까지 표시하고 불필요한 0은 뺍니다.
+func formatNum(v float64) string {
+	s := fmt.Sprintf("%.1f", v)
+	return strings.TrimSuffix(s, ".0")
+}
@@ -0,0 +1,140 @@
+package report
+
+// labels는 리포트 고정 문구입니다.
+type labels struct {
+	Title           map[Period]string
+	Period          string
+	Generated       string
+	Adherence       string
+	MeasurementDays string // %d/%d일 형식
+	GoalsSummary    string // 진행 중 %d개, 달성 %d개
+	BestStreak      string // %d일
+	Measurements    string
+	Metric          string
+	Count           string
+	Average         string
+	Min             string
+	Max             string
+	Goals           string
+	Progress        string
+	Streak          string // %d일 연속
+	Insights        string
+	NoMeasurements  string
+	NoGoals         string
+	NoInsights      string
+	Disclaimer      string
+	DateFormat      string
+}
+
+var reportLabels = map[string]labels{
+	"ko": {
+		Title:           map[Period]string{PeriodWeekly: "주간 건강 리포트", PeriodMonthly: "월간 건강 리포트"},
+		Period:          "기간",
+		Generated:       "생성",
+		Adherence:       "실천도",
+		MeasurementDays: "측정한 날 %d / %d일",
+		GoalsSummary:    "진행 중 목표 %d개, 달성 %d개",
+		BestStreak:      "최장 연속 기록 %d일",
+		Measurements:    "측정 요약",
+		Metric:          "지표",
+		Count:           "측정 수",
+		Average:         "평균",
+		Min:             "최저",
+		Max:             "최고",
+		Goals:           "목표 진행",
+		Progress:        "진행률",
+		Streak:          "%d일 연속",
+		Insights:        "AI 코칭 인사이트",
+		NoMeasurements:  "이 기간에 측정 기록이 없습니다.",
+		NoGoals:         "설정된 건강 목표가 없습니다.",
+		NoInsights:      "이 기간의 코칭 메시지가 없습니다.",
+		Disclaimer:      "이 리포트는 만파식 측정 기록을 요약한 참고 자료이며 의학적 진단을 대신하지 않습니다. 진료 시 담당 의료진과 함께 확인하세요.",
+		DateFormat:      "2006.01.02",
+	},
+	"en": {
+		Title:           map[Period]string{PeriodWeekly: "Weekly Health Report", PeriodMonthly: "Monthly Health Report"},
+		Period:          "Period",
+		Generated:       "Generated",
+		Adherence:       "Adherence",
+		MeasurementDays: "Measured on %d of %d days",
+		GoalsSummary:    "%d active goals, %d achieved",
+		BestStreak:      "Longest streak %d days",
+		Measurements:    "Measurements",
+		Metric:          "Metric",
+		Count:           "Readings",
+		Average:         "Average",
+		Min:             "Min",
+		Max:             "Max",
+		Goals:           "Goal Progress",
+		Progress:        "Progress",
+		Streak:          "%d-day streak",
+		Insights:        "AI Coaching Insights",
+		NoMeasurements:  "No measurements were recorded in this period.",
+		NoGoals:         "No health goals have been set.",
+		NoInsights:      "No coaching messages in this period.",
+		Disclaimer:      "This report summarizes ManPaSik measurements for reference only and is not a medical diagnosis. Review it with your care team.",
+		DateFormat:      "Jan 2, 2006",
+	},
+	"ja": {
+		Title:           map[Period]string{PeriodWeekly: "週間健康レポート", PeriodMonthly: "月間健康レポート"},
+		Period:          "期間",
+		Generated:       "作成",
+		Adherence:       "実践度",
+		MeasurementDays: "測定日数 %d / %d日",
+		GoalsSummary:    "進行中の目標 %d件、達成 %d件",
+		BestStreak:      "最長連続記録 %d日",
+		Measurements:    "測定サマリー",
+		Metric:          "指標",
+		Count:           "測定回数",
+		Average:         "平均",
+		Min:             "最低",
+		Max:             "最高",
+		Goals:           "目標の進捗",
+		Progress:        "進捗率",
+		Streak:          "%d日連続",
+		Insights:        "AIコーチングインサイト",
+		NoMeasurements:  "この期間の測定記録はありません。",
+		NoGoals:         "設定された健康目標はありません。",
+		NoInsights:      "この期間のコーチングメッセージはありません。",
+		Disclaimer:      "このレポートはManPaSikの測定記録をまとめた参考資料であり、医学的診断に代わるものではありません。受診の際は担当の医療スタッフとご確認ください。",
+		DateFormat:      "2006/01/02",
+	},
+	"zh": {
+		Title:           map[Period]string{PeriodWeekly: "每周健康报告", PeriodMonthly: "每月健康报告"},
+		Period:          "期间",
+		Generated:       "生成",
+		Adherence:       "执行度",
+		MeasurementDays: "测量天数 %d / %d天",
+		GoalsSummary:    "进行中目标 %d个，已达成 %d个",
+		BestStreak:      "最长连续记录 %d天",
+		Measurements:    "测量摘要",
+		Metric:          "指标",
+		Count:           "测量次数",
+		Average:         "平均",
+		Min:             "最低",
+		Max:             "最高",
+		Goals:           "目标进度",
+		Progress:        "进度",
+		Streak:          "连续%d天",
+		Insights:        "AI健康指导洞察",
+		NoMeasurements:  "此期间没有测量记录。",
+		NoGoals:         "尚未设置健康目标。",
+		NoInsights:      "此期间没有健康指导消息。",
+		Disclaimer:      "本报告汇总了ManPaSik测量记录，仅供参考，不能替代医学诊断。就诊时请与您的医护人员一起确认。",
+		DateFormat:      "2006-01-02",
+	},
+}
+
+// Supported는 리포트를 렌더링할 수 있는 언어인지 반환합니다.
+func Supported(language string) bool {
+	_, ok := reportLabels[language]
+	return ok
+}
+
+// labelsFor는 언어에 맞는 문구를 반환합니다. 지원하지 않는 언어는 한국어를 사용합니다.
+func labelsFor(language string) labels {
+	if l, ok := reportLabels[language]; ok {
+		return l
+	}
+	return reportLabels["ko"]
+}
@@ -0,0 +1,283 @@
+package report
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"unicode/utf16"
+)
+
+// A4 페이지 크기 (pt)
+const (
+	pageW = 595.0
+	pageH = 842.0
+)
+
+// rgb는 0~1 범위 색상입니다.
+type rgb [3]float64
+
+var (
+	colorText  = rgb{0.12, 0.16, 0.2}
+	colorMuted = rgb{0.48, 0.53, 0.58}
+	colorRule  = rgb{0.89, 0.91, 0.92}
+	colorPanel = rgb{0.96, 0.97, 0.98}
+	colorBrand = rgb{0.13, 0.53, 0.92}
+)
+
+// cjkFont는 뷰어 내장 CJK 폰트와 그 Adobe 문자 모음(ordering)입니다.
+type cjkFont struct {
+	name       string
+	encoding   string // UCS-2 CMap
+	ordering   string
+	supplement int
+	bbox       string
+	ascent     int
+	descent    int
+	capHeight  int
+}
+
+var (
+	fontKorea1 = cjkFont{"HYSMyeongJo-Medium", "UniKS-UCS2-H", "Korea1", 1, "0 -148 1001 880", 880, -120, 880}
+	fontJapan1 = cjkFont{"HeiseiMin-W3", "UniJIS-UCS2-H", "Japan1", 2, "-123 -257 1001 910", 857, -143, 718}
+	fontGB1    = cjkFont{"STSong-Light", "UniGB-UCS2-H", "GB1", 2, "-25 -254 1000 880", 880, -120, 880}
+)
+
+// languageFonts는 리포트 언어별 한자·가나 폰트입니다. 없는 언어는 Korea1 폰트만 씁니다.
+// Korea1에는 간체자·가나가 없어 일본어·중국어 리포트는 각 문자 모음의 폰트가 필요합니다.
+var languageFonts = map[string]cjkFont{"ja": fontJapan1, "zh": fontGB1}
+
+// pdfDoc은 텍스트와 선·사각형만 그리는 최소 PDF 작성기입니다.
+//
+// 라틴 문자는 표준 14 폰트(Helvetica)로, 그 밖의 문자는 뷰어 내장 CJK 폰트로 출력하므로
+// 폰트 파일을 내장하지 않아도 됩니다. 한글은 언어와 관계없이 HYSMyeongJo-Medium(Korea1)으로,
+// 나머지 CJK 문자는 일본어·중국어 리포트면 언어별 폰트(Japan1·GB1)로 출력합니다.
+type pdfDoc struct {
+	pages []*bytes.Buffer
+	cur   *bytes.Buffer
+	lang  *cjkFont // nil이면 모든 CJK 문자를 Korea1 폰트로 출력
+}
+
+func newPDFDoc(language string) *pdfDoc {
+	d := &pdfDoc{}
+	if f, ok := languageFonts[language]; ok {
+		d.lang = &f
+	}
+	d.addPage()
+	return d
+}
+
+func (d *pdfDoc) addPage() {
+	d.cur = &bytes.Buffer{}
+	d.pages = append(d.pages, d.cur)
+}
+
+// text는 (x, y) 기준선에 문자열을 씁니다. y는 페이지 위쪽에서 잰 거리입니다.
+func (d *pdfDoc) text(x, y, size float64, bold bool, c rgb, s string) {
+	latin := "/F1"
+	if bold {
+		latin = "/F2"
+	}
+	fmt.Fprintf(d.cur, "BT %.3f %.3f %.3f rg %.2f %.2f Td\n", c[0], c[1], c[2], x, pageH-y)
+	for _, run := range splitRuns(s) {
+		switch {
+		case run.latin:
+			fmt.Fprintf(d.cur, "%s %.1f Tf (%s) Tj\n", latin, size, escapePDFString(run.text))
+		case run.hangul || d.lang == nil:
+			fmt.Fprintf(d.cur, "/F3 %.1f Tf <%s> Tj\n", size, utf16Hex(run.text))
+		default:
+			fmt.Fprintf(d.cur, "/F4 %.1f Tf <%s> Tj\n", size, utf16Hex(run.text))
+		}
+	}
+	d.cur.WriteString("ET\n")
+}
+
+// line은 두 점을 잇는 선을 긋습니다.
+func (d *pdfDoc) line(x1, y1, x2, y2, width float64, c rgb) {
+	fmt.Fprintf(d.cur, "%.3f %.3f %.3f RG %.2f w %.2f %.2f m %.2f %.2f l S\n",
+		c[0], c[1], c[2], width, x1, pageH-y1, x2, pageH-y2)
+}
+
+// rect는 (x, y)를 왼쪽 위 꼭짓점으로 하는 사각형을 채웁니다.
+func (d *pdfDoc) rect(x, y, w, h float64, c rgb) {
+	fmt.Fprintf(d.cur, "%.3f %.3f %.3f rg %.2f %.2f %.2f %.2f re f\n",
+		c[0], c[1], c[2], x, pageH-y-h, w, h)
+}
+
+// polyline은 점들을 차례로 잇습니다. pts는 x, y 쌍입니다.
+func (d *pdfDoc) polyline(pts [][2]float64, width float64, c rgb) {
+	if len(pts) < 2 {
+		return
+	}
+	fmt.Fprintf(d.cur, "%.3f %.3f %.3f RG %.2f w 1 j %.2f %.2f m", c[0], c[1], c[2], width, pts[0][0], pageH-pts[0][1])
+	for _, p := range pts[1:] {
+		fmt.Fprintf(d.cur, " %.2f %.2f l", p[0], pageH-p[1])
+	}
+	d.cur.WriteString(" S\n")
+}
+
+// render는 PDF 파일을 조립합니다. 객체 번호: 1 카탈로그, 2 페이지 트리, 3~7 폰트
+// (언어별 CJK 폰트가 있으면 3~10), 이후 페이지·콘텐츠.
+func (d *pdfDoc) render() []byte {
+	var out bytes.Buffer
+	var offsets []int
+	obj := func(body string) {
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+	// cjk는 Type0 폰트와 그 하위 CIDFont·폰트 서술자 객체를 씁니다.
+	cjk := func(f cjkFont) {
+		next := len(offsets) + 1
+		obj(fmt.Sprintf("<< /Type /Font /Subtype /Type0 /BaseFont /%s /Encoding /%s /DescendantFonts [%d 0 R] >>",
+			f.name, f.encoding, next+1))
+		obj(fmt.Sprintf("<< /Type /Font /Subtype /CIDFontType0 /BaseFont /%s "+
+			"/CIDSystemInfo << /Registry (Adobe) /Ordering (%s) /Supplement %d >> "+
+			"/FontDescriptor %d 0 R /DW 1000 /W [1 95 500] >>", f.name, f.ordering, f.supplement, next+2))
+		obj(fmt.Sprintf("<< /Type /FontDescriptor /FontName /%s /Flags 6 /FontBBox [%s] "+
+			"/ItalicAngle 0 /Ascent %d /Descent %d /CapHeight %d /StemV 91 >>", f.name, f.bbox, f.ascent, f.descent, f.capHeight))
+	}
+
+	out.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+	firstPage := 8
+	fonts := "/F1 3 0 R /F2 4 0 R /F3 5 0 R"
+	if d.lang != nil {
+		firstPage = 11
+		fonts += " /F4 8 0 R"
+	}
+	kids := make([]string, len(d.pages))
+	for i := range d.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", firstPage+i*2)
+	}
+	obj("<< /Type /Catalog /Pages 2 0 R >>")
+	obj(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.pages)))
+	obj("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	obj("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+	cjk(fontKorea1)
+	if d.lang != nil {
+		cjk(*d.lang)
+	}
+	for i, content := range d.pages {
+		obj(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.0f %.0f] "+
+			"/Resources << /Font << %s >> >> /Contents %d 0 R >>",
+			pageW, pageH, fonts, firstPage+i*2+1))
+		obj(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", content.Len(), content.String()))
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, off := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+	return out.Bytes()
+}
+
+// textRun은 같은 폰트로 출력할 연속 구간입니다.
+type textRun struct {
+	text   string
+	latin  bool
+	hangul bool
+}
+
+// splitRuns는 문자열을 출력 가능한 ASCII 구간, 한글 구간, 그 밖의 구간으로 나눕니다.
+func splitRuns(s string) []textRun {
+	var runs []textRun
+	var sb strings.Builder
+	var cur textRun
+	for _, r := range s {
+		next := textRun{latin: r >= 0x20 && r < 0x7f, hangul: isHangul(r)}
+		if sb.Len() > 0 && (next.latin != cur.latin || next.hangul != cur.hangul) {
+			cur.text = sb.String()
+			runs = append(runs, cur)
+			sb.Reset()
+		}
+		cur = next
+		sb.WriteRune(r)
+	}
+	if sb.Len() > 0 {
+		cur.text = sb.String()
+		runs = append(runs, cur)
+	}
+	return runs
+}
+
+// isHangul은 한글 음절·자모인지 반환합니다.
+func isHangul(r rune) bool {
+	return (r >= 0xac00 && r <= 0xd7a3) || (r >= 0x1100 && r <= 0x11ff) || (r >= 0x3130 && r <= 0x318f)
+}
+
+// escapePDFString은 PDF 리터럴 문자열의 특수 문자를 이스케이프합니다.
+func escapePDFString(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, `(`, `\(`, `)`, `\)`)
+	return r.Replace(s)
+}
+
+// utf16Hex는 UCS2 CMap(UniKS·UniJIS·UniGB-UCS2-H)용 UTF-16BE 16진 문자열입니다. BMP 밖 문자는 '?'로 바꿉니다.
+func utf16Hex(s string) string {
+	var sb strings.Builder
+	for _, r := range s {
+		if r > 0xffff {
+			r = '?'
+		}
+		for _, u := range utf16.Encode([]rune{r}) {
+			fmt.Fprintf(&sb, "%04X", u)
+		}
+	}
+	return sb.String()
+}
+
+// textWidth는 문자열 폭(pt)의 근사값입니다. 줄바꿈·정렬에만 사용합니다.
+func textWidth(s string, size float64) float64 {
+	w := 0.0
+	for _, r := range s {
+		if r >= 0x20 && r < 0x7f {
+			w += 0.52 * size
+		} else {
+			w += size
+		}
+	}
+	return w
+}
+
+// wrapText는 문자열을 maxW 폭에 맞게 나눕니다. 공백에서 우선 끊고, 긴 단어는 글자 단위로 끊습니다.
+func wrapText(s string, size, maxW float64) []string {
+	var lines []string
+	for _, para := range strings.Split(s, "\n") {
+		var cur string
+		for _, word := range strings.Fields(para) {
+			next := word
+			if cur != "" {
+				next = cur + " " + word
+			}
+			if textWidth(next, size) <= maxW {
+				cur = next
+				continue
+			}
+			if cur != "" {
+				lines = append(lines, cur)
+				cur = ""
+			}
+			for textWidth(word, size) > maxW {
+				cut := fitRunes(word, size, maxW)
+				lines = append(lines, word[:cut])
+				word = word[cut:]
+			}
+			cur = word
+		}
+		if cur != "" {
+			lines = append(lines, cur)
+		}
+	}
+	return lines
+}
+
+// fitRunes는 maxW 폭에 들어가는 s의 앞부분 바이트 길이입니다 (최소 한 글자).
+func fitRunes(s string, size, maxW float64) int {
+	w := 0.0
+	for i, r := range s {
+		w += textWidth(string(r), size)
+		if w > maxW && i > 0 {
+			return i
+		}
+	}
+	return len(s)
+}
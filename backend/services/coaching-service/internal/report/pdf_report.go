@@ -0,0 +1,186 @@
+package report
+
+import (
+	"fmt"
+)
+
+// PDF 레이아웃 (pt)
+const (
+	pdfMargin   = 48.0
+	pdfContentW = pageW - 2*pdfMargin
+	pdfBottom   = pageH - pdfMargin
+	pdfChartH   = 110.0
+	pdfAxisW    = 36.0
+)
+
+// pdfLayout은 위에서 아래로 내려가며 그리는 커서입니다.
+type pdfLayout struct {
+	doc *pdfDoc
+	y   float64
+}
+
+// need는 h만큼 공간이 없으면 새 페이지로 넘깁니다.
+func (p *pdfLayout) need(h float64) {
+	if p.y+h > pdfBottom {
+		p.doc.addPage()
+		p.y = pdfMargin
+	}
+}
+
+func (p *pdfLayout) heading(s string) {
+	p.need(40)
+	p.y += 24
+	p.doc.text(pdfMargin, p.y, 14, true, colorText, s)
+	p.y += 6
+	p.doc.line(pdfMargin, p.y, pdfMargin+pdfContentW, p.y, 1, colorRule)
+	p.y += 8
+}
+
+// paragraph는 줄바꿈한 문단을 씁니다.
+func (p *pdfLayout) paragraph(s string, size float64, c rgb, indent float64) {
+	for _, ln := range wrapText(s, size, pdfContentW-indent) {
+		p.need(size * 1.5)
+		p.y += size * 1.5
+		p.doc.text(pdfMargin+indent, p.y, size, false, c, ln)
+	}
+}
+
+// bar는 진행률 막대를 그립니다.
+func (p *pdfLayout) bar(pct float64) {
+	p.need(14)
+	p.y += 4
+	p.doc.rect(pdfMargin, p.y, pdfContentW, 8, colorRule)
+	if w := pdfContentW * clampPct(pct) / 100; w > 0 {
+		p.doc.rect(pdfMargin, p.y, w, 8, colorBrand)
+	}
+	p.y += 8
+}
+
+// RenderPDF는 리포트를 A4 PDF 문서로 렌더링합니다.
+func RenderPDF(d *Data) ([]byte, error) {
+	l := labelsFor(d.Language)
+	p := &pdfLayout{doc: newPDFDoc(d.Language), y: pdfMargin}
+
+	p.y += 22
+	p.doc.text(pdfMargin, p.y, 22, true, colorText, l.Title[d.Period])
+	p.y += 18
+	p.doc.text(pdfMargin, p.y, 10, false, colorMuted, fmt.Sprintf("%s: %s - %s   %s: %s",
+		l.Period, d.Start.Format(l.DateFormat), d.End.Format(l.DateFormat), l.Generated, d.GeneratedAt.Format(l.DateFormat)))
+
+	p.heading(l.Adherence)
+	p.bar(d.Adherence.Pct())
+	summary := fmt.Sprintf(l.MeasurementDays, d.Adherence.MeasurementDays, d.Adherence.PeriodDays) +
+		fmt.Sprintf(" (%s%%), ", formatNum(d.Adherence.Pct())) +
+		fmt.Sprintf(l.GoalsSummary, d.Adherence.GoalsActive, d.Adherence.GoalsAchieved)
+	if d.Adherence.BestStreak > 0 {
+		summary += ", " + fmt.Sprintf(l.BestStreak, d.Adherence.BestStreak)
+	}
+	p.paragraph(summary, 10, colorText, 0)
+
+	p.heading(l.Measurements)
+	if len(d.Metrics) == 0 {
+		p.paragraph(l.NoMeasurements, 10, colorMuted, 0)
+	} else {
+		drawMetricTable(p, d, l)
+		for _, m := range d.Metrics {
+			drawMetricChart(p, d, m, l)
+		}
+	}
+
+	p.heading(l.Goals)
+	if len(d.Goals) == 0 {
+		p.paragraph(l.NoGoals, 10, colorMuted, 0)
+	}
+	for _, g := range d.Goals {
+		p.need(32)
+		p.y += 16
+		p.doc.text(pdfMargin, p.y, 10, true, colorText, g.Name+" - "+g.Status)
+		detail := fmt.Sprintf("%s / %s %s  %s%%", formatNum(g.Current), formatNum(g.Target), g.Unit, formatNum(clampPct(g.ProgressPct)))
+		if g.Streak > 0 {
+			detail += "  " + fmt.Sprintf(l.Streak, g.Streak)
+		}
+		p.doc.text(pdfMargin+pdfContentW-textWidth(detail, 9), p.y, 9, false, colorMuted, detail)
+		p.bar(g.ProgressPct)
+	}
+
+	p.heading(l.Insights)
+	if len(d.Insights) == 0 {
+		p.paragraph(l.NoInsights, 10, colorMuted, 0)
+	}
+	for _, s := range d.Insights {
+		p.need(15)
+		p.doc.text(pdfMargin, p.y+15, 10, false, colorBrand, "-")
+		p.paragraph(s, 10, colorText, 12)
+		p.y += 4
+	}
+
+	p.y += 16
+	p.paragraph(l.Disclaimer, 8, colorMuted, 0)
+	return p.doc.render(), nil
+}
+
+// drawMetricTable은 지표별 집계 표를 그립니다.
+func drawMetricTable(p *pdfLayout, d *Data, l labels) {
+	cols := []float64{pdfMargin, pdfMargin + 230, pdfMargin + 290, pdfMargin + 360, pdfMargin + 430}
+	row := func(bold bool, c rgb, cells ...string) {
+		p.need(18)
+		p.y += 15
+		for i, s := range cells {
+			p.doc.text(cols[i], p.y, 9, bold, c, s)
+		}
+		p.y += 3
+		p.doc.line(pdfMargin, p.y, pdfMargin+pdfContentW, p.y, 0.5, colorRule)
+	}
+	row(true, colorMuted, l.Metric, l.Count, l.Average, l.Min, l.Max)
+	for _, m := range d.Metrics {
+		name := m.Name
+		if m.Unit != "" {
+			name += " (" + m.Unit + ")"
+		}
+		row(false, colorText, name, fmt.Sprint(m.Count), formatNum(m.Avg), formatNum(m.Min), formatNum(m.Max))
+	}
+}
+
+// drawMetricChart는 지표의 일별 평균 추이를 선 그래프로 그립니다.
+func drawMetricChart(p *pdfLayout, d *Data, m Metric, l labels) {
+	p.need(pdfChartH + 40)
+	p.y += 22
+	p.doc.text(pdfMargin, p.y, 10, true, colorText, m.Name)
+	p.y += 8
+
+	days := d.Days()
+	lo, hi := chartRange(m)
+	left, top := pdfMargin+pdfAxisW, p.y
+	plotW := pdfContentW - pdfAxisW
+	x := func(i int) float64 {
+		if len(days) <= 1 {
+			return left + plotW/2
+		}
+		return left + plotW*float64(i)/float64(len(days)-1)
+	}
+	y := func(v float64) float64 { return top + pdfChartH*(1-(v-lo)/(hi-lo)) }
+
+	p.doc.rect(left, top, plotW, pdfChartH, colorPanel)
+	for _, v := range []float64{lo, (lo + hi) / 2, hi} {
+		p.doc.line(left, y(v), left+plotW, y(v), 0.5, colorRule)
+		label := formatNum(v)
+		p.doc.text(left-4-textWidth(label, 8), y(v)+3, 8, false, colorMuted, label)
+	}
+
+	index := dayIndex(days)
+	var pts [][2]float64
+	for _, pt := range m.Points {
+		i, ok := index[pt.Date.Format("2006-01-02")]
+		if !ok {
+			continue
+		}
+		pts = append(pts, [2]float64{x(i), y(pt.Value)})
+		p.doc.rect(x(i)-2, y(pt.Value)-2, 4, 4, colorBrand)
+	}
+	p.doc.polyline(pts, 1.5, colorBrand)
+
+	p.y = top + pdfChartH + 12
+	start, end := d.Start.Format(l.DateFormat), d.End.Format(l.DateFormat)
+	p.doc.text(left, p.y, 8, false, colorMuted, start)
+	p.doc.text(left+plotW-textWidth(end, 8), p.y, 8, false, colorMuted, end)
+}
@@ -0,0 +1,97 @@
+// Package report는 주간·월간 건강 리포트를 공유용 HTML과 PDF 문서로 렌더링합니다.
+//
+// 입력은 coaching-service가 집계한 Data이며, 렌더러는 외부 리소스(스크립트·웹 폰트·이미지)를
+// 참조하지 않는 단일 파일을 만듭니다. HTML 차트는 인라인 SVG, PDF 차트는 벡터 도형입니다.
+package report
+
+import (
+	"math"
+	"time"
+)
+
+// Period는 리포트 집계 기간입니다.
+type Period string
+
+const (
+	PeriodWeekly  Period = "weekly"
+	PeriodMonthly Period = "monthly"
+)
+
+// Data는 렌더링할 리포트 내용입니다. 문자열은 Language로 이미 현지화되어 있어야 합니다.
+type Data struct {
+	ReportID    string
+	UserID      string
+	Period      Period
+	Start       time.Time // 기간 첫날 (UTC 자정)
+	End         time.Time // 기간 마지막 날 (포함)
+	Language    string    // "ko", "en", "ja", "zh"
+	GeneratedAt time.Time
+	Metrics     []Metric
+	Goals       []Goal
+	Adherence   Adherence
+	Insights    []string
+}
+
+// Metric은 바이오마커 하나의 기간 집계와 일별 평균 추이입니다.
+type Metric struct {
+	Name   string
+	Unit   string
+	Points []Point // 날짜순 일별 평균
+	Count  int     // 기간 내 측정 수
+	Avg    float64
+	Min    float64
+	Max    float64
+}
+
+// Point는 하루의 평균값입니다.
+type Point struct {
+	Date  time.Time
+	Value float64
+}
+
+// Goal은 건강 목표의 진행 현황입니다.
+type Goal struct {
+	Name        string
+	Unit        string
+	Status      string // 현지화된 상태 이름
+	Current     float64
+	Target      float64
+	ProgressPct float64
+	Streak      int32
+}
+
+// Adherence는 기간 중 실천도입니다.
+type Adherence struct {
+	MeasurementDays int // 측정한 날 수
+	PeriodDays      int // 기간 일수
+	GoalsActive     int
+	GoalsAchieved   int
+	BestStreak      int32 // 진행 중 목표의 최장 연속 기록 일수
+}
+
+// Pct는 측정한 날의 비율(%)입니다.
+func (a Adherence) Pct() float64 {
+	if a.PeriodDays <= 0 {
+		return 0
+	}
+	return math.Round(float64(a.MeasurementDays)/float64(a.PeriodDays)*1000) / 10
+}
+
+// Days는 기간의 날짜 목록입니다.
+func (d *Data) Days() []time.Time {
+	var days []time.Time
+	for t := d.Start; !t.After(d.End); t = t.AddDate(0, 0, 1) {
+		days = append(days, t)
+	}
+	return days
+}
+
+// FileName은 다운로드 파일 이름(확장자 제외)입니다. 예: health-report-weekly-2026-03-02
+func (d *Data) FileName() string {
+	return "health-report-" + string(d.Period) + "-" + d.Start.Format("2006-01-02")
+}
+
+// clampPct는 진행률을 0~100으로 제한합니다.
+func clampPct(p float64) float64 {
+	return math.Max(0, math.Min(100, p))
+}
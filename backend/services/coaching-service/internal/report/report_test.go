@@ -0,0 +1,127 @@
+package report
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func sampleData(lang string) *Data {
+	start := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	return &Data{
+		ReportID:    "r1",
+		UserID:      "u1",
+		Period:      PeriodWeekly,
+		Start:       start,
+		End:         start.AddDate(0, 0, 6),
+		Language:    lang,
+		GeneratedAt: start.AddDate(0, 0, 7),
+		Metrics: []Metric{{
+			Name: "혈당 <glucose>", Unit: "mg/dL", Count: 3, Avg: 101, Min: 95, Max: 110,
+			Points: []Point{{Date: start, Value: 95}, {Date: start.AddDate(0, 0, 2), Value: 98}, {Date: start.AddDate(0, 0, 5), Value: 110}},
+		}},
+		Goals:     []Goal{{Name: "체중 감량", Unit: "kg", Status: "진행 중", Current: 72, Target: 68, ProgressPct: 40, Streak: 3}},
+		Adherence: Adherence{MeasurementDays: 3, PeriodDays: 7, GoalsActive: 1, BestStreak: 3},
+		Insights:  []string{"아침 공복 혈당이 안정적입니다 (평균 101 mg/dL)."},
+	}
+}
+
+func TestRenderHTML(t *testing.T) {
+	out, err := RenderHTML(sampleData("ko"))
+	if err != nil {
+		t.Fatalf("RenderHTML 실패: %v", err)
+	}
+	html := string(out)
+	for _, want := range []string{"주간 건강 리포트", "<svg", "<polyline", "2026.03.02", "체중 감량", "width:40%"} {
+		if !strings.Contains(html, want) {
+			t.Errorf("HTML에 %q 없음", want)
+		}
+	}
+	if strings.Contains(html, "<glucose>") {
+		t.Error("지표 이름이 이스케이프되지 않음")
+	}
+}
+
+func TestRenderHTML_LanguageAndEmpty(t *testing.T) {
+	d := sampleData("en")
+	d.Period, d.Metrics, d.Goals, d.Insights = PeriodMonthly, nil, nil, nil
+	out, err := RenderHTML(d)
+	if err != nil {
+		t.Fatalf("RenderHTML 실패: %v", err)
+	}
+	html := string(out)
+	for _, want := range []string{"Monthly Health Report", `lang="en"`, "No measurements were recorded", "No health goals"} {
+		if !strings.Contains(html, want) {
+			t.Errorf("HTML에 %q 없음", want)
+		}
+	}
+}
+
+func TestRenderPDF(t *testing.T) {
+	out, err := RenderPDF(sampleData("ko"))
+	if err != nil {
+		t.Fatalf("RenderPDF 실패: %v", err)
+	}
+	if !bytes.HasPrefix(out, []byte("%PDF-1.4")) || !bytes.HasSuffix(out, []byte("%%EOF\n")) {
+		t.Fatal("PDF 머리·꼬리가 올바르지 않음")
+	}
+	for _, want := range []string{"/HYSMyeongJo-Medium", "/UniKS-UCS2-H", "xref", utf16Hex("리포트")} {
+		if !bytes.Contains(out, []byte(want)) {
+			t.Errorf("PDF에 %q 없음", want)
+		}
+	}
+	// startxref가 실제 xref 위치를 가리키는지 확인
+	idx := bytes.LastIndex(out, []byte("\nxref\n"))
+	if !bytes.Contains(out, []byte("startxref\n"+strconv.Itoa(idx+1)+"\n")) {
+		t.Error("startxref 오프셋 불일치")
+	}
+}
+
+func TestRenderPDF_PageBreak(t *testing.T) {
+	d := sampleData("en")
+	for i := 0; i < 12; i++ {
+		d.Metrics = append(d.Metrics, d.Metrics[0])
+	}
+	out, err := RenderPDF(d)
+	if err != nil {
+		t.Fatalf("RenderPDF 실패: %v", err)
+	}
+	if n := bytes.Count(out, []byte("/Type /Page ")); n < 2 {
+		t.Errorf("페이지 수 = %d, 여러 페이지를 기대", n)
+	}
+}
+
+func TestRenderPDF_언어별_CJK_폰트(t *testing.T) {
+	ko, _ := RenderPDF(sampleData("ko"))
+	if bytes.Contains(ko, []byte("/F4")) {
+		t.Error("한국어 PDF에는 Korea1 폰트만 있어야 함")
+	}
+	for lang, font := range map[string]string{"ja": "/UniJIS-UCS2-H", "zh": "/UniGB-UCS2-H"} {
+		out, err := RenderPDF(sampleData(lang))
+		if err != nil {
+			t.Fatalf("%s RenderPDF 실패: %v", lang, err)
+		}
+		// 한자·가나는 언어별 폰트(F4), 한글은 Korea1 폰트(F3)
+		title := utf16Hex(labelsFor(lang).Title[PeriodWeekly])
+		for _, want := range []string{font, "/UniKS-UCS2-H", "/F4 22.0 Tf <" + title + ">", "/F3 10.0 Tf <" + utf16Hex("체중") + ">"} {
+			if !bytes.Contains(out, []byte(want)) {
+				t.Errorf("%s PDF에 %q 없음", lang, want)
+			}
+		}
+	}
+}
+
+func TestSplitRunsAndEscape(t *testing.T) {
+	runs := splitRuns("HbA1c 당화혈색소 (%)")
+	if len(runs) != 3 || !runs[0].latin || runs[1].latin || runs[1].text != "당화혈색소" || runs[2].text != " (%)" {
+		t.Fatalf("splitRuns = %+v", runs)
+	}
+	if runs := splitRuns("体重 체중"); len(runs) != 3 || runs[0].hangul || !runs[2].hangul {
+		t.Fatalf("한자·한글 구간 분리 = %+v", runs)
+	}
+	if got := escapePDFString(`a(b)\c`); got != `a\(b\)\\c` {
+		t.Errorf("escapePDFString = %q", got)
+	}
+}
@@ -0,0 +1,87 @@
+// Package kafka는 Kafka 기반 목표 마일스톤 발행기와 측정·식사 이벤트 소비 핸들러를 제공합니다.
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/manpasik/backend/services/coaching-service/internal/service"
+	"github.com/manpasik/backend/shared/events"
+)
+
+// EventPublisher는 Kafka를 사용하는 이벤트 발행기입니다.
+type EventPublisher struct {
+	eventBus *events.KafkaEventBus
+}
+
+// NewEventPublisher는 Kafka 기반 EventPublisher를 생성합니다.
+func NewEventPublisher(eventBus *events.KafkaEventBus) *EventPublisher {
+	return &EventPublisher{eventBus: eventBus}
+}
+
+// PublishGoalMilestone은 목표 마일스톤 이벤트를 Kafka에 발행합니다.
+// notification-service는 사용자 알림을 보내고, community-service는 참여 중인 챌린지 진행률에 반영합니다.
+func (p *EventPublisher) PublishGoalMilestone(ctx context.Context, m *service.GoalMilestone) error {
+	payload := map[string]interface{}{
+		"goal_id":       m.GoalID,
+		"category":      goalCategoryName(m.Category),
+		"metric_name":   m.MetricName,
+		"kind":          string(m.Kind),
+		"milestone":     m.Milestone,
+		"progress_pct":  m.ProgressPct,
+		"current_value": m.CurrentValue,
+		"target_value":  m.TargetValue,
+		"unit":          m.Unit,
+		"streak":        m.Streak,
+		"occurred_at":   m.OccurredAt.Format(time.RFC3339),
+	}
+
+	payloadBytes, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("이벤트 페이로드 직렬화 실패: %w", err)
+	}
+
+	kafkaEvent := events.Event{
+		Type: events.EventCoachingGoalMilestone,
+		Payload: map[string]interface{}{
+			"event_id":   uuid.New().String(),
+			"event_type": "manpasik." + events.EventCoachingGoalMilestone,
+			"version":    "1.0",
+			"timestamp":  time.Now().UTC().Format(time.RFC3339),
+			"source":     "coaching-service",
+			"user_id":    m.UserID,
+			"payload":    json.RawMessage(payloadBytes),
+		},
+	}
+
+	return p.eventBus.Publish(ctx, kafkaEvent)
+}
+
+// goalCategoryName은 이벤트 페이로드에 싣는 목표 카테고리 이름입니다.
+func goalCategoryName(c service.GoalCategory) string {
+	switch c {
+	case service.GoalCategoryBloodGlucose:
+		return "blood_glucose"
+	case service.GoalCategoryBloodPressure:
+		return "blood_pressure"
+	case service.GoalCategoryCholesterol:
+		return "cholesterol"
+	case service.GoalCategoryWeight:
+		return "weight"
+	case service.GoalCategoryExercise:
+		return "exercise"
+	case service.GoalCategoryNutrition:
+		return "nutrition"
+	case service.GoalCategorySleep:
+		return "sleep"
+	case service.GoalCategoryStress:
+		return "stress"
+	case service.GoalCategoryCustom:
+		return "custom"
+	default:
+		return "unknown"
+	}
+}
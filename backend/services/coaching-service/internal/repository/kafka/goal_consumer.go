@@ -0,0 +1,63 @@
+package kafka
+
+import (
+	"context"
+	"time"
+
+	"github.com/manpasik/backend/services/coaching-service/internal/service"
+	"github.com/manpasik/backend/shared/events"
+)
+
+// GoalProgressProcessor는 측정·식사 기록을 건강 목표 진행에 반영하는 대상입니다.
+type GoalProgressProcessor interface {
+	ProcessMeasurementCompleted(ctx context.Context, userID, sessionID string) ([]*service.HealthGoal, error)
+	ProcessMealLogged(ctx context.Context, meal *service.MealLog) ([]*service.HealthGoal, error)
+}
+
+// NewMeasurementCompletedHandler는 measurement.completed 이벤트를 받아
+// 해당 측정값을 사용자 목표 진행에 반영하는 핸들러를 반환합니다.
+func NewMeasurementCompletedHandler(processor GoalProgressProcessor) events.Handler {
+	return func(ctx context.Context, event events.Event) error {
+		payload := events.PayloadOf(event)
+		sessionID, _ := payload["session_id"].(string)
+		if sessionID == "" {
+			return nil
+		}
+		userID, _ := payload["user_id"].(string)
+		_, err := processor.ProcessMeasurementCompleted(ctx, userID, sessionID)
+		return err
+	}
+}
+
+// NewMealAnalyzedHandler는 vision.meal_analyzed 이벤트를 받아
+// 식사 칼로리·영양소 섭취량을 영양 목표 진행에 반영하는 핸들러를 반환합니다.
+func NewMealAnalyzedHandler(processor GoalProgressProcessor) events.Handler {
+	return func(ctx context.Context, event events.Event) error {
+		payload := events.PayloadOf(event)
+		userID, _ := payload["user_id"].(string)
+		if userID == "" {
+			userID, _ = event.Payload["user_id"].(string)
+		}
+		if userID == "" {
+			return nil
+		}
+
+		meal := &service.MealLog{UserID: userID, Nutrients: make(map[string]float64)}
+		meal.AnalysisID, _ = payload["analysis_id"].(string)
+		meal.MealType, _ = payload["meal_type"].(string)
+		meal.TotalCalorieKcal, _ = payload["total_calorie_kcal"].(float64)
+		if nutrients, ok := payload["nutrients"].(map[string]interface{}); ok {
+			for name, v := range nutrients {
+				if amount, ok := v.(float64); ok {
+					meal.Nutrients[name] = amount
+				}
+			}
+		}
+		if s, ok := payload["analyzed_at"].(string); ok {
+			meal.AnalyzedAt, _ = time.Parse(time.RFC3339, s)
+		}
+
+		_, err := processor.ProcessMealLogged(ctx, meal)
+		return err
+	}
+}
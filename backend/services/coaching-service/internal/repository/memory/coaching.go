@@ -18,13 +18,16 @@ type HealthGoalRepository struct {
 	mu    sync.RWMutex
 	byID  map[string]*service.HealthGoal
 	byUID map[string][]*service.HealthGoal // key: userID
+	// sources는 목표별로 반영한 기록 출처입니다 (key: goalID + "/" + sourceID).
+	sources map[string]bool
 }
 
 // NewHealthGoalRepository는 인메모리 HealthGoalRepository를 생성합니다.
 func NewHealthGoalRepository() *HealthGoalRepository {
 	return &HealthGoalRepository{
-		byID:  make(map[string]*service.HealthGoal),
-		byUID: make(map[string][]*service.HealthGoal),
+		byID:    make(map[string]*service.HealthGoal),
+		byUID:   make(map[string][]*service.HealthGoal),
+		sources: make(map[string]bool),
 	}
 }
 
@@ -75,6 +78,43 @@ func (r *HealthGoalRepository) GetByID(_ context.Context, id string) (*service.H
 func (r *HealthGoalRepository) Update(_ context.Context, goal *service.HealthGoal) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
+	r.store(goal)
+	return nil
+}
+
+// ApplyProgress는 기록 출처를 목표별로 한 번만 반영해 진행 상태를 저장합니다.
+func (r *HealthGoalRepository) ApplyProgress(_ context.Context, goal *service.HealthGoal, sourceID string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if g, ok := r.byID[goal.GoalID]; !ok || g.Status != service.GoalStatusActive {
+		return false, nil
+	}
+	if sourceID != "" {
+		key := goal.GoalID + "/" + sourceID
+		if r.sources[key] {
+			return false, nil
+		}
+		r.sources[key] = true
+	}
+	r.store(goal)
+	return true, nil
+}
+
+// CloseIfActive는 진행 중인 목표만 마감 상태로 저장합니다.
+func (r *HealthGoalRepository) CloseIfActive(_ context.Context, goal *service.HealthGoal) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if g, ok := r.byID[goal.GoalID]; !ok || g.Status != service.GoalStatusActive {
+		return false, nil
+	}
+	r.store(goal)
+	return true, nil
+}
+
+// store는 목표를 저장합니다. 호출자가 잠금을 보유해야 합니다.
+func (r *HealthGoalRepository) store(goal *service.HealthGoal) {
 	cp := *goal
 	r.byID[goal.GoalID] = &cp
 
@@ -86,7 +126,22 @@ func (r *HealthGoalRepository) Update(_ context.Context, goal *service.HealthGoa
 			break
 		}
 	}
-	return nil
+}
+
+// ListActiveDue는 목표일이 before 이전(포함)인 진행 중 목표를 반환합니다.
+func (r *HealthGoalRepository) ListActiveDue(_ context.Context, before time.Time) ([]*service.HealthGoal, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var result []*service.HealthGoal
+	for _, g := range r.byID {
+		if g.Status != service.GoalStatusActive || g.TargetDate.IsZero() || g.TargetDate.After(before) {
+			continue
+		}
+		cp := *g
+		result = append(result, &cp)
+	}
+	return result, nil
 }
 
 // ============================================================================
@@ -240,3 +295,42 @@ func copyDailyReport(src *service.DailyHealthReport) *service.DailyHealthReport
 	}
 	return &cp
 }
+
+// ============================================================================
+// ReportExportRepository — 인메모리 리포트 내보내기 기록 저장소
+// ============================================================================
+
+// ReportExportRepository는 인메모리 리포트 내보내기 기록 저장소입니다.
+type ReportExportRepository struct {
+	mu      sync.RWMutex
+	exports map[string]*service.ReportExport
+}
+
+// NewReportExportRepository는 인메모리 ReportExportRepository를 생성합니다.
+func NewReportExportRepository() *ReportExportRepository {
+	return &ReportExportRepository{
+		exports: make(map[string]*service.ReportExport),
+	}
+}
+
+// Save는 리포트 내보내기 기록을 저장합니다. 다운로드 링크는 저장하지 않습니다.
+func (r *ReportExportRepository) Save(_ context.Context, export *service.ReportExport) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	cp := *export
+	cp.HTMLURL, cp.PDFURL, cp.URLExpiresAt = "", "", time.Time{}
+	r.exports[export.ExportID] = &cp
+	return nil
+}
+
+// GetByID는 리포트 내보내기 기록을 조회합니다.
+func (r *ReportExportRepository) GetByID(_ context.Context, exportID string) (*service.ReportExport, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	export, ok := r.exports[exportID]
+	if !ok {
+		return nil, nil
+	}
+	cp := *export
+	return &cp, nil
+}
@@ -0,0 +1,30 @@
+// Package objectstore는 S3 호환 오브젝트 스토리지 기반 리포트 파일 저장소입니다.
+package objectstore
+
+import (
+	"bytes"
+	"context"
+	"time"
+
+	"github.com/manpasik/backend/shared/storage"
+)
+
+// ReportStore는 service.ReportFileStore의 S3 구현입니다.
+type ReportStore struct {
+	client *storage.S3Client
+}
+
+// NewReportStore는 S3 클라이언트 기반 리포트 파일 저장소를 생성합니다.
+func NewReportStore(client *storage.S3Client) *ReportStore {
+	return &ReportStore{client: client}
+}
+
+// Put은 리포트 파일을 업로드합니다.
+func (s *ReportStore) Put(ctx context.Context, key string, data []byte, contentType string) error {
+	return s.client.Upload(ctx, key, bytes.NewReader(data), int64(len(data)), contentType)
+}
+
+// PresignURL은 리포트 파일 다운로드용 presigned URL을 발급합니다.
+func (s *ReportStore) PresignURL(ctx context.Context, key string, expiry time.Duration) (string, error) {
+	return s.client.GetPresignedURL(ctx, key, expiry)
+}
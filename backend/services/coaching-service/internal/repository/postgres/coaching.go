@@ -8,6 +8,7 @@ import (
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/manpasik/backend/services/coaching-service/internal/report"
 	"github.com/manpasik/backend/services/coaching-service/internal/service"
 )
 
@@ -55,13 +56,11 @@ func (r *HealthGoalRepository) GetByUserID(ctx context.Context, userID string, s
 	var err error
 
 	if statusFilter == service.GoalStatusUnknown {
-		const q = `SELECT id, user_id, category, metric_name, target_value, current_value, unit,
-			progress_pct, status, COALESCE(description, ''), target_date, achieved_at, created_at
+		const q = `SELECT ` + goalColumns + `
 			FROM health_goals WHERE user_id = $1 ORDER BY created_at DESC`
 		rows, err = r.pool.Query(ctx, q, userID)
 	} else {
-		const q = `SELECT id, user_id, category, metric_name, target_value, current_value, unit,
-			progress_pct, status, COALESCE(description, ''), target_date, achieved_at, created_at
+		const q = `SELECT ` + goalColumns + `
 			FROM health_goals WHERE user_id = $1 AND status = $2 ORDER BY created_at DESC`
 		rows, err = r.pool.Query(ctx, q, userID, goalStatusToString(statusFilter))
 	}
@@ -83,55 +82,125 @@ func (r *HealthGoalRepository) GetByUserID(ctx context.Context, userID string, s
 
 // GetByID는 목표 ID로 건강 목표를 조회합니다.
 func (r *HealthGoalRepository) GetByID(ctx context.Context, id string) (*service.HealthGoal, error) {
-	const q = `SELECT id, user_id, category, metric_name, target_value, current_value, unit,
-		progress_pct, status, COALESCE(description, ''), target_date, achieved_at, created_at
+	const q = `SELECT ` + goalColumns + `
 		FROM health_goals WHERE id = $1`
 
-	var g service.HealthGoal
-	var cat, status string
-	err := r.pool.QueryRow(ctx, q, id).Scan(
-		&g.GoalID, &g.UserID, &cat, &g.MetricName,
-		&g.TargetValue, &g.CurrentValue, &g.Unit,
-		&g.ProgressPct, &status, &g.Description,
-		&g.TargetDate, &g.AchievedAt, &g.CreatedAt,
-	)
+	g, err := scanGoal(r.pool.QueryRow(ctx, q, id))
 	if err != nil {
 		if err == pgx.ErrNoRows {
 			return nil, nil
 		}
 		return nil, err
 	}
-	g.Category = goalCategoryFromString(cat)
-	g.Status = goalStatusFromString(status)
-	return &g, nil
+	return g, nil
 }
 
+const updateGoalSQL = `UPDATE health_goals SET
+		current_value = $1, progress_pct = $2, status = $3, description = $4,
+		target_date = $5, achieved_at = $6, start_value = $7, streak = $8,
+		last_progress_at = $9, last_milestone = $10, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $11`
+
 // Update는 건강 목표를 업데이트합니다.
 func (r *HealthGoalRepository) Update(ctx context.Context, goal *service.HealthGoal) error {
-	const q = `UPDATE health_goals SET
-		current_value = $1, progress_pct = $2, status = $3, description = $4,
-		target_date = $5, achieved_at = $6, updated_at = CURRENT_TIMESTAMP
-		WHERE id = $7`
-	_, err := r.pool.Exec(ctx, q,
+	_, err := r.pool.Exec(ctx, updateGoalSQL, goalUpdateArgs(goal)...)
+	return err
+}
+
+func goalUpdateArgs(goal *service.HealthGoal) []interface{} {
+	return []interface{}{
 		goal.CurrentValue,
 		goal.ProgressPct,
 		goalStatusToString(goal.Status),
 		goal.Description,
 		goal.TargetDate,
 		goal.AchievedAt,
+		goal.StartValue,
+		goal.Streak,
+		goal.LastProgressAt,
+		goal.LastMilestone,
 		goal.GoalID,
-	)
-	return err
+	}
+}
+
+// ApplyProgress는 기록 출처를 목표별로 한 번만 반영해 진행 상태를 저장합니다.
+// 출처 기록과 목표 갱신을 한 트랜잭션으로 처리해, 갱신이 실패하면 출처도 남기지 않습니다.
+func (r *HealthGoalRepository) ApplyProgress(ctx context.Context, goal *service.HealthGoal, sourceID string) (bool, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback(ctx)
+
+	if sourceID != "" {
+		const mark = `INSERT INTO goal_progress_sources (goal_id, source_id)
+			VALUES ($1, $2) ON CONFLICT DO NOTHING`
+		tag, err := tx.Exec(ctx, mark, goal.GoalID, sourceID)
+		if err != nil {
+			return false, err
+		}
+		if tag.RowsAffected() == 0 {
+			return false, nil
+		}
+	}
+
+	tag, err := tx.Exec(ctx, updateGoalSQL+` AND status = 'ACTIVE'`, goalUpdateArgs(goal)...)
+	if err != nil {
+		return false, err
+	}
+	if tag.RowsAffected() == 0 {
+		return false, nil
+	}
+	return true, tx.Commit(ctx)
+}
+
+// CloseIfActive는 진행 중인 목표만 마감 상태로 저장합니다.
+// 조건부 갱신이므로 여러 레플리카가 같은 목표를 마감해도 한 곳만 성공합니다.
+func (r *HealthGoalRepository) CloseIfActive(ctx context.Context, goal *service.HealthGoal) (bool, error) {
+	tag, err := r.pool.Exec(ctx, updateGoalSQL+` AND status = 'ACTIVE'`, goalUpdateArgs(goal)...)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+// ListActiveDue는 목표일이 before 이전(포함)인 진행 중 목표를 반환합니다.
+// 목표일 미설정(zero time)으로 저장된 목표는 제외합니다.
+func (r *HealthGoalRepository) ListActiveDue(ctx context.Context, before time.Time) ([]*service.HealthGoal, error) {
+	const q = `SELECT ` + goalColumns + `
+		FROM health_goals
+		WHERE status = 'ACTIVE' AND target_date > $1 AND target_date <= $2
+		ORDER BY target_date`
+	rows, err := r.pool.Query(ctx, q, time.Time{}, before)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var goals []*service.HealthGoal
+	for rows.Next() {
+		g, err := scanGoal(rows)
+		if err != nil {
+			return nil, err
+		}
+		goals = append(goals, g)
+	}
+	return goals, rows.Err()
 }
 
-func scanGoal(rows pgx.Rows) (*service.HealthGoal, error) {
+const goalColumns = `id, user_id, category, metric_name, target_value, current_value, unit,
+	progress_pct, status, COALESCE(description, ''), target_date, achieved_at, created_at,
+	start_value, streak, last_progress_at, last_milestone`
+
+func scanGoal(row pgx.Row) (*service.HealthGoal, error) {
 	var g service.HealthGoal
 	var cat, status string
-	if err := rows.Scan(
+	if err := row.Scan(
 		&g.GoalID, &g.UserID, &cat, &g.MetricName,
 		&g.TargetValue, &g.CurrentValue, &g.Unit,
 		&g.ProgressPct, &status, &g.Description,
 		&g.TargetDate, &g.AchievedAt, &g.CreatedAt,
+		&g.StartValue, &g.Streak, &g.LastProgressAt, &g.LastMilestone,
 	); err != nil {
 		return nil, err
 	}
@@ -162,8 +231,8 @@ func (r *CoachingMessageRepository) Save(ctx context.Context, msg *service.Coach
 	}
 
 	const q = `INSERT INTO coaching_messages
-		(id, user_id, coaching_type, title, body, risk_level, action_items, related_metric, related_value, created_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`
+		(id, user_id, coaching_type, title, body, risk_level, action_items, related_metric, related_value, created_at, strategy, language)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)`
 	_, err = r.pool.Exec(ctx, q,
 		msg.MessageID,
 		msg.UserID,
@@ -175,6 +244,8 @@ func (r *CoachingMessageRepository) Save(ctx context.Context, msg *service.Coach
 		msg.RelatedMetric,
 		msg.RelatedValue,
 		msg.CreatedAt,
+		string(msg.Strategy),
+		msg.Language,
 	)
 	return err
 }
@@ -199,13 +270,13 @@ func (r *CoachingMessageRepository) ListByUserID(ctx context.Context, userID str
 	var err error
 	if typeFilter == service.CoachingTypeUnknown {
 		const q = `SELECT id, user_id, coaching_type, title, body, risk_level, action_items,
-			COALESCE(related_metric, ''), related_value, created_at
+			COALESCE(related_metric, ''), related_value, created_at, strategy, language
 			FROM coaching_messages WHERE user_id = $1
 			ORDER BY created_at DESC LIMIT $2 OFFSET $3`
 		rows, err = r.pool.Query(ctx, q, userID, limit, offset)
 	} else {
 		const q = `SELECT id, user_id, coaching_type, title, body, risk_level, action_items,
-			COALESCE(related_metric, ''), related_value, created_at
+			COALESCE(related_metric, ''), related_value, created_at, strategy, language
 			FROM coaching_messages WHERE user_id = $1 AND coaching_type = $2
 			ORDER BY created_at DESC LIMIT $3 OFFSET $4`
 		rows, err = r.pool.Query(ctx, q, userID, coachingTypeToString(typeFilter), limit, offset)
@@ -221,14 +292,17 @@ func (r *CoachingMessageRepository) ListByUserID(ctx context.Context, userID str
 		var ct string
 		var rl int32
 		var actionJSON []byte
+		var strategy string
 		if err := rows.Scan(
 			&msg.MessageID, &msg.UserID, &ct, &msg.Title, &msg.Body,
 			&rl, &actionJSON, &msg.RelatedMetric, &msg.RelatedValue, &msg.CreatedAt,
+			&strategy, &msg.Language,
 		); err != nil {
 			return nil, 0, err
 		}
 		msg.CoachingType = coachingTypeFromString(ct)
 		msg.RiskLevel = service.RiskLevel(rl)
+		msg.Strategy = service.CoachingStrategy(strategy)
 		_ = json.Unmarshal(actionJSON, &msg.ActionItems)
 		messages = append(messages, &msg)
 	}
@@ -406,6 +480,8 @@ func goalStatusToString(s service.GoalStatus) string {
 		return "PAUSED"
 	case service.GoalStatusCancelled:
 		return "CANCELLED"
+	case service.GoalStatusFailed:
+		return "FAILED"
 	default:
 		return "ACTIVE"
 	}
@@ -421,6 +497,8 @@ func goalStatusFromString(s string) service.GoalStatus {
 		return service.GoalStatusPaused
 	case "CANCELLED":
 		return service.GoalStatusCancelled
+	case "FAILED":
+		return service.GoalStatusFailed
 	default:
 		return service.GoalStatusUnknown
 	}
@@ -463,3 +541,57 @@ func coachingTypeFromString(s string) service.CoachingType {
 		return service.CoachingTypeUnknown
 	}
 }
+
+// ============================================================================
+// ReportExportRepository — PostgreSQL 기반
+// ============================================================================
+
+// ReportExportRepository는 PostgreSQL 기반 리포트 내보내기 기록 저장소입니다.
+type ReportExportRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewReportExportRepository는 PostgreSQL ReportExportRepository를 생성합니다.
+func NewReportExportRepository(pool *pgxpool.Pool) *ReportExportRepository {
+	return &ReportExportRepository{pool: pool}
+}
+
+// Save는 리포트 내보내기 기록을 저장합니다.
+func (r *ReportExportRepository) Save(ctx context.Context, export *service.ReportExport) error {
+	const q = `INSERT INTO report_exports
+		(id, user_id, period, period_start, period_end, language, html_key, pdf_key, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+	_, err := r.pool.Exec(ctx, q,
+		export.ExportID,
+		export.UserID,
+		string(export.Period),
+		export.PeriodStart,
+		export.PeriodEnd,
+		export.Language,
+		export.HTMLKey,
+		export.PDFKey,
+		export.CreatedAt,
+	)
+	return err
+}
+
+// GetByID는 리포트 내보내기 기록을 조회합니다.
+func (r *ReportExportRepository) GetByID(ctx context.Context, exportID string) (*service.ReportExport, error) {
+	const q = `SELECT id, user_id, period, period_start, period_end, language, html_key, pdf_key, created_at
+		FROM report_exports WHERE id = $1`
+	var export service.ReportExport
+	var period string
+	err := r.pool.QueryRow(ctx, q, exportID).Scan(
+		&export.ExportID, &export.UserID, &period,
+		&export.PeriodStart, &export.PeriodEnd, &export.Language,
+		&export.HTMLKey, &export.PDFKey, &export.CreatedAt,
+	)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	export.Period = report.Period(period)
+	return &export, nil
+}
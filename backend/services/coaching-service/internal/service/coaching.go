@@ -9,7 +9,9 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/manpasik/backend/shared/clients"
 	apperrors "github.com/manpasik/backend/shared/errors"
+	"github.com/manpasik/backend/shared/scheduler"
 	"go.uber.org/zap"
 )
 
@@ -42,6 +44,7 @@ const (
 	GoalStatusAchieved  GoalStatus = 2
 	GoalStatusPaused    GoalStatus = 3
 	GoalStatusCancelled GoalStatus = 4
+	GoalStatusFailed    GoalStatus = 5 // 목표일까지 달성하지 못함
 )
 
 // CoachingType은 코칭 메시지 유형입니다.
@@ -95,6 +98,12 @@ type HealthGoal struct {
 	CreatedAt   time.Time
 	TargetDate  time.Time
 	AchievedAt  *time.Time
+
+	// 이벤트 기반 진행 추적 (측정·식사 기록)
+	StartValue     float64    // 목표 설정 후 첫 기록 값 (수치 목표의 진행률 기준)
+	Streak         int32      // 기록이 이어진 연속 일수
+	LastProgressAt *time.Time // 마지막으로 반영한 기록 시각
+	LastMilestone  int32      // 마지막으로 알린 진행률 구간 (25, 50, 75, 100)
 }
 
 // CoachingMessage는 코칭 메시지 엔티티입니다.
@@ -109,6 +118,8 @@ type CoachingMessage struct {
 	RelatedMetric string
 	RelatedValue  float64
 	CreatedAt     time.Time
+	Strategy      CoachingStrategy // 본문 생성 방식 (LLM·템플릿·규칙)
+	Language      string           // 메시지 언어 ("ko", "en")
 }
 
 // DailyHealthReport는 일일 건강 리포트입니다.
@@ -162,6 +173,15 @@ type HealthGoalRepository interface {
 	GetByUserID(ctx context.Context, userID string, statusFilter GoalStatus) ([]*HealthGoal, error)
 	GetByID(ctx context.Context, id string) (*HealthGoal, error)
 	Update(ctx context.Context, goal *HealthGoal) error
+	// ListActiveDue는 목표일이 before 이전(포함)인 진행 중 목표를 반환합니다.
+	ListActiveDue(ctx context.Context, before time.Time) ([]*HealthGoal, error)
+	// ApplyProgress는 기록 출처(sourceID)를 목표별로 한 번만 반영해 진행 상태를 저장합니다.
+	// 같은 출처가 이미 반영됐거나 목표가 더 이상 진행 중이 아니면 저장하지 않고 false를 반환합니다.
+	// sourceID가 비어 있으면 중복 검사 없이 저장합니다.
+	ApplyProgress(ctx context.Context, goal *HealthGoal, sourceID string) (bool, error)
+	// CloseIfActive는 진행 중인 목표만 마감 상태로 저장합니다.
+	// 다른 레플리카가 먼저 마감했으면 false를 반환합니다.
+	CloseIfActive(ctx context.Context, goal *HealthGoal) (bool, error)
 }
 
 // CoachingMessageRepository는 코칭 메시지 저장소 인터페이스입니다.
@@ -183,11 +203,19 @@ type DailyReportRepository interface {
 
 // CoachingService는 AI 건강 코칭 비즈니스 로직입니다.
 type CoachingService struct {
-	logger      *zap.Logger
-	goalRepo    HealthGoalRepository
-	msgRepo     CoachingMessageRepository
-	reportRepo  DailyReportRepository
-	rng         *rand.Rand
+	logger       *zap.Logger
+	goalRepo     HealthGoalRepository
+	msgRepo      CoachingMessageRepository
+	reportRepo   DailyReportRepository
+	rng          *rand.Rand
+	measurements clients.MeasurementClient      // optional: nil이면 측정 이벤트로 목표 진행 미반영
+	goalEvents   GoalEventPublisher             // optional: nil이면 마일스톤 이벤트 미발행
+	composer     clients.CoachingComposerClient // optional: nil이면 템플릿·규칙 기반 메시지만 생성
+	profiles     clients.UserProfileClient      // optional: nil이면 요청 언어 또는 기본 언어 사용
+	scheduler    *scheduler.Scheduler           // optional: nil이면 일일 팁·리포트 예약 발송 비활성화
+	notifier     clients.NotificationClient     // optional: nil이면 예약 코칭을 생성만 하고 알림 미발송
+	reportFiles  ReportFileStore                // optional: nil이면 리포트 내보내기 비활성화
+	exportRepo   ReportExportRepository
 }
 
 // NewCoachingService는 새 CoachingService를 생성합니다.
@@ -251,6 +279,7 @@ func (s *CoachingService) SetHealthGoal(ctx context.Context, userID string, cate
 		zap.String("goal_id", goal.GoalID),
 		zap.Int32("category", int32(category)),
 	)
+	s.ensureDailyCoaching(ctx, userID)
 	return goal, nil
 }
 
@@ -278,7 +307,9 @@ func (s *CoachingService) GetHealthGoals(ctx context.Context, userID string, sta
 // ============================================================================
 
 // GenerateCoaching은 AI 코칭 메시지를 생성합니다.
-func (s *CoachingService) GenerateCoaching(ctx context.Context, userID, measurementID string, coachingType CoachingType) (*CoachingMessage, error) {
+// 규칙 기반 초안을 만든 뒤 composeMessage로 사용자 목표·최근 측정·실천도와 언어에 맞게 개인화합니다.
+// language가 비어 있으면 사용자 프로필 언어를 사용합니다.
+func (s *CoachingService) GenerateCoaching(ctx context.Context, userID, measurementID string, coachingType CoachingType, language string) (*CoachingMessage, error) {
 	if userID == "" {
 		return nil, apperrors.New(apperrors.ErrInvalidInput, "user_id는 필수입니다")
 	}
@@ -309,6 +340,7 @@ func (s *CoachingService) GenerateCoaching(ctx context.Context, userID, measurem
 	default:
 		msg = s.generateDailyTip(userID)
 	}
+	s.composeMessage(ctx, msg, s.buildCoachingContext(ctx, userID, language))
 
 	if err := s.msgRepo.Save(ctx, msg); err != nil {
 		s.logger.Error("코칭 메시지 저장 실패", zap.Error(err))
@@ -319,6 +351,7 @@ func (s *CoachingService) GenerateCoaching(ctx context.Context, userID, measurem
 		zap.String("user_id", userID),
 		zap.String("message_id", msg.MessageID),
 		zap.Int32("coaching_type", int32(coachingType)),
+		zap.String("strategy", string(msg.Strategy)),
 	)
 	return msg, nil
 }
@@ -686,8 +719,7 @@ func (s *CoachingService) generateGoalProgress(ctx context.Context, userID strin
 	}
 
 	goal := goals[s.rng.Intn(len(goals))]
-	// 시뮬레이션: 목표 진행률 업데이트
-	progress := math.Round(s.rng.Float64() * 100 * 10) / 10
+	progress := math.Round(goal.ProgressPct*10) / 10
 
 	var body string
 	risk := RiskLevelLow
@@ -13,11 +13,12 @@ import (
 // ============================================================================
 
 type fakeGoalRepo struct {
-	goals map[string]*HealthGoal // key: goalID
+	goals   map[string]*HealthGoal // key: goalID
+	sources map[string]bool        // key: goalID/sourceID
 }
 
 func newFakeGoalRepo() *fakeGoalRepo {
-	return &fakeGoalRepo{goals: make(map[string]*HealthGoal)}
+	return &fakeGoalRepo{goals: make(map[string]*HealthGoal), sources: make(map[string]bool)}
 }
 
 func (r *fakeGoalRepo) Create(_ context.Context, goal *HealthGoal) error {
@@ -52,6 +53,38 @@ func (r *fakeGoalRepo) Update(_ context.Context, goal *HealthGoal) error {
 	return nil
 }
 
+func (r *fakeGoalRepo) ApplyProgress(_ context.Context, goal *HealthGoal, sourceID string) (bool, error) {
+	if g, ok := r.goals[goal.GoalID]; !ok || g.Status != GoalStatusActive {
+		return false, nil
+	}
+	if sourceID != "" {
+		if r.sources[goal.GoalID+"/"+sourceID] {
+			return false, nil
+		}
+		r.sources[goal.GoalID+"/"+sourceID] = true
+	}
+	r.goals[goal.GoalID] = goal
+	return true, nil
+}
+
+func (r *fakeGoalRepo) CloseIfActive(_ context.Context, goal *HealthGoal) (bool, error) {
+	if g, ok := r.goals[goal.GoalID]; !ok || g.Status != GoalStatusActive {
+		return false, nil
+	}
+	r.goals[goal.GoalID] = goal
+	return true, nil
+}
+
+func (r *fakeGoalRepo) ListActiveDue(_ context.Context, before time.Time) ([]*HealthGoal, error) {
+	var result []*HealthGoal
+	for _, g := range r.goals {
+		if g.Status == GoalStatusActive && !g.TargetDate.IsZero() && !g.TargetDate.After(before) {
+			result = append(result, g)
+		}
+	}
+	return result, nil
+}
+
 type fakeMsgRepo struct {
 	messages []*CoachingMessage
 }
@@ -282,7 +315,7 @@ func TestGenerateCoaching_MeasurementFeedback(t *testing.T) {
 	svc, _, _, _ := newTestCoachingService()
 	ctx := context.Background()
 
-	msg, err := svc.GenerateCoaching(ctx, "user-feedback", "msmt-001", CoachingTypeMeasurementFeedback)
+	msg, err := svc.GenerateCoaching(ctx, "user-feedback", "msmt-001", CoachingTypeMeasurementFeedback, "")
 	if err != nil {
 		t.Fatalf("GenerateCoaching(MeasurementFeedback) 실패: %v", err)
 	}
@@ -323,7 +356,7 @@ func TestGenerateCoaching_DailyTip(t *testing.T) {
 	svc, _, _, _ := newTestCoachingService()
 	ctx := context.Background()
 
-	msg, err := svc.GenerateCoaching(ctx, "user-tip", "", CoachingTypeDailyTip)
+	msg, err := svc.GenerateCoaching(ctx, "user-tip", "", CoachingTypeDailyTip, "")
 	if err != nil {
 		t.Fatalf("GenerateCoaching(DailyTip) 실패: %v", err)
 	}
@@ -352,7 +385,7 @@ func TestGenerateCoaching_Motivation(t *testing.T) {
 	svc, _, _, _ := newTestCoachingService()
 	ctx := context.Background()
 
-	msg, err := svc.GenerateCoaching(ctx, "user-motivation", "", CoachingTypeMotivation)
+	msg, err := svc.GenerateCoaching(ctx, "user-motivation", "", CoachingTypeMotivation, "")
 	if err != nil {
 		t.Fatalf("GenerateCoaching(Motivation) 실패: %v", err)
 	}
@@ -370,7 +403,7 @@ func TestGenerateCoaching_Motivation(t *testing.T) {
 	}
 
 	// user_id 누락 시 에러
-	_, err = svc.GenerateCoaching(ctx, "", "", CoachingTypeMotivation)
+	_, err = svc.GenerateCoaching(ctx, "", "", CoachingTypeMotivation, "")
 	if err == nil {
 		t.Error("user_id 누락 시 에러가 발생해야 합니다")
 	}
@@ -385,10 +418,10 @@ func TestListCoachingMessages(t *testing.T) {
 	ctx := context.Background()
 
 	// 여러 타입의 코칭 메시지 생성
-	_, _ = svc.GenerateCoaching(ctx, "user-list", "msmt-1", CoachingTypeMeasurementFeedback)
-	_, _ = svc.GenerateCoaching(ctx, "user-list", "", CoachingTypeDailyTip)
-	_, _ = svc.GenerateCoaching(ctx, "user-list", "", CoachingTypeMotivation)
-	_, _ = svc.GenerateCoaching(ctx, "user-list", "", CoachingTypeDailyTip)
+	_, _ = svc.GenerateCoaching(ctx, "user-list", "msmt-1", CoachingTypeMeasurementFeedback, "")
+	_, _ = svc.GenerateCoaching(ctx, "user-list", "", CoachingTypeDailyTip, "")
+	_, _ = svc.GenerateCoaching(ctx, "user-list", "", CoachingTypeMotivation, "")
+	_, _ = svc.GenerateCoaching(ctx, "user-list", "", CoachingTypeDailyTip, "")
 
 	// 전체 조회
 	messages, total, err := svc.ListCoachingMessages(ctx, "user-list", CoachingTypeUnknown, 10, 0)
@@ -0,0 +1,242 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/manpasik/backend/shared/clients"
+	"go.uber.org/zap"
+)
+
+// ============================================================================
+// 코칭 메시지 개인화 (Coaching Composer)
+// ============================================================================
+
+// CoachingStrategy는 코칭 메시지 본문을 만든 방식입니다.
+type CoachingStrategy string
+
+const (
+	CoachingStrategyLLM      CoachingStrategy = "llm"      // ai-inference LLM이 초안을 개인화
+	CoachingStrategyTemplate CoachingStrategy = "template" // LLM을 쓸 수 없어 템플릿 라이브러리에서 선택
+	CoachingStrategyRule     CoachingStrategy = "rule"     // 맞는 템플릿이 없어 규칙 기반 초안을 그대로 사용
+)
+
+const (
+	// defaultCoachingLanguage는 규칙 기반 초안의 언어이자 기본 메시지 언어입니다.
+	defaultCoachingLanguage = "ko"
+	// coachingContextDays는 실천도·최근 측정을 집계하는 기간(일)입니다.
+	coachingContextDays = 7
+	// maxContextBiomarkers는 컨텍스트에 포함할 바이오마커 수입니다.
+	maxContextBiomarkers = 5
+)
+
+// coachingLanguages는 코칭 메시지를 만들 수 있는 언어입니다 (템플릿 라이브러리 기준).
+var coachingLanguages = map[string]bool{"ko": true, "en": true}
+
+// CoachingContext는 코칭 메시지 개인화에 쓰는 사용자 컨텍스트입니다.
+type CoachingContext struct {
+	UserID          string
+	Language        string
+	Goals           []*HealthGoal                // 진행 중 목표
+	Recent          []clients.MeasurementSummary // 최근 coachingContextDays일 측정
+	MeasurementDays int                          // 최근 coachingContextDays일 중 측정한 날 수
+	BestStreak      int32                        // 진행 중 목표의 최장 연속 기록 일수
+}
+
+// SetCoachingComposer는 LLM 기반 코칭 메시지 개인화 클라이언트를 설정합니다 (optional).
+func (s *CoachingService) SetCoachingComposer(c clients.CoachingComposerClient) {
+	s.composer = c
+}
+
+// SetUserProfileClient는 사용자 선호 언어 조회 클라이언트를 설정합니다 (optional).
+func (s *CoachingService) SetUserProfileClient(c clients.UserProfileClient) {
+	s.profiles = c
+}
+
+// buildCoachingContext는 사용자의 진행 중 목표, 최근 측정, 실천도와 메시지 언어를 모읍니다.
+// 조회 실패는 메시지 생성을 막지 않고 해당 항목만 비워 둡니다.
+func (s *CoachingService) buildCoachingContext(ctx context.Context, userID, language string) *CoachingContext {
+	cc := &CoachingContext{UserID: userID, Language: s.resolveLanguage(ctx, userID, language)}
+
+	goals, err := s.goalRepo.GetByUserID(ctx, userID, GoalStatusActive)
+	if err != nil {
+		s.logger.Warn("코칭 컨텍스트 목표 조회 실패", zap.String("user_id", userID), zap.Error(err))
+	}
+	cc.Goals = goals
+	for _, g := range goals {
+		if g.Streak > cc.BestStreak {
+			cc.BestStreak = g.Streak
+		}
+	}
+
+	if s.measurements != nil {
+		now := time.Now().UTC()
+		recent, err := s.measurements.GetMeasurementsInRange(ctx, userID, now.AddDate(0, 0, -coachingContextDays), now)
+		if err != nil {
+			s.logger.Warn("코칭 컨텍스트 측정 조회 실패", zap.String("user_id", userID), zap.Error(err))
+		}
+		cc.Recent = recent
+		days := make(map[string]bool)
+		for _, m := range recent {
+			if len(m.MeasuredAt) >= len("2006-01-02") {
+				days[m.MeasuredAt[:len("2006-01-02")]] = true
+			}
+		}
+		cc.MeasurementDays = len(days)
+	}
+	return cc
+}
+
+// resolveLanguage는 요청 언어, 사용자 프로필 언어, 기본 언어 순으로 메시지 언어를 정합니다.
+func (s *CoachingService) resolveLanguage(ctx context.Context, userID, language string) string {
+	if lang := normalizeLanguage(language); lang != "" {
+		return lang
+	}
+	if s.profiles != nil {
+		preferred, err := s.profiles.GetLanguage(ctx, userID)
+		if err != nil {
+			s.logger.Warn("사용자 언어 조회 실패", zap.String("user_id", userID), zap.Error(err))
+		} else if lang := normalizeLanguage(preferred); lang != "" {
+			return lang
+		}
+	}
+	return defaultCoachingLanguage
+}
+
+// normalizeLanguage는 "en-US"를 "en"으로 바꾸고, 지원하지 않는 언어는 빈 값으로 반환합니다.
+func normalizeLanguage(lang string) string {
+	lang = baseLanguage(lang)
+	if coachingLanguages[lang] {
+		return lang
+	}
+	return ""
+}
+
+// baseLanguage는 언어 태그의 기본 언어("en-US" → "en")를 소문자로 반환합니다.
+func baseLanguage(lang string) string {
+	lang = strings.ToLower(strings.TrimSpace(lang))
+	if i := strings.IndexAny(lang, "-_"); i > 0 {
+		lang = lang[:i]
+	}
+	return lang
+}
+
+// primaryGoal은 메시지와 관련된 진행 중 목표를 고릅니다.
+// 메시지 지표와 같은 목표가 있으면 그 목표를, 없으면 진행률이 가장 높은 목표를 반환합니다.
+func (cc *CoachingContext) primaryGoal(relatedMetric string) *HealthGoal {
+	var best *HealthGoal
+	for _, g := range cc.Goals {
+		if relatedMetric != "" && g.MetricName == relatedMetric {
+			return g
+		}
+		if best == nil || g.ProgressPct > best.ProgressPct {
+			best = g
+		}
+	}
+	return best
+}
+
+// lines는 LLM 프롬프트에 넣을 컨텍스트 요약 문장입니다.
+func (cc *CoachingContext) lines() []string {
+	var lines []string
+	for _, g := range cc.Goals {
+		line := fmt.Sprintf("진행 중 목표 %s: 현재 %.1f / 목표 %.1f %s, 진행률 %.0f%%", g.MetricName, g.CurrentValue, g.TargetValue, g.Unit, g.ProgressPct)
+		if g.Streak > 0 {
+			line += fmt.Sprintf(", %d일 연속 기록", g.Streak)
+		}
+		if !g.TargetDate.IsZero() {
+			line += ", 목표일 " + g.TargetDate.Format("2006-01-02")
+		}
+		lines = append(lines, line)
+	}
+
+	latest := make(map[string]clients.MeasurementSummary)
+	for _, m := range cc.Recent {
+		if prev, ok := latest[m.BiomarkerID]; !ok || m.MeasuredAt > prev.MeasuredAt {
+			latest[m.BiomarkerID] = m
+		}
+	}
+	biomarkers := make([]string, 0, len(latest))
+	for id := range latest {
+		biomarkers = append(biomarkers, id)
+	}
+	sort.Strings(biomarkers)
+	if len(biomarkers) > maxContextBiomarkers {
+		biomarkers = biomarkers[:maxContextBiomarkers]
+	}
+	for _, id := range biomarkers {
+		m := latest[id]
+		lines = append(lines, fmt.Sprintf("최근 측정 %s: %.1f %s (%s)", id, m.Value, m.Unit, m.MeasuredAt))
+	}
+
+	if cc.Recent != nil || cc.MeasurementDays > 0 {
+		lines = append(lines, fmt.Sprintf("최근 %d일 중 측정한 날: %d일", coachingContextDays, cc.MeasurementDays))
+	}
+	return lines
+}
+
+// composeMessage는 규칙 기반 초안 msg를 사용자 컨텍스트로 개인화하고 생성 방식을 기록합니다.
+//
+//  1. LLM 개인화 클라이언트가 있으면 초안과 컨텍스트로 본문을 다시 작성합니다 (ai-inference 가드레일 적용).
+//     한국어가 아니면 제목·실천 항목은 같은 언어의 템플릿에서 가져옵니다.
+//  2. LLM을 쓸 수 없으면 코칭 유형·언어·위험도에 맞는 템플릿을 골라 채웁니다.
+//  3. 맞는 템플릿도 없으면 규칙 기반 초안(한국어)을 그대로 둡니다.
+func (s *CoachingService) composeMessage(ctx context.Context, msg *CoachingMessage, cc *CoachingContext) {
+	msg.Strategy = CoachingStrategyRule
+	msg.Language = defaultCoachingLanguage
+
+	rendered, hasTemplate := renderCoachingTemplate(msg, cc)
+
+	if s.composer != nil && (cc.Language == defaultCoachingLanguage || hasTemplate) {
+		out, err := s.composer.ComposeCoachingMessage(ctx, clients.CoachingComposeRequest{
+			UserID:       msg.UserID,
+			CoachingType: coachingTypeName(msg.CoachingType),
+			Language:     cc.Language,
+			DraftTitle:   msg.Title,
+			DraftBody:    msg.Body,
+			ContextLines: cc.lines(),
+		})
+		if err == nil && strings.TrimSpace(out.Body) != "" {
+			if cc.Language != defaultCoachingLanguage {
+				msg.Title, msg.ActionItems = rendered.Title, rendered.ActionItems
+			}
+			msg.Body = strings.TrimSpace(out.Body)
+			msg.Strategy = CoachingStrategyLLM
+			msg.Language = cc.Language
+			return
+		}
+		s.logger.Warn("LLM 코칭 메시지 생성 실패, 템플릿으로 대체",
+			zap.String("user_id", msg.UserID),
+			zap.Error(err),
+		)
+	}
+
+	if hasTemplate {
+		msg.Title, msg.Body, msg.ActionItems = rendered.Title, rendered.Body, rendered.ActionItems
+		msg.Strategy = CoachingStrategyTemplate
+		msg.Language = cc.Language
+	}
+}
+
+// coachingTypeName은 CoachingType을 "daily_tip" 형식 이름으로 변환합니다.
+func coachingTypeName(t CoachingType) string {
+	switch t {
+	case CoachingTypeMeasurementFeedback:
+		return "measurement_feedback"
+	case CoachingTypeDailyTip:
+		return "daily_tip"
+	case CoachingTypeGoalProgress:
+		return "goal_progress"
+	case CoachingTypeAlert:
+		return "alert"
+	case CoachingTypeMotivation:
+		return "motivation"
+	case CoachingTypeRecommendation:
+		return "recommendation"
+	default:
+		return "unknown"
+	}
+}
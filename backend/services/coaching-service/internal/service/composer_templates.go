@@ -0,0 +1,235 @@
+package service
+
+import (
+	"hash/fnv"
+	"strings"
+	"text/template"
+)
+
+// ============================================================================
+// 코칭 템플릿 라이브러리 (LLM 미사용 시 대체)
+// ============================================================================
+
+// templateGoal은 템플릿이 진행 중 목표를 필요로 하는지 나타냅니다.
+type templateGoal int
+
+const (
+	goalAny      templateGoal = iota // 목표 유무와 무관
+	goalRequired                     // 진행 중 목표가 있어야 함 ({{.Goal}} 사용)
+	goalAbsent                       // 진행 중 목표가 없을 때만 사용
+)
+
+// coachingTemplate은 코칭 메시지 템플릿입니다. Title·Body는 text/template 형식이며 templateData로 채웁니다.
+// ActionItems가 비어 있으면 규칙 기반 초안의 실천 항목을 그대로 사용합니다.
+type coachingTemplate struct {
+	Risk        RiskLevel // 0이면 모든 위험 수준
+	Goal        templateGoal
+	Title       string
+	Body        string
+	ActionItems []string
+}
+
+// templateKey는 템플릿 라이브러리 조회 키입니다.
+type templateKey struct {
+	Type     CoachingType
+	Language string
+}
+
+// templateData는 템플릿에 채우는 값입니다.
+type templateData struct {
+	DraftTitle      string
+	Draft           string  // 규칙 기반 초안 본문 (한국어)
+	Metric          string  // 메시지 언어로 옮긴 관련 지표 이름
+	Value           float64 // 관련 지표 값
+	Goal            string  // 관련 목표 이름
+	Progress        float64 // 관련 목표 진행률 (%)
+	Streak          int32   // 관련 목표 연속 기록 일수
+	MeasurementDays int     // 최근 Days일 중 측정한 날 수
+	Days            int
+}
+
+// renderedTemplate은 채워진 템플릿입니다.
+type renderedTemplate struct {
+	Title       string
+	Body        string
+	ActionItems []string
+}
+
+// coachingTemplates는 코칭 유형·언어별 템플릿 라이브러리입니다.
+var coachingTemplates = map[templateKey][]coachingTemplate{
+	// --- 측정 피드백 ---
+	{CoachingTypeMeasurementFeedback, "ko"}: {
+		{Risk: RiskLevelLow, Title: "{{.DraftTitle}}",
+			Body: "{{.Draft}}{{if .MeasurementDays}} 최근 {{.Days}}일 중 {{.MeasurementDays}}일을 측정하셨어요. 지금처럼 꾸준히 기록해 주세요.{{end}}"},
+		{Risk: RiskLevelModerate, Title: "{{.DraftTitle}}",
+			Body: "{{.Draft}}{{if .Streak}} {{.Goal}} 목표를 {{.Streak}}일째 이어가고 계시니, 작은 습관 하나만 더해 보세요.{{end}}"},
+		{Risk: RiskLevelHigh, Title: "{{.DraftTitle}}",
+			Body: "{{.Draft}} 측정 기록을 가지고 상담을 받으시면 더 정확한 안내를 받을 수 있습니다."},
+	},
+	{CoachingTypeMeasurementFeedback, "en"}: {
+		{Risk: RiskLevelLow, Title: "Your {{.Metric}} result",
+			Body:        "Your {{.Metric}} reading of {{printf \"%.1f\" .Value}} is within the normal range.{{if .MeasurementDays}} You measured on {{.MeasurementDays}} of the last {{.Days}} days — keep it up!{{end}}",
+			ActionItems: []string{"Keep your current diet and exercise routine", "Continue measuring regularly"}},
+		{Risk: RiskLevelModerate, Title: "Your {{.Metric}} result",
+			Body:        "Your {{.Metric}} reading of {{printf \"%.1f\" .Value}} is slightly above the reference range. Small lifestyle changes can bring it back down.",
+			ActionItems: []string{"Adjust your diet, starting with fewer refined carbs", "Do at least 30 minutes of aerobic exercise", "Measure again in 1–2 weeks"}},
+		{Risk: RiskLevelHigh, Title: "Your {{.Metric}} result needs attention",
+			Body:        "⚠️ Your {{.Metric}} reading of {{printf \"%.1f\" .Value}} is above the reference range. We recommend consulting a specialist and bringing your measurement history.",
+			ActionItems: []string{"See a specialist soon", "Avoid overeating and high-fat foods", "Measure again within 3 days"}},
+	},
+
+	// --- 일일 건강 팁 ---
+	{CoachingTypeDailyTip, "ko"}: {
+		{Title: "{{.DraftTitle}}",
+			Body: "{{.Draft}}{{if .Streak}} {{.Goal}} 목표를 {{.Streak}}일 연속 기록 중이에요. 오늘 팁으로 기록을 이어가 보세요!{{end}}"},
+	},
+	{CoachingTypeDailyTip, "en"}: {
+		{Title: "Walk after meals",
+			Body:        "A light 15-minute walk within 30 minutes of a meal helps blunt blood sugar spikes.{{if .Streak}} You're on a {{.Streak}}-day streak — add a walk today!{{end}}",
+			ActionItems: []string{"Walk for 15–30 minutes after meals", "Take the stairs", "Eat more slowly"}},
+		{Title: "Stay hydrated",
+			Body:        "Drinking enough water supports circulation and helps your body clear waste. Aim for about 2 liters a day.",
+			ActionItems: []string{"Drink a glass of water right after waking up", "Drink water 30 minutes before meals", "Choose water over caffeinated drinks"}},
+		{Title: "Sleep well",
+			Body:        "Consistent sleep and wake times stabilize your body clock and support your immune system.",
+			ActionItems: []string{"Reduce screen time an hour before bed", "Keep your bedroom at 18–20°C", "Have caffeine only before 2 p.m."}},
+		{Title: "Cut back on sodium",
+			Body:        "Too much sodium is a major cause of high blood pressure. Try to keep it under 2,000 mg a day.",
+			ActionItems: []string{"Use less salt when cooking", "Eat fewer processed foods", "Ask for less salt when eating out"}},
+	},
+
+	// --- 목표 진행 ---
+	{CoachingTypeGoalProgress, "ko"}: {
+		{Goal: goalRequired, Title: "{{.Goal}} 목표 진행 상황",
+			Body: "{{.Goal}} 목표 진행률이 {{printf \"%.0f\" .Progress}}%입니다.{{if .Streak}} {{.Streak}}일 연속으로 기록하고 계세요.{{end}} {{if ge .Progress 75.0}}목표가 눈앞이에요, 마지막까지 힘내세요!{{else if ge .Progress 25.0}}순조롭게 나아가고 있어요.{{else}}오늘 할 수 있는 작은 행동 하나부터 시작해 보세요.{{end}}"},
+	},
+	{CoachingTypeGoalProgress, "en"}: {
+		{Goal: goalRequired, Title: "{{.Goal}} goal progress",
+			Body:        "You're {{printf \"%.0f\" .Progress}}% of the way to your {{.Goal}} goal.{{if .Streak}} That's {{.Streak}} days in a row of logging.{{end}} {{if ge .Progress 75.0}}You're almost there — finish strong!{{else if ge .Progress 25.0}}You're making steady progress.{{else}}Start with one small step today.{{end}}",
+			ActionItems: []string{"Keep your current pace", "Check your trend in the weekly report"}},
+		{Goal: goalAbsent, Title: "Set a health goal",
+			Body:        "You don't have a health goal yet. Set one for blood glucose, blood pressure, weight and more to get personalized coaching.",
+			ActionItems: []string{"Add a goal from the health goals menu", "Start with a small, achievable goal"}},
+	},
+
+	// --- 건강 경고 ---
+	{CoachingTypeAlert, "ko"}: {
+		{Title: "{{.DraftTitle}}",
+			Body: "{{.Draft}} 증상이 있거나 수치가 계속 높게 나오면 미루지 말고 의료진과 상담하세요."},
+	},
+	{CoachingTypeAlert, "en"}: {
+		{Title: "{{.Metric}} alert",
+			Body:        "⚠️ Your recent {{.Metric}} reading ({{printf \"%.1f\" .Value}}) is outside the healthy range. Please consult a medical professional, and don't delay if you have symptoms.",
+			ActionItems: []string{"Consult a medical professional this week", "Keep monitoring regularly", "Log your meals and activity"}},
+	},
+
+	// --- 동기부여 ---
+	{CoachingTypeMotivation, "ko"}: {
+		{Goal: goalRequired, Title: "{{if .Streak}}{{.Streak}}일의 꾸준함{{else}}꾸준함이 건강의 비결입니다{{end}}",
+			Body: "{{if .Streak}}{{.Goal}} 목표를 {{.Streak}}일 연속 기록하셨어요! {{end}}작은 실천이 쌓여 큰 변화를 만듭니다. 오늘도 하나의 좋은 습관을 이어가 보세요. 💪"},
+		{Goal: goalAbsent, Title: "{{.DraftTitle}}", Body: "{{.Draft}}"},
+	},
+	{CoachingTypeMotivation, "en"}: {
+		{Title: "Consistency is the secret",
+			Body:        "{{if .Streak}}{{.Streak}} days in a row — amazing! {{end}}{{if .MeasurementDays}}You measured on {{.MeasurementDays}} of the last {{.Days}} days. {{end}}Small habits add up to big changes. Keep going! 💪",
+			ActionItems: []string{"Complete today's measurement", "Share your health goal with family or friends"}},
+	},
+
+	// --- 개인화 추천 ---
+	{CoachingTypeRecommendation, "ko"}: {
+		{Title: "{{.DraftTitle}}",
+			Body: "{{.Draft}}{{if .Goal}} {{.Goal}} 목표 달성에도 도움이 됩니다.{{end}}"},
+	},
+	{CoachingTypeRecommendation, "en"}: {
+		{Title: "Today's recommendation",
+			Body:        "Based on your recent records, try adding one healthy habit today{{if .Goal}} to support your {{.Goal}} goal{{end}}: more vegetables at each meal and a 30-minute walk.",
+			ActionItems: []string{"Eat vegetables first at each meal", "Walk for 30 minutes", "Log today's meals"}},
+	},
+}
+
+// metricNamesEN은 규칙 기반 초안의 지표 이름을 영어로 옮깁니다.
+var metricNamesEN = map[string]string{
+	"공복혈당":    "fasting glucose",
+	"총콜레스테롤":  "total cholesterol",
+	"혈압(수축기)": "systolic blood pressure",
+	"수축기혈압":   "systolic blood pressure",
+	"중성지방":    "triglycerides",
+	"체중":      "weight",
+}
+
+// localizeMetric은 지표 이름을 메시지 언어로 옮깁니다. 번역이 없으면 원래 이름을 사용합니다.
+func localizeMetric(name, language string) string {
+	if language == "en" {
+		if en, ok := metricNamesEN[name]; ok {
+			return en
+		}
+	}
+	return name
+}
+
+// renderCoachingTemplate은 메시지 유형·위험도와 컨텍스트 언어·목표 유무에 맞는 템플릿을 골라 채웁니다.
+// 같은 사용자·날짜·유형에는 항상 같은 템플릿을 고릅니다. 맞는 템플릿이 없으면 false를 반환합니다.
+func renderCoachingTemplate(msg *CoachingMessage, cc *CoachingContext) (renderedTemplate, bool) {
+	goal := cc.primaryGoal(msg.RelatedMetric)
+	var candidates []coachingTemplate
+	for _, t := range coachingTemplates[templateKey{msg.CoachingType, cc.Language}] {
+		if t.Risk != RiskLevelUnspecified && t.Risk != msg.RiskLevel {
+			continue
+		}
+		if (t.Goal == goalRequired && goal == nil) || (t.Goal == goalAbsent && goal != nil) {
+			continue
+		}
+		candidates = append(candidates, t)
+	}
+	if len(candidates) == 0 {
+		return renderedTemplate{}, false
+	}
+
+	h := fnv.New32a()
+	h.Write([]byte(msg.UserID + "|" + msg.CreatedAt.UTC().Format("2006-01-02") + "|" + coachingTypeName(msg.CoachingType)))
+	t := candidates[h.Sum32()%uint32(len(candidates))]
+
+	data := templateData{
+		DraftTitle:      msg.Title,
+		Draft:           msg.Body,
+		Metric:          localizeMetric(msg.RelatedMetric, cc.Language),
+		Value:           msg.RelatedValue,
+		MeasurementDays: cc.MeasurementDays,
+		Days:            coachingContextDays,
+	}
+	if goal != nil {
+		data.Goal = goal.MetricName
+		if cc.Language == defaultCoachingLanguage && goal.Description != "" {
+			data.Goal = goal.Description
+		}
+		data.Progress = goal.ProgressPct
+		data.Streak = goal.Streak
+	}
+
+	title, err := executeTemplate(t.Title, data)
+	if err != nil {
+		return renderedTemplate{}, false
+	}
+	body, err := executeTemplate(t.Body, data)
+	if err != nil {
+		return renderedTemplate{}, false
+	}
+	actions := t.ActionItems
+	if len(actions) == 0 {
+		actions = msg.ActionItems
+	}
+	return renderedTemplate{Title: title, Body: body, ActionItems: actions}, true
+}
+
+// executeTemplate은 text/template 문자열을 data로 채웁니다.
+func executeTemplate(text string, data templateData) (string, error) {
+	tmpl, err := template.New("coaching").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", err
+	}
+	var sb strings.Builder
+	if err := tmpl.Execute(&sb, data); err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(sb.String()), nil
+}
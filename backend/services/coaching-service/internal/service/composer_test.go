@@ -0,0 +1,153 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/manpasik/backend/shared/clients"
+)
+
+// ============================================================================
+// 테스트용 Fake 의존성
+// ============================================================================
+
+type fakeComposer struct {
+	body     string
+	err      error
+	requests []clients.CoachingComposeRequest
+}
+
+func (c *fakeComposer) ComposeCoachingMessage(_ context.Context, req clients.CoachingComposeRequest) (*clients.CoachingComposition, error) {
+	c.requests = append(c.requests, req)
+	if c.err != nil {
+		return nil, c.err
+	}
+	return &clients.CoachingComposition{Body: c.body, Provider: "openai", Model: "gpt-4o-mini"}, nil
+}
+
+type fakeProfiles struct {
+	language string
+	timezone string
+}
+
+func (p *fakeProfiles) GetLanguage(context.Context, string) (string, error) {
+	return p.language, nil
+}
+
+func (p *fakeProfiles) GetTimezone(context.Context, string) (string, error) {
+	return p.timezone, nil
+}
+
+// newComposerTestService는 연속 기록 중인 운동 목표와 최근 측정 2일치가 있는 서비스를 만듭니다.
+func newComposerTestService(t *testing.T) *CoachingService {
+	t.Helper()
+	svc, goalRepo, _, _ := newTestCoachingService()
+	goalRepo.goals["goal-walk"] = &HealthGoal{
+		GoalID: "goal-walk", UserID: "user-1", Category: GoalCategoryExercise, MetricName: "steps",
+		TargetValue: 8000, CurrentValue: 6000, Unit: "steps", ProgressPct: 75, Status: GoalStatusActive,
+		Description: "하루 8천 보 걷기", Streak: 5,
+	}
+	now := time.Now().UTC()
+	svc.SetMeasurementClient(&fakeMeasurementClient{inRange: []clients.MeasurementSummary{
+		{SessionID: "s1", BiomarkerID: "glucose", Value: 110, Unit: "mg/dL", MeasuredAt: now.AddDate(0, 0, -2).Format(time.RFC3339)},
+		{SessionID: "s2", BiomarkerID: "glucose", Value: 98, Unit: "mg/dL", MeasuredAt: now.Format(time.RFC3339)},
+	}})
+	return svc
+}
+
+// ============================================================================
+// 코칭 메시지 개인화
+// ============================================================================
+
+func TestGenerateCoaching_LLMStrategy(t *testing.T) {
+	svc := newComposerTestService(t)
+	composer := &fakeComposer{body: "5일 연속 걷기 기록, 정말 잘하고 계세요!"}
+	svc.SetCoachingComposer(composer)
+
+	msg, err := svc.GenerateCoaching(context.Background(), "user-1", "", CoachingTypeMotivation, "")
+	if err != nil {
+		t.Fatalf("GenerateCoaching 실패: %v", err)
+	}
+	if msg.Strategy != CoachingStrategyLLM || msg.Body != composer.body || msg.Language != "ko" {
+		t.Errorf("LLM 본문이 반영되어야 합니다: strategy=%s language=%s body=%q", msg.Strategy, msg.Language, msg.Body)
+	}
+
+	req := composer.requests[0]
+	if req.CoachingType != "motivation" || req.DraftBody == "" {
+		t.Errorf("초안이 전달되어야 합니다: %+v", req)
+	}
+	lines := strings.Join(req.ContextLines, "\n")
+	for _, want := range []string{"진행 중 목표 steps", "5일 연속 기록", "최근 측정 glucose: 98.0 mg/dL", "최근 7일 중 측정한 날: 2일"} {
+		if !strings.Contains(lines, want) {
+			t.Errorf("컨텍스트에 %q가 없습니다:\n%s", want, lines)
+		}
+	}
+}
+
+func TestGenerateCoaching_TemplateFallbackWhenLLMFails(t *testing.T) {
+	svc := newComposerTestService(t)
+	svc.SetCoachingComposer(&fakeComposer{err: errors.New("llm unavailable")})
+
+	msg, err := svc.GenerateCoaching(context.Background(), "user-1", "", CoachingTypeGoalProgress, "")
+	if err != nil {
+		t.Fatalf("GenerateCoaching 실패: %v", err)
+	}
+	if msg.Strategy != CoachingStrategyTemplate {
+		t.Fatalf("LLM 실패 시 템플릿을 사용해야 합니다: got %s", msg.Strategy)
+	}
+	if msg.Title != "하루 8천 보 걷기 목표 진행 상황" || !strings.Contains(msg.Body, "진행률이 75%") || !strings.Contains(msg.Body, "5일 연속") {
+		t.Errorf("목표 진행률과 연속 기록이 채워져야 합니다: %q / %q", msg.Title, msg.Body)
+	}
+	if msg.RelatedValue != 75 {
+		t.Errorf("실제 목표 진행률을 사용해야 합니다: got %.1f", msg.RelatedValue)
+	}
+}
+
+func TestGenerateCoaching_PreferredLanguageTemplate(t *testing.T) {
+	svc := newComposerTestService(t)
+	svc.SetUserProfileClient(&fakeProfiles{language: "en-US"})
+
+	msg, err := svc.GenerateCoaching(context.Background(), "user-1", "", CoachingTypeDailyTip, "")
+	if err != nil {
+		t.Fatalf("GenerateCoaching 실패: %v", err)
+	}
+	if msg.Strategy != CoachingStrategyTemplate || msg.Language != "en" {
+		t.Fatalf("프로필 언어(en) 템플릿을 사용해야 합니다: strategy=%s language=%s", msg.Strategy, msg.Language)
+	}
+	for _, item := range append([]string{msg.Title, msg.Body}, msg.ActionItems...) {
+		if strings.ContainsFunc(item, func(r rune) bool { return r >= '가' && r <= '힣' }) {
+			t.Errorf("영어 메시지에 한국어가 섞여 있습니다: %q", item)
+		}
+	}
+
+	// 같은 사용자·날짜·유형에는 같은 템플릿을 고릅니다
+	again, _ := svc.GenerateCoaching(context.Background(), "user-1", "", CoachingTypeDailyTip, "")
+	if again.Title != msg.Title {
+		t.Errorf("템플릿 선택이 결정적이어야 합니다: %q != %q", again.Title, msg.Title)
+	}
+
+	// 요청 언어가 프로필 언어보다 우선합니다
+	ko, _ := svc.GenerateCoaching(context.Background(), "user-1", "", CoachingTypeDailyTip, "ko")
+	if ko.Language != "ko" {
+		t.Errorf("요청 언어를 사용해야 합니다: got %s", ko.Language)
+	}
+}
+
+func TestGenerateCoaching_RuleStrategyWithoutTemplate(t *testing.T) {
+	svc, _, _, _ := newTestCoachingService()
+
+	// 한국어 목표 진행 템플릿은 목표가 있어야 하므로, 목표가 없으면 규칙 기반 초안을 그대로 사용
+	msg, err := svc.GenerateCoaching(context.Background(), "user-new", "", CoachingTypeGoalProgress, "fr")
+	if err != nil {
+		t.Fatalf("GenerateCoaching 실패: %v", err)
+	}
+	if msg.Strategy != CoachingStrategyRule || msg.Language != "ko" {
+		t.Errorf("규칙 기반 초안이어야 합니다: strategy=%s language=%s", msg.Strategy, msg.Language)
+	}
+	if msg.Title != "건강 목표를 설정해 보세요" {
+		t.Errorf("초안 제목이 유지되어야 합니다: got %q", msg.Title)
+	}
+}
@@ -0,0 +1,348 @@
+package service
+
+import (
+	"context"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/manpasik/backend/shared/clients"
+	apperrors "github.com/manpasik/backend/shared/errors"
+	"go.uber.org/zap"
+)
+
+// ============================================================================
+// 이벤트 기반 목표 진행 추적 (Goal Progress Tracking)
+// ============================================================================
+
+// GoalMilestoneKind는 목표 마일스톤 종류입니다.
+type GoalMilestoneKind string
+
+const (
+	MilestoneProgress GoalMilestoneKind = "progress" // 진행률 25/50/75/100% 구간 도달
+	MilestoneAchieved GoalMilestoneKind = "achieved" // 목표 달성
+	MilestoneFailed   GoalMilestoneKind = "failed"   // 목표일까지 미달성
+)
+
+// milestoneStep은 진행률 마일스톤 간격(%)입니다.
+const milestoneStep = 25
+
+// GoalMilestone은 목표 진행 마일스톤입니다.
+// coaching.goal_milestone 이벤트로 발행되어 알림과 커뮤니티 챌린지에 반영됩니다.
+type GoalMilestone struct {
+	GoalID       string
+	UserID       string
+	Category     GoalCategory
+	MetricName   string
+	Kind         GoalMilestoneKind
+	Milestone    int32 // 도달한 진행률 구간 (25, 50, 75, 100)
+	ProgressPct  float64
+	CurrentValue float64
+	TargetValue  float64
+	Unit         string
+	Streak       int32
+	OccurredAt   time.Time
+}
+
+// GoalEventPublisher는 목표 마일스톤 이벤트 발행 인터페이스입니다 (Kafka).
+type GoalEventPublisher interface {
+	PublishGoalMilestone(ctx context.Context, m *GoalMilestone) error
+}
+
+// MealLog는 vision-service가 분석한 식사 기록입니다 (vision.meal_analyzed).
+type MealLog struct {
+	AnalysisID       string
+	UserID           string
+	MealType         string
+	TotalCalorieKcal float64
+	Nutrients        map[string]float64 // 영양소 이름 → 섭취량
+	AnalyzedAt       time.Time
+}
+
+// categoryMetrics는 카테고리별 기록 지표 별칭입니다.
+// 목표 지표 이름에 별칭이 포함되면(예: "fasting_glucose", "daily_kcal") 같은 카테고리의 기록을 반영합니다.
+var categoryMetrics = map[GoalCategory][]string{
+	GoalCategoryBloodGlucose:  {"glucose", "blood_glucose", "혈당"},
+	GoalCategoryBloodPressure: {"blood_pressure", "systolic", "혈압"},
+	GoalCategoryCholesterol:   {"cholesterol", "cholesterol_total", "콜레스테롤"},
+	GoalCategoryWeight:        {"weight", "body_weight", "체중"},
+	GoalCategoryNutrition:     {"calories", "kcal", "칼로리"},
+}
+
+// SetMeasurementClient는 measurement.completed 이벤트의 측정값 조회 클라이언트를 설정합니다 (optional).
+func (s *CoachingService) SetMeasurementClient(c clients.MeasurementClient) {
+	s.measurements = c
+}
+
+// SetGoalEventPublisher는 목표 마일스톤 이벤트 발행기를 설정합니다 (optional).
+func (s *CoachingService) SetGoalEventPublisher(p GoalEventPublisher) {
+	s.goalEvents = p
+}
+
+// RecordGoalProgress는 기록 하나를 사용자의 진행 중 목표 중 지표가 일치하는 목표에 반영합니다.
+// 현재 값·진행률·연속 기록 일수를 갱신하고, 새 진행률 구간에 도달하면 마일스톤을 발행합니다.
+// 목표일이 없는 목표는 진행률 100%에 도달하면 바로 달성 처리합니다.
+// sourceID(측정 세션 ID·식사 분석 ID)는 목표별로 한 번만 반영되어, 이벤트가 재전달되어도 중복 집계되지 않습니다.
+func (s *CoachingService) RecordGoalProgress(ctx context.Context, userID, sourceID, metric string, value float64, at time.Time) ([]*HealthGoal, error) {
+	if userID == "" {
+		return nil, apperrors.New(apperrors.ErrInvalidInput, "user_id는 필수입니다")
+	}
+	if strings.TrimSpace(metric) == "" {
+		return nil, apperrors.New(apperrors.ErrInvalidInput, "지표 이름은 필수입니다")
+	}
+
+	goals, err := s.goalRepo.GetByUserID(ctx, userID, GoalStatusActive)
+	if err != nil {
+		s.logger.Error("건강 목표 조회 실패", zap.Error(err))
+		return nil, apperrors.New(apperrors.ErrInternal, "건강 목표 조회에 실패했습니다")
+	}
+
+	var updated []*HealthGoal
+	for _, current := range goals {
+		if !goalMatchesMetric(current, metric) {
+			continue
+		}
+		goal := *current
+		if !goal.observe(value, at) {
+			continue
+		}
+
+		var milestones []*GoalMilestone
+		if reached := int32(goal.ProgressPct) / milestoneStep * milestoneStep; reached > goal.LastMilestone {
+			goal.LastMilestone = reached
+			milestones = append(milestones, newGoalMilestone(&goal, MilestoneProgress, at))
+		}
+		if goal.TargetDate.IsZero() && goal.ProgressPct >= 100 {
+			achievedAt := at.UTC()
+			goal.Status = GoalStatusAchieved
+			goal.AchievedAt = &achievedAt
+			milestones = append(milestones, newGoalMilestone(&goal, MilestoneAchieved, at))
+		}
+
+		applied, err := s.goalRepo.ApplyProgress(ctx, &goal, sourceID)
+		if err != nil {
+			s.logger.Error("건강 목표 진행 갱신 실패", zap.String("goal_id", goal.GoalID), zap.Error(err))
+			return nil, apperrors.New(apperrors.ErrInternal, "건강 목표 진행 갱신에 실패했습니다")
+		}
+		if !applied {
+			continue
+		}
+		for _, m := range milestones {
+			s.publishMilestone(ctx, m)
+		}
+		updated = append(updated, &goal)
+	}
+	return updated, nil
+}
+
+// ProcessMeasurementCompleted는 measurement.completed 이벤트의 측정값을 조회해 목표 진행에 반영합니다.
+func (s *CoachingService) ProcessMeasurementCompleted(ctx context.Context, userID, sessionID string) ([]*HealthGoal, error) {
+	if sessionID == "" {
+		return nil, apperrors.New(apperrors.ErrInvalidInput, "session_id는 필수입니다")
+	}
+	if s.measurements == nil {
+		return nil, apperrors.New(apperrors.ErrServiceUnavailable, "측정 데이터 조회가 설정되지 않았습니다")
+	}
+
+	detail, err := s.measurements.GetMeasurement(ctx, sessionID)
+	if err != nil {
+		s.logger.Warn("측정 데이터 조회 실패", zap.String("session_id", sessionID), zap.Error(err))
+		return nil, apperrors.New(apperrors.ErrServiceUnavailable, "측정 데이터 조회에 실패했습니다")
+	}
+	if detail == nil || (userID != "" && detail.UserID != userID) {
+		return nil, apperrors.New(apperrors.ErrNotFound, "측정을 찾을 수 없습니다")
+	}
+
+	var updated []*HealthGoal
+	for _, r := range detail.Readings {
+		at, err := time.Parse(time.RFC3339, r.MeasuredAt)
+		if err != nil {
+			at = time.Now().UTC()
+		}
+		goals, err := s.RecordGoalProgress(ctx, detail.UserID, sessionID, r.BiomarkerID, r.Value, at)
+		if err != nil {
+			return nil, err
+		}
+		updated = append(updated, goals...)
+	}
+	return updated, nil
+}
+
+// ProcessMealLogged는 vision-service 식사 분석 결과의 칼로리·영양소 섭취량을 영양 목표에 반영합니다.
+func (s *CoachingService) ProcessMealLogged(ctx context.Context, meal *MealLog) ([]*HealthGoal, error) {
+	if meal == nil || meal.UserID == "" {
+		return nil, apperrors.New(apperrors.ErrInvalidInput, "user_id는 필수입니다")
+	}
+	at := meal.AnalyzedAt
+	if at.IsZero() {
+		at = time.Now().UTC()
+	}
+
+	updated, err := s.RecordGoalProgress(ctx, meal.UserID, meal.AnalysisID, "calories", meal.TotalCalorieKcal, at)
+	if err != nil {
+		return nil, err
+	}
+	for name, amount := range meal.Nutrients {
+		goals, err := s.RecordGoalProgress(ctx, meal.UserID, meal.AnalysisID, name, amount, at)
+		if err != nil {
+			return nil, err
+		}
+		updated = append(updated, goals...)
+	}
+	return updated, nil
+}
+
+// ExpireGoals는 목표일이 지난 진행 중 목표를 마감합니다.
+// 진행률 100%면 달성, 아니면 실패로 전환하고 마일스톤을 발행합니다.
+// 모든 레플리카가 스윕을 돌리므로, 조건부 갱신으로 직접 마감한 목표에 대해서만 마일스톤을 발행합니다.
+func (s *CoachingService) ExpireGoals(ctx context.Context, now time.Time) ([]*HealthGoal, error) {
+	goals, err := s.goalRepo.ListActiveDue(ctx, now)
+	if err != nil {
+		s.logger.Error("마감 대상 목표 조회 실패", zap.Error(err))
+		return nil, apperrors.New(apperrors.ErrInternal, "마감 대상 목표 조회에 실패했습니다")
+	}
+
+	closedAt := now.UTC()
+	var closed []*HealthGoal
+	for _, due := range goals {
+		goal := *due
+		kind := MilestoneFailed
+		goal.Status = GoalStatusFailed
+		if goal.ProgressPct >= 100 {
+			kind = MilestoneAchieved
+			goal.Status = GoalStatusAchieved
+			goal.AchievedAt = &closedAt
+		}
+		ok, err := s.goalRepo.CloseIfActive(ctx, &goal)
+		if err != nil {
+			s.logger.Error("목표 마감 실패", zap.String("goal_id", goal.GoalID), zap.Error(err))
+			return nil, apperrors.New(apperrors.ErrInternal, "목표 마감에 실패했습니다")
+		}
+		if !ok {
+			continue
+		}
+		s.publishMilestone(ctx, newGoalMilestone(&goal, kind, closedAt))
+		closed = append(closed, &goal)
+	}
+
+	if len(closed) > 0 {
+		s.logger.Info("목표일 마감 처리 완료", zap.Int("goals", len(closed)))
+	}
+	return closed, nil
+}
+
+// publishMilestone은 마일스톤을 발행합니다. 발행 실패는 목표 갱신을 되돌리지 않습니다.
+func (s *CoachingService) publishMilestone(ctx context.Context, m *GoalMilestone) {
+	if s.goalEvents == nil {
+		return
+	}
+	if err := s.goalEvents.PublishGoalMilestone(ctx, m); err != nil {
+		s.logger.Warn("목표 마일스톤 이벤트 발행 실패",
+			zap.String("goal_id", m.GoalID),
+			zap.String("kind", string(m.Kind)),
+			zap.Error(err),
+		)
+	}
+}
+
+func newGoalMilestone(goal *HealthGoal, kind GoalMilestoneKind, at time.Time) *GoalMilestone {
+	return &GoalMilestone{
+		GoalID:       goal.GoalID,
+		UserID:       goal.UserID,
+		Category:     goal.Category,
+		MetricName:   goal.MetricName,
+		Kind:         kind,
+		Milestone:    goal.LastMilestone,
+		ProgressPct:  goal.ProgressPct,
+		CurrentValue: goal.CurrentValue,
+		TargetValue:  goal.TargetValue,
+		Unit:         goal.Unit,
+		Streak:       goal.Streak,
+		OccurredAt:   at.UTC(),
+	}
+}
+
+// goalMatchesMetric은 기록 지표가 목표의 지표 이름과 일치하거나,
+// 목표 지표 이름과 기록 지표가 모두 목표 카테고리의 별칭에 해당하는지 반환합니다.
+func goalMatchesMetric(goal *HealthGoal, metric string) bool {
+	key := normalizeMetric(metric)
+	goalKey := normalizeMetric(goal.MetricName)
+	if goalKey == key {
+		return true
+	}
+
+	aliases := categoryMetrics[goal.Category]
+	recordMatches, goalMatches := false, false
+	for _, alias := range aliases {
+		recordMatches = recordMatches || alias == key
+		goalMatches = goalMatches || strings.Contains(goalKey, alias)
+	}
+	return recordMatches && goalMatches
+}
+
+func normalizeMetric(name string) string {
+	return strings.ReplaceAll(strings.ToLower(strings.TrimSpace(name)), " ", "_")
+}
+
+// tracksDailyTotal은 하루 누적량으로 진행률을 계산하는 목표인지 반환합니다 (운동량·영양 섭취).
+// 그 외 목표는 최근 기록 값이 시작 값에서 목표 값까지 좁혀진 비율로 계산합니다.
+func (g *HealthGoal) tracksDailyTotal() bool {
+	return g.Category == GoalCategoryExercise || g.Category == GoalCategoryNutrition
+}
+
+// observe는 기록 하나를 목표에 반영합니다. 이미 지난 날의 늦은 기록은 반영하지 않고 false를 반환합니다.
+// 연속 기록 일수는 UTC 날짜 기준으로, 전날에 이어 기록하면 늘고 하루 이상 비면 1로 초기화됩니다.
+func (g *HealthGoal) observe(value float64, at time.Time) bool {
+	day := at.UTC().Truncate(24 * time.Hour)
+	if g.LastProgressAt == nil {
+		g.Streak = 1
+		g.StartValue = value
+		g.CurrentValue = value
+	} else {
+		last := *g.LastProgressAt
+		lastDay := last.UTC().Truncate(24 * time.Hour)
+		switch {
+		case day.Before(lastDay):
+			return false
+		case day.Equal(lastDay):
+			if g.tracksDailyTotal() {
+				g.CurrentValue += value
+			} else if at.Before(last) {
+				return false
+			} else {
+				g.CurrentValue = value
+			}
+		default:
+			if day.Equal(lastDay.Add(24 * time.Hour)) {
+				g.Streak++
+			} else {
+				g.Streak = 1
+			}
+			g.CurrentValue = value
+		}
+	}
+
+	if g.LastProgressAt == nil || at.After(*g.LastProgressAt) {
+		t := at.UTC()
+		g.LastProgressAt = &t
+	}
+	g.ProgressPct = g.progress()
+	return true
+}
+
+// progress는 현재 값 기준 진행률(0~100)을 계산합니다.
+func (g *HealthGoal) progress() float64 {
+	var pct float64
+	switch {
+	case g.tracksDailyTotal():
+		pct = g.CurrentValue / g.TargetValue * 100
+	case g.StartValue == g.TargetValue:
+		if g.CurrentValue == g.TargetValue {
+			pct = 100
+		}
+	default:
+		pct = (g.StartValue - g.CurrentValue) / (g.StartValue - g.TargetValue) * 100
+	}
+	pct = math.Max(0, math.Min(100, pct))
+	return math.Round(pct*10) / 10
+}
@@ -0,0 +1,296 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/manpasik/backend/shared/clients"
+	apperrors "github.com/manpasik/backend/shared/errors"
+)
+
+// ============================================================================
+// 테스트용 Fake 의존성
+// ============================================================================
+
+type fakeGoalEvents struct {
+	milestones []*GoalMilestone
+}
+
+func (p *fakeGoalEvents) PublishGoalMilestone(_ context.Context, m *GoalMilestone) error {
+	p.milestones = append(p.milestones, m)
+	return nil
+}
+
+func (p *fakeGoalEvents) kinds() []string {
+	var out []string
+	for _, m := range p.milestones {
+		out = append(out, string(m.Kind))
+	}
+	return out
+}
+
+type fakeMeasurementClient struct {
+	details map[string]*clients.MeasurementDetail
+	inRange []clients.MeasurementSummary
+}
+
+func (c *fakeMeasurementClient) GetLatestMeasurements(context.Context, string, int) ([]clients.MeasurementSummary, error) {
+	return nil, nil
+}
+
+func (c *fakeMeasurementClient) GetMeasurement(_ context.Context, id string) (*clients.MeasurementDetail, error) {
+	return c.details[id], nil
+}
+
+func (c *fakeMeasurementClient) GetMeasurementsInRange(context.Context, string, time.Time, time.Time) ([]clients.MeasurementSummary, error) {
+	return c.inRange, nil
+}
+
+var progressDay = time.Date(2026, 3, 2, 8, 0, 0, 0, time.UTC)
+
+func newProgressTestService(t *testing.T) (*CoachingService, *fakeGoalRepo, *fakeGoalEvents) {
+	t.Helper()
+	svc, goalRepo, _, _ := newTestCoachingService()
+	publisher := &fakeGoalEvents{}
+	svc.SetGoalEventPublisher(publisher)
+	return svc, goalRepo, publisher
+}
+
+// ============================================================================
+// RecordGoalProgress
+// ============================================================================
+
+func TestRecordGoalProgress_LevelGoal(t *testing.T) {
+	svc, goalRepo, publisher := newProgressTestService(t)
+	ctx := context.Background()
+	goal, _ := svc.SetHealthGoal(ctx, "user-1", GoalCategoryBloodGlucose, "fasting_glucose", 100, "mg/dL", "공복 혈당 100", progressDay.AddDate(0, 1, 0))
+	other, _ := svc.SetHealthGoal(ctx, "user-1", GoalCategoryWeight, "weight", 65, "kg", "체중 65kg", progressDay.AddDate(0, 1, 0))
+
+	// 첫 기록은 시작 값: 진행률 0%
+	updated, err := svc.RecordGoalProgress(ctx, "user-1", "", "glucose", 140, progressDay)
+	if err != nil {
+		t.Fatalf("RecordGoalProgress failed: %v", err)
+	}
+	if len(updated) != 1 || updated[0].GoalID != goal.GoalID {
+		t.Fatalf("only the glucose goal should match, got %+v", updated)
+	}
+	// 다음 날 120 → (140-120)/(140-100) = 50%
+	svc.RecordGoalProgress(ctx, "user-1", "", "glucose", 120, progressDay.AddDate(0, 0, 1))
+
+	got := goalRepo.goals[goal.GoalID]
+	if got.StartValue != 140 || got.CurrentValue != 120 || got.ProgressPct != 50 {
+		t.Errorf("progress mismatch: start=%v current=%v pct=%v", got.StartValue, got.CurrentValue, got.ProgressPct)
+	}
+	if got.Streak != 2 || got.LastMilestone != 50 {
+		t.Errorf("streak/milestone mismatch: streak=%d milestone=%d", got.Streak, got.LastMilestone)
+	}
+	if got.Status != GoalStatusActive {
+		t.Errorf("goal with a target date should stay active until then, got %v", got.Status)
+	}
+	if goalRepo.goals[other.GoalID].LastProgressAt != nil {
+		t.Error("unrelated goal should not be updated")
+	}
+
+	// 25%와 50% 구간을 한 번에 넘으면 도달한 최고 구간 하나만 발행
+	if len(publisher.milestones) != 1 || publisher.milestones[0].Milestone != 50 || publisher.milestones[0].Kind != MilestoneProgress {
+		t.Errorf("expected a single 50%% milestone, got %+v", publisher.kinds())
+	}
+
+	// 이틀을 건너뛰면 연속 기록 초기화, 이미 지난 날의 기록은 무시
+	svc.RecordGoalProgress(ctx, "user-1", "", "glucose", 110, progressDay.AddDate(0, 0, 4))
+	late, _ := svc.RecordGoalProgress(ctx, "user-1", "", "glucose", 90, progressDay.AddDate(0, 0, 2))
+	got = goalRepo.goals[goal.GoalID]
+	if got.Streak != 1 || got.CurrentValue != 110 || len(late) != 0 {
+		t.Errorf("expected streak reset and late reading ignored: streak=%d current=%v", got.Streak, got.CurrentValue)
+	}
+	if got.LastMilestone != 75 {
+		t.Errorf("expected 75%% milestone, got %d", got.LastMilestone)
+	}
+}
+
+func TestRecordGoalProgress_DailyTotalWithoutTargetDate(t *testing.T) {
+	svc, goalRepo, publisher := newProgressTestService(t)
+	ctx := context.Background()
+	goal, _ := svc.SetHealthGoal(ctx, "user-1", GoalCategoryNutrition, "protein", 60, "g", "단백질 60g", time.Time{})
+
+	svc.RecordGoalProgress(ctx, "user-1", "", "Protein", 20, progressDay)
+	svc.RecordGoalProgress(ctx, "user-1", "", "protein", 25, progressDay.Add(4*time.Hour))
+	got := goalRepo.goals[goal.GoalID]
+	if got.CurrentValue != 45 || got.ProgressPct != 75 {
+		t.Errorf("same-day intake should accumulate: current=%v pct=%v", got.CurrentValue, got.ProgressPct)
+	}
+
+	// 다음 날은 새로 누적
+	svc.RecordGoalProgress(ctx, "user-1", "", "protein", 30, progressDay.AddDate(0, 0, 1))
+	if got = goalRepo.goals[goal.GoalID]; got.CurrentValue != 30 || got.Streak != 2 {
+		t.Errorf("next day should restart the daily total: current=%v streak=%d", got.CurrentValue, got.Streak)
+	}
+	svc.RecordGoalProgress(ctx, "user-1", "", "protein", 35, progressDay.AddDate(0, 0, 1).Add(time.Hour))
+
+	got = goalRepo.goals[goal.GoalID]
+	if got.Status != GoalStatusAchieved || got.AchievedAt == nil {
+		t.Errorf("goal without a target date should be achieved at 100%%, got %+v", got)
+	}
+	// 25% → 75% → 100% 진행 마일스톤 후 달성
+	want := []string{"progress", "progress", "progress", "achieved"}
+	if kinds := publisher.kinds(); fmt.Sprint(kinds) != fmt.Sprint(want) {
+		t.Errorf("milestones = %v, want %v", kinds, want)
+	}
+}
+
+func TestRecordGoalProgress_Validation(t *testing.T) {
+	svc, _, _ := newProgressTestService(t)
+	var appErr *apperrors.AppError
+	if _, err := svc.RecordGoalProgress(context.Background(), "", "", "glucose", 1, progressDay); !errors.As(err, &appErr) || appErr.Code != apperrors.ErrInvalidInput {
+		t.Errorf("expected INVALID_INPUT for empty user, got %v", err)
+	}
+	if _, err := svc.RecordGoalProgress(context.Background(), "user-1", "", " ", 1, progressDay); !errors.As(err, &appErr) || appErr.Code != apperrors.ErrInvalidInput {
+		t.Errorf("expected INVALID_INPUT for empty metric, got %v", err)
+	}
+}
+
+// ============================================================================
+// 측정·식사 이벤트 처리
+// ============================================================================
+
+func TestProcessMeasurementCompleted(t *testing.T) {
+	svc, goalRepo, _ := newProgressTestService(t)
+	ctx := context.Background()
+
+	var appErr *apperrors.AppError
+	if _, err := svc.ProcessMeasurementCompleted(ctx, "user-1", "sess-1"); !errors.As(err, &appErr) || appErr.Code != apperrors.ErrServiceUnavailable {
+		t.Errorf("expected SERVICE_UNAVAILABLE without measurement client, got %v", err)
+	}
+
+	svc.SetMeasurementClient(&fakeMeasurementClient{details: map[string]*clients.MeasurementDetail{
+		"sess-1": {SessionID: "sess-1", UserID: "user-1", Readings: []clients.MeasurementSummary{
+			{BiomarkerID: "glucose", Value: 130, Unit: "mg/dL", MeasuredAt: progressDay.Format(time.RFC3339)},
+		}},
+	}})
+	goal, _ := svc.SetHealthGoal(ctx, "user-1", GoalCategoryBloodGlucose, "glucose", 100, "mg/dL", "혈당", progressDay.AddDate(0, 1, 0))
+
+	updated, err := svc.ProcessMeasurementCompleted(ctx, "user-1", "sess-1")
+	if err != nil || len(updated) != 1 {
+		t.Fatalf("expected one updated goal, got %v %v", updated, err)
+	}
+	if got := goalRepo.goals[goal.GoalID]; got.CurrentValue != 130 || !got.LastProgressAt.Equal(progressDay) {
+		t.Errorf("measurement not applied: %+v", got)
+	}
+
+	if _, err := svc.ProcessMeasurementCompleted(ctx, "user-2", "sess-1"); !errors.As(err, &appErr) || appErr.Code != apperrors.ErrNotFound {
+		t.Errorf("expected NOT_FOUND for another user's measurement, got %v", err)
+	}
+}
+
+func TestProcessMealLogged(t *testing.T) {
+	svc, goalRepo, _ := newProgressTestService(t)
+	ctx := context.Background()
+	kcal, _ := svc.SetHealthGoal(ctx, "user-1", GoalCategoryNutrition, "daily_kcal", 2000, "kcal", "하루 2000kcal", progressDay.AddDate(0, 0, 7))
+	protein, _ := svc.SetHealthGoal(ctx, "user-1", GoalCategoryNutrition, "단백질", 60, "g", "단백질 60g", progressDay.AddDate(0, 0, 7))
+
+	_, err := svc.ProcessMealLogged(ctx, &MealLog{
+		UserID: "user-1", TotalCalorieKcal: 650, AnalyzedAt: progressDay,
+		Nutrients: map[string]float64{"단백질": 30, "지방": 20},
+	})
+	if err != nil {
+		t.Fatalf("ProcessMealLogged failed: %v", err)
+	}
+	if got := goalRepo.goals[kcal.GoalID]; got.CurrentValue != 650 {
+		t.Errorf("calorie goal should match by category metric, got %v", got.CurrentValue)
+	}
+	if got := goalRepo.goals[protein.GoalID]; got.CurrentValue != 30 || got.ProgressPct != 50 {
+		t.Errorf("nutrient goal mismatch: current=%v pct=%v", got.CurrentValue, got.ProgressPct)
+	}
+}
+
+func TestProcessMealLogged_DuplicateEventIgnored(t *testing.T) {
+	svc, goalRepo, _ := newProgressTestService(t)
+	ctx := context.Background()
+	kcal, _ := svc.SetHealthGoal(ctx, "user-1", GoalCategoryNutrition, "daily_kcal", 2000, "kcal", "하루 2000kcal", progressDay.AddDate(0, 0, 7))
+
+	meal := &MealLog{AnalysisID: "meal-1", UserID: "user-1", TotalCalorieKcal: 650, AnalyzedAt: progressDay}
+	svc.ProcessMealLogged(ctx, meal)
+	updated, err := svc.ProcessMealLogged(ctx, meal)
+	if err != nil {
+		t.Fatalf("ProcessMealLogged failed: %v", err)
+	}
+	if len(updated) != 0 {
+		t.Errorf("redelivered meal should not update goals, got %d", len(updated))
+	}
+	svc.ProcessMealLogged(ctx, &MealLog{AnalysisID: "meal-2", UserID: "user-1", TotalCalorieKcal: 350, AnalyzedAt: progressDay.Add(time.Hour)})
+	if got := goalRepo.goals[kcal.GoalID]; got.CurrentValue != 1000 {
+		t.Errorf("each meal should be counted once, got %v", got.CurrentValue)
+	}
+}
+
+// ============================================================================
+// ExpireGoals
+// ============================================================================
+
+func TestExpireGoals(t *testing.T) {
+	svc, goalRepo, publisher := newProgressTestService(t)
+	ctx := context.Background()
+	due := progressDay.AddDate(0, 0, 3)
+	achieved, _ := svc.SetHealthGoal(ctx, "user-1", GoalCategoryWeight, "weight", 70, "kg", "체중", due)
+	failed, _ := svc.SetHealthGoal(ctx, "user-1", GoalCategoryBloodGlucose, "glucose", 100, "mg/dL", "혈당", due)
+	later, _ := svc.SetHealthGoal(ctx, "user-1", GoalCategoryCholesterol, "cholesterol", 180, "mg/dL", "콜레스테롤", due.AddDate(0, 1, 0))
+
+	svc.RecordGoalProgress(ctx, "user-1", "", "weight", 75, progressDay)
+	svc.RecordGoalProgress(ctx, "user-1", "", "weight", 70, progressDay.AddDate(0, 0, 1))
+	publisher.milestones = nil
+
+	closed, err := svc.ExpireGoals(ctx, due.Add(time.Minute))
+	if err != nil {
+		t.Fatalf("ExpireGoals failed: %v", err)
+	}
+	if len(closed) != 2 {
+		t.Fatalf("expected 2 goals closed, got %d", len(closed))
+	}
+	if g := goalRepo.goals[achieved.GoalID]; g.Status != GoalStatusAchieved || g.AchievedAt == nil {
+		t.Errorf("goal at 100%% should be achieved, got %+v", g)
+	}
+	if g := goalRepo.goals[failed.GoalID]; g.Status != GoalStatusFailed || g.AchievedAt != nil {
+		t.Errorf("goal below 100%% should fail, got %+v", g)
+	}
+	if g := goalRepo.goals[later.GoalID]; g.Status != GoalStatusActive {
+		t.Errorf("goal before its target date should stay active, got %v", g.Status)
+	}
+	if len(publisher.milestones) != 2 {
+		t.Errorf("expected achieved/failed milestones, got %v", publisher.kinds())
+	}
+}
+
+// staleDueRepo는 다른 레플리카가 이미 마감한 목표를 여전히 진행 중으로 조회하는 저장소입니다.
+type staleDueRepo struct {
+	*fakeGoalRepo
+	stale []*HealthGoal
+}
+
+func (r *staleDueRepo) ListActiveDue(context.Context, time.Time) ([]*HealthGoal, error) {
+	return r.stale, nil
+}
+
+func TestExpireGoals_ClosedByAnotherReplica(t *testing.T) {
+	svc, goalRepo, publisher := newProgressTestService(t)
+	ctx := context.Background()
+	due := progressDay.AddDate(0, 0, 3)
+	goal, _ := svc.SetHealthGoal(ctx, "user-1", GoalCategoryBloodGlucose, "glucose", 100, "mg/dL", "혈당", due)
+
+	stale := *goalRepo.goals[goal.GoalID]
+	if _, err := svc.ExpireGoals(ctx, due.Add(time.Minute)); err != nil {
+		t.Fatalf("ExpireGoals failed: %v", err)
+	}
+	publisher.milestones = nil
+
+	svc.goalRepo = &staleDueRepo{fakeGoalRepo: goalRepo, stale: []*HealthGoal{&stale}}
+	closed, err := svc.ExpireGoals(ctx, due.Add(2*time.Minute))
+	if err != nil {
+		t.Fatalf("ExpireGoals failed: %v", err)
+	}
+	if len(closed) != 0 || len(publisher.milestones) != 0 {
+		t.Errorf("goal already closed elsewhere should not be closed again: closed=%d milestones=%v", len(closed), publisher.kinds())
+	}
+}
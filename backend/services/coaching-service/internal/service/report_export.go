@@ -0,0 +1,432 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/manpasik/backend/services/coaching-service/internal/report"
+	"github.com/manpasik/backend/shared/clients"
+	apperrors "github.com/manpasik/backend/shared/errors"
+	"go.uber.org/zap"
+)
+
+// ============================================================================
+// 건강 리포트 내보내기 (Health Report Export)
+// ============================================================================
+
+const (
+	// ReportURLExpiry는 리포트 다운로드 링크(presigned URL) 유효 시간입니다.
+	ReportURLExpiry = 24 * time.Hour
+	// maxReportInsights는 리포트에 싣는 AI 코칭 인사이트 수입니다.
+	maxReportInsights = 5
+	// maxReportMessages는 인사이트를 고르기 위해 훑는 최근 코칭 메시지 수입니다.
+	maxReportMessages = 500
+)
+
+// 리포트 파일 형식별 Content-Type
+const (
+	reportContentTypeHTML = "text/html; charset=utf-8"
+	reportContentTypePDF  = "application/pdf"
+)
+
+// ReportExport는 오브젝트 스토리지에 저장된 주간·월간 리포트 파일 한 벌입니다.
+type ReportExport struct {
+	ExportID    string
+	UserID      string
+	Period      report.Period
+	PeriodStart time.Time
+	PeriodEnd   time.Time // 기간 마지막 날 (포함)
+	Language    string
+	HTMLKey     string // 오브젝트 키
+	PDFKey      string
+	CreatedAt   time.Time
+
+	// 조회 시 발급하는 다운로드 링크 (저장하지 않음)
+	HTMLURL      string
+	PDFURL       string
+	URLExpiresAt time.Time
+}
+
+// ReportFileStore는 렌더링한 리포트 파일을 보관하는 오브젝트 스토리지입니다.
+type ReportFileStore interface {
+	Put(ctx context.Context, key string, data []byte, contentType string) error
+	PresignURL(ctx context.Context, key string, expiry time.Duration) (string, error)
+}
+
+// ReportExportRepository는 리포트 내보내기 기록 저장소입니다.
+type ReportExportRepository interface {
+	Save(ctx context.Context, export *ReportExport) error
+	// GetByID는 기록이 없으면 (nil, nil)을 반환합니다.
+	GetByID(ctx context.Context, exportID string) (*ReportExport, error)
+}
+
+// SetReportExporter는 리포트 파일 저장소와 내보내기 기록 저장소를 설정합니다 (optional).
+// 설정하지 않으면 리포트 내보내기가 비활성화됩니다.
+func (s *CoachingService) SetReportExporter(files ReportFileStore, repo ReportExportRepository) {
+	s.reportFiles = files
+	s.exportRepo = repo
+}
+
+// ReportPeriodRange는 periodStart가 속한 주(월요일 시작) 또는 달의 첫날과 마지막 날을 반환합니다.
+// periodStart가 비어 있으면 오늘이 속한 기간을 사용합니다.
+func ReportPeriodRange(period report.Period, periodStart time.Time) (time.Time, time.Time, error) {
+	if periodStart.IsZero() {
+		periodStart = time.Now().UTC()
+	}
+	day := time.Date(periodStart.Year(), periodStart.Month(), periodStart.Day(), 0, 0, 0, 0, time.UTC)
+	switch period {
+	case report.PeriodWeekly:
+		weekday := int(day.Weekday())
+		if weekday == 0 {
+			weekday = 7
+		}
+		start := day.AddDate(0, 0, -(weekday - 1))
+		return start, start.AddDate(0, 0, 6), nil
+	case report.PeriodMonthly:
+		start := time.Date(day.Year(), day.Month(), 1, 0, 0, 0, 0, time.UTC)
+		return start, start.AddDate(0, 1, -1), nil
+	default:
+		return time.Time{}, time.Time{}, apperrors.New(apperrors.ErrInvalidInput, "리포트 기간은 weekly 또는 monthly여야 합니다")
+	}
+}
+
+// ExportHealthReport는 주간·월간 리포트를 사용자 언어의 HTML과 PDF로 렌더링해 저장하고
+// 다운로드 링크를 반환합니다. 측정 집계·목표 진행·실천도·AI 코칭 인사이트를 담습니다.
+func (s *CoachingService) ExportHealthReport(ctx context.Context, userID string, period report.Period, periodStart time.Time, language string) (*ReportExport, error) {
+	if userID == "" {
+		return nil, apperrors.New(apperrors.ErrInvalidInput, "user_id는 필수입니다")
+	}
+	start, end, err := ReportPeriodRange(period, periodStart)
+	if err != nil {
+		return nil, err
+	}
+	if s.reportFiles == nil || s.exportRepo == nil {
+		return nil, apperrors.New(apperrors.ErrServiceUnavailable, "리포트 저장소가 설정되지 않았습니다")
+	}
+
+	language, err = s.resolveReportLanguage(ctx, userID, language)
+	if err != nil {
+		return nil, err
+	}
+	data, err := s.buildReportData(ctx, userID, period, start, end, language)
+	if err != nil {
+		return nil, err
+	}
+	htmlDoc, err := report.RenderHTML(data)
+	if err != nil {
+		s.logger.Error("리포트 HTML 렌더링 실패", zap.String("user_id", userID), zap.Error(err))
+		return nil, apperrors.New(apperrors.ErrInternal, "리포트 렌더링에 실패했습니다")
+	}
+	pdfDoc, err := report.RenderPDF(data)
+	if err != nil {
+		s.logger.Error("리포트 PDF 렌더링 실패", zap.String("user_id", userID), zap.Error(err))
+		return nil, apperrors.New(apperrors.ErrInternal, "리포트 렌더링에 실패했습니다")
+	}
+
+	export := &ReportExport{
+		ExportID:    data.ReportID,
+		UserID:      userID,
+		Period:      period,
+		PeriodStart: start,
+		PeriodEnd:   end,
+		Language:    data.Language,
+		CreatedAt:   data.GeneratedAt,
+	}
+	prefix := fmt.Sprintf("reports/%s/%s/%s", userID, export.ExportID, data.FileName())
+	export.HTMLKey, export.PDFKey = prefix+".html", prefix+".pdf"
+	if err := s.reportFiles.Put(ctx, export.HTMLKey, htmlDoc, reportContentTypeHTML); err != nil {
+		s.logger.Error("리포트 HTML 업로드 실패", zap.String("key", export.HTMLKey), zap.Error(err))
+		return nil, apperrors.New(apperrors.ErrServiceUnavailable, "리포트 저장에 실패했습니다")
+	}
+	if err := s.reportFiles.Put(ctx, export.PDFKey, pdfDoc, reportContentTypePDF); err != nil {
+		s.logger.Error("리포트 PDF 업로드 실패", zap.String("key", export.PDFKey), zap.Error(err))
+		return nil, apperrors.New(apperrors.ErrServiceUnavailable, "리포트 저장에 실패했습니다")
+	}
+	if err := s.exportRepo.Save(ctx, export); err != nil {
+		s.logger.Error("리포트 내보내기 기록 저장 실패", zap.String("export_id", export.ExportID), zap.Error(err))
+		return nil, apperrors.New(apperrors.ErrInternal, "리포트 저장에 실패했습니다")
+	}
+	if err := s.presignReport(ctx, export); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("건강 리포트 내보내기 완료",
+		zap.String("user_id", userID),
+		zap.String("export_id", export.ExportID),
+		zap.String("period", string(period)),
+	)
+	return export, nil
+}
+
+// GetHealthReportExport는 저장된 리포트의 새 다운로드 링크를 발급합니다.
+// userID가 주어지면 리포트 소유자와 같아야 합니다.
+func (s *CoachingService) GetHealthReportExport(ctx context.Context, exportID, userID string) (*ReportExport, error) {
+	if exportID == "" {
+		return nil, apperrors.New(apperrors.ErrInvalidInput, "export_id는 필수입니다")
+	}
+	if s.reportFiles == nil || s.exportRepo == nil {
+		return nil, apperrors.New(apperrors.ErrServiceUnavailable, "리포트 저장소가 설정되지 않았습니다")
+	}
+	export, err := s.exportRepo.GetByID(ctx, exportID)
+	if err != nil {
+		s.logger.Error("리포트 내보내기 기록 조회 실패", zap.String("export_id", exportID), zap.Error(err))
+		return nil, apperrors.New(apperrors.ErrInternal, "리포트 조회에 실패했습니다")
+	}
+	if export == nil || (userID != "" && export.UserID != userID) {
+		return nil, apperrors.New(apperrors.ErrNotFound, "리포트를 찾을 수 없습니다")
+	}
+	if err := s.presignReport(ctx, export); err != nil {
+		return nil, err
+	}
+	return export, nil
+}
+
+// presignReport는 HTML·PDF 다운로드 링크를 발급합니다.
+func (s *CoachingService) presignReport(ctx context.Context, export *ReportExport) error {
+	htmlURL, err := s.reportFiles.PresignURL(ctx, export.HTMLKey, ReportURLExpiry)
+	if err == nil {
+		export.PDFURL, err = s.reportFiles.PresignURL(ctx, export.PDFKey, ReportURLExpiry)
+	}
+	if err != nil {
+		s.logger.Error("리포트 다운로드 링크 발급 실패", zap.String("export_id", export.ExportID), zap.Error(err))
+		return apperrors.New(apperrors.ErrServiceUnavailable, "리포트 다운로드 링크 발급에 실패했습니다")
+	}
+	export.HTMLURL = htmlURL
+	export.URLExpiresAt = time.Now().UTC().Add(ReportURLExpiry)
+	return nil
+}
+
+// resolveReportLanguage는 리포트 언어를 정합니다. 요청한 언어를 렌더링할 수 없으면 거부하고,
+// 요청이 없으면 프로필 언어를, 프로필 언어도 지원하지 않으면 기본 언어를 사용합니다.
+func (s *CoachingService) resolveReportLanguage(ctx context.Context, userID, language string) (string, error) {
+	if strings.TrimSpace(language) != "" {
+		lang := baseLanguage(language)
+		if !report.Supported(lang) {
+			return "", apperrors.New(apperrors.ErrInvalidInput, "지원하지 않는 리포트 언어입니다: "+language)
+		}
+		return lang, nil
+	}
+	if s.profiles != nil {
+		preferred, err := s.profiles.GetLanguage(ctx, userID)
+		if err != nil {
+			s.logger.Warn("사용자 언어 조회 실패", zap.String("user_id", userID), zap.Error(err))
+		} else if lang := baseLanguage(preferred); report.Supported(lang) {
+			return lang, nil
+		}
+	}
+	return defaultCoachingLanguage, nil
+}
+
+// buildReportData는 기간 중 측정·목표·코칭 메시지를 리포트 내용으로 모읍니다.
+func (s *CoachingService) buildReportData(ctx context.Context, userID string, period report.Period, start, end time.Time, language string) (*report.Data, error) {
+	data := &report.Data{
+		ReportID:    uuid.New().String(),
+		UserID:      userID,
+		Period:      period,
+		Start:       start,
+		End:         end,
+		Language:    language,
+		GeneratedAt: time.Now().UTC(),
+	}
+	periodEnd := end.AddDate(0, 0, 1) // 마지막 날 포함
+	data.Adherence.PeriodDays = len(data.Days())
+
+	if s.measurements != nil {
+		readings, err := s.measurements.GetMeasurementsInRange(ctx, userID, start, periodEnd.Add(-time.Nanosecond))
+		if err != nil {
+			s.logger.Error("리포트 측정 조회 실패", zap.String("user_id", userID), zap.Error(err))
+			return nil, apperrors.New(apperrors.ErrServiceUnavailable, "측정 기록 조회에 실패했습니다")
+		}
+		data.Metrics, data.Adherence.MeasurementDays = aggregateReportMetrics(readings, language)
+	}
+
+	goals, err := s.goalRepo.GetByUserID(ctx, userID, GoalStatusUnknown)
+	if err != nil {
+		s.logger.Error("리포트 목표 조회 실패", zap.String("user_id", userID), zap.Error(err))
+		return nil, apperrors.New(apperrors.ErrInternal, "건강 목표 조회에 실패했습니다")
+	}
+	for _, g := range goals {
+		if !goalInReport(g, start, periodEnd) {
+			continue
+		}
+		switch g.Status {
+		case GoalStatusActive:
+			data.Adherence.GoalsActive++
+			if g.Streak > data.Adherence.BestStreak {
+				data.Adherence.BestStreak = g.Streak
+			}
+		case GoalStatusAchieved:
+			data.Adherence.GoalsAchieved++
+		}
+		data.Goals = append(data.Goals, report.Goal{
+			Name:        localizeMetric(g.MetricName, language),
+			Unit:        g.Unit,
+			Status:      goalStatusLabel(g.Status, language),
+			Current:     g.CurrentValue,
+			Target:      g.TargetValue,
+			ProgressPct: g.ProgressPct,
+			Streak:      g.Streak,
+		})
+	}
+
+	msgs, _, err := s.msgRepo.ListByUserID(ctx, userID, CoachingTypeUnknown, maxReportMessages, 0)
+	if err != nil {
+		s.logger.Warn("리포트 코칭 메시지 조회 실패", zap.String("user_id", userID), zap.Error(err))
+	}
+	data.Insights = reportInsights(msgs, start, periodEnd)
+	return data, nil
+}
+
+// goalInReport는 목표가 기간 리포트에 실릴지 판단합니다.
+// 진행 중·일시 중지 목표와, 기간 중 달성하거나 목표일이 지난 목표를 싣습니다.
+func goalInReport(g *HealthGoal, start, end time.Time) bool {
+	if !g.CreatedAt.Before(end) {
+		return false
+	}
+	switch g.Status {
+	case GoalStatusActive, GoalStatusPaused:
+		return true
+	case GoalStatusAchieved:
+		return g.AchievedAt != nil && !g.AchievedAt.Before(start) && g.AchievedAt.Before(end)
+	case GoalStatusFailed:
+		return !g.TargetDate.Before(start) && g.TargetDate.Before(end)
+	default:
+		return false
+	}
+}
+
+// aggregateReportMetrics는 측정값을 바이오마커별 일별 평균과 기간 통계로 집계하고 측정한 날 수를 함께 반환합니다.
+func aggregateReportMetrics(readings []clients.MeasurementSummary, language string) ([]report.Metric, int) {
+	type dayAgg struct {
+		sum   float64
+		count int
+	}
+	type metricAgg struct {
+		metric report.Metric
+		sum    float64
+		days   map[time.Time]*dayAgg
+	}
+	byID := make(map[string]*metricAgg)
+	measuredDays := make(map[time.Time]bool)
+	for _, r := range readings {
+		at, ok := parseMeasuredAt(r.MeasuredAt)
+		if !ok || r.BiomarkerID == "" {
+			continue
+		}
+		day := time.Date(at.Year(), at.Month(), at.Day(), 0, 0, 0, 0, time.UTC)
+		measuredDays[day] = true
+
+		agg := byID[r.BiomarkerID]
+		if agg == nil {
+			agg = &metricAgg{
+				metric: report.Metric{Name: localizeMetric(r.BiomarkerID, language), Unit: r.Unit, Min: r.Value, Max: r.Value},
+				days:   make(map[time.Time]*dayAgg),
+			}
+			byID[r.BiomarkerID] = agg
+		}
+		agg.metric.Count++
+		agg.sum += r.Value
+		agg.metric.Min = math.Min(agg.metric.Min, r.Value)
+		agg.metric.Max = math.Max(agg.metric.Max, r.Value)
+		if agg.days[day] == nil {
+			agg.days[day] = &dayAgg{}
+		}
+		agg.days[day].sum += r.Value
+		agg.days[day].count++
+	}
+
+	metrics := make([]report.Metric, 0, len(byID))
+	for _, agg := range byID {
+		m := agg.metric
+		m.Avg = math.Round(agg.sum/float64(m.Count)*10) / 10
+		for day, d := range agg.days {
+			m.Points = append(m.Points, report.Point{Date: day, Value: math.Round(d.sum/float64(d.count)*10) / 10})
+		}
+		sort.Slice(m.Points, func(i, j int) bool { return m.Points[i].Date.Before(m.Points[j].Date) })
+		metrics = append(metrics, m)
+	}
+	sort.Slice(metrics, func(i, j int) bool { return metrics[i].Name < metrics[j].Name })
+	return metrics, len(measuredDays)
+}
+
+// parseMeasuredAt은 측정 시각 문자열(RFC3339 또는 날짜)을 UTC 시각으로 바꿉니다.
+func parseMeasuredAt(s string) (time.Time, bool) {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t.UTC(), true
+	}
+	if len(s) >= len("2006-01-02") {
+		if t, err := time.Parse("2006-01-02", s[:len("2006-01-02")]); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// reportInsights는 기간 중 생성된 코칭 메시지 가운데 최근 것부터 인사이트로 고릅니다.
+// 일반 팁·동기부여 메시지는 개인 기록에 근거하지 않으므로 제외합니다.
+func reportInsights(msgs []*CoachingMessage, start, end time.Time) []string {
+	var picked []*CoachingMessage
+	for _, m := range msgs {
+		if m.CreatedAt.Before(start) || !m.CreatedAt.Before(end) || m.Body == "" {
+			continue
+		}
+		if m.CoachingType == CoachingTypeDailyTip || m.CoachingType == CoachingTypeMotivation {
+			continue
+		}
+		picked = append(picked, m)
+	}
+	sort.SliceStable(picked, func(i, j int) bool { return picked[i].CreatedAt.After(picked[j].CreatedAt) })
+	if len(picked) > maxReportInsights {
+		picked = picked[:maxReportInsights]
+	}
+	insights := make([]string, 0, len(picked))
+	for _, m := range picked {
+		insights = append(insights, m.Body)
+	}
+	return insights
+}
+
+// goalStatusLabels는 리포트에 표시할 목표 상태 이름입니다.
+var goalStatusLabels = map[string]map[GoalStatus]string{
+	"ko": {
+		GoalStatusActive:    "진행 중",
+		GoalStatusAchieved:  "달성",
+		GoalStatusPaused:    "일시 중지",
+		GoalStatusCancelled: "취소",
+		GoalStatusFailed:    "미달성",
+	},
+	"en": {
+		GoalStatusActive:    "In progress",
+		GoalStatusAchieved:  "Achieved",
+		GoalStatusPaused:    "Paused",
+		GoalStatusCancelled: "Cancelled",
+		GoalStatusFailed:    "Not achieved",
+	},
+	"ja": {
+		GoalStatusActive:    "進行中",
+		GoalStatusAchieved:  "達成",
+		GoalStatusPaused:    "一時停止",
+		GoalStatusCancelled: "取り消し",
+		GoalStatusFailed:    "未達成",
+	},
+	"zh": {
+		GoalStatusActive:    "进行中",
+		GoalStatusAchieved:  "已达成",
+		GoalStatusPaused:    "已暂停",
+		GoalStatusCancelled: "已取消",
+		GoalStatusFailed:    "未达成",
+	},
+}
+
+func goalStatusLabel(status GoalStatus, language string) string {
+	labels, ok := goalStatusLabels[language]
+	if !ok {
+		labels = goalStatusLabels[defaultCoachingLanguage]
+	}
+	return labels[status]
+}
@@ -0,0 +1,203 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/manpasik/backend/services/coaching-service/internal/report"
+	"github.com/manpasik/backend/shared/clients"
+	apperrors "github.com/manpasik/backend/shared/errors"
+)
+
+// ============================================================================
+// 테스트용 Fake 저장소
+// ============================================================================
+
+type fakeReportFiles struct {
+	objects      map[string][]byte
+	contentTypes map[string]string
+}
+
+func newFakeReportFiles() *fakeReportFiles {
+	return &fakeReportFiles{objects: make(map[string][]byte), contentTypes: make(map[string]string)}
+}
+
+func (f *fakeReportFiles) Put(_ context.Context, key string, data []byte, contentType string) error {
+	f.objects[key] = data
+	f.contentTypes[key] = contentType
+	return nil
+}
+
+func (f *fakeReportFiles) PresignURL(_ context.Context, key string, _ time.Duration) (string, error) {
+	if _, ok := f.objects[key]; !ok {
+		return "", errors.New("no such key")
+	}
+	return "https://s3.test/" + key + "?X-Amz-Signature=x", nil
+}
+
+type fakeExportRepo struct {
+	exports map[string]*ReportExport
+}
+
+func (r *fakeExportRepo) Save(_ context.Context, e *ReportExport) error {
+	cp := *e
+	r.exports[e.ExportID] = &cp
+	return nil
+}
+
+func (r *fakeExportRepo) GetByID(_ context.Context, id string) (*ReportExport, error) {
+	e, ok := r.exports[id]
+	if !ok {
+		return nil, nil
+	}
+	cp := *e
+	return &cp, nil
+}
+
+// newExportTestService는 2026-03-02 주에 혈당 측정 3회, 진행 중 목표 1개, 코칭 메시지 2개가 있는 서비스를 만듭니다.
+func newExportTestService(t *testing.T) (*CoachingService, *fakeReportFiles) {
+	t.Helper()
+	svc, goalRepo, msgRepo, _ := newTestCoachingService()
+	files := newFakeReportFiles()
+	svc.SetReportExporter(files, &fakeExportRepo{exports: make(map[string]*ReportExport)})
+
+	week := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	goalRepo.goals["goal-1"] = &HealthGoal{
+		GoalID: "goal-1", UserID: "user-1", Category: GoalCategoryWeight, MetricName: "체중",
+		TargetValue: 68, CurrentValue: 72, Unit: "kg", ProgressPct: 40, Status: GoalStatusActive,
+		CreatedAt: week.AddDate(0, -1, 0), Streak: 4,
+	}
+	svc.SetMeasurementClient(&fakeMeasurementClient{inRange: []clients.MeasurementSummary{
+		{BiomarkerID: "glucose", Value: 100, Unit: "mg/dL", MeasuredAt: week.Add(8 * time.Hour).Format(time.RFC3339)},
+		{BiomarkerID: "glucose", Value: 110, Unit: "mg/dL", MeasuredAt: week.Add(20 * time.Hour).Format(time.RFC3339)},
+		{BiomarkerID: "glucose", Value: 96, Unit: "mg/dL", MeasuredAt: week.AddDate(0, 0, 3).Format(time.RFC3339)},
+	}})
+	msgRepo.messages = append(msgRepo.messages,
+		&CoachingMessage{UserID: "user-1", CoachingType: CoachingTypeMeasurementFeedback, Body: "식후 혈당이 안정적입니다", CreatedAt: week.AddDate(0, 0, 1)},
+		&CoachingMessage{UserID: "user-1", CoachingType: CoachingTypeDailyTip, Body: "물을 충분히 드세요", CreatedAt: week.AddDate(0, 0, 2)},
+		&CoachingMessage{UserID: "user-1", CoachingType: CoachingTypeAlert, Body: "지난주 메시지", CreatedAt: week.AddDate(0, 0, -1)},
+	)
+	return svc, files
+}
+
+// ============================================================================
+// ExportHealthReport
+// ============================================================================
+
+func TestExportHealthReport_Weekly(t *testing.T) {
+	svc, files := newExportTestService(t)
+	ctx := context.Background()
+
+	export, err := svc.ExportHealthReport(ctx, "user-1", report.PeriodWeekly, time.Date(2026, 3, 5, 0, 0, 0, 0, time.UTC), "")
+	if err != nil {
+		t.Fatalf("ExportHealthReport 실패: %v", err)
+	}
+	if !export.PeriodStart.Equal(time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)) || !export.PeriodEnd.Equal(time.Date(2026, 3, 8, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("기간 = %v ~ %v, 2026-03-02 ~ 2026-03-08 기대", export.PeriodStart, export.PeriodEnd)
+	}
+	if export.Language != "ko" || export.HTMLURL == "" || export.PDFURL == "" || export.URLExpiresAt.IsZero() {
+		t.Errorf("내보내기 결과 = %+v", export)
+	}
+	if !strings.HasSuffix(export.PDFKey, "health-report-weekly-2026-03-02.pdf") || files.contentTypes[export.PDFKey] != "application/pdf" {
+		t.Errorf("PDF 키/형식 = %s / %s", export.PDFKey, files.contentTypes[export.PDFKey])
+	}
+
+	html := string(files.objects[export.HTMLKey])
+	for _, want := range []string{"주간 건강 리포트", "glucose", "102", "측정한 날 2 / 7일", "체중", "진행 중", "식후 혈당이 안정적입니다"} {
+		if !strings.Contains(html, want) {
+			t.Errorf("HTML에 %q 없음", want)
+		}
+	}
+	for _, unwanted := range []string{"물을 충분히 드세요", "지난주 메시지"} {
+		if strings.Contains(html, unwanted) {
+			t.Errorf("HTML에 제외할 메시지 %q 포함", unwanted)
+		}
+	}
+	if !strings.HasPrefix(string(files.objects[export.PDFKey]), "%PDF-") {
+		t.Error("PDF 파일이 아님")
+	}
+}
+
+func TestExportHealthReport_MonthlyEnglish(t *testing.T) {
+	svc, files := newExportTestService(t)
+
+	export, err := svc.ExportHealthReport(context.Background(), "user-1", report.PeriodMonthly, time.Date(2026, 2, 14, 0, 0, 0, 0, time.UTC), "en-US")
+	if err != nil {
+		t.Fatalf("ExportHealthReport 실패: %v", err)
+	}
+	if !export.PeriodEnd.Equal(time.Date(2026, 2, 28, 0, 0, 0, 0, time.UTC)) || export.Language != "en" {
+		t.Errorf("기간 끝/언어 = %v / %s", export.PeriodEnd, export.Language)
+	}
+	html := string(files.objects[export.HTMLKey])
+	for _, want := range []string{"Monthly Health Report", "weight", "In progress"} {
+		if !strings.Contains(html, want) {
+			t.Errorf("HTML에 %q 없음", want)
+		}
+	}
+}
+
+func TestExportHealthReport_Japanese(t *testing.T) {
+	svc, files := newExportTestService(t)
+
+	export, err := svc.ExportHealthReport(context.Background(), "user-1", report.PeriodWeekly, time.Date(2026, 3, 5, 0, 0, 0, 0, time.UTC), "ja-JP")
+	if err != nil {
+		t.Fatalf("ExportHealthReport 실패: %v", err)
+	}
+	if export.Language != "ja" {
+		t.Errorf("언어 = %s, want ja", export.Language)
+	}
+	if html := string(files.objects[export.HTMLKey]); !strings.Contains(html, "週間健康レポート") || !strings.Contains(html, "進行中") {
+		t.Errorf("일본어 리포트가 아님: %s", html)
+	}
+	if pdf := files.objects[export.PDFKey]; !strings.Contains(string(pdf), "/UniJIS-UCS2-H") {
+		t.Error("일본어 PDF에 Japan1 폰트가 없음")
+	}
+}
+
+func TestExportHealthReport_Errors(t *testing.T) {
+	svc, _ := newExportTestService(t)
+	ctx := context.Background()
+
+	if _, err := svc.ExportHealthReport(ctx, "user-1", "daily", time.Time{}, ""); !hasCode(err, apperrors.ErrInvalidInput) {
+		t.Errorf("잘못된 기간: %v, INVALID_INPUT 기대", err)
+	}
+	if _, err := svc.ExportHealthReport(ctx, "user-1", report.PeriodWeekly, time.Time{}, "fr"); !hasCode(err, apperrors.ErrInvalidInput) {
+		t.Errorf("지원하지 않는 언어: %v, INVALID_INPUT 기대", err)
+	}
+	bare, _, _, _ := newTestCoachingService()
+	if _, err := bare.ExportHealthReport(ctx, "user-1", report.PeriodWeekly, time.Time{}, ""); !hasCode(err, apperrors.ErrServiceUnavailable) {
+		t.Errorf("저장소 미설정: %v, SERVICE_UNAVAILABLE 기대", err)
+	}
+}
+
+// ============================================================================
+// GetHealthReportExport
+// ============================================================================
+
+func TestGetHealthReportExport_Ownership(t *testing.T) {
+	svc, _ := newExportTestService(t)
+	ctx := context.Background()
+	export, err := svc.ExportHealthReport(ctx, "user-1", report.PeriodWeekly, time.Time{}, "")
+	if err != nil {
+		t.Fatalf("ExportHealthReport 실패: %v", err)
+	}
+
+	got, err := svc.GetHealthReportExport(ctx, export.ExportID, "user-1")
+	if err != nil || got.PDFURL == "" || got.HTMLKey != export.HTMLKey {
+		t.Fatalf("GetHealthReportExport = %+v, %v", got, err)
+	}
+	if _, err := svc.GetHealthReportExport(ctx, export.ExportID, "user-2"); !hasCode(err, apperrors.ErrNotFound) {
+		t.Errorf("다른 사용자 조회: %v, NOT_FOUND 기대", err)
+	}
+	if _, err := svc.GetHealthReportExport(ctx, "missing", ""); !hasCode(err, apperrors.ErrNotFound) {
+		t.Errorf("없는 리포트: %v, NOT_FOUND 기대", err)
+	}
+}
+
+func hasCode(err error, code apperrors.ErrorCode) bool {
+	var appErr *apperrors.AppError
+	return errors.As(err, &appErr) && appErr.Code == code
+}
@@ -0,0 +1,172 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/manpasik/backend/shared/clients"
+	apperrors "github.com/manpasik/backend/shared/errors"
+	"github.com/manpasik/backend/shared/scheduler"
+	"go.uber.org/zap"
+)
+
+// ============================================================================
+// 일일 코칭 스케줄 (Daily Coaching Schedule)
+// ============================================================================
+
+// 스케줄 종류 (shared/scheduler Kind)
+const (
+	ScheduleKindDailyTip    = "coaching.daily_tip"
+	ScheduleKindDailyReport = "coaching.daily_report"
+)
+
+const (
+	// DefaultDailyTipTime은 일일 팁 기본 발송 시각(사용자 현지)입니다.
+	DefaultDailyTipTime = "08:00"
+	// DefaultDailyReportTime은 일일 리포트 기본 발송 시각(사용자 현지)입니다.
+	DefaultDailyReportTime = "21:00"
+	// ScheduleOff는 해당 일일 코칭을 끄는 시각 값입니다.
+	ScheduleOff = "off"
+)
+
+// CoachingSchedule은 사용자의 일일 코칭 발송 설정입니다. 꺼진 항목은 시각이 빈 값입니다.
+type CoachingSchedule struct {
+	UserID            string
+	DailyTipTime      string // 현지 "HH:MM"
+	DailyReportTime   string
+	Timezone          string // 스케줄 계산에 쓴 IANA 시간대
+	NextDailyTipAt    time.Time
+	NextDailyReportAt time.Time // 방해 금지 시간을 반영한 다음 발송 시각
+}
+
+// SetScheduler는 일일 팁·리포트 스케줄러를 설정하고 핸들러를 등록합니다 (optional).
+func (s *CoachingService) SetScheduler(sch *scheduler.Scheduler) {
+	s.scheduler = sch
+	sch.Handle(ScheduleKindDailyTip, s.runDailyTip)
+	sch.Handle(ScheduleKindDailyReport, s.runDailyReport)
+}
+
+// SetNotificationClient는 예약 코칭 메시지 발송용 알림 클라이언트를 설정합니다 (optional).
+func (s *CoachingService) SetNotificationClient(c clients.NotificationClient) {
+	s.notifier = c
+}
+
+func dailyScheduleID(kind, userID string) string {
+	return kind + ":" + userID
+}
+
+// SetCoachingSchedule은 일일 팁·리포트를 사용자 현지 시각 tipTime·reportTime에 보내도록 설정합니다.
+// 빈 값은 기본 시각, ScheduleOff는 해당 발송 해제를 뜻합니다.
+func (s *CoachingService) SetCoachingSchedule(ctx context.Context, userID, tipTime, reportTime string) (*CoachingSchedule, error) {
+	if userID == "" {
+		return nil, apperrors.New(apperrors.ErrInvalidInput, "user_id는 필수입니다")
+	}
+	if s.scheduler == nil {
+		return nil, apperrors.New(apperrors.ErrServiceUnavailable, "코칭 스케줄러가 비활성화되어 있습니다")
+	}
+	if err := s.putDailySchedule(ctx, ScheduleKindDailyTip, userID, tipTime, DefaultDailyTipTime); err != nil {
+		return nil, err
+	}
+	if err := s.putDailySchedule(ctx, ScheduleKindDailyReport, userID, reportTime, DefaultDailyReportTime); err != nil {
+		return nil, err
+	}
+	return s.GetCoachingSchedule(ctx, userID)
+}
+
+// GetCoachingSchedule은 사용자의 일일 코칭 발송 설정을 반환합니다.
+func (s *CoachingService) GetCoachingSchedule(ctx context.Context, userID string) (*CoachingSchedule, error) {
+	if userID == "" {
+		return nil, apperrors.New(apperrors.ErrInvalidInput, "user_id는 필수입니다")
+	}
+	if s.scheduler == nil {
+		return nil, apperrors.New(apperrors.ErrServiceUnavailable, "코칭 스케줄러가 비활성화되어 있습니다")
+	}
+	out := &CoachingSchedule{UserID: userID}
+	tip, err := s.scheduler.Get(ctx, dailyScheduleID(ScheduleKindDailyTip, userID))
+	if err != nil {
+		s.logger.Error("일일 팁 스케줄 조회 실패", zap.String("user_id", userID), zap.Error(err))
+		return nil, apperrors.New(apperrors.ErrInternal, "코칭 스케줄 조회에 실패했습니다")
+	}
+	if tip != nil {
+		out.DailyTipTime, out.NextDailyTipAt, out.Timezone = strings.Join(tip.TimesOfDay, ","), tip.NextRunAt, tip.Timezone
+	}
+	report, err := s.scheduler.Get(ctx, dailyScheduleID(ScheduleKindDailyReport, userID))
+	if err != nil {
+		s.logger.Error("일일 리포트 스케줄 조회 실패", zap.String("user_id", userID), zap.Error(err))
+		return nil, apperrors.New(apperrors.ErrInternal, "코칭 스케줄 조회에 실패했습니다")
+	}
+	if report != nil {
+		out.DailyReportTime, out.NextDailyReportAt, out.Timezone = strings.Join(report.TimesOfDay, ","), report.NextRunAt, report.Timezone
+	}
+	return out, nil
+}
+
+// putDailySchedule은 일일 코칭 스케줄 하나를 등록·변경하거나 해제합니다.
+func (s *CoachingService) putDailySchedule(ctx context.Context, kind, userID, at, fallback string) error {
+	id := dailyScheduleID(kind, userID)
+	at = strings.TrimSpace(at)
+	if strings.EqualFold(at, ScheduleOff) {
+		if err := s.scheduler.Remove(ctx, id); err != nil {
+			s.logger.Error("코칭 스케줄 해제 실패", zap.String("schedule_id", id), zap.Error(err))
+			return apperrors.New(apperrors.ErrInternal, "코칭 스케줄 해제에 실패했습니다")
+		}
+		return nil
+	}
+	if at == "" {
+		at = fallback
+	}
+	if _, err := s.scheduler.Put(ctx, &scheduler.Schedule{ID: id, Kind: kind, UserID: userID, TimesOfDay: []string{at}}); err != nil {
+		return err
+	}
+	return nil
+}
+
+// ensureDailyCoaching은 사용자의 첫 건강 목표가 만들어지면 기본 시각 일일 코칭 스케줄을 등록합니다.
+// 이후 목표에서는 다시 등록하지 않으므로 사용자가 ScheduleOff로 해제한 설정이 유지됩니다.
+func (s *CoachingService) ensureDailyCoaching(ctx context.Context, userID string) {
+	if s.scheduler == nil {
+		return
+	}
+	goals, err := s.goalRepo.GetByUserID(ctx, userID, GoalStatusUnknown)
+	if err != nil || len(goals) != 1 {
+		return
+	}
+	if _, err := s.SetCoachingSchedule(ctx, userID, "", ""); err != nil {
+		s.logger.Warn("기본 일일 코칭 스케줄 등록 실패", zap.String("user_id", userID), zap.Error(err))
+	}
+}
+
+// runDailyTip은 예약된 일일 팁을 생성해 알림으로 보냅니다.
+func (s *CoachingService) runDailyTip(ctx context.Context, run scheduler.Run) error {
+	msg, err := s.GenerateCoaching(ctx, run.Schedule.UserID, "", CoachingTypeDailyTip, "")
+	if err != nil {
+		return err
+	}
+	return s.notifyScheduled(ctx, run, msg.Title, msg.Body)
+}
+
+// runDailyReport는 사용자 현지 날짜의 일일 리포트를 생성해 요약을 알림으로 보냅니다.
+func (s *CoachingService) runDailyReport(ctx context.Context, run scheduler.Run) error {
+	local := run.Local
+	report, err := s.GenerateDailyReport(ctx, run.Schedule.UserID, time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		return err
+	}
+	title := fmt.Sprintf("%s 건강 리포트 (%.0f점)", local.Format("1/2"), report.OverallScore)
+	return s.notifyScheduled(ctx, run, title, report.Summary)
+}
+
+// notifyScheduled는 예약 코칭 메시지를 알림으로 보냅니다. 알림 클라이언트가 없으면 생성만 하고 기록을 남깁니다.
+func (s *CoachingService) notifyScheduled(ctx context.Context, run scheduler.Run, title, body string) error {
+	if s.notifier == nil {
+		s.logger.Info("예약 코칭 생성 완료 (알림 클라이언트 없음)", zap.String("run", run.Key()))
+		return nil
+	}
+	if err := s.notifier.SendNotification(ctx, run.Schedule.UserID, "system", title, body, "normal", "push"); err != nil {
+		s.logger.Warn("예약 코칭 알림 발송 실패", zap.String("run", run.Key()), zap.Int("attempt", run.Attempt), zap.Error(err))
+		return err
+	}
+	return nil
+}
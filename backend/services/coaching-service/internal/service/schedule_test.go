@@ -0,0 +1,117 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/manpasik/backend/shared/scheduler"
+)
+
+type sentNotification struct {
+	userID, notifType, title, body string
+}
+
+type fakeNotifier struct {
+	sent []sentNotification
+}
+
+func (n *fakeNotifier) SendNotification(_ context.Context, userID, notifType, title, body, _, _ string) error {
+	n.sent = append(n.sent, sentNotification{userID: userID, notifType: notifType, title: title, body: body})
+	return nil
+}
+
+type fakeScheduleSettings struct {
+	settings scheduler.Settings
+}
+
+func (f fakeScheduleSettings) UserSettings(context.Context, string) (scheduler.Settings, error) {
+	return f.settings, nil
+}
+
+// newScheduledTestService는 서울 시간대·방해 금지 시간(22:00~07:00) 사용자의 스케줄러를 붙인 서비스를 만듭니다.
+func newScheduledTestService(t *testing.T, now *time.Time) (*CoachingService, *scheduler.Scheduler, *fakeNotifier) {
+	t.Helper()
+	if _, err := time.LoadLocation("Asia/Seoul"); err != nil {
+		t.Skipf("Asia/Seoul 시간대 없음: %v", err)
+	}
+	svc, _, _, _ := newTestCoachingService()
+	sch := scheduler.New(scheduler.NewMemoryStore(),
+		scheduler.WithClock(func() time.Time { return *now }),
+		scheduler.WithSettings(fakeScheduleSettings{scheduler.Settings{
+			Timezone: "Asia/Seoul", QuietHoursStart: "22:00", QuietHoursEnd: "07:00", HasQuietHours: true,
+		}}),
+	)
+	notifier := &fakeNotifier{}
+	svc.SetScheduler(sch)
+	svc.SetNotificationClient(notifier)
+	return svc, sch, notifier
+}
+
+func TestSetHealthGoal_RegistersDefaultCoachingSchedule(t *testing.T) {
+	now := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC) // 09:00 KST
+	svc, _, _ := newScheduledTestService(t, &now)
+	ctx := context.Background()
+
+	if _, err := svc.SetHealthGoal(ctx, "user-1", GoalCategoryExercise, "steps", 8000, "steps", "", time.Time{}); err != nil {
+		t.Fatalf("SetHealthGoal 실패: %v", err)
+	}
+	sched, err := svc.GetCoachingSchedule(ctx, "user-1")
+	if err != nil {
+		t.Fatalf("GetCoachingSchedule 실패: %v", err)
+	}
+	if sched.DailyTipTime != DefaultDailyTipTime || sched.DailyReportTime != DefaultDailyReportTime {
+		t.Errorf("기본 시각 = (%s, %s), want (%s, %s)", sched.DailyTipTime, sched.DailyReportTime, DefaultDailyTipTime, DefaultDailyReportTime)
+	}
+	if sched.Timezone != "Asia/Seoul" {
+		t.Errorf("Timezone = %q, want Asia/Seoul", sched.Timezone)
+	}
+	// 오늘 08:00 KST는 지났으므로 다음 팁은 내일 08:00 KST(= 3/2 23:00 UTC)
+	if want := time.Date(2026, 3, 2, 23, 0, 0, 0, time.UTC); !sched.NextDailyTipAt.Equal(want) {
+		t.Errorf("NextDailyTipAt = %v, want %v", sched.NextDailyTipAt, want)
+	}
+
+	// 해제한 뒤 목표를 추가해도 다시 등록하지 않음
+	if _, err := svc.SetCoachingSchedule(ctx, "user-1", ScheduleOff, ScheduleOff); err != nil {
+		t.Fatalf("SetCoachingSchedule 실패: %v", err)
+	}
+	if _, err := svc.SetHealthGoal(ctx, "user-1", GoalCategoryWeight, "weight", 70, "kg", "", time.Time{}); err != nil {
+		t.Fatalf("SetHealthGoal 실패: %v", err)
+	}
+	sched, _ = svc.GetCoachingSchedule(ctx, "user-1")
+	if sched.DailyTipTime != "" || sched.DailyReportTime != "" {
+		t.Errorf("해제 후 스케줄 = (%s, %s), want 모두 비어 있음", sched.DailyTipTime, sched.DailyReportTime)
+	}
+}
+
+func TestSetCoachingSchedule_QuietHoursDeferAndDelivery(t *testing.T) {
+	now := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC) // 09:00 KST
+	svc, sch, notifier := newScheduledTestService(t, &now)
+	ctx := context.Background()
+
+	sched, err := svc.SetCoachingSchedule(ctx, "user-1", "06:30", "23:00")
+	if err != nil {
+		t.Fatalf("SetCoachingSchedule 실패: %v", err)
+	}
+	// 06:30·23:00 KST는 방해 금지 시간이므로 07:00 KST(= 22:00 UTC 전날)로 미뤄짐
+	want := time.Date(2026, 3, 2, 22, 0, 0, 0, time.UTC)
+	if !sched.NextDailyTipAt.Equal(want) || !sched.NextDailyReportAt.Equal(want) {
+		t.Errorf("다음 발송 = (%v, %v), want 둘 다 %v", sched.NextDailyTipAt, sched.NextDailyReportAt, want)
+	}
+
+	if _, err := svc.SetCoachingSchedule(ctx, "user-1", "25:00", ""); err == nil {
+		t.Error("잘못된 시각에 에러가 없습니다")
+	}
+
+	now = want
+	delivered, err := sch.Tick(ctx)
+	if err != nil {
+		t.Fatalf("Tick 실패: %v", err)
+	}
+	if delivered != 2 || len(notifier.sent) != 2 {
+		t.Fatalf("발송 = %d건 (알림 %d건), want 2건", delivered, len(notifier.sent))
+	}
+	if _, err := sch.Tick(ctx); err != nil || len(notifier.sent) != 2 {
+		t.Errorf("같은 시각 재실행 시 중복 발송: %d건 (err=%v)", len(notifier.sent), err)
+	}
+}
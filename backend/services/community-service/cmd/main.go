@@ -2,11 +2,13 @@
 //
 // 포트: gRPC :50067
 // 의존: PostgreSQL(선택) — 미설정 시 인메모리 저장소 사용
+// 의존: Kafka(선택) — coaching.goal_milestone 소비
 //
 // 기능:
 // - 게시글 작성 / 조회 / 목록 / 좋아요
 // - 댓글 작성 / 목록
 // - 건강 챌린지 생성 / 조회 / 참가 / 목록
+// - 건강 목표 마일스톤을 참가 중인 챌린지 진행에 반영
 package main
 
 import (
@@ -23,10 +25,12 @@ import (
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/manpasik/backend/services/community-service/internal/handler"
 	esRepo "github.com/manpasik/backend/services/community-service/internal/repository/elasticsearch"
+	kafkaConsumer "github.com/manpasik/backend/services/community-service/internal/repository/kafka"
 	"github.com/manpasik/backend/services/community-service/internal/repository/memory"
 	"github.com/manpasik/backend/services/community-service/internal/repository/postgres"
 	"github.com/manpasik/backend/services/community-service/internal/service"
 	"github.com/manpasik/backend/shared/config"
+	"github.com/manpasik/backend/shared/events"
 	"github.com/manpasik/backend/shared/search"
 	v1 "github.com/manpasik/backend/shared/gen/go/v1"
 	"github.com/manpasik/backend/shared/middleware"
@@ -114,6 +118,23 @@ func main() {
 		}
 	}
 
+	// 목표 마일스톤: Kafka 설정 시 coaching.goal_milestone 소비 → 챌린지 진행 반영
+	if _, kafkaBrokersSet := os.LookupEnv("KAFKA_BROKERS"); kafkaBrokersSet && len(cfg.Kafka.Brokers) > 0 {
+		eventBus, kafkaErr := events.NewKafkaEventBus(events.KafkaAdapterConfig{
+			Brokers:     cfg.Kafka.Brokers,
+			GroupID:     serviceName,
+			TopicPrefix: "manpasik.",
+		})
+		if kafkaErr != nil {
+			log.Printf("[%s] Kafka 연결 실패, 목표 기반 챌린지 진행 비활성: %v", serviceName, kafkaErr)
+		} else {
+			defer eventBus.Close()
+			eventBus.Subscribe(events.EventCoachingGoalMilestone, kafkaConsumer.NewGoalMilestoneHandler(communitySvc))
+			eventBus.StartConsuming(context.Background())
+			log.Printf("[%s] Kafka 연결됨: %v", serviceName, cfg.Kafka.Brokers)
+		}
+	}
+
 	grpcServer := grpc.NewServer(
 		grpc.ChainUnaryInterceptor(
 			middleware.RequestIDInterceptor(),
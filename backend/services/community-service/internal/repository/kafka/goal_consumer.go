@@ -0,0 +1,33 @@
+// Package kafka는 community-service가 소비하는 Kafka 이벤트 핸들러를 제공합니다.
+package kafka
+
+import (
+	"context"
+
+	"github.com/manpasik/backend/shared/events"
+)
+
+// ChallengeProgressApplier는 목표 마일스톤을 챌린지 진행에 반영하는 대상입니다.
+type ChallengeProgressApplier interface {
+	ApplyGoalMilestone(ctx context.Context, userID, goalCategory string, currentValue float64, streak int32) (int, error)
+}
+
+// NewGoalMilestoneHandler는 coaching.goal_milestone 이벤트를 받아
+// 사용자가 참가 중인 챌린지 진행에 반영하는 핸들러를 반환합니다.
+func NewGoalMilestoneHandler(applier ChallengeProgressApplier) events.Handler {
+	return func(ctx context.Context, event events.Event) error {
+		payload := events.PayloadOf(event)
+		userID, _ := event.Payload["user_id"].(string)
+		if userID == "" {
+			userID, _ = payload["user_id"].(string)
+		}
+		if userID == "" {
+			return nil
+		}
+		category, _ := payload["category"].(string)
+		currentValue, _ := payload["current_value"].(float64)
+		streak, _ := payload["streak"].(float64)
+		_, err := applier.ApplyGoalMilestone(ctx, userID, category, currentValue, int32(streak))
+		return err
+	}
+}
@@ -630,3 +630,60 @@ func (s *CommunityService) UpdateChallengeProgress(ctx context.Context, challeng
 
 	return newProgress, targetValue, newRank, nil
 }
+
+// ============================================================================
+// ApplyGoalMilestone — 건강 목표 마일스톤 → 챌린지 진행 반영
+// ============================================================================
+
+// goalCategoryChallengeTypes는 coaching-service 목표 카테고리별로 진행을 반영할 챌린지 유형입니다.
+var goalCategoryChallengeTypes = map[string]ChallengeType{
+	"blood_glucose":  ChTypeMeasurement,
+	"blood_pressure": ChTypeMeasurement,
+	"cholesterol":    ChTypeMeasurement,
+	"weight":         ChTypeMeasurement,
+	"nutrition":      ChTypeDiet,
+	"exercise":       ChTypeExercise,
+	"sleep":          ChTypeSleep,
+}
+
+// challengePageSize는 진행 중 챌린지를 훑을 때의 페이지 크기입니다.
+const challengePageSize = 100
+
+// ApplyGoalMilestone은 coaching.goal_milestone 이벤트를 사용자가 참가 중인 같은 유형의 진행 중 챌린지에 반영합니다.
+// 측정 챌린지는 연속 기록 일수를, 그 외 챌린지는 목표 지표의 현재 값을 진행 값으로 사용합니다.
+// 반영한 챌린지 수를 반환합니다.
+func (s *CommunityService) ApplyGoalMilestone(ctx context.Context, userID, goalCategory string, currentValue float64, streak int32) (int, error) {
+	if userID == "" {
+		return 0, apperrors.New(apperrors.ErrInvalidInput, "user_id는 필수입니다")
+	}
+	challengeType, ok := goalCategoryChallengeTypes[goalCategory]
+	if !ok {
+		return 0, nil
+	}
+
+	applied := 0
+	for offset := int32(0); ; offset += challengePageSize {
+		challenges, total, err := s.challengeRepo.FindAll(ctx, ChActive, challengePageSize, offset)
+		if err != nil {
+			s.logger.Error("챌린지 목록 조회 실패", zap.Error(err))
+			return applied, apperrors.New(apperrors.ErrInternal, "챌린지 목록 조회에 실패했습니다")
+		}
+		for _, ch := range challenges {
+			if ch.Type != challengeType || !ch.Participants[userID] {
+				continue
+			}
+			value := currentValue
+			if challengeType == ChTypeMeasurement {
+				value = float64(streak)
+			}
+			if _, _, _, err := s.UpdateChallengeProgress(ctx, ch.ID, userID, value); err != nil {
+				return applied, err
+			}
+			applied++
+		}
+		if len(challenges) == 0 || offset+challengePageSize >= total {
+			break
+		}
+	}
+	return applied, nil
+}
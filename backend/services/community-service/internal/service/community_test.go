@@ -481,3 +481,38 @@ func TestEndToEnd_CommunityFlow(t *testing.T) {
 		t.Errorf("E2E 반환 챌린지 수: got %d, want 1", len(challenges))
 	}
 }
+
+// ============================================================================
+// TestApplyGoalMilestone — 목표 마일스톤 → 챌린지 진행 반영
+// ============================================================================
+
+func TestApplyGoalMilestone(t *testing.T) {
+	svc := setupTestService()
+	ctx := context.Background()
+
+	svc.CreateChallenge(ctx, "user-1", service.ChTypeMeasurement, "혈당 챌린지", "매일 혈당 측정", "14일 연속 측정", 14, "일", 20, 14, time.Now().UTC())
+	diet, _ := svc.CreateChallenge(ctx, "user-2", service.ChTypeDiet, "식단 챌린지", "단백질 섭취", "하루 60g", 60, "g", 20, 14, time.Now().UTC())
+	svc.CreateChallenge(ctx, "user-3", service.ChTypeMeasurement, "다른 사용자 챌린지", "측정", "측정", 7, "일", 20, 7, time.Now().UTC())
+
+	applied, err := svc.ApplyGoalMilestone(ctx, "user-1", "blood_glucose", 120, 5)
+	if err != nil {
+		t.Fatalf("ApplyGoalMilestone 실패: %v", err)
+	}
+	if applied != 1 {
+		t.Errorf("참가 중인 측정 챌린지 1개에만 반영되어야 함: got %d", applied)
+	}
+
+	// 참가하지 않은 식단 챌린지는 반영하지 않음
+	if applied, _ := svc.ApplyGoalMilestone(ctx, "user-1", "nutrition", 45, 2); applied != 0 {
+		t.Errorf("참가하지 않은 챌린지에 반영됨: got %d", applied)
+	}
+	svc.JoinChallenge(ctx, diet.ID, "user-1")
+	if applied, _ := svc.ApplyGoalMilestone(ctx, "user-1", "nutrition", 45, 2); applied != 1 {
+		t.Errorf("참가 후 식단 챌린지에 반영되어야 함: got %d", applied)
+	}
+
+	// 챌린지 유형이 없는 카테고리는 무시
+	if applied, err := svc.ApplyGoalMilestone(ctx, "user-1", "stress", 3, 1); err != nil || applied != 0 {
+		t.Errorf("대응 챌린지 유형이 없으면 0이어야 함: got %d, %v", applied, err)
+	}
+}
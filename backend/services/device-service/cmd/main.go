@@ -8,6 +8,8 @@
 // - 디바이스 목록 조회
 // - 상태 관리 (online/offline/measuring/updating/error)
 // - OTA 펌웨어 업데이트
+// - 소유권 이전 (양측 확인), 등록 해제, 원격 초기화 명령 대기열
+// - 클리니컬 플릿: 디바이스 그룹·역할, 일괄 명령, 플릿 통계 (measurement.completed 구독으로 카트리지 사용량 집계)
 // - gRPC DeviceService
 package main
 
@@ -58,9 +60,13 @@ func main() {
 
 	log.Printf("[%s] Starting v%s...", serviceName, cfg.Version)
 
-	// DeviceRepository & EventRepository: PostgreSQL 또는 인메모리
+	// DeviceRepository & EventRepository & 소유권/명령 저장소: PostgreSQL 또는 인메모리
 	var deviceRepo service.DeviceRepository
 	var eventRepo service.DeviceEventRepository
+	var transferRepo service.TransferRepository
+	var ownershipRepo service.OwnershipRepository
+	var commandRepo service.CommandRepository
+	var fleetRepo service.FleetRepository
 	if _, dbHostSet := os.LookupEnv("DB_HOST"); dbHostSet && cfg.DB.Host != "" && cfg.DB.DBName != "" {
 		connCtx, connCancel := context.WithTimeout(context.Background(), 5*time.Second)
 		pool, poolErr := pgxpool.New(connCtx, cfg.DB.DSN())
@@ -69,6 +75,10 @@ func main() {
 			log.Printf("[%s] DB 풀 생성 실패, 인메모리 사용: %v", serviceName, poolErr)
 			deviceRepo = memory.NewDeviceRepository()
 			eventRepo = memory.NewDeviceEventRepository()
+			transferRepo = memory.NewTransferRepository()
+			ownershipRepo = memory.NewOwnershipRepository()
+			commandRepo = memory.NewCommandRepository()
+			fleetRepo = memory.NewFleetRepository()
 		} else {
 			pingCtx, pingCancel := context.WithTimeout(context.Background(), 3*time.Second)
 			if pingErr := pool.Ping(pingCtx); pingErr != nil {
@@ -77,17 +87,29 @@ func main() {
 				log.Printf("[%s] DB Ping 실패, 인메모리 사용: %v", serviceName, pingErr)
 				deviceRepo = memory.NewDeviceRepository()
 				eventRepo = memory.NewDeviceEventRepository()
+				transferRepo = memory.NewTransferRepository()
+				ownershipRepo = memory.NewOwnershipRepository()
+				commandRepo = memory.NewCommandRepository()
+				fleetRepo = memory.NewFleetRepository()
 			} else {
 				pingCancel()
 				defer pool.Close()
 				deviceRepo = postgres.NewDeviceRepository(pool)
 				eventRepo = postgres.NewDeviceEventRepository(pool)
+				transferRepo = postgres.NewTransferRepository(pool)
+				ownershipRepo = postgres.NewOwnershipRepository(pool)
+				commandRepo = postgres.NewCommandRepository(pool)
+				fleetRepo = postgres.NewFleetRepository(pool)
 				log.Printf("[%s] DB 연결됨: %s", serviceName, cfg.DB.DBName)
 			}
 		}
 	} else {
 		deviceRepo = memory.NewDeviceRepository()
 		eventRepo = memory.NewDeviceEventRepository()
+		transferRepo = memory.NewTransferRepository()
+		ownershipRepo = memory.NewOwnershipRepository()
+		commandRepo = memory.NewCommandRepository()
+		fleetRepo = memory.NewFleetRepository()
 		log.Printf("[%s] 인메모리 저장소 사용", serviceName)
 	}
 
@@ -112,7 +134,9 @@ func main() {
 
 	subChecker := memory.NewSubscriptionChecker()
 
-	deviceSvc := service.NewDeviceService(logger, deviceRepo, eventRepo, subChecker)
+	deviceSvc := service.NewDeviceService(logger, deviceRepo, eventRepo, subChecker, transferRepo, ownershipRepo, commandRepo)
+	deviceSvc.SetFleetRepository(fleetRepo)
+	deviceSvc.SetClinicalTierChecker(subChecker)
 
 	// EventPublisher: Kafka(Redpanda) 또는 인메모리
 	if _, kafkaBrokersSet := os.LookupEnv("KAFKA_BROKERS"); kafkaBrokersSet && len(cfg.Kafka.Brokers) > 0 {
@@ -127,6 +151,8 @@ func main() {
 		} else {
 			defer eventBus.Close()
 			deviceSvc.SetEventPublisher(kafkaPublisher.NewEventPublisher(eventBus))
+			eventBus.Subscribe(events.EventMeasurementCompleted, kafkaPublisher.NewMeasurementCompletedHandler(deviceSvc))
+			eventBus.StartConsuming(context.Background())
 			log.Printf("[%s] Kafka 연결됨: %v", serviceName, cfg.Kafka.Brokers)
 		}
 	} else {
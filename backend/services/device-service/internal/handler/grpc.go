@@ -3,6 +3,7 @@ package handler
 
 import (
 	"context"
+	"time"
 
 	"github.com/manpasik/backend/services/device-service/internal/service"
 	apperrors "github.com/manpasik/backend/shared/errors"
@@ -56,12 +57,7 @@ func (h *DeviceHandler) ListDevices(ctx context.Context, req *v1.ListDevicesRequ
 
 	var infos []*v1.DeviceInfo
 	for _, d := range devices {
-		infos = append(infos, &v1.DeviceInfo{
-			DeviceId:        d.ID,
-			Name:            d.Name,
-			FirmwareVersion: d.FirmwareVersion,
-			LastSeen:        timestamppb.New(d.LastSeen),
-		})
+		infos = append(infos, deviceToProto(d))
 	}
 
 	return &v1.ListDevicesResponse{Devices: infos}, nil
@@ -102,6 +98,696 @@ func (h *DeviceHandler) RequestOtaUpdate(ctx context.Context, req *v1.OtaRequest
 	}, nil
 }
 
+// InitiateDeviceTransfer는 소유권 이전 요청 RPC입니다.
+func (h *DeviceHandler) InitiateDeviceTransfer(ctx context.Context, req *v1.InitiateDeviceTransferRequest) (*v1.DeviceTransferInfo, error) {
+	if req == nil || req.DeviceId == "" || req.FromUserId == "" || req.ToUserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "device_id, from_user_id, to_user_id는 필수입니다")
+	}
+
+	transfer, err := h.svc.InitiateTransfer(ctx, req.DeviceId, req.FromUserId, req.ToUserId)
+	if err != nil {
+		return nil, toGRPC(err)
+	}
+	return transferToProto(transfer), nil
+}
+
+// ConfirmDeviceTransfer는 소유권 이전 확인 RPC입니다.
+func (h *DeviceHandler) ConfirmDeviceTransfer(ctx context.Context, req *v1.ConfirmDeviceTransferRequest) (*v1.DeviceTransferInfo, error) {
+	if req == nil || req.TransferId == "" || req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "transfer_id와 user_id는 필수입니다")
+	}
+
+	transfer, err := h.svc.ConfirmTransfer(ctx, req.TransferId, req.UserId)
+	if err != nil {
+		return nil, toGRPC(err)
+	}
+	return transferToProto(transfer), nil
+}
+
+// CancelDeviceTransfer는 소유권 이전 취소 RPC입니다.
+func (h *DeviceHandler) CancelDeviceTransfer(ctx context.Context, req *v1.CancelDeviceTransferRequest) (*v1.DeviceTransferInfo, error) {
+	if req == nil || req.TransferId == "" || req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "transfer_id와 user_id는 필수입니다")
+	}
+
+	transfer, err := h.svc.CancelTransfer(ctx, req.TransferId, req.UserId)
+	if err != nil {
+		return nil, toGRPC(err)
+	}
+	return transferToProto(transfer), nil
+}
+
+// ListDeviceOwnershipHistory는 소유 기간 이력 조회 RPC입니다.
+func (h *DeviceHandler) ListDeviceOwnershipHistory(ctx context.Context, req *v1.ListDeviceOwnershipHistoryRequest) (*v1.ListDeviceOwnershipHistoryResponse, error) {
+	if req == nil || req.DeviceId == "" {
+		return nil, status.Error(codes.InvalidArgument, "device_id는 필수입니다")
+	}
+
+	periods, err := h.svc.ListOwnershipHistory(ctx, req.DeviceId)
+	if err != nil {
+		return nil, toGRPC(err)
+	}
+
+	resp := &v1.ListDeviceOwnershipHistoryResponse{}
+	for _, p := range periods {
+		period := &v1.DeviceOwnershipPeriod{
+			DeviceId:  p.DeviceID,
+			UserId:    p.UserID,
+			StartedAt: timestamppb.New(p.StartedAt),
+			EndReason: p.EndReason,
+		}
+		if !p.EndedAt.IsZero() {
+			period.EndedAt = timestamppb.New(p.EndedAt)
+		}
+		resp.Periods = append(resp.Periods, period)
+	}
+	return resp, nil
+}
+
+// GetDeviceOwnerAt은 지정 시점의 디바이스 소유자 조회 RPC입니다.
+func (h *DeviceHandler) GetDeviceOwnerAt(ctx context.Context, req *v1.GetDeviceOwnerAtRequest) (*v1.GetDeviceOwnerAtResponse, error) {
+	if req == nil || req.DeviceId == "" {
+		return nil, status.Error(codes.InvalidArgument, "device_id는 필수입니다")
+	}
+
+	at := time.Now().UTC()
+	if req.At != nil {
+		at = req.At.AsTime()
+	}
+	userID, err := h.svc.OwnerAt(ctx, req.DeviceId, at)
+	if err != nil {
+		return nil, toGRPC(err)
+	}
+	return &v1.GetDeviceOwnerAtResponse{DeviceId: req.DeviceId, UserId: userID}, nil
+}
+
+// DeregisterDevice는 디바이스 등록 해제 RPC입니다.
+func (h *DeviceHandler) DeregisterDevice(ctx context.Context, req *v1.DeregisterDeviceRequest) (*v1.DeregisterDeviceResponse, error) {
+	if req == nil || req.DeviceId == "" || req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "device_id와 user_id는 필수입니다")
+	}
+
+	deregisteredAt, err := h.svc.DeregisterDevice(ctx, req.DeviceId, req.UserId, req.Reason)
+	if err != nil {
+		return nil, toGRPC(err)
+	}
+	return &v1.DeregisterDeviceResponse{
+		Success:        true,
+		DeregisteredAt: timestamppb.New(deregisteredAt),
+	}, nil
+}
+
+// RequestRemoteWipe는 원격 공장 초기화 요청 RPC입니다.
+func (h *DeviceHandler) RequestRemoteWipe(ctx context.Context, req *v1.RemoteWipeRequest) (*v1.RemoteWipeResponse, error) {
+	if req == nil || req.DeviceId == "" || req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "device_id와 user_id는 필수입니다")
+	}
+
+	cmd, err := h.svc.RequestRemoteWipe(ctx, req.DeviceId, req.UserId, req.Deregister)
+	if err != nil {
+		return nil, toGRPC(err)
+	}
+	return &v1.RemoteWipeResponse{
+		CommandId: cmd.ID,
+		QueuedAt:  timestamppb.New(cmd.CreatedAt),
+	}, nil
+}
+
+// ListPendingCommands는 디바이스 대기 명령 조회 RPC입니다.
+func (h *DeviceHandler) ListPendingCommands(ctx context.Context, req *v1.ListPendingCommandsRequest) (*v1.ListPendingCommandsResponse, error) {
+	if req == nil || req.DeviceId == "" {
+		return nil, status.Error(codes.InvalidArgument, "device_id는 필수입니다")
+	}
+
+	cmds, err := h.svc.ListPendingCommands(ctx, req.DeviceId)
+	if err != nil {
+		return nil, toGRPC(err)
+	}
+
+	resp := &v1.ListPendingCommandsResponse{}
+	for _, c := range cmds {
+		resp.Commands = append(resp.Commands, &v1.DeviceCommand{
+			CommandId:      c.ID,
+			CommandType:    commandTypeToProto(c.CommandType),
+			Payload:        c.Payload,
+			TargetDeviceId: c.DeviceID,
+		})
+	}
+	return resp, nil
+}
+
+// AcknowledgeDeviceCommand는 디바이스 명령 수행 결과 보고 RPC입니다.
+func (h *DeviceHandler) AcknowledgeDeviceCommand(ctx context.Context, req *v1.AcknowledgeDeviceCommandRequest) (*v1.AcknowledgeDeviceCommandResponse, error) {
+	if req == nil || req.DeviceId == "" || req.CommandId == "" {
+		return nil, status.Error(codes.InvalidArgument, "device_id와 command_id는 필수입니다")
+	}
+
+	if err := h.svc.AcknowledgeCommand(ctx, req.DeviceId, req.CommandId, req.Success, req.Message); err != nil {
+		return nil, toGRPC(err)
+	}
+	return &v1.AcknowledgeDeviceCommandResponse{Success: true}, nil
+}
+
+// RegisterHub는 Wi-Fi 허브/클라우드 게이트웨이 등록 RPC입니다.
+func (h *DeviceHandler) RegisterHub(ctx context.Context, req *v1.RegisterHubRequest) (*v1.RegisterDeviceResponse, error) {
+	if req == nil || req.DeviceId == "" || req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "device_id와 user_id는 필수입니다")
+	}
+
+	hub, regToken, err := h.svc.RegisterHub(ctx, req.DeviceId, req.SerialNumber, req.FirmwareVersion, req.UserId,
+		deviceKindFromProto(req.Kind), req.ParentHubId)
+	if err != nil {
+		return nil, toGRPC(err)
+	}
+
+	return &v1.RegisterDeviceResponse{
+		DeviceId:          hub.ID,
+		RegistrationToken: regToken,
+		RegisteredAt:      timestamppb.New(hub.RegisteredAt),
+	}, nil
+}
+
+// AttachReadersToHub는 허브에 리더를 배정하는 RPC입니다.
+func (h *DeviceHandler) AttachReadersToHub(ctx context.Context, req *v1.AttachReadersToHubRequest) (*v1.AttachReadersToHubResponse, error) {
+	if req == nil || req.HubId == "" || req.UserId == "" || len(req.DeviceIds) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "hub_id, user_id, device_ids는 필수입니다")
+	}
+
+	attached, failed, err := h.svc.AttachToHub(ctx, req.HubId, req.UserId, req.DeviceIds)
+	if err != nil {
+		return nil, toGRPC(err)
+	}
+	return &v1.AttachReadersToHubResponse{
+		AttachedCount:   int32(attached),
+		FailedDeviceIds: failed,
+	}, nil
+}
+
+// DetachReaderFromHub는 리더를 허브에서 분리하는 RPC입니다.
+func (h *DeviceHandler) DetachReaderFromHub(ctx context.Context, req *v1.DetachReaderFromHubRequest) (*v1.DetachReaderFromHubResponse, error) {
+	if req == nil || req.DeviceId == "" || req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "device_id와 user_id는 필수입니다")
+	}
+
+	if err := h.svc.DetachFromHub(ctx, req.DeviceId, req.UserId); err != nil {
+		return nil, toGRPC(err)
+	}
+	return &v1.DetachReaderFromHubResponse{Success: true}, nil
+}
+
+// ReportHubRelay는 허브가 하위 리더 상태를 일괄 중계하는 RPC입니다.
+func (h *DeviceHandler) ReportHubRelay(ctx context.Context, req *v1.HubRelayReport) (*v1.HubRelayResponse, error) {
+	if req == nil || req.HubId == "" {
+		return nil, status.Error(codes.InvalidArgument, "hub_id는 필수입니다")
+	}
+
+	updates := make([]service.RelayedStatus, 0, len(req.Devices))
+	for _, d := range req.Devices {
+		u := service.RelayedStatus{
+			DeviceID:       d.DeviceId,
+			Status:         deviceStatusFromProto(d.Status),
+			BatteryPercent: int(d.BatteryPercent),
+		}
+		if d.Timestamp != nil {
+			u.ObservedAt = d.Timestamp.AsTime()
+		}
+		updates = append(updates, u)
+	}
+
+	accepted, rejected, pending, err := h.svc.ReportHubRelay(ctx, req.HubId, updates)
+	if err != nil {
+		return nil, toGRPC(err)
+	}
+	return &v1.HubRelayResponse{
+		AcceptedCount:       int32(accepted),
+		RejectedDeviceIds:   rejected,
+		PendingCommandCount: int32(pending),
+	}, nil
+}
+
+// GetHubStatus는 허브 서브트리 상태 집계 RPC입니다.
+func (h *DeviceHandler) GetHubStatus(ctx context.Context, req *v1.GetHubStatusRequest) (*v1.HubStatus, error) {
+	if req == nil || req.HubId == "" {
+		return nil, status.Error(codes.InvalidArgument, "hub_id는 필수입니다")
+	}
+
+	st, err := h.svc.GetHubStatus(ctx, req.HubId)
+	if err != nil {
+		return nil, toGRPC(err)
+	}
+	return hubStatusToProto(st), nil
+}
+
+// ListHubReaders는 허브에 배정된 하위 디바이스 목록 RPC입니다.
+func (h *DeviceHandler) ListHubReaders(ctx context.Context, req *v1.ListHubReadersRequest) (*v1.ListDevicesResponse, error) {
+	if req == nil || req.HubId == "" {
+		return nil, status.Error(codes.InvalidArgument, "hub_id는 필수입니다")
+	}
+
+	devices, err := h.svc.ListHubDevices(ctx, req.HubId)
+	if err != nil {
+		return nil, toGRPC(err)
+	}
+
+	var infos []*v1.DeviceInfo
+	for _, d := range devices {
+		infos = append(infos, deviceToProto(d))
+	}
+	return &v1.ListDevicesResponse{Devices: infos}, nil
+}
+
+// SendDeviceCommand는 디바이스 명령 전송 RPC입니다 (허브 뒤 리더는 허브 경유).
+func (h *DeviceHandler) SendDeviceCommand(ctx context.Context, req *v1.SendDeviceCommandRequest) (*v1.SendDeviceCommandResponse, error) {
+	if req == nil || req.DeviceId == "" || req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "device_id와 user_id는 필수입니다")
+	}
+	cmdType := commandTypeFromProto(req.CommandType)
+	if cmdType == "" {
+		return nil, status.Error(codes.InvalidArgument, "지원하지 않는 command_type입니다")
+	}
+
+	cmd, err := h.svc.SendCommand(ctx, req.DeviceId, req.UserId, cmdType, req.Payload)
+	if err != nil {
+		return nil, toGRPC(err)
+	}
+	return &v1.SendDeviceCommandResponse{
+		CommandId:  cmd.ID,
+		RouteHubId: cmd.RouteHubID,
+		QueuedAt:   timestamppb.New(cmd.CreatedAt),
+	}, nil
+}
+
+// CreateDeviceGroup은 디바이스 그룹 생성 RPC입니다 (클리니컬 구독 필요).
+func (h *DeviceHandler) CreateDeviceGroup(ctx context.Context, req *v1.CreateDeviceGroupRequest) (*v1.DeviceGroupInfo, error) {
+	if req == nil || req.UserId == "" || req.Name == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id와 name은 필수입니다")
+	}
+
+	g, err := h.svc.CreateDeviceGroup(ctx, req.UserId, req.Name, req.Description)
+	if err != nil {
+		return nil, toGRPC(err)
+	}
+	return groupToProto(&service.GroupSummary{Group: g, MemberCount: 1, MyRole: service.GroupRoleOwner}), nil
+}
+
+// ListDeviceGroups는 사용자가 구성원인 디바이스 그룹 목록 RPC입니다.
+func (h *DeviceHandler) ListDeviceGroups(ctx context.Context, req *v1.ListDeviceGroupsRequest) (*v1.ListDeviceGroupsResponse, error) {
+	if req == nil || req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id는 필수입니다")
+	}
+
+	groups, err := h.svc.ListDeviceGroups(ctx, req.UserId)
+	if err != nil {
+		return nil, toGRPC(err)
+	}
+
+	var infos []*v1.DeviceGroupInfo
+	for _, g := range groups {
+		infos = append(infos, groupToProto(g))
+	}
+	return &v1.ListDeviceGroupsResponse{Groups: infos}, nil
+}
+
+// AddDeviceGroupMember는 그룹 구성원 추가/역할 변경 RPC입니다.
+func (h *DeviceHandler) AddDeviceGroupMember(ctx context.Context, req *v1.AddDeviceGroupMemberRequest) (*v1.DeviceGroupMember, error) {
+	if req == nil || req.GroupId == "" || req.UserId == "" || req.MemberUserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "group_id, user_id, member_user_id는 필수입니다")
+	}
+
+	m, err := h.svc.AddGroupMember(ctx, req.GroupId, req.UserId, req.MemberUserId, groupRoleFromProto(req.Role))
+	if err != nil {
+		return nil, toGRPC(err)
+	}
+	return &v1.DeviceGroupMember{
+		GroupId: m.GroupID,
+		UserId:  m.UserID,
+		Role:    groupRoleToProto(m.Role),
+		AddedAt: timestamppb.New(m.AddedAt),
+	}, nil
+}
+
+// RemoveDeviceGroupMember는 그룹 구성원 제거 RPC입니다.
+func (h *DeviceHandler) RemoveDeviceGroupMember(ctx context.Context, req *v1.RemoveDeviceGroupMemberRequest) (*v1.RemoveDeviceGroupMemberResponse, error) {
+	if req == nil || req.GroupId == "" || req.UserId == "" || req.MemberUserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "group_id, user_id, member_user_id는 필수입니다")
+	}
+
+	if err := h.svc.RemoveGroupMember(ctx, req.GroupId, req.UserId, req.MemberUserId); err != nil {
+		return nil, toGRPC(err)
+	}
+	return &v1.RemoveDeviceGroupMemberResponse{Success: true}, nil
+}
+
+// AddDevicesToGroup은 그룹에 디바이스를 추가하는 RPC입니다.
+func (h *DeviceHandler) AddDevicesToGroup(ctx context.Context, req *v1.AddDevicesToGroupRequest) (*v1.AddDevicesToGroupResponse, error) {
+	if req == nil || req.GroupId == "" || req.UserId == "" || len(req.DeviceIds) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "group_id, user_id, device_ids는 필수입니다")
+	}
+
+	added, failed, err := h.svc.AddDevicesToGroup(ctx, req.GroupId, req.UserId, req.DeviceIds)
+	if err != nil {
+		return nil, toGRPC(err)
+	}
+	return &v1.AddDevicesToGroupResponse{
+		AddedCount:      int32(added),
+		FailedDeviceIds: failed,
+	}, nil
+}
+
+// RemoveDeviceFromGroup은 그룹에서 디바이스를 제외하는 RPC입니다.
+func (h *DeviceHandler) RemoveDeviceFromGroup(ctx context.Context, req *v1.RemoveDeviceFromGroupRequest) (*v1.RemoveDeviceFromGroupResponse, error) {
+	if req == nil || req.GroupId == "" || req.UserId == "" || req.DeviceId == "" {
+		return nil, status.Error(codes.InvalidArgument, "group_id, user_id, device_id는 필수입니다")
+	}
+
+	if err := h.svc.RemoveDeviceFromGroup(ctx, req.GroupId, req.UserId, req.DeviceId); err != nil {
+		return nil, toGRPC(err)
+	}
+	return &v1.RemoveDeviceFromGroupResponse{Success: true}, nil
+}
+
+// SendBulkCommand는 그룹 대상 일괄 명령 RPC입니다.
+func (h *DeviceHandler) SendBulkCommand(ctx context.Context, req *v1.SendBulkCommandRequest) (*v1.BulkCommandInfo, error) {
+	if req == nil || req.GroupId == "" || req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "group_id와 user_id는 필수입니다")
+	}
+	cmdType := commandTypeFromProto(req.CommandType)
+	if cmdType == "" {
+		return nil, status.Error(codes.InvalidArgument, "지원하지 않는 command_type입니다")
+	}
+
+	st, err := h.svc.SendBulkCommand(ctx, req.GroupId, req.UserId, cmdType, req.Payload, req.DeviceIds)
+	if err != nil {
+		return nil, toGRPC(err)
+	}
+	return bulkToProto(st), nil
+}
+
+// GetBulkCommandStatus는 일괄 명령 진행 현황 RPC입니다.
+func (h *DeviceHandler) GetBulkCommandStatus(ctx context.Context, req *v1.GetBulkCommandStatusRequest) (*v1.BulkCommandInfo, error) {
+	if req == nil || req.BulkId == "" || req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "bulk_id와 user_id는 필수입니다")
+	}
+
+	st, err := h.svc.GetBulkCommandStatus(ctx, req.BulkId, req.UserId)
+	if err != nil {
+		return nil, toGRPC(err)
+	}
+	return bulkToProto(st), nil
+}
+
+// GetFleetStats는 그룹 플릿 통계 RPC입니다.
+func (h *DeviceHandler) GetFleetStats(ctx context.Context, req *v1.GetFleetStatsRequest) (*v1.FleetStats, error) {
+	if req == nil || req.GroupId == "" || req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "group_id와 user_id는 필수입니다")
+	}
+
+	st, err := h.svc.GetFleetStats(ctx, req.GroupId, req.UserId, int(req.Days))
+	if err != nil {
+		return nil, toGRPC(err)
+	}
+	return fleetStatsToProto(st), nil
+}
+
+// ListFleetStats는 전체 그룹 플릿 통계 RPC입니다 (admin-service 내부 호출 전용, 게이트웨이 미노출).
+func (h *DeviceHandler) ListFleetStats(ctx context.Context, req *v1.ListFleetStatsRequest) (*v1.ListFleetStatsResponse, error) {
+	var days int
+	if req != nil {
+		days = int(req.Days)
+	}
+
+	stats, err := h.svc.ListFleetStats(ctx, days)
+	if err != nil {
+		return nil, toGRPC(err)
+	}
+
+	var out []*v1.FleetStats
+	for _, st := range stats {
+		out = append(out, fleetStatsToProto(st))
+	}
+	return &v1.ListFleetStatsResponse{Groups: out}, nil
+}
+
+// deviceToProto는 Device를 proto 메시지로 변환합니다.
+func deviceToProto(d *service.Device) *v1.DeviceInfo {
+	info := &v1.DeviceInfo{
+		DeviceId:        d.ID,
+		Name:            d.Name,
+		FirmwareVersion: d.FirmwareVersion,
+		Status:          deviceStatusToProto(d.Status),
+		BatteryPercent:  int32(d.BatteryPercent),
+		LastSeen:        timestamppb.New(d.LastSeen),
+		Kind:            deviceKindToProto(d.Kind),
+		ParentHubId:     d.ParentHubID,
+		LastRelayHubId:  d.LastRelayHubID,
+	}
+	if !d.LastRelayAt.IsZero() {
+		info.LastRelayAt = timestamppb.New(d.LastRelayAt)
+	}
+	return info
+}
+
+func hubStatusToProto(st *service.HubStatus) *v1.HubStatus {
+	out := &v1.HubStatus{
+		Hub:                 deviceToProto(st.Hub),
+		TotalDevices:        int32(st.TotalDevices),
+		OnlineCount:         int32(st.Online),
+		OfflineCount:        int32(st.Offline),
+		MeasuringCount:      int32(st.Measuring),
+		ErrorCount:          int32(st.Error),
+		LowBatteryCount:     int32(st.LowBattery),
+		PendingCommandCount: int32(st.PendingCommands),
+	}
+	for _, child := range st.ChildHubs {
+		out.ChildHubs = append(out.ChildHubs, hubStatusToProto(child))
+	}
+	return out
+}
+
+func deviceKindToProto(k service.DeviceKind) v1.DeviceKind {
+	switch k {
+	case service.KindReader:
+		return v1.DeviceKind_DEVICE_KIND_READER
+	case service.KindWifiHub:
+		return v1.DeviceKind_DEVICE_KIND_WIFI_HUB
+	case service.KindCloudGateway:
+		return v1.DeviceKind_DEVICE_KIND_CLOUD_GATEWAY
+	default:
+		return v1.DeviceKind_DEVICE_KIND_UNKNOWN
+	}
+}
+
+func deviceKindFromProto(k v1.DeviceKind) service.DeviceKind {
+	switch k {
+	case v1.DeviceKind_DEVICE_KIND_READER:
+		return service.KindReader
+	case v1.DeviceKind_DEVICE_KIND_WIFI_HUB:
+		return service.KindWifiHub
+	case v1.DeviceKind_DEVICE_KIND_CLOUD_GATEWAY:
+		return service.KindCloudGateway
+	default:
+		return ""
+	}
+}
+
+func deviceStatusToProto(s service.DeviceStatus) v1.DeviceStatus {
+	switch s {
+	case service.StatusOnline:
+		return v1.DeviceStatus_DEVICE_STATUS_ONLINE
+	case service.StatusOffline, service.StatusDeregistered:
+		return v1.DeviceStatus_DEVICE_STATUS_OFFLINE
+	case service.StatusMeasuring:
+		return v1.DeviceStatus_DEVICE_STATUS_MEASURING
+	case service.StatusUpdating:
+		return v1.DeviceStatus_DEVICE_STATUS_UPDATING
+	case service.StatusError:
+		return v1.DeviceStatus_DEVICE_STATUS_ERROR
+	default:
+		return v1.DeviceStatus_DEVICE_STATUS_UNKNOWN
+	}
+}
+
+func deviceStatusFromProto(s v1.DeviceStatus) service.DeviceStatus {
+	switch s {
+	case v1.DeviceStatus_DEVICE_STATUS_ONLINE:
+		return service.StatusOnline
+	case v1.DeviceStatus_DEVICE_STATUS_OFFLINE:
+		return service.StatusOffline
+	case v1.DeviceStatus_DEVICE_STATUS_MEASURING:
+		return service.StatusMeasuring
+	case v1.DeviceStatus_DEVICE_STATUS_UPDATING:
+		return service.StatusUpdating
+	case v1.DeviceStatus_DEVICE_STATUS_ERROR:
+		return service.StatusError
+	default:
+		return ""
+	}
+}
+
+func commandTypeFromProto(t v1.CommandType) service.CommandType {
+	switch t {
+	case v1.CommandType_COMMAND_TYPE_START_MEASUREMENT:
+		return service.CommandStartMeasurement
+	case v1.CommandType_COMMAND_TYPE_STOP_MEASUREMENT:
+		return service.CommandStopMeasurement
+	case v1.CommandType_COMMAND_TYPE_CALIBRATE:
+		return service.CommandCalibrate
+	case v1.CommandType_COMMAND_TYPE_REBOOT:
+		return service.CommandReboot
+	case v1.CommandType_COMMAND_TYPE_OTA_UPDATE:
+		return service.CommandOtaUpdate
+	case v1.CommandType_COMMAND_TYPE_FACTORY_RESET:
+		return service.CommandFactoryReset
+	case v1.CommandType_COMMAND_TYPE_CALIBRATION_CHECK:
+		return service.CommandCalibrationCheck
+	case v1.CommandType_COMMAND_TYPE_STATUS_QUERY:
+		return service.CommandStatusQuery
+	case v1.CommandType_COMMAND_TYPE_LOCK:
+		return service.CommandLock
+	case v1.CommandType_COMMAND_TYPE_UNLOCK:
+		return service.CommandUnlock
+	default:
+		return ""
+	}
+}
+
+// transferToProto는 DeviceTransfer를 proto 메시지로 변환합니다.
+func transferToProto(t *service.DeviceTransfer) *v1.DeviceTransferInfo {
+	info := &v1.DeviceTransferInfo{
+		TransferId:    t.ID,
+		DeviceId:      t.DeviceID,
+		FromUserId:    t.FromUserID,
+		ToUserId:      t.ToUserID,
+		Status:        transferStatusToProto(t.Status),
+		FromConfirmed: t.FromConfirmed,
+		ToConfirmed:   t.ToConfirmed,
+		CreatedAt:     timestamppb.New(t.CreatedAt),
+		ExpiresAt:     timestamppb.New(t.ExpiresAt),
+	}
+	if !t.CompletedAt.IsZero() {
+		info.CompletedAt = timestamppb.New(t.CompletedAt)
+	}
+	return info
+}
+
+func transferStatusToProto(s service.TransferStatus) v1.DeviceTransferStatus {
+	switch s {
+	case service.TransferPending:
+		return v1.DeviceTransferStatus_DEVICE_TRANSFER_STATUS_PENDING
+	case service.TransferCompleted:
+		return v1.DeviceTransferStatus_DEVICE_TRANSFER_STATUS_COMPLETED
+	case service.TransferCancelled:
+		return v1.DeviceTransferStatus_DEVICE_TRANSFER_STATUS_CANCELLED
+	case service.TransferExpired:
+		return v1.DeviceTransferStatus_DEVICE_TRANSFER_STATUS_EXPIRED
+	default:
+		return v1.DeviceTransferStatus_DEVICE_TRANSFER_STATUS_UNKNOWN
+	}
+}
+
+func commandTypeToProto(t service.CommandType) v1.CommandType {
+	switch t {
+	case service.CommandStartMeasurement:
+		return v1.CommandType_COMMAND_TYPE_START_MEASUREMENT
+	case service.CommandStopMeasurement:
+		return v1.CommandType_COMMAND_TYPE_STOP_MEASUREMENT
+	case service.CommandCalibrate:
+		return v1.CommandType_COMMAND_TYPE_CALIBRATE
+	case service.CommandReboot:
+		return v1.CommandType_COMMAND_TYPE_REBOOT
+	case service.CommandOtaUpdate:
+		return v1.CommandType_COMMAND_TYPE_OTA_UPDATE
+	case service.CommandFactoryReset:
+		return v1.CommandType_COMMAND_TYPE_FACTORY_RESET
+	case service.CommandCalibrationCheck:
+		return v1.CommandType_COMMAND_TYPE_CALIBRATION_CHECK
+	case service.CommandStatusQuery:
+		return v1.CommandType_COMMAND_TYPE_STATUS_QUERY
+	case service.CommandLock:
+		return v1.CommandType_COMMAND_TYPE_LOCK
+	case service.CommandUnlock:
+		return v1.CommandType_COMMAND_TYPE_UNLOCK
+	default:
+		return v1.CommandType_COMMAND_TYPE_UNKNOWN
+	}
+}
+
+func groupToProto(g *service.GroupSummary) *v1.DeviceGroupInfo {
+	return &v1.DeviceGroupInfo{
+		GroupId:     g.Group.ID,
+		Name:        g.Group.Name,
+		Description: g.Group.Description,
+		OwnerId:     g.Group.OwnerID,
+		DeviceCount: int32(g.DeviceCount),
+		MemberCount: int32(g.MemberCount),
+		MyRole:      groupRoleToProto(g.MyRole),
+		CreatedAt:   timestamppb.New(g.Group.CreatedAt),
+	}
+}
+
+func groupRoleToProto(r service.GroupRole) v1.DeviceGroupRole {
+	switch r {
+	case service.GroupRoleOwner:
+		return v1.DeviceGroupRole_DEVICE_GROUP_ROLE_OWNER
+	case service.GroupRoleManager:
+		return v1.DeviceGroupRole_DEVICE_GROUP_ROLE_MANAGER
+	case service.GroupRoleOperator:
+		return v1.DeviceGroupRole_DEVICE_GROUP_ROLE_OPERATOR
+	case service.GroupRoleViewer:
+		return v1.DeviceGroupRole_DEVICE_GROUP_ROLE_VIEWER
+	default:
+		return v1.DeviceGroupRole_DEVICE_GROUP_ROLE_UNKNOWN
+	}
+}
+
+func groupRoleFromProto(r v1.DeviceGroupRole) service.GroupRole {
+	switch r {
+	case v1.DeviceGroupRole_DEVICE_GROUP_ROLE_OWNER:
+		return service.GroupRoleOwner
+	case v1.DeviceGroupRole_DEVICE_GROUP_ROLE_MANAGER:
+		return service.GroupRoleManager
+	case v1.DeviceGroupRole_DEVICE_GROUP_ROLE_OPERATOR:
+		return service.GroupRoleOperator
+	case v1.DeviceGroupRole_DEVICE_GROUP_ROLE_VIEWER:
+		return service.GroupRoleViewer
+	default:
+		return ""
+	}
+}
+
+func bulkToProto(st *service.BulkOperationStatus) *v1.BulkCommandInfo {
+	op := st.Operation
+	return &v1.BulkCommandInfo{
+		BulkId:            op.ID,
+		GroupId:           op.GroupID,
+		CommandType:       commandTypeToProto(op.CommandType),
+		IssuedBy:          op.IssuedBy,
+		TargetCount:       int32(op.TargetCount),
+		SkippedDeviceIds:  op.SkippedDeviceIDs,
+		PendingCount:      int32(st.Pending),
+		AcknowledgedCount: int32(st.Acknowledged),
+		FailedCount:       int32(st.Failed),
+		CreatedAt:         timestamppb.New(op.CreatedAt),
+	}
+}
+
+// fleetStatsToProto는 FleetStats를 proto 메시지로 변환합니다.
+func fleetStatsToProto(st *service.FleetStats) *v1.FleetStats {
+	return &v1.FleetStats{
+		GroupId:                      st.GroupID,
+		GroupName:                    st.GroupName,
+		TotalDevices:                 int32(st.TotalDevices),
+		OnlineCount:                  int32(st.Online),
+		OnlinePercent:                st.OnlinePercent,
+		CalibratedCount:              int32(st.Calibrated),
+		CalibrationCompliancePercent: st.CalibrationCompliance,
+		CartridgesUsed:               int32(st.CartridgesUsed),
+		LowBatteryCount:              int32(st.LowBattery),
+		PeriodDays:                   int32(st.PeriodDays),
+		CalculatedAt:                 timestamppb.New(st.CalculatedAt),
+	}
+}
+
 // toGRPC는 AppError를 gRPC status로 변환합니다.
 func toGRPC(err error) error {
 	if err == nil {
@@ -154,3 +154,68 @@ func (r *DeviceRepository) Delete(ctx context.Context, deviceID string) error {
 	r.rdb.Del(ctx, keys...)
 	return nil
 }
+
+// UpdateOwner는 소유자를 변경하고 이전/새 소유자의 캐시를 무효화합니다.
+func (r *DeviceRepository) UpdateOwner(ctx context.Context, deviceID, userID string) error {
+	device, _ := r.inner.GetByID(ctx, deviceID)
+
+	if err := r.inner.UpdateOwner(ctx, deviceID, userID); err != nil {
+		return err
+	}
+
+	keys := []string{
+		deviceByIDPrefix + deviceID,
+		deviceListByUser + userID,
+		deviceCountByUser + userID,
+	}
+	if device != nil {
+		keys = append(keys,
+			deviceListByUser+device.UserID,
+			deviceCountByUser+device.UserID,
+		)
+	}
+	r.rdb.Del(ctx, keys...)
+	return nil
+}
+
+// Deregister는 디바이스를 등록 해제하고 캐시를 무효화합니다 (구독 슬롯 즉시 반환).
+func (r *DeviceRepository) Deregister(ctx context.Context, deviceID string, at time.Time) error {
+	device, _ := r.inner.GetByID(ctx, deviceID)
+
+	if err := r.inner.Deregister(ctx, deviceID, at); err != nil {
+		return err
+	}
+
+	keys := []string{deviceByIDPrefix + deviceID}
+	if device != nil {
+		keys = append(keys,
+			deviceListByUser+device.UserID,
+			deviceCountByUser+device.UserID,
+		)
+	}
+	r.rdb.Del(ctx, keys...)
+	return nil
+}
+
+// SetParentHub는 상위 허브를 변경하고 캐시를 무효화합니다.
+func (r *DeviceRepository) SetParentHub(ctx context.Context, deviceID, hubID string) error {
+	if err := r.inner.SetParentHub(ctx, deviceID, hubID); err != nil {
+		return err
+	}
+	r.rdb.Del(ctx, deviceByIDPrefix+deviceID)
+	return nil
+}
+
+// RecordRelay는 마지막 중계 허브를 기록하고 캐시를 무효화합니다.
+func (r *DeviceRepository) RecordRelay(ctx context.Context, deviceID, hubID string, at time.Time) error {
+	if err := r.inner.RecordRelay(ctx, deviceID, hubID, at); err != nil {
+		return err
+	}
+	r.rdb.Del(ctx, deviceByIDPrefix+deviceID)
+	return nil
+}
+
+// ListByParentHub는 허브 하위 디바이스를 DB에서 직접 조회합니다 (상태 집계용이므로 캐시하지 않음).
+func (r *DeviceRepository) ListByParentHub(ctx context.Context, hubID string) ([]*service.Device, error) {
+	return r.inner.ListByParentHub(ctx, hubID)
+}
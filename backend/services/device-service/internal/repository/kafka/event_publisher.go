@@ -84,3 +84,67 @@ func (p *EventPublisher) PublishDeviceStatusChanged(ctx context.Context, event *
 
 	return p.eventBus.Publish(ctx, kafkaEvent)
 }
+
+// PublishDeviceTransferred는 디바이스 소유권 이전 이벤트를 Kafka에 발행합니다.
+//
+// 측정·보정 서비스는 transferred_at을 기준으로 이력의 소유자를 구분합니다.
+func (p *EventPublisher) PublishDeviceTransferred(ctx context.Context, event *service.DeviceTransferredEvent) error {
+	payload := map[string]interface{}{
+		"device_id":      event.DeviceID,
+		"transfer_id":    event.TransferID,
+		"from_user_id":   event.FromUserID,
+		"to_user_id":     event.ToUserID,
+		"serial_number":  event.SerialNumber,
+		"transferred_at": event.TransferredAt.Format(time.RFC3339),
+	}
+
+	payloadBytes, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("이벤트 페이로드 직렬화 실패: %w", err)
+	}
+
+	kafkaEvent := events.Event{
+		Type: events.EventDeviceTransferred,
+		Payload: map[string]interface{}{
+			"event_id":   uuid.New().String(),
+			"event_type": "manpasik." + events.EventDeviceTransferred,
+			"version":    "1.0",
+			"timestamp":  time.Now().UTC().Format(time.RFC3339),
+			"source":     "device-service",
+			"user_id":    event.ToUserID,
+			"payload":    json.RawMessage(payloadBytes),
+		},
+	}
+
+	return p.eventBus.Publish(ctx, kafkaEvent)
+}
+
+// PublishDeviceDeregistered는 디바이스 등록 해제 이벤트를 Kafka에 발행합니다.
+func (p *EventPublisher) PublishDeviceDeregistered(ctx context.Context, event *service.DeviceDeregisteredEvent) error {
+	payload := map[string]interface{}{
+		"device_id":       event.DeviceID,
+		"serial_number":   event.SerialNumber,
+		"reason":          event.Reason,
+		"deregistered_at": event.DeregisteredAt.Format(time.RFC3339),
+	}
+
+	payloadBytes, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("이벤트 페이로드 직렬화 실패: %w", err)
+	}
+
+	kafkaEvent := events.Event{
+		Type: events.EventDeviceDeregistered,
+		Payload: map[string]interface{}{
+			"event_id":   uuid.New().String(),
+			"event_type": "manpasik." + events.EventDeviceDeregistered,
+			"version":    "1.0",
+			"timestamp":  time.Now().UTC().Format(time.RFC3339),
+			"source":     "device-service",
+			"user_id":    event.UserID,
+			"payload":    json.RawMessage(payloadBytes),
+		},
+	}
+
+	return p.eventBus.Publish(ctx, kafkaEvent)
+}
@@ -0,0 +1,33 @@
+package kafka
+
+import (
+	"context"
+	"time"
+
+	"github.com/manpasik/backend/shared/events"
+)
+
+// CartridgeUsageRecorder는 측정 완료 시 카트리지 사용을 기록하는 대상입니다.
+type CartridgeUsageRecorder interface {
+	RecordCartridgeUse(ctx context.Context, deviceID string, at time.Time) error
+}
+
+// NewMeasurementCompletedHandler는 measurement.completed 이벤트를 받아
+// 디바이스별 카트리지 사용량(측정 1건 = 카트리지 1개)을 기록하는 핸들러를 반환합니다.
+func NewMeasurementCompletedHandler(recorder CartridgeUsageRecorder) events.Handler {
+	return func(ctx context.Context, event events.Event) error {
+		payload := events.PayloadOf(event)
+		deviceID, _ := payload["device_id"].(string)
+		if deviceID == "" {
+			return nil
+		}
+
+		at := time.Now().UTC()
+		if s, ok := payload["completed_at"].(string); ok {
+			if t, err := time.Parse(time.RFC3339, s); err == nil {
+				at = t
+			}
+		}
+		return recorder.RecordCartridgeUse(ctx, deviceID, at)
+	}
+}
@@ -45,7 +45,7 @@ func (r *DeviceRepository) ListByUser(_ context.Context, userID string) ([]*serv
 	defer r.mu.RUnlock()
 	var result []*service.Device
 	for _, d := range r.devices {
-		if d.UserID == userID {
+		if d.UserID == userID && d.DeregisteredAt.IsZero() {
 			cp := *d
 			result = append(result, &cp)
 		}
@@ -71,7 +71,7 @@ func (r *DeviceRepository) CountByUser(_ context.Context, userID string) (int, e
 	defer r.mu.RUnlock()
 	count := 0
 	for _, d := range r.devices {
-		if d.UserID == userID {
+		if d.UserID == userID && d.DeregisteredAt.IsZero() {
 			count++
 		}
 	}
@@ -84,3 +84,62 @@ func (r *DeviceRepository) Delete(_ context.Context, deviceID string) error {
 	delete(r.devices, deviceID)
 	return nil
 }
+
+func (r *DeviceRepository) UpdateOwner(_ context.Context, deviceID, userID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	d, ok := r.devices[deviceID]
+	if !ok {
+		return nil
+	}
+	d.UserID = userID
+	return nil
+}
+
+func (r *DeviceRepository) Deregister(_ context.Context, deviceID string, at time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	d, ok := r.devices[deviceID]
+	if !ok {
+		return nil
+	}
+	d.Status = service.StatusDeregistered
+	d.DeregisteredAt = at
+	return nil
+}
+
+func (r *DeviceRepository) SetParentHub(_ context.Context, deviceID, hubID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	d, ok := r.devices[deviceID]
+	if !ok {
+		return nil
+	}
+	d.ParentHubID = hubID
+	return nil
+}
+
+func (r *DeviceRepository) RecordRelay(_ context.Context, deviceID, hubID string, at time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	d, ok := r.devices[deviceID]
+	if !ok {
+		return nil
+	}
+	d.LastRelayHubID = hubID
+	d.LastRelayAt = at
+	return nil
+}
+
+func (r *DeviceRepository) ListByParentHub(_ context.Context, hubID string) ([]*service.Device, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var result []*service.Device
+	for _, d := range r.devices {
+		if d.ParentHubID == hubID && d.DeregisteredAt.IsZero() {
+			cp := *d
+			result = append(result, &cp)
+		}
+	}
+	return result, nil
+}
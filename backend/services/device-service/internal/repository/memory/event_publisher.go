@@ -21,3 +21,11 @@ func (p *EventPublisher) PublishDeviceRegistered(_ context.Context, _ *service.D
 func (p *EventPublisher) PublishDeviceStatusChanged(_ context.Context, _ *service.DeviceStatusChangedEvent) error {
 	return nil
 }
+
+func (p *EventPublisher) PublishDeviceTransferred(_ context.Context, _ *service.DeviceTransferredEvent) error {
+	return nil
+}
+
+func (p *EventPublisher) PublishDeviceDeregistered(_ context.Context, _ *service.DeviceDeregisteredEvent) error {
+	return nil
+}
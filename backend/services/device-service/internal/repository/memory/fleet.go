@@ -0,0 +1,222 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/manpasik/backend/services/device-service/internal/service"
+)
+
+// FleetRepository는 인메모리 디바이스 그룹·일괄 작업·플릿 사용량 저장소입니다.
+type FleetRepository struct {
+	mu           sync.RWMutex
+	groups       map[string]*service.DeviceGroup            // key: group ID
+	members      map[string]map[string]*service.GroupMember // key: group ID → user ID
+	devices      map[string]map[string]time.Time            // key: group ID → device ID → 추가 시각
+	bulks        map[string]*service.BulkOperation          // key: bulk ID
+	calibrations map[string]time.Time                       // key: device ID
+	cartridges   map[string][]time.Time                     // key: device ID
+}
+
+// NewFleetRepository는 인메모리 FleetRepository를 생성합니다.
+func NewFleetRepository() *FleetRepository {
+	return &FleetRepository{
+		groups:       make(map[string]*service.DeviceGroup),
+		members:      make(map[string]map[string]*service.GroupMember),
+		devices:      make(map[string]map[string]time.Time),
+		bulks:        make(map[string]*service.BulkOperation),
+		calibrations: make(map[string]time.Time),
+		cartridges:   make(map[string][]time.Time),
+	}
+}
+
+func (r *FleetRepository) CreateGroup(_ context.Context, g *service.DeviceGroup) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	cp := *g
+	r.groups[g.ID] = &cp
+	r.members[g.ID] = make(map[string]*service.GroupMember)
+	r.devices[g.ID] = make(map[string]time.Time)
+	return nil
+}
+
+func (r *FleetRepository) GetGroup(_ context.Context, groupID string) (*service.DeviceGroup, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	g, ok := r.groups[groupID]
+	if !ok {
+		return nil, nil
+	}
+	cp := *g
+	return &cp, nil
+}
+
+func (r *FleetRepository) ListGroups(_ context.Context) ([]*service.DeviceGroup, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	result := make([]*service.DeviceGroup, 0, len(r.groups))
+	for _, g := range r.groups {
+		cp := *g
+		result = append(result, &cp)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].CreatedAt.Before(result[j].CreatedAt) })
+	return result, nil
+}
+
+func (r *FleetRepository) ListGroupsByMember(_ context.Context, userID string) ([]*service.DeviceGroup, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var result []*service.DeviceGroup
+	for groupID, members := range r.members {
+		if _, ok := members[userID]; ok {
+			cp := *r.groups[groupID]
+			result = append(result, &cp)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].CreatedAt.Before(result[j].CreatedAt) })
+	return result, nil
+}
+
+func (r *FleetRepository) UpsertMember(_ context.Context, m *service.GroupMember) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	members, ok := r.members[m.GroupID]
+	if !ok {
+		return nil
+	}
+	cp := *m
+	members[m.UserID] = &cp
+	return nil
+}
+
+func (r *FleetRepository) RemoveMember(_ context.Context, groupID, userID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.members[groupID], userID)
+	return nil
+}
+
+func (r *FleetRepository) GetMember(_ context.Context, groupID, userID string) (*service.GroupMember, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	m, ok := r.members[groupID][userID]
+	if !ok {
+		return nil, nil
+	}
+	cp := *m
+	return &cp, nil
+}
+
+func (r *FleetRepository) ListMembers(_ context.Context, groupID string) ([]*service.GroupMember, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	result := make([]*service.GroupMember, 0, len(r.members[groupID]))
+	for _, m := range r.members[groupID] {
+		cp := *m
+		result = append(result, &cp)
+	}
+	return result, nil
+}
+
+func (r *FleetRepository) AddDevice(_ context.Context, groupID, deviceID string, at time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	devices, ok := r.devices[groupID]
+	if !ok {
+		return nil
+	}
+	if _, exists := devices[deviceID]; !exists {
+		devices[deviceID] = at
+	}
+	return nil
+}
+
+func (r *FleetRepository) RemoveDevice(_ context.Context, groupID, deviceID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.devices[groupID], deviceID)
+	return nil
+}
+
+func (r *FleetRepository) RemoveDeviceFromAllGroups(_ context.Context, deviceID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, devices := range r.devices {
+		delete(devices, deviceID)
+	}
+	return nil
+}
+
+func (r *FleetRepository) ListDeviceIDs(_ context.Context, groupID string) ([]string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	result := make([]string, 0, len(r.devices[groupID]))
+	for id := range r.devices[groupID] {
+		result = append(result, id)
+	}
+	sort.Strings(result)
+	return result, nil
+}
+
+func (r *FleetRepository) CreateBulkOperation(_ context.Context, op *service.BulkOperation) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	cp := *op
+	r.bulks[op.ID] = &cp
+	return nil
+}
+
+func (r *FleetRepository) GetBulkOperation(_ context.Context, bulkID string) (*service.BulkOperation, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	op, ok := r.bulks[bulkID]
+	if !ok {
+		return nil, nil
+	}
+	cp := *op
+	return &cp, nil
+}
+
+func (r *FleetRepository) RecordCalibration(_ context.Context, deviceID string, at time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if prev, ok := r.calibrations[deviceID]; !ok || at.After(prev) {
+		r.calibrations[deviceID] = at
+	}
+	return nil
+}
+
+func (r *FleetRepository) RecordCartridgeUse(_ context.Context, deviceID string, at time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cartridges[deviceID] = append(r.cartridges[deviceID], at)
+	return nil
+}
+
+func (r *FleetRepository) LastCalibrations(_ context.Context, deviceIDs []string) (map[string]time.Time, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	result := make(map[string]time.Time, len(deviceIDs))
+	for _, id := range deviceIDs {
+		if at, ok := r.calibrations[id]; ok {
+			result[id] = at
+		}
+	}
+	return result, nil
+}
+
+func (r *FleetRepository) CountCartridgeUse(_ context.Context, deviceIDs []string, since time.Time) (int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	count := 0
+	for _, id := range deviceIDs {
+		for _, at := range r.cartridges[id] {
+			if !at.Before(since) {
+				count++
+			}
+		}
+	}
+	return count, nil
+}
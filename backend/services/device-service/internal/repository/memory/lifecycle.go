@@ -0,0 +1,227 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/manpasik/backend/services/device-service/internal/service"
+)
+
+// ============================================================================
+// TransferRepository
+// ============================================================================
+
+// TransferRepository는 인메모리 소유권 이전 요청 저장소입니다.
+type TransferRepository struct {
+	mu        sync.RWMutex
+	transfers map[string]*service.DeviceTransfer // key: transfer ID
+}
+
+// NewTransferRepository는 인메모리 TransferRepository를 생성합니다.
+func NewTransferRepository() *TransferRepository {
+	return &TransferRepository{
+		transfers: make(map[string]*service.DeviceTransfer),
+	}
+}
+
+func (r *TransferRepository) Create(_ context.Context, t *service.DeviceTransfer) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	cp := *t
+	r.transfers[t.ID] = &cp
+	return nil
+}
+
+func (r *TransferRepository) GetByID(_ context.Context, transferID string) (*service.DeviceTransfer, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	t, ok := r.transfers[transferID]
+	if !ok {
+		return nil, nil
+	}
+	cp := *t
+	return &cp, nil
+}
+
+func (r *TransferRepository) GetPendingByDevice(_ context.Context, deviceID string) (*service.DeviceTransfer, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, t := range r.transfers {
+		if t.DeviceID == deviceID && t.Status == service.TransferPending {
+			cp := *t
+			return &cp, nil
+		}
+	}
+	return nil, nil
+}
+
+// Confirm은 대기 중인 요청에 userID 쪽 확인을 기록합니다.
+func (r *TransferRepository) Confirm(_ context.Context, transferID, userID string) (*service.DeviceTransfer, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	t, ok := r.transfers[transferID]
+	if !ok || t.Status != service.TransferPending {
+		return nil, nil
+	}
+	if userID == t.FromUserID {
+		t.FromConfirmed = true
+	}
+	if userID == t.ToUserID {
+		t.ToConfirmed = true
+	}
+	cp := *t
+	return &cp, nil
+}
+
+// UpdateStatus는 대기 중인 요청만 status로 전환합니다.
+func (r *TransferRepository) UpdateStatus(_ context.Context, transferID string, status service.TransferStatus, completedAt time.Time) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	t, ok := r.transfers[transferID]
+	if !ok || t.Status != service.TransferPending {
+		return false, nil
+	}
+	t.Status = status
+	t.CompletedAt = completedAt
+	return true, nil
+}
+
+// ============================================================================
+// OwnershipRepository
+// ============================================================================
+
+// OwnershipRepository는 인메모리 디바이스 소유 기간 저장소입니다.
+type OwnershipRepository struct {
+	mu      sync.RWMutex
+	periods map[string][]*service.OwnershipPeriod // key: device ID
+}
+
+// NewOwnershipRepository는 인메모리 OwnershipRepository를 생성합니다.
+func NewOwnershipRepository() *OwnershipRepository {
+	return &OwnershipRepository{
+		periods: make(map[string][]*service.OwnershipPeriod),
+	}
+}
+
+func (r *OwnershipRepository) Open(_ context.Context, p *service.OwnershipPeriod) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	cp := *p
+	r.periods[p.DeviceID] = append(r.periods[p.DeviceID], &cp)
+	return nil
+}
+
+func (r *OwnershipRepository) Close(_ context.Context, deviceID string, endedAt time.Time, reason string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, p := range r.periods[deviceID] {
+		if p.EndedAt.IsZero() {
+			p.EndedAt = endedAt
+			p.EndReason = reason
+		}
+	}
+	return nil
+}
+
+func (r *OwnershipRepository) ListByDevice(_ context.Context, deviceID string) ([]*service.OwnershipPeriod, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	result := make([]*service.OwnershipPeriod, 0, len(r.periods[deviceID]))
+	for _, p := range r.periods[deviceID] {
+		cp := *p
+		result = append(result, &cp)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].StartedAt.Before(result[j].StartedAt) })
+	return result, nil
+}
+
+// ============================================================================
+// CommandRepository
+// ============================================================================
+
+// CommandRepository는 인메모리 디바이스 명령 대기열입니다.
+type CommandRepository struct {
+	mu       sync.RWMutex
+	commands map[string]*service.QueuedCommand // key: command ID
+}
+
+// NewCommandRepository는 인메모리 CommandRepository를 생성합니다.
+func NewCommandRepository() *CommandRepository {
+	return &CommandRepository{
+		commands: make(map[string]*service.QueuedCommand),
+	}
+}
+
+func (r *CommandRepository) Enqueue(_ context.Context, cmd *service.QueuedCommand) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	cp := *cmd
+	r.commands[cmd.ID] = &cp
+	return nil
+}
+
+func (r *CommandRepository) GetByID(_ context.Context, commandID string) (*service.QueuedCommand, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	c, ok := r.commands[commandID]
+	if !ok {
+		return nil, nil
+	}
+	cp := *c
+	return &cp, nil
+}
+
+func (r *CommandRepository) ListPending(_ context.Context, deviceID string) ([]*service.QueuedCommand, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var result []*service.QueuedCommand
+	for _, c := range r.commands {
+		if c.DeviceID == deviceID && (c.Status == service.CommandQueued || c.Status == service.CommandDelivered) {
+			cp := *c
+			result = append(result, &cp)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].CreatedAt.Before(result[j].CreatedAt) })
+	return result, nil
+}
+
+func (r *CommandRepository) ListPendingByRoute(_ context.Context, hubID string) ([]*service.QueuedCommand, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var result []*service.QueuedCommand
+	for _, c := range r.commands {
+		if c.RouteHubID == hubID && (c.Status == service.CommandQueued || c.Status == service.CommandDelivered) {
+			cp := *c
+			result = append(result, &cp)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].CreatedAt.Before(result[j].CreatedAt) })
+	return result, nil
+}
+
+func (r *CommandRepository) ListByBulk(_ context.Context, bulkID string) ([]*service.QueuedCommand, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var result []*service.QueuedCommand
+	for _, c := range r.commands {
+		if c.BulkID == bulkID {
+			cp := *c
+			result = append(result, &cp)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].CreatedAt.Before(result[j].CreatedAt) })
+	return result, nil
+}
+
+func (r *CommandRepository) Update(_ context.Context, cmd *service.QueuedCommand) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.commands[cmd.ID]; !ok {
+		return nil
+	}
+	cp := *cmd
+	r.commands[cmd.ID] = &cp
+	return nil
+}
@@ -16,3 +16,8 @@ func NewSubscriptionChecker() *SubscriptionChecker {
 func (c *SubscriptionChecker) GetMaxDevices(_ context.Context, _ string) (int, error) {
 	return 999, nil
 }
+
+// IsClinical은 클리니컬 구독 여부를 반환합니다 (개발용: 항상 허용).
+func (c *SubscriptionChecker) IsClinical(_ context.Context, _ string) (bool, error) {
+	return true, nil
+}
@@ -25,10 +25,46 @@ func NewDeviceRepository(pool *pgxpool.Pool) *DeviceRepository {
 	return &DeviceRepository{pool: pool}
 }
 
+const deviceColumns = `id, device_id, user_id, COALESCE(name, ''), serial_number, firmware_version, status, battery_percent, last_seen, registered_at,
+	deregistered_at, kind, COALESCE(parent_hub_id::text, ''), COALESCE(last_relay_hub_id::text, ''), last_relay_at`
+
+func scanDevice(row pgx.Row) (*service.Device, error) {
+	var d service.Device
+	var status, kind string
+	var deregisteredAt, lastRelayAt *time.Time
+	if err := row.Scan(
+		&d.ID, &d.DeviceID, &d.UserID, &d.Name, &d.SerialNumber,
+		&d.FirmwareVersion, &status, &d.BatteryPercent, &d.LastSeen, &d.RegisteredAt,
+		&deregisteredAt, &kind, &d.ParentHubID, &d.LastRelayHubID, &lastRelayAt,
+	); err != nil {
+		return nil, err
+	}
+	d.Status = service.DeviceStatus(status)
+	d.Kind = service.DeviceKind(kind)
+	if deregisteredAt != nil {
+		d.DeregisteredAt = *deregisteredAt
+	}
+	if lastRelayAt != nil {
+		d.LastRelayAt = *lastRelayAt
+	}
+	return &d, nil
+}
+
+func nullableID(id string) *string {
+	if id == "" {
+		return nil
+	}
+	return &id
+}
+
 // Create는 디바이스를 생성합니다.
 func (r *DeviceRepository) Create(ctx context.Context, device *service.Device) error {
-	const q = `INSERT INTO devices (id, device_id, user_id, name, serial_number, firmware_version, status, battery_percent, last_seen, registered_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`
+	const q = `INSERT INTO devices (id, device_id, user_id, name, serial_number, firmware_version, status, battery_percent, last_seen, registered_at, kind, parent_hub_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)`
+	kind := device.Kind
+	if kind == "" {
+		kind = service.KindReader
+	}
 	_, err := r.pool.Exec(ctx, q,
 		device.ID,
 		device.DeviceID,
@@ -40,35 +76,34 @@ func (r *DeviceRepository) Create(ctx context.Context, device *service.Device) e
 		device.BatteryPercent,
 		device.LastSeen,
 		device.RegisteredAt,
+		string(kind),
+		nullableID(device.ParentHubID),
 	)
 	return err
 }
 
 // GetByID는 ID로 디바이스를 조회합니다.
 func (r *DeviceRepository) GetByID(ctx context.Context, deviceID string) (*service.Device, error) {
-	const q = `SELECT id, device_id, user_id, COALESCE(name, ''), serial_number, firmware_version, status, battery_percent, last_seen, registered_at
-		FROM devices WHERE id = $1`
-	var d service.Device
-	var status string
-	err := r.pool.QueryRow(ctx, q, deviceID).Scan(
-		&d.ID, &d.DeviceID, &d.UserID, &d.Name, &d.SerialNumber,
-		&d.FirmwareVersion, &status, &d.BatteryPercent, &d.LastSeen, &d.RegisteredAt,
-	)
+	const q = `SELECT ` + deviceColumns + ` FROM devices WHERE id = $1`
+	d, err := scanDevice(r.pool.QueryRow(ctx, q, deviceID))
 	if err != nil {
 		if err == pgx.ErrNoRows {
 			return nil, nil
 		}
 		return nil, err
 	}
-	d.Status = service.DeviceStatus(status)
-	return &d, nil
+	return d, nil
 }
 
 // ListByUser는 사용자의 디바이스 목록을 조회합니다.
 func (r *DeviceRepository) ListByUser(ctx context.Context, userID string) ([]*service.Device, error) {
-	const q = `SELECT id, device_id, user_id, COALESCE(name, ''), serial_number, firmware_version, status, battery_percent, last_seen, registered_at
-		FROM devices WHERE user_id = $1 ORDER BY registered_at DESC`
-	rows, err := r.pool.Query(ctx, q, userID)
+	const q = `SELECT ` + deviceColumns + `
+		FROM devices WHERE user_id = $1 AND deregistered_at IS NULL ORDER BY registered_at DESC`
+	return r.queryDevices(ctx, q, userID)
+}
+
+func (r *DeviceRepository) queryDevices(ctx context.Context, q string, args ...interface{}) ([]*service.Device, error) {
+	rows, err := r.pool.Query(ctx, q, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -76,16 +111,11 @@ func (r *DeviceRepository) ListByUser(ctx context.Context, userID string) ([]*se
 
 	var devices []*service.Device
 	for rows.Next() {
-		var d service.Device
-		var status string
-		if err := rows.Scan(
-			&d.ID, &d.DeviceID, &d.UserID, &d.Name, &d.SerialNumber,
-			&d.FirmwareVersion, &status, &d.BatteryPercent, &d.LastSeen, &d.RegisteredAt,
-		); err != nil {
+		d, err := scanDevice(rows)
+		if err != nil {
 			return nil, err
 		}
-		d.Status = service.DeviceStatus(status)
-		devices = append(devices, &d)
+		devices = append(devices, d)
 	}
 	return devices, rows.Err()
 }
@@ -99,7 +129,7 @@ func (r *DeviceRepository) UpdateStatus(ctx context.Context, deviceID string, st
 
 // CountByUser는 사용자의 디바이스 수를 반환합니다.
 func (r *DeviceRepository) CountByUser(ctx context.Context, userID string) (int, error) {
-	const q = `SELECT COUNT(*) FROM devices WHERE user_id = $1`
+	const q = `SELECT COUNT(*) FROM devices WHERE user_id = $1 AND deregistered_at IS NULL`
 	var count int
 	err := r.pool.QueryRow(ctx, q, userID).Scan(&count)
 	return count, err
@@ -112,6 +142,41 @@ func (r *DeviceRepository) Delete(ctx context.Context, deviceID string) error {
 	return err
 }
 
+// UpdateOwner는 디바이스 소유자를 변경합니다.
+func (r *DeviceRepository) UpdateOwner(ctx context.Context, deviceID, userID string) error {
+	const q = `UPDATE devices SET user_id = $1 WHERE id = $2`
+	_, err := r.pool.Exec(ctx, q, userID, deviceID)
+	return err
+}
+
+// Deregister는 디바이스를 등록 해제 상태로 전환합니다 (레코드·이력은 보존).
+func (r *DeviceRepository) Deregister(ctx context.Context, deviceID string, at time.Time) error {
+	const q = `UPDATE devices SET status = $1, deregistered_at = $2 WHERE id = $3`
+	_, err := r.pool.Exec(ctx, q, string(service.StatusDeregistered), at, deviceID)
+	return err
+}
+
+// SetParentHub는 디바이스의 상위 허브를 변경합니다 (빈 값 = 분리).
+func (r *DeviceRepository) SetParentHub(ctx context.Context, deviceID, hubID string) error {
+	const q = `UPDATE devices SET parent_hub_id = $1 WHERE id = $2`
+	_, err := r.pool.Exec(ctx, q, nullableID(hubID), deviceID)
+	return err
+}
+
+// RecordRelay는 디바이스 상태를 마지막으로 중계한 허브를 기록합니다.
+func (r *DeviceRepository) RecordRelay(ctx context.Context, deviceID, hubID string, at time.Time) error {
+	const q = `UPDATE devices SET last_relay_hub_id = $1, last_relay_at = $2 WHERE id = $3`
+	_, err := r.pool.Exec(ctx, q, hubID, at, deviceID)
+	return err
+}
+
+// ListByParentHub는 허브에 배정된 활성 디바이스 목록을 조회합니다.
+func (r *DeviceRepository) ListByParentHub(ctx context.Context, hubID string) ([]*service.Device, error) {
+	const q = `SELECT ` + deviceColumns + `
+		FROM devices WHERE parent_hub_id = $1 AND deregistered_at IS NULL ORDER BY registered_at ASC`
+	return r.queryDevices(ctx, q, hubID)
+}
+
 // ============================================================================
 // DeviceEventRepository
 // ============================================================================
@@ -0,0 +1,248 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/manpasik/backend/services/device-service/internal/service"
+)
+
+// ============================================================================
+// FleetRepository
+// ============================================================================
+
+// FleetRepository는 PostgreSQL 기반 FleetRepository 구현입니다.
+type FleetRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewFleetRepository는 FleetRepository를 생성합니다.
+func NewFleetRepository(pool *pgxpool.Pool) *FleetRepository {
+	return &FleetRepository{pool: pool}
+}
+
+const groupColumns = `id, name, COALESCE(description, ''), owner_id, created_at`
+
+func (r *FleetRepository) queryGroups(ctx context.Context, q string, args ...interface{}) ([]*service.DeviceGroup, error) {
+	rows, err := r.pool.Query(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var groups []*service.DeviceGroup
+	for rows.Next() {
+		var g service.DeviceGroup
+		if err := rows.Scan(&g.ID, &g.Name, &g.Description, &g.OwnerID, &g.CreatedAt); err != nil {
+			return nil, err
+		}
+		groups = append(groups, &g)
+	}
+	return groups, rows.Err()
+}
+
+// CreateGroup은 디바이스 그룹을 생성합니다.
+func (r *FleetRepository) CreateGroup(ctx context.Context, g *service.DeviceGroup) error {
+	const q = `INSERT INTO device_groups (id, name, description, owner_id, created_at) VALUES ($1, $2, $3, $4, $5)`
+	_, err := r.pool.Exec(ctx, q, g.ID, g.Name, g.Description, g.OwnerID, g.CreatedAt)
+	return err
+}
+
+// GetGroup은 ID로 디바이스 그룹을 조회합니다.
+func (r *FleetRepository) GetGroup(ctx context.Context, groupID string) (*service.DeviceGroup, error) {
+	const q = `SELECT ` + groupColumns + ` FROM device_groups WHERE id = $1`
+	var g service.DeviceGroup
+	err := r.pool.QueryRow(ctx, q, groupID).Scan(&g.ID, &g.Name, &g.Description, &g.OwnerID, &g.CreatedAt)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &g, nil
+}
+
+// ListGroups는 전체 디바이스 그룹을 조회합니다.
+func (r *FleetRepository) ListGroups(ctx context.Context) ([]*service.DeviceGroup, error) {
+	const q = `SELECT ` + groupColumns + ` FROM device_groups ORDER BY created_at ASC`
+	return r.queryGroups(ctx, q)
+}
+
+// ListGroupsByMember는 사용자가 구성원인 그룹을 조회합니다.
+func (r *FleetRepository) ListGroupsByMember(ctx context.Context, userID string) ([]*service.DeviceGroup, error) {
+	const q = `SELECT g.id, g.name, COALESCE(g.description, ''), g.owner_id, g.created_at
+		FROM device_groups g JOIN device_group_members m ON m.group_id = g.id
+		WHERE m.user_id = $1 ORDER BY g.created_at ASC`
+	return r.queryGroups(ctx, q, userID)
+}
+
+// UpsertMember는 구성원을 추가하거나 역할을 변경합니다.
+func (r *FleetRepository) UpsertMember(ctx context.Context, m *service.GroupMember) error {
+	const q = `INSERT INTO device_group_members (group_id, user_id, role, added_at) VALUES ($1, $2, $3, $4)
+		ON CONFLICT (group_id, user_id) DO UPDATE SET role = EXCLUDED.role`
+	_, err := r.pool.Exec(ctx, q, m.GroupID, m.UserID, string(m.Role), m.AddedAt)
+	return err
+}
+
+// RemoveMember는 구성원을 제거합니다.
+func (r *FleetRepository) RemoveMember(ctx context.Context, groupID, userID string) error {
+	const q = `DELETE FROM device_group_members WHERE group_id = $1 AND user_id = $2`
+	_, err := r.pool.Exec(ctx, q, groupID, userID)
+	return err
+}
+
+// GetMember는 그룹 구성원을 조회합니다.
+func (r *FleetRepository) GetMember(ctx context.Context, groupID, userID string) (*service.GroupMember, error) {
+	const q = `SELECT group_id, user_id, role, added_at FROM device_group_members WHERE group_id = $1 AND user_id = $2`
+	var m service.GroupMember
+	var role string
+	err := r.pool.QueryRow(ctx, q, groupID, userID).Scan(&m.GroupID, &m.UserID, &role, &m.AddedAt)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	m.Role = service.GroupRole(role)
+	return &m, nil
+}
+
+// ListMembers는 그룹 구성원 목록을 조회합니다.
+func (r *FleetRepository) ListMembers(ctx context.Context, groupID string) ([]*service.GroupMember, error) {
+	const q = `SELECT group_id, user_id, role, added_at FROM device_group_members WHERE group_id = $1 ORDER BY added_at ASC`
+	rows, err := r.pool.Query(ctx, q, groupID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var members []*service.GroupMember
+	for rows.Next() {
+		var m service.GroupMember
+		var role string
+		if err := rows.Scan(&m.GroupID, &m.UserID, &role, &m.AddedAt); err != nil {
+			return nil, err
+		}
+		m.Role = service.GroupRole(role)
+		members = append(members, &m)
+	}
+	return members, rows.Err()
+}
+
+// AddDevice는 디바이스를 그룹에 추가합니다 (이미 있으면 무시).
+func (r *FleetRepository) AddDevice(ctx context.Context, groupID, deviceID string, at time.Time) error {
+	const q = `INSERT INTO device_group_devices (group_id, device_id, added_at) VALUES ($1, $2, $3)
+		ON CONFLICT (group_id, device_id) DO NOTHING`
+	_, err := r.pool.Exec(ctx, q, groupID, deviceID, at)
+	return err
+}
+
+// RemoveDevice는 디바이스를 그룹에서 제외합니다.
+func (r *FleetRepository) RemoveDevice(ctx context.Context, groupID, deviceID string) error {
+	const q = `DELETE FROM device_group_devices WHERE group_id = $1 AND device_id = $2`
+	_, err := r.pool.Exec(ctx, q, groupID, deviceID)
+	return err
+}
+
+// RemoveDeviceFromAllGroups는 디바이스를 속한 모든 그룹에서 제외합니다.
+func (r *FleetRepository) RemoveDeviceFromAllGroups(ctx context.Context, deviceID string) error {
+	_, err := r.pool.Exec(ctx, `DELETE FROM device_group_devices WHERE device_id = $1`, deviceID)
+	return err
+}
+
+// ListDeviceIDs는 그룹에 속한 디바이스 ID 목록을 조회합니다.
+func (r *FleetRepository) ListDeviceIDs(ctx context.Context, groupID string) ([]string, error) {
+	const q = `SELECT device_id FROM device_group_devices WHERE group_id = $1 ORDER BY device_id`
+	rows, err := r.pool.Query(ctx, q, groupID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// CreateBulkOperation은 일괄 작업을 기록합니다.
+func (r *FleetRepository) CreateBulkOperation(ctx context.Context, op *service.BulkOperation) error {
+	const q = `INSERT INTO device_bulk_operations (id, group_id, command_type, issued_by, target_count, skipped_device_ids, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`
+	skipped := op.SkippedDeviceIDs
+	if skipped == nil {
+		skipped = []string{}
+	}
+	_, err := r.pool.Exec(ctx, q, op.ID, op.GroupID, string(op.CommandType), op.IssuedBy, op.TargetCount, skipped, op.CreatedAt)
+	return err
+}
+
+// GetBulkOperation은 일괄 작업을 조회합니다.
+func (r *FleetRepository) GetBulkOperation(ctx context.Context, bulkID string) (*service.BulkOperation, error) {
+	const q = `SELECT id, group_id, command_type, issued_by, target_count, skipped_device_ids, created_at
+		FROM device_bulk_operations WHERE id = $1`
+	var op service.BulkOperation
+	var cmdType string
+	err := r.pool.QueryRow(ctx, q, bulkID).Scan(
+		&op.ID, &op.GroupID, &cmdType, &op.IssuedBy, &op.TargetCount, &op.SkippedDeviceIDs, &op.CreatedAt,
+	)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	op.CommandType = service.CommandType(cmdType)
+	return &op, nil
+}
+
+// RecordCalibration은 디바이스의 마지막 교정 성공 시각을 갱신합니다.
+func (r *FleetRepository) RecordCalibration(ctx context.Context, deviceID string, at time.Time) error {
+	const q = `INSERT INTO device_calibration_status (device_id, last_calibrated_at) VALUES ($1, $2)
+		ON CONFLICT (device_id) DO UPDATE SET last_calibrated_at = GREATEST(device_calibration_status.last_calibrated_at, EXCLUDED.last_calibrated_at)`
+	_, err := r.pool.Exec(ctx, q, deviceID, at)
+	return err
+}
+
+// RecordCartridgeUse는 카트리지 사용 1건을 기록합니다.
+func (r *FleetRepository) RecordCartridgeUse(ctx context.Context, deviceID string, at time.Time) error {
+	const q = `INSERT INTO device_cartridge_usage (device_id, used_at) VALUES ($1, $2)`
+	_, err := r.pool.Exec(ctx, q, deviceID, at)
+	return err
+}
+
+// LastCalibrations는 디바이스별 마지막 교정 성공 시각을 조회합니다.
+func (r *FleetRepository) LastCalibrations(ctx context.Context, deviceIDs []string) (map[string]time.Time, error) {
+	const q = `SELECT device_id, last_calibrated_at FROM device_calibration_status WHERE device_id = ANY($1::uuid[])`
+	rows, err := r.pool.Query(ctx, q, deviceIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make(map[string]time.Time, len(deviceIDs))
+	for rows.Next() {
+		var id string
+		var at time.Time
+		if err := rows.Scan(&id, &at); err != nil {
+			return nil, err
+		}
+		result[id] = at
+	}
+	return result, rows.Err()
+}
+
+// CountCartridgeUse는 기간 내 디바이스들의 카트리지 사용 건수를 반환합니다.
+func (r *FleetRepository) CountCartridgeUse(ctx context.Context, deviceIDs []string, since time.Time) (int, error) {
+	const q = `SELECT COUNT(*) FROM device_cartridge_usage WHERE device_id = ANY($1::uuid[]) AND used_at >= $2`
+	var count int
+	err := r.pool.QueryRow(ctx, q, deviceIDs, since).Scan(&count)
+	return count, err
+}
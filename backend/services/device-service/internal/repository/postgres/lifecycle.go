@@ -0,0 +1,260 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/manpasik/backend/services/device-service/internal/service"
+)
+
+// ============================================================================
+// TransferRepository
+// ============================================================================
+
+// TransferRepository는 PostgreSQL 기반 TransferRepository 구현입니다.
+type TransferRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewTransferRepository는 TransferRepository를 생성합니다.
+func NewTransferRepository(pool *pgxpool.Pool) *TransferRepository {
+	return &TransferRepository{pool: pool}
+}
+
+const transferColumns = `id, device_id, from_user_id, to_user_id, status, from_confirmed, to_confirmed, created_at, expires_at, completed_at`
+
+func scanTransfer(row pgx.Row) (*service.DeviceTransfer, error) {
+	var t service.DeviceTransfer
+	var status string
+	var completedAt *time.Time
+	if err := row.Scan(
+		&t.ID, &t.DeviceID, &t.FromUserID, &t.ToUserID, &status,
+		&t.FromConfirmed, &t.ToConfirmed, &t.CreatedAt, &t.ExpiresAt, &completedAt,
+	); err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	t.Status = service.TransferStatus(status)
+	if completedAt != nil {
+		t.CompletedAt = *completedAt
+	}
+	return &t, nil
+}
+
+// Create는 소유권 이전 요청을 생성합니다.
+func (r *TransferRepository) Create(ctx context.Context, t *service.DeviceTransfer) error {
+	const q = `INSERT INTO device_transfers (` + transferColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, NULL)`
+	_, err := r.pool.Exec(ctx, q,
+		t.ID, t.DeviceID, t.FromUserID, t.ToUserID, string(t.Status),
+		t.FromConfirmed, t.ToConfirmed, t.CreatedAt, t.ExpiresAt,
+	)
+	return err
+}
+
+// GetByID는 ID로 소유권 이전 요청을 조회합니다.
+func (r *TransferRepository) GetByID(ctx context.Context, transferID string) (*service.DeviceTransfer, error) {
+	const q = `SELECT ` + transferColumns + ` FROM device_transfers WHERE id = $1`
+	return scanTransfer(r.pool.QueryRow(ctx, q, transferID))
+}
+
+// GetPendingByDevice는 디바이스의 대기 중인 이전 요청을 조회합니다.
+func (r *TransferRepository) GetPendingByDevice(ctx context.Context, deviceID string) (*service.DeviceTransfer, error) {
+	const q = `SELECT ` + transferColumns + ` FROM device_transfers
+		WHERE device_id = $1 AND status = 'pending' ORDER BY created_at DESC LIMIT 1`
+	return scanTransfer(r.pool.QueryRow(ctx, q, deviceID))
+}
+
+// Confirm은 대기 중인 요청에 userID 쪽 확인을 조건부 UPDATE로 기록합니다.
+// 요청이 이미 종료되었으면 nil을 반환합니다.
+func (r *TransferRepository) Confirm(ctx context.Context, transferID, userID string) (*service.DeviceTransfer, error) {
+	const q = `UPDATE device_transfers
+		SET from_confirmed = from_confirmed OR from_user_id = $2,
+			to_confirmed = to_confirmed OR to_user_id = $2
+		WHERE id = $1 AND status = 'pending'
+		RETURNING ` + transferColumns
+	return scanTransfer(r.pool.QueryRow(ctx, q, transferID, userID))
+}
+
+// UpdateStatus는 대기 중인 요청만 status로 전환합니다.
+func (r *TransferRepository) UpdateStatus(ctx context.Context, transferID string, status service.TransferStatus, completedAt time.Time) (bool, error) {
+	const q = `UPDATE device_transfers SET status = $2, completed_at = $3
+		WHERE id = $1 AND status = 'pending'`
+	tag, err := r.pool.Exec(ctx, q, transferID, string(status), nullableTime(completedAt))
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+// ============================================================================
+// OwnershipRepository
+// ============================================================================
+
+// OwnershipRepository는 PostgreSQL 기반 OwnershipRepository 구현입니다.
+type OwnershipRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewOwnershipRepository는 OwnershipRepository를 생성합니다.
+func NewOwnershipRepository(pool *pgxpool.Pool) *OwnershipRepository {
+	return &OwnershipRepository{pool: pool}
+}
+
+// Open은 새 소유 기간을 시작합니다.
+func (r *OwnershipRepository) Open(ctx context.Context, p *service.OwnershipPeriod) error {
+	const q = `INSERT INTO device_ownerships (device_id, user_id, started_at) VALUES ($1, $2, $3)`
+	_, err := r.pool.Exec(ctx, q, p.DeviceID, p.UserID, p.StartedAt)
+	return err
+}
+
+// Close는 열려 있는 소유 기간을 종료합니다.
+func (r *OwnershipRepository) Close(ctx context.Context, deviceID string, endedAt time.Time, reason string) error {
+	const q = `UPDATE device_ownerships SET ended_at = $1, end_reason = $2
+		WHERE device_id = $3 AND ended_at IS NULL`
+	_, err := r.pool.Exec(ctx, q, endedAt, reason, deviceID)
+	return err
+}
+
+// ListByDevice는 디바이스의 소유 기간을 시간순으로 조회합니다.
+func (r *OwnershipRepository) ListByDevice(ctx context.Context, deviceID string) ([]*service.OwnershipPeriod, error) {
+	const q = `SELECT device_id, user_id, started_at, ended_at, COALESCE(end_reason, '')
+		FROM device_ownerships WHERE device_id = $1 ORDER BY started_at ASC`
+	rows, err := r.pool.Query(ctx, q, deviceID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var periods []*service.OwnershipPeriod
+	for rows.Next() {
+		var p service.OwnershipPeriod
+		var endedAt *time.Time
+		if err := rows.Scan(&p.DeviceID, &p.UserID, &p.StartedAt, &endedAt, &p.EndReason); err != nil {
+			return nil, err
+		}
+		if endedAt != nil {
+			p.EndedAt = *endedAt
+		}
+		periods = append(periods, &p)
+	}
+	return periods, rows.Err()
+}
+
+// ============================================================================
+// CommandRepository
+// ============================================================================
+
+// CommandRepository는 PostgreSQL 기반 CommandRepository 구현입니다.
+type CommandRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewCommandRepository는 CommandRepository를 생성합니다.
+func NewCommandRepository(pool *pgxpool.Pool) *CommandRepository {
+	return &CommandRepository{pool: pool}
+}
+
+const commandColumns = `id, device_id, command_type, payload, status, COALESCE(route_hub_id::text, ''), COALESCE(bulk_id::text, ''), issued_by, COALESCE(result, ''), created_at, delivered_at, acknowledged_at`
+
+func scanCommand(row pgx.Row) (*service.QueuedCommand, error) {
+	var c service.QueuedCommand
+	var cmdType, status string
+	var deliveredAt, acknowledgedAt *time.Time
+	if err := row.Scan(
+		&c.ID, &c.DeviceID, &cmdType, &c.Payload, &status, &c.RouteHubID, &c.BulkID, &c.IssuedBy, &c.Result,
+		&c.CreatedAt, &deliveredAt, &acknowledgedAt,
+	); err != nil {
+		return nil, err
+	}
+	c.CommandType = service.CommandType(cmdType)
+	c.Status = service.CommandStatus(status)
+	if deliveredAt != nil {
+		c.DeliveredAt = *deliveredAt
+	}
+	if acknowledgedAt != nil {
+		c.AcknowledgedAt = *acknowledgedAt
+	}
+	return &c, nil
+}
+
+func nullableTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
+
+// Enqueue는 명령을 대기열에 추가합니다.
+func (r *CommandRepository) Enqueue(ctx context.Context, cmd *service.QueuedCommand) error {
+	const q = `INSERT INTO device_commands (id, device_id, command_type, payload, status, route_hub_id, bulk_id, issued_by, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+	_, err := r.pool.Exec(ctx, q,
+		cmd.ID, cmd.DeviceID, string(cmd.CommandType), cmd.Payload, string(cmd.Status),
+		nullableID(cmd.RouteHubID), nullableID(cmd.BulkID), cmd.IssuedBy, cmd.CreatedAt,
+	)
+	return err
+}
+
+// GetByID는 ID로 명령을 조회합니다.
+func (r *CommandRepository) GetByID(ctx context.Context, commandID string) (*service.QueuedCommand, error) {
+	const q = `SELECT ` + commandColumns + ` FROM device_commands WHERE id = $1`
+	cmd, err := scanCommand(r.pool.QueryRow(ctx, q, commandID))
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	return cmd, err
+}
+
+// ListPending은 디바이스의 미확인 명령을 생성 순으로 조회합니다.
+func (r *CommandRepository) ListPending(ctx context.Context, deviceID string) ([]*service.QueuedCommand, error) {
+	const q = `SELECT ` + commandColumns + ` FROM device_commands
+		WHERE device_id = $1 AND status IN ('queued', 'delivered') ORDER BY created_at ASC`
+	return r.queryCommands(ctx, q, deviceID)
+}
+
+// ListPendingByRoute는 허브를 경유하도록 지정된 미확인 명령을 생성 순으로 조회합니다.
+func (r *CommandRepository) ListPendingByRoute(ctx context.Context, hubID string) ([]*service.QueuedCommand, error) {
+	const q = `SELECT ` + commandColumns + ` FROM device_commands
+		WHERE route_hub_id = $1 AND status IN ('queued', 'delivered') ORDER BY created_at ASC`
+	return r.queryCommands(ctx, q, hubID)
+}
+
+// ListByBulk는 일괄 작업으로 생성된 명령을 생성 순으로 조회합니다.
+func (r *CommandRepository) ListByBulk(ctx context.Context, bulkID string) ([]*service.QueuedCommand, error) {
+	const q = `SELECT ` + commandColumns + ` FROM device_commands WHERE bulk_id = $1 ORDER BY created_at ASC`
+	return r.queryCommands(ctx, q, bulkID)
+}
+
+func (r *CommandRepository) queryCommands(ctx context.Context, q string, args ...interface{}) ([]*service.QueuedCommand, error) {
+	rows, err := r.pool.Query(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var cmds []*service.QueuedCommand
+	for rows.Next() {
+		cmd, err := scanCommand(rows)
+		if err != nil {
+			return nil, err
+		}
+		cmds = append(cmds, cmd)
+	}
+	return cmds, rows.Err()
+}
+
+// Update는 명령의 상태와 결과를 갱신합니다.
+func (r *CommandRepository) Update(ctx context.Context, cmd *service.QueuedCommand) error {
+	const q = `UPDATE device_commands
+		SET status = $1, result = $2, delivered_at = $3, acknowledged_at = $4
+		WHERE id = $5`
+	_, err := r.pool.Exec(ctx, q,
+		string(cmd.Status), cmd.Result, nullableTime(cmd.DeliveredAt), nullableTime(cmd.AcknowledgedAt), cmd.ID,
+	)
+	return err
+}
@@ -1,6 +1,8 @@
 // Package service는 device-service의 비즈니스 로직을 구현합니다.
 //
-// 기능: 디바이스 등록, 상태 관리, OTA 업데이트, 구독 기반 디바이스 수 제한
+// 기능: 디바이스 등록, 상태 관리, OTA 업데이트, 구독 기반 디바이스 수 제한,
+// 소유권 이전, 등록 해제, 원격 초기화, 허브 토폴로지(리더 → Wi-Fi 허브 → 클라우드 게이트웨이),
+// 클리니컬 플릿 관리(디바이스 그룹, 일괄 명령, 플릿 통계)
 package service
 
 import (
@@ -38,10 +40,33 @@ type DeviceStatusChangedEvent struct {
 	LastSeen        time.Time
 }
 
+// DeviceTransferredEvent는 디바이스 소유권 이전 완료 이벤트입니다.
+//
+// TransferredAt 이전의 측정·보정 기록은 FromUserID에, 이후 기록은 ToUserID에 귀속됩니다.
+type DeviceTransferredEvent struct {
+	DeviceID      string
+	TransferID    string
+	FromUserID    string
+	ToUserID      string
+	SerialNumber  string
+	TransferredAt time.Time
+}
+
+// DeviceDeregisteredEvent는 디바이스 등록 해제 이벤트입니다.
+type DeviceDeregisteredEvent struct {
+	DeviceID       string
+	UserID         string
+	SerialNumber   string
+	Reason         string
+	DeregisteredAt time.Time
+}
+
 // KafkaEventPublisher는 Kafka 이벤트 발행 인터페이스입니다.
 type KafkaEventPublisher interface {
 	PublishDeviceRegistered(ctx context.Context, event *DeviceRegisteredEvent) error
 	PublishDeviceStatusChanged(ctx context.Context, event *DeviceStatusChangedEvent) error
+	PublishDeviceTransferred(ctx context.Context, event *DeviceTransferredEvent) error
+	PublishDeviceDeregistered(ctx context.Context, event *DeviceDeregisteredEvent) error
 }
 
 // DeviceService는 디바이스 관리 서비스입니다.
@@ -50,10 +75,17 @@ type DeviceService struct {
 	deviceRepo     DeviceRepository
 	eventRepo      DeviceEventRepository
 	subChecker     SubscriptionChecker
+	transferRepo   TransferRepository
+	ownershipRepo  OwnershipRepository
+	commandRepo    CommandRepository
 	eventPublisher KafkaEventPublisher
+	fleetRepo      FleetRepository
+	clinicChecker  ClinicalTierChecker
 }
 
 // DeviceRepository는 디바이스 데이터 저장소 인터페이스입니다.
+//
+// ListByUser와 CountByUser는 등록 해제된 디바이스를 제외합니다.
 type DeviceRepository interface {
 	Create(ctx context.Context, device *Device) error
 	GetByID(ctx context.Context, deviceID string) (*Device, error)
@@ -61,6 +93,11 @@ type DeviceRepository interface {
 	UpdateStatus(ctx context.Context, deviceID string, status DeviceStatus, battery int, lastSeen time.Time) error
 	CountByUser(ctx context.Context, userID string) (int, error)
 	Delete(ctx context.Context, deviceID string) error
+	UpdateOwner(ctx context.Context, deviceID, userID string) error
+	Deregister(ctx context.Context, deviceID string, at time.Time) error
+	SetParentHub(ctx context.Context, deviceID, hubID string) error
+	RecordRelay(ctx context.Context, deviceID, hubID string, at time.Time) error
+	ListByParentHub(ctx context.Context, hubID string) ([]*Device, error)
 }
 
 // DeviceEventRepository는 디바이스 이벤트 저장소입니다.
@@ -83,6 +120,8 @@ const (
 	StatusMeasuring DeviceStatus = "measuring"
 	StatusUpdating  DeviceStatus = "updating"
 	StatusError     DeviceStatus = "error"
+	// StatusDeregistered는 등록 해제되어 구독 슬롯을 차지하지 않는 상태입니다.
+	StatusDeregistered DeviceStatus = "deregistered"
 )
 
 // Device는 디바이스 엔티티입니다.
@@ -97,13 +136,18 @@ type Device struct {
 	BatteryPercent  int
 	LastSeen        time.Time
 	RegisteredAt    time.Time
+	DeregisteredAt  time.Time // zero = 활성
+	Kind            DeviceKind
+	ParentHubID     string // 배정된 상위 허브
+	LastRelayHubID  string // 마지막으로 상태를 중계한 허브
+	LastRelayAt     time.Time
 }
 
 // DeviceEvent는 디바이스 이벤트 로그입니다.
 type DeviceEvent struct {
 	ID        string
 	DeviceID  string
-	EventType string // "registered", "status_changed", "ota_started", "ota_completed", "error"
+	EventType string // "registered", "status_changed", "ota_started", "ota_completed", "transferred", "deregistered", "wipe_requested", "command_acknowledged", "error"
 	Payload   map[string]interface{}
 	CreatedAt time.Time
 }
@@ -114,12 +158,18 @@ func NewDeviceService(
 	deviceRepo DeviceRepository,
 	eventRepo DeviceEventRepository,
 	subChecker SubscriptionChecker,
+	transferRepo TransferRepository,
+	ownershipRepo OwnershipRepository,
+	commandRepo CommandRepository,
 ) *DeviceService {
 	return &DeviceService{
-		logger:     logger,
-		deviceRepo: deviceRepo,
-		eventRepo:  eventRepo,
-		subChecker: subChecker,
+		logger:        logger,
+		deviceRepo:    deviceRepo,
+		eventRepo:     eventRepo,
+		subChecker:    subChecker,
+		transferRepo:  transferRepo,
+		ownershipRepo: ownershipRepo,
+		commandRepo:   commandRepo,
 	}
 }
 
@@ -128,10 +178,19 @@ func (s *DeviceService) SetEventPublisher(ep KafkaEventPublisher) {
 	s.eventPublisher = ep
 }
 
-// RegisterDevice는 새 디바이스를 등록합니다.
+// RegisterDevice는 새 리더기를 등록합니다.
 func (s *DeviceService) RegisterDevice(
 	ctx context.Context,
 	deviceHwID, serialNumber, firmwareVersion, userID string,
+) (*Device, string, error) {
+	return s.registerDevice(ctx, deviceHwID, serialNumber, firmwareVersion, userID, KindReader)
+}
+
+// registerDevice는 디바이스 종류와 무관한 공통 등록 절차입니다.
+func (s *DeviceService) registerDevice(
+	ctx context.Context,
+	deviceHwID, serialNumber, firmwareVersion, userID string,
+	kind DeviceKind,
 ) (*Device, string, error) {
 	// 입력 검증
 	if deviceHwID == "" || userID == "" {
@@ -162,19 +221,29 @@ func (s *DeviceService) RegisterDevice(
 		ID:              uuid.New().String(),
 		DeviceID:        deviceHwID,
 		UserID:          userID,
-		Name:            fmt.Sprintf("ManPaSik Reader %s", serialNumber[:4]),
+		Name:            defaultDeviceName(kind, serialNumber),
 		SerialNumber:    serialNumber,
 		FirmwareVersion: firmwareVersion,
 		Status:          StatusOnline,
 		BatteryPercent:  100,
 		LastSeen:        time.Now().UTC(),
 		RegisteredAt:    time.Now().UTC(),
+		Kind:            kind,
 	}
 
 	if err := s.deviceRepo.Create(ctx, device); err != nil {
 		return nil, "", apperrors.New(apperrors.ErrInternal, "디바이스 등록에 실패했습니다")
 	}
 
+	// 소유 기간 시작 기록 (측정·보정 이력 귀속 기준)
+	if err := s.ownershipRepo.Open(ctx, &OwnershipPeriod{
+		DeviceID:  device.ID,
+		UserID:    userID,
+		StartedAt: device.RegisteredAt,
+	}); err != nil {
+		s.logger.Warn("소유 기간 기록 실패", zap.String("device_id", device.ID), zap.Error(err))
+	}
+
 	// 등록 이벤트 기록
 	_ = s.eventRepo.LogEvent(ctx, &DeviceEvent{
 		ID:        uuid.New().String(),
@@ -183,6 +252,7 @@ func (s *DeviceService) RegisterDevice(
 		Payload: map[string]interface{}{
 			"serial_number":    serialNumber,
 			"firmware_version": firmwareVersion,
+			"kind":             string(kind),
 		},
 		CreatedAt: time.Now().UTC(),
 	})
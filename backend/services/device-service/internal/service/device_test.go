@@ -36,7 +36,7 @@ func (r *mockDeviceRepo) GetByID(_ context.Context, deviceID string) (*Device, e
 func (r *mockDeviceRepo) ListByUser(_ context.Context, userID string) ([]*Device, error) {
 	var result []*Device
 	for _, d := range r.devices {
-		if d.UserID == userID {
+		if d.UserID == userID && d.DeregisteredAt.IsZero() {
 			result = append(result, d)
 		}
 	}
@@ -57,7 +57,7 @@ func (r *mockDeviceRepo) UpdateStatus(_ context.Context, deviceID string, st Dev
 func (r *mockDeviceRepo) CountByUser(_ context.Context, userID string) (int, error) {
 	count := 0
 	for _, d := range r.devices {
-		if d.UserID == userID {
+		if d.UserID == userID && d.DeregisteredAt.IsZero() {
 			count++
 		}
 	}
@@ -69,6 +69,46 @@ func (r *mockDeviceRepo) Delete(_ context.Context, deviceID string) error {
 	return nil
 }
 
+func (r *mockDeviceRepo) UpdateOwner(_ context.Context, deviceID, userID string) error {
+	if d, ok := r.devices[deviceID]; ok {
+		d.UserID = userID
+	}
+	return nil
+}
+
+func (r *mockDeviceRepo) Deregister(_ context.Context, deviceID string, at time.Time) error {
+	if d, ok := r.devices[deviceID]; ok {
+		d.Status = StatusDeregistered
+		d.DeregisteredAt = at
+	}
+	return nil
+}
+
+func (r *mockDeviceRepo) SetParentHub(_ context.Context, deviceID, hubID string) error {
+	if d, ok := r.devices[deviceID]; ok {
+		d.ParentHubID = hubID
+	}
+	return nil
+}
+
+func (r *mockDeviceRepo) RecordRelay(_ context.Context, deviceID, hubID string, at time.Time) error {
+	if d, ok := r.devices[deviceID]; ok {
+		d.LastRelayHubID = hubID
+		d.LastRelayAt = at
+	}
+	return nil
+}
+
+func (r *mockDeviceRepo) ListByParentHub(_ context.Context, hubID string) ([]*Device, error) {
+	var result []*Device
+	for _, d := range r.devices {
+		if d.ParentHubID == hubID && d.DeregisteredAt.IsZero() {
+			result = append(result, d)
+		}
+	}
+	return result, nil
+}
+
 type mockEventRepo struct {
 	events []*DeviceEvent
 }
@@ -94,6 +134,302 @@ func (c *mockSubChecker) GetMaxDevices(_ context.Context, _ string) (int, error)
 	return c.maxDevices, nil
 }
 
+type mockTransferRepo struct {
+	transfers map[string]*DeviceTransfer
+	// afterGet은 GetByID가 조회를 마친 직후 호출되어 동시 요청을 흉내 냅니다.
+	afterGet func()
+}
+
+func newMockTransferRepo() *mockTransferRepo {
+	return &mockTransferRepo{transfers: make(map[string]*DeviceTransfer)}
+}
+
+func (r *mockTransferRepo) Create(_ context.Context, t *DeviceTransfer) error {
+	cp := *t
+	r.transfers[t.ID] = &cp
+	return nil
+}
+
+func (r *mockTransferRepo) GetByID(_ context.Context, transferID string) (*DeviceTransfer, error) {
+	t, ok := r.transfers[transferID]
+	if !ok {
+		return nil, nil
+	}
+	cp := *t
+	if r.afterGet != nil {
+		r.afterGet()
+	}
+	return &cp, nil
+}
+
+func (r *mockTransferRepo) GetPendingByDevice(_ context.Context, deviceID string) (*DeviceTransfer, error) {
+	for _, t := range r.transfers {
+		if t.DeviceID == deviceID && t.Status == TransferPending {
+			cp := *t
+			return &cp, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *mockTransferRepo) Confirm(_ context.Context, transferID, userID string) (*DeviceTransfer, error) {
+	t, ok := r.transfers[transferID]
+	if !ok || t.Status != TransferPending {
+		return nil, nil
+	}
+	if userID == t.FromUserID {
+		t.FromConfirmed = true
+	}
+	if userID == t.ToUserID {
+		t.ToConfirmed = true
+	}
+	cp := *t
+	return &cp, nil
+}
+
+func (r *mockTransferRepo) UpdateStatus(_ context.Context, transferID string, status TransferStatus, completedAt time.Time) (bool, error) {
+	t, ok := r.transfers[transferID]
+	if !ok || t.Status != TransferPending {
+		return false, nil
+	}
+	t.Status = status
+	t.CompletedAt = completedAt
+	return true, nil
+}
+
+type mockOwnershipRepo struct {
+	periods map[string][]*OwnershipPeriod
+}
+
+func newMockOwnershipRepo() *mockOwnershipRepo {
+	return &mockOwnershipRepo{periods: make(map[string][]*OwnershipPeriod)}
+}
+
+func (r *mockOwnershipRepo) Open(_ context.Context, p *OwnershipPeriod) error {
+	cp := *p
+	r.periods[p.DeviceID] = append(r.periods[p.DeviceID], &cp)
+	return nil
+}
+
+func (r *mockOwnershipRepo) Close(_ context.Context, deviceID string, endedAt time.Time, reason string) error {
+	for _, p := range r.periods[deviceID] {
+		if p.EndedAt.IsZero() {
+			p.EndedAt = endedAt
+			p.EndReason = reason
+		}
+	}
+	return nil
+}
+
+func (r *mockOwnershipRepo) ListByDevice(_ context.Context, deviceID string) ([]*OwnershipPeriod, error) {
+	return r.periods[deviceID], nil
+}
+
+type mockCommandRepo struct {
+	commands []*QueuedCommand
+}
+
+func newMockCommandRepo() *mockCommandRepo {
+	return &mockCommandRepo{}
+}
+
+func (r *mockCommandRepo) Enqueue(_ context.Context, cmd *QueuedCommand) error {
+	cp := *cmd
+	r.commands = append(r.commands, &cp)
+	return nil
+}
+
+func (r *mockCommandRepo) GetByID(_ context.Context, commandID string) (*QueuedCommand, error) {
+	for _, c := range r.commands {
+		if c.ID == commandID {
+			cp := *c
+			return &cp, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *mockCommandRepo) ListPending(_ context.Context, deviceID string) ([]*QueuedCommand, error) {
+	var result []*QueuedCommand
+	for _, c := range r.commands {
+		if c.DeviceID == deviceID && (c.Status == CommandQueued || c.Status == CommandDelivered) {
+			cp := *c
+			result = append(result, &cp)
+		}
+	}
+	return result, nil
+}
+
+func (r *mockCommandRepo) ListPendingByRoute(_ context.Context, hubID string) ([]*QueuedCommand, error) {
+	var result []*QueuedCommand
+	for _, c := range r.commands {
+		if c.RouteHubID == hubID && (c.Status == CommandQueued || c.Status == CommandDelivered) {
+			cp := *c
+			result = append(result, &cp)
+		}
+	}
+	return result, nil
+}
+
+func (r *mockCommandRepo) ListByBulk(_ context.Context, bulkID string) ([]*QueuedCommand, error) {
+	var result []*QueuedCommand
+	for _, c := range r.commands {
+		if c.BulkID == bulkID {
+			cp := *c
+			result = append(result, &cp)
+		}
+	}
+	return result, nil
+}
+
+func (r *mockCommandRepo) Update(_ context.Context, cmd *QueuedCommand) error {
+	for i, c := range r.commands {
+		if c.ID == cmd.ID {
+			cp := *cmd
+			r.commands[i] = &cp
+		}
+	}
+	return nil
+}
+
+type mockFleetRepo struct {
+	groups       map[string]*DeviceGroup
+	members      map[string]map[string]*GroupMember // group ID → user ID
+	devices      map[string]map[string]bool         // group ID → device ID
+	bulks        map[string]*BulkOperation
+	calibrations map[string]time.Time
+	cartridges   map[string][]time.Time
+}
+
+func newMockFleetRepo() *mockFleetRepo {
+	return &mockFleetRepo{
+		groups:       make(map[string]*DeviceGroup),
+		members:      make(map[string]map[string]*GroupMember),
+		devices:      make(map[string]map[string]bool),
+		bulks:        make(map[string]*BulkOperation),
+		calibrations: make(map[string]time.Time),
+		cartridges:   make(map[string][]time.Time),
+	}
+}
+
+func (r *mockFleetRepo) CreateGroup(_ context.Context, g *DeviceGroup) error {
+	r.groups[g.ID] = g
+	r.members[g.ID] = make(map[string]*GroupMember)
+	r.devices[g.ID] = make(map[string]bool)
+	return nil
+}
+
+func (r *mockFleetRepo) GetGroup(_ context.Context, groupID string) (*DeviceGroup, error) {
+	return r.groups[groupID], nil
+}
+
+func (r *mockFleetRepo) ListGroups(_ context.Context) ([]*DeviceGroup, error) {
+	var result []*DeviceGroup
+	for _, g := range r.groups {
+		result = append(result, g)
+	}
+	return result, nil
+}
+
+func (r *mockFleetRepo) ListGroupsByMember(_ context.Context, userID string) ([]*DeviceGroup, error) {
+	var result []*DeviceGroup
+	for id, members := range r.members {
+		if _, ok := members[userID]; ok {
+			result = append(result, r.groups[id])
+		}
+	}
+	return result, nil
+}
+
+func (r *mockFleetRepo) UpsertMember(_ context.Context, m *GroupMember) error {
+	cp := *m
+	r.members[m.GroupID][m.UserID] = &cp
+	return nil
+}
+
+func (r *mockFleetRepo) RemoveMember(_ context.Context, groupID, userID string) error {
+	delete(r.members[groupID], userID)
+	return nil
+}
+
+func (r *mockFleetRepo) GetMember(_ context.Context, groupID, userID string) (*GroupMember, error) {
+	return r.members[groupID][userID], nil
+}
+
+func (r *mockFleetRepo) ListMembers(_ context.Context, groupID string) ([]*GroupMember, error) {
+	var result []*GroupMember
+	for _, m := range r.members[groupID] {
+		result = append(result, m)
+	}
+	return result, nil
+}
+
+func (r *mockFleetRepo) AddDevice(_ context.Context, groupID, deviceID string, _ time.Time) error {
+	r.devices[groupID][deviceID] = true
+	return nil
+}
+
+func (r *mockFleetRepo) RemoveDevice(_ context.Context, groupID, deviceID string) error {
+	delete(r.devices[groupID], deviceID)
+	return nil
+}
+
+func (r *mockFleetRepo) RemoveDeviceFromAllGroups(_ context.Context, deviceID string) error {
+	for _, devices := range r.devices {
+		delete(devices, deviceID)
+	}
+	return nil
+}
+
+func (r *mockFleetRepo) ListDeviceIDs(_ context.Context, groupID string) ([]string, error) {
+	var result []string
+	for id := range r.devices[groupID] {
+		result = append(result, id)
+	}
+	return result, nil
+}
+
+func (r *mockFleetRepo) CreateBulkOperation(_ context.Context, op *BulkOperation) error {
+	r.bulks[op.ID] = op
+	return nil
+}
+
+func (r *mockFleetRepo) GetBulkOperation(_ context.Context, bulkID string) (*BulkOperation, error) {
+	return r.bulks[bulkID], nil
+}
+
+func (r *mockFleetRepo) RecordCalibration(_ context.Context, deviceID string, at time.Time) error {
+	r.calibrations[deviceID] = at
+	return nil
+}
+
+func (r *mockFleetRepo) RecordCartridgeUse(_ context.Context, deviceID string, at time.Time) error {
+	r.cartridges[deviceID] = append(r.cartridges[deviceID], at)
+	return nil
+}
+
+func (r *mockFleetRepo) LastCalibrations(_ context.Context, deviceIDs []string) (map[string]time.Time, error) {
+	result := make(map[string]time.Time)
+	for _, id := range deviceIDs {
+		if at, ok := r.calibrations[id]; ok {
+			result[id] = at
+		}
+	}
+	return result, nil
+}
+
+func (r *mockFleetRepo) CountCartridgeUse(_ context.Context, deviceIDs []string, since time.Time) (int, error) {
+	count := 0
+	for _, id := range deviceIDs {
+		for _, at := range r.cartridges[id] {
+			if !at.Before(since) {
+				count++
+			}
+		}
+	}
+	return count, nil
+}
+
 // =============================================================================
 // 헬퍼
 // =============================================================================
@@ -103,7 +439,8 @@ func newTestDeviceService(maxDevices int) (*DeviceService, *mockDeviceRepo, *moc
 	deviceRepo := newMockDeviceRepo()
 	eventRepo := newMockEventRepo()
 	subChecker := newMockSubChecker(maxDevices)
-	svc := NewDeviceService(logger, deviceRepo, eventRepo, subChecker)
+	svc := NewDeviceService(logger, deviceRepo, eventRepo, subChecker,
+		newMockTransferRepo(), newMockOwnershipRepo(), newMockCommandRepo())
 	return svc, deviceRepo, eventRepo
 }
 
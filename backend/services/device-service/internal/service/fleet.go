@@ -0,0 +1,626 @@
+package service
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	apperrors "github.com/manpasik/backend/shared/errors"
+	"github.com/manpasik/backend/shared/middleware"
+	"go.uber.org/zap"
+)
+
+// ============================================================================
+// 클리니컬 플릿 관리: 디바이스 그룹, 일괄 명령, 플릿 통계
+// ============================================================================
+
+const (
+	// calibrationValidity 이내에 교정(또는 교정 점검)에 성공한 디바이스를 준수로 집계합니다.
+	calibrationValidity = 30 * 24 * time.Hour
+	// defaultFleetStatsDays는 카트리지 사용량 기본 집계 기간입니다.
+	defaultFleetStatsDays = 30
+)
+
+// GroupRole은 디바이스 그룹 내 구성원 역할입니다.
+type GroupRole string
+
+const (
+	GroupRoleOwner    GroupRole = "owner"
+	GroupRoleManager  GroupRole = "manager"
+	GroupRoleOperator GroupRole = "operator"
+	GroupRoleViewer   GroupRole = "viewer"
+)
+
+func (r GroupRole) valid() bool {
+	switch r {
+	case GroupRoleOwner, GroupRoleManager, GroupRoleOperator, GroupRoleViewer:
+		return true
+	}
+	return false
+}
+
+// 그룹 권한 확인에 쓰는 작업 키 (DeviceService gRPC 메서드 이름)
+const (
+	fleetMethodPrefix = "/manpasik.v1.DeviceService/"
+
+	opListDeviceGroups      = fleetMethodPrefix + "ListDeviceGroups"
+	opAddGroupMember        = fleetMethodPrefix + "AddDeviceGroupMember"
+	opRemoveGroupMember     = fleetMethodPrefix + "RemoveDeviceGroupMember"
+	opAddDevicesToGroup     = fleetMethodPrefix + "AddDevicesToGroup"
+	opRemoveDeviceFromGroup = fleetMethodPrefix + "RemoveDeviceFromGroup"
+	opSendBulkCommand       = fleetMethodPrefix + "SendBulkCommand"
+	// opSendPrivilegedBulkCommand는 OTA·잠금처럼 디바이스 사용을 중단시키는 일괄 명령입니다.
+	opSendPrivilegedBulkCommand = fleetMethodPrefix + "SendBulkCommand:privileged"
+	opGetBulkCommandStatus      = fleetMethodPrefix + "GetBulkCommandStatus"
+	opGetFleetStats             = fleetMethodPrefix + "GetFleetStats"
+)
+
+// fleetPermissions는 그룹 역할별 허용 작업입니다.
+// 플랫폼 역할과 같은 middleware.RBACConfig 규칙으로 평가합니다.
+var fleetPermissions = &middleware.RBACConfig{
+	MethodRoles: map[string][]string{
+		opListDeviceGroups:          {string(GroupRoleOwner), string(GroupRoleManager), string(GroupRoleOperator), string(GroupRoleViewer)},
+		opAddGroupMember:            {string(GroupRoleOwner), string(GroupRoleManager)},
+		opRemoveGroupMember:         {string(GroupRoleOwner), string(GroupRoleManager)},
+		opAddDevicesToGroup:         {string(GroupRoleOwner), string(GroupRoleManager)},
+		opRemoveDeviceFromGroup:     {string(GroupRoleOwner), string(GroupRoleManager)},
+		opSendBulkCommand:           {string(GroupRoleOwner), string(GroupRoleManager), string(GroupRoleOperator)},
+		opSendPrivilegedBulkCommand: {string(GroupRoleOwner), string(GroupRoleManager)},
+		opGetBulkCommandStatus:      {string(GroupRoleOwner), string(GroupRoleManager), string(GroupRoleOperator), string(GroupRoleViewer)},
+		opGetFleetStats:             {string(GroupRoleOwner), string(GroupRoleManager), string(GroupRoleOperator), string(GroupRoleViewer)},
+	},
+}
+
+// bulkCommands는 일괄 명령으로 허용되는 명령과 관리자 권한 필요 여부입니다.
+var bulkCommands = map[CommandType]bool{
+	CommandCalibrationCheck: false,
+	CommandStatusQuery:      false,
+	CommandOtaUpdate:        true,
+	CommandLock:             true,
+	CommandUnlock:           true,
+}
+
+// DeviceGroup은 클리닉이 운영하는 디바이스 그룹입니다.
+type DeviceGroup struct {
+	ID          string
+	Name        string
+	Description string
+	OwnerID     string
+	CreatedAt   time.Time
+}
+
+// GroupMember는 디바이스 그룹 구성원입니다.
+type GroupMember struct {
+	GroupID string
+	UserID  string
+	Role    GroupRole
+	AddedAt time.Time
+}
+
+// GroupSummary는 그룹 목록 조회 결과입니다.
+type GroupSummary struct {
+	Group       *DeviceGroup
+	DeviceCount int
+	MemberCount int
+	MyRole      GroupRole
+}
+
+// BulkOperation은 그룹 대상 일괄 명령 기록입니다.
+type BulkOperation struct {
+	ID               string
+	GroupID          string
+	CommandType      CommandType
+	IssuedBy         string
+	TargetCount      int
+	SkippedDeviceIDs []string
+	CreatedAt        time.Time
+}
+
+// BulkOperationStatus는 일괄 명령의 진행 현황입니다.
+type BulkOperationStatus struct {
+	Operation    *BulkOperation
+	Pending      int
+	Acknowledged int
+	Failed       int
+}
+
+// FleetStats는 그룹 단위 플릿 통계입니다.
+type FleetStats struct {
+	GroupID               string
+	GroupName             string
+	TotalDevices          int
+	Online                int
+	OnlinePercent         float64
+	Calibrated            int
+	CalibrationCompliance float64
+	CartridgesUsed        int
+	LowBattery            int
+	PeriodDays            int
+	CalculatedAt          time.Time
+}
+
+// FleetRepository는 디바이스 그룹·일괄 작업·플릿 사용량 저장소입니다.
+type FleetRepository interface {
+	CreateGroup(ctx context.Context, g *DeviceGroup) error
+	GetGroup(ctx context.Context, groupID string) (*DeviceGroup, error)
+	ListGroups(ctx context.Context) ([]*DeviceGroup, error)
+	ListGroupsByMember(ctx context.Context, userID string) ([]*DeviceGroup, error)
+
+	UpsertMember(ctx context.Context, m *GroupMember) error
+	RemoveMember(ctx context.Context, groupID, userID string) error
+	GetMember(ctx context.Context, groupID, userID string) (*GroupMember, error)
+	ListMembers(ctx context.Context, groupID string) ([]*GroupMember, error)
+
+	AddDevice(ctx context.Context, groupID, deviceID string, at time.Time) error
+	RemoveDevice(ctx context.Context, groupID, deviceID string) error
+	// RemoveDeviceFromAllGroups는 디바이스를 속한 모든 그룹에서 제외합니다 (소유권 이전·등록 해제 시).
+	RemoveDeviceFromAllGroups(ctx context.Context, deviceID string) error
+	ListDeviceIDs(ctx context.Context, groupID string) ([]string, error)
+
+	CreateBulkOperation(ctx context.Context, op *BulkOperation) error
+	GetBulkOperation(ctx context.Context, bulkID string) (*BulkOperation, error)
+
+	RecordCalibration(ctx context.Context, deviceID string, at time.Time) error
+	RecordCartridgeUse(ctx context.Context, deviceID string, at time.Time) error
+	// LastCalibrations는 디바이스별 마지막 교정 성공 시각을 반환합니다 (기록 없는 디바이스는 제외).
+	LastCalibrations(ctx context.Context, deviceIDs []string) (map[string]time.Time, error)
+	CountCartridgeUse(ctx context.Context, deviceIDs []string, since time.Time) (int, error)
+}
+
+// ClinicalTierChecker는 사용자가 클리니컬 구독 중인지 확인합니다.
+type ClinicalTierChecker interface {
+	IsClinical(ctx context.Context, userID string) (bool, error)
+}
+
+// SetFleetRepository는 플릿 관리 저장소를 설정합니다 (optional).
+func (s *DeviceService) SetFleetRepository(repo FleetRepository) {
+	s.fleetRepo = repo
+}
+
+// SetClinicalTierChecker는 클리니컬 구독 확인기를 설정합니다 (optional, 미설정 시 확인 생략).
+func (s *DeviceService) SetClinicalTierChecker(c ClinicalTierChecker) {
+	s.clinicChecker = c
+}
+
+func (s *DeviceService) requireFleet() error {
+	if s.fleetRepo == nil {
+		return apperrors.New(apperrors.ErrInternal, "플릿 관리 저장소가 설정되지 않았습니다")
+	}
+	return nil
+}
+
+// authorizeGroup은 그룹 구성원 역할이 작업을 허용하는지 확인합니다.
+func (s *DeviceService) authorizeGroup(ctx context.Context, groupID, userID, op string) (*DeviceGroup, *GroupMember, error) {
+	if err := s.requireFleet(); err != nil {
+		return nil, nil, err
+	}
+	group, err := s.fleetRepo.GetGroup(ctx, groupID)
+	if err != nil || group == nil {
+		return nil, nil, apperrors.New(apperrors.ErrNotFound, "디바이스 그룹을 찾을 수 없습니다")
+	}
+	member, err := s.fleetRepo.GetMember(ctx, groupID, userID)
+	if err != nil || member == nil {
+		return nil, nil, apperrors.New(apperrors.ErrForbidden, "그룹 구성원이 아닙니다")
+	}
+	if !fleetPermissions.IsAllowed(op, string(member.Role)) {
+		return nil, nil, apperrors.New(apperrors.ErrForbidden, "그룹 역할에 이 작업 권한이 없습니다")
+	}
+	return group, member, nil
+}
+
+// CreateDeviceGroup은 디바이스 그룹을 생성하고 생성자를 owner로 등록합니다.
+// 클리니컬 구독 사용자만 생성할 수 있습니다.
+func (s *DeviceService) CreateDeviceGroup(ctx context.Context, userID, name, description string) (*DeviceGroup, error) {
+	if userID == "" || name == "" {
+		return nil, apperrors.New(apperrors.ErrInvalidInput, "user_id와 name은 필수입니다")
+	}
+	if err := s.requireFleet(); err != nil {
+		return nil, err
+	}
+	if s.clinicChecker != nil {
+		ok, err := s.clinicChecker.IsClinical(ctx, userID)
+		if err != nil {
+			return nil, apperrors.New(apperrors.ErrInternal, "구독 정보 확인에 실패했습니다")
+		}
+		if !ok {
+			return nil, apperrors.New(apperrors.ErrForbidden, "디바이스 그룹은 클리니컬 구독에서만 사용할 수 있습니다")
+		}
+	}
+
+	now := time.Now().UTC()
+	group := &DeviceGroup{
+		ID:          uuid.New().String(),
+		Name:        name,
+		Description: description,
+		OwnerID:     userID,
+		CreatedAt:   now,
+	}
+	if err := s.fleetRepo.CreateGroup(ctx, group); err != nil {
+		return nil, apperrors.New(apperrors.ErrInternal, "디바이스 그룹 생성에 실패했습니다")
+	}
+	if err := s.fleetRepo.UpsertMember(ctx, &GroupMember{GroupID: group.ID, UserID: userID, Role: GroupRoleOwner, AddedAt: now}); err != nil {
+		return nil, apperrors.New(apperrors.ErrInternal, "그룹 소유자 등록에 실패했습니다")
+	}
+
+	s.logger.Info("디바이스 그룹 생성", zap.String("group_id", group.ID), zap.String("owner_id", userID))
+	return group, nil
+}
+
+// ListDeviceGroups는 사용자가 속한 그룹 목록을 반환합니다.
+func (s *DeviceService) ListDeviceGroups(ctx context.Context, userID string) ([]*GroupSummary, error) {
+	if userID == "" {
+		return nil, apperrors.New(apperrors.ErrInvalidInput, "user_id는 필수입니다")
+	}
+	if err := s.requireFleet(); err != nil {
+		return nil, err
+	}
+	groups, err := s.fleetRepo.ListGroupsByMember(ctx, userID)
+	if err != nil {
+		return nil, apperrors.New(apperrors.ErrInternal, "디바이스 그룹 조회에 실패했습니다")
+	}
+
+	summaries := make([]*GroupSummary, 0, len(groups))
+	for _, g := range groups {
+		member, _ := s.fleetRepo.GetMember(ctx, g.ID, userID)
+		if member == nil || !fleetPermissions.IsAllowed(opListDeviceGroups, string(member.Role)) {
+			continue
+		}
+		members, _ := s.fleetRepo.ListMembers(ctx, g.ID)
+		deviceIDs, _ := s.fleetRepo.ListDeviceIDs(ctx, g.ID)
+		summaries = append(summaries, &GroupSummary{
+			Group:       g,
+			DeviceCount: len(deviceIDs),
+			MemberCount: len(members),
+			MyRole:      member.Role,
+		})
+	}
+	return summaries, nil
+}
+
+// AddGroupMember는 구성원을 추가하거나 역할을 변경합니다.
+// owner·manager 역할은 owner만 부여할 수 있고, 그룹 생성자의 역할은 변경할 수 없습니다.
+func (s *DeviceService) AddGroupMember(ctx context.Context, groupID, requesterID, memberID string, role GroupRole) (*GroupMember, error) {
+	if groupID == "" || requesterID == "" || memberID == "" {
+		return nil, apperrors.New(apperrors.ErrInvalidInput, "group_id, user_id, member_user_id는 필수입니다")
+	}
+	if !role.valid() {
+		return nil, apperrors.New(apperrors.ErrInvalidInput, "유효하지 않은 그룹 역할입니다")
+	}
+	group, requester, err := s.authorizeGroup(ctx, groupID, requesterID, opAddGroupMember)
+	if err != nil {
+		return nil, err
+	}
+	if memberID == group.OwnerID {
+		return nil, apperrors.New(apperrors.ErrConflict, "그룹 생성자의 역할은 변경할 수 없습니다")
+	}
+	if (role == GroupRoleOwner || role == GroupRoleManager) && requester.Role != GroupRoleOwner {
+		return nil, apperrors.New(apperrors.ErrForbidden, "owner·manager 역할은 owner만 부여할 수 있습니다")
+	}
+	if existing, _ := s.fleetRepo.GetMember(ctx, groupID, memberID); existing != nil &&
+		(existing.Role == GroupRoleOwner || existing.Role == GroupRoleManager) && requester.Role != GroupRoleOwner {
+		return nil, apperrors.New(apperrors.ErrForbidden, "owner·manager의 역할은 owner만 변경할 수 있습니다")
+	}
+
+	member := &GroupMember{GroupID: groupID, UserID: memberID, Role: role, AddedAt: time.Now().UTC()}
+	if err := s.fleetRepo.UpsertMember(ctx, member); err != nil {
+		return nil, apperrors.New(apperrors.ErrInternal, "그룹 구성원 추가에 실패했습니다")
+	}
+	return member, nil
+}
+
+// RemoveGroupMember는 구성원을 제거합니다.
+func (s *DeviceService) RemoveGroupMember(ctx context.Context, groupID, requesterID, memberID string) error {
+	if groupID == "" || requesterID == "" || memberID == "" {
+		return apperrors.New(apperrors.ErrInvalidInput, "group_id, user_id, member_user_id는 필수입니다")
+	}
+	group, requester, err := s.authorizeGroup(ctx, groupID, requesterID, opRemoveGroupMember)
+	if err != nil {
+		return err
+	}
+	if memberID == group.OwnerID {
+		return apperrors.New(apperrors.ErrConflict, "그룹 생성자는 제거할 수 없습니다")
+	}
+	target, _ := s.fleetRepo.GetMember(ctx, groupID, memberID)
+	if target == nil {
+		return apperrors.New(apperrors.ErrNotFound, "그룹 구성원을 찾을 수 없습니다")
+	}
+	if (target.Role == GroupRoleOwner || target.Role == GroupRoleManager) && requester.Role != GroupRoleOwner {
+		return apperrors.New(apperrors.ErrForbidden, "owner·manager는 owner만 제거할 수 있습니다")
+	}
+	if err := s.fleetRepo.RemoveMember(ctx, groupID, memberID); err != nil {
+		return apperrors.New(apperrors.ErrInternal, "그룹 구성원 제거에 실패했습니다")
+	}
+	return nil
+}
+
+// AddDevicesToGroup은 디바이스를 그룹에 추가합니다.
+// 그룹 구성원이 소유한 활성 디바이스만 추가되며, 실패한 ID는 따로 반환됩니다.
+func (s *DeviceService) AddDevicesToGroup(ctx context.Context, groupID, userID string, deviceIDs []string) (int, []string, error) {
+	if groupID == "" || userID == "" || len(deviceIDs) == 0 {
+		return 0, nil, apperrors.New(apperrors.ErrInvalidInput, "group_id, user_id, device_ids는 필수입니다")
+	}
+	if _, _, err := s.authorizeGroup(ctx, groupID, userID, opAddDevicesToGroup); err != nil {
+		return 0, nil, err
+	}
+	members, err := s.fleetRepo.ListMembers(ctx, groupID)
+	if err != nil {
+		return 0, nil, apperrors.New(apperrors.ErrInternal, "그룹 구성원 조회에 실패했습니다")
+	}
+	memberSet := make(map[string]bool, len(members))
+	for _, m := range members {
+		memberSet[m.UserID] = true
+	}
+
+	now := time.Now().UTC()
+	added := 0
+	var failed []string
+	for _, id := range deviceIDs {
+		device, err := s.deviceRepo.GetByID(ctx, id)
+		if err != nil || device == nil || !device.DeregisteredAt.IsZero() || !memberSet[device.UserID] {
+			failed = append(failed, id)
+			continue
+		}
+		if err := s.fleetRepo.AddDevice(ctx, groupID, id, now); err != nil {
+			failed = append(failed, id)
+			continue
+		}
+		added++
+	}
+	return added, failed, nil
+}
+
+// RemoveDeviceFromGroup은 디바이스를 그룹에서 제외합니다.
+func (s *DeviceService) RemoveDeviceFromGroup(ctx context.Context, groupID, userID, deviceID string) error {
+	if groupID == "" || userID == "" || deviceID == "" {
+		return apperrors.New(apperrors.ErrInvalidInput, "group_id, user_id, device_id는 필수입니다")
+	}
+	if _, _, err := s.authorizeGroup(ctx, groupID, userID, opRemoveDeviceFromGroup); err != nil {
+		return err
+	}
+	if err := s.fleetRepo.RemoveDevice(ctx, groupID, deviceID); err != nil {
+		return apperrors.New(apperrors.ErrInternal, "그룹 디바이스 제외에 실패했습니다")
+	}
+	return nil
+}
+
+// detachGroups는 소유자가 바뀌었거나 등록 해제된 디바이스를 모든 그룹에서 제외합니다.
+func (s *DeviceService) detachGroups(ctx context.Context, deviceID string) {
+	if s.fleetRepo == nil {
+		return
+	}
+	if err := s.fleetRepo.RemoveDeviceFromAllGroups(ctx, deviceID); err != nil {
+		s.logger.Warn("디바이스 그룹 제외 실패", zap.String("device_id", deviceID), zap.Error(err))
+	}
+}
+
+// SendBulkCommand는 그룹 디바이스에 명령을 일괄 등록합니다.
+// deviceIDs가 비어 있으면 그룹 전체가 대상이며, 그룹 밖이거나 등록 해제된 디바이스,
+// 현재 소유자가 그룹 구성원이 아닌 디바이스(AddDevicesToGroup과 같은 기준)는 건너뜁니다.
+func (s *DeviceService) SendBulkCommand(
+	ctx context.Context,
+	groupID, userID string,
+	cmdType CommandType,
+	payload []byte,
+	deviceIDs []string,
+) (*BulkOperationStatus, error) {
+	if groupID == "" || userID == "" {
+		return nil, apperrors.New(apperrors.ErrInvalidInput, "group_id와 user_id는 필수입니다")
+	}
+	privileged, ok := bulkCommands[cmdType]
+	if !ok {
+		return nil, apperrors.New(apperrors.ErrInvalidInput, "일괄 명령으로 지원하지 않는 명령입니다")
+	}
+	if cmdType == CommandOtaUpdate && len(payload) == 0 {
+		return nil, apperrors.New(apperrors.ErrInvalidInput, "OTA 일괄 명령에는 대상 버전이 필요합니다")
+	}
+	op := opSendBulkCommand
+	if privileged {
+		op = opSendPrivilegedBulkCommand
+	}
+	if _, _, err := s.authorizeGroup(ctx, groupID, userID, op); err != nil {
+		return nil, err
+	}
+
+	groupDevices, err := s.fleetRepo.ListDeviceIDs(ctx, groupID)
+	if err != nil {
+		return nil, apperrors.New(apperrors.ErrInternal, "그룹 디바이스 조회에 실패했습니다")
+	}
+	members, err := s.fleetRepo.ListMembers(ctx, groupID)
+	if err != nil {
+		return nil, apperrors.New(apperrors.ErrInternal, "그룹 구성원 조회에 실패했습니다")
+	}
+	memberSet := make(map[string]bool, len(members))
+	for _, m := range members {
+		memberSet[m.UserID] = true
+	}
+	targets := groupDevices
+	var skipped []string
+	if len(deviceIDs) > 0 {
+		inGroup := make(map[string]bool, len(groupDevices))
+		for _, id := range groupDevices {
+			inGroup[id] = true
+		}
+		targets = nil
+		for _, id := range deviceIDs {
+			if inGroup[id] {
+				targets = append(targets, id)
+			} else {
+				skipped = append(skipped, id)
+			}
+		}
+	}
+
+	bulk := &BulkOperation{
+		ID:          uuid.New().String(),
+		GroupID:     groupID,
+		CommandType: cmdType,
+		IssuedBy:    userID,
+		CreatedAt:   time.Now().UTC(),
+	}
+	for _, id := range targets {
+		device, err := s.deviceRepo.GetByID(ctx, id)
+		if err != nil || device == nil || !device.DeregisteredAt.IsZero() || !memberSet[device.UserID] {
+			skipped = append(skipped, id)
+			continue
+		}
+		if _, err := s.enqueueCommand(ctx, device, cmdType, payload, userID, bulk.ID); err != nil {
+			skipped = append(skipped, id)
+			continue
+		}
+		bulk.TargetCount++
+	}
+	bulk.SkippedDeviceIDs = skipped
+
+	if err := s.fleetRepo.CreateBulkOperation(ctx, bulk); err != nil {
+		return nil, apperrors.New(apperrors.ErrInternal, "일괄 작업 기록에 실패했습니다")
+	}
+
+	s.logger.Info("일괄 명령 등록",
+		zap.String("bulk_id", bulk.ID),
+		zap.String("group_id", groupID),
+		zap.String("command_type", string(cmdType)),
+		zap.Int("targets", bulk.TargetCount),
+		zap.Int("skipped", len(skipped)),
+	)
+	return &BulkOperationStatus{Operation: bulk, Pending: bulk.TargetCount}, nil
+}
+
+// GetBulkCommandStatus는 일괄 명령의 진행 현황을 반환합니다.
+func (s *DeviceService) GetBulkCommandStatus(ctx context.Context, bulkID, userID string) (*BulkOperationStatus, error) {
+	if bulkID == "" || userID == "" {
+		return nil, apperrors.New(apperrors.ErrInvalidInput, "bulk_id와 user_id는 필수입니다")
+	}
+	if err := s.requireFleet(); err != nil {
+		return nil, err
+	}
+	bulk, err := s.fleetRepo.GetBulkOperation(ctx, bulkID)
+	if err != nil || bulk == nil {
+		return nil, apperrors.New(apperrors.ErrNotFound, "일괄 작업을 찾을 수 없습니다")
+	}
+	if _, _, err := s.authorizeGroup(ctx, bulk.GroupID, userID, opGetBulkCommandStatus); err != nil {
+		return nil, err
+	}
+
+	cmds, err := s.commandRepo.ListByBulk(ctx, bulkID)
+	if err != nil {
+		return nil, apperrors.New(apperrors.ErrInternal, "일괄 명령 조회에 실패했습니다")
+	}
+	st := &BulkOperationStatus{Operation: bulk}
+	for _, c := range cmds {
+		switch c.Status {
+		case CommandAcknowledged:
+			st.Acknowledged++
+		case CommandFailed:
+			st.Failed++
+		default:
+			st.Pending++
+		}
+	}
+	return st, nil
+}
+
+// GetFleetStats는 그룹의 플릿 통계를 반환합니다.
+func (s *DeviceService) GetFleetStats(ctx context.Context, groupID, userID string, days int) (*FleetStats, error) {
+	if groupID == "" || userID == "" {
+		return nil, apperrors.New(apperrors.ErrInvalidInput, "group_id와 user_id는 필수입니다")
+	}
+	group, _, err := s.authorizeGroup(ctx, groupID, userID, opGetFleetStats)
+	if err != nil {
+		return nil, err
+	}
+	return s.computeFleetStats(ctx, group, days, time.Now().UTC())
+}
+
+// ListFleetStats는 전체 그룹의 플릿 통계를 반환합니다.
+// admin-service 집계 전용 내부 RPC이며 게이트웨이로 노출하지 않습니다. 호출자 권한은 검사하지 않습니다.
+func (s *DeviceService) ListFleetStats(ctx context.Context, days int) ([]*FleetStats, error) {
+	if err := s.requireFleet(); err != nil {
+		return nil, err
+	}
+	groups, err := s.fleetRepo.ListGroups(ctx)
+	if err != nil {
+		return nil, apperrors.New(apperrors.ErrInternal, "디바이스 그룹 조회에 실패했습니다")
+	}
+	now := time.Now().UTC()
+	result := make([]*FleetStats, 0, len(groups))
+	for _, g := range groups {
+		st, err := s.computeFleetStats(ctx, g, days, now)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, st)
+	}
+	return result, nil
+}
+
+// RecordCartridgeUse는 디바이스의 카트리지 사용(측정 완료)을 기록합니다.
+func (s *DeviceService) RecordCartridgeUse(ctx context.Context, deviceID string, at time.Time) error {
+	if deviceID == "" {
+		return apperrors.New(apperrors.ErrInvalidInput, "device_id는 필수입니다")
+	}
+	if s.fleetRepo == nil {
+		return nil
+	}
+	if at.IsZero() {
+		at = time.Now().UTC()
+	}
+	return s.fleetRepo.RecordCartridgeUse(ctx, deviceID, at)
+}
+
+func (s *DeviceService) computeFleetStats(ctx context.Context, group *DeviceGroup, days int, now time.Time) (*FleetStats, error) {
+	if days <= 0 {
+		days = defaultFleetStatsDays
+	}
+	ids, err := s.fleetRepo.ListDeviceIDs(ctx, group.ID)
+	if err != nil {
+		return nil, apperrors.New(apperrors.ErrInternal, "그룹 디바이스 조회에 실패했습니다")
+	}
+
+	st := &FleetStats{GroupID: group.ID, GroupName: group.Name, PeriodDays: days, CalculatedAt: now}
+	active := make([]string, 0, len(ids))
+	for _, id := range ids {
+		device, err := s.deviceRepo.GetByID(ctx, id)
+		if err != nil || device == nil || !device.DeregisteredAt.IsZero() {
+			continue
+		}
+		active = append(active, id)
+		switch effectiveStatus(device, now) {
+		case StatusOnline, StatusMeasuring, StatusUpdating:
+			st.Online++
+		}
+		if device.BatteryPercent < lowBatteryPercent {
+			st.LowBattery++
+		}
+	}
+	st.TotalDevices = len(active)
+	if st.TotalDevices == 0 {
+		return st, nil
+	}
+
+	calibrations, err := s.fleetRepo.LastCalibrations(ctx, active)
+	if err != nil {
+		return nil, apperrors.New(apperrors.ErrInternal, "교정 이력 조회에 실패했습니다")
+	}
+	for _, at := range calibrations {
+		if now.Sub(at) <= calibrationValidity {
+			st.Calibrated++
+		}
+	}
+	used, err := s.fleetRepo.CountCartridgeUse(ctx, active, now.AddDate(0, 0, -days))
+	if err != nil {
+		return nil, apperrors.New(apperrors.ErrInternal, "카트리지 사용량 조회에 실패했습니다")
+	}
+	st.CartridgesUsed = used
+	st.OnlinePercent = percentOf(st.Online, st.TotalDevices)
+	st.CalibrationCompliance = percentOf(st.Calibrated, st.TotalDevices)
+	return st, nil
+}
+
+// percentOf는 n/total 백분율을 소수 첫째 자리로 반올림합니다.
+func percentOf(n, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return math.Round(float64(n)/float64(total)*1000) / 10
+}
@@ -0,0 +1,217 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// =============================================================================
+// 클리니컬 플릿 관리 테스트
+// =============================================================================
+
+// fakeClinicChecker는 클리니컬 구독 사용자 집합으로 판정합니다.
+type fakeClinicChecker map[string]bool
+
+func (f fakeClinicChecker) IsClinical(_ context.Context, userID string) (bool, error) {
+	return f[userID], nil
+}
+
+// newTestFleetService는 user-1(owner)의 그룹과 user-1 소유 디바이스 2대를 준비합니다.
+func newTestFleetService(t *testing.T) (*DeviceService, *mockDeviceRepo, *mockFleetRepo, *DeviceGroup, []*Device) {
+	t.Helper()
+	svc, deviceRepo, _ := newTestDeviceService(10)
+	fleetRepo := newMockFleetRepo()
+	svc.SetFleetRepository(fleetRepo)
+	ctx := context.Background()
+
+	group, err := svc.CreateDeviceGroup(ctx, "user-1", "서울 클리닉", "")
+	if err != nil {
+		t.Fatalf("그룹 생성 실패: %v", err)
+	}
+	d1, _, _ := svc.RegisterDevice(ctx, "BLE-01", "SN-0001", "1.0.0", "user-1")
+	d2, _, _ := svc.RegisterDevice(ctx, "BLE-02", "SN-0002", "1.0.0", "user-1")
+	if added, failed, err := svc.AddDevicesToGroup(ctx, group.ID, "user-1", []string{d1.ID, d2.ID}); err != nil || added != 2 {
+		t.Fatalf("그룹 디바이스 추가 실패: added=%d, failed=%v, err=%v", added, failed, err)
+	}
+	return svc, deviceRepo, fleetRepo, group, []*Device{d1, d2}
+}
+
+func TestCreateDeviceGroup_클리니컬_구독_필요(t *testing.T) {
+	svc, _, _ := newTestDeviceService(10)
+	svc.SetFleetRepository(newMockFleetRepo())
+	svc.SetClinicalTierChecker(fakeClinicChecker{"clinic-1": true})
+	ctx := context.Background()
+
+	if _, err := svc.CreateDeviceGroup(ctx, "user-1", "개인 그룹", ""); err == nil {
+		t.Fatal("클리니컬 구독이 아니면 그룹을 만들 수 없어야 합니다")
+	}
+	group, err := svc.CreateDeviceGroup(ctx, "clinic-1", "서울 클리닉", "")
+	if err != nil {
+		t.Fatalf("그룹 생성 실패: %v", err)
+	}
+
+	groups, _ := svc.ListDeviceGroups(ctx, "clinic-1")
+	if len(groups) != 1 || groups[0].Group.ID != group.ID || groups[0].MyRole != GroupRoleOwner {
+		t.Errorf("생성자는 owner로 등록되어야 합니다: %+v", groups)
+	}
+}
+
+func TestFleet_역할별_권한(t *testing.T) {
+	svc, _, _, group, devices := newTestFleetService(t)
+	ctx := context.Background()
+
+	svc.AddGroupMember(ctx, group.ID, "user-1", "operator-1", GroupRoleOperator)
+	svc.AddGroupMember(ctx, group.ID, "user-1", "viewer-1", GroupRoleViewer)
+
+	if _, _, err := svc.AddDevicesToGroup(ctx, group.ID, "viewer-1", []string{devices[0].ID}); err == nil {
+		t.Error("viewer는 디바이스를 추가할 수 없어야 합니다")
+	}
+	if _, err := svc.SendBulkCommand(ctx, group.ID, "operator-1", CommandLock, nil, nil); err == nil {
+		t.Error("operator는 잠금 일괄 명령을 보낼 수 없어야 합니다")
+	}
+	if _, err := svc.SendBulkCommand(ctx, group.ID, "operator-1", CommandStatusQuery, nil, nil); err != nil {
+		t.Errorf("operator는 상태 조회 일괄 명령을 보낼 수 있어야 합니다: %v", err)
+	}
+	if _, err := svc.SendBulkCommand(ctx, group.ID, "outsider", CommandStatusQuery, nil, nil); err == nil {
+		t.Error("구성원이 아니면 일괄 명령을 보낼 수 없어야 합니다")
+	}
+	if _, err := svc.GetFleetStats(ctx, group.ID, "viewer-1", 0); err != nil {
+		t.Errorf("viewer는 플릿 통계를 조회할 수 있어야 합니다: %v", err)
+	}
+}
+
+func TestFleet_소유자_보호(t *testing.T) {
+	svc, _, _, group, _ := newTestFleetService(t)
+	ctx := context.Background()
+
+	svc.AddGroupMember(ctx, group.ID, "user-1", "manager-1", GroupRoleManager)
+
+	if err := svc.RemoveGroupMember(ctx, group.ID, "manager-1", "user-1"); err == nil {
+		t.Error("그룹 생성자는 제거할 수 없어야 합니다")
+	}
+	if _, err := svc.AddGroupMember(ctx, group.ID, "manager-1", "user-1", GroupRoleViewer); err == nil {
+		t.Error("그룹 생성자의 역할은 변경할 수 없어야 합니다")
+	}
+	if _, err := svc.AddGroupMember(ctx, group.ID, "manager-1", "manager-2", GroupRoleManager); err == nil {
+		t.Error("manager는 manager 역할을 부여할 수 없어야 합니다")
+	}
+	if _, err := svc.AddGroupMember(ctx, group.ID, "manager-1", "operator-1", GroupRoleOperator); err != nil {
+		t.Errorf("manager는 operator를 추가할 수 있어야 합니다: %v", err)
+	}
+}
+
+func TestAddDevicesToGroup_구성원_디바이스만(t *testing.T) {
+	svc, _, _, group, _ := newTestFleetService(t)
+	ctx := context.Background()
+
+	outsider, _, _ := svc.RegisterDevice(ctx, "BLE-09", "SN-0009", "1.0.0", "user-9")
+	added, failed, err := svc.AddDevicesToGroup(ctx, group.ID, "user-1", []string{outsider.ID})
+	if err != nil {
+		t.Fatalf("요청 자체는 성공해야 합니다: %v", err)
+	}
+	if added != 0 || len(failed) != 1 {
+		t.Errorf("구성원이 아닌 사용자의 디바이스는 거부되어야 합니다: added=%d, failed=%v", added, failed)
+	}
+
+	svc.AddGroupMember(ctx, group.ID, "user-1", "user-9", GroupRoleOperator)
+	if added, _, _ := svc.AddDevicesToGroup(ctx, group.ID, "user-1", []string{outsider.ID}); added != 1 {
+		t.Errorf("구성원이 된 뒤에는 추가되어야 합니다: added=%d", added)
+	}
+}
+
+func TestSendBulkCommand_진행_현황_집계(t *testing.T) {
+	svc, _, _, group, devices := newTestFleetService(t)
+	ctx := context.Background()
+
+	st, err := svc.SendBulkCommand(ctx, group.ID, "user-1", CommandCalibrationCheck, nil, []string{devices[0].ID, devices[1].ID, "not-in-group"})
+	if err != nil {
+		t.Fatalf("일괄 명령 실패: %v", err)
+	}
+	if st.Operation.TargetCount != 2 || len(st.Operation.SkippedDeviceIDs) != 1 {
+		t.Errorf("그룹 밖 디바이스는 건너뛰어야 합니다: target=%d, skipped=%v", st.Operation.TargetCount, st.Operation.SkippedDeviceIDs)
+	}
+
+	if _, err := svc.SendBulkCommand(ctx, group.ID, "user-1", CommandOtaUpdate, nil, nil); err == nil {
+		t.Error("OTA 일괄 명령은 대상 버전 없이 보낼 수 없어야 합니다")
+	}
+
+	pending0, _ := svc.ListPendingCommands(ctx, devices[0].ID)
+	pending1, _ := svc.ListPendingCommands(ctx, devices[1].ID)
+	svc.AcknowledgeCommand(ctx, devices[0].ID, pending0[0].ID, true, "")
+	svc.AcknowledgeCommand(ctx, devices[1].ID, pending1[0].ID, false, "센서 오류")
+
+	status, err := svc.GetBulkCommandStatus(ctx, st.Operation.ID, "user-1")
+	if err != nil {
+		t.Fatalf("진행 현황 조회 실패: %v", err)
+	}
+	if status.Acknowledged != 1 || status.Failed != 1 || status.Pending != 0 {
+		t.Errorf("진행 현황 불일치: ack=%d, failed=%d, pending=%d", status.Acknowledged, status.Failed, status.Pending)
+	}
+}
+
+func TestSendBulkCommand_소유자가_바뀐_디바이스_제외(t *testing.T) {
+	svc, deviceRepo, fleetRepo, group, devices := newTestFleetService(t)
+	ctx := context.Background()
+
+	// 그룹에 남아 있더라도 현재 소유자가 구성원이 아니면 대상에서 제외
+	deviceRepo.UpdateOwner(ctx, devices[0].ID, "user-9")
+	st, err := svc.SendBulkCommand(ctx, group.ID, "user-1", CommandLock, nil, nil)
+	if err != nil {
+		t.Fatalf("일괄 명령 실패: %v", err)
+	}
+	if st.Operation.TargetCount != 1 || len(st.Operation.SkippedDeviceIDs) != 1 || st.Operation.SkippedDeviceIDs[0] != devices[0].ID {
+		t.Errorf("구성원이 아닌 소유자의 디바이스는 건너뛰어야 합니다: target=%d, skipped=%v", st.Operation.TargetCount, st.Operation.SkippedDeviceIDs)
+	}
+
+	// 소유권 이전이 완료되면 그룹에서 제외
+	transfer, err := svc.InitiateTransfer(ctx, devices[1].ID, "user-1", "user-2")
+	if err != nil {
+		t.Fatalf("이전 요청 실패: %v", err)
+	}
+	svc.ConfirmTransfer(ctx, transfer.ID, "user-2")
+	if _, err := svc.ConfirmTransfer(ctx, transfer.ID, "user-1"); err != nil {
+		t.Fatalf("이전 확인 실패: %v", err)
+	}
+	if fleetRepo.devices[group.ID][devices[1].ID] {
+		t.Error("이전된 디바이스는 그룹에서 제외되어야 합니다")
+	}
+
+	// 등록 해제해도 그룹에서 제외
+	d3, _, _ := svc.RegisterDevice(ctx, "BLE-03", "SN-0003", "1.0.0", "user-1")
+	svc.AddDevicesToGroup(ctx, group.ID, "user-1", []string{d3.ID})
+	if _, err := svc.DeregisterDevice(ctx, d3.ID, "user-1", "폐기"); err != nil {
+		t.Fatalf("등록 해제 실패: %v", err)
+	}
+	if fleetRepo.devices[group.ID][d3.ID] {
+		t.Error("등록 해제된 디바이스는 그룹에서 제외되어야 합니다")
+	}
+}
+
+func TestGetFleetStats_온라인_교정_카트리지(t *testing.T) {
+	svc, deviceRepo, fleetRepo, group, devices := newTestFleetService(t)
+	ctx := context.Background()
+	now := time.Now().UTC()
+
+	deviceRepo.devices[devices[1].ID].LastSeen = now.Add(-time.Hour)
+	deviceRepo.devices[devices[1].ID].BatteryPercent = 10
+	fleetRepo.calibrations[devices[0].ID] = now.AddDate(0, 0, -3)
+	fleetRepo.calibrations[devices[1].ID] = now.AddDate(0, 0, -45)
+	svc.RecordCartridgeUse(ctx, devices[0].ID, now.AddDate(0, 0, -1))
+	svc.RecordCartridgeUse(ctx, devices[1].ID, now.AddDate(0, 0, -2))
+	svc.RecordCartridgeUse(ctx, devices[1].ID, now.AddDate(0, 0, -20))
+
+	st, err := svc.GetFleetStats(ctx, group.ID, "user-1", 7)
+	if err != nil {
+		t.Fatalf("플릿 통계 조회 실패: %v", err)
+	}
+	if st.TotalDevices != 2 || st.Online != 1 || st.OnlinePercent != 50 {
+		t.Errorf("온라인 집계 불일치: total=%d, online=%d (%.1f%%)", st.TotalDevices, st.Online, st.OnlinePercent)
+	}
+	if st.Calibrated != 1 || st.CalibrationCompliance != 50 {
+		t.Errorf("교정 준수율 불일치: calibrated=%d (%.1f%%)", st.Calibrated, st.CalibrationCompliance)
+	}
+	if st.CartridgesUsed != 2 || st.LowBattery != 1 {
+		t.Errorf("카트리지/배터리 집계 불일치: cartridges=%d, low=%d", st.CartridgesUsed, st.LowBattery)
+	}
+}
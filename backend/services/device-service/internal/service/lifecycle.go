@@ -0,0 +1,554 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	apperrors "github.com/manpasik/backend/shared/errors"
+	"go.uber.org/zap"
+)
+
+// ============================================================================
+// 소유권 이전 · 등록 해제 · 원격 초기화
+// ============================================================================
+
+// transferTTL은 소유권 이전 요청의 유효 기간입니다 (양측 확인 대기).
+const transferTTL = 72 * time.Hour
+
+// TransferStatus는 소유권 이전 상태입니다.
+type TransferStatus string
+
+const (
+	TransferPending   TransferStatus = "pending"
+	TransferCompleted TransferStatus = "completed"
+	TransferCancelled TransferStatus = "cancelled"
+	TransferExpired   TransferStatus = "expired"
+)
+
+// DeviceTransfer는 디바이스 소유권 이전 요청입니다.
+//
+// 기존 소유자(From)와 새 소유자(To)가 모두 확인해야 이전이 완료됩니다.
+type DeviceTransfer struct {
+	ID            string
+	DeviceID      string
+	FromUserID    string
+	ToUserID      string
+	Status        TransferStatus
+	FromConfirmed bool
+	ToConfirmed   bool
+	CreatedAt     time.Time
+	ExpiresAt     time.Time
+	CompletedAt   time.Time
+}
+
+// OwnershipPeriod는 디바이스의 소유 기간입니다.
+//
+// 측정·보정 기록은 기록 시점이 속한 소유 기간의 UserID에 귀속됩니다.
+type OwnershipPeriod struct {
+	DeviceID  string
+	UserID    string
+	StartedAt time.Time
+	EndedAt   time.Time // zero = 현재 소유 중
+	EndReason string    // "transferred", "deregistered"
+}
+
+// CommandType은 디바이스 명령 유형입니다.
+type CommandType string
+
+const (
+	CommandStartMeasurement CommandType = "start_measurement"
+	CommandStopMeasurement  CommandType = "stop_measurement"
+	CommandCalibrate        CommandType = "calibrate"
+	CommandReboot           CommandType = "reboot"
+	CommandOtaUpdate        CommandType = "ota_update"
+	CommandFactoryReset     CommandType = "factory_reset"
+	CommandCalibrationCheck CommandType = "calibration_check"
+	CommandStatusQuery      CommandType = "status_query"
+	CommandLock             CommandType = "lock"
+	CommandUnlock           CommandType = "unlock"
+)
+
+// CommandStatus는 대기열 명령의 상태입니다.
+type CommandStatus string
+
+const (
+	CommandQueued       CommandStatus = "queued"
+	CommandDelivered    CommandStatus = "delivered"
+	CommandAcknowledged CommandStatus = "acknowledged"
+	CommandFailed       CommandStatus = "failed"
+)
+
+// QueuedCommand는 디바이스가 다음 연결 시 수신할 대기열 명령입니다.
+type QueuedCommand struct {
+	ID             string
+	DeviceID       string
+	CommandType    CommandType
+	Payload        []byte
+	Status         CommandStatus
+	RouteHubID     string // 허브 경유 전달 시 중계 허브 (빈 값 = 직접 전달)
+	BulkID         string // 일괄 명령으로 생성된 경우 일괄 작업 ID
+	IssuedBy       string
+	Result         string
+	CreatedAt      time.Time
+	DeliveredAt    time.Time
+	AcknowledgedAt time.Time
+}
+
+// TransferRepository는 소유권 이전 요청 저장소입니다.
+type TransferRepository interface {
+	Create(ctx context.Context, t *DeviceTransfer) error
+	GetByID(ctx context.Context, transferID string) (*DeviceTransfer, error)
+	GetPendingByDevice(ctx context.Context, deviceID string) (*DeviceTransfer, error)
+	// Confirm은 대기 중인 요청에 userID 쪽 확인을 원자적으로 기록하고 갱신된 요청을 반환합니다.
+	// 요청이 이미 종료되었으면 nil을 반환합니다.
+	Confirm(ctx context.Context, transferID, userID string) (*DeviceTransfer, error)
+	// UpdateStatus는 대기 중인 요청만 status로 전환합니다.
+	// 다른 요청이 먼저 종료했으면 false를 반환합니다.
+	UpdateStatus(ctx context.Context, transferID string, status TransferStatus, completedAt time.Time) (bool, error)
+}
+
+// OwnershipRepository는 디바이스 소유 기간 저장소입니다.
+type OwnershipRepository interface {
+	Open(ctx context.Context, p *OwnershipPeriod) error
+	// Close는 현재 열려 있는 소유 기간을 종료합니다.
+	Close(ctx context.Context, deviceID string, endedAt time.Time, reason string) error
+	ListByDevice(ctx context.Context, deviceID string) ([]*OwnershipPeriod, error)
+}
+
+// CommandRepository는 디바이스 명령 대기열 저장소입니다.
+type CommandRepository interface {
+	Enqueue(ctx context.Context, cmd *QueuedCommand) error
+	GetByID(ctx context.Context, commandID string) (*QueuedCommand, error)
+	// ListPending은 queued/delivered 상태의 명령을 생성 순으로 반환합니다.
+	ListPending(ctx context.Context, deviceID string) ([]*QueuedCommand, error)
+	// ListPendingByRoute는 hubID를 경유하도록 지정된 미확인 명령을 반환합니다.
+	ListPendingByRoute(ctx context.Context, hubID string) ([]*QueuedCommand, error)
+	// ListByBulk는 일괄 작업으로 생성된 모든 명령을 반환합니다.
+	ListByBulk(ctx context.Context, bulkID string) ([]*QueuedCommand, error)
+	Update(ctx context.Context, cmd *QueuedCommand) error
+}
+
+// getOwnedDevice는 활성 디바이스를 조회하고 userID가 소유자인지 확인합니다.
+func (s *DeviceService) getOwnedDevice(ctx context.Context, deviceID, userID string) (*Device, error) {
+	device, err := s.deviceRepo.GetByID(ctx, deviceID)
+	if err != nil || device == nil {
+		return nil, apperrors.New(apperrors.ErrNotFound, "디바이스를 찾을 수 없습니다")
+	}
+	if !device.DeregisteredAt.IsZero() {
+		return nil, apperrors.New(apperrors.ErrConflict, "등록 해제된 디바이스입니다")
+	}
+	if device.UserID != userID {
+		return nil, apperrors.New(apperrors.ErrForbidden, "디바이스 소유자만 요청할 수 있습니다")
+	}
+	return device, nil
+}
+
+// InitiateTransfer는 디바이스 소유권 이전을 요청합니다.
+//
+// 요청만으로는 이전되지 않으며, 양측이 ConfirmTransfer로 확인해야 완료됩니다.
+func (s *DeviceService) InitiateTransfer(ctx context.Context, deviceID, fromUserID, toUserID string) (*DeviceTransfer, error) {
+	if deviceID == "" || fromUserID == "" || toUserID == "" {
+		return nil, apperrors.New(apperrors.ErrInvalidInput, "device_id, from_user_id, to_user_id는 필수입니다")
+	}
+	if fromUserID == toUserID {
+		return nil, apperrors.New(apperrors.ErrInvalidInput, "자기 자신에게 이전할 수 없습니다")
+	}
+
+	if _, err := s.getOwnedDevice(ctx, deviceID, fromUserID); err != nil {
+		return nil, err
+	}
+
+	existing, err := s.transferRepo.GetPendingByDevice(ctx, deviceID)
+	if err != nil {
+		return nil, apperrors.New(apperrors.ErrInternal, "이전 요청 조회에 실패했습니다")
+	}
+	now := time.Now().UTC()
+	if existing != nil {
+		if now.Before(existing.ExpiresAt) {
+			return nil, apperrors.New(apperrors.ErrConflict, "진행 중인 소유권 이전 요청이 있습니다")
+		}
+		_, _ = s.transferRepo.UpdateStatus(ctx, existing.ID, TransferExpired, time.Time{})
+	}
+
+	transfer := &DeviceTransfer{
+		ID:         uuid.New().String(),
+		DeviceID:   deviceID,
+		FromUserID: fromUserID,
+		ToUserID:   toUserID,
+		Status:     TransferPending,
+		CreatedAt:  now,
+		ExpiresAt:  now.Add(transferTTL),
+	}
+	if err := s.transferRepo.Create(ctx, transfer); err != nil {
+		return nil, apperrors.New(apperrors.ErrInternal, "소유권 이전 요청 생성에 실패했습니다")
+	}
+
+	s.logger.Info("소유권 이전 요청",
+		zap.String("transfer_id", transfer.ID),
+		zap.String("device_id", deviceID),
+		zap.String("from_user_id", fromUserID),
+		zap.String("to_user_id", toUserID),
+	)
+
+	return transfer, nil
+}
+
+// ConfirmTransfer는 이전 당사자 한 명의 확인을 기록합니다.
+// 양측 확인이 모두 모이면 소유권이 이전됩니다.
+//
+// 확인은 저장소에서 원자적으로 기록하므로 양측이 동시에 확인하거나
+// 취소와 겹쳐도 한쪽 확인이 사라지거나 취소가 덮어써지지 않습니다.
+func (s *DeviceService) ConfirmTransfer(ctx context.Context, transferID, userID string) (*DeviceTransfer, error) {
+	transfer, err := s.getPendingTransfer(ctx, transferID)
+	if err != nil {
+		return nil, err
+	}
+	if userID != transfer.FromUserID && userID != transfer.ToUserID {
+		return nil, apperrors.New(apperrors.ErrForbidden, "이전 당사자만 확인할 수 있습니다")
+	}
+
+	transfer, err = s.transferRepo.Confirm(ctx, transferID, userID)
+	if err != nil {
+		return nil, apperrors.New(apperrors.ErrInternal, "소유권 이전 확인에 실패했습니다")
+	}
+	if transfer == nil {
+		return nil, apperrors.New(apperrors.ErrConflict, "이미 종료된 이전 요청입니다")
+	}
+
+	if transfer.FromConfirmed && transfer.ToConfirmed {
+		if err := s.completeTransfer(ctx, transfer); err != nil {
+			return nil, err
+		}
+	}
+	return transfer, nil
+}
+
+// CancelTransfer는 진행 중인 소유권 이전을 취소합니다 (당사자 누구나 가능).
+func (s *DeviceService) CancelTransfer(ctx context.Context, transferID, userID string) (*DeviceTransfer, error) {
+	transfer, err := s.getPendingTransfer(ctx, transferID)
+	if err != nil {
+		return nil, err
+	}
+	if userID != transfer.FromUserID && userID != transfer.ToUserID {
+		return nil, apperrors.New(apperrors.ErrForbidden, "이전 당사자만 취소할 수 있습니다")
+	}
+
+	ok, err := s.transferRepo.UpdateStatus(ctx, transferID, TransferCancelled, time.Time{})
+	if err != nil {
+		return nil, apperrors.New(apperrors.ErrInternal, "소유권 이전 취소에 실패했습니다")
+	}
+	if !ok {
+		return nil, apperrors.New(apperrors.ErrConflict, "이미 종료된 이전 요청입니다")
+	}
+	transfer.Status = TransferCancelled
+	return transfer, nil
+}
+
+// getPendingTransfer는 대기 중인 이전 요청을 조회하며, 만료된 요청은 expired로 전환합니다.
+func (s *DeviceService) getPendingTransfer(ctx context.Context, transferID string) (*DeviceTransfer, error) {
+	if transferID == "" {
+		return nil, apperrors.New(apperrors.ErrInvalidInput, "transfer_id는 필수입니다")
+	}
+	transfer, err := s.transferRepo.GetByID(ctx, transferID)
+	if err != nil || transfer == nil {
+		return nil, apperrors.New(apperrors.ErrNotFound, "소유권 이전 요청을 찾을 수 없습니다")
+	}
+	if transfer.Status != TransferPending {
+		return nil, apperrors.New(apperrors.ErrConflict, fmt.Sprintf("이미 종료된 이전 요청입니다 (%s)", transfer.Status))
+	}
+	if time.Now().UTC().After(transfer.ExpiresAt) {
+		_, _ = s.transferRepo.UpdateStatus(ctx, transferID, TransferExpired, time.Time{})
+		return nil, apperrors.New(apperrors.ErrConflict, "만료된 소유권 이전 요청입니다")
+	}
+	return transfer, nil
+}
+
+// completeTransfer는 소유자를 변경하고 소유 기간을 분리합니다.
+// 소유자를 바꾸기 전에 요청을 completed로 먼저 전환해, 동시에 완료를 시도하거나
+// 취소된 요청이 두 번 이전되지 않도록 합니다.
+func (s *DeviceService) completeTransfer(ctx context.Context, transfer *DeviceTransfer) error {
+	device, err := s.getOwnedDevice(ctx, transfer.DeviceID, transfer.FromUserID)
+	if err != nil {
+		return err
+	}
+
+	// 새 소유자의 구독 한도 확인
+	currentCount, err := s.deviceRepo.CountByUser(ctx, transfer.ToUserID)
+	if err != nil {
+		return apperrors.New(apperrors.ErrInternal, "디바이스 수 확인에 실패했습니다")
+	}
+	maxDevices, err := s.subChecker.GetMaxDevices(ctx, transfer.ToUserID)
+	if err != nil {
+		maxDevices = 999
+	}
+	if currentCount >= maxDevices {
+		return apperrors.New(apperrors.ErrDeviceLimitExceeded,
+			fmt.Sprintf("새 소유자의 디바이스 등록 한도 초과 (현재: %d, 최대: %d)", currentCount, maxDevices))
+	}
+
+	now := time.Now().UTC()
+	ok, err := s.transferRepo.UpdateStatus(ctx, transfer.ID, TransferCompleted, now)
+	if err != nil {
+		return apperrors.New(apperrors.ErrInternal, "소유권 이전 상태 저장에 실패했습니다")
+	}
+	if !ok {
+		return apperrors.New(apperrors.ErrConflict, "이미 종료된 이전 요청입니다")
+	}
+	transfer.Status = TransferCompleted
+	transfer.CompletedAt = now
+
+	if err := s.deviceRepo.UpdateOwner(ctx, device.ID, transfer.ToUserID); err != nil {
+		s.logger.Error("이전 완료 후 소유자 변경 실패", zap.String("transfer_id", transfer.ID), zap.Error(err))
+		return apperrors.New(apperrors.ErrInternal, "소유자 변경에 실패했습니다")
+	}
+	// 기존 소유자의 허브 구성과 디바이스 그룹에서 분리
+	s.detachTopology(ctx, device)
+	s.detachGroups(ctx, device.ID)
+
+	// 소유 기간 분리: 이전 시점 이전 기록은 기존 소유자, 이후는 새 소유자
+	if err := s.ownershipRepo.Close(ctx, device.ID, now, "transferred"); err != nil {
+		s.logger.Warn("소유 기간 종료 기록 실패", zap.String("device_id", device.ID), zap.Error(err))
+	}
+	if err := s.ownershipRepo.Open(ctx, &OwnershipPeriod{
+		DeviceID:  device.ID,
+		UserID:    transfer.ToUserID,
+		StartedAt: now,
+	}); err != nil {
+		s.logger.Warn("소유 기간 시작 기록 실패", zap.String("device_id", device.ID), zap.Error(err))
+	}
+
+	_ = s.eventRepo.LogEvent(ctx, &DeviceEvent{
+		ID:        uuid.New().String(),
+		DeviceID:  device.ID,
+		EventType: "transferred",
+		Payload: map[string]interface{}{
+			"transfer_id":  transfer.ID,
+			"from_user_id": transfer.FromUserID,
+			"to_user_id":   transfer.ToUserID,
+		},
+		CreatedAt: now,
+	})
+
+	s.logger.Info("소유권 이전 완료",
+		zap.String("transfer_id", transfer.ID),
+		zap.String("device_id", device.ID),
+		zap.String("to_user_id", transfer.ToUserID),
+	)
+
+	if s.eventPublisher != nil {
+		evt := &DeviceTransferredEvent{
+			DeviceID:      device.ID,
+			TransferID:    transfer.ID,
+			FromUserID:    transfer.FromUserID,
+			ToUserID:      transfer.ToUserID,
+			SerialNumber:  device.SerialNumber,
+			TransferredAt: now,
+		}
+		if err := s.eventPublisher.PublishDeviceTransferred(ctx, evt); err != nil {
+			s.logger.Warn("소유권 이전 이벤트 발행 실패 (비치명적)", zap.Error(err))
+		}
+	}
+
+	return nil
+}
+
+// ListOwnershipHistory는 디바이스의 소유 기간 이력을 시간순으로 반환합니다.
+func (s *DeviceService) ListOwnershipHistory(ctx context.Context, deviceID string) ([]*OwnershipPeriod, error) {
+	if deviceID == "" {
+		return nil, apperrors.New(apperrors.ErrInvalidInput, "device_id는 필수입니다")
+	}
+	return s.ownershipRepo.ListByDevice(ctx, deviceID)
+}
+
+// OwnerAt은 지정 시점의 디바이스 소유자를 반환합니다.
+// 측정·보정 기록을 올바른 사용자에게 귀속시킬 때 사용합니다.
+func (s *DeviceService) OwnerAt(ctx context.Context, deviceID string, at time.Time) (string, error) {
+	periods, err := s.ListOwnershipHistory(ctx, deviceID)
+	if err != nil {
+		return "", err
+	}
+	for _, p := range periods {
+		if at.Before(p.StartedAt) {
+			continue
+		}
+		if p.EndedAt.IsZero() || at.Before(p.EndedAt) {
+			return p.UserID, nil
+		}
+	}
+	return "", apperrors.New(apperrors.ErrNotFound, "해당 시점의 소유자를 찾을 수 없습니다")
+}
+
+// DeregisterDevice는 디바이스 등록을 해제하여 구독 슬롯을 반환합니다.
+//
+// 디바이스 레코드와 이벤트 이력은 보존되며, 진행 중인 소유권 이전은 취소됩니다.
+func (s *DeviceService) DeregisterDevice(ctx context.Context, deviceID, userID, reason string) (time.Time, error) {
+	if deviceID == "" || userID == "" {
+		return time.Time{}, apperrors.New(apperrors.ErrInvalidInput, "device_id와 user_id는 필수입니다")
+	}
+
+	device, err := s.getOwnedDevice(ctx, deviceID, userID)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	now := time.Now().UTC()
+	if err := s.deviceRepo.Deregister(ctx, deviceID, now); err != nil {
+		return time.Time{}, apperrors.New(apperrors.ErrInternal, "디바이스 등록 해제에 실패했습니다")
+	}
+	if err := s.ownershipRepo.Close(ctx, deviceID, now, "deregistered"); err != nil {
+		s.logger.Warn("소유 기간 종료 기록 실패", zap.String("device_id", deviceID), zap.Error(err))
+	}
+	s.detachTopology(ctx, device)
+	s.detachGroups(ctx, deviceID)
+	if pending, _ := s.transferRepo.GetPendingByDevice(ctx, deviceID); pending != nil {
+		_, _ = s.transferRepo.UpdateStatus(ctx, pending.ID, TransferCancelled, time.Time{})
+	}
+
+	_ = s.eventRepo.LogEvent(ctx, &DeviceEvent{
+		ID:        uuid.New().String(),
+		DeviceID:  deviceID,
+		EventType: "deregistered",
+		Payload: map[string]interface{}{
+			"user_id": userID,
+			"reason":  reason,
+		},
+		CreatedAt: now,
+	})
+
+	s.logger.Info("디바이스 등록 해제",
+		zap.String("device_id", deviceID),
+		zap.String("user_id", userID),
+		zap.String("reason", reason),
+	)
+
+	if s.eventPublisher != nil {
+		evt := &DeviceDeregisteredEvent{
+			DeviceID:       deviceID,
+			UserID:         userID,
+			SerialNumber:   device.SerialNumber,
+			Reason:         reason,
+			DeregisteredAt: now,
+		}
+		if err := s.eventPublisher.PublishDeviceDeregistered(ctx, evt); err != nil {
+			s.logger.Warn("디바이스 등록 해제 이벤트 발행 실패 (비치명적)", zap.Error(err))
+		}
+	}
+
+	return now, nil
+}
+
+// RequestRemoteWipe는 공장 초기화 명령을 대기열에 추가합니다.
+// 디바이스는 다음 연결 시 명령을 수신합니다. deregister가 true면 즉시 등록 해제합니다.
+func (s *DeviceService) RequestRemoteWipe(ctx context.Context, deviceID, userID string, deregister bool) (*QueuedCommand, error) {
+	if deviceID == "" || userID == "" {
+		return nil, apperrors.New(apperrors.ErrInvalidInput, "device_id와 user_id는 필수입니다")
+	}
+	device, err := s.getOwnedDevice(ctx, deviceID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	cmd, err := s.enqueueCommand(ctx, device, CommandFactoryReset, nil, userID, "")
+	if err != nil {
+		return nil, apperrors.New(apperrors.ErrInternal, "원격 초기화 명령 등록에 실패했습니다")
+	}
+
+	_ = s.eventRepo.LogEvent(ctx, &DeviceEvent{
+		ID:        uuid.New().String(),
+		DeviceID:  deviceID,
+		EventType: "wipe_requested",
+		Payload: map[string]interface{}{
+			"command_id": cmd.ID,
+			"user_id":    userID,
+			"deregister": deregister,
+		},
+		CreatedAt: cmd.CreatedAt,
+	})
+
+	s.logger.Info("원격 초기화 명령 대기열 등록",
+		zap.String("device_id", deviceID),
+		zap.String("command_id", cmd.ID),
+	)
+
+	if deregister {
+		if _, err := s.DeregisterDevice(ctx, deviceID, userID, "remote_wipe"); err != nil {
+			return nil, err
+		}
+	}
+
+	return cmd, nil
+}
+
+// ListPendingCommands는 디바이스가 수신할 미확인 명령을 반환하고 delivered로 표시합니다.
+// 허브는 자신에게 온 명령과 함께 자신(및 하위 허브)을 경유하는 리더 명령도 수신합니다.
+func (s *DeviceService) ListPendingCommands(ctx context.Context, deviceID string) ([]*QueuedCommand, error) {
+	if deviceID == "" {
+		return nil, apperrors.New(apperrors.ErrInvalidInput, "device_id는 필수입니다")
+	}
+	cmds, err := s.pendingCommandsFor(ctx, deviceID)
+	if err != nil {
+		return nil, apperrors.New(apperrors.ErrInternal, "명령 대기열 조회에 실패했습니다")
+	}
+
+	now := time.Now().UTC()
+	for _, cmd := range cmds {
+		if cmd.Status != CommandQueued {
+			continue
+		}
+		cmd.Status = CommandDelivered
+		cmd.DeliveredAt = now
+		if err := s.commandRepo.Update(ctx, cmd); err != nil {
+			s.logger.Warn("명령 전달 상태 기록 실패", zap.String("command_id", cmd.ID), zap.Error(err))
+		}
+	}
+	return cmds, nil
+}
+
+// AcknowledgeCommand는 디바이스의 명령 수행 결과를 기록합니다.
+func (s *DeviceService) AcknowledgeCommand(ctx context.Context, deviceID, commandID string, success bool, message string) error {
+	if deviceID == "" || commandID == "" {
+		return apperrors.New(apperrors.ErrInvalidInput, "device_id와 command_id는 필수입니다")
+	}
+	cmd, err := s.commandRepo.GetByID(ctx, commandID)
+	if err != nil || cmd == nil || cmd.DeviceID != deviceID {
+		return apperrors.New(apperrors.ErrNotFound, "명령을 찾을 수 없습니다")
+	}
+	if cmd.Status == CommandAcknowledged || cmd.Status == CommandFailed {
+		return apperrors.New(apperrors.ErrConflict, "이미 처리된 명령입니다")
+	}
+
+	now := time.Now().UTC()
+	cmd.Status = CommandAcknowledged
+	if !success {
+		cmd.Status = CommandFailed
+	}
+	cmd.Result = message
+	cmd.AcknowledgedAt = now
+	if err := s.commandRepo.Update(ctx, cmd); err != nil {
+		return apperrors.New(apperrors.ErrInternal, "명령 결과 기록에 실패했습니다")
+	}
+
+	_ = s.eventRepo.LogEvent(ctx, &DeviceEvent{
+		ID:        uuid.New().String(),
+		DeviceID:  deviceID,
+		EventType: "command_acknowledged",
+		Payload: map[string]interface{}{
+			"command_id":   commandID,
+			"command_type": string(cmd.CommandType),
+			"success":      success,
+			"message":      message,
+		},
+		CreatedAt: now,
+	})
+
+	// 교정 명령 성공은 플릿 교정 준수율 집계에 반영
+	if success && s.fleetRepo != nil && (cmd.CommandType == CommandCalibrate || cmd.CommandType == CommandCalibrationCheck) {
+		if err := s.fleetRepo.RecordCalibration(ctx, deviceID, now); err != nil {
+			s.logger.Warn("교정 기록 실패", zap.String("device_id", deviceID), zap.Error(err))
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,278 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// =============================================================================
+// 소유권 이전 테스트
+// =============================================================================
+
+func TestTransfer_양측_확인_후_완료(t *testing.T) {
+	svc, deviceRepo, _ := newTestDeviceService(10)
+	ctx := context.Background()
+
+	device, _, _ := svc.RegisterDevice(ctx, "BLE-01", "SN-0001", "1.0.0", "user-1")
+
+	transfer, err := svc.InitiateTransfer(ctx, device.ID, "user-1", "user-2")
+	if err != nil {
+		t.Fatalf("이전 요청 실패: %v", err)
+	}
+	if transfer.Status != TransferPending {
+		t.Fatalf("초기 상태가 pending이어야 합니다: got %s", transfer.Status)
+	}
+
+	// 받는 쪽만 확인 → 아직 이전되지 않음
+	transfer, err = svc.ConfirmTransfer(ctx, transfer.ID, "user-2")
+	if err != nil {
+		t.Fatalf("수신자 확인 실패: %v", err)
+	}
+	if transfer.Status != TransferPending || deviceRepo.devices[device.ID].UserID != "user-1" {
+		t.Fatal("한쪽 확인만으로 이전되면 안 됩니다")
+	}
+
+	transfer, err = svc.ConfirmTransfer(ctx, transfer.ID, "user-1")
+	if err != nil {
+		t.Fatalf("기존 소유자 확인 실패: %v", err)
+	}
+	if transfer.Status != TransferCompleted {
+		t.Errorf("양측 확인 후 completed여야 합니다: got %s", transfer.Status)
+	}
+	if deviceRepo.devices[device.ID].UserID != "user-2" {
+		t.Errorf("소유자가 user-2로 변경되어야 합니다: got %s", deviceRepo.devices[device.ID].UserID)
+	}
+
+	list1, _ := svc.ListDevices(ctx, "user-1")
+	list2, _ := svc.ListDevices(ctx, "user-2")
+	if len(list1) != 0 || len(list2) != 1 {
+		t.Errorf("디바이스 목록 불일치: user-1=%d, user-2=%d", len(list1), len(list2))
+	}
+}
+
+func TestTransfer_이력_소유자_귀속(t *testing.T) {
+	svc, _, _ := newTestDeviceService(10)
+	ctx := context.Background()
+
+	device, _, _ := svc.RegisterDevice(ctx, "BLE-01", "SN-0001", "1.0.0", "user-1")
+	beforeTransfer := time.Now().UTC()
+
+	transfer, _ := svc.InitiateTransfer(ctx, device.ID, "user-1", "user-2")
+	svc.ConfirmTransfer(ctx, transfer.ID, "user-1")
+	svc.ConfirmTransfer(ctx, transfer.ID, "user-2")
+
+	periods, err := svc.ListOwnershipHistory(ctx, device.ID)
+	if err != nil {
+		t.Fatalf("소유 이력 조회 실패: %v", err)
+	}
+	if len(periods) != 2 {
+		t.Fatalf("소유 기간 2개여야 합니다: got %d", len(periods))
+	}
+	if periods[0].UserID != "user-1" || periods[0].EndReason != "transferred" {
+		t.Errorf("첫 소유 기간 불일치: %+v", periods[0])
+	}
+
+	owner, err := svc.OwnerAt(ctx, device.ID, beforeTransfer)
+	if err != nil || owner != "user-1" {
+		t.Errorf("이전 전 기록은 user-1 소유여야 합니다: got %q, err=%v", owner, err)
+	}
+	owner, err = svc.OwnerAt(ctx, device.ID, time.Now().UTC().Add(time.Minute))
+	if err != nil || owner != "user-2" {
+		t.Errorf("이전 후 기록은 user-2 소유여야 합니다: got %q, err=%v", owner, err)
+	}
+}
+
+func TestTransfer_소유자가_아니면_거부(t *testing.T) {
+	svc, _, _ := newTestDeviceService(10)
+	ctx := context.Background()
+
+	device, _, _ := svc.RegisterDevice(ctx, "BLE-01", "SN-0001", "1.0.0", "user-1")
+
+	if _, err := svc.InitiateTransfer(ctx, device.ID, "user-3", "user-2"); err == nil {
+		t.Fatal("소유자가 아닌 사용자의 이전 요청은 거부되어야 합니다")
+	}
+
+	transfer, _ := svc.InitiateTransfer(ctx, device.ID, "user-1", "user-2")
+	if _, err := svc.ConfirmTransfer(ctx, transfer.ID, "user-3"); err == nil {
+		t.Fatal("당사자가 아닌 사용자의 확인은 거부되어야 합니다")
+	}
+	if _, err := svc.InitiateTransfer(ctx, device.ID, "user-1", "user-4"); err == nil {
+		t.Fatal("진행 중인 이전 요청이 있으면 새 요청은 거부되어야 합니다")
+	}
+}
+
+func TestTransfer_만료(t *testing.T) {
+	svc, _, _ := newTestDeviceService(10)
+	ctx := context.Background()
+
+	device, _, _ := svc.RegisterDevice(ctx, "BLE-01", "SN-0001", "1.0.0", "user-1")
+	transfer, _ := svc.InitiateTransfer(ctx, device.ID, "user-1", "user-2")
+
+	transferRepo := svc.transferRepo.(*mockTransferRepo)
+	transferRepo.transfers[transfer.ID].ExpiresAt = time.Now().UTC().Add(-time.Minute)
+
+	if _, err := svc.ConfirmTransfer(ctx, transfer.ID, "user-2"); err == nil {
+		t.Fatal("만료된 이전 요청 확인은 실패해야 합니다")
+	}
+	if transferRepo.transfers[transfer.ID].Status != TransferExpired {
+		t.Errorf("만료 상태로 전환되어야 합니다: got %s", transferRepo.transfers[transfer.ID].Status)
+	}
+}
+
+func TestTransfer_수신자_한도_초과(t *testing.T) {
+	svc, deviceRepo, _ := newTestDeviceService(1)
+	ctx := context.Background()
+
+	device, _, _ := svc.RegisterDevice(ctx, "BLE-01", "SN-0001", "1.0.0", "user-1")
+	svc.RegisterDevice(ctx, "BLE-02", "SN-0002", "1.0.0", "user-2")
+
+	transfer, _ := svc.InitiateTransfer(ctx, device.ID, "user-1", "user-2")
+	svc.ConfirmTransfer(ctx, transfer.ID, "user-1")
+	if _, err := svc.ConfirmTransfer(ctx, transfer.ID, "user-2"); err == nil {
+		t.Fatal("새 소유자의 디바이스 한도 초과 시 이전이 실패해야 합니다")
+	}
+	if deviceRepo.devices[device.ID].UserID != "user-1" {
+		t.Error("이전 실패 시 소유자가 유지되어야 합니다")
+	}
+}
+
+func TestTransfer_취소(t *testing.T) {
+	svc, _, _ := newTestDeviceService(10)
+	ctx := context.Background()
+
+	device, _, _ := svc.RegisterDevice(ctx, "BLE-01", "SN-0001", "1.0.0", "user-1")
+	transfer, _ := svc.InitiateTransfer(ctx, device.ID, "user-1", "user-2")
+
+	cancelled, err := svc.CancelTransfer(ctx, transfer.ID, "user-2")
+	if err != nil {
+		t.Fatalf("취소 실패: %v", err)
+	}
+	if cancelled.Status != TransferCancelled {
+		t.Errorf("cancelled여야 합니다: got %s", cancelled.Status)
+	}
+	if _, err := svc.ConfirmTransfer(ctx, transfer.ID, "user-1"); err == nil {
+		t.Fatal("취소된 요청은 확인할 수 없어야 합니다")
+	}
+}
+
+func TestTransfer_동시_확인과_취소(t *testing.T) {
+	svc, deviceRepo, _ := newTestDeviceService(10)
+	ctx := context.Background()
+	transferRepo := svc.transferRepo.(*mockTransferRepo)
+
+	// 기존 소유자가 조회한 직후 새 소유자의 확인이 먼저 기록되어도 양측 확인이 모두 남음
+	device, _, _ := svc.RegisterDevice(ctx, "BLE-01", "SN-0001", "1.0.0", "user-1")
+	transfer, _ := svc.InitiateTransfer(ctx, device.ID, "user-1", "user-2")
+	transferRepo.afterGet = func() {
+		transferRepo.afterGet = nil
+		svc.ConfirmTransfer(ctx, transfer.ID, "user-2")
+	}
+	done, err := svc.ConfirmTransfer(ctx, transfer.ID, "user-1")
+	if err != nil {
+		t.Fatalf("확인 실패: %v", err)
+	}
+	if done.Status != TransferCompleted || deviceRepo.devices[device.ID].UserID != "user-2" {
+		t.Fatalf("동시 확인 후 이전이 완료되어야 합니다: %+v", done)
+	}
+
+	// 조회 직후 취소가 먼저 기록되면 확인은 거부되고 취소가 유지됨
+	transfer, _ = svc.InitiateTransfer(ctx, device.ID, "user-2", "user-3")
+	transferRepo.afterGet = func() {
+		transferRepo.afterGet = nil
+		svc.CancelTransfer(ctx, transfer.ID, "user-3")
+	}
+	if _, err := svc.ConfirmTransfer(ctx, transfer.ID, "user-2"); err == nil {
+		t.Fatal("취소와 겹친 확인은 거부되어야 합니다")
+	}
+	if stored := transferRepo.transfers[transfer.ID]; stored.Status != TransferCancelled || stored.FromConfirmed {
+		t.Errorf("취소가 덮어써지면 안 됩니다: %+v", stored)
+	}
+}
+
+// =============================================================================
+// 등록 해제 / 원격 초기화 테스트
+// =============================================================================
+
+func TestDeregisterDevice_구독_슬롯_반환(t *testing.T) {
+	svc, _, eventRepo := newTestDeviceService(1)
+	ctx := context.Background()
+
+	device, _, _ := svc.RegisterDevice(ctx, "BLE-01", "SN-0001", "1.0.0", "user-1")
+	if _, _, err := svc.RegisterDevice(ctx, "BLE-02", "SN-0002", "1.0.0", "user-1"); err == nil {
+		t.Fatal("한도 초과로 등록이 실패해야 합니다")
+	}
+
+	if _, err := svc.DeregisterDevice(ctx, device.ID, "user-1", "sold"); err != nil {
+		t.Fatalf("등록 해제 실패: %v", err)
+	}
+	if _, _, err := svc.RegisterDevice(ctx, "BLE-02", "SN-0002", "1.0.0", "user-1"); err != nil {
+		t.Fatalf("등록 해제 후 슬롯이 반환되어야 합니다: %v", err)
+	}
+
+	last := eventRepo.events[len(eventRepo.events)-2]
+	if last.EventType != "deregistered" {
+		t.Errorf("deregistered 이벤트가 기록되어야 합니다: got %s", last.EventType)
+	}
+
+	if _, err := svc.DeregisterDevice(ctx, device.ID, "user-1", "sold"); err == nil {
+		t.Fatal("이미 등록 해제된 디바이스는 다시 해제할 수 없어야 합니다")
+	}
+}
+
+func TestDeregisterDevice_진행중_이전_취소(t *testing.T) {
+	svc, _, _ := newTestDeviceService(10)
+	ctx := context.Background()
+
+	device, _, _ := svc.RegisterDevice(ctx, "BLE-01", "SN-0001", "1.0.0", "user-1")
+	transfer, _ := svc.InitiateTransfer(ctx, device.ID, "user-1", "user-2")
+
+	svc.DeregisterDevice(ctx, device.ID, "user-1", "lost")
+
+	stored := svc.transferRepo.(*mockTransferRepo).transfers[transfer.ID]
+	if stored.Status != TransferCancelled {
+		t.Errorf("등록 해제 시 진행 중 이전은 취소되어야 합니다: got %s", stored.Status)
+	}
+}
+
+func TestRequestRemoteWipe_대기열_및_확인(t *testing.T) {
+	svc, deviceRepo, _ := newTestDeviceService(10)
+	ctx := context.Background()
+
+	device, _, _ := svc.RegisterDevice(ctx, "BLE-01", "SN-0001", "1.0.0", "user-1")
+
+	if _, err := svc.RequestRemoteWipe(ctx, device.ID, "user-2", false); err == nil {
+		t.Fatal("소유자가 아니면 원격 초기화를 요청할 수 없어야 합니다")
+	}
+
+	cmd, err := svc.RequestRemoteWipe(ctx, device.ID, "user-1", true)
+	if err != nil {
+		t.Fatalf("원격 초기화 요청 실패: %v", err)
+	}
+	if cmd.CommandType != CommandFactoryReset || cmd.Status != CommandQueued {
+		t.Errorf("factory_reset 명령이 queued 상태여야 합니다: %+v", cmd)
+	}
+	if deviceRepo.devices[device.ID].DeregisteredAt.IsZero() {
+		t.Error("deregister=true이면 등록 해제되어야 합니다")
+	}
+
+	// 등록 해제 후에도 디바이스는 대기 명령을 수신해야 함
+	pending, err := svc.ListPendingCommands(ctx, device.ID)
+	if err != nil || len(pending) != 1 {
+		t.Fatalf("대기 명령 1개여야 합니다: got %d, err=%v", len(pending), err)
+	}
+	if pending[0].Status != CommandDelivered {
+		t.Errorf("조회 후 delivered로 전환되어야 합니다: got %s", pending[0].Status)
+	}
+
+	if err := svc.AcknowledgeCommand(ctx, device.ID, cmd.ID, true, "reset done"); err != nil {
+		t.Fatalf("명령 확인 실패: %v", err)
+	}
+	pending, _ = svc.ListPendingCommands(ctx, device.ID)
+	if len(pending) != 0 {
+		t.Errorf("확인 후 대기 명령이 없어야 합니다: got %d", len(pending))
+	}
+	if err := svc.AcknowledgeCommand(ctx, device.ID, cmd.ID, true, ""); err == nil {
+		t.Fatal("이미 처리된 명령은 다시 확인할 수 없어야 합니다")
+	}
+}
@@ -0,0 +1,475 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	apperrors "github.com/manpasik/backend/shared/errors"
+	"go.uber.org/zap"
+)
+
+// ============================================================================
+// 허브 토폴로지: BLE 리더 → Wi-Fi 허브 → 클라우드 게이트웨이
+// ============================================================================
+
+const (
+	// relayFreshness 이내에 중계한 허브가 있으면 명령을 해당 허브로 경유시킵니다.
+	relayFreshness = 10 * time.Minute
+	// offlineAfter 동안 소식이 없는 디바이스는 저장된 상태와 무관하게 offline으로 집계합니다.
+	offlineAfter = 5 * time.Minute
+	// lowBatteryPercent 미만은 배터리 부족으로 집계합니다.
+	lowBatteryPercent = 20
+)
+
+// DeviceKind는 토폴로지 상의 디바이스 종류입니다.
+type DeviceKind string
+
+const (
+	KindReader       DeviceKind = "reader"
+	KindWifiHub      DeviceKind = "wifi_hub"
+	KindCloudGateway DeviceKind = "cloud_gateway"
+)
+
+// tier는 토폴로지 계층입니다. 상위 허브는 항상 자식보다 높은 계층이어야 합니다.
+func (k DeviceKind) tier() int {
+	switch k {
+	case KindWifiHub:
+		return 1
+	case KindCloudGateway:
+		return 2
+	default:
+		return 0
+	}
+}
+
+// IsHub는 하위 디바이스를 가질 수 있는 종류인지 반환합니다.
+func (k DeviceKind) IsHub() bool {
+	return k == KindWifiHub || k == KindCloudGateway
+}
+
+// RelayedStatus는 허브가 중계한 하위 디바이스 상태입니다.
+type RelayedStatus struct {
+	DeviceID       string
+	Status         DeviceStatus
+	BatteryPercent int
+	ObservedAt     time.Time
+}
+
+// HubStatus는 허브 단위로 집계한 하위 디바이스 상태입니다.
+//
+// 합계는 하위 허브를 포함한 전체 서브트리의 리더 기준이며,
+// Online은 연결된(online/measuring/updating) 리더 수, Measuring은 그중 측정 중인 수입니다.
+type HubStatus struct {
+	Hub             *Device
+	TotalDevices    int
+	Online          int
+	Offline         int
+	Measuring       int
+	Error           int
+	LowBattery      int
+	PendingCommands int
+	ChildHubs       []*HubStatus
+}
+
+func defaultDeviceName(kind DeviceKind, serialNumber string) string {
+	suffix := serialNumber
+	if len(suffix) > 4 {
+		suffix = suffix[:4]
+	}
+	switch kind {
+	case KindWifiHub:
+		return fmt.Sprintf("ManPaSik Hub %s", suffix)
+	case KindCloudGateway:
+		return fmt.Sprintf("ManPaSik Gateway %s", suffix)
+	default:
+		return fmt.Sprintf("ManPaSik Reader %s", suffix)
+	}
+}
+
+// effectiveStatus는 마지막 수신 시각을 반영한 집계용 상태입니다.
+func effectiveStatus(d *Device, now time.Time) DeviceStatus {
+	switch d.Status {
+	case StatusOnline, StatusMeasuring, StatusUpdating:
+		if now.Sub(d.LastSeen) > offlineAfter {
+			return StatusOffline
+		}
+	}
+	return d.Status
+}
+
+// getHub는 활성 허브를 조회합니다.
+func (s *DeviceService) getHub(ctx context.Context, hubID string) (*Device, error) {
+	hub, err := s.deviceRepo.GetByID(ctx, hubID)
+	if err != nil || hub == nil {
+		return nil, apperrors.New(apperrors.ErrNotFound, "허브를 찾을 수 없습니다")
+	}
+	if !hub.Kind.IsHub() {
+		return nil, apperrors.New(apperrors.ErrInvalidInput, "허브가 아닌 디바이스입니다")
+	}
+	if !hub.DeregisteredAt.IsZero() {
+		return nil, apperrors.New(apperrors.ErrConflict, "등록 해제된 허브입니다")
+	}
+	return hub, nil
+}
+
+// RegisterHub는 Wi-Fi 허브 또는 클라우드 게이트웨이를 등록합니다.
+// parentHubID가 주어지면 해당 상위 허브 아래에 배치합니다.
+func (s *DeviceService) RegisterHub(
+	ctx context.Context,
+	deviceHwID, serialNumber, firmwareVersion, userID string,
+	kind DeviceKind,
+	parentHubID string,
+) (*Device, string, error) {
+	if !kind.IsHub() {
+		return nil, "", apperrors.New(apperrors.ErrInvalidInput, "허브 종류는 wifi_hub 또는 cloud_gateway여야 합니다")
+	}
+	if parentHubID != "" {
+		parent, err := s.getHub(ctx, parentHubID)
+		if err != nil {
+			return nil, "", err
+		}
+		if parent.UserID != userID {
+			return nil, "", apperrors.New(apperrors.ErrForbidden, "상위 허브 소유자만 하위 허브를 추가할 수 있습니다")
+		}
+		if parent.Kind.tier() <= kind.tier() {
+			return nil, "", apperrors.New(apperrors.ErrInvalidInput, "상위 허브는 더 높은 계층이어야 합니다")
+		}
+	}
+
+	hub, token, err := s.registerDevice(ctx, deviceHwID, serialNumber, firmwareVersion, userID, kind)
+	if err != nil {
+		return nil, "", err
+	}
+	if parentHubID != "" {
+		if err := s.deviceRepo.SetParentHub(ctx, hub.ID, parentHubID); err != nil {
+			return nil, "", apperrors.New(apperrors.ErrInternal, "상위 허브 배정에 실패했습니다")
+		}
+		hub.ParentHubID = parentHubID
+	}
+	return hub, token, nil
+}
+
+// AttachToHub는 디바이스들을 허브 아래에 배정합니다.
+// 허브와 같은 소유자의 하위 계층 디바이스만 배정되며, 실패한 ID는 따로 반환됩니다.
+func (s *DeviceService) AttachToHub(ctx context.Context, hubID, userID string, deviceIDs []string) (int, []string, error) {
+	if hubID == "" || userID == "" || len(deviceIDs) == 0 {
+		return 0, nil, apperrors.New(apperrors.ErrInvalidInput, "hub_id, user_id, device_ids는 필수입니다")
+	}
+	hub, err := s.getHub(ctx, hubID)
+	if err != nil {
+		return 0, nil, err
+	}
+	if hub.UserID != userID {
+		return 0, nil, apperrors.New(apperrors.ErrForbidden, "허브 소유자만 디바이스를 배정할 수 있습니다")
+	}
+
+	attached := 0
+	var failed []string
+	for _, id := range deviceIDs {
+		device, err := s.getOwnedDevice(ctx, id, userID)
+		if err != nil || device.ID == hub.ID || device.Kind.tier() >= hub.Kind.tier() {
+			failed = append(failed, id)
+			continue
+		}
+		previousHubID := device.ParentHubID
+		if err := s.deviceRepo.SetParentHub(ctx, device.ID, hub.ID); err != nil {
+			failed = append(failed, id)
+			continue
+		}
+		_ = s.eventRepo.LogEvent(ctx, &DeviceEvent{
+			ID:        uuid.New().String(),
+			DeviceID:  device.ID,
+			EventType: "hub_attached",
+			Payload: map[string]interface{}{
+				"hub_id":          hub.ID,
+				"previous_hub_id": previousHubID,
+			},
+			CreatedAt: time.Now().UTC(),
+		})
+		attached++
+	}
+
+	s.logger.Info("허브 디바이스 배정",
+		zap.String("hub_id", hubID),
+		zap.Int("attached", attached),
+		zap.Int("failed", len(failed)),
+	)
+	return attached, failed, nil
+}
+
+// DetachFromHub는 디바이스를 상위 허브에서 분리합니다.
+func (s *DeviceService) DetachFromHub(ctx context.Context, deviceID, userID string) error {
+	if deviceID == "" || userID == "" {
+		return apperrors.New(apperrors.ErrInvalidInput, "device_id와 user_id는 필수입니다")
+	}
+	device, err := s.getOwnedDevice(ctx, deviceID, userID)
+	if err != nil {
+		return err
+	}
+	if device.ParentHubID == "" {
+		return nil
+	}
+	if err := s.deviceRepo.SetParentHub(ctx, deviceID, ""); err != nil {
+		return apperrors.New(apperrors.ErrInternal, "허브 분리에 실패했습니다")
+	}
+	_ = s.eventRepo.LogEvent(ctx, &DeviceEvent{
+		ID:        uuid.New().String(),
+		DeviceID:  deviceID,
+		EventType: "hub_detached",
+		Payload: map[string]interface{}{
+			"hub_id": device.ParentHubID,
+		},
+		CreatedAt: time.Now().UTC(),
+	})
+	return nil
+}
+
+// detachTopology는 소유자 변경·등록 해제 시 디바이스를 허브 구성에서 분리합니다.
+// 허브라면 하위 디바이스도 함께 분리합니다.
+func (s *DeviceService) detachTopology(ctx context.Context, device *Device) {
+	if device.ParentHubID != "" {
+		if err := s.deviceRepo.SetParentHub(ctx, device.ID, ""); err != nil {
+			s.logger.Warn("허브 분리 실패", zap.String("device_id", device.ID), zap.Error(err))
+		}
+	}
+	if !device.Kind.IsHub() {
+		return
+	}
+	children, err := s.deviceRepo.ListByParentHub(ctx, device.ID)
+	if err != nil {
+		s.logger.Warn("하위 디바이스 조회 실패", zap.String("hub_id", device.ID), zap.Error(err))
+		return
+	}
+	for _, child := range children {
+		if err := s.deviceRepo.SetParentHub(ctx, child.ID, ""); err != nil {
+			s.logger.Warn("하위 디바이스 분리 실패", zap.String("device_id", child.ID), zap.Error(err))
+		}
+	}
+}
+
+// ReportHubRelay는 허브가 중계한 하위 디바이스 상태를 반영합니다.
+//
+// 허브와 같은 소유자의 디바이스만 반영되며(타인 디바이스 위장 방지),
+// 각 디바이스의 마지막 중계 허브가 갱신됩니다. 허브에 대기 중인 명령 수를 함께 반환합니다.
+func (s *DeviceService) ReportHubRelay(ctx context.Context, hubID string, updates []RelayedStatus) (int, []string, int, error) {
+	if hubID == "" {
+		return 0, nil, 0, apperrors.New(apperrors.ErrInvalidInput, "hub_id는 필수입니다")
+	}
+	hub, err := s.getHub(ctx, hubID)
+	if err != nil {
+		return 0, nil, 0, err
+	}
+
+	now := time.Now().UTC()
+	if err := s.deviceRepo.UpdateStatus(ctx, hub.ID, StatusOnline, hub.BatteryPercent, now); err != nil {
+		return 0, nil, 0, apperrors.New(apperrors.ErrInternal, "허브 상태 업데이트에 실패했습니다")
+	}
+
+	accepted := 0
+	var rejected []string
+	for _, u := range updates {
+		device, err := s.deviceRepo.GetByID(ctx, u.DeviceID)
+		if err != nil || device == nil || device.UserID != hub.UserID || !device.DeregisteredAt.IsZero() || device.ID == hub.ID {
+			rejected = append(rejected, u.DeviceID)
+			continue
+		}
+		observedAt := u.ObservedAt
+		if observedAt.IsZero() || observedAt.After(now) {
+			observedAt = now
+		}
+		status := u.Status
+		if status == "" {
+			status = StatusOnline
+		}
+		if err := s.deviceRepo.UpdateStatus(ctx, device.ID, status, u.BatteryPercent, observedAt); err != nil {
+			rejected = append(rejected, u.DeviceID)
+			continue
+		}
+		if err := s.deviceRepo.RecordRelay(ctx, device.ID, hub.ID, observedAt); err != nil {
+			s.logger.Warn("중계 허브 기록 실패", zap.String("device_id", device.ID), zap.Error(err))
+		}
+		accepted++
+	}
+
+	pending, err := s.pendingCommandsFor(ctx, hub.ID)
+	if err != nil {
+		s.logger.Warn("허브 대기 명령 조회 실패", zap.String("hub_id", hub.ID), zap.Error(err))
+	}
+	return accepted, rejected, len(pending), nil
+}
+
+// ListHubDevices는 허브에 배정된 하위 디바이스 목록을 반환합니다.
+func (s *DeviceService) ListHubDevices(ctx context.Context, hubID string) ([]*Device, error) {
+	if hubID == "" {
+		return nil, apperrors.New(apperrors.ErrInvalidInput, "hub_id는 필수입니다")
+	}
+	if _, err := s.getHub(ctx, hubID); err != nil {
+		return nil, err
+	}
+	return s.deviceRepo.ListByParentHub(ctx, hubID)
+}
+
+// GetHubStatus는 허브 서브트리의 디바이스 상태를 집계합니다.
+func (s *DeviceService) GetHubStatus(ctx context.Context, hubID string) (*HubStatus, error) {
+	if hubID == "" {
+		return nil, apperrors.New(apperrors.ErrInvalidInput, "hub_id는 필수입니다")
+	}
+	hub, err := s.getHub(ctx, hubID)
+	if err != nil {
+		return nil, err
+	}
+	return s.aggregateHub(ctx, hub, time.Now().UTC())
+}
+
+func (s *DeviceService) aggregateHub(ctx context.Context, hub *Device, now time.Time) (*HubStatus, error) {
+	children, err := s.deviceRepo.ListByParentHub(ctx, hub.ID)
+	if err != nil {
+		return nil, apperrors.New(apperrors.ErrInternal, "하위 디바이스 조회에 실패했습니다")
+	}
+
+	st := &HubStatus{Hub: hub}
+	for _, child := range children {
+		if child.Kind.IsHub() {
+			sub, err := s.aggregateHub(ctx, child, now)
+			if err != nil {
+				return nil, err
+			}
+			st.ChildHubs = append(st.ChildHubs, sub)
+			st.TotalDevices += sub.TotalDevices
+			st.Online += sub.Online
+			st.Offline += sub.Offline
+			st.Measuring += sub.Measuring
+			st.Error += sub.Error
+			st.LowBattery += sub.LowBattery
+			continue
+		}
+
+		st.TotalDevices++
+		switch effectiveStatus(child, now) {
+		case StatusOnline, StatusUpdating:
+			st.Online++
+		case StatusMeasuring:
+			st.Online++
+			st.Measuring++
+		case StatusError:
+			st.Error++
+		default:
+			st.Offline++
+		}
+		if child.BatteryPercent < lowBatteryPercent {
+			st.LowBattery++
+		}
+	}
+
+	pending, err := s.pendingCommandsFor(ctx, hub.ID)
+	if err == nil {
+		st.PendingCommands = len(pending)
+	}
+	return st, nil
+}
+
+// SendCommand는 디바이스 명령을 대기열에 추가합니다.
+// 리더가 허브 뒤에 있으면 명령은 해당 허브를 경유하도록 지정됩니다.
+func (s *DeviceService) SendCommand(ctx context.Context, deviceID, userID string, cmdType CommandType, payload []byte) (*QueuedCommand, error) {
+	if deviceID == "" || userID == "" || cmdType == "" {
+		return nil, apperrors.New(apperrors.ErrInvalidInput, "device_id, user_id, command_type은 필수입니다")
+	}
+	device, err := s.getOwnedDevice(ctx, deviceID, userID)
+	if err != nil {
+		return nil, err
+	}
+	cmd, err := s.enqueueCommand(ctx, device, cmdType, payload, userID, "")
+	if err != nil {
+		return nil, apperrors.New(apperrors.ErrInternal, "명령 등록에 실패했습니다")
+	}
+	return cmd, nil
+}
+
+// routeFor는 명령을 경유시킬 허브를 결정합니다.
+// 최근 중계한 허브를 우선하고, 없으면 배정된 상위 허브, 그것도 없으면 직접 전달합니다.
+func routeFor(d *Device, now time.Time) string {
+	if d.LastRelayHubID != "" && now.Sub(d.LastRelayAt) <= relayFreshness {
+		return d.LastRelayHubID
+	}
+	return d.ParentHubID
+}
+
+// enqueueCommand는 경로를 결정해 명령을 대기열에 추가하고 이벤트를 기록합니다.
+// bulkID는 일괄 명령으로 생성된 경우에만 지정합니다.
+func (s *DeviceService) enqueueCommand(ctx context.Context, device *Device, cmdType CommandType, payload []byte, issuedBy, bulkID string) (*QueuedCommand, error) {
+	now := time.Now().UTC()
+	cmd := &QueuedCommand{
+		ID:          uuid.New().String(),
+		DeviceID:    device.ID,
+		CommandType: cmdType,
+		Payload:     payload,
+		Status:      CommandQueued,
+		RouteHubID:  routeFor(device, now),
+		BulkID:      bulkID,
+		IssuedBy:    issuedBy,
+		CreatedAt:   now,
+	}
+	if err := s.commandRepo.Enqueue(ctx, cmd); err != nil {
+		return nil, err
+	}
+
+	_ = s.eventRepo.LogEvent(ctx, &DeviceEvent{
+		ID:        uuid.New().String(),
+		DeviceID:  device.ID,
+		EventType: "command_queued",
+		Payload: map[string]interface{}{
+			"command_id":   cmd.ID,
+			"command_type": string(cmdType),
+			"route_hub_id": cmd.RouteHubID,
+		},
+		CreatedAt: now,
+	})
+	return cmd, nil
+}
+
+// pendingCommandsFor는 디바이스가 받아야 할 미확인 명령을 상태 변경 없이 모읍니다.
+// 허브라면 자신과 하위 허브를 경유하는 명령을 포함합니다.
+func (s *DeviceService) pendingCommandsFor(ctx context.Context, deviceID string) ([]*QueuedCommand, error) {
+	cmds, err := s.commandRepo.ListPending(ctx, deviceID)
+	if err != nil {
+		return nil, err
+	}
+
+	device, err := s.deviceRepo.GetByID(ctx, deviceID)
+	if err != nil || device == nil || !device.Kind.IsHub() {
+		return cmds, nil
+	}
+
+	routes := []string{device.ID}
+	if device.Kind == KindCloudGateway {
+		children, err := s.deviceRepo.ListByParentHub(ctx, device.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, child := range children {
+			if child.Kind.IsHub() {
+				routes = append(routes, child.ID)
+			}
+		}
+	}
+
+	seen := make(map[string]bool, len(cmds))
+	for _, c := range cmds {
+		seen[c.ID] = true
+	}
+	for _, hubID := range routes {
+		routed, err := s.commandRepo.ListPendingByRoute(ctx, hubID)
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range routed {
+			if !seen[c.ID] {
+				seen[c.ID] = true
+				cmds = append(cmds, c)
+			}
+		}
+	}
+	return cmds, nil
+}
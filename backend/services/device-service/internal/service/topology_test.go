@@ -0,0 +1,183 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// =============================================================================
+// 허브 토폴로지 테스트
+// =============================================================================
+
+func TestRegisterHub_계층_검증(t *testing.T) {
+	svc, deviceRepo, _ := newTestDeviceService(10)
+	ctx := context.Background()
+
+	gateway, _, err := svc.RegisterHub(ctx, "GW-01", "GW0001", "1.0.0", "user-1", KindCloudGateway, "")
+	if err != nil {
+		t.Fatalf("게이트웨이 등록 실패: %v", err)
+	}
+	hub, _, err := svc.RegisterHub(ctx, "HUB-01", "HB0001", "1.0.0", "user-1", KindWifiHub, gateway.ID)
+	if err != nil {
+		t.Fatalf("허브 등록 실패: %v", err)
+	}
+	if deviceRepo.devices[hub.ID].ParentHubID != gateway.ID {
+		t.Errorf("허브가 게이트웨이 아래에 배치되어야 합니다: got %q", deviceRepo.devices[hub.ID].ParentHubID)
+	}
+
+	if _, _, err := svc.RegisterHub(ctx, "RD-01", "RD0001", "1.0.0", "user-1", KindReader, ""); err == nil {
+		t.Fatal("리더는 허브로 등록할 수 없어야 합니다")
+	}
+	if _, _, err := svc.RegisterHub(ctx, "GW-02", "GW0002", "1.0.0", "user-1", KindCloudGateway, hub.ID); err == nil {
+		t.Fatal("게이트웨이는 Wi-Fi 허브 아래에 둘 수 없어야 합니다")
+	}
+	if _, _, err := svc.RegisterHub(ctx, "HUB-02", "HB0002", "1.0.0", "user-2", KindWifiHub, gateway.ID); err == nil {
+		t.Fatal("다른 사용자의 게이트웨이 아래에 허브를 둘 수 없어야 합니다")
+	}
+}
+
+func TestAttachToHub_소유자_일치만_배정(t *testing.T) {
+	svc, deviceRepo, _ := newTestDeviceService(10)
+	ctx := context.Background()
+
+	hub, _, _ := svc.RegisterHub(ctx, "HUB-01", "HB0001", "1.0.0", "user-1", KindWifiHub, "")
+	mine, _, _ := svc.RegisterDevice(ctx, "BLE-01", "SN-0001", "1.0.0", "user-1")
+	other, _, _ := svc.RegisterDevice(ctx, "BLE-02", "SN-0002", "1.0.0", "user-2")
+
+	attached, failed, err := svc.AttachToHub(ctx, hub.ID, "user-1", []string{mine.ID, other.ID})
+	if err != nil {
+		t.Fatalf("배정 실패: %v", err)
+	}
+	if attached != 1 || len(failed) != 1 || failed[0] != other.ID {
+		t.Errorf("본인 디바이스만 배정되어야 합니다: attached=%d, failed=%v", attached, failed)
+	}
+	if deviceRepo.devices[mine.ID].ParentHubID != hub.ID {
+		t.Error("리더의 상위 허브가 설정되어야 합니다")
+	}
+
+	if err := svc.DetachFromHub(ctx, mine.ID, "user-1"); err != nil {
+		t.Fatalf("분리 실패: %v", err)
+	}
+	readers, _ := svc.ListHubDevices(ctx, hub.ID)
+	if len(readers) != 0 {
+		t.Errorf("분리 후 하위 디바이스가 없어야 합니다: got %d", len(readers))
+	}
+}
+
+func TestReportHubRelay_타인_디바이스_거부(t *testing.T) {
+	svc, deviceRepo, _ := newTestDeviceService(10)
+	ctx := context.Background()
+
+	hub, _, _ := svc.RegisterHub(ctx, "HUB-01", "HB0001", "1.0.0", "user-1", KindWifiHub, "")
+	mine, _, _ := svc.RegisterDevice(ctx, "BLE-01", "SN-0001", "1.0.0", "user-1")
+	other, _, _ := svc.RegisterDevice(ctx, "BLE-02", "SN-0002", "1.0.0", "user-2")
+
+	accepted, rejected, _, err := svc.ReportHubRelay(ctx, hub.ID, []RelayedStatus{
+		{DeviceID: mine.ID, Status: StatusMeasuring, BatteryPercent: 55},
+		{DeviceID: other.ID, Status: StatusError, BatteryPercent: 10},
+	})
+	if err != nil {
+		t.Fatalf("중계 보고 실패: %v", err)
+	}
+	if accepted != 1 || len(rejected) != 1 || rejected[0] != other.ID {
+		t.Errorf("본인 디바이스만 반영되어야 합니다: accepted=%d, rejected=%v", accepted, rejected)
+	}
+
+	d := deviceRepo.devices[mine.ID]
+	if d.Status != StatusMeasuring || d.BatteryPercent != 55 || d.LastRelayHubID != hub.ID {
+		t.Errorf("중계 상태가 반영되어야 합니다: %+v", d)
+	}
+	if deviceRepo.devices[other.ID].Status == StatusError {
+		t.Error("타인 디바이스 상태는 변경되면 안 됩니다")
+	}
+}
+
+func TestGetHubStatus_하위_허브_포함_집계(t *testing.T) {
+	svc, deviceRepo, _ := newTestDeviceService(10)
+	ctx := context.Background()
+
+	gateway, _, _ := svc.RegisterHub(ctx, "GW-01", "GW0001", "1.0.0", "user-1", KindCloudGateway, "")
+	hub, _, _ := svc.RegisterHub(ctx, "HUB-01", "HB0001", "1.0.0", "user-1", KindWifiHub, gateway.ID)
+	r1, _, _ := svc.RegisterDevice(ctx, "BLE-01", "SN-0001", "1.0.0", "user-1")
+	r2, _, _ := svc.RegisterDevice(ctx, "BLE-02", "SN-0002", "1.0.0", "user-1")
+	r3, _, _ := svc.RegisterDevice(ctx, "BLE-03", "SN-0003", "1.0.0", "user-1")
+	svc.AttachToHub(ctx, hub.ID, "user-1", []string{r1.ID, r2.ID})
+	svc.AttachToHub(ctx, gateway.ID, "user-1", []string{r3.ID})
+
+	deviceRepo.devices[r1.ID].Status = StatusMeasuring
+	deviceRepo.devices[r2.ID].BatteryPercent = 15
+	// 오래 소식이 없는 리더는 저장된 상태가 online이어도 offline으로 집계
+	deviceRepo.devices[r3.ID].LastSeen = time.Now().UTC().Add(-time.Hour)
+
+	st, err := svc.GetHubStatus(ctx, gateway.ID)
+	if err != nil {
+		t.Fatalf("허브 상태 조회 실패: %v", err)
+	}
+	if st.TotalDevices != 3 || st.Online != 2 || st.Offline != 1 || st.Measuring != 1 || st.LowBattery != 1 {
+		t.Errorf("집계 불일치: total=%d online=%d offline=%d measuring=%d low=%d",
+			st.TotalDevices, st.Online, st.Offline, st.Measuring, st.LowBattery)
+	}
+	if len(st.ChildHubs) != 1 || st.ChildHubs[0].TotalDevices != 2 {
+		t.Errorf("하위 허브 집계가 포함되어야 합니다: %+v", st.ChildHubs)
+	}
+}
+
+func TestSendCommand_허브_경유_전달(t *testing.T) {
+	svc, _, _ := newTestDeviceService(10)
+	ctx := context.Background()
+
+	hub, _, _ := svc.RegisterHub(ctx, "HUB-01", "HB0001", "1.0.0", "user-1", KindWifiHub, "")
+	direct, _, _ := svc.RegisterDevice(ctx, "BLE-01", "SN-0001", "1.0.0", "user-1")
+	behind, _, _ := svc.RegisterDevice(ctx, "BLE-02", "SN-0002", "1.0.0", "user-1")
+	svc.ReportHubRelay(ctx, hub.ID, []RelayedStatus{{DeviceID: behind.ID, Status: StatusOnline, BatteryPercent: 80}})
+
+	cmd, err := svc.SendCommand(ctx, behind.ID, "user-1", CommandStartMeasurement, nil)
+	if err != nil {
+		t.Fatalf("명령 전송 실패: %v", err)
+	}
+	if cmd.RouteHubID != hub.ID {
+		t.Errorf("최근 중계 허브를 경유해야 합니다: got %q", cmd.RouteHubID)
+	}
+	directCmd, _ := svc.SendCommand(ctx, direct.ID, "user-1", CommandReboot, nil)
+	if directCmd.RouteHubID != "" {
+		t.Errorf("허브에 속하지 않은 리더는 직접 전달되어야 합니다: got %q", directCmd.RouteHubID)
+	}
+
+	pending, err := svc.ListPendingCommands(ctx, hub.ID)
+	if err != nil || len(pending) != 1 || pending[0].DeviceID != behind.ID {
+		t.Fatalf("허브가 하위 리더 명령을 수신해야 합니다: got %d, err=%v", len(pending), err)
+	}
+	if err := svc.AcknowledgeCommand(ctx, behind.ID, cmd.ID, true, ""); err != nil {
+		t.Fatalf("리더 명령 확인 실패: %v", err)
+	}
+
+	if _, err := svc.SendCommand(ctx, behind.ID, "user-2", CommandReboot, nil); err == nil {
+		t.Fatal("소유자가 아니면 명령을 보낼 수 없어야 합니다")
+	}
+}
+
+func TestTransfer_허브_구성_분리(t *testing.T) {
+	svc, deviceRepo, _ := newTestDeviceService(10)
+	ctx := context.Background()
+
+	hub, _, _ := svc.RegisterHub(ctx, "HUB-01", "HB0001", "1.0.0", "user-1", KindWifiHub, "")
+	reader, _, _ := svc.RegisterDevice(ctx, "BLE-01", "SN-0001", "1.0.0", "user-1")
+	svc.AttachToHub(ctx, hub.ID, "user-1", []string{reader.ID})
+
+	transfer, _ := svc.InitiateTransfer(ctx, reader.ID, "user-1", "user-2")
+	svc.ConfirmTransfer(ctx, transfer.ID, "user-1")
+	svc.ConfirmTransfer(ctx, transfer.ID, "user-2")
+
+	if deviceRepo.devices[reader.ID].ParentHubID != "" {
+		t.Error("소유자가 바뀐 리더는 기존 허브에서 분리되어야 합니다")
+	}
+
+	// 허브 등록 해제 시 하위 리더도 분리
+	other, _, _ := svc.RegisterDevice(ctx, "BLE-02", "SN-0002", "1.0.0", "user-1")
+	svc.AttachToHub(ctx, hub.ID, "user-1", []string{other.ID})
+	svc.DeregisterDevice(ctx, hub.ID, "user-1", "replaced")
+	if deviceRepo.devices[other.ID].ParentHubID != "" {
+		t.Error("허브 등록 해제 시 하위 리더가 분리되어야 합니다")
+	}
+}
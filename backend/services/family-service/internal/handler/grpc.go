@@ -142,6 +142,25 @@ func (h *FamilyHandler) ListFamilyMembers(ctx context.Context, req *v1.ListFamil
 	return &v1.ListFamilyMembersResponse{Members: pbMembers}, nil
 }
 
+// ListGuardians는 긴급 알림 대상 보호자 조회 RPC입니다.
+func (h *FamilyHandler) ListGuardians(ctx context.Context, req *v1.ListGuardiansRequest) (*v1.ListGuardiansResponse, error) {
+	if req == nil || req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id는 필수입니다")
+	}
+
+	guardians, err := h.svc.ListGuardians(ctx, req.UserId)
+	if err != nil {
+		return nil, toGRPC(err)
+	}
+
+	var pbGuardians []*v1.FamilyMember
+	for _, m := range guardians {
+		pbGuardians = append(pbGuardians, memberToProto(m))
+	}
+
+	return &v1.ListGuardiansResponse{Guardians: pbGuardians}, nil
+}
+
 // SetSharingPreferences는 공유 설정 변경 RPC입니다.
 func (h *FamilyHandler) SetSharingPreferences(ctx context.Context, req *v1.SetSharingPreferencesRequest) (*v1.SharingPreferences, error) {
 	if req == nil || req.UserId == "" || req.GroupId == "" {
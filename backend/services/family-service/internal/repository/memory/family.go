@@ -69,6 +69,18 @@ func (r *FamilyMemberRepository) FindByGroupID(_ context.Context, groupID string
 	return members, nil
 }
 
+func (r *FamilyMemberRepository) FindByUserID(_ context.Context, userID string) ([]*service.FamilyMember, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var members []*service.FamilyMember
+	for _, m := range r.store {
+		if m.UserID == userID {
+			members = append(members, m)
+		}
+	}
+	return members, nil
+}
+
 func (r *FamilyMemberRepository) FindByUserIDAndGroupID(_ context.Context, userID, groupID string) (*service.FamilyMember, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
@@ -138,6 +138,32 @@ func (r *MemberRepository) FindByGroupID(ctx context.Context, groupID string) ([
 	return list, rows.Err()
 }
 
+// FindByUserID는 사용자의 모든 그룹 멤버십을 조회합니다.
+func (r *MemberRepository) FindByUserID(ctx context.Context, userID string) ([]*service.FamilyMember, error) {
+	const q = `SELECT user_id, group_id, COALESCE(display_name,''), COALESCE(email,''), role::text, sharing_enabled, joined_at
+		FROM family_members WHERE user_id = $1 ORDER BY joined_at ASC`
+	rows, err := r.pool.Query(ctx, q, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []*service.FamilyMember
+	for rows.Next() {
+		var m service.FamilyMember
+		var roleStr string
+		if err := rows.Scan(&m.UserID, &m.GroupID, &m.DisplayName, &m.Email, &roleStr, &m.SharingEnabled, &m.JoinedAt); err != nil {
+			return nil, err
+		}
+		m.Role = dbToRole[roleStr]
+		if m.Role == 0 {
+			m.Role = service.RoleMember
+		}
+		list = append(list, &m)
+	}
+	return list, rows.Err()
+}
+
 // FindByUserIDAndGroupID는 사용자와 그룹으로 멤버를 조회합니다.
 func (r *MemberRepository) FindByUserIDAndGroupID(ctx context.Context, userID, groupID string) (*service.FamilyMember, error) {
 	const q = `SELECT user_id, group_id, COALESCE(display_name,''), COALESCE(email,''), role::text, sharing_enabled, joined_at
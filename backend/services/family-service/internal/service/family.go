@@ -105,6 +105,8 @@ type FamilyGroupRepository interface {
 type FamilyMemberRepository interface {
 	Save(ctx context.Context, m *FamilyMember) error
 	FindByGroupID(ctx context.Context, groupID string) ([]*FamilyMember, error)
+	// FindByUserID는 사용자의 모든 그룹 멤버십을 반환합니다.
+	FindByUserID(ctx context.Context, userID string) ([]*FamilyMember, error)
 	FindByUserIDAndGroupID(ctx context.Context, userID, groupID string) (*FamilyMember, error)
 	Remove(ctx context.Context, userID, groupID string) error
 	CountByGroupID(ctx context.Context, groupID string) (int, error)
@@ -355,6 +357,37 @@ func (s *FamilyService) ListFamilyMembers(ctx context.Context, groupID string) (
 	return s.memberRepo.FindByGroupID(ctx, groupID)
 }
 
+// ListGuardians는 사용자가 속한 모든 그룹의 보호자와 그룹 관리자를 반환합니다 (본인 제외, 중복 제거).
+// 긴급 에스컬레이션 대상이므로 공유 설정과 관계없이 반환합니다.
+func (s *FamilyService) ListGuardians(ctx context.Context, userID string) ([]*FamilyMember, error) {
+	if userID == "" {
+		return nil, apperrors.New(apperrors.ErrInvalidInput, "입력값이 올바르지 않습니다")
+	}
+	memberships, err := s.memberRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("멤버십 조회 실패: %w", err)
+	}
+
+	seen := make(map[string]bool)
+	var guardians []*FamilyMember
+	for _, ms := range memberships {
+		members, err := s.memberRepo.FindByGroupID(ctx, ms.GroupID)
+		if err != nil {
+			return nil, fmt.Errorf("멤버 조회 실패: %w", err)
+		}
+		for _, m := range members {
+			if m.UserID == userID || seen[m.UserID] {
+				continue
+			}
+			if m.Role == RoleGuardian || m.Role == RoleOwner {
+				seen[m.UserID] = true
+				guardians = append(guardians, m)
+			}
+		}
+	}
+	return guardians, nil
+}
+
 // SetSharingPreferences는 건강 데이터 공유 설정을 변경합니다.
 func (s *FamilyService) SetSharingPreferences(ctx context.Context, pref *SharingPreferences) (*SharingPreferences, error) {
 	if pref == nil || pref.UserID == "" || pref.GroupID == "" {
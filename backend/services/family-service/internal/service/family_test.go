@@ -206,6 +206,52 @@ func TestListFamilyMembers(t *testing.T) {
 	}
 }
 
+func TestListGuardians(t *testing.T) {
+	svc := setupTestService()
+	ctx := context.Background()
+
+	// elder는 두 그룹에 속함: 그룹1(관리자 owner-g1, 보호자 guard-1), 그룹2(관리자 owner-g2, 보호자 guard-1 중복)
+	g1, _ := svc.CreateFamilyGroup(ctx, "owner-g1", "최씨 가족", "")
+	for _, m := range []struct {
+		userID string
+		role   service.FamilyRole
+	}{{"elder", service.RoleElderly}, {"guard-1", service.RoleGuardian}, {"kid", service.RoleChild}} {
+		inv, _ := svc.InviteMember(ctx, g1.ID, "owner-g1", m.userID+"@test.com", m.role, "")
+		svc.RespondToInvitation(ctx, inv.ID, m.userID, true)
+	}
+	g2, _ := svc.CreateFamilyGroup(ctx, "owner-g2", "요양 모임", "")
+	for _, userID := range []string{"elder", "guard-1"} {
+		role := service.RoleMember
+		if userID == "guard-1" {
+			role = service.RoleGuardian
+		}
+		inv, _ := svc.InviteMember(ctx, g2.ID, "owner-g2", userID+"@test.com", role, "")
+		svc.RespondToInvitation(ctx, inv.ID, userID, true)
+	}
+
+	guardians, err := svc.ListGuardians(ctx, "elder")
+	if err != nil {
+		t.Fatalf("보호자 조회 실패: %v", err)
+	}
+	got := make(map[string]bool)
+	for _, g := range guardians {
+		got[g.UserID] = true
+	}
+	if len(guardians) != 3 || !got["owner-g1"] || !got["owner-g2"] || !got["guard-1"] {
+		t.Fatalf("보호자 불일치: got %v, want owner-g1, owner-g2, guard-1", got)
+	}
+
+	// 그룹 관리자 본인은 자신을 제외한 보호자만
+	guardians, _ = svc.ListGuardians(ctx, "owner-g1")
+	if len(guardians) != 1 || guardians[0].UserID != "guard-1" {
+		t.Fatalf("관리자 기준 보호자 불일치: %+v", guardians)
+	}
+
+	if _, err := svc.ListGuardians(ctx, ""); err == nil {
+		t.Fatal("빈 user_id는 오류여야 함")
+	}
+}
+
 func TestSetSharingPreferences(t *testing.T) {
 	svc := setupTestService()
 	ctx := context.Background()
@@ -53,6 +53,7 @@ type ServiceClients struct {
 	Notification v1.NotificationServiceClient
 	Translation  v1.TranslationServiceClient
 	Telemedicine v1.TelemedicineServiceClient
+	Environment  v1.EnvironmentServiceClient
 }
 
 func main() {
@@ -83,7 +84,7 @@ func main() {
 		clients.AiInference, clients.Cartridge, clients.Calibration, clients.Coaching,
 		clients.Reservation, clients.Admin, clients.Family, clients.HealthRecord,
 		clients.Prescription, clients.Community, clients.Video, clients.Notification,
-		clients.Translation, clients.Telemedicine, cfg.JWT.Secret)
+		clients.Translation, clients.Telemedicine, clients.Environment, cfg.JWT.Secret)
 
 	// 라우터 설정
 	mux := restHandler.SetupRoutes()
@@ -150,6 +151,7 @@ func connectServices(opts []grpc.DialOption) ([]*grpc.ClientConn, *ServiceClient
 		{"prescription", getEnv("PRESCRIPTION_SERVICE_ADDR", "localhost:50069")},
 		{"translation", getEnv("TRANSLATION_SERVICE_ADDR", "localhost:50070")},
 		{"video", getEnv("VIDEO_SERVICE_ADDR", "localhost:50071")},
+		{"analytics", getEnv("ANALYTICS_SERVICE_ADDR", "localhost:50074")},
 	}
 
 	connMap := make(map[string]*grpc.ClientConn)
@@ -230,6 +232,9 @@ func connectServices(opts []grpc.DialOption) ([]*grpc.ClientConn, *ServiceClient
 	if c, ok := connMap["telemedicine"]; ok {
 		clients.Telemedicine = v1.NewTelemedicineServiceClient(c)
 	}
+	if c, ok := connMap["analytics"]; ok {
+		clients.Environment = v1.NewEnvironmentServiceClient(c)
+	}
 
 	connected := 0
 	for _, c := range conns {
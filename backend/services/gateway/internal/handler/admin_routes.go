@@ -3,8 +3,10 @@ package handler
 import (
 	"net/http"
 	"strconv"
+	"time"
 
 	v1 "github.com/manpasik/backend/shared/gen/go/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 // registerAdminRoutes는 관리자 관련 REST 엔드포인트를 등록합니다.
@@ -43,6 +45,26 @@ func (h *RestHandler) registerAdminRoutes(mux *http.ServeMux) {
 	mux.HandleFunc("GET /api/v1/admin/metrics", h.handleGetAdminMetrics)
 	mux.HandleFunc("GET /api/v1/admin/hierarchy", h.handleGetAdminHierarchy)
 	mux.HandleFunc("GET /api/v1/admin/compliance", h.handleGetComplianceReport)
+
+	// AI usage & cost
+	mux.HandleFunc("GET /api/v1/admin/ai/usage", h.handleGetLLMUsageReport)
+
+	// AI model registry (candidate upload, shadow evaluation, promotion)
+	mux.HandleFunc("POST /api/v1/admin/ai/models", h.handleRegisterModelVersion)
+	mux.HandleFunc("POST /api/v1/admin/ai/models/promote", h.handlePromoteModelVersion)
+
+	// Fingerprint reference library curation
+	mux.HandleFunc("GET /api/v1/admin/fingerprints", h.handleListFingerprintReferences)
+	mux.HandleFunc("POST /api/v1/admin/fingerprints", h.handleAddFingerprintReference)
+	mux.HandleFunc("POST /api/v1/admin/fingerprints/{referenceId}/review", h.handleReviewFingerprintReference)
+
+	// Notification templates (per-language variants with version history)
+	mux.HandleFunc("GET /api/v1/admin/notification-templates", h.handleListNotificationTemplates)
+	mux.HandleFunc("POST /api/v1/admin/notification-templates/preview", h.handlePreviewNotificationTemplate)
+	mux.HandleFunc("GET /api/v1/admin/notification-templates/{key}/{language}/versions", h.handleListNotificationTemplateVersions)
+	mux.HandleFunc("PUT /api/v1/admin/notification-templates/{key}/{language}", h.handleSaveNotificationTemplate)
+	mux.HandleFunc("DELETE /api/v1/admin/notification-templates/{key}/{language}", h.handleDeleteNotificationTemplate)
+	mux.HandleFunc("POST /api/v1/admin/notification-templates/{key}/{language}/rollback", h.handleRollbackNotificationTemplate)
 }
 
 // ── Admin CRUD ──
@@ -524,3 +546,369 @@ func (h *RestHandler) handleGetComplianceReport(w http.ResponseWriter, r *http.R
 	}
 	writeProtoJSON(w, http.StatusOK, resp)
 }
+
+// ── AI Usage & Cost ──
+
+// handleGetLLMUsageReport는 LLM 사용량·예상 비용 보고서를 반환합니다.
+// start_date·end_date는 YYYY-MM-DD(UTC)이며 end_date 당일을 포함합니다. 생략하면 이번 달 1일부터 현재까지입니다.
+func (h *RestHandler) handleGetLLMUsageReport(w http.ResponseWriter, r *http.Request) {
+	if h.aiInference == nil {
+		writeError(w, http.StatusServiceUnavailable, "ai inference service unavailable")
+		return
+	}
+	req := &v1.GetLLMUsageReportRequest{UserId: r.URL.Query().Get("user_id")}
+	if v := r.URL.Query().Get("start_date"); v != "" {
+		start, err := time.Parse("2006-01-02", v)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "start_date must be YYYY-MM-DD")
+			return
+		}
+		req.StartTime = timestamppb.New(start)
+	}
+	if v := r.URL.Query().Get("end_date"); v != "" {
+		end, err := time.Parse("2006-01-02", v)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "end_date must be YYYY-MM-DD")
+			return
+		}
+		req.EndTime = timestamppb.New(end.AddDate(0, 0, 1))
+	}
+	resp, err := h.aiInference.GetLLMUsageReport(r.Context(), req)
+	if err != nil {
+		writeError(w, grpcHTTPStatus(err), err.Error())
+		return
+	}
+	writeProtoJSON(w, http.StatusOK, resp)
+}
+
+// handleRegisterModelVersion은 후보 모델 버전을 등록합니다. artifact는 base64이며,
+// status를 생략하면 섀도 후보가 되어 운영 모델과 함께 실행·비교됩니다.
+func (h *RestHandler) handleRegisterModelVersion(w http.ResponseWriter, r *http.Request) {
+	if h.aiInference == nil {
+		writeError(w, http.StatusServiceUnavailable, "ai inference service unavailable")
+		return
+	}
+	var body struct {
+		ModelType         int32              `json:"model_type"`
+		Name              string             `json:"name"`
+		Version           string             `json:"version"`
+		Description       string             `json:"description"`
+		Kind              string             `json:"kind"`
+		Artifact          []byte             `json:"artifact"`
+		Metrics           map[string]float64 `json:"metrics"`
+		TrainingDataStart *time.Time         `json:"training_data_start"`
+		TrainingDataEnd   *time.Time         `json:"training_data_end"`
+		TrainedAt         *time.Time         `json:"trained_at"`
+		Status            string             `json:"status"`
+	}
+	if err := readJSON(r, &body); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	req := &v1.RegisterModelVersionRequest{
+		ModelType:   v1.AiModelType(body.ModelType),
+		Name:        body.Name,
+		Version:     body.Version,
+		Description: body.Description,
+		Kind:        body.Kind,
+		Artifact:    body.Artifact,
+		Metrics:     body.Metrics,
+		Status:      body.Status,
+	}
+	if body.TrainingDataStart != nil {
+		req.TrainingDataStart = timestamppb.New(*body.TrainingDataStart)
+	}
+	if body.TrainingDataEnd != nil {
+		req.TrainingDataEnd = timestamppb.New(*body.TrainingDataEnd)
+	}
+	if body.TrainedAt != nil {
+		req.TrainedAt = timestamppb.New(*body.TrainedAt)
+	}
+	resp, err := h.aiInference.RegisterModelVersion(r.Context(), req)
+	if err != nil {
+		writeError(w, grpcHTTPStatus(err), err.Error())
+		return
+	}
+	writeProtoJSON(w, http.StatusCreated, resp)
+}
+
+// handlePromoteModelVersion은 등록된 모델 버전을 운영으로 전환합니다.
+func (h *RestHandler) handlePromoteModelVersion(w http.ResponseWriter, r *http.Request) {
+	if h.aiInference == nil {
+		writeError(w, http.StatusServiceUnavailable, "ai inference service unavailable")
+		return
+	}
+	var body struct {
+		ModelType int32  `json:"model_type"`
+		Version   string `json:"version"`
+	}
+	if err := readJSON(r, &body); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	resp, err := h.aiInference.PromoteModelVersion(r.Context(), &v1.PromoteModelVersionRequest{
+		ModelType: v1.AiModelType(body.ModelType),
+		Version:   body.Version,
+	})
+	if err != nil {
+		writeError(w, grpcHTTPStatus(err), err.Error())
+		return
+	}
+	writeProtoJSON(w, http.StatusOK, resp)
+}
+
+// ── Fingerprint reference library ──
+
+func (h *RestHandler) handleListFingerprintReferences(w http.ResponseWriter, r *http.Request) {
+	if h.measurement == nil {
+		writeError(w, http.StatusServiceUnavailable, "measurement service unavailable")
+		return
+	}
+	q := r.URL.Query()
+	resp, err := h.measurement.ListFingerprintReferences(r.Context(), &v1.ListFingerprintReferencesRequest{
+		Status:        q.Get("status"),
+		Substance:     q.Get("substance"),
+		CartridgeType: q.Get("cartridge_type"),
+		Matrix:        q.Get("matrix"),
+		Limit:         queryInt(r, "limit", 20),
+		Offset:        queryInt(r, "offset", 0),
+	})
+	if err != nil {
+		writeError(w, grpcHTTPStatus(err), err.Error())
+		return
+	}
+	writeProtoJSON(w, http.StatusOK, resp)
+}
+
+func (h *RestHandler) handleAddFingerprintReference(w http.ResponseWriter, r *http.Request) {
+	if h.measurement == nil {
+		writeError(w, http.StatusServiceUnavailable, "measurement service unavailable")
+		return
+	}
+	var body struct {
+		Substance         string    `json:"substance"`
+		Concentration     float64   `json:"concentration"`
+		ConcentrationUnit string    `json:"concentration_unit"`
+		Matrix            string    `json:"matrix"`
+		CartridgeType     string    `json:"cartridge_type"`
+		FingerprintVector []float32 `json:"fingerprint_vector"`
+		SourceSessionID   string    `json:"source_session_id"`
+		Notes             string    `json:"notes"`
+		CreatedBy         string    `json:"created_by"`
+	}
+	if err := readJSON(r, &body); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	resp, err := h.measurement.AddFingerprintReference(r.Context(), &v1.AddFingerprintReferenceRequest{
+		Substance:         body.Substance,
+		Concentration:     body.Concentration,
+		ConcentrationUnit: body.ConcentrationUnit,
+		Matrix:            body.Matrix,
+		CartridgeType:     body.CartridgeType,
+		FingerprintVector: body.FingerprintVector,
+		SourceSessionId:   body.SourceSessionID,
+		Notes:             body.Notes,
+		CreatedBy:         body.CreatedBy,
+	})
+	if err != nil {
+		writeError(w, grpcHTTPStatus(err), err.Error())
+		return
+	}
+	writeProtoJSON(w, http.StatusCreated, resp)
+}
+
+func (h *RestHandler) handleReviewFingerprintReference(w http.ResponseWriter, r *http.Request) {
+	if h.measurement == nil {
+		writeError(w, http.StatusServiceUnavailable, "measurement service unavailable")
+		return
+	}
+	var body struct {
+		Status     string `json:"status"`
+		ReviewerID string `json:"reviewer_id"`
+		Notes      string `json:"notes"`
+	}
+	if err := readJSON(r, &body); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	resp, err := h.measurement.ReviewFingerprintReference(r.Context(), &v1.ReviewFingerprintReferenceRequest{
+		ReferenceId: r.PathValue("referenceId"),
+		Status:      body.Status,
+		ReviewerId:  body.ReviewerID,
+		Notes:       body.Notes,
+	})
+	if err != nil {
+		writeError(w, grpcHTTPStatus(err), err.Error())
+		return
+	}
+	writeProtoJSON(w, http.StatusOK, resp)
+}
+
+// ── Notification templates ──
+
+// notificationTemplateBody는 템플릿 편집 요청 본문입니다. type/priority/channel은 enum 이름입니다 (예: NOTIFICATION_TYPE_SYSTEM).
+type notificationTemplateBody struct {
+	Title        string   `json:"title"`
+	Body         string   `json:"body"`
+	Type         string   `json:"type"`
+	Priority     string   `json:"priority"`
+	Channel      string   `json:"channel"`
+	Placeholders []string `json:"placeholders"`
+}
+
+func (b *notificationTemplateBody) toProto(key, language string) *v1.NotificationTemplate {
+	return &v1.NotificationTemplate{
+		TemplateKey:  key,
+		Language:     language,
+		Title:        b.Title,
+		Body:         b.Body,
+		Type:         v1.NotificationType(v1.NotificationType_value[b.Type]),
+		Priority:     v1.NotificationPriority(v1.NotificationPriority_value[b.Priority]),
+		Channel:      v1.NotificationChannel(v1.NotificationChannel_value[b.Channel]),
+		Placeholders: b.Placeholders,
+	}
+}
+
+func (h *RestHandler) handleListNotificationTemplates(w http.ResponseWriter, r *http.Request) {
+	if h.admin == nil {
+		writeError(w, http.StatusServiceUnavailable, "admin service unavailable")
+		return
+	}
+	resp, err := h.admin.ListNotificationTemplates(r.Context(), &v1.ListNotificationTemplatesRequest{
+		TemplateKey: r.URL.Query().Get("template_key"),
+		Language:    r.URL.Query().Get("language"),
+	})
+	if err != nil {
+		writeError(w, grpcHTTPStatus(err), err.Error())
+		return
+	}
+	writeProtoJSON(w, http.StatusOK, resp)
+}
+
+func (h *RestHandler) handleListNotificationTemplateVersions(w http.ResponseWriter, r *http.Request) {
+	if h.admin == nil {
+		writeError(w, http.StatusServiceUnavailable, "admin service unavailable")
+		return
+	}
+	resp, err := h.admin.ListNotificationTemplateVersions(r.Context(), &v1.ListNotificationTemplateVersionsRequest{
+		TemplateKey: r.PathValue("key"),
+		Language:    r.PathValue("language"),
+	})
+	if err != nil {
+		writeError(w, grpcHTTPStatus(err), err.Error())
+		return
+	}
+	writeProtoJSON(w, http.StatusOK, resp)
+}
+
+func (h *RestHandler) handleSaveNotificationTemplate(w http.ResponseWriter, r *http.Request) {
+	if h.admin == nil {
+		writeError(w, http.StatusServiceUnavailable, "admin service unavailable")
+		return
+	}
+	var body struct {
+		notificationTemplateBody
+		BaseVersion int32  `json:"base_version"`
+		UpdatedBy   string `json:"updated_by"`
+	}
+	if err := readJSON(r, &body); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	resp, err := h.admin.SaveNotificationTemplate(r.Context(), &v1.SaveNotificationTemplateRequest{
+		Template:    body.toProto(r.PathValue("key"), r.PathValue("language")),
+		BaseVersion: body.BaseVersion,
+		UpdatedBy:   body.UpdatedBy,
+	})
+	if err != nil {
+		writeError(w, grpcHTTPStatus(err), err.Error())
+		return
+	}
+	writeProtoJSON(w, http.StatusOK, resp)
+}
+
+func (h *RestHandler) handleDeleteNotificationTemplate(w http.ResponseWriter, r *http.Request) {
+	if h.admin == nil {
+		writeError(w, http.StatusServiceUnavailable, "admin service unavailable")
+		return
+	}
+	var body struct {
+		BaseVersion int32  `json:"base_version"`
+		UpdatedBy   string `json:"updated_by"`
+	}
+	if r.ContentLength != 0 {
+		if err := readJSON(r, &body); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid request body")
+			return
+		}
+	}
+	resp, err := h.admin.DeleteNotificationTemplate(r.Context(), &v1.DeleteNotificationTemplateRequest{
+		TemplateKey: r.PathValue("key"),
+		Language:    r.PathValue("language"),
+		BaseVersion: body.BaseVersion,
+		UpdatedBy:   body.UpdatedBy,
+	})
+	if err != nil {
+		writeError(w, grpcHTTPStatus(err), err.Error())
+		return
+	}
+	writeProtoJSON(w, http.StatusOK, resp)
+}
+
+func (h *RestHandler) handleRollbackNotificationTemplate(w http.ResponseWriter, r *http.Request) {
+	if h.admin == nil {
+		writeError(w, http.StatusServiceUnavailable, "admin service unavailable")
+		return
+	}
+	var body struct {
+		Version   int32  `json:"version"`
+		UpdatedBy string `json:"updated_by"`
+	}
+	if err := readJSON(r, &body); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	resp, err := h.admin.RollbackNotificationTemplate(r.Context(), &v1.RollbackNotificationTemplateRequest{
+		TemplateKey: r.PathValue("key"),
+		Language:    r.PathValue("language"),
+		Version:     body.Version,
+		UpdatedBy:   body.UpdatedBy,
+	})
+	if err != nil {
+		writeError(w, grpcHTTPStatus(err), err.Error())
+		return
+	}
+	writeProtoJSON(w, http.StatusOK, resp)
+}
+
+func (h *RestHandler) handlePreviewNotificationTemplate(w http.ResponseWriter, r *http.Request) {
+	if h.admin == nil {
+		writeError(w, http.StatusServiceUnavailable, "admin service unavailable")
+		return
+	}
+	var body struct {
+		TemplateKey string                    `json:"template_key"`
+		Language    string                    `json:"language"`
+		Data        map[string]string         `json:"data"`
+		Draft       *notificationTemplateBody `json:"draft"`
+	}
+	if err := readJSON(r, &body); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	req := &v1.PreviewNotificationTemplateRequest{
+		TemplateKey: body.TemplateKey,
+		Language:    body.Language,
+		Data:        body.Data,
+	}
+	if body.Draft != nil {
+		req.Draft = body.Draft.toProto(body.TemplateKey, body.Language)
+	}
+	resp, err := h.admin.PreviewNotificationTemplate(r.Context(), req)
+	if err != nil {
+		writeError(w, grpcHTTPStatus(err), err.Error())
+		return
+	}
+	writeProtoJSON(w, http.StatusOK, resp)
+}
@@ -2,8 +2,10 @@ package handler
 
 import (
 	"net/http"
+	"time"
 
 	v1 "github.com/manpasik/backend/shared/gen/go/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 // registerCoachingRoutes는 AI 코칭 관련 REST 엔드포인트를 등록합니다.
@@ -14,7 +16,11 @@ func (h *RestHandler) registerCoachingRoutes(mux *http.ServeMux) {
 	mux.HandleFunc("GET /api/v1/coaching/messages", h.handleListCoachingMessages)
 	mux.HandleFunc("POST /api/v1/coaching/daily-report", h.handleGenerateDailyReport)
 	mux.HandleFunc("GET /api/v1/coaching/weekly-report", h.handleGetWeeklyReport)
+	mux.HandleFunc("POST /api/v1/coaching/reports/export", h.handleExportHealthReport)
+	mux.HandleFunc("GET /api/v1/coaching/reports/{exportId}", h.handleGetHealthReportExport)
 	mux.HandleFunc("GET /api/v1/coaching/recommendations", h.handleGetRecommendations)
+	mux.HandleFunc("PUT /api/v1/coaching/schedule", h.handleSetCoachingSchedule)
+	mux.HandleFunc("GET /api/v1/coaching/schedule", h.handleGetCoachingSchedule)
 }
 
 func (h *RestHandler) handleSetHealthGoal(w http.ResponseWriter, r *http.Request) {
@@ -74,6 +80,7 @@ func (h *RestHandler) handleGenerateCoaching(w http.ResponseWriter, r *http.Requ
 		UserID        string `json:"user_id"`
 		MeasurementID string `json:"measurement_id"`
 		CoachingType  int32  `json:"coaching_type"`
+		Language      string `json:"language"`
 	}
 	if err := readJSON(r, &body); err != nil {
 		writeError(w, http.StatusBadRequest, "invalid request body")
@@ -83,6 +90,7 @@ func (h *RestHandler) handleGenerateCoaching(w http.ResponseWriter, r *http.Requ
 		UserId:        body.UserID,
 		MeasurementId: body.MeasurementID,
 		CoachingType:  v1.CoachingType(body.CoachingType),
+		Language:      body.Language,
 	})
 	if err != nil {
 		writeError(w, http.StatusInternalServerError, err.Error())
@@ -146,6 +154,61 @@ func (h *RestHandler) handleGetWeeklyReport(w http.ResponseWriter, r *http.Reque
 	writeProtoJSON(w, http.StatusOK, resp)
 }
 
+// handleExportHealthReport는 주간·월간 건강 리포트를 HTML/PDF로 내보내고 다운로드 링크를 반환합니다.
+// period_start는 YYYY-MM-DD이며, 생략하면 이번 주(월)입니다.
+func (h *RestHandler) handleExportHealthReport(w http.ResponseWriter, r *http.Request) {
+	if h.coaching == nil {
+		writeError(w, http.StatusServiceUnavailable, "coaching service unavailable")
+		return
+	}
+	var body struct {
+		UserID      string `json:"user_id"`
+		Period      int32  `json:"period"`
+		PeriodStart string `json:"period_start"`
+		Language    string `json:"language"`
+	}
+	if err := readJSON(r, &body); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	req := &v1.ExportHealthReportRequest{
+		UserId:   body.UserID,
+		Period:   v1.ReportPeriod(body.Period),
+		Language: body.Language,
+	}
+	if body.PeriodStart != "" {
+		start, err := time.Parse("2006-01-02", body.PeriodStart)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "period_start must be YYYY-MM-DD")
+			return
+		}
+		req.PeriodStart = timestamppb.New(start)
+	}
+	resp, err := h.coaching.ExportHealthReport(r.Context(), req)
+	if err != nil {
+		writeError(w, grpcHTTPStatus(err), err.Error())
+		return
+	}
+	writeProtoJSON(w, http.StatusCreated, resp)
+}
+
+// handleGetHealthReportExport는 내보낸 리포트를 새 다운로드 링크와 함께 반환합니다.
+func (h *RestHandler) handleGetHealthReportExport(w http.ResponseWriter, r *http.Request) {
+	if h.coaching == nil {
+		writeError(w, http.StatusServiceUnavailable, "coaching service unavailable")
+		return
+	}
+	resp, err := h.coaching.GetHealthReportExport(r.Context(), &v1.GetHealthReportExportRequest{
+		ExportId: r.PathValue("exportId"),
+		UserId:   r.URL.Query().Get("user_id"),
+	})
+	if err != nil {
+		writeError(w, grpcHTTPStatus(err), err.Error())
+		return
+	}
+	writeProtoJSON(w, http.StatusOK, resp)
+}
+
 func (h *RestHandler) handleGetRecommendations(w http.ResponseWriter, r *http.Request) {
 	if h.coaching == nil {
 		writeError(w, http.StatusServiceUnavailable, "coaching service unavailable")
@@ -162,3 +225,44 @@ func (h *RestHandler) handleGetRecommendations(w http.ResponseWriter, r *http.Re
 	}
 	writeProtoJSON(w, http.StatusOK, resp)
 }
+
+func (h *RestHandler) handleSetCoachingSchedule(w http.ResponseWriter, r *http.Request) {
+	if h.coaching == nil {
+		writeError(w, http.StatusServiceUnavailable, "coaching service unavailable")
+		return
+	}
+	var body struct {
+		UserID          string `json:"user_id"`
+		DailyTipTime    string `json:"daily_tip_time"`
+		DailyReportTime string `json:"daily_report_time"`
+	}
+	if err := readJSON(r, &body); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	resp, err := h.coaching.SetCoachingSchedule(r.Context(), &v1.SetCoachingScheduleRequest{
+		UserId:          body.UserID,
+		DailyTipTime:    body.DailyTipTime,
+		DailyReportTime: body.DailyReportTime,
+	})
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeProtoJSON(w, http.StatusOK, resp)
+}
+
+func (h *RestHandler) handleGetCoachingSchedule(w http.ResponseWriter, r *http.Request) {
+	if h.coaching == nil {
+		writeError(w, http.StatusServiceUnavailable, "coaching service unavailable")
+		return
+	}
+	resp, err := h.coaching.GetCoachingSchedule(r.Context(), &v1.GetCoachingScheduleRequest{
+		UserId: r.URL.Query().Get("user_id"),
+	})
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeProtoJSON(w, http.StatusOK, resp)
+}
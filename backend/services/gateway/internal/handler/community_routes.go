@@ -60,6 +60,8 @@ func (h *RestHandler) registerCommunityRoutes(mux *http.ServeMux) {
 	mux.HandleFunc("POST /api/v1/telemedicine/consultations/{consultationId}/video", h.handleStartVideoSession)
 	mux.HandleFunc("POST /api/v1/telemedicine/consultations/{consultationId}/end-video", h.handleEndVideoSession)
 	mux.HandleFunc("POST /api/v1/telemedicine/consultations/{consultationId}/rate", h.handleRateConsultation)
+	mux.HandleFunc("POST /api/v1/telemedicine/consultations/{consultationId}/attachments", h.handleAttachHealthReport)
+	mux.HandleFunc("GET /api/v1/telemedicine/consultations/{consultationId}/attachments", h.handleListConsultationAttachments)
 
 	// Video
 	mux.HandleFunc("POST /api/v1/video/rooms", h.handleCreateVideoRoom)
@@ -1033,6 +1035,47 @@ func (h *RestHandler) handleRateConsultation(w http.ResponseWriter, r *http.Requ
 	writeProtoJSON(w, http.StatusOK, resp)
 }
 
+func (h *RestHandler) handleAttachHealthReport(w http.ResponseWriter, r *http.Request) {
+	if h.telemedicine == nil {
+		writeError(w, http.StatusServiceUnavailable, "telemedicine service unavailable")
+		return
+	}
+	var body struct {
+		UserID   string `json:"user_id"`
+		ExportID string `json:"export_id"`
+	}
+	if err := readJSON(r, &body); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	resp, err := h.telemedicine.AttachHealthReport(r.Context(), &v1.AttachHealthReportRequest{
+		ConsultationId: r.PathValue("consultationId"),
+		UserId:         body.UserID,
+		ExportId:       body.ExportID,
+	})
+	if err != nil {
+		writeError(w, grpcHTTPStatus(err), err.Error())
+		return
+	}
+	writeProtoJSON(w, http.StatusCreated, resp)
+}
+
+func (h *RestHandler) handleListConsultationAttachments(w http.ResponseWriter, r *http.Request) {
+	if h.telemedicine == nil {
+		writeError(w, http.StatusServiceUnavailable, "telemedicine service unavailable")
+		return
+	}
+	resp, err := h.telemedicine.ListConsultationAttachments(r.Context(), &v1.ListConsultationAttachmentsRequest{
+		ConsultationId: r.PathValue("consultationId"),
+		UserId:         r.URL.Query().Get("user_id"),
+	})
+	if err != nil {
+		writeError(w, grpcHTTPStatus(err), err.Error())
+		return
+	}
+	writeProtoJSON(w, http.StatusOK, resp)
+}
+
 // ── Video (new) ──
 
 func (h *RestHandler) handleCreateVideoRoom(w http.ResponseWriter, r *http.Request) {
@@ -64,6 +64,21 @@ func (m *mockMeasurementClient) ExportSingleMeasurement(_ context.Context, _ *v1
 func (m *mockMeasurementClient) ExportToFHIRObservations(_ context.Context, _ *v1.ExportToFHIRObservationsRequest, _ ...grpc.CallOption) (*v1.ExportFHIRResponse, error) {
 	return &v1.ExportFHIRResponse{}, nil
 }
+func (m *mockMeasurementClient) GetMeasurement(_ context.Context, _ *v1.GetMeasurementRequest, _ ...grpc.CallOption) (*v1.GetMeasurementResponse, error) {
+	return &v1.GetMeasurementResponse{}, nil
+}
+func (m *mockMeasurementClient) AddFingerprintReference(_ context.Context, _ *v1.AddFingerprintReferenceRequest, _ ...grpc.CallOption) (*v1.FingerprintReference, error) {
+	return &v1.FingerprintReference{ReferenceId: "ref-1", Status: "pending"}, nil
+}
+func (m *mockMeasurementClient) ReviewFingerprintReference(_ context.Context, req *v1.ReviewFingerprintReferenceRequest, _ ...grpc.CallOption) (*v1.FingerprintReference, error) {
+	return &v1.FingerprintReference{ReferenceId: req.ReferenceId, Status: req.Status}, nil
+}
+func (m *mockMeasurementClient) ListFingerprintReferences(_ context.Context, _ *v1.ListFingerprintReferencesRequest, _ ...grpc.CallOption) (*v1.ListFingerprintReferencesResponse, error) {
+	return &v1.ListFingerprintReferencesResponse{}, nil
+}
+func (m *mockMeasurementClient) IdentifySubstance(_ context.Context, _ *v1.IdentifySubstanceRequest, _ ...grpc.CallOption) (*v1.SubstanceIdentification, error) {
+	return &v1.SubstanceIdentification{Unknown: true}, nil
+}
 
 // mockDeviceClient는 DeviceServiceClient를 모킹합니다.
 type mockDeviceClient struct{}
@@ -83,6 +98,84 @@ func (m *mockDeviceClient) RequestOtaUpdate(_ context.Context, _ *v1.OtaRequest,
 func (m *mockDeviceClient) UpdateDeviceStatus(_ context.Context, _ *v1.UpdateDeviceStatusRequest, _ ...grpc.CallOption) (*v1.UpdateDeviceStatusResponse, error) {
 	return &v1.UpdateDeviceStatusResponse{}, nil
 }
+func (m *mockDeviceClient) InitiateDeviceTransfer(_ context.Context, _ *v1.InitiateDeviceTransferRequest, _ ...grpc.CallOption) (*v1.DeviceTransferInfo, error) {
+	return &v1.DeviceTransferInfo{}, nil
+}
+func (m *mockDeviceClient) ConfirmDeviceTransfer(_ context.Context, _ *v1.ConfirmDeviceTransferRequest, _ ...grpc.CallOption) (*v1.DeviceTransferInfo, error) {
+	return &v1.DeviceTransferInfo{}, nil
+}
+func (m *mockDeviceClient) CancelDeviceTransfer(_ context.Context, _ *v1.CancelDeviceTransferRequest, _ ...grpc.CallOption) (*v1.DeviceTransferInfo, error) {
+	return &v1.DeviceTransferInfo{}, nil
+}
+func (m *mockDeviceClient) ListDeviceOwnershipHistory(_ context.Context, _ *v1.ListDeviceOwnershipHistoryRequest, _ ...grpc.CallOption) (*v1.ListDeviceOwnershipHistoryResponse, error) {
+	return &v1.ListDeviceOwnershipHistoryResponse{}, nil
+}
+func (m *mockDeviceClient) GetDeviceOwnerAt(_ context.Context, req *v1.GetDeviceOwnerAtRequest, _ ...grpc.CallOption) (*v1.GetDeviceOwnerAtResponse, error) {
+	return &v1.GetDeviceOwnerAtResponse{DeviceId: req.DeviceId}, nil
+}
+func (m *mockDeviceClient) DeregisterDevice(_ context.Context, _ *v1.DeregisterDeviceRequest, _ ...grpc.CallOption) (*v1.DeregisterDeviceResponse, error) {
+	return &v1.DeregisterDeviceResponse{}, nil
+}
+func (m *mockDeviceClient) RequestRemoteWipe(_ context.Context, _ *v1.RemoteWipeRequest, _ ...grpc.CallOption) (*v1.RemoteWipeResponse, error) {
+	return &v1.RemoteWipeResponse{}, nil
+}
+func (m *mockDeviceClient) ListPendingCommands(_ context.Context, _ *v1.ListPendingCommandsRequest, _ ...grpc.CallOption) (*v1.ListPendingCommandsResponse, error) {
+	return &v1.ListPendingCommandsResponse{}, nil
+}
+func (m *mockDeviceClient) AcknowledgeDeviceCommand(_ context.Context, _ *v1.AcknowledgeDeviceCommandRequest, _ ...grpc.CallOption) (*v1.AcknowledgeDeviceCommandResponse, error) {
+	return &v1.AcknowledgeDeviceCommandResponse{}, nil
+}
+func (m *mockDeviceClient) RegisterHub(_ context.Context, _ *v1.RegisterHubRequest, _ ...grpc.CallOption) (*v1.RegisterDeviceResponse, error) {
+	return &v1.RegisterDeviceResponse{}, nil
+}
+func (m *mockDeviceClient) AttachReadersToHub(_ context.Context, _ *v1.AttachReadersToHubRequest, _ ...grpc.CallOption) (*v1.AttachReadersToHubResponse, error) {
+	return &v1.AttachReadersToHubResponse{}, nil
+}
+func (m *mockDeviceClient) DetachReaderFromHub(_ context.Context, _ *v1.DetachReaderFromHubRequest, _ ...grpc.CallOption) (*v1.DetachReaderFromHubResponse, error) {
+	return &v1.DetachReaderFromHubResponse{}, nil
+}
+func (m *mockDeviceClient) ReportHubRelay(_ context.Context, _ *v1.HubRelayReport, _ ...grpc.CallOption) (*v1.HubRelayResponse, error) {
+	return &v1.HubRelayResponse{}, nil
+}
+func (m *mockDeviceClient) GetHubStatus(_ context.Context, _ *v1.GetHubStatusRequest, _ ...grpc.CallOption) (*v1.HubStatus, error) {
+	return &v1.HubStatus{}, nil
+}
+func (m *mockDeviceClient) ListHubReaders(_ context.Context, _ *v1.ListHubReadersRequest, _ ...grpc.CallOption) (*v1.ListDevicesResponse, error) {
+	return &v1.ListDevicesResponse{}, nil
+}
+func (m *mockDeviceClient) SendDeviceCommand(_ context.Context, _ *v1.SendDeviceCommandRequest, _ ...grpc.CallOption) (*v1.SendDeviceCommandResponse, error) {
+	return &v1.SendDeviceCommandResponse{}, nil
+}
+func (m *mockDeviceClient) CreateDeviceGroup(_ context.Context, _ *v1.CreateDeviceGroupRequest, _ ...grpc.CallOption) (*v1.DeviceGroupInfo, error) {
+	return &v1.DeviceGroupInfo{}, nil
+}
+func (m *mockDeviceClient) ListDeviceGroups(_ context.Context, _ *v1.ListDeviceGroupsRequest, _ ...grpc.CallOption) (*v1.ListDeviceGroupsResponse, error) {
+	return &v1.ListDeviceGroupsResponse{}, nil
+}
+func (m *mockDeviceClient) AddDeviceGroupMember(_ context.Context, _ *v1.AddDeviceGroupMemberRequest, _ ...grpc.CallOption) (*v1.DeviceGroupMember, error) {
+	return &v1.DeviceGroupMember{}, nil
+}
+func (m *mockDeviceClient) RemoveDeviceGroupMember(_ context.Context, _ *v1.RemoveDeviceGroupMemberRequest, _ ...grpc.CallOption) (*v1.RemoveDeviceGroupMemberResponse, error) {
+	return &v1.RemoveDeviceGroupMemberResponse{}, nil
+}
+func (m *mockDeviceClient) AddDevicesToGroup(_ context.Context, _ *v1.AddDevicesToGroupRequest, _ ...grpc.CallOption) (*v1.AddDevicesToGroupResponse, error) {
+	return &v1.AddDevicesToGroupResponse{}, nil
+}
+func (m *mockDeviceClient) RemoveDeviceFromGroup(_ context.Context, _ *v1.RemoveDeviceFromGroupRequest, _ ...grpc.CallOption) (*v1.RemoveDeviceFromGroupResponse, error) {
+	return &v1.RemoveDeviceFromGroupResponse{}, nil
+}
+func (m *mockDeviceClient) SendBulkCommand(_ context.Context, _ *v1.SendBulkCommandRequest, _ ...grpc.CallOption) (*v1.BulkCommandInfo, error) {
+	return &v1.BulkCommandInfo{}, nil
+}
+func (m *mockDeviceClient) GetBulkCommandStatus(_ context.Context, _ *v1.GetBulkCommandStatusRequest, _ ...grpc.CallOption) (*v1.BulkCommandInfo, error) {
+	return &v1.BulkCommandInfo{}, nil
+}
+func (m *mockDeviceClient) GetFleetStats(_ context.Context, _ *v1.GetFleetStatsRequest, _ ...grpc.CallOption) (*v1.FleetStats, error) {
+	return &v1.FleetStats{}, nil
+}
+func (m *mockDeviceClient) ListFleetStats(_ context.Context, _ *v1.ListFleetStatsRequest, _ ...grpc.CallOption) (*v1.ListFleetStatsResponse, error) {
+	return &v1.ListFleetStatsResponse{}, nil
+}
 
 // mockShopClient는 ShopServiceClient를 모킹합니다.
 type mockShopClient struct{}
@@ -213,6 +306,9 @@ func (m *mockFamilyClient) GetSharedHealthData(_ context.Context, _ *v1.GetShare
 func (m *mockFamilyClient) ValidateSharingAccess(_ context.Context, _ *v1.ValidateSharingAccessRequest, _ ...grpc.CallOption) (*v1.ValidateSharingAccessResponse, error) {
 	return &v1.ValidateSharingAccessResponse{}, nil
 }
+func (m *mockFamilyClient) ListGuardians(_ context.Context, _ *v1.ListGuardiansRequest, _ ...grpc.CallOption) (*v1.ListGuardiansResponse, error) {
+	return &v1.ListGuardiansResponse{}, nil
+}
 
 // mockHealthRecordClient는 HealthRecordServiceClient를 모킹합니다.
 type mockHealthRecordClient struct{}
@@ -355,6 +451,12 @@ func (m *mockTelemedicineClient) EndVideoSession(_ context.Context, _ *v1.EndVid
 func (m *mockTelemedicineClient) RateConsultation(_ context.Context, _ *v1.RateConsultationRequest, _ ...grpc.CallOption) (*v1.RateConsultationResponse, error) {
 	return &v1.RateConsultationResponse{}, nil
 }
+func (m *mockTelemedicineClient) AttachHealthReport(_ context.Context, req *v1.AttachHealthReportRequest, _ ...grpc.CallOption) (*v1.ConsultationAttachment, error) {
+	return &v1.ConsultationAttachment{AttachmentId: "att-1", ConsultationId: req.ConsultationId, UserId: req.UserId, ExportId: req.ExportId}, nil
+}
+func (m *mockTelemedicineClient) ListConsultationAttachments(_ context.Context, _ *v1.ListConsultationAttachmentsRequest, _ ...grpc.CallOption) (*v1.ListConsultationAttachmentsResponse, error) {
+	return &v1.ListConsultationAttachmentsResponse{}, nil
+}
 
 // mockNotificationClient는 NotificationServiceClient를 모킹합니다.
 type mockNotificationClient struct{}
@@ -383,6 +485,31 @@ func (m *mockNotificationClient) GetNotificationPreferences(_ context.Context, _
 func (m *mockNotificationClient) SendFromTemplate(_ context.Context, _ *v1.SendFromTemplateRequest, _ ...grpc.CallOption) (*v1.Notification, error) {
 	return &v1.Notification{}, nil
 }
+func (m *mockNotificationClient) AcknowledgeEscalation(_ context.Context, req *v1.AcknowledgeEscalationRequest, _ ...grpc.CallOption) (*v1.Escalation, error) {
+	return &v1.Escalation{EscalationId: req.EscalationId, Stage: v1.EscalationStage_ESCALATION_STAGE_RESOLVED, ResolvedBy: req.ResolvedBy}, nil
+}
+func (m *mockNotificationClient) ListActiveEscalations(_ context.Context, _ *v1.ListActiveEscalationsRequest, _ ...grpc.CallOption) (*v1.ListActiveEscalationsResponse, error) {
+	return &v1.ListActiveEscalationsResponse{}, nil
+}
+func (m *mockNotificationClient) ListNotificationTemplates(_ context.Context, _ *v1.ListNotificationTemplatesRequest, _ ...grpc.CallOption) (*v1.ListNotificationTemplatesResponse, error) {
+	return &v1.ListNotificationTemplatesResponse{}, nil
+}
+func (m *mockNotificationClient) ListNotificationTemplateVersions(_ context.Context, _ *v1.ListNotificationTemplateVersionsRequest, _ ...grpc.CallOption) (*v1.ListNotificationTemplatesResponse, error) {
+	return &v1.ListNotificationTemplatesResponse{}, nil
+}
+func (m *mockNotificationClient) SaveNotificationTemplate(_ context.Context, req *v1.SaveNotificationTemplateRequest, _ ...grpc.CallOption) (*v1.NotificationTemplate, error) {
+	t := req.Template
+	return &v1.NotificationTemplate{TemplateKey: t.TemplateKey, Language: t.Language, Version: req.BaseVersion + 1, Title: t.Title, UpdatedBy: req.UpdatedBy}, nil
+}
+func (m *mockNotificationClient) DeleteNotificationTemplate(_ context.Context, req *v1.DeleteNotificationTemplateRequest, _ ...grpc.CallOption) (*v1.NotificationTemplate, error) {
+	return &v1.NotificationTemplate{TemplateKey: req.TemplateKey, Language: req.Language, Version: req.BaseVersion + 1, Deleted: true}, nil
+}
+func (m *mockNotificationClient) RollbackNotificationTemplate(_ context.Context, req *v1.RollbackNotificationTemplateRequest, _ ...grpc.CallOption) (*v1.NotificationTemplate, error) {
+	return &v1.NotificationTemplate{TemplateKey: req.TemplateKey, Language: req.Language}, nil
+}
+func (m *mockNotificationClient) PreviewNotificationTemplate(_ context.Context, req *v1.PreviewNotificationTemplateRequest, _ ...grpc.CallOption) (*v1.PreviewNotificationTemplateResponse, error) {
+	return &v1.PreviewNotificationTemplateResponse{Valid: true, Language: req.Language}, nil
+}
 
 // mockTranslationClient는 TranslationServiceClient를 모킹합니다.
 type mockTranslationClient struct{}
@@ -433,6 +560,18 @@ func (m *mockCoachingClient) GetWeeklyReport(_ context.Context, _ *v1.GetWeeklyR
 func (m *mockCoachingClient) GetRecommendations(_ context.Context, _ *v1.GetRecommendationsRequest, _ ...grpc.CallOption) (*v1.GetRecommendationsResponse, error) {
 	return &v1.GetRecommendationsResponse{}, nil
 }
+func (m *mockCoachingClient) SetCoachingSchedule(_ context.Context, req *v1.SetCoachingScheduleRequest, _ ...grpc.CallOption) (*v1.CoachingSchedule, error) {
+	return &v1.CoachingSchedule{UserId: req.UserId, DailyTipTime: req.DailyTipTime, DailyReportTime: req.DailyReportTime}, nil
+}
+func (m *mockCoachingClient) GetCoachingSchedule(_ context.Context, req *v1.GetCoachingScheduleRequest, _ ...grpc.CallOption) (*v1.CoachingSchedule, error) {
+	return &v1.CoachingSchedule{UserId: req.UserId}, nil
+}
+func (m *mockCoachingClient) ExportHealthReport(_ context.Context, req *v1.ExportHealthReportRequest, _ ...grpc.CallOption) (*v1.HealthReportExport, error) {
+	return &v1.HealthReportExport{ExportId: "export-1", UserId: req.UserId, Period: req.Period}, nil
+}
+func (m *mockCoachingClient) GetHealthReportExport(_ context.Context, req *v1.GetHealthReportExportRequest, _ ...grpc.CallOption) (*v1.HealthReportExport, error) {
+	return &v1.HealthReportExport{ExportId: req.ExportId, UserId: req.UserId}, nil
+}
 
 // mockAdminClient는 AdminServiceClient를 모킹합니다.
 type mockAdminClient struct{}
@@ -491,6 +630,28 @@ func (m *mockAdminClient) GetRevenueStats(_ context.Context, _ *v1.GetRevenueSta
 func (m *mockAdminClient) GetInventoryStats(_ context.Context, _ *v1.GetInventoryStatsRequest, _ ...grpc.CallOption) (*v1.GetInventoryStatsResponse, error) {
 	return &v1.GetInventoryStatsResponse{}, nil
 }
+func (m *mockAdminClient) GetFleetStats(_ context.Context, _ *v1.AdminGetFleetStatsRequest, _ ...grpc.CallOption) (*v1.AdminGetFleetStatsResponse, error) {
+	return &v1.AdminGetFleetStatsResponse{}, nil
+}
+func (m *mockAdminClient) ListNotificationTemplates(_ context.Context, _ *v1.ListNotificationTemplatesRequest, _ ...grpc.CallOption) (*v1.ListNotificationTemplatesResponse, error) {
+	return &v1.ListNotificationTemplatesResponse{}, nil
+}
+func (m *mockAdminClient) ListNotificationTemplateVersions(_ context.Context, _ *v1.ListNotificationTemplateVersionsRequest, _ ...grpc.CallOption) (*v1.ListNotificationTemplatesResponse, error) {
+	return &v1.ListNotificationTemplatesResponse{}, nil
+}
+func (m *mockAdminClient) SaveNotificationTemplate(_ context.Context, req *v1.SaveNotificationTemplateRequest, _ ...grpc.CallOption) (*v1.NotificationTemplate, error) {
+	t := req.Template
+	return &v1.NotificationTemplate{TemplateKey: t.TemplateKey, Language: t.Language, Version: req.BaseVersion + 1, Title: t.Title, UpdatedBy: req.UpdatedBy}, nil
+}
+func (m *mockAdminClient) DeleteNotificationTemplate(_ context.Context, req *v1.DeleteNotificationTemplateRequest, _ ...grpc.CallOption) (*v1.NotificationTemplate, error) {
+	return &v1.NotificationTemplate{TemplateKey: req.TemplateKey, Language: req.Language, Version: req.BaseVersion + 1, Deleted: true}, nil
+}
+func (m *mockAdminClient) RollbackNotificationTemplate(_ context.Context, req *v1.RollbackNotificationTemplateRequest, _ ...grpc.CallOption) (*v1.NotificationTemplate, error) {
+	return &v1.NotificationTemplate{TemplateKey: req.TemplateKey, Language: req.Language}, nil
+}
+func (m *mockAdminClient) PreviewNotificationTemplate(_ context.Context, req *v1.PreviewNotificationTemplateRequest, _ ...grpc.CallOption) (*v1.PreviewNotificationTemplateResponse, error) {
+	return &v1.PreviewNotificationTemplateResponse{Valid: true, Language: req.Language}, nil
+}
 
 // ============================================================================
 // 테스트 헬퍼
@@ -0,0 +1,90 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	v1 "github.com/manpasik/backend/shared/gen/go/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// registerEnvironmentRoutes는 지역 환경 모니터링(공유 동의·측정값 제출) REST 엔드포인트를 등록합니다.
+// 공개 집계 조회는 analytics-service의 공개 HTTP API가 직접 제공합니다.
+func (h *RestHandler) registerEnvironmentRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("PUT /api/v1/environment/consent", h.handleSetEnvironmentSharingConsent)
+	mux.HandleFunc("POST /api/v1/environment/readings", h.handleSubmitEnvironmentReading)
+}
+
+func (h *RestHandler) handleSetEnvironmentSharingConsent(w http.ResponseWriter, r *http.Request) {
+	if h.environment == nil {
+		writeError(w, http.StatusServiceUnavailable, "environment service unavailable")
+		return
+	}
+	var body struct {
+		UserID string `json:"user_id"`
+		OptIn  bool   `json:"opt_in"`
+	}
+	if err := readJSON(r, &body); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	resp, err := h.environment.SetEnvironmentSharingConsent(r.Context(), &v1.SetEnvironmentSharingConsentRequest{
+		UserId: body.UserID,
+		OptIn:  body.OptIn,
+	})
+	if err != nil {
+		writeError(w, grpcHTTPStatus(err), err.Error())
+		return
+	}
+	writeProtoJSON(w, http.StatusOK, resp)
+}
+
+func (h *RestHandler) handleSubmitEnvironmentReading(w http.ResponseWriter, r *http.Request) {
+	if h.environment == nil {
+		writeError(w, http.StatusServiceUnavailable, "environment service unavailable")
+		return
+	}
+	var body struct {
+		UserID       string   `json:"user_id"`
+		Metric       string   `json:"metric"`
+		Value        float64  `json:"value"`
+		Unit         string   `json:"unit"`
+		CountryCode  string   `json:"country_code"`
+		RegionCode   string   `json:"region_code"`
+		DistrictCode string   `json:"district_code"`
+		Latitude     *float64 `json:"latitude"`
+		Longitude    *float64 `json:"longitude"`
+		MeasuredAt   string   `json:"measured_at"`
+	}
+	if err := readJSON(r, &body); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	req := &v1.SubmitEnvironmentReadingRequest{
+		UserId:       body.UserID,
+		Metric:       body.Metric,
+		Value:        body.Value,
+		Unit:         body.Unit,
+		CountryCode:  body.CountryCode,
+		RegionCode:   body.RegionCode,
+		DistrictCode: body.DistrictCode,
+	}
+	if body.Latitude != nil && body.Longitude != nil {
+		req.HasLocation = true
+		req.Latitude, req.Longitude = *body.Latitude, *body.Longitude
+	}
+	if body.MeasuredAt != "" {
+		t, err := time.Parse(time.RFC3339, body.MeasuredAt)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "measured_at must be RFC3339")
+			return
+		}
+		req.MeasuredAt = timestamppb.New(t)
+	}
+	resp, err := h.environment.SubmitEnvironmentReading(r.Context(), req)
+	if err != nil {
+		writeError(w, grpcHTTPStatus(err), err.Error())
+		return
+	}
+	writeProtoJSON(w, http.StatusCreated, resp)
+}
@@ -1,11 +1,19 @@
 package handler
 
 import (
+	"context"
 	"encoding/json"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
+
+	v1 "github.com/manpasik/backend/shared/gen/go/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // newNilHandler는 모든 gRPC 클라이언트가 nil인 핸들러를 반환합니다.
@@ -294,6 +302,204 @@ func TestGetSystemStats_ServiceUnavailable(t *testing.T) {
 	assertStatus(t, w.Code, http.StatusServiceUnavailable)
 }
 
+// ---------------------------------------------------------------------------
+// 13. AI 채팅 스트리밍 (SSE)
+// ---------------------------------------------------------------------------
+
+// fakeChatStream은 정해진 응답을 순서대로 반환한 뒤 err(기본 io.EOF)를 반환합니다.
+type fakeChatStream struct {
+	grpc.ClientStream
+	msgs []*v1.StreamChatResponse
+	err  error
+}
+
+func (s *fakeChatStream) Recv() (*v1.StreamChatResponse, error) {
+	if len(s.msgs) == 0 {
+		if s.err != nil {
+			return nil, s.err
+		}
+		return nil, io.EOF
+	}
+	m := s.msgs[0]
+	s.msgs = s.msgs[1:]
+	return m, nil
+}
+
+// fakeAiInferenceClient는 StreamChat과 GetLLMUsageReport만 구현한 AI 추론 클라이언트입니다.
+type fakeAiInferenceClient struct {
+	v1.AiInferenceServiceClient
+	stream   *fakeChatStream
+	req      *v1.StreamChatRequest
+	usageReq *v1.GetLLMUsageReportRequest
+	register *v1.RegisterModelVersionRequest
+}
+
+func (c *fakeAiInferenceClient) RegisterModelVersion(_ context.Context, req *v1.RegisterModelVersionRequest, _ ...grpc.CallOption) (*v1.ModelInfo, error) {
+	c.register = req
+	return &v1.ModelInfo{ModelType: req.ModelType, Version: req.Version, Status: "shadow"}, nil
+}
+
+func (c *fakeAiInferenceClient) GetLLMUsageReport(_ context.Context, req *v1.GetLLMUsageReportRequest, _ ...grpc.CallOption) (*v1.LLMUsageReport, error) {
+	c.usageReq = req
+	return &v1.LLMUsageReport{TotalCalls: 3, TotalCostUsd: 0.02}, nil
+}
+
+func (c *fakeAiInferenceClient) StreamChat(_ context.Context, req *v1.StreamChatRequest, _ ...grpc.CallOption) (grpc.ServerStreamingClient[v1.StreamChatResponse], error) {
+	c.req = req
+	return c.stream, nil
+}
+
+func TestStreamChat_ServiceUnavailable(t *testing.T) {
+	h := newNilHandler()
+	body := `{"user_id":"u1","message":"hi"}`
+	req := httptest.NewRequest("POST", "/api/v1/ai/chat/stream", strings.NewReader(body))
+	w := httptest.NewRecorder()
+	h.handleStreamChat(w, req)
+
+	assertStatus(t, w.Code, http.StatusServiceUnavailable)
+}
+
+func TestStreamChat_SSEEvents(t *testing.T) {
+	client := &fakeAiInferenceClient{stream: &fakeChatStream{msgs: []*v1.StreamChatResponse{
+		{Chunk: "안녕", SessionId: "chat_1"},
+		{Chunk: "하세요", SessionId: "chat_1"},
+		{IsFinal: true, SessionId: "chat_1", FullResponse: "안녕하세요", TokensUsed: 12},
+	}}}
+	h := &RestHandler{aiInference: client}
+	mux := h.SetupRoutes()
+
+	body := `{"user_id":"u1","message":"hi","session_id":"chat_1","context_measurement_ids":["m1"]}`
+	req := httptest.NewRequest("POST", "/api/v1/ai/chat/stream", strings.NewReader(body))
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	assertStatus(t, w.Code, http.StatusOK)
+	if ct := w.Header().Get("Content-Type"); ct != "text/event-stream" {
+		t.Errorf("Content-Type = %q", ct)
+	}
+	if client.req.GetSessionId() != "chat_1" || len(client.req.GetContextMeasurementIds()) != 1 {
+		t.Errorf("요청 변환이 올바르지 않습니다: %+v", client.req)
+	}
+	want := "event: chunk\ndata: {\"delta\":\"안녕\",\"session_id\":\"chat_1\"}\n\n" +
+		"event: chunk\ndata: {\"delta\":\"하세요\",\"session_id\":\"chat_1\"}\n\n" +
+		"event: done\ndata: {\"full_response\":\"안녕하세요\",\"session_id\":\"chat_1\",\"tokens_used\":12}\n\n"
+	if got := w.Body.String(); got != want {
+		t.Errorf("SSE 본문이 다릅니다:\n%s", got)
+	}
+}
+
+func TestStreamChat_DoneIncludesSources(t *testing.T) {
+	client := &fakeAiInferenceClient{stream: &fakeChatStream{msgs: []*v1.StreamChatResponse{
+		{IsFinal: true, SessionId: "chat_1", FullResponse: "공복혈당은 126 mg/dL였습니다 [1].", TokensUsed: 20,
+			Sources: []*v1.ChatSource{{Index: 1, SourceType: "health_record", SourceId: "rec-1", Title: "혈액검사 결과", RecordedAt: "2026-10-09T00:00:00Z"}}},
+	}}}
+	h := &RestHandler{aiInference: client}
+	req := httptest.NewRequest("POST", "/api/v1/ai/chat/stream", strings.NewReader(`{"user_id":"u1","message":"hi"}`))
+	w := httptest.NewRecorder()
+	h.handleStreamChat(w, req)
+
+	assertStatus(t, w.Code, http.StatusOK)
+	want := `"sources":[{"index":1,"recorded_at":"2026-10-09T00:00:00Z","source_id":"rec-1","source_type":"health_record","title":"혈액검사 결과"}]`
+	if !strings.Contains(w.Body.String(), want) {
+		t.Errorf("done 이벤트에 출처가 없습니다:\n%s", w.Body.String())
+	}
+}
+
+func TestLLMUsageReport_DateRange(t *testing.T) {
+	client := &fakeAiInferenceClient{}
+	h := &RestHandler{aiInference: client}
+	mux := h.SetupRoutes()
+
+	req := httptest.NewRequest("GET", "/api/v1/admin/ai/usage?start_date=2026-03-01&end_date=2026-03-31&user_id=u1", nil)
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	assertStatus(t, w.Code, http.StatusOK)
+	// end_date 당일 포함: 종료 시각은 다음 날 0시
+	if got := client.usageReq.GetEndTime().AsTime(); !got.Equal(time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("end_time = %v", got)
+	}
+	if client.usageReq.GetUserId() != "u1" || !strings.Contains(w.Body.String(), `"totalCalls":3`) {
+		t.Errorf("요청·응답 변환이 올바르지 않습니다: %+v %s", client.usageReq, w.Body.String())
+	}
+
+	req = httptest.NewRequest("GET", "/api/v1/admin/ai/usage?start_date=03/01/2026", nil)
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+	assertStatus(t, w.Code, http.StatusBadRequest)
+}
+
+func TestAdminRegisterModelVersion(t *testing.T) {
+	client := &fakeAiInferenceClient{}
+	h := &RestHandler{aiInference: client}
+	mux := h.SetupRoutes()
+
+	body := `{"model_type":1,"name":"BiomarkerClassifier","version":"1.1.0","kind":"reference_range",
+		"artifact":"eyJyYW5nZXMiOltdfQ==","metrics":{"accuracy":0.95},"trained_at":"2026-03-01T00:00:00Z"}`
+	req := httptest.NewRequest("POST", "/api/v1/admin/ai/models", strings.NewReader(body))
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	assertStatus(t, w.Code, http.StatusCreated)
+	got := client.register
+	if got.GetModelType() != v1.AiModelType_AI_MODEL_TYPE_BIOMARKER_CLASSIFIER || string(got.GetArtifact()) != `{"ranges":[]}` ||
+		got.GetMetrics()["accuracy"] != 0.95 || !got.GetTrainedAt().AsTime().Equal(time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("요청 변환이 올바르지 않습니다: %+v", got)
+	}
+	if !strings.Contains(w.Body.String(), `"status":"shadow"`) {
+		t.Errorf("응답이 올바르지 않습니다: %s", w.Body.String())
+	}
+}
+
+func TestFingerprintLibrary_Routes(t *testing.T) {
+	h := &RestHandler{measurement: &mockMeasurementClient{}}
+	mux := h.SetupRoutes()
+
+	req := httptest.NewRequest("POST", "/api/v1/admin/fingerprints/ref-9/review", strings.NewReader(`{"status":"approved","reviewer_id":"admin-1"}`))
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+	assertStatus(t, w.Code, http.StatusOK)
+	if !strings.Contains(w.Body.String(), `"referenceId":"ref-9"`) || !strings.Contains(w.Body.String(), `"status":"approved"`) {
+		t.Errorf("검토 응답이 올바르지 않습니다: %s", w.Body.String())
+	}
+
+	// 본문 없이도 기본 k·임계값으로 식별
+	req = httptest.NewRequest("POST", "/api/v1/measurements/sess-1/identify", nil)
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+	assertStatus(t, w.Code, http.StatusOK)
+	if !strings.Contains(w.Body.String(), `"unknown":true`) {
+		t.Errorf("식별 응답이 올바르지 않습니다: %s", w.Body.String())
+	}
+}
+
+func TestStreamChat_ErrorBeforeFirstChunk(t *testing.T) {
+	client := &fakeAiInferenceClient{stream: &fakeChatStream{err: status.Error(codes.ResourceExhausted, "token budget exceeded")}}
+	h := &RestHandler{aiInference: client}
+	req := httptest.NewRequest("POST", "/api/v1/ai/chat/stream", strings.NewReader(`{"user_id":"u1","message":"hi"}`))
+	w := httptest.NewRecorder()
+	h.handleStreamChat(w, req)
+
+	assertStatus(t, w.Code, http.StatusTooManyRequests)
+	assertErrorContains(t, w.Body.Bytes(), "token budget exceeded")
+}
+
+func TestStreamChat_ErrorMidStream(t *testing.T) {
+	client := &fakeAiInferenceClient{stream: &fakeChatStream{
+		msgs: []*v1.StreamChatResponse{{Chunk: "부분", SessionId: "chat_1"}},
+		err:  status.Error(codes.Unavailable, "provider down"),
+	}}
+	h := &RestHandler{aiInference: client}
+	req := httptest.NewRequest("POST", "/api/v1/ai/chat/stream", strings.NewReader(`{"user_id":"u1","message":"hi"}`))
+	w := httptest.NewRecorder()
+	h.handleStreamChat(w, req)
+
+	assertStatus(t, w.Code, http.StatusOK)
+	if !strings.Contains(w.Body.String(), "event: error\ndata: ") || !strings.Contains(w.Body.String(), "provider down") {
+		t.Errorf("error 이벤트가 없습니다:\n%s", w.Body.String())
+	}
+}
+
 // ---------------------------------------------------------------------------
 // 유틸리티 함수 테스트
 // ---------------------------------------------------------------------------
@@ -385,6 +591,48 @@ func TestSetupRoutes_HealthCheck(t *testing.T) {
 	assertStatus(t, w.Code, http.StatusOK)
 }
 
+// fakeEnvironmentClient는 EnvironmentServiceClient 테스트 대역입니다.
+type fakeEnvironmentClient struct {
+	submitReq *v1.SubmitEnvironmentReadingRequest
+}
+
+func (c *fakeEnvironmentClient) SetEnvironmentSharingConsent(_ context.Context, req *v1.SetEnvironmentSharingConsentRequest, _ ...grpc.CallOption) (*v1.SetEnvironmentSharingConsentResponse, error) {
+	return &v1.SetEnvironmentSharingConsentResponse{OptIn: req.GetOptIn()}, nil
+}
+
+func (c *fakeEnvironmentClient) SubmitEnvironmentReading(_ context.Context, req *v1.SubmitEnvironmentReadingRequest, _ ...grpc.CallOption) (*v1.SubmitEnvironmentReadingResponse, error) {
+	c.submitReq = req
+	if req.GetMetric() == "" {
+		return nil, status.Error(codes.InvalidArgument, "지원하지 않는 환경 지표입니다")
+	}
+	return &v1.SubmitEnvironmentReadingResponse{ReadingId: "env-1", Metric: req.GetMetric(), Unit: req.GetUnit()}, nil
+}
+
+func TestEnvironment_Routes(t *testing.T) {
+	client := &fakeEnvironmentClient{}
+	h := &RestHandler{environment: client}
+	mux := h.SetupRoutes()
+
+	req := httptest.NewRequest("PUT", "/api/v1/environment/consent", strings.NewReader(`{"user_id":"u1","opt_in":true}`))
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+	assertStatus(t, w.Code, http.StatusOK)
+
+	body := `{"user_id":"u1","metric":"radon","value":120,"unit":"Bq/m3","country_code":"KR","region_code":"seoul","latitude":37.49,"longitude":127.02,"measured_at":"2026-10-01T09:00:00Z"}`
+	req = httptest.NewRequest("POST", "/api/v1/environment/readings", strings.NewReader(body))
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+	assertStatus(t, w.Code, http.StatusCreated)
+	if !client.submitReq.GetHasLocation() || client.submitReq.GetLatitude() != 37.49 || client.submitReq.GetMeasuredAt() == nil {
+		t.Errorf("요청 변환이 올바르지 않습니다: %+v", client.submitReq)
+	}
+
+	req = httptest.NewRequest("POST", "/api/v1/environment/readings", strings.NewReader(`{"user_id":"u1"}`))
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+	assertStatus(t, w.Code, http.StatusBadRequest)
+}
+
 func TestSetupRoutes_AuthLogin_ViaMux(t *testing.T) {
 	h := newNilHandler()
 	mux := h.SetupRoutes()
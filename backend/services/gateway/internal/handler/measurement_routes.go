@@ -51,6 +51,9 @@ func (h *RestHandler) registerMeasurementRoutes(mux *http.ServeMux) {
 	mux.HandleFunc("POST /api/v1/measurements/{sessionId}/export", h.handleExportSingleMeasurement)
 	mux.HandleFunc("POST /api/v1/measurements/export/fhir", h.handleExportToFHIRObservations)
 
+	// Measurement substance identification (fingerprint reference library kNN)
+	mux.HandleFunc("POST /api/v1/measurements/{sessionId}/identify", h.handleIdentifySubstance)
+
 	// Device – OTA & status
 	mux.HandleFunc("POST /api/v1/devices/{deviceId}/ota", h.handleRequestOtaUpdate)
 	mux.HandleFunc("PUT /api/v1/devices/{deviceId}/status", h.handleUpdateDeviceStatus)
@@ -119,6 +122,35 @@ func (h *RestHandler) handleGetMeasurementHistory(w http.ResponseWriter, r *http
 	writeProtoJSON(w, http.StatusOK, resp)
 }
 
+func (h *RestHandler) handleIdentifySubstance(w http.ResponseWriter, r *http.Request) {
+	if h.measurement == nil {
+		writeError(w, http.StatusServiceUnavailable, "measurement service unavailable")
+		return
+	}
+	var body struct {
+		Matrix      string  `json:"matrix"`
+		K           int32   `json:"k"`
+		MaxDistance float32 `json:"max_distance"`
+	}
+	if r.ContentLength != 0 {
+		if err := readJSON(r, &body); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid request body")
+			return
+		}
+	}
+	resp, err := h.measurement.IdentifySubstance(r.Context(), &v1.IdentifySubstanceRequest{
+		SessionId:   r.PathValue("sessionId"),
+		Matrix:      body.Matrix,
+		K:           body.K,
+		MaxDistance: body.MaxDistance,
+	})
+	if err != nil {
+		writeError(w, grpcHTTPStatus(err), err.Error())
+		return
+	}
+	writeProtoJSON(w, http.StatusOK, resp)
+}
+
 // ── Device ──
 
 func (h *RestHandler) handleRegisterDevice(w http.ResponseWriter, r *http.Request) {
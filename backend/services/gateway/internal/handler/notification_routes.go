@@ -20,6 +20,10 @@ func (h *RestHandler) registerNotificationRoutes(mux *http.ServeMux) {
 	// 개별 알림 조회 + 푸시 토큰 등록
 	mux.HandleFunc("GET /api/v1/notifications/alerts/{alertId}", h.handleGetAlert)
 	mux.HandleFunc("POST /api/v1/notifications/push-token", h.handleRegisterPushToken)
+
+	// 긴급 알림 에스컬레이션
+	mux.HandleFunc("GET /api/v1/notifications/escalations", h.handleListActiveEscalations)
+	mux.HandleFunc("POST /api/v1/notifications/escalations/{escalationId}/ack", h.handleAcknowledgeEscalation)
 }
 
 func (h *RestHandler) handleSendNotification(w http.ResponseWriter, r *http.Request) {
@@ -196,6 +200,7 @@ func (h *RestHandler) handleSendFromTemplate(w http.ResponseWriter, r *http.Requ
 		TemplateKey string            `json:"template_key"`
 		UserID      string            `json:"user_id"`
 		Data        map[string]string `json:"data"`
+		Language    string            `json:"language"`
 	}
 	if err := readJSON(r, &body); err != nil {
 		writeError(w, http.StatusBadRequest, "invalid request body")
@@ -205,9 +210,10 @@ func (h *RestHandler) handleSendFromTemplate(w http.ResponseWriter, r *http.Requ
 		TemplateKey: body.TemplateKey,
 		UserId:      body.UserID,
 		Data:        body.Data,
+		Language:    body.Language,
 	})
 	if err != nil {
-		writeError(w, http.StatusBadRequest, err.Error())
+		writeError(w, grpcHTTPStatus(err), err.Error())
 		return
 	}
 	writeProtoJSON(w, http.StatusCreated, resp)
@@ -264,3 +270,45 @@ func (h *RestHandler) handleRegisterPushToken(w http.ResponseWriter, r *http.Req
 	}
 	writeProtoJSON(w, http.StatusOK, resp)
 }
+
+// ── 긴급 알림 에스컬레이션 ──
+
+func (h *RestHandler) handleListActiveEscalations(w http.ResponseWriter, r *http.Request) {
+	if h.notification == nil {
+		writeError(w, http.StatusServiceUnavailable, "notification service unavailable")
+		return
+	}
+	resp, err := h.notification.ListActiveEscalations(r.Context(), &v1.ListActiveEscalationsRequest{
+		UserId: r.URL.Query().Get("user_id"),
+	})
+	if err != nil {
+		writeError(w, grpcHTTPStatus(err), err.Error())
+		return
+	}
+	writeProtoJSON(w, http.StatusOK, resp)
+}
+
+func (h *RestHandler) handleAcknowledgeEscalation(w http.ResponseWriter, r *http.Request) {
+	if h.notification == nil {
+		writeError(w, http.StatusServiceUnavailable, "notification service unavailable")
+		return
+	}
+	var body struct {
+		ResolvedBy string `json:"resolved_by"`
+	}
+	if r.ContentLength != 0 {
+		if err := readJSON(r, &body); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid request body")
+			return
+		}
+	}
+	resp, err := h.notification.AcknowledgeEscalation(r.Context(), &v1.AcknowledgeEscalationRequest{
+		EscalationId: r.PathValue("escalationId"),
+		ResolvedBy:   body.ResolvedBy,
+	})
+	if err != nil {
+		writeError(w, grpcHTTPStatus(err), err.Error())
+		return
+	}
+	writeProtoJSON(w, http.StatusOK, resp)
+}
@@ -36,6 +36,7 @@ type RestHandler struct {
 	notification v1.NotificationServiceClient
 	translation  v1.TranslationServiceClient
 	telemedicine v1.TelemedicineServiceClient
+	environment  v1.EnvironmentServiceClient
 
 	jwtSecret string
 }
@@ -63,6 +64,7 @@ func NewRestHandler(
 	notification v1.NotificationServiceClient,
 	translation v1.TranslationServiceClient,
 	telemedicine v1.TelemedicineServiceClient,
+	environment v1.EnvironmentServiceClient,
 	jwtSecret string,
 ) *RestHandler {
 	return &RestHandler{
@@ -72,7 +74,7 @@ func NewRestHandler(
 		coaching: coaching, reservation: reservation, admin: admin,
 		family: family, healthRecord: healthRecord, prescription: prescription,
 		community: community, video: video, notification: notification,
-		translation: translation, telemedicine: telemedicine,
+		translation: translation, telemedicine: telemedicine, environment: environment,
 		jwtSecret: jwtSecret,
 	}
 }
@@ -99,6 +101,7 @@ func (h *RestHandler) SetupRoutes() *http.ServeMux {
 	h.registerSubscriptionRoutes(mux)
 	h.registerCoachingRoutes(mux)
 	h.registerAdminRoutes(mux)
+	h.registerEnvironmentRoutes(mux)
 
 	return mux
 }
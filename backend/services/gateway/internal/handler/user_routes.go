@@ -1,9 +1,16 @@
 package handler
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
+	"time"
 
 	v1 "github.com/manpasik/backend/shared/gen/go/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // registerUserRoutes는 사용자/AI 추론 관련 REST 엔드포인트를 등록합니다.
@@ -21,6 +28,7 @@ func (h *RestHandler) registerUserRoutes(mux *http.ServeMux) {
 	mux.HandleFunc("GET /api/v1/ai/health-score/{userId}", h.handleGetHealthScore)
 	mux.HandleFunc("POST /api/v1/ai/predict-trend", h.handlePredictTrend)
 	mux.HandleFunc("GET /api/v1/ai/models", h.handleListAiModels)
+	mux.HandleFunc("POST /api/v1/ai/chat/stream", h.handleStreamChat)
 }
 
 // ── User ──
@@ -216,3 +224,117 @@ func (h *RestHandler) handleListAiModels(w http.ResponseWriter, r *http.Request)
 	}
 	writeProtoJSON(w, http.StatusOK, resp)
 }
+
+// handleStreamChat은 AI 건강 상담 응답을 Server-Sent Events로 전달합니다.
+//
+//	event: chunk  data: {"session_id": "...", "delta": "..."}
+//	event: done   data: {"session_id": "...", "full_response": "...", "tokens_used": N}
+//	event: error  data: {"error": "..."}
+//
+// 첫 메시지 수신 전 오류는 일반 JSON 오류 응답으로, 스트림 도중 오류는 error 이벤트로 전달합니다.
+func (h *RestHandler) handleStreamChat(w http.ResponseWriter, r *http.Request) {
+	if h.aiInference == nil {
+		writeError(w, http.StatusServiceUnavailable, "ai-inference service unavailable")
+		return
+	}
+	var body struct {
+		UserID                string   `json:"user_id"`
+		SessionID             string   `json:"session_id"`
+		Message               string   `json:"message"`
+		ContextMeasurementIDs []string `json:"context_measurement_ids"`
+	}
+	if err := readJSON(r, &body); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	stream, err := h.aiInference.StreamChat(r.Context(), &v1.StreamChatRequest{
+		UserId:                body.UserID,
+		SessionId:             body.SessionID,
+		Message:               body.Message,
+		ContextMeasurementIds: body.ContextMeasurementIDs,
+	})
+	if err != nil {
+		writeError(w, grpcHTTPStatus(err), err.Error())
+		return
+	}
+	first, err := stream.Recv()
+	if err != nil {
+		writeError(w, grpcHTTPStatus(err), err.Error())
+		return
+	}
+
+	// 스트림은 서버 WriteTimeout보다 길어질 수 있으므로 쓰기 기한을 해제 (미지원 Writer는 무시)
+	rc := http.NewResponseController(w)
+	_ = rc.SetWriteDeadline(time.Time{})
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	for msg := first; ; {
+		if msg.IsFinal {
+			done := map[string]any{
+				"session_id":    msg.SessionId,
+				"full_response": msg.FullResponse,
+				"tokens_used":   msg.TokensUsed,
+			}
+			if len(msg.Sources) > 0 {
+				sources := make([]map[string]any, len(msg.Sources))
+				for i, src := range msg.Sources {
+					sources[i] = map[string]any{
+						"index":       src.Index,
+						"source_type": src.SourceType,
+						"source_id":   src.SourceId,
+						"title":       src.Title,
+						"recorded_at": src.RecordedAt,
+					}
+				}
+				done["sources"] = sources
+			}
+			writeSSE(w, rc, "done", done)
+			return
+		}
+		if !writeSSE(w, rc, "chunk", map[string]any{"session_id": msg.SessionId, "delta": msg.Chunk}) {
+			return // 클라이언트 연결 종료
+		}
+		if msg, err = stream.Recv(); err != nil {
+			if !errors.Is(err, io.EOF) {
+				writeSSE(w, rc, "error", map[string]string{"error": err.Error()})
+			}
+			return
+		}
+	}
+}
+
+// writeSSE는 SSE 이벤트 하나를 쓰고 즉시 플러시합니다. 쓰기에 실패하면 false를 반환합니다.
+func writeSSE(w http.ResponseWriter, rc *http.ResponseController, event string, data any) bool {
+	b, err := json.Marshal(data)
+	if err != nil {
+		return false
+	}
+	if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, b); err != nil {
+		return false
+	}
+	return rc.Flush() == nil
+}
+
+// grpcHTTPStatus는 gRPC 상태 코드를 HTTP 상태 코드로 변환합니다.
+func grpcHTTPStatus(err error) int {
+	switch status.Code(err) {
+	case codes.InvalidArgument:
+		return http.StatusBadRequest
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -130,3 +130,8 @@ func (rw *responseWriter) WriteHeader(code int) {
 	rw.statusCode = code
 	rw.ResponseWriter.WriteHeader(code)
 }
+
+// Unwrap은 http.ResponseController가 Flush 등 원본 Writer 기능에 접근할 수 있게 합니다 (SSE 스트리밍).
+func (rw *responseWriter) Unwrap() http.ResponseWriter {
+	return rw.ResponseWriter
+}
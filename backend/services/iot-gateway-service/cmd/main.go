@@ -1,39 +1,137 @@
 // iot-gateway-service: IoT 디바이스 게이트웨이 마이크로서비스
 //
-// 포트: HTTP :8080
-// 의존: 없음 — 인메모리 저장소 사용
+// 포트: HTTP :8080, MQTT :1883
+// 의존: PostgreSQL(선택, 데이터 규칙) — 미설정 시 인메모리 저장소 사용, KAFKA_BROKERS 설정 시 명령 실패·규칙 발동 이벤트 발행
 //
 // 기능:
 // - IoT 디바이스 등록 / 조회
-// - 디바이스 명령 전송
+// - 디바이스 명령 전송 (queued → sent → delivered → acked/failed/expired, TTL·백오프 재시도)
 // - 디바이스 데이터 수신
+// - 데이터 규칙 엔진 (임계값·윈도우 집계, 히스테리시스·쿨다운, 알림/명령 동작)
+// - 내장 MQTT 브로커 (디바이스별 인증, data/status 토픽 수신, command 토픽 발행, Last Will 기반 offline 감지)
 // - 헬스 체크 엔드포인트
 package main
 
 import (
+	"context"
 	"log"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"strconv"
+	"strings"
 	"syscall"
+	"time"
 
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/manpasik/backend/services/iot-gateway-service/internal/handler"
+	kafkaPublisher "github.com/manpasik/backend/services/iot-gateway-service/internal/repository/kafka"
 	"github.com/manpasik/backend/services/iot-gateway-service/internal/repository/memory"
+	"github.com/manpasik/backend/services/iot-gateway-service/internal/repository/postgres"
 	"github.com/manpasik/backend/services/iot-gateway-service/internal/service"
+	"github.com/manpasik/backend/shared/config"
+	"github.com/manpasik/backend/shared/events"
 )
 
 const serviceName = "iot-gateway-service"
 
 func main() {
+	cfg := config.LoadFromEnv(serviceName)
+
 	httpPort := os.Getenv("HTTP_PORT")
 	if httpPort == "" {
 		httpPort = ":8080"
 	}
 
+	mqttPort := os.Getenv("MQTT_PORT")
+	if mqttPort == "" {
+		mqttPort = ":1883"
+	}
+
 	log.Printf("[%s] Starting...", serviceName)
 
 	repo := memory.NewIoTRepository()
 	svc := service.NewIoTGatewayService(repo)
-	_ = svc
+	svc.SetCommandPolicy(commandPolicyFromEnv())
+
+	// RuleRepository: PostgreSQL 또는 인메모리
+	// DB_HOST 환경변수가 명시적으로 설정된 경우에만 PostgreSQL 사용 시도
+	var ruleRepo service.RuleRepository = memory.NewRuleRepository()
+	if _, dbHostSet := os.LookupEnv("DB_HOST"); dbHostSet && cfg.DB.Host != "" && cfg.DB.DBName != "" {
+		connCtx, connCancel := context.WithTimeout(context.Background(), 5*time.Second)
+		pool, err := pgxpool.New(connCtx, cfg.DB.DSN())
+		connCancel()
+		if err != nil {
+			log.Printf("[%s] DB 풀 생성 실패, 인메모리 규칙 저장소 사용: %v", serviceName, err)
+		} else {
+			pingCtx, pingCancel := context.WithTimeout(context.Background(), 3*time.Second)
+			pingErr := pool.Ping(pingCtx)
+			pingCancel()
+			if pingErr != nil {
+				pool.Close()
+				log.Printf("[%s] DB Ping 실패, 인메모리 규칙 저장소 사용: %v", serviceName, pingErr)
+			} else {
+				defer pool.Close()
+				ruleRepo = postgres.NewRuleRepository(pool)
+				log.Printf("[%s] DB 연결됨: %s", serviceName, cfg.DB.DBName)
+			}
+		}
+	} else {
+		log.Printf("[%s] 인메모리 규칙 저장소 사용", serviceName)
+	}
+	svc.SetRuleRepository(ruleRepo)
+
+	// 명령 최종 실패·규칙 발동 이벤트: Kafka(Redpanda) 설정 시에만 발행
+	if brokers := os.Getenv("KAFKA_BROKERS"); brokers != "" {
+		eventBus, kafkaErr := events.NewKafkaEventBus(events.KafkaAdapterConfig{
+			Brokers:     strings.Split(brokers, ","),
+			GroupID:     serviceName,
+			TopicPrefix: "manpasik.",
+		})
+		if kafkaErr != nil {
+			log.Printf("[%s] Kafka 연결 실패, 이벤트 미발행: %v", serviceName, kafkaErr)
+		} else {
+			defer eventBus.Close()
+			publisher := kafkaPublisher.NewEventPublisher(eventBus)
+			svc.SetEventPublisher(publisher)
+			svc.SetRuleEventPublisher(publisher)
+			log.Printf("[%s] Kafka 연결됨: %s", serviceName, brokers)
+		}
+	}
+
+	mqttLis, err := net.Listen("tcp", mqttPort)
+	if err != nil {
+		log.Fatalf("[%s] MQTT listen error: %v", serviceName, err)
+	}
+	mqttAdapter, err := handler.NewMQTTAdapter(svc, mqttLis)
+	if err != nil {
+		log.Fatalf("[%s] MQTT adapter error: %v", serviceName, err)
+	}
+	if err := mqttAdapter.Start(); err != nil {
+		log.Fatalf("[%s] MQTT broker error: %v", serviceName, err)
+	}
+	defer mqttAdapter.Close()
+	svc.SetCommandPublisher(mqttAdapter)
+	log.Printf("[%s] MQTT broker on %s", serviceName, mqttPort)
+
+	// 명령 재발행·만료 스윕
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		ticker := time.NewTicker(time.Second)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := svc.ProcessCommands(ctx); err != nil {
+					log.Printf("[%s] 명령 스윕 실패: %v", serviceName, err)
+				}
+			}
+		}
+	}()
 
 	mux := http.NewServeMux()
 	mux.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
@@ -54,3 +152,23 @@ func main() {
 	sig := <-sigCh
 	log.Printf("[%s] Received signal %v, shutting down...", serviceName, sig)
 }
+
+// commandPolicyFromEnv는 IOT_COMMAND_TTL, IOT_COMMAND_MAX_ATTEMPTS,
+// IOT_COMMAND_BACKOFF, IOT_COMMAND_MAX_BACKOFF 환경 변수로 명령 정책을 구성합니다.
+// 설정하지 않거나 잘못된 값은 기본값을 사용합니다.
+func commandPolicyFromEnv() service.CommandPolicy {
+	p := service.DefaultCommandPolicy()
+	if d, err := time.ParseDuration(os.Getenv("IOT_COMMAND_TTL")); err == nil {
+		p.TTL = d
+	}
+	if n, err := strconv.Atoi(os.Getenv("IOT_COMMAND_MAX_ATTEMPTS")); err == nil {
+		p.MaxAttempts = n
+	}
+	if d, err := time.ParseDuration(os.Getenv("IOT_COMMAND_BACKOFF")); err == nil {
+		p.InitialBackoff = d
+	}
+	if d, err := time.ParseDuration(os.Getenv("IOT_COMMAND_MAX_BACKOFF")); err == nil {
+		p.MaxBackoff = d
+	}
+	return p
+}
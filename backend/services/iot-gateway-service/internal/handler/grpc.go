@@ -5,6 +5,7 @@ import (
 	"context"
 
 	"github.com/manpasik/backend/services/iot-gateway-service/internal/service"
+	"github.com/manpasik/backend/shared/middleware"
 )
 
 // IoTGatewayHandler는 IoTGatewayService를 래핑하는 핸들러입니다.
@@ -22,11 +23,26 @@ func (h *IoTGatewayHandler) RegisterDevice(ctx context.Context, deviceID, protoc
 	return h.svc.RegisterDevice(ctx, deviceID, protocol, meta)
 }
 
+// RotateDeviceCredential은 디바이스 접속 비밀번호 교체를 처리합니다.
+func (h *IoTGatewayHandler) RotateDeviceCredential(ctx context.Context, deviceID, currentCredential string) (*service.IoTDevice, error) {
+	return h.svc.RotateDeviceCredential(ctx, deviceID, currentCredential)
+}
+
 // SendCommand는 디바이스 명령 전송을 처리합니다.
 func (h *IoTGatewayHandler) SendCommand(ctx context.Context, deviceID, commandType, payload string) (*service.IoTCommand, error) {
 	return h.svc.SendCommand(ctx, deviceID, commandType, payload)
 }
 
+// AcknowledgeCommand는 디바이스의 명령 실행 결과 응답을 처리합니다.
+func (h *IoTGatewayHandler) AcknowledgeCommand(ctx context.Context, deviceID, commandID string, success bool, message string) (*service.IoTCommand, error) {
+	return h.svc.AcknowledgeCommand(ctx, deviceID, commandID, success, message)
+}
+
+// ListCommands는 명령 목록 조회를 처리합니다.
+func (h *IoTGatewayHandler) ListCommands(ctx context.Context, deviceID, status string, limit, offset int) ([]*service.IoTCommand, error) {
+	return h.svc.ListCommands(ctx, deviceID, status, limit, offset)
+}
+
 // ReceiveData는 디바이스 데이터 수신을 처리합니다.
 func (h *IoTGatewayHandler) ReceiveData(ctx context.Context, deviceID, dataType string, value float64, unit string) (*service.IoTData, error) {
 	return h.svc.ReceiveData(ctx, deviceID, dataType, value, unit)
@@ -46,3 +62,29 @@ func (h *IoTGatewayHandler) GetCommand(ctx context.Context, commandID string) (*
 func (h *IoTGatewayHandler) ListData(ctx context.Context, deviceID string, limit, offset int) ([]*service.IoTData, error) {
 	return h.svc.ListData(ctx, deviceID, limit, offset)
 }
+
+// CreateRule은 데이터 규칙 생성을 처리합니다. 관리자 여부는 인증 인터셉터가 넣은 역할로 판단합니다.
+func (h *IoTGatewayHandler) CreateRule(ctx context.Context, rule *service.Rule) (*service.Rule, error) {
+	role, _ := middleware.UserRoleFromContext(ctx)
+	return h.svc.CreateRule(ctx, rule, role == middleware.RoleAdmin)
+}
+
+// ListRules는 규칙 목록 조회를 처리합니다.
+func (h *IoTGatewayHandler) ListRules(ctx context.Context, ownerID string) ([]*service.Rule, error) {
+	return h.svc.ListRules(ctx, ownerID)
+}
+
+// SetRuleEnabled는 규칙 활성화/비활성화를 처리합니다.
+func (h *IoTGatewayHandler) SetRuleEnabled(ctx context.Context, ruleID, ownerID string, enabled bool) error {
+	return h.svc.SetRuleEnabled(ctx, ruleID, ownerID, enabled)
+}
+
+// DeleteRule은 규칙 삭제를 처리합니다.
+func (h *IoTGatewayHandler) DeleteRule(ctx context.Context, ruleID, ownerID string) error {
+	return h.svc.DeleteRule(ctx, ruleID, ownerID)
+}
+
+// ListRuleTriggers는 규칙 발동 이력 조회를 처리합니다.
+func (h *IoTGatewayHandler) ListRuleTriggers(ctx context.Context, ruleID, ownerID string, limit int) ([]*service.RuleTrigger, error) {
+	return h.svc.ListRuleTriggers(ctx, ruleID, ownerID, limit)
+}
@@ -0,0 +1,362 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"log/slog"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	mqtt "github.com/mochi-mqtt/server/v2"
+	"github.com/mochi-mqtt/server/v2/listeners"
+	"github.com/mochi-mqtt/server/v2/packets"
+
+	"github.com/manpasik/backend/services/iot-gateway-service/internal/service"
+)
+
+// MQTT 토픽 구조 (deviceId는 접속 username·client ID와 같아야 함)
+//
+//	manpasik/{deviceId}/data/{dataType}        디바이스 → 게이트웨이 측정 데이터
+//	manpasik/{deviceId}/status                 디바이스 → 게이트웨이 연결 상태 (online/offline, Last Will)
+//	manpasik/{deviceId}/command/{commandType}  게이트웨이 → 디바이스 명령 (QoS 1, PUBACK = delivered)
+//	manpasik/{deviceId}/ack                    디바이스 → 게이트웨이 명령 실행 결과
+const (
+	topicRoot       = "manpasik"
+	topicKindData   = "data"
+	topicKindStatus = "status"
+	topicKindCmd    = "command"
+	topicKindAck    = "ack"
+
+	statusPayloadOnline  = "online"
+	statusPayloadOffline = "offline"
+)
+
+// DataTopic은 디바이스 데이터 토픽을 반환합니다.
+func DataTopic(deviceID, dataType string) string {
+	return topicRoot + "/" + deviceID + "/" + topicKindData + "/" + dataType
+}
+
+// StatusTopic은 디바이스 상태(Last Will) 토픽을 반환합니다.
+func StatusTopic(deviceID string) string {
+	return topicRoot + "/" + deviceID + "/" + topicKindStatus
+}
+
+// CommandTopic은 디바이스 명령 토픽을 반환합니다.
+func CommandTopic(deviceID, commandType string) string {
+	return topicRoot + "/" + deviceID + "/" + topicKindCmd + "/" + commandType
+}
+
+// AckTopic은 디바이스 명령 응답 토픽을 반환합니다.
+func AckTopic(deviceID string) string {
+	return topicRoot + "/" + deviceID + "/" + topicKindAck
+}
+
+// parseTopic은 manpasik/{deviceId}/{kind}[/{sub}] 토픽을 분해합니다.
+func parseTopic(topic string) (deviceID, kind, sub string, ok bool) {
+	parts := strings.Split(topic, "/")
+	if len(parts) < 3 || len(parts) > 4 || parts[0] != topicRoot || parts[1] == "" {
+		return "", "", "", false
+	}
+	if len(parts) == 4 {
+		sub = parts[3]
+	}
+	return parts[1], parts[2], sub, true
+}
+
+// commandMessage는 명령 토픽으로 발행되는 페이로드입니다.
+type commandMessage struct {
+	CommandID   string `json:"command_id"`
+	CommandType string `json:"command_type"`
+	Payload     string `json:"payload,omitempty"`
+}
+
+// ackMessage는 응답 토픽 페이로드입니다. success가 없으면 성공으로 간주합니다.
+type ackMessage struct {
+	CommandID string `json:"command_id"`
+	Success   *bool  `json:"success"`
+	Message   string `json:"message"`
+}
+
+// dataMessage는 데이터 토픽 페이로드입니다. 숫자만 보낸 경우 단위는 비어 있습니다.
+type dataMessage struct {
+	Value *float64 `json:"value"`
+	Unit  string   `json:"unit"`
+}
+
+// MQTTAdapter는 내장 MQTT 브로커로 디바이스 데이터·상태를 수신하고 명령을 발행합니다.
+// MQTT 3.1.1과 5를 모두 지원합니다.
+type MQTTAdapter struct {
+	svc    *service.IoTGatewayService
+	server *mqtt.Server
+}
+
+// NewMQTTAdapter는 주어진 리스너에서 동작하는 MQTTAdapter를 생성합니다.
+func NewMQTTAdapter(svc *service.IoTGatewayService, lis net.Listener) (*MQTTAdapter, error) {
+	server := mqtt.New(&mqtt.Options{
+		InlineClient: true,
+		Logger:       slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelWarn})),
+	})
+
+	a := &MQTTAdapter{svc: svc, server: server}
+	if err := server.AddHook(&deviceAuthHook{svc: svc}, nil); err != nil {
+		return nil, err
+	}
+	if err := server.AddHook(&commandDeliveryHook{svc: svc, inflight: make(map[string]string)}, nil); err != nil {
+		return nil, err
+	}
+	if err := server.AddListener(listeners.NewNet("iot-mqtt", lis)); err != nil {
+		return nil, err
+	}
+	if err := server.Subscribe(topicRoot+"/+/"+topicKindData+"/+", 1, a.onData); err != nil {
+		return nil, err
+	}
+	if err := server.Subscribe(topicRoot+"/+/"+topicKindStatus, 2, a.onStatus); err != nil {
+		return nil, err
+	}
+	if err := server.Subscribe(topicRoot+"/+/"+topicKindAck, 3, a.onAck); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// Start는 브로커 리스너를 시작합니다 (비차단).
+func (a *MQTTAdapter) Start() error {
+	return a.server.Serve()
+}
+
+// Close는 브로커를 종료합니다.
+func (a *MQTTAdapter) Close() error {
+	return a.server.Close()
+}
+
+// PublishCommand는 명령을 디바이스 명령 토픽으로 발행합니다 (service.CommandPublisher 구현).
+func (a *MQTTAdapter) PublishCommand(_ context.Context, cmd *service.IoTCommand) error {
+	payload, err := json.Marshal(commandMessage{
+		CommandID:   cmd.ID,
+		CommandType: cmd.CommandType,
+		Payload:     cmd.Payload,
+	})
+	if err != nil {
+		return err
+	}
+	return a.server.Publish(CommandTopic(cmd.DeviceID, cmd.CommandType), payload, false, 1)
+}
+
+// onData는 데이터 토픽 메시지를 ReceiveData로 전달합니다.
+func (a *MQTTAdapter) onData(_ *mqtt.Client, _ packets.Subscription, pk packets.Packet) {
+	deviceID, _, dataType, ok := parseTopic(pk.TopicName)
+	if !ok || dataType == "" {
+		return
+	}
+	value, unit, err := parseDataPayload(pk.Payload)
+	if err != nil {
+		log.Printf("[mqtt] 데이터 페이로드 오류 (topic=%s): %v", pk.TopicName, err)
+		return
+	}
+	if _, err := a.svc.ReceiveData(context.Background(), deviceID, dataType, value, unit); err != nil {
+		log.Printf("[mqtt] 데이터 수신 실패 (device=%s): %v", deviceID, err)
+	}
+}
+
+// onStatus는 상태 토픽(디바이스 보고 또는 Last Will)으로 연결 상태를 갱신합니다.
+func (a *MQTTAdapter) onStatus(_ *mqtt.Client, _ packets.Subscription, pk packets.Packet) {
+	deviceID, _, _, ok := parseTopic(pk.TopicName)
+	if !ok {
+		return
+	}
+	var status string
+	switch string(bytes.TrimSpace(pk.Payload)) {
+	case statusPayloadOnline:
+		status = service.DeviceStatusOnline
+	case statusPayloadOffline:
+		status = service.DeviceStatusOffline
+	default:
+		return
+	}
+	if err := a.svc.UpdateDeviceStatus(context.Background(), deviceID, status); err != nil {
+		log.Printf("[mqtt] 상태 갱신 실패 (device=%s): %v", deviceID, err)
+	}
+}
+
+// onAck는 응답 토픽 메시지를 명령과 대조해 acked/failed로 종료합니다.
+func (a *MQTTAdapter) onAck(_ *mqtt.Client, _ packets.Subscription, pk packets.Packet) {
+	deviceID, _, _, ok := parseTopic(pk.TopicName)
+	if !ok {
+		return
+	}
+	var msg ackMessage
+	if err := json.Unmarshal(pk.Payload, &msg); err != nil || msg.CommandID == "" {
+		log.Printf("[mqtt] 응답 페이로드 오류 (topic=%s): %v", pk.TopicName, err)
+		return
+	}
+	success := msg.Success == nil || *msg.Success
+	if _, err := a.svc.AcknowledgeCommand(context.Background(), deviceID, msg.CommandID, success, msg.Message); err != nil {
+		log.Printf("[mqtt] 명령 응답 처리 실패 (device=%s): %v", deviceID, err)
+	}
+}
+
+// parseDataPayload는 {"value":..,"unit":..} JSON 또는 숫자 문자열을 해석합니다.
+func parseDataPayload(payload []byte) (float64, string, error) {
+	trimmed := bytes.TrimSpace(payload)
+	if len(trimmed) > 0 && trimmed[0] == '{' {
+		var msg dataMessage
+		if err := json.Unmarshal(trimmed, &msg); err != nil {
+			return 0, "", err
+		}
+		if msg.Value == nil {
+			return 0, "", errors.New("value 필드가 없습니다")
+		}
+		return *msg.Value, msg.Unit, nil
+	}
+	value, err := strconv.ParseFloat(string(trimmed), 64)
+	if err != nil {
+		return 0, "", err
+	}
+	return value, "", nil
+}
+
+// ============================================================================
+// deviceAuthHook — 디바이스별 인증·토픽 ACL
+// ============================================================================
+
+// deviceAuthHook은 디바이스별 자격 증명으로 접속을 인증하고
+// 각 디바이스가 자신의 토픽에만 접근하도록 제한합니다.
+type deviceAuthHook struct {
+	mqtt.HookBase
+	svc *service.IoTGatewayService
+}
+
+func (h *deviceAuthHook) ID() string {
+	return "manpasik-device-auth"
+}
+
+func (h *deviceAuthHook) Provides(b byte) bool {
+	return bytes.Contains([]byte{
+		mqtt.OnConnectAuthenticate,
+		mqtt.OnACLCheck,
+		mqtt.OnSessionEstablished,
+	}, []byte{b})
+}
+
+// OnConnectAuthenticate는 username=deviceId, password=발급 비밀번호를 확인합니다.
+// client ID는 deviceId와 같아야 하며, offline 상태를 알리는 Last Will이 필수입니다.
+func (h *deviceAuthHook) OnConnectAuthenticate(cl *mqtt.Client, pk packets.Packet) bool {
+	deviceID := string(pk.Connect.Username)
+	if deviceID == "" || cl.ID != deviceID {
+		return false
+	}
+	if !pk.Connect.WillFlag || pk.Connect.WillTopic != StatusTopic(deviceID) ||
+		string(bytes.TrimSpace(pk.Connect.WillPayload)) != statusPayloadOffline {
+		return false
+	}
+	return h.svc.AuthenticateDevice(context.Background(), deviceID, string(pk.Connect.Password))
+}
+
+// OnACLCheck는 디바이스가 자신의 data·status·ack 토픽에만 발행하고
+// 자신의 command 토픽만 구독하도록 제한합니다.
+func (h *deviceAuthHook) OnACLCheck(cl *mqtt.Client, topic string, write bool) bool {
+	deviceID := string(cl.Properties.Username)
+	if deviceID == "" {
+		return false
+	}
+	if write {
+		id, kind, sub, ok := parseTopic(topic)
+		if !ok || id != deviceID {
+			return false
+		}
+		switch kind {
+		case topicKindData:
+			return sub != ""
+		case topicKindStatus, topicKindAck:
+			return sub == ""
+		}
+		return false
+	}
+	return strings.HasPrefix(topic, topicRoot+"/"+deviceID+"/"+topicKindCmd+"/")
+}
+
+// OnSessionEstablished는 인증된 디바이스를 online으로 표시합니다.
+func (h *deviceAuthHook) OnSessionEstablished(cl *mqtt.Client, _ packets.Packet) {
+	if cl.Net.Inline {
+		return
+	}
+	deviceID := string(cl.Properties.Username)
+	if err := h.svc.UpdateDeviceStatus(context.Background(), deviceID, service.DeviceStatusOnline); err != nil {
+		log.Printf("[mqtt] 상태 갱신 실패 (device=%s): %v", deviceID, err)
+	}
+}
+
+// ============================================================================
+// commandDeliveryHook — 명령 수신 확인 추적
+// ============================================================================
+
+// commandDeliveryHook은 디바이스로 나간 QoS 1 명령 패킷을 추적하다가
+// 디바이스의 PUBACK을 받으면 해당 명령을 delivered로 표시합니다.
+type commandDeliveryHook struct {
+	mqtt.HookBase
+	svc *service.IoTGatewayService
+
+	mu       sync.Mutex
+	inflight map[string]string // key: clientID/packetID, value: 명령 ID
+}
+
+func (h *commandDeliveryHook) ID() string {
+	return "manpasik-command-delivery"
+}
+
+func (h *commandDeliveryHook) Provides(b byte) bool {
+	return bytes.Contains([]byte{
+		mqtt.OnQosPublish,
+		mqtt.OnQosComplete,
+		mqtt.OnQosDropped,
+	}, []byte{b})
+}
+
+// OnQosPublish는 명령 토픽 패킷의 packet ID와 명령 ID를 기록합니다.
+func (h *commandDeliveryHook) OnQosPublish(cl *mqtt.Client, pk packets.Packet, _ int64, _ int) {
+	if _, kind, _, ok := parseTopic(pk.TopicName); !ok || kind != topicKindCmd {
+		return
+	}
+	var msg commandMessage
+	if err := json.Unmarshal(pk.Payload, &msg); err != nil || msg.CommandID == "" {
+		return
+	}
+	h.mu.Lock()
+	h.inflight[inflightKey(cl, pk.PacketID)] = msg.CommandID
+	h.mu.Unlock()
+}
+
+// OnQosComplete는 PUBACK을 받은 명령을 delivered로 표시합니다.
+func (h *commandDeliveryHook) OnQosComplete(cl *mqtt.Client, pk packets.Packet) {
+	commandID, ok := h.take(cl, pk.PacketID)
+	if !ok {
+		return
+	}
+	if err := h.svc.MarkCommandDelivered(context.Background(), commandID); err != nil {
+		log.Printf("[mqtt] 명령 수신 확인 처리 실패 (command=%s): %v", commandID, err)
+	}
+}
+
+// OnQosDropped는 전달을 포기한 패킷의 추적을 정리합니다 (재발행은 서비스가 담당).
+func (h *commandDeliveryHook) OnQosDropped(cl *mqtt.Client, pk packets.Packet) {
+	h.take(cl, pk.PacketID)
+}
+
+func (h *commandDeliveryHook) take(cl *mqtt.Client, packetID uint16) (string, bool) {
+	key := inflightKey(cl, packetID)
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	commandID, ok := h.inflight[key]
+	delete(h.inflight, key)
+	return commandID, ok
+}
+
+func inflightKey(cl *mqtt.Client, packetID uint16) string {
+	return cl.ID + "/" + strconv.Itoa(int(packetID))
+}
@@ -0,0 +1,288 @@
+package handler_test
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/url"
+	"testing"
+	"time"
+
+	paho "github.com/eclipse/paho.mqtt.golang"
+
+	"github.com/manpasik/backend/services/iot-gateway-service/internal/handler"
+	"github.com/manpasik/backend/services/iot-gateway-service/internal/repository/memory"
+	"github.com/manpasik/backend/services/iot-gateway-service/internal/service"
+)
+
+// ============================================================================
+// 테스트 헬퍼 — 프로세스 내 MQTT 브로커
+// ============================================================================
+
+// startBroker는 임의 포트에서 MQTTAdapter를 시작하고 브로커 주소를 반환합니다.
+func startBroker(t *testing.T) (*service.IoTGatewayService, string) {
+	t.Helper()
+	svc := service.NewIoTGatewayService(memory.NewIoTRepository())
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("리스너 생성 실패: %v", err)
+	}
+	adapter, err := handler.NewMQTTAdapter(svc, lis)
+	if err != nil {
+		t.Fatalf("MQTTAdapter 생성 실패: %v", err)
+	}
+	if err := adapter.Start(); err != nil {
+		t.Fatalf("브로커 시작 실패: %v", err)
+	}
+	t.Cleanup(func() { _ = adapter.Close() })
+	svc.SetCommandPublisher(adapter)
+	return svc, lis.Addr().String()
+}
+
+// deviceOptions는 Last Will을 포함한 디바이스 접속 옵션을 만듭니다.
+func deviceOptions(addr, deviceID, password string) *paho.ClientOptions {
+	return paho.NewClientOptions().
+		AddBroker("tcp://"+addr).
+		SetClientID(deviceID).
+		SetUsername(deviceID).
+		SetPassword(password).
+		SetWill(handler.StatusTopic(deviceID), "offline", 1, false).
+		SetProtocolVersion(4).
+		SetAutoReconnect(false).
+		SetConnectRetry(false)
+}
+
+func connect(t *testing.T, opts *paho.ClientOptions) paho.Client {
+	t.Helper()
+	client := paho.NewClient(opts)
+	token := client.Connect()
+	if !token.WaitTimeout(3 * time.Second) {
+		t.Fatal("MQTT 접속 시간 초과")
+	}
+	if err := token.Error(); err != nil {
+		t.Fatalf("MQTT 접속 실패: %v", err)
+	}
+	t.Cleanup(func() { client.Disconnect(100) })
+	return client
+}
+
+func publish(t *testing.T, client paho.Client, topic, payload string) {
+	t.Helper()
+	token := client.Publish(topic, 1, false, payload)
+	if !token.WaitTimeout(3*time.Second) || token.Error() != nil {
+		t.Fatalf("발행 실패 (%s): %v", topic, token.Error())
+	}
+}
+
+// waitFor는 조건이 참이 될 때까지 최대 3초 대기합니다.
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(3 * time.Second)
+	for time.Now().Before(deadline) {
+		if cond() {
+			return
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	t.Fatalf("대기 시간 초과: %s", what)
+}
+
+func registerMQTTDevice(t *testing.T, svc *service.IoTGatewayService, deviceID string) string {
+	t.Helper()
+	device, err := svc.RegisterDevice(context.Background(), deviceID, service.ProtocolMQTT, nil)
+	if err != nil {
+		t.Fatalf("디바이스 등록 실패: %v", err)
+	}
+	if device.Credential == "" {
+		t.Fatal("MQTT 디바이스에는 접속 비밀번호가 발급되어야 합니다")
+	}
+	return device.Credential
+}
+
+// ============================================================================
+// MQTT 통합 테스트
+// ============================================================================
+
+func TestMQTT_데이터_토픽_수신(t *testing.T) {
+	svc, addr := startBroker(t)
+	ctx := context.Background()
+	password := registerMQTTDevice(t, svc, "mqtt-dev-1")
+
+	client := connect(t, deviceOptions(addr, "mqtt-dev-1", password))
+	publish(t, client, handler.DataTopic("mqtt-dev-1", "radon"), `{"value":152.5,"unit":"Bq/m3"}`)
+	publish(t, client, handler.DataTopic("mqtt-dev-1", "temperature"), "21.5")
+
+	waitFor(t, "데이터 2건 저장", func() bool {
+		data, _ := svc.ListData(ctx, "mqtt-dev-1", 10, 0)
+		return len(data) == 2
+	})
+	data, _ := svc.ListData(ctx, "mqtt-dev-1", 10, 0)
+	if data[0].DataType != "radon" || data[0].Value != 152.5 || data[0].Unit != "Bq/m3" {
+		t.Errorf("JSON 페이로드 해석 불일치: %+v", data[0])
+	}
+	if data[1].DataType != "temperature" || data[1].Value != 21.5 {
+		t.Errorf("숫자 페이로드 해석 불일치: %+v", data[1])
+	}
+
+	device, _ := svc.GetDevice(ctx, "mqtt-dev-1")
+	if device.Status != service.DeviceStatusOnline {
+		t.Errorf("접속한 디바이스는 online이어야 합니다: got %s", device.Status)
+	}
+}
+
+func TestMQTT_인증_실패(t *testing.T) {
+	svc, addr := startBroker(t)
+	password := registerMQTTDevice(t, svc, "mqtt-dev-1")
+
+	cases := map[string]*paho.ClientOptions{
+		"잘못된 비밀번호":      deviceOptions(addr, "mqtt-dev-1", "wrong"),
+		"미등록 디바이스":      deviceOptions(addr, "mqtt-dev-9", password),
+		"Last Will 없음":  deviceOptions(addr, "mqtt-dev-1", password).UnsetWill(),
+		"client ID 불일치": deviceOptions(addr, "mqtt-dev-1", password).SetClientID("other"),
+	}
+	for name, opts := range cases {
+		client := paho.NewClient(opts)
+		token := client.Connect()
+		token.WaitTimeout(3 * time.Second)
+		if token.Error() == nil {
+			client.Disconnect(100)
+			t.Errorf("%s: 접속이 거부되어야 합니다", name)
+		}
+	}
+}
+
+func TestMQTT_타_디바이스_토픽_발행_차단(t *testing.T) {
+	svc, addr := startBroker(t)
+	ctx := context.Background()
+	p1 := registerMQTTDevice(t, svc, "mqtt-dev-1")
+	registerMQTTDevice(t, svc, "mqtt-dev-2")
+
+	client := connect(t, deviceOptions(addr, "mqtt-dev-1", p1))
+	publish(t, client, handler.DataTopic("mqtt-dev-1", "radon"), "100")
+	waitFor(t, "본인 데이터 저장", func() bool {
+		data, _ := svc.ListData(ctx, "mqtt-dev-1", 10, 0)
+		return len(data) == 1
+	})
+
+	// MQTT 3.1.1은 발행 거부 응답이 없으므로 브로커가 연결을 끊음
+	client.Publish(handler.DataTopic("mqtt-dev-2", "radon"), 1, false, "999").WaitTimeout(time.Second)
+	waitFor(t, "권한 없는 발행 후 연결 종료", func() bool { return !client.IsConnectionOpen() })
+	if data, _ := svc.ListData(ctx, "mqtt-dev-2", 10, 0); len(data) != 0 {
+		t.Errorf("다른 디바이스 토픽으로 발행된 데이터는 저장되면 안 됩니다: got %d", len(data))
+	}
+}
+
+func TestMQTT_명령_토픽_발행(t *testing.T) {
+	svc, addr := startBroker(t)
+	ctx := context.Background()
+	password := registerMQTTDevice(t, svc, "mqtt-dev-1")
+
+	client := connect(t, deviceOptions(addr, "mqtt-dev-1", password))
+	received := make(chan paho.Message, 1)
+	token := client.Subscribe("manpasik/mqtt-dev-1/command/#", 1, func(_ paho.Client, m paho.Message) {
+		received <- m
+	})
+	if !token.WaitTimeout(3*time.Second) || token.Error() != nil {
+		t.Fatalf("명령 토픽 구독 실패: %v", token.Error())
+	}
+
+	cmd, err := svc.SendCommand(ctx, "mqtt-dev-1", "measure", `{"type":"radon"}`)
+	if err != nil {
+		t.Fatalf("SendCommand 실패: %v", err)
+	}
+	if cmd.Status != service.CommandStatusSent {
+		t.Errorf("MQTT로 발행된 명령은 sent 상태여야 합니다: got %s", cmd.Status)
+	}
+
+	select {
+	case m := <-received:
+		if m.Topic() != handler.CommandTopic("mqtt-dev-1", "measure") {
+			t.Errorf("명령 토픽 불일치: got %s", m.Topic())
+		}
+		var msg struct {
+			CommandID string `json:"command_id"`
+			Payload   string `json:"payload"`
+		}
+		if err := json.Unmarshal(m.Payload(), &msg); err != nil || msg.CommandID != cmd.ID || msg.Payload != `{"type":"radon"}` {
+			t.Errorf("명령 페이로드 불일치: %s", m.Payload())
+		}
+	case <-time.After(3 * time.Second):
+		t.Fatal("디바이스가 명령을 수신하지 못했습니다")
+	}
+
+	// 다른 디바이스의 명령 토픽은 구독할 수 없음
+	token = client.Subscribe("manpasik/mqtt-dev-2/command/#", 1, nil)
+	token.WaitTimeout(3 * time.Second)
+	if st, ok := token.(*paho.SubscribeToken); ok {
+		for _, code := range st.Result() {
+			if code != 0x80 {
+				t.Errorf("다른 디바이스 명령 토픽 구독은 거부되어야 합니다: code=%#x", code)
+			}
+		}
+	}
+}
+
+func TestMQTT_LastWill_offline(t *testing.T) {
+	svc, addr := startBroker(t)
+	ctx := context.Background()
+	password := registerMQTTDevice(t, svc, "mqtt-dev-1")
+
+	// 연결을 직접 보관해 비정상 종료(네트워크 단절)를 흉내냄
+	var conn net.Conn
+	opts := deviceOptions(addr, "mqtt-dev-1", password).
+		SetCustomOpenConnectionFn(func(uri *url.URL, _ paho.ClientOptions) (net.Conn, error) {
+			c, err := net.Dial("tcp", uri.Host)
+			conn = c
+			return c, err
+		})
+	connect(t, opts)
+
+	waitFor(t, "online 전환", func() bool {
+		d, _ := svc.GetDevice(ctx, "mqtt-dev-1")
+		return d.Status == service.DeviceStatusOnline
+	})
+
+	_ = conn.Close()
+
+	waitFor(t, "Last Will로 offline 전환", func() bool {
+		d, _ := svc.GetDevice(ctx, "mqtt-dev-1")
+		return d.Status == service.DeviceStatusOffline
+	})
+}
+
+func TestMQTT_명령_수신확인과_응답(t *testing.T) {
+	svc, addr := startBroker(t)
+	ctx := context.Background()
+	password := registerMQTTDevice(t, svc, "mqtt-dev-1")
+
+	client := connect(t, deviceOptions(addr, "mqtt-dev-1", password))
+	received := make(chan string, 1)
+	token := client.Subscribe("manpasik/mqtt-dev-1/command/#", 1, func(_ paho.Client, m paho.Message) {
+		var msg struct {
+			CommandID string `json:"command_id"`
+		}
+		_ = json.Unmarshal(m.Payload(), &msg)
+		received <- msg.CommandID
+	})
+	if !token.WaitTimeout(3*time.Second) || token.Error() != nil {
+		t.Fatalf("명령 토픽 구독 실패: %v", token.Error())
+	}
+
+	cmd, err := svc.SendCommand(ctx, "mqtt-dev-1", "measure", "")
+	if err != nil {
+		t.Fatalf("SendCommand 실패: %v", err)
+	}
+	// 디바이스의 PUBACK으로 delivered 전이
+	waitFor(t, "delivered 전이", func() bool {
+		c, _ := svc.GetCommand(ctx, cmd.ID)
+		return c.Status == service.CommandStatusDelivered
+	})
+
+	commandID := <-received
+	publish(t, client, handler.AckTopic("mqtt-dev-1"), `{"command_id":"`+commandID+`","success":true,"message":"ok"}`)
+	waitFor(t, "acked 전이", func() bool {
+		c, _ := svc.GetCommand(ctx, cmd.ID)
+		return c.Status == service.CommandStatusAcked
+	})
+}
@@ -0,0 +1,96 @@
+// Package kafka는 Kafka 기반 명령·규칙 이벤트 발행기를 제공합니다.
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/manpasik/backend/services/iot-gateway-service/internal/service"
+	"github.com/manpasik/backend/shared/events"
+)
+
+// EventPublisher는 Kafka를 사용하는 이벤트 발행기입니다.
+type EventPublisher struct {
+	eventBus *events.KafkaEventBus
+}
+
+// NewEventPublisher는 Kafka 기반 EventPublisher를 생성합니다.
+func NewEventPublisher(eventBus *events.KafkaEventBus) *EventPublisher {
+	return &EventPublisher{eventBus: eventBus}
+}
+
+// PublishCommandFailed는 명령 최종 실패(failed/expired) 이벤트를 Kafka에 발행합니다.
+// notification-service가 디바이스 사용자에게 명령 실패 알림을 보냅니다.
+func (p *EventPublisher) PublishCommandFailed(ctx context.Context, event *service.CommandFailedEvent) error {
+	payload := map[string]interface{}{
+		"command_id":   event.CommandID,
+		"device_id":    event.DeviceID,
+		"command_type": event.CommandType,
+		"status":       event.Status,
+		"reason":       event.Reason,
+		"attempts":     event.Attempts,
+		"failed_at":    event.FailedAt.Format(time.RFC3339),
+	}
+
+	payloadBytes, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("이벤트 페이로드 직렬화 실패: %w", err)
+	}
+
+	kafkaEvent := events.Event{
+		Type: events.EventIoTCommandFailed,
+		Payload: map[string]interface{}{
+			"event_id":   uuid.New().String(),
+			"event_type": "manpasik." + events.EventIoTCommandFailed,
+			"version":    "1.0",
+			"timestamp":  time.Now().UTC().Format(time.RFC3339),
+			"source":     "iot-gateway-service",
+			"user_id":    event.UserID,
+			"payload":    json.RawMessage(payloadBytes),
+		},
+	}
+
+	return p.eventBus.Publish(ctx, kafkaEvent)
+}
+
+// PublishRuleTriggered는 notify 규칙 발동 이벤트를 Kafka에 발행합니다.
+// notification-service는 디바이스 사용자(없으면 규칙 소유자)에게 알림을 보냅니다.
+func (p *EventPublisher) PublishRuleTriggered(ctx context.Context, event *service.RuleTriggeredEvent) error {
+	payload := map[string]interface{}{
+		"rule_id":      event.RuleID,
+		"rule_name":    event.RuleName,
+		"owner_id":     event.OwnerID,
+		"device_id":    event.DeviceID,
+		"data_type":    event.DataType,
+		"expression":   event.Expression,
+		"value":        event.Value,
+		"triggered_at": event.TriggeredAt.Format(time.RFC3339),
+	}
+
+	payloadBytes, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("이벤트 페이로드 직렬화 실패: %w", err)
+	}
+
+	userID := event.UserID
+	if userID == "" {
+		userID = event.OwnerID
+	}
+	kafkaEvent := events.Event{
+		Type: events.EventIoTRuleTriggered,
+		Payload: map[string]interface{}{
+			"event_id":   uuid.New().String(),
+			"event_type": "manpasik." + events.EventIoTRuleTriggered,
+			"version":    "1.0",
+			"timestamp":  time.Now().UTC().Format(time.RFC3339),
+			"source":     "iot-gateway-service",
+			"user_id":    userID,
+			"payload":    json.RawMessage(payloadBytes),
+		},
+	}
+
+	return p.eventBus.Publish(ctx, kafkaEvent)
+}
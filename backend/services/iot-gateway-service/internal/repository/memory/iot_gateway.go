@@ -3,7 +3,10 @@ package memory
 
 import (
 	"context"
+	"fmt"
+	"sort"
 	"sync"
+	"time"
 
 	"github.com/manpasik/backend/services/iot-gateway-service/internal/service"
 )
@@ -25,11 +28,14 @@ func NewIoTRepository() *IoTRepository {
 	}
 }
 
-// RegisterDevice는 디바이스를 등록합니다.
+// RegisterDevice는 디바이스를 등록합니다. 같은 디바이스 ID가 이미 있으면 오류를 반환합니다.
 func (r *IoTRepository) RegisterDevice(_ context.Context, device *service.IoTDevice) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
+	if _, ok := r.devices[device.DeviceID]; ok {
+		return fmt.Errorf("디바이스가 이미 있습니다: %s", device.DeviceID)
+	}
 	cp := *device
 	if cp.Metadata != nil {
 		cp.Metadata = make(map[string]string, len(device.Metadata))
@@ -60,6 +66,33 @@ func (r *IoTRepository) GetDevice(_ context.Context, deviceID string) (*service.
 	return &cp, nil
 }
 
+// UpdateDeviceCredential은 디바이스 접속 비밀번호 해시를 교체합니다.
+func (r *IoTRepository) UpdateDeviceCredential(_ context.Context, deviceID, credentialHash string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	d, ok := r.devices[deviceID]
+	if !ok {
+		return fmt.Errorf("디바이스를 찾을 수 없습니다: %s", deviceID)
+	}
+	d.CredentialHash = credentialHash
+	return nil
+}
+
+// UpdateDeviceStatus는 디바이스 연결 상태와 마지막 통신 시각을 갱신합니다.
+func (r *IoTRepository) UpdateDeviceStatus(_ context.Context, deviceID, status string, at time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	d, ok := r.devices[deviceID]
+	if !ok {
+		return nil
+	}
+	d.Status = status
+	d.LastPingAt = at
+	return nil
+}
+
 // SendCommand는 명령을 저장합니다.
 func (r *IoTRepository) SendCommand(_ context.Context, cmd *service.IoTCommand) error {
 	r.mu.Lock()
@@ -83,6 +116,65 @@ func (r *IoTRepository) GetCommand(_ context.Context, commandID string) (*servic
 	return &cp, nil
 }
 
+// UpdateCommand는 명령의 상태·시도 정보를 갱신합니다.
+func (r *IoTRepository) UpdateCommand(_ context.Context, cmd *service.IoTCommand) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.commands[cmd.ID]; ok {
+		cp := *cmd
+		r.commands[cmd.ID] = &cp
+	}
+	return nil
+}
+
+// ListCommands는 명령 목록을 최신순으로 조회합니다.
+func (r *IoTRepository) ListCommands(_ context.Context, deviceID, status string, limit, offset int) ([]*service.IoTCommand, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var filtered []*service.IoTCommand
+	for _, c := range r.commands {
+		if (deviceID == "" || c.DeviceID == deviceID) && (status == "" || c.Status == status) {
+			cp := *c
+			filtered = append(filtered, &cp)
+		}
+	}
+	sort.Slice(filtered, func(i, j int) bool {
+		return filtered[i].CreatedAt.After(filtered[j].CreatedAt)
+	})
+
+	total := len(filtered)
+	if offset >= total {
+		return nil, nil
+	}
+	end := offset + limit
+	if end > total {
+		end = total
+	}
+
+	return filtered[offset:end], nil
+}
+
+// ListOpenCommands는 종료되지 않은(queued/sent/delivered) 명령을 생성순으로 조회합니다.
+func (r *IoTRepository) ListOpenCommands(_ context.Context) ([]*service.IoTCommand, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var open []*service.IoTCommand
+	for _, c := range r.commands {
+		switch c.Status {
+		case service.CommandStatusQueued, service.CommandStatusSent, service.CommandStatusDelivered:
+			cp := *c
+			open = append(open, &cp)
+		}
+	}
+	sort.Slice(open, func(i, j int) bool {
+		return open[i].CreatedAt.Before(open[j].CreatedAt)
+	})
+	return open, nil
+}
+
 // ReceiveData는 수신 데이터를 저장합니다.
 func (r *IoTRepository) ReceiveData(_ context.Context, data *service.IoTData) error {
 	r.mu.Lock()
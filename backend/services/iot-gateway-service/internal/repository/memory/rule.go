@@ -0,0 +1,159 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/manpasik/backend/services/iot-gateway-service/internal/service"
+)
+
+// RuleRepository는 인메모리 규칙 저장소입니다.
+type RuleRepository struct {
+	mu       sync.RWMutex
+	rules    map[string]*service.Rule      // key: Rule ID
+	states   map[string]*service.RuleState // key: RuleID/DeviceID
+	triggers []*service.RuleTrigger
+}
+
+// NewRuleRepository는 인메모리 RuleRepository를 생성합니다.
+func NewRuleRepository() *RuleRepository {
+	return &RuleRepository{
+		rules:  make(map[string]*service.Rule),
+		states: make(map[string]*service.RuleState),
+	}
+}
+
+// CreateRule은 규칙을 저장합니다.
+func (r *RuleRepository) CreateRule(_ context.Context, rule *service.Rule) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	cp := *rule
+	r.rules[rule.ID] = &cp
+	return nil
+}
+
+// GetRule은 규칙 ID로 조회합니다.
+func (r *RuleRepository) GetRule(_ context.Context, ruleID string) (*service.Rule, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	rule, ok := r.rules[ruleID]
+	if !ok {
+		return nil, nil
+	}
+	cp := *rule
+	return &cp, nil
+}
+
+// ListRules는 소유자의 규칙을 생성순으로 조회합니다.
+func (r *RuleRepository) ListRules(_ context.Context, ownerID string) ([]*service.Rule, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var result []*service.Rule
+	for _, rule := range r.rules {
+		if ownerID == "" || rule.OwnerID == ownerID {
+			cp := *rule
+			result = append(result, &cp)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].CreatedAt.Before(result[j].CreatedAt)
+	})
+	return result, nil
+}
+
+// ListActiveRules는 디바이스·데이터 유형에 적용되는 활성 규칙을 조회합니다.
+func (r *RuleRepository) ListActiveRules(_ context.Context, deviceID, dataType string) ([]*service.Rule, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var result []*service.Rule
+	for _, rule := range r.rules {
+		if rule.Enabled && rule.DataType == dataType && (rule.DeviceID == "" || rule.DeviceID == deviceID) {
+			cp := *rule
+			result = append(result, &cp)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].CreatedAt.Before(result[j].CreatedAt)
+	})
+	return result, nil
+}
+
+// SetRuleEnabled는 규칙 활성 여부를 변경합니다.
+func (r *RuleRepository) SetRuleEnabled(_ context.Context, ruleID string, enabled bool) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if rule, ok := r.rules[ruleID]; ok {
+		rule.Enabled = enabled
+	}
+	return nil
+}
+
+// DeleteRule은 규칙과 평가 상태를 삭제합니다.
+func (r *RuleRepository) DeleteRule(_ context.Context, ruleID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.rules, ruleID)
+	for key, st := range r.states {
+		if st.RuleID == ruleID {
+			delete(r.states, key)
+		}
+	}
+	return nil
+}
+
+// GetRuleState는 규칙·디바이스 조합의 평가 상태를 조회합니다.
+func (r *RuleRepository) GetRuleState(_ context.Context, ruleID, deviceID string) (*service.RuleState, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	st, ok := r.states[ruleID+"/"+deviceID]
+	if !ok {
+		return nil, nil
+	}
+	cp := *st
+	cp.Samples = append([]service.RuleSample(nil), st.Samples...)
+	return &cp, nil
+}
+
+// SaveRuleState는 평가 상태를 저장합니다.
+func (r *RuleRepository) SaveRuleState(_ context.Context, state *service.RuleState) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	cp := *state
+	cp.Samples = append([]service.RuleSample(nil), state.Samples...)
+	r.states[state.RuleID+"/"+state.DeviceID] = &cp
+	return nil
+}
+
+// SaveRuleTrigger는 발동 이력을 저장합니다.
+func (r *RuleRepository) SaveRuleTrigger(_ context.Context, trigger *service.RuleTrigger) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	cp := *trigger
+	r.triggers = append(r.triggers, &cp)
+	return nil
+}
+
+// ListRuleTriggers는 규칙의 발동 이력을 최신순으로 조회합니다.
+func (r *RuleRepository) ListRuleTriggers(_ context.Context, ruleID string, limit int) ([]*service.RuleTrigger, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var result []*service.RuleTrigger
+	for i := len(r.triggers) - 1; i >= 0 && len(result) < limit; i-- {
+		if r.triggers[i].RuleID == ruleID {
+			cp := *r.triggers[i]
+			result = append(result, &cp)
+		}
+	}
+	return result, nil
+}
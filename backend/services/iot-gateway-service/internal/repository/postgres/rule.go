@@ -0,0 +1,205 @@
+// Package postgres는 iot-gateway-service의 PostgreSQL 저장소 구현입니다.
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/manpasik/backend/services/iot-gateway-service/internal/service"
+)
+
+// ============================================================================
+// RuleRepository
+// ============================================================================
+
+// RuleRepository는 PostgreSQL 기반 RuleRepository 구현입니다.
+type RuleRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewRuleRepository는 RuleRepository를 생성합니다.
+func NewRuleRepository(pool *pgxpool.Pool) *RuleRepository {
+	return &RuleRepository{pool: pool}
+}
+
+const ruleColumns = `id, owner_id, name, device_id, data_type, expression,
+	aggregation, window_ms, operator, threshold, for_ms, hysteresis, cooldown_ms,
+	action_type, command_type, command_payload, enabled, created_at`
+
+func scanRule(row pgx.Row) (*service.Rule, error) {
+	var r service.Rule
+	var windowMs, forMs, cooldownMs int64
+	err := row.Scan(
+		&r.ID, &r.OwnerID, &r.Name, &r.DeviceID, &r.DataType, &r.Expression,
+		&r.Aggregation, &windowMs, &r.Operator, &r.Threshold, &forMs, &r.Hysteresis, &cooldownMs,
+		&r.Action.Type, &r.Action.CommandType, &r.Action.CommandPayload, &r.Enabled, &r.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	r.Window = time.Duration(windowMs) * time.Millisecond
+	r.For = time.Duration(forMs) * time.Millisecond
+	r.Cooldown = time.Duration(cooldownMs) * time.Millisecond
+	return &r, nil
+}
+
+func (r *RuleRepository) queryRules(ctx context.Context, q string, args ...interface{}) ([]*service.Rule, error) {
+	rows, err := r.pool.Query(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var rules []*service.Rule
+	for rows.Next() {
+		rule, err := scanRule(rows)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+	return rules, rows.Err()
+}
+
+// CreateRule은 규칙을 저장합니다.
+func (r *RuleRepository) CreateRule(ctx context.Context, rule *service.Rule) error {
+	const q = `INSERT INTO iot_rules (` + ruleColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18)`
+	_, err := r.pool.Exec(ctx, q,
+		rule.ID, rule.OwnerID, rule.Name, rule.DeviceID, rule.DataType, rule.Expression,
+		rule.Aggregation, rule.Window.Milliseconds(), rule.Operator, rule.Threshold, rule.For.Milliseconds(),
+		rule.Hysteresis, rule.Cooldown.Milliseconds(),
+		rule.Action.Type, rule.Action.CommandType, rule.Action.CommandPayload, rule.Enabled, rule.CreatedAt,
+	)
+	return err
+}
+
+// GetRule은 규칙 ID로 조회합니다.
+func (r *RuleRepository) GetRule(ctx context.Context, ruleID string) (*service.Rule, error) {
+	const q = `SELECT ` + ruleColumns + ` FROM iot_rules WHERE id = $1`
+	rule, err := scanRule(r.pool.QueryRow(ctx, q, ruleID))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return rule, nil
+}
+
+// ListRules는 소유자의 규칙을 생성순으로 조회합니다. ownerID가 비어 있으면 전체를 조회합니다.
+func (r *RuleRepository) ListRules(ctx context.Context, ownerID string) ([]*service.Rule, error) {
+	const q = `SELECT ` + ruleColumns + ` FROM iot_rules
+		WHERE ($1 = '' OR owner_id = $1) ORDER BY created_at ASC`
+	return r.queryRules(ctx, q, ownerID)
+}
+
+// ListActiveRules는 디바이스·데이터 유형에 적용되는 활성 규칙을 조회합니다.
+func (r *RuleRepository) ListActiveRules(ctx context.Context, deviceID, dataType string) ([]*service.Rule, error) {
+	const q = `SELECT ` + ruleColumns + ` FROM iot_rules
+		WHERE enabled AND data_type = $2 AND (device_id = '' OR device_id = $1) ORDER BY created_at ASC`
+	return r.queryRules(ctx, q, deviceID, dataType)
+}
+
+// SetRuleEnabled는 규칙 활성 여부를 변경합니다.
+func (r *RuleRepository) SetRuleEnabled(ctx context.Context, ruleID string, enabled bool) error {
+	_, err := r.pool.Exec(ctx, `UPDATE iot_rules SET enabled = $2 WHERE id = $1`, ruleID, enabled)
+	return err
+}
+
+// DeleteRule은 규칙을 삭제합니다. 평가 상태와 발동 이력은 외래 키로 함께 삭제됩니다.
+func (r *RuleRepository) DeleteRule(ctx context.Context, ruleID string) error {
+	_, err := r.pool.Exec(ctx, `DELETE FROM iot_rules WHERE id = $1`, ruleID)
+	return err
+}
+
+// GetRuleState는 규칙·디바이스 조합의 평가 상태를 조회합니다.
+func (r *RuleRepository) GetRuleState(ctx context.Context, ruleID, deviceID string) (*service.RuleState, error) {
+	const q = `SELECT samples, observed_since, condition_since, active, last_triggered_at
+		FROM iot_rule_states WHERE rule_id = $1 AND device_id = $2`
+	st := service.RuleState{RuleID: ruleID, DeviceID: deviceID}
+	var samples []byte
+	var observedSince, conditionSince, lastTriggeredAt *time.Time
+	err := r.pool.QueryRow(ctx, q, ruleID, deviceID).Scan(&samples, &observedSince, &conditionSince, &st.Active, &lastTriggeredAt)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal(samples, &st.Samples); err != nil {
+		return nil, err
+	}
+	st.ObservedSince = derefTime(observedSince)
+	st.ConditionSince = derefTime(conditionSince)
+	st.LastTriggeredAt = derefTime(lastTriggeredAt)
+	return &st, nil
+}
+
+// SaveRuleState는 평가 상태를 저장합니다.
+func (r *RuleRepository) SaveRuleState(ctx context.Context, state *service.RuleState) error {
+	samples := state.Samples
+	if samples == nil {
+		samples = []service.RuleSample{}
+	}
+	data, err := json.Marshal(samples)
+	if err != nil {
+		return err
+	}
+	const q = `INSERT INTO iot_rule_states (rule_id, device_id, samples, observed_since, condition_since, active, last_triggered_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, NOW())
+		ON CONFLICT (rule_id, device_id) DO UPDATE SET
+			samples = EXCLUDED.samples, observed_since = EXCLUDED.observed_since,
+			condition_since = EXCLUDED.condition_since, active = EXCLUDED.active,
+			last_triggered_at = EXCLUDED.last_triggered_at, updated_at = NOW()`
+	_, err = r.pool.Exec(ctx, q, state.RuleID, state.DeviceID, data,
+		nullTime(state.ObservedSince), nullTime(state.ConditionSince), state.Active, nullTime(state.LastTriggeredAt))
+	return err
+}
+
+// SaveRuleTrigger는 발동 이력을 저장합니다.
+func (r *RuleRepository) SaveRuleTrigger(ctx context.Context, trigger *service.RuleTrigger) error {
+	const q = `INSERT INTO iot_rule_triggers (id, rule_id, device_id, data_type, value, command_id, triggered_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`
+	_, err := r.pool.Exec(ctx, q, trigger.ID, trigger.RuleID, trigger.DeviceID, trigger.DataType,
+		trigger.Value, trigger.CommandID, trigger.TriggeredAt)
+	return err
+}
+
+// ListRuleTriggers는 규칙의 발동 이력을 최신순으로 조회합니다.
+func (r *RuleRepository) ListRuleTriggers(ctx context.Context, ruleID string, limit int) ([]*service.RuleTrigger, error) {
+	const q = `SELECT id, rule_id, device_id, data_type, value, command_id, triggered_at
+		FROM iot_rule_triggers WHERE rule_id = $1 ORDER BY triggered_at DESC LIMIT $2`
+	rows, err := r.pool.Query(ctx, q, ruleID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var triggers []*service.RuleTrigger
+	for rows.Next() {
+		var t service.RuleTrigger
+		if err := rows.Scan(&t.ID, &t.RuleID, &t.DeviceID, &t.DataType, &t.Value, &t.CommandID, &t.TriggeredAt); err != nil {
+			return nil, err
+		}
+		triggers = append(triggers, &t)
+	}
+	return triggers, rows.Err()
+}
+
+func nullTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
+
+func derefTime(t *time.Time) time.Time {
+	if t == nil {
+		return time.Time{}
+	}
+	return *t
+}
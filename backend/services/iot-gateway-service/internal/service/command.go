@@ -0,0 +1,287 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+)
+
+// ============================================================================
+// 명령 수명주기 (Command Lifecycle)
+// ============================================================================
+
+// CommandPolicy는 명령 TTL과 재시도 정책입니다.
+type CommandPolicy struct {
+	// TTL은 명령 생성 후 acked/failed 응답을 기다리는 최대 시간입니다.
+	TTL time.Duration
+	// MaxAttempts는 수신 확인이 없을 때 발행을 시도하는 최대 횟수입니다 (첫 발행 포함).
+	MaxAttempts int
+	// InitialBackoff는 첫 재발행까지의 대기 시간이며 시도마다 두 배씩 늘어납니다.
+	InitialBackoff time.Duration
+	// MaxBackoff는 재발행 대기 시간의 상한입니다.
+	MaxBackoff time.Duration
+}
+
+// DefaultCommandPolicy는 기본 정책을 반환합니다 (TTL 5분, 최대 5회, 5초→1분 백오프).
+func DefaultCommandPolicy() CommandPolicy {
+	return CommandPolicy{
+		TTL:            5 * time.Minute,
+		MaxAttempts:    5,
+		InitialBackoff: 5 * time.Second,
+		MaxBackoff:     time.Minute,
+	}
+}
+
+// backoff는 attempts번 발행한 뒤 다음 재발행까지의 대기 시간을 반환합니다.
+func (p CommandPolicy) backoff(attempts int) time.Duration {
+	d := p.InitialBackoff
+	for i := 1; i < attempts && d < p.MaxBackoff; i++ {
+		d *= 2
+	}
+	if d > p.MaxBackoff {
+		d = p.MaxBackoff
+	}
+	return d
+}
+
+// CommandFailedEvent는 명령이 최종 실패(failed/expired)했을 때 발행되는 이벤트입니다.
+type CommandFailedEvent struct {
+	CommandID   string
+	DeviceID    string
+	UserID      string // 디바이스 메타데이터의 user_id (없으면 빈 값)
+	CommandType string
+	Status      string // failed 또는 expired
+	Reason      string
+	Attempts    int
+	FailedAt    time.Time
+}
+
+// CommandEventPublisher는 명령 이벤트를 notification-service로 발행합니다.
+type CommandEventPublisher interface {
+	PublishCommandFailed(ctx context.Context, event *CommandFailedEvent) error
+}
+
+// SetCommandPolicy는 명령 TTL·재시도 정책을 설정합니다. 0 이하 값은 기본값을 사용합니다.
+func (s *IoTGatewayService) SetCommandPolicy(p CommandPolicy) {
+	def := DefaultCommandPolicy()
+	if p.TTL <= 0 {
+		p.TTL = def.TTL
+	}
+	if p.MaxAttempts <= 0 {
+		p.MaxAttempts = def.MaxAttempts
+	}
+	if p.InitialBackoff <= 0 {
+		p.InitialBackoff = def.InitialBackoff
+	}
+	if p.MaxBackoff < p.InitialBackoff {
+		p.MaxBackoff = p.InitialBackoff
+	}
+	s.policy = p
+}
+
+// SetEventPublisher는 명령 최종 실패 이벤트 발행기를 설정합니다.
+func (s *IoTGatewayService) SetEventPublisher(p CommandEventPublisher) {
+	s.events = p
+}
+
+// MarkCommandDelivered는 디바이스의 수신 확인(MQTT PUBACK)으로 명령을 delivered로 전이합니다.
+// 이미 delivered 이후 상태이면 무시합니다.
+func (s *IoTGatewayService) MarkCommandDelivered(ctx context.Context, commandID string) error {
+	if commandID == "" {
+		return errors.New("command_id는 필수입니다")
+	}
+
+	s.cmdMu.Lock()
+	defer s.cmdMu.Unlock()
+
+	cmd, err := s.GetCommand(ctx, commandID)
+	if err != nil {
+		return err
+	}
+	if cmd.Status != CommandStatusQueued && cmd.Status != CommandStatusSent {
+		return nil
+	}
+
+	now := s.now()
+	cmd.Status = CommandStatusDelivered
+	cmd.DeliveredAt = now
+	cmd.UpdatedAt = now
+	if err := s.repo.UpdateCommand(ctx, cmd); err != nil {
+		return errors.New("명령 상태 갱신에 실패했습니다: " + err.Error())
+	}
+	return nil
+}
+
+// AcknowledgeCommand는 디바이스의 실행 결과 응답을 명령과 대조해 acked 또는 failed로 종료합니다.
+// 응답한 디바이스가 명령 대상이 아니면 거부하며, 이미 종료된 명령에 대한 중복 응답은 무시합니다.
+func (s *IoTGatewayService) AcknowledgeCommand(ctx context.Context, deviceID, commandID string, success bool, message string) (*IoTCommand, error) {
+	if deviceID == "" {
+		return nil, errors.New("device_id는 필수입니다")
+	}
+
+	s.cmdMu.Lock()
+	defer s.cmdMu.Unlock()
+
+	cmd, err := s.GetCommand(ctx, commandID)
+	if err != nil {
+		return nil, err
+	}
+	if cmd.DeviceID != deviceID {
+		return nil, errors.New("다른 디바이스의 명령에는 응답할 수 없습니다: " + commandID)
+	}
+	if isCommandClosed(cmd.Status) {
+		return cmd, nil
+	}
+
+	if !success {
+		if message == "" {
+			message = "디바이스가 명령 실행 실패를 응답했습니다"
+		}
+		return cmd, s.closeCommandLocked(ctx, cmd, CommandStatusFailed, message)
+	}
+
+	now := s.now()
+	if cmd.DeliveredAt.IsZero() {
+		cmd.DeliveredAt = now
+	}
+	cmd.Status = CommandStatusAcked
+	cmd.ResultMessage = message
+	cmd.CompletedAt = now
+	cmd.UpdatedAt = now
+	if err := s.repo.UpdateCommand(ctx, cmd); err != nil {
+		return nil, errors.New("명령 상태 갱신에 실패했습니다: " + err.Error())
+	}
+	return cmd, nil
+}
+
+// ProcessCommands는 종료되지 않은 명령을 점검해 TTL이 지난 명령은 expired로,
+// 재발행 시각이 된 queued/sent 명령은 백오프에 따라 재발행하거나 재시도 소진 시 failed로 전이합니다.
+// delivered 명령은 디바이스가 이미 수신했으므로 재발행하지 않고 응답 또는 만료를 기다립니다.
+// 주기적으로 호출해야 합니다.
+func (s *IoTGatewayService) ProcessCommands(ctx context.Context) error {
+	s.cmdMu.Lock()
+	defer s.cmdMu.Unlock()
+
+	cmds, err := s.repo.ListOpenCommands(ctx)
+	if err != nil {
+		return errors.New("명령 목록 조회에 실패했습니다: " + err.Error())
+	}
+
+	now := s.now()
+	for _, cmd := range cmds {
+		if !now.Before(cmd.ExpiresAt) {
+			if err := s.closeCommandLocked(ctx, cmd, CommandStatusExpired, "TTL 내에 디바이스 응답이 없습니다"); err != nil {
+				log.Printf("[iot-gateway] 명령 만료 처리 실패 (command=%s): %v", cmd.ID, err)
+			}
+			continue
+		}
+		if cmd.Status == CommandStatusDelivered || now.Before(cmd.NextAttemptAt) {
+			continue
+		}
+		if cmd.Attempts >= cmd.MaxAttempts {
+			if err := s.closeCommandLocked(ctx, cmd, CommandStatusFailed, "재시도 횟수를 모두 소진했습니다"); err != nil {
+				log.Printf("[iot-gateway] 명령 실패 처리 실패 (command=%s): %v", cmd.ID, err)
+			}
+			continue
+		}
+
+		device, err := s.repo.GetDevice(ctx, cmd.DeviceID)
+		if err != nil || device == nil || !s.canDispatch(device) {
+			continue
+		}
+		s.dispatchLocked(ctx, cmd)
+	}
+	return nil
+}
+
+// ListCommands는 명령 목록을 조회합니다. deviceID·status가 비어 있으면 전체를 조회합니다.
+func (s *IoTGatewayService) ListCommands(ctx context.Context, deviceID, status string, limit, offset int) ([]*IoTCommand, error) {
+	if status != "" && !isCommandStatus(status) {
+		return nil, errors.New("지원하지 않는 명령 상태입니다: " + status)
+	}
+	if limit <= 0 {
+		limit = 20
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	cmds, err := s.repo.ListCommands(ctx, deviceID, status, limit, offset)
+	if err != nil {
+		return nil, errors.New("명령 목록 조회에 실패했습니다: " + err.Error())
+	}
+	return cmds, nil
+}
+
+// canDispatch는 디바이스로 명령을 발행할 수단이 있는지 확인합니다.
+func (s *IoTGatewayService) canDispatch(device *IoTDevice) bool {
+	return device.Protocol == ProtocolMQTT && s.publisher != nil
+}
+
+// dispatchLocked는 명령을 발행하고 시도 횟수·다음 재발행 시각을 기록합니다.
+// 발행에 실패하면 queued로 남아 다음 재발행 시각에 다시 시도합니다. cmdMu를 잡고 호출해야 합니다.
+func (s *IoTGatewayService) dispatchLocked(ctx context.Context, cmd *IoTCommand) {
+	now := s.now()
+	cmd.Attempts++
+	cmd.NextAttemptAt = now.Add(s.policy.backoff(cmd.Attempts))
+	cmd.UpdatedAt = now
+	if err := s.publisher.PublishCommand(ctx, cmd); err != nil {
+		log.Printf("[iot-gateway] 명령 발행 실패 (command=%s, attempt=%d): %v", cmd.ID, cmd.Attempts, err)
+	} else {
+		cmd.Status = CommandStatusSent
+		cmd.SentAt = now
+	}
+	if err := s.repo.UpdateCommand(ctx, cmd); err != nil {
+		log.Printf("[iot-gateway] 명령 상태 갱신 실패 (command=%s): %v", cmd.ID, err)
+	}
+}
+
+// closeCommandLocked는 명령을 failed/expired로 종료하고 최종 실패 이벤트를 발행합니다.
+// cmdMu를 잡고 호출해야 합니다.
+func (s *IoTGatewayService) closeCommandLocked(ctx context.Context, cmd *IoTCommand, status, reason string) error {
+	now := s.now()
+	cmd.Status = status
+	cmd.ResultMessage = reason
+	cmd.CompletedAt = now
+	cmd.UpdatedAt = now
+	if err := s.repo.UpdateCommand(ctx, cmd); err != nil {
+		return errors.New("명령 상태 갱신에 실패했습니다: " + err.Error())
+	}
+
+	if s.events == nil {
+		return nil
+	}
+	event := &CommandFailedEvent{
+		CommandID:   cmd.ID,
+		DeviceID:    cmd.DeviceID,
+		CommandType: cmd.CommandType,
+		Status:      status,
+		Reason:      reason,
+		Attempts:    cmd.Attempts,
+		FailedAt:    now,
+	}
+	if device, err := s.repo.GetDevice(ctx, cmd.DeviceID); err == nil && device != nil {
+		event.UserID = device.Metadata["user_id"]
+	}
+	if err := s.events.PublishCommandFailed(ctx, event); err != nil {
+		log.Printf("[iot-gateway] 명령 실패 이벤트 발행 실패 (command=%s): %v", cmd.ID, err)
+	}
+	return nil
+}
+
+func isCommandClosed(status string) bool {
+	switch status {
+	case CommandStatusAcked, CommandStatusFailed, CommandStatusExpired:
+		return true
+	}
+	return false
+}
+
+func isCommandStatus(status string) bool {
+	switch status {
+	case CommandStatusQueued, CommandStatusSent, CommandStatusDelivered:
+		return true
+	}
+	return isCommandClosed(status)
+}
@@ -0,0 +1,214 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/manpasik/backend/services/iot-gateway-service/internal/repository/memory"
+	"github.com/manpasik/backend/services/iot-gateway-service/internal/service"
+)
+
+// ============================================================================
+// 테스트 더블
+// ============================================================================
+
+// fakeClock은 테스트에서 직접 앞당기는 시계입니다.
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	c.now = c.now.Add(d)
+	c.mu.Unlock()
+}
+
+// fakePublisher는 발행 횟수를 세고 fail이 true이면 발행을 실패시킵니다.
+type fakePublisher struct {
+	published int
+	fail      bool
+}
+
+func (p *fakePublisher) PublishCommand(_ context.Context, _ *service.IoTCommand) error {
+	if p.fail {
+		return errors.New("브로커 연결 끊김")
+	}
+	p.published++
+	return nil
+}
+
+// fakeEventPublisher는 발행된 명령 실패 이벤트를 보관합니다.
+type fakeEventPublisher struct {
+	events []*service.CommandFailedEvent
+}
+
+func (p *fakeEventPublisher) PublishCommandFailed(_ context.Context, e *service.CommandFailedEvent) error {
+	p.events = append(p.events, e)
+	return nil
+}
+
+// newCommandTestService는 가짜 시계·발행기와 MQTT 디바이스 1대를 준비합니다.
+// 정책: TTL 1분, 최대 3회, 백오프 1초→2초(상한 2초).
+func newCommandTestService(t *testing.T) (*service.IoTGatewayService, *fakeClock, *fakePublisher, *fakeEventPublisher) {
+	t.Helper()
+	svc := service.NewIoTGatewayService(memory.NewIoTRepository())
+	clock := &fakeClock{now: time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)}
+	pub := &fakePublisher{}
+	ev := &fakeEventPublisher{}
+	svc.SetClock(clock.Now)
+	svc.SetCommandPublisher(pub)
+	svc.SetEventPublisher(ev)
+	svc.SetCommandPolicy(service.CommandPolicy{
+		TTL:            time.Minute,
+		MaxAttempts:    3,
+		InitialBackoff: time.Second,
+		MaxBackoff:     2 * time.Second,
+	})
+
+	if _, err := svc.RegisterDevice(context.Background(), "dev-1", service.ProtocolMQTT, map[string]string{"user_id": "user-1"}); err != nil {
+		t.Fatalf("디바이스 등록 실패: %v", err)
+	}
+	return svc, clock, pub, ev
+}
+
+// ============================================================================
+// 명령 수명주기 테스트
+// ============================================================================
+
+func TestProcessCommands_백오프_재발행_후_재시도_소진(t *testing.T) {
+	svc, clock, pub, ev := newCommandTestService(t)
+	ctx := context.Background()
+
+	cmd, err := svc.SendCommand(ctx, "dev-1", "measure", "")
+	if err != nil {
+		t.Fatalf("SendCommand 실패: %v", err)
+	}
+	if cmd.Status != service.CommandStatusSent || cmd.Attempts != 1 {
+		t.Fatalf("첫 발행 후 sent/1회여야 합니다: %s/%d", cmd.Status, cmd.Attempts)
+	}
+
+	// 백오프 1초 전에는 재발행하지 않음
+	clock.Advance(500 * time.Millisecond)
+	svc.ProcessCommands(ctx)
+	if pub.published != 1 {
+		t.Errorf("백오프 전 재발행 불가: published=%d", pub.published)
+	}
+
+	clock.Advance(500 * time.Millisecond) // t=1s → 2회
+	svc.ProcessCommands(ctx)
+	clock.Advance(time.Second) // t=2s, 다음 재발행은 t=3s
+	svc.ProcessCommands(ctx)
+	if pub.published != 2 {
+		t.Errorf("두 번째 백오프는 2초여야 합니다: published=%d", pub.published)
+	}
+	clock.Advance(time.Second) // t=3s → 3회
+	svc.ProcessCommands(ctx)
+	if pub.published != 3 {
+		t.Fatalf("세 번째 발행 누락: published=%d", pub.published)
+	}
+
+	clock.Advance(2 * time.Second) // t=5s, 재시도 소진
+	svc.ProcessCommands(ctx)
+	got, _ := svc.GetCommand(ctx, cmd.ID)
+	if got.Status != service.CommandStatusFailed || got.Attempts != 3 {
+		t.Errorf("재시도 소진 시 failed여야 합니다: %s/%d", got.Status, got.Attempts)
+	}
+	if len(ev.events) != 1 || ev.events[0].Status != service.CommandStatusFailed || ev.events[0].UserID != "user-1" {
+		t.Errorf("최종 실패 이벤트 불일치: %+v", ev.events)
+	}
+}
+
+func TestProcessCommands_발행_실패시_queued_유지(t *testing.T) {
+	svc, clock, pub, _ := newCommandTestService(t)
+	ctx := context.Background()
+
+	pub.fail = true
+	cmd, _ := svc.SendCommand(ctx, "dev-1", "measure", "")
+	if cmd.Status != service.CommandStatusQueued || cmd.Attempts != 1 {
+		t.Fatalf("발행 실패 시 queued로 남아야 합니다: %s/%d", cmd.Status, cmd.Attempts)
+	}
+
+	pub.fail = false
+	clock.Advance(time.Second)
+	svc.ProcessCommands(ctx)
+	got, _ := svc.GetCommand(ctx, cmd.ID)
+	if got.Status != service.CommandStatusSent || got.Attempts != 2 {
+		t.Errorf("재발행 성공 시 sent여야 합니다: %s/%d", got.Status, got.Attempts)
+	}
+}
+
+func TestProcessCommands_수신후_응답없으면_만료(t *testing.T) {
+	svc, clock, pub, ev := newCommandTestService(t)
+	ctx := context.Background()
+
+	cmd, _ := svc.SendCommand(ctx, "dev-1", "calibrate", "")
+	if err := svc.MarkCommandDelivered(ctx, cmd.ID); err != nil {
+		t.Fatalf("MarkCommandDelivered 실패: %v", err)
+	}
+
+	// delivered 명령은 재발행하지 않음
+	clock.Advance(30 * time.Second)
+	svc.ProcessCommands(ctx)
+	if pub.published != 1 {
+		t.Errorf("delivered 명령은 재발행하면 안 됩니다: published=%d", pub.published)
+	}
+
+	clock.Advance(30 * time.Second)
+	svc.ProcessCommands(ctx)
+	got, _ := svc.GetCommand(ctx, cmd.ID)
+	if got.Status != service.CommandStatusExpired || got.DeliveredAt.IsZero() {
+		t.Errorf("TTL 경과 시 expired여야 합니다: %+v", got)
+	}
+	if len(ev.events) != 1 || ev.events[0].Status != service.CommandStatusExpired {
+		t.Errorf("만료 이벤트 불일치: %+v", ev.events)
+	}
+
+	// 만료 후 도착한 응답은 결과를 바꾸지 않음
+	svc.AcknowledgeCommand(ctx, "dev-1", cmd.ID, true, "")
+	if got, _ := svc.GetCommand(ctx, cmd.ID); got.Status != service.CommandStatusExpired {
+		t.Errorf("종료된 명령의 상태가 바뀌었습니다: %s", got.Status)
+	}
+}
+
+func TestAcknowledgeCommand_디바이스_대조와_목록_조회(t *testing.T) {
+	svc, _, _, ev := newCommandTestService(t)
+	ctx := context.Background()
+	svc.RegisterDevice(ctx, "dev-2", service.ProtocolMQTT, nil)
+
+	ok, _ := svc.SendCommand(ctx, "dev-1", "measure", "")
+	bad, _ := svc.SendCommand(ctx, "dev-1", "reboot", "")
+	svc.SendCommand(ctx, "dev-2", "measure", "")
+
+	if _, err := svc.AcknowledgeCommand(ctx, "dev-2", ok.ID, true, ""); err == nil {
+		t.Error("다른 디바이스의 응답은 거부되어야 합니다")
+	}
+	if got, err := svc.AcknowledgeCommand(ctx, "dev-1", ok.ID, true, "완료"); err != nil || got.Status != service.CommandStatusAcked {
+		t.Errorf("성공 응답 시 acked여야 합니다: %+v, err=%v", got, err)
+	}
+	if got, _ := svc.AcknowledgeCommand(ctx, "dev-1", bad.ID, false, "배터리 부족"); got.Status != service.CommandStatusFailed || got.ResultMessage != "배터리 부족" {
+		t.Errorf("실패 응답 시 failed여야 합니다: %+v", got)
+	}
+	if len(ev.events) != 1 || ev.events[0].CommandID != bad.ID || ev.events[0].Reason != "배터리 부족" {
+		t.Errorf("실패 응답 이벤트 불일치: %+v", ev.events)
+	}
+
+	if cmds, _ := svc.ListCommands(ctx, "dev-1", "", 10, 0); len(cmds) != 2 {
+		t.Errorf("dev-1 명령 2건이어야 합니다: got %d", len(cmds))
+	}
+	if cmds, _ := svc.ListCommands(ctx, "", service.CommandStatusSent, 10, 0); len(cmds) != 1 || cmds[0].DeviceID != "dev-2" {
+		t.Errorf("sent 필터 결과 불일치: %+v", cmds)
+	}
+	if _, err := svc.ListCommands(ctx, "", "unknown", 10, 0); err == nil {
+		t.Error("알 수 없는 상태 필터는 거부되어야 합니다")
+	}
+}
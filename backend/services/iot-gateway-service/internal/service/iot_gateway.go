@@ -3,10 +3,36 @@ package service
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
 	"errors"
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
+	apperrors "github.com/manpasik/backend/shared/errors"
+)
+
+// ProtocolMQTT는 MQTT로 연결하는 디바이스의 프로토콜 값입니다.
+const ProtocolMQTT = "mqtt"
+
+// 디바이스 연결 상태
+const (
+	DeviceStatusRegistered = "registered"
+	DeviceStatusOnline     = "online"
+	DeviceStatusOffline    = "offline"
+)
+
+// 명령 상태: queued → sent → delivered → acked, 또는 failed/expired로 종료
+const (
+	CommandStatusQueued    = "queued"    // 저장됨, 아직 발행되지 않음 (또는 발행 실패 후 재시도 대기)
+	CommandStatusSent      = "sent"      // 명령 토픽으로 발행됨
+	CommandStatusDelivered = "delivered" // 디바이스가 수신을 확인함 (MQTT PUBACK)
+	CommandStatusAcked     = "acked"     // 디바이스가 실행 성공을 응답함
+	CommandStatusFailed    = "failed"    // 디바이스 실패 응답 또는 재시도 소진
+	CommandStatusExpired   = "expired"   // TTL 내에 응답이 없음
 )
 
 // ============================================================================
@@ -21,6 +47,10 @@ type IoTDevice struct {
 	LastPingAt time.Time
 	Status     string
 	Metadata   map[string]string
+	// CredentialHash는 MQTT 접속 비밀번호의 SHA-256 해시입니다.
+	CredentialHash string
+	// Credential은 등록 응답에서만 채워지는 평문 비밀번호이며 저장되지 않습니다.
+	Credential string
 }
 
 // IoTCommand는 디바이스에 전송하는 명령 엔티티입니다.
@@ -31,6 +61,19 @@ type IoTCommand struct {
 	Payload     string
 	Status      string
 	CreatedAt   time.Time
+	// Attempts는 지금까지 발행한 횟수이며 MaxAttempts에 도달하면 재시도를 멈춥니다.
+	Attempts    int
+	MaxAttempts int
+	// NextAttemptAt은 발행 후 수신 확인이 없을 때 재발행할 시각입니다.
+	NextAttemptAt time.Time
+	ExpiresAt     time.Time
+	SentAt        time.Time
+	DeliveredAt   time.Time
+	// CompletedAt은 acked/failed/expired로 종료된 시각입니다.
+	CompletedAt time.Time
+	// ResultMessage는 디바이스 응답 메시지 또는 실패 사유입니다.
+	ResultMessage string
+	UpdatedAt     time.Time
 }
 
 // IoTData는 디바이스로부터 수신한 데이터 엔티티입니다.
@@ -49,35 +92,81 @@ type IoTData struct {
 
 // IoTRepository는 IoT 데이터 저장소 인터페이스입니다.
 type IoTRepository interface {
-	// RegisterDevice는 디바이스를 등록합니다.
+	// RegisterDevice는 디바이스를 등록합니다. 같은 디바이스 ID가 이미 있으면 오류를 반환합니다.
 	RegisterDevice(ctx context.Context, device *IoTDevice) error
+	// UpdateDeviceCredential은 디바이스 접속 비밀번호 해시를 교체합니다.
+	UpdateDeviceCredential(ctx context.Context, deviceID, credentialHash string) error
 	// GetDevice는 디바이스 ID로 조회합니다.
 	GetDevice(ctx context.Context, deviceID string) (*IoTDevice, error)
+	// UpdateDeviceStatus는 디바이스 연결 상태와 마지막 통신 시각을 갱신합니다.
+	UpdateDeviceStatus(ctx context.Context, deviceID, status string, at time.Time) error
 	// SendCommand는 명령을 저장합니다.
 	SendCommand(ctx context.Context, cmd *IoTCommand) error
 	// GetCommand는 명령 ID로 조회합니다.
 	GetCommand(ctx context.Context, commandID string) (*IoTCommand, error)
+	// UpdateCommand는 명령의 상태·시도 정보를 갱신합니다.
+	UpdateCommand(ctx context.Context, cmd *IoTCommand) error
+	// ListCommands는 명령 목록을 최신순으로 조회합니다. 빈 deviceID/status는 필터하지 않습니다.
+	ListCommands(ctx context.Context, deviceID, status string, limit, offset int) ([]*IoTCommand, error)
+	// ListOpenCommands는 종료되지 않은(queued/sent/delivered) 명령을 조회합니다.
+	ListOpenCommands(ctx context.Context) ([]*IoTCommand, error)
 	// ReceiveData는 수신 데이터를 저장합니다.
 	ReceiveData(ctx context.Context, data *IoTData) error
 	// ListData는 디바이스의 수신 데이터 목록을 조회합니다.
 	ListData(ctx context.Context, deviceID string, limit, offset int) ([]*IoTData, error)
 }
 
+// CommandPublisher는 디바이스 프로토콜로 명령을 전달합니다 (예: MQTT 명령 토픽).
+type CommandPublisher interface {
+	PublishCommand(ctx context.Context, cmd *IoTCommand) error
+}
+
 // ============================================================================
 // IoTGatewayService
 // ============================================================================
 
 // IoTGatewayService는 IoT 게이트웨이 비즈니스 로직입니다.
 type IoTGatewayService struct {
-	repo IoTRepository
+	repo      IoTRepository
+	publisher CommandPublisher      // MQTT 명령 발행기 (선택)
+	events    CommandEventPublisher // 명령 최종 실패 이벤트 발행기 (선택)
+	policy    CommandPolicy
+	clock     func() time.Time
+
+	rules      RuleRepository     // 데이터 규칙 저장소 (선택)
+	ruleEvents RuleEventPublisher // 규칙 발동 이벤트 발행기 (선택)
+
+	// cmdMu는 MQTT 콜백과 재시도 스윕이 같은 명령을 동시에 전이시키지 않도록 보호합니다.
+	cmdMu sync.Mutex
+	// ruleMu는 규칙 평가 상태의 읽기-갱신을 직렬화합니다.
+	ruleMu sync.Mutex
 }
 
 // NewIoTGatewayService는 새 IoTGatewayService를 생성합니다.
 func NewIoTGatewayService(repo IoTRepository) *IoTGatewayService {
-	return &IoTGatewayService{repo: repo}
+	return &IoTGatewayService{
+		repo:   repo,
+		policy: DefaultCommandPolicy(),
+		clock:  time.Now,
+	}
+}
+
+// SetClock은 현재 시각 함수를 교체합니다 (테스트용).
+func (s *IoTGatewayService) SetClock(now func() time.Time) {
+	s.clock = now
+}
+
+func (s *IoTGatewayService) now() time.Time {
+	return s.clock().UTC()
+}
+
+// SetCommandPublisher는 MQTT 디바이스용 명령 발행기를 설정합니다.
+func (s *IoTGatewayService) SetCommandPublisher(p CommandPublisher) {
+	s.publisher = p
 }
 
 // RegisterDevice는 새 IoT 디바이스를 등록합니다.
+// 이미 등록된 디바이스는 다시 등록할 수 없으며(AlreadyExists), 비밀번호 교체는 RotateDeviceCredential을 사용합니다.
 func (s *IoTGatewayService) RegisterDevice(ctx context.Context, deviceID, protocol string, metadata map[string]string) (*IoTDevice, error) {
 	if deviceID == "" {
 		return nil, errors.New("device_id는 필수입니다")
@@ -85,21 +174,66 @@ func (s *IoTGatewayService) RegisterDevice(ctx context.Context, deviceID, protoc
 	if protocol == "" {
 		return nil, errors.New("protocol은 필수입니다")
 	}
+	existing, err := s.repo.GetDevice(ctx, deviceID)
+	if err != nil {
+		return nil, errors.New("디바이스 조회에 실패했습니다: " + err.Error())
+	}
+	if existing != nil {
+		return nil, apperrors.New(apperrors.ErrAlreadyExists, "이미 등록된 디바이스입니다: "+deviceID)
+	}
 
-	now := time.Now().UTC()
+	now := s.now()
 	device := &IoTDevice{
 		ID:         uuid.New().String(),
 		DeviceID:   deviceID,
 		Protocol:   protocol,
 		LastPingAt: now,
-		Status:     "registered",
+		Status:     DeviceStatusRegistered,
 		Metadata:   metadata,
 	}
 
+	// MQTT 디바이스는 디바이스별 접속 비밀번호를 발급하고 해시만 저장
+	var credential string
+	if protocol == ProtocolMQTT {
+		var err error
+		credential, err = newCredential()
+		if err != nil {
+			return nil, errors.New("디바이스 인증 정보 생성에 실패했습니다: " + err.Error())
+		}
+		device.CredentialHash = hashCredential(credential)
+	}
+
 	if err := s.repo.RegisterDevice(ctx, device); err != nil {
 		return nil, errors.New("디바이스 등록에 실패했습니다: " + err.Error())
 	}
 
+	device.Credential = credential
+	return device, nil
+}
+
+// RotateDeviceCredential은 현재 접속 비밀번호를 확인한 뒤 새 비밀번호를 발급합니다.
+// 반환된 디바이스의 Credential에만 새 평문 비밀번호가 담깁니다.
+func (s *IoTGatewayService) RotateDeviceCredential(ctx context.Context, deviceID, currentCredential string) (*IoTDevice, error) {
+	device, err := s.GetDevice(ctx, deviceID)
+	if err != nil {
+		return nil, err
+	}
+	if device.CredentialHash == "" {
+		return nil, errors.New("접속 비밀번호를 쓰지 않는 디바이스입니다: " + deviceID)
+	}
+	if !s.AuthenticateDevice(ctx, deviceID, currentCredential) {
+		return nil, apperrors.New(apperrors.ErrUnauthorized, "현재 디바이스 비밀번호가 일치하지 않습니다")
+	}
+
+	credential, err := newCredential()
+	if err != nil {
+		return nil, errors.New("디바이스 인증 정보 생성에 실패했습니다: " + err.Error())
+	}
+	device.CredentialHash = hashCredential(credential)
+	if err := s.repo.UpdateDeviceCredential(ctx, deviceID, device.CredentialHash); err != nil {
+		return nil, errors.New("디바이스 인증 정보 갱신에 실패했습니다: " + err.Error())
+	}
+	device.Credential = credential
 	return device, nil
 }
 
@@ -121,20 +255,32 @@ func (s *IoTGatewayService) SendCommand(ctx context.Context, deviceID, commandTy
 		return nil, errors.New("디바이스를 찾을 수 없습니다: " + deviceID)
 	}
 
-	now := time.Now().UTC()
+	now := s.now()
 	cmd := &IoTCommand{
-		ID:          uuid.New().String(),
-		DeviceID:    deviceID,
-		CommandType: commandType,
-		Payload:     payload,
-		Status:      "pending",
-		CreatedAt:   now,
+		ID:            uuid.New().String(),
+		DeviceID:      deviceID,
+		CommandType:   commandType,
+		Payload:       payload,
+		Status:        CommandStatusQueued,
+		CreatedAt:     now,
+		MaxAttempts:   s.policy.MaxAttempts,
+		NextAttemptAt: now,
+		ExpiresAt:     now.Add(s.policy.TTL),
+		UpdatedAt:     now,
 	}
 
+	s.cmdMu.Lock()
+	defer s.cmdMu.Unlock()
+
 	if err := s.repo.SendCommand(ctx, cmd); err != nil {
 		return nil, errors.New("명령 전송에 실패했습니다: " + err.Error())
 	}
 
+	// MQTT 디바이스는 명령 토픽으로 즉시 발행 (실패 시 queued로 남아 재시도)
+	if s.canDispatch(device) {
+		s.dispatchLocked(ctx, cmd)
+	}
+
 	return cmd, nil
 }
 
@@ -147,7 +293,7 @@ func (s *IoTGatewayService) ReceiveData(ctx context.Context, deviceID, dataType
 		return nil, errors.New("data_type은 필수입니다")
 	}
 
-	now := time.Now().UTC()
+	now := s.now()
 	data := &IoTData{
 		ID:         uuid.New().String(),
 		DeviceID:   deviceID,
@@ -161,9 +307,37 @@ func (s *IoTGatewayService) ReceiveData(ctx context.Context, deviceID, dataType
 		return nil, errors.New("데이터 수신에 실패했습니다: " + err.Error())
 	}
 
+	s.evaluateRules(ctx, data)
+
 	return data, nil
 }
 
+// AuthenticateDevice는 MQTT 접속 비밀번호가 디바이스에 발급된 것과 일치하는지 확인합니다.
+func (s *IoTGatewayService) AuthenticateDevice(ctx context.Context, deviceID, credential string) bool {
+	if deviceID == "" || credential == "" {
+		return false
+	}
+	device, err := s.repo.GetDevice(ctx, deviceID)
+	if err != nil || device == nil || device.CredentialHash == "" {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(device.CredentialHash), []byte(hashCredential(credential))) == 1
+}
+
+// UpdateDeviceStatus는 디바이스 연결 상태(online/offline)를 갱신합니다.
+func (s *IoTGatewayService) UpdateDeviceStatus(ctx context.Context, deviceID, status string) error {
+	if deviceID == "" {
+		return errors.New("device_id는 필수입니다")
+	}
+	if status != DeviceStatusOnline && status != DeviceStatusOffline {
+		return errors.New("지원하지 않는 디바이스 상태입니다: " + status)
+	}
+	if err := s.repo.UpdateDeviceStatus(ctx, deviceID, status, s.now()); err != nil {
+		return errors.New("디바이스 상태 갱신에 실패했습니다: " + err.Error())
+	}
+	return nil
+}
+
 // GetDevice는 디바이스를 조회합니다.
 func (s *IoTGatewayService) GetDevice(ctx context.Context, deviceID string) (*IoTDevice, error) {
 	if deviceID == "" {
@@ -212,3 +386,17 @@ func (s *IoTGatewayService) ListData(ctx context.Context, deviceID string, limit
 
 	return s.repo.ListData(ctx, deviceID, limit, offset)
 }
+
+// newCredential은 32바이트 난수 기반 접속 비밀번호를 생성합니다.
+func newCredential() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+func hashCredential(credential string) string {
+	sum := sha256.Sum256([]byte(credential))
+	return hex.EncodeToString(sum[:])
+}
@@ -2,10 +2,12 @@ package service_test
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	"github.com/manpasik/backend/services/iot-gateway-service/internal/repository/memory"
 	"github.com/manpasik/backend/services/iot-gateway-service/internal/service"
+	apperrors "github.com/manpasik/backend/shared/errors"
 )
 
 // newTestService는 테스트용 IoTGatewayService를 생성합니다.
@@ -71,6 +73,45 @@ func TestRegisterDevice_MissingProtocol(t *testing.T) {
 	}
 }
 
+func TestRegisterDevice_AlreadyExists(t *testing.T) {
+	svc := newTestService()
+	ctx := context.Background()
+
+	first, err := svc.RegisterDevice(ctx, "device-003", service.ProtocolMQTT, map[string]string{"user_id": "user-1"})
+	if err != nil {
+		t.Fatalf("RegisterDevice 실패: %v", err)
+	}
+	_, err = svc.RegisterDevice(ctx, "device-003", service.ProtocolMQTT, map[string]string{"user_id": "attacker"})
+	var appErr *apperrors.AppError
+	if !errors.As(err, &appErr) || appErr.Code != apperrors.ErrAlreadyExists {
+		t.Fatalf("재등록 에러 = %v, want AlreadyExists", err)
+	}
+	// 기존 비밀번호와 소유자는 그대로
+	if !svc.AuthenticateDevice(ctx, "device-003", first.Credential) {
+		t.Error("재등록 시도 후 기존 비밀번호로 인증할 수 있어야 합니다")
+	}
+	if device, _ := svc.GetDevice(ctx, "device-003"); device.Metadata["user_id"] != "user-1" {
+		t.Errorf("재등록 시도가 메타데이터를 덮어씀: %v", device.Metadata)
+	}
+}
+
+func TestRotateDeviceCredential(t *testing.T) {
+	svc := newTestService()
+	ctx := context.Background()
+	first, _ := svc.RegisterDevice(ctx, "device-004", service.ProtocolMQTT, nil)
+
+	if _, err := svc.RotateDeviceCredential(ctx, "device-004", "wrong"); err == nil {
+		t.Fatal("현재 비밀번호가 틀리면 교체가 거부되어야 합니다")
+	}
+	rotated, err := svc.RotateDeviceCredential(ctx, "device-004", first.Credential)
+	if err != nil {
+		t.Fatalf("RotateDeviceCredential 실패: %v", err)
+	}
+	if svc.AuthenticateDevice(ctx, "device-004", first.Credential) || !svc.AuthenticateDevice(ctx, "device-004", rotated.Credential) {
+		t.Error("교체 후에는 새 비밀번호로만 인증되어야 합니다")
+	}
+}
+
 // ============================================================================
 // 명령 전송 테스트
 // ============================================================================
@@ -101,8 +142,8 @@ func TestSendCommand_Success(t *testing.T) {
 	if cmd.Payload != `{"delay":5}` {
 		t.Errorf("Payload: got %s, want {\"delay\":5}", cmd.Payload)
 	}
-	if cmd.Status != "pending" {
-		t.Errorf("Status: got %s, want pending", cmd.Status)
+	if cmd.Status != "queued" {
+		t.Errorf("Status: got %s, want queued", cmd.Status)
 	}
 	if cmd.CreatedAt.IsZero() {
 		t.Error("CreatedAt가 zero입니다")
@@ -293,8 +334,8 @@ func TestEndToEnd_IoTFlow(t *testing.T) {
 	if err != nil {
 		t.Fatalf("E2E: 명령 전송 실패: %v", err)
 	}
-	if cmd.Status != "pending" {
-		t.Errorf("E2E: Command Status: got %s, want pending", cmd.Status)
+	if cmd.Status != "queued" {
+		t.Errorf("E2E: Command Status: got %s, want queued", cmd.Status)
 	}
 
 	// 4. 명령 조회
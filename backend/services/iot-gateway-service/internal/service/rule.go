@@ -0,0 +1,466 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ============================================================================
+// 데이터 규칙 엔진 (Rule Engine)
+// ============================================================================
+
+// 규칙 집계 방식
+const (
+	RuleAggLatest = "value" // 최신 값
+	RuleAggAvg    = "avg"   // 윈도우 이동 평균
+	RuleAggMin    = "min"
+	RuleAggMax    = "max"
+)
+
+// 규칙 동작 유형
+const (
+	RuleActionNotify  = "notify"  // iot.rule_triggered 이벤트 → notification-service
+	RuleActionCommand = "command" // 데이터를 보낸 디바이스에 명령 전송 (관리자 전용)
+)
+
+// Rule은 디바이스 데이터 스트림에 대한 임계값 규칙입니다.
+//
+// Expression 형식: "<집계>[(<윈도우>)] <연산자> <임계값> [for <지속시간>]"
+//
+//	value > 148 for 1h     최신 값이 1시간 동안 계속 148 초과
+//	avg(15m) > 35          15분 이동 평균이 35 초과
+//	min(10m) < 18.5        10분 최솟값이 18.5 미만
+type Rule struct {
+	ID      string
+	OwnerID string // 사용자 ID 또는 관리자 ID
+	Name    string
+	// DeviceID가 비어 있으면 DataType을 보내는 모든 디바이스에 적용됩니다 (관리자 전용).
+	DeviceID   string
+	DataType   string
+	Expression string
+
+	// 아래 조건 필드는 Expression에서 해석됩니다.
+	Aggregation string
+	Window      time.Duration
+	Operator    string
+	Threshold   float64
+	For         time.Duration
+
+	// Hysteresis는 발동 후 해제되기 위해 임계값 반대쪽으로 벗어나야 하는 폭입니다.
+	Hysteresis float64
+	// Cooldown은 재발동 사이의 최소 간격입니다.
+	Cooldown time.Duration
+
+	Action    RuleAction
+	Enabled   bool
+	CreatedAt time.Time
+}
+
+// RuleAction은 규칙 발동 시 수행할 동작입니다.
+type RuleAction struct {
+	Type           string
+	CommandType    string // RuleActionCommand 전용
+	CommandPayload string
+}
+
+// RuleSample은 윈도우 집계에 사용하는 측정값입니다.
+type RuleSample struct {
+	Value float64
+	At    time.Time
+}
+
+// RuleState는 규칙·디바이스 조합별 증분 평가 상태입니다.
+type RuleState struct {
+	RuleID   string
+	DeviceID string
+	// Samples는 윈도우 안의 측정값이며 오래된 순입니다.
+	Samples []RuleSample
+	// ObservedSince는 끊김 없이 측정값을 받기 시작한 시각입니다.
+	// 윈도우 집계는 이 시각부터 Window가 지나야 평가합니다.
+	ObservedSince time.Time
+	// ConditionSince는 조건이 연속으로 참이 된 시각입니다 (거짓이면 zero).
+	ConditionSince time.Time
+	// Active는 발동 후 아직 해제되지 않았음을 뜻합니다.
+	Active          bool
+	LastTriggeredAt time.Time
+}
+
+// RuleTrigger는 규칙 발동 이력입니다.
+type RuleTrigger struct {
+	ID          string
+	RuleID      string
+	DeviceID    string
+	DataType    string
+	Value       float64 // 발동 시점의 집계 값
+	CommandID   string  // RuleActionCommand로 전송된 명령 ID
+	TriggeredAt time.Time
+}
+
+// RuleTriggeredEvent는 notify 규칙 발동 시 발행되는 이벤트입니다.
+type RuleTriggeredEvent struct {
+	RuleID      string
+	RuleName    string
+	OwnerID     string
+	UserID      string // 디바이스 메타데이터의 user_id
+	DeviceID    string
+	DataType    string
+	Expression  string
+	Value       float64
+	TriggeredAt time.Time
+}
+
+// RuleRepository는 규칙·평가 상태·발동 이력 저장소입니다.
+type RuleRepository interface {
+	CreateRule(ctx context.Context, rule *Rule) error
+	GetRule(ctx context.Context, ruleID string) (*Rule, error)
+	// ListRules는 소유자의 규칙을 조회합니다. ownerID가 비어 있으면 전체를 조회합니다.
+	ListRules(ctx context.Context, ownerID string) ([]*Rule, error)
+	// ListActiveRules는 디바이스·데이터 유형에 적용되는 활성 규칙을 조회합니다.
+	ListActiveRules(ctx context.Context, deviceID, dataType string) ([]*Rule, error)
+	SetRuleEnabled(ctx context.Context, ruleID string, enabled bool) error
+	DeleteRule(ctx context.Context, ruleID string) error
+	GetRuleState(ctx context.Context, ruleID, deviceID string) (*RuleState, error)
+	SaveRuleState(ctx context.Context, state *RuleState) error
+	SaveRuleTrigger(ctx context.Context, trigger *RuleTrigger) error
+	// ListRuleTriggers는 규칙의 발동 이력을 최신순으로 조회합니다.
+	ListRuleTriggers(ctx context.Context, ruleID string, limit int) ([]*RuleTrigger, error)
+}
+
+// RuleEventPublisher는 규칙 발동 이벤트를 발행합니다.
+type RuleEventPublisher interface {
+	PublishRuleTriggered(ctx context.Context, event *RuleTriggeredEvent) error
+}
+
+// SetRuleRepository는 규칙 저장소를 설정합니다. 설정하지 않으면 규칙을 평가하지 않습니다.
+func (s *IoTGatewayService) SetRuleRepository(repo RuleRepository) {
+	s.rules = repo
+}
+
+// SetRuleEventPublisher는 규칙 발동 이벤트 발행기를 설정합니다.
+func (s *IoTGatewayService) SetRuleEventPublisher(p RuleEventPublisher) {
+	s.ruleEvents = p
+}
+
+// ruleExpr는 "avg(15m) > 35 for 1h" 형식의 규칙 식입니다.
+var ruleExpr = regexp.MustCompile(`^(value|avg|min|max)(?:\(([0-9a-z.]+)\))?\s*(>=|<=|>|<)\s*(-?[0-9]+(?:\.[0-9]+)?)(?:\s+for\s+([0-9a-z.]+))?$`)
+
+// parseRuleExpression은 규칙 식을 해석해 조건 필드를 채웁니다.
+func parseRuleExpression(rule *Rule) error {
+	m := ruleExpr.FindStringSubmatch(strings.ToLower(strings.TrimSpace(rule.Expression)))
+	if m == nil {
+		return errors.New("규칙 식 형식이 올바르지 않습니다: " + rule.Expression)
+	}
+
+	rule.Aggregation = m[1]
+	rule.Window = 0
+	if m[2] != "" {
+		if rule.Aggregation == RuleAggLatest {
+			return errors.New("value에는 윈도우를 지정할 수 없습니다")
+		}
+		d, err := time.ParseDuration(m[2])
+		if err != nil || d <= 0 {
+			return errors.New("윈도우가 올바르지 않습니다: " + m[2])
+		}
+		rule.Window = d
+	} else if rule.Aggregation != RuleAggLatest {
+		return errors.New(rule.Aggregation + "에는 윈도우가 필요합니다 (예: avg(15m))")
+	}
+
+	rule.Operator = m[3]
+	rule.Threshold, _ = strconv.ParseFloat(m[4], 64)
+
+	rule.For = 0
+	if m[5] != "" {
+		d, err := time.ParseDuration(m[5])
+		if err != nil || d < 0 {
+			return errors.New("지속 시간이 올바르지 않습니다: " + m[5])
+		}
+		rule.For = d
+	}
+	return nil
+}
+
+// CreateRule은 규칙을 검증·해석한 뒤 저장합니다.
+// 일반 사용자는 자신의 디바이스(메타데이터 user_id)에 대한 알림 규칙만 만들 수 있고,
+// 전체 디바이스 규칙(DeviceID 없음)과 명령 동작 규칙은 관리자(admin)만 만들 수 있습니다.
+func (s *IoTGatewayService) CreateRule(ctx context.Context, rule *Rule, admin bool) (*Rule, error) {
+	if s.rules == nil {
+		return nil, errors.New("규칙 저장소가 설정되지 않았습니다")
+	}
+	if rule.OwnerID == "" {
+		return nil, errors.New("owner_id는 필수입니다")
+	}
+	if rule.DataType == "" {
+		return nil, errors.New("data_type은 필수입니다")
+	}
+	if err := parseRuleExpression(rule); err != nil {
+		return nil, err
+	}
+	if rule.Hysteresis < 0 || rule.Cooldown < 0 {
+		return nil, errors.New("hysteresis와 cooldown은 0 이상이어야 합니다")
+	}
+	switch rule.Action.Type {
+	case RuleActionNotify:
+	case RuleActionCommand:
+		if !admin {
+			return nil, errors.New("명령 동작 규칙은 관리자만 만들 수 있습니다")
+		}
+		if rule.Action.CommandType == "" {
+			return nil, errors.New("명령 동작에는 command_type이 필요합니다")
+		}
+	default:
+		return nil, errors.New("지원하지 않는 규칙 동작입니다: " + rule.Action.Type)
+	}
+	if rule.DeviceID == "" {
+		if !admin {
+			return nil, errors.New("전체 디바이스 규칙은 관리자만 만들 수 있습니다")
+		}
+	} else {
+		device, err := s.GetDevice(ctx, rule.DeviceID)
+		if err != nil {
+			return nil, err
+		}
+		if !admin && device.Metadata["user_id"] != rule.OwnerID {
+			return nil, errors.New("디바이스 소유자만 규칙을 만들 수 있습니다")
+		}
+	}
+
+	rule.ID = uuid.New().String()
+	rule.Enabled = true
+	rule.CreatedAt = s.now()
+	if err := s.rules.CreateRule(ctx, rule); err != nil {
+		return nil, errors.New("규칙 저장에 실패했습니다: " + err.Error())
+	}
+	return rule, nil
+}
+
+// ListRules는 소유자의 규칙 목록을 조회합니다.
+func (s *IoTGatewayService) ListRules(ctx context.Context, ownerID string) ([]*Rule, error) {
+	if s.rules == nil {
+		return nil, errors.New("규칙 저장소가 설정되지 않았습니다")
+	}
+	return s.rules.ListRules(ctx, ownerID)
+}
+
+// SetRuleEnabled는 규칙을 켜거나 끕니다. 소유자만 변경할 수 있습니다.
+func (s *IoTGatewayService) SetRuleEnabled(ctx context.Context, ruleID, ownerID string, enabled bool) error {
+	if _, err := s.ownedRule(ctx, ruleID, ownerID); err != nil {
+		return err
+	}
+	return s.rules.SetRuleEnabled(ctx, ruleID, enabled)
+}
+
+// DeleteRule은 규칙을 삭제합니다. 소유자만 삭제할 수 있습니다.
+func (s *IoTGatewayService) DeleteRule(ctx context.Context, ruleID, ownerID string) error {
+	if _, err := s.ownedRule(ctx, ruleID, ownerID); err != nil {
+		return err
+	}
+	return s.rules.DeleteRule(ctx, ruleID)
+}
+
+// ListRuleTriggers는 규칙의 발동 이력을 조회합니다.
+func (s *IoTGatewayService) ListRuleTriggers(ctx context.Context, ruleID, ownerID string, limit int) ([]*RuleTrigger, error) {
+	if _, err := s.ownedRule(ctx, ruleID, ownerID); err != nil {
+		return nil, err
+	}
+	if limit <= 0 {
+		limit = 20
+	}
+	return s.rules.ListRuleTriggers(ctx, ruleID, limit)
+}
+
+func (s *IoTGatewayService) ownedRule(ctx context.Context, ruleID, ownerID string) (*Rule, error) {
+	if s.rules == nil {
+		return nil, errors.New("규칙 저장소가 설정되지 않았습니다")
+	}
+	rule, err := s.rules.GetRule(ctx, ruleID)
+	if err != nil {
+		return nil, errors.New("규칙 조회에 실패했습니다: " + err.Error())
+	}
+	if rule == nil {
+		return nil, errors.New("규칙을 찾을 수 없습니다: " + ruleID)
+	}
+	if rule.OwnerID != ownerID {
+		return nil, errors.New("규칙 소유자만 접근할 수 있습니다")
+	}
+	return rule, nil
+}
+
+// evaluateRules는 수신 데이터에 적용되는 활성 규칙을 증분 평가합니다.
+// 규칙 평가 실패는 데이터 수신을 막지 않도록 로그만 남깁니다.
+func (s *IoTGatewayService) evaluateRules(ctx context.Context, data *IoTData) {
+	if s.rules == nil {
+		return
+	}
+	rules, err := s.rules.ListActiveRules(ctx, data.DeviceID, data.DataType)
+	if err != nil {
+		log.Printf("[iot-gateway] 규칙 조회 실패 (device=%s): %v", data.DeviceID, err)
+		return
+	}
+
+	s.ruleMu.Lock()
+	defer s.ruleMu.Unlock()
+	for _, rule := range rules {
+		if err := s.evaluateRule(ctx, rule, data); err != nil {
+			log.Printf("[iot-gateway] 규칙 평가 실패 (rule=%s, device=%s): %v", rule.ID, data.DeviceID, err)
+		}
+	}
+}
+
+// evaluateRule은 규칙 하나의 상태를 새 측정값으로 갱신하고 필요하면 발동합니다.
+//
+//   - 윈도우 집계는 측정값이 Window만큼 쌓인 뒤부터 평가합니다.
+//   - 조건이 참이 된 시각부터 For 동안 계속 참이면 발동합니다.
+//   - 발동 후에는 집계 값이 임계값 반대쪽으로 Hysteresis 이상 벗어나야 해제(재무장)됩니다.
+//   - 재무장되더라도 마지막 발동 후 Cooldown이 지나기 전에는 발동하지 않습니다.
+func (s *IoTGatewayService) evaluateRule(ctx context.Context, rule *Rule, data *IoTData) error {
+	state, err := s.rules.GetRuleState(ctx, rule.ID, data.DeviceID)
+	if err != nil {
+		return err
+	}
+	now := data.ReceivedAt
+	if state == nil {
+		state = &RuleState{RuleID: rule.ID, DeviceID: data.DeviceID, ObservedSince: now}
+	} else if n := len(state.Samples); rule.Window > 0 && (n == 0 || now.Sub(state.Samples[n-1].At) > rule.Window) {
+		// 윈도우보다 긴 공백 뒤에는 이전 측정값이 모두 밀려나므로 관측을 다시 시작합니다.
+		state.ObservedSince = now
+		state.ConditionSince = time.Time{}
+	}
+
+	state.Samples = append(state.Samples, RuleSample{Value: data.Value, At: now})
+	cutoff := now.Add(-rule.Window)
+	i := 0
+	for i < len(state.Samples)-1 && !state.Samples[i].At.After(cutoff) {
+		i++
+	}
+	state.Samples = state.Samples[i:]
+	if rule.Window > 0 && now.Sub(state.ObservedSince) < rule.Window {
+		// 측정값이 윈도우를 다 채우기 전의 집계는 대표성이 없으므로 평가하지 않습니다.
+		return s.rules.SaveRuleState(ctx, state)
+	}
+	value := aggregate(rule.Aggregation, state.Samples)
+
+	if compare(rule.Operator, value, rule.Threshold) {
+		if state.ConditionSince.IsZero() {
+			state.ConditionSince = now
+		}
+		heldFor := now.Sub(state.ConditionSince)
+		coolingDown := !state.LastTriggeredAt.IsZero() && now.Sub(state.LastTriggeredAt) < rule.Cooldown
+		if !state.Active && heldFor >= rule.For && !coolingDown {
+			state.Active = true
+			state.LastTriggeredAt = now
+			if err := s.rules.SaveRuleState(ctx, state); err != nil {
+				return err
+			}
+			return s.triggerRule(ctx, rule, data, value)
+		}
+	} else {
+		state.ConditionSince = time.Time{}
+		if state.Active && cleared(rule, value) {
+			state.Active = false
+		}
+	}
+	return s.rules.SaveRuleState(ctx, state)
+}
+
+// triggerRule은 발동 이력을 남기고 규칙 동작을 수행합니다.
+func (s *IoTGatewayService) triggerRule(ctx context.Context, rule *Rule, data *IoTData, value float64) error {
+	trigger := &RuleTrigger{
+		ID:          uuid.New().String(),
+		RuleID:      rule.ID,
+		DeviceID:    data.DeviceID,
+		DataType:    data.DataType,
+		Value:       value,
+		TriggeredAt: data.ReceivedAt,
+	}
+
+	switch rule.Action.Type {
+	case RuleActionCommand:
+		cmd, err := s.SendCommand(ctx, data.DeviceID, rule.Action.CommandType, rule.Action.CommandPayload)
+		if err != nil {
+			return err
+		}
+		trigger.CommandID = cmd.ID
+	case RuleActionNotify:
+		if s.ruleEvents != nil {
+			event := &RuleTriggeredEvent{
+				RuleID:      rule.ID,
+				RuleName:    rule.Name,
+				OwnerID:     rule.OwnerID,
+				DeviceID:    data.DeviceID,
+				DataType:    data.DataType,
+				Expression:  rule.Expression,
+				Value:       value,
+				TriggeredAt: data.ReceivedAt,
+			}
+			if device, err := s.repo.GetDevice(ctx, data.DeviceID); err == nil && device != nil {
+				event.UserID = device.Metadata["user_id"]
+			}
+			if err := s.ruleEvents.PublishRuleTriggered(ctx, event); err != nil {
+				log.Printf("[iot-gateway] 규칙 발동 이벤트 발행 실패 (rule=%s): %v", rule.ID, err)
+			}
+		}
+	}
+
+	return s.rules.SaveRuleTrigger(ctx, trigger)
+}
+
+func aggregate(agg string, samples []RuleSample) float64 {
+	last := samples[len(samples)-1].Value
+	switch agg {
+	case RuleAggAvg:
+		var sum float64
+		for _, sm := range samples {
+			sum += sm.Value
+		}
+		return sum / float64(len(samples))
+	case RuleAggMin:
+		v := last
+		for _, sm := range samples {
+			if sm.Value < v {
+				v = sm.Value
+			}
+		}
+		return v
+	case RuleAggMax:
+		v := last
+		for _, sm := range samples {
+			if sm.Value > v {
+				v = sm.Value
+			}
+		}
+		return v
+	}
+	return last
+}
+
+func compare(op string, value, threshold float64) bool {
+	switch op {
+	case ">":
+		return value > threshold
+	case ">=":
+		return value >= threshold
+	case "<":
+		return value < threshold
+	case "<=":
+		return value <= threshold
+	}
+	return false
+}
+
+// cleared는 발동된 규칙이 히스테리시스 폭을 넘어 해제되었는지 확인합니다.
+func cleared(rule *Rule, value float64) bool {
+	switch rule.Operator {
+	case ">", ">=":
+		return value <= rule.Threshold-rule.Hysteresis
+	default:
+		return value >= rule.Threshold+rule.Hysteresis
+	}
+}
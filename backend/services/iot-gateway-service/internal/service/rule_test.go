@@ -0,0 +1,236 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/manpasik/backend/services/iot-gateway-service/internal/repository/memory"
+	"github.com/manpasik/backend/services/iot-gateway-service/internal/service"
+)
+
+// fakeRuleEvents는 발행된 규칙 발동 이벤트를 보관합니다.
+type fakeRuleEvents struct {
+	events []*service.RuleTriggeredEvent
+}
+
+func (p *fakeRuleEvents) PublishRuleTriggered(_ context.Context, e *service.RuleTriggeredEvent) error {
+	p.events = append(p.events, e)
+	return nil
+}
+
+// newRuleTestService는 가짜 시계와 규칙 저장소, 디바이스 dev-1(user-1)을 준비합니다.
+func newRuleTestService(t *testing.T) (*service.IoTGatewayService, *fakeClock, *fakeRuleEvents) {
+	t.Helper()
+	svc, clock, _, _ := newCommandTestService(t)
+	ev := &fakeRuleEvents{}
+	svc.SetRuleRepository(memory.NewRuleRepository())
+	svc.SetRuleEventPublisher(ev)
+	return svc, clock, ev
+}
+
+// createRule은 관리자 권한으로 규칙을 만듭니다 (권한 검사는 TestCreateRule_권한에서 확인).
+func createRule(t *testing.T, svc *service.IoTGatewayService, rule *service.Rule) *service.Rule {
+	t.Helper()
+	if rule.OwnerID == "" {
+		rule.OwnerID = "user-1"
+	}
+	if rule.Action.Type == "" {
+		rule.Action.Type = service.RuleActionNotify
+	}
+	created, err := svc.CreateRule(context.Background(), rule, true)
+	if err != nil {
+		t.Fatalf("규칙 생성 실패: %v", err)
+	}
+	return created
+}
+
+// feed는 시계를 d만큼 앞당긴 뒤 값을 수신합니다.
+func feed(t *testing.T, svc *service.IoTGatewayService, clock *fakeClock, d time.Duration, dataType string, value float64) {
+	t.Helper()
+	clock.Advance(d)
+	if _, err := svc.ReceiveData(context.Background(), "dev-1", dataType, value, ""); err != nil {
+		t.Fatalf("ReceiveData 실패: %v", err)
+	}
+}
+
+// ============================================================================
+// 규칙 엔진 테스트
+// ============================================================================
+
+func TestRule_지속시간_조건(t *testing.T) {
+	svc, clock, ev := newRuleTestService(t)
+	createRule(t, svc, &service.Rule{Name: "라돈 경보", DataType: "radon", Expression: "value > 148 for 1h"})
+
+	feed(t, svc, clock, 0, "radon", 150)
+	feed(t, svc, clock, 30*time.Minute, "radon", 160)
+	if len(ev.events) != 0 {
+		t.Fatalf("1시간이 지나기 전에는 발동하면 안 됩니다: %d", len(ev.events))
+	}
+
+	// 중간에 조건이 깨지면 지속 시간이 다시 시작됨
+	feed(t, svc, clock, 10*time.Minute, "radon", 140)
+	feed(t, svc, clock, 10*time.Minute, "radon", 155)
+	feed(t, svc, clock, 50*time.Minute, "radon", 155)
+	if len(ev.events) != 0 {
+		t.Fatalf("조건이 끊기면 지속 시간이 초기화되어야 합니다: %d", len(ev.events))
+	}
+	feed(t, svc, clock, 10*time.Minute, "radon", 152)
+	if len(ev.events) != 1 || ev.events[0].Value != 152 || ev.events[0].UserID != "user-1" {
+		t.Fatalf("1시간 지속 시 발동해야 합니다: %+v", ev.events)
+	}
+
+	// 발동 상태에서는 반복 발동하지 않음
+	feed(t, svc, clock, 10*time.Minute, "radon", 170)
+	if len(ev.events) != 1 {
+		t.Errorf("해제 전에는 재발동하면 안 됩니다: %d", len(ev.events))
+	}
+}
+
+func TestRule_이동평균_윈도우(t *testing.T) {
+	svc, clock, ev := newRuleTestService(t)
+	createRule(t, svc, &service.Rule{DataType: "pm25", Expression: "avg(15m) > 35"})
+
+	// 측정값이 15분을 채우기 전에는 첫 측정(60)만으로 발동하지 않음
+	feed(t, svc, clock, 0, "pm25", 60)
+	feed(t, svc, clock, 5*time.Minute, "pm25", 10)
+	feed(t, svc, clock, 5*time.Minute, "pm25", 20)
+	if len(ev.events) != 0 {
+		t.Fatalf("윈도우가 채워지기 전에는 발동하면 안 됩니다: %d", len(ev.events))
+	}
+	feed(t, svc, clock, 5*time.Minute, "pm25", 90) // 윈도우: 10,20,90 → 40
+	if len(ev.events) != 1 || ev.events[0].Value != 40 {
+		t.Fatalf("윈도우가 채워지면 평균으로 발동해야 합니다: %+v", ev.events)
+	}
+
+	// 평균이 떨어지면 해제, 이후 다시 35 초과 시 재발동
+	feed(t, svc, clock, 10*time.Minute, "pm25", 0) // 윈도우: 90,0 → 45
+	feed(t, svc, clock, 6*time.Minute, "pm25", 0)  // 윈도우: 0,0 → 0 (해제)
+	feed(t, svc, clock, time.Minute, "pm25", 120)  // 윈도우: 0,0,120 → 40
+	if len(ev.events) != 2 || ev.events[1].Value != 40 {
+		t.Errorf("윈도우 평균으로 재발동해야 합니다: %+v", ev.events)
+	}
+}
+
+func TestRule_윈도우_공백_후_다시_채움(t *testing.T) {
+	svc, clock, ev := newRuleTestService(t)
+	createRule(t, svc, &service.Rule{DataType: "co2", Expression: "max(10m) > 50"})
+
+	feed(t, svc, clock, 0, "co2", 10)
+	feed(t, svc, clock, 10*time.Minute, "co2", 10)
+
+	// 1시간 공백 뒤 첫 측정값만으로는 발동하지 않음
+	feed(t, svc, clock, time.Hour, "co2", 100)
+	if len(ev.events) != 0 {
+		t.Fatalf("공백 뒤 윈도우가 다시 채워지기 전에는 발동하면 안 됩니다: %d", len(ev.events))
+	}
+	feed(t, svc, clock, 10*time.Minute, "co2", 100)
+	if len(ev.events) != 1 {
+		t.Errorf("윈도우가 다시 채워지면 발동해야 합니다: %d", len(ev.events))
+	}
+}
+
+func TestRule_히스테리시스와_쿨다운(t *testing.T) {
+	svc, clock, ev := newRuleTestService(t)
+	createRule(t, svc, &service.Rule{
+		DataType:   "temperature",
+		Expression: "value > 100",
+		Hysteresis: 10,
+		Cooldown:   10 * time.Minute,
+	})
+
+	feed(t, svc, clock, 0, "temperature", 110)
+	feed(t, svc, clock, time.Minute, "temperature", 95) // 히스테리시스 폭 안: 해제 안 됨
+	feed(t, svc, clock, time.Minute, "temperature", 110)
+	if len(ev.events) != 1 {
+		t.Fatalf("히스테리시스 폭 안에서는 재발동하면 안 됩니다: %d", len(ev.events))
+	}
+
+	feed(t, svc, clock, time.Minute, "temperature", 85)  // 해제
+	feed(t, svc, clock, time.Minute, "temperature", 120) // t=4m, 쿨다운 중
+	if len(ev.events) != 1 {
+		t.Fatalf("쿨다운 중에는 발동하면 안 됩니다: %d", len(ev.events))
+	}
+	feed(t, svc, clock, 6*time.Minute, "temperature", 120) // t=10m
+	if len(ev.events) != 2 {
+		t.Errorf("쿨다운 후 재발동해야 합니다: %d", len(ev.events))
+	}
+}
+
+func TestRule_명령_동작과_발동_이력(t *testing.T) {
+	svc, clock, ev := newRuleTestService(t)
+	ctx := context.Background()
+	rule := createRule(t, svc, &service.Rule{
+		DeviceID:   "dev-1",
+		DataType:   "humidity",
+		Expression: "value < 30",
+		Action:     service.RuleAction{Type: service.RuleActionCommand, CommandType: "humidifier_on"},
+	})
+
+	feed(t, svc, clock, 0, "humidity", 25)
+	triggers, err := svc.ListRuleTriggers(ctx, rule.ID, "user-1", 10)
+	if err != nil || len(triggers) != 1 || triggers[0].CommandID == "" {
+		t.Fatalf("명령 동작 발동 이력 불일치: %+v, err=%v", triggers, err)
+	}
+	cmd, _ := svc.GetCommand(ctx, triggers[0].CommandID)
+	if cmd.CommandType != "humidifier_on" || cmd.DeviceID != "dev-1" {
+		t.Errorf("규칙이 보낸 명령 불일치: %+v", cmd)
+	}
+	if len(ev.events) != 0 {
+		t.Errorf("명령 동작은 알림 이벤트를 발행하지 않습니다: %d", len(ev.events))
+	}
+
+	if _, err := svc.ListRuleTriggers(ctx, rule.ID, "user-2", 10); err == nil {
+		t.Error("다른 사용자는 발동 이력을 볼 수 없어야 합니다")
+	}
+	svc.SetRuleEnabled(ctx, rule.ID, "user-1", false)
+	feed(t, svc, clock, time.Minute, "humidity", 50)
+	feed(t, svc, clock, time.Minute, "humidity", 20)
+	if triggers, _ := svc.ListRuleTriggers(ctx, rule.ID, "user-1", 10); len(triggers) != 1 {
+		t.Errorf("비활성 규칙은 평가하지 않아야 합니다: %d", len(triggers))
+	}
+}
+
+func TestCreateRule_식_검증(t *testing.T) {
+	svc, _, _ := newRuleTestService(t)
+	ctx := context.Background()
+
+	invalid := map[string]*service.Rule{
+		"형식 오류":     {OwnerID: "user-1", DataType: "radon", Expression: "radon is high", Action: service.RuleAction{Type: service.RuleActionNotify}},
+		"윈도우 누락":    {OwnerID: "user-1", DataType: "pm25", Expression: "avg > 35", Action: service.RuleAction{Type: service.RuleActionNotify}},
+		"명령 유형 누락":  {OwnerID: "user-1", DataType: "pm25", Expression: "value > 35", Action: service.RuleAction{Type: service.RuleActionCommand}},
+		"미등록 디바이스":  {OwnerID: "user-1", DeviceID: "dev-9", DataType: "pm25", Expression: "value > 35", Action: service.RuleAction{Type: service.RuleActionNotify}},
+		"음수 히스테리시스": {OwnerID: "user-1", DataType: "pm25", Expression: "value > 35", Hysteresis: -1, Action: service.RuleAction{Type: service.RuleActionNotify}},
+	}
+	for name, rule := range invalid {
+		if _, err := svc.CreateRule(ctx, rule, true); err == nil {
+			t.Errorf("%s: 규칙 생성이 거부되어야 합니다", name)
+		}
+	}
+
+	rule := createRule(t, svc, &service.Rule{DataType: "pm25", Expression: "MAX(1h) >= 75.5 for 30m"})
+	if rule.Aggregation != service.RuleAggMax || rule.Window != time.Hour || rule.Operator != ">=" || rule.Threshold != 75.5 || rule.For != 30*time.Minute {
+		t.Errorf("식 해석 결과 불일치: %+v", rule)
+	}
+}
+
+func TestCreateRule_권한(t *testing.T) {
+	svc, _, _ := newRuleTestService(t)
+	ctx := context.Background()
+	notify := service.RuleAction{Type: service.RuleActionNotify}
+
+	denied := map[string]*service.Rule{
+		"다른 사용자 디바이스": {OwnerID: "user-2", DeviceID: "dev-1", DataType: "pm25", Expression: "value > 35", Action: notify},
+		"전체 디바이스 규칙":  {OwnerID: "user-1", DataType: "pm25", Expression: "value > 35", Action: notify},
+		"명령 동작":       {OwnerID: "user-1", DeviceID: "dev-1", DataType: "humidity", Expression: "value < 30", Action: service.RuleAction{Type: service.RuleActionCommand, CommandType: "humidifier_on"}},
+	}
+	for name, rule := range denied {
+		if _, err := svc.CreateRule(ctx, rule, false); err == nil {
+			t.Errorf("%s: 일반 사용자의 규칙 생성이 거부되어야 합니다", name)
+		}
+	}
+
+	if _, err := svc.CreateRule(ctx, &service.Rule{OwnerID: "user-1", DeviceID: "dev-1", DataType: "pm25", Expression: "value > 35", Action: notify}, false); err != nil {
+		t.Errorf("소유자는 자신의 디바이스 알림 규칙을 만들 수 있어야 합니다: %v", err)
+	}
+}
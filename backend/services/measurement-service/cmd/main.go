@@ -9,6 +9,7 @@
 // - 핑거프린트 벡터 저장 (Milvus 또는 인메모리)
 // - 측정 완료 이벤트 발행 (Kafka 또는 인메모리)
 // - 측정 기록 조회 (GetMeasurementHistory)
+// - 핑거프린트 참조 라이브러리 큐레이션 및 kNN 물질 식별 (IdentifySubstance)
 // - gRPC MeasurementService
 package main
 
@@ -18,6 +19,7 @@ import (
 	"net"
 	"os"
 	"os/signal"
+	"strconv"
 	"syscall"
 	"time"
 
@@ -31,6 +33,7 @@ import (
 	milvusRepo "github.com/manpasik/backend/services/measurement-service/internal/repository/milvus"
 	"github.com/manpasik/backend/services/measurement-service/internal/repository/postgres"
 	"github.com/manpasik/backend/services/measurement-service/internal/service"
+	"github.com/manpasik/backend/shared/clients"
 	"github.com/manpasik/backend/shared/config"
 	"github.com/manpasik/backend/shared/events"
 	"github.com/manpasik/backend/shared/search"
@@ -40,6 +43,7 @@ import (
 	"github.com/manpasik/backend/shared/vectordb"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/health"
 	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
@@ -64,6 +68,7 @@ func main() {
 	// SessionRepository / MeasurementRepository: PostgreSQL/TimescaleDB 또는 인메모리
 	var sessionRepo service.SessionRepository
 	var measureRepo service.MeasurementRepository
+	var referenceRepo service.FingerprintLibraryRepository
 	if _, dbHostSet := os.LookupEnv("DB_HOST"); dbHostSet && cfg.DB.Host != "" && cfg.DB.DBName != "" {
 		connCtx, connCancel := context.WithTimeout(context.Background(), 5*time.Second)
 		pool, err := pgxpool.New(connCtx, cfg.DB.DSN())
@@ -85,6 +90,7 @@ func main() {
 				defer pool.Close()
 				sessionRepo = postgres.NewSessionRepository(pool)
 				measureRepo = postgres.NewMeasurementRepository(pool)
+				referenceRepo = postgres.NewFingerprintLibraryRepository(pool)
 				log.Printf("[%s] DB 연결됨: %s", serviceName, cfg.DB.DBName)
 			}
 		}
@@ -93,14 +99,19 @@ func main() {
 		measureRepo = memory.NewMeasurementRepository()
 		log.Printf("[%s] 인메모리 저장소 사용", serviceName)
 	}
+	if referenceRepo == nil {
+		referenceRepo = memory.NewFingerprintLibraryRepository()
+	}
 
 	// VectorRepository: Milvus 또는 인메모리
+	// 참조 라이브러리 벡터는 측정 핑거프린트와 분리된 "{컬렉션}_references" 컬렉션에 저장
 	var vectorRepo service.VectorRepository
+	var referenceIndex service.VectorRepository
 	if _, milvusHostSet := os.LookupEnv("MILVUS_HOST"); milvusHostSet && cfg.Milvus.Host != "" {
 		milvusClient, milvusErr := vectordb.NewMilvusClient(
 			cfg.Milvus.Addr(),
 			cfg.Milvus.CollectionName,
-			896, // 핑거프린트 최대 차원 (MAX_CHANNELS)
+			service.FingerprintDimension, // 핑거프린트 최대 차원 (MAX_CHANNELS)
 		)
 		if milvusErr != nil {
 			log.Printf("[%s] Milvus 연결 실패, 인메모리 VectorRepo 사용: %v", serviceName, milvusErr)
@@ -109,16 +120,28 @@ func main() {
 			defer milvusClient.Close()
 			vectorRepo = milvusRepo.NewVectorRepository(milvusClient)
 			log.Printf("[%s] Milvus 연결됨: %s (컬렉션: %s)", serviceName, cfg.Milvus.Addr(), cfg.Milvus.CollectionName)
+
+			referenceClient, refErr := vectordb.NewMilvusClient(cfg.Milvus.Addr(), cfg.Milvus.CollectionName+"_references", service.FingerprintDimension)
+			if refErr != nil {
+				log.Printf("[%s] Milvus 참조 컬렉션 준비 실패, 인메모리 참조 인덱스 사용: %v", serviceName, refErr)
+			} else {
+				defer referenceClient.Close()
+				referenceIndex = milvusRepo.NewVectorRepository(referenceClient)
+			}
 		}
 	} else {
 		vectorRepo = memory.NewVectorRepository()
 		log.Printf("[%s] 인메모리 VectorRepo 사용", serviceName)
 	}
+	if referenceIndex == nil {
+		referenceIndex = memory.NewVectorRepository()
+	}
 
 	// EventPublisher: Kafka(Redpanda) 또는 인메모리
 	var eventPublisher service.EventPublisher
+	var eventBus *events.KafkaEventBus
 	if _, kafkaBrokersSet := os.LookupEnv("KAFKA_BROKERS"); kafkaBrokersSet && len(cfg.Kafka.Brokers) > 0 {
-		eventBus, kafkaErr := events.NewKafkaEventBus(events.KafkaAdapterConfig{
+		bus, kafkaErr := events.NewKafkaEventBus(events.KafkaAdapterConfig{
 			Brokers:     cfg.Kafka.Brokers,
 			GroupID:     serviceName,
 			TopicPrefix: "manpasik.",
@@ -127,6 +150,7 @@ func main() {
 			log.Printf("[%s] Kafka 연결 실패, 인메모리 EventPublisher 사용: %v", serviceName, kafkaErr)
 			eventPublisher = memory.NewEventPublisher()
 		} else {
+			eventBus = bus
 			defer eventBus.Close()
 			eventPublisher = kafkaPublisher.NewEventPublisher(eventBus)
 			log.Printf("[%s] Kafka 연결됨: %v", serviceName, cfg.Kafka.Brokers)
@@ -137,6 +161,35 @@ func main() {
 	}
 
 	measureSvc := service.NewMeasurementService(logger, sessionRepo, measureRepo, vectorRepo, eventPublisher)
+	measureSvc.SetFingerprintLibrary(referenceRepo, referenceIndex)
+	// 미확인 판정 거리 상한: 요청의 max_distance는 이 값보다 느슨하게 지정할 수 없음
+	if v := os.Getenv("FINGERPRINT_MAX_UNKNOWN_DISTANCE"); v != "" {
+		limit, err := strconv.ParseFloat(v, 32)
+		if err != nil || limit <= 0 {
+			log.Fatalf("[%s] FINGERPRINT_MAX_UNKNOWN_DISTANCE가 올바르지 않습니다: %q", serviceName, v)
+		}
+		measureSvc.SetUnknownDistanceLimit(float32(limit))
+	}
+
+	// 디바이스 소유 이력: DEVICE_SERVICE_ADDR 설정 시 세션을 시작 시점의 소유자에게만 귀속
+	if deviceAddr := os.Getenv("DEVICE_SERVICE_ADDR"); deviceAddr != "" {
+		deviceConn, err := grpc.NewClient(deviceAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		if err != nil {
+			log.Printf("[%s] device-service 연결 실패, 소유자 확인 비활성: %v", serviceName, err)
+		} else {
+			defer deviceConn.Close()
+			measureSvc.SetDeviceOwnerClient(clients.NewGRPCDeviceOwnerClient(v1.NewDeviceServiceClient(deviceConn)))
+			log.Printf("[%s] device-service 연결됨: %s", serviceName, deviceAddr)
+		}
+	} else {
+		log.Printf("[%s] DEVICE_SERVICE_ADDR 미설정 — 디바이스 소유자 확인 비활성", serviceName)
+	}
+
+	// 소유권 이전: 이전 소유자의 진행 중 세션 종료
+	if eventBus != nil {
+		eventBus.Subscribe(events.EventDeviceTransferred, kafkaPublisher.NewDeviceTransferredHandler(measureSvc))
+		eventBus.StartConsuming(context.Background())
+	}
 
 	// SearchIndexer: Elasticsearch 또는 인메모리 (no-op)
 	if _, esURLSet := os.LookupEnv("ELASTICSEARCH_URL"); esURLSet && cfg.Elasticsearch.URL != "" {
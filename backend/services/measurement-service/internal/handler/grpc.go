@@ -94,6 +94,7 @@ func (h *MeasurementHandler) GetMeasurementHistory(ctx context.Context, req *v1.
 			PrimaryValue:  s.PrimaryValue,
 			Unit:          s.Unit,
 			MeasuredAt:    timestamppb.New(s.MeasuredAt),
+			Confidence:    s.Confidence,
 		})
 	}
 
@@ -103,6 +104,37 @@ func (h *MeasurementHandler) GetMeasurementHistory(ctx context.Context, req *v1.
 	}, nil
 }
 
+// GetMeasurement는 단일 측정 세션 조회 RPC입니다.
+func (h *MeasurementHandler) GetMeasurement(ctx context.Context, req *v1.GetMeasurementRequest) (*v1.GetMeasurementResponse, error) {
+	if req == nil || req.SessionId == "" {
+		return nil, status.Error(codes.InvalidArgument, "session_id는 필수입니다")
+	}
+
+	session, readings, err := h.svc.GetMeasurement(ctx, req.SessionId)
+	if err != nil {
+		return nil, toGRPC(err)
+	}
+
+	resp := &v1.GetMeasurementResponse{
+		SessionId: session.ID,
+		UserId:    session.UserID,
+		DeviceId:  session.DeviceID,
+		Status:    session.Status,
+	}
+	for _, r := range readings {
+		resp.Readings = append(resp.Readings, &v1.MeasurementSummary{
+			SessionId:     r.SessionID,
+			CartridgeType: r.CartridgeType,
+			PrimaryValue:  r.PrimaryValue,
+			Unit:          r.Unit,
+			MeasuredAt:    timestamppb.New(r.MeasuredAt),
+			Confidence:    r.Confidence,
+			QcFlags:       r.QCFlags,
+		})
+	}
+	return resp, nil
+}
+
 // ExportSingleMeasurement는 단일 측정 세션의 FHIR 내보내기 RPC입니다.
 func (h *MeasurementHandler) ExportSingleMeasurement(ctx context.Context, req *v1.ExportSingleMeasurementRequest) (*v1.ExportFHIRResponse, error) {
 	if req == nil || req.SessionId == "" {
@@ -148,6 +180,127 @@ func (h *MeasurementHandler) ExportToFHIRObservations(ctx context.Context, req *
 	}, nil
 }
 
+// AddFingerprintReference는 참조 핑거프린트 등록 RPC입니다 (관리자).
+func (h *MeasurementHandler) AddFingerprintReference(ctx context.Context, req *v1.AddFingerprintReferenceRequest) (*v1.FingerprintReference, error) {
+	if req == nil || req.Substance == "" {
+		return nil, status.Error(codes.InvalidArgument, "substance는 필수입니다")
+	}
+
+	ref, err := h.svc.AddReference(ctx, &service.FingerprintReference{
+		Substance:         req.Substance,
+		Concentration:     req.Concentration,
+		ConcentrationUnit: req.ConcentrationUnit,
+		Matrix:            req.Matrix,
+		CartridgeType:     req.CartridgeType,
+		Vector:            req.FingerprintVector,
+		SourceSessionID:   req.SourceSessionId,
+		Notes:             req.Notes,
+		CreatedBy:         req.CreatedBy,
+	})
+	if err != nil {
+		return nil, toGRPC(err)
+	}
+	return toFingerprintReferenceProto(ref), nil
+}
+
+// ReviewFingerprintReference는 참조 핑거프린트 승인·폐기 RPC입니다 (관리자).
+func (h *MeasurementHandler) ReviewFingerprintReference(ctx context.Context, req *v1.ReviewFingerprintReferenceRequest) (*v1.FingerprintReference, error) {
+	if req == nil || req.ReferenceId == "" {
+		return nil, status.Error(codes.InvalidArgument, "reference_id는 필수입니다")
+	}
+
+	ref, err := h.svc.ReviewReference(ctx, req.ReferenceId, req.Status, req.ReviewerId, req.Notes)
+	if err != nil {
+		return nil, toGRPC(err)
+	}
+	return toFingerprintReferenceProto(ref), nil
+}
+
+// ListFingerprintReferences는 참조 라이브러리 조회 RPC입니다.
+func (h *MeasurementHandler) ListFingerprintReferences(ctx context.Context, req *v1.ListFingerprintReferencesRequest) (*v1.ListFingerprintReferencesResponse, error) {
+	if req == nil {
+		req = &v1.ListFingerprintReferencesRequest{}
+	}
+
+	refs, total, err := h.svc.ListReferences(ctx, service.ReferenceFilter{
+		Status:        req.Status,
+		Substance:     req.Substance,
+		CartridgeType: req.CartridgeType,
+		Matrix:        req.Matrix,
+		Limit:         int(req.Limit),
+		Offset:        int(req.Offset),
+	})
+	if err != nil {
+		return nil, toGRPC(err)
+	}
+
+	resp := &v1.ListFingerprintReferencesResponse{TotalCount: int32(total)}
+	for _, ref := range refs {
+		resp.References = append(resp.References, toFingerprintReferenceProto(ref))
+	}
+	return resp, nil
+}
+
+// IdentifySubstance는 참조 라이브러리 kNN 물질 식별 RPC입니다.
+func (h *MeasurementHandler) IdentifySubstance(ctx context.Context, req *v1.IdentifySubstanceRequest) (*v1.SubstanceIdentification, error) {
+	if req == nil || (req.SessionId == "" && len(req.FingerprintVector) == 0) {
+		return nil, status.Error(codes.InvalidArgument, "session_id 또는 fingerprint_vector가 필요합니다")
+	}
+
+	result, err := h.svc.IdentifySubstance(ctx, service.IdentifyQuery{
+		SessionID:     req.SessionId,
+		Vector:        req.FingerprintVector,
+		CartridgeType: req.CartridgeType,
+		Matrix:        req.Matrix,
+		K:             int(req.K),
+		MaxDistance:   req.MaxDistance,
+	})
+	if err != nil {
+		return nil, toGRPC(err)
+	}
+
+	resp := &v1.SubstanceIdentification{
+		SessionId:       result.SessionID,
+		CartridgeType:   result.CartridgeType,
+		Matrix:          result.Matrix,
+		Unknown:         result.Unknown,
+		NearestDistance: result.NearestDistance,
+		Threshold:       result.Threshold,
+		K:               int32(result.K),
+	}
+	for _, c := range result.Candidates {
+		resp.Candidates = append(resp.Candidates, &v1.SubstanceCandidate{
+			Substance:              c.Substance,
+			Distance:               c.Distance,
+			Votes:                  int32(c.Votes),
+			Confidence:             c.Confidence,
+			EstimatedConcentration: c.EstimatedConcentration,
+			ConcentrationUnit:      c.ConcentrationUnit,
+			ReferenceIds:           c.ReferenceIDs,
+		})
+	}
+	return resp, nil
+}
+
+func toFingerprintReferenceProto(ref *service.FingerprintReference) *v1.FingerprintReference {
+	return &v1.FingerprintReference{
+		ReferenceId:       ref.ID,
+		Substance:         ref.Substance,
+		Concentration:     ref.Concentration,
+		ConcentrationUnit: ref.ConcentrationUnit,
+		Matrix:            ref.Matrix,
+		CartridgeType:     ref.CartridgeType,
+		VectorDimension:   int32(len(ref.Vector)),
+		SourceSessionId:   ref.SourceSessionID,
+		Status:            ref.Status,
+		Notes:             ref.Notes,
+		CreatedBy:         ref.CreatedBy,
+		ReviewedBy:        ref.ReviewedBy,
+		CreatedAt:         timestamppb.New(ref.CreatedAt),
+		UpdatedAt:         timestamppb.New(ref.UpdatedAt),
+	}
+}
+
 // toGRPC는 AppError를 gRPC status로 변환합니다.
 func toGRPC(err error) error {
 	if err == nil {
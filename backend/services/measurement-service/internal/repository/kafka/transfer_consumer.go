@@ -0,0 +1,27 @@
+package kafka
+
+import (
+	"context"
+
+	"github.com/manpasik/backend/shared/events"
+)
+
+// DeviceTransferProcessor는 디바이스 소유권 이전을 측정 세션에 반영하는 대상입니다.
+type DeviceTransferProcessor interface {
+	ProcessDeviceTransferred(ctx context.Context, deviceID, fromUserID string) (int, error)
+}
+
+// NewDeviceTransferredHandler는 device.transferred 이벤트를 받아
+// 이전 소유자의 진행 중 측정 세션을 종료하는 핸들러를 반환합니다.
+func NewDeviceTransferredHandler(processor DeviceTransferProcessor) events.Handler {
+	return func(ctx context.Context, event events.Event) error {
+		payload := events.PayloadOf(event)
+		deviceID, _ := payload["device_id"].(string)
+		if deviceID == "" {
+			return nil
+		}
+		fromUserID, _ := payload["from_user_id"].(string)
+		_, err := processor.ProcessDeviceTransferred(ctx, deviceID, fromUserID)
+		return err
+	}
+}
@@ -0,0 +1,108 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/manpasik/backend/services/measurement-service/internal/service"
+)
+
+// FingerprintLibraryRepository는 인메모리 참조 핑거프린트 라이브러리입니다.
+type FingerprintLibraryRepository struct {
+	mu   sync.RWMutex
+	refs map[string]*service.FingerprintReference // key: reference ID
+}
+
+// NewFingerprintLibraryRepository는 인메모리 FingerprintLibraryRepository를 생성합니다.
+func NewFingerprintLibraryRepository() *FingerprintLibraryRepository {
+	return &FingerprintLibraryRepository{
+		refs: make(map[string]*service.FingerprintReference),
+	}
+}
+
+func (r *FingerprintLibraryRepository) CreateReference(_ context.Context, ref *service.FingerprintReference) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	cp := *ref
+	r.refs[ref.ID] = &cp
+	return nil
+}
+
+func (r *FingerprintLibraryRepository) GetReference(_ context.Context, id string) (*service.FingerprintReference, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	ref, ok := r.refs[id]
+	if !ok {
+		return nil, nil
+	}
+	cp := *ref
+	return &cp, nil
+}
+
+func (r *FingerprintLibraryRepository) GetReferences(_ context.Context, ids []string) ([]*service.FingerprintReference, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	out := make([]*service.FingerprintReference, 0, len(ids))
+	for _, id := range ids {
+		if ref, ok := r.refs[id]; ok {
+			cp := *ref
+			out = append(out, &cp)
+		}
+	}
+	return out, nil
+}
+
+func (r *FingerprintLibraryRepository) UpdateReference(_ context.Context, ref *service.FingerprintReference) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.refs[ref.ID]; !ok {
+		return nil
+	}
+	cp := *ref
+	r.refs[ref.ID] = &cp
+	return nil
+}
+
+func (r *FingerprintLibraryRepository) DeleteReference(_ context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.refs, id)
+	return nil
+}
+
+func (r *FingerprintLibraryRepository) ListReferences(_ context.Context, filter service.ReferenceFilter) ([]*service.FingerprintReference, int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var filtered []*service.FingerprintReference
+	for _, ref := range r.refs {
+		if filter.Status != "" && ref.Status != filter.Status {
+			continue
+		}
+		if filter.Substance != "" && !strings.EqualFold(ref.Substance, filter.Substance) {
+			continue
+		}
+		if filter.CartridgeType != "" && ref.CartridgeType != filter.CartridgeType {
+			continue
+		}
+		if filter.Matrix != "" && !strings.EqualFold(ref.Matrix, filter.Matrix) {
+			continue
+		}
+		cp := *ref
+		filtered = append(filtered, &cp)
+	}
+	// 최신 등록순
+	sort.Slice(filtered, func(i, j int) bool { return filtered[i].CreatedAt.After(filtered[j].CreatedAt) })
+
+	total := len(filtered)
+	if filter.Offset >= total {
+		return nil, total, nil
+	}
+	end := filter.Offset + filter.Limit
+	if filter.Limit <= 0 || end > total {
+		end = total
+	}
+	return filtered[filter.Offset:end], total, nil
+}
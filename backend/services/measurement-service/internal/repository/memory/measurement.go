@@ -2,6 +2,7 @@ package memory
 
 import (
 	"context"
+	"sort"
 	"sync"
 	"time"
 
@@ -47,6 +48,7 @@ func (r *MeasurementRepository) GetHistory(_ context.Context, userID string, sta
 			PrimaryValue:  d.PrimaryValue,
 			Unit:          d.Unit,
 			MeasuredAt:    d.Time,
+			Confidence:    d.Confidence,
 		})
 	}
 
@@ -60,3 +62,18 @@ func (r *MeasurementRepository) GetHistory(_ context.Context, userID string, sta
 	}
 	return filtered[offset:endIdx], total, nil
 }
+
+// GetBySession은 세션의 측정 데이터를 시간순으로 조회합니다.
+func (r *MeasurementRepository) GetBySession(_ context.Context, sessionID string) ([]*service.MeasurementData, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var result []*service.MeasurementData
+	for _, d := range r.data {
+		if d.SessionID == sessionID {
+			result = append(result, d)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Time.Before(result[j].Time) })
+	return result, nil
+}
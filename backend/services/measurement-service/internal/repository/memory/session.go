@@ -40,6 +40,19 @@ func (r *SessionRepository) GetSession(_ context.Context, sessionID string) (*se
 	return &cp, nil
 }
 
+func (r *SessionRepository) ListActiveByDevice(_ context.Context, deviceID string) ([]*service.MeasurementSession, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var result []*service.MeasurementSession
+	for _, s := range r.sessions {
+		if s.DeviceID == deviceID && s.Status == "active" {
+			cp := *s
+			result = append(result, &cp)
+		}
+	}
+	return result, nil
+}
+
 func (r *SessionRepository) EndSession(_ context.Context, sessionID string, totalMeasurements int, endedAt time.Time) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
@@ -0,0 +1,127 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/manpasik/backend/services/measurement-service/internal/service"
+)
+
+// FingerprintLibraryRepository는 PostgreSQL 기반 참조 핑거프린트 라이브러리 구현입니다.
+// 벡터는 참조 인덱스(Milvus) 재구축을 위해 원본도 함께 보관합니다.
+type FingerprintLibraryRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewFingerprintLibraryRepository는 FingerprintLibraryRepository를 생성합니다.
+func NewFingerprintLibraryRepository(pool *pgxpool.Pool) *FingerprintLibraryRepository {
+	return &FingerprintLibraryRepository{pool: pool}
+}
+
+const referenceColumns = `id, substance, concentration, concentration_unit, matrix, cartridge_type,
+	fingerprint, COALESCE(source_session_id, ''), status, notes, created_by, reviewed_by, created_at, updated_at`
+
+// CreateReference는 참조 핑거프린트를 저장합니다.
+func (r *FingerprintLibraryRepository) CreateReference(ctx context.Context, ref *service.FingerprintReference) error {
+	const q = `INSERT INTO fingerprint_references
+		(id, substance, concentration, concentration_unit, matrix, cartridge_type,
+		 fingerprint, source_session_id, status, notes, created_by, reviewed_by, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, NULLIF($8, ''), $9, $10, $11, $12, $13, $14)`
+	_, err := r.pool.Exec(ctx, q,
+		ref.ID, ref.Substance, ref.Concentration, ref.ConcentrationUnit, ref.Matrix, ref.CartridgeType,
+		ref.Vector, ref.SourceSessionID, ref.Status, ref.Notes, ref.CreatedBy, ref.ReviewedBy, ref.CreatedAt, ref.UpdatedAt,
+	)
+	return err
+}
+
+// GetReference는 ID로 참조 핑거프린트를 조회합니다.
+func (r *FingerprintLibraryRepository) GetReference(ctx context.Context, id string) (*service.FingerprintReference, error) {
+	row := r.pool.QueryRow(ctx, `SELECT `+referenceColumns+` FROM fingerprint_references WHERE id = $1`, id)
+	ref, err := scanReference(row)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	return ref, err
+}
+
+// GetReferences는 ID 목록에 해당하는 참조 핑거프린트를 조회합니다.
+func (r *FingerprintLibraryRepository) GetReferences(ctx context.Context, ids []string) ([]*service.FingerprintReference, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	rows, err := r.pool.Query(ctx, `SELECT `+referenceColumns+` FROM fingerprint_references WHERE id = ANY($1)`, ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var refs []*service.FingerprintReference
+	for rows.Next() {
+		ref, err := scanReference(rows)
+		if err != nil {
+			return nil, err
+		}
+		refs = append(refs, ref)
+	}
+	return refs, rows.Err()
+}
+
+// UpdateReference는 참조의 검토 상태와 메모를 갱신합니다.
+func (r *FingerprintLibraryRepository) UpdateReference(ctx context.Context, ref *service.FingerprintReference) error {
+	const q = `UPDATE fingerprint_references SET status = $1, notes = $2, reviewed_by = $3, updated_at = $4 WHERE id = $5`
+	_, err := r.pool.Exec(ctx, q, ref.Status, ref.Notes, ref.ReviewedBy, ref.UpdatedAt, ref.ID)
+	return err
+}
+
+// DeleteReference는 참조 핑거프린트를 삭제합니다.
+func (r *FingerprintLibraryRepository) DeleteReference(ctx context.Context, id string) error {
+	_, err := r.pool.Exec(ctx, `DELETE FROM fingerprint_references WHERE id = $1`, id)
+	return err
+}
+
+// ListReferences는 조건에 맞는 참조를 최신 등록순으로 조회합니다.
+func (r *FingerprintLibraryRepository) ListReferences(ctx context.Context, filter service.ReferenceFilter) ([]*service.FingerprintReference, int, error) {
+	where := ` WHERE ($1 = '' OR status = $1)
+		AND ($2 = '' OR LOWER(substance) = LOWER($2))
+		AND ($3 = '' OR cartridge_type = $3)
+		AND ($4 = '' OR LOWER(matrix) = LOWER($4))`
+	args := []interface{}{filter.Status, filter.Substance, filter.CartridgeType, filter.Matrix}
+
+	var total int
+	if err := r.pool.QueryRow(ctx, `SELECT COUNT(*) FROM fingerprint_references`+where, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+	if total == 0 || filter.Offset >= total {
+		return nil, total, nil
+	}
+
+	q := `SELECT ` + referenceColumns + ` FROM fingerprint_references` + where + ` ORDER BY created_at DESC LIMIT $5 OFFSET $6`
+	rows, err := r.pool.Query(ctx, q, append(args, filter.Limit, filter.Offset)...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var refs []*service.FingerprintReference
+	for rows.Next() {
+		ref, err := scanReference(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		refs = append(refs, ref)
+	}
+	return refs, total, rows.Err()
+}
+
+func scanReference(row pgx.Row) (*service.FingerprintReference, error) {
+	var ref service.FingerprintReference
+	err := row.Scan(
+		&ref.ID, &ref.Substance, &ref.Concentration, &ref.ConcentrationUnit, &ref.Matrix, &ref.CartridgeType,
+		&ref.Vector, &ref.SourceSessionID, &ref.Status, &ref.Notes, &ref.CreatedBy, &ref.ReviewedBy, &ref.CreatedAt, &ref.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &ref, nil
+}
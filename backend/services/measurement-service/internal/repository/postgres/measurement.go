@@ -24,16 +24,16 @@ func (r *MeasurementRepository) Store(ctx context.Context, data *service.Measure
 		(time, session_id, device_id, user_id, cartridge_type,
 		 raw_channels, s_det, s_ref, alpha, s_corrected,
 		 primary_value, unit, confidence, fingerprint_dim,
-		 temp_c, humidity_pct, battery_pct)
+		 temp_c, humidity_pct, battery_pct, fingerprint)
 		VALUES ($1, $2, $3, $4, $5,
 		        $6, $7, $8, $9, $10,
 		        $11, $12, $13, $14,
-		        $15, $16, $17)`
+		        $15, $16, $17, $18)`
 	_, err := r.pool.Exec(ctx, q,
 		data.Time, data.SessionID, data.DeviceID, data.UserID, data.CartridgeType,
 		data.RawChannels, data.SDet, data.SRef, data.Alpha, data.SCorrected,
 		data.PrimaryValue, data.Unit, data.Confidence, len(data.FingerprintVector),
-		data.TempC, data.HumidityPct, data.BatteryPct,
+		data.TempC, data.HumidityPct, data.BatteryPct, data.FingerprintVector,
 	)
 	return err
 }
@@ -74,7 +74,7 @@ func (r *MeasurementRepository) GetHistory(
 	}
 
 	// 데이터 조회 (time DESC)
-	dataQ := `SELECT md.session_id, md.cartridge_type, md.primary_value, md.unit, md.time
+	dataQ := `SELECT md.session_id, md.cartridge_type, md.primary_value, md.unit, md.time, COALESCE(md.confidence, 0)
 		FROM measurement_data md
 		JOIN measurement_sessions ms ON md.session_id = ms.id
 		WHERE md.user_id = $1 AND ms.status = 'completed'`
@@ -104,7 +104,7 @@ func (r *MeasurementRepository) GetHistory(
 	var results []*service.MeasurementSummary
 	for rows.Next() {
 		var m service.MeasurementSummary
-		if err := rows.Scan(&m.SessionID, &m.CartridgeType, &m.PrimaryValue, &m.Unit, &m.MeasuredAt); err != nil {
+		if err := rows.Scan(&m.SessionID, &m.CartridgeType, &m.PrimaryValue, &m.Unit, &m.MeasuredAt, &m.Confidence); err != nil {
 			return nil, 0, err
 		}
 		results = append(results, &m)
@@ -116,6 +116,37 @@ func (r *MeasurementRepository) GetHistory(
 	return results, total, nil
 }
 
+// GetBySession은 세션의 측정 데이터를 시간순으로 조회합니다.
+func (r *MeasurementRepository) GetBySession(ctx context.Context, sessionID string) ([]*service.MeasurementData, error) {
+	const q = `SELECT time, session_id, device_id, user_id, cartridge_type,
+		raw_channels, s_det, s_ref, alpha, s_corrected,
+		primary_value, unit, COALESCE(confidence, 0),
+		COALESCE(temp_c, 0), COALESCE(humidity_pct, 0), COALESCE(battery_pct, 0), fingerprint
+		FROM measurement_data
+		WHERE session_id = $1
+		ORDER BY time ASC`
+	rows, err := r.pool.Query(ctx, q, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []*service.MeasurementData
+	for rows.Next() {
+		var d service.MeasurementData
+		if err := rows.Scan(
+			&d.Time, &d.SessionID, &d.DeviceID, &d.UserID, &d.CartridgeType,
+			&d.RawChannels, &d.SDet, &d.SRef, &d.Alpha, &d.SCorrected,
+			&d.PrimaryValue, &d.Unit, &d.Confidence,
+			&d.TempC, &d.HumidityPct, &d.BatteryPct, &d.FingerprintVector,
+		); err != nil {
+			return nil, err
+		}
+		results = append(results, &d)
+	}
+	return results, rows.Err()
+}
+
 // itoa는 간단한 정수→문자열 변환입니다 (쿼리 파라미터 인덱스용).
 func itoa(n int) string {
 	if n < 10 {
@@ -49,6 +49,30 @@ func (r *SessionRepository) GetSession(ctx context.Context, sessionID string) (*
 	return &s, nil
 }
 
+// ListActiveByDevice는 디바이스의 진행 중 세션을 조회합니다.
+func (r *SessionRepository) ListActiveByDevice(ctx context.Context, deviceID string) ([]*service.MeasurementSession, error) {
+	const q = `SELECT id, device_id, cartridge_id, user_id, status, total_measurements, started_at, ended_at
+		FROM measurement_sessions WHERE device_id = $1 AND status = 'active' ORDER BY started_at`
+	rows, err := r.pool.Query(ctx, q, deviceID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var sessions []*service.MeasurementSession
+	for rows.Next() {
+		var s service.MeasurementSession
+		if err := rows.Scan(
+			&s.ID, &s.DeviceID, &s.CartridgeID, &s.UserID,
+			&s.Status, &s.TotalMeasurements, &s.StartedAt, &s.EndedAt,
+		); err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, &s)
+	}
+	return sessions, rows.Err()
+}
+
 // EndSession은 측정 세션을 종료합니다.
 func (r *SessionRepository) EndSession(ctx context.Context, sessionID string, totalMeasurements int, endedAt time.Time) error {
 	const q = `UPDATE measurement_sessions SET status = 'completed', total_measurements = $1, ended_at = $2 WHERE id = $3`
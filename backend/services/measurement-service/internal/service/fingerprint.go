@@ -0,0 +1,433 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	apperrors "github.com/manpasik/backend/shared/errors"
+	"go.uber.org/zap"
+)
+
+// 핑거프린트 참조 상태 — 관리자 승인(approved)된 참조만 물질 식별에 사용합니다.
+const (
+	ReferenceStatusPending  = "pending"
+	ReferenceStatusApproved = "approved"
+	ReferenceStatusRetired  = "retired"
+)
+
+const (
+	// FingerprintDimension은 핑거프린트 벡터 차원입니다 (MAX_CHANNELS, Milvus 컬렉션 차원과 같아야 함).
+	FingerprintDimension = 896
+	// DefaultIdentifyK는 물질 식별 시 투표에 참여하는 최근접 참조 수입니다.
+	DefaultIdentifyK = 5
+	// DefaultUnknownDistance는 최근접 참조의 코사인 거리가 이 값을 넘으면 "미확인"으로 판정하는 기본 임계값입니다.
+	DefaultUnknownDistance float32 = 0.15
+
+	maxIdentifyK = 50
+	// referenceOverfetch는 상태·카트리지·매트릭스 필터로 제외될 참조를 감안한 후보 조회 배수입니다.
+	referenceOverfetch = 10
+	// distanceSmoothing은 거리 역수 가중치가 거리 0에서 발산하지 않도록 더하는 값입니다.
+	distanceSmoothing = 0.01
+)
+
+// FingerprintReference는 물질·농도·매트릭스가 라벨링된 참조 핑거프린트입니다.
+type FingerprintReference struct {
+	ID                string
+	Substance         string  // 예: "glucose", "ethanol"
+	Concentration     float64 // 라벨 농도 (0이면 미지정)
+	ConcentrationUnit string
+	Matrix            string // 시료 매트릭스 (예: "blood", "saliva", "buffer")
+	CartridgeType     string
+	Vector            []float32
+	SourceSessionID   string // 측정 세션에서 가져온 경우 원본 세션
+	Status            string // pending, approved, retired
+	Notes             string
+	CreatedBy         string
+	ReviewedBy        string
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+}
+
+// ReferenceFilter는 참조 라이브러리 목록 조회 조건입니다. 빈 값은 조건에서 제외합니다.
+type ReferenceFilter struct {
+	Status        string
+	Substance     string
+	CartridgeType string
+	Matrix        string
+	Limit         int
+	Offset        int
+}
+
+// FingerprintLibraryRepository는 참조 핑거프린트 라이브러리 저장소 인터페이스입니다 (PostgreSQL).
+type FingerprintLibraryRepository interface {
+	CreateReference(ctx context.Context, ref *FingerprintReference) error
+	// GetReference는 참조가 없으면 (nil, nil)을 반환합니다.
+	GetReference(ctx context.Context, id string) (*FingerprintReference, error)
+	// GetReferences는 ID 목록에 해당하는 참조를 반환합니다. 없는 ID는 건너뜁니다.
+	GetReferences(ctx context.Context, ids []string) ([]*FingerprintReference, error)
+	UpdateReference(ctx context.Context, ref *FingerprintReference) error
+	// DeleteReference는 참조를 삭제합니다. 색인에 실패한 등록을 되돌릴 때 사용합니다.
+	DeleteReference(ctx context.Context, id string) error
+	ListReferences(ctx context.Context, filter ReferenceFilter) ([]*FingerprintReference, int, error)
+}
+
+// IdentifyQuery는 물질 식별 요청입니다. SessionID 또는 Vector 중 하나가 필요합니다.
+type IdentifyQuery struct {
+	SessionID     string
+	Vector        []float32
+	CartridgeType string  // 비어 있으면 세션의 카트리지 유형
+	Matrix        string  // 비어 있으면 매트릭스 무관
+	K             int     // 0이면 DefaultIdentifyK
+	MaxDistance   float32 // 0이면 설정된 임계값 상한, 상한보다 크면 상한으로 제한
+}
+
+// SubstanceCandidate는 식별 후보 물질 하나입니다.
+type SubstanceCandidate struct {
+	Substance              string
+	Distance               float32 // 해당 물질 참조 중 최근접 코사인 거리
+	Votes                  int     // k개 이웃 중 해당 물질 참조 수
+	Confidence             float64 // 거리 역수 가중 득표율 (0 ~ 1)
+	EstimatedConcentration float64 // 같은 단위 참조 농도의 거리 가중 평균 (라벨 농도가 없으면 0)
+	ConcentrationUnit      string
+	ReferenceIDs           []string
+}
+
+// SubstanceIdentification은 kNN 물질 식별 결과입니다.
+type SubstanceIdentification struct {
+	SessionID       string
+	CartridgeType   string
+	Matrix          string
+	Candidates      []SubstanceCandidate // 신뢰도 내림차순
+	Unknown         bool                 // 최근접 참조가 임계값보다 멀거나 비교할 참조가 없음
+	NearestDistance float32
+	Threshold       float32
+	K               int
+}
+
+// SetFingerprintLibrary는 참조 라이브러리 저장소와 참조 벡터 인덱스를 설정합니다 (optional).
+// 참조 벡터는 측정 핑거프린트와 섞이지 않도록 별도 인덱스(Milvus 컬렉션)에 저장합니다.
+func (s *MeasurementService) SetFingerprintLibrary(repo FingerprintLibraryRepository, index VectorRepository) {
+	s.referenceRepo = repo
+	s.referenceIndex = index
+}
+
+// SetUnknownDistanceLimit는 미확인 판정 거리 임계값의 상한을 설정합니다 (optional, 기본 DefaultUnknownDistance).
+// 요청의 max_distance는 이 값보다 엄격하게만 지정할 수 있습니다.
+func (s *MeasurementService) SetUnknownDistanceLimit(limit float32) {
+	s.unknownLimit = limit
+}
+
+func (s *MeasurementService) unknownDistanceLimit() float32 {
+	if s.unknownLimit > 0 {
+		return s.unknownLimit
+	}
+	return DefaultUnknownDistance
+}
+
+// checkFingerprintDimension은 벡터가 인덱스 차원과 같은지 확인합니다.
+func checkFingerprintDimension(vector []float32) error {
+	if len(vector) != FingerprintDimension {
+		return apperrors.New(apperrors.ErrInvalidInput, fmt.Sprintf("fingerprint_vector는 %d차원이어야 합니다 (got %d)", FingerprintDimension, len(vector)))
+	}
+	return nil
+}
+
+func (s *MeasurementService) fingerprintLibraryEnabled() error {
+	if s.referenceRepo == nil || s.referenceIndex == nil {
+		return apperrors.New(apperrors.ErrServiceUnavailable, "핑거프린트 참조 라이브러리가 설정되지 않았습니다")
+	}
+	return nil
+}
+
+// AddReference는 라벨링된 참조 핑거프린트를 검토 대기(pending) 상태로 등록합니다.
+// Vector가 비어 있으면 SourceSessionID 세션의 마지막 핑거프린트를 사용합니다.
+func (s *MeasurementService) AddReference(ctx context.Context, ref *FingerprintReference) (*FingerprintReference, error) {
+	if err := s.fingerprintLibraryEnabled(); err != nil {
+		return nil, err
+	}
+	ref.Substance = strings.TrimSpace(ref.Substance)
+	ref.Matrix = strings.TrimSpace(ref.Matrix)
+	if ref.Substance == "" {
+		return nil, apperrors.New(apperrors.ErrInvalidInput, "substance는 필수입니다")
+	}
+	if ref.Concentration < 0 {
+		return nil, apperrors.New(apperrors.ErrInvalidInput, "concentration은 0 이상이어야 합니다")
+	}
+	if len(ref.Vector) == 0 {
+		if ref.SourceSessionID == "" {
+			return nil, apperrors.New(apperrors.ErrInvalidInput, "fingerprint_vector 또는 source_session_id가 필요합니다")
+		}
+		vector, cartridgeType, err := s.sessionFingerprint(ctx, ref.SourceSessionID)
+		if err != nil {
+			return nil, err
+		}
+		ref.Vector = vector
+		if ref.CartridgeType == "" {
+			ref.CartridgeType = cartridgeType
+		}
+	}
+	if ref.CartridgeType == "" {
+		return nil, apperrors.New(apperrors.ErrInvalidInput, "cartridge_type은 필수입니다")
+	}
+	if err := checkFingerprintDimension(ref.Vector); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	ref.ID = uuid.New().String()
+	ref.Status = ReferenceStatusPending
+	ref.ReviewedBy = ""
+	ref.CreatedAt = now
+	ref.UpdatedAt = now
+	if err := s.referenceRepo.CreateReference(ctx, ref); err != nil {
+		s.logger.Error("참조 핑거프린트 저장 실패", zap.String("substance", ref.Substance), zap.Error(err))
+		return nil, apperrors.New(apperrors.ErrInternal, "참조 핑거프린트 등록에 실패했습니다")
+	}
+	// 인덱스에는 등록 즉시 넣고, 식별 시 승인 상태로 거릅니다 (검토 결과에 따라 재색인하지 않음).
+	// 색인에 실패하면 색인 없는 참조가 남지 않도록 저장한 행을 삭제합니다.
+	if err := s.referenceIndex.StoreFingerprint(ctx, ref.ID, ref.Vector); err != nil {
+		s.logger.Error("참조 벡터 색인 실패", zap.String("reference_id", ref.ID), zap.Error(err))
+		if delErr := s.referenceRepo.DeleteReference(ctx, ref.ID); delErr != nil {
+			s.logger.Error("색인 실패 참조 삭제 실패", zap.String("reference_id", ref.ID), zap.Error(delErr))
+		}
+		return nil, apperrors.New(apperrors.ErrInternal, "참조 벡터 색인에 실패했습니다")
+	}
+
+	s.logger.Info("참조 핑거프린트 등록",
+		zap.String("reference_id", ref.ID),
+		zap.String("substance", ref.Substance),
+		zap.String("cartridge_type", ref.CartridgeType),
+	)
+	return ref, nil
+}
+
+// ReviewReference는 관리자가 참조를 승인(approved)하거나 폐기(retired)합니다.
+// 검토 대기로 되돌릴 수는 없으며, 폐기한 참조는 다시 승인할 수 있습니다.
+func (s *MeasurementService) ReviewReference(ctx context.Context, id, status, reviewerID, notes string) (*FingerprintReference, error) {
+	if err := s.fingerprintLibraryEnabled(); err != nil {
+		return nil, err
+	}
+	if id == "" || reviewerID == "" {
+		return nil, apperrors.New(apperrors.ErrInvalidInput, "reference_id, reviewer_id는 필수입니다")
+	}
+	if status != ReferenceStatusApproved && status != ReferenceStatusRetired {
+		return nil, apperrors.New(apperrors.ErrInvalidInput, "status는 approved 또는 retired여야 합니다")
+	}
+
+	ref, err := s.referenceRepo.GetReference(ctx, id)
+	if err != nil {
+		return nil, apperrors.New(apperrors.ErrInternal, "참조 핑거프린트 조회에 실패했습니다")
+	}
+	if ref == nil {
+		return nil, apperrors.New(apperrors.ErrNotFound, "참조 핑거프린트를 찾을 수 없습니다")
+	}
+
+	ref.Status = status
+	ref.ReviewedBy = reviewerID
+	if notes != "" {
+		ref.Notes = notes
+	}
+	ref.UpdatedAt = time.Now().UTC()
+	if err := s.referenceRepo.UpdateReference(ctx, ref); err != nil {
+		return nil, apperrors.New(apperrors.ErrInternal, "참조 핑거프린트 검토 저장에 실패했습니다")
+	}
+
+	s.logger.Info("참조 핑거프린트 검토",
+		zap.String("reference_id", id),
+		zap.String("status", status),
+		zap.String("reviewer_id", reviewerID),
+	)
+	return ref, nil
+}
+
+// ListReferences는 참조 라이브러리를 조회합니다.
+func (s *MeasurementService) ListReferences(ctx context.Context, filter ReferenceFilter) ([]*FingerprintReference, int, error) {
+	if err := s.fingerprintLibraryEnabled(); err != nil {
+		return nil, 0, err
+	}
+	switch filter.Status {
+	case "", ReferenceStatusPending, ReferenceStatusApproved, ReferenceStatusRetired:
+	default:
+		return nil, 0, apperrors.New(apperrors.ErrInvalidInput, "알 수 없는 참조 상태입니다")
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = 20
+	}
+	if filter.Limit > 100 {
+		filter.Limit = 100
+	}
+	if filter.Offset < 0 {
+		filter.Offset = 0
+	}
+	refs, total, err := s.referenceRepo.ListReferences(ctx, filter)
+	if err != nil {
+		return nil, 0, apperrors.New(apperrors.ErrInternal, "참조 라이브러리 조회에 실패했습니다")
+	}
+	return refs, total, nil
+}
+
+// IdentifySubstance는 핑거프린트를 승인된 참조 라이브러리와 비교해 kNN으로 물질을 식별합니다.
+//
+// 같은 카트리지 유형(및 지정 시 매트릭스)의 최근접 참조 k개가 거리 역수 가중치로 투표하고,
+// 최근접 참조의 거리가 임계값을 넘으면 후보는 그대로 돌려주되 Unknown으로 표시합니다.
+func (s *MeasurementService) IdentifySubstance(ctx context.Context, q IdentifyQuery) (*SubstanceIdentification, error) {
+	if err := s.fingerprintLibraryEnabled(); err != nil {
+		return nil, err
+	}
+	if q.SessionID == "" && len(q.Vector) == 0 {
+		return nil, apperrors.New(apperrors.ErrInvalidInput, "session_id 또는 fingerprint_vector가 필요합니다")
+	}
+	if q.K <= 0 {
+		q.K = DefaultIdentifyK
+	}
+	if q.K > maxIdentifyK {
+		q.K = maxIdentifyK
+	}
+	if limit := s.unknownDistanceLimit(); q.MaxDistance <= 0 || q.MaxDistance > limit {
+		q.MaxDistance = limit
+	}
+
+	vector := q.Vector
+	cartridgeType := q.CartridgeType
+	if len(vector) == 0 {
+		v, ct, err := s.sessionFingerprint(ctx, q.SessionID)
+		if err != nil {
+			return nil, err
+		}
+		vector = v
+		if cartridgeType == "" {
+			cartridgeType = ct
+		}
+	}
+	if err := checkFingerprintDimension(vector); err != nil {
+		return nil, err
+	}
+
+	hits, err := s.referenceIndex.SearchSimilar(ctx, vector, q.K*referenceOverfetch)
+	if err != nil {
+		s.logger.Error("참조 벡터 검색 실패", zap.String("session_id", q.SessionID), zap.Error(err))
+		return nil, apperrors.New(apperrors.ErrInternal, "참조 벡터 검색에 실패했습니다")
+	}
+	ids := make([]string, 0, len(hits))
+	for _, h := range hits {
+		ids = append(ids, h.SessionID)
+	}
+	refs, err := s.referenceRepo.GetReferences(ctx, ids)
+	if err != nil {
+		return nil, apperrors.New(apperrors.ErrInternal, "참조 핑거프린트 조회에 실패했습니다")
+	}
+	byID := make(map[string]*FingerprintReference, len(refs))
+	for _, r := range refs {
+		byID[r.ID] = r
+	}
+
+	var neighbours []neighbour
+	for _, h := range hits {
+		ref, ok := byID[h.SessionID]
+		if !ok || ref.Status != ReferenceStatusApproved {
+			continue
+		}
+		if cartridgeType != "" && ref.CartridgeType != cartridgeType {
+			continue
+		}
+		if q.Matrix != "" && !strings.EqualFold(ref.Matrix, q.Matrix) {
+			continue
+		}
+		neighbours = append(neighbours, neighbour{ref: ref, distance: h.Distance})
+	}
+	sort.SliceStable(neighbours, func(i, j int) bool { return neighbours[i].distance < neighbours[j].distance })
+	if len(neighbours) > q.K {
+		neighbours = neighbours[:q.K]
+	}
+
+	result := &SubstanceIdentification{
+		SessionID:     q.SessionID,
+		CartridgeType: cartridgeType,
+		Matrix:        q.Matrix,
+		Threshold:     q.MaxDistance,
+		K:             q.K,
+		Unknown:       true,
+	}
+	if len(neighbours) == 0 {
+		return result, nil
+	}
+	result.NearestDistance = neighbours[0].distance
+	result.Unknown = neighbours[0].distance > q.MaxDistance
+	result.Candidates = voteSubstances(neighbours)
+	return result, nil
+}
+
+// neighbour는 필터를 통과한 최근접 참조와 거리입니다.
+type neighbour struct {
+	ref      *FingerprintReference
+	distance float32
+}
+
+// voteSubstances는 거리순으로 정렬된 이웃을 물질별로 묶어 가중 득표율과 농도 추정치를 계산합니다.
+func voteSubstances(neighbours []neighbour) []SubstanceCandidate {
+	type tally struct {
+		candidate  SubstanceCandidate
+		weight     float64
+		concWeight float64
+		concSum    float64
+	}
+	var order []string
+	tallies := make(map[string]*tally)
+	var totalWeight float64
+	for _, n := range neighbours {
+		w := 1 / (math.Max(float64(n.distance), 0) + distanceSmoothing)
+		totalWeight += w
+		t, ok := tallies[n.ref.Substance]
+		if !ok {
+			// 최근접 참조의 단위를 농도 추정 기준 단위로 사용
+			t = &tally{candidate: SubstanceCandidate{
+				Substance:         n.ref.Substance,
+				Distance:          n.distance,
+				ConcentrationUnit: n.ref.ConcentrationUnit,
+			}}
+			tallies[n.ref.Substance] = t
+			order = append(order, n.ref.Substance)
+		}
+		t.candidate.Votes++
+		t.candidate.ReferenceIDs = append(t.candidate.ReferenceIDs, n.ref.ID)
+		t.weight += w
+		if n.ref.Concentration > 0 && n.ref.ConcentrationUnit == t.candidate.ConcentrationUnit {
+			t.concSum += w * n.ref.Concentration
+			t.concWeight += w
+		}
+	}
+
+	candidates := make([]SubstanceCandidate, 0, len(order))
+	for _, name := range order {
+		t := tallies[name]
+		t.candidate.Confidence = t.weight / totalWeight
+		if t.concWeight > 0 {
+			t.candidate.EstimatedConcentration = t.concSum / t.concWeight
+		}
+		candidates = append(candidates, t.candidate)
+	}
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].Confidence > candidates[j].Confidence })
+	return candidates
+}
+
+// sessionFingerprint는 세션의 마지막 핑거프린트 벡터와 카트리지 유형을 반환합니다.
+func (s *MeasurementService) sessionFingerprint(ctx context.Context, sessionID string) ([]float32, string, error) {
+	data, err := s.measureRepo.GetBySession(ctx, sessionID)
+	if err != nil {
+		return nil, "", apperrors.New(apperrors.ErrInternal, "측정 데이터 조회에 실패했습니다")
+	}
+	for i := len(data) - 1; i >= 0; i-- {
+		if len(data[i].FingerprintVector) > 0 {
+			return data[i].FingerprintVector, data[i].CartridgeType, nil
+		}
+	}
+	if len(data) == 0 {
+		return nil, "", apperrors.New(apperrors.ErrNotFound, "측정 세션을 찾을 수 없습니다")
+	}
+	return nil, "", apperrors.New(apperrors.ErrInvalidInput, "핑거프린트 벡터가 없는 측정입니다")
+}
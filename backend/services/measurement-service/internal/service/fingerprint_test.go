@@ -0,0 +1,305 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sort"
+	"testing"
+	"time"
+
+	apperrors "github.com/manpasik/backend/shared/errors"
+)
+
+// =============================================================================
+// 참조 라이브러리 목(Mock)
+// =============================================================================
+
+type mockLibraryRepo struct {
+	refs map[string]*FingerprintReference
+}
+
+func newMockLibraryRepo() *mockLibraryRepo {
+	return &mockLibraryRepo{refs: make(map[string]*FingerprintReference)}
+}
+
+func (r *mockLibraryRepo) CreateReference(_ context.Context, ref *FingerprintReference) error {
+	cp := *ref
+	r.refs[ref.ID] = &cp
+	return nil
+}
+
+func (r *mockLibraryRepo) GetReference(_ context.Context, id string) (*FingerprintReference, error) {
+	ref, ok := r.refs[id]
+	if !ok {
+		return nil, nil
+	}
+	cp := *ref
+	return &cp, nil
+}
+
+func (r *mockLibraryRepo) GetReferences(_ context.Context, ids []string) ([]*FingerprintReference, error) {
+	var out []*FingerprintReference
+	for _, id := range ids {
+		if ref, ok := r.refs[id]; ok {
+			out = append(out, ref)
+		}
+	}
+	return out, nil
+}
+
+func (r *mockLibraryRepo) UpdateReference(_ context.Context, ref *FingerprintReference) error {
+	cp := *ref
+	r.refs[ref.ID] = &cp
+	return nil
+}
+
+func (r *mockLibraryRepo) DeleteReference(_ context.Context, id string) error {
+	delete(r.refs, id)
+	return nil
+}
+
+func (r *mockLibraryRepo) ListReferences(_ context.Context, filter ReferenceFilter) ([]*FingerprintReference, int, error) {
+	var out []*FingerprintReference
+	for _, ref := range r.refs {
+		if filter.Status == "" || ref.Status == filter.Status {
+			out = append(out, ref)
+		}
+	}
+	return out, len(out), nil
+}
+
+// cosineIndex는 코사인 거리로 검색하는 인메모리 참조 인덱스입니다.
+type cosineIndex struct {
+	vectors  map[string][]float32
+	storeErr error
+}
+
+func (x *cosineIndex) StoreFingerprint(_ context.Context, id string, vector []float32) error {
+	if x.storeErr != nil {
+		return x.storeErr
+	}
+	x.vectors[id] = vector
+	return nil
+}
+
+func (x *cosineIndex) SearchSimilar(_ context.Context, vector []float32, topK int) ([]SimilarResult, error) {
+	var out []SimilarResult
+	for id, v := range x.vectors {
+		var dot, na, nb float64
+		for i := range v {
+			dot += float64(v[i]) * float64(vector[i])
+			na += float64(v[i]) * float64(v[i])
+			nb += float64(vector[i]) * float64(vector[i])
+		}
+		score := float32(dot / (math.Sqrt(na) * math.Sqrt(nb)))
+		out = append(out, SimilarResult{SessionID: id, Score: score, Distance: 1 - score})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Distance < out[j].Distance })
+	if len(out) > topK {
+		out = out[:topK]
+	}
+	return out, nil
+}
+
+// fp는 앞 성분만 지정하고 나머지를 0으로 채운 FingerprintDimension 차원 벡터입니다.
+func fp(values ...float32) []float32 {
+	v := make([]float32, FingerprintDimension)
+	copy(v, values)
+	return v
+}
+
+func newTestLibraryService() (*MeasurementService, *mockLibraryRepo, *mockMeasureRepo) {
+	svc, _, measureRepo, _, _ := newTestMeasurementService()
+	lib := newMockLibraryRepo()
+	svc.SetFingerprintLibrary(lib, &cosineIndex{vectors: make(map[string][]float32)})
+	return svc, lib, measureRepo
+}
+
+// addApproved는 참조를 등록하고 승인합니다.
+func addApproved(t *testing.T, svc *MeasurementService, substance string, conc float64, cartridge string, vec []float32) *FingerprintReference {
+	t.Helper()
+	ref, err := svc.AddReference(context.Background(), &FingerprintReference{
+		Substance: substance, Concentration: conc, ConcentrationUnit: "mg/dL",
+		Matrix: "blood", CartridgeType: cartridge, Vector: vec, CreatedBy: "admin-1",
+	})
+	if err != nil {
+		t.Fatalf("참조 등록 실패: %v", err)
+	}
+	if _, err := svc.ReviewReference(context.Background(), ref.ID, ReferenceStatusApproved, "admin-2", ""); err != nil {
+		t.Fatalf("참조 승인 실패: %v", err)
+	}
+	return ref
+}
+
+// =============================================================================
+// 참조 라이브러리 테스트
+// =============================================================================
+
+func TestAddReference_세션_핑거프린트_사용(t *testing.T) {
+	svc, lib, measureRepo := newTestLibraryService()
+	ctx := context.Background()
+	measureRepo.Store(ctx, &MeasurementData{SessionID: "sess-1", CartridgeType: "glucose", FingerprintVector: fp(0.1, 0.2, 0.3), Time: time.Now()})
+	measureRepo.Store(ctx, &MeasurementData{SessionID: "sess-1", CartridgeType: "glucose", FingerprintVector: fp(0.4, 0.5, 0.6), Time: time.Now()})
+
+	ref, err := svc.AddReference(ctx, &FingerprintReference{Substance: " glucose ", Concentration: 100, SourceSessionID: "sess-1"})
+	if err != nil {
+		t.Fatalf("참조 등록 실패: %v", err)
+	}
+	if ref.Status != ReferenceStatusPending || ref.CartridgeType != "glucose" || ref.Substance != "glucose" {
+		t.Errorf("등록 결과 불일치: %+v", ref)
+	}
+	if len(ref.Vector) != FingerprintDimension || ref.Vector[0] != 0.4 {
+		t.Errorf("세션의 마지막 핑거프린트를 사용해야 합니다: %v", ref.Vector)
+	}
+	if len(lib.refs) != 1 {
+		t.Errorf("참조가 1개 저장되어야 합니다: got %d", len(lib.refs))
+	}
+
+	var appErr *apperrors.AppError
+	_, err = svc.AddReference(ctx, &FingerprintReference{Substance: "glucose"})
+	if !errors.As(err, &appErr) || appErr.Code != apperrors.ErrInvalidInput {
+		t.Errorf("벡터·세션 없이 등록하면 ErrInvalidInput이어야 합니다: %v", err)
+	}
+}
+
+func TestAddReference_차원_검증과_색인_실패_정리(t *testing.T) {
+	svc, lib, _ := newTestLibraryService()
+	ctx := context.Background()
+
+	var appErr *apperrors.AppError
+	_, err := svc.AddReference(ctx, &FingerprintReference{Substance: "glucose", CartridgeType: "glucose", Vector: []float32{1, 0, 0}})
+	if !errors.As(err, &appErr) || appErr.Code != apperrors.ErrInvalidInput {
+		t.Errorf("차원이 다른 벡터는 ErrInvalidInput이어야 합니다: %v", err)
+	}
+
+	index := &cosineIndex{vectors: make(map[string][]float32), storeErr: errors.New("milvus unavailable")}
+	svc.SetFingerprintLibrary(lib, index)
+	if _, err := svc.AddReference(ctx, &FingerprintReference{Substance: "glucose", CartridgeType: "glucose", Vector: fp(1)}); err == nil {
+		t.Fatal("색인 실패 시 오류를 반환해야 합니다")
+	}
+	if len(lib.refs) != 0 {
+		t.Errorf("색인에 실패한 참조는 삭제되어야 합니다: %d개 남음", len(lib.refs))
+	}
+}
+
+func TestReviewReference_상태_검증(t *testing.T) {
+	svc, _, _ := newTestLibraryService()
+	ctx := context.Background()
+	ref, _ := svc.AddReference(ctx, &FingerprintReference{Substance: "ethanol", CartridgeType: "voc", Vector: fp(1, 0, 0)})
+
+	var appErr *apperrors.AppError
+	if _, err := svc.ReviewReference(ctx, ref.ID, ReferenceStatusPending, "admin-1", ""); !errors.As(err, &appErr) || appErr.Code != apperrors.ErrInvalidInput {
+		t.Errorf("pending으로 되돌릴 수 없어야 합니다: %v", err)
+	}
+	if _, err := svc.ReviewReference(ctx, "missing", ReferenceStatusApproved, "admin-1", ""); !errors.As(err, &appErr) || appErr.Code != apperrors.ErrNotFound {
+		t.Errorf("없는 참조는 ErrNotFound여야 합니다: %v", err)
+	}
+	retired, err := svc.ReviewReference(ctx, ref.ID, ReferenceStatusRetired, "admin-1", "오염 시료")
+	if err != nil || retired.Status != ReferenceStatusRetired || retired.ReviewedBy != "admin-1" || retired.Notes != "오염 시료" {
+		t.Errorf("폐기 결과 불일치: %+v %v", retired, err)
+	}
+}
+
+// =============================================================================
+// IdentifySubstance 테스트
+// =============================================================================
+
+func TestIdentifySubstance_kNN_투표와_농도_추정(t *testing.T) {
+	svc, _, _ := newTestLibraryService()
+	ctx := context.Background()
+	addApproved(t, svc, "glucose", 100, "glucose", fp(1, 0.05, 0))
+	addApproved(t, svc, "glucose", 200, "glucose", fp(1, 0.1, 0))
+	addApproved(t, svc, "ethanol", 50, "glucose", fp(0.2, 1, 0))
+	// 다른 카트리지와 미승인·폐기 참조는 투표에서 제외
+	addApproved(t, svc, "lactate", 10, "lactate", fp(1, 0.06, 0))
+	svc.AddReference(ctx, &FingerprintReference{Substance: "fructose", CartridgeType: "glucose", Vector: fp(1, 0.07, 0)})
+	retired := addApproved(t, svc, "sucrose", 10, "glucose", fp(1, 0.07, 0))
+	svc.ReviewReference(ctx, retired.ID, ReferenceStatusRetired, "admin-2", "")
+
+	result, err := svc.IdentifySubstance(ctx, IdentifyQuery{Vector: fp(1, 0.06, 0), CartridgeType: "glucose", K: 3})
+	if err != nil {
+		t.Fatalf("식별 실패: %v", err)
+	}
+	if result.Unknown {
+		t.Fatalf("가까운 참조가 있으면 미확인이 아니어야 합니다: %+v", result)
+	}
+	if len(result.Candidates) != 2 || result.Candidates[0].Substance != "glucose" {
+		t.Fatalf("glucose가 1순위여야 합니다: %+v", result.Candidates)
+	}
+	top := result.Candidates[0]
+	if top.Votes != 2 || top.Confidence < 0.9 {
+		t.Errorf("glucose 득표·신뢰도 불일치: %+v", top)
+	}
+	if top.EstimatedConcentration <= 100 || top.EstimatedConcentration >= 200 || top.ConcentrationUnit != "mg/dL" {
+		t.Errorf("농도 추정은 두 참조 사이여야 합니다: %+v", top)
+	}
+	if result.Candidates[1].Substance != "ethanol" || result.NearestDistance != top.Distance {
+		t.Errorf("후보 순서·최근접 거리 불일치: %+v", result)
+	}
+}
+
+func TestIdentifySubstance_미확인_판정(t *testing.T) {
+	svc, _, measureRepo := newTestLibraryService()
+	ctx := context.Background()
+	addApproved(t, svc, "glucose", 100, "glucose", fp(1, 0, 0))
+	measureRepo.Store(ctx, &MeasurementData{SessionID: "sess-x", CartridgeType: "glucose", FingerprintVector: fp(0.3, 0, 1), Time: time.Now()})
+
+	result, err := svc.IdentifySubstance(ctx, IdentifyQuery{SessionID: "sess-x"})
+	if err != nil {
+		t.Fatalf("식별 실패: %v", err)
+	}
+	if !result.Unknown || result.Threshold != DefaultUnknownDistance || result.K != DefaultIdentifyK {
+		t.Errorf("임계값보다 먼 참조만 있으면 미확인이어야 합니다: %+v", result)
+	}
+	if len(result.Candidates) != 1 || result.CartridgeType != "glucose" {
+		t.Errorf("미확인이어도 후보는 반환해야 합니다: %+v", result)
+	}
+
+	// 매트릭스가 맞는 참조가 없으면 후보 없이 미확인
+	empty, _ := svc.IdentifySubstance(ctx, IdentifyQuery{Vector: fp(1, 0, 0), CartridgeType: "glucose", Matrix: "saliva"})
+	if !empty.Unknown || len(empty.Candidates) != 0 {
+		t.Errorf("비교할 참조가 없으면 후보 없이 미확인이어야 합니다: %+v", empty)
+	}
+}
+
+func TestIdentifySubstance_쿼리_검증과_임계값_상한(t *testing.T) {
+	svc, _, _ := newTestLibraryService()
+	ctx := context.Background()
+	addApproved(t, svc, "glucose", 100, "glucose", fp(1, 0, 0))
+
+	var appErr *apperrors.AppError
+	_, err := svc.IdentifySubstance(ctx, IdentifyQuery{Vector: []float32{1, 0, 0}, CartridgeType: "glucose"})
+	if !errors.As(err, &appErr) || appErr.Code != apperrors.ErrInvalidInput {
+		t.Errorf("차원이 다른 쿼리는 ErrInvalidInput이어야 합니다: %v", err)
+	}
+
+	// 거리 0.3인 쿼리: 요청 임계값 0.9는 상한으로 제한되어 미확인
+	far := fp(0.3, 0, 1)
+	result, err := svc.IdentifySubstance(ctx, IdentifyQuery{Vector: far, CartridgeType: "glucose", MaxDistance: 0.9})
+	if err != nil {
+		t.Fatalf("식별 실패: %v", err)
+	}
+	if !result.Unknown || result.Threshold != DefaultUnknownDistance {
+		t.Errorf("요청 임계값은 상한을 넘을 수 없습니다: %+v", result)
+	}
+
+	svc.SetUnknownDistanceLimit(0.9)
+	result, _ = svc.IdentifySubstance(ctx, IdentifyQuery{Vector: far, CartridgeType: "glucose", MaxDistance: 0.9})
+	if result.Unknown || result.Threshold != 0.9 {
+		t.Errorf("설정한 상한 이내의 임계값은 그대로 사용해야 합니다: %+v", result)
+	}
+	strict, _ := svc.IdentifySubstance(ctx, IdentifyQuery{Vector: fp(1, 0.05, 0), CartridgeType: "glucose", MaxDistance: 0.0001})
+	if !strict.Unknown || strict.Threshold != 0.0001 {
+		t.Errorf("더 엄격한 임계값은 허용해야 합니다: %+v", strict)
+	}
+}
+
+func TestIdentifySubstance_라이브러리_미설정(t *testing.T) {
+	svc, _, _, _, _ := newTestMeasurementService()
+	var appErr *apperrors.AppError
+	_, err := svc.IdentifySubstance(context.Background(), IdentifyQuery{Vector: fp(1)})
+	if !errors.As(err, &appErr) || appErr.Code != apperrors.ErrServiceUnavailable {
+		t.Errorf("라이브러리 미설정 시 ErrServiceUnavailable이어야 합니다: %v", err)
+	}
+}
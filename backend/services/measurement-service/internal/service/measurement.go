@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/manpasik/backend/shared/clients"
 	apperrors "github.com/manpasik/backend/shared/errors"
 	"go.uber.org/zap"
 )
@@ -21,7 +22,11 @@ type MeasurementService struct {
 	measureRepo    MeasurementRepository
 	vectorRepo     VectorRepository
 	eventPublisher EventPublisher
-	searchIndexer  SearchIndexer // optional: nil이면 인덱싱 비활성화
+	searchIndexer  SearchIndexer                // optional: nil이면 인덱싱 비활성화
+	referenceRepo  FingerprintLibraryRepository // optional: nil이면 물질 식별 비활성화
+	referenceIndex VectorRepository             // 참조 핑거프린트 전용 벡터 인덱스
+	unknownLimit   float32                      // 미확인 판정 거리 임계값 상한 (0이면 DefaultUnknownDistance)
+	deviceOwners   clients.DeviceOwnerClient    // optional: nil이면 디바이스 소유자 확인 생략
 }
 
 // SessionRepository는 측정 세션 저장소 인터페이스입니다 (PostgreSQL).
@@ -29,12 +34,16 @@ type SessionRepository interface {
 	CreateSession(ctx context.Context, session *MeasurementSession) error
 	GetSession(ctx context.Context, sessionID string) (*MeasurementSession, error)
 	EndSession(ctx context.Context, sessionID string, totalMeasurements int, endedAt time.Time) error
+	// ListActiveByDevice는 디바이스의 진행 중(active) 세션을 조회합니다.
+	ListActiveByDevice(ctx context.Context, deviceID string) ([]*MeasurementSession, error)
 }
 
 // MeasurementRepository는 측정 데이터 저장소 인터페이스입니다 (TimescaleDB).
 type MeasurementRepository interface {
 	Store(ctx context.Context, data *MeasurementData) error
 	GetHistory(ctx context.Context, userID string, start, end time.Time, limit, offset int) ([]*MeasurementSummary, int, error)
+	// GetBySession은 세션의 측정 데이터를 시간순으로 조회합니다.
+	GetBySession(ctx context.Context, sessionID string) ([]*MeasurementData, error)
 }
 
 // VectorRepository는 핑거프린트 벡터 저장소 인터페이스입니다 (Milvus).
@@ -93,6 +102,8 @@ type MeasurementSummary struct {
 	PrimaryValue  float64
 	Unit          string
 	MeasuredAt    time.Time
+	Confidence    float64
+	QCFlags       []string
 }
 
 // SimilarResult는 유사 벡터 검색 결과입니다.
@@ -134,6 +145,12 @@ func (s *MeasurementService) SetSearchIndexer(indexer SearchIndexer) {
 	s.searchIndexer = indexer
 }
 
+// SetDeviceOwnerClient는 디바이스 소유 이력 조회 클라이언트를 설정합니다 (optional).
+// 설정하면 측정 세션은 시작 시점의 디바이스 소유자에게만 귀속됩니다.
+func (s *MeasurementService) SetDeviceOwnerClient(c clients.DeviceOwnerClient) {
+	s.deviceOwners = c
+}
+
 // StartSession은 새 측정 세션을 시작합니다.
 func (s *MeasurementService) StartSession(
 	ctx context.Context,
@@ -144,12 +161,17 @@ func (s *MeasurementService) StartSession(
 		return nil, apperrors.New(apperrors.ErrInvalidInput, "device_id, cartridge_id, user_id는 필수입니다")
 	}
 
+	startedAt := time.Now().UTC()
+	if err := s.checkDeviceOwner(ctx, deviceID, userID, startedAt); err != nil {
+		return nil, err
+	}
+
 	session := &MeasurementSession{
 		ID:          uuid.New().String(),
 		DeviceID:    deviceID,
 		CartridgeID: cartridgeID,
 		UserID:      userID,
-		StartedAt:   time.Now().UTC(),
+		StartedAt:   startedAt,
 		Status:      "active",
 	}
 
@@ -253,6 +275,55 @@ func (s *MeasurementService) EndSession(
 	}, nil
 }
 
+// ProcessDeviceTransferred는 소유권 이전(device.transferred) 시 이전 소유자의 진행 중 세션을 종료합니다.
+// 이전 시각 이후의 측정이 이전 소유자 기록으로 쌓이지 않도록 합니다. 종료한 세션 수를 반환합니다.
+func (s *MeasurementService) ProcessDeviceTransferred(ctx context.Context, deviceID, fromUserID string) (int, error) {
+	if deviceID == "" {
+		return 0, apperrors.New(apperrors.ErrInvalidInput, "device_id는 필수입니다")
+	}
+
+	sessions, err := s.sessionRepo.ListActiveByDevice(ctx, deviceID)
+	if err != nil {
+		return 0, apperrors.New(apperrors.ErrInternal, "진행 중 세션 조회에 실패했습니다")
+	}
+	ended := 0
+	for _, session := range sessions {
+		if fromUserID != "" && session.UserID != fromUserID {
+			continue
+		}
+		if _, err := s.EndSession(ctx, session.ID); err != nil {
+			return ended, err
+		}
+		ended++
+	}
+	if ended > 0 {
+		s.logger.Info("소유권 이전으로 이전 소유자 세션 종료",
+			zap.String("device_id", deviceID),
+			zap.Int("sessions", ended),
+		)
+	}
+	return ended, nil
+}
+
+// checkDeviceOwner는 at 시점의 디바이스 소유자가 userID인지 확인합니다.
+func (s *MeasurementService) checkDeviceOwner(ctx context.Context, deviceID, userID string, at time.Time) error {
+	if s.deviceOwners == nil {
+		return nil
+	}
+	owner, err := s.deviceOwners.OwnerAt(ctx, deviceID, at)
+	if err != nil {
+		s.logger.Warn("디바이스 소유자 조회 실패", zap.String("device_id", deviceID), zap.Error(err))
+		return apperrors.New(apperrors.ErrServiceUnavailable, "디바이스 소유자 확인에 실패했습니다")
+	}
+	if owner == "" {
+		return apperrors.New(apperrors.ErrNotFound, "등록된 디바이스를 찾을 수 없습니다")
+	}
+	if owner != userID {
+		return apperrors.New(apperrors.ErrForbidden, "디바이스 소유자만 측정을 시작할 수 있습니다")
+	}
+	return nil
+}
+
 // GetHistory는 측정 기록을 조회합니다.
 func (s *MeasurementService) GetHistory(
 	ctx context.Context,
@@ -274,6 +345,68 @@ func (s *MeasurementService) GetHistory(
 	return s.measureRepo.GetHistory(ctx, userID, start, end, limit, offset)
 }
 
+// GetMeasurement는 측정 세션과 측정값별 신뢰도·QC 플래그를 조회합니다.
+func (s *MeasurementService) GetMeasurement(ctx context.Context, sessionID string) (*MeasurementSession, []*MeasurementSummary, error) {
+	if sessionID == "" {
+		return nil, nil, apperrors.New(apperrors.ErrInvalidInput, "session_id는 필수입니다")
+	}
+
+	session, err := s.sessionRepo.GetSession(ctx, sessionID)
+	if err != nil || session == nil {
+		return nil, nil, apperrors.New(apperrors.ErrNotFound, "측정 세션을 찾을 수 없습니다")
+	}
+
+	data, err := s.measureRepo.GetBySession(ctx, sessionID)
+	if err != nil {
+		return nil, nil, apperrors.New(apperrors.ErrInternal, "측정 데이터 조회에 실패했습니다")
+	}
+
+	readings := make([]*MeasurementSummary, 0, len(data))
+	for _, d := range data {
+		readings = append(readings, &MeasurementSummary{
+			SessionID:     d.SessionID,
+			CartridgeType: d.CartridgeType,
+			PrimaryValue:  d.PrimaryValue,
+			Unit:          d.Unit,
+			MeasuredAt:    d.Time,
+			Confidence:    d.Confidence,
+			QCFlags:       QualityFlags(d),
+		})
+	}
+	return session, readings, nil
+}
+
+// 측정 품질 관리(QC) 플래그
+const (
+	QCTemperatureOutOfRange = "temperature_out_of_range" // 동작 온도 10~40°C 벗어남
+	QCHumidityHigh          = "humidity_high"            // 상대습도 85% 초과
+	QCBatteryLow            = "battery_low"              // 배터리 10% 미만
+	QCLowConfidence         = "low_confidence"           // 신호 신뢰도 0.5 미만
+	QCReferenceInvalid      = "reference_invalid"        // 기준 채널 신호 없음
+)
+
+// QualityFlags는 측정 환경과 신호 품질로 QC 경고를 판정합니다.
+// 환경 값이 0이면 센서 미보고로 보고 판정하지 않습니다.
+func QualityFlags(d *MeasurementData) []string {
+	var flags []string
+	if d.TempC != 0 && (d.TempC < 10 || d.TempC > 40) {
+		flags = append(flags, QCTemperatureOutOfRange)
+	}
+	if d.HumidityPct > 85 {
+		flags = append(flags, QCHumidityHigh)
+	}
+	if d.BatteryPct > 0 && d.BatteryPct < 10 {
+		flags = append(flags, QCBatteryLow)
+	}
+	if d.Confidence < 0.5 {
+		flags = append(flags, QCLowConfidence)
+	}
+	if len(d.RawChannels) > 0 && d.SRef <= 0 {
+		flags = append(flags, QCReferenceInvalid)
+	}
+	return flags
+}
+
 // defaultLOINCMap은 바이오마커 이름에 대한 LOINC 코드 매핑입니다.
 var defaultLOINCMap = map[string]string{
 	"blood_glucose":     "15074-8",
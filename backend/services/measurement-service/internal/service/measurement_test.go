@@ -2,9 +2,12 @@ package service
 
 import (
 	"context"
+	"errors"
+	"strings"
 	"testing"
 	"time"
 
+	apperrors "github.com/manpasik/backend/shared/errors"
 	"go.uber.org/zap"
 )
 
@@ -33,6 +36,16 @@ func (r *mockSessionRepo) GetSession(_ context.Context, sessionID string) (*Meas
 	return s, nil
 }
 
+func (r *mockSessionRepo) ListActiveByDevice(_ context.Context, deviceID string) ([]*MeasurementSession, error) {
+	var result []*MeasurementSession
+	for _, s := range r.sessions {
+		if s.DeviceID == deviceID && s.Status == "active" {
+			result = append(result, s)
+		}
+	}
+	return result, nil
+}
+
 func (r *mockSessionRepo) EndSession(_ context.Context, sessionID string, total int, endedAt time.Time) error {
 	s, ok := r.sessions[sessionID]
 	if !ok {
@@ -85,6 +98,16 @@ func (r *mockMeasureRepo) GetHistory(_ context.Context, userID string, start, en
 	return filtered[offset:endIdx], total, nil
 }
 
+func (r *mockMeasureRepo) GetBySession(_ context.Context, sessionID string) ([]*MeasurementData, error) {
+	var result []*MeasurementData
+	for _, d := range r.data {
+		if d.SessionID == sessionID {
+			result = append(result, d)
+		}
+	}
+	return result, nil
+}
+
 type mockVectorRepo struct {
 	vectors map[string][]float32
 }
@@ -178,6 +201,50 @@ func TestStartSession_빈_입력(t *testing.T) {
 	}
 }
 
+// fakeDeviceOwners는 디바이스별 현재 소유자를 돌려주는 소유 이력 조회 대역입니다.
+type fakeDeviceOwners map[string]string
+
+func (f fakeDeviceOwners) OwnerAt(_ context.Context, deviceID string, _ time.Time) (string, error) {
+	return f[deviceID], nil
+}
+
+func TestStartSession_디바이스_소유자만_허용(t *testing.T) {
+	svc, _, _, _, _ := newTestMeasurementService()
+	svc.SetDeviceOwnerClient(fakeDeviceOwners{"device-1": "user-2"})
+	ctx := context.Background()
+
+	var appErr *apperrors.AppError
+	if _, err := svc.StartSession(ctx, "device-1", "cartridge-glucose", "user-1"); !errors.As(err, &appErr) || appErr.Code != apperrors.ErrForbidden {
+		t.Fatalf("이전 소유자의 세션 시작은 거부되어야 합니다: %v", err)
+	}
+	if _, err := svc.StartSession(ctx, "device-9", "cartridge-glucose", "user-1"); !errors.As(err, &appErr) || appErr.Code != apperrors.ErrNotFound {
+		t.Fatalf("소유자 없는 디바이스는 거부되어야 합니다: %v", err)
+	}
+	session, err := svc.StartSession(ctx, "device-1", "cartridge-glucose", "user-2")
+	if err != nil || session.UserID != "user-2" {
+		t.Fatalf("현재 소유자의 세션 시작 실패: %v", err)
+	}
+}
+
+func TestProcessDeviceTransferred_이전_소유자_세션_종료(t *testing.T) {
+	svc, sessionRepo, _, _, eventPub := newTestMeasurementService()
+	ctx := context.Background()
+
+	old, _ := svc.StartSession(ctx, "device-1", "cartridge-glucose", "user-1")
+	other, _ := svc.StartSession(ctx, "device-2", "cartridge-glucose", "user-1")
+
+	ended, err := svc.ProcessDeviceTransferred(ctx, "device-1", "user-1")
+	if err != nil || ended != 1 {
+		t.Fatalf("이전 소유자 세션 종료 실패: ended=%d, err=%v", ended, err)
+	}
+	if sessionRepo.sessions[old.ID].Status != "completed" || sessionRepo.sessions[other.ID].Status != "active" {
+		t.Errorf("이전된 디바이스의 세션만 종료되어야 합니다")
+	}
+	if len(eventPub.events) != 1 || eventPub.events[0].UserID != "user-1" {
+		t.Errorf("종료된 세션은 이전 소유자 기록으로 완료 이벤트가 발행되어야 합니다: %+v", eventPub.events)
+	}
+}
+
 // =============================================================================
 // ProcessMeasurement 테스트
 // =============================================================================
@@ -230,6 +297,38 @@ func TestProcessMeasurement_빈_세션ID(t *testing.T) {
 	}
 }
 
+// =============================================================================
+// GetMeasurement 테스트
+// =============================================================================
+
+func TestGetMeasurement_QC_플래그(t *testing.T) {
+	svc, _, _, _, _ := newTestMeasurementService()
+	ctx := context.Background()
+
+	session, _ := svc.StartSession(ctx, "device-1", "cartridge-glucose", "user-1")
+	svc.ProcessMeasurement(ctx, &MeasurementData{
+		SessionID: session.ID, DeviceID: "device-1", UserID: "user-1", CartridgeType: "glucose",
+		PrimaryValue: 98, Unit: "mg/dL", Confidence: 0.42, TempC: 45, HumidityPct: 50,
+		RawChannels: []float64{1.2, 0.8}, SRef: 0, Time: time.Now().UTC(),
+	})
+
+	got, readings, err := svc.GetMeasurement(ctx, session.ID)
+	if err != nil {
+		t.Fatalf("측정 조회 실패: %v", err)
+	}
+	if got.UserID != "user-1" || len(readings) != 1 || readings[0].Confidence != 0.42 {
+		t.Fatalf("측정 조회 결과 불일치: %+v %+v", got, readings)
+	}
+	want := []string{QCTemperatureOutOfRange, QCLowConfidence, QCReferenceInvalid}
+	if strings.Join(readings[0].QCFlags, ",") != strings.Join(want, ",") {
+		t.Errorf("QC 플래그 불일치: got %v, want %v", readings[0].QCFlags, want)
+	}
+
+	if _, _, err := svc.GetMeasurement(ctx, "missing"); err == nil {
+		t.Error("존재하지 않는 세션은 에러가 발생해야 합니다")
+	}
+}
+
 // =============================================================================
 // EndSession 테스트
 // =============================================================================
@@ -2,11 +2,17 @@
 //
 // 포트: gRPC :50062
 // 의존: PostgreSQL(선택) — 미설정 시 인메모리 저장소 사용
+// 의존: Kafka(선택) — coaching.goal_milestone, ai.anomaly_detected, health_alert.triggered, iot.rule_triggered, iot.command_failed 소비
+// 의존: family-service(선택, FAMILY_SERVICE_ADDR) — 에스컬레이션 보호자 조회
+// 의존: user-service(선택, USER_SERVICE_ADDR) — 방해 금지 시간 계산용 사용자 시간대
 //
 // 기능:
-// - 푸시/이메일/SMS/인앱 알림 발송
+// - 푸시/이메일/SMS/인앱 알림 발송 (실패 시 push → sms → email → in_app 폴백, 시도별 기록)
+// - 방해 금지 시간(사용자 시간대 기준) 동안 긴급이 아닌 알림은 종료 시각으로 연기 발송
 // - 알림 목록 조회 / 읽음 처리
 // - 알림 설정(선호도) 관리
+// - 건강 목표 마일스톤(진행률 구간 도달·달성·실패) 알림
+// - 긴급 건강 알림 에스컬레이션 (인앱 → 보호자 푸시 → AI 음성 확인 → 119, 재시작·다중 레플리카 안전)
 package main
 
 import (
@@ -23,9 +29,11 @@ import (
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/manpasik/backend/services/notification-service/internal/handler"
 	"github.com/manpasik/backend/services/notification-service/internal/push"
+	kafkaConsumer "github.com/manpasik/backend/services/notification-service/internal/repository/kafka"
 	"github.com/manpasik/backend/services/notification-service/internal/repository/memory"
 	"github.com/manpasik/backend/services/notification-service/internal/repository/postgres"
 	"github.com/manpasik/backend/services/notification-service/internal/service"
+	"github.com/manpasik/backend/shared/clients"
 	"github.com/manpasik/backend/shared/config"
 	"github.com/manpasik/backend/shared/events"
 	v1 "github.com/manpasik/backend/shared/gen/go/v1"
@@ -33,6 +41,7 @@ import (
 	"github.com/manpasik/backend/shared/observability"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/health"
 	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
@@ -57,6 +66,8 @@ func main() {
 
 	var notiRepo service.NotificationRepository
 	var prefRepo service.PreferencesRepository
+	var escRepo service.EscalationRepository
+	var tmplRepo service.TemplateRepository
 	if _, dbHostSet := os.LookupEnv("DB_HOST"); dbHostSet && cfg.DB.Host != "" && cfg.DB.DBName != "" {
 		connCtx, connCancel := context.WithTimeout(context.Background(), 5*time.Second)
 		pool, poolErr := pgxpool.New(connCtx, cfg.DB.DSN())
@@ -65,6 +76,8 @@ func main() {
 			log.Printf("[%s] DB 풀 생성 실패, 인메모리 사용: %v", serviceName, poolErr)
 			notiRepo = memory.NewNotificationRepository()
 			prefRepo = memory.NewPreferencesRepository()
+			escRepo = memory.NewEscalationRepository()
+			tmplRepo = memory.NewTemplateRepository()
 		} else {
 			pingCtx, pingCancel := context.WithTimeout(context.Background(), 3*time.Second)
 			if pingErr := pool.Ping(pingCtx); pingErr != nil {
@@ -73,22 +86,34 @@ func main() {
 				log.Printf("[%s] DB Ping 실패, 인메모리 사용: %v", serviceName, pingErr)
 				notiRepo = memory.NewNotificationRepository()
 				prefRepo = memory.NewPreferencesRepository()
+				escRepo = memory.NewEscalationRepository()
+				tmplRepo = memory.NewTemplateRepository()
 			} else {
 				pingCancel()
 				defer pool.Close()
 				notiRepo = postgres.NewNotificationRepository(pool)
 				prefRepo = postgres.NewPreferencesRepository(pool)
+				escRepo = postgres.NewEscalationRepository(pool)
+				tmplRepo = postgres.NewTemplateRepository(pool)
 				log.Printf("[%s] DB 연결됨: %s", serviceName, cfg.DB.DBName)
 			}
 		}
 	} else {
 		notiRepo = memory.NewNotificationRepository()
 		prefRepo = memory.NewPreferencesRepository()
+		escRepo = memory.NewEscalationRepository()
+		tmplRepo = memory.NewTemplateRepository()
 		log.Printf("[%s] 인메모리 저장소 사용", serviceName)
 	}
 
 	notiSvc := service.NewNotificationService(logger, notiRepo, prefRepo)
 
+	// 알림 템플릿: 저장소에 없는 기본 템플릿(ko, en, ja, zh)을 버전 1로 채움
+	notiSvc.SetTemplateRepository(tmplRepo)
+	if err := notiSvc.EnsureDefaultTemplates(context.Background()); err != nil {
+		log.Printf("[%s] 기본 알림 템플릿 저장 실패: %v", serviceName, err)
+	}
+
 	// FCM 푸시 알림 전송기: DB config 우선 → 환경변수 fallback
 	fcmKey := config.LoadConfigWithFallback(nil, "fcm.server_key", "FCM_SERVER_KEY")
 	fcmProject := config.LoadConfigWithFallback(nil, "fcm.project_id", "FIREBASE_PROJECT_ID")
@@ -106,6 +131,24 @@ func main() {
 
 	// 이메일 전송기: 향후 SMTP 설정 시 활성화
 	notiSvc.SetEmailSender(push.NewNoopEmailSender())
+	// SMS 전송기: 미설정 — SMS 시도는 실패로 기록되고 다음 채널로 폴백
+
+	// 사용자 시간대: USER_SERVICE_ADDR 설정 시 프로필 시간대로 방해 금지 시간 계산 (미설정 시 UTC)
+	if userAddr := os.Getenv("USER_SERVICE_ADDR"); userAddr != "" {
+		userConn, dialErr := grpc.NewClient(userAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		if dialErr != nil {
+			log.Printf("[%s] user-service 연결 실패, 방해 금지 시간은 UTC 기준: %v", serviceName, dialErr)
+		} else {
+			defer userConn.Close()
+			notiSvc.SetUserProfileClient(clients.NewGRPCUserProfileClient(v1.NewUserServiceClient(userConn)))
+			log.Printf("[%s] user-service 연결됨: %s", serviceName, userAddr)
+		}
+	}
+
+	// 방해 금지 시간으로 미뤄진 알림 발송 루프
+	deferredCtx, deferredCancel := context.WithCancel(context.Background())
+	defer deferredCancel()
+	go notiSvc.RunDeferredDelivery(deferredCtx)
 
 	// ConfigWatcher: FCM 설정 변경 시 핫리로드
 	configWatcher := events.NewEventBusConfigWatcher(events.NewEventBus())
@@ -123,6 +166,46 @@ func main() {
 	})
 	defer configWatcher.Close()
 
+	// 긴급 알림 에스컬레이션: 저장소에 상태를 보관하고 리더 레플리카가 예정 단계를 실행
+	escSvc := service.NewEscalationService(logger, notiSvc, escRepo)
+	if familyAddr := os.Getenv("FAMILY_SERVICE_ADDR"); familyAddr != "" {
+		familyConn, dialErr := grpc.NewClient(familyAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		if dialErr != nil {
+			log.Printf("[%s] family-service 연결 실패, 보호자 단계 건너뜀: %v", serviceName, dialErr)
+		} else {
+			defer familyConn.Close()
+			escSvc.SetGuardianClient(clients.NewGRPCGuardianClient(v1.NewFamilyServiceClient(familyConn)))
+			log.Printf("[%s] family-service 연결됨: %s", serviceName, familyAddr)
+		}
+	} else {
+		log.Printf("[%s] FAMILY_SERVICE_ADDR 미설정 — 에스컬레이션 보호자 단계 건너뜀", serviceName)
+	}
+	escCtx, escCancel := context.WithCancel(context.Background())
+	defer escCancel()
+	go escSvc.Run(escCtx)
+
+	// 목표 마일스톤·긴급 알림·응급 단서 확인·IoT 규칙 알림: Kafka 설정 시 coaching.goal_milestone, ai.anomaly_detected, health_alert.triggered, iot.rule_triggered, iot.command_failed 소비
+	if _, kafkaBrokersSet := os.LookupEnv("KAFKA_BROKERS"); kafkaBrokersSet && len(cfg.Kafka.Brokers) > 0 {
+		eventBus, kafkaErr := events.NewKafkaEventBus(events.KafkaAdapterConfig{
+			Brokers:     cfg.Kafka.Brokers,
+			GroupID:     serviceName,
+			TopicPrefix: "manpasik.",
+		})
+		if kafkaErr != nil {
+			log.Printf("[%s] Kafka 연결 실패, 목표 마일스톤 알림·에스컬레이션 트리거 비활성: %v", serviceName, kafkaErr)
+		} else {
+			defer eventBus.Close()
+			eventBus.Subscribe(events.EventCoachingGoalMilestone, kafkaConsumer.NewGoalMilestoneHandler(notiSvc))
+			eventBus.Subscribe(events.EventAIAnomalyDetected, kafkaConsumer.NewAnomalyEscalationHandler(escSvc))
+			eventBus.Subscribe(events.EventHealthAlertTriggered, kafkaConsumer.NewHealthAlertEscalationHandler(escSvc))
+			eventBus.Subscribe(events.EventHealthAlertTriggered, kafkaConsumer.NewSafetyCheckHandler(notiSvc))
+			eventBus.Subscribe(events.EventIoTRuleTriggered, kafkaConsumer.NewIoTRuleTriggeredHandler(notiSvc))
+			eventBus.Subscribe(events.EventIoTCommandFailed, kafkaConsumer.NewIoTCommandFailedHandler(notiSvc))
+			eventBus.StartConsuming(context.Background())
+			log.Printf("[%s] Kafka 연결됨: %v", serviceName, cfg.Kafka.Brokers)
+		}
+	}
+
 	grpcServer := grpc.NewServer(
 		grpc.ChainUnaryInterceptor(
 			middleware.RequestIDInterceptor(),
@@ -135,6 +218,7 @@ func main() {
 	healthServer.SetServingStatus(serviceName, healthpb.HealthCheckResponse_SERVING)
 
 	notiHandler := handler.NewNotificationHandler(notiSvc, logger)
+	notiHandler.SetEscalationService(escSvc)
 	v1.RegisterNotificationServiceServer(grpcServer, notiHandler)
 
 	reflection.Register(grpcServer)
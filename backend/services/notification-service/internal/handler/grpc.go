@@ -17,8 +17,9 @@ import (
 // NotificationHandler는 NotificationService gRPC 서버를 구현합니다.
 type NotificationHandler struct {
 	v1.UnimplementedNotificationServiceServer
-	svc *service.NotificationService
-	log *zap.Logger
+	svc    *service.NotificationService
+	escSvc *service.EscalationService // optional: nil이면 에스컬레이션 RPC 비활성
+	log    *zap.Logger
 }
 
 // NewNotificationHandler는 NotificationHandler를 생성합니다.
@@ -26,6 +27,11 @@ func NewNotificationHandler(svc *service.NotificationService, log *zap.Logger) *
 	return &NotificationHandler{svc: svc, log: log}
 }
 
+// SetEscalationService는 긴급 알림 에스컬레이션 서비스를 설정합니다 (optional).
+func (h *NotificationHandler) SetEscalationService(escSvc *service.EscalationService) {
+	h.escSvc = escSvc
+}
+
 // SendNotification은 알림 발송 RPC입니다.
 func (h *NotificationHandler) SendNotification(ctx context.Context, req *v1.SendNotificationRequest) (*v1.Notification, error) {
 	if req == nil || req.UserId == "" {
@@ -174,7 +180,8 @@ func (h *NotificationHandler) GetNotificationPreferences(ctx context.Context, re
 	return preferencesToProto(pref), nil
 }
 
-// SendFromTemplate은 사전 정의된 템플릿으로 알림을 발송하는 RPC입니다.
+// SendFromTemplate은 알림 템플릿으로 알림을 발송하는 RPC입니다.
+// language가 비어 있으면 사용자 알림 설정 언어를 쓰며, 해당 언어 템플릿이 없으면 en → ko 순으로 폴백합니다.
 func (h *NotificationHandler) SendFromTemplate(ctx context.Context, req *v1.SendFromTemplateRequest) (*v1.Notification, error) {
 	if req == nil || req.UserId == "" {
 		return nil, status.Error(codes.InvalidArgument, "user_id는 필수입니다")
@@ -183,31 +190,211 @@ func (h *NotificationHandler) SendFromTemplate(ctx context.Context, req *v1.Send
 		return nil, status.Error(codes.InvalidArgument, "template_key는 필수입니다")
 	}
 
-	// data map의 값들을 args로 변환
-	var args []interface{}
-	for _, v := range req.Data {
-		args = append(args, v)
+	noti, err := h.svc.SendFromTemplate(ctx, req.UserId, req.TemplateKey, req.Language, req.Data)
+	if err != nil {
+		return nil, toGRPC(err)
+	}
+	return notificationToProto(noti), nil
+}
+
+// ListNotificationTemplates는 템플릿 최신 버전 목록 조회 RPC입니다.
+func (h *NotificationHandler) ListNotificationTemplates(ctx context.Context, req *v1.ListNotificationTemplatesRequest) (*v1.ListNotificationTemplatesResponse, error) {
+	if req == nil {
+		req = &v1.ListNotificationTemplatesRequest{}
+	}
+	list, err := h.svc.ListTemplates(ctx, req.TemplateKey, req.Language)
+	if err != nil {
+		return nil, toGRPC(err)
+	}
+	return templatesToProto(list), nil
+}
+
+// ListNotificationTemplateVersions는 템플릿 언어별 버전 이력 조회 RPC입니다.
+func (h *NotificationHandler) ListNotificationTemplateVersions(ctx context.Context, req *v1.ListNotificationTemplateVersionsRequest) (*v1.ListNotificationTemplatesResponse, error) {
+	if req == nil || req.TemplateKey == "" || req.Language == "" {
+		return nil, status.Error(codes.InvalidArgument, "template_key와 language는 필수입니다")
+	}
+	list, err := h.svc.ListTemplateVersions(ctx, req.TemplateKey, req.Language)
+	if err != nil {
+		return nil, toGRPC(err)
+	}
+	return templatesToProto(list), nil
+}
+
+// SaveNotificationTemplate은 템플릿을 새 버전으로 저장하는 RPC입니다.
+func (h *NotificationHandler) SaveNotificationTemplate(ctx context.Context, req *v1.SaveNotificationTemplateRequest) (*v1.NotificationTemplate, error) {
+	if req == nil || req.Template == nil {
+		return nil, status.Error(codes.InvalidArgument, "template은 필수입니다")
+	}
+	t, err := h.svc.SaveTemplate(ctx, protoTemplateToService(req.Template), int(req.BaseVersion), req.UpdatedBy)
+	if err != nil {
+		return nil, toGRPC(err)
+	}
+	return templateToProto(t), nil
+}
+
+// DeleteNotificationTemplate은 템플릿 언어 변형을 삭제 표시하는 RPC입니다.
+func (h *NotificationHandler) DeleteNotificationTemplate(ctx context.Context, req *v1.DeleteNotificationTemplateRequest) (*v1.NotificationTemplate, error) {
+	if req == nil || req.TemplateKey == "" || req.Language == "" {
+		return nil, status.Error(codes.InvalidArgument, "template_key와 language는 필수입니다")
+	}
+	t, err := h.svc.DeleteTemplate(ctx, req.TemplateKey, req.Language, int(req.BaseVersion), req.UpdatedBy)
+	if err != nil {
+		return nil, toGRPC(err)
+	}
+	return templateToProto(t), nil
+}
+
+// RollbackNotificationTemplate은 템플릿을 이전 버전 내용으로 되돌리는 RPC입니다.
+func (h *NotificationHandler) RollbackNotificationTemplate(ctx context.Context, req *v1.RollbackNotificationTemplateRequest) (*v1.NotificationTemplate, error) {
+	if req == nil || req.TemplateKey == "" || req.Language == "" || req.Version <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "template_key, language, version은 필수입니다")
+	}
+	t, err := h.svc.RollbackTemplate(ctx, req.TemplateKey, req.Language, int(req.Version), req.UpdatedBy)
+	if err != nil {
+		return nil, toGRPC(err)
+	}
+	return templateToProto(t), nil
+}
+
+// PreviewNotificationTemplate은 저장된 템플릿 또는 초안을 검증·렌더링하는 RPC입니다.
+func (h *NotificationHandler) PreviewNotificationTemplate(ctx context.Context, req *v1.PreviewNotificationTemplateRequest) (*v1.PreviewNotificationTemplateResponse, error) {
+	if req == nil || (req.Draft == nil && req.TemplateKey == "") {
+		return nil, status.Error(codes.InvalidArgument, "template_key 또는 draft는 필수입니다")
+	}
+	var draft *service.NotificationTemplate
+	if req.Draft != nil {
+		draft = protoTemplateToService(req.Draft)
+	}
+	p, err := h.svc.PreviewTemplate(ctx, req.TemplateKey, req.Language, draft, req.Data)
+	if err != nil {
+		return nil, toGRPC(err)
+	}
+	return &v1.PreviewNotificationTemplateResponse{
+		Valid:               p.Valid,
+		Errors:              p.Errors,
+		Language:            p.Language,
+		Version:             int32(p.Version),
+		Title:               p.Title,
+		Body:                p.Body,
+		MissingPlaceholders: p.MissingPlaceholders,
+	}, nil
+}
+
+// AcknowledgeEscalation은 에스컬레이션 확인(해제) RPC입니다.
+func (h *NotificationHandler) AcknowledgeEscalation(ctx context.Context, req *v1.AcknowledgeEscalationRequest) (*v1.Escalation, error) {
+	if req == nil || req.EscalationId == "" {
+		return nil, status.Error(codes.InvalidArgument, "escalation_id는 필수입니다")
+	}
+	if h.escSvc == nil {
+		return nil, status.Error(codes.Unavailable, "에스컬레이션이 비활성화되어 있습니다")
 	}
 
-	err := h.svc.SendFromTemplate(ctx, req.UserId, req.TemplateKey, args...)
+	event, err := h.escSvc.AcknowledgeEscalation(ctx, req.EscalationId, req.ResolvedBy)
 	if err != nil {
 		return nil, toGRPC(err)
 	}
+	return escalationToProto(event), nil
+}
 
-	// SendFromTemplate은 내부에서 SendNotification을 호출하므로
-	// 가장 최근 알림을 조회하여 반환
-	notis, _, _, err := h.svc.ListNotifications(ctx, req.UserId, service.TypeUnknown, false, 1, 0)
-	if err != nil || len(notis) == 0 {
-		return &v1.Notification{UserId: req.UserId}, nil
+// ListActiveEscalations는 사용자의 진행 중 에스컬레이션 조회 RPC입니다.
+func (h *NotificationHandler) ListActiveEscalations(ctx context.Context, req *v1.ListActiveEscalationsRequest) (*v1.ListActiveEscalationsResponse, error) {
+	if req == nil || req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id는 필수입니다")
+	}
+	if h.escSvc == nil {
+		return nil, status.Error(codes.Unavailable, "에스컬레이션이 비활성화되어 있습니다")
 	}
 
-	return notificationToProto(notis[0]), nil
+	events, err := h.escSvc.GetActiveEscalations(ctx, req.UserId)
+	if err != nil {
+		return nil, toGRPC(err)
+	}
+	resp := &v1.ListActiveEscalationsResponse{}
+	for _, e := range events {
+		resp.Escalations = append(resp.Escalations, escalationToProto(e))
+	}
+	return resp, nil
 }
 
 // ============================================================================
 // 변환 헬퍼
 // ============================================================================
 
+func templateToProto(t *service.NotificationTemplate) *v1.NotificationTemplate {
+	pb := &v1.NotificationTemplate{
+		TemplateKey:  t.Key,
+		Language:     t.Language,
+		Version:      int32(t.Version),
+		Title:        t.Title,
+		Body:         t.Body,
+		Type:         serviceNotificationTypeToProto(t.Type),
+		Priority:     servicePriorityToProto(t.Priority),
+		Channel:      serviceChannelToProto(t.Channel),
+		Placeholders: t.Placeholders,
+		Deleted:      t.Deleted,
+		UpdatedBy:    t.UpdatedBy,
+	}
+	if !t.CreatedAt.IsZero() {
+		pb.CreatedAt = timestamppb.New(t.CreatedAt)
+	}
+	return pb
+}
+
+func templatesToProto(list []*service.NotificationTemplate) *v1.ListNotificationTemplatesResponse {
+	resp := &v1.ListNotificationTemplatesResponse{}
+	for _, t := range list {
+		resp.Templates = append(resp.Templates, templateToProto(t))
+	}
+	return resp
+}
+
+func protoTemplateToService(pb *v1.NotificationTemplate) *service.NotificationTemplate {
+	return &service.NotificationTemplate{
+		Key:          pb.TemplateKey,
+		Language:     pb.Language,
+		Version:      int(pb.Version),
+		Title:        pb.Title,
+		Body:         pb.Body,
+		Type:         protoNotificationTypeToService(pb.Type),
+		Priority:     protoPriorityToService(pb.Priority),
+		Channel:      protoChannelToService(pb.Channel),
+		Placeholders: pb.Placeholders,
+	}
+}
+
+func escalationToProto(e *service.EscalationEvent) *v1.Escalation {
+	pb := &v1.Escalation{
+		EscalationId:     e.ID,
+		UserId:           e.UserID,
+		AlertType:        e.AlertType,
+		Stage:            v1.EscalationStage(e.Stage),
+		MeasurementId:    e.MeasurementID,
+		Value:            e.Value,
+		CreatedAt:        timestamppb.New(e.CreatedAt),
+		LastEscalationAt: timestamppb.New(e.LastEscalation),
+		ResolvedBy:       e.ResolvedBy,
+	}
+	if e.NextStageAt != nil {
+		pb.NextStageAt = timestamppb.New(*e.NextStageAt)
+	}
+	if e.ResolvedAt != nil {
+		pb.ResolvedAt = timestamppb.New(*e.ResolvedAt)
+	}
+	for _, d := range e.Deliveries {
+		pb.Deliveries = append(pb.Deliveries, &v1.EscalationDelivery{
+			Stage:           v1.EscalationStage(d.Stage),
+			RecipientUserId: d.RecipientID,
+			Channel:         d.Channel,
+			Status:          d.Status,
+			Attempt:         int32(d.Attempt),
+			Error:           d.Error,
+			CreatedAt:       timestamppb.New(d.CreatedAt),
+		})
+	}
+	return pb
+}
+
 func notificationToProto(n *service.Notification) *v1.Notification {
 	// n.Data is a JSON string; proto expects map[string]string.
 	dataMap := make(map[string]string)
@@ -233,6 +420,21 @@ func notificationToProto(n *service.Notification) *v1.Notification {
 	if n.ReadAt != nil {
 		pb.ReadAt = timestamppb.New(*n.ReadAt)
 	}
+	pb.DeliveryStatus = n.Status
+	if n.DeliveredVia != service.ChannelUnknown {
+		pb.DeliveredChannel = serviceChannelToProto(n.DeliveredVia)
+	}
+	if n.DeliverAt != nil {
+		pb.DeliverAt = timestamppb.New(*n.DeliverAt)
+	}
+	for _, a := range n.Attempts {
+		pb.DeliveryAttempts = append(pb.DeliveryAttempts, &v1.NotificationDeliveryAttempt{
+			Channel:     serviceChannelToProto(a.Channel),
+			Status:      a.Status,
+			Error:       a.Error,
+			AttemptedAt: timestamppb.New(a.CreatedAt),
+		})
+	}
 	return pb
 }
 
@@ -0,0 +1,93 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/manpasik/backend/services/notification-service/internal/service"
+	"github.com/manpasik/backend/shared/events"
+)
+
+// severityCritical은 에스컬레이션을 시작하는 심각도입니다.
+const severityCritical = "critical"
+
+// EscalationTrigger는 긴급 건강 알림으로 에스컬레이션을 시작하는 대상입니다.
+type EscalationTrigger interface {
+	TriggerEscalation(ctx context.Context, userID, alertType, measurementID, value string) (*service.EscalationEvent, error)
+}
+
+// NewAnomalyEscalationHandler는 ai.anomaly_detected 이벤트 중 severity가 critical인 것으로 에스컬레이션을 시작하는 핸들러를 반환합니다.
+func NewAnomalyEscalationHandler(trigger EscalationTrigger) events.Handler {
+	return func(ctx context.Context, event events.Event) error {
+		payload := events.PayloadOf(event)
+		if severity, _ := payload["severity"].(string); severity != severityCritical {
+			return nil
+		}
+		userID := eventUserID(event.Payload, payload)
+		if userID == "" {
+			return nil
+		}
+		measurementID, _ := payload["measurement_id"].(string)
+		biomarker, _ := payload["biomarker"].(string)
+		unit, _ := payload["unit"].(string)
+		value := strings.TrimSpace(fmt.Sprintf("%s %v %s", biomarker, payload["value"], unit))
+		_, err := trigger.TriggerEscalation(ctx, userID, "health_critical", measurementID, value)
+		return err
+	}
+}
+
+// NewHealthAlertEscalationHandler는 health_alert.triggered 이벤트 중 severity가 critical인 것으로 에스컬레이션을 시작하는 핸들러를 반환합니다.
+func NewHealthAlertEscalationHandler(trigger EscalationTrigger) events.Handler {
+	return func(ctx context.Context, event events.Event) error {
+		payload := events.PayloadOf(event)
+		if severity, _ := payload["severity"].(string); severity != severityCritical {
+			return nil
+		}
+		userID := eventUserID(event.Payload, payload)
+		if userID == "" {
+			return nil
+		}
+		alertType, _ := payload["alert_type"].(string)
+		if alertType == "" {
+			alertType = "health_critical"
+		}
+		measurementID, _ := payload["measurement_id"].(string)
+		value, _ := payload["value"].(string)
+		if value == "" {
+			value, _ = payload["message"].(string)
+		}
+		_, err := trigger.TriggerEscalation(ctx, userID, alertType, measurementID, value)
+		return err
+	}
+}
+
+// SafetyCheckNotifier는 응급 단서 확인 요청을 사용자 알림으로 보내는 대상입니다.
+type SafetyCheckNotifier interface {
+	NotifySafetyCheck(ctx context.Context, userID string) error
+}
+
+// NewSafetyCheckHandler는 health_alert.triggered 이벤트 중 requires_confirmation인 것(예: AI 채팅 응급 단서)으로
+// 사용자에게 상태 확인 알림을 보내는 핸들러를 반환합니다. 이 이벤트는 critical이 아니므로 에스컬레이션은 시작되지 않습니다.
+func NewSafetyCheckHandler(notifier SafetyCheckNotifier) events.Handler {
+	return func(ctx context.Context, event events.Event) error {
+		payload := events.PayloadOf(event)
+		if confirm, _ := payload["requires_confirmation"].(bool); !confirm {
+			return nil
+		}
+		userID := eventUserID(event.Payload, payload)
+		if userID == "" {
+			return nil
+		}
+		return notifier.NotifySafetyCheck(ctx, userID)
+	}
+}
+
+// eventUserID는 봉투 또는 payload의 user_id를 꺼냅니다.
+func eventUserID(envelope, payload map[string]interface{}) string {
+	if userID, _ := envelope["user_id"].(string); userID != "" {
+		return userID
+	}
+	userID, _ := payload["user_id"].(string)
+	return userID
+}
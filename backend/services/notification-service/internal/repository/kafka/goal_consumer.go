@@ -0,0 +1,32 @@
+// Package kafka는 notification-service가 소비하는 Kafka 이벤트 핸들러를 제공합니다.
+package kafka
+
+import (
+	"context"
+
+	"github.com/manpasik/backend/shared/events"
+)
+
+// GoalMilestoneNotifier는 목표 마일스톤을 사용자 알림으로 보내는 대상입니다.
+type GoalMilestoneNotifier interface {
+	NotifyGoalMilestone(ctx context.Context, userID, kind, metricName string, milestone, streak int32) error
+}
+
+// NewGoalMilestoneHandler는 coaching.goal_milestone 이벤트를 받아 목표 진행 알림을 보내는 핸들러를 반환합니다.
+func NewGoalMilestoneHandler(notifier GoalMilestoneNotifier) events.Handler {
+	return func(ctx context.Context, event events.Event) error {
+		payload := events.PayloadOf(event)
+		userID, _ := event.Payload["user_id"].(string)
+		if userID == "" {
+			userID, _ = payload["user_id"].(string)
+		}
+		if userID == "" {
+			return nil
+		}
+		kind, _ := payload["kind"].(string)
+		metricName, _ := payload["metric_name"].(string)
+		milestone, _ := payload["milestone"].(float64)
+		streak, _ := payload["streak"].(float64)
+		return notifier.NotifyGoalMilestone(ctx, userID, kind, metricName, int32(milestone), int32(streak))
+	}
+}
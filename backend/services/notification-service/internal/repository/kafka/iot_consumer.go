@@ -0,0 +1,51 @@
+package kafka
+
+import (
+	"context"
+
+	"github.com/manpasik/backend/shared/events"
+)
+
+// IoTRuleNotifier는 IoT 알림 규칙 발동을 사용자 알림으로 보내는 대상입니다.
+type IoTRuleNotifier interface {
+	NotifyIoTRuleTriggered(ctx context.Context, userID, ruleName, dataType string, value float64) error
+}
+
+// NewIoTRuleTriggeredHandler는 iot.rule_triggered 이벤트를 받아 디바이스 사용자(없으면 규칙 소유자)에게
+// 알림을 보내는 핸들러를 반환합니다.
+func NewIoTRuleTriggeredHandler(notifier IoTRuleNotifier) events.Handler {
+	return func(ctx context.Context, event events.Event) error {
+		payload := events.PayloadOf(event)
+		userID := eventUserID(event.Payload, payload)
+		if userID == "" {
+			userID, _ = payload["owner_id"].(string)
+		}
+		if userID == "" {
+			return nil
+		}
+		ruleName, _ := payload["rule_name"].(string)
+		dataType, _ := payload["data_type"].(string)
+		value, _ := payload["value"].(float64)
+		return notifier.NotifyIoTRuleTriggered(ctx, userID, ruleName, dataType, value)
+	}
+}
+
+// IoTCommandFailureNotifier는 IoT 명령 최종 실패를 사용자 알림으로 보내는 대상입니다.
+type IoTCommandFailureNotifier interface {
+	NotifyIoTCommandFailed(ctx context.Context, userID, deviceID, commandType string) error
+}
+
+// NewIoTCommandFailedHandler는 iot.command_failed 이벤트(재시도 소진·TTL 만료)를 받아
+// 디바이스 사용자에게 알림을 보내는 핸들러를 반환합니다. 사용자를 알 수 없는 디바이스는 건너뜁니다.
+func NewIoTCommandFailedHandler(notifier IoTCommandFailureNotifier) events.Handler {
+	return func(ctx context.Context, event events.Event) error {
+		payload := events.PayloadOf(event)
+		userID := eventUserID(event.Payload, payload)
+		if userID == "" {
+			return nil
+		}
+		deviceID, _ := payload["device_id"].(string)
+		commandType, _ := payload["command_type"].(string)
+		return notifier.NotifyIoTCommandFailed(ctx, userID, deviceID, commandType)
+	}
+}
@@ -0,0 +1,179 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/manpasik/backend/services/notification-service/internal/service"
+)
+
+// escalationRow는 이벤트와 단계 실행 임대를 함께 보관합니다.
+type escalationRow struct {
+	event      service.EscalationEvent
+	leaseOwner string
+	leaseUntil time.Time
+}
+
+type leaderLease struct {
+	holder string
+	until  time.Time
+}
+
+// EscalationRepository는 에스컬레이션 인메모리 저장소입니다.
+// 단일 프로세스에서만 유효하므로 재시작하면 진행 중 에스컬레이션이 사라집니다.
+type EscalationRepository struct {
+	mu         sync.Mutex
+	events     map[string]*escalationRow
+	deliveries map[string][]*service.EscalationDelivery // escalationID → 기록
+	leases     map[string]leaderLease
+}
+
+// NewEscalationRepository는 새 인메모리 에스컬레이션 저장소를 생성합니다.
+func NewEscalationRepository() *EscalationRepository {
+	return &EscalationRepository{
+		events:     make(map[string]*escalationRow),
+		deliveries: make(map[string][]*service.EscalationDelivery),
+		leases:     make(map[string]leaderLease),
+	}
+}
+
+// Create는 에스컬레이션을 저장합니다. 사용자에게 활성 에스컬레이션이 있으면 ErrEscalationActive입니다.
+func (r *EscalationRepository) Create(_ context.Context, e *service.EscalationEvent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, row := range r.events {
+		if row.event.UserID == e.UserID && row.event.Active() {
+			return service.ErrEscalationActive
+		}
+	}
+	row := &escalationRow{event: *e}
+	row.event.Deliveries = nil
+	r.events[e.ID] = row
+	return nil
+}
+
+// FindByID는 에스컬레이션을 조회합니다.
+func (r *EscalationRepository) FindByID(_ context.Context, id string) (*service.EscalationEvent, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	row, ok := r.events[id]
+	if !ok {
+		return nil, nil
+	}
+	e := row.event
+	return &e, nil
+}
+
+// FindActiveByUserID는 사용자의 활성 에스컬레이션을 생성 순으로 조회합니다.
+func (r *EscalationRepository) FindActiveByUserID(_ context.Context, userID string) ([]*service.EscalationEvent, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var result []*service.EscalationEvent
+	for _, row := range r.events {
+		if row.event.UserID == userID && row.event.Active() {
+			e := row.event
+			result = append(result, &e)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].CreatedAt.Before(result[j].CreatedAt) })
+	return result, nil
+}
+
+// FindDue는 다음 단계 시각이 된 활성 에스컬레이션을 오래된 순으로 조회합니다.
+func (r *EscalationRepository) FindDue(_ context.Context, now time.Time, limit int) ([]*service.EscalationEvent, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var result []*service.EscalationEvent
+	for _, row := range r.events {
+		if row.event.Active() && row.event.NextStageAt != nil && !row.event.NextStageAt.After(now) {
+			e := row.event
+			result = append(result, &e)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].NextStageAt.Before(*result[j].NextStageAt) })
+	if limit > 0 && len(result) > limit {
+		result = result[:limit]
+	}
+	return result, nil
+}
+
+// Claim은 단계 실행 임대를 잡습니다.
+func (r *EscalationRepository) Claim(_ context.Context, id string, fromStage service.EscalationStage, worker string, now, leaseUntil time.Time) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	row, ok := r.events[id]
+	if !ok || !row.event.Active() || row.event.Stage != fromStage {
+		return false, nil
+	}
+	if row.leaseOwner != "" && row.leaseOwner != worker && row.leaseUntil.After(now) {
+		return false, nil
+	}
+	row.leaseOwner, row.leaseUntil = worker, leaseUntil
+	return true, nil
+}
+
+// Advance는 단계 진행 결과를 저장하고 임대를 풉니다.
+func (r *EscalationRepository) Advance(_ context.Context, e *service.EscalationEvent, fromStage service.EscalationStage) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	row, ok := r.events[e.ID]
+	if !ok || !row.event.Active() || row.event.Stage != fromStage {
+		return false, nil
+	}
+	row.event.Stage = e.Stage
+	row.event.Attempt = e.Attempt
+	row.event.NextStageAt = e.NextStageAt
+	row.event.LastEscalation = e.LastEscalation
+	row.leaseOwner, row.leaseUntil = "", time.Time{}
+	return true, nil
+}
+
+// Resolve는 활성 에스컬레이션을 해제합니다.
+func (r *EscalationRepository) Resolve(_ context.Context, id, resolvedBy string, at time.Time) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	row, ok := r.events[id]
+	if !ok || !row.event.Active() {
+		return false, nil
+	}
+	row.event.Stage = service.StageResolved
+	row.event.ResolvedAt = &at
+	row.event.ResolvedBy = resolvedBy
+	row.event.NextStageAt = nil
+	return true, nil
+}
+
+// SaveDelivery는 전달 기록을 저장합니다.
+func (r *EscalationRepository) SaveDelivery(_ context.Context, d *service.EscalationDelivery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	cp := *d
+	r.deliveries[d.EscalationID] = append(r.deliveries[d.EscalationID], &cp)
+	return nil
+}
+
+// FindDeliveries는 전달 기록을 저장 순으로 조회합니다.
+func (r *EscalationRepository) FindDeliveries(_ context.Context, escalationID string) ([]*service.EscalationDelivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	result := make([]*service.EscalationDelivery, 0, len(r.deliveries[escalationID]))
+	for _, d := range r.deliveries[escalationID] {
+		cp := *d
+		result = append(result, &cp)
+	}
+	return result, nil
+}
+
+// AcquireLeadership은 리더 임대를 잡거나 갱신합니다.
+func (r *EscalationRepository) AcquireLeadership(_ context.Context, name, holder string, now, until time.Time) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	cur, ok := r.leases[name]
+	if ok && cur.holder != holder && cur.until.After(now) {
+		return false, nil
+	}
+	r.leases[name] = leaderLease{holder: holder, until: until}
+	return true, nil
+}
@@ -3,6 +3,7 @@ package memory
 
 import (
 	"context"
+	"sort"
 	"sync"
 	"time"
 
@@ -12,22 +13,26 @@ import (
 
 // NotificationRepository는 알림 인메모리 저장소입니다.
 type NotificationRepository struct {
-	mu    sync.RWMutex
-	store map[string]*service.Notification
+	mu       sync.RWMutex
+	store    map[string]*service.Notification
+	attempts map[string][]*service.DeliveryAttempt // notificationID → 전송 시도
 }
 
 // NewNotificationRepository는 새 인메모리 알림 저장소를 생성합니다.
 func NewNotificationRepository() *NotificationRepository {
 	return &NotificationRepository{
-		store: make(map[string]*service.Notification),
+		store:    make(map[string]*service.Notification),
+		attempts: make(map[string][]*service.DeliveryAttempt),
 	}
 }
 
-// Save는 알림을 저장합니다.
+// Save는 알림을 저장합니다. 이후 호출자가 고치는 전달 상태와 섞이지 않도록 복사본을 보관합니다.
 func (r *NotificationRepository) Save(_ context.Context, n *service.Notification) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
-	r.store[n.ID] = n
+	cp := *n
+	cp.Attempts = nil
+	r.store[n.ID] = &cp
 	return nil
 }
 
@@ -131,6 +136,71 @@ func (r *NotificationRepository) GetUnreadCount(_ context.Context, userID string
 	return total, byType, nil
 }
 
+// UpdateDelivery는 전달 상태를 저장합니다.
+func (r *NotificationRepository) UpdateDelivery(_ context.Context, n *service.Notification) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored, ok := r.store[n.ID]
+	if !ok {
+		return apperrors.New(apperrors.ErrNotFound, "리소스를 찾을 수 없습니다")
+	}
+	stored.Status = n.Status
+	stored.DeliveredVia = n.DeliveredVia
+	stored.DeliverAt = n.DeliverAt
+	return nil
+}
+
+// FindDeferred는 발송 시각이 된 미뤄진 알림을 오래된 순으로 조회합니다.
+func (r *NotificationRepository) FindDeferred(_ context.Context, now time.Time, limit int) ([]*service.Notification, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var result []*service.Notification
+	for _, n := range r.store {
+		if n.Status == service.DeliveryDeferred && n.DeliverAt != nil && !n.DeliverAt.After(now) {
+			cp := *n
+			result = append(result, &cp)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].DeliverAt.Before(*result[j].DeliverAt) })
+	if limit > 0 && len(result) > limit {
+		result = result[:limit]
+	}
+	return result, nil
+}
+
+// ClaimDeferred는 미뤄진 알림의 발송 권한을 잡습니다.
+func (r *NotificationRepository) ClaimDeferred(_ context.Context, id string, due, until time.Time) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	n, ok := r.store[id]
+	if !ok || n.Status != service.DeliveryDeferred || n.DeliverAt == nil || !n.DeliverAt.Equal(due) {
+		return false, nil
+	}
+	n.DeliverAt = &until
+	return true, nil
+}
+
+// SaveAttempt는 채널 전송 시도를 저장합니다.
+func (r *NotificationRepository) SaveAttempt(_ context.Context, a *service.DeliveryAttempt) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	cp := *a
+	r.attempts[a.NotificationID] = append(r.attempts[a.NotificationID], &cp)
+	return nil
+}
+
+// FindAttempts는 알림의 전송 시도를 저장 순으로 조회합니다.
+func (r *NotificationRepository) FindAttempts(_ context.Context, notificationID string) ([]*service.DeliveryAttempt, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	result := make([]*service.DeliveryAttempt, 0, len(r.attempts[notificationID]))
+	for _, a := range r.attempts[notificationID] {
+		cp := *a
+		result = append(result, &cp)
+	}
+	return result, nil
+}
+
 // PreferencesRepository는 알림 설정 인메모리 저장소입니다.
 type PreferencesRepository struct {
 	mu    sync.RWMutex
@@ -0,0 +1,97 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/manpasik/backend/services/notification-service/internal/service"
+)
+
+// TemplateRepository는 알림 템플릿 인메모리 저장소입니다.
+type TemplateRepository struct {
+	mu       sync.RWMutex
+	versions map[string][]*service.NotificationTemplate // key/language → 버전 오름차순
+}
+
+// NewTemplateRepository는 새 인메모리 템플릿 저장소를 생성합니다.
+func NewTemplateRepository() *TemplateRepository {
+	return &TemplateRepository{versions: make(map[string][]*service.NotificationTemplate)}
+}
+
+func templateID(key, language string) string { return key + "/" + language }
+
+func copyTemplate(t *service.NotificationTemplate) *service.NotificationTemplate {
+	c := *t
+	c.Placeholders = append([]string(nil), t.Placeholders...)
+	return &c
+}
+
+// FindLatest는 최신 버전을 반환합니다 (삭제 표시 포함).
+func (r *TemplateRepository) FindLatest(_ context.Context, key, language string) (*service.NotificationTemplate, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	list := r.versions[templateID(key, language)]
+	if len(list) == 0 {
+		return nil, nil
+	}
+	return copyTemplate(list[len(list)-1]), nil
+}
+
+// FindVersion은 특정 버전을 반환합니다.
+func (r *TemplateRepository) FindVersion(_ context.Context, key, language string, version int) (*service.NotificationTemplate, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, t := range r.versions[templateID(key, language)] {
+		if t.Version == version {
+			return copyTemplate(t), nil
+		}
+	}
+	return nil, nil
+}
+
+// ListVersions는 모든 버전을 최신순으로 반환합니다.
+func (r *TemplateRepository) ListVersions(_ context.Context, key, language string) ([]*service.NotificationTemplate, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	list := r.versions[templateID(key, language)]
+	result := make([]*service.NotificationTemplate, 0, len(list))
+	for i := len(list) - 1; i >= 0; i-- {
+		result = append(result, copyTemplate(list[i]))
+	}
+	return result, nil
+}
+
+// ListLatest는 삭제되지 않은 최신 버전 목록을 key·language 순으로 반환합니다.
+func (r *TemplateRepository) ListLatest(_ context.Context, key, language string) ([]*service.NotificationTemplate, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var result []*service.NotificationTemplate
+	for _, list := range r.versions {
+		latest := list[len(list)-1]
+		if latest.Deleted || (key != "" && latest.Key != key) || (language != "" && latest.Language != language) {
+			continue
+		}
+		result = append(result, copyTemplate(latest))
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Key != result[j].Key {
+			return result[i].Key < result[j].Key
+		}
+		return result[i].Language < result[j].Language
+	})
+	return result, nil
+}
+
+// Insert는 다음 버전일 때만 저장합니다.
+func (r *TemplateRepository) Insert(_ context.Context, t *service.NotificationTemplate) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	id := templateID(t.Key, t.Language)
+	list := r.versions[id]
+	if len(list) > 0 && list[len(list)-1].Version >= t.Version {
+		return false, nil
+	}
+	r.versions[id] = append(list, copyTemplate(t))
+	return true, nil
+}
@@ -0,0 +1,189 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/manpasik/backend/services/notification-service/internal/service"
+)
+
+// ============================================================================
+// EscalationRepository
+// ============================================================================
+
+// EscalationRepository는 PostgreSQL 기반 EscalationRepository 구현입니다.
+// 테이블: escalations, escalation_deliveries, leader_leases (42-escalations.sql)
+type EscalationRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewEscalationRepository는 EscalationRepository를 생성합니다.
+func NewEscalationRepository(pool *pgxpool.Pool) *EscalationRepository {
+	return &EscalationRepository{pool: pool}
+}
+
+const escalationColumns = `id, user_id, alert_type, stage, COALESCE(measurement_id,''), COALESCE(value,''), attempt,
+	next_stage_at, created_at, last_escalation_at, resolved_at, COALESCE(resolved_by,'')`
+
+// activeEscalation은 해제되지 않은 에스컬레이션 조건입니다 (StageResolved = 5).
+const activeEscalation = `resolved_at IS NULL AND stage < 5`
+
+// activeUserIndex는 사용자당 활성 에스컬레이션을 하나로 제한하는 유일 인덱스입니다 (23505 unique_violation).
+const activeUserIndex = "idx_escalations_active_user"
+
+// Create는 에스컬레이션을 저장합니다.
+// 사용자별 활성 에스컬레이션 유일 인덱스(47-escalation-active-user.sql)에 걸리면 ErrEscalationActive입니다.
+func (r *EscalationRepository) Create(ctx context.Context, e *service.EscalationEvent) error {
+	const q = `INSERT INTO escalations
+		(id, user_id, alert_type, stage, measurement_id, value, attempt, next_stage_at, created_at, last_escalation_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`
+	_, err := r.pool.Exec(ctx, q,
+		e.ID, e.UserID, e.AlertType, int(e.Stage), nullIfEmpty(e.MeasurementID), nullIfEmpty(e.Value),
+		e.Attempt, nullTime(e.NextStageAt), e.CreatedAt, e.LastEscalation,
+	)
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23505" && pgErr.ConstraintName == activeUserIndex {
+		return service.ErrEscalationActive
+	}
+	return err
+}
+
+// FindByID는 에스컬레이션을 조회합니다.
+func (r *EscalationRepository) FindByID(ctx context.Context, id string) (*service.EscalationEvent, error) {
+	e, err := scanEscalation(r.pool.QueryRow(ctx, `SELECT `+escalationColumns+` FROM escalations WHERE id = $1`, id))
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	return e, err
+}
+
+// FindActiveByUserID는 사용자의 활성 에스컬레이션을 생성 순으로 조회합니다.
+func (r *EscalationRepository) FindActiveByUserID(ctx context.Context, userID string) ([]*service.EscalationEvent, error) {
+	return r.query(ctx, `SELECT `+escalationColumns+` FROM escalations
+		WHERE user_id = $1 AND `+activeEscalation+` ORDER BY created_at ASC`, userID)
+}
+
+// FindDue는 다음 단계 시각이 된 활성 에스컬레이션을 오래된 순으로 조회합니다.
+func (r *EscalationRepository) FindDue(ctx context.Context, now time.Time, limit int) ([]*service.EscalationEvent, error) {
+	return r.query(ctx, `SELECT `+escalationColumns+` FROM escalations
+		WHERE `+activeEscalation+` AND next_stage_at <= $1 ORDER BY next_stage_at ASC LIMIT $2`, now, limit)
+}
+
+// Claim은 단계 실행 임대를 잡습니다. 조건부 UPDATE로 레플리카 간 하나만 성공합니다.
+func (r *EscalationRepository) Claim(ctx context.Context, id string, fromStage service.EscalationStage, worker string, now, leaseUntil time.Time) (bool, error) {
+	const q = `UPDATE escalations SET lease_owner = $3, lease_until = $5
+		WHERE id = $1 AND stage = $2 AND ` + activeEscalation + `
+		  AND (lease_owner IS NULL OR lease_owner = $3 OR lease_until <= $4)`
+	tag, err := r.pool.Exec(ctx, q, id, int(fromStage), worker, now, leaseUntil)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+// Advance는 단계 진행 결과를 저장하고 임대를 풉니다. 그사이 해제되었으면 저장하지 않습니다.
+func (r *EscalationRepository) Advance(ctx context.Context, e *service.EscalationEvent, fromStage service.EscalationStage) (bool, error) {
+	const q = `UPDATE escalations SET stage = $3, attempt = $4, next_stage_at = $5, last_escalation_at = $6,
+			lease_owner = NULL, lease_until = NULL
+		WHERE id = $1 AND stage = $2 AND ` + activeEscalation
+	tag, err := r.pool.Exec(ctx, q, e.ID, int(fromStage), int(e.Stage), e.Attempt, nullTime(e.NextStageAt), e.LastEscalation)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+// Resolve는 활성 에스컬레이션을 해제합니다.
+func (r *EscalationRepository) Resolve(ctx context.Context, id, resolvedBy string, at time.Time) (bool, error) {
+	const q = `UPDATE escalations SET stage = 5, resolved_at = $3, resolved_by = $2, next_stage_at = NULL
+		WHERE id = $1 AND ` + activeEscalation
+	tag, err := r.pool.Exec(ctx, q, id, resolvedBy, at)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+// SaveDelivery는 전달 기록을 저장합니다.
+func (r *EscalationRepository) SaveDelivery(ctx context.Context, d *service.EscalationDelivery) error {
+	const q = `INSERT INTO escalation_deliveries
+		(id, escalation_id, stage, recipient_id, channel, status, attempt, notification_id, error, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`
+	_, err := r.pool.Exec(ctx, q,
+		d.ID, d.EscalationID, int(d.Stage), d.RecipientID, d.Channel, d.Status, d.Attempt,
+		nullIfEmpty(d.NotificationID), nullIfEmpty(d.Error), d.CreatedAt,
+	)
+	return err
+}
+
+// FindDeliveries는 전달 기록을 시간순으로 조회합니다.
+func (r *EscalationRepository) FindDeliveries(ctx context.Context, escalationID string) ([]*service.EscalationDelivery, error) {
+	const q = `SELECT id, escalation_id, stage, recipient_id, channel, status, attempt,
+			COALESCE(notification_id,''), COALESCE(error,''), created_at
+		FROM escalation_deliveries WHERE escalation_id = $1 ORDER BY created_at ASC`
+	rows, err := r.pool.Query(ctx, q, escalationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []*service.EscalationDelivery
+	for rows.Next() {
+		var d service.EscalationDelivery
+		var stage int
+		if err := rows.Scan(&d.ID, &d.EscalationID, &stage, &d.RecipientID, &d.Channel, &d.Status, &d.Attempt,
+			&d.NotificationID, &d.Error, &d.CreatedAt); err != nil {
+			return nil, err
+		}
+		d.Stage = service.EscalationStage(stage)
+		list = append(list, &d)
+	}
+	return list, rows.Err()
+}
+
+// AcquireLeadership은 리더 임대를 잡거나 갱신합니다. 임대가 만료되었거나 같은 holder일 때만 갱신됩니다.
+func (r *EscalationRepository) AcquireLeadership(ctx context.Context, name, holder string, now, until time.Time) (bool, error) {
+	const q = `INSERT INTO leader_leases (name, holder, lease_until) VALUES ($1, $2, $4)
+		ON CONFLICT (name) DO UPDATE SET holder = EXCLUDED.holder, lease_until = EXCLUDED.lease_until
+		WHERE leader_leases.holder = EXCLUDED.holder OR leader_leases.lease_until <= $3`
+	tag, err := r.pool.Exec(ctx, q, name, holder, now, until)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+func (r *EscalationRepository) query(ctx context.Context, q string, args ...interface{}) ([]*service.EscalationEvent, error) {
+	rows, err := r.pool.Query(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []*service.EscalationEvent
+	for rows.Next() {
+		e, err := scanEscalation(rows)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, e)
+	}
+	return list, rows.Err()
+}
+
+func scanEscalation(row pgx.Row) (*service.EscalationEvent, error) {
+	var e service.EscalationEvent
+	var stage int
+	if err := row.Scan(
+		&e.ID, &e.UserID, &e.AlertType, &stage, &e.MeasurementID, &e.Value, &e.Attempt,
+		&e.NextStageAt, &e.CreatedAt, &e.LastEscalation, &e.ResolvedAt, &e.ResolvedBy,
+	); err != nil {
+		return nil, err
+	}
+	e.Stage = service.EscalationStage(stage)
+	return &e, nil
+}
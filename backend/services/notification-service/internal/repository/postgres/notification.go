@@ -103,25 +103,28 @@ func (r *NotificationRepository) Save(ctx context.Context, n *service.Notificati
 		priorityStr = "normal"
 	}
 
-	const q = `INSERT INTO notifications (id, user_id, type, channel, priority, title, body, data, is_read, created_at, read_at)
-		VALUES ($1, $2, $3::notification_type, $4::notification_channel, $5::notification_priority, $6, $7, $8, $9, $10, $11)`
+	const q = `INSERT INTO notifications (id, user_id, type, channel, priority, title, body, data, is_read, created_at, read_at, delivery_status, deliver_at)
+		VALUES ($1, $2, $3::notification_type, $4::notification_channel, $5::notification_priority, $6, $7, $8, $9, $10, $11, $12, $13)`
 	_, err := r.pool.Exec(ctx, q,
 		n.ID, n.UserID, typeStr, channelStr, priorityStr,
 		n.Title, nullIfEmpty(n.Body), dataJSON, n.IsRead, n.CreatedAt, nullTime(n.ReadAt),
+		nullIfEmptyOrDefault(n.Status, service.DeliveryPending), nullTime(n.DeliverAt),
 	)
 	return err
 }
 
 // FindByID는 ID로 알림을 조회합니다.
 func (r *NotificationRepository) FindByID(ctx context.Context, id string) (*service.Notification, error) {
-	const q = `SELECT id, user_id, type::text, channel::text, priority::text, title, COALESCE(body,''), COALESCE(data::text,'{}'), is_read, created_at, read_at
+	const q = `SELECT id, user_id, type::text, channel::text, priority::text, title, COALESCE(body,''), COALESCE(data::text,'{}'), is_read, created_at, read_at,
+			delivery_status, COALESCE(delivered_channel::text,''), deliver_at
 		FROM notifications WHERE id = $1`
 	var n service.Notification
 	var typeStr, channelStr, priorityStr string
-	var dataJSON string
+	var dataJSON, deliveredStr string
 	err := r.pool.QueryRow(ctx, q, id).Scan(
 		&n.ID, &n.UserID, &typeStr, &channelStr, &priorityStr,
 		&n.Title, &n.Body, &dataJSON, &n.IsRead, &n.CreatedAt, &n.ReadAt,
+		&n.Status, &deliveredStr, &n.DeliverAt,
 	)
 	if err != nil {
 		if err == pgx.ErrNoRows {
@@ -137,6 +140,7 @@ func (r *NotificationRepository) FindByID(ctx context.Context, id string) (*serv
 	if n.Channel == 0 {
 		n.Channel = service.ChannelInApp
 	}
+	n.DeliveredVia = dbToChannel[deliveredStr]
 	n.Priority = dbToPriority[priorityStr]
 	if n.Priority == 0 {
 		n.Priority = service.PriorityNormal
@@ -183,7 +187,8 @@ func (r *NotificationRepository) FindByUserID(ctx context.Context, userID string
 
 	// select with pagination
 	selArgs := []interface{}{userID}
-	selQuery := `SELECT id, user_id, type::text, channel::text, priority::text, title, COALESCE(body,''), COALESCE(data::text,'{}'), is_read, created_at, read_at
+	selQuery := `SELECT id, user_id, type::text, channel::text, priority::text, title, COALESCE(body,''), COALESCE(data::text,'{}'), is_read, created_at, read_at,
+			delivery_status, COALESCE(delivered_channel::text,''), deliver_at
 		FROM notifications WHERE user_id = $1`
 	if typeFilter != service.TypeUnknown {
 		typeStr, _ := typeToDB[typeFilter]
@@ -208,10 +213,11 @@ func (r *NotificationRepository) FindByUserID(ctx context.Context, userID string
 	var list []*service.Notification
 	for rows.Next() {
 		var n service.Notification
-		var typeStr, channelStr, priorityStr, dataJSON string
+		var typeStr, channelStr, priorityStr, dataJSON, deliveredStr string
 		if err := rows.Scan(
 			&n.ID, &n.UserID, &typeStr, &channelStr, &priorityStr,
 			&n.Title, &n.Body, &dataJSON, &n.IsRead, &n.CreatedAt, &n.ReadAt,
+			&n.Status, &deliveredStr, &n.DeliverAt,
 		); err != nil {
 			return nil, 0, err
 		}
@@ -223,6 +229,7 @@ func (r *NotificationRepository) FindByUserID(ctx context.Context, userID string
 		if n.Channel == 0 {
 			n.Channel = service.ChannelInApp
 		}
+		n.DeliveredVia = dbToChannel[deliveredStr]
 		n.Priority = dbToPriority[priorityStr]
 		if n.Priority == 0 {
 			n.Priority = service.PriorityNormal
@@ -285,6 +292,96 @@ func (r *NotificationRepository) GetUnreadCount(ctx context.Context, userID stri
 	return total, byType, rows.Err()
 }
 
+// UpdateDelivery는 전달 상태를 저장합니다.
+func (r *NotificationRepository) UpdateDelivery(ctx context.Context, n *service.Notification) error {
+	const q = `UPDATE notifications SET delivery_status = $2, delivered_channel = $3::notification_channel, deliver_at = $4 WHERE id = $1`
+	_, err := r.pool.Exec(ctx, q, n.ID, n.Status, nullIfEmpty(channelToDBOrEmpty(n.DeliveredVia)), nullTime(n.DeliverAt))
+	return err
+}
+
+// FindDeferred는 발송 시각이 된 미뤄진 알림을 오래된 순으로 조회합니다.
+func (r *NotificationRepository) FindDeferred(ctx context.Context, now time.Time, limit int) ([]*service.Notification, error) {
+	const q = `SELECT id FROM notifications WHERE delivery_status = 'deferred' AND deliver_at <= $1 ORDER BY deliver_at ASC LIMIT $2`
+	rows, err := r.pool.Query(ctx, q, now, limit)
+	if err != nil {
+		return nil, err
+	}
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	list := make([]*service.Notification, 0, len(ids))
+	for _, id := range ids {
+		n, err := r.FindByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if n != nil {
+			list = append(list, n)
+		}
+	}
+	return list, nil
+}
+
+// ClaimDeferred는 조건부 UPDATE로 미뤄진 알림의 발송 권한을 잡습니다. 레플리카 간 하나만 성공합니다.
+func (r *NotificationRepository) ClaimDeferred(ctx context.Context, id string, due, until time.Time) (bool, error) {
+	const q = `UPDATE notifications SET deliver_at = $3 WHERE id = $1 AND delivery_status = 'deferred' AND deliver_at = $2`
+	tag, err := r.pool.Exec(ctx, q, id, due, until)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+// SaveAttempt는 채널 전송 시도를 저장합니다.
+func (r *NotificationRepository) SaveAttempt(ctx context.Context, a *service.DeliveryAttempt) error {
+	const q = `INSERT INTO notification_delivery_attempts (id, notification_id, channel, status, error, attempted_at)
+		VALUES ($1, $2, $3::notification_channel, $4, $5, $6)`
+	_, err := r.pool.Exec(ctx, q, a.ID, a.NotificationID, channelToDBOrEmpty(a.Channel), a.Status, nullIfEmpty(a.Error), a.CreatedAt)
+	return err
+}
+
+// FindAttempts는 알림의 전송 시도를 시간순으로 조회합니다.
+func (r *NotificationRepository) FindAttempts(ctx context.Context, notificationID string) ([]*service.DeliveryAttempt, error) {
+	const q = `SELECT id, notification_id, channel::text, status, COALESCE(error,''), attempted_at
+		FROM notification_delivery_attempts WHERE notification_id = $1 ORDER BY attempted_at ASC`
+	rows, err := r.pool.Query(ctx, q, notificationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []*service.DeliveryAttempt
+	for rows.Next() {
+		var a service.DeliveryAttempt
+		var channelStr string
+		if err := rows.Scan(&a.ID, &a.NotificationID, &channelStr, &a.Status, &a.Error, &a.CreatedAt); err != nil {
+			return nil, err
+		}
+		a.Channel = dbToChannel[channelStr]
+		list = append(list, &a)
+	}
+	return list, rows.Err()
+}
+
+// channelToDBOrEmpty는 전달되지 않은 채널(ChannelUnknown)을 빈 문자열로 변환합니다.
+func channelToDBOrEmpty(c service.NotificationChannel) string {
+	if c == service.ChannelUnknown {
+		return ""
+	}
+	return channelToDB[c]
+}
+
 func dbToTypeStr(db string) string {
 	switch db {
 	case "measurement":
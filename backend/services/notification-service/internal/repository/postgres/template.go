@@ -0,0 +1,115 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/manpasik/backend/services/notification-service/internal/service"
+)
+
+// ============================================================================
+// TemplateRepository
+// ============================================================================
+
+// TemplateRepository는 PostgreSQL 기반 TemplateRepository 구현입니다.
+// 테이블: notification_templates (44-notification-templates.sql)
+type TemplateRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewTemplateRepository는 TemplateRepository를 생성합니다.
+func NewTemplateRepository(pool *pgxpool.Pool) *TemplateRepository {
+	return &TemplateRepository{pool: pool}
+}
+
+const templateColumns = `template_key, language, version, title, body, type, priority, channel,
+	placeholders, deleted, updated_by, created_at`
+
+// FindLatest는 최신 버전을 반환합니다 (삭제 표시 포함).
+func (r *TemplateRepository) FindLatest(ctx context.Context, key, language string) (*service.NotificationTemplate, error) {
+	t, err := scanTemplate(r.pool.QueryRow(ctx, `SELECT `+templateColumns+` FROM notification_templates
+		WHERE template_key = $1 AND language = $2 ORDER BY version DESC LIMIT 1`, key, language))
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	return t, err
+}
+
+// FindVersion은 특정 버전을 반환합니다.
+func (r *TemplateRepository) FindVersion(ctx context.Context, key, language string, version int) (*service.NotificationTemplate, error) {
+	t, err := scanTemplate(r.pool.QueryRow(ctx, `SELECT `+templateColumns+` FROM notification_templates
+		WHERE template_key = $1 AND language = $2 AND version = $3`, key, language, version))
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	return t, err
+}
+
+// ListVersions는 모든 버전을 최신순으로 반환합니다.
+func (r *TemplateRepository) ListVersions(ctx context.Context, key, language string) ([]*service.NotificationTemplate, error) {
+	return r.query(ctx, `SELECT `+templateColumns+` FROM notification_templates
+		WHERE template_key = $1 AND language = $2 ORDER BY version DESC`, key, language)
+}
+
+// ListLatest는 삭제되지 않은 최신 버전 목록을 key·language 순으로 반환합니다.
+func (r *TemplateRepository) ListLatest(ctx context.Context, key, language string) ([]*service.NotificationTemplate, error) {
+	return r.query(ctx, `SELECT `+templateColumns+` FROM (
+			SELECT DISTINCT ON (template_key, language) * FROM notification_templates
+			WHERE ($1 = '' OR template_key = $1) AND ($2 = '' OR language = $2)
+			ORDER BY template_key, language, version DESC
+		) latest WHERE NOT deleted ORDER BY template_key, language`, key, language)
+}
+
+// Insert는 새 버전을 저장합니다. 같은 버전이 이미 있으면(동시 수정) false를 반환합니다.
+func (r *TemplateRepository) Insert(ctx context.Context, t *service.NotificationTemplate) (bool, error) {
+	const q = `INSERT INTO notification_templates (` + templateColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+		ON CONFLICT (template_key, language, version) DO NOTHING`
+	placeholders := t.Placeholders
+	if placeholders == nil {
+		placeholders = []string{}
+	}
+	tag, err := r.pool.Exec(ctx, q,
+		t.Key, t.Language, t.Version, t.Title, t.Body,
+		service.NotificationTypeToString(t.Type), service.NotificationPriorityToString(t.Priority), service.NotificationChannelToString(t.Channel),
+		placeholders, t.Deleted, t.UpdatedBy, t.CreatedAt,
+	)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+func (r *TemplateRepository) query(ctx context.Context, q string, args ...interface{}) ([]*service.NotificationTemplate, error) {
+	rows, err := r.pool.Query(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []*service.NotificationTemplate
+	for rows.Next() {
+		t, err := scanTemplate(rows)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, t)
+	}
+	return list, rows.Err()
+}
+
+func scanTemplate(row pgx.Row) (*service.NotificationTemplate, error) {
+	var t service.NotificationTemplate
+	var nType, priority, channel string
+	if err := row.Scan(
+		&t.Key, &t.Language, &t.Version, &t.Title, &t.Body, &nType, &priority, &channel,
+		&t.Placeholders, &t.Deleted, &t.UpdatedBy, &t.CreatedAt,
+	); err != nil {
+		return nil, err
+	}
+	t.Type = service.ParseNotificationType(nType)
+	t.Priority = service.ParseNotificationPriority(priority)
+	t.Channel = service.ParseNotificationChannel(channel)
+	return &t, nil
+}
@@ -0,0 +1,185 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/manpasik/backend/services/notification-service/internal/repository/memory"
+	"github.com/manpasik/backend/services/notification-service/internal/service"
+	"go.uber.org/zap"
+)
+
+type fakeProfiles map[string]string // userID → 시간대
+
+func (p fakeProfiles) GetLanguage(_ context.Context, _ string) (string, error) { return "", nil }
+
+func (p fakeProfiles) GetTimezone(_ context.Context, userID string) (string, error) {
+	return p[userID], nil
+}
+
+type fakeEmail struct {
+	sent []string
+	err  error
+}
+
+func (e *fakeEmail) SendEmail(_ context.Context, userID, _, _ string) error {
+	if e.err != nil {
+		return e.err
+	}
+	e.sent = append(e.sent, userID)
+	return nil
+}
+
+type deliveryFixture struct {
+	svc   *service.NotificationService
+	repo  *memory.NotificationRepository
+	push  *fakePush
+	email *fakeEmail
+	clock *fakeClock
+}
+
+// newDeliveryFixture는 서울 시간대, 방해 금지 22:00~07:00 사용자(user-1)를 준비합니다.
+// 시계는 2026-03-02 23:30 KST입니다.
+func newDeliveryFixture(t *testing.T) *deliveryFixture {
+	t.Helper()
+	f := &deliveryFixture{
+		repo:  memory.NewNotificationRepository(),
+		push:  &fakePush{failures: make(map[string]int)},
+		email: &fakeEmail{},
+		clock: &fakeClock{now: time.Date(2026, 3, 2, 14, 30, 0, 0, time.UTC)},
+	}
+	prefRepo := memory.NewPreferencesRepository()
+	f.svc = service.NewNotificationService(zap.NewNop(), f.repo, prefRepo)
+	f.svc.SetPushSender(f.push)
+	f.svc.SetEmailSender(f.email)
+	f.svc.SetClock(f.clock.Now)
+	f.svc.SetUserProfileClient(fakeProfiles{"user-1": "Asia/Seoul"})
+	_, err := f.svc.UpdatePreferences(context.Background(), &service.NotificationPreferences{
+		UserID: "user-1", PushEnabled: true, EmailEnabled: true, InAppEnabled: true,
+		QuietHoursStart: "22:00", QuietHoursEnd: "07:00", Language: "ko",
+	})
+	if err != nil {
+		t.Fatalf("설정 저장 실패: %v", err)
+	}
+	return f
+}
+
+func TestSendNotification_QuietHoursDefers(t *testing.T) {
+	f := newDeliveryFixture(t)
+	ctx := context.Background()
+
+	noti, err := f.svc.SendNotification(ctx, "user-1", service.TypeCommunity, service.ChannelPush, service.PriorityNormal, "새 댓글", "내용", "")
+	if err != nil {
+		t.Fatalf("발송 실패: %v", err)
+	}
+	want := time.Date(2026, 3, 2, 22, 0, 0, 0, time.UTC) // 다음 날 07:00 KST
+	if noti.Status != service.DeliveryDeferred || noti.DeliverAt == nil || !noti.DeliverAt.Equal(want) {
+		t.Fatalf("연기 결과 status=%s deliver_at=%v, want deferred %v", noti.Status, noti.DeliverAt, want)
+	}
+	if len(f.push.sent) != 0 {
+		t.Fatalf("방해 금지 시간에 푸시가 발송됨: %v", f.push.sent)
+	}
+
+	// 종료 전에는 발송하지 않음
+	f.clock.Advance(7 * time.Hour)
+	if n, _ := f.svc.DeliverDeferred(ctx); n != 0 {
+		t.Fatalf("종료 전 발송 수 = %d, want 0", n)
+	}
+
+	f.clock.Advance(30 * time.Minute)
+	if n, err := f.svc.DeliverDeferred(ctx); err != nil || n != 1 {
+		t.Fatalf("종료 후 발송 = %d, %v", n, err)
+	}
+	if n, _ := f.svc.DeliverDeferred(ctx); n != 0 {
+		t.Fatalf("같은 알림이 다시 발송됨: %d", n)
+	}
+	if len(f.push.sent) != 1 {
+		t.Fatalf("푸시 발송 수 = %d, want 1", len(f.push.sent))
+	}
+	stored, _ := f.repo.FindByID(ctx, noti.ID)
+	if stored.Status != service.DeliveryDelivered || stored.DeliveredVia != service.ChannelPush || stored.DeliverAt != nil {
+		t.Fatalf("발송 후 상태 = %+v", stored)
+	}
+}
+
+func TestSendNotification_QuietHoursOverrides(t *testing.T) {
+	f := newDeliveryFixture(t)
+	ctx := context.Background()
+
+	urgent, _ := f.svc.SendNotification(ctx, "user-1", service.TypeHealthAlert, service.ChannelPush, service.PriorityUrgent, "건강 이상 감지", "혈당 420", "")
+	if urgent.Status != service.DeliveryDelivered || len(f.push.sent) != 1 {
+		t.Fatalf("긴급 알림이 바로 발송되지 않음: status=%s push=%d", urgent.Status, len(f.push.sent))
+	}
+
+	inApp, _ := f.svc.SendNotification(ctx, "user-1", service.TypeSystem, service.ChannelInApp, service.PriorityNormal, "공지", "내용", "")
+	if inApp.Status != service.DeliveryDelivered {
+		t.Fatalf("인앱 알림 status = %s, want delivered", inApp.Status)
+	}
+
+	// 시간대가 없는 사용자는 UTC 기준 (14:30 UTC는 방해 금지 시간 아님)
+	f.svc.UpdatePreferences(ctx, &service.NotificationPreferences{
+		UserID: "user-utc", PushEnabled: true, InAppEnabled: true, QuietHoursStart: "22:00", QuietHoursEnd: "07:00",
+	})
+	utc, _ := f.svc.SendNotification(ctx, "user-utc", service.TypeCommunity, service.ChannelPush, service.PriorityNormal, "새 댓글", "내용", "")
+	if utc.Status != service.DeliveryDelivered {
+		t.Fatalf("UTC 사용자 status = %s, want delivered", utc.Status)
+	}
+}
+
+func TestNotifySafetyCheck_QuietHoursNotDeferred(t *testing.T) {
+	f := newDeliveryFixture(t) // 23:30 KST, 방해 금지 시간
+	ctx := context.Background()
+
+	if err := f.svc.NotifySafetyCheck(ctx, "user-1"); err != nil {
+		t.Fatalf("안전 확인 알림 실패: %v", err)
+	}
+	if len(f.push.sent) != 1 {
+		t.Fatalf("방해 금지 시간에 안전 확인 푸시가 발송되지 않음: %v", f.push.sent)
+	}
+	notis, _, _, err := f.svc.ListNotifications(ctx, "user-1", service.TypeUnknown, false, 10, 0)
+	if err != nil || len(notis) != 1 {
+		t.Fatalf("알림 목록 = %d, %v", len(notis), err)
+	}
+	if notis[0].Status != service.DeliveryDelivered || notis[0].Priority != service.PriorityUrgent {
+		t.Fatalf("안전 확인 알림 status=%s priority=%v, want delivered urgent", notis[0].Status, notis[0].Priority)
+	}
+}
+
+func TestSendNotification_ChannelFallback(t *testing.T) {
+	f := newDeliveryFixture(t)
+	ctx := context.Background()
+	f.clock.Advance(-6 * time.Hour) // 17:30 KST
+	f.push.failures["user-1"] = 1
+
+	// push 실패 → sms는 꺼져 있어 건너뜀 → email 성공
+	noti, err := f.svc.SendNotification(ctx, "user-1", service.TypeAppointment, service.ChannelPush, service.PriorityHigh, "진료 예약", "내일 10시", "")
+	if err != nil {
+		t.Fatalf("발송 실패: %v", err)
+	}
+	if noti.Channel != service.ChannelPush || noti.DeliveredVia != service.ChannelEmail || noti.Status != service.DeliveryDelivered {
+		t.Fatalf("폴백 결과 channel=%d via=%d status=%s", noti.Channel, noti.DeliveredVia, noti.Status)
+	}
+	attempts, _ := f.repo.FindAttempts(ctx, noti.ID)
+	if len(attempts) != 2 ||
+		attempts[0].Channel != service.ChannelPush || attempts[0].Status != service.DeliveryFailed || attempts[0].Error == "" ||
+		attempts[1].Channel != service.ChannelEmail || attempts[1].Status != service.DeliveryDelivered {
+		t.Fatalf("시도 기록 = %+v", attempts)
+	}
+
+	// 외부 채널이 모두 실패하면 인앱으로 전달
+	f.push.failures["user-1"] = 1
+	f.email.err = errors.New("SMTP down")
+	noti, _ = f.svc.SendNotification(ctx, "user-1", service.TypeAppointment, service.ChannelPush, service.PriorityHigh, "진료 예약", "내일 10시", "")
+	if noti.DeliveredVia != service.ChannelInApp || len(noti.Attempts) != 3 {
+		t.Fatalf("인앱 폴백 via=%d attempts=%d", noti.DeliveredVia, len(noti.Attempts))
+	}
+
+	// 전달 확인이 필요한 발송은 인앱으로 폴백하지 않고 실패를 반환
+	f.push.failures["user-1"] = 1
+	noti, err = f.svc.SendNotificationConfirmed(ctx, "user-1", service.TypeHealthAlert, service.ChannelPush, service.PriorityUrgent, "긴급", "내용", "")
+	if err == nil || noti.Status != service.DeliveryFailed {
+		t.Fatalf("확인 발송 결과 status=%s err=%v, 실패 기대", noti.Status, err)
+	}
+}
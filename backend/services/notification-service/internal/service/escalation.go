@@ -3,9 +3,16 @@ package service
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"os"
+	"strings"
 	"time"
 
+	"github.com/google/uuid"
+	"github.com/manpasik/backend/shared/clients"
+	apperrors "github.com/manpasik/backend/shared/errors"
 	"go.uber.org/zap"
 )
 
@@ -27,80 +34,255 @@ const (
 	StageCancelled
 )
 
+// 전달 채널
+const (
+	DeliveryChannelInApp     = "in_app"
+	DeliveryChannelPush      = "push"
+	DeliveryChannelVoiceCall = "voice_call"
+	DeliveryChannelEmergency = "emergency_119"
+)
+
+// 해제 주체
+const (
+	ResolvedByUser     = "user"
+	ResolvedByGuardian = "guardian"
+	ResolvedByOperator = "operator"
+)
+
+// escalationLeaderLease는 에스컬레이션 엔진 리더 임대 이름입니다.
+const escalationLeaderLease = "notification.escalation"
+
 // EscalationEvent는 에스컬레이션 이벤트입니다.
 type EscalationEvent struct {
 	ID             string
 	UserID         string
-	AlertType      string // "health_critical", "fall_detected", "no_response"
-	Stage          EscalationStage
+	AlertType      string          // "health_critical", "fall_detected", "no_response"
+	Stage          EscalationStage // 마지막으로 실행을 마친 단계
 	MeasurementID  string
 	Value          string
+	Attempt        int        // 다음 단계의 실패한 시도 횟수
+	NextStageAt    *time.Time // 다음 단계 예정 시각 (nil: 진행할 단계 없음)
 	CreatedAt      time.Time
 	LastEscalation time.Time
 	ResolvedAt     *time.Time
 	ResolvedBy     string // "user", "guardian", "operator", ""
+
+	Deliveries []*EscalationDelivery // 조회 시 채움
+}
+
+// Active는 아직 해제되지 않은 에스컬레이션인지 반환합니다.
+func (e *EscalationEvent) Active() bool {
+	return e.ResolvedAt == nil && e.Stage < StageResolved
+}
+
+// EscalationDelivery는 단계별 수신자 전달 기록입니다.
+type EscalationDelivery struct {
+	ID             string
+	EscalationID   string
+	Stage          EscalationStage
+	RecipientID    string // 보호자 등 수신자 user ID (119 신고는 대상 사용자)
+	Channel        string
+	Status         string
+	Attempt        int
+	NotificationID string
+	Error          string
+	CreatedAt      time.Time
+}
+
+// EscalationRepository는 에스컬레이션 저장소 인터페이스입니다.
+// Claim·Advance·Resolve는 레플리카 간에 원자적이어야 합니다.
+// ErrEscalationActive는 사용자에게 이미 활성 에스컬레이션이 있어 새로 만들 수 없음을 뜻합니다.
+var ErrEscalationActive = errors.New("진행 중인 에스컬레이션이 있습니다")
+
+type EscalationRepository interface {
+	// Create는 이벤트를 저장합니다. 사용자당 활성 이벤트는 하나뿐이며,
+	// 이미 있으면 ErrEscalationActive를 반환합니다.
+	Create(ctx context.Context, e *EscalationEvent) error
+	// FindByID는 이벤트를 반환하고, 없으면 (nil, nil)을 반환합니다.
+	FindByID(ctx context.Context, id string) (*EscalationEvent, error)
+	FindActiveByUserID(ctx context.Context, userID string) ([]*EscalationEvent, error)
+	// FindDue는 해제되지 않았고 NextStageAt이 now 이전인 이벤트를 오래된 순으로 반환합니다.
+	FindDue(ctx context.Context, now time.Time, limit int) ([]*EscalationEvent, error)
+	// Claim은 fromStage 다음 단계의 실행을 worker에게 leaseUntil까지 맡깁니다.
+	// 해제되었거나, 단계가 fromStage가 아니거나, 다른 worker의 임대가 남아 있으면 false입니다.
+	Claim(ctx context.Context, id string, fromStage EscalationStage, worker string, now, leaseUntil time.Time) (bool, error)
+	// Advance는 단계·시도 횟수·다음 예정 시각을 저장하고 임대를 풉니다.
+	// 해제되었거나 단계가 fromStage가 아니면 저장하지 않고 false를 반환합니다.
+	Advance(ctx context.Context, e *EscalationEvent, fromStage EscalationStage) (bool, error)
+	// Resolve는 활성 이벤트를 해제합니다. 없거나 이미 해제되었으면 false입니다.
+	Resolve(ctx context.Context, id, resolvedBy string, at time.Time) (bool, error)
+	SaveDelivery(ctx context.Context, d *EscalationDelivery) error
+	// FindDeliveries는 이벤트의 전달 기록을 시간순으로 반환합니다.
+	FindDeliveries(ctx context.Context, escalationID string) ([]*EscalationDelivery, error)
+	// AcquireLeadership은 holder가 until까지 name 임대를 잡거나 갱신합니다.
+	// 다른 holder의 임대가 남아 있으면 false입니다.
+	AcquireLeadership(ctx context.Context, name, holder string, now, until time.Time) (bool, error)
+}
+
+// VoiceCaller는 AI 음성 확인 전화 연동 인터페이스입니다 (TTS/STT 기반 통화 서비스).
+// 통화가 연결되어 안내가 전달되면 nil을 반환합니다.
+type VoiceCaller interface {
+	CallForConfirmation(ctx context.Context, e *EscalationEvent) error
+}
+
+// EmergencyReporter는 119 신고 연동 인터페이스입니다.
+// 신고가 접수되면 nil을 반환합니다.
+type EmergencyReporter interface {
+	ReportEmergency(ctx context.Context, e *EscalationEvent) error
+}
+
+// OperatorAlerter는 자동 처리로 끝내지 못한 에스컬레이션을 운영자에게 알리는 연동입니다 (온콜 호출 등).
+type OperatorAlerter interface {
+	AlertOperator(ctx context.Context, e *EscalationEvent, reason string) error
 }
 
 // EscalationConfig는 에스컬레이션 타이밍 설정입니다.
 type EscalationConfig struct {
-	Stage2Delay time.Duration // Stage1→Stage2 대기 시간 (기본 3분)
-	Stage3Delay time.Duration // Stage2→Stage3 대기 시간 (기본 3분)
-	Stage4Delay time.Duration // Stage3→Stage4 대기 시간 (기본 4분)
+	Stage2Delay     time.Duration // Stage1→Stage2 대기 시간 (기본 3분)
+	Stage3Delay     time.Duration // Stage2→Stage3 대기 시간 (기본 3분)
+	Stage4Delay     time.Duration // Stage3→Stage4 대기 시간 (기본 4분)
+	RetryBackoff    time.Duration // 단계 실패 시 재시도 간격, 시도마다 배수로 증가 (기본 15초)
+	MaxRetryBackoff time.Duration // 재시도 간격 상한 (기본 2분)
+	MaxAttempts     int           // 단계별 최대 시도 횟수, 넘으면 다음 단계로 즉시 진행 (기본 3). 119 신고는 운영자 알림 후 계속 재시도
+	PollInterval    time.Duration // 예정 단계 확인 주기 (기본 5초)
+	LeaseDuration   time.Duration // 리더·단계 실행 임대 시간 (기본 30초)
+	StageTimeout    time.Duration // 단계 실행 제한 시간, 임대 시간보다 짧아야 함 (기본 20초)
 }
 
 // DefaultEscalationConfig는 기본 에스컬레이션 설정입니다.
 var DefaultEscalationConfig = EscalationConfig{
-	Stage2Delay: 3 * time.Minute,
-	Stage3Delay: 3 * time.Minute,
-	Stage4Delay: 4 * time.Minute,
+	Stage2Delay:     3 * time.Minute,
+	Stage3Delay:     3 * time.Minute,
+	Stage4Delay:     4 * time.Minute,
+	RetryBackoff:    15 * time.Second,
+	MaxRetryBackoff: 2 * time.Minute,
+	MaxAttempts:     3,
+	PollInterval:    5 * time.Second,
+	LeaseDuration:   30 * time.Second,
+	StageTimeout:    20 * time.Second,
 }
 
+// escalationBatchSize는 한 번에 처리하는 예정 이벤트 수입니다.
+const escalationBatchSize = 100
+
 // EscalationService는 에스컬레이션 상태 머신을 관리합니다.
+// 상태와 다음 단계 시각은 저장소에 보관하며, 리더 레플리카의 Run 루프가 예정된 단계를 실행합니다.
 type EscalationService struct {
 	log       *zap.Logger
 	notiSvc   *NotificationService
+	repo      EscalationRepository
+	guardians clients.GuardianClient // optional: nil이면 보호자 단계를 건너뜀
+	voice     VoiceCaller            // optional: nil이면 음성 확인 단계를 기록만 함
+	emergency EmergencyReporter      // optional: nil이면 119 신고 단계를 기록만 함
+	operator  OperatorAlerter        // optional: nil이면 운영자 알림을 오류 로그로만 남김
 	config    EscalationConfig
-	active    map[string]*EscalationEvent // eventID → event
-	guardians map[string][]string         // userID → guardian userIDs
+	worker    string
+	now       func() time.Time
 }
 
 // NewEscalationService는 EscalationService를 생성합니다.
-func NewEscalationService(log *zap.Logger, notiSvc *NotificationService) *EscalationService {
+func NewEscalationService(log *zap.Logger, notiSvc *NotificationService, repo EscalationRepository) *EscalationService {
+	host, _ := os.Hostname()
 	return &EscalationService{
-		log:       log,
-		notiSvc:   notiSvc,
-		config:    DefaultEscalationConfig,
-		active:    make(map[string]*EscalationEvent),
-		guardians: make(map[string][]string),
+		log:     log,
+		notiSvc: notiSvc,
+		repo:    repo,
+		config:  DefaultEscalationConfig,
+		worker:  fmt.Sprintf("%s-%d", host, os.Getpid()),
+		now:     time.Now,
 	}
 }
 
-// SetConfig는 에스컬레이션 타이밍 설정을 변경합니다.
+// SetConfig는 에스컬레이션 타이밍 설정을 변경합니다. 0인 항목은 기본값을 사용합니다.
 func (es *EscalationService) SetConfig(cfg EscalationConfig) {
+	def := DefaultEscalationConfig
+	if cfg.RetryBackoff <= 0 {
+		cfg.RetryBackoff = def.RetryBackoff
+	}
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = def.MaxAttempts
+	}
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = def.PollInterval
+	}
+	if cfg.MaxRetryBackoff <= 0 {
+		cfg.MaxRetryBackoff = def.MaxRetryBackoff
+	}
+	if cfg.LeaseDuration <= 0 {
+		cfg.LeaseDuration = def.LeaseDuration
+	}
+	// 단계가 임대보다 오래 걸리면 다른 레플리카가 같은 단계를 다시 임대할 수 있음
+	if cfg.StageTimeout <= 0 || cfg.StageTimeout >= cfg.LeaseDuration {
+		cfg.StageTimeout = cfg.LeaseDuration * 2 / 3
+	}
 	es.config = cfg
 }
 
+// SetGuardianClient는 보호자 조회 클라이언트(family-service)를 설정합니다 (optional).
+func (es *EscalationService) SetGuardianClient(c clients.GuardianClient) {
+	es.guardians = c
+}
+
+// SetVoiceCaller는 AI 음성 확인 전화 연동을 설정합니다 (optional).
+func (es *EscalationService) SetVoiceCaller(v VoiceCaller) {
+	es.voice = v
+}
+
+// SetEmergencyReporter는 119 신고 연동을 설정합니다 (optional).
+func (es *EscalationService) SetEmergencyReporter(r EmergencyReporter) {
+	es.emergency = r
+}
+
+// SetOperatorAlerter는 운영자 알림 연동을 설정합니다 (optional).
+func (es *EscalationService) SetOperatorAlerter(a OperatorAlerter) {
+	es.operator = a
+}
+
+// SetWorkerID는 임대에 기록할 레플리카 식별자를 설정합니다 (기본: 호스트명-pid).
+func (es *EscalationService) SetWorkerID(id string) {
+	if id != "" {
+		es.worker = id
+	}
+}
+
+// SetClock은 시간 공급원을 바꿉니다 (테스트용).
+func (es *EscalationService) SetClock(now func() time.Time) {
+	es.now = now
+}
+
 // TriggerEscalation은 건강 이상 감지 시 에스컬레이션 체인을 시작합니다.
+// 사용자의 에스컬레이션이 이미 진행 중이면 새로 만들지 않고 그것을 반환합니다.
+// 같은 알림을 받은 다른 레플리카가 먼저 만든 경우도 저장소의 유일성 제약으로 걸러
+// 보호자 호출·119 신고가 두 번 진행되지 않습니다.
 func (es *EscalationService) TriggerEscalation(ctx context.Context, userID, alertType, measurementID, value string) (*EscalationEvent, error) {
 	if userID == "" || alertType == "" {
-		return nil, fmt.Errorf("userID와 alertType은 필수입니다")
+		return nil, apperrors.New(apperrors.ErrInvalidInput, "userID와 alertType은 필수입니다")
 	}
 
+	if active, err := es.findActive(ctx, userID); err != nil || active != nil {
+		return active, err
+	}
+
+	now := es.now()
 	event := &EscalationEvent{
-		ID:             fmt.Sprintf("esc-%d", time.Now().UnixNano()),
+		ID:             uuid.New().String(),
 		UserID:         userID,
 		AlertType:      alertType,
-		Stage:          StageInAppAlert,
+		Stage:          StageNone,
 		MeasurementID:  measurementID,
 		Value:          value,
-		CreatedAt:      time.Now(),
-		LastEscalation: time.Now(),
+		NextStageAt:    &now,
+		CreatedAt:      now,
+		LastEscalation: now,
+	}
+	if err := es.repo.Create(ctx, event); err != nil {
+		if errors.Is(err, ErrEscalationActive) {
+			if active, findErr := es.findActive(ctx, userID); findErr != nil || active != nil {
+				return active, findErr
+			}
+		}
+		return nil, fmt.Errorf("에스컬레이션 저장 실패: %w", err)
 	}
-
-	es.active[event.ID] = event
-
-	// Stage 1: 인앱 알림 즉시 발송
-	es.executeStage1(ctx, event)
 
 	es.log.Info("에스컬레이션 시작",
 		zap.String("event_id", event.ID),
@@ -109,119 +291,399 @@ func (es *EscalationService) TriggerEscalation(ctx context.Context, userID, aler
 		zap.String("value", value),
 	)
 
-	// 비동기 에스컬레이션 체인 시작
-	go es.runEscalationChain(event)
+	// Stage 1: 인앱 알림 즉시 발송. 실패하거나 중단되면 Run 루프가 이어서 실행합니다.
+	if err := es.process(ctx, event, now); err != nil {
+		es.log.Warn("Stage1 즉시 실행 실패, 루프에서 재시도", zap.String("event_id", event.ID), zap.Error(err))
+	}
 
-	return event, nil
+	return es.load(ctx, event.ID)
+}
+
+// findActive는 사용자의 활성 에스컬레이션을 반환합니다. 없으면 (nil, nil)입니다.
+func (es *EscalationService) findActive(ctx context.Context, userID string) (*EscalationEvent, error) {
+	active, err := es.repo.FindActiveByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("에스컬레이션 조회 실패: %w", err)
+	}
+	if len(active) == 0 {
+		return nil, nil
+	}
+	return active[0], nil
 }
 
 // AcknowledgeEscalation은 사용자/보호자가 에스컬레이션을 확인하여 중단합니다.
-func (es *EscalationService) AcknowledgeEscalation(ctx context.Context, eventID, resolvedBy string) error {
-	event, exists := es.active[eventID]
-	if !exists {
-		return fmt.Errorf("에스컬레이션 이벤트를 찾을 수 없습니다: %s", eventID)
+// 이미 해제된 에스컬레이션을 다시 확인하면 현재 상태를 그대로 반환합니다.
+func (es *EscalationService) AcknowledgeEscalation(ctx context.Context, eventID, resolvedBy string) (*EscalationEvent, error) {
+	if eventID == "" {
+		return nil, apperrors.New(apperrors.ErrInvalidInput, "escalation_id는 필수입니다")
+	}
+	if resolvedBy == "" {
+		resolvedBy = ResolvedByUser
+	}
+	if resolvedBy != ResolvedByUser && resolvedBy != ResolvedByGuardian && resolvedBy != ResolvedByOperator {
+		return nil, apperrors.New(apperrors.ErrInvalidInput, "resolved_by는 user, guardian, operator 중 하나여야 합니다")
 	}
 
-	now := time.Now()
-	event.ResolvedAt = &now
-	event.ResolvedBy = resolvedBy
-	event.Stage = StageResolved
+	resolved, err := es.repo.Resolve(ctx, eventID, resolvedBy, es.now())
+	if err != nil {
+		return nil, fmt.Errorf("에스컬레이션 해제 실패: %w", err)
+	}
+	event, err := es.load(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	if resolved {
+		es.log.Info("에스컬레이션 해제",
+			zap.String("event_id", eventID),
+			zap.String("resolved_by", resolvedBy),
+			zap.Int("stage", int(event.Stage)),
+		)
+	}
+	return event, nil
+}
 
-	delete(es.active, eventID)
+// GetActiveEscalations은 사용자의 활성 에스컬레이션 목록을 전달 기록과 함께 반환합니다.
+func (es *EscalationService) GetActiveEscalations(ctx context.Context, userID string) ([]*EscalationEvent, error) {
+	if userID == "" {
+		return nil, apperrors.New(apperrors.ErrInvalidInput, "user_id는 필수입니다")
+	}
+	events, err := es.repo.FindActiveByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("에스컬레이션 조회 실패: %w", err)
+	}
+	for _, e := range events {
+		if e.Deliveries, err = es.repo.FindDeliveries(ctx, e.ID); err != nil {
+			return nil, fmt.Errorf("전달 기록 조회 실패: %w", err)
+		}
+	}
+	return events, nil
+}
 
-	es.log.Info("에스컬레이션 해제",
-		zap.String("event_id", eventID),
-		zap.String("resolved_by", resolvedBy),
-	)
+// Run은 ctx가 취소될 때까지 PollInterval마다 Tick을 실행합니다.
+func (es *EscalationService) Run(ctx context.Context) {
+	ticker := time.NewTicker(es.config.PollInterval)
+	defer ticker.Stop()
+	for {
+		if _, err := es.Tick(ctx); err != nil && ctx.Err() == nil {
+			es.log.Error("에스컬레이션 루프 실행 실패", zap.Error(err))
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
 
-	return nil
+// Tick은 리더 임대를 잡은 레플리카에서만 예정된 단계를 실행하고, 실행한 이벤트 수를 반환합니다.
+// 이벤트마다 리더 임대를 갱신하며, 임대를 잃으면 남은 이벤트는 새 리더에게 맡깁니다.
+func (es *EscalationService) Tick(ctx context.Context) (int, error) {
+	now := es.now()
+	leader, err := es.repo.AcquireLeadership(ctx, escalationLeaderLease, es.worker, now, now.Add(es.config.LeaseDuration))
+	if err != nil || !leader {
+		return 0, err
+	}
+
+	due, err := es.repo.FindDue(ctx, now, escalationBatchSize)
+	if err != nil {
+		return 0, err
+	}
+	processed := 0
+	for i, e := range due {
+		if i > 0 {
+			now = es.now()
+			leader, err := es.repo.AcquireLeadership(ctx, escalationLeaderLease, es.worker, now, now.Add(es.config.LeaseDuration))
+			if err != nil || !leader {
+				return processed, err
+			}
+		}
+		if err := es.process(ctx, e, now); err != nil {
+			es.log.Error("에스컬레이션 단계 처리 실패", zap.String("event_id", e.ID), zap.Error(err))
+			continue
+		}
+		processed++
+	}
+	return processed, nil
 }
 
-// GetActiveEscalations은 사용자의 활성 에스컬레이션 목록을 반환합니다.
-func (es *EscalationService) GetActiveEscalations(userID string) []*EscalationEvent {
-	var result []*EscalationEvent
-	for _, event := range es.active {
-		if event.UserID == userID {
-			result = append(result, event)
+// --- 내부 메서드 ---
+
+// process는 이벤트의 다음 단계를 임대한 뒤 실행하고, 결과에 따라 단계를 진행하거나 재시도를 예약합니다.
+// 다른 레플리카가 임대 중이거나 그사이 해제되었으면 아무것도 하지 않습니다.
+// 단계 실행은 StageTimeout(임대 시간보다 짧음)으로 제한하여 임대가 끝난 뒤에도 전화·신고가 이어지지 않게 합니다.
+func (es *EscalationService) process(ctx context.Context, e *EscalationEvent, now time.Time) error {
+	next := e.Stage + 1
+	if next > StageEmergencyCall {
+		return nil
+	}
+	claimed, err := es.repo.Claim(ctx, e.ID, e.Stage, es.worker, now, now.Add(es.config.LeaseDuration))
+	if err != nil || !claimed {
+		return err
+	}
+
+	attempt := e.Attempt + 1
+	stageCtx, cancel := context.WithTimeout(ctx, es.config.StageTimeout)
+	stageErr := es.executeStage(stageCtx, e, next, attempt)
+	cancel()
+
+	updated := *e
+	switch {
+	case stageErr == nil:
+		updated.Stage, updated.Attempt, updated.LastEscalation = next, 0, now
+		updated.NextStageAt = es.nextStageAt(next, now)
+	case attempt < es.config.MaxAttempts || next == StageEmergencyCall:
+		// 119 신고는 마지막 단계이므로 시도를 소진해도 포기하지 않고 상한 간격으로 계속 재시도합니다.
+		retryAt := now.Add(es.retryBackoff(attempt))
+		updated.Attempt, updated.NextStageAt = attempt, &retryAt
+		es.log.Warn("에스컬레이션 단계 전달 실패, 재시도 예약",
+			zap.String("event_id", e.ID),
+			zap.Int("stage", int(next)),
+			zap.Int("attempt", attempt),
+			zap.Error(stageErr),
+		)
+		if next == StageEmergencyCall && attempt == es.config.MaxAttempts {
+			es.alertOperator(ctx, e, fmt.Sprintf("119 신고 %d회 실패: %v", attempt, stageErr))
 		}
+	default:
+		// 전달을 확인하지 못한 채 시도를 모두 소진하면 다음 단계로 즉시 넘어갑니다.
+		updated.Stage, updated.Attempt, updated.LastEscalation = next, 0, now
+		updated.NextStageAt = &now
+		es.log.Error("에스컬레이션 단계 전달 실패, 다음 단계로 진행",
+			zap.String("event_id", e.ID),
+			zap.Int("stage", int(next)),
+			zap.Int("attempts", attempt),
+			zap.Error(stageErr),
+		)
+	}
+
+	if _, err := es.repo.Advance(ctx, &updated, e.Stage); err != nil {
+		return fmt.Errorf("에스컬레이션 상태 저장 실패: %w", err)
 	}
-	return result
+	return nil
 }
 
-// SetGuardians는 사용자의 보호자 목록을 설정합니다.
-func (es *EscalationService) SetGuardians(userID string, guardianIDs []string) {
-	es.guardians[userID] = guardianIDs
+// retryBackoff는 attempt번째 실패 뒤 재시도 간격입니다 (시도마다 배수로 늘고 MaxRetryBackoff에서 멈춤).
+func (es *EscalationService) retryBackoff(attempt int) time.Duration {
+	backoff := es.config.RetryBackoff * time.Duration(attempt)
+	if backoff > es.config.MaxRetryBackoff {
+		backoff = es.config.MaxRetryBackoff
+	}
+	return backoff
 }
 
-// --- 내부 메서드 ---
+// alertOperator는 자동 처리로 끝내지 못한 에스컬레이션을 운영자에게 알립니다.
+func (es *EscalationService) alertOperator(ctx context.Context, e *EscalationEvent, reason string) {
+	es.log.Error("운영자 확인 필요: 에스컬레이션 자동 처리 실패",
+		zap.String("event_id", e.ID),
+		zap.String("user_id", e.UserID),
+		zap.String("alert_type", e.AlertType),
+		zap.String("reason", reason),
+	)
+	if es.operator == nil {
+		return
+	}
+	if err := es.operator.AlertOperator(ctx, e, reason); err != nil {
+		es.log.Error("운영자 알림 실패", zap.String("event_id", e.ID), zap.Error(err))
+	}
+}
+
+// nextStageAt은 stage를 마친 뒤 다음 단계 예정 시각을 반환합니다 (마지막 단계 뒤에는 nil).
+func (es *EscalationService) nextStageAt(stage EscalationStage, now time.Time) *time.Time {
+	var delay time.Duration
+	switch stage {
+	case StageInAppAlert:
+		delay = es.config.Stage2Delay
+	case StageGuardianPush:
+		delay = es.config.Stage3Delay
+	case StageAIVoiceCall:
+		delay = es.config.Stage4Delay
+	default:
+		return nil
+	}
+	at := now.Add(delay)
+	return &at
+}
+
+// executeStage는 단계를 실행하고 수신자별 전달을 기록합니다.
+// 재시도에서는 이미 전달을 확인한 수신자를 건너뜁니다 (음성 전화·119 신고 포함). 한 명이라도 실패하면 오류를 반환합니다.
+func (es *EscalationService) executeStage(ctx context.Context, e *EscalationEvent, stage EscalationStage, attempt int) error {
+	done, err := es.confirmedRecipients(ctx, e.ID, stage)
+	if err != nil {
+		return err
+	}
+
+	switch stage {
+	case StageInAppAlert:
+		return es.executeStage1(ctx, e, attempt, done)
+	case StageGuardianPush:
+		return es.executeStage2(ctx, e, attempt, done)
+	case StageAIVoiceCall:
+		return es.executeStage3(ctx, e, attempt, done)
+	case StageEmergencyCall:
+		return es.executeStage4(ctx, e, attempt, done)
+	}
+	return nil
+}
 
 // executeStage1: AI 분석 + 인앱 알림
-func (es *EscalationService) executeStage1(ctx context.Context, event *EscalationEvent) {
+func (es *EscalationService) executeStage1(ctx context.Context, e *EscalationEvent, attempt int, done map[string]bool) error {
+	if done[e.UserID] {
+		return nil
+	}
 	title := "건강 이상 감지"
-	body := fmt.Sprintf("[긴급] %s 수치가 위험 범위입니다: %s. 즉시 확인해주세요.", event.AlertType, event.Value)
-	_, _ = es.notiSvc.SendNotification(ctx, event.UserID, TypeHealthAlert, ChannelInApp, PriorityUrgent, title, body, event.MeasurementID)
+	body := fmt.Sprintf("[긴급] %s 수치가 위험 범위입니다: %s. 즉시 확인해주세요.", e.AlertType, e.Value)
+	noti, err := es.notiSvc.SendNotificationConfirmed(ctx, e.UserID, TypeHealthAlert, ChannelInApp, PriorityUrgent, title, body, escalationData(e))
+	return es.record(ctx, e, StageInAppAlert, e.UserID, DeliveryChannelInApp, attempt, noti, err)
 }
 
 // executeStage2: 보호자 푸시 알림
-func (es *EscalationService) executeStage2(ctx context.Context, event *EscalationEvent) {
-	guardianIDs := es.guardians[event.UserID]
+func (es *EscalationService) executeStage2(ctx context.Context, e *EscalationEvent, attempt int, done map[string]bool) error {
+	if es.guardians == nil {
+		return es.skip(ctx, e, StageGuardianPush, "", DeliveryChannelPush, attempt, "보호자 조회 연동이 설정되지 않았습니다")
+	}
+	guardianIDs, err := es.guardians.ListGuardians(ctx, e.UserID)
+	if err != nil {
+		return fmt.Errorf("보호자 조회 실패: %w", err)
+	}
+	if len(guardianIDs) == 0 {
+		return es.skip(ctx, e, StageGuardianPush, "", DeliveryChannelPush, attempt, "등록된 보호자가 없습니다")
+	}
+
+	title := "가족 건강 긴급 알림"
+	body := fmt.Sprintf("가족 구성원의 %s 수치가 위험합니다: %s. 확인이 필요합니다.", e.AlertType, e.Value)
+	var failed []string
 	for _, gid := range guardianIDs {
-		title := "가족 건강 긴급 알림"
-		body := fmt.Sprintf("가족 구성원의 %s 수치가 위험합니다: %s. 확인이 필요합니다.", event.AlertType, event.Value)
-		_, _ = es.notiSvc.SendNotification(ctx, gid, TypeHealthAlert, ChannelPush, PriorityUrgent, title, body, event.MeasurementID)
+		if done[gid] {
+			continue
+		}
+		noti, sendErr := es.notiSvc.SendNotificationConfirmed(ctx, gid, TypeHealthAlert, ChannelPush, PriorityUrgent, title, body, escalationData(e))
+		if err := es.record(ctx, e, StageGuardianPush, gid, DeliveryChannelPush, attempt, noti, sendErr); err != nil {
+			failed = append(failed, gid)
+		}
 	}
-	event.Stage = StageGuardianPush
-	event.LastEscalation = time.Now()
+	if len(failed) > 0 {
+		return fmt.Errorf("보호자 %d명에게 전달 실패: %s", len(failed), strings.Join(failed, ", "))
+	}
+	return nil
 }
 
 // executeStage3: AI 음성 확인 전화
-func (es *EscalationService) executeStage3(_ context.Context, event *EscalationEvent) {
-	// 실제 구현: TTS/STT 기반 AI 음성 통화 서비스 연동
-	// 사용자에게 전화하여 "괜찮으세요? 1번을 눌러 확인해주세요" 시나리오 실행
+func (es *EscalationService) executeStage3(ctx context.Context, e *EscalationEvent, attempt int, done map[string]bool) error {
+	if done[e.UserID] {
+		return nil // 이전 시도에서 통화를 마쳤으나 단계 진행을 저장하지 못한 경우
+	}
 	es.log.Info("Stage3: AI 음성 확인 전화 발신",
-		zap.String("event_id", event.ID),
-		zap.String("user_id", event.UserID),
+		zap.String("event_id", e.ID),
+		zap.String("user_id", e.UserID),
 	)
-	event.Stage = StageAIVoiceCall
-	event.LastEscalation = time.Now()
+	if es.voice == nil {
+		return es.skip(ctx, e, StageAIVoiceCall, e.UserID, DeliveryChannelVoiceCall, attempt, "음성 통화 연동이 설정되지 않았습니다")
+	}
+	err := es.voice.CallForConfirmation(ctx, e)
+	return es.record(ctx, e, StageAIVoiceCall, e.UserID, DeliveryChannelVoiceCall, attempt, nil, err)
 }
 
 // executeStage4: 119 자동 신고
-func (es *EscalationService) executeStage4(_ context.Context, event *EscalationEvent) {
-	// 실제 구현: 119 API 또는 긴급 연락 시스템 연동
-	// 사용자 위치 정보 + 증상 정보를 포함하여 신고
+func (es *EscalationService) executeStage4(ctx context.Context, e *EscalationEvent, attempt int, done map[string]bool) error {
+	if done[e.UserID] {
+		return nil // 이미 접수된 신고를 중복 신고하지 않음
+	}
 	es.log.Warn("Stage4: 119 자동 신고 발동",
-		zap.String("event_id", event.ID),
-		zap.String("user_id", event.UserID),
-		zap.String("alert_type", event.AlertType),
-		zap.String("value", event.Value),
+		zap.String("event_id", e.ID),
+		zap.String("user_id", e.UserID),
+		zap.String("alert_type", e.AlertType),
+		zap.String("value", e.Value),
 	)
-	event.Stage = StageEmergencyCall
-	event.LastEscalation = time.Now()
+	if es.emergency == nil {
+		return es.skip(ctx, e, StageEmergencyCall, e.UserID, DeliveryChannelEmergency, attempt, "119 신고 연동이 설정되지 않았습니다")
+	}
+	err := es.emergency.ReportEmergency(ctx, e)
+	return es.record(ctx, e, StageEmergencyCall, e.UserID, DeliveryChannelEmergency, attempt, nil, err)
 }
 
-// runEscalationChain은 비동기로 에스컬레이션 체인을 실행합니다.
-func (es *EscalationService) runEscalationChain(event *EscalationEvent) {
-	ctx := context.Background()
+// record는 전달 결과를 저장하고, 전달에 실패했으면 그 오류를 반환합니다.
+func (es *EscalationService) record(ctx context.Context, e *EscalationEvent, stage EscalationStage, recipient, channel string, attempt int, noti *Notification, sendErr error) error {
+	d := &EscalationDelivery{
+		ID:           uuid.New().String(),
+		EscalationID: e.ID,
+		Stage:        stage,
+		RecipientID:  recipient,
+		Channel:      channel,
+		Status:       DeliveryDelivered,
+		Attempt:      attempt,
+		CreatedAt:    es.now(),
+	}
+	if noti != nil {
+		d.NotificationID = noti.ID
+		if noti.DeliveredVia != ChannelUnknown {
+			d.Channel = NotificationChannelToString(noti.DeliveredVia) // 폴백된 실제 채널
+		}
+	}
+	if sendErr != nil {
+		d.Status, d.Error = DeliveryFailed, sendErr.Error()
+	}
+	// 단계 제한 시간이 지났어도 결과는 남김
+	if err := es.repo.SaveDelivery(context.WithoutCancel(ctx), d); err != nil {
+		es.log.Warn("전달 기록 저장 실패", zap.String("event_id", e.ID), zap.Error(err))
+	}
+	return sendErr
+}
 
-	// Stage1 → Stage2 대기
-	time.Sleep(es.config.Stage2Delay)
-	if _, ok := es.active[event.ID]; !ok {
-		return // 이미 해제됨
+// skip은 수신자나 연동이 없어 건너뛴 단계를 기록합니다.
+func (es *EscalationService) skip(ctx context.Context, e *EscalationEvent, stage EscalationStage, recipient, channel string, attempt int, reason string) error {
+	d := &EscalationDelivery{
+		ID:           uuid.New().String(),
+		EscalationID: e.ID,
+		Stage:        stage,
+		RecipientID:  recipient,
+		Channel:      channel,
+		Status:       DeliverySkipped,
+		Attempt:      attempt,
+		Error:        reason,
+		CreatedAt:    es.now(),
+	}
+	// 단계 제한 시간이 지났어도 결과는 남김
+	if err := es.repo.SaveDelivery(context.WithoutCancel(ctx), d); err != nil {
+		es.log.Warn("전달 기록 저장 실패", zap.String("event_id", e.ID), zap.Error(err))
 	}
-	es.executeStage2(ctx, event)
+	return nil
+}
 
-	// Stage2 → Stage3 대기
-	time.Sleep(es.config.Stage3Delay)
-	if _, ok := es.active[event.ID]; !ok {
-		return
+// confirmedRecipients는 단계에서 이미 전달을 확인한 수신자 집합을 반환합니다.
+func (es *EscalationService) confirmedRecipients(ctx context.Context, eventID string, stage EscalationStage) (map[string]bool, error) {
+	deliveries, err := es.repo.FindDeliveries(ctx, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("전달 기록 조회 실패: %w", err)
 	}
-	es.executeStage3(ctx, event)
+	done := make(map[string]bool)
+	for _, d := range deliveries {
+		if d.Stage == stage && d.Status == DeliveryDelivered {
+			done[d.RecipientID] = true
+		}
+	}
+	return done, nil
+}
 
-	// Stage3 → Stage4 대기
-	time.Sleep(es.config.Stage4Delay)
-	if _, ok := es.active[event.ID]; !ok {
-		return
+// load는 이벤트를 전달 기록과 함께 조회합니다.
+func (es *EscalationService) load(ctx context.Context, eventID string) (*EscalationEvent, error) {
+	event, err := es.repo.FindByID(ctx, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("에스컬레이션 조회 실패: %w", err)
+	}
+	if event == nil {
+		return nil, apperrors.New(apperrors.ErrNotFound, "에스컬레이션 이벤트를 찾을 수 없습니다")
 	}
-	es.executeStage4(ctx, event)
+	if event.Deliveries, err = es.repo.FindDeliveries(ctx, eventID); err != nil {
+		return nil, fmt.Errorf("전달 기록 조회 실패: %w", err)
+	}
+	return event, nil
+}
+
+// escalationData는 알림에 싣는 에스컬레이션 식별 정보입니다. 앱은 이 ID로 확인(해제)을 요청합니다.
+func escalationData(e *EscalationEvent) string {
+	data, _ := json.Marshal(map[string]string{
+		"escalation_id":  e.ID,
+		"measurement_id": e.MeasurementID,
+	})
+	return string(data)
 }
@@ -0,0 +1,463 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/manpasik/backend/services/notification-service/internal/repository/memory"
+	"github.com/manpasik/backend/services/notification-service/internal/service"
+	apperrors "github.com/manpasik/backend/shared/errors"
+	"go.uber.org/zap"
+)
+
+// ============================================================================
+// 테스트용 Fake
+// ============================================================================
+
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+type fakePush struct {
+	mu       sync.Mutex
+	sent     []string
+	failures map[string]int // userID → 남은 실패 횟수
+}
+
+func (p *fakePush) SendPush(_ context.Context, userID, _, _, _ string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.failures[userID] > 0 {
+		p.failures[userID]--
+		return errors.New("FCM unavailable")
+	}
+	p.sent = append(p.sent, userID)
+	return nil
+}
+
+type fakeGuardians map[string][]string
+
+func (g fakeGuardians) ListGuardians(_ context.Context, userID string) ([]string, error) {
+	return g[userID], nil
+}
+
+type fakeEmergency struct {
+	reported   []string
+	failures   int  // 남은 실패 횟수
+	noDeadline bool // 제한 시간 없는 context로 호출된 적이 있는지
+}
+
+func (f *fakeEmergency) ReportEmergency(ctx context.Context, e *service.EscalationEvent) error {
+	if _, ok := ctx.Deadline(); !ok {
+		f.noDeadline = true
+	}
+	if f.failures > 0 {
+		f.failures--
+		return errors.New("119 gateway unavailable")
+	}
+	f.reported = append(f.reported, e.ID)
+	return nil
+}
+
+type fakeOperator struct{ alerts []string }
+
+func (o *fakeOperator) AlertOperator(_ context.Context, e *service.EscalationEvent, _ string) error {
+	o.alerts = append(o.alerts, e.ID)
+	return nil
+}
+
+type escalationFixture struct {
+	svc       *service.EscalationService
+	repo      *memory.EscalationRepository
+	noti      *service.NotificationService
+	push      *fakePush
+	guardians fakeGuardians
+	emergency *fakeEmergency
+	clock     *fakeClock
+}
+
+func newEscalationFixture(t *testing.T) *escalationFixture {
+	t.Helper()
+	f := &escalationFixture{
+		repo:      memory.NewEscalationRepository(),
+		push:      &fakePush{failures: make(map[string]int)},
+		guardians: fakeGuardians{"user-1": {"guardian-1", "guardian-2"}},
+		emergency: &fakeEmergency{},
+		clock:     &fakeClock{now: time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)},
+	}
+	f.noti = service.NewNotificationService(zap.NewNop(), memory.NewNotificationRepository(), memory.NewPreferencesRepository())
+	f.noti.SetPushSender(f.push)
+	f.svc = f.newReplica("replica-a")
+	return f
+}
+
+// newReplica는 같은 저장소를 쓰는 다른 레플리카(또는 재시작한 프로세스)를 만듭니다.
+func (f *escalationFixture) newReplica(worker string) *service.EscalationService {
+	svc := service.NewEscalationService(zap.NewNop(), f.noti, f.repo)
+	svc.SetWorkerID(worker)
+	svc.SetClock(f.clock.Now)
+	svc.SetGuardianClient(f.guardians)
+	svc.SetEmergencyReporter(f.emergency)
+	return svc
+}
+
+func (f *escalationFixture) get(t *testing.T, id string) *service.EscalationEvent {
+	t.Helper()
+	e, err := f.repo.FindByID(context.Background(), id)
+	if err != nil || e == nil {
+		t.Fatalf("에스컬레이션 조회 실패: %v", err)
+	}
+	return e
+}
+
+func deliveriesAt(e []*service.EscalationDelivery, stage service.EscalationStage) []*service.EscalationDelivery {
+	var out []*service.EscalationDelivery
+	for _, d := range e {
+		if d.Stage == stage {
+			out = append(out, d)
+		}
+	}
+	return out
+}
+
+// ============================================================================
+// 단계 진행
+// ============================================================================
+
+func TestEscalation_FullChain(t *testing.T) {
+	f := newEscalationFixture(t)
+	ctx := context.Background()
+
+	event, err := f.svc.TriggerEscalation(ctx, "user-1", "health_critical", "m-1", "glucose 420 mg/dL")
+	if err != nil {
+		t.Fatalf("TriggerEscalation 실패: %v", err)
+	}
+	if event.Stage != service.StageInAppAlert || len(event.Deliveries) != 1 || event.Deliveries[0].Status != service.DeliveryDelivered {
+		t.Fatalf("Stage1 즉시 실행 기대: stage=%d deliveries=%+v", event.Stage, event.Deliveries)
+	}
+	if want := f.clock.Now().Add(3 * time.Minute); event.NextStageAt == nil || !event.NextStageAt.Equal(want) {
+		t.Fatalf("다음 단계 시각 = %v, want %v", event.NextStageAt, want)
+	}
+
+	// 같은 측정으로 다시 트리거하면 기존 에스컬레이션 반환
+	again, _ := f.svc.TriggerEscalation(ctx, "user-1", "health_critical", "m-1", "glucose 420 mg/dL")
+	if again.ID != event.ID {
+		t.Fatalf("중복 트리거가 새 에스컬레이션을 만듦: %s != %s", again.ID, event.ID)
+	}
+
+	// 예정 시각 전에는 진행하지 않음
+	f.clock.Advance(2 * time.Minute)
+	if n, _ := f.svc.Tick(ctx); n != 0 {
+		t.Fatalf("예정 전 Tick 처리 수 = %d, want 0", n)
+	}
+
+	f.clock.Advance(time.Minute)
+	if n, err := f.svc.Tick(ctx); err != nil || n != 1 {
+		t.Fatalf("Stage2 Tick = %d, %v", n, err)
+	}
+	if got := f.get(t, event.ID).Stage; got != service.StageGuardianPush {
+		t.Fatalf("stage = %d, want GuardianPush", got)
+	}
+	if len(f.push.sent) != 2 {
+		t.Fatalf("보호자 푸시 수 = %d, want 2", len(f.push.sent))
+	}
+
+	// Stage3: 음성 통화 연동 없음 → 건너뜀 기록 후 진행
+	f.clock.Advance(3 * time.Minute)
+	f.svc.Tick(ctx)
+	// Stage4: 119 신고
+	f.clock.Advance(4 * time.Minute)
+	f.svc.Tick(ctx)
+
+	final := f.get(t, event.ID)
+	if final.Stage != service.StageEmergencyCall || final.NextStageAt != nil {
+		t.Fatalf("최종 상태 stage=%d next=%v", final.Stage, final.NextStageAt)
+	}
+	if len(f.emergency.reported) != 1 {
+		t.Fatalf("119 신고 수 = %d, want 1", len(f.emergency.reported))
+	}
+	deliveries, _ := f.repo.FindDeliveries(ctx, event.ID)
+	if d := deliveriesAt(deliveries, service.StageAIVoiceCall); len(d) != 1 || d[0].Status != service.DeliverySkipped {
+		t.Fatalf("Stage3 기록 = %+v, skipped 기대", d)
+	}
+}
+
+func TestEscalation_Acknowledge(t *testing.T) {
+	f := newEscalationFixture(t)
+	ctx := context.Background()
+	event, _ := f.svc.TriggerEscalation(ctx, "user-1", "health_critical", "m-1", "SpO2 82%")
+
+	acked, err := f.svc.AcknowledgeEscalation(ctx, event.ID, service.ResolvedByGuardian)
+	if err != nil {
+		t.Fatalf("AcknowledgeEscalation 실패: %v", err)
+	}
+	if acked.Stage != service.StageResolved || acked.ResolvedBy != service.ResolvedByGuardian || acked.ResolvedAt == nil {
+		t.Fatalf("해제 결과 = %+v", acked)
+	}
+
+	f.clock.Advance(time.Hour)
+	if n, _ := f.svc.Tick(ctx); n != 0 || len(f.push.sent) != 0 {
+		t.Fatalf("해제 후 진행됨: processed=%d push=%d", n, len(f.push.sent))
+	}
+	if active, _ := f.svc.GetActiveEscalations(ctx, "user-1"); len(active) != 0 {
+		t.Fatalf("활성 에스컬레이션 = %d, want 0", len(active))
+	}
+
+	// 다시 확인해도 처음 해제 주체 유지
+	again, err := f.svc.AcknowledgeEscalation(ctx, event.ID, service.ResolvedByUser)
+	if err != nil || again.ResolvedBy != service.ResolvedByGuardian {
+		t.Fatalf("재확인 = %+v, %v", again, err)
+	}
+
+	var appErr *apperrors.AppError
+	if _, err := f.svc.AcknowledgeEscalation(ctx, "missing", ""); !errors.As(err, &appErr) || appErr.Code != apperrors.ErrNotFound {
+		t.Fatalf("없는 에스컬레이션: %v, NOT_FOUND 기대", err)
+	}
+	if _, err := f.svc.AcknowledgeEscalation(ctx, event.ID, "robot"); !errors.As(err, &appErr) || appErr.Code != apperrors.ErrInvalidInput {
+		t.Fatalf("잘못된 resolved_by: %v, INVALID_INPUT 기대", err)
+	}
+}
+
+// ============================================================================
+// 재시작·리더 선출
+// ============================================================================
+
+func TestEscalation_SurvivesRestart(t *testing.T) {
+	f := newEscalationFixture(t)
+	ctx := context.Background()
+	event, _ := f.svc.TriggerEscalation(ctx, "user-1", "fall_detected", "", "낙상 감지")
+
+	// 프로세스 재시작: 같은 저장소로 새 서비스 생성
+	restarted := f.newReplica("replica-a")
+	f.clock.Advance(3 * time.Minute)
+	if n, err := restarted.Tick(ctx); err != nil || n != 1 {
+		t.Fatalf("재시작 후 Tick = %d, %v", n, err)
+	}
+	if got := f.get(t, event.ID).Stage; got != service.StageGuardianPush {
+		t.Fatalf("재시작 후 stage = %d, want GuardianPush", got)
+	}
+}
+
+func TestEscalation_LeaderOnly(t *testing.T) {
+	f := newEscalationFixture(t)
+	ctx := context.Background()
+	other := f.newReplica("replica-b")
+	cfg := service.DefaultEscalationConfig
+	cfg.LeaseDuration = 10 * time.Minute
+	f.svc.SetConfig(cfg)
+	other.SetConfig(cfg)
+	f.svc.TriggerEscalation(ctx, "user-1", "health_critical", "m-1", "HR 190")
+
+	f.clock.Advance(3 * time.Minute)
+	if n, _ := f.svc.Tick(ctx); n != 1 {
+		t.Fatalf("리더 Tick 처리 수 = %d, want 1", n)
+	}
+	f.clock.Advance(3 * time.Minute)
+	if n, _ := other.Tick(ctx); n != 0 {
+		t.Fatalf("리더 임대 중 다른 레플리카가 처리함: %d", n)
+	}
+
+	// 리더 임대가 만료되면 다른 레플리카가 이어받음
+	f.clock.Advance(cfg.LeaseDuration)
+	if n, _ := other.Tick(ctx); n != 1 {
+		t.Fatalf("임대 만료 후 다른 레플리카 처리 수 = %d, want 1", n)
+	}
+}
+
+// ============================================================================
+// 재시도·전달 확인
+// ============================================================================
+
+func TestEscalation_RetriesOnlyFailedRecipients(t *testing.T) {
+	f := newEscalationFixture(t)
+	ctx := context.Background()
+	event, _ := f.svc.TriggerEscalation(ctx, "user-1", "health_critical", "m-1", "glucose 35 mg/dL")
+	f.push.failures["guardian-2"] = 1
+
+	f.clock.Advance(3 * time.Minute)
+	f.svc.Tick(ctx)
+	e := f.get(t, event.ID)
+	if e.Stage != service.StageInAppAlert || e.Attempt != 1 {
+		t.Fatalf("첫 시도 실패 후 stage=%d attempt=%d, 재시도 대기 기대", e.Stage, e.Attempt)
+	}
+
+	f.clock.Advance(service.DefaultEscalationConfig.RetryBackoff)
+	f.svc.Tick(ctx)
+	e = f.get(t, event.ID)
+	if e.Stage != service.StageGuardianPush || e.Attempt != 0 {
+		t.Fatalf("재시도 성공 후 stage=%d attempt=%d", e.Stage, e.Attempt)
+	}
+	// guardian-1은 한 번만, guardian-2는 재시도에서 전달
+	if len(f.push.sent) != 2 || f.push.sent[0] != "guardian-1" || f.push.sent[1] != "guardian-2" {
+		t.Fatalf("푸시 전달 = %v", f.push.sent)
+	}
+	deliveries, _ := f.repo.FindDeliveries(ctx, event.ID)
+	if d := deliveriesAt(deliveries, service.StageGuardianPush); len(d) != 3 || d[1].Status != service.DeliveryFailed || d[2].Attempt != 2 {
+		t.Fatalf("Stage2 전달 기록 = %+v", d)
+	}
+}
+
+func TestEscalation_ExhaustedRetriesEscalateImmediately(t *testing.T) {
+	f := newEscalationFixture(t)
+	ctx := context.Background()
+	event, _ := f.svc.TriggerEscalation(ctx, "user-1", "health_critical", "m-1", "BP 220/130")
+	f.push.failures["guardian-1"] = 10
+
+	f.clock.Advance(3 * time.Minute)
+	for i := 0; i < service.DefaultEscalationConfig.MaxAttempts; i++ {
+		f.svc.Tick(ctx)
+		f.clock.Advance(time.Minute)
+	}
+	e := f.get(t, event.ID)
+	if e.Stage != service.StageGuardianPush || e.NextStageAt == nil || e.NextStageAt.After(f.clock.Now()) {
+		t.Fatalf("재시도 소진 후 stage=%d next=%v — 다음 단계 즉시 예정 기대", e.Stage, e.NextStageAt)
+	}
+}
+
+// advanceToEmergency는 Stage3까지 진행해 119 신고 단계만 남깁니다.
+func (f *escalationFixture) advanceToEmergency(t *testing.T, ctx context.Context) *service.EscalationEvent {
+	t.Helper()
+	event, _ := f.svc.TriggerEscalation(ctx, "user-1", "health_critical", "m-1", "HR 20")
+	f.clock.Advance(3 * time.Minute)
+	f.svc.Tick(ctx)
+	f.clock.Advance(3 * time.Minute)
+	f.svc.Tick(ctx)
+	if got := f.get(t, event.ID).Stage; got != service.StageAIVoiceCall {
+		t.Fatalf("stage = %d, want AIVoiceCall", got)
+	}
+	f.clock.Advance(4 * time.Minute)
+	return event
+}
+
+func TestEscalation_EmergencyCallRetriesAfterExhaustion(t *testing.T) {
+	f := newEscalationFixture(t)
+	ctx := context.Background()
+	operator := &fakeOperator{}
+	f.svc.SetOperatorAlerter(operator)
+	event := f.advanceToEmergency(t, ctx)
+	f.emergency.failures = 6
+
+	for i := 0; i < 6; i++ {
+		f.svc.Tick(ctx)
+		e := f.get(t, event.ID)
+		if e.Stage != service.StageAIVoiceCall || e.NextStageAt == nil {
+			t.Fatalf("%d번째 실패 후 stage=%d next=%v — 119 신고 재시도 예약 기대", i+1, e.Stage, e.NextStageAt)
+		}
+		if backoff := e.NextStageAt.Sub(f.clock.Now()); backoff > service.DefaultEscalationConfig.MaxRetryBackoff {
+			t.Fatalf("재시도 간격 %v가 상한을 넘음", backoff)
+		}
+		f.clock.Advance(e.NextStageAt.Sub(f.clock.Now()))
+	}
+	if len(operator.alerts) != 1 {
+		t.Fatalf("운영자 알림 수 = %d, want 1", len(operator.alerts))
+	}
+
+	f.svc.Tick(ctx)
+	if e := f.get(t, event.ID); e.Stage != service.StageEmergencyCall || len(f.emergency.reported) != 1 {
+		t.Fatalf("재시도 끝에 신고되어야 함: stage=%d reported=%v", e.Stage, f.emergency.reported)
+	}
+	if f.emergency.noDeadline {
+		t.Fatal("119 신고는 제한 시간이 있는 context로 호출되어야 함")
+	}
+}
+
+func TestEscalation_EmergencyCallNotRepeated(t *testing.T) {
+	f := newEscalationFixture(t)
+	ctx := context.Background()
+	event := f.advanceToEmergency(t, ctx)
+
+	// 이전 레플리카가 신고를 마쳤지만 단계 진행을 저장하기 전에 중단된 경우
+	f.repo.SaveDelivery(ctx, &service.EscalationDelivery{
+		ID: "d-prev", EscalationID: event.ID, Stage: service.StageEmergencyCall, RecipientID: "user-1",
+		Channel: service.DeliveryChannelEmergency, Status: service.DeliveryDelivered, Attempt: 1, CreatedAt: f.clock.Now(),
+	})
+	f.svc.Tick(ctx)
+	if e := f.get(t, event.ID); e.Stage != service.StageEmergencyCall || len(f.emergency.reported) != 0 {
+		t.Fatalf("중복 신고: stage=%d reported=%v", e.Stage, f.emergency.reported)
+	}
+}
+
+func TestEscalation_ConcurrentAcknowledgeAndTick(t *testing.T) {
+	f := newEscalationFixture(t)
+	ctx := context.Background()
+	event, _ := f.svc.TriggerEscalation(ctx, "user-1", "health_critical", "m-1", "HR 30")
+	f.clock.Advance(3 * time.Minute)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(2)
+		go func() { defer wg.Done(); f.svc.Tick(ctx) }()
+		go func() { defer wg.Done(); f.svc.AcknowledgeEscalation(ctx, event.ID, service.ResolvedByUser) }()
+	}
+	wg.Wait()
+
+	e := f.get(t, event.ID)
+	if e.Stage != service.StageResolved || e.ResolvedAt == nil {
+		t.Fatalf("동시 확인 후 상태 = stage %d", e.Stage)
+	}
+	if len(f.push.sent) > 2 {
+		t.Fatalf("보호자 푸시 중복 발송: %v", f.push.sent)
+	}
+}
+
+// staleActiveRepo는 첫 활성 조회에서 다른 레플리카가 만든 에스컬레이션을 아직 보지 못한 것처럼 동작합니다.
+type staleActiveRepo struct {
+	*memory.EscalationRepository
+	stale bool
+}
+
+func (r *staleActiveRepo) FindActiveByUserID(ctx context.Context, userID string) ([]*service.EscalationEvent, error) {
+	if r.stale {
+		r.stale = false
+		return nil, nil
+	}
+	return r.EscalationRepository.FindActiveByUserID(ctx, userID)
+}
+
+func TestTriggerEscalation_다른_레플리카가_먼저_생성(t *testing.T) {
+	f := newEscalationFixture(t)
+	ctx := context.Background()
+
+	event, err := f.svc.TriggerEscalation(ctx, "user-1", "health_critical", "m-1", "HR 190")
+	if err != nil {
+		t.Fatalf("TriggerEscalation 실패: %v", err)
+	}
+
+	replicaB := service.NewEscalationService(zap.NewNop(), f.noti, &staleActiveRepo{EscalationRepository: f.repo, stale: true})
+	replicaB.SetClock(f.clock.Now)
+	again, err := replicaB.TriggerEscalation(ctx, "user-1", "health_critical", "m-1", "HR 190")
+	if err != nil {
+		t.Fatalf("이미 활성인 에스컬레이션은 오류 없이 반환해야 함: %v", err)
+	}
+	if again.ID != event.ID {
+		t.Fatalf("동시 트리거가 새 에스컬레이션을 만듦: %s != %s", again.ID, event.ID)
+	}
+	if active, _ := f.repo.FindActiveByUserID(ctx, "user-1"); len(active) != 1 {
+		t.Fatalf("활성 에스컬레이션 수 = %d, want 1", len(active))
+	}
+}
+
+func TestTriggerEscalation_InvalidInput(t *testing.T) {
+	f := newEscalationFixture(t)
+	_, err := f.svc.TriggerEscalation(context.Background(), "", "health_critical", "", "")
+	var appErr *apperrors.AppError
+	if !errors.As(err, &appErr) || appErr.Code != apperrors.ErrInvalidInput {
+		t.Fatalf("빈 userID: %v, INVALID_INPUT 기대", err)
+	}
+}
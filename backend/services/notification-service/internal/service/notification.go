@@ -4,11 +4,14 @@ package service
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"sync"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/manpasik/backend/shared/clients"
 	apperrors "github.com/manpasik/backend/shared/errors"
+	"github.com/manpasik/backend/shared/scheduler"
 	"go.uber.org/zap"
 )
 
@@ -48,19 +51,51 @@ const (
 	PriorityUrgent
 )
 
+// channelFallbackOrder는 채널 전송 실패 시 다음으로 시도하는 순서입니다.
+var channelFallbackOrder = []NotificationChannel{ChannelPush, ChannelSMS, ChannelEmail, ChannelInApp}
+
+// 전달 상태
+const (
+	DeliveryDelivered = "delivered" // 전송기가 수신을 확인함
+	DeliveryFailed    = "failed"    // 전송 실패 (재시도 대상)
+	DeliverySkipped   = "skipped"   // 수신자·연동이 없어 건너뜀
+	DeliveryDeferred  = "deferred"  // 방해 금지 시간이라 종료 시각에 발송
+	DeliveryPending   = "pending"   // 저장 후 채널 전송 중
+)
+
+const (
+	deferredPollInterval = 30 * time.Second
+	deferredClaimLease   = time.Minute // 발송 중 레플리카가 죽으면 이 시간 뒤 다시 시도
+	deferredBatchSize    = 100
+)
+
 // Notification은 알림 도메인 객체입니다.
 type Notification struct {
-	ID        string
-	UserID    string
-	Type      NotificationType
-	Channel   NotificationChannel
-	Priority  NotificationPriority
-	Title     string
-	Body      string
-	Data      string
-	IsRead    bool
-	CreatedAt time.Time
-	ReadAt    *time.Time
+	ID           string
+	UserID       string
+	Type         NotificationType
+	Channel      NotificationChannel // 요청(또는 자동 선택)된 채널
+	Priority     NotificationPriority
+	Title        string
+	Body         string
+	Data         string
+	IsRead       bool
+	CreatedAt    time.Time
+	ReadAt       *time.Time
+	Status       string              // DeliveryPending, DeliveryDelivered, DeliveryDeferred, DeliveryFailed
+	DeliveredVia NotificationChannel // 폴백 후 실제 전달된 채널
+	DeliverAt    *time.Time          // 미뤄진 발송 시각 (DeliveryDeferred일 때)
+	Attempts     []*DeliveryAttempt  // 이번 발송의 채널별 시도 (조회 시에는 비어 있음)
+}
+
+// DeliveryAttempt는 채널 하나로 전송을 시도한 기록입니다.
+type DeliveryAttempt struct {
+	ID             string
+	NotificationID string
+	Channel        NotificationChannel
+	Status         string // DeliveryDelivered, DeliveryFailed
+	Error          string
+	CreatedAt      time.Time
 }
 
 // NotificationPreferences는 사용자 알림 설정입니다.
@@ -86,6 +121,15 @@ type NotificationRepository interface {
 	MarkAsRead(ctx context.Context, id string) error
 	MarkAllAsRead(ctx context.Context, userID string) (int, error)
 	GetUnreadCount(ctx context.Context, userID string) (int, map[string]int, error)
+	// UpdateDelivery는 전달 상태(Status, DeliveredVia, DeliverAt)를 저장합니다.
+	UpdateDelivery(ctx context.Context, n *Notification) error
+	// FindDeferred는 발송 시각이 된 미뤄진 알림을 오래된 순으로 조회합니다.
+	FindDeferred(ctx context.Context, now time.Time, limit int) ([]*Notification, error)
+	// ClaimDeferred는 발송 시각이 아직 due인 미뤄진 알림의 발송 시각을 until로 옮겨 발송 권한을 잡습니다.
+	// 레플리카 간 하나만 성공합니다.
+	ClaimDeferred(ctx context.Context, id string, due, until time.Time) (bool, error)
+	SaveAttempt(ctx context.Context, a *DeliveryAttempt) error
+	FindAttempts(ctx context.Context, notificationID string) ([]*DeliveryAttempt, error)
 }
 
 // PreferencesRepository는 알림 설정 저장소 인터페이스입니다.
@@ -104,13 +148,22 @@ type EmailSender interface {
 	SendEmail(ctx context.Context, userID, subject, body string) error
 }
 
+// SMSSender는 실제 SMS 전송 인터페이스입니다.
+type SMSSender interface {
+	SendSMS(ctx context.Context, userID, body string) error
+}
+
 // NotificationService는 알림 서비스 핵심 로직입니다.
 type NotificationService struct {
 	log         *zap.Logger
 	notiRepo    NotificationRepository
 	prefRepo    PreferencesRepository
-	pushSender  PushSender  // optional: nil이면 푸시 미발송
-	emailSender EmailSender // optional: nil이면 이메일 미발송
+	pushSender  PushSender                // optional: nil이면 푸시 미발송
+	emailSender EmailSender               // optional: nil이면 이메일 미발송
+	smsSender   SMSSender                 // optional: nil이면 SMS 미발송
+	profiles    clients.UserProfileClient // optional: nil이면 방해 금지 시간을 UTC로 계산
+	tmplRepo    TemplateRepository        // optional: nil이면 기본 템플릿만 사용
+	now         func() time.Time
 	mu          sync.RWMutex
 }
 
@@ -120,6 +173,7 @@ func NewNotificationService(log *zap.Logger, notiRepo NotificationRepository, pr
 		log:      log,
 		notiRepo: notiRepo,
 		prefRepo: prefRepo,
+		now:      time.Now,
 	}
 }
 
@@ -133,19 +187,74 @@ func (s *NotificationService) SetEmailSender(es EmailSender) {
 	s.emailSender = es
 }
 
+// SetSMSSender는 SMS 전송기를 설정합니다 (optional).
+func (s *NotificationService) SetSMSSender(ss SMSSender) {
+	s.smsSender = ss
+}
+
+// SetUserProfileClient는 방해 금지 시간 계산에 쓸 사용자 시간대 조회 클라이언트를 설정합니다 (optional).
+func (s *NotificationService) SetUserProfileClient(c clients.UserProfileClient) {
+	s.profiles = c
+}
+
+// SetClock은 현재 시각 함수를 교체합니다 (테스트용).
+func (s *NotificationService) SetClock(now func() time.Time) {
+	s.now = now
+}
+
 // SendNotification은 알림을 발송합니다.
+// 긴급(urgent)이 아닌 푸시·SMS·이메일 알림은 사용자 시간대의 방해 금지 시간이면 종료 시각으로 미뤄 발송합니다.
+// 채널 전송에 실패하면 push → sms → email → in_app 순으로 폴백하며, 모두 실패해도 알림은 저장되고 오류를 반환하지 않습니다.
 func (s *NotificationService) SendNotification(ctx context.Context, userID string, nType NotificationType, channel NotificationChannel, priority NotificationPriority, title, body string, data string) (*Notification, error) {
+	noti, pref, err := s.save(ctx, userID, nType, channel, priority, title, body, data)
+	if err != nil {
+		return nil, err
+	}
+	if noti.Status == DeliveryDeferred {
+		return noti, nil
+	}
+	if err := s.deliver(ctx, noti, pref, true); err != nil {
+		s.log.Warn("채널 전송 실패 (알림은 저장됨)", zap.Error(err), zap.String("notification_id", noti.ID))
+	}
+	s.logSent(noti)
+	return noti, nil
+}
+
+// SendNotificationConfirmed는 SendNotification과 같지만 채널 전송 실패를 오류로 반환합니다.
+// 전달 확인이 필요한 긴급 알림에 사용합니다. 인앱 저장은 수신 확인이 아니므로 인앱으로는 폴백하지 않으며,
+// 전송에 실패해도 저장된 알림은 함께 반환합니다.
+func (s *NotificationService) SendNotificationConfirmed(ctx context.Context, userID string, nType NotificationType, channel NotificationChannel, priority NotificationPriority, title, body string, data string) (*Notification, error) {
+	noti, pref, err := s.save(ctx, userID, nType, channel, priority, title, body, data)
+	if err != nil {
+		return nil, err
+	}
+	if noti.Status == DeliveryDeferred {
+		return noti, nil
+	}
+	if err := s.deliver(ctx, noti, pref, false); err != nil {
+		return noti, err
+	}
+	s.logSent(noti)
+	return noti, nil
+}
+
+// save는 입력을 검증하고 채널·우선순위·방해 금지 시간 적용 여부를 정해 알림을 저장합니다.
+func (s *NotificationService) save(ctx context.Context, userID string, nType NotificationType, channel NotificationChannel, priority NotificationPriority, title, body string, data string) (*Notification, *NotificationPreferences, error) {
 	if userID == "" {
-		return nil, apperrors.New(apperrors.ErrInvalidInput, "입력값이 올바르지 않습니다")
+		return nil, nil, apperrors.New(apperrors.ErrInvalidInput, "입력값이 올바르지 않습니다")
 	}
 	if title == "" {
-		return nil, apperrors.New(apperrors.ErrInvalidInput, "입력값이 올바르지 않습니다")
+		return nil, nil, apperrors.New(apperrors.ErrInvalidInput, "입력값이 올바르지 않습니다")
+	}
+
+	pref, err := s.prefRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		pref = nil
 	}
 
 	// 채널이 0이면 사용자 설정에 따라 자동 선택
 	if channel == ChannelUnknown {
-		pref, err := s.prefRepo.FindByUserID(ctx, userID)
-		if err != nil || pref == nil {
+		if pref == nil {
 			channel = ChannelInApp // 기본값
 		} else {
 			channel = s.selectBestChannel(pref, priority)
@@ -157,6 +266,7 @@ func (s *NotificationService) SendNotification(ctx context.Context, userID strin
 		priority = PriorityNormal
 	}
 
+	now := s.now()
 	noti := &Notification{
 		ID:        uuid.New().String(),
 		UserID:    userID,
@@ -167,37 +277,206 @@ func (s *NotificationService) SendNotification(ctx context.Context, userID strin
 		Body:      body,
 		Data:      data,
 		IsRead:    false,
-		CreatedAt: time.Now(),
+		CreatedAt: now,
+		Status:    DeliveryPending,
+	}
+	if deliverAt := s.quietHoursEnd(ctx, noti, pref, now); deliverAt.After(now) {
+		noti.Status, noti.DeliverAt = DeliveryDeferred, &deliverAt
 	}
 
 	if err := s.notiRepo.Save(ctx, noti); err != nil {
-		return nil, fmt.Errorf("알림 저장 실패: %w", err)
+		return nil, nil, fmt.Errorf("알림 저장 실패: %w", err)
+	}
+	if noti.Status == DeliveryDeferred {
+		s.log.Info("방해 금지 시간 — 알림 발송 연기",
+			zap.String("notification_id", noti.ID),
+			zap.String("user_id", userID),
+			zap.Time("deliver_at", deliverAtOf(noti)),
+		)
+	}
+	return noti, s.preferencesOrDefault(userID, pref), nil
+}
+
+// quietHoursEnd는 알림을 보낼 수 있는 시각을 반환합니다. 방해 금지 시간이 아니면 now입니다.
+// 긴급(urgent) 알림과 소리 없이 쌓이는 인앱 알림은 항상 바로 보냅니다.
+func (s *NotificationService) quietHoursEnd(ctx context.Context, noti *Notification, pref *NotificationPreferences, now time.Time) time.Time {
+	if pref == nil || noti.Priority >= PriorityUrgent || noti.Channel == ChannelInApp {
+		return now
+	}
+	if pref.QuietHoursStart == "" || pref.QuietHoursEnd == "" {
+		return now
+	}
+	var timezone string
+	if s.profiles != nil {
+		tz, err := s.profiles.GetTimezone(ctx, noti.UserID)
+		if err != nil {
+			s.log.Warn("사용자 시간대 조회 실패, UTC 기준으로 방해 금지 시간 적용", zap.String("user_id", noti.UserID), zap.Error(err))
+		}
+		timezone = tz
+	}
+	return scheduler.QuietHoursEnd(now, timezone, pref.QuietHoursStart, pref.QuietHoursEnd)
+}
+
+// deliver는 저장된 알림을 요청 채널부터 폴백 순서대로 보내고 시도마다 기록합니다.
+// 첫 채널 이후로는 사용자가 켠 채널만 시도하며, inAppFallback이 false면 인앱으로는 폴백하지 않습니다.
+func (s *NotificationService) deliver(ctx context.Context, noti *Notification, pref *NotificationPreferences, inAppFallback bool) error {
+	var lastErr error
+	for _, ch := range fallbackChain(noti.Channel, pref, inAppFallback) {
+		err := s.sendVia(ctx, ch, noti)
+		s.recordAttempt(ctx, noti, ch, err)
+		if err == nil {
+			noti.Status, noti.DeliveredVia, noti.DeliverAt = DeliveryDelivered, ch, nil
+			lastErr = nil
+			break
+		}
+		lastErr = err
+	}
+	if lastErr != nil {
+		noti.Status, noti.DeliverAt = DeliveryFailed, nil
 	}
+	if err := s.notiRepo.UpdateDelivery(ctx, noti); err != nil {
+		s.log.Warn("전달 상태 저장 실패", zap.String("notification_id", noti.ID), zap.Error(err))
+	}
+	return lastErr
+}
 
-	// 실제 채널별 전송 (optional, 실패해도 알림 저장은 유지)
-	switch channel {
+// fallbackChain은 primary부터 시도할 채널 목록을 반환합니다.
+func fallbackChain(primary NotificationChannel, pref *NotificationPreferences, inAppFallback bool) []NotificationChannel {
+	chain := []NotificationChannel{primary}
+	after := false
+	for _, ch := range channelFallbackOrder {
+		if ch == primary {
+			after = true
+			continue
+		}
+		if !after || !channelEnabled(pref, ch) || (ch == ChannelInApp && !inAppFallback) {
+			continue
+		}
+		chain = append(chain, ch)
+	}
+	return chain
+}
+
+func channelEnabled(pref *NotificationPreferences, ch NotificationChannel) bool {
+	switch ch {
 	case ChannelPush:
-		if s.pushSender != nil {
-			if err := s.pushSender.SendPush(ctx, userID, title, body, data); err != nil {
-				s.log.Warn("FCM 푸시 전송 실패 (알림은 저장됨)", zap.Error(err), zap.String("notification_id", noti.ID))
-			}
+		return pref.PushEnabled
+	case ChannelSMS:
+		return pref.SMSEnabled
+	case ChannelEmail:
+		return pref.EmailEnabled
+	case ChannelInApp:
+		return pref.InAppEnabled
+	}
+	return false
+}
+
+// sendVia는 채널 하나로 알림을 보냅니다. 인앱 알림은 저장으로 전달이 끝납니다.
+func (s *NotificationService) sendVia(ctx context.Context, ch NotificationChannel, noti *Notification) error {
+	switch ch {
+	case ChannelPush:
+		if s.pushSender == nil {
+			return fmt.Errorf("푸시 전송기가 설정되지 않았습니다")
+		}
+		if err := s.pushSender.SendPush(ctx, noti.UserID, noti.Title, noti.Body, noti.Data); err != nil {
+			return fmt.Errorf("FCM 푸시 전송 실패: %w", err)
+		}
+	case ChannelSMS:
+		if s.smsSender == nil {
+			return fmt.Errorf("SMS 전송기가 설정되지 않았습니다")
+		}
+		if err := s.smsSender.SendSMS(ctx, noti.UserID, noti.Title+"\n"+noti.Body); err != nil {
+			return fmt.Errorf("SMS 전송 실패: %w", err)
 		}
 	case ChannelEmail:
-		if s.emailSender != nil {
-			if err := s.emailSender.SendEmail(ctx, userID, title, body); err != nil {
-				s.log.Warn("이메일 전송 실패 (알림은 저장됨)", zap.Error(err), zap.String("notification_id", noti.ID))
-			}
+		if s.emailSender == nil {
+			return fmt.Errorf("이메일 전송기가 설정되지 않았습니다")
+		}
+		if err := s.emailSender.SendEmail(ctx, noti.UserID, noti.Title, noti.Body); err != nil {
+			return fmt.Errorf("이메일 전송 실패: %w", err)
 		}
 	}
+	return nil
+}
 
+// recordAttempt는 채널 전송 시도를 기록합니다.
+func (s *NotificationService) recordAttempt(ctx context.Context, noti *Notification, ch NotificationChannel, sendErr error) {
+	a := &DeliveryAttempt{
+		ID:             uuid.New().String(),
+		NotificationID: noti.ID,
+		Channel:        ch,
+		Status:         DeliveryDelivered,
+		CreatedAt:      s.now(),
+	}
+	if sendErr != nil {
+		a.Status, a.Error = DeliveryFailed, sendErr.Error()
+	}
+	noti.Attempts = append(noti.Attempts, a)
+	if err := s.notiRepo.SaveAttempt(ctx, a); err != nil {
+		s.log.Warn("전송 시도 기록 실패", zap.String("notification_id", noti.ID), zap.Error(err))
+	}
+}
+
+// RunDeferredDelivery는 ctx가 끝날 때까지 미뤄진 알림을 주기적으로 발송합니다.
+func (s *NotificationService) RunDeferredDelivery(ctx context.Context) {
+	ticker := time.NewTicker(deferredPollInterval)
+	defer ticker.Stop()
+	for {
+		if _, err := s.DeliverDeferred(ctx); err != nil {
+			s.log.Warn("미뤄진 알림 발송 실패", zap.Error(err))
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// DeliverDeferred는 발송 시각이 된 미뤄진 알림을 보내고 처리한 수를 반환합니다.
+// 여러 레플리카가 동시에 실행해도 알림마다 하나만 발송합니다.
+func (s *NotificationService) DeliverDeferred(ctx context.Context) (int, error) {
+	now := s.now()
+	due, err := s.notiRepo.FindDeferred(ctx, now, deferredBatchSize)
+	if err != nil {
+		return 0, err
+	}
+	processed := 0
+	for _, noti := range due {
+		claimed, err := s.notiRepo.ClaimDeferred(ctx, noti.ID, deliverAtOf(noti), now.Add(deferredClaimLease))
+		if err != nil {
+			return processed, err
+		}
+		if !claimed {
+			continue
+		}
+		pref, err := s.prefRepo.FindByUserID(ctx, noti.UserID)
+		if err != nil {
+			pref = nil
+		}
+		if err := s.deliver(ctx, noti, s.preferencesOrDefault(noti.UserID, pref), true); err != nil {
+			s.log.Warn("채널 전송 실패 (알림은 저장됨)", zap.Error(err), zap.String("notification_id", noti.ID))
+		}
+		s.logSent(noti)
+		processed++
+	}
+	return processed, nil
+}
+
+func deliverAtOf(n *Notification) time.Time {
+	if n.DeliverAt == nil {
+		return time.Time{}
+	}
+	return *n.DeliverAt
+}
+
+func (s *NotificationService) logSent(noti *Notification) {
 	s.log.Info("알림 발송 완료",
 		zap.String("notification_id", noti.ID),
-		zap.String("user_id", userID),
-		zap.Int("channel", int(channel)),
-		zap.Int("type", int(nType)),
+		zap.String("user_id", noti.UserID),
+		zap.Int("channel", int(noti.Channel)),
+		zap.Int("type", int(noti.Type)),
 	)
-
-	return noti, nil
 }
 
 // selectBestChannel은 사용자 설정과 우선순위에 따라 최적의 채널을 선택합니다.
@@ -286,124 +565,100 @@ func (s *NotificationService) GetPreferences(ctx context.Context, userID string)
 		return nil, err
 	}
 
-	// 설정이 없으면 기본값 반환
-	if pref == nil {
-		pref = &NotificationPreferences{
-			UserID:             userID,
-			PushEnabled:        true,
-			EmailEnabled:       true,
-			SMSEnabled:         false,
-			InAppEnabled:       true,
-			HealthAlertEnabled: true,
-			CoachingEnabled:    true,
-			PromotionEnabled:   false,
-			Language:           "ko",
-		}
-	}
-
-	return pref, nil
+	return s.preferencesOrDefault(userID, pref), nil
 }
 
-// NotificationTemplate은 재사용 가능한 알림 템플릿을 정의합니다.
-type NotificationTemplate struct {
-	Key      string
-	Title    string
-	BodyFmt  string // fmt.Sprintf 포맷 문자열
-	Type     string // NotificationType 이름 ("prescription", "appointment", ...)
-	Priority string // "low", "normal", "high", "urgent"
-	Channel  string // "push", "email", "sms", "in_app"
-}
-
-// PredefinedTemplates는 모든 사전 정의된 알림 템플릿을 포함합니다.
-var PredefinedTemplates = map[string]NotificationTemplate{
-	// Prescription
-	"prescription_created":   {Key: "prescription_created", Title: "새 처방전 발행", BodyFmt: "%s 의사가 처방전을 발행했습니다", Type: "prescription", Priority: "high", Channel: "push"},
-	"prescription_sent":      {Key: "prescription_sent", Title: "처방전 전송 완료", BodyFmt: "%s 약국에 처방전이 전송되었습니다", Type: "prescription", Priority: "normal", Channel: "push"},
-	"prescription_ready":     {Key: "prescription_ready", Title: "약 조제 완료", BodyFmt: "%s 약국에서 약 조제가 완료되었습니다. 수령해 주세요", Type: "prescription", Priority: "high", Channel: "push"},
-	"prescription_dispensed": {Key: "prescription_dispensed", Title: "약 수령 완료", BodyFmt: "처방전 수령이 확인되었습니다", Type: "prescription", Priority: "normal", Channel: "in_app"},
-	// Delivery
-	"delivery_started": {Key: "delivery_started", Title: "배송 출발", BodyFmt: "처방약이 배송을 시작했습니다. 예상 도착: %s", Type: "prescription", Priority: "normal", Channel: "push"},
-	"delivery_arrived": {Key: "delivery_arrived", Title: "배송 완료", BodyFmt: "처방약이 도착했습니다", Type: "prescription", Priority: "high", Channel: "push"},
-	// Appointment
-	"appointment_reminder":  {Key: "appointment_reminder", Title: "진료 예약 알림", BodyFmt: "%s에 %s 예약이 있습니다", Type: "appointment", Priority: "high", Channel: "push"},
-	"appointment_cancelled": {Key: "appointment_cancelled", Title: "예약 취소", BodyFmt: "%s 예약이 취소되었습니다", Type: "appointment", Priority: "normal", Channel: "push"},
-	// Health alert
-	"health_alert_critical": {Key: "health_alert_critical", Title: "건강 이상 감지", BodyFmt: "%s 수치가 위험 범위입니다: %s", Type: "health_alert", Priority: "urgent", Channel: "push"},
-	"health_alert_warning":  {Key: "health_alert_warning", Title: "건강 주의", BodyFmt: "%s 수치가 주의 범위입니다: %s", Type: "health_alert", Priority: "high", Channel: "push"},
-	// Measurement
-	"measurement_complete": {Key: "measurement_complete", Title: "측정 완료", BodyFmt: "%s 측정이 완료되었습니다. 결과를 확인하세요", Type: "measurement", Priority: "normal", Channel: "in_app"},
-	// Family
-	"family_data_shared": {Key: "family_data_shared", Title: "가족 데이터 공유", BodyFmt: "%s님이 건강 데이터를 공유했습니다", Type: "system", Priority: "normal", Channel: "in_app"},
-}
-
-// parseNotificationType은 문자열에서 NotificationType으로 변환합니다.
-func parseNotificationType(s string) NotificationType {
-	switch s {
-	case "measurement":
-		return TypeMeasurement
-	case "health_alert":
-		return TypeHealthAlert
-	case "appointment":
-		return TypeAppointment
-	case "prescription":
-		return TypePrescription
-	case "community":
-		return TypeCommunity
-	case "system":
-		return TypeSystem
-	case "promotion":
-		return TypePromotion
-	default:
-		return TypeUnknown
+// preferencesOrDefault는 설정이 없으면 기본값을 반환합니다.
+func (s *NotificationService) preferencesOrDefault(userID string, pref *NotificationPreferences) *NotificationPreferences {
+	if pref != nil {
+		return pref
+	}
+	return &NotificationPreferences{
+		UserID:             userID,
+		PushEnabled:        true,
+		EmailEnabled:       true,
+		SMSEnabled:         false,
+		InAppEnabled:       true,
+		HealthAlertEnabled: true,
+		CoachingEnabled:    true,
+		PromotionEnabled:   false,
+		Language:           "ko",
 	}
 }
 
-// parseNotificationPriority는 문자열에서 NotificationPriority로 변환합니다.
-func parseNotificationPriority(s string) NotificationPriority {
-	switch s {
-	case "low":
-		return PriorityLow
-	case "normal":
-		return PriorityNormal
-	case "high":
-		return PriorityHigh
-	case "urgent":
-		return PriorityUrgent
-	default:
-		return PriorityNormal
+// NotifyGoalMilestone은 coaching-service의 목표 마일스톤(진행률 구간 도달·달성·실패)을 알림으로 보냅니다.
+// 코칭 알림을 끈 사용자에게는 보내지 않습니다.
+func (s *NotificationService) NotifyGoalMilestone(ctx context.Context, userID, kind, metricName string, milestone, streak int32) error {
+	pref, err := s.GetPreferences(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !pref.CoachingEnabled {
+		return nil
 	}
-}
 
-// parseNotificationChannel은 문자열에서 NotificationChannel로 변환합니다.
-func parseNotificationChannel(s string) NotificationChannel {
-	switch s {
-	case "push":
-		return ChannelPush
-	case "email":
-		return ChannelEmail
-	case "sms":
-		return ChannelSMS
-	case "in_app":
-		return ChannelInApp
+	switch kind {
+	case "achieved":
+		_, err = s.SendFromTemplate(ctx, userID, "goal_achieved", "", map[string]string{"metric_name": metricName})
+	case "failed":
+		_, err = s.SendFromTemplate(ctx, userID, "goal_failed", "", map[string]string{"metric_name": metricName})
 	default:
-		return ChannelUnknown
+		_, err = s.SendFromTemplate(ctx, userID, "goal_milestone", "", map[string]string{
+			"metric_name": metricName,
+			"milestone":   strconv.Itoa(int(milestone)),
+			"streak":      strconv.Itoa(int(streak)),
+		})
 	}
+	return err
 }
 
-// SendFromTemplate은 사전 정의된 템플릿을 사용하여 알림을 발송합니다.
-func (s *NotificationService) SendFromTemplate(ctx context.Context, userID, templateKey string, args ...interface{}) error {
-	tmpl, exists := PredefinedTemplates[templateKey]
-	if !exists {
-		return fmt.Errorf("알림 템플릿 없음: %s", templateKey)
+// NotifyIoTRuleTriggered는 iot-gateway-service의 알림 규칙 발동을 디바이스 사용자에게 보냅니다.
+func (s *NotificationService) NotifyIoTRuleTriggered(ctx context.Context, userID, ruleName, dataType string, value float64) error {
+	if ruleName == "" {
+		ruleName = dataType
 	}
-	body := fmt.Sprintf(tmpl.BodyFmt, args...)
-	nType := parseNotificationType(tmpl.Type)
-	priority := parseNotificationPriority(tmpl.Priority)
-	channel := parseNotificationChannel(tmpl.Channel)
-	_, err := s.SendNotification(ctx, userID, nType, channel, priority, tmpl.Title, body, "")
+	_, err := s.SendFromTemplate(ctx, userID, "iot_rule_triggered", "", map[string]string{
+		"rule_name": ruleName,
+		"data_type": dataType,
+		"value":     strconv.FormatFloat(value, 'f', -1, 64),
+	})
 	return err
 }
 
+// NotifyIoTCommandFailed는 iot-gateway-service가 디바이스에 전달하지 못한 명령(재시도 소진·TTL 만료)을
+// 디바이스 사용자에게 알립니다.
+func (s *NotificationService) NotifyIoTCommandFailed(ctx context.Context, userID, deviceID, commandType string) error {
+	_, err := s.SendFromTemplate(ctx, userID, "iot_command_failed", "", map[string]string{
+		"device_id":    deviceID,
+		"command_type": commandType,
+	})
+	return err
+}
+
+// NotifySafetyCheck는 AI 채팅 입력에서 응급 단서가 감지되었을 때 사용자에게 상태 확인을 요청합니다.
+// 키워드 감지만으로는 응급 여부를 알 수 없으므로 보호자·119 에스컬레이션은 시작하지 않고,
+// 사용자가 직접 긴급 호출하도록 안내합니다. 방해 금지 시간에도 미루지 않도록 템플릿은 긴급(urgent)입니다.
+func (s *NotificationService) NotifySafetyCheck(ctx context.Context, userID string) error {
+	_, err := s.SendFromTemplate(ctx, userID, "health_safety_check", "", nil)
+	return err
+}
+
+// NotificationChannelToString은 알림 채널을 문자열로 변환합니다.
+func NotificationChannelToString(c NotificationChannel) string {
+	switch c {
+	case ChannelPush:
+		return "push"
+	case ChannelEmail:
+		return "email"
+	case ChannelSMS:
+		return "sms"
+	case ChannelInApp:
+		return "in_app"
+	default:
+		return "unknown"
+	}
+}
+
 // NotificationTypeToString는 알림 타입을 문자열로 변환합니다.
 func NotificationTypeToString(t NotificationType) string {
 	switch t {
@@ -425,3 +680,49 @@ func NotificationTypeToString(t NotificationType) string {
 		return "unknown"
 	}
 }
+
+// NotificationPriorityToString은 알림 우선순위를 문자열로 변환합니다.
+func NotificationPriorityToString(p NotificationPriority) string {
+	switch p {
+	case PriorityLow:
+		return "low"
+	case PriorityNormal:
+		return "normal"
+	case PriorityHigh:
+		return "high"
+	case PriorityUrgent:
+		return "urgent"
+	default:
+		return "unknown"
+	}
+}
+
+// ParseNotificationType은 NotificationTypeToString의 역변환입니다. 알 수 없는 값은 TypeUnknown입니다.
+func ParseNotificationType(s string) NotificationType {
+	for t := TypeMeasurement; t <= TypePromotion; t++ {
+		if NotificationTypeToString(t) == s {
+			return t
+		}
+	}
+	return TypeUnknown
+}
+
+// ParseNotificationPriority는 NotificationPriorityToString의 역변환입니다. 알 수 없는 값은 PriorityUnknown입니다.
+func ParseNotificationPriority(s string) NotificationPriority {
+	for p := PriorityLow; p <= PriorityUrgent; p++ {
+		if NotificationPriorityToString(p) == s {
+			return p
+		}
+	}
+	return PriorityUnknown
+}
+
+// ParseNotificationChannel은 NotificationChannelToString의 역변환입니다. 알 수 없는 값은 ChannelUnknown입니다.
+func ParseNotificationChannel(s string) NotificationChannel {
+	for c := ChannelPush; c <= ChannelInApp; c++ {
+		if NotificationChannelToString(c) == s {
+			return c
+		}
+	}
+	return ChannelUnknown
+}
@@ -2,6 +2,7 @@ package service_test
 
 import (
 	"context"
+	"strings"
 	"testing"
 
 	"github.com/manpasik/backend/services/notification-service/internal/repository/memory"
@@ -273,7 +274,7 @@ func TestSendFromTemplate_Success(t *testing.T) {
 	ctx := context.Background()
 
 	// prescription_created 템플릿 사용
-	err := svc.SendFromTemplate(ctx, "user-tmpl", "prescription_created", "김의사")
+	_, err := svc.SendFromTemplate(ctx, "user-tmpl", "prescription_created", "", map[string]string{"doctor_name": "김의사"})
 	if err != nil {
 		t.Fatalf("SendFromTemplate 실패: %v", err)
 	}
@@ -304,7 +305,7 @@ func TestSendFromTemplate_HealthAlert(t *testing.T) {
 	svc := setupTestService()
 	ctx := context.Background()
 
-	err := svc.SendFromTemplate(ctx, "user-alert", "health_alert_critical", "혈당", "350 mg/dL")
+	_, err := svc.SendFromTemplate(ctx, "user-alert", "health_alert_critical", "", map[string]string{"biomarker": "혈당", "value": "350 mg/dL"})
 	if err != nil {
 		t.Fatalf("SendFromTemplate 실패: %v", err)
 	}
@@ -325,51 +326,76 @@ func TestSendFromTemplate_InvalidTemplate(t *testing.T) {
 	svc := setupTestService()
 	ctx := context.Background()
 
-	err := svc.SendFromTemplate(ctx, "user-1", "nonexistent_template")
+	_, err := svc.SendFromTemplate(ctx, "user-1", "nonexistent_template", "", nil)
 	if err == nil {
 		t.Fatal("존재하지 않는 템플릿에 에러가 반환되어야 함")
 	}
 }
 
-func TestPredefinedTemplatesExist(t *testing.T) {
+func TestDefaultTemplatesExist(t *testing.T) {
 	expectedKeys := []string{
 		"prescription_created", "prescription_sent", "prescription_ready", "prescription_dispensed",
 		"delivery_started", "delivery_arrived",
 		"appointment_reminder", "appointment_cancelled",
-		"health_alert_critical", "health_alert_warning",
+		"health_alert_critical", "health_alert_warning", "health_safety_check",
 		"measurement_complete",
 		"family_data_shared",
+		"iot_rule_triggered", "iot_command_failed",
+		"goal_milestone", "goal_achieved", "goal_failed",
 	}
 
-	for _, key := range expectedKeys {
-		tmpl, exists := service.PredefinedTemplates[key]
-		if !exists {
-			t.Errorf("템플릿 '%s'가 PredefinedTemplates에 없습니다", key)
-			continue
-		}
-		if tmpl.Key != key {
-			t.Errorf("템플릿 '%s'의 Key 불일치: got %s", key, tmpl.Key)
-		}
-		if tmpl.Title == "" {
-			t.Errorf("템플릿 '%s'의 Title이 비어 있습니다", key)
-		}
-		if tmpl.BodyFmt == "" {
-			t.Errorf("템플릿 '%s'의 BodyFmt가 비어 있습니다", key)
-		}
-		if tmpl.Type == "" {
-			t.Errorf("템플릿 '%s'의 Type이 비어 있습니다", key)
-		}
-		if tmpl.Priority == "" {
-			t.Errorf("템플릿 '%s'의 Priority가 비어 있습니다", key)
+	svc := setupTestService()
+	ctx := context.Background()
+	byKey := make(map[string]int)
+	for i := range service.DefaultTemplates {
+		tmpl := service.DefaultTemplates[i]
+		byKey[tmpl.Key]++
+		preview, err := svc.PreviewTemplate(ctx, "", "", &tmpl, nil)
+		if err != nil || !preview.Valid {
+			t.Errorf("템플릿 '%s/%s'가 유효하지 않습니다: %v %v", tmpl.Key, tmpl.Language, preview.Errors, err)
 		}
-		if tmpl.Channel == "" {
-			t.Errorf("템플릿 '%s'의 Channel이 비어 있습니다", key)
+	}
+
+	for _, key := range expectedKeys {
+		if byKey[key] != len(service.SupportedTemplateLanguages) {
+			t.Errorf("템플릿 '%s'의 언어 수: got %d, want %d", key, byKey[key], len(service.SupportedTemplateLanguages))
 		}
 	}
+	if len(byKey) != len(expectedKeys) {
+		t.Errorf("DefaultTemplates 키 수: got %d, want %d", len(byKey), len(expectedKeys))
+	}
+}
+
+func TestNotifyGoalMilestone(t *testing.T) {
+	svc := setupTestService()
+	ctx := context.Background()
 
-	// 총 템플릿 수 확인
-	if len(service.PredefinedTemplates) != len(expectedKeys) {
-		t.Errorf("PredefinedTemplates 수: got %d, want %d", len(service.PredefinedTemplates), len(expectedKeys))
+	if err := svc.NotifyGoalMilestone(ctx, "user-goal", "progress", "체중", 50, 3); err != nil {
+		t.Fatalf("마일스톤 알림 실패: %v", err)
+	}
+	if err := svc.NotifyGoalMilestone(ctx, "user-goal", "achieved", "체중", 100, 4); err != nil {
+		t.Fatalf("달성 알림 실패: %v", err)
+	}
+	list, total, _, err := svc.ListNotifications(ctx, "user-goal", service.TypeUnknown, false, 10, 0)
+	if err != nil || total != 2 {
+		t.Fatalf("알림 2건이 저장되어야 함: got %d (%v)", total, err)
+	}
+	var bodies []string
+	for _, n := range list {
+		bodies = append(bodies, n.Body)
+	}
+	joined := strings.Join(bodies, "|")
+	if !strings.Contains(joined, "체중 목표 진행률이 50%에 도달했습니다 (연속 기록 3일)") || !strings.Contains(joined, "체중 목표를 달성했습니다") {
+		t.Errorf("알림 본문 불일치: %v", bodies)
+	}
+
+	// 코칭 알림을 끈 사용자에게는 보내지 않음
+	svc.UpdatePreferences(ctx, &service.NotificationPreferences{UserID: "user-off", InAppEnabled: true, CoachingEnabled: false})
+	if err := svc.NotifyGoalMilestone(ctx, "user-off", "failed", "혈당", 25, 0); err != nil {
+		t.Fatalf("알림 생략은 에러가 아니어야 함: %v", err)
+	}
+	if _, total, _, _ := svc.ListNotifications(ctx, "user-off", service.TypeUnknown, false, 10, 0); total != 0 {
+		t.Errorf("코칭 알림 비활성 사용자에게 알림이 저장됨: %d", total)
 	}
 }
 
@@ -419,3 +445,35 @@ func TestEndToEnd_NotificationFlow(t *testing.T) {
 		t.Fatalf("전체 읽음 처리 수 불일치: got %d, want 2 (이미 1개 읽음)", count)
 	}
 }
+
+func TestNotifyIoTCommandFailed(t *testing.T) {
+	svc := setupTestService()
+	ctx := context.Background()
+
+	if err := svc.NotifyIoTCommandFailed(ctx, "user-iot", "purifier-1", "set_mode"); err != nil {
+		t.Fatalf("명령 실패 알림 실패: %v", err)
+	}
+	list, total, _, err := svc.ListNotifications(ctx, "user-iot", service.TypeUnknown, false, 10, 0)
+	if err != nil || total != 1 {
+		t.Fatalf("알림 1건이 저장되어야 함: got %d (%v)", total, err)
+	}
+	if list[0].Title != "기기 명령 실패" || !strings.Contains(list[0].Body, "purifier-1 기기에 보낸 set_mode 명령") {
+		t.Errorf("알림 내용 불일치: %q / %q", list[0].Title, list[0].Body)
+	}
+}
+
+func TestNotifyIoTRuleTriggered(t *testing.T) {
+	svc := setupTestService()
+	ctx := context.Background()
+
+	if err := svc.NotifyIoTRuleTriggered(ctx, "user-iot", "라돈 경보", "radon", 152.5); err != nil {
+		t.Fatalf("규칙 발동 알림 실패: %v", err)
+	}
+	list, total, _, err := svc.ListNotifications(ctx, "user-iot", service.TypeUnknown, false, 10, 0)
+	if err != nil || total != 1 {
+		t.Fatalf("알림 1건이 저장되어야 함: got %d (%v)", total, err)
+	}
+	if list[0].Title != "기기 알림: 라돈 경보" || !strings.Contains(list[0].Body, "radon 값이 설정한 조건에 도달했습니다: 152.5") {
+		t.Errorf("알림 내용 불일치: %q / %q", list[0].Title, list[0].Body)
+	}
+}
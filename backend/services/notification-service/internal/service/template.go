@@ -0,0 +1,444 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	apperrors "github.com/manpasik/backend/shared/errors"
+	"go.uber.org/zap"
+)
+
+// 템플릿 언어
+const (
+	DefaultTemplateLanguage = "ko"
+	fallbackTemplateLang    = "en"
+	templateSystemAuthor    = "system"
+)
+
+// SupportedTemplateLanguages는 템플릿을 작성할 수 있는 언어입니다.
+var SupportedTemplateLanguages = []string{"ko", "en", "ja", "zh"}
+
+var (
+	// placeholderPattern은 {{name}} 형식의 이름 있는 placeholder입니다.
+	placeholderPattern = regexp.MustCompile(`\{\{\s*([a-z][a-z0-9_]*)\s*\}\}`)
+	// placeholderAny는 이름 형식과 관계없이 {{...}}를 찾습니다 (검증용).
+	placeholderAny  = regexp.MustCompile(`\{\{([^{}]*)\}\}`)
+	templateKeyRule = regexp.MustCompile(`^[a-z][a-z0-9_]{1,63}$`)
+)
+
+// NotificationTemplate은 알림 템플릿의 언어별 버전입니다.
+// 제목·본문은 {{doctor_name}}처럼 이름 있는 placeholder를 사용하며, 저장할 때마다 새 버전이 생깁니다.
+type NotificationTemplate struct {
+	Key          string
+	Language     string // ko, en, ja, zh
+	Version      int
+	Title        string
+	Body         string
+	Type         NotificationType
+	Priority     NotificationPriority
+	Channel      NotificationChannel
+	Placeholders []string // 선언된 placeholder 이름
+	Deleted      bool     // 삭제 표시 버전 (이 버전이 최신이면 발송에 쓰지 않음)
+	UpdatedBy    string
+	CreatedAt    time.Time
+}
+
+// TemplatePreview는 템플릿 검증·렌더링 결과입니다.
+type TemplatePreview struct {
+	Valid               bool
+	Errors              []string
+	Language            string
+	Version             int
+	Title               string
+	Body                string
+	MissingPlaceholders []string
+}
+
+// TemplateRepository는 알림 템플릿 저장소 인터페이스입니다.
+type TemplateRepository interface {
+	// FindLatest는 (key, language)의 최신 버전을 반환합니다. 삭제 표시 버전도 포함하며, 없으면 nil입니다.
+	FindLatest(ctx context.Context, key, language string) (*NotificationTemplate, error)
+	FindVersion(ctx context.Context, key, language string, version int) (*NotificationTemplate, error)
+	// ListVersions는 (key, language)의 모든 버전을 최신순으로 반환합니다.
+	ListVersions(ctx context.Context, key, language string) ([]*NotificationTemplate, error)
+	// ListLatest는 삭제되지 않은 최신 버전 목록을 key·language 순으로 반환합니다. 빈 필터는 전체입니다.
+	ListLatest(ctx context.Context, key, language string) ([]*NotificationTemplate, error)
+	// Insert는 새 버전을 저장합니다. 같은 버전이 이미 있으면 false를 반환합니다.
+	Insert(ctx context.Context, t *NotificationTemplate) (bool, error)
+}
+
+// SetTemplateRepository는 템플릿 저장소를 설정합니다 (optional: nil이면 기본 템플릿만 사용하며 편집 불가).
+func (s *NotificationService) SetTemplateRepository(repo TemplateRepository) {
+	s.tmplRepo = repo
+}
+
+// EnsureDefaultTemplates는 저장소에 없는 기본 템플릿을 버전 1로 넣습니다.
+// 관리자가 삭제하거나 수정한 템플릿은 덮어쓰지 않습니다.
+func (s *NotificationService) EnsureDefaultTemplates(ctx context.Context) error {
+	if s.tmplRepo == nil {
+		return nil
+	}
+	for _, def := range DefaultTemplates {
+		existing, err := s.tmplRepo.FindLatest(ctx, def.Key, def.Language)
+		if err != nil {
+			return err
+		}
+		if existing != nil {
+			continue
+		}
+		t := def
+		t.Version, t.UpdatedBy, t.CreatedAt = 1, templateSystemAuthor, s.now()
+		if _, err := s.tmplRepo.Insert(ctx, &t); err != nil {
+			return fmt.Errorf("기본 템플릿 저장 실패 (%s/%s): %w", def.Key, def.Language, err)
+		}
+	}
+	return nil
+}
+
+// SendFromTemplate은 템플릿으로 알림을 발송합니다.
+// language가 비어 있으면 사용자 알림 설정 언어(없으면 프로필 언어)를 쓰고, 해당 언어 템플릿이 없으면 en → ko 순으로 폴백합니다.
+func (s *NotificationService) SendFromTemplate(ctx context.Context, userID, templateKey, language string, data map[string]string) (*Notification, error) {
+	if userID == "" || templateKey == "" {
+		return nil, apperrors.New(apperrors.ErrInvalidInput, "user_id와 template_key는 필수입니다")
+	}
+	if language == "" {
+		language = s.userLanguage(ctx, userID)
+	}
+	tmpl, err := s.resolveTemplate(ctx, templateKey, language)
+	if err != nil {
+		return nil, err
+	}
+	title, body, missing := renderTemplate(tmpl, data)
+	if len(missing) > 0 {
+		return nil, apperrors.New(apperrors.ErrInvalidInput, "템플릿 데이터가 누락되었습니다: "+strings.Join(missing, ", "))
+	}
+	return s.SendNotification(ctx, userID, tmpl.Type, tmpl.Channel, tmpl.Priority, title, body, "")
+}
+
+// userLanguage는 사용자 알림 설정 언어, 없으면 프로필 언어를 반환합니다.
+func (s *NotificationService) userLanguage(ctx context.Context, userID string) string {
+	if pref, err := s.prefRepo.FindByUserID(ctx, userID); err == nil && pref != nil && pref.Language != "" {
+		return pref.Language
+	}
+	if s.profiles != nil {
+		if lang, err := s.profiles.GetLanguage(ctx, userID); err == nil && lang != "" {
+			return lang
+		}
+	}
+	return DefaultTemplateLanguage
+}
+
+// resolveTemplate은 언어 폴백을 적용해 발송에 쓸 템플릿을 찾습니다.
+func (s *NotificationService) resolveTemplate(ctx context.Context, key, language string) (*NotificationTemplate, error) {
+	for _, lang := range templateLanguageChain(language) {
+		t, err := s.findTemplate(ctx, key, lang)
+		if err != nil {
+			return nil, err
+		}
+		if t != nil {
+			return t, nil
+		}
+	}
+	return nil, apperrors.New(apperrors.ErrNotFound, "알림 템플릿을 찾을 수 없습니다: "+key)
+}
+
+// findTemplate은 (key, language)의 발송 가능한 최신 버전을 반환합니다. 저장소가 없으면 기본 템플릿에서 찾습니다.
+func (s *NotificationService) findTemplate(ctx context.Context, key, language string) (*NotificationTemplate, error) {
+	if s.tmplRepo == nil {
+		for i := range DefaultTemplates {
+			if DefaultTemplates[i].Key == key && DefaultTemplates[i].Language == language {
+				t := DefaultTemplates[i]
+				t.Version = 1
+				return &t, nil
+			}
+		}
+		return nil, nil
+	}
+	t, err := s.tmplRepo.FindLatest(ctx, key, language)
+	if err != nil || t == nil || t.Deleted {
+		return nil, err
+	}
+	return t, nil
+}
+
+// templateLanguageChain은 "zh-CN" → [zh, en, ko]처럼 시도할 언어 순서를 반환합니다.
+func templateLanguageChain(language string) []string {
+	var chain []string
+	add := func(lang string) {
+		for _, l := range chain {
+			if l == lang {
+				return
+			}
+		}
+		chain = append(chain, lang)
+	}
+	if lang := normalizeLanguage(language); lang != "" {
+		add(lang)
+	}
+	add(fallbackTemplateLang)
+	add(DefaultTemplateLanguage)
+	return chain
+}
+
+// normalizeLanguage는 "en-US", "zh_TW"에서 기본 언어 코드만 소문자로 꺼냅니다.
+func normalizeLanguage(language string) string {
+	lang := strings.ToLower(strings.TrimSpace(language))
+	if i := strings.IndexAny(lang, "-_"); i >= 0 {
+		lang = lang[:i]
+	}
+	return lang
+}
+
+func isSupportedLanguage(language string) bool {
+	for _, l := range SupportedTemplateLanguages {
+		if l == language {
+			return true
+		}
+	}
+	return false
+}
+
+// renderTemplate은 placeholder를 data 값으로 채웁니다. 값이 없는 placeholder는 그대로 두고 이름을 반환합니다.
+func renderTemplate(t *NotificationTemplate, data map[string]string) (title, body string, missing []string) {
+	seen := make(map[string]bool)
+	fill := func(text string) string {
+		return placeholderPattern.ReplaceAllStringFunc(text, func(m string) string {
+			name := placeholderPattern.FindStringSubmatch(m)[1]
+			if v, ok := data[name]; ok {
+				return v
+			}
+			if !seen[name] {
+				seen[name] = true
+				missing = append(missing, name)
+			}
+			return m
+		})
+	}
+	return fill(t.Title), fill(t.Body), missing
+}
+
+// usedPlaceholders는 제목·본문에서 쓰인 placeholder 이름을 정렬해 반환합니다.
+func usedPlaceholders(t *NotificationTemplate) []string {
+	set := make(map[string]bool)
+	for _, text := range []string{t.Title, t.Body} {
+		for _, m := range placeholderPattern.FindAllStringSubmatch(text, -1) {
+			set[m[1]] = true
+		}
+	}
+	names := make([]string, 0, len(set))
+	for name := range set {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// validateTemplate은 템플릿의 형식 오류를 모두 반환합니다.
+func validateTemplate(t *NotificationTemplate) []string {
+	var errs []string
+	if !templateKeyRule.MatchString(t.Key) {
+		errs = append(errs, "template_key는 소문자·숫자·밑줄로 된 2~64자여야 합니다")
+	}
+	if !isSupportedLanguage(t.Language) {
+		errs = append(errs, "지원하지 않는 언어입니다: "+t.Language+" (ko, en, ja, zh)")
+	}
+	if strings.TrimSpace(t.Title) == "" {
+		errs = append(errs, "제목은 필수입니다")
+	}
+	if strings.TrimSpace(t.Body) == "" {
+		errs = append(errs, "본문은 필수입니다")
+	}
+	if t.Type == TypeUnknown {
+		errs = append(errs, "알림 유형이 올바르지 않습니다")
+	}
+	if t.Priority == PriorityUnknown {
+		errs = append(errs, "우선순위가 올바르지 않습니다")
+	}
+	if t.Channel == ChannelUnknown {
+		errs = append(errs, "채널이 올바르지 않습니다")
+	}
+
+	declared := make(map[string]bool, len(t.Placeholders))
+	for _, name := range t.Placeholders {
+		if !placeholderPattern.MatchString("{{" + name + "}}") {
+			errs = append(errs, "placeholder 이름이 올바르지 않습니다: "+name)
+		}
+		declared[name] = true
+	}
+	for _, text := range []string{t.Title, t.Body} {
+		for _, m := range placeholderAny.FindAllStringSubmatch(text, -1) {
+			if !placeholderPattern.MatchString(m[0]) {
+				errs = append(errs, "placeholder 형식이 올바르지 않습니다: "+m[0])
+			}
+		}
+		if strings.Count(text, "{{") != strings.Count(text, "}}") {
+			errs = append(errs, "닫히지 않은 placeholder가 있습니다")
+		}
+	}
+	for _, name := range usedPlaceholders(t) {
+		if !declared[name] {
+			errs = append(errs, "선언되지 않은 placeholder입니다: "+name)
+		}
+	}
+	return errs
+}
+
+// ============================================================================
+// 템플릿 관리 (admin-service가 호출)
+// ============================================================================
+
+// ListTemplates는 삭제되지 않은 템플릿의 최신 버전 목록을 반환합니다.
+func (s *NotificationService) ListTemplates(ctx context.Context, key, language string) ([]*NotificationTemplate, error) {
+	if s.tmplRepo == nil {
+		var list []*NotificationTemplate
+		for i := range DefaultTemplates {
+			t := DefaultTemplates[i]
+			if (key == "" || t.Key == key) && (language == "" || t.Language == language) {
+				t.Version = 1
+				list = append(list, &t)
+			}
+		}
+		return list, nil
+	}
+	return s.tmplRepo.ListLatest(ctx, key, normalizeLanguage(language))
+}
+
+// ListTemplateVersions는 템플릿 언어별 버전 이력을 최신순으로 반환합니다.
+func (s *NotificationService) ListTemplateVersions(ctx context.Context, key, language string) ([]*NotificationTemplate, error) {
+	if err := s.requireTemplateRepo(); err != nil {
+		return nil, err
+	}
+	if key == "" || language == "" {
+		return nil, apperrors.New(apperrors.ErrInvalidInput, "template_key와 language는 필수입니다")
+	}
+	return s.tmplRepo.ListVersions(ctx, key, normalizeLanguage(language))
+}
+
+// SaveTemplate은 템플릿을 검증해 새 버전으로 저장합니다.
+// baseVersion은 수정 기준 버전(새 템플릿은 0)이며, 그사이 다른 버전이 저장되었으면 충돌 오류를 반환합니다.
+func (s *NotificationService) SaveTemplate(ctx context.Context, t *NotificationTemplate, baseVersion int, updatedBy string) (*NotificationTemplate, error) {
+	if err := s.requireTemplateRepo(); err != nil {
+		return nil, err
+	}
+	if t == nil {
+		return nil, apperrors.New(apperrors.ErrInvalidInput, "템플릿은 필수입니다")
+	}
+	draft := *t
+	draft.Language = normalizeLanguage(draft.Language)
+	draft.Deleted = false
+	if errs := validateTemplate(&draft); len(errs) > 0 {
+		return nil, apperrors.New(apperrors.ErrValidationFailed, strings.Join(errs, "; "))
+	}
+	return s.insertVersion(ctx, &draft, baseVersion, updatedBy)
+}
+
+// DeleteTemplate은 템플릿 언어 변형을 삭제 표시 버전으로 남깁니다. 이력은 유지되며 RollbackTemplate으로 되살릴 수 있습니다.
+func (s *NotificationService) DeleteTemplate(ctx context.Context, key, language string, baseVersion int, updatedBy string) (*NotificationTemplate, error) {
+	if err := s.requireTemplateRepo(); err != nil {
+		return nil, err
+	}
+	latest, err := s.tmplRepo.FindLatest(ctx, key, normalizeLanguage(language))
+	if err != nil {
+		return nil, err
+	}
+	if latest == nil || latest.Deleted {
+		return nil, apperrors.New(apperrors.ErrNotFound, "알림 템플릿을 찾을 수 없습니다")
+	}
+	marker := *latest
+	marker.Deleted = true
+	return s.insertVersion(ctx, &marker, baseVersion, updatedBy)
+}
+
+// RollbackTemplate은 이전 버전의 내용을 새 버전으로 복사해 되돌립니다.
+func (s *NotificationService) RollbackTemplate(ctx context.Context, key, language string, version int, updatedBy string) (*NotificationTemplate, error) {
+	if err := s.requireTemplateRepo(); err != nil {
+		return nil, err
+	}
+	language = normalizeLanguage(language)
+	target, err := s.tmplRepo.FindVersion(ctx, key, language, version)
+	if err != nil {
+		return nil, err
+	}
+	if target == nil || target.Deleted {
+		return nil, apperrors.New(apperrors.ErrNotFound, "되돌릴 템플릿 버전을 찾을 수 없습니다")
+	}
+	latest, err := s.tmplRepo.FindLatest(ctx, key, language)
+	if err != nil {
+		return nil, err
+	}
+	restored := *target
+	return s.insertVersion(ctx, &restored, latest.Version, updatedBy)
+}
+
+// PreviewTemplate은 저장된 템플릿(언어 폴백 적용) 또는 초안을 검증하고 data로 렌더링합니다.
+func (s *NotificationService) PreviewTemplate(ctx context.Context, key, language string, draft *NotificationTemplate, data map[string]string) (*TemplatePreview, error) {
+	var tmpl *NotificationTemplate
+	if draft != nil {
+		d := *draft
+		d.Language = normalizeLanguage(d.Language)
+		tmpl = &d
+	} else {
+		t, err := s.resolveTemplate(ctx, key, language)
+		if err != nil {
+			return nil, err
+		}
+		tmpl = t
+	}
+
+	errs := validateTemplate(tmpl)
+	title, body, missing := renderTemplate(tmpl, data)
+	return &TemplatePreview{
+		Valid:               len(errs) == 0,
+		Errors:              errs,
+		Language:            tmpl.Language,
+		Version:             tmpl.Version,
+		Title:               title,
+		Body:                body,
+		MissingPlaceholders: missing,
+	}, nil
+}
+
+// insertVersion은 최신 버전이 baseVersion일 때만 다음 버전으로 저장합니다.
+func (s *NotificationService) insertVersion(ctx context.Context, t *NotificationTemplate, baseVersion int, updatedBy string) (*NotificationTemplate, error) {
+	latest, err := s.tmplRepo.FindLatest(ctx, t.Key, t.Language)
+	if err != nil {
+		return nil, err
+	}
+	current := 0
+	if latest != nil {
+		current = latest.Version
+	}
+	if baseVersion != current {
+		return nil, apperrors.New(apperrors.ErrConflict, fmt.Sprintf("템플릿이 그사이 수정되었습니다 (현재 버전 %d)", current))
+	}
+	if updatedBy == "" {
+		updatedBy = templateSystemAuthor
+	}
+	t.Version, t.UpdatedBy, t.CreatedAt = current+1, updatedBy, s.now()
+	ok, err := s.tmplRepo.Insert(ctx, t)
+	if err != nil {
+		return nil, fmt.Errorf("템플릿 저장 실패: %w", err)
+	}
+	if !ok {
+		return nil, apperrors.New(apperrors.ErrConflict, "템플릿이 그사이 수정되었습니다")
+	}
+	s.log.Info("알림 템플릿 저장",
+		zap.String("template_key", t.Key),
+		zap.String("language", t.Language),
+		zap.Int("version", t.Version),
+		zap.Bool("deleted", t.Deleted),
+		zap.String("updated_by", updatedBy),
+	)
+	return t, nil
+}
+
+func (s *NotificationService) requireTemplateRepo() error {
+	if s.tmplRepo == nil {
+		return apperrors.New(apperrors.ErrServiceUnavailable, "템플릿 저장소가 설정되지 않았습니다")
+	}
+	return nil
+}
@@ -0,0 +1,155 @@
+package service
+
+// defaultTemplate은 기본 템플릿 하나의 공통 속성과 언어별 [제목, 본문]입니다.
+type defaultTemplate struct {
+	key          string
+	nType        NotificationType
+	priority     NotificationPriority
+	channel      NotificationChannel
+	placeholders []string
+	texts        map[string][2]string
+}
+
+// DefaultTemplates는 기본 제공 알림 템플릿(ko, en, ja, zh)입니다.
+// 템플릿 저장소가 비어 있으면 EnsureDefaultTemplates가 버전 1로 넣고, 이후 관리자가 admin-service에서 편집합니다.
+var DefaultTemplates = buildDefaultTemplates([]defaultTemplate{
+	// Prescription
+	{"prescription_created", TypePrescription, PriorityHigh, ChannelPush, []string{"doctor_name"}, map[string][2]string{
+		"ko": {"새 처방전 발행", "{{doctor_name}} 의사가 처방전을 발행했습니다"},
+		"en": {"New prescription issued", "Dr. {{doctor_name}} has issued a prescription"},
+		"ja": {"新しい処方箋の発行", "{{doctor_name}}医師が処方箋を発行しました"},
+		"zh": {"新处方已开具", "{{doctor_name}}医生已为您开具处方"},
+	}},
+	{"prescription_sent", TypePrescription, PriorityNormal, ChannelPush, []string{"pharmacy_name"}, map[string][2]string{
+		"ko": {"처방전 전송 완료", "{{pharmacy_name}} 약국에 처방전이 전송되었습니다"},
+		"en": {"Prescription sent", "Your prescription has been sent to {{pharmacy_name}}"},
+		"ja": {"処方箋の送信完了", "{{pharmacy_name}}薬局に処方箋を送信しました"},
+		"zh": {"处方已发送", "您的处方已发送至{{pharmacy_name}}药房"},
+	}},
+	{"prescription_ready", TypePrescription, PriorityHigh, ChannelPush, []string{"pharmacy_name"}, map[string][2]string{
+		"ko": {"약 조제 완료", "{{pharmacy_name}} 약국에서 약 조제가 완료되었습니다. 수령해 주세요"},
+		"en": {"Medication ready", "Your medication is ready at {{pharmacy_name}}. Please pick it up"},
+		"ja": {"調剤完了", "{{pharmacy_name}}薬局で調剤が完了しました。お受け取りください"},
+		"zh": {"药品已备好", "{{pharmacy_name}}药房已完成配药，请前往领取"},
+	}},
+	{"prescription_dispensed", TypePrescription, PriorityNormal, ChannelInApp, nil, map[string][2]string{
+		"ko": {"약 수령 완료", "처방전 수령이 확인되었습니다"},
+		"en": {"Medication picked up", "Your prescription pickup has been confirmed"},
+		"ja": {"受け取り完了", "処方薬の受け取りが確認されました"},
+		"zh": {"药品已领取", "已确认您领取了处方药"},
+	}},
+	// Delivery
+	{"delivery_started", TypePrescription, PriorityNormal, ChannelPush, []string{"eta"}, map[string][2]string{
+		"ko": {"배송 출발", "처방약이 배송을 시작했습니다. 예상 도착: {{eta}}"},
+		"en": {"Delivery on the way", "Your medication is on its way. Estimated arrival: {{eta}}"},
+		"ja": {"配送開始", "処方薬の配送を開始しました。到着予定: {{eta}}"},
+		"zh": {"配送已出发", "您的处方药已开始配送，预计送达：{{eta}}"},
+	}},
+	{"delivery_arrived", TypePrescription, PriorityHigh, ChannelPush, nil, map[string][2]string{
+		"ko": {"배송 완료", "처방약이 도착했습니다"},
+		"en": {"Delivered", "Your medication has arrived"},
+		"ja": {"配送完了", "処方薬が到着しました"},
+		"zh": {"配送完成", "您的处方药已送达"},
+	}},
+	// Appointment
+	{"appointment_reminder", TypeAppointment, PriorityHigh, ChannelPush, []string{"appointment_time", "appointment_name"}, map[string][2]string{
+		"ko": {"진료 예약 알림", "{{appointment_time}}에 {{appointment_name}} 예약이 있습니다"},
+		"en": {"Appointment reminder", "You have a {{appointment_name}} appointment at {{appointment_time}}"},
+		"ja": {"診療予約のお知らせ", "{{appointment_time}}に{{appointment_name}}の予約があります"},
+		"zh": {"预约提醒", "您在{{appointment_time}}有{{appointment_name}}预约"},
+	}},
+	{"appointment_cancelled", TypeAppointment, PriorityNormal, ChannelPush, []string{"appointment_name"}, map[string][2]string{
+		"ko": {"예약 취소", "{{appointment_name}} 예약이 취소되었습니다"},
+		"en": {"Appointment cancelled", "Your {{appointment_name}} appointment has been cancelled"},
+		"ja": {"予約キャンセル", "{{appointment_name}}の予約がキャンセルされました"},
+		"zh": {"预约已取消", "您的{{appointment_name}}预约已取消"},
+	}},
+	// Health alert
+	{"health_alert_critical", TypeHealthAlert, PriorityUrgent, ChannelPush, []string{"biomarker", "value"}, map[string][2]string{
+		"ko": {"건강 이상 감지", "{{biomarker}} 수치가 위험 범위입니다: {{value}}"},
+		"en": {"Health alert", "Your {{biomarker}} level is in the critical range: {{value}}"},
+		"ja": {"健康異常を検知", "{{biomarker}}の数値が危険範囲です: {{value}}"},
+		"zh": {"健康异常警报", "您的{{biomarker}}数值处于危险范围：{{value}}"},
+	}},
+	{"health_alert_warning", TypeHealthAlert, PriorityHigh, ChannelPush, []string{"biomarker", "value"}, map[string][2]string{
+		"ko": {"건강 주의", "{{biomarker}} 수치가 주의 범위입니다: {{value}}"},
+		"en": {"Health warning", "Your {{biomarker}} level is in the caution range: {{value}}"},
+		"ja": {"健康注意", "{{biomarker}}の数値が注意範囲です: {{value}}"},
+		"zh": {"健康提醒", "您的{{biomarker}}数值处于注意范围：{{value}}"},
+	}},
+	{"health_safety_check", TypeHealthAlert, PriorityUrgent, ChannelPush, nil, map[string][2]string{
+		"ko": {"괜찮으신가요?", "AI 상담 중 응급 증상과 관련된 말씀이 있었습니다. 지금 증상이 있다면 즉시 119에 연락하거나 앱의 긴급 버튼을 눌러 주세요"},
+		"en": {"Are you okay?", "You mentioned emergency symptoms in an AI chat. If you have these symptoms now, call emergency services or tap the emergency button in the app"},
+		"ja": {"大丈夫ですか？", "AI相談で緊急症状に関するお話がありました。今症状がある場合はすぐに119へ連絡するか、アプリの緊急ボタンを押してください"},
+		"zh": {"您还好吗？", "您在AI咨询中提到了紧急症状。如果现在有这些症状，请立即拨打急救电话或点击应用中的紧急按钮"},
+	}},
+	// Measurement
+	{"measurement_complete", TypeMeasurement, PriorityNormal, ChannelInApp, []string{"measurement_name"}, map[string][2]string{
+		"ko": {"측정 완료", "{{measurement_name}} 측정이 완료되었습니다. 결과를 확인하세요"},
+		"en": {"Measurement complete", "Your {{measurement_name}} measurement is complete. Check your results"},
+		"ja": {"測定完了", "{{measurement_name}}の測定が完了しました。結果をご確認ください"},
+		"zh": {"测量完成", "{{measurement_name}}测量已完成，请查看结果"},
+	}},
+	// Family
+	{"family_data_shared", TypeSystem, PriorityNormal, ChannelInApp, []string{"member_name"}, map[string][2]string{
+		"ko": {"가족 데이터 공유", "{{member_name}}님이 건강 데이터를 공유했습니다"},
+		"en": {"Family data shared", "{{member_name}} shared their health data with you"},
+		"ja": {"家族データの共有", "{{member_name}}さんが健康データを共有しました"},
+		"zh": {"家人数据共享", "{{member_name}}与您共享了健康数据"},
+	}},
+	// IoT
+	{"iot_rule_triggered", TypeHealthAlert, PriorityHigh, ChannelPush, []string{"rule_name", "data_type", "value"}, map[string][2]string{
+		"ko": {"기기 알림: {{rule_name}}", "{{data_type}} 값이 설정한 조건에 도달했습니다: {{value}}"},
+		"en": {"Device alert: {{rule_name}}", "Your {{data_type}} reading met the rule condition: {{value}}"},
+		"ja": {"機器アラート: {{rule_name}}", "{{data_type}}の値が設定した条件に達しました: {{value}}"},
+		"zh": {"设备提醒：{{rule_name}}", "{{data_type}}数值已达到设定条件：{{value}}"},
+	}},
+	{"iot_command_failed", TypeSystem, PriorityNormal, ChannelPush, []string{"device_id", "command_type"}, map[string][2]string{
+		"ko": {"기기 명령 실패", "{{device_id}} 기기에 보낸 {{command_type}} 명령을 전달하지 못했습니다. 기기 연결을 확인한 뒤 다시 시도해 주세요."},
+		"en": {"Device command failed", "The {{command_type}} command could not be delivered to device {{device_id}}. Check the device connection and try again."},
+		"ja": {"機器コマンド失敗", "{{device_id}}機器に送信した{{command_type}}コマンドを届けられませんでした。機器の接続を確認してから再度お試しください。"},
+		"zh": {"设备指令失败", "发送到设备{{device_id}}的{{command_type}}指令未能送达。请检查设备连接后重试。"},
+	}},
+	// Coaching goal
+	{"goal_milestone", TypeSystem, PriorityNormal, ChannelInApp, []string{"metric_name", "milestone", "streak"}, map[string][2]string{
+		"ko": {"목표 진행 알림", "{{metric_name}} 목표 진행률이 {{milestone}}%에 도달했습니다 (연속 기록 {{streak}}일)"},
+		"en": {"Goal progress", "Your {{metric_name}} goal is {{milestone}}% complete ({{streak}}-day streak)"},
+		"ja": {"目標の進捗", "{{metric_name}}の目標達成率が{{milestone}}%に到達しました（連続記録{{streak}}日）"},
+		"zh": {"目标进度", "您的{{metric_name}}目标完成度已达{{milestone}}%（连续记录{{streak}}天）"},
+	}},
+	{"goal_achieved", TypeSystem, PriorityNormal, ChannelPush, []string{"metric_name"}, map[string][2]string{
+		"ko": {"목표 달성", "{{metric_name}} 목표를 달성했습니다. 축하합니다!"},
+		"en": {"Goal achieved", "You reached your {{metric_name}} goal. Congratulations!"},
+		"ja": {"目標達成", "{{metric_name}}の目標を達成しました。おめでとうございます！"},
+		"zh": {"目标达成", "您已达成{{metric_name}}目标，恭喜！"},
+	}},
+	{"goal_failed", TypeSystem, PriorityLow, ChannelInApp, []string{"metric_name"}, map[string][2]string{
+		"ko": {"목표 기간 종료", "{{metric_name}} 목표 기간이 끝났습니다. 새 목표로 다시 도전해 보세요"},
+		"en": {"Goal period ended", "Your {{metric_name}} goal period has ended. Try again with a new goal"},
+		"ja": {"目標期間終了", "{{metric_name}}の目標期間が終了しました。新しい目標で再挑戦しましょう"},
+		"zh": {"目标周期结束", "您的{{metric_name}}目标周期已结束，设定新目标再试一次吧"},
+	}},
+})
+
+func buildDefaultTemplates(defs []defaultTemplate) []NotificationTemplate {
+	var list []NotificationTemplate
+	for _, d := range defs {
+		for _, lang := range SupportedTemplateLanguages {
+			text, ok := d.texts[lang]
+			if !ok {
+				continue
+			}
+			list = append(list, NotificationTemplate{
+				Key:          d.key,
+				Language:     lang,
+				Title:        text[0],
+				Body:         text[1],
+				Type:         d.nType,
+				Priority:     d.priority,
+				Channel:      d.channel,
+				Placeholders: d.placeholders,
+			})
+		}
+	}
+	return list
+}
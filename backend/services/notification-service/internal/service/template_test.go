@@ -0,0 +1,158 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/manpasik/backend/services/notification-service/internal/repository/memory"
+	"github.com/manpasik/backend/services/notification-service/internal/service"
+	apperrors "github.com/manpasik/backend/shared/errors"
+	"go.uber.org/zap"
+)
+
+type languageProfiles map[string]string // userID → 프로필 언어
+
+func (p languageProfiles) GetLanguage(_ context.Context, userID string) (string, error) {
+	return p[userID], nil
+}
+
+func (p languageProfiles) GetTimezone(_ context.Context, _ string) (string, error) { return "", nil }
+
+func setupTemplateService(t *testing.T) *service.NotificationService {
+	t.Helper()
+	svc := service.NewNotificationService(zap.NewNop(), memory.NewNotificationRepository(), memory.NewPreferencesRepository())
+	svc.SetTemplateRepository(memory.NewTemplateRepository())
+	if err := svc.EnsureDefaultTemplates(context.Background()); err != nil {
+		t.Fatalf("기본 템플릿 저장 실패: %v", err)
+	}
+	return svc
+}
+
+func hasErrorCode(err error, code apperrors.ErrorCode) bool {
+	var appErr *apperrors.AppError
+	return errors.As(err, &appErr) && appErr.Code == code
+}
+
+func TestSendFromTemplate_LanguageFallback(t *testing.T) {
+	svc := setupTemplateService(t)
+	ctx := context.Background()
+	svc.SetUserProfileClient(languageProfiles{"user-ja": "ja", "user-fr": "fr"})
+	svc.UpdatePreferences(ctx, &service.NotificationPreferences{UserID: "user-en", InAppEnabled: true, Language: "en-US"})
+
+	data := map[string]string{"measurement_name": "Glucose"}
+	tests := []struct {
+		userID, language, wantTitle string
+	}{
+		{"user-en", "", "Measurement complete"}, // 알림 설정 언어 (en-US → en)
+		{"user-ja", "", "測定完了"},                 // 설정이 없으면 프로필 언어
+		{"user-fr", "", "Measurement complete"}, // 지원하지 않는 언어 → en
+		{"user-none", "", "측정 완료"},              // 언어 정보 없음 → ko
+		{"user-en", "zh-CN", "测量完成"},            // 요청 언어 우선
+	}
+	for _, tt := range tests {
+		noti, err := svc.SendFromTemplate(ctx, tt.userID, "measurement_complete", tt.language, data)
+		if err != nil {
+			t.Fatalf("%s: 발송 실패: %v", tt.userID, err)
+		}
+		if noti.Title != tt.wantTitle || !strings.Contains(noti.Body, "Glucose") {
+			t.Errorf("%s(%q): got %q / %q, want title %q", tt.userID, tt.language, noti.Title, noti.Body, tt.wantTitle)
+		}
+	}
+
+	// en 변형을 삭제하면 ko로 폴백
+	latest, _ := svc.ListTemplateVersions(ctx, "measurement_complete", "en")
+	if _, err := svc.DeleteTemplate(ctx, "measurement_complete", "en", latest[0].Version, "admin-1"); err != nil {
+		t.Fatalf("삭제 실패: %v", err)
+	}
+	noti, _ := svc.SendFromTemplate(ctx, "user-fr", "measurement_complete", "", data)
+	if noti.Title != "측정 완료" {
+		t.Errorf("en 삭제 후 title = %q, want ko", noti.Title)
+	}
+
+	// 누락된 placeholder 데이터는 발송하지 않음
+	if _, err := svc.SendFromTemplate(ctx, "user-en", "measurement_complete", "", nil); !hasErrorCode(err, apperrors.ErrInvalidInput) {
+		t.Errorf("데이터 누락 에러 = %v, want InvalidInput", err)
+	}
+}
+
+func TestSaveTemplate_Versioning(t *testing.T) {
+	svc := setupTemplateService(t)
+	ctx := context.Background()
+
+	draft := &service.NotificationTemplate{
+		Key: "delivery_arrived", Language: "en", Title: "Package arrived", Body: "Your medication from {{pharmacy_name}} has arrived",
+		Type: service.TypePrescription, Priority: service.PriorityHigh, Channel: service.ChannelPush, Placeholders: []string{"pharmacy_name"},
+	}
+	v2, err := svc.SaveTemplate(ctx, draft, 1, "admin-1")
+	if err != nil || v2.Version != 2 || v2.UpdatedBy != "admin-1" {
+		t.Fatalf("저장 결과 = %+v, %v", v2, err)
+	}
+
+	// 오래된 기준 버전으로 저장하면 충돌
+	if _, err := svc.SaveTemplate(ctx, draft, 1, "admin-2"); !hasErrorCode(err, apperrors.ErrConflict) {
+		t.Fatalf("충돌 에러 = %v, want Conflict", err)
+	}
+
+	// 버전 1로 롤백하면 버전 3으로 복사
+	v3, err := svc.RollbackTemplate(ctx, "delivery_arrived", "en", 1, "admin-1")
+	if err != nil || v3.Version != 3 || v3.Title != "Delivered" {
+		t.Fatalf("롤백 결과 = %+v, %v", v3, err)
+	}
+	versions, _ := svc.ListTemplateVersions(ctx, "delivery_arrived", "en")
+	if len(versions) != 3 || versions[0].Version != 3 || versions[2].Version != 1 {
+		t.Fatalf("버전 이력 = %d건", len(versions))
+	}
+
+	// 삭제된 템플릿은 목록에서 빠지고 새 버전(삭제 표시)이 남음
+	deleted, err := svc.DeleteTemplate(ctx, "delivery_arrived", "en", 3, "admin-1")
+	if err != nil || !deleted.Deleted || deleted.Version != 4 {
+		t.Fatalf("삭제 결과 = %+v, %v", deleted, err)
+	}
+	list, _ := svc.ListTemplates(ctx, "delivery_arrived", "")
+	if len(list) != 3 {
+		t.Errorf("삭제 후 언어 수 = %d, want 3", len(list))
+	}
+
+	// 재시작해도 관리자가 바꾼 템플릿은 덮어쓰지 않음
+	if err := svc.EnsureDefaultTemplates(ctx); err != nil {
+		t.Fatalf("기본 템플릿 재적용 실패: %v", err)
+	}
+	if versions, _ := svc.ListTemplateVersions(ctx, "delivery_arrived", "en"); len(versions) != 4 {
+		t.Errorf("기본 템플릿이 덮어씀: %d건", len(versions))
+	}
+}
+
+func TestPreviewTemplate_Validation(t *testing.T) {
+	svc := setupTemplateService(t)
+	ctx := context.Background()
+
+	preview, err := svc.PreviewTemplate(ctx, "appointment_reminder", "ja", nil, map[string]string{"appointment_time": "10:00"})
+	if err != nil {
+		t.Fatalf("미리보기 실패: %v", err)
+	}
+	if !preview.Valid || preview.Language != "ja" || preview.Version != 1 ||
+		!strings.HasPrefix(preview.Body, "10:00に") || len(preview.MissingPlaceholders) != 1 || preview.MissingPlaceholders[0] != "appointment_name" {
+		t.Fatalf("미리보기 = %+v", preview)
+	}
+
+	invalid := &service.NotificationTemplate{
+		Key: "custom_notice", Language: "fr", Title: "", Body: "Hello {{user_name}}, {{ bad-name }}",
+		Type: service.TypeSystem, Priority: service.PriorityNormal, Channel: service.ChannelInApp,
+	}
+	preview, _ = svc.PreviewTemplate(ctx, "", "", invalid, nil)
+	if preview.Valid {
+		t.Fatal("잘못된 초안이 유효로 판정됨")
+	}
+	joined := strings.Join(preview.Errors, "|")
+	for _, want := range []string{"지원하지 않는 언어", "제목은 필수", "placeholder 형식", "선언되지 않은 placeholder입니다: user_name"} {
+		if !strings.Contains(joined, want) {
+			t.Errorf("검증 오류에 %q 없음: %v", want, preview.Errors)
+		}
+	}
+
+	if _, err := svc.SaveTemplate(ctx, invalid, 0, "admin-1"); !hasErrorCode(err, apperrors.ErrValidationFailed) {
+		t.Errorf("잘못된 템플릿 저장 에러 = %v, want ValidationFailed", err)
+	}
+}
@@ -27,12 +27,15 @@ import (
 	"github.com/manpasik/backend/services/prescription-service/internal/repository/memory"
 	"github.com/manpasik/backend/services/prescription-service/internal/repository/postgres"
 	"github.com/manpasik/backend/services/prescription-service/internal/service"
+	"github.com/manpasik/backend/shared/clients"
 	"github.com/manpasik/backend/shared/config"
 	v1 "github.com/manpasik/backend/shared/gen/go/v1"
 	"github.com/manpasik/backend/shared/middleware"
 	"github.com/manpasik/backend/shared/observability"
+	"github.com/manpasik/backend/shared/scheduler"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/health"
 	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
@@ -61,6 +64,7 @@ func main() {
 	var prescriptionRepo service.PrescriptionRepository
 	var interactionRepo service.DrugInteractionRepository
 	var tokenRepo service.TokenRepository
+	var scheduleStore scheduler.Store = scheduler.NewMemoryStore()
 
 	if _, dbHostSet := os.LookupEnv("DB_HOST"); dbHostSet && cfg.DB.Host != "" && cfg.DB.DBName != "" {
 		connCtx, connCancel := context.WithTimeout(context.Background(), 5*time.Second)
@@ -86,6 +90,7 @@ func main() {
 				prescriptionRepo = postgres.NewPrescriptionRepository(pool)
 				interactionRepo = postgres.NewDrugInteractionRepository(pool)
 				tokenRepo = postgres.NewTokenRepository(pool)
+				scheduleStore = scheduler.NewPostgresStore(pool)
 				log.Printf("[%s] DB 연결됨: %s", serviceName, cfg.DB.DBName)
 			}
 		}
@@ -98,6 +103,35 @@ func main() {
 
 	prescriptionSvc := service.NewPrescriptionService(logger, prescriptionRepo, interactionRepo, tokenRepo)
 
+	// 복약 알림 스케줄: 사용자 시간대(USER_SERVICE_ADDR)·방해 금지 시간(NOTIFICATION_SERVICE_ADDR) 반영, 레플리카 간 1회 발송
+	var scheduleSettings scheduler.ClientSettings
+	if userAddr := os.Getenv("USER_SERVICE_ADDR"); userAddr != "" {
+		userConn, dialErr := grpc.NewClient(userAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		if dialErr != nil {
+			log.Printf("[%s] user-service 연결 실패, 복약 알림은 UTC 기준: %v", serviceName, dialErr)
+		} else {
+			defer userConn.Close()
+			scheduleSettings.Profiles = clients.NewGRPCUserProfileClient(v1.NewUserServiceClient(userConn))
+			log.Printf("[%s] user-service 연결됨: %s", serviceName, userAddr)
+		}
+	}
+	if notificationAddr := os.Getenv("NOTIFICATION_SERVICE_ADDR"); notificationAddr != "" {
+		notificationConn, dialErr := grpc.NewClient(notificationAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		if dialErr != nil {
+			log.Printf("[%s] notification-service 연결 실패, 복약 알림 미발송: %v", serviceName, dialErr)
+		} else {
+			defer notificationConn.Close()
+			notifications := clients.NewGRPCNotificationClient(v1.NewNotificationServiceClient(notificationConn))
+			prescriptionSvc.SetNotificationClient(notifications)
+			scheduleSettings.Preferences = notifications
+			log.Printf("[%s] notification-service 연결됨: %s", serviceName, notificationAddr)
+		}
+	} else {
+		log.Printf("[%s] NOTIFICATION_SERVICE_ADDR 미설정 — 복약 알림은 기록만 함", serviceName)
+	}
+	reminderScheduler := scheduler.New(scheduleStore, scheduler.WithSettings(scheduleSettings))
+	prescriptionSvc.SetScheduler(reminderScheduler)
+
 	grpcServer := grpc.NewServer(
 		grpc.ChainUnaryInterceptor(
 			middleware.RequestIDInterceptor(),
@@ -122,6 +156,8 @@ func main() {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
+	go reminderScheduler.Start(ctx)
+
 	go func() {
 		sigCh := make(chan os.Signal, 1)
 		signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
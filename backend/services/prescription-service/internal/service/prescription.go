@@ -8,7 +8,9 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/manpasik/backend/shared/clients"
 	apperrors "github.com/manpasik/backend/shared/errors"
+	"github.com/manpasik/backend/shared/scheduler"
 	"go.uber.org/zap"
 )
 
@@ -157,6 +159,8 @@ type PrescriptionService struct {
 	interactionRepo  DrugInteractionRepository
 	tokenRepo        TokenRepository
 	eventPub         EventPublisher
+	scheduler        *scheduler.Scheduler      // optional: nil이면 복약 알림 예약 비활성화
+	notifier         clients.NotificationClient // optional: nil이면 복약 알림을 기록만 함
 }
 
 // NewPrescriptionService는 PrescriptionService를 생성합니다.
@@ -213,6 +217,7 @@ func (s *PrescriptionService) CreatePrescription(ctx context.Context, patientUse
 		zap.String("patient_user_id", patientUserID),
 		zap.Int("medication_count", len(medications)),
 	)
+	s.syncReminderSchedule(ctx, prescription)
 
 	// Publish prescription.created event
 	if s.eventPub != nil {
@@ -274,6 +279,7 @@ func (s *PrescriptionService) UpdatePrescriptionStatus(ctx context.Context, pres
 		zap.String("prescription_id", prescriptionID),
 		zap.Int("new_status", int(newStatus)),
 	)
+	s.syncReminderSchedule(ctx, prescription)
 
 	return prescription, nil
 }
@@ -305,6 +311,7 @@ func (s *PrescriptionService) AddMedication(ctx context.Context, prescriptionID
 		zap.String("prescription_id", prescriptionID),
 		zap.String("drug_name", medication.DrugName),
 	)
+	s.syncReminderSchedule(ctx, prescription)
 
 	return prescription, nil
 }
@@ -346,6 +353,7 @@ func (s *PrescriptionService) RemoveMedication(ctx context.Context, prescription
 		zap.String("prescription_id", prescriptionID),
 		zap.String("medication_id", medicationID),
 	)
+	s.syncReminderSchedule(ctx, prescription)
 
 	return prescription, nil
 }
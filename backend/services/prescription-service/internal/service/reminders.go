@@ -0,0 +1,126 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/manpasik/backend/shared/clients"
+	apperrors "github.com/manpasik/backend/shared/errors"
+	"github.com/manpasik/backend/shared/scheduler"
+	"go.uber.org/zap"
+)
+
+// ScheduleKindMedicationReminder는 복약 알림 스케줄 종류입니다 (shared/scheduler Kind).
+const ScheduleKindMedicationReminder = "prescription.medication_reminder"
+
+// SetScheduler는 복약 알림 스케줄러를 설정하고 핸들러를 등록합니다 (optional).
+// 설정하면 활성 처방전마다 복용 시각(사용자 현지)에 알림을 예약합니다.
+func (s *PrescriptionService) SetScheduler(sch *scheduler.Scheduler) {
+	s.scheduler = sch
+	sch.Handle(ScheduleKindMedicationReminder, s.runMedicationReminder)
+}
+
+// SetNotificationClient는 복약 알림 발송 클라이언트를 설정합니다 (optional).
+func (s *PrescriptionService) SetNotificationClient(c clients.NotificationClient) {
+	s.notifier = c
+}
+
+func reminderScheduleID(prescriptionID string) string {
+	return "rx:" + prescriptionID
+}
+
+// remindsMedication은 처방전이 복약 알림 대상인지 확인합니다 (활성·미만료·약물 있음).
+func remindsMedication(p *Prescription, now time.Time) bool {
+	if p.Status != StatusActive || len(p.Medications) == 0 {
+		return false
+	}
+	return p.ExpiresAt.IsZero() || now.Before(p.ExpiresAt)
+}
+
+// reminderTimes는 처방전 약물들의 복용 시각 합집합입니다.
+func reminderTimes(p *Prescription) []string {
+	seen := make(map[string]bool)
+	var times []string
+	for _, m := range p.Medications {
+		for _, t := range parseFrequencyToTimes(m.Frequency) {
+			if !seen[t] {
+				seen[t] = true
+				times = append(times, t)
+			}
+		}
+	}
+	sort.Strings(times)
+	return times
+}
+
+// syncReminderSchedule은 처방전 상태·약물에 맞게 복약 알림 스케줄을 등록·갱신·해제합니다.
+// 스케줄 실패는 처방전 변경을 막지 않고 기록만 남깁니다.
+func (s *PrescriptionService) syncReminderSchedule(ctx context.Context, p *Prescription) {
+	if s.scheduler == nil {
+		return
+	}
+	id := reminderScheduleID(p.ID)
+	if !remindsMedication(p, time.Now()) {
+		if err := s.scheduler.Remove(ctx, id); err != nil {
+			s.log.Warn("복약 알림 스케줄 해제 실패", zap.String("prescription_id", p.ID), zap.Error(err))
+		}
+		return
+	}
+	_, err := s.scheduler.Put(ctx, &scheduler.Schedule{
+		ID:         id,
+		Kind:       ScheduleKindMedicationReminder,
+		UserID:     p.PatientUserID,
+		TimesOfDay: reminderTimes(p),
+		Payload:    map[string]string{"prescription_id": p.ID},
+	})
+	if err != nil {
+		s.log.Warn("복약 알림 스케줄 등록 실패", zap.String("prescription_id", p.ID), zap.Error(err))
+	}
+}
+
+// runMedicationReminder는 예약 시각에 복용할 약물을 알림으로 보냅니다.
+// 처방전이 더 이상 알림 대상이 아니면 스케줄을 해제합니다.
+func (s *PrescriptionService) runMedicationReminder(ctx context.Context, run scheduler.Run) error {
+	prescriptionID := run.Schedule.Payload["prescription_id"]
+	p, err := s.prescriptionRepo.FindByID(ctx, prescriptionID)
+	var appErr *apperrors.AppError
+	if err != nil && !(errors.As(err, &appErr) && appErr.Code == apperrors.ErrNotFound) {
+		return err
+	}
+	if p == nil || !remindsMedication(p, run.FireAt) {
+		s.log.Info("복약 알림 대상 아님, 스케줄 해제", zap.String("prescription_id", prescriptionID))
+		return s.scheduler.Remove(ctx, run.Schedule.ID)
+	}
+
+	slot := run.Local.Format("15:04")
+	var due []string
+	for _, m := range p.Medications {
+		for _, t := range parseFrequencyToTimes(m.Frequency) {
+			if t == slot {
+				due = append(due, strings.TrimSpace(m.DrugName+" "+m.Dosage))
+			}
+		}
+	}
+	if len(due) == 0 {
+		return nil
+	}
+
+	title := "복약 시간입니다"
+	body := fmt.Sprintf("%s 복용분: %s", slot, strings.Join(due, ", "))
+	if !run.FireAt.Equal(run.SlotAt) {
+		title = "방해 금지 시간 중 복약 알림"
+	}
+	if s.notifier == nil {
+		s.log.Info("복약 알림 (알림 클라이언트 없음)", zap.String("run", run.Key()), zap.String("body", body))
+		return nil
+	}
+	if err := s.notifier.SendNotification(ctx, p.PatientUserID, "prescription", title, body, "high", "push"); err != nil {
+		s.log.Warn("복약 알림 발송 실패", zap.String("run", run.Key()), zap.Int("attempt", run.Attempt), zap.Error(err))
+		return err
+	}
+	return nil
+}
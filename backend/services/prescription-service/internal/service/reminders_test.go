@@ -0,0 +1,86 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/manpasik/backend/services/prescription-service/internal/repository/memory"
+	"github.com/manpasik/backend/services/prescription-service/internal/service"
+	"github.com/manpasik/backend/shared/scheduler"
+	"go.uber.org/zap"
+)
+
+type sentReminder struct {
+	userID, notifType, title, body string
+}
+
+type fakeNotifier struct {
+	sent []sentReminder
+}
+
+func (n *fakeNotifier) SendNotification(_ context.Context, userID, notifType, title, body, _, _ string) error {
+	n.sent = append(n.sent, sentReminder{userID: userID, notifType: notifType, title: title, body: body})
+	return nil
+}
+
+func TestMedicationReminders_ScheduledAndDelivered(t *testing.T) {
+	ctx := context.Background()
+	day := time.Now().UTC().Truncate(24 * time.Hour)
+	now := day.Add(time.Hour) // 01:00 UTC (시간대 미설정 사용자 → UTC 기준)
+
+	svc := service.NewPrescriptionService(zap.NewNop(), memory.NewPrescriptionRepository(), memory.NewDrugInteractionRepository(), memory.NewTokenRepository())
+	sch := scheduler.New(scheduler.NewMemoryStore(), scheduler.WithClock(func() time.Time { return now }))
+	notifier := &fakeNotifier{}
+	svc.SetScheduler(sch)
+	svc.SetNotificationClient(notifier)
+
+	p, err := svc.CreatePrescription(ctx, "user-1", "doctor-1", "", "고혈압", "", []*service.Medication{
+		{DrugName: "아모시실린", Dosage: "500mg", Frequency: "1일 3회"},
+		{DrugName: "암로디핀", Dosage: "5mg", Frequency: "1일 1회"},
+	})
+	if err != nil {
+		t.Fatalf("CreatePrescription 실패: %v", err)
+	}
+
+	sched, err := sch.Get(ctx, "rx:"+p.ID)
+	if err != nil || sched == nil {
+		t.Fatalf("복약 알림 스케줄이 없습니다 (err=%v)", err)
+	}
+	if got := len(sched.TimesOfDay); got != 3 {
+		t.Errorf("복용 시각 수 = %d, want 3 (08:00, 13:00, 20:00): %v", got, sched.TimesOfDay)
+	}
+	if want := day.Add(8 * time.Hour); !sched.NextRunAt.Equal(want) {
+		t.Errorf("NextRunAt = %v, want %v", sched.NextRunAt, want)
+	}
+
+	// 08:00 복용분은 두 약물, 13:00 복용분은 아모시실린만
+	now = day.Add(8 * time.Hour)
+	if _, err := sch.Tick(ctx); err != nil {
+		t.Fatalf("Tick 실패: %v", err)
+	}
+	now = day.Add(13 * time.Hour)
+	if _, err := sch.Tick(ctx); err != nil {
+		t.Fatalf("Tick 실패: %v", err)
+	}
+	if len(notifier.sent) != 2 {
+		t.Fatalf("알림 수 = %d, want 2", len(notifier.sent))
+	}
+	if got, want := notifier.sent[0].body, "08:00 복용분: 아모시실린 500mg, 암로디핀 5mg"; got != want {
+		t.Errorf("08:00 알림 = %q, want %q", got, want)
+	}
+	if got, want := notifier.sent[1].body, "13:00 복용분: 아모시실린 500mg"; got != want {
+		t.Errorf("13:00 알림 = %q, want %q", got, want)
+	}
+	if notifier.sent[0].notifType != "prescription" {
+		t.Errorf("알림 유형 = %q, want prescription", notifier.sent[0].notifType)
+	}
+
+	// 처방전이 취소되면 스케줄 해제
+	if _, err := svc.UpdatePrescriptionStatus(ctx, p.ID, service.StatusCancelled, ""); err != nil {
+		t.Fatalf("UpdatePrescriptionStatus 실패: %v", err)
+	}
+	if sched, _ := sch.Get(ctx, "rx:"+p.ID); sched != nil {
+		t.Error("취소된 처방전의 복약 알림 스케줄이 남아 있습니다")
+	}
+}
@@ -2,12 +2,14 @@
 //
 // 포트: gRPC :50065
 // 의존: PostgreSQL(선택) — 미설정 시 인메모리 저장소 사용
+// 의존: coaching-service(선택) — 환자 건강 리포트 다운로드 링크, 미설정 시 리포트 첨부 비활성화
 //
 // 기능:
 // - 원격진료 상담 생성/조회/목록
 // - 의사 매칭
 // - 비디오 세션 시작/종료
 // - 상담 평점
+// - 환자 주간·월간 건강 리포트 상담 첨부
 package main
 
 import (
@@ -24,10 +26,12 @@ import (
 	"github.com/manpasik/backend/services/telemedicine-service/internal/repository/memory"
 	"github.com/manpasik/backend/services/telemedicine-service/internal/repository/postgres"
 	"github.com/manpasik/backend/services/telemedicine-service/internal/service"
+	"github.com/manpasik/backend/shared/clients"
 	"github.com/manpasik/backend/shared/config"
 	v1 "github.com/manpasik/backend/shared/gen/go/v1"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/health"
 	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
@@ -51,6 +55,7 @@ func main() {
 	var consultationRepo service.ConsultationRepository
 	var doctorRepo service.DoctorRepository
 	var videoSessionRepo service.VideoSessionRepository
+	var attachmentRepo service.AttachmentRepository
 
 	if _, dbHostSet := os.LookupEnv("DB_HOST"); dbHostSet && cfg.DB.Host != "" && cfg.DB.DBName != "" {
 		connCtx, connCancel := context.WithTimeout(context.Background(), 5*time.Second)
@@ -61,6 +66,7 @@ func main() {
 			consultationRepo = memory.NewConsultationRepository()
 			doctorRepo = memory.NewDoctorRepository()
 			videoSessionRepo = memory.NewVideoSessionRepository()
+			attachmentRepo = memory.NewAttachmentRepository()
 		} else {
 			pingCtx, pingCancel := context.WithTimeout(context.Background(), 3*time.Second)
 			if pingErr := pool.Ping(pingCtx); pingErr != nil {
@@ -70,12 +76,14 @@ func main() {
 				consultationRepo = memory.NewConsultationRepository()
 				doctorRepo = memory.NewDoctorRepository()
 				videoSessionRepo = memory.NewVideoSessionRepository()
+				attachmentRepo = memory.NewAttachmentRepository()
 			} else {
 				pingCancel()
 				defer pool.Close()
 				consultationRepo = postgres.NewConsultationRepository(pool)
 				doctorRepo = postgres.NewDoctorRepository(pool)
 				videoSessionRepo = postgres.NewVideoSessionRepository(pool)
+				attachmentRepo = postgres.NewAttachmentRepository(pool)
 				log.Printf("[%s] DB 연결됨: %s", serviceName, cfg.DB.DBName)
 			}
 		}
@@ -83,11 +91,26 @@ func main() {
 		consultationRepo = memory.NewConsultationRepository()
 		doctorRepo = memory.NewDoctorRepository()
 		videoSessionRepo = memory.NewVideoSessionRepository()
+		attachmentRepo = memory.NewAttachmentRepository()
 		log.Printf("[%s] 인메모리 저장소 사용", serviceName)
 	}
 
 	teleSvc := service.NewTelemedicineService(logger, consultationRepo, doctorRepo, videoSessionRepo)
 
+	// 건강 리포트 첨부: COACHING_SERVICE_ADDR 설정 시 환자 리포트를 상담에 첨부하고 다운로드 링크 재발급
+	if coachingAddr := os.Getenv("COACHING_SERVICE_ADDR"); coachingAddr != "" {
+		coachingConn, dialErr := grpc.NewClient(coachingAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		if dialErr != nil {
+			log.Printf("[%s] coaching-service 연결 실패, 리포트 첨부 비활성: %v", serviceName, dialErr)
+		} else {
+			defer coachingConn.Close()
+			teleSvc.SetReportAttachments(clients.NewGRPCCoachingClient(v1.NewCoachingServiceClient(coachingConn)), attachmentRepo)
+			log.Printf("[%s] coaching-service 연결됨: %s", serviceName, coachingAddr)
+		}
+	} else {
+		log.Printf("[%s] COACHING_SERVICE_ADDR 미설정 — 리포트 첨부 비활성화", serviceName)
+	}
+
 	grpcServer := grpc.NewServer()
 
 	healthServer := health.NewServer()
@@ -164,6 +164,39 @@ func (h *TelemedicineHandler) RateConsultation(ctx context.Context, req *v1.Rate
 	}, nil
 }
 
+// AttachHealthReport는 건강 리포트 상담 첨부 RPC입니다.
+func (h *TelemedicineHandler) AttachHealthReport(ctx context.Context, req *v1.AttachHealthReportRequest) (*v1.ConsultationAttachment, error) {
+	if req == nil || req.ConsultationId == "" {
+		return nil, status.Error(codes.InvalidArgument, "consultation_id는 필수입니다")
+	}
+	if req.UserId == "" || req.ExportId == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id와 export_id는 필수입니다")
+	}
+
+	attachment, err := h.svc.AttachHealthReport(ctx, req.ConsultationId, req.UserId, req.ExportId)
+	if err != nil {
+		return nil, toGRPC(err)
+	}
+	return attachmentToProto(attachment), nil
+}
+
+// ListConsultationAttachments는 상담 첨부 리포트 목록 RPC입니다.
+func (h *TelemedicineHandler) ListConsultationAttachments(ctx context.Context, req *v1.ListConsultationAttachmentsRequest) (*v1.ListConsultationAttachmentsResponse, error) {
+	if req == nil || req.ConsultationId == "" || req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "consultation_id, user_id는 필수입니다")
+	}
+
+	attachments, err := h.svc.ListConsultationAttachments(ctx, req.ConsultationId, req.UserId)
+	if err != nil {
+		return nil, toGRPC(err)
+	}
+	resp := &v1.ListConsultationAttachmentsResponse{}
+	for _, a := range attachments {
+		resp.Attachments = append(resp.Attachments, attachmentToProto(a))
+	}
+	return resp, nil
+}
+
 // ============================================================================
 // 변환 헬퍼
 // ============================================================================
@@ -196,6 +229,36 @@ func consultationToProto(c *service.Consultation) *v1.Consultation {
 	return pb
 }
 
+func attachmentToProto(a *service.ConsultationAttachment) *v1.ConsultationAttachment {
+	pa := &v1.ConsultationAttachment{
+		AttachmentId:   a.ID,
+		ConsultationId: a.ConsultationID,
+		UserId:         a.UserID,
+		ExportId:       a.ExportID,
+		Period:         reportPeriodToProto(a.Period),
+		PeriodStart:    timestamppb.New(a.PeriodStart),
+		PeriodEnd:      timestamppb.New(a.PeriodEnd),
+		HtmlUrl:        a.HTMLURL,
+		PdfUrl:         a.PDFURL,
+		AttachedAt:     timestamppb.New(a.AttachedAt),
+	}
+	if !a.URLExpiresAt.IsZero() {
+		pa.UrlExpiresAt = timestamppb.New(a.URLExpiresAt)
+	}
+	return pa
+}
+
+func reportPeriodToProto(p string) v1.ReportPeriod {
+	switch p {
+	case "weekly":
+		return v1.ReportPeriod_REPORT_PERIOD_WEEKLY
+	case "monthly":
+		return v1.ReportPeriod_REPORT_PERIOD_MONTHLY
+	default:
+		return v1.ReportPeriod_REPORT_PERIOD_UNKNOWN
+	}
+}
+
 func doctorProfileToProto(d *service.DoctorProfile) *v1.DoctorProfile {
 	return &v1.DoctorProfile{
 		DoctorId:           d.ID,
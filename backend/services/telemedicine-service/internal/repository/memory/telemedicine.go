@@ -4,6 +4,7 @@ package memory
 import (
 	"context"
 	"sync"
+	"time"
 
 	"github.com/manpasik/backend/services/telemedicine-service/internal/service"
 	apperrors "github.com/manpasik/backend/shared/errors"
@@ -270,3 +271,38 @@ func (r *VideoSessionRepository) Update(_ context.Context, s *service.VideoSessi
 	r.store[s.ID] = s
 	return nil
 }
+
+// AttachmentRepository는 상담 첨부 인메모리 저장소입니다.
+type AttachmentRepository struct {
+	mu    sync.RWMutex
+	store []*service.ConsultationAttachment
+}
+
+// NewAttachmentRepository는 새 인메모리 상담 첨부 저장소를 생성합니다.
+func NewAttachmentRepository() *AttachmentRepository {
+	return &AttachmentRepository{}
+}
+
+// Save는 상담 첨부를 저장합니다. 다운로드 링크는 저장하지 않습니다.
+func (r *AttachmentRepository) Save(_ context.Context, a *service.ConsultationAttachment) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	cp := *a
+	cp.HTMLURL, cp.PDFURL, cp.URLExpiresAt = "", "", time.Time{}
+	r.store = append(r.store, &cp)
+	return nil
+}
+
+// FindByConsultationID는 상담의 첨부를 첨부 순으로 조회합니다.
+func (r *AttachmentRepository) FindByConsultationID(_ context.Context, consultationID string) ([]*service.ConsultationAttachment, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var result []*service.ConsultationAttachment
+	for _, a := range r.store {
+		if a.ConsultationID == consultationID {
+			cp := *a
+			result = append(result, &cp)
+		}
+	}
+	return result, nil
+}
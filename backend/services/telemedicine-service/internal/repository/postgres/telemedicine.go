@@ -1,7 +1,7 @@
 // Package postgres는 telemedicine-service의 PostgreSQL 저장소 구현입니다.
 //
-// DB 스키마: infrastructure/database/init/15-telemedicine.sql
-// 테이블: doctors, consultations, video_sessions
+// DB 스키마: infrastructure/database/init/15-telemedicine.sql, 41-health-report-exports.sql
+// 테이블: doctors, consultations, video_sessions, consultation_attachments
 package postgres
 
 import (
@@ -502,3 +502,53 @@ func (r *VideoSessionRepository) Update(ctx context.Context, s *service.VideoSes
 func itoa(n int) string {
 	return strconv.Itoa(n)
 }
+
+// ============================================================================
+// AttachmentRepository
+// ============================================================================
+
+// AttachmentRepository는 PostgreSQL 기반 상담 첨부 저장소입니다.
+type AttachmentRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewAttachmentRepository는 AttachmentRepository를 생성합니다.
+func NewAttachmentRepository(pool *pgxpool.Pool) *AttachmentRepository {
+	return &AttachmentRepository{pool: pool}
+}
+
+// Save는 상담 첨부를 저장합니다.
+func (r *AttachmentRepository) Save(ctx context.Context, a *service.ConsultationAttachment) error {
+	const q = `INSERT INTO consultation_attachments
+		(attachment_id, consultation_id, user_id, export_id, period, period_start, period_end, attached_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+	_, err := r.pool.Exec(ctx, q,
+		a.ID, a.ConsultationID, a.UserID, a.ExportID,
+		a.Period, a.PeriodStart, a.PeriodEnd, a.AttachedAt,
+	)
+	return err
+}
+
+// FindByConsultationID는 상담의 첨부를 첨부 순으로 조회합니다.
+func (r *AttachmentRepository) FindByConsultationID(ctx context.Context, consultationID string) ([]*service.ConsultationAttachment, error) {
+	const q = `SELECT attachment_id, consultation_id, user_id, export_id, period, period_start, period_end, attached_at
+		FROM consultation_attachments WHERE consultation_id = $1 ORDER BY attached_at ASC`
+	rows, err := r.pool.Query(ctx, q, consultationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []*service.ConsultationAttachment
+	for rows.Next() {
+		var a service.ConsultationAttachment
+		if err := rows.Scan(
+			&a.ID, &a.ConsultationID, &a.UserID, &a.ExportID,
+			&a.Period, &a.PeriodStart, &a.PeriodEnd, &a.AttachedAt,
+		); err != nil {
+			return nil, err
+		}
+		result = append(result, &a)
+	}
+	return result, rows.Err()
+}
@@ -0,0 +1,177 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/manpasik/backend/shared/clients"
+	apperrors "github.com/manpasik/backend/shared/errors"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ConsultationAttachment는 상담에 첨부한 환자 건강 리포트입니다.
+type ConsultationAttachment struct {
+	ID             string
+	ConsultationID string
+	UserID         string // 리포트 소유자 (상담 환자)
+	ExportID       string // coaching-service 리포트 ID
+	Period         string // "weekly", "monthly"
+	PeriodStart    time.Time
+	PeriodEnd      time.Time
+	AttachedAt     time.Time
+
+	// 조회 시 coaching-service에서 새로 발급받는 다운로드 링크 (저장하지 않음)
+	HTMLURL      string
+	PDFURL       string
+	URLExpiresAt time.Time
+}
+
+// AttachmentRepository는 상담 첨부 저장소 인터페이스입니다.
+type AttachmentRepository interface {
+	Save(ctx context.Context, a *ConsultationAttachment) error
+	// FindByConsultationID는 첨부 시각 순으로 반환합니다.
+	FindByConsultationID(ctx context.Context, consultationID string) ([]*ConsultationAttachment, error)
+}
+
+// SetReportAttachments는 건강 리포트 조회 클라이언트와 첨부 저장소를 설정합니다 (optional).
+// 설정하지 않으면 상담에 리포트를 첨부할 수 없습니다.
+func (s *TelemedicineService) SetReportAttachments(reports clients.HealthReportClient, repo AttachmentRepository) {
+	s.reports = reports
+	s.attachmentRepo = repo
+}
+
+// AttachHealthReport는 환자가 내보낸 건강 리포트를 상담에 첨부합니다.
+// 상담 환자 본인의 리포트만 첨부할 수 있고, 같은 리포트를 다시 첨부하면 기존 첨부를 반환합니다.
+func (s *TelemedicineService) AttachHealthReport(ctx context.Context, consultationID, userID, exportID string) (*ConsultationAttachment, error) {
+	if consultationID == "" {
+		return nil, apperrors.New(apperrors.ErrInvalidInput, "consultation_id는 필수입니다")
+	}
+	if userID == "" {
+		return nil, apperrors.New(apperrors.ErrInvalidInput, "user_id는 필수입니다")
+	}
+	if exportID == "" {
+		return nil, apperrors.New(apperrors.ErrInvalidInput, "export_id는 필수입니다")
+	}
+	if s.reports == nil || s.attachmentRepo == nil {
+		return nil, apperrors.New(apperrors.ErrServiceUnavailable, "건강 리포트 첨부가 비활성화되어 있습니다")
+	}
+
+	consultation, err := s.findConsultation(ctx, consultationID)
+	if err != nil {
+		return nil, err
+	}
+	if consultation.PatientUserID != userID {
+		return nil, apperrors.New(apperrors.ErrForbidden, "상담 환자 본인의 리포트만 첨부할 수 있습니다")
+	}
+	if consultation.Status == StatusCancelled || consultation.Status == StatusNoShow {
+		return nil, apperrors.New(apperrors.ErrConflict, "취소되었거나 종료된 상담에는 첨부할 수 없습니다")
+	}
+
+	export, err := s.reports.GetHealthReportExport(ctx, exportID, userID)
+	if err != nil {
+		return nil, reportLookupError(err)
+	}
+
+	existing, err := s.attachmentRepo.FindByConsultationID(ctx, consultationID)
+	if err != nil {
+		return nil, fmt.Errorf("첨부 조회 실패: %w", err)
+	}
+	for _, a := range existing {
+		if a.ExportID == exportID {
+			withURLs(a, export)
+			return a, nil
+		}
+	}
+
+	attachment := &ConsultationAttachment{
+		ID:             uuid.New().String(),
+		ConsultationID: consultationID,
+		UserID:         userID,
+		ExportID:       exportID,
+		Period:         export.Period,
+		PeriodStart:    export.PeriodStart,
+		PeriodEnd:      export.PeriodEnd,
+		AttachedAt:     time.Now(),
+	}
+	if err := s.attachmentRepo.Save(ctx, attachment); err != nil {
+		return nil, fmt.Errorf("첨부 저장 실패: %w", err)
+	}
+	withURLs(attachment, export)
+
+	s.log.Info("건강 리포트 첨부 완료",
+		zap.String("consultation_id", consultationID),
+		zap.String("export_id", exportID),
+	)
+	return attachment, nil
+}
+
+// ListConsultationAttachments는 상담에 첨부된 리포트를 새 다운로드 링크와 함께 반환합니다.
+// 상담 환자와 담당 의사(userID가 상담의 DoctorID)만 조회할 수 있습니다.
+// 링크 발급에 실패한 첨부는 링크 없이 반환합니다.
+func (s *TelemedicineService) ListConsultationAttachments(ctx context.Context, consultationID, userID string) ([]*ConsultationAttachment, error) {
+	if consultationID == "" {
+		return nil, apperrors.New(apperrors.ErrInvalidInput, "consultation_id는 필수입니다")
+	}
+	if userID == "" {
+		return nil, apperrors.New(apperrors.ErrInvalidInput, "user_id는 필수입니다")
+	}
+	if s.attachmentRepo == nil {
+		return nil, apperrors.New(apperrors.ErrServiceUnavailable, "건강 리포트 첨부가 비활성화되어 있습니다")
+	}
+	consultation, err := s.findConsultation(ctx, consultationID)
+	if err != nil {
+		return nil, err
+	}
+	if userID != consultation.PatientUserID && (consultation.DoctorID == "" || userID != consultation.DoctorID) {
+		return nil, apperrors.New(apperrors.ErrForbidden, "상담 환자와 담당 의사만 첨부 리포트를 조회할 수 있습니다")
+	}
+
+	attachments, err := s.attachmentRepo.FindByConsultationID(ctx, consultationID)
+	if err != nil {
+		return nil, fmt.Errorf("첨부 조회 실패: %w", err)
+	}
+	for _, a := range attachments {
+		if s.reports == nil {
+			break
+		}
+		export, err := s.reports.GetHealthReportExport(ctx, a.ExportID, a.UserID)
+		if err != nil {
+			s.log.Warn("첨부 리포트 링크 발급 실패", zap.String("export_id", a.ExportID), zap.Error(err))
+			continue
+		}
+		withURLs(a, export)
+	}
+	return attachments, nil
+}
+
+// findConsultation은 상담을 조회하고, 없으면 NotFound를 반환합니다.
+func (s *TelemedicineService) findConsultation(ctx context.Context, consultationID string) (*Consultation, error) {
+	consultation, err := s.consultRepo.FindByID(ctx, consultationID)
+	if err != nil {
+		return nil, err
+	}
+	if consultation == nil {
+		return nil, apperrors.New(apperrors.ErrNotFound, "상담을 찾을 수 없습니다")
+	}
+	return consultation, nil
+}
+
+func withURLs(a *ConsultationAttachment, export *clients.HealthReportExport) {
+	a.HTMLURL, a.PDFURL, a.URLExpiresAt = export.HTMLURL, export.PDFURL, export.URLExpiresAt
+}
+
+// reportLookupError는 coaching-service 리포트 조회 오류를 AppError로 바꿉니다.
+func reportLookupError(err error) error {
+	switch status.Code(err) {
+	case codes.NotFound:
+		return apperrors.New(apperrors.ErrNotFound, "건강 리포트를 찾을 수 없습니다")
+	case codes.InvalidArgument:
+		return apperrors.New(apperrors.ErrInvalidInput, "건강 리포트 요청이 올바르지 않습니다")
+	default:
+		return apperrors.New(apperrors.ErrServiceUnavailable, "건강 리포트를 조회할 수 없습니다")
+	}
+}
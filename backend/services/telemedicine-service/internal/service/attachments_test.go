@@ -0,0 +1,156 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/manpasik/backend/services/telemedicine-service/internal/repository/memory"
+	"github.com/manpasik/backend/services/telemedicine-service/internal/service"
+	"github.com/manpasik/backend/shared/clients"
+	apperrors "github.com/manpasik/backend/shared/errors"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeReports는 coaching-service 리포트 조회를 흉내 냅니다. 호출마다 새 링크를 발급합니다.
+type fakeReports struct {
+	exports map[string]*clients.HealthReportExport
+	calls   int
+}
+
+func (f *fakeReports) GetHealthReportExport(_ context.Context, exportID, userID string) (*clients.HealthReportExport, error) {
+	f.calls++
+	e, ok := f.exports[exportID]
+	if !ok || e.UserID != userID {
+		return nil, status.Error(codes.NotFound, "리포트를 찾을 수 없습니다")
+	}
+	cp := *e
+	cp.PDFURL = e.PDFURL + "?sig=" + string(rune('0'+f.calls))
+	return &cp, nil
+}
+
+func setupAttachmentService(t *testing.T) (*service.TelemedicineService, *fakeReports) {
+	t.Helper()
+	svc, _, reports := setupAttachmentServiceWithRepo(t)
+	return svc, reports
+}
+
+func setupAttachmentServiceWithRepo(t *testing.T) (*service.TelemedicineService, *memory.ConsultationRepository, *fakeReports) {
+	t.Helper()
+	consultRepo := memory.NewConsultationRepository()
+	svc := service.NewTelemedicineService(zap.NewNop(), consultRepo, memory.NewDoctorRepository(), memory.NewVideoSessionRepository())
+	week := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	reports := &fakeReports{exports: map[string]*clients.HealthReportExport{
+		"export-1": {ExportID: "export-1", UserID: "patient-1", Period: "weekly", PeriodStart: week, PeriodEnd: week.AddDate(0, 0, 6),
+			HTMLURL: "https://s3.test/r.html", PDFURL: "https://s3.test/r.pdf", URLExpiresAt: week.AddDate(0, 0, 8)},
+	}}
+	svc.SetReportAttachments(reports, memory.NewAttachmentRepository())
+	return svc, consultRepo, reports
+}
+
+func TestAttachHealthReport_Success(t *testing.T) {
+	svc, _ := setupAttachmentService(t)
+	ctx := context.Background()
+	c, _ := svc.CreateConsultation(ctx, "patient-1", service.SpecialtyInternal, "혈당 상담", "")
+
+	a, err := svc.AttachHealthReport(ctx, c.ID, "patient-1", "export-1")
+	if err != nil {
+		t.Fatalf("첨부 실패: %v", err)
+	}
+	if a.Period != "weekly" || a.PDFURL == "" || a.URLExpiresAt.IsZero() {
+		t.Fatalf("첨부 결과 불일치: %+v", a)
+	}
+
+	// 같은 리포트를 다시 첨부하면 기존 첨부를 반환
+	again, err := svc.AttachHealthReport(ctx, c.ID, "patient-1", "export-1")
+	if err != nil || again.ID != a.ID {
+		t.Fatalf("재첨부: got %+v, %v — 기존 첨부 %s 기대", again, err, a.ID)
+	}
+
+	list, err := svc.ListConsultationAttachments(ctx, c.ID, "patient-1")
+	if err != nil {
+		t.Fatalf("첨부 목록 실패: %v", err)
+	}
+	if len(list) != 1 {
+		t.Fatalf("첨부 수: got %d, want 1", len(list))
+	}
+	if list[0].PDFURL == "" || list[0].PDFURL == a.PDFURL {
+		t.Fatalf("목록 조회 시 새 링크가 발급되어야 함: %s", list[0].PDFURL)
+	}
+}
+
+func TestListConsultationAttachments_ParticipantsOnly(t *testing.T) {
+	svc, consultRepo, _ := setupAttachmentServiceWithRepo(t)
+	ctx := context.Background()
+	c, _ := svc.CreateConsultation(ctx, "patient-1", service.SpecialtyInternal, "혈당 상담", "")
+	if _, err := svc.AttachHealthReport(ctx, c.ID, "patient-1", "export-1"); err != nil {
+		t.Fatalf("첨부 실패: %v", err)
+	}
+
+	var appErr *apperrors.AppError
+	// 의사 배정 전에는 환자 외 누구도 조회할 수 없음
+	if _, err := svc.ListConsultationAttachments(ctx, c.ID, "doctor-1"); !errors.As(err, &appErr) || appErr.Code != apperrors.ErrForbidden {
+		t.Fatalf("배정 전 의사: got %v, want FORBIDDEN", err)
+	}
+
+	c.DoctorID = "doctor-1"
+	if err := consultRepo.Update(ctx, c); err != nil {
+		t.Fatalf("의사 배정 실패: %v", err)
+	}
+	list, err := svc.ListConsultationAttachments(ctx, c.ID, "doctor-1")
+	if err != nil || len(list) != 1 || list[0].PDFURL == "" {
+		t.Fatalf("담당 의사 조회: got %+v, %v", list, err)
+	}
+
+	for _, userID := range []string{"patient-2", "doctor-2"} {
+		if _, err := svc.ListConsultationAttachments(ctx, c.ID, userID); !errors.As(err, &appErr) || appErr.Code != apperrors.ErrForbidden {
+			t.Errorf("%s: got %v, want FORBIDDEN", userID, err)
+		}
+	}
+	if _, err := svc.ListConsultationAttachments(ctx, c.ID, ""); !errors.As(err, &appErr) || appErr.Code != apperrors.ErrInvalidInput {
+		t.Errorf("user_id 누락: got %v, want INVALID_INPUT", err)
+	}
+}
+
+func TestAttachHealthReport_Rejected(t *testing.T) {
+	svc, _ := setupAttachmentService(t)
+	ctx := context.Background()
+	c, _ := svc.CreateConsultation(ctx, "patient-1", service.SpecialtyInternal, "혈당 상담", "")
+	other, _ := svc.CreateConsultation(ctx, "patient-2", service.SpecialtyInternal, "두통", "")
+
+	cases := []struct {
+		name           string
+		consultationID string
+		userID         string
+		exportID       string
+		code           apperrors.ErrorCode
+	}{
+		{"다른 환자의 상담", other.ID, "patient-1", "export-1", apperrors.ErrForbidden},
+		{"남의 리포트", other.ID, "patient-2", "export-1", apperrors.ErrNotFound},
+		{"없는 리포트", c.ID, "patient-1", "missing", apperrors.ErrNotFound},
+		{"없는 상담", "missing", "patient-1", "export-1", apperrors.ErrNotFound},
+		{"리포트 ID 누락", c.ID, "patient-1", "", apperrors.ErrInvalidInput},
+	}
+	for _, tc := range cases {
+		_, err := svc.AttachHealthReport(ctx, tc.consultationID, tc.userID, tc.exportID)
+		var appErr *apperrors.AppError
+		if !errors.As(err, &appErr) || appErr.Code != tc.code {
+			t.Errorf("%s: got %v, want %s", tc.name, err, tc.code)
+		}
+	}
+}
+
+func TestAttachHealthReport_Disabled(t *testing.T) {
+	svc := setupTestService()
+	ctx := context.Background()
+	c, _ := svc.CreateConsultation(ctx, "patient-1", service.SpecialtyInternal, "혈당 상담", "")
+
+	_, err := svc.AttachHealthReport(ctx, c.ID, "patient-1", "export-1")
+	var appErr *apperrors.AppError
+	if !errors.As(err, &appErr) || appErr.Code != apperrors.ErrServiceUnavailable {
+		t.Fatalf("리포트 클라이언트 미설정: got %v, want SERVICE_UNAVAILABLE", err)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/manpasik/backend/shared/clients"
 	apperrors "github.com/manpasik/backend/shared/errors"
 	"go.uber.org/zap"
 )
@@ -128,6 +129,9 @@ type TelemedicineService struct {
 	consultRepo ConsultationRepository
 	doctorRepo  DoctorRepository
 	sessionRepo VideoSessionRepository
+
+	reports        clients.HealthReportClient // optional: nil이면 건강 리포트 첨부 비활성화
+	attachmentRepo AttachmentRepository
 }
 
 // NewTelemedicineService는 TelemedicineService를 생성합니다.
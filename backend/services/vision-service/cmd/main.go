@@ -2,6 +2,7 @@
 //
 // 포트: gRPC :50071
 // 의존: 인메모리 저장소 (향후 PostgreSQL 추가)
+// 의존: Kafka(선택) — 식사 분석 완료 시 vision.meal_analyzed 발행
 //
 // 기능:
 // - 음식 이미지 AI 분석
@@ -24,9 +25,11 @@ import (
 	"net/http"
 
 	"github.com/manpasik/backend/services/vision-service/internal/handler"
+	kafkaPublisher "github.com/manpasik/backend/services/vision-service/internal/repository/kafka"
 	"github.com/manpasik/backend/services/vision-service/internal/repository/memory"
 	"github.com/manpasik/backend/services/vision-service/internal/service"
 	"github.com/manpasik/backend/shared/config"
+	"github.com/manpasik/backend/shared/events"
 	"github.com/manpasik/backend/shared/middleware"
 	"github.com/manpasik/backend/shared/observability"
 	"go.uber.org/zap"
@@ -59,6 +62,22 @@ func main() {
 	// TODO: 실제 AI Vision Analyzer 설정 (TFLite/Cloud Vision)
 	// visionSvc.SetAnalyzer(analyzer)
 
+	// 식사 이벤트: Kafka 설정 시 vision.meal_analyzed 발행 (coaching-service 영양 목표 진행)
+	if _, kafkaBrokersSet := os.LookupEnv("KAFKA_BROKERS"); kafkaBrokersSet && len(cfg.Kafka.Brokers) > 0 {
+		eventBus, kafkaErr := events.NewKafkaEventBus(events.KafkaAdapterConfig{
+			Brokers:     cfg.Kafka.Brokers,
+			GroupID:     serviceName,
+			TopicPrefix: "manpasik.",
+		})
+		if kafkaErr != nil {
+			log.Printf("[%s] Kafka 연결 실패, 식사 이벤트 발행 비활성: %v", serviceName, kafkaErr)
+		} else {
+			defer eventBus.Close()
+			visionSvc.SetEventPublisher(kafkaPublisher.NewEventPublisher(eventBus))
+			log.Printf("[%s] Kafka 연결됨: %v", serviceName, cfg.Kafka.Brokers)
+		}
+	}
+
 	// 핸들러 생성 (Proto 확장 후 gRPC 등록 활성화)
 	_ = handler.NewVisionHandler(visionSvc, logger)
 
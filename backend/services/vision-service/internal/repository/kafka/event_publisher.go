@@ -0,0 +1,61 @@
+// Package kafka는 Kafka 기반 식사 분석 이벤트 발행기를 제공합니다.
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/manpasik/backend/services/vision-service/internal/service"
+	"github.com/manpasik/backend/shared/events"
+)
+
+// EventPublisher는 Kafka를 사용하는 이벤트 발행기입니다.
+type EventPublisher struct {
+	eventBus *events.KafkaEventBus
+}
+
+// NewEventPublisher는 Kafka 기반 EventPublisher를 생성합니다.
+func NewEventPublisher(eventBus *events.KafkaEventBus) *EventPublisher {
+	return &EventPublisher{eventBus: eventBus}
+}
+
+// PublishMealAnalyzed는 식사 분석 완료 이벤트를 Kafka에 발행합니다.
+// 영양소는 음식 항목별 섭취량을 영양소 이름별로 합산해 싣습니다.
+func (p *EventPublisher) PublishMealAnalyzed(ctx context.Context, a *service.FoodAnalysis) error {
+	analyzedAt := a.CreatedAt
+	if a.AnalyzedAt != nil {
+		analyzedAt = *a.AnalyzedAt
+	}
+	payload := map[string]interface{}{
+		"analysis_id":        a.ID,
+		"user_id":            a.UserID,
+		"meal_type":          a.MealType,
+		"total_calorie_kcal": a.TotalCalorieKcal,
+		"food_items":         len(a.FoodItems),
+		"nutrients":          a.NutrientTotals(),
+		"analyzed_at":        analyzedAt.Format(time.RFC3339),
+	}
+
+	payloadBytes, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("이벤트 페이로드 직렬화 실패: %w", err)
+	}
+
+	kafkaEvent := events.Event{
+		Type: events.EventVisionMealAnalyzed,
+		Payload: map[string]interface{}{
+			"event_id":   uuid.New().String(),
+			"event_type": "manpasik." + events.EventVisionMealAnalyzed,
+			"version":    "1.0",
+			"timestamp":  time.Now().UTC().Format(time.RFC3339),
+			"source":     "vision-service",
+			"user_id":    a.UserID,
+			"payload":    json.RawMessage(payloadBytes),
+		},
+	}
+
+	return p.eventBus.Publish(ctx, kafkaEvent)
+}
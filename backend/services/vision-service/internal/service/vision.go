@@ -63,6 +63,17 @@ type FoodAnalysis struct {
 	ErrorMessage    string
 }
 
+// NutrientTotals는 인식된 음식 항목의 영양소 섭취량을 영양소 이름별로 합산합니다.
+func (a *FoodAnalysis) NutrientTotals() map[string]float64 {
+	totals := make(map[string]float64)
+	for _, item := range a.FoodItems {
+		for _, n := range item.Nutrients {
+			totals[n.Name] += n.Amount
+		}
+	}
+	return totals
+}
+
 // ============================================================================
 // 리포지토리 인터페이스
 // ============================================================================
@@ -75,6 +86,12 @@ type FoodAnalysisRepository interface {
 	Update(ctx context.Context, analysis *FoodAnalysis) error
 }
 
+// MealEventPublisher는 식사 분석 완료 이벤트 발행 인터페이스입니다 (Kafka).
+// coaching-service가 vision.meal_analyzed를 소비해 영양 목표 진행에 반영합니다.
+type MealEventPublisher interface {
+	PublishMealAnalyzed(ctx context.Context, analysis *FoodAnalysis) error
+}
+
 // VisionAnalyzer는 AI 비전 분석 인터페이스입니다.
 // 실제 구현은 TFLite, Cloud Vision API, 또는 자체 모델이 됩니다.
 type VisionAnalyzer interface {
@@ -89,7 +106,8 @@ type VisionAnalyzer interface {
 type VisionService struct {
 	logger   *zap.Logger
 	repo     FoodAnalysisRepository
-	analyzer VisionAnalyzer // optional: nil이면 시뮬레이션 모드
+	analyzer VisionAnalyzer     // optional: nil이면 시뮬레이션 모드
+	events   MealEventPublisher // optional: nil이면 식사 이벤트 미발행
 }
 
 // NewVisionService는 새 VisionService를 생성합니다.
@@ -105,6 +123,11 @@ func (s *VisionService) SetAnalyzer(a VisionAnalyzer) {
 	s.analyzer = a
 }
 
+// SetEventPublisher는 식사 분석 이벤트 발행기를 설정합니다 (optional).
+func (s *VisionService) SetEventPublisher(p MealEventPublisher) {
+	s.events = p
+}
+
 // AnalyzeFood는 음식 이미지를 분석합니다.
 func (s *VisionService) AnalyzeFood(ctx context.Context, userID, imageURL, mealType string) (*FoodAnalysis, error) {
 	if userID == "" {
@@ -167,6 +190,13 @@ func (s *VisionService) AnalyzeFood(ctx context.Context, userID, imageURL, mealT
 		zap.Int("food_items", len(foodItems)),
 	)
 
+	// 이벤트 발행 실패는 분석 결과에 영향을 주지 않음
+	if s.events != nil {
+		if err := s.events.PublishMealAnalyzed(ctx, analysis); err != nil {
+			s.logger.Warn("식사 분석 이벤트 발행 실패", zap.String("analysis_id", analysis.ID), zap.Error(err))
+		}
+	}
+
 	return analysis, nil
 }
 
@@ -212,3 +212,38 @@ func TestSetAnalyzer(t *testing.T) {
 	// SetAnalyzer에 nil을 설정해도 panic이 발생하지 않아야 함
 	svc.SetAnalyzer(nil)
 }
+
+type recordingMealPublisher struct {
+	published []*service.FoodAnalysis
+}
+
+func (p *recordingMealPublisher) PublishMealAnalyzed(_ context.Context, a *service.FoodAnalysis) error {
+	p.published = append(p.published, a)
+	return nil
+}
+
+func TestAnalyzeFood_식사_이벤트_발행(t *testing.T) {
+	svc := setupTestService()
+	publisher := &recordingMealPublisher{}
+	svc.SetEventPublisher(publisher)
+
+	result, err := svc.AnalyzeFood(context.Background(), "user-1", "https://example.com/food.jpg", "dinner")
+	if err != nil {
+		t.Fatalf("음식 분석 실패: %v", err)
+	}
+	if len(publisher.published) != 1 || publisher.published[0].ID != result.ID {
+		t.Fatalf("분석 완료 시 식사 이벤트 1건이 발행되어야 함: got %d", len(publisher.published))
+	}
+
+	var want float64
+	for _, item := range result.FoodItems {
+		for _, n := range item.Nutrients {
+			if n.Name == "탄수화물" {
+				want += n.Amount
+			}
+		}
+	}
+	if got := result.NutrientTotals()["탄수화물"]; got != want {
+		t.Errorf("영양소 합계 불일치: got %f, want %f", got, want)
+	}
+}
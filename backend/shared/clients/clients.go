@@ -1,6 +1,9 @@
 package clients
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 // NotificationClient sends notifications to users
 type NotificationClient interface {
@@ -12,11 +15,29 @@ type PrescriptionClient interface {
 	CreatePrescriptionFromReservation(ctx context.Context, userID, doctorName, diagnosis string, medications []MedicationItem) (string, error)
 }
 
-// MedicationItem for prescription creation
+// PrescriptionHistoryClient lists a user's prescriptions
+type PrescriptionHistoryClient interface {
+	// ListPrescriptions returns the user's most recent prescriptions regardless of status.
+	ListPrescriptions(ctx context.Context, userID string, limit int) ([]PrescriptionSummary, error)
+}
+
+// PrescriptionSummary represents a prescription for cross-service use
+type PrescriptionSummary struct {
+	PrescriptionID string
+	DoctorName     string
+	Diagnosis      string
+	Notes          string
+	Status         string // lower-case name such as "active"
+	Medications    []MedicationItem
+	PrescribedAt   time.Time
+}
+
+// MedicationItem for prescription creation and listing
 type MedicationItem struct {
-	Name     string
-	Dosage   string
-	Duration string
+	Name      string
+	Dosage    string
+	Duration  string
+	Frequency string
 }
 
 // HealthScoreClient gets health scores
@@ -27,6 +48,11 @@ type HealthScoreClient interface {
 // MeasurementClient gets measurement data
 type MeasurementClient interface {
 	GetLatestMeasurements(ctx context.Context, userID string, limit int) ([]MeasurementSummary, error)
+	// GetMeasurement returns a single measurement session with its readings.
+	// It returns (nil, nil) when the measurement does not exist.
+	GetMeasurement(ctx context.Context, measurementID string) (*MeasurementDetail, error)
+	// GetMeasurementsInRange returns all of the user's measurements measured within [start, end].
+	GetMeasurementsInRange(ctx context.Context, userID string, start, end time.Time) ([]MeasurementSummary, error)
 }
 
 // MeasurementSummary represents a measurement for cross-service use
@@ -36,9 +62,199 @@ type MeasurementSummary struct {
 	Value       float64
 	Unit        string
 	MeasuredAt  string
+	Confidence  float64
+	QCFlags     []string
+}
+
+// MeasurementDetail represents a single measurement session with its readings
+type MeasurementDetail struct {
+	SessionID string
+	UserID    string
+	DeviceID  string
+	Status    string
+	Readings  []MeasurementSummary
+}
+
+// SubstanceIdentifierClient identifies substances by comparing a measurement's
+// fingerprint with the curated reference library
+type SubstanceIdentifierClient interface {
+	// IdentifySubstance runs kNN identification on the measurement's fingerprint using the
+	// library defaults for k and the unknown-rejection threshold.
+	IdentifySubstance(ctx context.Context, measurementID string) (*SubstanceIdentification, error)
+}
+
+// SubstanceIdentification is the kNN identification result for a measurement.
+// Unknown is set when the nearest approved reference is farther than Threshold.
+type SubstanceIdentification struct {
+	CartridgeType   string
+	Candidates      []SubstanceCandidate
+	Unknown         bool
+	NearestDistance float32
+	Threshold       float32
+}
+
+// SubstanceCandidate is a candidate substance ordered by Confidence
+type SubstanceCandidate struct {
+	Substance              string
+	Distance               float32
+	Votes                  int
+	Confidence             float64
+	EstimatedConcentration float64
+	ConcentrationUnit      string
+}
+
+// CoachingClient gets coaching data such as health goals
+type CoachingClient interface {
+	// GetHealthGoals returns all of the user's health goals regardless of status.
+	GetHealthGoals(ctx context.Context, userID string) ([]HealthGoalSummary, error)
+	// ListCoachingMessages returns the user's most recent coaching messages, newest first.
+	ListCoachingMessages(ctx context.Context, userID string, limit int) ([]CoachingMessageSummary, error)
+}
+
+// CoachingMessageSummary represents a coaching message for cross-service use
+type CoachingMessageSummary struct {
+	MessageID     string
+	Title         string
+	Body          string
+	RelatedMetric string
+	CreatedAt     time.Time
+}
+
+// HealthGoalSummary represents a health goal for cross-service use.
+// Category and Status are lower-case names such as "exercise" and "active".
+type HealthGoalSummary struct {
+	GoalID       string
+	Category     string
+	MetricName   string
+	TargetValue  float64
+	CurrentValue float64
+	Unit         string
+	ProgressPct  float64
+	Status       string
+}
+
+// HealthReportClient resolves exported weekly and monthly health reports
+type HealthReportClient interface {
+	// GetHealthReportExport returns the report with freshly presigned download links.
+	// It fails with NotFound when the report does not exist or is not owned by userID.
+	GetHealthReportExport(ctx context.Context, exportID, userID string) (*HealthReportExport, error)
+}
+
+// HealthReportExport represents an exported health report for cross-service use.
+// Period is "weekly" or "monthly"; PeriodEnd is the last day of the period.
+type HealthReportExport struct {
+	ExportID     string
+	UserID       string
+	Period       string
+	PeriodStart  time.Time
+	PeriodEnd    time.Time
+	HTMLURL      string
+	PDFURL       string
+	URLExpiresAt time.Time
+}
+
+// HealthRecordClient gets health records and data sharing consents
+type HealthRecordClient interface {
+	// ListHealthRecords returns the user's most recent health records of all types.
+	ListHealthRecords(ctx context.Context, userID string, limit int) ([]HealthRecordSummary, error)
+	// ListConsents returns all of the user's data sharing consents regardless of status.
+	ListConsents(ctx context.Context, userID string) ([]DataSharingConsentSummary, error)
+}
+
+// HealthRecordSummary represents a health record for cross-service use.
+// RecordType is a lower-case name such as "lab_result".
+type HealthRecordSummary struct {
+	RecordID    string
+	RecordType  string
+	Title       string
+	Description string
+	Provider    string
+	CreatedAt   time.Time
+}
+
+// DataSharingConsentSummary represents a data sharing consent for cross-service use
+type DataSharingConsentSummary struct {
+	ConsentID  string
+	ProviderID string
+	Scope      []string
+	Status     string // "active", "revoked", "expired"
+	ExpiresAt  time.Time
+}
+
+// MealLogClient gets meal logs recorded by food image analysis
+type MealLogClient interface {
+	// GetMealLogs returns the user's completed meal analyses logged within [start, end].
+	GetMealLogs(ctx context.Context, userID string, start, end time.Time) ([]MealLog, error)
+}
+
+// MealLog represents a single analyzed meal
+type MealLog struct {
+	AnalysisID       string
+	MealType         string // breakfast, lunch, dinner, snack
+	TotalCalorieKcal float64
+	LoggedAt         time.Time
 }
 
-// SubscriptionClient checks subscription status
+// SubscriptionClient checks subscription status.
+// CheckAccess returns whether the feature is allowed and the user's current tier.
 type SubscriptionClient interface {
 	CheckAccess(ctx context.Context, userID, feature string) (bool, string, error)
 }
+
+// CoachingComposerClient personalizes rule-based coaching drafts with the ai-inference LLM.
+// Calls go through ai-inference's PHI redaction and medical-safety guardrails.
+type CoachingComposerClient interface {
+	// ComposeCoachingMessage returns the rewritten message body. It returns an error when
+	// the LLM is disabled or fails, in which case callers fall back to their own templates.
+	ComposeCoachingMessage(ctx context.Context, req CoachingComposeRequest) (*CoachingComposition, error)
+}
+
+// CoachingComposeRequest is a coaching draft with the user context to personalize it with.
+// CoachingType is a lower-case name such as "daily_tip".
+type CoachingComposeRequest struct {
+	UserID       string
+	CoachingType string
+	Language     string // "ko", "en", ...
+	DraftTitle   string
+	DraftBody    string
+	ContextLines []string // goal progress, recent measurements, adherence
+}
+
+// CoachingComposition is an LLM-written coaching message body
+type CoachingComposition struct {
+	Body     string
+	Provider string
+	Model    string
+	Cached   bool
+}
+
+// DeviceOwnerClient resolves device ownership over time
+type DeviceOwnerClient interface {
+	// OwnerAt returns the user who owned the device at the given time,
+	// or an empty string when the device had no owner then.
+	OwnerAt(ctx context.Context, deviceID string, at time.Time) (string, error)
+}
+
+// UserProfileClient gets user profile preferences
+type UserProfileClient interface {
+	// GetLanguage returns the user's preferred language code such as "ko" or "en",
+	// or an empty string when the user has not set one.
+	GetLanguage(ctx context.Context, userID string) (string, error)
+	// GetTimezone returns the user's IANA timezone such as "Asia/Seoul",
+	// or an empty string when the user has not set one.
+	GetTimezone(ctx context.Context, userID string) (string, error)
+}
+
+// NotificationPreferenceClient gets notification delivery preferences
+type NotificationPreferenceClient interface {
+	// GetQuietHours returns the user's do-not-disturb window as local "HH:MM" times,
+	// or empty strings when none is set.
+	GetQuietHours(ctx context.Context, userID string) (start, end string, err error)
+}
+
+// GuardianClient gets the people to alert when a user has a health emergency
+type GuardianClient interface {
+	// ListGuardians returns the user IDs of the guardians and family group owners in every
+	// family group the user belongs to, excluding the user.
+	ListGuardians(ctx context.Context, userID string) ([]string, error)
+}
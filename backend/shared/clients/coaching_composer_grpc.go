@@ -0,0 +1,39 @@
+package clients
+
+import (
+	"context"
+	"strings"
+
+	v1 "github.com/manpasik/backend/shared/gen/go/v1"
+)
+
+// GRPCCoachingComposerClient is a CoachingComposerClient backed by ai-inference-service gRPC
+type GRPCCoachingComposerClient struct {
+	client v1.AiInferenceServiceClient
+}
+
+// NewGRPCCoachingComposerClient creates a coaching composer client over an existing gRPC client
+func NewGRPCCoachingComposerClient(client v1.AiInferenceServiceClient) *GRPCCoachingComposerClient {
+	return &GRPCCoachingComposerClient{client: client}
+}
+
+// ComposeCoachingMessage asks ai-inference to personalize the draft body
+func (c *GRPCCoachingComposerClient) ComposeCoachingMessage(ctx context.Context, req CoachingComposeRequest) (*CoachingComposition, error) {
+	resp, err := c.client.ComposeCoachingMessage(ctx, &v1.ComposeCoachingMessageRequest{
+		UserId:       req.UserID,
+		CoachingType: v1.CoachingType(v1.CoachingType_value["COACHING_TYPE_"+strings.ToUpper(req.CoachingType)]),
+		Language:     req.Language,
+		DraftTitle:   req.DraftTitle,
+		DraftBody:    req.DraftBody,
+		ContextLines: req.ContextLines,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &CoachingComposition{
+		Body:     resp.Body,
+		Provider: resp.Provider,
+		Model:    resp.Model,
+		Cached:   resp.Cached,
+	}, nil
+}
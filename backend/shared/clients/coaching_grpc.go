@@ -0,0 +1,82 @@
+package clients
+
+import (
+	"context"
+	"strings"
+
+	v1 "github.com/manpasik/backend/shared/gen/go/v1"
+)
+
+// GRPCCoachingClient is a CoachingClient backed by coaching-service gRPC
+type GRPCCoachingClient struct {
+	client v1.CoachingServiceClient
+}
+
+// NewGRPCCoachingClient creates a coaching client over an existing gRPC client
+func NewGRPCCoachingClient(client v1.CoachingServiceClient) *GRPCCoachingClient {
+	return &GRPCCoachingClient{client: client}
+}
+
+// GetHealthGoals returns all of the user's health goals
+func (c *GRPCCoachingClient) GetHealthGoals(ctx context.Context, userID string) ([]HealthGoalSummary, error) {
+	resp, err := c.client.GetHealthGoals(ctx, &v1.GetHealthGoalsRequest{UserId: userID})
+	if err != nil {
+		return nil, err
+	}
+	result := make([]HealthGoalSummary, 0, len(resp.Goals))
+	for _, g := range resp.Goals {
+		result = append(result, HealthGoalSummary{
+			GoalID:       g.GoalId,
+			Category:     enumSuffix(g.Category.String(), "GOAL_CATEGORY_"),
+			MetricName:   g.MetricName,
+			TargetValue:  g.TargetValue,
+			CurrentValue: g.CurrentValue,
+			Unit:         g.Unit,
+			ProgressPct:  g.ProgressPct,
+			Status:       enumSuffix(g.Status.String(), "GOAL_STATUS_"),
+		})
+	}
+	return result, nil
+}
+
+// enumSuffix turns "GOAL_STATUS_ACTIVE" into "active"
+func enumSuffix(name, prefix string) string {
+	return strings.ToLower(strings.TrimPrefix(name, prefix))
+}
+
+// ListCoachingMessages returns the user's most recent coaching messages
+func (c *GRPCCoachingClient) ListCoachingMessages(ctx context.Context, userID string, limit int) ([]CoachingMessageSummary, error) {
+	resp, err := c.client.ListCoachingMessages(ctx, &v1.ListCoachingMessagesRequest{UserId: userID, Limit: int32(limit)})
+	if err != nil {
+		return nil, err
+	}
+	result := make([]CoachingMessageSummary, 0, len(resp.Messages))
+	for _, m := range resp.Messages {
+		result = append(result, CoachingMessageSummary{
+			MessageID:     m.MessageId,
+			Title:         m.Title,
+			Body:          m.Body,
+			RelatedMetric: m.RelatedMetric,
+			CreatedAt:     timeOf(m.CreatedAt),
+		})
+	}
+	return result, nil
+}
+
+// GetHealthReportExport returns an exported health report with fresh download links
+func (c *GRPCCoachingClient) GetHealthReportExport(ctx context.Context, exportID, userID string) (*HealthReportExport, error) {
+	resp, err := c.client.GetHealthReportExport(ctx, &v1.GetHealthReportExportRequest{ExportId: exportID, UserId: userID})
+	if err != nil {
+		return nil, err
+	}
+	return &HealthReportExport{
+		ExportID:     resp.ExportId,
+		UserID:       resp.UserId,
+		Period:       enumSuffix(resp.Period.String(), "REPORT_PERIOD_"),
+		PeriodStart:  timeOf(resp.PeriodStart),
+		PeriodEnd:    timeOf(resp.PeriodEnd),
+		HTMLURL:      resp.HtmlUrl,
+		PDFURL:       resp.PdfUrl,
+		URLExpiresAt: timeOf(resp.UrlExpiresAt),
+	}, nil
+}
@@ -0,0 +1,36 @@
+package clients
+
+import (
+	"context"
+	"time"
+
+	v1 "github.com/manpasik/backend/shared/gen/go/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// GRPCDeviceOwnerClient is a DeviceOwnerClient backed by device-service gRPC
+type GRPCDeviceOwnerClient struct {
+	client v1.DeviceServiceClient
+}
+
+// NewGRPCDeviceOwnerClient creates a device owner client over an existing gRPC client
+func NewGRPCDeviceOwnerClient(client v1.DeviceServiceClient) *GRPCDeviceOwnerClient {
+	return &GRPCDeviceOwnerClient{client: client}
+}
+
+// OwnerAt returns the device's owner at the given time from its ownership history
+func (c *GRPCDeviceOwnerClient) OwnerAt(ctx context.Context, deviceID string, at time.Time) (string, error) {
+	resp, err := c.client.GetDeviceOwnerAt(ctx, &v1.GetDeviceOwnerAtRequest{
+		DeviceId: deviceID,
+		At:       timestamppb.New(at),
+	})
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return "", nil
+		}
+		return "", err
+	}
+	return resp.UserId, nil
+}
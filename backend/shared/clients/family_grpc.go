@@ -0,0 +1,30 @@
+package clients
+
+import (
+	"context"
+
+	v1 "github.com/manpasik/backend/shared/gen/go/v1"
+)
+
+// GRPCGuardianClient is a GuardianClient backed by family-service gRPC
+type GRPCGuardianClient struct {
+	client v1.FamilyServiceClient
+}
+
+// NewGRPCGuardianClient creates a guardian client over an existing gRPC client
+func NewGRPCGuardianClient(client v1.FamilyServiceClient) *GRPCGuardianClient {
+	return &GRPCGuardianClient{client: client}
+}
+
+// ListGuardians returns the user's guardians from family-service
+func (c *GRPCGuardianClient) ListGuardians(ctx context.Context, userID string) ([]string, error) {
+	resp, err := c.client.ListGuardians(ctx, &v1.ListGuardiansRequest{UserId: userID})
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, 0, len(resp.Guardians))
+	for _, g := range resp.Guardians {
+		ids = append(ids, g.UserId)
+	}
+	return ids, nil
+}
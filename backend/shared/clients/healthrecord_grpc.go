@@ -0,0 +1,71 @@
+package clients
+
+import (
+	"context"
+	"time"
+
+	v1 "github.com/manpasik/backend/shared/gen/go/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// GRPCHealthRecordClient is a HealthRecordClient backed by health-record-service gRPC
+type GRPCHealthRecordClient struct {
+	client v1.HealthRecordServiceClient
+}
+
+// NewGRPCHealthRecordClient creates a health record client over an existing gRPC client
+func NewGRPCHealthRecordClient(client v1.HealthRecordServiceClient) *GRPCHealthRecordClient {
+	return &GRPCHealthRecordClient{client: client}
+}
+
+// ListHealthRecords returns the user's most recent health records
+func (c *GRPCHealthRecordClient) ListHealthRecords(ctx context.Context, userID string, limit int) ([]HealthRecordSummary, error) {
+	resp, err := c.client.ListRecords(ctx, &v1.ListHealthRecordsRequest{UserId: userID, Limit: int32(limit)})
+	if err != nil {
+		return nil, err
+	}
+	result := make([]HealthRecordSummary, 0, len(resp.Records))
+	for _, r := range resp.Records {
+		result = append(result, HealthRecordSummary{
+			RecordID:    r.RecordId,
+			RecordType:  enumSuffix(r.RecordType.String(), "HEALTH_RECORD_TYPE_"),
+			Title:       r.Title,
+			Description: r.Description,
+			Provider:    r.Provider,
+			CreatedAt:   timeOf(r.CreatedAt),
+		})
+	}
+	return result, nil
+}
+
+// ListConsents returns all of the user's data sharing consents
+func (c *GRPCHealthRecordClient) ListConsents(ctx context.Context, userID string) ([]DataSharingConsentSummary, error) {
+	resp, err := c.client.ListDataSharingConsents(ctx, &v1.ListConsentsRequest{UserId: userID})
+	if err != nil {
+		return nil, err
+	}
+	result := make([]DataSharingConsentSummary, 0, len(resp.Consents))
+	for _, cs := range resp.Consents {
+		s := DataSharingConsentSummary{
+			ConsentID:  cs.Id,
+			ProviderID: cs.ProviderId,
+			Scope:      cs.Scope,
+			Status:     cs.Status,
+		}
+		// health-record-service formats consent times as RFC3339; an unparsable
+		// expiry stays zero and is treated as expired by callers.
+		if t, err := time.Parse(time.RFC3339, cs.ExpiresAt); err == nil {
+			s.ExpiresAt = t
+		}
+		result = append(result, s)
+	}
+	return result, nil
+}
+
+// timeOf converts an optional protobuf timestamp, keeping nil as the zero time
+func timeOf(ts *timestamppb.Timestamp) time.Time {
+	if ts == nil {
+		return time.Time{}
+	}
+	return ts.AsTime()
+}
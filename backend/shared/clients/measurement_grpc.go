@@ -0,0 +1,126 @@
+package clients
+
+import (
+	"context"
+	"time"
+
+	v1 "github.com/manpasik/backend/shared/gen/go/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// historyPageSize matches the maximum page size of GetMeasurementHistory
+	historyPageSize = 100
+	// maxHistoryPages bounds a single range query
+	maxHistoryPages = 50
+)
+
+// GRPCMeasurementClient is a MeasurementClient backed by measurement-service gRPC
+type GRPCMeasurementClient struct {
+	client v1.MeasurementServiceClient
+}
+
+// NewGRPCMeasurementClient creates a measurement client over an existing gRPC client
+func NewGRPCMeasurementClient(client v1.MeasurementServiceClient) *GRPCMeasurementClient {
+	return &GRPCMeasurementClient{client: client}
+}
+
+// GetLatestMeasurements returns the user's most recent measurements
+func (c *GRPCMeasurementClient) GetLatestMeasurements(ctx context.Context, userID string, limit int) ([]MeasurementSummary, error) {
+	resp, err := c.client.GetMeasurementHistory(ctx, &v1.GetHistoryRequest{UserId: userID, Limit: int32(limit)})
+	if err != nil {
+		return nil, err
+	}
+	result := make([]MeasurementSummary, 0, len(resp.Measurements))
+	for _, m := range resp.Measurements {
+		result = append(result, toMeasurementSummary(m))
+	}
+	return result, nil
+}
+
+// GetMeasurementsInRange pages through GetMeasurementHistory for [start, end]
+func (c *GRPCMeasurementClient) GetMeasurementsInRange(ctx context.Context, userID string, start, end time.Time) ([]MeasurementSummary, error) {
+	var result []MeasurementSummary
+	for page := 0; page < maxHistoryPages; page++ {
+		resp, err := c.client.GetMeasurementHistory(ctx, &v1.GetHistoryRequest{
+			UserId:    userID,
+			StartTime: timestamppb.New(start),
+			EndTime:   timestamppb.New(end),
+			Limit:     historyPageSize,
+			Offset:    int32(page * historyPageSize),
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range resp.Measurements {
+			result = append(result, toMeasurementSummary(m))
+		}
+		if len(resp.Measurements) < historyPageSize || len(result) >= int(resp.TotalCount) {
+			break
+		}
+	}
+	return result, nil
+}
+
+// GetMeasurement returns a single measurement session with its readings
+func (c *GRPCMeasurementClient) GetMeasurement(ctx context.Context, measurementID string) (*MeasurementDetail, error) {
+	resp, err := c.client.GetMeasurement(ctx, &v1.GetMeasurementRequest{SessionId: measurementID})
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	detail := &MeasurementDetail{
+		SessionID: resp.SessionId,
+		UserID:    resp.UserId,
+		DeviceID:  resp.DeviceId,
+		Status:    resp.Status,
+	}
+	for _, r := range resp.Readings {
+		detail.Readings = append(detail.Readings, toMeasurementSummary(r))
+	}
+	return detail, nil
+}
+
+// IdentifySubstance identifies the measurement's substance against the reference library
+func (c *GRPCMeasurementClient) IdentifySubstance(ctx context.Context, measurementID string) (*SubstanceIdentification, error) {
+	resp, err := c.client.IdentifySubstance(ctx, &v1.IdentifySubstanceRequest{SessionId: measurementID})
+	if err != nil {
+		return nil, err
+	}
+	result := &SubstanceIdentification{
+		CartridgeType:   resp.CartridgeType,
+		Unknown:         resp.Unknown,
+		NearestDistance: resp.NearestDistance,
+		Threshold:       resp.Threshold,
+	}
+	for _, cand := range resp.Candidates {
+		result.Candidates = append(result.Candidates, SubstanceCandidate{
+			Substance:              cand.Substance,
+			Distance:               cand.Distance,
+			Votes:                  int(cand.Votes),
+			Confidence:             cand.Confidence,
+			EstimatedConcentration: cand.EstimatedConcentration,
+			ConcentrationUnit:      cand.ConcentrationUnit,
+		})
+	}
+	return result, nil
+}
+
+func toMeasurementSummary(m *v1.MeasurementSummary) MeasurementSummary {
+	s := MeasurementSummary{
+		SessionID:   m.SessionId,
+		BiomarkerID: m.CartridgeType,
+		Value:       m.PrimaryValue,
+		Unit:        m.Unit,
+		Confidence:  m.Confidence,
+		QCFlags:     m.QcFlags,
+	}
+	if m.MeasuredAt != nil {
+		s.MeasuredAt = m.MeasuredAt.AsTime().UTC().Format(time.RFC3339)
+	}
+	return s
+}
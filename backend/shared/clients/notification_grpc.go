@@ -0,0 +1,42 @@
+package clients
+
+import (
+	"context"
+	"strings"
+
+	v1 "github.com/manpasik/backend/shared/gen/go/v1"
+)
+
+// GRPCNotificationClient is a NotificationClient and NotificationPreferenceClient
+// backed by notification-service gRPC
+type GRPCNotificationClient struct {
+	client v1.NotificationServiceClient
+}
+
+// NewGRPCNotificationClient creates a notification client over an existing gRPC client
+func NewGRPCNotificationClient(client v1.NotificationServiceClient) *GRPCNotificationClient {
+	return &GRPCNotificationClient{client: client}
+}
+
+// SendNotification sends a notification. notifType, priority and channel are lower-case
+// enum names such as "prescription", "high" and "push"; unknown names map to UNKNOWN.
+func (c *GRPCNotificationClient) SendNotification(ctx context.Context, userID, notifType, title, body, priority, channel string) error {
+	_, err := c.client.SendNotification(ctx, &v1.SendNotificationRequest{
+		UserId:   userID,
+		Type:     v1.NotificationType(v1.NotificationType_value["NOTIFICATION_TYPE_"+strings.ToUpper(notifType)]),
+		Title:    title,
+		Body:     body,
+		Priority: v1.NotificationPriority(v1.NotificationPriority_value["NOTIFICATION_PRIORITY_"+strings.ToUpper(priority)]),
+		Channel:  v1.NotificationChannel(v1.NotificationChannel_value["NOTIFICATION_CHANNEL_"+strings.ToUpper(channel)]),
+	})
+	return err
+}
+
+// GetQuietHours returns the quiet hours from the user's notification preferences
+func (c *GRPCNotificationClient) GetQuietHours(ctx context.Context, userID string) (string, string, error) {
+	prefs, err := c.client.GetNotificationPreferences(ctx, &v1.GetNotificationPreferencesRequest{UserId: userID})
+	if err != nil {
+		return "", "", err
+	}
+	return prefs.QuietHoursStart, prefs.QuietHoursEnd, nil
+}
@@ -0,0 +1,46 @@
+package clients
+
+import (
+	"context"
+	"fmt"
+
+	v1 "github.com/manpasik/backend/shared/gen/go/v1"
+)
+
+// GRPCPrescriptionClient is a PrescriptionHistoryClient backed by prescription-service gRPC
+type GRPCPrescriptionClient struct {
+	client v1.PrescriptionServiceClient
+}
+
+// NewGRPCPrescriptionClient creates a prescription client over an existing gRPC client
+func NewGRPCPrescriptionClient(client v1.PrescriptionServiceClient) *GRPCPrescriptionClient {
+	return &GRPCPrescriptionClient{client: client}
+}
+
+// ListPrescriptions returns the user's most recent prescriptions
+func (c *GRPCPrescriptionClient) ListPrescriptions(ctx context.Context, userID string, limit int) ([]PrescriptionSummary, error) {
+	resp, err := c.client.ListPrescriptions(ctx, &v1.ListPrescriptionsRequest{UserId: userID, Limit: int32(limit)})
+	if err != nil {
+		return nil, err
+	}
+	result := make([]PrescriptionSummary, 0, len(resp.Prescriptions))
+	for _, p := range resp.Prescriptions {
+		s := PrescriptionSummary{
+			PrescriptionID: p.PrescriptionId,
+			DoctorName:     p.DoctorName,
+			Diagnosis:      p.Diagnosis,
+			Notes:          p.Notes,
+			Status:         enumSuffix(p.Status.String(), "PRESCRIPTION_STATUS_"),
+			PrescribedAt:   timeOf(p.PrescribedAt),
+		}
+		for _, m := range p.Medications {
+			item := MedicationItem{Name: m.Name, Dosage: m.Dosage, Frequency: m.Frequency}
+			if m.DurationDays > 0 {
+				item.Duration = fmt.Sprintf("%d일", m.DurationDays)
+			}
+			s.Medications = append(s.Medications, item)
+		}
+		result = append(result, s)
+	}
+	return result, nil
+}
@@ -0,0 +1,27 @@
+package clients
+
+import (
+	"context"
+
+	v1 "github.com/manpasik/backend/shared/gen/go/v1"
+)
+
+// GRPCSubscriptionClient is a SubscriptionClient backed by subscription-service gRPC
+type GRPCSubscriptionClient struct {
+	client v1.SubscriptionServiceClient
+}
+
+// NewGRPCSubscriptionClient creates a subscription client over an existing gRPC client
+func NewGRPCSubscriptionClient(client v1.SubscriptionServiceClient) *GRPCSubscriptionClient {
+	return &GRPCSubscriptionClient{client: client}
+}
+
+// CheckAccess reports whether the user may use feature, along with the user's
+// current tier in lower case (free, basic, pro, clinical)
+func (c *GRPCSubscriptionClient) CheckAccess(ctx context.Context, userID, feature string) (bool, string, error) {
+	resp, err := c.client.CheckFeatureAccess(ctx, &v1.CheckFeatureAccessRequest{UserId: userID, FeatureName: feature})
+	if err != nil {
+		return false, "", err
+	}
+	return resp.Allowed, enumSuffix(resp.CurrentTier.String(), "SUBSCRIPTION_TIER_"), nil
+}
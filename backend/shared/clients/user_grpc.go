@@ -0,0 +1,35 @@
+package clients
+
+import (
+	"context"
+
+	v1 "github.com/manpasik/backend/shared/gen/go/v1"
+)
+
+// GRPCUserProfileClient is a UserProfileClient backed by user-service gRPC
+type GRPCUserProfileClient struct {
+	client v1.UserServiceClient
+}
+
+// NewGRPCUserProfileClient creates a user profile client over an existing gRPC client
+func NewGRPCUserProfileClient(client v1.UserServiceClient) *GRPCUserProfileClient {
+	return &GRPCUserProfileClient{client: client}
+}
+
+// GetLanguage returns the language set on the user's profile
+func (c *GRPCUserProfileClient) GetLanguage(ctx context.Context, userID string) (string, error) {
+	profile, err := c.client.GetProfile(ctx, &v1.GetProfileRequest{UserId: userID})
+	if err != nil {
+		return "", err
+	}
+	return profile.Language, nil
+}
+
+// GetTimezone returns the timezone set on the user's profile
+func (c *GRPCUserProfileClient) GetTimezone(ctx context.Context, userID string) (string, error) {
+	profile, err := c.client.GetProfile(ctx, &v1.GetProfileRequest{UserId: userID})
+	if err != nil {
+		return "", err
+	}
+	return profile.Timezone, nil
+}
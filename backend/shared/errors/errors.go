@@ -39,6 +39,7 @@ const (
 	ErrSubscriptionRequired ErrorCode = "SUBSCRIPTION_REQUIRED"
 	ErrCartridgeExpired     ErrorCode = "CARTRIDGE_EXPIRED"
 	ErrMeasurementFailed    ErrorCode = "MEASUREMENT_FAILED"
+	ErrQuotaExceeded        ErrorCode = "QUOTA_EXCEEDED"
 )
 
 // AppError는 만파식 애플리케이션 에러입니다.
@@ -85,6 +86,8 @@ func (e *AppError) grpcCode() codes.Code {
 		return codes.Unavailable
 	case ErrDeviceLimitExceeded, ErrSubscriptionRequired, ErrCartridgeExpired:
 		return codes.FailedPrecondition
+	case ErrQuotaExceeded:
+		return codes.ResourceExhausted
 	default:
 		return codes.Internal
 	}
@@ -109,6 +112,8 @@ func FromGRPC(err error) *AppError {
 		code = ErrNotFound
 	case codes.AlreadyExists:
 		code = ErrAlreadyExists
+	case codes.ResourceExhausted:
+		code = ErrQuotaExceeded
 	}
 
 	return New(code, st.Message())
@@ -2,6 +2,7 @@ package events
 
 import (
 	"context"
+	"encoding/json"
 	"sync"
 )
 
@@ -21,6 +22,23 @@ type Event struct {
 // Handler is a function that handles an event
 type Handler func(ctx context.Context, event Event) error
 
+// PayloadOf returns the domain payload of an event. Publishers wrap it in the
+// envelope's "payload" field, which arrives as a map after a Kafka round trip
+// and as json.RawMessage when published in-process. Events without an envelope
+// return the top-level fields.
+func PayloadOf(event Event) map[string]interface{} {
+	switch inner := event.Payload["payload"].(type) {
+	case map[string]interface{}:
+		return inner
+	case json.RawMessage:
+		var m map[string]interface{}
+		if err := json.Unmarshal(inner, &m); err == nil {
+			return m
+		}
+	}
+	return event.Payload
+}
+
 // EventBus provides publish-subscribe messaging
 type EventBus struct {
 	mu       sync.RWMutex
@@ -66,6 +84,14 @@ const (
 	EventPrescriptionSentToPharmacy = "prescription.sent_to_pharmacy"
 	EventPrescriptionDispensed      = "prescription.dispensed"
 
+	// Device
+	EventDeviceTransferred  = "device.transferred"
+	EventDeviceDeregistered = "device.deregistered"
+
+	// IoT Gateway
+	EventIoTCommandFailed = "iot.command_failed"
+	EventIoTRuleTriggered = "iot.rule_triggered"
+
 	// Measurement & Calibration
 	EventMeasurementCompleted   = "measurement.completed"
 	EventCalibrationCompleted   = "calibration.completed"
@@ -73,10 +99,15 @@ const (
 
 	// AI / Inference
 	EventAIAnalysisCompleted = "ai.analysis_completed"
+	EventAIAnomalyDetected   = "ai.anomaly_detected"
 
 	// Coaching
-	EventCoachingGoalSet     = "coaching.goal_set"
-	EventCoachingTipDelivered = "coaching.tip_delivered"
+	EventCoachingGoalSet       = "coaching.goal_set"
+	EventCoachingTipDelivered  = "coaching.tip_delivered"
+	EventCoachingGoalMilestone = "coaching.goal_milestone"
+
+	// Vision
+	EventVisionMealAnalyzed = "vision.meal_analyzed"
 
 	// Health
 	EventHealthAlertTriggered = "health_alert.triggered"
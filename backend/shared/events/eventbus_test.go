@@ -0,0 +1,25 @@
+package events
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestPayloadOf(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload map[string]interface{}
+	}{
+		{"kafka envelope", map[string]interface{}{"event_type": "x", "payload": map[string]interface{}{"user_id": "u-1"}}},
+		{"in-process envelope", map[string]interface{}{"event_type": "x", "payload": json.RawMessage(`{"user_id":"u-1"}`)}},
+		{"no envelope", map[string]interface{}{"user_id": "u-1"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := PayloadOf(Event{Type: "x", Payload: tt.payload})
+			if got["user_id"] != "u-1" {
+				t.Errorf("PayloadOf() = %v, want user_id u-1", got)
+			}
+		})
+	}
+}
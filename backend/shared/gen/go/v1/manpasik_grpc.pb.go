@@ -349,12 +349,17 @@ var AuthService_ServiceDesc = grpc.ServiceDesc{
 }
 
 const (
-	MeasurementService_StartSession_FullMethodName             = "/manpasik.v1.MeasurementService/StartSession"
-	MeasurementService_StreamMeasurement_FullMethodName        = "/manpasik.v1.MeasurementService/StreamMeasurement"
-	MeasurementService_EndSession_FullMethodName               = "/manpasik.v1.MeasurementService/EndSession"
-	MeasurementService_GetMeasurementHistory_FullMethodName    = "/manpasik.v1.MeasurementService/GetMeasurementHistory"
-	MeasurementService_ExportSingleMeasurement_FullMethodName  = "/manpasik.v1.MeasurementService/ExportSingleMeasurement"
-	MeasurementService_ExportToFHIRObservations_FullMethodName = "/manpasik.v1.MeasurementService/ExportToFHIRObservations"
+	MeasurementService_StartSession_FullMethodName               = "/manpasik.v1.MeasurementService/StartSession"
+	MeasurementService_StreamMeasurement_FullMethodName          = "/manpasik.v1.MeasurementService/StreamMeasurement"
+	MeasurementService_EndSession_FullMethodName                 = "/manpasik.v1.MeasurementService/EndSession"
+	MeasurementService_GetMeasurementHistory_FullMethodName      = "/manpasik.v1.MeasurementService/GetMeasurementHistory"
+	MeasurementService_GetMeasurement_FullMethodName             = "/manpasik.v1.MeasurementService/GetMeasurement"
+	MeasurementService_ExportSingleMeasurement_FullMethodName    = "/manpasik.v1.MeasurementService/ExportSingleMeasurement"
+	MeasurementService_ExportToFHIRObservations_FullMethodName   = "/manpasik.v1.MeasurementService/ExportToFHIRObservations"
+	MeasurementService_AddFingerprintReference_FullMethodName    = "/manpasik.v1.MeasurementService/AddFingerprintReference"
+	MeasurementService_ReviewFingerprintReference_FullMethodName = "/manpasik.v1.MeasurementService/ReviewFingerprintReference"
+	MeasurementService_ListFingerprintReferences_FullMethodName  = "/manpasik.v1.MeasurementService/ListFingerprintReferences"
+	MeasurementService_IdentifySubstance_FullMethodName          = "/manpasik.v1.MeasurementService/IdentifySubstance"
 )
 
 // MeasurementServiceClient is the client API for MeasurementService service.
@@ -369,9 +374,17 @@ type MeasurementServiceClient interface {
 	EndSession(ctx context.Context, in *EndSessionRequest, opts ...grpc.CallOption) (*EndSessionResponse, error)
 	// 측정 기록 조회
 	GetMeasurementHistory(ctx context.Context, in *GetHistoryRequest, opts ...grpc.CallOption) (*GetHistoryResponse, error)
+	// 단일 측정 세션 조회 (측정값별 신뢰도·QC 플래그 포함)
+	GetMeasurement(ctx context.Context, in *GetMeasurementRequest, opts ...grpc.CallOption) (*GetMeasurementResponse, error)
 	// Phase 4: FHIR export
 	ExportSingleMeasurement(ctx context.Context, in *ExportSingleMeasurementRequest, opts ...grpc.CallOption) (*ExportFHIRResponse, error)
 	ExportToFHIRObservations(ctx context.Context, in *ExportToFHIRObservationsRequest, opts ...grpc.CallOption) (*ExportFHIRResponse, error)
+	// 핑거프린트 참조 라이브러리 (관리자 큐레이션)
+	AddFingerprintReference(ctx context.Context, in *AddFingerprintReferenceRequest, opts ...grpc.CallOption) (*FingerprintReference, error)
+	ReviewFingerprintReference(ctx context.Context, in *ReviewFingerprintReferenceRequest, opts ...grpc.CallOption) (*FingerprintReference, error)
+	ListFingerprintReferences(ctx context.Context, in *ListFingerprintReferencesRequest, opts ...grpc.CallOption) (*ListFingerprintReferencesResponse, error)
+	// 참조 라이브러리 kNN 물질 식별
+	IdentifySubstance(ctx context.Context, in *IdentifySubstanceRequest, opts ...grpc.CallOption) (*SubstanceIdentification, error)
 }
 
 type measurementServiceClient struct {
@@ -425,6 +438,16 @@ func (c *measurementServiceClient) GetMeasurementHistory(ctx context.Context, in
 	return out, nil
 }
 
+func (c *measurementServiceClient) GetMeasurement(ctx context.Context, in *GetMeasurementRequest, opts ...grpc.CallOption) (*GetMeasurementResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetMeasurementResponse)
+	err := c.cc.Invoke(ctx, MeasurementService_GetMeasurement_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *measurementServiceClient) ExportSingleMeasurement(ctx context.Context, in *ExportSingleMeasurementRequest, opts ...grpc.CallOption) (*ExportFHIRResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ExportFHIRResponse)
@@ -445,6 +468,46 @@ func (c *measurementServiceClient) ExportToFHIRObservations(ctx context.Context,
 	return out, nil
 }
 
+func (c *measurementServiceClient) AddFingerprintReference(ctx context.Context, in *AddFingerprintReferenceRequest, opts ...grpc.CallOption) (*FingerprintReference, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FingerprintReference)
+	err := c.cc.Invoke(ctx, MeasurementService_AddFingerprintReference_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *measurementServiceClient) ReviewFingerprintReference(ctx context.Context, in *ReviewFingerprintReferenceRequest, opts ...grpc.CallOption) (*FingerprintReference, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FingerprintReference)
+	err := c.cc.Invoke(ctx, MeasurementService_ReviewFingerprintReference_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *measurementServiceClient) ListFingerprintReferences(ctx context.Context, in *ListFingerprintReferencesRequest, opts ...grpc.CallOption) (*ListFingerprintReferencesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListFingerprintReferencesResponse)
+	err := c.cc.Invoke(ctx, MeasurementService_ListFingerprintReferences_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *measurementServiceClient) IdentifySubstance(ctx context.Context, in *IdentifySubstanceRequest, opts ...grpc.CallOption) (*SubstanceIdentification, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SubstanceIdentification)
+	err := c.cc.Invoke(ctx, MeasurementService_IdentifySubstance_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // MeasurementServiceServer is the server API for MeasurementService service.
 // All implementations must embed UnimplementedMeasurementServiceServer
 // for forward compatibility.
@@ -457,9 +520,17 @@ type MeasurementServiceServer interface {
 	EndSession(context.Context, *EndSessionRequest) (*EndSessionResponse, error)
 	// 측정 기록 조회
 	GetMeasurementHistory(context.Context, *GetHistoryRequest) (*GetHistoryResponse, error)
+	// 단일 측정 세션 조회 (측정값별 신뢰도·QC 플래그 포함)
+	GetMeasurement(context.Context, *GetMeasurementRequest) (*GetMeasurementResponse, error)
 	// Phase 4: FHIR export
 	ExportSingleMeasurement(context.Context, *ExportSingleMeasurementRequest) (*ExportFHIRResponse, error)
 	ExportToFHIRObservations(context.Context, *ExportToFHIRObservationsRequest) (*ExportFHIRResponse, error)
+	// 핑거프린트 참조 라이브러리 (관리자 큐레이션)
+	AddFingerprintReference(context.Context, *AddFingerprintReferenceRequest) (*FingerprintReference, error)
+	ReviewFingerprintReference(context.Context, *ReviewFingerprintReferenceRequest) (*FingerprintReference, error)
+	ListFingerprintReferences(context.Context, *ListFingerprintReferencesRequest) (*ListFingerprintReferencesResponse, error)
+	// 참조 라이브러리 kNN 물질 식별
+	IdentifySubstance(context.Context, *IdentifySubstanceRequest) (*SubstanceIdentification, error)
 	mustEmbedUnimplementedMeasurementServiceServer()
 }
 
@@ -482,12 +553,27 @@ func (UnimplementedMeasurementServiceServer) EndSession(context.Context, *EndSes
 func (UnimplementedMeasurementServiceServer) GetMeasurementHistory(context.Context, *GetHistoryRequest) (*GetHistoryResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetMeasurementHistory not implemented")
 }
+func (UnimplementedMeasurementServiceServer) GetMeasurement(context.Context, *GetMeasurementRequest) (*GetMeasurementResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetMeasurement not implemented")
+}
 func (UnimplementedMeasurementServiceServer) ExportSingleMeasurement(context.Context, *ExportSingleMeasurementRequest) (*ExportFHIRResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method ExportSingleMeasurement not implemented")
 }
 func (UnimplementedMeasurementServiceServer) ExportToFHIRObservations(context.Context, *ExportToFHIRObservationsRequest) (*ExportFHIRResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method ExportToFHIRObservations not implemented")
 }
+func (UnimplementedMeasurementServiceServer) AddFingerprintReference(context.Context, *AddFingerprintReferenceRequest) (*FingerprintReference, error) {
+	return nil, status.Error(codes.Unimplemented, "method AddFingerprintReference not implemented")
+}
+func (UnimplementedMeasurementServiceServer) ReviewFingerprintReference(context.Context, *ReviewFingerprintReferenceRequest) (*FingerprintReference, error) {
+	return nil, status.Error(codes.Unimplemented, "method ReviewFingerprintReference not implemented")
+}
+func (UnimplementedMeasurementServiceServer) ListFingerprintReferences(context.Context, *ListFingerprintReferencesRequest) (*ListFingerprintReferencesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListFingerprintReferences not implemented")
+}
+func (UnimplementedMeasurementServiceServer) IdentifySubstance(context.Context, *IdentifySubstanceRequest) (*SubstanceIdentification, error) {
+	return nil, status.Error(codes.Unimplemented, "method IdentifySubstance not implemented")
+}
 func (UnimplementedMeasurementServiceServer) mustEmbedUnimplementedMeasurementServiceServer() {}
 func (UnimplementedMeasurementServiceServer) testEmbeddedByValue()                            {}
 
@@ -570,6 +656,24 @@ func _MeasurementService_GetMeasurementHistory_Handler(srv interface{}, ctx cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MeasurementService_GetMeasurement_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMeasurementRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MeasurementServiceServer).GetMeasurement(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MeasurementService_GetMeasurement_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MeasurementServiceServer).GetMeasurement(ctx, req.(*GetMeasurementRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _MeasurementService_ExportSingleMeasurement_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ExportSingleMeasurementRequest)
 	if err := dec(in); err != nil {
@@ -606,6 +710,78 @@ func _MeasurementService_ExportToFHIRObservations_Handler(srv interface{}, ctx c
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MeasurementService_AddFingerprintReference_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddFingerprintReferenceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MeasurementServiceServer).AddFingerprintReference(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MeasurementService_AddFingerprintReference_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MeasurementServiceServer).AddFingerprintReference(ctx, req.(*AddFingerprintReferenceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MeasurementService_ReviewFingerprintReference_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReviewFingerprintReferenceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MeasurementServiceServer).ReviewFingerprintReference(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MeasurementService_ReviewFingerprintReference_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MeasurementServiceServer).ReviewFingerprintReference(ctx, req.(*ReviewFingerprintReferenceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MeasurementService_ListFingerprintReferences_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListFingerprintReferencesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MeasurementServiceServer).ListFingerprintReferences(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MeasurementService_ListFingerprintReferences_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MeasurementServiceServer).ListFingerprintReferences(ctx, req.(*ListFingerprintReferencesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MeasurementService_IdentifySubstance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IdentifySubstanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MeasurementServiceServer).IdentifySubstance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MeasurementService_IdentifySubstance_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MeasurementServiceServer).IdentifySubstance(ctx, req.(*IdentifySubstanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // MeasurementService_ServiceDesc is the grpc.ServiceDesc for MeasurementService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -625,6 +801,10 @@ var MeasurementService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetMeasurementHistory",
 			Handler:    _MeasurementService_GetMeasurementHistory_Handler,
 		},
+		{
+			MethodName: "GetMeasurement",
+			Handler:    _MeasurementService_GetMeasurement_Handler,
+		},
 		{
 			MethodName: "ExportSingleMeasurement",
 			Handler:    _MeasurementService_ExportSingleMeasurement_Handler,
@@ -633,6 +813,22 @@ var MeasurementService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ExportToFHIRObservations",
 			Handler:    _MeasurementService_ExportToFHIRObservations_Handler,
 		},
+		{
+			MethodName: "AddFingerprintReference",
+			Handler:    _MeasurementService_AddFingerprintReference_Handler,
+		},
+		{
+			MethodName: "ReviewFingerprintReference",
+			Handler:    _MeasurementService_ReviewFingerprintReference_Handler,
+		},
+		{
+			MethodName: "ListFingerprintReferences",
+			Handler:    _MeasurementService_ListFingerprintReferences_Handler,
+		},
+		{
+			MethodName: "IdentifySubstance",
+			Handler:    _MeasurementService_IdentifySubstance_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -646,11 +842,37 @@ var MeasurementService_ServiceDesc = grpc.ServiceDesc{
 }
 
 const (
-	DeviceService_RegisterDevice_FullMethodName     = "/manpasik.v1.DeviceService/RegisterDevice"
-	DeviceService_ListDevices_FullMethodName        = "/manpasik.v1.DeviceService/ListDevices"
-	DeviceService_StreamDeviceStatus_FullMethodName = "/manpasik.v1.DeviceService/StreamDeviceStatus"
-	DeviceService_RequestOtaUpdate_FullMethodName   = "/manpasik.v1.DeviceService/RequestOtaUpdate"
-	DeviceService_UpdateDeviceStatus_FullMethodName = "/manpasik.v1.DeviceService/UpdateDeviceStatus"
+	DeviceService_RegisterDevice_FullMethodName             = "/manpasik.v1.DeviceService/RegisterDevice"
+	DeviceService_ListDevices_FullMethodName                = "/manpasik.v1.DeviceService/ListDevices"
+	DeviceService_StreamDeviceStatus_FullMethodName         = "/manpasik.v1.DeviceService/StreamDeviceStatus"
+	DeviceService_RequestOtaUpdate_FullMethodName           = "/manpasik.v1.DeviceService/RequestOtaUpdate"
+	DeviceService_UpdateDeviceStatus_FullMethodName         = "/manpasik.v1.DeviceService/UpdateDeviceStatus"
+	DeviceService_InitiateDeviceTransfer_FullMethodName     = "/manpasik.v1.DeviceService/InitiateDeviceTransfer"
+	DeviceService_ConfirmDeviceTransfer_FullMethodName      = "/manpasik.v1.DeviceService/ConfirmDeviceTransfer"
+	DeviceService_CancelDeviceTransfer_FullMethodName       = "/manpasik.v1.DeviceService/CancelDeviceTransfer"
+	DeviceService_ListDeviceOwnershipHistory_FullMethodName = "/manpasik.v1.DeviceService/ListDeviceOwnershipHistory"
+	DeviceService_GetDeviceOwnerAt_FullMethodName           = "/manpasik.v1.DeviceService/GetDeviceOwnerAt"
+	DeviceService_DeregisterDevice_FullMethodName           = "/manpasik.v1.DeviceService/DeregisterDevice"
+	DeviceService_RequestRemoteWipe_FullMethodName          = "/manpasik.v1.DeviceService/RequestRemoteWipe"
+	DeviceService_ListPendingCommands_FullMethodName        = "/manpasik.v1.DeviceService/ListPendingCommands"
+	DeviceService_AcknowledgeDeviceCommand_FullMethodName   = "/manpasik.v1.DeviceService/AcknowledgeDeviceCommand"
+	DeviceService_RegisterHub_FullMethodName                = "/manpasik.v1.DeviceService/RegisterHub"
+	DeviceService_AttachReadersToHub_FullMethodName         = "/manpasik.v1.DeviceService/AttachReadersToHub"
+	DeviceService_DetachReaderFromHub_FullMethodName        = "/manpasik.v1.DeviceService/DetachReaderFromHub"
+	DeviceService_ReportHubRelay_FullMethodName             = "/manpasik.v1.DeviceService/ReportHubRelay"
+	DeviceService_GetHubStatus_FullMethodName               = "/manpasik.v1.DeviceService/GetHubStatus"
+	DeviceService_ListHubReaders_FullMethodName             = "/manpasik.v1.DeviceService/ListHubReaders"
+	DeviceService_SendDeviceCommand_FullMethodName          = "/manpasik.v1.DeviceService/SendDeviceCommand"
+	DeviceService_CreateDeviceGroup_FullMethodName          = "/manpasik.v1.DeviceService/CreateDeviceGroup"
+	DeviceService_ListDeviceGroups_FullMethodName           = "/manpasik.v1.DeviceService/ListDeviceGroups"
+	DeviceService_AddDeviceGroupMember_FullMethodName       = "/manpasik.v1.DeviceService/AddDeviceGroupMember"
+	DeviceService_RemoveDeviceGroupMember_FullMethodName    = "/manpasik.v1.DeviceService/RemoveDeviceGroupMember"
+	DeviceService_AddDevicesToGroup_FullMethodName          = "/manpasik.v1.DeviceService/AddDevicesToGroup"
+	DeviceService_RemoveDeviceFromGroup_FullMethodName      = "/manpasik.v1.DeviceService/RemoveDeviceFromGroup"
+	DeviceService_SendBulkCommand_FullMethodName            = "/manpasik.v1.DeviceService/SendBulkCommand"
+	DeviceService_GetBulkCommandStatus_FullMethodName       = "/manpasik.v1.DeviceService/GetBulkCommandStatus"
+	DeviceService_GetFleetStats_FullMethodName              = "/manpasik.v1.DeviceService/GetFleetStats"
+	DeviceService_ListFleetStats_FullMethodName             = "/manpasik.v1.DeviceService/ListFleetStats"
 )
 
 // DeviceServiceClient is the client API for DeviceService service.
@@ -667,6 +889,38 @@ type DeviceServiceClient interface {
 	RequestOtaUpdate(ctx context.Context, in *OtaRequest, opts ...grpc.CallOption) (*OtaResponse, error)
 	// Phase 4: Device status management
 	UpdateDeviceStatus(ctx context.Context, in *UpdateDeviceStatusRequest, opts ...grpc.CallOption) (*UpdateDeviceStatusResponse, error)
+	// 소유권 이전 (양측 확인 필요)
+	InitiateDeviceTransfer(ctx context.Context, in *InitiateDeviceTransferRequest, opts ...grpc.CallOption) (*DeviceTransferInfo, error)
+	ConfirmDeviceTransfer(ctx context.Context, in *ConfirmDeviceTransferRequest, opts ...grpc.CallOption) (*DeviceTransferInfo, error)
+	CancelDeviceTransfer(ctx context.Context, in *CancelDeviceTransferRequest, opts ...grpc.CallOption) (*DeviceTransferInfo, error)
+	ListDeviceOwnershipHistory(ctx context.Context, in *ListDeviceOwnershipHistoryRequest, opts ...grpc.CallOption) (*ListDeviceOwnershipHistoryResponse, error)
+	// 지정 시점의 소유자 조회 (측정·보정 기록 귀속)
+	GetDeviceOwnerAt(ctx context.Context, in *GetDeviceOwnerAtRequest, opts ...grpc.CallOption) (*GetDeviceOwnerAtResponse, error)
+	// 등록 해제 및 원격 초기화
+	DeregisterDevice(ctx context.Context, in *DeregisterDeviceRequest, opts ...grpc.CallOption) (*DeregisterDeviceResponse, error)
+	RequestRemoteWipe(ctx context.Context, in *RemoteWipeRequest, opts ...grpc.CallOption) (*RemoteWipeResponse, error)
+	// 대기 중인 디바이스 명령 조회 / 수신 확인
+	ListPendingCommands(ctx context.Context, in *ListPendingCommandsRequest, opts ...grpc.CallOption) (*ListPendingCommandsResponse, error)
+	AcknowledgeDeviceCommand(ctx context.Context, in *AcknowledgeDeviceCommandRequest, opts ...grpc.CallOption) (*AcknowledgeDeviceCommandResponse, error)
+	// 허브 토폴로지 (BLE 리더 → Wi-Fi 허브 → 클라우드 게이트웨이)
+	RegisterHub(ctx context.Context, in *RegisterHubRequest, opts ...grpc.CallOption) (*RegisterDeviceResponse, error)
+	AttachReadersToHub(ctx context.Context, in *AttachReadersToHubRequest, opts ...grpc.CallOption) (*AttachReadersToHubResponse, error)
+	DetachReaderFromHub(ctx context.Context, in *DetachReaderFromHubRequest, opts ...grpc.CallOption) (*DetachReaderFromHubResponse, error)
+	ReportHubRelay(ctx context.Context, in *HubRelayReport, opts ...grpc.CallOption) (*HubRelayResponse, error)
+	GetHubStatus(ctx context.Context, in *GetHubStatusRequest, opts ...grpc.CallOption) (*HubStatus, error)
+	ListHubReaders(ctx context.Context, in *ListHubReadersRequest, opts ...grpc.CallOption) (*ListDevicesResponse, error)
+	SendDeviceCommand(ctx context.Context, in *SendDeviceCommandRequest, opts ...grpc.CallOption) (*SendDeviceCommandResponse, error)
+	// 클리니컬 플릿 관리 (디바이스 그룹, 일괄 명령, 플릿 통계)
+	CreateDeviceGroup(ctx context.Context, in *CreateDeviceGroupRequest, opts ...grpc.CallOption) (*DeviceGroupInfo, error)
+	ListDeviceGroups(ctx context.Context, in *ListDeviceGroupsRequest, opts ...grpc.CallOption) (*ListDeviceGroupsResponse, error)
+	AddDeviceGroupMember(ctx context.Context, in *AddDeviceGroupMemberRequest, opts ...grpc.CallOption) (*DeviceGroupMember, error)
+	RemoveDeviceGroupMember(ctx context.Context, in *RemoveDeviceGroupMemberRequest, opts ...grpc.CallOption) (*RemoveDeviceGroupMemberResponse, error)
+	AddDevicesToGroup(ctx context.Context, in *AddDevicesToGroupRequest, opts ...grpc.CallOption) (*AddDevicesToGroupResponse, error)
+	RemoveDeviceFromGroup(ctx context.Context, in *RemoveDeviceFromGroupRequest, opts ...grpc.CallOption) (*RemoveDeviceFromGroupResponse, error)
+	SendBulkCommand(ctx context.Context, in *SendBulkCommandRequest, opts ...grpc.CallOption) (*BulkCommandInfo, error)
+	GetBulkCommandStatus(ctx context.Context, in *GetBulkCommandStatusRequest, opts ...grpc.CallOption) (*BulkCommandInfo, error)
+	GetFleetStats(ctx context.Context, in *GetFleetStatsRequest, opts ...grpc.CallOption) (*FleetStats, error)
+	ListFleetStats(ctx context.Context, in *ListFleetStatsRequest, opts ...grpc.CallOption) (*ListFleetStatsResponse, error)
 }
 
 type deviceServiceClient struct {
@@ -730,141 +984,979 @@ func (c *deviceServiceClient) UpdateDeviceStatus(ctx context.Context, in *Update
 	return out, nil
 }
 
-// DeviceServiceServer is the server API for DeviceService service.
-// All implementations must embed UnimplementedDeviceServiceServer
-// for forward compatibility.
-type DeviceServiceServer interface {
-	// 디바이스 등록
-	RegisterDevice(context.Context, *RegisterDeviceRequest) (*RegisterDeviceResponse, error)
-	// 디바이스 목록 조회
-	ListDevices(context.Context, *ListDevicesRequest) (*ListDevicesResponse, error)
-	// 디바이스 상태 업데이트 스트림
-	StreamDeviceStatus(grpc.BidiStreamingServer[DeviceStatusUpdate, DeviceCommand]) error
-	// 펌웨어 OTA 업데이트
-	RequestOtaUpdate(context.Context, *OtaRequest) (*OtaResponse, error)
-	// Phase 4: Device status management
-	UpdateDeviceStatus(context.Context, *UpdateDeviceStatusRequest) (*UpdateDeviceStatusResponse, error)
-	mustEmbedUnimplementedDeviceServiceServer()
+func (c *deviceServiceClient) InitiateDeviceTransfer(ctx context.Context, in *InitiateDeviceTransferRequest, opts ...grpc.CallOption) (*DeviceTransferInfo, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeviceTransferInfo)
+	err := c.cc.Invoke(ctx, DeviceService_InitiateDeviceTransfer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-// UnimplementedDeviceServiceServer must be embedded to have
-// forward compatible implementations.
-//
-// NOTE: this should be embedded by value instead of pointer to avoid a nil
-// pointer dereference when methods are called.
-type UnimplementedDeviceServiceServer struct{}
-
-func (UnimplementedDeviceServiceServer) RegisterDevice(context.Context, *RegisterDeviceRequest) (*RegisterDeviceResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "method RegisterDevice not implemented")
-}
-func (UnimplementedDeviceServiceServer) ListDevices(context.Context, *ListDevicesRequest) (*ListDevicesResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "method ListDevices not implemented")
-}
-func (UnimplementedDeviceServiceServer) StreamDeviceStatus(grpc.BidiStreamingServer[DeviceStatusUpdate, DeviceCommand]) error {
-	return status.Error(codes.Unimplemented, "method StreamDeviceStatus not implemented")
-}
-func (UnimplementedDeviceServiceServer) RequestOtaUpdate(context.Context, *OtaRequest) (*OtaResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "method RequestOtaUpdate not implemented")
+func (c *deviceServiceClient) ConfirmDeviceTransfer(ctx context.Context, in *ConfirmDeviceTransferRequest, opts ...grpc.CallOption) (*DeviceTransferInfo, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeviceTransferInfo)
+	err := c.cc.Invoke(ctx, DeviceService_ConfirmDeviceTransfer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedDeviceServiceServer) UpdateDeviceStatus(context.Context, *UpdateDeviceStatusRequest) (*UpdateDeviceStatusResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "method UpdateDeviceStatus not implemented")
+
+func (c *deviceServiceClient) CancelDeviceTransfer(ctx context.Context, in *CancelDeviceTransferRequest, opts ...grpc.CallOption) (*DeviceTransferInfo, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeviceTransferInfo)
+	err := c.cc.Invoke(ctx, DeviceService_CancelDeviceTransfer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedDeviceServiceServer) mustEmbedUnimplementedDeviceServiceServer() {}
-func (UnimplementedDeviceServiceServer) testEmbeddedByValue()                       {}
 
-// UnsafeDeviceServiceServer may be embedded to opt out of forward compatibility for this service.
-// Use of this interface is not recommended, as added methods to DeviceServiceServer will
-// result in compilation errors.
-type UnsafeDeviceServiceServer interface {
-	mustEmbedUnimplementedDeviceServiceServer()
+func (c *deviceServiceClient) ListDeviceOwnershipHistory(ctx context.Context, in *ListDeviceOwnershipHistoryRequest, opts ...grpc.CallOption) (*ListDeviceOwnershipHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListDeviceOwnershipHistoryResponse)
+	err := c.cc.Invoke(ctx, DeviceService_ListDeviceOwnershipHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-func RegisterDeviceServiceServer(s grpc.ServiceRegistrar, srv DeviceServiceServer) {
-	// If the following call panics, it indicates UnimplementedDeviceServiceServer was
-	// embedded by pointer and is nil.  This will cause panics if an
-	// unimplemented method is ever invoked, so we test this at initialization
-	// time to prevent it from happening at runtime later due to I/O.
-	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
-		t.testEmbeddedByValue()
+func (c *deviceServiceClient) GetDeviceOwnerAt(ctx context.Context, in *GetDeviceOwnerAtRequest, opts ...grpc.CallOption) (*GetDeviceOwnerAtResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetDeviceOwnerAtResponse)
+	err := c.cc.Invoke(ctx, DeviceService_GetDeviceOwnerAt_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
 	}
-	s.RegisterService(&DeviceService_ServiceDesc, srv)
+	return out, nil
 }
 
-func _DeviceService_RegisterDevice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(RegisterDeviceRequest)
-	if err := dec(in); err != nil {
+func (c *deviceServiceClient) DeregisterDevice(ctx context.Context, in *DeregisterDeviceRequest, opts ...grpc.CallOption) (*DeregisterDeviceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeregisterDeviceResponse)
+	err := c.cc.Invoke(ctx, DeviceService_DeregisterDevice_FullMethodName, in, out, cOpts...)
+	if err != nil {
 		return nil, err
 	}
-	if interceptor == nil {
-		return srv.(DeviceServiceServer).RegisterDevice(ctx, in)
+	return out, nil
+}
+
+func (c *deviceServiceClient) RequestRemoteWipe(ctx context.Context, in *RemoteWipeRequest, opts ...grpc.CallOption) (*RemoteWipeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RemoteWipeResponse)
+	err := c.cc.Invoke(ctx, DeviceService_RequestRemoteWipe_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: DeviceService_RegisterDevice_FullMethodName,
+	return out, nil
+}
+
+func (c *deviceServiceClient) ListPendingCommands(ctx context.Context, in *ListPendingCommandsRequest, opts ...grpc.CallOption) (*ListPendingCommandsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListPendingCommandsResponse)
+	err := c.cc.Invoke(ctx, DeviceService_ListPendingCommands_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DeviceServiceServer).RegisterDevice(ctx, req.(*RegisterDeviceRequest))
+	return out, nil
+}
+
+func (c *deviceServiceClient) AcknowledgeDeviceCommand(ctx context.Context, in *AcknowledgeDeviceCommandRequest, opts ...grpc.CallOption) (*AcknowledgeDeviceCommandResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AcknowledgeDeviceCommandResponse)
+	err := c.cc.Invoke(ctx, DeviceService_AcknowledgeDeviceCommand_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
 	}
-	return interceptor(ctx, in, info, handler)
+	return out, nil
 }
 
-func _DeviceService_ListDevices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListDevicesRequest)
-	if err := dec(in); err != nil {
+func (c *deviceServiceClient) RegisterHub(ctx context.Context, in *RegisterHubRequest, opts ...grpc.CallOption) (*RegisterDeviceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RegisterDeviceResponse)
+	err := c.cc.Invoke(ctx, DeviceService_RegisterHub_FullMethodName, in, out, cOpts...)
+	if err != nil {
 		return nil, err
 	}
-	if interceptor == nil {
-		return srv.(DeviceServiceServer).ListDevices(ctx, in)
+	return out, nil
+}
+
+func (c *deviceServiceClient) AttachReadersToHub(ctx context.Context, in *AttachReadersToHubRequest, opts ...grpc.CallOption) (*AttachReadersToHubResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AttachReadersToHubResponse)
+	err := c.cc.Invoke(ctx, DeviceService_AttachReadersToHub_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: DeviceService_ListDevices_FullMethodName,
+	return out, nil
+}
+
+func (c *deviceServiceClient) DetachReaderFromHub(ctx context.Context, in *DetachReaderFromHubRequest, opts ...grpc.CallOption) (*DetachReaderFromHubResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DetachReaderFromHubResponse)
+	err := c.cc.Invoke(ctx, DeviceService_DetachReaderFromHub_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DeviceServiceServer).ListDevices(ctx, req.(*ListDevicesRequest))
+	return out, nil
+}
+
+func (c *deviceServiceClient) ReportHubRelay(ctx context.Context, in *HubRelayReport, opts ...grpc.CallOption) (*HubRelayResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(HubRelayResponse)
+	err := c.cc.Invoke(ctx, DeviceService_ReportHubRelay_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
 	}
-	return interceptor(ctx, in, info, handler)
+	return out, nil
 }
 
-func _DeviceService_StreamDeviceStatus_Handler(srv interface{}, stream grpc.ServerStream) error {
+func (c *deviceServiceClient) GetHubStatus(ctx context.Context, in *GetHubStatusRequest, opts ...grpc.CallOption) (*HubStatus, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(HubStatus)
+	err := c.cc.Invoke(ctx, DeviceService_GetHubStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deviceServiceClient) ListHubReaders(ctx context.Context, in *ListHubReadersRequest, opts ...grpc.CallOption) (*ListDevicesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListDevicesResponse)
+	err := c.cc.Invoke(ctx, DeviceService_ListHubReaders_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deviceServiceClient) SendDeviceCommand(ctx context.Context, in *SendDeviceCommandRequest, opts ...grpc.CallOption) (*SendDeviceCommandResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SendDeviceCommandResponse)
+	err := c.cc.Invoke(ctx, DeviceService_SendDeviceCommand_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deviceServiceClient) CreateDeviceGroup(ctx context.Context, in *CreateDeviceGroupRequest, opts ...grpc.CallOption) (*DeviceGroupInfo, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeviceGroupInfo)
+	err := c.cc.Invoke(ctx, DeviceService_CreateDeviceGroup_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deviceServiceClient) ListDeviceGroups(ctx context.Context, in *ListDeviceGroupsRequest, opts ...grpc.CallOption) (*ListDeviceGroupsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListDeviceGroupsResponse)
+	err := c.cc.Invoke(ctx, DeviceService_ListDeviceGroups_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deviceServiceClient) AddDeviceGroupMember(ctx context.Context, in *AddDeviceGroupMemberRequest, opts ...grpc.CallOption) (*DeviceGroupMember, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeviceGroupMember)
+	err := c.cc.Invoke(ctx, DeviceService_AddDeviceGroupMember_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deviceServiceClient) RemoveDeviceGroupMember(ctx context.Context, in *RemoveDeviceGroupMemberRequest, opts ...grpc.CallOption) (*RemoveDeviceGroupMemberResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RemoveDeviceGroupMemberResponse)
+	err := c.cc.Invoke(ctx, DeviceService_RemoveDeviceGroupMember_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deviceServiceClient) AddDevicesToGroup(ctx context.Context, in *AddDevicesToGroupRequest, opts ...grpc.CallOption) (*AddDevicesToGroupResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AddDevicesToGroupResponse)
+	err := c.cc.Invoke(ctx, DeviceService_AddDevicesToGroup_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deviceServiceClient) RemoveDeviceFromGroup(ctx context.Context, in *RemoveDeviceFromGroupRequest, opts ...grpc.CallOption) (*RemoveDeviceFromGroupResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RemoveDeviceFromGroupResponse)
+	err := c.cc.Invoke(ctx, DeviceService_RemoveDeviceFromGroup_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deviceServiceClient) SendBulkCommand(ctx context.Context, in *SendBulkCommandRequest, opts ...grpc.CallOption) (*BulkCommandInfo, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BulkCommandInfo)
+	err := c.cc.Invoke(ctx, DeviceService_SendBulkCommand_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deviceServiceClient) GetBulkCommandStatus(ctx context.Context, in *GetBulkCommandStatusRequest, opts ...grpc.CallOption) (*BulkCommandInfo, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BulkCommandInfo)
+	err := c.cc.Invoke(ctx, DeviceService_GetBulkCommandStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deviceServiceClient) GetFleetStats(ctx context.Context, in *GetFleetStatsRequest, opts ...grpc.CallOption) (*FleetStats, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FleetStats)
+	err := c.cc.Invoke(ctx, DeviceService_GetFleetStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deviceServiceClient) ListFleetStats(ctx context.Context, in *ListFleetStatsRequest, opts ...grpc.CallOption) (*ListFleetStatsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListFleetStatsResponse)
+	err := c.cc.Invoke(ctx, DeviceService_ListFleetStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DeviceServiceServer is the server API for DeviceService service.
+// All implementations must embed UnimplementedDeviceServiceServer
+// for forward compatibility.
+type DeviceServiceServer interface {
+	// 디바이스 등록
+	RegisterDevice(context.Context, *RegisterDeviceRequest) (*RegisterDeviceResponse, error)
+	// 디바이스 목록 조회
+	ListDevices(context.Context, *ListDevicesRequest) (*ListDevicesResponse, error)
+	// 디바이스 상태 업데이트 스트림
+	StreamDeviceStatus(grpc.BidiStreamingServer[DeviceStatusUpdate, DeviceCommand]) error
+	// 펌웨어 OTA 업데이트
+	RequestOtaUpdate(context.Context, *OtaRequest) (*OtaResponse, error)
+	// Phase 4: Device status management
+	UpdateDeviceStatus(context.Context, *UpdateDeviceStatusRequest) (*UpdateDeviceStatusResponse, error)
+	// 소유권 이전 (양측 확인 필요)
+	InitiateDeviceTransfer(context.Context, *InitiateDeviceTransferRequest) (*DeviceTransferInfo, error)
+	ConfirmDeviceTransfer(context.Context, *ConfirmDeviceTransferRequest) (*DeviceTransferInfo, error)
+	CancelDeviceTransfer(context.Context, *CancelDeviceTransferRequest) (*DeviceTransferInfo, error)
+	ListDeviceOwnershipHistory(context.Context, *ListDeviceOwnershipHistoryRequest) (*ListDeviceOwnershipHistoryResponse, error)
+	// 지정 시점의 소유자 조회 (측정·보정 기록 귀속)
+	GetDeviceOwnerAt(context.Context, *GetDeviceOwnerAtRequest) (*GetDeviceOwnerAtResponse, error)
+	// 등록 해제 및 원격 초기화
+	DeregisterDevice(context.Context, *DeregisterDeviceRequest) (*DeregisterDeviceResponse, error)
+	RequestRemoteWipe(context.Context, *RemoteWipeRequest) (*RemoteWipeResponse, error)
+	// 대기 중인 디바이스 명령 조회 / 수신 확인
+	ListPendingCommands(context.Context, *ListPendingCommandsRequest) (*ListPendingCommandsResponse, error)
+	AcknowledgeDeviceCommand(context.Context, *AcknowledgeDeviceCommandRequest) (*AcknowledgeDeviceCommandResponse, error)
+	// 허브 토폴로지 (BLE 리더 → Wi-Fi 허브 → 클라우드 게이트웨이)
+	RegisterHub(context.Context, *RegisterHubRequest) (*RegisterDeviceResponse, error)
+	AttachReadersToHub(context.Context, *AttachReadersToHubRequest) (*AttachReadersToHubResponse, error)
+	DetachReaderFromHub(context.Context, *DetachReaderFromHubRequest) (*DetachReaderFromHubResponse, error)
+	ReportHubRelay(context.Context, *HubRelayReport) (*HubRelayResponse, error)
+	GetHubStatus(context.Context, *GetHubStatusRequest) (*HubStatus, error)
+	ListHubReaders(context.Context, *ListHubReadersRequest) (*ListDevicesResponse, error)
+	SendDeviceCommand(context.Context, *SendDeviceCommandRequest) (*SendDeviceCommandResponse, error)
+	// 클리니컬 플릿 관리 (디바이스 그룹, 일괄 명령, 플릿 통계)
+	CreateDeviceGroup(context.Context, *CreateDeviceGroupRequest) (*DeviceGroupInfo, error)
+	ListDeviceGroups(context.Context, *ListDeviceGroupsRequest) (*ListDeviceGroupsResponse, error)
+	AddDeviceGroupMember(context.Context, *AddDeviceGroupMemberRequest) (*DeviceGroupMember, error)
+	RemoveDeviceGroupMember(context.Context, *RemoveDeviceGroupMemberRequest) (*RemoveDeviceGroupMemberResponse, error)
+	AddDevicesToGroup(context.Context, *AddDevicesToGroupRequest) (*AddDevicesToGroupResponse, error)
+	RemoveDeviceFromGroup(context.Context, *RemoveDeviceFromGroupRequest) (*RemoveDeviceFromGroupResponse, error)
+	SendBulkCommand(context.Context, *SendBulkCommandRequest) (*BulkCommandInfo, error)
+	GetBulkCommandStatus(context.Context, *GetBulkCommandStatusRequest) (*BulkCommandInfo, error)
+	GetFleetStats(context.Context, *GetFleetStatsRequest) (*FleetStats, error)
+	ListFleetStats(context.Context, *ListFleetStatsRequest) (*ListFleetStatsResponse, error)
+	mustEmbedUnimplementedDeviceServiceServer()
+}
+
+// UnimplementedDeviceServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedDeviceServiceServer struct{}
+
+func (UnimplementedDeviceServiceServer) RegisterDevice(context.Context, *RegisterDeviceRequest) (*RegisterDeviceResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RegisterDevice not implemented")
+}
+func (UnimplementedDeviceServiceServer) ListDevices(context.Context, *ListDevicesRequest) (*ListDevicesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListDevices not implemented")
+}
+func (UnimplementedDeviceServiceServer) StreamDeviceStatus(grpc.BidiStreamingServer[DeviceStatusUpdate, DeviceCommand]) error {
+	return status.Error(codes.Unimplemented, "method StreamDeviceStatus not implemented")
+}
+func (UnimplementedDeviceServiceServer) RequestOtaUpdate(context.Context, *OtaRequest) (*OtaResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RequestOtaUpdate not implemented")
+}
+func (UnimplementedDeviceServiceServer) UpdateDeviceStatus(context.Context, *UpdateDeviceStatusRequest) (*UpdateDeviceStatusResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdateDeviceStatus not implemented")
+}
+func (UnimplementedDeviceServiceServer) InitiateDeviceTransfer(context.Context, *InitiateDeviceTransferRequest) (*DeviceTransferInfo, error) {
+	return nil, status.Error(codes.Unimplemented, "method InitiateDeviceTransfer not implemented")
+}
+func (UnimplementedDeviceServiceServer) ConfirmDeviceTransfer(context.Context, *ConfirmDeviceTransferRequest) (*DeviceTransferInfo, error) {
+	return nil, status.Error(codes.Unimplemented, "method ConfirmDeviceTransfer not implemented")
+}
+func (UnimplementedDeviceServiceServer) CancelDeviceTransfer(context.Context, *CancelDeviceTransferRequest) (*DeviceTransferInfo, error) {
+	return nil, status.Error(codes.Unimplemented, "method CancelDeviceTransfer not implemented")
+}
+func (UnimplementedDeviceServiceServer) ListDeviceOwnershipHistory(context.Context, *ListDeviceOwnershipHistoryRequest) (*ListDeviceOwnershipHistoryResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListDeviceOwnershipHistory not implemented")
+}
+func (UnimplementedDeviceServiceServer) GetDeviceOwnerAt(context.Context, *GetDeviceOwnerAtRequest) (*GetDeviceOwnerAtResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetDeviceOwnerAt not implemented")
+}
+func (UnimplementedDeviceServiceServer) DeregisterDevice(context.Context, *DeregisterDeviceRequest) (*DeregisterDeviceResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeregisterDevice not implemented")
+}
+func (UnimplementedDeviceServiceServer) RequestRemoteWipe(context.Context, *RemoteWipeRequest) (*RemoteWipeResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RequestRemoteWipe not implemented")
+}
+func (UnimplementedDeviceServiceServer) ListPendingCommands(context.Context, *ListPendingCommandsRequest) (*ListPendingCommandsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListPendingCommands not implemented")
+}
+func (UnimplementedDeviceServiceServer) AcknowledgeDeviceCommand(context.Context, *AcknowledgeDeviceCommandRequest) (*AcknowledgeDeviceCommandResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method AcknowledgeDeviceCommand not implemented")
+}
+func (UnimplementedDeviceServiceServer) RegisterHub(context.Context, *RegisterHubRequest) (*RegisterDeviceResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RegisterHub not implemented")
+}
+func (UnimplementedDeviceServiceServer) AttachReadersToHub(context.Context, *AttachReadersToHubRequest) (*AttachReadersToHubResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method AttachReadersToHub not implemented")
+}
+func (UnimplementedDeviceServiceServer) DetachReaderFromHub(context.Context, *DetachReaderFromHubRequest) (*DetachReaderFromHubResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DetachReaderFromHub not implemented")
+}
+func (UnimplementedDeviceServiceServer) ReportHubRelay(context.Context, *HubRelayReport) (*HubRelayResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ReportHubRelay not implemented")
+}
+func (UnimplementedDeviceServiceServer) GetHubStatus(context.Context, *GetHubStatusRequest) (*HubStatus, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetHubStatus not implemented")
+}
+func (UnimplementedDeviceServiceServer) ListHubReaders(context.Context, *ListHubReadersRequest) (*ListDevicesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListHubReaders not implemented")
+}
+func (UnimplementedDeviceServiceServer) SendDeviceCommand(context.Context, *SendDeviceCommandRequest) (*SendDeviceCommandResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SendDeviceCommand not implemented")
+}
+func (UnimplementedDeviceServiceServer) CreateDeviceGroup(context.Context, *CreateDeviceGroupRequest) (*DeviceGroupInfo, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateDeviceGroup not implemented")
+}
+func (UnimplementedDeviceServiceServer) ListDeviceGroups(context.Context, *ListDeviceGroupsRequest) (*ListDeviceGroupsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListDeviceGroups not implemented")
+}
+func (UnimplementedDeviceServiceServer) AddDeviceGroupMember(context.Context, *AddDeviceGroupMemberRequest) (*DeviceGroupMember, error) {
+	return nil, status.Error(codes.Unimplemented, "method AddDeviceGroupMember not implemented")
+}
+func (UnimplementedDeviceServiceServer) RemoveDeviceGroupMember(context.Context, *RemoveDeviceGroupMemberRequest) (*RemoveDeviceGroupMemberResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RemoveDeviceGroupMember not implemented")
+}
+func (UnimplementedDeviceServiceServer) AddDevicesToGroup(context.Context, *AddDevicesToGroupRequest) (*AddDevicesToGroupResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method AddDevicesToGroup not implemented")
+}
+func (UnimplementedDeviceServiceServer) RemoveDeviceFromGroup(context.Context, *RemoveDeviceFromGroupRequest) (*RemoveDeviceFromGroupResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RemoveDeviceFromGroup not implemented")
+}
+func (UnimplementedDeviceServiceServer) SendBulkCommand(context.Context, *SendBulkCommandRequest) (*BulkCommandInfo, error) {
+	return nil, status.Error(codes.Unimplemented, "method SendBulkCommand not implemented")
+}
+func (UnimplementedDeviceServiceServer) GetBulkCommandStatus(context.Context, *GetBulkCommandStatusRequest) (*BulkCommandInfo, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetBulkCommandStatus not implemented")
+}
+func (UnimplementedDeviceServiceServer) GetFleetStats(context.Context, *GetFleetStatsRequest) (*FleetStats, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetFleetStats not implemented")
+}
+func (UnimplementedDeviceServiceServer) ListFleetStats(context.Context, *ListFleetStatsRequest) (*ListFleetStatsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListFleetStats not implemented")
+}
+func (UnimplementedDeviceServiceServer) mustEmbedUnimplementedDeviceServiceServer() {}
+func (UnimplementedDeviceServiceServer) testEmbeddedByValue()                       {}
+
+// UnsafeDeviceServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to DeviceServiceServer will
+// result in compilation errors.
+type UnsafeDeviceServiceServer interface {
+	mustEmbedUnimplementedDeviceServiceServer()
+}
+
+func RegisterDeviceServiceServer(s grpc.ServiceRegistrar, srv DeviceServiceServer) {
+	// If the following call panics, it indicates UnimplementedDeviceServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&DeviceService_ServiceDesc, srv)
+}
+
+func _DeviceService_RegisterDevice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegisterDeviceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeviceServiceServer).RegisterDevice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DeviceService_RegisterDevice_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeviceServiceServer).RegisterDevice(ctx, req.(*RegisterDeviceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeviceService_ListDevices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDevicesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeviceServiceServer).ListDevices(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DeviceService_ListDevices_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeviceServiceServer).ListDevices(ctx, req.(*ListDevicesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeviceService_StreamDeviceStatus_Handler(srv interface{}, stream grpc.ServerStream) error {
 	return srv.(DeviceServiceServer).StreamDeviceStatus(&grpc.GenericServerStream[DeviceStatusUpdate, DeviceCommand]{ServerStream: stream})
 }
 
-// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
-type DeviceService_StreamDeviceStatusServer = grpc.BidiStreamingServer[DeviceStatusUpdate, DeviceCommand]
-
-func _DeviceService_RequestOtaUpdate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(OtaRequest)
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DeviceService_StreamDeviceStatusServer = grpc.BidiStreamingServer[DeviceStatusUpdate, DeviceCommand]
+
+func _DeviceService_RequestOtaUpdate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OtaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeviceServiceServer).RequestOtaUpdate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DeviceService_RequestOtaUpdate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeviceServiceServer).RequestOtaUpdate(ctx, req.(*OtaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeviceService_UpdateDeviceStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateDeviceStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeviceServiceServer).UpdateDeviceStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DeviceService_UpdateDeviceStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeviceServiceServer).UpdateDeviceStatus(ctx, req.(*UpdateDeviceStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeviceService_InitiateDeviceTransfer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InitiateDeviceTransferRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeviceServiceServer).InitiateDeviceTransfer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DeviceService_InitiateDeviceTransfer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeviceServiceServer).InitiateDeviceTransfer(ctx, req.(*InitiateDeviceTransferRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeviceService_ConfirmDeviceTransfer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConfirmDeviceTransferRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeviceServiceServer).ConfirmDeviceTransfer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DeviceService_ConfirmDeviceTransfer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeviceServiceServer).ConfirmDeviceTransfer(ctx, req.(*ConfirmDeviceTransferRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeviceService_CancelDeviceTransfer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelDeviceTransferRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeviceServiceServer).CancelDeviceTransfer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DeviceService_CancelDeviceTransfer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeviceServiceServer).CancelDeviceTransfer(ctx, req.(*CancelDeviceTransferRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeviceService_ListDeviceOwnershipHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDeviceOwnershipHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeviceServiceServer).ListDeviceOwnershipHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DeviceService_ListDeviceOwnershipHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeviceServiceServer).ListDeviceOwnershipHistory(ctx, req.(*ListDeviceOwnershipHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeviceService_GetDeviceOwnerAt_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDeviceOwnerAtRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeviceServiceServer).GetDeviceOwnerAt(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DeviceService_GetDeviceOwnerAt_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeviceServiceServer).GetDeviceOwnerAt(ctx, req.(*GetDeviceOwnerAtRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeviceService_DeregisterDevice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeregisterDeviceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeviceServiceServer).DeregisterDevice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DeviceService_DeregisterDevice_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeviceServiceServer).DeregisterDevice(ctx, req.(*DeregisterDeviceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeviceService_RequestRemoteWipe_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoteWipeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeviceServiceServer).RequestRemoteWipe(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DeviceService_RequestRemoteWipe_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeviceServiceServer).RequestRemoteWipe(ctx, req.(*RemoteWipeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeviceService_ListPendingCommands_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPendingCommandsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeviceServiceServer).ListPendingCommands(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DeviceService_ListPendingCommands_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeviceServiceServer).ListPendingCommands(ctx, req.(*ListPendingCommandsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeviceService_AcknowledgeDeviceCommand_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AcknowledgeDeviceCommandRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeviceServiceServer).AcknowledgeDeviceCommand(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DeviceService_AcknowledgeDeviceCommand_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeviceServiceServer).AcknowledgeDeviceCommand(ctx, req.(*AcknowledgeDeviceCommandRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeviceService_RegisterHub_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegisterHubRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeviceServiceServer).RegisterHub(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DeviceService_RegisterHub_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeviceServiceServer).RegisterHub(ctx, req.(*RegisterHubRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeviceService_AttachReadersToHub_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AttachReadersToHubRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeviceServiceServer).AttachReadersToHub(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DeviceService_AttachReadersToHub_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeviceServiceServer).AttachReadersToHub(ctx, req.(*AttachReadersToHubRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeviceService_DetachReaderFromHub_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DetachReaderFromHubRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeviceServiceServer).DetachReaderFromHub(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DeviceService_DetachReaderFromHub_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeviceServiceServer).DetachReaderFromHub(ctx, req.(*DetachReaderFromHubRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeviceService_ReportHubRelay_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HubRelayReport)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeviceServiceServer).ReportHubRelay(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DeviceService_ReportHubRelay_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeviceServiceServer).ReportHubRelay(ctx, req.(*HubRelayReport))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeviceService_GetHubStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetHubStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeviceServiceServer).GetHubStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DeviceService_GetHubStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeviceServiceServer).GetHubStatus(ctx, req.(*GetHubStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeviceService_ListHubReaders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListHubReadersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeviceServiceServer).ListHubReaders(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DeviceService_ListHubReaders_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeviceServiceServer).ListHubReaders(ctx, req.(*ListHubReadersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeviceService_SendDeviceCommand_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendDeviceCommandRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeviceServiceServer).SendDeviceCommand(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DeviceService_SendDeviceCommand_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeviceServiceServer).SendDeviceCommand(ctx, req.(*SendDeviceCommandRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeviceService_CreateDeviceGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateDeviceGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeviceServiceServer).CreateDeviceGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DeviceService_CreateDeviceGroup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeviceServiceServer).CreateDeviceGroup(ctx, req.(*CreateDeviceGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeviceService_ListDeviceGroups_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDeviceGroupsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeviceServiceServer).ListDeviceGroups(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DeviceService_ListDeviceGroups_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeviceServiceServer).ListDeviceGroups(ctx, req.(*ListDeviceGroupsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeviceService_AddDeviceGroupMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddDeviceGroupMemberRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeviceServiceServer).AddDeviceGroupMember(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DeviceService_AddDeviceGroupMember_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeviceServiceServer).AddDeviceGroupMember(ctx, req.(*AddDeviceGroupMemberRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeviceService_RemoveDeviceGroupMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveDeviceGroupMemberRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeviceServiceServer).RemoveDeviceGroupMember(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DeviceService_RemoveDeviceGroupMember_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeviceServiceServer).RemoveDeviceGroupMember(ctx, req.(*RemoveDeviceGroupMemberRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeviceService_AddDevicesToGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddDevicesToGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeviceServiceServer).AddDevicesToGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DeviceService_AddDevicesToGroup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeviceServiceServer).AddDevicesToGroup(ctx, req.(*AddDevicesToGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeviceService_RemoveDeviceFromGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveDeviceFromGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeviceServiceServer).RemoveDeviceFromGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DeviceService_RemoveDeviceFromGroup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeviceServiceServer).RemoveDeviceFromGroup(ctx, req.(*RemoveDeviceFromGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeviceService_SendBulkCommand_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendBulkCommandRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeviceServiceServer).SendBulkCommand(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DeviceService_SendBulkCommand_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeviceServiceServer).SendBulkCommand(ctx, req.(*SendBulkCommandRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeviceService_GetBulkCommandStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBulkCommandStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeviceServiceServer).GetBulkCommandStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DeviceService_GetBulkCommandStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeviceServiceServer).GetBulkCommandStatus(ctx, req.(*GetBulkCommandStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeviceService_GetFleetStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFleetStatsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DeviceServiceServer).RequestOtaUpdate(ctx, in)
+		return srv.(DeviceServiceServer).GetFleetStats(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: DeviceService_RequestOtaUpdate_FullMethodName,
+		FullMethod: DeviceService_GetFleetStats_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DeviceServiceServer).RequestOtaUpdate(ctx, req.(*OtaRequest))
+		return srv.(DeviceServiceServer).GetFleetStats(ctx, req.(*GetFleetStatsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DeviceService_UpdateDeviceStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdateDeviceStatusRequest)
+func _DeviceService_ListFleetStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListFleetStatsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DeviceServiceServer).UpdateDeviceStatus(ctx, in)
+		return srv.(DeviceServiceServer).ListFleetStats(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: DeviceService_UpdateDeviceStatus_FullMethodName,
+		FullMethod: DeviceService_ListFleetStats_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DeviceServiceServer).UpdateDeviceStatus(ctx, req.(*UpdateDeviceStatusRequest))
+		return srv.(DeviceServiceServer).ListFleetStats(ctx, req.(*ListFleetStatsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -892,6 +1984,110 @@ var DeviceService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "UpdateDeviceStatus",
 			Handler:    _DeviceService_UpdateDeviceStatus_Handler,
 		},
+		{
+			MethodName: "InitiateDeviceTransfer",
+			Handler:    _DeviceService_InitiateDeviceTransfer_Handler,
+		},
+		{
+			MethodName: "ConfirmDeviceTransfer",
+			Handler:    _DeviceService_ConfirmDeviceTransfer_Handler,
+		},
+		{
+			MethodName: "CancelDeviceTransfer",
+			Handler:    _DeviceService_CancelDeviceTransfer_Handler,
+		},
+		{
+			MethodName: "ListDeviceOwnershipHistory",
+			Handler:    _DeviceService_ListDeviceOwnershipHistory_Handler,
+		},
+		{
+			MethodName: "GetDeviceOwnerAt",
+			Handler:    _DeviceService_GetDeviceOwnerAt_Handler,
+		},
+		{
+			MethodName: "DeregisterDevice",
+			Handler:    _DeviceService_DeregisterDevice_Handler,
+		},
+		{
+			MethodName: "RequestRemoteWipe",
+			Handler:    _DeviceService_RequestRemoteWipe_Handler,
+		},
+		{
+			MethodName: "ListPendingCommands",
+			Handler:    _DeviceService_ListPendingCommands_Handler,
+		},
+		{
+			MethodName: "AcknowledgeDeviceCommand",
+			Handler:    _DeviceService_AcknowledgeDeviceCommand_Handler,
+		},
+		{
+			MethodName: "RegisterHub",
+			Handler:    _DeviceService_RegisterHub_Handler,
+		},
+		{
+			MethodName: "AttachReadersToHub",
+			Handler:    _DeviceService_AttachReadersToHub_Handler,
+		},
+		{
+			MethodName: "DetachReaderFromHub",
+			Handler:    _DeviceService_DetachReaderFromHub_Handler,
+		},
+		{
+			MethodName: "ReportHubRelay",
+			Handler:    _DeviceService_ReportHubRelay_Handler,
+		},
+		{
+			MethodName: "GetHubStatus",
+			Handler:    _DeviceService_GetHubStatus_Handler,
+		},
+		{
+			MethodName: "ListHubReaders",
+			Handler:    _DeviceService_ListHubReaders_Handler,
+		},
+		{
+			MethodName: "SendDeviceCommand",
+			Handler:    _DeviceService_SendDeviceCommand_Handler,
+		},
+		{
+			MethodName: "CreateDeviceGroup",
+			Handler:    _DeviceService_CreateDeviceGroup_Handler,
+		},
+		{
+			MethodName: "ListDeviceGroups",
+			Handler:    _DeviceService_ListDeviceGroups_Handler,
+		},
+		{
+			MethodName: "AddDeviceGroupMember",
+			Handler:    _DeviceService_AddDeviceGroupMember_Handler,
+		},
+		{
+			MethodName: "RemoveDeviceGroupMember",
+			Handler:    _DeviceService_RemoveDeviceGroupMember_Handler,
+		},
+		{
+			MethodName: "AddDevicesToGroup",
+			Handler:    _DeviceService_AddDevicesToGroup_Handler,
+		},
+		{
+			MethodName: "RemoveDeviceFromGroup",
+			Handler:    _DeviceService_RemoveDeviceFromGroup_Handler,
+		},
+		{
+			MethodName: "SendBulkCommand",
+			Handler:    _DeviceService_SendBulkCommand_Handler,
+		},
+		{
+			MethodName: "GetBulkCommandStatus",
+			Handler:    _DeviceService_GetBulkCommandStatus_Handler,
+		},
+		{
+			MethodName: "GetFleetStats",
+			Handler:    _DeviceService_GetFleetStats_Handler,
+		},
+		{
+			MethodName: "ListFleetStats",
+			Handler:    _DeviceService_ListFleetStats_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -2121,12 +3317,16 @@ var PaymentService_ServiceDesc = grpc.ServiceDesc{
 }
 
 const (
-	AiInferenceService_AnalyzeMeasurement_FullMethodName = "/manpasik.v1.AiInferenceService/AnalyzeMeasurement"
-	AiInferenceService_GetHealthScore_FullMethodName     = "/manpasik.v1.AiInferenceService/GetHealthScore"
-	AiInferenceService_PredictTrend_FullMethodName       = "/manpasik.v1.AiInferenceService/PredictTrend"
-	AiInferenceService_GetModelInfo_FullMethodName       = "/manpasik.v1.AiInferenceService/GetModelInfo"
-	AiInferenceService_ListModels_FullMethodName         = "/manpasik.v1.AiInferenceService/ListModels"
-	AiInferenceService_StreamChat_FullMethodName         = "/manpasik.v1.AiInferenceService/StreamChat"
+	AiInferenceService_AnalyzeMeasurement_FullMethodName     = "/manpasik.v1.AiInferenceService/AnalyzeMeasurement"
+	AiInferenceService_GetHealthScore_FullMethodName         = "/manpasik.v1.AiInferenceService/GetHealthScore"
+	AiInferenceService_PredictTrend_FullMethodName           = "/manpasik.v1.AiInferenceService/PredictTrend"
+	AiInferenceService_GetModelInfo_FullMethodName           = "/manpasik.v1.AiInferenceService/GetModelInfo"
+	AiInferenceService_ListModels_FullMethodName             = "/manpasik.v1.AiInferenceService/ListModels"
+	AiInferenceService_StreamChat_FullMethodName             = "/manpasik.v1.AiInferenceService/StreamChat"
+	AiInferenceService_GetLLMUsageReport_FullMethodName      = "/manpasik.v1.AiInferenceService/GetLLMUsageReport"
+	AiInferenceService_ComposeCoachingMessage_FullMethodName = "/manpasik.v1.AiInferenceService/ComposeCoachingMessage"
+	AiInferenceService_RegisterModelVersion_FullMethodName   = "/manpasik.v1.AiInferenceService/RegisterModelVersion"
+	AiInferenceService_PromoteModelVersion_FullMethodName    = "/manpasik.v1.AiInferenceService/PromoteModelVersion"
 )
 
 // AiInferenceServiceClient is the client API for AiInferenceService service.
@@ -2140,6 +3340,13 @@ type AiInferenceServiceClient interface {
 	ListModels(ctx context.Context, in *ListModelsRequest, opts ...grpc.CallOption) (*ListModelsResponse, error)
 	// Phase 9: AI 스트리밍 채팅 (C1)
 	StreamChat(ctx context.Context, in *StreamChatRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[StreamChatResponse], error)
+	// 관리자: 사용자·기능·프로바이더별 LLM 사용량과 예상 비용
+	GetLLMUsageReport(ctx context.Context, in *GetLLMUsageReportRequest, opts ...grpc.CallOption) (*LLMUsageReport, error)
+	// coaching-service 전용: 코칭 메시지 본문을 사용자 컨텍스트로 개인화 (안전 가드레일 적용)
+	ComposeCoachingMessage(ctx context.Context, in *ComposeCoachingMessageRequest, opts ...grpc.CallOption) (*ComposeCoachingMessageResponse, error)
+	// 관리자: 후보 모델 버전 등록(아티팩트 업로드, 섀도 후보) 및 운영 전환
+	RegisterModelVersion(ctx context.Context, in *RegisterModelVersionRequest, opts ...grpc.CallOption) (*ModelInfo, error)
+	PromoteModelVersion(ctx context.Context, in *PromoteModelVersionRequest, opts ...grpc.CallOption) (*ModelInfo, error)
 }
 
 type aiInferenceServiceClient struct {
@@ -2219,6 +3426,46 @@ func (c *aiInferenceServiceClient) StreamChat(ctx context.Context, in *StreamCha
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type AiInferenceService_StreamChatClient = grpc.ServerStreamingClient[StreamChatResponse]
 
+func (c *aiInferenceServiceClient) GetLLMUsageReport(ctx context.Context, in *GetLLMUsageReportRequest, opts ...grpc.CallOption) (*LLMUsageReport, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LLMUsageReport)
+	err := c.cc.Invoke(ctx, AiInferenceService_GetLLMUsageReport_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aiInferenceServiceClient) ComposeCoachingMessage(ctx context.Context, in *ComposeCoachingMessageRequest, opts ...grpc.CallOption) (*ComposeCoachingMessageResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ComposeCoachingMessageResponse)
+	err := c.cc.Invoke(ctx, AiInferenceService_ComposeCoachingMessage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aiInferenceServiceClient) RegisterModelVersion(ctx context.Context, in *RegisterModelVersionRequest, opts ...grpc.CallOption) (*ModelInfo, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ModelInfo)
+	err := c.cc.Invoke(ctx, AiInferenceService_RegisterModelVersion_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aiInferenceServiceClient) PromoteModelVersion(ctx context.Context, in *PromoteModelVersionRequest, opts ...grpc.CallOption) (*ModelInfo, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ModelInfo)
+	err := c.cc.Invoke(ctx, AiInferenceService_PromoteModelVersion_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // AiInferenceServiceServer is the server API for AiInferenceService service.
 // All implementations must embed UnimplementedAiInferenceServiceServer
 // for forward compatibility.
@@ -2230,6 +3477,13 @@ type AiInferenceServiceServer interface {
 	ListModels(context.Context, *ListModelsRequest) (*ListModelsResponse, error)
 	// Phase 9: AI 스트리밍 채팅 (C1)
 	StreamChat(*StreamChatRequest, grpc.ServerStreamingServer[StreamChatResponse]) error
+	// 관리자: 사용자·기능·프로바이더별 LLM 사용량과 예상 비용
+	GetLLMUsageReport(context.Context, *GetLLMUsageReportRequest) (*LLMUsageReport, error)
+	// coaching-service 전용: 코칭 메시지 본문을 사용자 컨텍스트로 개인화 (안전 가드레일 적용)
+	ComposeCoachingMessage(context.Context, *ComposeCoachingMessageRequest) (*ComposeCoachingMessageResponse, error)
+	// 관리자: 후보 모델 버전 등록(아티팩트 업로드, 섀도 후보) 및 운영 전환
+	RegisterModelVersion(context.Context, *RegisterModelVersionRequest) (*ModelInfo, error)
+	PromoteModelVersion(context.Context, *PromoteModelVersionRequest) (*ModelInfo, error)
 	mustEmbedUnimplementedAiInferenceServiceServer()
 }
 
@@ -2258,6 +3512,18 @@ func (UnimplementedAiInferenceServiceServer) ListModels(context.Context, *ListMo
 func (UnimplementedAiInferenceServiceServer) StreamChat(*StreamChatRequest, grpc.ServerStreamingServer[StreamChatResponse]) error {
 	return status.Error(codes.Unimplemented, "method StreamChat not implemented")
 }
+func (UnimplementedAiInferenceServiceServer) GetLLMUsageReport(context.Context, *GetLLMUsageReportRequest) (*LLMUsageReport, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetLLMUsageReport not implemented")
+}
+func (UnimplementedAiInferenceServiceServer) ComposeCoachingMessage(context.Context, *ComposeCoachingMessageRequest) (*ComposeCoachingMessageResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ComposeCoachingMessage not implemented")
+}
+func (UnimplementedAiInferenceServiceServer) RegisterModelVersion(context.Context, *RegisterModelVersionRequest) (*ModelInfo, error) {
+	return nil, status.Error(codes.Unimplemented, "method RegisterModelVersion not implemented")
+}
+func (UnimplementedAiInferenceServiceServer) PromoteModelVersion(context.Context, *PromoteModelVersionRequest) (*ModelInfo, error) {
+	return nil, status.Error(codes.Unimplemented, "method PromoteModelVersion not implemented")
+}
 func (UnimplementedAiInferenceServiceServer) mustEmbedUnimplementedAiInferenceServiceServer() {}
 func (UnimplementedAiInferenceServiceServer) testEmbeddedByValue()                            {}
 
@@ -2380,6 +3646,78 @@ func _AiInferenceService_StreamChat_Handler(srv interface{}, stream grpc.ServerS
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type AiInferenceService_StreamChatServer = grpc.ServerStreamingServer[StreamChatResponse]
 
+func _AiInferenceService_GetLLMUsageReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLLMUsageReportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AiInferenceServiceServer).GetLLMUsageReport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AiInferenceService_GetLLMUsageReport_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AiInferenceServiceServer).GetLLMUsageReport(ctx, req.(*GetLLMUsageReportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AiInferenceService_ComposeCoachingMessage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ComposeCoachingMessageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AiInferenceServiceServer).ComposeCoachingMessage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AiInferenceService_ComposeCoachingMessage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AiInferenceServiceServer).ComposeCoachingMessage(ctx, req.(*ComposeCoachingMessageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AiInferenceService_RegisterModelVersion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegisterModelVersionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AiInferenceServiceServer).RegisterModelVersion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AiInferenceService_RegisterModelVersion_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AiInferenceServiceServer).RegisterModelVersion(ctx, req.(*RegisterModelVersionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AiInferenceService_PromoteModelVersion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PromoteModelVersionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AiInferenceServiceServer).PromoteModelVersion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AiInferenceService_PromoteModelVersion_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AiInferenceServiceServer).PromoteModelVersion(ctx, req.(*PromoteModelVersionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // AiInferenceService_ServiceDesc is the grpc.ServiceDesc for AiInferenceService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -2407,6 +3745,22 @@ var AiInferenceService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListModels",
 			Handler:    _AiInferenceService_ListModels_Handler,
 		},
+		{
+			MethodName: "GetLLMUsageReport",
+			Handler:    _AiInferenceService_GetLLMUsageReport_Handler,
+		},
+		{
+			MethodName: "ComposeCoachingMessage",
+			Handler:    _AiInferenceService_ComposeCoachingMessage_Handler,
+		},
+		{
+			MethodName: "RegisterModelVersion",
+			Handler:    _AiInferenceService_RegisterModelVersion_Handler,
+		},
+		{
+			MethodName: "PromoteModelVersion",
+			Handler:    _AiInferenceService_PromoteModelVersion_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -3107,13 +4461,17 @@ var CalibrationService_ServiceDesc = grpc.ServiceDesc{
 }
 
 const (
-	CoachingService_SetHealthGoal_FullMethodName        = "/manpasik.v1.CoachingService/SetHealthGoal"
-	CoachingService_GetHealthGoals_FullMethodName       = "/manpasik.v1.CoachingService/GetHealthGoals"
-	CoachingService_GenerateCoaching_FullMethodName     = "/manpasik.v1.CoachingService/GenerateCoaching"
-	CoachingService_ListCoachingMessages_FullMethodName = "/manpasik.v1.CoachingService/ListCoachingMessages"
-	CoachingService_GenerateDailyReport_FullMethodName  = "/manpasik.v1.CoachingService/GenerateDailyReport"
-	CoachingService_GetWeeklyReport_FullMethodName      = "/manpasik.v1.CoachingService/GetWeeklyReport"
-	CoachingService_GetRecommendations_FullMethodName   = "/manpasik.v1.CoachingService/GetRecommendations"
+	CoachingService_SetHealthGoal_FullMethodName         = "/manpasik.v1.CoachingService/SetHealthGoal"
+	CoachingService_GetHealthGoals_FullMethodName        = "/manpasik.v1.CoachingService/GetHealthGoals"
+	CoachingService_GenerateCoaching_FullMethodName      = "/manpasik.v1.CoachingService/GenerateCoaching"
+	CoachingService_ListCoachingMessages_FullMethodName  = "/manpasik.v1.CoachingService/ListCoachingMessages"
+	CoachingService_GenerateDailyReport_FullMethodName   = "/manpasik.v1.CoachingService/GenerateDailyReport"
+	CoachingService_GetWeeklyReport_FullMethodName       = "/manpasik.v1.CoachingService/GetWeeklyReport"
+	CoachingService_GetRecommendations_FullMethodName    = "/manpasik.v1.CoachingService/GetRecommendations"
+	CoachingService_SetCoachingSchedule_FullMethodName   = "/manpasik.v1.CoachingService/SetCoachingSchedule"
+	CoachingService_GetCoachingSchedule_FullMethodName   = "/manpasik.v1.CoachingService/GetCoachingSchedule"
+	CoachingService_ExportHealthReport_FullMethodName    = "/manpasik.v1.CoachingService/ExportHealthReport"
+	CoachingService_GetHealthReportExport_FullMethodName = "/manpasik.v1.CoachingService/GetHealthReportExport"
 )
 
 // CoachingServiceClient is the client API for CoachingService service.
@@ -3134,6 +4492,14 @@ type CoachingServiceClient interface {
 	GetWeeklyReport(ctx context.Context, in *GetWeeklyReportRequest, opts ...grpc.CallOption) (*WeeklyHealthReport, error)
 	// 개인화 추천 조회
 	GetRecommendations(ctx context.Context, in *GetRecommendationsRequest, opts ...grpc.CallOption) (*GetRecommendationsResponse, error)
+	// 일일 팁·리포트 발송 시각 설정 (사용자 시간대·방해 금지 시간 반영)
+	SetCoachingSchedule(ctx context.Context, in *SetCoachingScheduleRequest, opts ...grpc.CallOption) (*CoachingSchedule, error)
+	// 일일 팁·리포트 발송 설정 조회
+	GetCoachingSchedule(ctx context.Context, in *GetCoachingScheduleRequest, opts ...grpc.CallOption) (*CoachingSchedule, error)
+	// 주간·월간 건강 리포트 HTML·PDF 생성 및 다운로드 링크 발급
+	ExportHealthReport(ctx context.Context, in *ExportHealthReportRequest, opts ...grpc.CallOption) (*HealthReportExport, error)
+	// 생성된 리포트의 새 다운로드 링크 발급
+	GetHealthReportExport(ctx context.Context, in *GetHealthReportExportRequest, opts ...grpc.CallOption) (*HealthReportExport, error)
 }
 
 type coachingServiceClient struct {
@@ -3214,6 +4580,46 @@ func (c *coachingServiceClient) GetRecommendations(ctx context.Context, in *GetR
 	return out, nil
 }
 
+func (c *coachingServiceClient) SetCoachingSchedule(ctx context.Context, in *SetCoachingScheduleRequest, opts ...grpc.CallOption) (*CoachingSchedule, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CoachingSchedule)
+	err := c.cc.Invoke(ctx, CoachingService_SetCoachingSchedule_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *coachingServiceClient) GetCoachingSchedule(ctx context.Context, in *GetCoachingScheduleRequest, opts ...grpc.CallOption) (*CoachingSchedule, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CoachingSchedule)
+	err := c.cc.Invoke(ctx, CoachingService_GetCoachingSchedule_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *coachingServiceClient) ExportHealthReport(ctx context.Context, in *ExportHealthReportRequest, opts ...grpc.CallOption) (*HealthReportExport, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(HealthReportExport)
+	err := c.cc.Invoke(ctx, CoachingService_ExportHealthReport_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *coachingServiceClient) GetHealthReportExport(ctx context.Context, in *GetHealthReportExportRequest, opts ...grpc.CallOption) (*HealthReportExport, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(HealthReportExport)
+	err := c.cc.Invoke(ctx, CoachingService_GetHealthReportExport_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // CoachingServiceServer is the server API for CoachingService service.
 // All implementations must embed UnimplementedCoachingServiceServer
 // for forward compatibility.
@@ -3232,6 +4638,14 @@ type CoachingServiceServer interface {
 	GetWeeklyReport(context.Context, *GetWeeklyReportRequest) (*WeeklyHealthReport, error)
 	// 개인화 추천 조회
 	GetRecommendations(context.Context, *GetRecommendationsRequest) (*GetRecommendationsResponse, error)
+	// 일일 팁·리포트 발송 시각 설정 (사용자 시간대·방해 금지 시간 반영)
+	SetCoachingSchedule(context.Context, *SetCoachingScheduleRequest) (*CoachingSchedule, error)
+	// 일일 팁·리포트 발송 설정 조회
+	GetCoachingSchedule(context.Context, *GetCoachingScheduleRequest) (*CoachingSchedule, error)
+	// 주간·월간 건강 리포트 HTML·PDF 생성 및 다운로드 링크 발급
+	ExportHealthReport(context.Context, *ExportHealthReportRequest) (*HealthReportExport, error)
+	// 생성된 리포트의 새 다운로드 링크 발급
+	GetHealthReportExport(context.Context, *GetHealthReportExportRequest) (*HealthReportExport, error)
 	mustEmbedUnimplementedCoachingServiceServer()
 }
 
@@ -3263,6 +4677,18 @@ func (UnimplementedCoachingServiceServer) GetWeeklyReport(context.Context, *GetW
 func (UnimplementedCoachingServiceServer) GetRecommendations(context.Context, *GetRecommendationsRequest) (*GetRecommendationsResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetRecommendations not implemented")
 }
+func (UnimplementedCoachingServiceServer) SetCoachingSchedule(context.Context, *SetCoachingScheduleRequest) (*CoachingSchedule, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetCoachingSchedule not implemented")
+}
+func (UnimplementedCoachingServiceServer) GetCoachingSchedule(context.Context, *GetCoachingScheduleRequest) (*CoachingSchedule, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetCoachingSchedule not implemented")
+}
+func (UnimplementedCoachingServiceServer) ExportHealthReport(context.Context, *ExportHealthReportRequest) (*HealthReportExport, error) {
+	return nil, status.Error(codes.Unimplemented, "method ExportHealthReport not implemented")
+}
+func (UnimplementedCoachingServiceServer) GetHealthReportExport(context.Context, *GetHealthReportExportRequest) (*HealthReportExport, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetHealthReportExport not implemented")
+}
 func (UnimplementedCoachingServiceServer) mustEmbedUnimplementedCoachingServiceServer() {}
 func (UnimplementedCoachingServiceServer) testEmbeddedByValue()                         {}
 
@@ -3410,6 +4836,78 @@ func _CoachingService_GetRecommendations_Handler(srv interface{}, ctx context.Co
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CoachingService_SetCoachingSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetCoachingScheduleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CoachingServiceServer).SetCoachingSchedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CoachingService_SetCoachingSchedule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CoachingServiceServer).SetCoachingSchedule(ctx, req.(*SetCoachingScheduleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CoachingService_GetCoachingSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCoachingScheduleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CoachingServiceServer).GetCoachingSchedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CoachingService_GetCoachingSchedule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CoachingServiceServer).GetCoachingSchedule(ctx, req.(*GetCoachingScheduleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CoachingService_ExportHealthReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportHealthReportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CoachingServiceServer).ExportHealthReport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CoachingService_ExportHealthReport_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CoachingServiceServer).ExportHealthReport(ctx, req.(*ExportHealthReportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CoachingService_GetHealthReportExport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetHealthReportExportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CoachingServiceServer).GetHealthReportExport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CoachingService_GetHealthReportExport_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CoachingServiceServer).GetHealthReportExport(ctx, req.(*GetHealthReportExportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // CoachingService_ServiceDesc is the grpc.ServiceDesc for CoachingService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -3438,12 +4936,28 @@ var CoachingService_ServiceDesc = grpc.ServiceDesc{
 			Handler:    _CoachingService_GenerateDailyReport_Handler,
 		},
 		{
-			MethodName: "GetWeeklyReport",
-			Handler:    _CoachingService_GetWeeklyReport_Handler,
+			MethodName: "GetWeeklyReport",
+			Handler:    _CoachingService_GetWeeklyReport_Handler,
+		},
+		{
+			MethodName: "GetRecommendations",
+			Handler:    _CoachingService_GetRecommendations_Handler,
+		},
+		{
+			MethodName: "SetCoachingSchedule",
+			Handler:    _CoachingService_SetCoachingSchedule_Handler,
+		},
+		{
+			MethodName: "GetCoachingSchedule",
+			Handler:    _CoachingService_GetCoachingSchedule_Handler,
+		},
+		{
+			MethodName: "ExportHealthReport",
+			Handler:    _CoachingService_ExportHealthReport_Handler,
 		},
 		{
-			MethodName: "GetRecommendations",
-			Handler:    _CoachingService_GetRecommendations_Handler,
+			MethodName: "GetHealthReportExport",
+			Handler:    _CoachingService_GetHealthReportExport_Handler,
 		},
 	},
 	Streams:  []grpc.StreamDesc{},
@@ -3897,24 +5411,31 @@ var ReservationService_ServiceDesc = grpc.ServiceDesc{
 }
 
 const (
-	AdminService_CreateAdmin_FullMethodName         = "/manpasik.v1.AdminService/CreateAdmin"
-	AdminService_GetAdmin_FullMethodName            = "/manpasik.v1.AdminService/GetAdmin"
-	AdminService_ListAdmins_FullMethodName          = "/manpasik.v1.AdminService/ListAdmins"
-	AdminService_UpdateAdminRole_FullMethodName     = "/manpasik.v1.AdminService/UpdateAdminRole"
-	AdminService_DeactivateAdmin_FullMethodName     = "/manpasik.v1.AdminService/DeactivateAdmin"
-	AdminService_ListUsers_FullMethodName           = "/manpasik.v1.AdminService/ListUsers"
-	AdminService_GetSystemStats_FullMethodName      = "/manpasik.v1.AdminService/GetSystemStats"
-	AdminService_GetAuditLog_FullMethodName         = "/manpasik.v1.AdminService/GetAuditLog"
-	AdminService_SetSystemConfig_FullMethodName     = "/manpasik.v1.AdminService/SetSystemConfig"
-	AdminService_GetSystemConfig_FullMethodName     = "/manpasik.v1.AdminService/GetSystemConfig"
-	AdminService_ListAdminsByRegion_FullMethodName  = "/manpasik.v1.AdminService/ListAdminsByRegion"
-	AdminService_ListSystemConfigs_FullMethodName   = "/manpasik.v1.AdminService/ListSystemConfigs"
-	AdminService_GetConfigWithMeta_FullMethodName   = "/manpasik.v1.AdminService/GetConfigWithMeta"
-	AdminService_ValidateConfigValue_FullMethodName = "/manpasik.v1.AdminService/ValidateConfigValue"
-	AdminService_BulkSetConfigs_FullMethodName      = "/manpasik.v1.AdminService/BulkSetConfigs"
-	AdminService_GetAuditLogDetails_FullMethodName  = "/manpasik.v1.AdminService/GetAuditLogDetails"
-	AdminService_GetRevenueStats_FullMethodName     = "/manpasik.v1.AdminService/GetRevenueStats"
-	AdminService_GetInventoryStats_FullMethodName   = "/manpasik.v1.AdminService/GetInventoryStats"
+	AdminService_CreateAdmin_FullMethodName                      = "/manpasik.v1.AdminService/CreateAdmin"
+	AdminService_GetAdmin_FullMethodName                         = "/manpasik.v1.AdminService/GetAdmin"
+	AdminService_ListAdmins_FullMethodName                       = "/manpasik.v1.AdminService/ListAdmins"
+	AdminService_UpdateAdminRole_FullMethodName                  = "/manpasik.v1.AdminService/UpdateAdminRole"
+	AdminService_DeactivateAdmin_FullMethodName                  = "/manpasik.v1.AdminService/DeactivateAdmin"
+	AdminService_ListUsers_FullMethodName                        = "/manpasik.v1.AdminService/ListUsers"
+	AdminService_GetSystemStats_FullMethodName                   = "/manpasik.v1.AdminService/GetSystemStats"
+	AdminService_GetAuditLog_FullMethodName                      = "/manpasik.v1.AdminService/GetAuditLog"
+	AdminService_SetSystemConfig_FullMethodName                  = "/manpasik.v1.AdminService/SetSystemConfig"
+	AdminService_GetSystemConfig_FullMethodName                  = "/manpasik.v1.AdminService/GetSystemConfig"
+	AdminService_ListAdminsByRegion_FullMethodName               = "/manpasik.v1.AdminService/ListAdminsByRegion"
+	AdminService_ListSystemConfigs_FullMethodName                = "/manpasik.v1.AdminService/ListSystemConfigs"
+	AdminService_GetConfigWithMeta_FullMethodName                = "/manpasik.v1.AdminService/GetConfigWithMeta"
+	AdminService_ValidateConfigValue_FullMethodName              = "/manpasik.v1.AdminService/ValidateConfigValue"
+	AdminService_BulkSetConfigs_FullMethodName                   = "/manpasik.v1.AdminService/BulkSetConfigs"
+	AdminService_GetAuditLogDetails_FullMethodName               = "/manpasik.v1.AdminService/GetAuditLogDetails"
+	AdminService_GetRevenueStats_FullMethodName                  = "/manpasik.v1.AdminService/GetRevenueStats"
+	AdminService_GetInventoryStats_FullMethodName                = "/manpasik.v1.AdminService/GetInventoryStats"
+	AdminService_GetFleetStats_FullMethodName                    = "/manpasik.v1.AdminService/GetFleetStats"
+	AdminService_ListNotificationTemplates_FullMethodName        = "/manpasik.v1.AdminService/ListNotificationTemplates"
+	AdminService_ListNotificationTemplateVersions_FullMethodName = "/manpasik.v1.AdminService/ListNotificationTemplateVersions"
+	AdminService_SaveNotificationTemplate_FullMethodName         = "/manpasik.v1.AdminService/SaveNotificationTemplate"
+	AdminService_DeleteNotificationTemplate_FullMethodName       = "/manpasik.v1.AdminService/DeleteNotificationTemplate"
+	AdminService_RollbackNotificationTemplate_FullMethodName     = "/manpasik.v1.AdminService/RollbackNotificationTemplate"
+	AdminService_PreviewNotificationTemplate_FullMethodName      = "/manpasik.v1.AdminService/PreviewNotificationTemplate"
 )
 
 // AdminServiceClient is the client API for AdminService service.
@@ -3943,6 +5464,15 @@ type AdminServiceClient interface {
 	// Phase 9: 매출/재고 통계 (C12)
 	GetRevenueStats(ctx context.Context, in *GetRevenueStatsRequest, opts ...grpc.CallOption) (*GetRevenueStatsResponse, error)
 	GetInventoryStats(ctx context.Context, in *GetInventoryStatsRequest, opts ...grpc.CallOption) (*GetInventoryStatsResponse, error)
+	// 클리니컬 플릿 통계 (device-service 집계)
+	GetFleetStats(ctx context.Context, in *AdminGetFleetStatsRequest, opts ...grpc.CallOption) (*AdminGetFleetStatsResponse, error)
+	// 알림 템플릿 CRUD·버전 관리 (notification-service 저장소, 감사 로그 기록)
+	ListNotificationTemplates(ctx context.Context, in *ListNotificationTemplatesRequest, opts ...grpc.CallOption) (*ListNotificationTemplatesResponse, error)
+	ListNotificationTemplateVersions(ctx context.Context, in *ListNotificationTemplateVersionsRequest, opts ...grpc.CallOption) (*ListNotificationTemplatesResponse, error)
+	SaveNotificationTemplate(ctx context.Context, in *SaveNotificationTemplateRequest, opts ...grpc.CallOption) (*NotificationTemplate, error)
+	DeleteNotificationTemplate(ctx context.Context, in *DeleteNotificationTemplateRequest, opts ...grpc.CallOption) (*NotificationTemplate, error)
+	RollbackNotificationTemplate(ctx context.Context, in *RollbackNotificationTemplateRequest, opts ...grpc.CallOption) (*NotificationTemplate, error)
+	PreviewNotificationTemplate(ctx context.Context, in *PreviewNotificationTemplateRequest, opts ...grpc.CallOption) (*PreviewNotificationTemplateResponse, error)
 }
 
 type adminServiceClient struct {
@@ -4133,6 +5663,76 @@ func (c *adminServiceClient) GetInventoryStats(ctx context.Context, in *GetInven
 	return out, nil
 }
 
+func (c *adminServiceClient) GetFleetStats(ctx context.Context, in *AdminGetFleetStatsRequest, opts ...grpc.CallOption) (*AdminGetFleetStatsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AdminGetFleetStatsResponse)
+	err := c.cc.Invoke(ctx, AdminService_GetFleetStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) ListNotificationTemplates(ctx context.Context, in *ListNotificationTemplatesRequest, opts ...grpc.CallOption) (*ListNotificationTemplatesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListNotificationTemplatesResponse)
+	err := c.cc.Invoke(ctx, AdminService_ListNotificationTemplates_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) ListNotificationTemplateVersions(ctx context.Context, in *ListNotificationTemplateVersionsRequest, opts ...grpc.CallOption) (*ListNotificationTemplatesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListNotificationTemplatesResponse)
+	err := c.cc.Invoke(ctx, AdminService_ListNotificationTemplateVersions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) SaveNotificationTemplate(ctx context.Context, in *SaveNotificationTemplateRequest, opts ...grpc.CallOption) (*NotificationTemplate, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(NotificationTemplate)
+	err := c.cc.Invoke(ctx, AdminService_SaveNotificationTemplate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) DeleteNotificationTemplate(ctx context.Context, in *DeleteNotificationTemplateRequest, opts ...grpc.CallOption) (*NotificationTemplate, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(NotificationTemplate)
+	err := c.cc.Invoke(ctx, AdminService_DeleteNotificationTemplate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) RollbackNotificationTemplate(ctx context.Context, in *RollbackNotificationTemplateRequest, opts ...grpc.CallOption) (*NotificationTemplate, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(NotificationTemplate)
+	err := c.cc.Invoke(ctx, AdminService_RollbackNotificationTemplate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) PreviewNotificationTemplate(ctx context.Context, in *PreviewNotificationTemplateRequest, opts ...grpc.CallOption) (*PreviewNotificationTemplateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PreviewNotificationTemplateResponse)
+	err := c.cc.Invoke(ctx, AdminService_PreviewNotificationTemplate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // AdminServiceServer is the server API for AdminService service.
 // All implementations must embed UnimplementedAdminServiceServer
 // for forward compatibility.
@@ -4159,6 +5759,15 @@ type AdminServiceServer interface {
 	// Phase 9: 매출/재고 통계 (C12)
 	GetRevenueStats(context.Context, *GetRevenueStatsRequest) (*GetRevenueStatsResponse, error)
 	GetInventoryStats(context.Context, *GetInventoryStatsRequest) (*GetInventoryStatsResponse, error)
+	// 클리니컬 플릿 통계 (device-service 집계)
+	GetFleetStats(context.Context, *AdminGetFleetStatsRequest) (*AdminGetFleetStatsResponse, error)
+	// 알림 템플릿 CRUD·버전 관리 (notification-service 저장소, 감사 로그 기록)
+	ListNotificationTemplates(context.Context, *ListNotificationTemplatesRequest) (*ListNotificationTemplatesResponse, error)
+	ListNotificationTemplateVersions(context.Context, *ListNotificationTemplateVersionsRequest) (*ListNotificationTemplatesResponse, error)
+	SaveNotificationTemplate(context.Context, *SaveNotificationTemplateRequest) (*NotificationTemplate, error)
+	DeleteNotificationTemplate(context.Context, *DeleteNotificationTemplateRequest) (*NotificationTemplate, error)
+	RollbackNotificationTemplate(context.Context, *RollbackNotificationTemplateRequest) (*NotificationTemplate, error)
+	PreviewNotificationTemplate(context.Context, *PreviewNotificationTemplateRequest) (*PreviewNotificationTemplateResponse, error)
 	mustEmbedUnimplementedAdminServiceServer()
 }
 
@@ -4223,6 +5832,27 @@ func (UnimplementedAdminServiceServer) GetRevenueStats(context.Context, *GetReve
 func (UnimplementedAdminServiceServer) GetInventoryStats(context.Context, *GetInventoryStatsRequest) (*GetInventoryStatsResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetInventoryStats not implemented")
 }
+func (UnimplementedAdminServiceServer) GetFleetStats(context.Context, *AdminGetFleetStatsRequest) (*AdminGetFleetStatsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetFleetStats not implemented")
+}
+func (UnimplementedAdminServiceServer) ListNotificationTemplates(context.Context, *ListNotificationTemplatesRequest) (*ListNotificationTemplatesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListNotificationTemplates not implemented")
+}
+func (UnimplementedAdminServiceServer) ListNotificationTemplateVersions(context.Context, *ListNotificationTemplateVersionsRequest) (*ListNotificationTemplatesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListNotificationTemplateVersions not implemented")
+}
+func (UnimplementedAdminServiceServer) SaveNotificationTemplate(context.Context, *SaveNotificationTemplateRequest) (*NotificationTemplate, error) {
+	return nil, status.Error(codes.Unimplemented, "method SaveNotificationTemplate not implemented")
+}
+func (UnimplementedAdminServiceServer) DeleteNotificationTemplate(context.Context, *DeleteNotificationTemplateRequest) (*NotificationTemplate, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteNotificationTemplate not implemented")
+}
+func (UnimplementedAdminServiceServer) RollbackNotificationTemplate(context.Context, *RollbackNotificationTemplateRequest) (*NotificationTemplate, error) {
+	return nil, status.Error(codes.Unimplemented, "method RollbackNotificationTemplate not implemented")
+}
+func (UnimplementedAdminServiceServer) PreviewNotificationTemplate(context.Context, *PreviewNotificationTemplateRequest) (*PreviewNotificationTemplateResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method PreviewNotificationTemplate not implemented")
+}
 func (UnimplementedAdminServiceServer) mustEmbedUnimplementedAdminServiceServer() {}
 func (UnimplementedAdminServiceServer) testEmbeddedByValue()                      {}
 
@@ -4568,6 +6198,132 @@ func _AdminService_GetInventoryStats_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AdminService_GetFleetStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AdminGetFleetStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).GetFleetStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_GetFleetStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).GetFleetStats(ctx, req.(*AdminGetFleetStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_ListNotificationTemplates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListNotificationTemplatesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).ListNotificationTemplates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_ListNotificationTemplates_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).ListNotificationTemplates(ctx, req.(*ListNotificationTemplatesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_ListNotificationTemplateVersions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListNotificationTemplateVersionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).ListNotificationTemplateVersions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_ListNotificationTemplateVersions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).ListNotificationTemplateVersions(ctx, req.(*ListNotificationTemplateVersionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_SaveNotificationTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SaveNotificationTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).SaveNotificationTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_SaveNotificationTemplate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).SaveNotificationTemplate(ctx, req.(*SaveNotificationTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_DeleteNotificationTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteNotificationTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).DeleteNotificationTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_DeleteNotificationTemplate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).DeleteNotificationTemplate(ctx, req.(*DeleteNotificationTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_RollbackNotificationTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RollbackNotificationTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).RollbackNotificationTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_RollbackNotificationTemplate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).RollbackNotificationTemplate(ctx, req.(*RollbackNotificationTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_PreviewNotificationTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PreviewNotificationTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).PreviewNotificationTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_PreviewNotificationTemplate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).PreviewNotificationTemplate(ctx, req.(*PreviewNotificationTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // AdminService_ServiceDesc is the grpc.ServiceDesc for AdminService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -4647,6 +6403,34 @@ var AdminService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetInventoryStats",
 			Handler:    _AdminService_GetInventoryStats_Handler,
 		},
+		{
+			MethodName: "GetFleetStats",
+			Handler:    _AdminService_GetFleetStats_Handler,
+		},
+		{
+			MethodName: "ListNotificationTemplates",
+			Handler:    _AdminService_ListNotificationTemplates_Handler,
+		},
+		{
+			MethodName: "ListNotificationTemplateVersions",
+			Handler:    _AdminService_ListNotificationTemplateVersions_Handler,
+		},
+		{
+			MethodName: "SaveNotificationTemplate",
+			Handler:    _AdminService_SaveNotificationTemplate_Handler,
+		},
+		{
+			MethodName: "DeleteNotificationTemplate",
+			Handler:    _AdminService_DeleteNotificationTemplate_Handler,
+		},
+		{
+			MethodName: "RollbackNotificationTemplate",
+			Handler:    _AdminService_RollbackNotificationTemplate_Handler,
+		},
+		{
+			MethodName: "PreviewNotificationTemplate",
+			Handler:    _AdminService_PreviewNotificationTemplate_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "manpasik.proto",
@@ -4663,6 +6447,7 @@ const (
 	FamilyService_SetSharingPreferences_FullMethodName = "/manpasik.v1.FamilyService/SetSharingPreferences"
 	FamilyService_GetSharedHealthData_FullMethodName   = "/manpasik.v1.FamilyService/GetSharedHealthData"
 	FamilyService_ValidateSharingAccess_FullMethodName = "/manpasik.v1.FamilyService/ValidateSharingAccess"
+	FamilyService_ListGuardians_FullMethodName         = "/manpasik.v1.FamilyService/ListGuardians"
 )
 
 // FamilyServiceClient is the client API for FamilyService service.
@@ -4680,6 +6465,8 @@ type FamilyServiceClient interface {
 	GetSharedHealthData(ctx context.Context, in *GetSharedHealthDataRequest, opts ...grpc.CallOption) (*GetSharedHealthDataResponse, error)
 	// Phase 6: 공유 접근 검증
 	ValidateSharingAccess(ctx context.Context, in *ValidateSharingAccessRequest, opts ...grpc.CallOption) (*ValidateSharingAccessResponse, error)
+	// 긴급 알림 에스컬레이션 대상 보호자 조회
+	ListGuardians(ctx context.Context, in *ListGuardiansRequest, opts ...grpc.CallOption) (*ListGuardiansResponse, error)
 }
 
 type familyServiceClient struct {
@@ -4790,6 +6577,16 @@ func (c *familyServiceClient) ValidateSharingAccess(ctx context.Context, in *Val
 	return out, nil
 }
 
+func (c *familyServiceClient) ListGuardians(ctx context.Context, in *ListGuardiansRequest, opts ...grpc.CallOption) (*ListGuardiansResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListGuardiansResponse)
+	err := c.cc.Invoke(ctx, FamilyService_ListGuardians_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // FamilyServiceServer is the server API for FamilyService service.
 // All implementations must embed UnimplementedFamilyServiceServer
 // for forward compatibility.
@@ -4805,6 +6602,8 @@ type FamilyServiceServer interface {
 	GetSharedHealthData(context.Context, *GetSharedHealthDataRequest) (*GetSharedHealthDataResponse, error)
 	// Phase 6: 공유 접근 검증
 	ValidateSharingAccess(context.Context, *ValidateSharingAccessRequest) (*ValidateSharingAccessResponse, error)
+	// 긴급 알림 에스컬레이션 대상 보호자 조회
+	ListGuardians(context.Context, *ListGuardiansRequest) (*ListGuardiansResponse, error)
 	mustEmbedUnimplementedFamilyServiceServer()
 }
 
@@ -4845,6 +6644,9 @@ func (UnimplementedFamilyServiceServer) GetSharedHealthData(context.Context, *Ge
 func (UnimplementedFamilyServiceServer) ValidateSharingAccess(context.Context, *ValidateSharingAccessRequest) (*ValidateSharingAccessResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method ValidateSharingAccess not implemented")
 }
+func (UnimplementedFamilyServiceServer) ListGuardians(context.Context, *ListGuardiansRequest) (*ListGuardiansResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListGuardians not implemented")
+}
 func (UnimplementedFamilyServiceServer) mustEmbedUnimplementedFamilyServiceServer() {}
 func (UnimplementedFamilyServiceServer) testEmbeddedByValue()                       {}
 
@@ -5046,6 +6848,24 @@ func _FamilyService_ValidateSharingAccess_Handler(srv interface{}, ctx context.C
 	return interceptor(ctx, in, info, handler)
 }
 
+func _FamilyService_ListGuardians_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListGuardiansRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FamilyServiceServer).ListGuardians(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FamilyService_ListGuardians_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FamilyServiceServer).ListGuardians(ctx, req.(*ListGuardiansRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // FamilyService_ServiceDesc is the grpc.ServiceDesc for FamilyService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -5093,6 +6913,10 @@ var FamilyService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ValidateSharingAccess",
 			Handler:    _FamilyService_ValidateSharingAccess_Handler,
 		},
+		{
+			MethodName: "ListGuardians",
+			Handler:    _FamilyService_ListGuardians_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "manpasik.proto",
@@ -7071,14 +8895,22 @@ var VideoService_ServiceDesc = grpc.ServiceDesc{
 }
 
 const (
-	NotificationService_SendNotification_FullMethodName              = "/manpasik.v1.NotificationService/SendNotification"
-	NotificationService_ListNotifications_FullMethodName             = "/manpasik.v1.NotificationService/ListNotifications"
-	NotificationService_MarkAsRead_FullMethodName                    = "/manpasik.v1.NotificationService/MarkAsRead"
-	NotificationService_MarkAllAsRead_FullMethodName                 = "/manpasik.v1.NotificationService/MarkAllAsRead"
-	NotificationService_GetUnreadCount_FullMethodName                = "/manpasik.v1.NotificationService/GetUnreadCount"
-	NotificationService_UpdateNotificationPreferences_FullMethodName = "/manpasik.v1.NotificationService/UpdateNotificationPreferences"
-	NotificationService_GetNotificationPreferences_FullMethodName    = "/manpasik.v1.NotificationService/GetNotificationPreferences"
-	NotificationService_SendFromTemplate_FullMethodName              = "/manpasik.v1.NotificationService/SendFromTemplate"
+	NotificationService_SendNotification_FullMethodName                 = "/manpasik.v1.NotificationService/SendNotification"
+	NotificationService_ListNotifications_FullMethodName                = "/manpasik.v1.NotificationService/ListNotifications"
+	NotificationService_MarkAsRead_FullMethodName                       = "/manpasik.v1.NotificationService/MarkAsRead"
+	NotificationService_MarkAllAsRead_FullMethodName                    = "/manpasik.v1.NotificationService/MarkAllAsRead"
+	NotificationService_GetUnreadCount_FullMethodName                   = "/manpasik.v1.NotificationService/GetUnreadCount"
+	NotificationService_UpdateNotificationPreferences_FullMethodName    = "/manpasik.v1.NotificationService/UpdateNotificationPreferences"
+	NotificationService_GetNotificationPreferences_FullMethodName       = "/manpasik.v1.NotificationService/GetNotificationPreferences"
+	NotificationService_SendFromTemplate_FullMethodName                 = "/manpasik.v1.NotificationService/SendFromTemplate"
+	NotificationService_AcknowledgeEscalation_FullMethodName            = "/manpasik.v1.NotificationService/AcknowledgeEscalation"
+	NotificationService_ListActiveEscalations_FullMethodName            = "/manpasik.v1.NotificationService/ListActiveEscalations"
+	NotificationService_ListNotificationTemplates_FullMethodName        = "/manpasik.v1.NotificationService/ListNotificationTemplates"
+	NotificationService_ListNotificationTemplateVersions_FullMethodName = "/manpasik.v1.NotificationService/ListNotificationTemplateVersions"
+	NotificationService_SaveNotificationTemplate_FullMethodName         = "/manpasik.v1.NotificationService/SaveNotificationTemplate"
+	NotificationService_DeleteNotificationTemplate_FullMethodName       = "/manpasik.v1.NotificationService/DeleteNotificationTemplate"
+	NotificationService_RollbackNotificationTemplate_FullMethodName     = "/manpasik.v1.NotificationService/RollbackNotificationTemplate"
+	NotificationService_PreviewNotificationTemplate_FullMethodName      = "/manpasik.v1.NotificationService/PreviewNotificationTemplate"
 )
 
 // NotificationServiceClient is the client API for NotificationService service.
@@ -7094,6 +8926,16 @@ type NotificationServiceClient interface {
 	GetNotificationPreferences(ctx context.Context, in *GetNotificationPreferencesRequest, opts ...grpc.CallOption) (*NotificationPreferences, error)
 	// Phase 6: 템플릿 기반 알림 발송
 	SendFromTemplate(ctx context.Context, in *SendFromTemplateRequest, opts ...grpc.CallOption) (*Notification, error)
+	// 긴급 건강 알림 에스컬레이션 (인앱 → 보호자 푸시 → AI 음성 확인 → 119)
+	AcknowledgeEscalation(ctx context.Context, in *AcknowledgeEscalationRequest, opts ...grpc.CallOption) (*Escalation, error)
+	ListActiveEscalations(ctx context.Context, in *ListActiveEscalationsRequest, opts ...grpc.CallOption) (*ListActiveEscalationsResponse, error)
+	// 다국어 알림 템플릿 관리 (버전 관리, 미리보기·검증)
+	ListNotificationTemplates(ctx context.Context, in *ListNotificationTemplatesRequest, opts ...grpc.CallOption) (*ListNotificationTemplatesResponse, error)
+	ListNotificationTemplateVersions(ctx context.Context, in *ListNotificationTemplateVersionsRequest, opts ...grpc.CallOption) (*ListNotificationTemplatesResponse, error)
+	SaveNotificationTemplate(ctx context.Context, in *SaveNotificationTemplateRequest, opts ...grpc.CallOption) (*NotificationTemplate, error)
+	DeleteNotificationTemplate(ctx context.Context, in *DeleteNotificationTemplateRequest, opts ...grpc.CallOption) (*NotificationTemplate, error)
+	RollbackNotificationTemplate(ctx context.Context, in *RollbackNotificationTemplateRequest, opts ...grpc.CallOption) (*NotificationTemplate, error)
+	PreviewNotificationTemplate(ctx context.Context, in *PreviewNotificationTemplateRequest, opts ...grpc.CallOption) (*PreviewNotificationTemplateResponse, error)
 }
 
 type notificationServiceClient struct {
@@ -7184,6 +9026,86 @@ func (c *notificationServiceClient) SendFromTemplate(ctx context.Context, in *Se
 	return out, nil
 }
 
+func (c *notificationServiceClient) AcknowledgeEscalation(ctx context.Context, in *AcknowledgeEscalationRequest, opts ...grpc.CallOption) (*Escalation, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Escalation)
+	err := c.cc.Invoke(ctx, NotificationService_AcknowledgeEscalation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *notificationServiceClient) ListActiveEscalations(ctx context.Context, in *ListActiveEscalationsRequest, opts ...grpc.CallOption) (*ListActiveEscalationsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListActiveEscalationsResponse)
+	err := c.cc.Invoke(ctx, NotificationService_ListActiveEscalations_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *notificationServiceClient) ListNotificationTemplates(ctx context.Context, in *ListNotificationTemplatesRequest, opts ...grpc.CallOption) (*ListNotificationTemplatesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListNotificationTemplatesResponse)
+	err := c.cc.Invoke(ctx, NotificationService_ListNotificationTemplates_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *notificationServiceClient) ListNotificationTemplateVersions(ctx context.Context, in *ListNotificationTemplateVersionsRequest, opts ...grpc.CallOption) (*ListNotificationTemplatesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListNotificationTemplatesResponse)
+	err := c.cc.Invoke(ctx, NotificationService_ListNotificationTemplateVersions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *notificationServiceClient) SaveNotificationTemplate(ctx context.Context, in *SaveNotificationTemplateRequest, opts ...grpc.CallOption) (*NotificationTemplate, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(NotificationTemplate)
+	err := c.cc.Invoke(ctx, NotificationService_SaveNotificationTemplate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *notificationServiceClient) DeleteNotificationTemplate(ctx context.Context, in *DeleteNotificationTemplateRequest, opts ...grpc.CallOption) (*NotificationTemplate, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(NotificationTemplate)
+	err := c.cc.Invoke(ctx, NotificationService_DeleteNotificationTemplate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *notificationServiceClient) RollbackNotificationTemplate(ctx context.Context, in *RollbackNotificationTemplateRequest, opts ...grpc.CallOption) (*NotificationTemplate, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(NotificationTemplate)
+	err := c.cc.Invoke(ctx, NotificationService_RollbackNotificationTemplate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *notificationServiceClient) PreviewNotificationTemplate(ctx context.Context, in *PreviewNotificationTemplateRequest, opts ...grpc.CallOption) (*PreviewNotificationTemplateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PreviewNotificationTemplateResponse)
+	err := c.cc.Invoke(ctx, NotificationService_PreviewNotificationTemplate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // NotificationServiceServer is the server API for NotificationService service.
 // All implementations must embed UnimplementedNotificationServiceServer
 // for forward compatibility.
@@ -7197,6 +9119,16 @@ type NotificationServiceServer interface {
 	GetNotificationPreferences(context.Context, *GetNotificationPreferencesRequest) (*NotificationPreferences, error)
 	// Phase 6: 템플릿 기반 알림 발송
 	SendFromTemplate(context.Context, *SendFromTemplateRequest) (*Notification, error)
+	// 긴급 건강 알림 에스컬레이션 (인앱 → 보호자 푸시 → AI 음성 확인 → 119)
+	AcknowledgeEscalation(context.Context, *AcknowledgeEscalationRequest) (*Escalation, error)
+	ListActiveEscalations(context.Context, *ListActiveEscalationsRequest) (*ListActiveEscalationsResponse, error)
+	// 다국어 알림 템플릿 관리 (버전 관리, 미리보기·검증)
+	ListNotificationTemplates(context.Context, *ListNotificationTemplatesRequest) (*ListNotificationTemplatesResponse, error)
+	ListNotificationTemplateVersions(context.Context, *ListNotificationTemplateVersionsRequest) (*ListNotificationTemplatesResponse, error)
+	SaveNotificationTemplate(context.Context, *SaveNotificationTemplateRequest) (*NotificationTemplate, error)
+	DeleteNotificationTemplate(context.Context, *DeleteNotificationTemplateRequest) (*NotificationTemplate, error)
+	RollbackNotificationTemplate(context.Context, *RollbackNotificationTemplateRequest) (*NotificationTemplate, error)
+	PreviewNotificationTemplate(context.Context, *PreviewNotificationTemplateRequest) (*PreviewNotificationTemplateResponse, error)
 	mustEmbedUnimplementedNotificationServiceServer()
 }
 
@@ -7225,11 +9157,35 @@ func (UnimplementedNotificationServiceServer) GetUnreadCount(context.Context, *G
 func (UnimplementedNotificationServiceServer) UpdateNotificationPreferences(context.Context, *UpdateNotificationPreferencesRequest) (*NotificationPreferences, error) {
 	return nil, status.Error(codes.Unimplemented, "method UpdateNotificationPreferences not implemented")
 }
-func (UnimplementedNotificationServiceServer) GetNotificationPreferences(context.Context, *GetNotificationPreferencesRequest) (*NotificationPreferences, error) {
-	return nil, status.Error(codes.Unimplemented, "method GetNotificationPreferences not implemented")
+func (UnimplementedNotificationServiceServer) GetNotificationPreferences(context.Context, *GetNotificationPreferencesRequest) (*NotificationPreferences, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetNotificationPreferences not implemented")
+}
+func (UnimplementedNotificationServiceServer) SendFromTemplate(context.Context, *SendFromTemplateRequest) (*Notification, error) {
+	return nil, status.Error(codes.Unimplemented, "method SendFromTemplate not implemented")
+}
+func (UnimplementedNotificationServiceServer) AcknowledgeEscalation(context.Context, *AcknowledgeEscalationRequest) (*Escalation, error) {
+	return nil, status.Error(codes.Unimplemented, "method AcknowledgeEscalation not implemented")
+}
+func (UnimplementedNotificationServiceServer) ListActiveEscalations(context.Context, *ListActiveEscalationsRequest) (*ListActiveEscalationsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListActiveEscalations not implemented")
+}
+func (UnimplementedNotificationServiceServer) ListNotificationTemplates(context.Context, *ListNotificationTemplatesRequest) (*ListNotificationTemplatesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListNotificationTemplates not implemented")
+}
+func (UnimplementedNotificationServiceServer) ListNotificationTemplateVersions(context.Context, *ListNotificationTemplateVersionsRequest) (*ListNotificationTemplatesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListNotificationTemplateVersions not implemented")
+}
+func (UnimplementedNotificationServiceServer) SaveNotificationTemplate(context.Context, *SaveNotificationTemplateRequest) (*NotificationTemplate, error) {
+	return nil, status.Error(codes.Unimplemented, "method SaveNotificationTemplate not implemented")
+}
+func (UnimplementedNotificationServiceServer) DeleteNotificationTemplate(context.Context, *DeleteNotificationTemplateRequest) (*NotificationTemplate, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteNotificationTemplate not implemented")
+}
+func (UnimplementedNotificationServiceServer) RollbackNotificationTemplate(context.Context, *RollbackNotificationTemplateRequest) (*NotificationTemplate, error) {
+	return nil, status.Error(codes.Unimplemented, "method RollbackNotificationTemplate not implemented")
 }
-func (UnimplementedNotificationServiceServer) SendFromTemplate(context.Context, *SendFromTemplateRequest) (*Notification, error) {
-	return nil, status.Error(codes.Unimplemented, "method SendFromTemplate not implemented")
+func (UnimplementedNotificationServiceServer) PreviewNotificationTemplate(context.Context, *PreviewNotificationTemplateRequest) (*PreviewNotificationTemplateResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method PreviewNotificationTemplate not implemented")
 }
 func (UnimplementedNotificationServiceServer) mustEmbedUnimplementedNotificationServiceServer() {}
 func (UnimplementedNotificationServiceServer) testEmbeddedByValue()                             {}
@@ -7396,6 +9352,150 @@ func _NotificationService_SendFromTemplate_Handler(srv interface{}, ctx context.
 	return interceptor(ctx, in, info, handler)
 }
 
+func _NotificationService_AcknowledgeEscalation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AcknowledgeEscalationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotificationServiceServer).AcknowledgeEscalation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NotificationService_AcknowledgeEscalation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotificationServiceServer).AcknowledgeEscalation(ctx, req.(*AcknowledgeEscalationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NotificationService_ListActiveEscalations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListActiveEscalationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotificationServiceServer).ListActiveEscalations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NotificationService_ListActiveEscalations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotificationServiceServer).ListActiveEscalations(ctx, req.(*ListActiveEscalationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NotificationService_ListNotificationTemplates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListNotificationTemplatesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotificationServiceServer).ListNotificationTemplates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NotificationService_ListNotificationTemplates_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotificationServiceServer).ListNotificationTemplates(ctx, req.(*ListNotificationTemplatesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NotificationService_ListNotificationTemplateVersions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListNotificationTemplateVersionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotificationServiceServer).ListNotificationTemplateVersions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NotificationService_ListNotificationTemplateVersions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotificationServiceServer).ListNotificationTemplateVersions(ctx, req.(*ListNotificationTemplateVersionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NotificationService_SaveNotificationTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SaveNotificationTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotificationServiceServer).SaveNotificationTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NotificationService_SaveNotificationTemplate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotificationServiceServer).SaveNotificationTemplate(ctx, req.(*SaveNotificationTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NotificationService_DeleteNotificationTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteNotificationTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotificationServiceServer).DeleteNotificationTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NotificationService_DeleteNotificationTemplate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotificationServiceServer).DeleteNotificationTemplate(ctx, req.(*DeleteNotificationTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NotificationService_RollbackNotificationTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RollbackNotificationTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotificationServiceServer).RollbackNotificationTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NotificationService_RollbackNotificationTemplate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotificationServiceServer).RollbackNotificationTemplate(ctx, req.(*RollbackNotificationTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NotificationService_PreviewNotificationTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PreviewNotificationTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotificationServiceServer).PreviewNotificationTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NotificationService_PreviewNotificationTemplate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotificationServiceServer).PreviewNotificationTemplate(ctx, req.(*PreviewNotificationTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // NotificationService_ServiceDesc is the grpc.ServiceDesc for NotificationService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -7435,6 +9535,38 @@ var NotificationService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "SendFromTemplate",
 			Handler:    _NotificationService_SendFromTemplate_Handler,
 		},
+		{
+			MethodName: "AcknowledgeEscalation",
+			Handler:    _NotificationService_AcknowledgeEscalation_Handler,
+		},
+		{
+			MethodName: "ListActiveEscalations",
+			Handler:    _NotificationService_ListActiveEscalations_Handler,
+		},
+		{
+			MethodName: "ListNotificationTemplates",
+			Handler:    _NotificationService_ListNotificationTemplates_Handler,
+		},
+		{
+			MethodName: "ListNotificationTemplateVersions",
+			Handler:    _NotificationService_ListNotificationTemplateVersions_Handler,
+		},
+		{
+			MethodName: "SaveNotificationTemplate",
+			Handler:    _NotificationService_SaveNotificationTemplate_Handler,
+		},
+		{
+			MethodName: "DeleteNotificationTemplate",
+			Handler:    _NotificationService_DeleteNotificationTemplate_Handler,
+		},
+		{
+			MethodName: "RollbackNotificationTemplate",
+			Handler:    _NotificationService_RollbackNotificationTemplate_Handler,
+		},
+		{
+			MethodName: "PreviewNotificationTemplate",
+			Handler:    _NotificationService_PreviewNotificationTemplate_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "manpasik.proto",
@@ -7773,13 +9905,15 @@ var TranslationService_ServiceDesc = grpc.ServiceDesc{
 }
 
 const (
-	TelemedicineService_CreateConsultation_FullMethodName = "/manpasik.v1.TelemedicineService/CreateConsultation"
-	TelemedicineService_GetConsultation_FullMethodName    = "/manpasik.v1.TelemedicineService/GetConsultation"
-	TelemedicineService_ListConsultations_FullMethodName  = "/manpasik.v1.TelemedicineService/ListConsultations"
-	TelemedicineService_MatchDoctor_FullMethodName        = "/manpasik.v1.TelemedicineService/MatchDoctor"
-	TelemedicineService_StartVideoSession_FullMethodName  = "/manpasik.v1.TelemedicineService/StartVideoSession"
-	TelemedicineService_EndVideoSession_FullMethodName    = "/manpasik.v1.TelemedicineService/EndVideoSession"
-	TelemedicineService_RateConsultation_FullMethodName   = "/manpasik.v1.TelemedicineService/RateConsultation"
+	TelemedicineService_CreateConsultation_FullMethodName          = "/manpasik.v1.TelemedicineService/CreateConsultation"
+	TelemedicineService_GetConsultation_FullMethodName             = "/manpasik.v1.TelemedicineService/GetConsultation"
+	TelemedicineService_ListConsultations_FullMethodName           = "/manpasik.v1.TelemedicineService/ListConsultations"
+	TelemedicineService_MatchDoctor_FullMethodName                 = "/manpasik.v1.TelemedicineService/MatchDoctor"
+	TelemedicineService_StartVideoSession_FullMethodName           = "/manpasik.v1.TelemedicineService/StartVideoSession"
+	TelemedicineService_EndVideoSession_FullMethodName             = "/manpasik.v1.TelemedicineService/EndVideoSession"
+	TelemedicineService_RateConsultation_FullMethodName            = "/manpasik.v1.TelemedicineService/RateConsultation"
+	TelemedicineService_AttachHealthReport_FullMethodName          = "/manpasik.v1.TelemedicineService/AttachHealthReport"
+	TelemedicineService_ListConsultationAttachments_FullMethodName = "/manpasik.v1.TelemedicineService/ListConsultationAttachments"
 )
 
 // TelemedicineServiceClient is the client API for TelemedicineService service.
@@ -7793,6 +9927,10 @@ type TelemedicineServiceClient interface {
 	StartVideoSession(ctx context.Context, in *StartVideoSessionRequest, opts ...grpc.CallOption) (*VideoSession, error)
 	EndVideoSession(ctx context.Context, in *EndVideoSessionRequest, opts ...grpc.CallOption) (*VideoSession, error)
 	RateConsultation(ctx context.Context, in *RateConsultationRequest, opts ...grpc.CallOption) (*RateConsultationResponse, error)
+	// 환자의 건강 리포트를 진료에 첨부
+	AttachHealthReport(ctx context.Context, in *AttachHealthReportRequest, opts ...grpc.CallOption) (*ConsultationAttachment, error)
+	// 진료 첨부 리포트 목록 (새 다운로드 링크 포함)
+	ListConsultationAttachments(ctx context.Context, in *ListConsultationAttachmentsRequest, opts ...grpc.CallOption) (*ListConsultationAttachmentsResponse, error)
 }
 
 type telemedicineServiceClient struct {
@@ -7873,6 +10011,26 @@ func (c *telemedicineServiceClient) RateConsultation(ctx context.Context, in *Ra
 	return out, nil
 }
 
+func (c *telemedicineServiceClient) AttachHealthReport(ctx context.Context, in *AttachHealthReportRequest, opts ...grpc.CallOption) (*ConsultationAttachment, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ConsultationAttachment)
+	err := c.cc.Invoke(ctx, TelemedicineService_AttachHealthReport_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *telemedicineServiceClient) ListConsultationAttachments(ctx context.Context, in *ListConsultationAttachmentsRequest, opts ...grpc.CallOption) (*ListConsultationAttachmentsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListConsultationAttachmentsResponse)
+	err := c.cc.Invoke(ctx, TelemedicineService_ListConsultationAttachments_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // TelemedicineServiceServer is the server API for TelemedicineService service.
 // All implementations must embed UnimplementedTelemedicineServiceServer
 // for forward compatibility.
@@ -7884,6 +10042,10 @@ type TelemedicineServiceServer interface {
 	StartVideoSession(context.Context, *StartVideoSessionRequest) (*VideoSession, error)
 	EndVideoSession(context.Context, *EndVideoSessionRequest) (*VideoSession, error)
 	RateConsultation(context.Context, *RateConsultationRequest) (*RateConsultationResponse, error)
+	// 환자의 건강 리포트를 진료에 첨부
+	AttachHealthReport(context.Context, *AttachHealthReportRequest) (*ConsultationAttachment, error)
+	// 진료 첨부 리포트 목록 (새 다운로드 링크 포함)
+	ListConsultationAttachments(context.Context, *ListConsultationAttachmentsRequest) (*ListConsultationAttachmentsResponse, error)
 	mustEmbedUnimplementedTelemedicineServiceServer()
 }
 
@@ -7915,6 +10077,12 @@ func (UnimplementedTelemedicineServiceServer) EndVideoSession(context.Context, *
 func (UnimplementedTelemedicineServiceServer) RateConsultation(context.Context, *RateConsultationRequest) (*RateConsultationResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method RateConsultation not implemented")
 }
+func (UnimplementedTelemedicineServiceServer) AttachHealthReport(context.Context, *AttachHealthReportRequest) (*ConsultationAttachment, error) {
+	return nil, status.Error(codes.Unimplemented, "method AttachHealthReport not implemented")
+}
+func (UnimplementedTelemedicineServiceServer) ListConsultationAttachments(context.Context, *ListConsultationAttachmentsRequest) (*ListConsultationAttachmentsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListConsultationAttachments not implemented")
+}
 func (UnimplementedTelemedicineServiceServer) mustEmbedUnimplementedTelemedicineServiceServer() {}
 func (UnimplementedTelemedicineServiceServer) testEmbeddedByValue()                             {}
 
@@ -8062,6 +10230,42 @@ func _TelemedicineService_RateConsultation_Handler(srv interface{}, ctx context.
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TelemedicineService_AttachHealthReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AttachHealthReportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TelemedicineServiceServer).AttachHealthReport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TelemedicineService_AttachHealthReport_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TelemedicineServiceServer).AttachHealthReport(ctx, req.(*AttachHealthReportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TelemedicineService_ListConsultationAttachments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListConsultationAttachmentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TelemedicineServiceServer).ListConsultationAttachments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TelemedicineService_ListConsultationAttachments_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TelemedicineServiceServer).ListConsultationAttachments(ctx, req.(*ListConsultationAttachmentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // TelemedicineService_ServiceDesc is the grpc.ServiceDesc for TelemedicineService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -8097,6 +10301,158 @@ var TelemedicineService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "RateConsultation",
 			Handler:    _TelemedicineService_RateConsultation_Handler,
 		},
+		{
+			MethodName: "AttachHealthReport",
+			Handler:    _TelemedicineService_AttachHealthReport_Handler,
+		},
+		{
+			MethodName: "ListConsultationAttachments",
+			Handler:    _TelemedicineService_ListConsultationAttachments_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "manpasik.proto",
+}
+
+const (
+	EnvironmentService_SetEnvironmentSharingConsent_FullMethodName = "/manpasik.v1.EnvironmentService/SetEnvironmentSharingConsent"
+	EnvironmentService_SubmitEnvironmentReading_FullMethodName     = "/manpasik.v1.EnvironmentService/SubmitEnvironmentReading"
+)
+
+// EnvironmentServiceClient is the client API for EnvironmentService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type EnvironmentServiceClient interface {
+	// 환경 데이터 공유 동의 설정 (철회 시 기여한 측정값 삭제)
+	SetEnvironmentSharingConsent(ctx context.Context, in *SetEnvironmentSharingConsentRequest, opts ...grpc.CallOption) (*SetEnvironmentSharingConsentResponse, error)
+	// 공유 동의한 사용자의 환경 측정값 제출 (익명화 저장)
+	SubmitEnvironmentReading(ctx context.Context, in *SubmitEnvironmentReadingRequest, opts ...grpc.CallOption) (*SubmitEnvironmentReadingResponse, error)
+}
+
+type environmentServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewEnvironmentServiceClient(cc grpc.ClientConnInterface) EnvironmentServiceClient {
+	return &environmentServiceClient{cc}
+}
+
+func (c *environmentServiceClient) SetEnvironmentSharingConsent(ctx context.Context, in *SetEnvironmentSharingConsentRequest, opts ...grpc.CallOption) (*SetEnvironmentSharingConsentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetEnvironmentSharingConsentResponse)
+	err := c.cc.Invoke(ctx, EnvironmentService_SetEnvironmentSharingConsent_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *environmentServiceClient) SubmitEnvironmentReading(ctx context.Context, in *SubmitEnvironmentReadingRequest, opts ...grpc.CallOption) (*SubmitEnvironmentReadingResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SubmitEnvironmentReadingResponse)
+	err := c.cc.Invoke(ctx, EnvironmentService_SubmitEnvironmentReading_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// EnvironmentServiceServer is the server API for EnvironmentService service.
+// All implementations must embed UnimplementedEnvironmentServiceServer
+// for forward compatibility.
+type EnvironmentServiceServer interface {
+	// 환경 데이터 공유 동의 설정 (철회 시 기여한 측정값 삭제)
+	SetEnvironmentSharingConsent(context.Context, *SetEnvironmentSharingConsentRequest) (*SetEnvironmentSharingConsentResponse, error)
+	// 공유 동의한 사용자의 환경 측정값 제출 (익명화 저장)
+	SubmitEnvironmentReading(context.Context, *SubmitEnvironmentReadingRequest) (*SubmitEnvironmentReadingResponse, error)
+	mustEmbedUnimplementedEnvironmentServiceServer()
+}
+
+// UnimplementedEnvironmentServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedEnvironmentServiceServer struct{}
+
+func (UnimplementedEnvironmentServiceServer) SetEnvironmentSharingConsent(context.Context, *SetEnvironmentSharingConsentRequest) (*SetEnvironmentSharingConsentResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetEnvironmentSharingConsent not implemented")
+}
+func (UnimplementedEnvironmentServiceServer) SubmitEnvironmentReading(context.Context, *SubmitEnvironmentReadingRequest) (*SubmitEnvironmentReadingResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SubmitEnvironmentReading not implemented")
+}
+func (UnimplementedEnvironmentServiceServer) mustEmbedUnimplementedEnvironmentServiceServer() {}
+func (UnimplementedEnvironmentServiceServer) testEmbeddedByValue()                            {}
+
+// UnsafeEnvironmentServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to EnvironmentServiceServer will
+// result in compilation errors.
+type UnsafeEnvironmentServiceServer interface {
+	mustEmbedUnimplementedEnvironmentServiceServer()
+}
+
+func RegisterEnvironmentServiceServer(s grpc.ServiceRegistrar, srv EnvironmentServiceServer) {
+	// If the following call panics, it indicates UnimplementedEnvironmentServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&EnvironmentService_ServiceDesc, srv)
+}
+
+func _EnvironmentService_SetEnvironmentSharingConsent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetEnvironmentSharingConsentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EnvironmentServiceServer).SetEnvironmentSharingConsent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EnvironmentService_SetEnvironmentSharingConsent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EnvironmentServiceServer).SetEnvironmentSharingConsent(ctx, req.(*SetEnvironmentSharingConsentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EnvironmentService_SubmitEnvironmentReading_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubmitEnvironmentReadingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EnvironmentServiceServer).SubmitEnvironmentReading(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EnvironmentService_SubmitEnvironmentReading_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EnvironmentServiceServer).SubmitEnvironmentReading(ctx, req.(*SubmitEnvironmentReadingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// EnvironmentService_ServiceDesc is the grpc.ServiceDesc for EnvironmentService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var EnvironmentService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "manpasik.v1.EnvironmentService",
+	HandlerType: (*EnvironmentServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "SetEnvironmentSharingConsent",
+			Handler:    _EnvironmentService_SetEnvironmentSharingConsent_Handler,
+		},
+		{
+			MethodName: "SubmitEnvironmentReading",
+			Handler:    _EnvironmentService_SubmitEnvironmentReading_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "manpasik.proto",
@@ -146,6 +146,31 @@ func TestRBACInterceptor_NoRoleInContext(t *testing.T) {
 	}
 }
 
+func TestRBACConfig_IsAllowed(t *testing.T) {
+	config := &RBACConfig{
+		MethodRoles: map[string][]string{
+			"/test/Manage": {"owner", "manager"},
+		},
+	}
+
+	if !config.IsAllowed("/test/Manage", "manager") {
+		t.Error("listed role should be allowed")
+	}
+	if config.IsAllowed("/test/Manage", "viewer") {
+		t.Error("unlisted role should be denied")
+	}
+	if !config.IsAllowed("/test/Manage", RoleAdmin) {
+		t.Error("admin should always be allowed")
+	}
+	if !config.IsAllowed("/test/Open", "viewer") {
+		t.Error("unrestricted method should be allowed")
+	}
+	var nilConfig *RBACConfig
+	if !nilConfig.IsAllowed("/test/Manage", "viewer") {
+		t.Error("nil config should allow everything")
+	}
+}
+
 // --- Rate Limiter Tests ---
 
 func TestRateLimiter_Allow(t *testing.T) {
@@ -24,6 +24,28 @@ type RBACConfig struct {
 	MethodRoles map[string][]string
 }
 
+// IsAllowed reports whether role may call method.
+// Unrestricted methods are open to any role, and admin is always allowed.
+// Services also use it directly for resource-scoped roles (e.g. device group roles).
+func (c *RBACConfig) IsAllowed(method, role string) bool {
+	if c == nil {
+		return true
+	}
+	allowedRoles, restricted := c.MethodRoles[method]
+	if !restricted {
+		return true
+	}
+	if role == RoleAdmin {
+		return true
+	}
+	for _, allowed := range allowedRoles {
+		if role == allowed {
+			return true
+		}
+	}
+	return false
+}
+
 // RBACInterceptor creates a role-based access control interceptor
 func RBACInterceptor(config *RBACConfig) grpc.UnaryServerInterceptor {
 	return func(
@@ -37,8 +59,7 @@ func RBACInterceptor(config *RBACConfig) grpc.UnaryServerInterceptor {
 			return handler(ctx, req)
 		}
 
-		allowedRoles, restricted := config.MethodRoles[info.FullMethod]
-		if !restricted {
+		if _, restricted := config.MethodRoles[info.FullMethod]; !restricted {
 			return handler(ctx, req)
 		}
 
@@ -48,18 +69,10 @@ func RBACInterceptor(config *RBACConfig) grpc.UnaryServerInterceptor {
 			return nil, status.Error(codes.PermissionDenied, "역할 정보 없음")
 		}
 
-		// Admin always has access
-		if role == RoleAdmin {
+		if config.IsAllowed(info.FullMethod, role) {
 			return handler(ctx, req)
 		}
 
-		// Check if role is in allowed list
-		for _, allowed := range allowedRoles {
-			if role == allowed {
-				return handler(ctx, req)
-			}
-		}
-
 		return nil, status.Errorf(codes.PermissionDenied, "역할 '%s'은(는) 이 작업에 대한 권한이 없습니다", role)
 	}
 }
@@ -76,8 +89,7 @@ func RBACStreamInterceptor(config *RBACConfig) grpc.StreamServerInterceptor {
 			return handler(srv, ss)
 		}
 
-		allowedRoles, restricted := config.MethodRoles[info.FullMethod]
-		if !restricted {
+		if _, restricted := config.MethodRoles[info.FullMethod]; !restricted {
 			return handler(srv, ss)
 		}
 
@@ -86,16 +98,10 @@ func RBACStreamInterceptor(config *RBACConfig) grpc.StreamServerInterceptor {
 			return status.Error(codes.PermissionDenied, "역할 정보 없음")
 		}
 
-		if role == RoleAdmin {
+		if config.IsAllowed(info.FullMethod, role) {
 			return handler(srv, ss)
 		}
 
-		for _, allowed := range allowedRoles {
-			if role == allowed {
-				return handler(srv, ss)
-			}
-		}
-
 		return status.Errorf(codes.PermissionDenied, "역할 '%s'은(는) 이 작업에 대한 권한이 없습니다", role)
 	}
 }
@@ -122,6 +128,10 @@ func DefaultRBACConfig() *RBACConfig {
 			"/manpasik.v1.TelemedicineService/UpdatePrescription": medicalOrAdmin,
 			"/manpasik.v1.TelemedicineService/EndConsultation":    medicalOrAdmin,
 
+			// AI Inference — model registry management is admin only
+			"/manpasik.v1.AiInferenceService/RegisterModelVersion": adminOnly,
+			"/manpasik.v1.AiInferenceService/PromoteModelVersion":  adminOnly,
+
 			// Translation — admin only for management
 			"/manpasik.v1.TranslationService/UpdateTranslation":  adminOnly,
 			"/manpasik.v1.TranslationService/BulkImport":         adminOnly,
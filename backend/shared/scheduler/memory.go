@@ -0,0 +1,178 @@
+package scheduler
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+// run states recorded per occurrence
+const (
+	runClaimed = "claimed"
+	runDone    = "done"
+	runFailed  = "failed"
+)
+
+type occurrence struct {
+	scheduleID string
+	fireAt     int64
+}
+
+type runState struct {
+	worker     string
+	status     string
+	attempts   int
+	leaseUntil time.Time
+	lastError  string
+}
+
+// MemoryStore is an in-process Store. Claims are atomic among schedulers sharing the
+// same MemoryStore, which makes it suitable for single-replica deployments and tests.
+type MemoryStore struct {
+	mu        sync.Mutex
+	schedules map[string]*Schedule
+	runs      map[occurrence]*runState
+}
+
+// NewMemoryStore creates an empty in-memory store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		schedules: make(map[string]*Schedule),
+		runs:      make(map[occurrence]*runState),
+	}
+}
+
+func cloneSchedule(s *Schedule) *Schedule {
+	cp := *s
+	cp.TimesOfDay = append([]string(nil), s.TimesOfDay...)
+	if s.Payload != nil {
+		cp.Payload = make(map[string]string, len(s.Payload))
+		for k, v := range s.Payload {
+			cp.Payload[k] = v
+		}
+	}
+	if s.LastRunAt != nil {
+		last := *s.LastRunAt
+		cp.LastRunAt = &last
+	}
+	return &cp
+}
+
+// Save inserts or replaces a schedule
+func (m *MemoryStore) Save(_ context.Context, s *Schedule) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.schedules[s.ID] = cloneSchedule(s)
+	return nil
+}
+
+// Get returns the schedule, or (nil, nil) when it does not exist
+func (m *MemoryStore) Get(_ context.Context, id string) (*Schedule, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	s, ok := m.schedules[id]
+	if !ok {
+		return nil, nil
+	}
+	return cloneSchedule(s), nil
+}
+
+// Delete removes a schedule and its run history
+func (m *MemoryStore) Delete(_ context.Context, id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.schedules, id)
+	for occ := range m.runs {
+		if occ.scheduleID == id {
+			delete(m.runs, occ)
+		}
+	}
+	return nil
+}
+
+// ListByUser returns the user's schedules ordered by ID
+func (m *MemoryStore) ListByUser(_ context.Context, userID string) ([]*Schedule, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var out []*Schedule
+	for _, s := range m.schedules {
+		if s.UserID == userID {
+			out = append(out, cloneSchedule(s))
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+	return out, nil
+}
+
+// ListDue returns due schedules of the given kinds, oldest NextRunAt first
+func (m *MemoryStore) ListDue(_ context.Context, kinds []string, now time.Time, limit int) ([]*Schedule, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	wanted := make(map[string]bool, len(kinds))
+	for _, k := range kinds {
+		wanted[k] = true
+	}
+	var out []*Schedule
+	for _, s := range m.schedules {
+		if wanted[s.Kind] && !s.NextRunAt.IsZero() && !s.NextRunAt.After(now) {
+			out = append(out, cloneSchedule(s))
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].NextRunAt.Equal(out[j].NextRunAt) {
+			return out[i].ID < out[j].ID
+		}
+		return out[i].NextRunAt.Before(out[j].NextRunAt)
+	})
+	if limit > 0 && len(out) > limit {
+		out = out[:limit]
+	}
+	return out, nil
+}
+
+// Claim takes the occurrence unless it is done or leased by another live worker
+func (m *MemoryStore) Claim(_ context.Context, scheduleID string, fireAt time.Time, worker string, now, leaseUntil time.Time) (int, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	occ := occurrence{scheduleID: scheduleID, fireAt: fireAt.UnixNano()}
+	st, ok := m.runs[occ]
+	if !ok {
+		st = &runState{}
+		m.runs[occ] = st
+	} else if st.status == runDone || (st.status == runClaimed && st.leaseUntil.After(now)) {
+		return 0, false, nil
+	}
+	st.worker = worker
+	st.status = runClaimed
+	st.attempts++
+	st.leaseUntil = leaseUntil
+	return st.attempts, true, nil
+}
+
+// Complete marks worker's claim done and advances the schedule if it still points at fireAt
+func (m *MemoryStore) Complete(_ context.Context, next *Schedule, fireAt time.Time, worker string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	st, ok := m.runs[occurrence{scheduleID: next.ID, fireAt: fireAt.UnixNano()}]
+	if !ok || st.status != runClaimed || st.worker != worker {
+		return false, nil
+	}
+	st.status = runDone
+	if cur, ok := m.schedules[next.ID]; ok && cur.NextRunAt.Equal(fireAt) {
+		m.schedules[next.ID] = cloneSchedule(next)
+	}
+	return true, nil
+}
+
+// Release makes worker's claim on the occurrence claimable again
+func (m *MemoryStore) Release(_ context.Context, scheduleID string, fireAt time.Time, worker string, _ time.Time, cause string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if st, ok := m.runs[occurrence{scheduleID: scheduleID, fireAt: fireAt.UnixNano()}]; ok && st.status == runClaimed && st.worker == worker {
+		st.status = runFailed
+		st.leaseUntil = time.Time{}
+		st.lastError = cause
+	}
+	return nil
+}
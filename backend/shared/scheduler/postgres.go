@@ -0,0 +1,173 @@
+package scheduler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PostgresStore is a Store shared by all replicas through the schedules and
+// schedule_runs tables. Claims rely on the schedule_runs primary key, so two
+// replicas can never both hold a live claim on the same occurrence.
+type PostgresStore struct {
+	pool *pgxpool.Pool
+}
+
+// NewPostgresStore creates a PostgreSQL-backed store
+func NewPostgresStore(pool *pgxpool.Pool) *PostgresStore {
+	return &PostgresStore{pool: pool}
+}
+
+const scheduleColumns = `id, kind, user_id, times_of_day, cron, timezone, quiet_hours_start, quiet_hours_end,
+	payload, next_slot_at, next_run_at, last_run_at, created_at, updated_at`
+
+// Save inserts or replaces a schedule
+func (p *PostgresStore) Save(ctx context.Context, s *Schedule) error {
+	payload, err := json.Marshal(s.Payload)
+	if err != nil {
+		return err
+	}
+	const q = `INSERT INTO schedules (` + scheduleColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+		ON CONFLICT (id) DO UPDATE SET
+			kind = EXCLUDED.kind, user_id = EXCLUDED.user_id, times_of_day = EXCLUDED.times_of_day,
+			cron = EXCLUDED.cron, timezone = EXCLUDED.timezone,
+			quiet_hours_start = EXCLUDED.quiet_hours_start, quiet_hours_end = EXCLUDED.quiet_hours_end,
+			payload = EXCLUDED.payload, next_slot_at = EXCLUDED.next_slot_at, next_run_at = EXCLUDED.next_run_at,
+			last_run_at = EXCLUDED.last_run_at, updated_at = EXCLUDED.updated_at`
+	_, err = p.pool.Exec(ctx, q,
+		s.ID, s.Kind, s.UserID, s.TimesOfDay, s.Cron, s.Timezone, s.QuietHoursStart, s.QuietHoursEnd,
+		payload, s.NextSlotAt, s.NextRunAt, s.LastRunAt, s.CreatedAt, s.UpdatedAt,
+	)
+	return err
+}
+
+// Get returns the schedule, or (nil, nil) when it does not exist
+func (p *PostgresStore) Get(ctx context.Context, id string) (*Schedule, error) {
+	const q = `SELECT ` + scheduleColumns + ` FROM schedules WHERE id = $1`
+	s, err := scanSchedule(p.pool.QueryRow(ctx, q, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	return s, err
+}
+
+// Delete removes a schedule; its run history is removed by cascade
+func (p *PostgresStore) Delete(ctx context.Context, id string) error {
+	_, err := p.pool.Exec(ctx, `DELETE FROM schedules WHERE id = $1`, id)
+	return err
+}
+
+// ListByUser returns the user's schedules ordered by ID
+func (p *PostgresStore) ListByUser(ctx context.Context, userID string) ([]*Schedule, error) {
+	const q = `SELECT ` + scheduleColumns + ` FROM schedules WHERE user_id = $1 ORDER BY id`
+	return p.query(ctx, q, userID)
+}
+
+// ListDue returns due schedules of the given kinds, oldest next_run_at first
+func (p *PostgresStore) ListDue(ctx context.Context, kinds []string, now time.Time, limit int) ([]*Schedule, error) {
+	const q = `SELECT ` + scheduleColumns + ` FROM schedules
+		WHERE kind = ANY($1) AND next_run_at <= $2
+		ORDER BY next_run_at, id LIMIT $3`
+	return p.query(ctx, q, kinds, now, limit)
+}
+
+// Claim inserts the occurrence row, or re-takes it when it failed or its lease expired
+func (p *PostgresStore) Claim(ctx context.Context, scheduleID string, fireAt time.Time, worker string, now, leaseUntil time.Time) (int, bool, error) {
+	const q = `INSERT INTO schedule_runs (schedule_id, occurrence_at, worker, status, attempts, lease_until, updated_at)
+		VALUES ($1, $2, $3, 'claimed', 1, $4, $5)
+		ON CONFLICT (schedule_id, occurrence_at) DO UPDATE SET
+			worker = EXCLUDED.worker, status = 'claimed', attempts = schedule_runs.attempts + 1,
+			lease_until = EXCLUDED.lease_until, updated_at = EXCLUDED.updated_at
+		WHERE schedule_runs.status = 'failed'
+		   OR (schedule_runs.status = 'claimed' AND schedule_runs.lease_until <= $5)
+		RETURNING attempts`
+	var attempts int
+	err := p.pool.QueryRow(ctx, q, scheduleID, fireAt, worker, leaseUntil, now).Scan(&attempts)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	return attempts, true, nil
+}
+
+// Complete marks worker's claim done and advances the schedule if it still points at fireAt
+func (p *PostgresStore) Complete(ctx context.Context, next *Schedule, fireAt time.Time, worker string) (bool, error) {
+	tx, err := p.pool.Begin(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback(ctx)
+
+	tag, err := tx.Exec(ctx,
+		`UPDATE schedule_runs SET status = 'done', updated_at = $4
+		 WHERE schedule_id = $1 AND occurrence_at = $2 AND worker = $3 AND status = 'claimed'`,
+		next.ID, fireAt, worker, next.UpdatedAt,
+	)
+	if err != nil {
+		return false, err
+	}
+	if tag.RowsAffected() == 0 {
+		return false, nil
+	}
+	const q = `UPDATE schedules SET timezone = $3, quiet_hours_start = $4, quiet_hours_end = $5,
+			next_slot_at = $6, next_run_at = $7, last_run_at = $8, updated_at = $9
+		WHERE id = $1 AND next_run_at = $2`
+	if _, err := tx.Exec(ctx, q,
+		next.ID, fireAt, next.Timezone, next.QuietHoursStart, next.QuietHoursEnd,
+		next.NextSlotAt, next.NextRunAt, next.LastRunAt, next.UpdatedAt,
+	); err != nil {
+		return false, err
+	}
+	return true, tx.Commit(ctx)
+}
+
+// Release makes worker's claim on the occurrence claimable again
+func (p *PostgresStore) Release(ctx context.Context, scheduleID string, fireAt time.Time, worker string, now time.Time, cause string) error {
+	_, err := p.pool.Exec(ctx,
+		`UPDATE schedule_runs SET status = 'failed', error = $4, updated_at = $5
+		 WHERE schedule_id = $1 AND occurrence_at = $2 AND worker = $3 AND status = 'claimed'`,
+		scheduleID, fireAt, worker, cause, now,
+	)
+	return err
+}
+
+func (p *PostgresStore) query(ctx context.Context, q string, args ...interface{}) ([]*Schedule, error) {
+	rows, err := p.pool.Query(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var out []*Schedule
+	for rows.Next() {
+		s, err := scanSchedule(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, s)
+	}
+	return out, rows.Err()
+}
+
+func scanSchedule(row pgx.Row) (*Schedule, error) {
+	var s Schedule
+	var payload []byte
+	if err := row.Scan(
+		&s.ID, &s.Kind, &s.UserID, &s.TimesOfDay, &s.Cron, &s.Timezone, &s.QuietHoursStart, &s.QuietHoursEnd,
+		&payload, &s.NextSlotAt, &s.NextRunAt, &s.LastRunAt, &s.CreatedAt, &s.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+	if len(payload) > 0 {
+		if err := json.Unmarshal(payload, &s.Payload); err != nil {
+			return nil, err
+		}
+	}
+	return &s, nil
+}
@@ -0,0 +1,364 @@
+// Package scheduler delivers per-user recurring jobs at the user's local time.
+//
+// A Schedule fires at "HH:MM" times of day or on a 5-field cron expression evaluated in the
+// user's IANA timezone. Slots that fall inside the user's quiet hours are deferred to the end
+// of the window. Schedules live in a shared Store; before running an occurrence a replica
+// claims it with a lease, so each occurrence is handled by exactly one replica, and it is
+// retried by another replica only when the claiming one fails or dies before completing it.
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	apperrors "github.com/manpasik/backend/shared/errors"
+)
+
+// Default tuning values
+const (
+	DefaultLease       = 2 * time.Minute
+	DefaultInterval    = 30 * time.Second
+	DefaultBatchSize   = 100
+	DefaultMaxAttempts = 3
+)
+
+// Schedule is a recurring per-user job
+type Schedule struct {
+	ID              string // unique, e.g. "coaching.daily_tip:<user_id>"
+	Kind            string // selects the Handler
+	UserID          string
+	TimesOfDay      []string // "HH:MM" local times, every day; ignored when Cron is set
+	Cron            string   // "min hour dom month dow" in local time
+	Timezone        string   // IANA name such as "Asia/Seoul"; empty means UTC
+	QuietHoursStart string   // "HH:MM" local, empty for none
+	QuietHoursEnd   string
+	Payload         map[string]string
+	NextSlotAt      time.Time // next scheduled slot
+	NextRunAt       time.Time // next delivery time (NextSlotAt deferred past quiet hours)
+	LastRunAt       *time.Time
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+// Run is one occurrence of a schedule handed to a Handler
+type Run struct {
+	Schedule *Schedule
+	SlotAt   time.Time // scheduled slot
+	FireAt   time.Time // delivery time, later than SlotAt when deferred by quiet hours
+	Local    time.Time // SlotAt in the schedule's timezone
+	Attempt  int       // 1 for the first delivery attempt
+}
+
+// Key identifies the occurrence. Downstream systems can use it as an idempotency key.
+func (r Run) Key() string {
+	return r.Schedule.ID + "@" + r.FireAt.UTC().Format(time.RFC3339)
+}
+
+// Handler runs an occurrence. Returning an error releases the claim so the occurrence is
+// retried on a later tick, up to the scheduler's max attempts. ctx expires at two thirds of
+// the claim lease; a handler must stop delivering once it is done, because after the lease
+// another replica may claim the same occurrence.
+type Handler func(ctx context.Context, run Run) error
+
+// Settings are the user preferences that affect delivery time
+type Settings struct {
+	Timezone        string // empty when unknown
+	QuietHoursStart string
+	QuietHoursEnd   string
+	HasQuietHours   bool // the quiet hours were looked up; empty times then mean none
+}
+
+// SettingsSource resolves a user's current timezone and quiet hours. Each time a schedule's
+// next run is computed, a non-empty Timezone and looked-up quiet hours replace the values
+// stored on the schedule.
+type SettingsSource interface {
+	UserSettings(ctx context.Context, userID string) (Settings, error)
+}
+
+// Store persists schedules and occurrence claims. Implementations must make Claim atomic
+// across replicas.
+type Store interface {
+	// Save inserts or replaces a schedule
+	Save(ctx context.Context, s *Schedule) error
+	// Get returns the schedule, or (nil, nil) when it does not exist
+	Get(ctx context.Context, id string) (*Schedule, error)
+	Delete(ctx context.Context, id string) error
+	ListByUser(ctx context.Context, userID string) ([]*Schedule, error)
+	// ListDue returns schedules of the given kinds whose NextRunAt is at or before now, oldest first
+	ListDue(ctx context.Context, kinds []string, now time.Time, limit int) ([]*Schedule, error)
+	// Claim takes the occurrence (scheduleID, fireAt) for worker until leaseUntil. It fails when
+	// the occurrence is already done or leased by another worker whose lease has not expired.
+	// attempt counts claims of the occurrence, including this one.
+	Claim(ctx context.Context, scheduleID string, fireAt time.Time, worker string, now, leaseUntil time.Time) (attempt int, ok bool, err error)
+	// Complete marks the occurrence done and, if the stored schedule still points at fireAt,
+	// stores next (its advanced NextSlotAt/NextRunAt and resolved settings). It changes nothing
+	// and returns false when worker no longer holds the claim (the lease expired and another
+	// worker re-claimed the occurrence).
+	Complete(ctx context.Context, next *Schedule, fireAt time.Time, worker string) (bool, error)
+	// Release gives up worker's failed claim so the occurrence can be claimed again
+	Release(ctx context.Context, scheduleID string, fireAt time.Time, worker string, now time.Time, cause string) error
+}
+
+// Option configures a Scheduler
+type Option func(*Scheduler)
+
+// WithSettings resolves timezone and quiet hours from user preferences
+func WithSettings(src SettingsSource) Option {
+	return func(s *Scheduler) { s.settings = src }
+}
+
+// WithWorkerID sets the replica identity recorded on claims (default: hostname and pid)
+func WithWorkerID(id string) Option {
+	return func(s *Scheduler) {
+		if id != "" {
+			s.worker = id
+		}
+	}
+}
+
+// WithLease sets how long a claim is held before another replica may retry it
+func WithLease(d time.Duration) Option {
+	return func(s *Scheduler) {
+		if d > 0 {
+			s.lease = d
+		}
+	}
+}
+
+// WithInterval sets how often Start polls for due schedules
+func WithInterval(d time.Duration) Option {
+	return func(s *Scheduler) {
+		if d > 0 {
+			s.interval = d
+		}
+	}
+}
+
+// WithMaxAttempts sets how many times a failing occurrence is tried before it is skipped
+func WithMaxAttempts(n int) Option {
+	return func(s *Scheduler) {
+		if n > 0 {
+			s.maxAttempts = n
+		}
+	}
+}
+
+// WithClock overrides the time source (tests)
+func WithClock(now func() time.Time) Option {
+	return func(s *Scheduler) { s.now = now }
+}
+
+// Scheduler computes next runs and delivers due occurrences to registered handlers
+type Scheduler struct {
+	store       Store
+	settings    SettingsSource
+	worker      string
+	lease       time.Duration
+	interval    time.Duration
+	batch       int
+	maxAttempts int
+	now         func() time.Time
+
+	mu       sync.RWMutex
+	handlers map[string]Handler
+}
+
+// New creates a scheduler over store
+func New(store Store, opts ...Option) *Scheduler {
+	host, _ := os.Hostname()
+	s := &Scheduler{
+		store:       store,
+		worker:      fmt.Sprintf("%s-%d", host, os.Getpid()),
+		lease:       DefaultLease,
+		interval:    DefaultInterval,
+		batch:       DefaultBatchSize,
+		maxAttempts: DefaultMaxAttempts,
+		now:         time.Now,
+		handlers:    make(map[string]Handler),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Handle registers the handler for a schedule kind. Only registered kinds are polled,
+// so services sharing one Store each run only their own schedules.
+func (s *Scheduler) Handle(kind string, h Handler) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.handlers[kind] = h
+}
+
+// Put validates the schedule, resolves the user's settings, computes its next run and
+// saves it, replacing any schedule with the same ID.
+func (s *Scheduler) Put(ctx context.Context, sched *Schedule) (*Schedule, error) {
+	if sched == nil || sched.ID == "" || sched.Kind == "" || sched.UserID == "" {
+		return nil, apperrors.New(apperrors.ErrInvalidInput, "schedule id, kind and user_id are required")
+	}
+	if _, err := parseSpec(sched.Cron, sched.TimesOfDay); err != nil {
+		return nil, apperrors.New(apperrors.ErrInvalidInput, err.Error())
+	}
+	now := s.now().UTC()
+	if existing, err := s.store.Get(ctx, sched.ID); err != nil {
+		return nil, err
+	} else if existing != nil {
+		sched.CreatedAt = existing.CreatedAt
+		sched.LastRunAt = existing.LastRunAt
+	}
+	if sched.CreatedAt.IsZero() {
+		sched.CreatedAt = now
+	}
+	sched.UpdatedAt = now
+	if err := s.advance(ctx, sched, now); err != nil {
+		return nil, err
+	}
+	if err := s.store.Save(ctx, sched); err != nil {
+		return nil, err
+	}
+	return sched, nil
+}
+
+// Remove deletes a schedule; removing a missing schedule is not an error
+func (s *Scheduler) Remove(ctx context.Context, id string) error {
+	return s.store.Delete(ctx, id)
+}
+
+// Get returns a schedule, or nil when it does not exist
+func (s *Scheduler) Get(ctx context.Context, id string) (*Schedule, error) {
+	return s.store.Get(ctx, id)
+}
+
+// Start polls for due schedules every interval until ctx is cancelled
+func (s *Scheduler) Start(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		if _, err := s.Tick(ctx); err != nil && ctx.Err() == nil {
+			log.Printf("[scheduler] tick failed: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Tick delivers every due occurrence this replica can claim and returns how many handlers succeeded
+func (s *Scheduler) Tick(ctx context.Context) (int, error) {
+	s.mu.RLock()
+	kinds := make([]string, 0, len(s.handlers))
+	for k := range s.handlers {
+		kinds = append(kinds, k)
+	}
+	s.mu.RUnlock()
+	if len(kinds) == 0 {
+		return 0, nil
+	}
+
+	now := s.now().UTC()
+	due, err := s.store.ListDue(ctx, kinds, now, s.batch)
+	if err != nil {
+		return 0, err
+	}
+	delivered := 0
+	for _, sched := range due {
+		ok, err := s.runOnce(ctx, sched, now)
+		if err != nil {
+			return delivered, err
+		}
+		if ok {
+			delivered++
+		}
+	}
+	return delivered, nil
+}
+
+// runOnce claims and runs a single due occurrence
+func (s *Scheduler) runOnce(ctx context.Context, sched *Schedule, now time.Time) (bool, error) {
+	s.mu.RLock()
+	handler := s.handlers[sched.Kind]
+	s.mu.RUnlock()
+
+	fireAt := sched.NextRunAt
+	attempt, ok, err := s.store.Claim(ctx, sched.ID, fireAt, s.worker, now, now.Add(s.lease))
+	if err != nil || !ok {
+		return false, err
+	}
+
+	// The handler must finish well inside the lease so no other replica re-claims the
+	// occurrence while it is still being delivered.
+	run := Run{Schedule: sched, SlotAt: sched.NextSlotAt, FireAt: fireAt, Local: sched.NextSlotAt.In(location(sched.Timezone)), Attempt: attempt}
+	runCtx, cancel := context.WithTimeout(ctx, s.lease*2/3)
+	runErr := handler(runCtx, run)
+	cancel()
+	if runErr != nil && attempt < s.maxAttempts {
+		log.Printf("[scheduler] %s attempt %d failed, will retry: %v", run.Key(), attempt, runErr)
+		return false, s.store.Release(ctx, sched.ID, fireAt, s.worker, now, runErr.Error())
+	}
+	if runErr != nil {
+		log.Printf("[scheduler] %s failed after %d attempts, skipping: %v", run.Key(), attempt, runErr)
+	}
+
+	next := *sched
+	last := fireAt
+	next.LastRunAt = &last
+	next.UpdatedAt = now
+	if err := s.advance(ctx, &next, now); err != nil {
+		return false, err
+	}
+	owned, err := s.store.Complete(ctx, &next, fireAt, s.worker)
+	if err != nil {
+		return false, err
+	}
+	if !owned {
+		log.Printf("[scheduler] %s claim lost to another worker after the lease expired", run.Key())
+		return false, nil
+	}
+	return runErr == nil, nil
+}
+
+// advance refreshes the user's settings and sets the first slot after `after`
+func (s *Scheduler) advance(ctx context.Context, sched *Schedule, after time.Time) error {
+	if s.settings != nil {
+		if st, err := s.settings.UserSettings(ctx, sched.UserID); err != nil {
+			log.Printf("[scheduler] settings for user %s unavailable, keeping stored values: %v", sched.UserID, err)
+		} else {
+			if st.Timezone != "" {
+				sched.Timezone = st.Timezone
+			}
+			if st.HasQuietHours {
+				sched.QuietHoursStart, sched.QuietHoursEnd = st.QuietHoursStart, st.QuietHoursEnd
+			}
+		}
+	}
+	sp, err := parseSpec(sched.Cron, sched.TimesOfDay)
+	if err != nil {
+		return apperrors.New(apperrors.ErrInvalidInput, err.Error())
+	}
+	slot := sp.next(after, location(sched.Timezone))
+	if slot.IsZero() {
+		return apperrors.New(apperrors.ErrInvalidInput, "schedule never fires")
+	}
+	sched.NextSlotAt = slot.UTC()
+	sched.NextRunAt = parseQuietHours(sched.QuietHoursStart, sched.QuietHoursEnd).apply(slot).UTC()
+	return nil
+}
+
+// location loads an IANA timezone, falling back to UTC for empty or unknown names
+func location(name string) *time.Location {
+	if strings.TrimSpace(name) == "" {
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
@@ -0,0 +1,264 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) Set(t time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = t
+}
+
+type staticSettings map[string]Settings
+
+func (s staticSettings) UserSettings(_ context.Context, userID string) (Settings, error) {
+	return s[userID], nil
+}
+
+func TestScheduler_PutUsesUserTimezoneAndQuietHours(t *testing.T) {
+	seoul := mustLoad(t, "Asia/Seoul")
+	clock := &fakeClock{now: time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)} // 09:00 KST
+	s := New(NewMemoryStore(), WithClock(clock.Now), WithSettings(staticSettings{
+		"user-1": {Timezone: "Asia/Seoul", QuietHoursStart: "22:00", QuietHoursEnd: "07:00", HasQuietHours: true},
+	}))
+
+	sched, err := s.Put(context.Background(), &Schedule{ID: "tip:user-1", Kind: "tip", UserID: "user-1", TimesOfDay: []string{"23:00"}})
+	if err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+	if sched.Timezone != "Asia/Seoul" {
+		t.Errorf("Timezone = %q, want Asia/Seoul", sched.Timezone)
+	}
+	if want := time.Date(2026, 3, 2, 23, 0, 0, 0, seoul); !sched.NextSlotAt.Equal(want) {
+		t.Errorf("NextSlotAt = %v, want %v", sched.NextSlotAt, want)
+	}
+	if want := time.Date(2026, 3, 3, 7, 0, 0, 0, seoul); !sched.NextRunAt.Equal(want) {
+		t.Errorf("NextRunAt = %v, want %v (deferred past quiet hours)", sched.NextRunAt, want)
+	}
+}
+
+func TestScheduler_PutRejectsInvalidSchedule(t *testing.T) {
+	s := New(NewMemoryStore())
+	for _, sched := range []*Schedule{
+		{Kind: "tip", UserID: "user-1", TimesOfDay: []string{"08:00"}},
+		{ID: "tip:user-1", Kind: "tip", UserID: "user-1", TimesOfDay: []string{"8am"}},
+		{ID: "tip:user-1", Kind: "tip", UserID: "user-1", Cron: "0 0 31 2 *"},
+	} {
+		if _, err := s.Put(context.Background(), sched); err == nil {
+			t.Errorf("Put(%+v) error = nil, want error", sched)
+		}
+	}
+}
+
+func TestScheduler_TickDeliversOnceAcrossReplicas(t *testing.T) {
+	store := NewMemoryStore()
+	clock := &fakeClock{now: time.Date(2026, 3, 2, 7, 0, 0, 0, time.UTC)}
+	var calls int32
+	handler := func(_ context.Context, run Run) error {
+		atomic.AddInt32(&calls, 1)
+		if run.Local.Hour() != 8 {
+			t.Errorf("run.Local = %v, want 08:00 local", run.Local)
+		}
+		return nil
+	}
+
+	replicas := make([]*Scheduler, 3)
+	for i := range replicas {
+		replicas[i] = New(store, WithClock(clock.Now), WithWorkerID(string(rune('a'+i))))
+		replicas[i].Handle("tip", handler)
+	}
+	if _, err := replicas[0].Put(context.Background(), &Schedule{ID: "tip:user-1", Kind: "tip", UserID: "user-1", TimesOfDay: []string{"08:00"}}); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+
+	clock.Set(time.Date(2026, 3, 2, 8, 0, 30, 0, time.UTC))
+	var wg sync.WaitGroup
+	for _, r := range replicas {
+		wg.Add(1)
+		go func(r *Scheduler) {
+			defer wg.Done()
+			if _, err := r.Tick(context.Background()); err != nil {
+				t.Errorf("Tick() error = %v", err)
+			}
+		}(r)
+	}
+	wg.Wait()
+	if calls != 1 {
+		t.Fatalf("handler calls = %d, want 1", calls)
+	}
+
+	sched, _ := store.Get(context.Background(), "tip:user-1")
+	if want := time.Date(2026, 3, 3, 8, 0, 0, 0, time.UTC); !sched.NextRunAt.Equal(want) {
+		t.Errorf("NextRunAt = %v, want %v", sched.NextRunAt, want)
+	}
+	if sched.LastRunAt == nil {
+		t.Error("LastRunAt = nil, want the delivered occurrence")
+	}
+}
+
+func TestScheduler_TickRetriesFailedRunThenSkips(t *testing.T) {
+	store := NewMemoryStore()
+	clock := &fakeClock{now: time.Date(2026, 3, 2, 7, 0, 0, 0, time.UTC)}
+	s := New(store, WithClock(clock.Now), WithMaxAttempts(2))
+	var attempts []int
+	s.Handle("tip", func(_ context.Context, run Run) error {
+		attempts = append(attempts, run.Attempt)
+		return errors.New("notification-service unavailable")
+	})
+	if _, err := s.Put(context.Background(), &Schedule{ID: "tip:user-1", Kind: "tip", UserID: "user-1", TimesOfDay: []string{"08:00"}}); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+
+	clock.Set(time.Date(2026, 3, 2, 8, 0, 0, 0, time.UTC))
+	for i := 0; i < 3; i++ {
+		if _, err := s.Tick(context.Background()); err != nil {
+			t.Fatalf("Tick() error = %v", err)
+		}
+	}
+	if len(attempts) != 2 || attempts[0] != 1 || attempts[1] != 2 {
+		t.Fatalf("attempts = %v, want [1 2]", attempts)
+	}
+	sched, _ := store.Get(context.Background(), "tip:user-1")
+	if want := time.Date(2026, 3, 3, 8, 0, 0, 0, time.UTC); !sched.NextRunAt.Equal(want) {
+		t.Errorf("NextRunAt = %v, want %v after giving up", sched.NextRunAt, want)
+	}
+}
+
+func TestScheduler_ExpiredLeaseIsReclaimed(t *testing.T) {
+	store := NewMemoryStore()
+	fireAt := time.Date(2026, 3, 2, 8, 0, 0, 0, time.UTC)
+	now := fireAt
+
+	if _, ok, _ := store.Claim(context.Background(), "tip:user-1", fireAt, "a", now, now.Add(time.Minute)); !ok {
+		t.Fatal("first claim failed")
+	}
+	if _, ok, _ := store.Claim(context.Background(), "tip:user-1", fireAt, "b", now.Add(30*time.Second), now.Add(2*time.Minute)); ok {
+		t.Fatal("claim during a live lease succeeded")
+	}
+	attempt, ok, _ := store.Claim(context.Background(), "tip:user-1", fireAt, "b", now.Add(2*time.Minute), now.Add(4*time.Minute))
+	if !ok || attempt != 2 {
+		t.Fatalf("claim after lease expiry = (%d, %v), want (2, true)", attempt, ok)
+	}
+}
+
+func TestScheduler_HandlerDeadlineIsInsideLease(t *testing.T) {
+	store := NewMemoryStore()
+	clock := &fakeClock{now: time.Date(2026, 3, 2, 7, 0, 0, 0, time.UTC)}
+	lease := 90 * time.Millisecond
+	a := New(store, WithClock(clock.Now), WithWorkerID("a"), WithLease(lease))
+	b := New(store, WithClock(clock.Now), WithWorkerID("b"), WithLease(lease))
+
+	started := make(chan struct{})
+	var budget time.Duration
+	a.Handle("tip", func(ctx context.Context, _ Run) error {
+		deadline, ok := ctx.Deadline()
+		if !ok {
+			t.Error("handler context has no deadline")
+		}
+		budget = time.Until(deadline)
+		close(started)
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	var bCalls int32
+	b.Handle("tip", func(context.Context, Run) error {
+		atomic.AddInt32(&bCalls, 1)
+		return nil
+	})
+	if _, err := a.Put(context.Background(), &Schedule{ID: "tip:user-1", Kind: "tip", UserID: "user-1", TimesOfDay: []string{"08:00"}}); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+
+	clock.Set(time.Date(2026, 3, 2, 8, 0, 0, 0, time.UTC))
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		if _, err := a.Tick(context.Background()); err != nil {
+			t.Errorf("a.Tick() error = %v", err)
+		}
+	}()
+	<-started
+	if budget > lease*2/3 {
+		t.Errorf("handler budget = %v, want at most %v", budget, lease*2/3)
+	}
+	// b polls while a's claim is live
+	if n, _ := b.Tick(context.Background()); n != 0 || atomic.LoadInt32(&bCalls) != 0 {
+		t.Fatalf("b delivered during a's lease")
+	}
+	<-done
+
+	// a timed out and released the claim, so b retries it
+	if n, err := b.Tick(context.Background()); err != nil || n != 1 {
+		t.Fatalf("b.Tick() = (%d, %v), want (1, nil)", n, err)
+	}
+}
+
+func TestScheduler_LostClaimIsNotCompleted(t *testing.T) {
+	store := NewMemoryStore()
+	clock := &fakeClock{now: time.Date(2026, 3, 2, 7, 0, 0, 0, time.UTC)}
+	a := New(store, WithClock(clock.Now), WithWorkerID("a"), WithLease(time.Minute))
+	b := New(store, WithClock(clock.Now), WithWorkerID("b"), WithLease(time.Minute))
+
+	started, resume := make(chan struct{}), make(chan struct{})
+	// a ignores its deadline and keeps running past the lease
+	a.Handle("tip", func(context.Context, Run) error {
+		close(started)
+		<-resume
+		return nil
+	})
+	var bCalls int32
+	b.Handle("tip", func(context.Context, Run) error {
+		atomic.AddInt32(&bCalls, 1)
+		return nil
+	})
+	if _, err := a.Put(context.Background(), &Schedule{ID: "tip:user-1", Kind: "tip", UserID: "user-1", TimesOfDay: []string{"08:00"}}); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+
+	clock.Set(time.Date(2026, 3, 2, 8, 0, 0, 0, time.UTC))
+	result := make(chan int, 1)
+	go func() {
+		n, err := a.Tick(context.Background())
+		if err != nil {
+			t.Errorf("a.Tick() error = %v", err)
+		}
+		result <- n
+	}()
+	<-started
+
+	// b polls after a's lease expired, re-claims the occurrence and completes it
+	clock.Set(time.Date(2026, 3, 2, 8, 2, 0, 0, time.UTC))
+	if n, err := b.Tick(context.Background()); err != nil || n != 1 {
+		t.Fatalf("b.Tick() = (%d, %v), want (1, nil)", n, err)
+	}
+	close(resume)
+	if n := <-result; n != 0 {
+		t.Errorf("a.Tick() delivered = %d, want 0 after losing the claim", n)
+	}
+
+	ok, err := store.Complete(context.Background(), &Schedule{ID: "tip:user-1"}, time.Date(2026, 3, 2, 8, 0, 0, 0, time.UTC), "a")
+	if err != nil || ok {
+		t.Errorf("Complete() by the previous holder = (%v, %v), want (false, nil)", ok, err)
+	}
+	sched, _ := store.Get(context.Background(), "tip:user-1")
+	if want := time.Date(2026, 3, 3, 8, 0, 0, 0, time.UTC); !sched.NextRunAt.Equal(want) {
+		t.Errorf("NextRunAt = %v, want %v", sched.NextRunAt, want)
+	}
+}
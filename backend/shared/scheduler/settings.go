@@ -0,0 +1,36 @@
+package scheduler
+
+import (
+	"context"
+
+	"github.com/manpasik/backend/shared/clients"
+)
+
+// ClientSettings resolves settings from user-service (timezone) and notification-service
+// (quiet hours). Either client may be nil.
+type ClientSettings struct {
+	Profiles    clients.UserProfileClient
+	Preferences clients.NotificationPreferenceClient
+}
+
+// UserSettings returns the user's timezone and quiet hours. Any failed lookup fails the
+// whole call so the scheduler keeps the values stored on the schedule instead of
+// clearing quiet hours it could not read.
+func (c ClientSettings) UserSettings(ctx context.Context, userID string) (Settings, error) {
+	var st Settings
+	if c.Profiles != nil {
+		tz, err := c.Profiles.GetTimezone(ctx, userID)
+		if err != nil {
+			return Settings{}, err
+		}
+		st.Timezone = tz
+	}
+	if c.Preferences != nil {
+		start, end, err := c.Preferences.GetQuietHours(ctx, userID)
+		if err != nil {
+			return Settings{}, err
+		}
+		st.QuietHoursStart, st.QuietHoursEnd, st.HasQuietHours = start, end, true
+	}
+	return st, nil
+}
@@ -0,0 +1,238 @@
+package scheduler
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxSearchDays bounds the search for the next matching day (covers Feb 29 cron specs)
+const maxSearchDays = 4*366 + 1
+
+// spec is a parsed schedule: local times of day plus the days they apply to
+type spec struct {
+	minutes []int // sorted minutes of the day (0 ~ 1439)
+	months  uint64
+	doms    uint64
+	dows    uint64
+	domAny  bool
+	dowAny  bool
+}
+
+// parseSpec parses either a 5-field cron expression ("min hour dom month dow") or
+// a list of "HH:MM" times of day that repeat every day. Cron takes precedence.
+func parseSpec(cron string, timesOfDay []string) (*spec, error) {
+	if strings.TrimSpace(cron) != "" {
+		return parseCron(cron)
+	}
+	if len(timesOfDay) == 0 {
+		return nil, fmt.Errorf("schedule needs either a cron expression or times of day")
+	}
+	sp := &spec{domAny: true, dowAny: true, months: fullMask(1, 12), doms: fullMask(1, 31), dows: fullMask(0, 6)}
+	seen := make(map[int]bool)
+	for _, t := range timesOfDay {
+		m, ok := parseClock(t)
+		if !ok {
+			return nil, fmt.Errorf("invalid time of day %q (want HH:MM)", t)
+		}
+		if !seen[m] {
+			seen[m] = true
+			sp.minutes = append(sp.minutes, m)
+		}
+	}
+	sort.Ints(sp.minutes)
+	return sp, nil
+}
+
+// parseCron parses a standard 5-field cron expression. Each field accepts "*", numbers,
+// ranges ("1-5"), lists ("8,20") and steps ("*/15", "0-30/10"); day of week 7 is Sunday.
+// As in cron, when both day of month and day of week are restricted either may match.
+func parseCron(expr string) (*spec, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("cron expression %q must have 5 fields", expr)
+	}
+	minutes, _, err := parseField(fields[0], 0, 59)
+	if err != nil {
+		return nil, fmt.Errorf("cron minute: %w", err)
+	}
+	hours, _, err := parseField(fields[1], 0, 23)
+	if err != nil {
+		return nil, fmt.Errorf("cron hour: %w", err)
+	}
+	doms, domAny, err := parseField(fields[2], 1, 31)
+	if err != nil {
+		return nil, fmt.Errorf("cron day of month: %w", err)
+	}
+	months, _, err := parseField(fields[3], 1, 12)
+	if err != nil {
+		return nil, fmt.Errorf("cron month: %w", err)
+	}
+	dows, dowAny, err := parseField(fields[4], 0, 7)
+	if err != nil {
+		return nil, fmt.Errorf("cron day of week: %w", err)
+	}
+	if dows&(1<<7) != 0 {
+		dows = dows&^(1<<7) | 1
+	}
+
+	sp := &spec{months: months, doms: doms, dows: dows, domAny: domAny, dowAny: dowAny}
+	for h := 0; h < 24; h++ {
+		if hours&(1<<uint(h)) == 0 {
+			continue
+		}
+		for m := 0; m < 60; m++ {
+			if minutes&(1<<uint(m)) != 0 {
+				sp.minutes = append(sp.minutes, h*60+m)
+			}
+		}
+	}
+	return sp, nil
+}
+
+// parseField parses one cron field into a bit mask. unrestricted reports a plain "*" field.
+func parseField(field string, min, max int) (mask uint64, unrestricted bool, err error) {
+	for _, part := range strings.Split(field, ",") {
+		rangePart, step := part, 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			rangePart = part[:i]
+			if step, err = strconv.Atoi(part[i+1:]); err != nil || step <= 0 {
+				return 0, false, fmt.Errorf("invalid step in %q", part)
+			}
+		}
+		lo, hi := min, max
+		switch {
+		case rangePart == "*":
+			unrestricted = unrestricted || step == 1
+		case strings.Contains(rangePart, "-"):
+			bounds := strings.SplitN(rangePart, "-", 2)
+			if lo, err = strconv.Atoi(bounds[0]); err != nil {
+				return 0, false, fmt.Errorf("invalid range %q", part)
+			}
+			if hi, err = strconv.Atoi(bounds[1]); err != nil {
+				return 0, false, fmt.Errorf("invalid range %q", part)
+			}
+		default:
+			if lo, err = strconv.Atoi(rangePart); err != nil {
+				return 0, false, fmt.Errorf("invalid value %q", part)
+			}
+			hi = lo
+			if step > 1 {
+				hi = max
+			}
+		}
+		if lo < min || hi > max || lo > hi {
+			return 0, false, fmt.Errorf("%q out of range %d-%d", part, min, max)
+		}
+		for v := lo; v <= hi; v += step {
+			mask |= 1 << uint(v)
+		}
+	}
+	return mask, unrestricted, nil
+}
+
+func fullMask(lo, hi int) uint64 {
+	var mask uint64
+	for v := lo; v <= hi; v++ {
+		mask |= 1 << uint(v)
+	}
+	return mask
+}
+
+// matchesDay reports whether the spec fires on the given local date
+func (sp *spec) matchesDay(t time.Time) bool {
+	if sp.months&(1<<uint(t.Month())) == 0 {
+		return false
+	}
+	dom := sp.doms&(1<<uint(t.Day())) != 0
+	dow := sp.dows&(1<<uint(t.Weekday())) != 0
+	switch {
+	case sp.domAny && sp.dowAny:
+		return true
+	case sp.domAny:
+		return dow
+	case sp.dowAny:
+		return dom
+	default:
+		return dom || dow
+	}
+}
+
+// next returns the first slot strictly after the given instant, evaluated in loc.
+// It returns the zero time when the spec never fires (e.g. "0 0 31 2 *").
+func (sp *spec) next(after time.Time, loc *time.Location) time.Time {
+	local := after.In(loc)
+	day := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc)
+	for i := 0; i < maxSearchDays; i++ {
+		d := day.AddDate(0, 0, i)
+		if !sp.matchesDay(d) {
+			continue
+		}
+		for _, m := range sp.minutes {
+			t := time.Date(d.Year(), d.Month(), d.Day(), m/60, m%60, 0, 0, loc)
+			if t.After(after) {
+				return t
+			}
+		}
+	}
+	return time.Time{}
+}
+
+// parseClock parses "HH:MM" into minutes of the day
+func parseClock(s string) (int, bool) {
+	t, err := time.Parse("15:04", strings.TrimSpace(s))
+	if err != nil {
+		return 0, false
+	}
+	return t.Hour()*60 + t.Minute(), true
+}
+
+// quietHours is a daily do-not-disturb window in local time. The window may wrap past
+// midnight ("22:00"-"07:00"). A zero-length or unparsable window means no quiet hours.
+type quietHours struct {
+	start, end int
+	valid      bool
+}
+
+func parseQuietHours(start, end string) quietHours {
+	s, ok1 := parseClock(start)
+	e, ok2 := parseClock(end)
+	return quietHours{start: s, end: e, valid: ok1 && ok2 && s != e}
+}
+
+// apply moves t to the end of the quiet window when it falls inside one; otherwise it returns t
+func (q quietHours) apply(t time.Time) time.Time {
+	if !q.valid {
+		return t
+	}
+	m := t.Hour()*60 + t.Minute()
+	end := time.Date(t.Year(), t.Month(), t.Day(), q.end/60, q.end%60, 0, 0, t.Location())
+	if q.start < q.end {
+		if m >= q.start && m < q.end {
+			return end
+		}
+		return t
+	}
+	// the window wraps past midnight: after start defers to tomorrow's end, before end to today's
+	if m >= q.start {
+		return end.AddDate(0, 0, 1)
+	}
+	if m < q.end {
+		return end
+	}
+	return t
+}
+
+// QuietHoursEnd returns when a message due at t may be delivered under the user's quiet hours
+// ("HH:MM" start and end in the given IANA timezone): t itself outside the window, otherwise
+// the end of the window. Empty or unknown timezones are treated as UTC.
+func QuietHoursEnd(t time.Time, timezone, start, end string) time.Time {
+	local := t.In(location(timezone))
+	deferred := parseQuietHours(start, end).apply(local)
+	if deferred.Equal(local) {
+		return t
+	}
+	return deferred.UTC()
+}
@@ -0,0 +1,143 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+)
+
+func mustLoad(t *testing.T, name string) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Skipf("timezone %s unavailable: %v", name, err)
+	}
+	return loc
+}
+
+func TestSpec_next(t *testing.T) {
+	seoul := mustLoad(t, "Asia/Seoul")
+	tests := []struct {
+		name  string
+		cron  string
+		times []string
+		after time.Time
+		want  time.Time
+	}{
+		{
+			name:  "later today in local time",
+			times: []string{"21:00", "08:00"},
+			after: time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC), // 09:00 KST
+			want:  time.Date(2026, 3, 2, 21, 0, 0, 0, seoul),
+		},
+		{
+			name:  "rolls over to tomorrow",
+			times: []string{"08:00"},
+			after: time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC),
+			want:  time.Date(2026, 3, 3, 8, 0, 0, 0, seoul),
+		},
+		{
+			name:  "slot equal to after is skipped",
+			times: []string{"09:00"},
+			after: time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC),
+			want:  time.Date(2026, 3, 3, 9, 0, 0, 0, seoul),
+		},
+		{
+			name:  "cron weekdays",
+			cron:  "30 7 * * 1-5",
+			after: time.Date(2026, 3, 6, 23, 0, 0, 0, time.UTC), // Sat 08:00 KST
+			want:  time.Date(2026, 3, 9, 7, 30, 0, 0, seoul),    // Mon
+		},
+		{
+			name:  "cron sunday as 7",
+			cron:  "0 20 * * 7",
+			after: time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC),
+			want:  time.Date(2026, 3, 8, 20, 0, 0, 0, seoul),
+		},
+		{
+			name:  "cron leap day",
+			cron:  "0 9 29 2 *",
+			after: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+			want:  time.Date(2028, 2, 29, 9, 0, 0, 0, seoul),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sp, err := parseSpec(tt.cron, tt.times)
+			if err != nil {
+				t.Fatalf("parseSpec() error = %v", err)
+			}
+			if got := sp.next(tt.after, seoul); !got.Equal(tt.want) {
+				t.Errorf("next() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSpec_nextNeverFires(t *testing.T) {
+	sp, err := parseSpec("0 0 31 2 *", nil)
+	if err != nil {
+		t.Fatalf("parseSpec() error = %v", err)
+	}
+	if got := sp.next(time.Now(), time.UTC); !got.IsZero() {
+		t.Errorf("next() = %v, want zero time", got)
+	}
+}
+
+func TestParseSpec_invalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		cron  string
+		times []string
+	}{
+		{name: "empty", cron: ""},
+		{name: "bad time of day", times: []string{"25:00"}},
+		{name: "four cron fields", cron: "0 8 * *"},
+		{name: "minute out of range", cron: "60 8 * * *"},
+		{name: "bad step", cron: "*/0 8 * * *"},
+		{name: "reversed range", cron: "0 9-8 * * *"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := parseSpec(tt.cron, tt.times); err == nil {
+				t.Error("parseSpec() error = nil, want error")
+			}
+		})
+	}
+}
+
+func TestQuietHours_apply(t *testing.T) {
+	day := func(h, m int) time.Time { return time.Date(2026, 3, 2, h, m, 0, 0, time.UTC) }
+	tests := []struct {
+		name       string
+		start, end string
+		in, want   time.Time
+	}{
+		{name: "outside window", start: "22:00", end: "07:00", in: day(21, 0), want: day(21, 0)},
+		{name: "after start defers to next morning", start: "22:00", end: "07:00", in: day(23, 30), want: day(7, 0).AddDate(0, 0, 1)},
+		{name: "before end defers to this morning", start: "22:00", end: "07:00", in: day(6, 0), want: day(7, 0)},
+		{name: "end is not quiet", start: "22:00", end: "07:00", in: day(7, 0), want: day(7, 0)},
+		{name: "daytime window", start: "12:00", end: "13:30", in: day(12, 15), want: day(13, 30)},
+		{name: "unset", start: "", end: "", in: day(23, 0), want: day(23, 0)},
+		{name: "zero length", start: "22:00", end: "22:00", in: day(22, 0), want: day(22, 0)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseQuietHours(tt.start, tt.end).apply(tt.in); !got.Equal(tt.want) {
+				t.Errorf("apply() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQuietHoursEnd(t *testing.T) {
+	seoul := mustLoad(t, "Asia/Seoul")
+	at := time.Date(2026, 3, 2, 14, 30, 0, 0, time.UTC) // 23:30 KST
+
+	if got, want := QuietHoursEnd(at, "Asia/Seoul", "22:00", "07:00"), time.Date(2026, 3, 3, 7, 0, 0, 0, seoul); !got.Equal(want) {
+		t.Errorf("in Seoul quiet hours: got %v, want %v", got, want)
+	}
+	// 14:30 UTC is outside the same window evaluated in UTC
+	if got := QuietHoursEnd(at, "", "22:00", "07:00"); !got.Equal(at) {
+		t.Errorf("UTC fallback: got %v, want %v", got, at)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"strings"
 	"time"
 
 	"github.com/milvus-io/milvus-sdk-go/v2/client"
@@ -109,12 +110,31 @@ func (m *MilvusClient) Insert(ctx context.Context, id, sessionID string, vector
 	return err
 }
 
+// Upsert inserts a vector or replaces the one already stored under the same ID.
+func (m *MilvusClient) Upsert(ctx context.Context, id, sessionID string, vector []float32) error {
+	idCol := entity.NewColumnVarChar("id", []string{id})
+	sessionCol := entity.NewColumnVarChar("session_id", []string{sessionID})
+	vectorCol := entity.NewColumnFloatVector("vector", m.dimension, [][]float32{vector})
+
+	_, err := m.client.Upsert(ctx, m.collection, "", idCol, sessionCol, vectorCol)
+	return err
+}
+
 // Search finds the topK most similar vectors using cosine similarity.
 func (m *MilvusClient) Search(ctx context.Context, vector []float32, topK int) ([]SearchResult, error) {
+	return m.search(ctx, "", vector, topK)
+}
+
+// SearchInSession finds the topK most similar vectors among those stored with sessionID.
+func (m *MilvusClient) SearchInSession(ctx context.Context, sessionID string, vector []float32, topK int) ([]SearchResult, error) {
+	return m.search(ctx, sessionExpr(sessionID), vector, topK)
+}
+
+func (m *MilvusClient) search(ctx context.Context, expr string, vector []float32, topK int) ([]SearchResult, error) {
 	sp, _ := entity.NewIndexIvfFlatSearchParam(16)
 
 	results, err := m.client.Search(
-		ctx, m.collection, nil, "",
+		ctx, m.collection, nil, expr,
 		[]string{"id", "session_id"},
 		[]entity.Vector{entity.FloatVector(vector)},
 		"vector", entity.COSINE, topK, sp,
@@ -138,6 +158,12 @@ func (m *MilvusClient) Search(ctx context.Context, vector []float32, topK int) (
 	return searchResults, nil
 }
 
+// sessionExpr builds a boolean expression matching a single session ID.
+func sessionExpr(sessionID string) string {
+	escaped := strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(sessionID)
+	return `session_id == "` + escaped + `"`
+}
+
 // SearchResult holds a single search result from Milvus.
 type SearchResult struct {
 	ID        string
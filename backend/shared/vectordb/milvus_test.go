@@ -29,3 +29,12 @@ func TestNewMilvusClient_InvalidAddress(t *testing.T) {
 		t.Error("expected error when connecting to invalid address, got nil")
 	}
 }
+
+func TestSessionExpr_EscapesQuotes(t *testing.T) {
+	if got := sessionExpr("user-1"); got != `session_id == "user-1"` {
+		t.Errorf("unexpected expr: %s", got)
+	}
+	if got := sessionExpr(`a"b\c`); got != `session_id == "a\"b\\c"` {
+		t.Errorf("quotes and backslashes must be escaped: %s", got)
+	}
+}
@@ -0,0 +1,61 @@
+-- =============================================================================
+-- 26-device-lifecycle.sql
+-- 디바이스 소유권 이전, 등록 해제, 원격 명령 대기열 (03-device.sql 보강)
+-- =============================================================================
+
+-- 등록 해제 시각 (NULL = 활성). 등록 해제된 디바이스는 구독 슬롯에서 제외됩니다.
+ALTER TABLE devices ADD COLUMN IF NOT EXISTS deregistered_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_devices_user_active ON devices(user_id) WHERE deregistered_at IS NULL;
+
+-- 소유권 이전 요청 (양측 확인 후 완료)
+CREATE TABLE IF NOT EXISTS device_transfers (
+    id              UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    device_id       UUID NOT NULL REFERENCES devices(id) ON DELETE CASCADE,
+    from_user_id    UUID NOT NULL,
+    to_user_id      UUID NOT NULL,
+    status          VARCHAR(20) NOT NULL DEFAULT 'pending',  -- 'pending', 'completed', 'cancelled', 'expired'
+    from_confirmed  BOOLEAN NOT NULL DEFAULT FALSE,
+    to_confirmed    BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    expires_at      TIMESTAMPTZ NOT NULL,
+    completed_at    TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_device_transfers_device ON device_transfers(device_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_device_transfers_pending ON device_transfers(device_id) WHERE status = 'pending';
+
+-- 소유 기간 이력: 측정·보정 기록은 기록 시점의 소유자에게 귀속
+CREATE TABLE IF NOT EXISTS device_ownerships (
+    id              UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    device_id       UUID NOT NULL REFERENCES devices(id) ON DELETE CASCADE,
+    user_id         UUID NOT NULL,
+    started_at      TIMESTAMPTZ NOT NULL,
+    ended_at        TIMESTAMPTZ,
+    end_reason      VARCHAR(20)  -- 'transferred', 'deregistered'
+);
+
+CREATE INDEX IF NOT EXISTS idx_device_ownerships_device ON device_ownerships(device_id, started_at);
+CREATE INDEX IF NOT EXISTS idx_device_ownerships_user ON device_ownerships(user_id);
+
+-- 기존 디바이스의 현재 소유 기간 백필
+INSERT INTO device_ownerships (device_id, user_id, started_at)
+SELECT d.id, d.user_id, d.registered_at
+FROM devices d
+WHERE NOT EXISTS (SELECT 1 FROM device_ownerships o WHERE o.device_id = d.id);
+
+-- 디바이스 명령 대기열 (원격 초기화 등, 다음 연결 시 전달)
+CREATE TABLE IF NOT EXISTS device_commands (
+    id              UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    device_id       UUID NOT NULL REFERENCES devices(id) ON DELETE CASCADE,
+    command_type    VARCHAR(30) NOT NULL,  -- 'factory_reset', 'reboot', 'calibrate', ...
+    payload         BYTEA,
+    status          VARCHAR(20) NOT NULL DEFAULT 'queued',  -- 'queued', 'delivered', 'acknowledged', 'failed'
+    issued_by       UUID NOT NULL,
+    result          TEXT,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    delivered_at    TIMESTAMPTZ,
+    acknowledged_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_device_commands_pending ON device_commands(device_id, created_at) WHERE status IN ('queued', 'delivered');
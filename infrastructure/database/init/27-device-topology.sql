@@ -0,0 +1,17 @@
+-- =============================================================================
+-- 27-device-topology.sql
+-- 디바이스 허브 토폴로지: BLE 리더 → Wi-Fi 허브 → 클라우드 게이트웨이 (26-device-lifecycle.sql 보강)
+-- =============================================================================
+
+-- 디바이스 종류(reader, wifi_hub, cloud_gateway)와 배정된 상위 허브, 마지막 중계 허브
+ALTER TABLE devices ADD COLUMN IF NOT EXISTS kind VARCHAR(20) NOT NULL DEFAULT 'reader';
+ALTER TABLE devices ADD COLUMN IF NOT EXISTS parent_hub_id UUID REFERENCES devices(id) ON DELETE SET NULL;
+ALTER TABLE devices ADD COLUMN IF NOT EXISTS last_relay_hub_id UUID REFERENCES devices(id) ON DELETE SET NULL;
+ALTER TABLE devices ADD COLUMN IF NOT EXISTS last_relay_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_devices_parent_hub ON devices(parent_hub_id) WHERE parent_hub_id IS NOT NULL AND deregistered_at IS NULL;
+
+-- 허브 경유 명령: 허브가 폴링할 때 route_hub_id로 하위 리더 명령을 함께 수신
+ALTER TABLE device_commands ADD COLUMN IF NOT EXISTS route_hub_id UUID REFERENCES devices(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_device_commands_route ON device_commands(route_hub_id, created_at) WHERE status IN ('queued', 'delivered');
@@ -0,0 +1,61 @@
+-- =============================================================================
+-- 28-device-fleet.sql
+-- 클리니컬 플릿 관리: 디바이스 그룹, 일괄 명령, 교정·카트리지 사용량 (26-device-lifecycle.sql 보강)
+-- =============================================================================
+
+-- 디바이스 그룹 (클리니컬 구독 사용자가 생성)
+CREATE TABLE IF NOT EXISTS device_groups (
+    id              UUID PRIMARY KEY,
+    name            VARCHAR(100) NOT NULL,
+    description     TEXT,
+    owner_id        UUID NOT NULL,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- 그룹 구성원 역할: owner, manager, operator, viewer
+CREATE TABLE IF NOT EXISTS device_group_members (
+    group_id        UUID NOT NULL REFERENCES device_groups(id) ON DELETE CASCADE,
+    user_id         UUID NOT NULL,
+    role            VARCHAR(20) NOT NULL,
+    added_at        TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (group_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_device_group_members_user ON device_group_members(user_id);
+
+CREATE TABLE IF NOT EXISTS device_group_devices (
+    group_id        UUID NOT NULL REFERENCES device_groups(id) ON DELETE CASCADE,
+    device_id       UUID NOT NULL REFERENCES devices(id) ON DELETE CASCADE,
+    added_at        TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (group_id, device_id)
+);
+
+-- 일괄 명령 기록. 개별 명령은 device_commands.bulk_id로 연결
+CREATE TABLE IF NOT EXISTS device_bulk_operations (
+    id                  UUID PRIMARY KEY,
+    group_id            UUID NOT NULL REFERENCES device_groups(id) ON DELETE CASCADE,
+    command_type        VARCHAR(30) NOT NULL,
+    issued_by           UUID NOT NULL,
+    target_count        INTEGER NOT NULL DEFAULT 0,
+    skipped_device_ids  TEXT[] NOT NULL DEFAULT '{}',
+    created_at          TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE device_commands ADD COLUMN IF NOT EXISTS bulk_id UUID REFERENCES device_bulk_operations(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_device_commands_bulk ON device_commands(bulk_id) WHERE bulk_id IS NOT NULL;
+
+-- 마지막 교정 성공 시각 (교정 준수율 집계)
+CREATE TABLE IF NOT EXISTS device_calibration_status (
+    device_id           UUID PRIMARY KEY REFERENCES devices(id) ON DELETE CASCADE,
+    last_calibrated_at  TIMESTAMPTZ NOT NULL
+);
+
+-- 카트리지 사용 기록 (measurement.completed 1건 = 카트리지 1개)
+CREATE TABLE IF NOT EXISTS device_cartridge_usage (
+    id              BIGSERIAL PRIMARY KEY,
+    device_id       UUID NOT NULL REFERENCES devices(id) ON DELETE CASCADE,
+    used_at         TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_device_cartridge_usage_device ON device_cartridge_usage(device_id, used_at);
@@ -0,0 +1,18 @@
+-- =============================================================================
+-- 29-ai-baselines.sql
+-- 개인 기준선 이상 탐지: 사용자·바이오마커별 중앙값/MAD 윈도우와 EWMA 상태 (08-ai-inference.sql 보강)
+-- =============================================================================
+
+CREATE TABLE IF NOT EXISTS user_biomarker_baselines (
+  user_id          VARCHAR(64)        NOT NULL,
+  biomarker        VARCHAR(64)        NOT NULL,
+  sample_count     INTEGER            NOT NULL DEFAULT 0,
+  recent_values    DOUBLE PRECISION[] NOT NULL DEFAULT '{}',  -- 최근 30개 측정값 (시간순)
+  ewma             DOUBLE PRECISION   NOT NULL DEFAULT 0,
+  suspect_run      INTEGER            NOT NULL DEFAULT 0,      -- 지속 변화 의심 구간 길이 (기준선 계산 제외)
+  shift_run        INTEGER            NOT NULL DEFAULT 0,      -- 의심 구간 중 EWMA 2σ 초과 횟수
+  shift_active     BOOLEAN            NOT NULL DEFAULT FALSE,  -- 지속 변화 이벤트 발행 여부
+  last_measured_at TIMESTAMPTZ        NOT NULL,
+  updated_at       TIMESTAMPTZ        NOT NULL DEFAULT NOW(),
+  PRIMARY KEY (user_id, biomarker)
+);
@@ -0,0 +1,12 @@
+-- =============================================================================
+-- 30-ai-health-score-features.sql
+-- 설명 가능한 건강 점수: 특징별 기여도·데이터 커버리지·모델 버전 (08-ai-inference.sql 보강)
+-- =============================================================================
+
+ALTER TABLE health_scores ADD COLUMN IF NOT EXISTS feature_contributions JSONB;
+ALTER TABLE health_scores ADD COLUMN IF NOT EXISTS category_coverage JSONB;
+ALTER TABLE health_scores ADD COLUMN IF NOT EXISTS data_coverage DOUBLE PRECISION NOT NULL DEFAULT 0;
+ALTER TABLE health_scores ADD COLUMN IF NOT EXISTS insufficient_data BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE health_scores ADD COLUMN IF NOT EXISTS model_version VARCHAR(32);
+
+CREATE INDEX IF NOT EXISTS idx_health_scores_user_calculated ON health_scores (user_id, calculated_at DESC);
@@ -0,0 +1,33 @@
+-- =============================================================================
+-- 31-ai-model-registry.sql
+-- AI 모델 레지스트리: 버전별 아티팩트·학습 데이터 기간·평가 지표, 섀도 비교 누적 지표 (08-ai-inference.sql의 ai_models 대체)
+-- =============================================================================
+
+CREATE TABLE IF NOT EXISTS ai_model_versions (
+  model_type          ai_model_type      NOT NULL,
+  name                VARCHAR(128)       NOT NULL,
+  version             VARCHAR(32)        NOT NULL,
+  description         TEXT,
+  kind                VARCHAR(64)        NOT NULL DEFAULT '',   -- 모델 로더 종류 (빈 값: 코드 내장 알고리즘)
+  artifact_uri        TEXT               NOT NULL,              -- 오브젝트 스토리지 키 또는 builtin://...
+  artifact_sha256     CHAR(64),
+  training_data_start TIMESTAMPTZ,
+  training_data_end   TIMESTAMPTZ,
+  trained_at          TIMESTAMPTZ,
+  metrics             JSONB,
+  status              VARCHAR(32)        NOT NULL,              -- active, shadow, training, deprecated
+  created_at          TIMESTAMPTZ        NOT NULL DEFAULT NOW(),
+  updated_at          TIMESTAMPTZ        NOT NULL DEFAULT NOW(),
+  PRIMARY KEY (model_type, version)
+);
+
+CREATE TABLE IF NOT EXISTS ai_model_shadow_stats (
+  model_type          ai_model_type      NOT NULL,
+  candidate_version   VARCHAR(32)        NOT NULL,
+  runs                INTEGER            NOT NULL DEFAULT 0,
+  compared            INTEGER            NOT NULL DEFAULT 0,   -- 비교한 바이오마커 수
+  agreed              INTEGER            NOT NULL DEFAULT 0,   -- 분류가 일치한 바이오마커 수
+  sum_abs_score_delta DOUBLE PRECISION   NOT NULL DEFAULT 0,
+  last_recorded_at    TIMESTAMPTZ,
+  PRIMARY KEY (model_type, candidate_version)
+);
@@ -0,0 +1,63 @@
+-- =============================================================================
+-- 32-llm-router.sql
+-- LLM 멀티 프로바이더 라우터: 폴백 체인·프로바이더별 자격 증명·재시도·등급별 일일 토큰 예산 (25-admin-settings-ext.sql 보강)
+-- =============================================================================
+
+-- 사용자별 일일 LLM 토큰 사용량 (ai-inference-service 토큰 예산)
+CREATE TABLE IF NOT EXISTS llm_token_usage (
+    user_id     VARCHAR(36)  NOT NULL,
+    usage_date  DATE         NOT NULL,   -- UTC 기준 일자
+    tokens      BIGINT       NOT NULL DEFAULT 0,
+    updated_at  TIMESTAMPTZ  DEFAULT NOW(),
+    PRIMARY KEY (user_id, usage_date)
+);
+CREATE INDEX IF NOT EXISTS idx_llm_token_usage_date ON llm_token_usage(usage_date);
+
+INSERT INTO system_configs (key, value, description) VALUES
+    ('llm.providers',                    '', 'LLM 폴백 순서 (쉼표 구분, 비어있으면 llm.provider 단독)'),
+    ('llm.base_url',                     '', 'OpenAI API 기본 URL (비어있으면 공식 엔드포인트)'),
+    ('llm.anthropic.api_key',            '', 'Anthropic API 키'),
+    ('llm.anthropic.model',              'claude-3-5-sonnet-latest', 'Anthropic 모델 이름'),
+    ('llm.azure.endpoint',               '', 'Azure OpenAI 리소스 엔드포인트'),
+    ('llm.azure.api_key',                '', 'Azure OpenAI API 키'),
+    ('llm.azure.deployment',             '', 'Azure OpenAI 배포 이름'),
+    ('llm.azure.api_version',            '2024-06-01', 'Azure OpenAI API 버전'),
+    ('llm.local.base_url',               '', '로컬 OpenAI 호환 서버 URL'),
+    ('llm.local.model',                  '', '로컬 모델 이름'),
+    ('llm.local.api_key',                '', '로컬 서버 API 키 (선택)'),
+    ('llm.timeout_seconds',              '20', 'LLM 호출 1회 타임아웃 (초)'),
+    ('llm.max_retries',                  '2', 'LLM 프로바이더별 재시도 횟수 (429/5xx)'),
+    ('llm.budget.free_daily_tokens',     '20000', 'Free 등급 사용자 일일 LLM 토큰 한도'),
+    ('llm.budget.basic_daily_tokens',    '100000', 'Basic 등급 사용자 일일 LLM 토큰 한도'),
+    ('llm.budget.pro_daily_tokens',      '500000', 'Pro 등급 사용자 일일 LLM 토큰 한도'),
+    ('llm.budget.clinical_daily_tokens', '2000000', 'Clinical 등급 사용자 일일 LLM 토큰 한도')
+ON CONFLICT (key) DO NOTHING;
+
+INSERT INTO config_metadata (config_key, category, value_type, security_level, is_required, default_value, env_var_name, service_name, restart_required, display_order) VALUES
+    ('llm.providers',                    'ai', 'string', 'internal', false, '', 'LLM_PROVIDERS',                  'ai-inference-service', true,  15),
+    ('llm.base_url',                     'ai', 'url',    'internal', false, '', 'LLM_BASE_URL',                   'ai-inference-service', true,  16),
+    ('llm.anthropic.api_key',            'ai', 'secret', 'secret',   false, '', 'ANTHROPIC_API_KEY',              'ai-inference-service', true,  20),
+    ('llm.anthropic.model',              'ai', 'string', 'internal', false, 'claude-3-5-sonnet-latest', 'ANTHROPIC_MODEL', 'ai-inference-service', true, 21),
+    ('llm.azure.endpoint',               'ai', 'url',    'internal', false, '', 'AZURE_OPENAI_ENDPOINT',          'ai-inference-service', true,  30),
+    ('llm.azure.api_key',                'ai', 'secret', 'secret',   false, '', 'AZURE_OPENAI_API_KEY',           'ai-inference-service', true,  31),
+    ('llm.azure.deployment',             'ai', 'string', 'internal', false, '', 'AZURE_OPENAI_DEPLOYMENT',        'ai-inference-service', true,  32),
+    ('llm.azure.api_version',            'ai', 'string', 'internal', false, '2024-06-01', 'AZURE_OPENAI_API_VERSION', 'ai-inference-service', true, 33),
+    ('llm.local.base_url',               'ai', 'url',    'internal', false, '', 'LOCAL_LLM_BASE_URL',             'ai-inference-service', true,  40),
+    ('llm.local.model',                  'ai', 'string', 'internal', false, '', 'LOCAL_LLM_MODEL',                'ai-inference-service', true,  41),
+    ('llm.local.api_key',                'ai', 'secret', 'secret',   false, '', 'LOCAL_LLM_API_KEY',              'ai-inference-service', true,  42),
+    ('llm.timeout_seconds',              'ai', 'number', 'internal', false, '20', 'LLM_TIMEOUT_SECONDS',          'ai-inference-service', true,  50),
+    ('llm.max_retries',                  'ai', 'number', 'internal', false, '2', 'LLM_MAX_RETRIES',               'ai-inference-service', true,  51),
+    ('llm.budget.free_daily_tokens',     'ai', 'number', 'internal', false, '20000', 'LLM_BUDGET_FREE_DAILY_TOKENS',         'ai-inference-service', true, 60),
+    ('llm.budget.basic_daily_tokens',    'ai', 'number', 'internal', false, '100000', 'LLM_BUDGET_BASIC_DAILY_TOKENS',       'ai-inference-service', true, 61),
+    ('llm.budget.pro_daily_tokens',      'ai', 'number', 'internal', false, '500000', 'LLM_BUDGET_PRO_DAILY_TOKENS',         'ai-inference-service', true, 62),
+    ('llm.budget.clinical_daily_tokens', 'ai', 'number', 'internal', false, '2000000', 'LLM_BUDGET_CLINICAL_DAILY_TOKENS',   'ai-inference-service', true, 63)
+ON CONFLICT (config_key) DO NOTHING;
+
+-- 라우터가 읽는 기존 키: 환경변수 폴백 이름 보강, azure 프로바이더 허용
+UPDATE config_metadata SET allowed_values = ARRAY['openai','anthropic','azure','local'] WHERE config_key = 'llm.provider';
+UPDATE config_metadata SET env_var_name = 'LLM_PROVIDER'   WHERE config_key = 'llm.provider'   AND env_var_name IS NULL;
+UPDATE config_metadata SET env_var_name = 'LLM_API_KEY'    WHERE config_key = 'llm.api_key'    AND env_var_name IS NULL;
+UPDATE config_metadata SET env_var_name = 'LLM_MODEL'      WHERE config_key = 'llm.model'      AND env_var_name IS NULL;
+UPDATE config_metadata SET env_var_name = 'LLM_MAX_TOKENS' WHERE config_key = 'llm.max_tokens' AND env_var_name IS NULL;
+UPDATE config_metadata SET validation_min = 0 WHERE config_key IN ('llm.max_retries', 'llm.budget.free_daily_tokens', 'llm.budget.basic_daily_tokens', 'llm.budget.pro_daily_tokens', 'llm.budget.clinical_daily_tokens');
+UPDATE config_metadata SET validation_min = 1, validation_max = 120 WHERE config_key = 'llm.timeout_seconds';
@@ -0,0 +1,26 @@
+-- =============================================================================
+-- 33-ai-chat.sql
+-- AI 건강 상담 대화: 세션(스레드)·메시지·토큰 사용량 (AiInferenceService.StreamChat)
+-- =============================================================================
+
+CREATE TABLE IF NOT EXISTS ai_chat_sessions (
+    session_id   VARCHAR(64)  PRIMARY KEY,
+    user_id      VARCHAR(36)  NOT NULL,
+    title        VARCHAR(200) NOT NULL DEFAULT '',
+    tokens_used  BIGINT       NOT NULL DEFAULT 0,   -- 세션 누적 LLM 토큰
+    created_at   TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    updated_at   TIMESTAMPTZ  NOT NULL DEFAULT NOW()
+);
+CREATE INDEX IF NOT EXISTS idx_ai_chat_sessions_user ON ai_chat_sessions(user_id, updated_at DESC);
+
+CREATE TABLE IF NOT EXISTS ai_chat_messages (
+    message_id   VARCHAR(64)  PRIMARY KEY,
+    session_id   VARCHAR(64)  NOT NULL REFERENCES ai_chat_sessions(session_id) ON DELETE CASCADE,
+    role         VARCHAR(16)  NOT NULL CHECK (role IN ('user', 'assistant')),
+    content      TEXT         NOT NULL,
+    tokens_used  INTEGER      NOT NULL DEFAULT 0,   -- assistant 응답 생성 토큰
+    provider     VARCHAR(32)  NOT NULL DEFAULT '',
+    model        VARCHAR(128) NOT NULL DEFAULT '',
+    created_at   TIMESTAMPTZ  NOT NULL DEFAULT NOW()
+);
+CREATE INDEX IF NOT EXISTS idx_ai_chat_messages_session ON ai_chat_messages(session_id, created_at);
@@ -0,0 +1,30 @@
+-- =============================================================================
+-- 34-llm-guardrail.sql
+-- LLM 가드레일: 프롬프트 개인정보 가명 처리·응답 의료 안전 정책 결정 감사 기록
+-- =============================================================================
+
+-- 가드레일 결정 감사 로그 (원문 프롬프트·응답은 저장하지 않음, 사용자는 가명으로만 기록)
+CREATE TABLE IF NOT EXISTS llm_guardrail_audit (
+    id          BIGSERIAL    PRIMARY KEY,
+    user_ref    VARCHAR(64)  NOT NULL DEFAULT '',   -- HMAC 가명 (시스템 호출이면 빈 값)
+    operation   VARCHAR(20)  NOT NULL,              -- chat, chat_stream
+    provider    VARCHAR(20)  NOT NULL DEFAULT '',
+    model       VARCHAR(100) NOT NULL DEFAULT '',
+    redactions  JSONB        NOT NULL DEFAULT '{}', -- 항목별 치환 건수 {"rrn": 1, "phone": 2}
+    findings    TEXT[]       NOT NULL DEFAULT '{}', -- diagnosis, dosage, emergency
+    actions     TEXT[]       NOT NULL DEFAULT '{}', -- redact, rewrite, disclaimer, escalate
+    escalated   BOOLEAN      NOT NULL DEFAULT false,
+    failed      BOOLEAN      NOT NULL DEFAULT false,
+    created_at  TIMESTAMPTZ  NOT NULL DEFAULT NOW()
+);
+CREATE INDEX IF NOT EXISTS idx_llm_guardrail_audit_created ON llm_guardrail_audit(created_at);
+CREATE INDEX IF NOT EXISTS idx_llm_guardrail_audit_user ON llm_guardrail_audit(user_ref, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_llm_guardrail_audit_findings ON llm_guardrail_audit USING GIN (findings);
+
+INSERT INTO system_configs (key, value, description) VALUES
+    ('llm.guardrail.pseudonym_secret', '', 'LLM 프롬프트 사용자 가명(HMAC) 키 (비어있으면 재시작마다 무작위)')
+ON CONFLICT (key) DO NOTHING;
+
+INSERT INTO config_metadata (config_key, category, value_type, security_level, is_required, default_value, env_var_name, service_name, restart_required, display_order) VALUES
+    ('llm.guardrail.pseudonym_secret', 'ai', 'secret', 'secret', false, '', 'LLM_PSEUDONYM_SECRET', 'ai-inference-service', true, 70)
+ON CONFLICT (config_key) DO NOTHING;
@@ -0,0 +1,28 @@
+-- =============================================================================
+-- 35-ai-chat-rag.sql
+-- AI 채팅 검색 증강(RAG): 사용자가 AI 어시스턴트(manpasik-ai-assistant)에게 공유 동의한
+-- 건강 기록·측정 요약·처방·코칭 메시지의 색인 자료 (벡터는 Milvus 또는 인메모리 색인)
+-- =============================================================================
+
+CREATE TABLE IF NOT EXISTS ai_rag_snippets (
+    snippet_id   VARCHAR(120) NOT NULL,                -- {source_type}:{source_id}
+    user_id      VARCHAR(64)  NOT NULL,
+    source_type  VARCHAR(20)  NOT NULL,                -- health_record, measurement, prescription, coaching (동의 scope)
+    source_id    VARCHAR(100) NOT NULL,                -- 원본 ID (측정 요약은 바이오마커 ID)
+    title        TEXT         NOT NULL DEFAULT '',
+    body         TEXT         NOT NULL,
+    recorded_at  TIMESTAMPTZ,
+    indexed_at   TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, snippet_id)
+);
+CREATE INDEX IF NOT EXISTS idx_ai_rag_snippets_source ON ai_rag_snippets(user_id, source_type);
+
+INSERT INTO system_configs (key, value, description) VALUES
+    ('llm.embedding.model', 'text-embedding-3-small', 'AI 채팅 검색 증강 임베딩 모델 (OpenAI API 키가 없으면 내장 해싱 임베딩 사용)'),
+    ('llm.embedding.dimension', '256', 'AI 채팅 검색 증강 임베딩 차원 (변경 시 Milvus 컬렉션 재생성 필요)')
+ON CONFLICT (key) DO NOTHING;
+
+INSERT INTO config_metadata (config_key, category, value_type, security_level, is_required, default_value, env_var_name, service_name, restart_required, display_order) VALUES
+    ('llm.embedding.model', 'ai', 'string', 'internal', false, 'text-embedding-3-small', 'LLM_EMBEDDING_MODEL', 'ai-inference-service', true, 71),
+    ('llm.embedding.dimension', 'ai', 'number', 'internal', false, '256', 'LLM_EMBEDDING_DIMENSION', 'ai-inference-service', true, 72)
+ON CONFLICT (config_key) DO NOTHING;
@@ -0,0 +1,44 @@
+-- =============================================================================
+-- 36-llm-cache-cost.sql
+-- LLM 응답 캐시(Redis, 미연결 시 인메모리)와 사용자·기능·프로바이더별 사용량·예상 비용 원장,
+-- 구독 등급별 월간 AI 비용 한도
+-- =============================================================================
+
+-- LLM 호출별 사용량 (캐시 적중은 tokens·cost_usd 0으로 기록)
+CREATE TABLE IF NOT EXISTS llm_usage_events (
+    id          BIGSERIAL     PRIMARY KEY,
+    user_id     VARCHAR(36)   NOT NULL DEFAULT '',   -- 시스템 호출이면 빈 값
+    feature     VARCHAR(40)   NOT NULL DEFAULT '',   -- health_insight, analysis_summary, recommendation, chat
+    provider    VARCHAR(20)   NOT NULL DEFAULT '',
+    model       VARCHAR(100)  NOT NULL DEFAULT '',
+    tokens      INTEGER       NOT NULL DEFAULT 0,
+    cost_usd    NUMERIC(12,6) NOT NULL DEFAULT 0,    -- llm.pricing 단가 기준 예상 비용
+    cache_hit   BOOLEAN       NOT NULL DEFAULT FALSE,
+    created_at  TIMESTAMPTZ   NOT NULL DEFAULT NOW()
+);
+CREATE INDEX IF NOT EXISTS idx_llm_usage_events_user ON llm_usage_events(user_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_llm_usage_events_created ON llm_usage_events(created_at);
+
+INSERT INTO system_configs (key, value, description) VALUES
+    ('llm.pricing',                      '', 'LLM 모델별 100만 토큰당 예상 비용 USD (예: gpt-4o=5,gpt-4o-mini=0.3, 비어있으면 기본 단가)'),
+    ('llm.cache.ttl_seconds',            '86400', 'LLM 분석 응답 캐시 유효 시간 (초)'),
+    ('llm.cache.semantic_threshold',     '0', 'LLM 의미 유사 캐시 임계값 (0이면 정확 일치만, 사용 시 0.95 이상 권장)'),
+    ('llm.budget.free_monthly_usd',      '0.5', 'Free 등급 사용자 월간 AI 예상 비용 한도 (USD, 0이면 무제한)'),
+    ('llm.budget.basic_monthly_usd',     '3', 'Basic 등급 사용자 월간 AI 예상 비용 한도 (USD, 0이면 무제한)'),
+    ('llm.budget.pro_monthly_usd',       '15', 'Pro 등급 사용자 월간 AI 예상 비용 한도 (USD, 0이면 무제한)'),
+    ('llm.budget.clinical_monthly_usd',  '0', 'Clinical 등급 사용자 월간 AI 예상 비용 한도 (USD, 0이면 무제한)')
+ON CONFLICT (key) DO NOTHING;
+
+INSERT INTO config_metadata (config_key, category, value_type, security_level, is_required, default_value, env_var_name, service_name, restart_required, display_order) VALUES
+    ('llm.pricing',                      'ai', 'string', 'internal', false, '', 'LLM_PRICING',                          'ai-inference-service', true, 52),
+    ('llm.budget.free_monthly_usd',      'ai', 'number', 'internal', false, '0.5', 'LLM_BUDGET_FREE_MONTHLY_USD',       'ai-inference-service', true, 64),
+    ('llm.budget.basic_monthly_usd',     'ai', 'number', 'internal', false, '3', 'LLM_BUDGET_BASIC_MONTHLY_USD',        'ai-inference-service', true, 65),
+    ('llm.budget.pro_monthly_usd',       'ai', 'number', 'internal', false, '15', 'LLM_BUDGET_PRO_MONTHLY_USD',         'ai-inference-service', true, 66),
+    ('llm.budget.clinical_monthly_usd',  'ai', 'number', 'internal', false, '0', 'LLM_BUDGET_CLINICAL_MONTHLY_USD',     'ai-inference-service', true, 67),
+    ('llm.cache.ttl_seconds',            'ai', 'number', 'internal', false, '86400', 'LLM_CACHE_TTL_SECONDS',           'ai-inference-service', true, 73),
+    ('llm.cache.semantic_threshold',     'ai', 'number', 'internal', false, '0', 'LLM_CACHE_SEMANTIC_THRESHOLD',        'ai-inference-service', true, 74)
+ON CONFLICT (config_key) DO NOTHING;
+
+UPDATE config_metadata SET validation_min = 0 WHERE config_key IN ('llm.budget.free_monthly_usd', 'llm.budget.basic_monthly_usd', 'llm.budget.pro_monthly_usd', 'llm.budget.clinical_monthly_usd');
+UPDATE config_metadata SET validation_min = 60 WHERE config_key = 'llm.cache.ttl_seconds';
+UPDATE config_metadata SET validation_min = 0, validation_max = 1 WHERE config_key = 'llm.cache.semantic_threshold';
@@ -0,0 +1,38 @@
+-- =============================================================================
+-- 37-fingerprint-library.sql
+-- 라벨링된 핑거프린트 참조 라이브러리(관리자 큐레이션)와 kNN 물질 식별 AI 모델
+-- 참조 벡터 인덱스는 Milvus "{MILVUS_COLLECTION}_references" 컬렉션 (원본 벡터는 이 테이블에 보관)
+-- =============================================================================
+
+CREATE TABLE IF NOT EXISTS fingerprint_references (
+    id                  UUID             PRIMARY KEY,
+    substance           VARCHAR(100)     NOT NULL,
+    concentration       DOUBLE PRECISION NOT NULL DEFAULT 0,    -- 0이면 농도 라벨 없음
+    concentration_unit  VARCHAR(20)      NOT NULL DEFAULT '',
+    matrix              VARCHAR(50)      NOT NULL DEFAULT '',   -- blood, saliva, buffer 등
+    cartridge_type      VARCHAR(50)      NOT NULL,
+    fingerprint         REAL[]           NOT NULL,
+    source_session_id   UUID             REFERENCES measurement_sessions(id) ON DELETE SET NULL,
+    status              VARCHAR(20)      NOT NULL DEFAULT 'pending'
+                        CHECK (status IN ('pending', 'approved', 'retired')),
+    notes               TEXT             NOT NULL DEFAULT '',
+    created_by          VARCHAR(64)      NOT NULL DEFAULT '',
+    reviewed_by         VARCHAR(64)      NOT NULL DEFAULT '',
+    created_at          TIMESTAMPTZ      NOT NULL DEFAULT NOW(),
+    updated_at          TIMESTAMPTZ      NOT NULL DEFAULT NOW()
+);
+CREATE INDEX IF NOT EXISTS idx_fingerprint_refs_status ON fingerprint_references(status, cartridge_type);
+CREATE INDEX IF NOT EXISTS idx_fingerprint_refs_substance ON fingerprint_references(LOWER(substance));
+
+-- 세션 핑거프린트로 식별·참조 등록을 하기 위해 측정 데이터에 벡터 원본 보관
+ALTER TABLE measurement_data ADD COLUMN IF NOT EXISTS fingerprint REAL[];
+
+-- AI 모델 유형: 핑거프린트 참조 라이브러리 kNN 물질 식별
+ALTER TYPE ai_model_type ADD VALUE IF NOT EXISTS 'SUBSTANCE_IDENTIFIER';
+
+INSERT INTO ai_models (model_type, name, version, description, accuracy, last_trained, status) VALUES
+  ('SUBSTANCE_IDENTIFIER', 'SubstanceIdentifier', '1.0.0', '핑거프린트 참조 라이브러리 kNN 물질 식별', 0, NULL, 'active')
+ON CONFLICT (model_type) DO NOTHING;
+
+-- 분석 결과의 물질 식별 결과 (SUBSTANCE_IDENTIFIER 요청 시)
+ALTER TABLE analysis_results ADD COLUMN IF NOT EXISTS substance_identification JSONB;
@@ -0,0 +1,24 @@
+-- =============================================================================
+-- 38-goal-progress.sql
+-- 이벤트 기반 건강 목표 진행 추적 (measurement.completed·vision.meal_analyzed 소비), 출처별 1회 반영
+-- 목표일 마감 시 달성(ACHIEVED) 또는 실패(FAILED)로 전환, 마일스톤은 coaching.goal_milestone으로 발행
+-- =============================================================================
+
+-- 목표 상태: 목표일까지 미달성
+ALTER TYPE goal_status ADD VALUE IF NOT EXISTS 'FAILED';
+
+ALTER TABLE health_goals ADD COLUMN IF NOT EXISTS start_value      DOUBLE PRECISION NOT NULL DEFAULT 0;  -- 목표 설정 후 첫 기록 값
+ALTER TABLE health_goals ADD COLUMN IF NOT EXISTS streak           INTEGER          NOT NULL DEFAULT 0;  -- 기록이 이어진 연속 일수
+ALTER TABLE health_goals ADD COLUMN IF NOT EXISTS last_progress_at TIMESTAMPTZ;                          -- 마지막으로 반영한 기록 시각
+ALTER TABLE health_goals ADD COLUMN IF NOT EXISTS last_milestone   INTEGER          NOT NULL DEFAULT 0;  -- 마지막으로 알린 진행률 구간 (25/50/75/100)
+
+-- 목표일 마감 스윕: 진행 중 목표를 목표일 순으로 조회
+CREATE INDEX IF NOT EXISTS idx_health_goals_due ON health_goals (target_date) WHERE status = 'ACTIVE';
+
+-- 목표별로 반영한 기록 출처 (측정 세션 ID·식사 분석 ID): 이벤트 재전달 시 중복 집계 방지
+CREATE TABLE IF NOT EXISTS goal_progress_sources (
+    goal_id     UUID         NOT NULL REFERENCES health_goals(id) ON DELETE CASCADE,
+    source_id   VARCHAR(128) NOT NULL,
+    applied_at  TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (goal_id, source_id)
+);
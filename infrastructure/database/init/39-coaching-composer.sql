@@ -0,0 +1,7 @@
+-- =============================================================================
+-- 39-coaching-composer.sql
+-- 개인화 코칭 메시지: 생성 방식(LLM·템플릿·규칙)과 메시지 언어 기록
+-- =============================================================================
+
+ALTER TABLE coaching_messages ADD COLUMN IF NOT EXISTS strategy VARCHAR(16) NOT NULL DEFAULT 'rule';  -- llm, template, rule
+ALTER TABLE coaching_messages ADD COLUMN IF NOT EXISTS language VARCHAR(8)  NOT NULL DEFAULT 'ko';
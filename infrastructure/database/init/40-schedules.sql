@@ -0,0 +1,38 @@
+-- =============================================================================
+-- 40-schedules.sql
+-- 사용자 시간대 기반 공용 스케줄러 (shared/scheduler)
+-- 코칭 일일 팁·리포트와 복약 알림을 사용자 현지 시각에, 방해 금지 시간을 피해, 레플리카 간 1회만 실행
+-- =============================================================================
+
+CREATE TABLE IF NOT EXISTS schedules (
+    id                VARCHAR(128) PRIMARY KEY,          -- 예: coaching.daily_tip:<user_id>, rx:<prescription_id>
+    kind              VARCHAR(64)  NOT NULL,             -- 핸들러 종류 (coaching.daily_tip, prescription.medication_reminder 등)
+    user_id           VARCHAR(64)  NOT NULL,
+    times_of_day      TEXT[]       NOT NULL DEFAULT '{}', -- 현지 "HH:MM" (cron이 있으면 무시)
+    cron              VARCHAR(128) NOT NULL DEFAULT '',   -- 5필드 cron (현지 시각 기준)
+    timezone          VARCHAR(64)  NOT NULL DEFAULT '',   -- IANA 시간대, 비어 있으면 UTC
+    quiet_hours_start VARCHAR(5)   NOT NULL DEFAULT '',
+    quiet_hours_end   VARCHAR(5)   NOT NULL DEFAULT '',
+    payload           JSONB        NOT NULL DEFAULT '{}',
+    next_slot_at      TIMESTAMPTZ  NOT NULL,              -- 다음 예정 시각
+    next_run_at       TIMESTAMPTZ  NOT NULL,              -- 방해 금지 시간을 반영한 다음 실행 시각
+    last_run_at       TIMESTAMPTZ,
+    created_at        TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    updated_at        TIMESTAMPTZ  NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_schedules_due  ON schedules (kind, next_run_at);
+CREATE INDEX IF NOT EXISTS idx_schedules_user ON schedules (user_id);
+
+-- 실행 단위(schedule_id, occurrence_at) 점유 기록: 기본 키로 레플리카 간 중복 실행을 막음
+CREATE TABLE IF NOT EXISTS schedule_runs (
+    schedule_id   VARCHAR(128) NOT NULL REFERENCES schedules(id) ON DELETE CASCADE,
+    occurrence_at TIMESTAMPTZ  NOT NULL,
+    worker        VARCHAR(128) NOT NULL,
+    status        VARCHAR(16)  NOT NULL DEFAULT 'claimed', -- claimed, done, failed
+    attempts      INTEGER      NOT NULL DEFAULT 1,
+    lease_until   TIMESTAMPTZ  NOT NULL,
+    error         TEXT         NOT NULL DEFAULT '',
+    updated_at    TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (schedule_id, occurrence_at)
+);
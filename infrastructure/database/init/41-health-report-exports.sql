@@ -0,0 +1,33 @@
+-- =============================================================================
+-- 41-health-report-exports.sql
+-- 주간·월간 건강 리포트 내보내기 (HTML/PDF, S3 저장) 및 원격진료 상담 첨부
+-- 파일은 S3에 저장하고 다운로드 링크는 조회 시마다 presigned URL로 새로 발급
+-- =============================================================================
+
+CREATE TABLE IF NOT EXISTS report_exports (
+    id           VARCHAR(64)  PRIMARY KEY,
+    user_id      VARCHAR(64)  NOT NULL,
+    period       VARCHAR(16)  NOT NULL,             -- weekly, monthly
+    period_start DATE         NOT NULL,
+    period_end   DATE         NOT NULL,             -- 마지막 날 포함
+    language     VARCHAR(8)   NOT NULL DEFAULT 'ko',
+    html_key     TEXT         NOT NULL,             -- S3 객체 키
+    pdf_key      TEXT         NOT NULL,
+    created_at   TIMESTAMPTZ  NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_report_exports_user ON report_exports(user_id, created_at DESC);
+
+CREATE TABLE IF NOT EXISTS consultation_attachments (
+    attachment_id   VARCHAR(64)  PRIMARY KEY,
+    consultation_id VARCHAR(64)  NOT NULL,
+    user_id         VARCHAR(64)  NOT NULL,          -- 리포트 소유자 (상담 환자)
+    export_id       VARCHAR(64)  NOT NULL,          -- report_exports.id (coaching-service)
+    period          VARCHAR(16)  NOT NULL,
+    period_start    DATE         NOT NULL,
+    period_end      DATE         NOT NULL,
+    attached_at     TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    UNIQUE (consultation_id, export_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_consultation_attachments_consultation ON consultation_attachments(consultation_id, attached_at);
@@ -0,0 +1,47 @@
+-- =============================================================================
+-- 42-escalations.sql
+-- 긴급 건강 알림 에스컬레이션 상태·단계별 전달 기록 (notification-service)
+-- 단계 예정 시각을 저장해 재시작 후에도 이어서 진행하고, 리더 임대로 한 레플리카만 실행
+-- =============================================================================
+
+CREATE TABLE IF NOT EXISTS escalations (
+    id                 VARCHAR(64)  PRIMARY KEY,
+    user_id            VARCHAR(64)  NOT NULL,
+    alert_type         VARCHAR(64)  NOT NULL,
+    stage              SMALLINT     NOT NULL DEFAULT 0,   -- 0 대기, 1 앱 알림, 2 보호자 푸시, 3 AI 음성, 4 119, 5 해제
+    measurement_id     VARCHAR(64),
+    value              TEXT,
+    attempt            INTEGER      NOT NULL DEFAULT 0,   -- 현재 단계 재시도 횟수
+    next_stage_at      TIMESTAMPTZ,                       -- NULL이면 더 진행할 단계 없음
+    created_at         TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    last_escalation_at TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    resolved_at        TIMESTAMPTZ,
+    resolved_by        VARCHAR(16),                       -- user, guardian, operator
+    lease_owner        VARCHAR(128),                      -- 단계 실행 중인 워커
+    lease_until        TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_escalations_user ON escalations(user_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_escalations_due ON escalations(next_stage_at) WHERE resolved_at IS NULL;
+
+CREATE TABLE IF NOT EXISTS escalation_deliveries (
+    id              VARCHAR(64)  PRIMARY KEY,
+    escalation_id   VARCHAR(64)  NOT NULL REFERENCES escalations(id) ON DELETE CASCADE,
+    stage           SMALLINT     NOT NULL,
+    recipient_id    VARCHAR(64)  NOT NULL,
+    channel         VARCHAR(32)  NOT NULL,                -- in_app, push, voice_call, emergency_119
+    status          VARCHAR(16)  NOT NULL,                -- delivered, failed, skipped
+    attempt         INTEGER      NOT NULL DEFAULT 1,
+    notification_id VARCHAR(64),
+    error           TEXT,
+    created_at      TIMESTAMPTZ  NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_escalation_deliveries_escalation ON escalation_deliveries(escalation_id, created_at);
+
+-- 레플리카 간 리더 선출용 임대 (만료되면 다른 레플리카가 이어받음)
+CREATE TABLE IF NOT EXISTS leader_leases (
+    name        VARCHAR(64)  PRIMARY KEY,
+    holder      VARCHAR(128) NOT NULL,
+    lease_until TIMESTAMPTZ  NOT NULL
+);
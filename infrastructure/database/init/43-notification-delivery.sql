@@ -0,0 +1,22 @@
+-- =============================================================================
+-- 43-notification-delivery.sql
+-- 알림 전달 상태: 방해 금지 시간 연기 발송, 채널 폴백(push → sms → email → in_app) 시도 기록
+-- 기존 알림은 발송 완료(delivered)로 간주
+-- =============================================================================
+
+ALTER TABLE notifications ADD COLUMN IF NOT EXISTS delivery_status VARCHAR(16) NOT NULL DEFAULT 'delivered'; -- pending, delivered, deferred, failed
+ALTER TABLE notifications ADD COLUMN IF NOT EXISTS delivered_channel notification_channel;                -- 폴백 후 실제 전달된 채널
+ALTER TABLE notifications ADD COLUMN IF NOT EXISTS deliver_at TIMESTAMPTZ;                                -- 연기된 발송 시각
+
+CREATE INDEX IF NOT EXISTS idx_notifications_deferred ON notifications(deliver_at) WHERE delivery_status = 'deferred';
+
+CREATE TABLE IF NOT EXISTS notification_delivery_attempts (
+    id              UUID PRIMARY KEY,
+    notification_id UUID NOT NULL REFERENCES notifications(id) ON DELETE CASCADE,
+    channel         notification_channel NOT NULL,
+    status          VARCHAR(16)  NOT NULL,              -- delivered, failed
+    error           TEXT,
+    attempted_at    TIMESTAMPTZ  NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_notification_delivery_attempts_notification ON notification_delivery_attempts(notification_id, attempted_at);
@@ -0,0 +1,22 @@
+-- =============================================================================
+-- 44-notification-templates.sql
+-- 알림 템플릿: 언어별(ko, en, ja, zh) 변형과 버전 이력, {{name}} 형식 placeholder
+-- 저장할 때마다 새 버전을 추가하며, 삭제는 deleted = TRUE 버전으로 남김 (롤백 가능)
+-- 기본 템플릿은 notification-service 시작 시 버전 1로 채워짐
+-- =============================================================================
+
+CREATE TABLE IF NOT EXISTS notification_templates (
+    template_key  VARCHAR(64)  NOT NULL,
+    language      VARCHAR(8)   NOT NULL,              -- ko, en, ja, zh
+    version       INT          NOT NULL,
+    title         TEXT         NOT NULL,
+    body          TEXT         NOT NULL,
+    type          VARCHAR(32)  NOT NULL,              -- measurement, health_alert, appointment, prescription, community, system, promotion
+    priority      VARCHAR(16)  NOT NULL,              -- low, normal, high, urgent
+    channel       VARCHAR(16)  NOT NULL,              -- push, email, sms, in_app
+    placeholders  TEXT[]       NOT NULL DEFAULT '{}',
+    deleted       BOOLEAN      NOT NULL DEFAULT FALSE,
+    updated_by    VARCHAR(128) NOT NULL,
+    created_at    TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (template_key, language, version)
+);
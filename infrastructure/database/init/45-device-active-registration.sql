@@ -0,0 +1,11 @@
+-- =============================================================================
+-- 45-device-active-registration.sql
+-- 디바이스 등록 유일성을 활성 등록으로 한정 (03-device.sql의 UNIQUE(device_id, user_id) 대체)
+-- 등록 해제(deregistered_at)된 행은 이력으로 남고, 같은 사용자가 같은 디바이스를 다시 등록할 수 있습니다.
+-- =============================================================================
+
+ALTER TABLE devices DROP CONSTRAINT IF EXISTS devices_device_id_user_id_key;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_devices_active_registration
+    ON devices (device_id, user_id)
+    WHERE deregistered_at IS NULL;
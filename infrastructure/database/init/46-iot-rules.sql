@@ -0,0 +1,54 @@
+-- =============================================================================
+-- 46-iot-rules.sql
+-- IoT 데이터 규칙 엔진: 규칙, 규칙·디바이스별 증분 평가 상태, 발동 이력
+-- 규칙 식(expression)의 해석 결과를 함께 저장해 로드할 때 다시 해석하지 않음
+-- device_id가 빈 문자열이면 data_type을 보내는 모든 디바이스에 적용 (관리자 전용)
+-- =============================================================================
+
+CREATE TABLE IF NOT EXISTS iot_rules (
+    id               UUID          PRIMARY KEY,
+    owner_id         VARCHAR(128)  NOT NULL,
+    name             VARCHAR(200)  NOT NULL DEFAULT '',
+    device_id        VARCHAR(128)  NOT NULL DEFAULT '',
+    data_type        VARCHAR(64)   NOT NULL,
+    expression       TEXT          NOT NULL,
+    aggregation      VARCHAR(16)   NOT NULL,              -- value, avg, min, max
+    window_ms        BIGINT        NOT NULL DEFAULT 0,
+    operator         VARCHAR(4)    NOT NULL,              -- >, >=, <, <=
+    threshold        DOUBLE PRECISION NOT NULL,
+    for_ms           BIGINT        NOT NULL DEFAULT 0,
+    hysteresis       DOUBLE PRECISION NOT NULL DEFAULT 0,
+    cooldown_ms      BIGINT        NOT NULL DEFAULT 0,
+    action_type      VARCHAR(16)   NOT NULL,              -- notify, command
+    command_type     VARCHAR(64)   NOT NULL DEFAULT '',
+    command_payload  TEXT          NOT NULL DEFAULT '',
+    enabled          BOOLEAN       NOT NULL DEFAULT TRUE,
+    created_at       TIMESTAMPTZ   NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_iot_rules_owner ON iot_rules(owner_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_iot_rules_active ON iot_rules(data_type, device_id) WHERE enabled;
+
+CREATE TABLE IF NOT EXISTS iot_rule_states (
+    rule_id            UUID          NOT NULL REFERENCES iot_rules(id) ON DELETE CASCADE,
+    device_id          VARCHAR(128)  NOT NULL,
+    samples            JSONB         NOT NULL DEFAULT '[]'::jsonb,   -- 윈도우 안의 측정값 (오래된 순)
+    observed_since     TIMESTAMPTZ,
+    condition_since    TIMESTAMPTZ,
+    active             BOOLEAN       NOT NULL DEFAULT FALSE,
+    last_triggered_at  TIMESTAMPTZ,
+    updated_at         TIMESTAMPTZ   NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (rule_id, device_id)
+);
+
+CREATE TABLE IF NOT EXISTS iot_rule_triggers (
+    id            UUID              PRIMARY KEY,
+    rule_id       UUID              NOT NULL REFERENCES iot_rules(id) ON DELETE CASCADE,
+    device_id     VARCHAR(128)      NOT NULL,
+    data_type     VARCHAR(64)       NOT NULL,
+    value         DOUBLE PRECISION  NOT NULL,
+    command_id    VARCHAR(64)       NOT NULL DEFAULT '',
+    triggered_at  TIMESTAMPTZ       NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_iot_rule_triggers_rule ON iot_rule_triggers(rule_id, triggered_at DESC);
@@ -0,0 +1,10 @@
+-- =============================================================================
+-- 47-escalation-active-user.sql
+-- 사용자당 활성(해제되지 않은) 에스컬레이션을 하나로 제한 (42-escalations.sql 보완)
+-- 같은 알림을 여러 레플리카가 동시에 받아도 에스컬레이션이 하나만 생성되어
+-- 보호자 호출·119 신고가 중복되지 않습니다. 조건은 activeEscalation(stage 5 = 해제)과 같습니다.
+-- =============================================================================
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_escalations_active_user
+    ON escalations (user_id)
+    WHERE resolved_at IS NULL AND stage < 5;